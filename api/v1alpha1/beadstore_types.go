@@ -20,8 +20,24 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// BeadBackend is where a BeadStore reads its beads from.
+// +kubebuilder:validation:Enum=git;github;jira
+type BeadBackend string
+
+const (
+	// BeadBackendGit reads the beads database committed to the rig repository
+	BeadBackendGit BeadBackend = "git"
+	// BeadBackendGitHub imports the issues of a GitHub repository
+	BeadBackendGitHub BeadBackend = "github"
+	// BeadBackendJira imports the issues matching a Jira query
+	BeadBackendJira BeadBackend = "jira"
+)
+
 // BeadStoreSpec defines the desired state of BeadStore.
 // A BeadStore manages the configuration for a beads issue tracking database.
+// +kubebuilder:validation:XValidation:rule="!has(self.backend) || self.backend != 'github' || has(self.github)",message="github is required when backend is github"
+// +kubebuilder:validation:XValidation:rule="!has(self.backend) || self.backend != 'jira' || has(self.jira)",message="jira is required when backend is jira"
+// +kubebuilder:validation:XValidation:rule="!has(self.gitSync) || !self.gitSync || !has(self.backend) || self.backend == 'git'",message="gitSync requires the git backend"
 type BeadStoreSpec struct {
 	// rigRef references the Rig this BeadStore is associated with.
 	// +kubebuilder:validation:Required
@@ -40,6 +56,77 @@ type BeadStoreSpec struct {
 	// +kubebuilder:default="5m"
 	// +optional
 	SyncInterval *metav1.Duration `json:"syncInterval,omitempty"`
+
+	// gitSync enables two-way sync of beads between the operator's cache
+	// (the <name>-beads ConfigMap) and the rig repository. Beads changed on
+	// both sides since the last sync are reported as conflicts, never overwritten.
+	// +optional
+	GitSync bool `json:"gitSync,omitempty"`
+
+	// beadsPath is the path of the beads database in the rig repository.
+	// +kubebuilder:default=".beads/issues.jsonl"
+	// +optional
+	BeadsPath string `json:"beadsPath,omitempty"`
+
+	// backend is where beads are read from. With github or jira, the
+	// tracker's issues are imported read-only into the beads cache on
+	// every sync, replacing the previous import.
+	// +kubebuilder:default=git
+	// +optional
+	Backend BeadBackend `json:"backend,omitempty"`
+
+	// github configures the github backend.
+	// +optional
+	GitHub *GitHubBeadSource `json:"github,omitempty"`
+
+	// jira configures the jira backend.
+	// +optional
+	Jira *JiraBeadSource `json:"jira,omitempty"`
+}
+
+// GitHubBeadSource imports GitHub issues as beads. Issue 42 becomes bead <prefix>42.
+type GitHubBeadSource struct {
+	// repository is the "owner/repo" to import issues from.
+	// Defaults to the rig repository when it is hosted on GitHub.
+	// +kubebuilder:validation:Pattern=`^[A-Za-z0-9_.-]+/[A-Za-z0-9_.-]+$`
+	// +optional
+	Repository string `json:"repository,omitempty"`
+
+	// apiURL is the GitHub API endpoint, for GitHub Enterprise.
+	// +kubebuilder:default="https://api.github.com"
+	// +optional
+	APIURL string `json:"apiURL,omitempty"`
+
+	// tokenSecretRef references the key of a Secret holding a GitHub token.
+	// Optional for public repositories.
+	// +optional
+	TokenSecretRef *SecretKeyRef `json:"tokenSecretRef,omitempty"`
+
+	// labels restricts the import to issues carrying all of these labels.
+	// +optional
+	Labels []string `json:"labels,omitempty"`
+
+	// includeClosed also imports closed issues.
+	// +optional
+	IncludeClosed bool `json:"includeClosed,omitempty"`
+}
+
+// JiraBeadSource imports Jira issues as beads. Issue PROJ-42 becomes bead <prefix>proj-42.
+type JiraBeadSource struct {
+	// url is the Jira site (e.g., https://example.atlassian.net).
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+
+	// jql selects the issues to import.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	JQL string `json:"jql"`
+
+	// credentialsSecretRef references a Secret with keys "email" and
+	// "apiToken" (Jira Cloud), or "token" (a Data Center personal access token).
+	// +optional
+	CredentialsSecretRef *SecretReference `json:"credentialsSecretRef,omitempty"`
 }
 
 // BeadStoreStatus defines the observed state of BeadStore.
@@ -57,6 +144,24 @@ type BeadStoreStatus struct {
 	// +optional
 	IssueCount int32 `json:"issueCount"`
 
+	// revision is the rig repository commit the beads were last synced at.
+	// +optional
+	Revision string `json:"revision,omitempty"`
+
+	// beads lists the beads in the store, sorted by ID and capped at
+	// MaxBeadSummaries. Descriptions are kept in the <name>-beads ConfigMap.
+	// +listType=map
+	// +listMapKey=id
+	// +kubebuilder:validation:MaxItems=500
+	// +optional
+	Beads []BeadSummary `json:"beads,omitempty"`
+
+	// conflicts lists beads changed by both the operator and the repository
+	// since the last sync. Resolve them with the gastown.io/resolve-beads
+	// annotation ("<bead-id>=local|remote,...").
+	// +optional
+	Conflicts []BeadConflict `json:"conflicts,omitempty"`
+
 	// conditions represent the current state of the BeadStore resource.
 	// +listType=map
 	// +listMapKey=type
@@ -64,10 +169,64 @@ type BeadStoreStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// MaxBeadSummaries caps BeadStoreStatus.Beads so the status stays well
+// below the object size limit.
+const MaxBeadSummaries = 500
+
+// BeadSummary is the metadata of a bead.
+type BeadSummary struct {
+	// id is the bead ID.
+	ID string `json:"id"`
+
+	// title is the bead title.
+	// +optional
+	Title string `json:"title,omitempty"`
+
+	// status is the bead status (open, in_progress, closed).
+	// +optional
+	Status string `json:"status,omitempty"`
+
+	// priority ranges from 0 (critical) to 4 (backlog).
+	// +optional
+	Priority *int32 `json:"priority,omitempty"`
+
+	// type is the bead issue type (e.g., task, bug, feature).
+	// +optional
+	Type string `json:"type,omitempty"`
+
+	// externalRef links a bead imported from an issue tracker to its issue.
+	// +optional
+	ExternalRef string `json:"externalRef,omitempty"`
+}
+
+// BeadConflict is a bead modified concurrently by the operator and the repository.
+// Revisions are content hashes; an empty revision means the bead is absent on that side.
+type BeadConflict struct {
+	// id is the bead ID.
+	ID string `json:"id"`
+
+	// baseRevision is the revision both sides agreed on at the last sync.
+	// +optional
+	BaseRevision string `json:"baseRevision,omitempty"`
+
+	// localRevision is the operator's revision.
+	// +optional
+	LocalRevision string `json:"localRevision,omitempty"`
+
+	// remoteRevision is the repository's revision.
+	// +optional
+	RemoteRevision string `json:"remoteRevision,omitempty"`
+
+	// detectedAt is when the conflict was first seen.
+	// +optional
+	DetectedAt *metav1.Time `json:"detectedAt,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Rig",type=string,JSONPath=`.spec.rigRef`
 // +kubebuilder:printcolumn:name="Prefix",type=string,JSONPath=`.spec.prefix`
+// +kubebuilder:printcolumn:name="Backend",type=string,JSONPath=`.spec.backend`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Issues",type=integer,JSONPath=`.status.issueCount`
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
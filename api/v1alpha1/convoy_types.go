@@ -42,6 +42,146 @@ type ConvoySpec struct {
 	// RigRef references the Rig where Polecats will be created.
 	// +optional
 	RigRef string `json:"rigRef,omitempty"`
+
+	// Dependencies are edges between tracked beads. The polecat of a bead
+	// with dependencies is held until the polecats of the beads it depends
+	// on are Done or merged, turning the convoy into a pipeline.
+	// +listType=map
+	// +listMapKey=bead
+	// +optional
+	Dependencies []BeadDependency `json:"dependencies,omitempty"`
+
+	// OnComplete are actions run once when the convoy becomes Complete, in
+	// order. Their results are recorded in status.onComplete.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	OnComplete []ConvoyCompletionAction `json:"onComplete,omitempty"`
+}
+
+// BeadDependency makes a tracked bead wait for other tracked beads
+type BeadDependency struct {
+	// Bead is the tracked bead that waits
+	// +kubebuilder:validation:MinLength=1
+	Bead string `json:"bead"`
+
+	// DependsOn are the tracked beads that must be Done or merged first
+	// +kubebuilder:validation:MinItems=1
+	DependsOn []string `json:"dependsOn"`
+}
+
+// ConvoyCompletionAction is an action run when a convoy completes. Exactly
+// one of followUpConvoy, tag, webhook and summaryBead is set.
+type ConvoyCompletionAction struct {
+	// Name identifies the action in status.onComplete
+	// +kubebuilder:validation:MinLength=1
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// FollowUpConvoy creates another convoy on the same rig
+	// +optional
+	FollowUpConvoy *FollowUpConvoyAction `json:"followUpConvoy,omitempty"`
+
+	// Tag tags the target branch of the rig's Refinery
+	// +optional
+	Tag *TagAction `json:"tag,omitempty"`
+
+	// Webhook posts a summary of the convoy to a URL
+	// +optional
+	Webhook *WebhookAction `json:"webhook,omitempty"`
+
+	// SummaryBead opens a bead summarizing the convoy in the rig's BeadStore
+	// +optional
+	SummaryBead *SummaryBeadAction `json:"summaryBead,omitempty"`
+}
+
+// FollowUpConvoyAction creates a convoy once this one completes
+type FollowUpConvoyAction struct {
+	// Name of the convoy to create. Defaults to <convoy>-<action>.
+	// +optional
+	Name string `json:"name,omitempty"`
+
+	// Description is a human-readable description of the follow-up convoy
+	// +kubebuilder:validation:MinLength=1
+	Description string `json:"description"`
+
+	// TrackedBeads is the list of bead IDs the follow-up convoy tracks
+	// +kubebuilder:validation:MinItems=1
+	TrackedBeads []string `json:"trackedBeads"`
+
+	// Parallelism controls how many Polecats of the follow-up convoy can run
+	// concurrently
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	Parallelism int32 `json:"parallelism,omitempty"`
+
+	// Dependencies are edges between the beads of the follow-up convoy
+	// +optional
+	Dependencies []BeadDependency `json:"dependencies,omitempty"`
+}
+
+// TagAction tags the repository once the convoy completes
+type TagAction struct {
+	// Name of the tag
+	// +kubebuilder:validation:MinLength=1
+	Name string `json:"name"`
+
+	// Message of the annotated tag. Defaults to "Convoy <convoy> complete".
+	// +optional
+	Message string `json:"message,omitempty"`
+}
+
+// WebhookAction posts a JSON summary of the convoy, with the outcome of each
+// tracked bead, once it completes
+type WebhookAction struct {
+	// URL to post to
+	// +kubebuilder:validation:Pattern=`^https?://`
+	URL string `json:"url"`
+}
+
+// SummaryBeadAction opens a bead summarizing the convoy once it completes
+type SummaryBeadAction struct {
+	// Title of the bead. Defaults to "Convoy <convoy> complete".
+	// +optional
+	Title string `json:"title,omitempty"`
+}
+
+// CompletionActionPhase is the state of a completion action
+// +kubebuilder:validation:Enum=Pending;Succeeded;Failed
+type CompletionActionPhase string
+
+const (
+	CompletionActionPending   CompletionActionPhase = "Pending"
+	CompletionActionSucceeded CompletionActionPhase = "Succeeded"
+	CompletionActionFailed    CompletionActionPhase = "Failed"
+)
+
+// CompletionActionResult is the result of a completion action
+type CompletionActionResult struct {
+	// Name is the action in spec.onComplete
+	Name string `json:"name"`
+
+	// Phase is Pending until the action succeeded, or failed too many times
+	Phase CompletionActionPhase `json:"phase"`
+
+	// Ref is what the action produced: the follow-up convoy, the tagged
+	// commit, the webhook's HTTP status or the summary bead
+	// +optional
+	Ref string `json:"ref,omitempty"`
+
+	// Message is the error of the last failed attempt
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// Attempts is how many times the action ran
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
+	// LastAttemptTime is when the action last ran
+	// +optional
+	LastAttemptTime *metav1.Time `json:"lastAttemptTime,omitempty"`
 }
 
 // ConvoyPhase represents the lifecycle phase of a Convoy
@@ -73,6 +213,41 @@ type ConvoyStatus struct {
 	// +optional
 	PendingBeads []string `json:"pendingBeads,omitempty"`
 
+	// DispatchedBeads are the tracked beads whose dependencies are met; their
+	// polecats may start
+	// +optional
+	DispatchedBeads []string `json:"dispatchedBeads,omitempty"`
+
+	// BlockedBeads are the tracked beads waiting for their dependencies
+	// +optional
+	BlockedBeads []string `json:"blockedBeads,omitempty"`
+
+	// Beads is the metadata of the tracked beads, resolved from the rig's BeadStore
+	// +listType=map
+	// +listMapKey=id
+	// +optional
+	Beads []BeadSummary `json:"beads,omitempty"`
+
+	// Outcomes is the outcome of each tracked bead, in the order of
+	// spec.trackedBeads and capped at MaxConvoyOutcomes
+	// +listType=map
+	// +listMapKey=bead
+	// +kubebuilder:validation:MaxItems=250
+	// +optional
+	Outcomes []BeadOutcome `json:"outcomes,omitempty"`
+
+	// OnComplete are the results of the spec.onComplete actions, once the
+	// convoy is Complete
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	OnComplete []CompletionActionResult `json:"onComplete,omitempty"`
+
+	// SummaryBead is the bead the rig's BeadStore opened for a summaryBead
+	// action
+	// +optional
+	SummaryBead string `json:"summaryBead,omitempty"`
+
 	// StartedAt is when the convoy started
 	// +optional
 	StartedAt *metav1.Time `json:"startedAt,omitempty"`
@@ -88,8 +263,45 @@ type ConvoyStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// MaxConvoyOutcomes caps ConvoyStatus.Outcomes so the status stays well
+// below the object size limit. Beads beyond it are still tracked.
+const MaxConvoyOutcomes = 250
+
+// BeadOutcome is the outcome of a tracked bead, from the polecat working on it
+type BeadOutcome struct {
+	// Bead is the tracked bead
+	Bead string `json:"bead"`
+
+	// Polecat is the polecat working on the bead, empty until one is assigned
+	// +optional
+	Polecat string `json:"polecat,omitempty"`
+
+	// Phase is the phase of the polecat
+	// +optional
+	Phase PolecatPhase `json:"phase,omitempty"`
+
+	// MergeCommit is the commit the Refinery merged the polecat's branch as
+	// +optional
+	MergeCommit string `json:"mergeCommit,omitempty"`
+
+	// CostUSD is the cost of the polecat's latest attempt in US dollars
+	// +optional
+	CostUSD string `json:"costUSD,omitempty"`
+
+	// Duration is how long the polecat took, from its creation until it
+	// finished
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// FailureReason is why the polecat's last attempt failed, e.g.
+	// TaskIncomplete; empty once it is done
+	// +optional
+	FailureReason string `json:"failureReason,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=cv
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Progress",type="string",JSONPath=".status.progress"
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
@@ -0,0 +1,278 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/url"
+	"slices"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/org/gastown-operator/pkg/webhookmetrics"
+)
+
+// ConvoyRigLabel is the label the Convoy defaulter sets to spec.rigRef.
+const ConvoyRigLabel = "gastown.io/rig"
+
+// log is for logging in this package.
+var convoylog = logf.Log.WithName("convoy-resource")
+
+// SetupConvoyWebhookWithManager registers the Convoy webhooks with the manager.
+func SetupConvoyWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &Convoy{}).
+		WithValidator(webhookmetrics.Validator[*Convoy]("convoy", &ConvoyCustomValidator{Client: mgr.GetClient()})).
+		WithDefaulter(webhookmetrics.Defaulter[*Convoy]("convoy", &ConvoyCustomDefaulter{})).
+		Complete()
+}
+
+// +kubebuilder:webhook:path=/mutate-gastown-gastown-io-v1alpha1-convoy,mutating=true,failurePolicy=fail,sideEffects=None,groups=gastown.gastown.io,resources=convoys,verbs=create;update,versions=v1alpha1,name=mconvoy.kb.io,admissionReviewVersions=v1
+
+// ConvoyCustomDefaulter implements admission.Defaulter[*Convoy] for Convoy.
+type ConvoyCustomDefaulter struct{}
+
+var _ admission.Defaulter[*Convoy] = &ConvoyCustomDefaulter{}
+
+// Default implements admission.Defaulter so a webhook will be registered for the type.
+func (d *ConvoyCustomDefaulter) Default(ctx context.Context, convoy *Convoy) error {
+	convoylog.Info("defaulting", "name", convoy.Name)
+
+	// Default Description to a summary of the tracked beads
+	if strings.TrimSpace(convoy.Spec.Description) == "" && len(convoy.Spec.TrackedBeads) > 0 {
+		convoy.Spec.Description = "Convoy tracking " + strings.Join(convoy.Spec.TrackedBeads, ", ")
+	}
+
+	// Label the convoy with its rig so it can be selected alongside the rig's polecats
+	if convoy.Spec.RigRef != "" {
+		if convoy.Labels == nil {
+			convoy.Labels = map[string]string{}
+		}
+		if _, ok := convoy.Labels[ConvoyRigLabel]; !ok {
+			convoy.Labels[ConvoyRigLabel] = convoy.Spec.RigRef
+		}
+	}
+
+	return nil
+}
+
+// +kubebuilder:webhook:path=/validate-gastown-gastown-io-v1alpha1-convoy,mutating=false,failurePolicy=fail,sideEffects=None,groups=gastown.gastown.io,resources=convoys,verbs=create;update,versions=v1alpha1,name=vconvoy.kb.io,admissionReviewVersions=v1
+
+// ConvoyCustomValidator implements admission.Validator[*Convoy] for Convoy.
+type ConvoyCustomValidator struct {
+	// Client looks up the Rig named by spec.rigRef. If nil, rigRef is not checked.
+	Client client.Reader
+}
+
+var _ admission.Validator[*Convoy] = &ConvoyCustomValidator{}
+
+// ValidateCreate implements admission.Validator so a webhook will be registered for the type.
+func (v *ConvoyCustomValidator) ValidateCreate(ctx context.Context, convoy *Convoy) (admission.Warnings, error) {
+	convoylog.Info("validate create", "name", convoy.Name)
+
+	return v.validateConvoy(ctx, convoy)
+}
+
+// ValidateUpdate implements admission.Validator so a webhook will be registered for the type.
+func (v *ConvoyCustomValidator) ValidateUpdate(ctx context.Context, oldConvoy, convoy *Convoy) (admission.Warnings, error) {
+	convoylog.Info("validate update", "name", convoy.Name)
+
+	return v.validateConvoy(ctx, convoy)
+}
+
+// ValidateDelete implements admission.Validator so a webhook will be registered for the type.
+func (v *ConvoyCustomValidator) ValidateDelete(ctx context.Context, convoy *Convoy) (admission.Warnings, error) {
+	convoylog.Info("validate delete", "name", convoy.Name)
+
+	// No validation on delete
+	return nil, nil
+}
+
+// validateConvoy performs validation common to create and update.
+func (v *ConvoyCustomValidator) validateConvoy(ctx context.Context, convoy *Convoy) (admission.Warnings, error) {
+	var allErrs []string
+
+	// Validate TrackedBeads
+	allErrs = append(allErrs, validateTrackedBeads("spec", convoy.Spec.TrackedBeads)...)
+	allErrs = append(allErrs, validateDependencies("spec", convoy.Spec.TrackedBeads, convoy.Spec.Dependencies)...)
+	allErrs = append(allErrs, validateCompletionActions(convoy)...)
+
+	// Validate RigRef if present
+	if convoy.Spec.RigRef != "" && v.Client != nil {
+		rig := &Rig{}
+		err := v.Client.Get(ctx, client.ObjectKey{Name: convoy.Spec.RigRef}, rig)
+		switch {
+		case apierrors.IsNotFound(err):
+			allErrs = append(allErrs, fmt.Sprintf("spec.rigRef: rig %q not found", convoy.Spec.RigRef))
+		case err != nil:
+			return nil, fmt.Errorf("failed to get rig %q: %w", convoy.Spec.RigRef, err)
+		}
+	}
+
+	if len(allErrs) > 0 {
+		return nil, fmt.Errorf("validation failed: %s", strings.Join(allErrs, "; "))
+	}
+
+	return nil, nil
+}
+
+// validateTrackedBeads checks that the bead list under path is non-empty and
+// has no blank or duplicate IDs.
+func validateTrackedBeads(path string, beads []string) []string {
+	if len(beads) == 0 {
+		return []string{path + ".trackedBeads: at least one bead is required"}
+	}
+
+	var errs []string
+	seen := make(map[string]bool, len(beads))
+	for i, bead := range beads {
+		if strings.TrimSpace(bead) == "" {
+			errs = append(errs, fmt.Sprintf("%s.trackedBeads[%d]: must not be empty", path, i))
+			continue
+		}
+		if seen[bead] {
+			errs = append(errs, fmt.Sprintf("%s.trackedBeads[%d]: duplicate bead %q", path, i, bead))
+		}
+		seen[bead] = true
+	}
+	return errs
+}
+
+// validateDependencies checks that the dependencies under path are between
+// tracked beads and form no cycle, which would hold its beads forever.
+func validateDependencies(path string, tracked []string, deps []BeadDependency) []string {
+	var errs []string
+	graph := make(map[string][]string, len(deps))
+	for i, dep := range deps {
+		if !slices.Contains(tracked, dep.Bead) {
+			errs = append(errs, fmt.Sprintf("%s.dependencies[%d].bead: %q is not a tracked bead", path, i, dep.Bead))
+		}
+		for j, on := range dep.DependsOn {
+			switch {
+			case on == dep.Bead:
+				errs = append(errs, fmt.Sprintf("%s.dependencies[%d].dependsOn[%d]: bead %q depends on itself", path, i, j, on))
+			case !slices.Contains(tracked, on):
+				errs = append(errs, fmt.Sprintf("%s.dependencies[%d].dependsOn[%d]: %q is not a tracked bead", path, i, j, on))
+			}
+		}
+		graph[dep.Bead] = append(graph[dep.Bead], dep.DependsOn...)
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if cycle := dependencyCycle(graph); cycle != nil {
+		errs = append(errs, path+".dependencies: cycle "+strings.Join(cycle, " -> "))
+	}
+	return errs
+}
+
+// dependencyCycle returns a cycle of the dependency graph, starting and
+// ending with the same bead, or nil if there is none.
+func dependencyCycle(graph map[string][]string) []string {
+	const (
+		visiting = 1
+		visited  = 2
+	)
+	state := make(map[string]int, len(graph))
+	var path []string
+	var visit func(bead string) []string
+	visit = func(bead string) []string {
+		switch state[bead] {
+		case visiting:
+			start := slices.Index(path, bead)
+			return append(slices.Clone(path[start:]), bead)
+		case visited:
+			return nil
+		}
+		state[bead] = visiting
+		path = append(path, bead)
+		for _, on := range graph[bead] {
+			if cycle := visit(on); cycle != nil {
+				return cycle
+			}
+		}
+		path = path[:len(path)-1]
+		state[bead] = visited
+		return nil
+	}
+
+	// Visit in a stable order so the reported cycle does not vary
+	beads := slices.Sorted(maps.Keys(graph))
+	for _, bead := range beads {
+		if cycle := visit(bead); cycle != nil {
+			return cycle
+		}
+	}
+	return nil
+}
+
+// validateCompletionActions checks that each completion action has a unique
+// name and exactly one kind, and that actions on the rig's repository or
+// BeadStore have a rig to act on.
+func validateCompletionActions(convoy *Convoy) []string {
+	var errs []string
+	seen := make(map[string]bool, len(convoy.Spec.OnComplete))
+	for i, action := range convoy.Spec.OnComplete {
+		path := fmt.Sprintf("spec.onComplete[%d]", i)
+		if seen[action.Name] {
+			errs = append(errs, fmt.Sprintf("%s.name: duplicate action %q", path, action.Name))
+		}
+		seen[action.Name] = true
+
+		var kinds []string
+		if action.FollowUpConvoy != nil {
+			kinds = append(kinds, "followUpConvoy")
+		}
+		if action.Tag != nil {
+			kinds = append(kinds, "tag")
+		}
+		if action.Webhook != nil {
+			kinds = append(kinds, "webhook")
+		}
+		if action.SummaryBead != nil {
+			kinds = append(kinds, "summaryBead")
+		}
+		if len(kinds) != 1 {
+			errs = append(errs, fmt.Sprintf("%s: exactly one of followUpConvoy, tag, webhook or summaryBead is required, got %d",
+				path, len(kinds)))
+			continue
+		}
+
+		switch {
+		case action.FollowUpConvoy != nil:
+			followUp := action.FollowUpConvoy
+			if followUp.Name == convoy.Name {
+				errs = append(errs, fmt.Sprintf("%s.followUpConvoy.name: must differ from the convoy's own name", path))
+			}
+			errs = append(errs, validateTrackedBeads(path+".followUpConvoy", followUp.TrackedBeads)...)
+			errs = append(errs, validateDependencies(path+".followUpConvoy", followUp.TrackedBeads, followUp.Dependencies)...)
+		case action.Webhook != nil:
+			if u, err := url.Parse(action.Webhook.URL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+				errs = append(errs, fmt.Sprintf("%s.webhook.url: %q is not an http(s) URL", path, action.Webhook.URL))
+			}
+		case convoy.Spec.RigRef == "":
+			errs = append(errs, fmt.Sprintf("%s.%s: requires spec.rigRef", path, kinds[0]))
+		}
+	}
+	return errs
+}
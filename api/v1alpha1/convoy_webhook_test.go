@@ -0,0 +1,350 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+func TestConvoyCustomValidator_ValidateCreate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	require.NoError(t, AddToScheme(scheme))
+	validator := &ConvoyCustomValidator{
+		Client: fake.NewClientBuilder().WithScheme(scheme).WithObjects(&Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+		}).Build(),
+	}
+	ctx := context.Background()
+
+	tests := []struct {
+		name    string
+		spec    ConvoySpec
+		wantErr bool
+		errMsg  string
+	}{
+		{
+			name: "valid convoy",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1", "gt-2"},
+			},
+			wantErr: false,
+		},
+		{
+			name: "valid convoy with existing rig",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				RigRef:       "test-rig",
+			},
+			wantErr: false,
+		},
+		{
+			name: "empty tracked beads",
+			spec: ConvoySpec{
+				Description: "batch",
+			},
+			wantErr: true,
+			errMsg:  "at least one bead is required",
+		},
+		{
+			name: "duplicate bead",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1", "gt-2", "gt-1"},
+			},
+			wantErr: true,
+			errMsg:  `spec.trackedBeads[2]: duplicate bead "gt-1"`,
+		},
+		{
+			name: "blank bead",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1", " "},
+			},
+			wantErr: true,
+			errMsg:  "spec.trackedBeads[1]: must not be empty",
+		},
+		{
+			name: "valid dependencies",
+			spec: ConvoySpec{
+				Description:  "pipeline",
+				TrackedBeads: []string{"gt-1", "gt-2", "gt-3"},
+				Dependencies: []BeadDependency{
+					{Bead: "gt-2", DependsOn: []string{"gt-1"}},
+					{Bead: "gt-3", DependsOn: []string{"gt-1", "gt-2"}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "dependency on an untracked bead",
+			spec: ConvoySpec{
+				Description:  "pipeline",
+				TrackedBeads: []string{"gt-1", "gt-2"},
+				Dependencies: []BeadDependency{{Bead: "gt-2", DependsOn: []string{"gt-9"}}},
+			},
+			wantErr: true,
+			errMsg:  `spec.dependencies[0].dependsOn[0]: "gt-9" is not a tracked bead`,
+		},
+		{
+			name: "dependency of an untracked bead",
+			spec: ConvoySpec{
+				Description:  "pipeline",
+				TrackedBeads: []string{"gt-1"},
+				Dependencies: []BeadDependency{{Bead: "gt-9", DependsOn: []string{"gt-1"}}},
+			},
+			wantErr: true,
+			errMsg:  `spec.dependencies[0].bead: "gt-9" is not a tracked bead`,
+		},
+		{
+			name: "bead depending on itself",
+			spec: ConvoySpec{
+				Description:  "pipeline",
+				TrackedBeads: []string{"gt-1"},
+				Dependencies: []BeadDependency{{Bead: "gt-1", DependsOn: []string{"gt-1"}}},
+			},
+			wantErr: true,
+			errMsg:  `bead "gt-1" depends on itself`,
+		},
+		{
+			name: "dependency cycle",
+			spec: ConvoySpec{
+				Description:  "pipeline",
+				TrackedBeads: []string{"gt-1", "gt-2", "gt-3"},
+				Dependencies: []BeadDependency{
+					{Bead: "gt-1", DependsOn: []string{"gt-3"}},
+					{Bead: "gt-2", DependsOn: []string{"gt-1"}},
+					{Bead: "gt-3", DependsOn: []string{"gt-2"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  "spec.dependencies: cycle gt-1 -> gt-3 -> gt-2 -> gt-1",
+		},
+		{
+			name: "missing rig",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				RigRef:       "no-such-rig",
+			},
+			wantErr: true,
+			errMsg:  `spec.rigRef: rig "no-such-rig" not found`,
+		},
+		{
+			name: "completion actions",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				RigRef:       "test-rig",
+				OnComplete: []ConvoyCompletionAction{
+					{Name: "next", FollowUpConvoy: &FollowUpConvoyAction{
+						Description: "next batch", TrackedBeads: []string{"gt-2", "gt-3"},
+						Dependencies: []BeadDependency{{Bead: "gt-3", DependsOn: []string{"gt-2"}}},
+					}},
+					{Name: "release", Tag: &TagAction{Name: "batch-1"}},
+					{Name: "notify", Webhook: &WebhookAction{URL: "https://hooks.example.com/convoys"}},
+					{Name: "summary", SummaryBead: &SummaryBeadAction{}},
+				},
+			},
+		},
+		{
+			name: "completion action of no kind",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				OnComplete:   []ConvoyCompletionAction{{Name: "nothing"}},
+			},
+			wantErr: true,
+			errMsg:  "spec.onComplete[0]: exactly one of followUpConvoy, tag, webhook or summaryBead is required, got 0",
+		},
+		{
+			name: "completion action of two kinds",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				OnComplete: []ConvoyCompletionAction{{
+					Name:        "both",
+					Webhook:     &WebhookAction{URL: "https://hooks.example.com"},
+					SummaryBead: &SummaryBeadAction{},
+				}},
+			},
+			wantErr: true,
+			errMsg:  "got 2",
+		},
+		{
+			name: "duplicate completion action",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				OnComplete: []ConvoyCompletionAction{
+					{Name: "notify", Webhook: &WebhookAction{URL: "https://a.example.com"}},
+					{Name: "notify", Webhook: &WebhookAction{URL: "https://b.example.com"}},
+				},
+			},
+			wantErr: true,
+			errMsg:  `spec.onComplete[1].name: duplicate action "notify"`,
+		},
+		{
+			name: "tag without a rig",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				OnComplete:   []ConvoyCompletionAction{{Name: "release", Tag: &TagAction{Name: "v1"}}},
+			},
+			wantErr: true,
+			errMsg:  "spec.onComplete[0].tag: requires spec.rigRef",
+		},
+		{
+			name: "webhook to a non-http URL",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				OnComplete:   []ConvoyCompletionAction{{Name: "notify", Webhook: &WebhookAction{URL: "ftp://example.com"}}},
+			},
+			wantErr: true,
+			errMsg:  `spec.onComplete[0].webhook.url: "ftp://example.com" is not an http(s) URL`,
+		},
+		{
+			name: "follow-up convoy with a dependency cycle",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				OnComplete: []ConvoyCompletionAction{{Name: "next", FollowUpConvoy: &FollowUpConvoyAction{
+					Description: "next", TrackedBeads: []string{"gt-2", "gt-3"},
+					Dependencies: []BeadDependency{
+						{Bead: "gt-2", DependsOn: []string{"gt-3"}},
+						{Bead: "gt-3", DependsOn: []string{"gt-2"}},
+					},
+				}}},
+			},
+			wantErr: true,
+			errMsg:  "spec.onComplete[0].followUpConvoy.dependencies: cycle gt-2 -> gt-3 -> gt-2",
+		},
+		{
+			name: "follow-up convoy named after the convoy",
+			spec: ConvoySpec{
+				Description:  "batch",
+				TrackedBeads: []string{"gt-1"},
+				OnComplete: []ConvoyCompletionAction{{Name: "again", FollowUpConvoy: &FollowUpConvoyAction{
+					Name: "test-convoy", Description: "again", TrackedBeads: []string{"gt-1"},
+				}}},
+			},
+			wantErr: true,
+			errMsg:  "spec.onComplete[0].followUpConvoy.name: must differ from the convoy's own name",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			convoy := &Convoy{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-convoy", Namespace: "default"},
+				Spec:       tt.spec,
+			}
+			_, err := validator.ValidateCreate(ctx, convoy)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tt.errMsg)
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConvoyCustomValidator_ValidateUpdate(t *testing.T) {
+	validator := &ConvoyCustomValidator{}
+	ctx := context.Background()
+
+	oldConvoy := &Convoy{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-convoy"},
+		Spec:       ConvoySpec{Description: "batch", TrackedBeads: []string{"gt-1"}},
+	}
+	newConvoy := oldConvoy.DeepCopy()
+	newConvoy.Spec.TrackedBeads = append(newConvoy.Spec.TrackedBeads, "gt-1")
+
+	_, err := validator.ValidateUpdate(ctx, oldConvoy, newConvoy)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "duplicate bead")
+
+	// Without a client, rigRef is not looked up
+	newConvoy.Spec.TrackedBeads = []string{"gt-1", "gt-2"}
+	newConvoy.Spec.RigRef = "unknown-rig"
+	_, err = validator.ValidateUpdate(ctx, oldConvoy, newConvoy)
+	require.NoError(t, err)
+}
+
+func TestConvoyCustomValidator_ValidateDelete(t *testing.T) {
+	validator := &ConvoyCustomValidator{}
+
+	warnings, err := validator.ValidateDelete(context.Background(), &Convoy{})
+	require.NoError(t, err)
+	assert.Nil(t, warnings)
+}
+
+func TestConvoyCustomDefaulter_Default(t *testing.T) {
+	defaulter := &ConvoyCustomDefaulter{}
+	ctx := context.Background()
+
+	tests := []struct {
+		name            string
+		convoy          *Convoy
+		wantDescription string
+		wantLabels      map[string]string
+	}{
+		{
+			name: "description and rig label defaulted",
+			convoy: &Convoy{
+				Spec: ConvoySpec{TrackedBeads: []string{"gt-1", "gt-2"}, RigRef: "test-rig"},
+			},
+			wantDescription: "Convoy tracking gt-1, gt-2",
+			wantLabels:      map[string]string{ConvoyRigLabel: "test-rig"},
+		},
+		{
+			name: "description and labels preserved",
+			convoy: &Convoy{
+				ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{ConvoyRigLabel: "other", "team": "a"}},
+				Spec:       ConvoySpec{Description: "release batch", TrackedBeads: []string{"gt-1"}, RigRef: "test-rig"},
+			},
+			wantDescription: "release batch",
+			wantLabels:      map[string]string{ConvoyRigLabel: "other", "team": "a"},
+		},
+		{
+			name: "no labels without rigRef",
+			convoy: &Convoy{
+				Spec: ConvoySpec{TrackedBeads: []string{"gt-1"}},
+			},
+			wantDescription: "Convoy tracking gt-1",
+			wantLabels:      nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.NoError(t, defaulter.Default(ctx, tt.convoy))
+			assert.Equal(t, tt.wantDescription, tt.convoy.Spec.Description)
+			assert.Equal(t, tt.wantLabels, tt.convoy.Labels)
+		})
+	}
+}
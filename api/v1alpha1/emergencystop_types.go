@@ -0,0 +1,106 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// EmergencyStopSpec defines the desired state of EmergencyStop
+type EmergencyStopSpec struct {
+	// Reason explains why the stop was engaged; recorded in events
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Reason string `json:"reason"`
+
+	// Engaged holds all dispatching and merging across every rig while true.
+	// Set to false (or delete the resource) to release the stop.
+	// +kubebuilder:default=true
+	// +optional
+	Engaged bool `json:"engaged"`
+
+	// TerminatePods deletes the agent Pods of running polecats when the stop
+	// engages. Their Polecats are restarted once the stop is released.
+	// +optional
+	TerminatePods bool `json:"terminatePods,omitempty"`
+}
+
+// EmergencyStopPhase represents the current phase of an EmergencyStop
+// +kubebuilder:validation:Enum=Engaged;Released
+type EmergencyStopPhase string
+
+const (
+	EmergencyStopPhaseEngaged  EmergencyStopPhase = "Engaged"
+	EmergencyStopPhaseReleased EmergencyStopPhase = "Released"
+)
+
+// EmergencyStopStatus defines the observed state of EmergencyStop
+type EmergencyStopStatus struct {
+	// Phase is Engaged while the stop holds work, Released afterwards
+	// +optional
+	Phase EmergencyStopPhase `json:"phase,omitempty"`
+
+	// EngagedAt is when the stop was last engaged
+	// +optional
+	EngagedAt *metav1.Time `json:"engagedAt,omitempty"`
+
+	// ReleasedAt is when the stop was last released
+	// +optional
+	ReleasedAt *metav1.Time `json:"releasedAt,omitempty"`
+
+	// TerminatedPolecats lists the polecats (namespace/name) whose Pods were deleted
+	// +optional
+	TerminatedPolecats []string `json:"terminatedPolecats,omitempty"`
+
+	// Conditions represent the current state of the EmergencyStop resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=estop
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Reason",type="string",JSONPath=".spec.reason"
+// +kubebuilder:printcolumn:name="Terminate",type="boolean",JSONPath=".spec.terminatePods"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// EmergencyStop is the Schema for the emergencystops API.
+// While any EmergencyStop is engaged, no rig starts new polecats and no
+// Refinery merges, regardless of the rigs' own suspend settings.
+type EmergencyStop struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   EmergencyStopSpec   `json:"spec,omitempty"`
+	Status EmergencyStopStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// EmergencyStopList contains a list of EmergencyStop
+type EmergencyStopList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []EmergencyStop `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&EmergencyStop{}, &EmergencyStopList{})
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// GastownConfigName is the name of the GastownConfig the operator applies.
+// GastownConfigs with other names are ignored.
+const GastownConfigName = "default"
+
+// GastownConfigSpec defines the operator-wide configuration. Unset fields
+// fall back to the operator's environment variables, then built-in defaults.
+type GastownConfigSpec struct {
+	// ChildNamespace is where Witness and Refinery CRs of rigs without
+	// spec.childNamespace are created (replaces GASTOWN_NAMESPACE)
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ChildNamespace string `json:"childNamespace,omitempty"`
+
+	// Images overrides the default images of agent Pods
+	// +optional
+	Images ImageDefaults `json:"images,omitempty"`
+
+	// ImageMirrors rewrite the images of the Pods the operator creates
+	// (agents, image probes and Refinery tests) to pull through a registry
+	// mirror or pull-through cache, e.g. where ghcr.io is not reachable. An
+	// image starting with a prefix has it replaced by the mirror; the longest
+	// matching prefix wins.
+	// +listType=map
+	// +listMapKey=prefix
+	// +optional
+	ImageMirrors []ImageMirror `json:"imageMirrors,omitempty"`
+
+	// Requeue tunes the controllers' requeue intervals
+	// +optional
+	Requeue RequeueIntervals `json:"requeue,omitempty"`
+
+	// AgentResources are the default compute resources of agent containers
+	// for polecats without spec.kubernetes.resources
+	// +optional
+	AgentResources *corev1.ResourceRequirements `json:"agentResources,omitempty"`
+
+	// ModelPricing sets or overrides the prices, in USD per million tokens,
+	// used to estimate polecat costs. Keys are model names or name prefixes
+	// (e.g. "claude-sonnet", "devstral").
+	// +optional
+	ModelPricing map[string]ModelPrice `json:"modelPricing,omitempty"`
+
+	// FeatureGates enables or disables optional operator features by name
+	// (PolecatTTLCleanup, AgentImageProbe, TelemetrySidecar). Unknown gates are
+	// reported in the Ready condition and ignored.
+	// +optional
+	FeatureGates map[string]bool `json:"featureGates,omitempty"`
+
+	// Scheduling shares a global polecat capacity between rigs
+	// +optional
+	Scheduling PolecatScheduling `json:"scheduling,omitempty"`
+
+	// PolecatCleanup bounds how long a deleted polecat waits for the cleanup
+	// of its Pod before its finalizer is removed anyway
+	// +optional
+	PolecatCleanup PolecatCleanupPolicy `json:"polecatCleanup,omitempty"`
+
+	// Controllers tunes the workqueues of controllers by name (polecat, rig,
+	// refinery, witness, convoy, beadstore, polecat-telemetry). Read when the
+	// operator starts; the manager's flags take precedence.
+	// +optional
+	Controllers map[string]ControllerTuning `json:"controllers,omitempty"`
+
+	// CacheSyncPeriod is how often the operator's informer caches resync
+	// every resource, requeuing it with all controllers watching it (default
+	// 10h). Read when the operator starts; --cache-sync-period takes
+	// precedence.
+	// +optional
+	CacheSyncPeriod *metav1.Duration `json:"cacheSyncPeriod,omitempty"`
+}
+
+// ControllerTuning tunes the workqueue of a controller. Unset fields keep
+// the controller's built-in values.
+type ControllerTuning struct {
+	// MaxConcurrentReconciles is how many resources the controller
+	// reconciles at once (built in: polecat 5, rig 3, convoy 3, refinery 2,
+	// witness 2, beadstore 1, polecat-telemetry 1)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	MaxConcurrentReconciles int32 `json:"maxConcurrentReconciles,omitempty"`
+
+	// RateLimiterBaseDelay is how long a resource whose reconcile failed
+	// waits before its first retry; the delay doubles with every further
+	// failure (default 5ms)
+	// +optional
+	RateLimiterBaseDelay *metav1.Duration `json:"rateLimiterBaseDelay,omitempty"`
+
+	// RateLimiterMaxDelay caps the retry delay of failing resources
+	// (default 1000s)
+	// +optional
+	RateLimiterMaxDelay *metav1.Duration `json:"rateLimiterMaxDelay,omitempty"`
+
+	// RateLimiterQPS is how many retries per second the controller makes
+	// across all resources once RateLimiterBurst is used up (default 10)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RateLimiterQPS int32 `json:"rateLimiterQPS,omitempty"`
+
+	// RateLimiterBurst is the size of the token bucket behind
+	// RateLimiterQPS: how many retries the controller makes at once
+	// (default 100)
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	RateLimiterBurst int32 `json:"rateLimiterBurst,omitempty"`
+}
+
+// Actions on a polecat whose cleanup did not succeed within the timeout
+const (
+	// CleanupOnTimeoutForce force-deletes the Pod and removes the finalizer
+	CleanupOnTimeoutForce = "Force"
+
+	// CleanupOnTimeoutWait keeps retrying the cleanup
+	CleanupOnTimeoutWait = "Wait"
+)
+
+// PolecatCleanupPolicy bounds the cleanup of deleted polecats, so that a
+// Pod that cannot be deleted does not hold the polecat, and with it the
+// deletion of its namespace, forever
+type PolecatCleanupPolicy struct {
+	// Timeout is how long, from the deletion of a polecat, its cleanup is
+	// retried (default 10m)
+	// +optional
+	Timeout *metav1.Duration `json:"timeout,omitempty"`
+
+	// OnTimeout is what happens once the timeout expired: Force
+	// force-deletes the Pod and removes the finalizer, with a
+	// FinalizerForced event; Wait keeps retrying
+	// +kubebuilder:validation:Enum=Force;Wait
+	// +kubebuilder:default=Force
+	// +optional
+	OnTimeout string `json:"onTimeout,omitempty"`
+}
+
+// PolecatScheduling limits how many polecats work at once across all rigs
+// and how the limit is shared between them
+type PolecatScheduling struct {
+	// MaxActivePolecats is the number of polecats that may work at once
+	// across all rigs, e.g. to stay within a shared model quota or rate
+	// limit. Polecats over the limit wait with the WaitingForCapacity
+	// condition. 0 means unlimited.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxActivePolecats int32 `json:"maxActivePolecats,omitempty"`
+
+	// RigWeights are the shares of the capacity by rig name (default 1).
+	// Free capacity goes to the waiting rig with the fewest active polecats
+	// per unit of weight, so a rig of weight 2 gets twice the polecats of a
+	// rig of weight 1 while both have work waiting.
+	// +optional
+	RigWeights map[string]int32 `json:"rigWeights,omitempty"`
+}
+
+// ImageDefaults are the default container images of agent Pods
+type ImageDefaults struct {
+	// Git is the image of the git-init container (replaces GASTOWN_GIT_IMAGE)
+	// +optional
+	Git string `json:"git,omitempty"`
+
+	// Claude is the Claude Code agent image (replaces GASTOWN_CLAUDE_IMAGE)
+	// +optional
+	Claude string `json:"claude,omitempty"`
+
+	// OpenCode is the OpenCode agent image (replaces GASTOWN_OPENCODE_IMAGE)
+	// +optional
+	OpenCode string `json:"opencode,omitempty"`
+
+	// Aider is the Aider agent image (replaces GASTOWN_AIDER_IMAGE)
+	// +optional
+	Aider string `json:"aider,omitempty"`
+
+	// Telemetry is the telemetry sidecar image (replaces GASTOWN_TELEMETRY_IMAGE)
+	// +optional
+	Telemetry string `json:"telemetry,omitempty"`
+}
+
+// ImageMirror maps an image prefix to a registry mirror
+type ImageMirror struct {
+	// Prefix is a registry, optionally with a repository path, e.g. ghcr.io
+	// or ghcr.io/boshu2. It matches whole path components; images without
+	// a registry are matched as docker.io/library/<name> or docker.io/<name>.
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._:-]*(/[a-zA-Z0-9._-]+)*$`
+	Prefix string `json:"prefix"`
+
+	// Mirror replaces the prefix, e.g. registry.internal/ghcr
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._:-]*(/[a-zA-Z0-9._-]+)*$`
+	Mirror string `json:"mirror"`
+}
+
+// ModelPrice is the price of a model in USD per million tokens
+type ModelPrice struct {
+	// Input is the price of a million input tokens (e.g. "3.00")
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	Input string `json:"input"`
+
+	// Output is the price of a million output tokens (e.g. "15.00")
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]+)?$`
+	Output string `json:"output"`
+}
+
+// RequeueIntervals tunes how often controllers come back to a resource
+type RequeueIntervals struct {
+	// Short is used while waiting for fast state changes (default 10s)
+	// +optional
+	Short *metav1.Duration `json:"short,omitempty"`
+
+	// Default is the periodic re-sync interval (default 30s)
+	// +optional
+	Default *metav1.Duration `json:"default,omitempty"`
+
+	// Long is used after errors and while work is held (default 1m)
+	// +optional
+	Long *metav1.Duration `json:"long,omitempty"`
+}
+
+// GastownConfigStatus defines the observed state of GastownConfig
+type GastownConfigStatus struct {
+	// ObservedGeneration is the generation last applied by the operator
+	// +optional
+	ObservedGeneration int64 `json:"observedGeneration,omitempty"`
+
+	// OperatorVersion is the version of the operator that last applied the
+	// configuration
+	// +optional
+	OperatorVersion string `json:"operatorVersion,omitempty"`
+
+	// APIVersion is the Gas Town API version served to that operator
+	// +optional
+	APIVersion string `json:"apiVersion,omitempty"`
+
+	// SchemaVersion is the resource schema version of that operator. An
+	// operator with an older schema version leaves resources stamped with a
+	// newer one alone (see the gastown.io/schema-version annotation).
+	// +optional
+	SchemaVersion int32 `json:"schemaVersion,omitempty"`
+
+	// Conditions represent the current state of the GastownConfig resource
+	// +listType=map
+	// +listMapKey=type
+	// +optional
+	Conditions []metav1.Condition `json:"conditions,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster,shortName=gtconfig
+// +kubebuilder:printcolumn:name="Ready",type="string",JSONPath=".status.conditions[?(@.type=='Ready')].status"
+// +kubebuilder:printcolumn:name="Operator",type="string",JSONPath=".status.operatorVersion"
+// +kubebuilder:printcolumn:name="Schema",type="integer",JSONPath=".status.schemaVersion"
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// GastownConfig is the Schema for the gastownconfigs API.
+// The GastownConfig named "default" configures the operator and is
+// hot-reloaded: changes apply to the next reconcile without a restart.
+type GastownConfig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   GastownConfigSpec   `json:"spec,omitempty"`
+	Status GastownConfigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// GastownConfigList contains a list of GastownConfig
+type GastownConfigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []GastownConfig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&GastownConfig{}, &GastownConfigList{})
+}
@@ -63,12 +63,15 @@ const (
 )
 
 // AgentType represents the coding agent to use
-// Currently only claude-code is supported
-// +kubebuilder:validation:Enum=claude-code
+// +kubebuilder:validation:Enum=claude-code;opencode;aider;custom
 type AgentType string
 
 const (
 	AgentTypeClaudeCode AgentType = "claude-code"
+	AgentTypeOpenCode   AgentType = "opencode"
+	AgentTypeAider      AgentType = "aider"
+	// AgentTypeCustom runs agentConfig.command with the prompt in $GT_PROMPT
+	AgentTypeCustom AgentType = "custom"
 )
 
 // LLMProvider represents the LLM provider to use
@@ -123,7 +126,8 @@ type AgentConfig struct {
 	// +optional
 	Image string `json:"image,omitempty"`
 
-	// Command overrides the default entrypoint command
+	// Command overrides the default entrypoint command. The prompt is passed
+	// in $GT_PROMPT. Required for the custom agent.
 	// +optional
 	Command []string `json:"command,omitempty"`
 
@@ -140,7 +144,102 @@ type AgentConfig struct {
 	Env []corev1.EnvVar `json:"env,omitempty"`
 }
 
+// AgentProbeSpec configures liveness and startup probes on the agent container.
+// By default the probes check the freshness of a heartbeat file that the agent
+// startup script touches whenever the agent shows activity (session transcript
+// or workspace writes). When probes are configured the Pod uses
+// restartPolicy=OnFailure so the kubelet restarts a hung agent in place,
+// keeping the cloned workspace, before controller-level stall handling kicks in.
+// An agent exiting under the exit code contract is not restarted: the
+// controller fails its Pod, and the bead is retried like after any failure.
+type AgentProbeSpec struct {
+	// HeartbeatFile is the path of the heartbeat file inside the agent container.
+	// Must live on a writable volume (e.g., /tmp).
+	// +kubebuilder:default="/tmp/gt-heartbeat"
+	// +optional
+	HeartbeatFile string `json:"heartbeatFile,omitempty"`
+
+	// HeartbeatMaxAgeSeconds is how old the heartbeat file may get before the
+	// agent is considered hung and the liveness probe fails.
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	HeartbeatMaxAgeSeconds int32 `json:"heartbeatMaxAgeSeconds,omitempty"`
+
+	// StartupTimeoutSeconds is how long the agent may take to write its first
+	// heartbeat before the startup probe fails.
+	// +kubebuilder:default=300
+	// +kubebuilder:validation:Minimum=10
+	// +optional
+	StartupTimeoutSeconds int32 `json:"startupTimeoutSeconds,omitempty"`
+
+	// PeriodSeconds is how often the probes run.
+	// +kubebuilder:default=30
+	// +kubebuilder:validation:Minimum=1
+	// +optional
+	PeriodSeconds int32 `json:"periodSeconds,omitempty"`
+
+	// LivenessProbe replaces the generated heartbeat liveness probe entirely.
+	// +optional
+	LivenessProbe *corev1.Probe `json:"livenessProbe,omitempty"`
+
+	// StartupProbe replaces the generated heartbeat startup probe entirely.
+	// +optional
+	StartupProbe *corev1.Probe `json:"startupProbe,omitempty"`
+}
+
+// AdditionalRepository is a repo cloned into the polecat workspace besides
+// the primary GitRepository
+type AdditionalRepository struct {
+	// URL is the git repo URL to clone (SSH or HTTPS format)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^(git@[a-zA-Z0-9._-]+:|https?://[a-zA-Z0-9._-]+/)[a-zA-Z0-9._/-]+(\.git)?$`
+	URL string `json:"url"`
+
+	// Branch is the branch to checkout
+	// +kubebuilder:default=main
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._/-]+$`
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// Path is the directory under /workspace to clone into. The primary
+	// repository lives in "repo".
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`
+	// +kubebuilder:validation:MaxLength=63
+	Path string `json:"path"`
+
+	// GitSecretRef references a Secret containing the SSH key, or HTTPS
+	// token (token or username/password keys), for this repo.
+	// Defaults to the polecat's GitSecretRef.
+	// +optional
+	GitSecretRef *SecretReference `json:"gitSecretRef,omitempty"`
+}
+
+// CSISecretVolume is a volume of the Secrets Store CSI driver
+// (https://secrets-store-csi-driver.sigs.k8s.io) that mounts credentials
+// straight from an external store such as Vault.
+type CSISecretVolume struct {
+	// Driver is the CSI driver of the volume
+	// +kubebuilder:default=secrets-store.csi.k8s.io
+	// +optional
+	Driver string `json:"driver,omitempty"`
+
+	// VolumeAttributes are passed to the driver, e.g. the
+	// secretProviderClass naming the SecretProviderClass to mount
+	// +kubebuilder:validation:MinProperties=1
+	VolumeAttributes map[string]string `json:"volumeAttributes"`
+
+	// NodePublishSecretRef references a Secret with the credentials the
+	// provider authenticates to the store with, for providers that need one
+	// +optional
+	NodePublishSecretRef *corev1.LocalObjectReference `json:"nodePublishSecretRef,omitempty"`
+}
+
 // KubernetesSpec defines configuration for kubernetes execution mode
+// +kubebuilder:validation:XValidation:rule="!has(self.dnsPolicy) || self.dnsPolicy != 'None' || has(self.dnsConfig)",message="dnsConfig is required with dnsPolicy None"
+// +kubebuilder:validation:XValidation:rule="has(self.gitSecretRef) != has(self.gitCredsCSI)",message="exactly one of gitSecretRef and gitCredsCSI is required"
+// +kubebuilder:validation:XValidation:rule="!has(self.claudeCredsSecretRef) || !has(self.claudeCredsCSI)",message="claudeCredsSecretRef and claudeCredsCSI are mutually exclusive"
 type KubernetesSpec struct {
 	// GitRepository is the git repo URL to clone (SSH or HTTPS format)
 	// +kubebuilder:validation:Required
@@ -158,15 +257,39 @@ type KubernetesSpec struct {
 	// +optional
 	WorkBranch string `json:"workBranch,omitempty"`
 
-	// GitSecretRef references a Secret containing SSH key for git
-	// +kubebuilder:validation:Required
-	GitSecretRef SecretReference `json:"gitSecretRef"`
+	// GitSecretRef references a Secret containing git credentials: an SSH key,
+	// or an HTTPS token (token or username/password keys), with which SSH
+	// repository URLs are rewritten to HTTPS. Required unless GitCredsCSI is
+	// provided.
+	// +optional
+	GitSecretRef SecretReference `json:"gitSecretRef,omitzero"`
+
+	// GitCredsCSI mounts the git credentials from a Secrets Store CSI driver
+	// volume instead of GitSecretRef, e.g. from Vault, so they are never
+	// stored in a Secret. The volume must provide the same files.
+	// +optional
+	GitCredsCSI *CSISecretVolume `json:"gitCredsCSI,omitempty"`
+
+	// AdditionalRepositories are further repos the bead spans (e.g. a client
+	// of the API in GitRepository), cloned next to it with the work branch
+	// checked out. GitRepository stays the primary repo: its work branch is
+	// the one the Refinery merges.
+	// +listType=map
+	// +listMapKey=path
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	AdditionalRepositories []AdditionalRepository `json:"additionalRepositories,omitempty"`
 
 	// ClaudeCredsSecretRef references a Secret containing ~/.claude/ contents
 	// Required unless ApiKeySecretRef is provided
 	// +optional
 	ClaudeCredsSecretRef *SecretReference `json:"claudeCredsSecretRef,omitempty"`
 
+	// ClaudeCredsCSI mounts the ~/.claude/ contents from a Secrets Store CSI
+	// driver volume instead of ClaudeCredsSecretRef
+	// +optional
+	ClaudeCredsCSI *CSISecretVolume `json:"claudeCredsCSI,omitempty"`
+
 	// ApiKeySecretRef references a Secret containing the ANTHROPIC_API_KEY
 	// Alternative to ClaudeCredsSecretRef for headless API key authentication
 	// +optional
@@ -185,6 +308,55 @@ type KubernetesSpec struct {
 	// +optional
 	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
 
+	// PriorityClassName is the PriorityClass of the agent Pod
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// NodeSelector restricts the agent Pod to nodes with these labels, e.g.
+	// a dedicated GPU or spot node pool
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations let the agent Pod run on tainted nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity constrains the nodes the agent Pod runs on, and its placement
+	// relative to other Pods
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints spread agent Pods across zones or nodes
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// DNSPolicy is the DNS policy of the agent Pod (default ClusterFirst)
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig adds nameservers, search domains and resolver options to
+	// the agent Pod, e.g. to resolve internal git hosts and registries.
+	// Required with dnsPolicy None.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// HostAliases are added to the agent Pod's /etc/hosts
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// ServiceAccountName is the ServiceAccount the agent Pod runs as. Defaults
+	// to the rig's agentServiceAccount when it has one, else the namespace's
+	// default ServiceAccount.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ImagePullSecrets are used to pull the images of the agent Pod from
+	// private registries. Defaults to the rig's imagePullSecrets.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
 	// SSHKnownHostsConfigMapRef references a ConfigMap containing SSH known_hosts
 	// If provided, uses the 'known_hosts' key from this ConfigMap instead of pre-populated keys.
 	// Use this for private Git servers or to override the default host key verification.
@@ -198,6 +370,39 @@ type KubernetesSpec struct {
 	// +kubebuilder:default=yes
 	// +optional
 	SSHStrictHostKeyChecking string `json:"sshStrictHostKeyChecking,omitempty"`
+
+	// PromptTemplateRef references a ConfigMap whose 'prompt.tmpl' key holds a
+	// Go template for the agent prompt, replacing the built-in prompt. The
+	// template is rendered with the agent context (.Polecat, .Namespace, .Rig,
+	// .Bead, .Convoy, .Task, .Branch.Repository, .Branch.Base, .Branch.Work).
+	// +optional
+	PromptTemplateRef *corev1.LocalObjectReference `json:"promptTemplateRef,omitempty"`
+
+	// Probes configures liveness/startup probes on the agent container.
+	// If nil, no probes are added and a hung agent is only caught by the
+	// Witness stuck threshold or ActiveDeadlineSeconds.
+	// +optional
+	Probes *AgentProbeSpec `json:"probes,omitempty"`
+
+	// Checkpoint commits and pushes the agent's work in progress to the work
+	// branch periodically. A Pod replacing one that ran past
+	// ActiveDeadlineSeconds, or failed, resumes from the work branch instead
+	// of cloning the base branch again.
+	// +optional
+	Checkpoint *CheckpointSpec `json:"checkpoint,omitempty"`
+}
+
+// CheckpointSpec configures checkpoints of the agent's work in progress.
+// Checkpoints are commits on the work branch of the primary repository,
+// made without running commit hooks.
+type CheckpointSpec struct {
+	// IntervalSeconds is how often uncommitted work is committed and the
+	// work branch pushed. Work done since the last checkpoint is lost when
+	// the Pod is killed.
+	// +kubebuilder:default=600
+	// +kubebuilder:validation:Minimum=60
+	// +optional
+	IntervalSeconds int32 `json:"intervalSeconds,omitempty"`
 }
 
 // PolecatSpec defines the desired state of Polecat
@@ -243,13 +448,66 @@ type PolecatSpec struct {
 	// +optional
 	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
 
-	// TTLSecondsAfterFinished limits how long a completed polecat persists
+	// TTLSecondsAfterFinished deletes the polecat this many seconds after it
+	// reaches Done or Terminated. Done polecats whose rig has a Refinery are
+	// kept until their branch is merged.
+	// +kubebuilder:validation:Minimum=0
 	// +optional
 	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
 
 	// MaxIdleSeconds terminates polecat if idle for this duration
 	// +optional
 	MaxIdleSeconds *int32 `json:"maxIdleSeconds,omitempty"`
+
+	// HeartbeatTimeoutSeconds marks the polecat Stuck when its agent has not
+	// touched the heartbeat file for this long while the Pod runs. The agent
+	// container gets a readiness probe on the heartbeat, so a stalled agent is
+	// reported, not restarted; see spec.kubernetes.probes for restarts.
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	HeartbeatTimeoutSeconds *int32 `json:"heartbeatTimeoutSeconds,omitempty"`
+
+	// SyncIntervalSeconds is how often the polecat's status is re-synced from
+	// its Pod while it works. Defaults to the GastownConfig's requeue.short.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	SyncIntervalSeconds *int32 `json:"syncIntervalSeconds,omitempty"`
+
+	// RestartOnSecretChange recreates the agent Pod when a Secret it mounts
+	// or reads, e.g. the git or Claude credentials, is rotated, so the agent
+	// works with the new credentials. Work in the Pod that was not pushed is
+	// lost with it.
+	// +optional
+	RestartOnSecretChange bool `json:"restartOnSecretChange,omitempty"`
+
+	// MergePriority orders this polecat's branch in the Refinery merge queue
+	// when the Refinery uses the "priority" queue policy. Higher merges first.
+	// +optional
+	MergePriority int32 `json:"mergePriority,omitempty"`
+
+	// MergeAfter lists polecats (in the same namespace) whose branches must be
+	// merged before the Refinery considers this polecat's branch.
+	// +optional
+	MergeAfter []string `json:"mergeAfter,omitempty"`
+
+	// Urgent starts the polecat outside its rig's execution windows
+	// +optional
+	Urgent bool `json:"urgent,omitempty"`
+
+	// SplitOf makes this a planning polecat: instead of working on the bead,
+	// its agent proposes splitting it into smaller beads, because the named
+	// polecat ran out of time or budget on it. Planning polecats are created
+	// by the operator for rigs with settings.suggestSplits and are never merged.
+	// +optional
+	SplitOf string `json:"splitOf,omitempty"`
+
+	// Repositories names the rig repositories (the Rig's spec.repositories)
+	// the task spans. Each is cloned into /workspace/<name> on the work
+	// branch, and the Refinery merges the branch in each.
+	// +listType=set
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
 }
 
 // PolecatPhase represents the observed lifecycle phase
@@ -274,10 +532,50 @@ type PolecatStatus struct {
 	// +optional
 	AssignedBead string `json:"assignedBead,omitempty"`
 
+	// Bead is the metadata of the assigned bead, resolved from the rig's BeadStore
+	// +optional
+	Bead *BeadSummary `json:"bead,omitempty"`
+
+	// Attempts is how many agent Pods have been started for the assigned bead
+	// +optional
+	Attempts int32 `json:"attempts,omitempty"`
+
 	// Branch is the git branch the polecat is working on
 	// +optional
 	Branch string `json:"branch,omitempty"`
 
+	// ResumedFrom is the checkpoint commit of the work branch the current
+	// Pod resumed from, when spec.kubernetes.checkpoint found one
+	// +optional
+	ResumedFrom string `json:"resumedFrom,omitempty"`
+
+	// MergedCommit is the commit the Refinery merged the branch as
+	// +optional
+	MergedCommit string `json:"mergedCommit,omitempty"`
+
+	// MergedCommits are the commits the Refinery merged the branch as in
+	// the rig repositories of spec.repositories, by repository name
+	// +optional
+	MergedCommits map[string]string `json:"mergedCommits,omitempty"`
+
+	// PullRequestURL is the pull request the Refinery opened for the branch
+	// +optional
+	PullRequestURL string `json:"pullRequestURL,omitempty"`
+
+	// FollowUpBead is the bead filed after the Witness gave up on the polecat
+	// +optional
+	FollowUpBead string `json:"followUpBead,omitempty"`
+
+	// SplitProposal is the split of the bead proposed by a planning polecat,
+	// read from its log once it finished. Approve it with the
+	// gastown.io/approve-split annotation.
+	// +optional
+	SplitProposal []ProposedBead `json:"splitProposal,omitempty"`
+
+	// SplitBeads are the beads filed for the approved SplitProposal
+	// +optional
+	SplitBeads []string `json:"splitBeads,omitempty"`
+
 	// PodName is the name of the Pod running the agent
 	// +optional
 	PodName string `json:"podName,omitempty"`
@@ -289,6 +587,11 @@ type PolecatStatus struct {
 	// +optional
 	LastActivity *metav1.Time `json:"lastActivity,omitempty"`
 
+	// FinishedAt is when the polecat reached Done or Terminated; the TTL
+	// counts from here
+	// +optional
+	FinishedAt *metav1.Time `json:"finishedAt,omitempty"`
+
 	// Agent is the agent type currently running
 	// +optional
 	Agent AgentType `json:"agent,omitempty"`
@@ -301,6 +604,42 @@ type PolecatStatus struct {
 	// +optional
 	AgentModel string `json:"agentModel,omitempty"`
 
+	// AgentRestarts is how many times the kubelet restarted the agent container,
+	// e.g., after a failed liveness probe
+	// +optional
+	AgentRestarts int32 `json:"agentRestarts,omitempty"`
+
+	// ConsecutiveFailures counts the failed attempts in a row to build,
+	// create or delete the agent Pod. Retries back off exponentially with
+	// it; it is reset once the Pod is created, synced or removed.
+	// +optional
+	ConsecutiveFailures int32 `json:"consecutiveFailures,omitempty"`
+
+	// CostEstimate is the estimated cost of the task, computed before the
+	// agent Pod starts
+	// +optional
+	CostEstimate *CostEstimate `json:"costEstimate,omitempty"`
+
+	// Usage is the token usage reported by the agent of the latest attempt
+	// and what it cost. Only the claude agent reports usage.
+	// +optional
+	Usage *TokenUsage `json:"usage,omitempty"`
+
+	// LastFailure describes the last failed attempt at the assigned bead.
+	// It is handed to the next attempt so a retry can learn from it.
+	// +optional
+	LastFailure *PolecatFailure `json:"lastFailure,omitempty"`
+
+	// LastLogs is the tail of the agent container log, captured when the
+	// Pod fails so it survives Pod garbage collection
+	// +optional
+	LastLogs string `json:"lastLogs,omitempty"`
+
+	// LogsArtifact is the URL of the full agent log uploaded to the rig's
+	// log archive
+	// +optional
+	LogsArtifact string `json:"logsArtifact,omitempty"`
+
 	// Conditions represent the current state of the Polecat resource
 	// +listType=map
 	// +listMapKey=type
@@ -308,14 +647,91 @@ type PolecatStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// CostEstimate is the estimated token usage and cost of a polecat's task
+type CostEstimate struct {
+	// Model is the model the estimate is priced for, empty for the agent's
+	// default model
+	// +optional
+	Model string `json:"model,omitempty"`
+
+	// InputTokens is the estimated number of input tokens
+	InputTokens int64 `json:"inputTokens"`
+
+	// OutputTokens is the estimated number of output tokens
+	OutputTokens int64 `json:"outputTokens"`
+
+	// USD is the estimated cost in US dollars (e.g. "1.74")
+	USD string `json:"usd"`
+}
+
+// TokenUsage is the token usage of an agent session and its estimated cost
+type TokenUsage struct {
+	// InputTokens is the number of input tokens, excluding CacheReadTokens
+	InputTokens int64 `json:"inputTokens"`
+
+	// CacheReadTokens is the number of input tokens read from the prompt
+	// cache, which are billed at a fraction of the input price
+	// +optional
+	CacheReadTokens int64 `json:"cacheReadTokens,omitempty"`
+
+	// OutputTokens is the number of output tokens
+	OutputTokens int64 `json:"outputTokens"`
+
+	// USD is the cost in US dollars at the model's list price (e.g. "0.87")
+	USD string `json:"usd"`
+
+	// UpdatedAt is when the usage was last read from the agent
+	// +optional
+	UpdatedAt *metav1.Time `json:"updatedAt,omitempty"`
+}
+
+// ProposedBead is a smaller bead proposed to replace a bead that was too
+// big to finish
+type ProposedBead struct {
+	// Title of the bead
+	Title string `json:"title"`
+
+	// Description of the bead
+	// +optional
+	Description string `json:"description,omitempty"`
+}
+
+// PolecatFailure describes a failed attempt at a bead
+type PolecatFailure struct {
+	// Bead is the bead the attempt worked on
+	Bead string `json:"bead"`
+
+	// Attempt is the number of the failed attempt
+	Attempt int32 `json:"attempt"`
+
+	// PodName is the Pod that ran the attempt
+	// +optional
+	PodName string `json:"podName,omitempty"`
+
+	// Reason is the exit reason of the agent, e.g. RateLimited or TaskIncomplete
+	Reason string `json:"reason"`
+
+	// Message describes the failure
+	// +optional
+	Message string `json:"message,omitempty"`
+
+	// FailedAt is when the failure was observed
+	// +optional
+	FailedAt *metav1.Time `json:"failedAt,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
+// +kubebuilder:resource:shortName=pc
 // +kubebuilder:printcolumn:name="Rig",type="string",JSONPath=".spec.rig"
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
 // +kubebuilder:printcolumn:name="Bead",type="string",JSONPath=".status.assignedBead"
 // +kubebuilder:printcolumn:name="Pod",type="string",JSONPath=".status.podName"
 // +kubebuilder:printcolumn:name="Active",type="boolean",JSONPath=".status.podActive"
 // +kubebuilder:printcolumn:name="Model",type="string",JSONPath=".status.agentModel",priority=1
+// +kubebuilder:printcolumn:name="Est. Cost",type="string",JSONPath=".status.costEstimate.usd",priority=1
+// +kubebuilder:printcolumn:name="Cost",type="string",JSONPath=".status.usage.usd",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Polecat is the Schema for the polecats API.
@@ -26,20 +26,30 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/org/gastown-operator/pkg/webhookmetrics"
 )
 
+// PolecatProtectAnnotation marks a polecat whose in-flight work must not be
+// thrown away by accident. While it is set to "true" and the polecat has not
+// finished, the validating webhook rejects deleting the polecat or setting its
+// desiredState to Terminated. Remove the annotation to override:
+//
+//	kubectl annotate polecat <name> gastown.io/protect-
+const PolecatProtectAnnotation = "gastown.io/protect"
+
 // log is for logging in this package.
 var polecatlog = logf.Log.WithName("polecat-resource")
 
 // SetupPolecatWebhookWithManager registers the Polecat webhooks with the manager.
 func SetupPolecatWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &Polecat{}).
-		WithValidator(&PolecatCustomValidator{}).
-		WithDefaulter(&PolecatCustomDefaulter{}).
+		WithValidator(webhookmetrics.Validator[*Polecat]("polecat", &PolecatCustomValidator{})).
+		WithDefaulter(webhookmetrics.Defaulter[*Polecat]("polecat", &PolecatCustomDefaulter{})).
 		Complete()
 }
 
-// +kubebuilder:webhook:path=/validate-gastown-gastown-io-v1alpha1-polecat,mutating=false,failurePolicy=fail,sideEffects=None,groups=gastown.gastown.io,resources=polecats,verbs=create;update,versions=v1alpha1,name=vpolecat.kb.io,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/validate-gastown-gastown-io-v1alpha1-polecat,mutating=false,failurePolicy=fail,sideEffects=None,groups=gastown.gastown.io,resources=polecats,verbs=create;update;delete,versions=v1alpha1,name=vpolecat.kb.io,admissionReviewVersions=v1
 
 // PolecatCustomValidator implements admission.Validator[*Polecat] for Polecat.
 type PolecatCustomValidator struct{}
@@ -68,6 +78,15 @@ func (v *PolecatCustomValidator) ValidateUpdate(ctx context.Context, oldPolecat,
 			oldPolecat.Spec.ExecutionMode, polecat.Spec.ExecutionMode)
 	}
 
+	// Protected polecats cannot be terminated mid-flight. Dropping the
+	// annotation in the same update is the explicit override.
+	if polecat.Spec.DesiredState == PolecatDesiredTerminated &&
+		oldPolecat.Spec.DesiredState != PolecatDesiredTerminated &&
+		isProtectedInFlight(polecat) {
+		return nil, fmt.Errorf("polecat %q is protected by the %s annotation: remove it to terminate the polecat",
+			polecat.Name, PolecatProtectAnnotation)
+	}
+
 	return v.validatePolecat(polecat)
 }
 
@@ -75,10 +94,27 @@ func (v *PolecatCustomValidator) ValidateUpdate(ctx context.Context, oldPolecat,
 func (v *PolecatCustomValidator) ValidateDelete(ctx context.Context, polecat *Polecat) (admission.Warnings, error) {
 	polecatlog.Info("validate delete", "name", polecat.Name)
 
-	// No validation on delete
+	if isProtectedInFlight(polecat) {
+		return nil, fmt.Errorf("polecat %q is protected by the %s annotation: remove it to delete the polecat",
+			polecat.Name, PolecatProtectAnnotation)
+	}
+
 	return nil, nil
 }
 
+// isProtectedInFlight reports whether the polecat carries the protect
+// annotation and has not yet finished its work.
+func isProtectedInFlight(polecat *Polecat) bool {
+	if polecat.Annotations[PolecatProtectAnnotation] != "true" {
+		return false
+	}
+	switch polecat.Status.Phase {
+	case PolecatPhaseDone, PolecatPhaseTerminated:
+		return false
+	}
+	return true
+}
+
 // validatePolecat performs validation common to create and update.
 func (v *PolecatCustomValidator) validatePolecat(polecat *Polecat) (admission.Warnings, error) {
 	var allErrs []string
@@ -94,7 +130,7 @@ func (v *PolecatCustomValidator) validatePolecat(polecat *Polecat) (admission.Wa
 		if polecat.Spec.Kubernetes == nil {
 			allErrs = append(allErrs, "spec.kubernetes: is required when executionMode is 'kubernetes'")
 		} else {
-			errs := validateKubernetesSpec(polecat.Spec.Kubernetes)
+			errs := validateKubernetesSpec(polecat.Spec.Kubernetes, polecat.Spec.Agent, polecat.Spec.AgentConfig)
 			allErrs = append(allErrs, errs...)
 		}
 	}
@@ -123,6 +159,17 @@ func (v *PolecatCustomValidator) validatePolecat(polecat *Polecat) (admission.Wa
 		allErrs = append(allErrs, "spec.maxIdleSeconds: must be non-negative")
 	}
 
+	if err := validateSyncInterval(polecat.Spec.SyncIntervalSeconds); err != nil {
+		allErrs = append(allErrs, fmt.Sprintf("spec.syncIntervalSeconds: %v", err))
+	}
+
+	// Validate merge dependencies
+	for _, dep := range polecat.Spec.MergeAfter {
+		if dep == polecat.Name {
+			allErrs = append(allErrs, "spec.mergeAfter: a polecat cannot depend on itself")
+		}
+	}
+
 	// Warning for long-running configurations
 	if polecat.Spec.Kubernetes != nil &&
 		polecat.Spec.Kubernetes.ActiveDeadlineSeconds != nil &&
@@ -137,8 +184,8 @@ func (v *PolecatCustomValidator) validatePolecat(polecat *Polecat) (admission.Wa
 	return warnings, nil
 }
 
-// validateKubernetesSpec validates the kubernetes execution spec.
-func validateKubernetesSpec(k *KubernetesSpec) []string {
+// validateKubernetesSpec validates the kubernetes execution spec for the given agent.
+func validateKubernetesSpec(k *KubernetesSpec, agent AgentType, cfg *AgentConfig) []string {
 	var errs []string
 
 	// GitRepository is required (validated by CRD, but double-check)
@@ -146,23 +193,118 @@ func validateKubernetesSpec(k *KubernetesSpec) []string {
 		errs = append(errs, "spec.kubernetes.gitRepository: is required")
 	}
 
-	// GitSecretRef is required
-	if k.GitSecretRef.Name == "" {
+	// Git credentials come from either a Secret or a CSI volume
+	switch {
+	case k.GitCredsCSI != nil && k.GitSecretRef.Name != "":
+		errs = append(errs, "spec.kubernetes: gitSecretRef and gitCredsCSI are mutually exclusive")
+	case k.GitCredsCSI != nil:
+		errs = append(errs, validateCSISecretVolume("spec.kubernetes.gitCredsCSI", k.GitCredsCSI)...)
+	case k.GitSecretRef.Name == "":
 		errs = append(errs, "spec.kubernetes.gitSecretRef.name: is required")
 	}
-
-	// Either ClaudeCredsSecretRef or ApiKeySecretRef is required for authentication
-	hasOAuth := k.ClaudeCredsSecretRef != nil && k.ClaudeCredsSecretRef.Name != ""
-	hasAPIKey := k.ApiKeySecretRef != nil && k.ApiKeySecretRef.Name != ""
-	if !hasOAuth && !hasAPIKey {
-		errs = append(errs, "spec.kubernetes: either claudeCredsSecretRef or apiKeySecretRef is required")
+	if k.ClaudeCredsCSI != nil {
+		if k.ClaudeCredsSecretRef != nil {
+			errs = append(errs, "spec.kubernetes: claudeCredsSecretRef and claudeCredsCSI are mutually exclusive")
+		}
+		errs = append(errs, validateCSISecretVolume("spec.kubernetes.claudeCredsCSI", k.ClaudeCredsCSI)...)
 	}
 
+	errs = append(errs, validateAgentCredentials(k, agent, cfg)...)
+
 	// Validate ActiveDeadlineSeconds
 	if k.ActiveDeadlineSeconds != nil && *k.ActiveDeadlineSeconds <= 0 {
 		errs = append(errs, "spec.kubernetes.activeDeadlineSeconds: must be positive")
 	}
 
+	if k.Probes != nil {
+		errs = append(errs, validateAgentProbes(k.Probes)...)
+	}
+
+	errs = append(errs, validateAdditionalRepositories(k.AdditionalRepositories)...)
+
+	return errs
+}
+
+// validateAdditionalRepositories checks that additional repositories are
+// cloned next to, not over, the primary repository.
+func validateAdditionalRepositories(repos []AdditionalRepository) []string {
+	var errs []string
+	for i, repo := range repos {
+		field := fmt.Sprintf("spec.kubernetes.additionalRepositories[%d]", i)
+		if repo.URL == "" {
+			errs = append(errs, field+".url: is required")
+		}
+		// The primary repository is cloned into /workspace/repo
+		if repo.Path == "repo" {
+			errs = append(errs, field+`.path: "repo" is reserved for spec.kubernetes.gitRepository`)
+		}
+		if repo.GitSecretRef != nil && repo.GitSecretRef.Name == "" {
+			errs = append(errs, field+".gitSecretRef.name: is required")
+		}
+	}
+	return errs
+}
+
+// validateCSISecretVolume checks that a CSI volume tells its driver what to
+// mount.
+func validateCSISecretVolume(field string, v *CSISecretVolume) []string {
+	if len(v.VolumeAttributes) == 0 {
+		return []string{field + ".volumeAttributes: is required"}
+	}
+	return nil
+}
+
+// validateAgentCredentials checks that the agent has what it needs to run.
+func validateAgentCredentials(k *KubernetesSpec, agent AgentType, cfg *AgentConfig) []string {
+	hasAPIKey := k.ApiKeySecretRef != nil && k.ApiKeySecretRef.Name != ""
+	if cfg != nil && cfg.ModelProvider != nil && cfg.ModelProvider.APIKeySecretRef != nil &&
+		cfg.ModelProvider.APIKeySecretRef.Name != "" {
+		hasAPIKey = true
+	}
+
+	switch agent {
+	case "", AgentTypeClaudeCode:
+		// Either ClaudeCredsSecretRef or ApiKeySecretRef is required for authentication
+		hasOAuth := k.ClaudeCredsSecretRef != nil && k.ClaudeCredsSecretRef.Name != "" || k.ClaudeCredsCSI != nil
+		if !hasOAuth && !hasAPIKey {
+			return []string{"spec.kubernetes: either claudeCredsSecretRef or apiKeySecretRef is required"}
+		}
+	case AgentTypeOpenCode, AgentTypeAider:
+		// Local Ollama models need no API key
+		if !hasAPIKey && (cfg == nil || cfg.Provider != LLMProviderOllama) {
+			return []string{fmt.Sprintf(
+				"spec.agentConfig.modelProvider.apiKeySecretRef: is required for agent %q (or set spec.kubernetes.apiKeySecretRef)",
+				agent)}
+		}
+	case AgentTypeCustom:
+		if cfg == nil || len(cfg.Command) == 0 {
+			return []string{"spec.agentConfig.command: is required for the custom agent"}
+		}
+	}
+	return nil
+}
+
+// validateAgentProbes validates the agent liveness/startup probe settings.
+func validateAgentProbes(p *AgentProbeSpec) []string {
+	var errs []string
+
+	if p.HeartbeatFile != "" && !strings.HasPrefix(p.HeartbeatFile, "/") {
+		errs = append(errs, "spec.kubernetes.probes.heartbeatFile: must be an absolute path")
+	}
+
+	if p.HeartbeatMaxAgeSeconds < 0 {
+		errs = append(errs, "spec.kubernetes.probes.heartbeatMaxAgeSeconds: must be non-negative")
+	}
+
+	if p.StartupTimeoutSeconds < 0 {
+		errs = append(errs, "spec.kubernetes.probes.startupTimeoutSeconds: must be non-negative")
+	}
+
+	// The liveness probe only samples once per period, so the max age must cover it
+	if p.HeartbeatMaxAgeSeconds > 0 && p.PeriodSeconds > 0 && p.HeartbeatMaxAgeSeconds < p.PeriodSeconds {
+		errs = append(errs, "spec.kubernetes.probes.heartbeatMaxAgeSeconds: must be at least periodSeconds")
+	}
+
 	return errs
 }
 
@@ -209,6 +209,32 @@ func TestPolecatCustomValidator_ValidateCreate(t *testing.T) {
 			wantErr:     false,
 			wantWarning: true,
 		},
+		{
+			name: "merge dependency on itself",
+			polecat: &Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-polecat"},
+				Spec: PolecatSpec{
+					Rig:          "test-rig",
+					DesiredState: PolecatDesiredIdle,
+					MergeAfter:   []string{"other-polecat", "test-polecat"},
+				},
+			},
+			wantErr:     true,
+			errContains: "spec.mergeAfter: a polecat cannot depend on itself",
+		},
+		{
+			name: "sync interval too short",
+			polecat: &Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-polecat"},
+				Spec: PolecatSpec{
+					Rig:                 "test-rig",
+					DesiredState:        PolecatDesiredIdle,
+					SyncIntervalSeconds: int32Ptr(1),
+				},
+			},
+			wantErr:     true,
+			errContains: "spec.syncIntervalSeconds: must be between 5 and 3600 seconds, got 1",
+		},
 	}
 
 	for _, tt := range tests {
@@ -344,6 +370,75 @@ func TestPolecatCustomValidator_ValidateDelete(t *testing.T) {
 	warnings, err := validator.ValidateDelete(ctx, polecat)
 	require.NoError(t, err)
 	assert.Nil(t, warnings)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		phase       PolecatPhase
+		wantErr     bool
+	}{
+		{name: "protected and working", annotations: map[string]string{PolecatProtectAnnotation: "true"}, phase: PolecatPhaseWorking, wantErr: true},
+		{name: "protected and stuck", annotations: map[string]string{PolecatProtectAnnotation: "true"}, phase: PolecatPhaseStuck, wantErr: true},
+		{name: "protected but done", annotations: map[string]string{PolecatProtectAnnotation: "true"}, phase: PolecatPhaseDone},
+		{name: "protection disabled", annotations: map[string]string{PolecatProtectAnnotation: "false"}, phase: PolecatPhaseWorking},
+		{name: "unprotected", phase: PolecatPhaseWorking},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := polecat.DeepCopy()
+			p.Annotations = tt.annotations
+			p.Status.Phase = tt.phase
+			_, err := validator.ValidateDelete(ctx, p)
+			if tt.wantErr {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), "protected by the gastown.io/protect annotation")
+			} else {
+				require.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestPolecatCustomValidator_ValidateUpdate_Protected(t *testing.T) {
+	validator := &PolecatCustomValidator{}
+	ctx := context.Background()
+
+	oldPolecat := &Polecat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "test-polecat",
+			Annotations: map[string]string{PolecatProtectAnnotation: "true"},
+		},
+		Spec: PolecatSpec{
+			Rig:           "test-rig",
+			DesiredState:  PolecatDesiredWorking,
+			ExecutionMode: ExecutionModeKubernetes,
+			Kubernetes: &KubernetesSpec{
+				GitRepository:        "git@github.com:org/repo.git",
+				GitSecretRef:         SecretReference{Name: "git-secret"},
+				ClaudeCredsSecretRef: &SecretReference{Name: "claude-creds"},
+			},
+		},
+		Status: PolecatStatus{Phase: PolecatPhaseWorking},
+	}
+
+	terminated := oldPolecat.DeepCopy()
+	terminated.Spec.DesiredState = PolecatDesiredTerminated
+	_, err := validator.ValidateUpdate(ctx, oldPolecat, terminated)
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "remove it to terminate the polecat")
+
+	// Removing the annotation in the same update overrides the protection
+	overridden := terminated.DeepCopy()
+	delete(overridden.Annotations, PolecatProtectAnnotation)
+	_, err = validator.ValidateUpdate(ctx, oldPolecat, overridden)
+	require.NoError(t, err)
+
+	// Other updates to a protected polecat are allowed
+	relabeled := oldPolecat.DeepCopy()
+	relabeled.Labels = map[string]string{"team": "a"}
+	_, err = validator.ValidateUpdate(ctx, oldPolecat, relabeled)
+	require.NoError(t, err)
 }
 
 // Note: WrongType tests removed - generics enforce type safety at compile time
@@ -482,6 +577,8 @@ func TestValidateKubernetesSpec(t *testing.T) {
 	tests := []struct {
 		name        string
 		spec        *KubernetesSpec
+		agent       AgentType
+		agentConfig *AgentConfig
 		wantErrs    int
 		errContains []string
 	}{
@@ -524,11 +621,154 @@ func TestValidateKubernetesSpec(t *testing.T) {
 			wantErrs:    1,
 			errContains: []string{"spec.kubernetes.activeDeadlineSeconds: must be positive"},
 		},
+		{
+			name: "valid probes",
+			spec: &KubernetesSpec{
+				GitRepository:        "git@github.com:org/repo.git",
+				GitSecretRef:         SecretReference{Name: "git-secret"},
+				ClaudeCredsSecretRef: &SecretReference{Name: "claude-creds"},
+				Probes: &AgentProbeSpec{
+					HeartbeatFile:          "/tmp/hb",
+					HeartbeatMaxAgeSeconds: 300,
+					PeriodSeconds:          30,
+				},
+			},
+			wantErrs: 0,
+		},
+		{
+			name: "invalid probes",
+			spec: &KubernetesSpec{
+				GitRepository:        "git@github.com:org/repo.git",
+				GitSecretRef:         SecretReference{Name: "git-secret"},
+				ClaudeCredsSecretRef: &SecretReference{Name: "claude-creds"},
+				Probes: &AgentProbeSpec{
+					HeartbeatFile:          "tmp/hb",
+					HeartbeatMaxAgeSeconds: 10,
+					PeriodSeconds:          30,
+				},
+			},
+			wantErrs: 2,
+			errContains: []string{
+				"spec.kubernetes.probes.heartbeatFile: must be an absolute path",
+				"spec.kubernetes.probes.heartbeatMaxAgeSeconds: must be at least periodSeconds",
+			},
+		},
+		{
+			name: "opencode with model provider key",
+			spec: &KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitSecretRef:  SecretReference{Name: "git-secret"},
+			},
+			agent: AgentTypeOpenCode,
+			agentConfig: &AgentConfig{
+				ModelProvider: &ModelProviderConfig{APIKeySecretRef: &SecretKeyRef{Name: "llm", Key: "key"}},
+			},
+			wantErrs: 0,
+		},
+		{
+			name: "aider without api key",
+			spec: &KubernetesSpec{
+				GitRepository:        "git@github.com:org/repo.git",
+				GitSecretRef:         SecretReference{Name: "git-secret"},
+				ClaudeCredsSecretRef: &SecretReference{Name: "claude-creds"},
+			},
+			agent:       AgentTypeAider,
+			agentConfig: &AgentConfig{Provider: LLMProviderOpenAI},
+			wantErrs:    1,
+			errContains: []string{
+				`spec.agentConfig.modelProvider.apiKeySecretRef: is required for agent "aider" (or set spec.kubernetes.apiKeySecretRef)`,
+			},
+		},
+		{
+			name: "aider with ollama needs no api key",
+			spec: &KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitSecretRef:  SecretReference{Name: "git-secret"},
+			},
+			agent:       AgentTypeAider,
+			agentConfig: &AgentConfig{Provider: LLMProviderOllama},
+			wantErrs:    0,
+		},
+		{
+			name: "custom agent without command",
+			spec: &KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitSecretRef:  SecretReference{Name: "git-secret"},
+			},
+			agent:       AgentTypeCustom,
+			wantErrs:    1,
+			errContains: []string{"spec.agentConfig.command: is required for the custom agent"},
+		},
+		{
+			name: "valid additional repositories",
+			spec: &KubernetesSpec{
+				GitRepository:        "git@github.com:org/api.git",
+				GitSecretRef:         SecretReference{Name: "git-secret"},
+				ClaudeCredsSecretRef: &SecretReference{Name: "claude-creds"},
+				AdditionalRepositories: []AdditionalRepository{
+					{URL: "git@github.com:org/client.git", Path: "client"},
+					{URL: "git@gitlab.com:org/docs.git", Path: "docs", GitSecretRef: &SecretReference{Name: "docs"}},
+				},
+			},
+			wantErrs: 0,
+		},
+		{
+			name: "additional repository over the primary repository",
+			spec: &KubernetesSpec{
+				GitRepository:        "git@github.com:org/api.git",
+				GitSecretRef:         SecretReference{Name: "git-secret"},
+				ClaudeCredsSecretRef: &SecretReference{Name: "claude-creds"},
+				AdditionalRepositories: []AdditionalRepository{
+					{Path: "repo", GitSecretRef: &SecretReference{}},
+				},
+			},
+			wantErrs: 3,
+			errContains: []string{
+				"spec.kubernetes.additionalRepositories[0].url: is required",
+				`spec.kubernetes.additionalRepositories[0].path: "repo" is reserved for spec.kubernetes.gitRepository`,
+				"spec.kubernetes.additionalRepositories[0].gitSecretRef.name: is required",
+			},
+		},
+		{
+			name: "custom agent with command",
+			spec: &KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitSecretRef:  SecretReference{Name: "git-secret"},
+			},
+			agent:       AgentTypeCustom,
+			agentConfig: &AgentConfig{Command: []string{"/usr/local/bin/my-agent"}},
+			wantErrs:    0,
+		},
+		{
+			name: "credentials from CSI volumes",
+			spec: &KubernetesSpec{
+				GitRepository:  "git@github.com:org/repo.git",
+				GitCredsCSI:    &CSISecretVolume{VolumeAttributes: map[string]string{"secretProviderClass": "git-creds"}},
+				ClaudeCredsCSI: &CSISecretVolume{VolumeAttributes: map[string]string{"secretProviderClass": "claude-creds"}},
+			},
+			wantErrs: 0,
+		},
+		{
+			name: "credentials from both a Secret and a CSI volume",
+			spec: &KubernetesSpec{
+				GitRepository:        "git@github.com:org/repo.git",
+				GitSecretRef:         SecretReference{Name: "git-secret"},
+				GitCredsCSI:          &CSISecretVolume{VolumeAttributes: map[string]string{"secretProviderClass": "git-creds"}},
+				ClaudeCredsSecretRef: &SecretReference{Name: "claude-creds"},
+				ClaudeCredsCSI:       &CSISecretVolume{},
+			},
+			wantErrs: 3,
+			errContains: []string{
+				"spec.kubernetes: gitSecretRef and gitCredsCSI are mutually exclusive",
+				"spec.kubernetes: claudeCredsSecretRef and claudeCredsCSI are mutually exclusive",
+				"spec.kubernetes.claudeCredsCSI.volumeAttributes: is required",
+			},
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			errs := validateKubernetesSpec(tt.spec)
+			errs := validateKubernetesSpec(tt.spec, tt.agent, tt.agentConfig)
 			assert.Len(t, errs, tt.wantErrs)
 			for _, expected := range tt.errContains {
 				found := false
@@ -638,3 +878,7 @@ func TestValidateResources(t *testing.T) {
 func int64Ptr(i int64) *int64 {
 	return &i
 }
+
+func int32Ptr(i int32) *int32 {
+	return &i
+}
@@ -17,12 +17,89 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
+// MergeQueuePolicy controls the order in which the Refinery merges ready branches.
+// +kubebuilder:validation:Enum=fifo;priority;smallest-diff-first
+type MergeQueuePolicy string
+
+const (
+	// MergeQueuePolicyFIFO merges branches in the order their polecats finished.
+	MergeQueuePolicyFIFO MergeQueuePolicy = "fifo"
+
+	// MergeQueuePolicyPriority merges branches by polecat spec.mergePriority
+	// (highest first), falling back to FIFO order for equal priorities.
+	MergeQueuePolicyPriority MergeQueuePolicy = "priority"
+
+	// MergeQueuePolicySmallestDiffFirst merges the branches with the fewest
+	// changed lines first, falling back to FIFO order.
+	MergeQueuePolicySmallestDiffFirst MergeQueuePolicy = "smallest-diff-first"
+)
+
+// MergeStrategy controls how the Refinery lands polecat branches on the target branch.
+// +kubebuilder:validation:Enum=push;pullRequest
+type MergeStrategy string
+
+const (
+	// MergeStrategyPush rebases each branch, runs the test command and pushes
+	// directly to the target branch.
+	MergeStrategyPush MergeStrategy = "push"
+
+	// MergeStrategyPullRequest opens a GitHub pull request per branch and
+	// waits for it to be merged.
+	MergeStrategyPullRequest MergeStrategy = "pullRequest"
+)
+
+// MergeMethod is how the push strategy lands a rebased branch.
+// +kubebuilder:validation:Enum=rebase;squash;merge
+type MergeMethod string
+
+const (
+	// MergeMethodRebase fast-forwards the target branch to the rebased
+	// branch's commits.
+	MergeMethodRebase MergeMethod = "rebase"
+
+	// MergeMethodSquash lands the rebased branch as a single commit.
+	MergeMethodSquash MergeMethod = "squash"
+
+	// MergeMethodMerge lands the rebased branch with a merge commit.
+	MergeMethodMerge MergeMethod = "merge"
+)
+
+// GitProvider is the git hosting service the Refinery opens pull requests on.
+// +kubebuilder:validation:Enum=github;gitlab;bitbucket
+type GitProvider string
+
+const (
+	// GitProviderGitHub opens GitHub pull requests.
+	GitProviderGitHub GitProvider = "github"
+
+	// GitProviderGitLab opens GitLab merge requests (gitlab.com or self-hosted).
+	GitProviderGitLab GitProvider = "gitlab"
+
+	// GitProviderBitbucket opens Bitbucket Cloud pull requests.
+	GitProviderBitbucket GitProvider = "bitbucket"
+)
+
+// PullRequestMergeMethod is the merge method used for auto-merge.
+// +kubebuilder:validation:Enum=merge;squash;rebase
+type PullRequestMergeMethod string
+
+// Merge methods supported by auto-merge.
+const (
+	PullRequestMergeMethodMerge  PullRequestMergeMethod = "merge"
+	PullRequestMergeMethodSquash PullRequestMergeMethod = "squash"
+	PullRequestMergeMethodRebase PullRequestMergeMethod = "rebase"
+)
+
 // RefinerySpec defines the desired state of Refinery (Crucible in Olympian API).
 // A Refinery processes merge queues for a Rig, sequentially rebasing and merging
 // polecat branches after validation.
+// +kubebuilder:validation:XValidation:rule="!has(self.testImage) || has(self.testCommand)",message="testCommand is required when testImage is set"
+// +kubebuilder:validation:XValidation:rule="((!has(self.mergeStrategy) || self.mergeStrategy != 'pullRequest') && (!has(self.requiredChecks) || size(self.requiredChecks) == 0) && (!has(self.branchProtectionChecks) || !self.branchProtectionChecks)) || (has(self.provider) && self.provider == 'gitlab' ? has(self.gitlabTokenSecretRef) : has(self.provider) && self.provider == 'bitbucket' ? has(self.bitbucketTokenSecretRef) : has(self.githubTokenSecretRef))",message="the token secret for the selected provider is required when mergeStrategy is pullRequest, requiredChecks or branchProtectionChecks are set"
+// +kubebuilder:validation:XValidation:rule="!has(self.branchProtectionChecks) || !self.branchProtectionChecks || !has(self.provider) || self.provider == 'github'",message="branchProtectionChecks requires the github provider"
 type RefinerySpec struct {
 	// rigRef references the Rig (Forge) to process merges for.
 	// +kubebuilder:validation:Required
@@ -33,12 +110,39 @@ type RefinerySpec struct {
 	// +optional
 	TargetBranch string `json:"targetBranch,omitempty"`
 
+	// syncIntervalSeconds is how often an idle Refinery looks for branches to
+	// merge, and how often open pull requests and pending checks are polled.
+	// Defaults to the GastownConfig's requeue.default.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	SyncIntervalSeconds *int32 `json:"syncIntervalSeconds,omitempty"`
+
 	// testCommand is the command to run after rebase to validate the branch.
 	// If empty, no tests are run.
 	// +optional
 	TestCommand string `json:"testCommand,omitempty"`
 
+	// testImage runs testCommand in a short-lived Pod with this image instead
+	// of in the operator. The Refinery pushes the rebased branch, checks it
+	// out in the Pod and merges exactly that commit if testCommand exits 0.
+	// Applies to the push strategy.
+	// +optional
+	TestImage string `json:"testImage,omitempty"`
+
+	// testTimeout is how long a test Pod may take, including scheduling and
+	// checkout, before the merge fails.
+	// +kubebuilder:default="30m"
+	// +optional
+	TestTimeout *metav1.Duration `json:"testTimeout,omitempty"`
+
+	// testResources are the compute resources of the test Pod's container.
+	// +optional
+	TestResources *corev1.ResourceRequirements `json:"testResources,omitempty"`
+
 	// parallelism controls how many merges can be processed concurrently.
+	// Each lane merges in its own working directory; when another lane moves
+	// the target branch first, the lane rebases onto the new tip and retries.
 	// Default is 1 (sequential processing).
 	// +kubebuilder:default=1
 	// +kubebuilder:validation:Minimum=1
@@ -48,6 +152,260 @@ type RefinerySpec struct {
 	// gitSecretRef references the Secret containing git credentials.
 	// +optional
 	GitSecretRef *SecretReference `json:"gitSecretRef,omitempty"`
+
+	// maxCommitsBehind is how far the target branch may advance past a queued
+	// branch before the Refinery refreshes it: the branch is rebased onto the
+	// target, retested with testCommand and force-pushed, so it is never
+	// merged on the strength of tests run against an old target.
+	// Zero (the default) disables drift detection.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	MaxCommitsBehind int32 `json:"maxCommitsBehind,omitempty"`
+
+	// queuePolicy controls the order in which ready branches are merged.
+	// Dependencies declared with polecat spec.mergeAfter are always honored.
+	// +kubebuilder:default=fifo
+	// +optional
+	QueuePolicy MergeQueuePolicy `json:"queuePolicy,omitempty"`
+
+	// mergeStrategy controls how branches land on the target branch.
+	// With pullRequest, parallelism bounds the number of open pull requests
+	// and testCommand is not run (repository checks gate the merge instead).
+	// +kubebuilder:default=push
+	// +optional
+	MergeStrategy MergeStrategy `json:"mergeStrategy,omitempty"`
+
+	// mergeMethod is how the push strategy lands a branch once it is rebased
+	// and tested: rebase fast-forwards the target branch to its commits,
+	// squash lands them as a single commit and merge with a merge commit.
+	// The pullRequest strategy uses pullRequest.mergeMethod instead.
+	// +kubebuilder:default=rebase
+	// +optional
+	MergeMethod MergeMethod `json:"mergeMethod,omitempty"`
+
+	// commitMessageTemplate is the Go template of the message of squash and
+	// merge commits, rendered with .BeadID, .Title (the bead's title, else
+	// the first line of the task), .Polecat, .Branch and .TargetBranch.
+	// Defaults to "<bead>: <title>", i.e. {{.BeadID}}: {{.Title}}.
+	// +optional
+	CommitMessageTemplate string `json:"commitMessageTemplate,omitempty"`
+
+	// provider is the git hosting service used by the pullRequest strategy.
+	// +kubebuilder:default=github
+	// +optional
+	Provider GitProvider `json:"provider,omitempty"`
+
+	// githubTokenSecretRef references the Secret key holding a GitHub token
+	// with permission to open pull requests. Required for the pullRequest
+	// strategy with the github provider.
+	// +optional
+	GitHubTokenSecretRef *SecretKeyRef `json:"githubTokenSecretRef,omitempty"`
+
+	// gitlabTokenSecretRef references the Secret key holding a GitLab access
+	// token with the api scope. Required for the pullRequest strategy with
+	// the gitlab provider.
+	// +optional
+	GitLabTokenSecretRef *SecretKeyRef `json:"gitlabTokenSecretRef,omitempty"`
+
+	// bitbucketTokenSecretRef references the Secret key holding a Bitbucket
+	// access token, or "username:app-password". Required for the pullRequest
+	// strategy with the bitbucket provider.
+	// +optional
+	BitbucketTokenSecretRef *SecretKeyRef `json:"bitbucketTokenSecretRef,omitempty"`
+
+	// pullRequest configures the pullRequest merge strategy.
+	// +optional
+	PullRequest *PullRequestSpec `json:"pullRequest,omitempty"`
+
+	// requiredChecks are the names of provider check runs or commit statuses
+	// that must succeed on the rebased head before the push strategy merges
+	// it. The Refinery pushes the rebased branch, waits for the checks and
+	// merges exactly the checked commit. Uses the provider's token secret.
+	// +listType=set
+	// +optional
+	RequiredChecks []string `json:"requiredChecks,omitempty"`
+
+	// branchProtectionChecks also requires the status checks that the
+	// provider's protection of targetBranch requires (GitHub branch
+	// protection and rulesets), read before each merge, so branches the
+	// provider would refuse are not pushed. Like requiredChecks, the checks
+	// must pass on the rebased head. Uses the provider's token secret, which
+	// needs administration read permission for classic branch protection.
+	// +optional
+	BranchProtectionChecks bool `json:"branchProtectionChecks,omitempty"`
+
+	// requiredChecksTimeout is how long the Refinery waits for the required
+	// checks of a head before failing the merge.
+	// +kubebuilder:default="30m"
+	// +optional
+	RequiredChecksTimeout *metav1.Duration `json:"requiredChecksTimeout,omitempty"`
+
+	// autoResolveConflicts creates a Polecat to resolve the conflicts when a
+	// branch fails to rebase onto targetBranch. The new Polecat works on the
+	// conflicted branch and takes the original's place in the merge queue
+	// until it finishes. Applies to the push strategy.
+	// +optional
+	AutoResolveConflicts bool `json:"autoResolveConflicts,omitempty"`
+
+	// release configures an optional release step that tags the target branch
+	// after each merged batch (i.e., when the merge queue drains, or when the
+	// branches of a scheduled batch have merged).
+	// +optional
+	Release *ReleaseSpec `json:"release,omitempty"`
+
+	// batch accumulates merge-ready branches and lands them together when a
+	// schedule fires, instead of merging each as soon as it is ready, to
+	// reduce CI churn on the target branch. Branches wait in the queue for
+	// the first firing after they became ready and are then merged in queue
+	// order.
+	// +optional
+	Batch *MergeBatchSpec `json:"batch,omitempty"`
+
+	// ownership guards the target branch against other Refineries merging
+	// into it, e.g. of a staging and a production cluster pointed at the
+	// same repository. The Refinery holds a lease in a ref of the remote
+	// while it merges, and holds its queue while another Refinery's lease is
+	// valid. Only Refineries that set it honor the lease.
+	// +optional
+	Ownership *OwnershipSpec `json:"ownership,omitempty"`
+}
+
+// OwnershipSpec configures the lease a Refinery holds on its target branch.
+type OwnershipSpec struct {
+	// leaseDuration is how long the lease stays valid after the Refinery
+	// last renewed it, which it does whenever it merges. Another Refinery
+	// takes over once it expired.
+	// +kubebuilder:default="10m"
+	// +optional
+	LeaseDuration *metav1.Duration `json:"leaseDuration,omitempty"`
+}
+
+// MergeBatchSpec schedules the batches the Refinery merges in.
+type MergeBatchSpec struct {
+	// schedule is a 5-field cron expression (minute hour day-of-month month
+	// day-of-week) at which batches land, e.g. "*/30 * * * *" for every
+	// 30 minutes.
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=9
+	Schedule string `json:"schedule"`
+
+	// timeZone is the IANA time zone the schedule is evaluated in
+	// (e.g., "Europe/Berlin").
+	// +kubebuilder:default=UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// PullRequestSpec configures pull requests opened by the Refinery.
+type PullRequestSpec struct {
+	// autoMerge merges each pull request once its checks pass: GitHub
+	// auto-merge, GitLab "merge when pipeline succeeds", or a merge by the
+	// Refinery on Bitbucket (which has no auto-merge). Without it, pull
+	// requests wait for a human to merge them.
+	// +optional
+	AutoMerge bool `json:"autoMerge,omitempty"`
+
+	// mergeMethod is the merge method used by auto-merge.
+	// +kubebuilder:default=squash
+	// +optional
+	MergeMethod PullRequestMergeMethod `json:"mergeMethod,omitempty"`
+
+	// githubAPIURL overrides the GitHub API base URL (for GitHub Enterprise).
+	// +kubebuilder:default="https://api.github.com"
+	// +optional
+	GitHubAPIURL string `json:"githubAPIURL,omitempty"`
+
+	// gitlabAPIURL overrides the GitLab API base URL.
+	// Defaults to https://<rig git host>/api/v4.
+	// +optional
+	GitLabAPIURL string `json:"gitlabAPIURL,omitempty"`
+
+	// bitbucketAPIURL overrides the Bitbucket API base URL.
+	// +kubebuilder:default="https://api.bitbucket.org/2.0"
+	// +optional
+	BitbucketAPIURL string `json:"bitbucketAPIURL,omitempty"`
+}
+
+// VersionPolicy determines how the next release version is derived.
+// +kubebuilder:validation:Enum=patch;minor;major;calver
+type VersionPolicy string
+
+const (
+	// VersionPolicyPatch bumps the patch component of the latest semver tag.
+	VersionPolicyPatch VersionPolicy = "patch"
+
+	// VersionPolicyMinor bumps the minor component of the latest semver tag.
+	VersionPolicyMinor VersionPolicy = "minor"
+
+	// VersionPolicyMajor bumps the major component of the latest semver tag.
+	VersionPolicyMajor VersionPolicy = "major"
+
+	// VersionPolicyCalVer uses the merge date (YYYY.MM.DD), adding a counter
+	// suffix for additional releases on the same day.
+	VersionPolicyCalVer VersionPolicy = "calver"
+)
+
+// ReleaseSpec configures tagging releases after merged batches.
+// +kubebuilder:validation:XValidation:rule="!has(self.githubRelease) || !self.githubRelease || has(self.githubTokenSecretRef)",message="githubTokenSecretRef is required when githubRelease is true"
+type ReleaseSpec struct {
+	// versionPolicy determines how the next version is computed.
+	// +kubebuilder:default=patch
+	// +optional
+	VersionPolicy VersionPolicy `json:"versionPolicy,omitempty"`
+
+	// tagPrefix is prepended to the version to form the tag name.
+	// +kubebuilder:default="v"
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._/-]*$`
+	// +optional
+	TagPrefix string `json:"tagPrefix,omitempty"`
+
+	// githubRelease also creates a GitHub release for the tag.
+	// Requires githubTokenSecretRef.
+	// +optional
+	GitHubRelease bool `json:"githubRelease,omitempty"`
+
+	// githubTokenSecretRef references the Secret key holding a GitHub token
+	// with permission to create releases.
+	// +optional
+	GitHubTokenSecretRef *SecretKeyRef `json:"githubTokenSecretRef,omitempty"`
+
+	// githubAPIURL overrides the GitHub API base URL (for GitHub Enterprise).
+	// +kubebuilder:default="https://api.github.com"
+	// +optional
+	GitHubAPIURL string `json:"githubAPIURL,omitempty"`
+}
+
+// ReleaseStatus records a release cut by the Refinery.
+type ReleaseStatus struct {
+	// tag is the annotated tag that was pushed.
+	Tag string `json:"tag"`
+
+	// commit is the target branch commit that was tagged.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// url is the GitHub release URL, if a release was created.
+	// +optional
+	URL string `json:"url,omitempty"`
+
+	// time is when the release was cut.
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+}
+
+// BranchCleanupStatus records a run of the merged-branch cleanup.
+type BranchCleanupStatus struct {
+	// time is when the cleanup ran.
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// dryRun is true when branches were only reported, not deleted.
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+
+	// branches lists the branches deleted (or, in a dry run, due for deletion).
+	// +optional
+	Branches []string `json:"branches,omitempty"`
 }
 
 // SecretReference contains information to locate a secret.
@@ -69,9 +427,14 @@ type RefineryStatus struct {
 	QueueLength int32 `json:"queueLength"`
 
 	// currentMerge is the branch currently being processed.
+	// With parallelism > 1 this is the branch in the first lane; see activeMerges.
 	// +optional
 	CurrentMerge string `json:"currentMerge,omitempty"`
 
+	// activeMerges lists the merges in flight, one entry per busy lane.
+	// +optional
+	ActiveMerges []ActiveMerge `json:"activeMerges,omitempty"`
+
 	// lastMergeTime is the timestamp of the last successful merge.
 	// +optional
 	LastMergeTime *metav1.Time `json:"lastMergeTime,omitempty"`
@@ -80,6 +443,33 @@ type RefineryStatus struct {
 	// +optional
 	MergesSummary MergesSummary `json:"mergesSummary,omitempty"`
 
+	// mergeHistory lists the most recent merge attempts, oldest first.
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	MergeHistory []MergeRecord `json:"mergeHistory,omitempty"`
+
+	// lastRelease is the most recent release cut after a merged batch.
+	// +optional
+	LastRelease *ReleaseStatus `json:"lastRelease,omitempty"`
+
+	// lastBranchCleanup is the most recent run of the rig's branchCleanup policy.
+	// +optional
+	LastBranchCleanup *BranchCleanupStatus `json:"lastBranchCleanup,omitempty"`
+
+	// queue is the ordered merge queue. The first unblocked entry is merged next.
+	// +optional
+	Queue []MergeQueueEntry `json:"queue,omitempty"`
+
+	// nextBatchTime is when the next merge batch lands, when spec.batch is set.
+	// +optional
+	NextBatchTime *metav1.Time `json:"nextBatchTime,omitempty"`
+
+	// waitingOnChecks names the required checks still pending on the heads
+	// the Refinery waits to merge.
+	// +listType=set
+	// +optional
+	WaitingOnChecks []string `json:"waitingOnChecks,omitempty"`
+
 	// conditions represent the current state of the Refinery resource.
 	// +listType=map
 	// +listMapKey=type
@@ -87,6 +477,127 @@ type RefineryStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// MergeQueueEntry is a polecat branch waiting in the merge queue.
+type MergeQueueEntry struct {
+	// polecat is the name of the Polecat whose branch is queued.
+	Polecat string `json:"polecat"`
+
+	// branch is the polecat's work branch.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// priority is the polecat's spec.mergePriority.
+	// +optional
+	Priority int32 `json:"priority,omitempty"`
+
+	// readySince is when the polecat became ready for merge (FIFO key).
+	// +optional
+	ReadySince *metav1.Time `json:"readySince,omitempty"`
+
+	// diffSize is the number of changed lines against the target branch.
+	// Only measured for the smallest-diff-first policy.
+	// +optional
+	DiffSize *int32 `json:"diffSize,omitempty"`
+
+	// blockedBy lists mergeAfter dependencies that have not been merged yet.
+	// +optional
+	BlockedBy []string `json:"blockedBy,omitempty"`
+
+	// commitsBehind is the number of target branch commits the branch does
+	// not contain. Only measured when spec.maxCommitsBehind is set.
+	// +optional
+	CommitsBehind *int32 `json:"commitsBehind,omitempty"`
+
+	// lastRefreshTime is when the Refinery last rebased and retested the
+	// branch because it drifted too far behind the target branch.
+	// +optional
+	LastRefreshTime *metav1.Time `json:"lastRefreshTime,omitempty"`
+
+	// batchTime is when the batch the branch lands in is due, when
+	// spec.batch is set. The branch is not merged before.
+	// +optional
+	BatchTime *metav1.Time `json:"batchTime,omitempty"`
+}
+
+// ActiveMerge is a merge in flight in one of the Refinery's parallel lanes.
+type ActiveMerge struct {
+	// lane is the index of the merge lane (0 to parallelism-1).
+	Lane int32 `json:"lane"`
+
+	// polecat is the name of the Polecat being merged.
+	Polecat string `json:"polecat"`
+
+	// branch is the polecat's work branch.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// startedAt is when the lane picked up the merge.
+	// +optional
+	StartedAt *metav1.Time `json:"startedAt,omitempty"`
+
+	// checkedCommit is the rebased head pushed for spec.requiredChecks or
+	// the test Pod of spec.testImage; only this commit is merged.
+	// +optional
+	CheckedCommit string `json:"checkedCommit,omitempty"`
+
+	// checksStartedAt is when the Refinery started waiting for the required
+	// checks or the tests of checkedCommit.
+	// +optional
+	ChecksStartedAt *metav1.Time `json:"checksStartedAt,omitempty"`
+
+	// holder is the operator replica (its Pod name) that runs the merge.
+	// A lane held by another replica was interrupted by a leader change and
+	// is resumed by the new leader.
+	// +optional
+	Holder string `json:"holder,omitempty"`
+}
+
+// MergeResult is the outcome of a merge attempt.
+// +kubebuilder:validation:Enum=Succeeded;Failed
+type MergeResult string
+
+const (
+	// MergeResultSucceeded means the branch landed on the target branch.
+	MergeResultSucceeded MergeResult = "Succeeded"
+
+	// MergeResultFailed means the merge failed (conflicts, test failures).
+	MergeResultFailed MergeResult = "Failed"
+)
+
+// MergeRecord is one merge attempt in the Refinery's merge history.
+type MergeRecord struct {
+	// polecat is the name of the Polecat whose branch was merged.
+	Polecat string `json:"polecat"`
+
+	// branch is the polecat's work branch.
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// result is the outcome of the attempt.
+	Result MergeResult `json:"result"`
+
+	// commit is the commit the branch was merged as, when it succeeded.
+	// +optional
+	Commit string `json:"commit,omitempty"`
+
+	// method is the merge method the branch was merged with, when known.
+	// +optional
+	Method MergeMethod `json:"method,omitempty"`
+
+	// time is when the attempt finished.
+	// +optional
+	Time *metav1.Time `json:"time,omitempty"`
+
+	// duration is how long the attempt took, from the lane picking up the
+	// branch to the merge landing or failing.
+	// +optional
+	Duration *metav1.Duration `json:"duration,omitempty"`
+
+	// reason is why the attempt failed.
+	// +optional
+	Reason string `json:"reason,omitempty"`
+}
+
 // MergesSummary contains aggregate merge statistics.
 type MergesSummary struct {
 	// total is the total number of merges attempted.
@@ -104,15 +615,19 @@ type MergesSummary struct {
 
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=rf
 // +kubebuilder:printcolumn:name="Rig",type=string,JSONPath=`.spec.rigRef`
 // +kubebuilder:printcolumn:name="Phase",type=string,JSONPath=`.status.phase`
 // +kubebuilder:printcolumn:name="Queue",type=integer,JSONPath=`.status.queueLength`
+// +kubebuilder:printcolumn:name="Succeeded",type=integer,JSONPath=`.status.mergesSummary.succeeded`
+// +kubebuilder:printcolumn:name="Failed",type=integer,JSONPath=`.status.mergesSummary.failed`
 // +kubebuilder:printcolumn:name="Current",type=string,JSONPath=`.status.currentMerge`
+// +kubebuilder:printcolumn:name="Next Batch",type=string,JSONPath=`.status.nextBatchTime`,priority=1
 // +kubebuilder:printcolumn:name="Age",type=date,JSONPath=`.metadata.creationTimestamp`
 
 // Refinery is the Schema for the refineries API.
 // Also known as Crucible in the Olympian API naming convention.
-// A Refinery processes merge queues, sequentially rebasing and merging polecat branches.
+// A Refinery processes merge queues, rebasing and merging polecat branches in one or more lanes.
 type Refinery struct {
 	metav1.TypeMeta   `json:",inline"`
 	metav1.ObjectMeta `json:"metadata,omitempty"`
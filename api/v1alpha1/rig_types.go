@@ -17,6 +17,8 @@ limitations under the License.
 package v1alpha1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
@@ -34,6 +36,390 @@ type RigSpec struct {
 	// Settings for the rig
 	// +optional
 	Settings RigSettings `json:"settings,omitempty"`
+
+	// SyncIntervalSeconds is how often the rig's status is re-synced, e.g.
+	// shorter for busy rigs and longer for quiet ones. Defaults to the
+	// GastownConfig's requeue.default.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	SyncIntervalSeconds *int32 `json:"syncIntervalSeconds,omitempty"`
+
+	// ChildNamespace is the namespace the rig's Witness, Refinery and
+	// Polecats are placed in. Defaults to the operator-wide namespace
+	// (GASTOWN_NAMESPACE, gastown-system). Immutable.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ChildNamespace string `json:"childNamespace,omitempty"`
+
+	// CreateNamespace provisions ChildNamespace for the rig: the namespace is
+	// labeled with the rig, limited by a ResourceQuota and isolated by a
+	// NetworkPolicy, and is deleted with the rig. Requires childNamespace.
+	// +optional
+	CreateNamespace bool `json:"createNamespace,omitempty"`
+
+	// NamespaceQuota is the hard limits of the provisioned namespace's
+	// ResourceQuota (e.g., requests.cpu, limits.memory). "pods" defaults to
+	// settings.maxPolecats plus headroom for the rig's Jobs.
+	// +optional
+	NamespaceQuota corev1.ResourceList `json:"namespaceQuota,omitempty"`
+
+	// Suspend freezes the rig: new polecats do not start and the Refinery
+	// stops merging. Polecats already running finish their current work.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// LogArchive uploads the full agent log of failed polecats to an
+	// object storage bucket. The last few KB are always kept in the
+	// Polecat's status.lastLogs.
+	// +optional
+	LogArchive *LogArchiveSpec `json:"logArchive,omitempty"`
+
+	// BranchCleanup deletes polecat branches that stay on the remote after
+	// they merged (pull request merges, or merges that kept the source branch).
+	// Executed periodically by the rig's Refinery.
+	// +optional
+	BranchCleanup *BranchCleanupSpec `json:"branchCleanup,omitempty"`
+
+	// ExecutionWindows restricts when new polecats start, e.g. to off-peak
+	// hours when API rates and cluster capacity are cheap. A polecat created
+	// outside every window waits with a WaitingForWindow condition unless it
+	// is urgent. Empty allows polecats to start at any time.
+	// +optional
+	ExecutionWindows []ExecutionWindow `json:"executionWindows,omitempty"`
+
+	// Budget caps what the rig's agents spend, as measured from the token
+	// usage they report
+	// +optional
+	Budget *RigBudget `json:"budget,omitempty"`
+
+	// AgentServiceAccount provisions a ServiceAccount for the rig's agent
+	// Pods in the child namespace, e.g. for agents that deploy preview
+	// environments with kubectl. Polecats without
+	// spec.kubernetes.serviceAccountName run as it.
+	// +optional
+	AgentServiceAccount *AgentServiceAccountSpec `json:"agentServiceAccount,omitempty"`
+
+	// NetworkPolicy restricts the egress of the rig's Pods, those labeled
+	// gastown.io/rig=<rig> in the child namespace, to the destinations it
+	// allows
+	// +optional
+	NetworkPolicy *RigNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// ImagePullSecrets are used by the agent Pods of polecats without
+	// spec.kubernetes.imagePullSecrets. The Secrets must exist in the
+	// polecats' namespace.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// PolecatDefaults are the Pod settings of the rig's polecats that do not
+	// set their own in spec.kubernetes
+	// +optional
+	PolecatDefaults *PolecatDefaults `json:"polecatDefaults,omitempty"`
+
+	// Signing signs the commits of the rig's agents and the tags and
+	// rebased commits of its Refinery, which merges only branches whose
+	// commits carry a good signature of the key
+	// +optional
+	Signing *CommitSigningSpec `json:"signing,omitempty"`
+
+	// Repositories are further repositories of the rig, e.g. the frontend of
+	// a rig whose gitURL is the backend. Polecats whose task spans them list
+	// them in spec.repositories; the Refinery merges the polecat's branch in
+	// each of them.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Repositories []RigRepository `json:"repositories,omitempty"`
+
+	// WarmPool keeps agent Pods of the rig cloned and waiting for work in
+	// the child namespace. A polecat whose Pod would be built like them is
+	// assigned to one instead of starting a Pod; others, and every polecat
+	// while the pool is empty, start their own.
+	// +optional
+	WarmPool *WarmPoolSpec `json:"warmPool,omitempty"`
+}
+
+// WarmPoolSpec configures the warm Pods of a rig. They are built like the
+// Pod of a polecat that kubectl gt sling creates: the claude agent working
+// on the rig's gitURL, with the rig's polecatDefaults, imagePullSecrets,
+// signing key and agent ServiceAccount.
+type WarmPoolSpec struct {
+	// Size is the number of idle warm Pods kept. 0 keeps none.
+	// +kubebuilder:validation:Minimum=0
+	// +kubebuilder:validation:Maximum=20
+	Size int32 `json:"size"`
+
+	// GitBranch is the branch the warm Pods clone
+	// +kubebuilder:default=main
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._/-]+$`
+	// +optional
+	GitBranch string `json:"gitBranch,omitempty"`
+
+	// GitSecretRef references the Secret with the git credentials of the
+	// warm Pods. Defaults to git-creds, like kubectl gt sling.
+	// +optional
+	GitSecretRef *SecretReference `json:"gitSecretRef,omitempty"`
+
+	// ClaudeCredsSecretRef references the Secret with the ~/.claude/
+	// contents of the warm Pods. Defaults to claude-creds, like kubectl gt
+	// sling.
+	// +optional
+	ClaudeCredsSecretRef *SecretReference `json:"claudeCredsSecretRef,omitempty"`
+}
+
+// RigRepository is a repository of a rig besides its gitURL
+type RigRepository struct {
+	// Name identifies the repository and is the directory under /workspace
+	// it is cloned into. The repository of gitURL lives in "repo".
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9][a-zA-Z0-9._-]*$`
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:XValidation:rule="self != 'repo'",message="repo is the directory of the rig's gitURL"
+	Name string `json:"name"`
+
+	// GitURL is the remote repository URL (SSH or HTTPS format)
+	// +kubebuilder:validation:Pattern=`^(git@[a-zA-Z0-9._-]+:|https?://[a-zA-Z0-9._-]+/)[a-zA-Z0-9._/-]+(\.git)?$`
+	GitURL string `json:"gitURL"`
+
+	// Branch is the branch polecats start from and the Refinery merges into
+	// +kubebuilder:default=main
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._/-]+$`
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// GitSecretRef references a Secret containing the SSH key, or HTTPS
+	// token, of this repository. It must exist in the namespace of the rig's
+	// polecats and Refinery. Defaults to their own gitSecretRef.
+	// +optional
+	GitSecretRef *SecretReference `json:"gitSecretRef,omitempty"`
+}
+
+// PolecatDefaults are the agent Pod settings a rig's polecats inherit. Each
+// applies to polecats leaving the matching spec.kubernetes field unset.
+type PolecatDefaults struct {
+	// Image is the agent container image, unless the polecat's agentConfig
+	// sets one
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources are the resource requirements of the agent container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PriorityClassName is the PriorityClass of the agent Pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// NodeSelector constrains the agent Pods to matching nodes
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations let the agent Pods schedule onto tainted nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+}
+
+// CommitSigningFormat is the kind of key commits are signed with
+// +kubebuilder:validation:Enum=gpg;ssh
+type CommitSigningFormat string
+
+const (
+	// CommitSigningGPG signs with an OpenPGP key
+	CommitSigningGPG CommitSigningFormat = "gpg"
+
+	// CommitSigningSSH signs with an SSH key
+	CommitSigningSSH CommitSigningFormat = "ssh"
+)
+
+// CommitSigningSpec is the key a rig's commits are signed with
+type CommitSigningSpec struct {
+	// Format of the key: gpg for an ASCII-armored OpenPGP private key, ssh
+	// for an OpenSSH private key. Neither may have a passphrase.
+	// +kubebuilder:default=gpg
+	// +optional
+	Format CommitSigningFormat `json:"format,omitempty"`
+
+	// KeySecretRef references the Secret holding the private key under
+	// signing-key. It must exist in the namespace of the rig's polecats and
+	// Refinery.
+	KeySecretRef SecretReference `json:"keySecretRef"`
+}
+
+// AgentServiceAccountSpec is the ServiceAccount of a rig's agents and what it
+// may do in the rig's child namespace
+type AgentServiceAccountSpec struct {
+	// Rules of the Role granted to the agents in the child namespace. The
+	// operator must hold these permissions itself, or be allowed to
+	// escalate.
+	// +optional
+	Rules []rbacv1.PolicyRule `json:"rules,omitempty"`
+
+	// ClusterRole names an existing ClusterRole (e.g. "edit") granted to the
+	// agents in the child namespace only
+	// +optional
+	ClusterRole string `json:"clusterRole,omitempty"`
+}
+
+// RigNetworkPolicy is the egress a rig's agent Pods are allowed. DNS is
+// always allowed.
+type RigNetworkPolicy struct {
+	// Egress are the destinations the Pods may reach. Empty denies all
+	// egress but DNS.
+	// +kubebuilder:validation:MaxItems=32
+	// +optional
+	Egress []RigEgressRule `json:"egress,omitempty"`
+}
+
+// RigEgressRule allows egress to an IP block
+type RigEgressRule struct {
+	// CIDR is the IPv4 or IPv6 block, e.g. 140.82.112.0/20 for GitHub or
+	// 0.0.0.0/0 for anywhere. NetworkPolicies cannot match domain names;
+	// allow the addresses they resolve to.
+	CIDR string `json:"cidr"`
+
+	// Except are blocks within CIDR that stay denied, e.g. the cluster's
+	// pod and service networks or the cloud metadata endpoint
+	// +optional
+	Except []string `json:"except,omitempty"`
+
+	// Ports are the allowed TCP ports, e.g. 22 and 443. Empty allows all
+	// ports.
+	// +kubebuilder:validation:items:Minimum=1
+	// +kubebuilder:validation:items:Maximum=65535
+	// +optional
+	Ports []int32 `json:"ports,omitempty"`
+}
+
+// RigBudget caps the spend of a rig's agents
+type RigBudget struct {
+	// MaxUSDPerDay is the daily budget in USD (e.g. "50.00"). Once the
+	// polecats created on a UTC day have spent it, polecats that have not
+	// started yet are held with an OverBudget condition until the next day
+	// or until the budget is raised. Unset disables the cap.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]{1,2})?$`
+	// +optional
+	MaxUSDPerDay string `json:"maxUSDPerDay,omitempty"`
+}
+
+// ExecutionWindow is a period during which new polecats may start. It opens
+// each time Schedule fires and stays open for Duration.
+type ExecutionWindow struct {
+	// Schedule is a 5-field cron expression (minute hour day-of-month month
+	// day-of-week), e.g. "0 22 * * mon-fri" for weeknights at 22:00
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=9
+	Schedule string `json:"schedule"`
+
+	// Duration is how long the window stays open after it opens (at most 168h)
+	// +kubebuilder:validation:Required
+	Duration metav1.Duration `json:"duration"`
+
+	// TimeZone is the IANA time zone the schedule is evaluated in
+	// (e.g., "Europe/Berlin")
+	// +kubebuilder:default=UTC
+	// +optional
+	TimeZone string `json:"timeZone,omitempty"`
+}
+
+// BranchCleanupSpec is the retention policy for merged polecat branches.
+// A merged branch is deleted once any enabled rule matches.
+type BranchCleanupSpec struct {
+	// AfterDays deletes a branch this many days after it merged
+	// (0 deletes it on the next cleanup run). Unset disables the rule.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	AfterDays *int32 `json:"afterDays,omitempty"`
+
+	// AfterConvoyComplete deletes a branch once the convoy tracking the
+	// polecat's bead is complete
+	// +optional
+	AfterConvoyComplete bool `json:"afterConvoyComplete,omitempty"`
+
+	// DryRun reports the branches that would be deleted without deleting them
+	// +optional
+	DryRun bool `json:"dryRun,omitempty"`
+}
+
+// LogArchiveProvider is the object storage service logs are uploaded to
+// +kubebuilder:validation:Enum=s3;gcs
+type LogArchiveProvider string
+
+const (
+	// LogArchiveProviderS3 uploads to Amazon S3 or an S3-compatible store
+	LogArchiveProviderS3 LogArchiveProvider = "s3"
+	// LogArchiveProviderGCS uploads to Google Cloud Storage (XML API with HMAC keys)
+	LogArchiveProviderGCS LogArchiveProvider = "gcs"
+)
+
+// LogArchiveSpec configures where failed polecat logs are uploaded
+type LogArchiveSpec struct {
+	// Provider is the object storage service
+	// +kubebuilder:default=s3
+	// +optional
+	Provider LogArchiveProvider `json:"provider,omitempty"`
+
+	// Bucket is the bucket name
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=3
+	Bucket string `json:"bucket"`
+
+	// Prefix is prepended to object keys (e.g., "gastown/logs")
+	// +optional
+	Prefix string `json:"prefix,omitempty"`
+
+	// Region is the bucket region (S3 only)
+	// +kubebuilder:default=us-east-1
+	// +optional
+	Region string `json:"region,omitempty"`
+
+	// Endpoint overrides the service URL, e.g. for MinIO
+	// (defaults to https://s3.<region>.amazonaws.com or https://storage.googleapis.com)
+	// +optional
+	Endpoint string `json:"endpoint,omitempty"`
+
+	// CredentialsSecretRef references a Secret in the Polecat's namespace
+	// with "accessKeyID" and "secretAccessKey" (GCS: HMAC key) keys
+	// +kubebuilder:validation:Required
+	CredentialsSecretRef SecretReference `json:"credentialsSecretRef"`
+
+	// Redaction configures how secrets are masked in logs before they are
+	// archived or kept in the Polecat status. Common credentials and email
+	// addresses are masked unless the default rules are disabled.
+	// +optional
+	Redaction *LogRedactionSpec `json:"redaction,omitempty"`
+}
+
+// LogRedactionSpec configures the masking of secrets in agent logs
+type LogRedactionSpec struct {
+	// DisableDefaultRules turns off the built-in rules (API keys and tokens,
+	// private keys, URL credentials, secret assignments, email addresses)
+	// +optional
+	DisableDefaultRules bool `json:"disableDefaultRules,omitempty"`
+
+	// Rules are applied after the default rules, in order
+	// +kubebuilder:validation:MaxItems=50
+	// +optional
+	Rules []LogRedactionRule `json:"rules,omitempty"`
+}
+
+// LogRedactionRule masks the matches of a regular expression
+type LogRedactionRule struct {
+	// Name identifies the rule in the default replacement
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	Name string `json:"name"`
+
+	// Pattern is an RE2 regular expression (https://github.com/google/re2/wiki/Syntax)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:MinLength=1
+	Pattern string `json:"pattern"`
+
+	// Replacement replaces each match; $1 or ${name} expand capture groups.
+	// Defaults to [REDACTED:<name>].
+	// +optional
+	Replacement string `json:"replacement,omitempty"`
 }
 
 // RigSettings contains optional configuration for a rig
@@ -48,8 +434,41 @@ type RigSettings struct {
 	// +kubebuilder:default=8
 	// +optional
 	MaxPolecats int `json:"maxPolecats,omitempty"`
+
+	// MaxTaskCostUSD is the per-task budget in USD (e.g. "5.00"). Polecats
+	// whose estimated cost exceeds it are handled per OverBudgetAction.
+	// Unset disables the budget.
+	// +kubebuilder:validation:Pattern=`^[0-9]+(\.[0-9]{1,2})?$`
+	// +optional
+	MaxTaskCostUSD string `json:"maxTaskCostUSD,omitempty"`
+
+	// OverBudgetAction is what happens to polecats over MaxTaskCostUSD:
+	// Reject holds them until the budget is raised or the task is split,
+	// Flag only reports them
+	// +kubebuilder:default=Reject
+	// +optional
+	OverBudgetAction OverBudgetAction `json:"overBudgetAction,omitempty"`
+
+	// SuggestSplits spawns a planning polecat when a polecat runs out of its
+	// activeDeadlineSeconds or of MaxTaskCostUSD without finishing. It
+	// proposes splitting the bead into smaller beads, which are filed in the
+	// rig's BeadStore once approved.
+	// +optional
+	SuggestSplits bool `json:"suggestSplits,omitempty"`
 }
 
+// OverBudgetAction is what happens to polecats over their rig's task budget
+// +kubebuilder:validation:Enum=Reject;Flag
+type OverBudgetAction string
+
+const (
+	// OverBudgetReject holds the polecat before it starts
+	OverBudgetReject OverBudgetAction = "Reject"
+
+	// OverBudgetFlag starts the polecat and reports the overrun
+	OverBudgetFlag OverBudgetAction = "Flag"
+)
+
 // RigPhase represents the current lifecycle phase of a Rig
 // +kubebuilder:validation:Enum=Initializing;Ready;Degraded
 type RigPhase string
@@ -69,9 +488,22 @@ type RigStatus struct {
 	// PolecatCount is the current number of polecats in this rig
 	PolecatCount int `json:"polecatCount,omitempty"`
 
+	// Polecats breaks PolecatCount down by phase
+	// +optional
+	Polecats *RigPolecatCounts `json:"polecats,omitempty"`
+
 	// ActiveConvoys is the number of convoys currently in progress
 	ActiveConvoys int `json:"activeConvoys,omitempty"`
 
+	// Convoys summarizes the rig's pending and in-progress convoys, by name
+	// +kubebuilder:validation:MaxItems=20
+	// +optional
+	Convoys []RigConvoySummary `json:"convoys,omitempty"`
+
+	// LastMergeTime is the last successful merge of the rig's Refineries
+	// +optional
+	LastMergeTime *metav1.Time `json:"lastMergeTime,omitempty"`
+
 	// WitnessCreated indicates if the Witness CR has been auto-provisioned
 	// +optional
 	WitnessCreated bool `json:"witnessCreated,omitempty"`
@@ -81,10 +513,24 @@ type RigStatus struct {
 	RefineryCreated bool `json:"refineryCreated,omitempty"`
 
 	// ChildNamespace is the namespace where child resources (Witness, Refinery) are created
-	// Defaults to the operator namespace (gastown-system)
+	// (spec.childNamespace, or the operator namespace gastown-system)
 	// +optional
 	ChildNamespace string `json:"childNamespace,omitempty"`
 
+	// AgentServiceAccount is the ServiceAccount provisioned in ChildNamespace
+	// for spec.agentServiceAccount
+	// +optional
+	AgentServiceAccount string `json:"agentServiceAccount,omitempty"`
+
+	// WarmPods is the number of warm Pods of spec.warmPool waiting for a
+	// bead
+	// +optional
+	WarmPods int32 `json:"warmPods,omitempty"`
+
+	// Usage aggregates the token usage and spend of the rig's polecats
+	// +optional
+	Usage *RigUsage `json:"usage,omitempty"`
+
 	// Conditions represent the current state of the Rig resource
 	// +listType=map
 	// +listMapKey=type
@@ -92,12 +538,70 @@ type RigStatus struct {
 	Conditions []metav1.Condition `json:"conditions,omitempty"`
 }
 
+// MaxRigConvoySummaries caps RigStatus.Convoys.
+const MaxRigConvoySummaries = 20
+
+// RigPolecatCounts is the number of a rig's polecats in each phase.
+// Polecats without a phase yet are only counted in PolecatCount.
+type RigPolecatCounts struct {
+	Working    int `json:"working"`
+	Idle       int `json:"idle"`
+	Stuck      int `json:"stuck"`
+	Done       int `json:"done"`
+	Terminated int `json:"terminated"`
+}
+
+// RigConvoySummary is the progress of one of a rig's convoys.
+type RigConvoySummary struct {
+	// Name and Namespace identify the Convoy
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+
+	// Phase is the convoy's phase
+	Phase ConvoyPhase `json:"phase"`
+
+	// Progress is the convoy's progress (e.g., "2/3")
+	// +optional
+	Progress string `json:"progress,omitempty"`
+}
+
+// RigUsage is the token usage and spend of a rig's polecats. Polecats that
+// were deleted no longer count.
+type RigUsage struct {
+	// Day is the UTC date (YYYY-MM-DD) DayUSD covers
+	Day string `json:"day"`
+
+	// DayUSD is the spend of the polecats created on Day (e.g. "12.40")
+	DayUSD string `json:"dayUSD"`
+
+	// TotalUSD is the spend of all the rig's polecats
+	TotalUSD string `json:"totalUSD"`
+
+	// InputTokens is the input tokens used by all the rig's polecats,
+	// including tokens read from the prompt cache
+	// +optional
+	InputTokens int64 `json:"inputTokens,omitempty"`
+
+	// OutputTokens is the output tokens used by all the rig's polecats
+	// +optional
+	OutputTokens int64 `json:"outputTokens,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
+// +kubebuilder:storageversion
 // +kubebuilder:resource:scope=Cluster
 // +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspend"
 // +kubebuilder:printcolumn:name="Polecats",type="integer",JSONPath=".status.polecatCount"
+// +kubebuilder:printcolumn:name="Working",type="integer",JSONPath=".status.polecats.working"
+// +kubebuilder:printcolumn:name="Idle",type="integer",JSONPath=".status.polecats.idle",priority=1
+// +kubebuilder:printcolumn:name="Stuck",type="integer",JSONPath=".status.polecats.stuck"
+// +kubebuilder:printcolumn:name="Done",type="integer",JSONPath=".status.polecats.done",priority=1
+// +kubebuilder:printcolumn:name="Terminated",type="integer",JSONPath=".status.polecats.terminated",priority=1
 // +kubebuilder:printcolumn:name="Convoys",type="integer",JSONPath=".status.activeConvoys"
+// +kubebuilder:printcolumn:name="Last Merge",type="date",JSONPath=".status.lastMergeTime"
+// +kubebuilder:printcolumn:name="Spent Today",type="string",JSONPath=".status.usage.dayUSD",priority=1
 // +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
 
 // Rig is the Schema for the rigs API.
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"context"
 	"fmt"
+	"net"
 	"net/url"
 	"regexp"
 	"strings"
@@ -26,6 +27,9 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/org/gastown-operator/pkg/schedule"
+	"github.com/org/gastown-operator/pkg/webhookmetrics"
 )
 
 // log is for logging in this package.
@@ -34,8 +38,8 @@ var riglog = logf.Log.WithName("rig-resource")
 // SetupRigWebhookWithManager registers the Rig webhooks with the manager.
 func SetupRigWebhookWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewWebhookManagedBy(mgr, &Rig{}).
-		WithValidator(&RigCustomValidator{}).
-		WithDefaulter(&RigCustomDefaulter{}).
+		WithValidator(webhookmetrics.Validator[*Rig]("rig", &RigCustomValidator{})).
+		WithDefaulter(webhookmetrics.Defaulter[*Rig]("rig", &RigCustomDefaulter{})).
 		Complete()
 }
 
@@ -86,6 +90,10 @@ func (v *RigCustomValidator) ValidateUpdate(ctx context.Context, oldRig, rig *Ri
 		return nil, fmt.Errorf("spec.beadsPrefix is immutable: cannot change from %q to %q",
 			oldRig.Spec.BeadsPrefix, rig.Spec.BeadsPrefix)
 	}
+	if oldRig.Spec.ChildNamespace != rig.Spec.ChildNamespace {
+		return nil, fmt.Errorf("spec.childNamespace is immutable: cannot change from %q to %q",
+			oldRig.Spec.ChildNamespace, rig.Spec.ChildNamespace)
+	}
 
 	return v.validateRig(rig)
 }
@@ -124,6 +132,35 @@ func (v *RigCustomValidator) validateRig(rig *Rig) (admission.Warnings, error) {
 		}
 	}
 
+	if err := validateSyncInterval(rig.Spec.SyncIntervalSeconds); err != nil {
+		allErrs = append(allErrs, fmt.Sprintf("spec.syncIntervalSeconds: %v", err))
+	}
+
+	if rig.Spec.CreateNamespace && rig.Spec.ChildNamespace == "" {
+		allErrs = append(allErrs, "spec.createNamespace: requires spec.childNamespace")
+	}
+	if len(rig.Spec.NamespaceQuota) > 0 && !rig.Spec.CreateNamespace {
+		warnings = append(warnings, "spec.namespaceQuota is ignored unless spec.createNamespace is set")
+	}
+
+	if policy := rig.Spec.NetworkPolicy; policy != nil {
+		allErrs = append(allErrs, validateEgressRules(policy.Egress)...)
+	}
+
+	for i, w := range rig.Spec.ExecutionWindows {
+		if _, err := schedule.NewWindow(w.Schedule, w.Duration.Duration, w.TimeZone); err != nil {
+			allErrs = append(allErrs, fmt.Sprintf("spec.executionWindows[%d]: %v", i, err))
+		}
+	}
+
+	if archive := rig.Spec.LogArchive; archive != nil && archive.Redaction != nil {
+		for i, rule := range archive.Redaction.Rules {
+			if _, err := regexp.Compile(rule.Pattern); err != nil {
+				allErrs = append(allErrs, fmt.Sprintf("spec.logArchive.redaction.rules[%d].pattern: %v", i, err))
+			}
+		}
+	}
+
 	if len(allErrs) > 0 {
 		return warnings, fmt.Errorf("validation failed: %s", strings.Join(allErrs, "; "))
 	}
@@ -131,6 +168,33 @@ func (v *RigCustomValidator) validateRig(rig *Rig) (admission.Warnings, error) {
 	return warnings, nil
 }
 
+// validateEgressRules checks the IP blocks and ports of a rig's network
+// policy.
+func validateEgressRules(rules []RigEgressRule) []string {
+	var errs []string
+	for i, rule := range rules {
+		field := fmt.Sprintf("spec.networkPolicy.egress[%d]", i)
+		_, block, err := net.ParseCIDR(rule.CIDR)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s.cidr: %q is not a CIDR", field, rule.CIDR))
+		}
+		for j, except := range rule.Except {
+			_, excluded, err := net.ParseCIDR(except)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s.except[%d]: %q is not a CIDR", field, j, except))
+			} else if block != nil && !block.Contains(excluded.IP) {
+				errs = append(errs, fmt.Sprintf("%s.except[%d]: %s is not within %s", field, j, except, rule.CIDR))
+			}
+		}
+		for j, port := range rule.Ports {
+			if port < 1 || port > 65535 {
+				errs = append(errs, fmt.Sprintf("%s.ports[%d]: %d is not a port", field, j, port))
+			}
+		}
+	}
+	return errs
+}
+
 // validateGitURL checks that the Git URL is valid.
 func validateGitURL(gitURL string) error {
 	if gitURL == "" {
@@ -191,6 +255,25 @@ func validateBeadsPrefix(prefix string) error {
 	return nil
 }
 
+// Bounds of spec.syncIntervalSeconds of Polecats, Rigs and Refineries
+const (
+	minSyncIntervalSeconds = 5
+	maxSyncIntervalSeconds = 3600
+)
+
+// validateSyncInterval checks that a sync interval, if set, is neither so
+// short that it floods the API server nor so long that status goes stale.
+func validateSyncInterval(seconds *int32) error {
+	if seconds == nil {
+		return nil
+	}
+	if *seconds < minSyncIntervalSeconds || *seconds > maxSyncIntervalSeconds {
+		return fmt.Errorf("must be between %d and %d seconds, got %d",
+			minSyncIntervalSeconds, maxSyncIntervalSeconds, *seconds)
+	}
+	return nil
+}
+
 // validateNamepoolTheme checks that the namepool theme is valid.
 func validateNamepoolTheme(theme string) error {
 	validThemes := map[string]bool{
@@ -19,6 +19,7 @@ package v1alpha1
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -226,6 +227,26 @@ func TestValidateNamepoolTheme(t *testing.T) {
 	}
 }
 
+func TestValidateSyncInterval(t *testing.T) {
+	for _, tc := range []struct {
+		seconds *int32
+		wantErr bool
+	}{
+		{seconds: nil},
+		{seconds: int32Ptr(5)},
+		{seconds: int32Ptr(3600)},
+		{seconds: int32Ptr(4), wantErr: true},
+		{seconds: int32Ptr(3601), wantErr: true},
+	} {
+		err := validateSyncInterval(tc.seconds)
+		if tc.wantErr {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+}
+
 func TestRigCustomValidator_ValidateCreate(t *testing.T) {
 	validator := &RigCustomValidator{}
 	ctx := context.Background()
@@ -298,6 +319,120 @@ func TestRigCustomValidator_ValidateCreate(t *testing.T) {
 			},
 			wantErr: true,
 		},
+		{
+			name: "createNamespace without childNamespace",
+			rig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:          "git@github.com:org/repo.git",
+					BeadsPrefix:     "test",
+					CreateNamespace: true,
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid network policy",
+			rig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:      "git@github.com:org/repo.git",
+					BeadsPrefix: "test",
+					NetworkPolicy: &RigNetworkPolicy{Egress: []RigEgressRule{
+						{CIDR: "0.0.0.0/0", Except: []string{"10.0.0.0/8"}, Ports: []int32{443}},
+						{CIDR: "2001:db8::/32"},
+					}},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "network policy with an exception outside its block",
+			rig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:      "git@github.com:org/repo.git",
+					BeadsPrefix: "test",
+					NetworkPolicy: &RigNetworkPolicy{Egress: []RigEgressRule{
+						{CIDR: "140.82.112.0/20", Except: []string{"10.0.0.0/8"}},
+					}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "network policy with a domain instead of a CIDR",
+			rig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:        "git@github.com:org/repo.git",
+					BeadsPrefix:   "test",
+					NetworkPolicy: &RigNetworkPolicy{Egress: []RigEgressRule{{CIDR: "github.com"}}},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "valid execution windows",
+			rig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:      "git@github.com:org/repo.git",
+					BeadsPrefix: "test",
+					ExecutionWindows: []ExecutionWindow{
+						{Schedule: "0 22 * * mon-fri", Duration: metav1.Duration{Duration: 8 * time.Hour}, TimeZone: "Europe/Berlin"},
+						{Schedule: "0 0 * * sat", Duration: metav1.Duration{Duration: 48 * time.Hour}},
+					},
+				},
+			},
+			wantErr: false,
+		},
+		{
+			name: "invalid execution window schedule",
+			rig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:      "git@github.com:org/repo.git",
+					BeadsPrefix: "test",
+					ExecutionWindows: []ExecutionWindow{
+						{Schedule: "0 25 * * *", Duration: metav1.Duration{Duration: time.Hour}},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid execution window time zone",
+			rig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:      "git@github.com:org/repo.git",
+					BeadsPrefix: "test",
+					ExecutionWindows: []ExecutionWindow{
+						{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: time.Hour}, TimeZone: "Nowhere/Town"},
+					},
+				},
+			},
+			wantErr: true,
+		},
+		{
+			name: "invalid log redaction pattern",
+			rig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:      "git@github.com:org/repo.git",
+					BeadsPrefix: "test",
+					LogArchive: &LogArchiveSpec{
+						Bucket:               "logs",
+						CredentialsSecretRef: SecretReference{Name: "archive-creds"},
+						Redaction: &LogRedactionSpec{
+							Rules: []LogRedactionRule{{Name: "customer-id", Pattern: "cust_[0-9+"}},
+						},
+					},
+				},
+			},
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -356,6 +491,19 @@ func TestRigCustomValidator_ValidateUpdate(t *testing.T) {
 			wantErr: true,
 			errMsg:  "spec.beadsPrefix is immutable",
 		},
+		{
+			name: "invalid update - set child namespace (immutable)",
+			newRig: &Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "test-rig"},
+				Spec: RigSpec{
+					GitURL:         "git@github.com:org/repo.git",
+					BeadsPrefix:    "test",
+					ChildNamespace: "rig-test",
+				},
+			},
+			wantErr: true,
+			errMsg:  "spec.childNamespace is immutable",
+		},
 	}
 
 	for _, tt := range tests {
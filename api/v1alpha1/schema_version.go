@@ -0,0 +1,44 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha1
+
+import (
+	"strconv"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// SchemaVersion is the version of the Gas Town resource schemas this
+// operator reads and writes. It is bumped by releases adding fields that an
+// older operator would drop when it rewrites an object, so that operators of
+// different versions running side by side during a staged upgrade (or after
+// a rollback) can tell which objects they can safely process.
+const SchemaVersion = 1
+
+// SchemaVersionAnnotation records the highest SchemaVersion of the operators
+// that processed a resource.
+const SchemaVersionAnnotation = "gastown.io/schema-version"
+
+// ObjectSchemaVersion returns the schema version recorded on obj, or 0 if it
+// has none or it is not a number.
+func ObjectSchemaVersion(obj metav1.Object) int {
+	version, err := strconv.Atoi(obj.GetAnnotations()[SchemaVersionAnnotation])
+	if err != nil || version < 0 {
+		return 0
+	}
+	return version
+}
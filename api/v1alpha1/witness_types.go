@@ -41,6 +41,21 @@ type WitnessSpec struct {
 	// +kubebuilder:default="mayor"
 	// +optional
 	EscalationTarget string `json:"escalationTarget,omitempty"`
+
+	// autoReport makes the Witness give up on polecats that stay failed or
+	// stalled, filing a follow-up bead for each so the work re-enters the
+	// backlog with context. Disabled when unset.
+	// +optional
+	AutoReport *WitnessAutoReport `json:"autoReport,omitempty"`
+}
+
+// WitnessAutoReport configures follow-up beads for polecats the Witness gives up on.
+type WitnessAutoReport struct {
+	// giveUpAfter is how long a polecat may stay failed or stalled before the
+	// Witness gives up on it.
+	// +kubebuilder:default="1h"
+	// +optional
+	GiveUpAfter *metav1.Duration `json:"giveUpAfter,omitempty"`
 }
 
 // WitnessStatus defines the observed state of Witness.
@@ -58,6 +73,14 @@ type WitnessStatus struct {
 	// +optional
 	PolecatsSummary PolecatsSummary `json:"polecatsSummary,omitempty"`
 
+	// stalledPolecats lists the running polecats without agent activity for
+	// longer than spec.stuckThreshold. The Witness marks them with the
+	// Stalled condition and their phase becomes Stuck.
+	// +listType=map
+	// +listMapKey=name
+	// +optional
+	StalledPolecats []StalledPolecat `json:"stalledPolecats,omitempty"`
+
 	// conditions represent the current state of the Witness resource.
 	// +listType=map
 	// +listMapKey=type
@@ -83,6 +106,16 @@ type PolecatsSummary struct {
 	Stuck int32 `json:"stuck"`
 }
 
+// StalledPolecat is a polecat the Witness found without agent activity.
+type StalledPolecat struct {
+	// name is the name of the Polecat.
+	Name string `json:"name"`
+
+	// lastActivity is when the polecat last showed activity.
+	// +optional
+	LastActivity *metav1.Time `json:"lastActivity,omitempty"`
+}
+
 // +kubebuilder:object:root=true
 // +kubebuilder:subresource:status
 // +kubebuilder:printcolumn:name="Rig",type=string,JSONPath=`.spec.rigRef`
@@ -22,10 +22,54 @@ package v1alpha1
 
 import (
 	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ActiveMerge) DeepCopyInto(out *ActiveMerge) {
+	*out = *in
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.ChecksStartedAt != nil {
+		in, out := &in.ChecksStartedAt, &out.ChecksStartedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ActiveMerge.
+func (in *ActiveMerge) DeepCopy() *ActiveMerge {
+	if in == nil {
+		return nil
+	}
+	out := new(ActiveMerge)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AdditionalRepository) DeepCopyInto(out *AdditionalRepository) {
+	*out = *in
+	if in.GitSecretRef != nil {
+		in, out := &in.GitSecretRef, &out.GitSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AdditionalRepository.
+func (in *AdditionalRepository) DeepCopy() *AdditionalRepository {
+	if in == nil {
+		return nil
+	}
+	out := new(AdditionalRepository)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *AgentConfig) DeepCopyInto(out *AgentConfig) {
 	*out = *in
@@ -68,6 +112,112 @@ func (in *AgentConfig) DeepCopy() *AgentConfig {
 	return out
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentProbeSpec) DeepCopyInto(out *AgentProbeSpec) {
+	*out = *in
+	if in.LivenessProbe != nil {
+		in, out := &in.LivenessProbe, &out.LivenessProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.StartupProbe != nil {
+		in, out := &in.StartupProbe, &out.StartupProbe
+		*out = new(corev1.Probe)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentProbeSpec.
+func (in *AgentProbeSpec) DeepCopy() *AgentProbeSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentProbeSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AgentServiceAccountSpec) DeepCopyInto(out *AgentServiceAccountSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]rbacv1.PolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AgentServiceAccountSpec.
+func (in *AgentServiceAccountSpec) DeepCopy() *AgentServiceAccountSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AgentServiceAccountSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeadConflict) DeepCopyInto(out *BeadConflict) {
+	*out = *in
+	if in.DetectedAt != nil {
+		in, out := &in.DetectedAt, &out.DetectedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeadConflict.
+func (in *BeadConflict) DeepCopy() *BeadConflict {
+	if in == nil {
+		return nil
+	}
+	out := new(BeadConflict)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeadDependency) DeepCopyInto(out *BeadDependency) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeadDependency.
+func (in *BeadDependency) DeepCopy() *BeadDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(BeadDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BeadOutcome) DeepCopyInto(out *BeadOutcome) {
+	*out = *in
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeadOutcome.
+func (in *BeadOutcome) DeepCopy() *BeadOutcome {
+	if in == nil {
+		return nil
+	}
+	out := new(BeadOutcome)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *BeadStore) DeepCopyInto(out *BeadStore) {
 	*out = *in
@@ -140,6 +290,16 @@ func (in *BeadStoreSpec) DeepCopyInto(out *BeadStoreSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.GitHub != nil {
+		in, out := &in.GitHub, &out.GitHub
+		*out = new(GitHubBeadSource)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Jira != nil {
+		in, out := &in.Jira, &out.Jira
+		*out = new(JiraBeadSource)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeadStoreSpec.
@@ -159,6 +319,20 @@ func (in *BeadStoreStatus) DeepCopyInto(out *BeadStoreStatus) {
 		in, out := &in.LastSyncTime, &out.LastSyncTime
 		*out = (*in).DeepCopy()
 	}
+	if in.Beads != nil {
+		in, out := &in.Beads, &out.Beads
+		*out = make([]BeadSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Conflicts != nil {
+		in, out := &in.Conflicts, &out.Conflicts
+		*out = make([]BeadConflict, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -179,283 +353,438 @@ func (in *BeadStoreStatus) DeepCopy() *BeadStoreStatus {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Convoy) DeepCopyInto(out *Convoy) {
+func (in *BeadSummary) DeepCopyInto(out *BeadSummary) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.Priority != nil {
+		in, out := &in.Priority, &out.Priority
+		*out = new(int32)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Convoy.
-func (in *Convoy) DeepCopy() *Convoy {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BeadSummary.
+func (in *BeadSummary) DeepCopy() *BeadSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(Convoy)
+	out := new(BeadSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Convoy) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConvoyList) DeepCopyInto(out *ConvoyList) {
+func (in *BranchCleanupSpec) DeepCopyInto(out *BranchCleanupSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Convoy, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.AfterDays != nil {
+		in, out := &in.AfterDays, &out.AfterDays
+		*out = new(int32)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvoyList.
-func (in *ConvoyList) DeepCopy() *ConvoyList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchCleanupSpec.
+func (in *BranchCleanupSpec) DeepCopy() *BranchCleanupSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(ConvoyList)
+	out := new(BranchCleanupSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *ConvoyList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConvoySpec) DeepCopyInto(out *ConvoySpec) {
+func (in *BranchCleanupStatus) DeepCopyInto(out *BranchCleanupStatus) {
 	*out = *in
-	if in.TrackedBeads != nil {
-		in, out := &in.TrackedBeads, &out.TrackedBeads
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.Branches != nil {
+		in, out := &in.Branches, &out.Branches
 		*out = make([]string, len(*in))
 		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvoySpec.
-func (in *ConvoySpec) DeepCopy() *ConvoySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BranchCleanupStatus.
+func (in *BranchCleanupStatus) DeepCopy() *BranchCleanupStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(ConvoySpec)
+	out := new(BranchCleanupStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ConvoyStatus) DeepCopyInto(out *ConvoyStatus) {
+func (in *CSISecretVolume) DeepCopyInto(out *CSISecretVolume) {
 	*out = *in
-	if in.CompletedBeads != nil {
-		in, out := &in.CompletedBeads, &out.CompletedBeads
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.PendingBeads != nil {
-		in, out := &in.PendingBeads, &out.PendingBeads
-		*out = make([]string, len(*in))
-		copy(*out, *in)
-	}
-	if in.StartedAt != nil {
-		in, out := &in.StartedAt, &out.StartedAt
-		*out = (*in).DeepCopy()
-	}
-	if in.CompletedAt != nil {
-		in, out := &in.CompletedAt, &out.CompletedAt
-		*out = (*in).DeepCopy()
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
+	if in.VolumeAttributes != nil {
+		in, out := &in.VolumeAttributes, &out.VolumeAttributes
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
 		}
 	}
+	if in.NodePublishSecretRef != nil {
+		in, out := &in.NodePublishSecretRef, &out.NodePublishSecretRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvoyStatus.
-func (in *ConvoyStatus) DeepCopy() *ConvoyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CSISecretVolume.
+func (in *CSISecretVolume) DeepCopy() *CSISecretVolume {
 	if in == nil {
 		return nil
 	}
-	out := new(ConvoyStatus)
+	out := new(CSISecretVolume)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
+func (in *CheckpointSpec) DeepCopyInto(out *CheckpointSpec) {
 	*out = *in
-	out.GitSecretRef = in.GitSecretRef
-	if in.ClaudeCredsSecretRef != nil {
-		in, out := &in.ClaudeCredsSecretRef, &out.ClaudeCredsSecretRef
-		*out = new(SecretReference)
-		**out = **in
-	}
-	if in.ApiKeySecretRef != nil {
-		in, out := &in.ApiKeySecretRef, &out.ApiKeySecretRef
-		*out = new(SecretKeyRef)
-		**out = **in
-	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.ActiveDeadlineSeconds != nil {
-		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
-		*out = new(int64)
-		**out = **in
-	}
-	if in.SSHKnownHostsConfigMapRef != nil {
-		in, out := &in.SSHKnownHostsConfigMapRef, &out.SSHKnownHostsConfigMapRef
-		*out = new(corev1.LocalObjectReference)
-		**out = **in
-	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
-func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CheckpointSpec.
+func (in *CheckpointSpec) DeepCopy() *CheckpointSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(KubernetesSpec)
+	out := new(CheckpointSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MergesSummary) DeepCopyInto(out *MergesSummary) {
+func (in *CommitSigningSpec) DeepCopyInto(out *CommitSigningSpec) {
 	*out = *in
+	out.KeySecretRef = in.KeySecretRef
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergesSummary.
-func (in *MergesSummary) DeepCopy() *MergesSummary {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CommitSigningSpec.
+func (in *CommitSigningSpec) DeepCopy() *CommitSigningSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(MergesSummary)
+	out := new(CommitSigningSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *ModelProviderConfig) DeepCopyInto(out *ModelProviderConfig) {
+func (in *CompletionActionResult) DeepCopyInto(out *CompletionActionResult) {
 	*out = *in
-	if in.APIKeySecretRef != nil {
-		in, out := &in.APIKeySecretRef, &out.APIKeySecretRef
-		*out = new(SecretKeyRef)
-		**out = **in
+	if in.LastAttemptTime != nil {
+		in, out := &in.LastAttemptTime, &out.LastAttemptTime
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProviderConfig.
-func (in *ModelProviderConfig) DeepCopy() *ModelProviderConfig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CompletionActionResult.
+func (in *CompletionActionResult) DeepCopy() *CompletionActionResult {
 	if in == nil {
 		return nil
 	}
-	out := new(ModelProviderConfig)
+	out := new(CompletionActionResult)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Polecat) DeepCopyInto(out *Polecat) {
+func (in *ControllerTuning) DeepCopyInto(out *ControllerTuning) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
+	if in.RateLimiterBaseDelay != nil {
+		in, out := &in.RateLimiterBaseDelay, &out.RateLimiterBaseDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.RateLimiterMaxDelay != nil {
+		in, out := &in.RateLimiterMaxDelay, &out.RateLimiterMaxDelay
+		*out = new(v1.Duration)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Polecat.
-func (in *Polecat) DeepCopy() *Polecat {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ControllerTuning.
+func (in *ControllerTuning) DeepCopy() *ControllerTuning {
 	if in == nil {
 		return nil
 	}
-	out := new(Polecat)
+	out := new(ControllerTuning)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Polecat) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Convoy) DeepCopyInto(out *Convoy) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Convoy.
+func (in *Convoy) DeepCopy() *Convoy {
+	if in == nil {
+		return nil
+	}
+	out := new(Convoy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Convoy) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
 	}
 	return nil
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolecatCustomDefaulter) DeepCopyInto(out *PolecatCustomDefaulter) {
+func (in *ConvoyCompletionAction) DeepCopyInto(out *ConvoyCompletionAction) {
 	*out = *in
+	if in.FollowUpConvoy != nil {
+		in, out := &in.FollowUpConvoy, &out.FollowUpConvoy
+		*out = new(FollowUpConvoyAction)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Tag != nil {
+		in, out := &in.Tag, &out.Tag
+		*out = new(TagAction)
+		**out = **in
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(WebhookAction)
+		**out = **in
+	}
+	if in.SummaryBead != nil {
+		in, out := &in.SummaryBead, &out.SummaryBead
+		*out = new(SummaryBeadAction)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatCustomDefaulter.
-func (in *PolecatCustomDefaulter) DeepCopy() *PolecatCustomDefaulter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvoyCompletionAction.
+func (in *ConvoyCompletionAction) DeepCopy() *ConvoyCompletionAction {
 	if in == nil {
 		return nil
 	}
-	out := new(PolecatCustomDefaulter)
+	out := new(ConvoyCompletionAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolecatCustomValidator) DeepCopyInto(out *PolecatCustomValidator) {
+func (in *ConvoyList) DeepCopyInto(out *ConvoyList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Convoy, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatCustomValidator.
-func (in *PolecatCustomValidator) DeepCopy() *PolecatCustomValidator {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvoyList.
+func (in *ConvoyList) DeepCopy() *ConvoyList {
 	if in == nil {
 		return nil
 	}
-	out := new(PolecatCustomValidator)
+	out := new(ConvoyList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ConvoyList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolecatList) DeepCopyInto(out *PolecatList) {
+func (in *ConvoySpec) DeepCopyInto(out *ConvoySpec) {
+	*out = *in
+	if in.TrackedBeads != nil {
+		in, out := &in.TrackedBeads, &out.TrackedBeads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]BeadDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OnComplete != nil {
+		in, out := &in.OnComplete, &out.OnComplete
+		*out = make([]ConvoyCompletionAction, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvoySpec.
+func (in *ConvoySpec) DeepCopy() *ConvoySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ConvoySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ConvoyStatus) DeepCopyInto(out *ConvoyStatus) {
+	*out = *in
+	if in.CompletedBeads != nil {
+		in, out := &in.CompletedBeads, &out.CompletedBeads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.PendingBeads != nil {
+		in, out := &in.PendingBeads, &out.PendingBeads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.DispatchedBeads != nil {
+		in, out := &in.DispatchedBeads, &out.DispatchedBeads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.BlockedBeads != nil {
+		in, out := &in.BlockedBeads, &out.BlockedBeads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Beads != nil {
+		in, out := &in.Beads, &out.Beads
+		*out = make([]BeadSummary, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Outcomes != nil {
+		in, out := &in.Outcomes, &out.Outcomes
+		*out = make([]BeadOutcome, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.OnComplete != nil {
+		in, out := &in.OnComplete, &out.OnComplete
+		*out = make([]CompletionActionResult, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.StartedAt != nil {
+		in, out := &in.StartedAt, &out.StartedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletedAt != nil {
+		in, out := &in.CompletedAt, &out.CompletedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ConvoyStatus.
+func (in *ConvoyStatus) DeepCopy() *ConvoyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ConvoyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CostEstimate) DeepCopyInto(out *CostEstimate) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CostEstimate.
+func (in *CostEstimate) DeepCopy() *CostEstimate {
+	if in == nil {
+		return nil
+	}
+	out := new(CostEstimate)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyStop) DeepCopyInto(out *EmergencyStop) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyStop.
+func (in *EmergencyStop) DeepCopy() *EmergencyStop {
+	if in == nil {
+		return nil
+	}
+	out := new(EmergencyStop)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *EmergencyStop) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyStopList) DeepCopyInto(out *EmergencyStopList) {
 	*out = *in
 	out.TypeMeta = in.TypeMeta
 	in.ListMeta.DeepCopyInto(&out.ListMeta)
 	if in.Items != nil {
 		in, out := &in.Items, &out.Items
-		*out = make([]Polecat, len(*in))
+		*out = make([]EmergencyStop, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatList.
-func (in *PolecatList) DeepCopy() *PolecatList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyStopList.
+func (in *EmergencyStopList) DeepCopy() *EmergencyStopList {
 	if in == nil {
 		return nil
 	}
-	out := new(PolecatList)
+	out := new(EmergencyStopList)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *PolecatList) DeepCopyObject() runtime.Object {
+func (in *EmergencyStopList) DeepCopyObject() runtime.Object {
 	if c := in.DeepCopy(); c != nil {
 		return c
 	}
@@ -463,173 +792,1586 @@ func (in *PolecatList) DeepCopyObject() runtime.Object {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolecatSpec) DeepCopyInto(out *PolecatSpec) {
+func (in *EmergencyStopSpec) DeepCopyInto(out *EmergencyStopSpec) {
 	*out = *in
-	if in.Kubernetes != nil {
-		in, out := &in.Kubernetes, &out.Kubernetes
-		*out = new(KubernetesSpec)
-		(*in).DeepCopyInto(*out)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyStopSpec.
+func (in *EmergencyStopSpec) DeepCopy() *EmergencyStopSpec {
+	if in == nil {
+		return nil
 	}
-	if in.AgentConfig != nil {
-		in, out := &in.AgentConfig, &out.AgentConfig
-		*out = new(AgentConfig)
-		(*in).DeepCopyInto(*out)
+	out := new(EmergencyStopSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EmergencyStopStatus) DeepCopyInto(out *EmergencyStopStatus) {
+	*out = *in
+	if in.EngagedAt != nil {
+		in, out := &in.EngagedAt, &out.EngagedAt
+		*out = (*in).DeepCopy()
 	}
-	if in.Resources != nil {
-		in, out := &in.Resources, &out.Resources
-		*out = new(corev1.ResourceRequirements)
-		(*in).DeepCopyInto(*out)
+	if in.ReleasedAt != nil {
+		in, out := &in.ReleasedAt, &out.ReleasedAt
+		*out = (*in).DeepCopy()
 	}
-	if in.TTLSecondsAfterFinished != nil {
-		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
-		*out = new(int32)
-		**out = **in
+	if in.TerminatedPolecats != nil {
+		in, out := &in.TerminatedPolecats, &out.TerminatedPolecats
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.MaxIdleSeconds != nil {
-		in, out := &in.MaxIdleSeconds, &out.MaxIdleSeconds
-		*out = new(int32)
-		**out = **in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EmergencyStopStatus.
+func (in *EmergencyStopStatus) DeepCopy() *EmergencyStopStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(EmergencyStopStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExecutionWindow) DeepCopyInto(out *ExecutionWindow) {
+	*out = *in
+	out.Duration = in.Duration
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExecutionWindow.
+func (in *ExecutionWindow) DeepCopy() *ExecutionWindow {
+	if in == nil {
+		return nil
+	}
+	out := new(ExecutionWindow)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FollowUpConvoyAction) DeepCopyInto(out *FollowUpConvoyAction) {
+	*out = *in
+	if in.TrackedBeads != nil {
+		in, out := &in.TrackedBeads, &out.TrackedBeads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Dependencies != nil {
+		in, out := &in.Dependencies, &out.Dependencies
+		*out = make([]BeadDependency, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FollowUpConvoyAction.
+func (in *FollowUpConvoyAction) DeepCopy() *FollowUpConvoyAction {
+	if in == nil {
+		return nil
+	}
+	out := new(FollowUpConvoyAction)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GastownConfig) DeepCopyInto(out *GastownConfig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GastownConfig.
+func (in *GastownConfig) DeepCopy() *GastownConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GastownConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GastownConfig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GastownConfigList) DeepCopyInto(out *GastownConfigList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]GastownConfig, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GastownConfigList.
+func (in *GastownConfigList) DeepCopy() *GastownConfigList {
+	if in == nil {
+		return nil
+	}
+	out := new(GastownConfigList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *GastownConfigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GastownConfigSpec) DeepCopyInto(out *GastownConfigSpec) {
+	*out = *in
+	out.Images = in.Images
+	if in.ImageMirrors != nil {
+		in, out := &in.ImageMirrors, &out.ImageMirrors
+		*out = make([]ImageMirror, len(*in))
+		copy(*out, *in)
+	}
+	in.Requeue.DeepCopyInto(&out.Requeue)
+	if in.AgentResources != nil {
+		in, out := &in.AgentResources, &out.AgentResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ModelPricing != nil {
+		in, out := &in.ModelPricing, &out.ModelPricing
+		*out = make(map[string]ModelPrice, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.FeatureGates != nil {
+		in, out := &in.FeatureGates, &out.FeatureGates
+		*out = make(map[string]bool, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	in.Scheduling.DeepCopyInto(&out.Scheduling)
+	in.PolecatCleanup.DeepCopyInto(&out.PolecatCleanup)
+	if in.Controllers != nil {
+		in, out := &in.Controllers, &out.Controllers
+		*out = make(map[string]ControllerTuning, len(*in))
+		for key, val := range *in {
+			(*out)[key] = *val.DeepCopy()
+		}
+	}
+	if in.CacheSyncPeriod != nil {
+		in, out := &in.CacheSyncPeriod, &out.CacheSyncPeriod
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GastownConfigSpec.
+func (in *GastownConfigSpec) DeepCopy() *GastownConfigSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(GastownConfigSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GastownConfigStatus) DeepCopyInto(out *GastownConfigStatus) {
+	*out = *in
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GastownConfigStatus.
+func (in *GastownConfigStatus) DeepCopy() *GastownConfigStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(GastownConfigStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GitHubBeadSource) DeepCopyInto(out *GitHubBeadSource) {
+	*out = *in
+	if in.TokenSecretRef != nil {
+		in, out := &in.TokenSecretRef, &out.TokenSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.Labels != nil {
+		in, out := &in.Labels, &out.Labels
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GitHubBeadSource.
+func (in *GitHubBeadSource) DeepCopy() *GitHubBeadSource {
+	if in == nil {
+		return nil
+	}
+	out := new(GitHubBeadSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageDefaults) DeepCopyInto(out *ImageDefaults) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageDefaults.
+func (in *ImageDefaults) DeepCopy() *ImageDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageMirror) DeepCopyInto(out *ImageMirror) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageMirror.
+func (in *ImageMirror) DeepCopy() *ImageMirror {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageMirror)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *JiraBeadSource) DeepCopyInto(out *JiraBeadSource) {
+	*out = *in
+	if in.CredentialsSecretRef != nil {
+		in, out := &in.CredentialsSecretRef, &out.CredentialsSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new JiraBeadSource.
+func (in *JiraBeadSource) DeepCopy() *JiraBeadSource {
+	if in == nil {
+		return nil
+	}
+	out := new(JiraBeadSource)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KubernetesSpec) DeepCopyInto(out *KubernetesSpec) {
+	*out = *in
+	out.GitSecretRef = in.GitSecretRef
+	if in.GitCredsCSI != nil {
+		in, out := &in.GitCredsCSI, &out.GitCredsCSI
+		*out = new(CSISecretVolume)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AdditionalRepositories != nil {
+		in, out := &in.AdditionalRepositories, &out.AdditionalRepositories
+		*out = make([]AdditionalRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ClaudeCredsSecretRef != nil {
+		in, out := &in.ClaudeCredsSecretRef, &out.ClaudeCredsSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.ClaudeCredsCSI != nil {
+		in, out := &in.ClaudeCredsCSI, &out.ClaudeCredsCSI
+		*out = new(CSISecretVolume)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ApiKeySecretRef != nil {
+		in, out := &in.ApiKeySecretRef, &out.ApiKeySecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ActiveDeadlineSeconds != nil {
+		in, out := &in.ActiveDeadlineSeconds, &out.ActiveDeadlineSeconds
+		*out = new(int64)
+		**out = **in
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Affinity != nil {
+		in, out := &in.Affinity, &out.Affinity
+		*out = new(corev1.Affinity)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]corev1.TopologySpreadConstraint, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.DNSConfig != nil {
+		in, out := &in.DNSConfig, &out.DNSConfig
+		*out = new(corev1.PodDNSConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.HostAliases != nil {
+		in, out := &in.HostAliases, &out.HostAliases
+		*out = make([]corev1.HostAlias, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.SSHKnownHostsConfigMapRef != nil {
+		in, out := &in.SSHKnownHostsConfigMapRef, &out.SSHKnownHostsConfigMapRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.PromptTemplateRef != nil {
+		in, out := &in.PromptTemplateRef, &out.PromptTemplateRef
+		*out = new(corev1.LocalObjectReference)
+		**out = **in
+	}
+	if in.Probes != nil {
+		in, out := &in.Probes, &out.Probes
+		*out = new(AgentProbeSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Checkpoint != nil {
+		in, out := &in.Checkpoint, &out.Checkpoint
+		*out = new(CheckpointSpec)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KubernetesSpec.
+func (in *KubernetesSpec) DeepCopy() *KubernetesSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(KubernetesSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogArchiveSpec) DeepCopyInto(out *LogArchiveSpec) {
+	*out = *in
+	out.CredentialsSecretRef = in.CredentialsSecretRef
+	if in.Redaction != nil {
+		in, out := &in.Redaction, &out.Redaction
+		*out = new(LogRedactionSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogArchiveSpec.
+func (in *LogArchiveSpec) DeepCopy() *LogArchiveSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogArchiveSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRedactionRule) DeepCopyInto(out *LogRedactionRule) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRedactionRule.
+func (in *LogRedactionRule) DeepCopy() *LogRedactionRule {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRedactionRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogRedactionSpec) DeepCopyInto(out *LogRedactionSpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]LogRedactionRule, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogRedactionSpec.
+func (in *LogRedactionSpec) DeepCopy() *LogRedactionSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(LogRedactionSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeBatchSpec) DeepCopyInto(out *MergeBatchSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeBatchSpec.
+func (in *MergeBatchSpec) DeepCopy() *MergeBatchSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeBatchSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeQueueEntry) DeepCopyInto(out *MergeQueueEntry) {
+	*out = *in
+	if in.ReadySince != nil {
+		in, out := &in.ReadySince, &out.ReadySince
+		*out = (*in).DeepCopy()
+	}
+	if in.DiffSize != nil {
+		in, out := &in.DiffSize, &out.DiffSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.BlockedBy != nil {
+		in, out := &in.BlockedBy, &out.BlockedBy
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.CommitsBehind != nil {
+		in, out := &in.CommitsBehind, &out.CommitsBehind
+		*out = new(int32)
+		**out = **in
+	}
+	if in.LastRefreshTime != nil {
+		in, out := &in.LastRefreshTime, &out.LastRefreshTime
+		*out = (*in).DeepCopy()
+	}
+	if in.BatchTime != nil {
+		in, out := &in.BatchTime, &out.BatchTime
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeQueueEntry.
+func (in *MergeQueueEntry) DeepCopy() *MergeQueueEntry {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeQueueEntry)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergeRecord) DeepCopyInto(out *MergeRecord) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+	if in.Duration != nil {
+		in, out := &in.Duration, &out.Duration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergeRecord.
+func (in *MergeRecord) DeepCopy() *MergeRecord {
+	if in == nil {
+		return nil
+	}
+	out := new(MergeRecord)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MergesSummary) DeepCopyInto(out *MergesSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MergesSummary.
+func (in *MergesSummary) DeepCopy() *MergesSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(MergesSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelPrice) DeepCopyInto(out *ModelPrice) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelPrice.
+func (in *ModelPrice) DeepCopy() *ModelPrice {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelPrice)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ModelProviderConfig) DeepCopyInto(out *ModelProviderConfig) {
+	*out = *in
+	if in.APIKeySecretRef != nil {
+		in, out := &in.APIKeySecretRef, &out.APIKeySecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ModelProviderConfig.
+func (in *ModelProviderConfig) DeepCopy() *ModelProviderConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ModelProviderConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *OwnershipSpec) DeepCopyInto(out *OwnershipSpec) {
+	*out = *in
+	if in.LeaseDuration != nil {
+		in, out := &in.LeaseDuration, &out.LeaseDuration
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new OwnershipSpec.
+func (in *OwnershipSpec) DeepCopy() *OwnershipSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(OwnershipSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Polecat) DeepCopyInto(out *Polecat) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Polecat.
+func (in *Polecat) DeepCopy() *Polecat {
+	if in == nil {
+		return nil
+	}
+	out := new(Polecat)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Polecat) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatCleanupPolicy) DeepCopyInto(out *PolecatCleanupPolicy) {
+	*out = *in
+	if in.Timeout != nil {
+		in, out := &in.Timeout, &out.Timeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatCleanupPolicy.
+func (in *PolecatCleanupPolicy) DeepCopy() *PolecatCleanupPolicy {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatCleanupPolicy)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatCustomDefaulter) DeepCopyInto(out *PolecatCustomDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatCustomDefaulter.
+func (in *PolecatCustomDefaulter) DeepCopy() *PolecatCustomDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatCustomDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatCustomValidator) DeepCopyInto(out *PolecatCustomValidator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatCustomValidator.
+func (in *PolecatCustomValidator) DeepCopy() *PolecatCustomValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatCustomValidator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatDefaults) DeepCopyInto(out *PolecatDefaults) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NodeSelector != nil {
+		in, out := &in.NodeSelector, &out.NodeSelector
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.Tolerations != nil {
+		in, out := &in.Tolerations, &out.Tolerations
+		*out = make([]corev1.Toleration, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatDefaults.
+func (in *PolecatDefaults) DeepCopy() *PolecatDefaults {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatDefaults)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatFailure) DeepCopyInto(out *PolecatFailure) {
+	*out = *in
+	if in.FailedAt != nil {
+		in, out := &in.FailedAt, &out.FailedAt
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatFailure.
+func (in *PolecatFailure) DeepCopy() *PolecatFailure {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatFailure)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatList) DeepCopyInto(out *PolecatList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Polecat, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatList.
+func (in *PolecatList) DeepCopy() *PolecatList {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *PolecatList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatScheduling) DeepCopyInto(out *PolecatScheduling) {
+	*out = *in
+	if in.RigWeights != nil {
+		in, out := &in.RigWeights, &out.RigWeights
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatScheduling.
+func (in *PolecatScheduling) DeepCopy() *PolecatScheduling {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatScheduling)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatSpec) DeepCopyInto(out *PolecatSpec) {
+	*out = *in
+	if in.Kubernetes != nil {
+		in, out := &in.Kubernetes, &out.Kubernetes
+		*out = new(KubernetesSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AgentConfig != nil {
+		in, out := &in.AgentConfig, &out.AgentConfig
+		*out = new(AgentConfig)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TTLSecondsAfterFinished != nil {
+		in, out := &in.TTLSecondsAfterFinished, &out.TTLSecondsAfterFinished
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxIdleSeconds != nil {
+		in, out := &in.MaxIdleSeconds, &out.MaxIdleSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.HeartbeatTimeoutSeconds != nil {
+		in, out := &in.HeartbeatTimeoutSeconds, &out.HeartbeatTimeoutSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.SyncIntervalSeconds != nil {
+		in, out := &in.SyncIntervalSeconds, &out.SyncIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MergeAfter != nil {
+		in, out := &in.MergeAfter, &out.MergeAfter
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatSpec.
+func (in *PolecatSpec) DeepCopy() *PolecatSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatStatus) DeepCopyInto(out *PolecatStatus) {
+	*out = *in
+	if in.Bead != nil {
+		in, out := &in.Bead, &out.Bead
+		*out = new(BeadSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MergedCommits != nil {
+		in, out := &in.MergedCommits, &out.MergedCommits
+		*out = make(map[string]string, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.SplitProposal != nil {
+		in, out := &in.SplitProposal, &out.SplitProposal
+		*out = make([]ProposedBead, len(*in))
+		copy(*out, *in)
+	}
+	if in.SplitBeads != nil {
+		in, out := &in.SplitBeads, &out.SplitBeads
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.LastActivity != nil {
+		in, out := &in.LastActivity, &out.LastActivity
+		*out = (*in).DeepCopy()
+	}
+	if in.FinishedAt != nil {
+		in, out := &in.FinishedAt, &out.FinishedAt
+		*out = (*in).DeepCopy()
+	}
+	if in.CostEstimate != nil {
+		in, out := &in.CostEstimate, &out.CostEstimate
+		*out = new(CostEstimate)
+		**out = **in
+	}
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(TokenUsage)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastFailure != nil {
+		in, out := &in.LastFailure, &out.LastFailure
+		*out = new(PolecatFailure)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatStatus.
+func (in *PolecatStatus) DeepCopy() *PolecatStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PolecatsSummary) DeepCopyInto(out *PolecatsSummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatsSummary.
+func (in *PolecatsSummary) DeepCopy() *PolecatsSummary {
+	if in == nil {
+		return nil
+	}
+	out := new(PolecatsSummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ProposedBead) DeepCopyInto(out *ProposedBead) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ProposedBead.
+func (in *ProposedBead) DeepCopy() *ProposedBead {
+	if in == nil {
+		return nil
+	}
+	out := new(ProposedBead)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PullRequestSpec) DeepCopyInto(out *PullRequestSpec) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PullRequestSpec.
+func (in *PullRequestSpec) DeepCopy() *PullRequestSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PullRequestSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Refinery) DeepCopyInto(out *Refinery) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Refinery.
+func (in *Refinery) DeepCopy() *Refinery {
+	if in == nil {
+		return nil
+	}
+	out := new(Refinery)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Refinery) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RefineryList) DeepCopyInto(out *RefineryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Refinery, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefineryList.
+func (in *RefineryList) DeepCopy() *RefineryList {
+	if in == nil {
+		return nil
+	}
+	out := new(RefineryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RefineryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RefinerySpec) DeepCopyInto(out *RefinerySpec) {
+	*out = *in
+	if in.SyncIntervalSeconds != nil {
+		in, out := &in.SyncIntervalSeconds, &out.SyncIntervalSeconds
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TestTimeout != nil {
+		in, out := &in.TestTimeout, &out.TestTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.TestResources != nil {
+		in, out := &in.TestResources, &out.TestResources
+		*out = new(corev1.ResourceRequirements)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.GitSecretRef != nil {
+		in, out := &in.GitSecretRef, &out.GitSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.GitHubTokenSecretRef != nil {
+		in, out := &in.GitHubTokenSecretRef, &out.GitHubTokenSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.GitLabTokenSecretRef != nil {
+		in, out := &in.GitLabTokenSecretRef, &out.GitLabTokenSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.BitbucketTokenSecretRef != nil {
+		in, out := &in.BitbucketTokenSecretRef, &out.BitbucketTokenSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+	if in.PullRequest != nil {
+		in, out := &in.PullRequest, &out.PullRequest
+		*out = new(PullRequestSpec)
+		**out = **in
+	}
+	if in.RequiredChecks != nil {
+		in, out := &in.RequiredChecks, &out.RequiredChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.RequiredChecksTimeout != nil {
+		in, out := &in.RequiredChecksTimeout, &out.RequiredChecksTimeout
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Release != nil {
+		in, out := &in.Release, &out.Release
+		*out = new(ReleaseSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Batch != nil {
+		in, out := &in.Batch, &out.Batch
+		*out = new(MergeBatchSpec)
+		**out = **in
+	}
+	if in.Ownership != nil {
+		in, out := &in.Ownership, &out.Ownership
+		*out = new(OwnershipSpec)
+		(*in).DeepCopyInto(*out)
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefinerySpec.
+func (in *RefinerySpec) DeepCopy() *RefinerySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RefinerySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RefineryStatus) DeepCopyInto(out *RefineryStatus) {
+	*out = *in
+	if in.ActiveMerges != nil {
+		in, out := &in.ActiveMerges, &out.ActiveMerges
+		*out = make([]ActiveMerge, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastMergeTime != nil {
+		in, out := &in.LastMergeTime, &out.LastMergeTime
+		*out = (*in).DeepCopy()
+	}
+	out.MergesSummary = in.MergesSummary
+	if in.MergeHistory != nil {
+		in, out := &in.MergeHistory, &out.MergeHistory
+		*out = make([]MergeRecord, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.LastRelease != nil {
+		in, out := &in.LastRelease, &out.LastRelease
+		*out = new(ReleaseStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.LastBranchCleanup != nil {
+		in, out := &in.LastBranchCleanup, &out.LastBranchCleanup
+		*out = new(BranchCleanupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Queue != nil {
+		in, out := &in.Queue, &out.Queue
+		*out = make([]MergeQueueEntry, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.NextBatchTime != nil {
+		in, out := &in.NextBatchTime, &out.NextBatchTime
+		*out = (*in).DeepCopy()
+	}
+	if in.WaitingOnChecks != nil {
+		in, out := &in.WaitingOnChecks, &out.WaitingOnChecks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]v1.Condition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefineryStatus.
+func (in *RefineryStatus) DeepCopy() *RefineryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RefineryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReleaseSpec) DeepCopyInto(out *ReleaseSpec) {
+	*out = *in
+	if in.GitHubTokenSecretRef != nil {
+		in, out := &in.GitHubTokenSecretRef, &out.GitHubTokenSecretRef
+		*out = new(SecretKeyRef)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseSpec.
+func (in *ReleaseSpec) DeepCopy() *ReleaseSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ReleaseSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ReleaseStatus) DeepCopyInto(out *ReleaseStatus) {
+	*out = *in
+	if in.Time != nil {
+		in, out := &in.Time, &out.Time
+		*out = (*in).DeepCopy()
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ReleaseStatus.
+func (in *ReleaseStatus) DeepCopy() *ReleaseStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ReleaseStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RequeueIntervals) DeepCopyInto(out *RequeueIntervals) {
+	*out = *in
+	if in.Short != nil {
+		in, out := &in.Short, &out.Short
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Default != nil {
+		in, out := &in.Default, &out.Default
+		*out = new(v1.Duration)
+		**out = **in
+	}
+	if in.Long != nil {
+		in, out := &in.Long, &out.Long
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RequeueIntervals.
+func (in *RequeueIntervals) DeepCopy() *RequeueIntervals {
+	if in == nil {
+		return nil
+	}
+	out := new(RequeueIntervals)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Rig) DeepCopyInto(out *Rig) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	in.Status.DeepCopyInto(&out.Status)
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rig.
+func (in *Rig) DeepCopy() *Rig {
+	if in == nil {
+		return nil
+	}
+	out := new(Rig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Rig) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RigBudget) DeepCopyInto(out *RigBudget) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigBudget.
+func (in *RigBudget) DeepCopy() *RigBudget {
+	if in == nil {
+		return nil
+	}
+	out := new(RigBudget)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RigConvoySummary) DeepCopyInto(out *RigConvoySummary) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigConvoySummary.
+func (in *RigConvoySummary) DeepCopy() *RigConvoySummary {
+	if in == nil {
+		return nil
+	}
+	out := new(RigConvoySummary)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RigCustomDefaulter) DeepCopyInto(out *RigCustomDefaulter) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigCustomDefaulter.
+func (in *RigCustomDefaulter) DeepCopy() *RigCustomDefaulter {
+	if in == nil {
+		return nil
+	}
+	out := new(RigCustomDefaulter)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RigCustomValidator) DeepCopyInto(out *RigCustomValidator) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigCustomValidator.
+func (in *RigCustomValidator) DeepCopy() *RigCustomValidator {
+	if in == nil {
+		return nil
+	}
+	out := new(RigCustomValidator)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RigEgressRule) DeepCopyInto(out *RigEgressRule) {
+	*out = *in
+	if in.Except != nil {
+		in, out := &in.Except, &out.Except
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatSpec.
-func (in *PolecatSpec) DeepCopy() *PolecatSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigEgressRule.
+func (in *RigEgressRule) DeepCopy() *RigEgressRule {
 	if in == nil {
 		return nil
 	}
-	out := new(PolecatSpec)
+	out := new(RigEgressRule)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolecatStatus) DeepCopyInto(out *PolecatStatus) {
+func (in *RigList) DeepCopyInto(out *RigList) {
 	*out = *in
-	if in.LastActivity != nil {
-		in, out := &in.LastActivity, &out.LastActivity
-		*out = (*in).DeepCopy()
-	}
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
+	out.TypeMeta = in.TypeMeta
+	in.ListMeta.DeepCopyInto(&out.ListMeta)
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Rig, len(*in))
 		for i := range *in {
 			(*in)[i].DeepCopyInto(&(*out)[i])
 		}
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatStatus.
-func (in *PolecatStatus) DeepCopy() *PolecatStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigList.
+func (in *RigList) DeepCopy() *RigList {
 	if in == nil {
 		return nil
 	}
-	out := new(PolecatStatus)
+	out := new(RigList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *RigList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PolecatsSummary) DeepCopyInto(out *PolecatsSummary) {
+func (in *RigNetworkPolicy) DeepCopyInto(out *RigNetworkPolicy) {
 	*out = *in
+	if in.Egress != nil {
+		in, out := &in.Egress, &out.Egress
+		*out = make([]RigEgressRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PolecatsSummary.
-func (in *PolecatsSummary) DeepCopy() *PolecatsSummary {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigNetworkPolicy.
+func (in *RigNetworkPolicy) DeepCopy() *RigNetworkPolicy {
 	if in == nil {
 		return nil
 	}
-	out := new(PolecatsSummary)
+	out := new(RigNetworkPolicy)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Refinery) DeepCopyInto(out *Refinery) {
+func (in *RigPolecatCounts) DeepCopyInto(out *RigPolecatCounts) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Refinery.
-func (in *Refinery) DeepCopy() *Refinery {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigPolecatCounts.
+func (in *RigPolecatCounts) DeepCopy() *RigPolecatCounts {
 	if in == nil {
 		return nil
 	}
-	out := new(Refinery)
+	out := new(RigPolecatCounts)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Refinery) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RefineryList) DeepCopyInto(out *RefineryList) {
+func (in *RigRepository) DeepCopyInto(out *RigRepository) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Refinery, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.GitSecretRef != nil {
+		in, out := &in.GitSecretRef, &out.GitSecretRef
+		*out = new(SecretReference)
+		**out = **in
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefineryList.
-func (in *RefineryList) DeepCopy() *RefineryList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigRepository.
+func (in *RigRepository) DeepCopy() *RigRepository {
 	if in == nil {
 		return nil
 	}
-	out := new(RefineryList)
+	out := new(RigRepository)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RefineryList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RigSettings) DeepCopyInto(out *RigSettings) {
+	*out = *in
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigSettings.
+func (in *RigSettings) DeepCopy() *RigSettings {
+	if in == nil {
+		return nil
 	}
-	return nil
+	out := new(RigSettings)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RefinerySpec) DeepCopyInto(out *RefinerySpec) {
+func (in *RigSpec) DeepCopyInto(out *RigSpec) {
 	*out = *in
-	if in.GitSecretRef != nil {
-		in, out := &in.GitSecretRef, &out.GitSecretRef
-		*out = new(SecretReference)
+	out.Settings = in.Settings
+	if in.SyncIntervalSeconds != nil {
+		in, out := &in.SyncIntervalSeconds, &out.SyncIntervalSeconds
+		*out = new(int32)
 		**out = **in
 	}
+	if in.NamespaceQuota != nil {
+		in, out := &in.NamespaceQuota, &out.NamespaceQuota
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.LogArchive != nil {
+		in, out := &in.LogArchive, &out.LogArchive
+		*out = new(LogArchiveSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.BranchCleanup != nil {
+		in, out := &in.BranchCleanup, &out.BranchCleanup
+		*out = new(BranchCleanupSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ExecutionWindows != nil {
+		in, out := &in.ExecutionWindows, &out.ExecutionWindows
+		*out = make([]ExecutionWindow, len(*in))
+		copy(*out, *in)
+	}
+	if in.Budget != nil {
+		in, out := &in.Budget, &out.Budget
+		*out = new(RigBudget)
+		**out = **in
+	}
+	if in.AgentServiceAccount != nil {
+		in, out := &in.AgentServiceAccount, &out.AgentServiceAccount
+		*out = new(AgentServiceAccountSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(RigNetworkPolicy)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]corev1.LocalObjectReference, len(*in))
+		copy(*out, *in)
+	}
+	if in.PolecatDefaults != nil {
+		in, out := &in.PolecatDefaults, &out.PolecatDefaults
+		*out = new(PolecatDefaults)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Signing != nil {
+		in, out := &in.Signing, &out.Signing
+		*out = new(CommitSigningSpec)
+		**out = **in
+	}
+	if in.Repositories != nil {
+		in, out := &in.Repositories, &out.Repositories
+		*out = make([]RigRepository, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.WarmPool != nil {
+		in, out := &in.WarmPool, &out.WarmPool
+		*out = new(WarmPoolSpec)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefinerySpec.
-func (in *RefinerySpec) DeepCopy() *RefinerySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigSpec.
+func (in *RigSpec) DeepCopy() *RigSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RefinerySpec)
+	out := new(RigSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RefineryStatus) DeepCopyInto(out *RefineryStatus) {
+func (in *RigStatus) DeepCopyInto(out *RigStatus) {
 	*out = *in
+	if in.Polecats != nil {
+		in, out := &in.Polecats, &out.Polecats
+		*out = new(RigPolecatCounts)
+		**out = **in
+	}
+	if in.Convoys != nil {
+		in, out := &in.Convoys, &out.Convoys
+		*out = make([]RigConvoySummary, len(*in))
+		copy(*out, *in)
+	}
 	if in.LastMergeTime != nil {
 		in, out := &in.LastMergeTime, &out.LastMergeTime
 		*out = (*in).DeepCopy()
 	}
-	out.MergesSummary = in.MergesSummary
+	if in.Usage != nil {
+		in, out := &in.Usage, &out.Usage
+		*out = new(RigUsage)
+		**out = **in
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
@@ -639,184 +2381,165 @@ func (in *RefineryStatus) DeepCopyInto(out *RefineryStatus) {
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RefineryStatus.
-func (in *RefineryStatus) DeepCopy() *RefineryStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigStatus.
+func (in *RigStatus) DeepCopy() *RigStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(RefineryStatus)
+	out := new(RigStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Rig) DeepCopyInto(out *Rig) {
+func (in *RigUsage) DeepCopyInto(out *RigUsage) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	out.Spec = in.Spec
-	in.Status.DeepCopyInto(&out.Status)
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Rig.
-func (in *Rig) DeepCopy() *Rig {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigUsage.
+func (in *RigUsage) DeepCopy() *RigUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(Rig)
+	out := new(RigUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Rig) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RigCustomDefaulter) DeepCopyInto(out *RigCustomDefaulter) {
+func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigCustomDefaulter.
-func (in *RigCustomDefaulter) DeepCopy() *RigCustomDefaulter {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
+func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
 	if in == nil {
 		return nil
 	}
-	out := new(RigCustomDefaulter)
+	out := new(SecretKeyRef)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RigCustomValidator) DeepCopyInto(out *RigCustomValidator) {
+func (in *SecretReference) DeepCopyInto(out *SecretReference) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigCustomValidator.
-func (in *RigCustomValidator) DeepCopy() *RigCustomValidator {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
+func (in *SecretReference) DeepCopy() *SecretReference {
 	if in == nil {
 		return nil
 	}
-	out := new(RigCustomValidator)
+	out := new(SecretReference)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RigList) DeepCopyInto(out *RigList) {
+func (in *StalledPolecat) DeepCopyInto(out *StalledPolecat) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ListMeta.DeepCopyInto(&out.ListMeta)
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Rig, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.LastActivity != nil {
+		in, out := &in.LastActivity, &out.LastActivity
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigList.
-func (in *RigList) DeepCopy() *RigList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new StalledPolecat.
+func (in *StalledPolecat) DeepCopy() *StalledPolecat {
 	if in == nil {
 		return nil
 	}
-	out := new(RigList)
+	out := new(StalledPolecat)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *RigList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RigSettings) DeepCopyInto(out *RigSettings) {
+func (in *SummaryBeadAction) DeepCopyInto(out *SummaryBeadAction) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigSettings.
-func (in *RigSettings) DeepCopy() *RigSettings {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SummaryBeadAction.
+func (in *SummaryBeadAction) DeepCopy() *SummaryBeadAction {
 	if in == nil {
 		return nil
 	}
-	out := new(RigSettings)
+	out := new(SummaryBeadAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RigSpec) DeepCopyInto(out *RigSpec) {
+func (in *TagAction) DeepCopyInto(out *TagAction) {
 	*out = *in
-	out.Settings = in.Settings
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigSpec.
-func (in *RigSpec) DeepCopy() *RigSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TagAction.
+func (in *TagAction) DeepCopy() *TagAction {
 	if in == nil {
 		return nil
 	}
-	out := new(RigSpec)
+	out := new(TagAction)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RigStatus) DeepCopyInto(out *RigStatus) {
+func (in *TokenUsage) DeepCopyInto(out *TokenUsage) {
 	*out = *in
-	if in.Conditions != nil {
-		in, out := &in.Conditions, &out.Conditions
-		*out = make([]v1.Condition, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.UpdatedAt != nil {
+		in, out := &in.UpdatedAt, &out.UpdatedAt
+		*out = (*in).DeepCopy()
 	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RigStatus.
-func (in *RigStatus) DeepCopy() *RigStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TokenUsage.
+func (in *TokenUsage) DeepCopy() *TokenUsage {
 	if in == nil {
 		return nil
 	}
-	out := new(RigStatus)
+	out := new(TokenUsage)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretKeyRef) DeepCopyInto(out *SecretKeyRef) {
+func (in *WarmPoolSpec) DeepCopyInto(out *WarmPoolSpec) {
 	*out = *in
+	if in.GitSecretRef != nil {
+		in, out := &in.GitSecretRef, &out.GitSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
+	if in.ClaudeCredsSecretRef != nil {
+		in, out := &in.ClaudeCredsSecretRef, &out.ClaudeCredsSecretRef
+		*out = new(SecretReference)
+		**out = **in
+	}
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretKeyRef.
-func (in *SecretKeyRef) DeepCopy() *SecretKeyRef {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WarmPoolSpec.
+func (in *WarmPoolSpec) DeepCopy() *WarmPoolSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretKeyRef)
+	out := new(WarmPoolSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *SecretReference) DeepCopyInto(out *SecretReference) {
+func (in *WebhookAction) DeepCopyInto(out *WebhookAction) {
 	*out = *in
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretReference.
-func (in *SecretReference) DeepCopy() *SecretReference {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WebhookAction.
+func (in *WebhookAction) DeepCopy() *WebhookAction {
 	if in == nil {
 		return nil
 	}
-	out := new(SecretReference)
+	out := new(WebhookAction)
 	in.DeepCopyInto(out)
 	return out
 }
@@ -848,6 +2571,26 @@ func (in *Witness) DeepCopyObject() runtime.Object {
 	return nil
 }
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *WitnessAutoReport) DeepCopyInto(out *WitnessAutoReport) {
+	*out = *in
+	if in.GiveUpAfter != nil {
+		in, out := &in.GiveUpAfter, &out.GiveUpAfter
+		*out = new(v1.Duration)
+		**out = **in
+	}
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WitnessAutoReport.
+func (in *WitnessAutoReport) DeepCopy() *WitnessAutoReport {
+	if in == nil {
+		return nil
+	}
+	out := new(WitnessAutoReport)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *WitnessList) DeepCopyInto(out *WitnessList) {
 	*out = *in
@@ -893,6 +2636,11 @@ func (in *WitnessSpec) DeepCopyInto(out *WitnessSpec) {
 		*out = new(v1.Duration)
 		**out = **in
 	}
+	if in.AutoReport != nil {
+		in, out := &in.AutoReport, &out.AutoReport
+		*out = new(WitnessAutoReport)
+		(*in).DeepCopyInto(*out)
+	}
 }
 
 // DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new WitnessSpec.
@@ -913,6 +2661,13 @@ func (in *WitnessStatus) DeepCopyInto(out *WitnessStatus) {
 		*out = (*in).DeepCopy()
 	}
 	out.PolecatsSummary = in.PolecatsSummary
+	if in.StalledPolecats != nil {
+		in, out := &in.StalledPolecats, &out.StalledPolecats
+		*out = make([]StalledPolecat, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	if in.Conditions != nil {
 		in, out := &in.Conditions, &out.Conditions
 		*out = make([]v1.Condition, len(*in))
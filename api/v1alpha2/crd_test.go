@@ -0,0 +1,115 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"sigs.k8s.io/yaml"
+)
+
+// crd holds the fields of a CustomResourceDefinition (or a patch to one)
+// that decide how its versions are served.
+type crd struct {
+	Spec struct {
+		Conversion *struct {
+			Strategy string `json:"strategy"`
+		} `json:"conversion"`
+		Versions []struct {
+			Name    string `json:"name"`
+			Served  bool   `json:"served"`
+			Storage bool   `json:"storage"`
+		} `json:"versions"`
+	} `json:"spec"`
+}
+
+// kustomization holds the fields of a kustomization.yaml that decide
+// whether the conversion webhook is deployed.
+type kustomization struct {
+	Resources []string `json:"resources"`
+	Patches   []struct {
+		Path string `json:"path"`
+	} `json:"patches"`
+}
+
+func (k kustomization) patchPaths() []string {
+	var paths []string
+	for _, p := range k.Patches {
+		paths = append(paths, p.Path)
+	}
+	return paths
+}
+
+func readYAML(t *testing.T, path string, v any) {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "..", path))
+	require.NoError(t, err)
+	require.NoError(t, yaml.Unmarshal(data, v))
+}
+
+// TestCRDsServeV1alpha2 checks that the CRDs installed with Kustomize or
+// Helm serve v1alpha2 without storing it.
+func TestCRDsServeV1alpha2(t *testing.T) {
+	for _, dir := range []string{"config/crd/bases", "helm/gastown-operator/crds"} {
+		for _, file := range []string{"gastown.gastown.io_rigs.yaml", "gastown.gastown.io_polecats.yaml"} {
+			t.Run(filepath.Join(dir, file), func(t *testing.T) {
+				var c crd
+				readYAML(t, filepath.Join(dir, file), &c)
+
+				found := false
+				for _, v := range c.Spec.Versions {
+					if v.Name != GroupVersion.Version {
+						continue
+					}
+					found = true
+					assert.True(t, v.Served)
+					assert.False(t, v.Storage)
+				}
+				assert.True(t, found, "no v1alpha2 version")
+			})
+		}
+	}
+}
+
+// TestKustomizeConvertsV1alpha2 checks that the default Kustomize install
+// sends the conversions of the CRDs serving v1alpha2 to the webhook server:
+// the API server would otherwise return v1alpha1 objects relabelled as
+// v1alpha2. Helm installs get the same conversion from the manager.
+func TestKustomizeConvertsV1alpha2(t *testing.T) {
+	var crds kustomization
+	readYAML(t, "config/crd/kustomization.yaml", &crds)
+	for _, kind := range []string{"rigs", "polecats"} {
+		patch := "patches/webhook_in_" + kind + ".yaml"
+		assert.Contains(t, crds.patchPaths(), patch)
+
+		var c crd
+		readYAML(t, filepath.Join("config/crd", patch), &c)
+		require.NotNil(t, c.Spec.Conversion, patch)
+		assert.Equal(t, "Webhook", c.Spec.Conversion.Strategy, patch)
+	}
+
+	var def kustomization
+	readYAML(t, "config/default/kustomization.yaml", &def)
+	assert.Contains(t, def.Resources, "../webhook")
+	assert.Contains(t, def.Resources, "../certmanager")
+	assert.Contains(t, def.patchPaths(), "manager_webhook_patch.yaml")
+	assert.NotContains(t, def.patchPaths(), "manager_disable_webhooks_patch.yaml")
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// SetupPolecatWebhookWithManager registers the Polecat conversion webhook
+// with the manager.
+func SetupPolecatWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &Polecat{}).Complete()
+}
+
+var _ conversion.Convertible = &Polecat{}
+
+// ConvertTo converts this Polecat to the hub version (v1alpha1).
+func (src *Polecat) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Polecat)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.PolecatSpec{
+		Rig:                     src.Spec.Rig,
+		DesiredState:            src.Spec.DesiredState,
+		BeadID:                  src.Spec.BeadID,
+		TaskDescription:         src.Spec.TaskDescription,
+		ExecutionMode:           src.Spec.ExecutionMode,
+		Kubernetes:              convertKubernetesToHub(src.Spec.Kubernetes),
+		Agent:                   src.Spec.Agent,
+		AgentConfig:             src.Spec.AgentConfig,
+		Resources:               src.Spec.Resources,
+		TTLSecondsAfterFinished: src.Spec.TTLSecondsAfterFinished,
+		MaxIdleSeconds:          src.Spec.MaxIdleSeconds,
+		HeartbeatTimeoutSeconds: src.Spec.HeartbeatTimeoutSeconds,
+		SyncIntervalSeconds:     src.Spec.SyncIntervalSeconds,
+		RestartOnSecretChange:   src.Spec.RestartOnSecretChange,
+		MergePriority:           src.Spec.MergePriority,
+		MergeAfter:              src.Spec.MergeAfter,
+		Urgent:                  src.Spec.Urgent,
+		SplitOf:                 src.Spec.SplitOf,
+		Repositories:            src.Spec.Repositories,
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1alpha1) to this Polecat.
+func (dst *Polecat) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Polecat)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = PolecatSpec{
+		Rig:                     src.Spec.Rig,
+		DesiredState:            src.Spec.DesiredState,
+		BeadID:                  src.Spec.BeadID,
+		TaskDescription:         src.Spec.TaskDescription,
+		ExecutionMode:           src.Spec.ExecutionMode,
+		Kubernetes:              convertKubernetesFromHub(src.Spec.Kubernetes),
+		Agent:                   src.Spec.Agent,
+		AgentConfig:             src.Spec.AgentConfig,
+		Resources:               src.Spec.Resources,
+		TTLSecondsAfterFinished: src.Spec.TTLSecondsAfterFinished,
+		MaxIdleSeconds:          src.Spec.MaxIdleSeconds,
+		HeartbeatTimeoutSeconds: src.Spec.HeartbeatTimeoutSeconds,
+		SyncIntervalSeconds:     src.Spec.SyncIntervalSeconds,
+		RestartOnSecretChange:   src.Spec.RestartOnSecretChange,
+		MergePriority:           src.Spec.MergePriority,
+		MergeAfter:              src.Spec.MergeAfter,
+		Urgent:                  src.Spec.Urgent,
+		SplitOf:                 src.Spec.SplitOf,
+		Repositories:            src.Spec.Repositories,
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// convertKubernetesToHub flattens the git, auth and runtime sections into
+// the v1alpha1 KubernetesSpec.
+func convertKubernetesToHub(in *KubernetesSpec) *v1alpha1.KubernetesSpec {
+	if in == nil {
+		return nil
+	}
+	out := &v1alpha1.KubernetesSpec{
+		GitRepository:             in.Git.Repository,
+		GitBranch:                 in.Git.Branch,
+		WorkBranch:                in.Git.WorkBranch,
+		GitSecretRef:              in.Git.SecretRef,
+		GitCredsCSI:               in.Git.CredsCSI,
+		AdditionalRepositories:    in.Git.AdditionalRepositories,
+		SSHKnownHostsConfigMapRef: in.Git.SSHKnownHostsConfigMapRef,
+		SSHStrictHostKeyChecking:  in.Git.SSHStrictHostKeyChecking,
+	}
+	if auth := in.Auth; auth != nil {
+		out.ClaudeCredsSecretRef = auth.ClaudeCredsSecretRef
+		out.ClaudeCredsCSI = auth.ClaudeCredsCSI
+		out.ApiKeySecretRef = auth.APIKeySecretRef
+	}
+	if runtime := in.Runtime; runtime != nil {
+		out.Image = runtime.Image
+		out.Resources = runtime.Resources
+		out.ActiveDeadlineSeconds = runtime.ActiveDeadlineSeconds
+		out.PriorityClassName = runtime.PriorityClassName
+		out.NodeSelector = runtime.NodeSelector
+		out.Tolerations = runtime.Tolerations
+		out.Affinity = runtime.Affinity
+		out.TopologySpreadConstraints = runtime.TopologySpreadConstraints
+		out.DNSPolicy = runtime.DNSPolicy
+		out.DNSConfig = runtime.DNSConfig
+		out.HostAliases = runtime.HostAliases
+		out.ServiceAccountName = runtime.ServiceAccountName
+		out.ImagePullSecrets = runtime.ImagePullSecrets
+		out.PromptTemplateRef = runtime.PromptTemplateRef
+		out.Probes = runtime.Probes
+		out.Checkpoint = runtime.Checkpoint
+	}
+	return out
+}
+
+// convertKubernetesFromHub splits the v1alpha1 KubernetesSpec into the git,
+// auth and runtime sections, leaving out the sections with nothing set.
+func convertKubernetesFromHub(in *v1alpha1.KubernetesSpec) *KubernetesSpec {
+	if in == nil {
+		return nil
+	}
+	out := &KubernetesSpec{
+		Git: GitSpec{
+			Repository:                in.GitRepository,
+			Branch:                    in.GitBranch,
+			WorkBranch:                in.WorkBranch,
+			SecretRef:                 in.GitSecretRef,
+			CredsCSI:                  in.GitCredsCSI,
+			AdditionalRepositories:    in.AdditionalRepositories,
+			SSHKnownHostsConfigMapRef: in.SSHKnownHostsConfigMapRef,
+			SSHStrictHostKeyChecking:  in.SSHStrictHostKeyChecking,
+		},
+	}
+	auth := AuthSpec{
+		ClaudeCredsSecretRef: in.ClaudeCredsSecretRef,
+		ClaudeCredsCSI:       in.ClaudeCredsCSI,
+		APIKeySecretRef:      in.ApiKeySecretRef,
+	}
+	if auth != (AuthSpec{}) {
+		out.Auth = &auth
+	}
+	runtime := RuntimeSpec{
+		Image:                     in.Image,
+		Resources:                 in.Resources,
+		ActiveDeadlineSeconds:     in.ActiveDeadlineSeconds,
+		PriorityClassName:         in.PriorityClassName,
+		NodeSelector:              in.NodeSelector,
+		Tolerations:               in.Tolerations,
+		Affinity:                  in.Affinity,
+		TopologySpreadConstraints: in.TopologySpreadConstraints,
+		DNSPolicy:                 in.DNSPolicy,
+		DNSConfig:                 in.DNSConfig,
+		HostAliases:               in.HostAliases,
+		ServiceAccountName:        in.ServiceAccountName,
+		ImagePullSecrets:          in.ImagePullSecrets,
+		PromptTemplateRef:         in.PromptTemplateRef,
+		Probes:                    in.Probes,
+		Checkpoint:                in.Checkpoint,
+	}
+	if !equality.Semantic.DeepEqual(runtime, RuntimeSpec{}) {
+		out.Runtime = &runtime
+	}
+	return out
+}
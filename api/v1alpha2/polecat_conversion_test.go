@@ -0,0 +1,157 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// fullHubPolecat returns a v1alpha1 Polecat setting every spec field, so a
+// field missing from the conversion is lost in the round trip.
+func fullHubPolecat() *v1alpha1.Polecat {
+	deadline := int64(1800)
+	ttl, idle, heartbeat, sync := int32(60), int32(300), int32(120), int32(30)
+	return &v1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "furiosa",
+			Namespace:   "gastown-system",
+			Labels:      map[string]string{"gastown.io/rig": "wasteland"},
+			Annotations: map[string]string{v1alpha1.PolecatProtectAnnotation: "true"},
+		},
+		Spec: v1alpha1.PolecatSpec{
+			Rig:             "wasteland",
+			DesiredState:    v1alpha1.PolecatDesiredWorking,
+			BeadID:          "wl-123",
+			TaskDescription: "Fix the war rig",
+			ExecutionMode:   v1alpha1.ExecutionModeKubernetes,
+			Kubernetes: &v1alpha1.KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitBranch:     "main",
+				WorkBranch:    "feature/wl-123",
+				GitSecretRef:  v1alpha1.SecretReference{Name: "git-creds"},
+				GitCredsCSI:   &v1alpha1.CSISecretVolume{VolumeAttributes: map[string]string{"secretProviderClass": "git"}},
+				AdditionalRepositories: []v1alpha1.AdditionalRepository{
+					{URL: "git@github.com:org/client.git", Path: "client"},
+				},
+				ClaudeCredsSecretRef:      &v1alpha1.SecretReference{Name: "claude-creds"},
+				ClaudeCredsCSI:            &v1alpha1.CSISecretVolume{VolumeAttributes: map[string]string{"secretProviderClass": "claude"}},
+				ApiKeySecretRef:           &v1alpha1.SecretKeyRef{Name: "api-key", Key: "key"},
+				Image:                     "registry.example.com/agent:v1",
+				Resources:                 &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}},
+				ActiveDeadlineSeconds:     &deadline,
+				PriorityClassName:         "agents",
+				NodeSelector:              map[string]string{"pool": "agents"},
+				Tolerations:               []corev1.Toleration{{Key: "agents", Operator: corev1.TolerationOpExists}},
+				Affinity:                  &corev1.Affinity{NodeAffinity: &corev1.NodeAffinity{}},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{MaxSkew: 1, TopologyKey: "zone"}},
+				DNSPolicy:                 corev1.DNSNone,
+				DNSConfig:                 &corev1.PodDNSConfig{Nameservers: []string{"10.0.0.10"}},
+				HostAliases:               []corev1.HostAlias{{IP: "10.0.0.2", Hostnames: []string{"git.internal"}}},
+				ServiceAccountName:        "agent",
+				ImagePullSecrets:          []corev1.LocalObjectReference{{Name: "registry"}},
+				SSHKnownHostsConfigMapRef: &corev1.LocalObjectReference{Name: "known-hosts"},
+				SSHStrictHostKeyChecking:  "yes",
+				PromptTemplateRef:         &corev1.LocalObjectReference{Name: "prompt"},
+				Probes:                    &v1alpha1.AgentProbeSpec{HeartbeatMaxAgeSeconds: 300},
+				Checkpoint:                &v1alpha1.CheckpointSpec{IntervalSeconds: 300},
+			},
+			Agent:                   v1alpha1.AgentTypeClaudeCode,
+			AgentConfig:             &v1alpha1.AgentConfig{Model: "claude-sonnet-4"},
+			Resources:               &corev1.ResourceRequirements{Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("1Gi")}},
+			TTLSecondsAfterFinished: &ttl,
+			MaxIdleSeconds:          &idle,
+			HeartbeatTimeoutSeconds: &heartbeat,
+			SyncIntervalSeconds:     &sync,
+			RestartOnSecretChange:   true,
+			MergePriority:           10,
+			MergeAfter:              []string{"nux"},
+			Urgent:                  true,
+			SplitOf:                 "slit",
+			Repositories:            []string{"frontend"},
+		},
+		Status: v1alpha1.PolecatStatus{
+			Phase:        v1alpha1.PolecatPhaseWorking,
+			AssignedBead: "wl-123",
+			PodName:      "polecat-furiosa",
+		},
+	}
+}
+
+// assertAllFieldsSet fails for the zero fields of the struct v, so that new
+// API fields are added to the conversion fixtures.
+func assertAllFieldsSet(t *testing.T, v any) {
+	t.Helper()
+	rv := reflect.ValueOf(v)
+	for i := range rv.NumField() {
+		assert.False(t, rv.Field(i).IsZero(), "fixture does not set %s.%s", rv.Type().Name(), rv.Type().Field(i).Name)
+	}
+}
+
+func TestPolecatConversionRoundTrip(t *testing.T) {
+	hub := fullHubPolecat()
+	assertAllFieldsSet(t, hub.Spec)
+	assertAllFieldsSet(t, *hub.Spec.Kubernetes)
+
+	var spoke Polecat
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Equal(t, "git@github.com:org/repo.git", spoke.Spec.Kubernetes.Git.Repository)
+	assert.Equal(t, "claude-creds", spoke.Spec.Kubernetes.Auth.ClaudeCredsSecretRef.Name)
+	assert.Equal(t, "registry.example.com/agent:v1", spoke.Spec.Kubernetes.Runtime.Image)
+
+	var back v1alpha1.Polecat
+	require.NoError(t, spoke.ConvertTo(&back))
+	assert.Equal(t, hub.ObjectMeta, back.ObjectMeta)
+	assert.Equal(t, hub.Spec, back.Spec)
+	assert.Equal(t, hub.Status, back.Status)
+}
+
+func TestPolecatConversionOmitsEmptySections(t *testing.T) {
+	hub := &v1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "gastown-system"},
+		Spec: v1alpha1.PolecatSpec{
+			Rig:          "wasteland",
+			DesiredState: v1alpha1.PolecatDesiredIdle,
+			Kubernetes: &v1alpha1.KubernetesSpec{
+				GitRepository: "https://github.com/org/repo.git",
+				GitSecretRef:  v1alpha1.SecretReference{Name: "git-creds"},
+			},
+		},
+	}
+
+	var spoke Polecat
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Nil(t, spoke.Spec.Kubernetes.Auth)
+	assert.Nil(t, spoke.Spec.Kubernetes.Runtime)
+
+	var back v1alpha1.Polecat
+	require.NoError(t, spoke.ConvertTo(&back))
+	assert.Equal(t, hub.Spec, back.Spec)
+
+	// Without spec.kubernetes there is nothing to split
+	hub.Spec.Kubernetes = nil
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Nil(t, spoke.Spec.Kubernetes)
+}
@@ -0,0 +1,347 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// KubernetesSpec defines configuration for kubernetes execution mode, in
+// sections for the repositories the agent works on, the credentials it
+// authenticates to its model with and the Pod it runs in
+type KubernetesSpec struct {
+	// Git is the repository the agent clones and pushes its work branch to
+	// +kubebuilder:validation:Required
+	Git GitSpec `json:"git"`
+
+	// Auth is the agent's credentials for its model provider
+	// +optional
+	Auth *AuthSpec `json:"auth,omitempty"`
+
+	// Runtime is the agent Pod's image, resources and scheduling. Settings
+	// left unset default to the rig's spec.polecatDefaults.runtime.
+	// +optional
+	Runtime *RuntimeSpec `json:"runtime,omitempty"`
+}
+
+// GitSpec is the repository a polecat works on and the credentials it
+// clones and pushes with
+// +kubebuilder:validation:XValidation:rule="has(self.secretRef) != has(self.credsCSI)",message="exactly one of secretRef and credsCSI is required"
+type GitSpec struct {
+	// Repository is the git repo URL to clone (SSH or HTTPS format)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^(git@[a-zA-Z0-9._-]+:|https?://[a-zA-Z0-9._-]+/)[a-zA-Z0-9._/-]+(\.git)?$`
+	Repository string `json:"repository"`
+
+	// Branch is the branch to checkout
+	// +kubebuilder:default=main
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._/-]+$`
+	// +optional
+	Branch string `json:"branch,omitempty"`
+
+	// WorkBranch is the branch name to create for work (defaults to feature/<beadID>)
+	// +kubebuilder:validation:Pattern=`^[a-zA-Z0-9._/-]+$`
+	// +optional
+	WorkBranch string `json:"workBranch,omitempty"`
+
+	// SecretRef references a Secret containing git credentials: an SSH key,
+	// or an HTTPS token (token or username/password keys), with which SSH
+	// repository URLs are rewritten to HTTPS. Required unless CredsCSI is
+	// provided.
+	// +optional
+	SecretRef v1alpha1.SecretReference `json:"secretRef,omitzero"`
+
+	// CredsCSI mounts the git credentials from a Secrets Store CSI driver
+	// volume instead of SecretRef, e.g. from Vault, so they are never
+	// stored in a Secret. The volume must provide the same files.
+	// +optional
+	CredsCSI *v1alpha1.CSISecretVolume `json:"credsCSI,omitempty"`
+
+	// AdditionalRepositories are further repos the bead spans (e.g. a client
+	// of the API in Repository), cloned next to it with the work branch
+	// checked out. Repository stays the primary repo: its work branch is
+	// the one the Refinery merges.
+	// +listType=map
+	// +listMapKey=path
+	// +kubebuilder:validation:MaxItems=8
+	// +optional
+	AdditionalRepositories []v1alpha1.AdditionalRepository `json:"additionalRepositories,omitempty"`
+
+	// SSHKnownHostsConfigMapRef references a ConfigMap containing SSH known_hosts
+	// If provided, uses the 'known_hosts' key from this ConfigMap instead of pre-populated keys.
+	// Use this for private Git servers or to override the default host key verification.
+	// +optional
+	SSHKnownHostsConfigMapRef *corev1.LocalObjectReference `json:"sshKnownHostsConfigMapRef,omitempty"`
+
+	// SSHStrictHostKeyChecking controls SSH host key verification behavior.
+	// Only "yes" is supported - hosts must be in known_hosts (most secure).
+	// For private Git servers, provide host keys via SSHKnownHostsConfigMapRef.
+	// +kubebuilder:validation:Enum=yes
+	// +kubebuilder:default=yes
+	// +optional
+	SSHStrictHostKeyChecking string `json:"sshStrictHostKeyChecking,omitempty"`
+}
+
+// AuthSpec is the credentials an agent authenticates to its model provider
+// with: the Claude OAuth credentials or an API key
+// +kubebuilder:validation:XValidation:rule="!has(self.claudeCredsSecretRef) || !has(self.claudeCredsCSI)",message="claudeCredsSecretRef and claudeCredsCSI are mutually exclusive"
+type AuthSpec struct {
+	// ClaudeCredsSecretRef references a Secret containing ~/.claude/ contents
+	// Required unless APIKeySecretRef is provided
+	// +optional
+	ClaudeCredsSecretRef *v1alpha1.SecretReference `json:"claudeCredsSecretRef,omitempty"`
+
+	// ClaudeCredsCSI mounts the ~/.claude/ contents from a Secrets Store CSI
+	// driver volume instead of ClaudeCredsSecretRef
+	// +optional
+	ClaudeCredsCSI *v1alpha1.CSISecretVolume `json:"claudeCredsCSI,omitempty"`
+
+	// APIKeySecretRef references a Secret containing the ANTHROPIC_API_KEY
+	// Alternative to ClaudeCredsSecretRef for headless API key authentication
+	// +optional
+	APIKeySecretRef *v1alpha1.SecretKeyRef `json:"apiKeySecretRef,omitempty"`
+}
+
+// RuntimeSpec is the agent Pod's image, resources and scheduling
+// +kubebuilder:validation:XValidation:rule="!has(self.dnsPolicy) || self.dnsPolicy != 'None' || has(self.dnsConfig)",message="dnsConfig is required with dnsPolicy None"
+type RuntimeSpec struct {
+	// Image overrides the default agent container image
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources for the agent container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// ActiveDeadlineSeconds is the max runtime before Pod is terminated
+	// (default 3600)
+	// +optional
+	ActiveDeadlineSeconds *int64 `json:"activeDeadlineSeconds,omitempty"`
+
+	// PriorityClassName is the PriorityClass of the agent Pod
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// NodeSelector restricts the agent Pod to nodes with these labels, e.g.
+	// a dedicated GPU or spot node pool
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations let the agent Pod run on tainted nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// Affinity constrains the nodes the agent Pod runs on, and its placement
+	// relative to other Pods
+	// +optional
+	Affinity *corev1.Affinity `json:"affinity,omitempty"`
+
+	// TopologySpreadConstraints spread agent Pods across zones or nodes
+	// +optional
+	TopologySpreadConstraints []corev1.TopologySpreadConstraint `json:"topologySpreadConstraints,omitempty"`
+
+	// DNSPolicy is the DNS policy of the agent Pod (default ClusterFirst)
+	// +kubebuilder:validation:Enum=ClusterFirstWithHostNet;ClusterFirst;Default;None
+	// +optional
+	DNSPolicy corev1.DNSPolicy `json:"dnsPolicy,omitempty"`
+
+	// DNSConfig adds nameservers, search domains and resolver options to
+	// the agent Pod, e.g. to resolve internal git hosts and registries.
+	// Required with dnsPolicy None.
+	// +optional
+	DNSConfig *corev1.PodDNSConfig `json:"dnsConfig,omitempty"`
+
+	// HostAliases are added to the agent Pod's /etc/hosts
+	// +optional
+	HostAliases []corev1.HostAlias `json:"hostAliases,omitempty"`
+
+	// ServiceAccountName is the ServiceAccount the agent Pod runs as. Defaults
+	// to the rig's agentServiceAccount when it has one, else the namespace's
+	// default ServiceAccount.
+	// +kubebuilder:validation:MaxLength=253
+	// +optional
+	ServiceAccountName string `json:"serviceAccountName,omitempty"`
+
+	// ImagePullSecrets are used to pull the images of the agent Pod from
+	// private registries
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+
+	// PromptTemplateRef references a ConfigMap whose 'prompt.tmpl' key holds a
+	// Go template for the agent prompt, replacing the built-in prompt. The
+	// template is rendered with the agent context (.Polecat, .Namespace, .Rig,
+	// .Bead, .Convoy, .Task, .Branch.Repository, .Branch.Base, .Branch.Work).
+	// +optional
+	PromptTemplateRef *corev1.LocalObjectReference `json:"promptTemplateRef,omitempty"`
+
+	// Probes configures liveness/startup probes on the agent container.
+	// If nil, no probes are added and a hung agent is only caught by the
+	// Witness stuck threshold or ActiveDeadlineSeconds.
+	// +optional
+	Probes *v1alpha1.AgentProbeSpec `json:"probes,omitempty"`
+
+	// Checkpoint commits and pushes the agent's work in progress to the work
+	// branch periodically. A Pod replacing one that ran past
+	// activeDeadlineSeconds, or failed, resumes from the work branch instead
+	// of cloning the base branch again.
+	// +optional
+	Checkpoint *v1alpha1.CheckpointSpec `json:"checkpoint,omitempty"`
+}
+
+// PolecatSpec defines the desired state of Polecat
+type PolecatSpec struct {
+	// Rig is the name of the rig this polecat belongs to
+	// +kubebuilder:validation:Required
+	Rig string `json:"rig"`
+
+	// DesiredState is the target lifecycle state
+	// +kubebuilder:validation:Required
+	// +kubebuilder:default=Idle
+	DesiredState v1alpha1.PolecatDesiredState `json:"desiredState"`
+
+	// BeadID is the bead to hook (triggers gt sling if set)
+	// +optional
+	BeadID string `json:"beadID,omitempty"`
+
+	// TaskDescription provides the full task details for the polecat to work on.
+	// Used when beads are not synced to the target repository.
+	// +optional
+	TaskDescription string `json:"taskDescription,omitempty"`
+
+	// ExecutionMode determines where the polecat runs
+	// +kubebuilder:default=kubernetes
+	// +optional
+	ExecutionMode v1alpha1.ExecutionMode `json:"executionMode,omitempty"`
+
+	// Kubernetes contains configuration for kubernetes execution mode
+	// Required when executionMode is "kubernetes"
+	// +optional
+	Kubernetes *KubernetesSpec `json:"kubernetes,omitempty"`
+
+	// Agent is the coding agent type to use
+	// +kubebuilder:default=claude-code
+	// +optional
+	Agent v1alpha1.AgentType `json:"agent,omitempty"`
+
+	// AgentConfig provides configuration for the coding agent
+	// +optional
+	AgentConfig *v1alpha1.AgentConfig `json:"agentConfig,omitempty"`
+
+	// Resources defines compute resources for the polecat pod
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// TTLSecondsAfterFinished deletes the polecat this many seconds after it
+	// reaches Done or Terminated. Done polecats whose rig has a Refinery are
+	// kept until their branch is merged.
+	// +kubebuilder:validation:Minimum=0
+	// +optional
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+
+	// MaxIdleSeconds terminates polecat if idle for this duration
+	// +optional
+	MaxIdleSeconds *int32 `json:"maxIdleSeconds,omitempty"`
+
+	// HeartbeatTimeoutSeconds marks the polecat Stuck when its agent has not
+	// touched the heartbeat file for this long while the Pod runs. The agent
+	// container gets a readiness probe on the heartbeat, so a stalled agent is
+	// reported, not restarted; see spec.kubernetes.runtime.probes for restarts.
+	// +kubebuilder:validation:Minimum=30
+	// +optional
+	HeartbeatTimeoutSeconds *int32 `json:"heartbeatTimeoutSeconds,omitempty"`
+
+	// SyncIntervalSeconds is how often the polecat's status is re-synced from
+	// its Pod while it works. Defaults to the GastownConfig's requeue.short.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	SyncIntervalSeconds *int32 `json:"syncIntervalSeconds,omitempty"`
+
+	// RestartOnSecretChange recreates the agent Pod when a Secret it mounts
+	// or reads, e.g. the git or Claude credentials, is rotated, so the agent
+	// works with the new credentials. Work in the Pod that was not pushed is
+	// lost with it.
+	// +optional
+	RestartOnSecretChange bool `json:"restartOnSecretChange,omitempty"`
+
+	// MergePriority orders this polecat's branch in the Refinery merge queue
+	// when the Refinery uses the "priority" queue policy. Higher merges first.
+	// +optional
+	MergePriority int32 `json:"mergePriority,omitempty"`
+
+	// MergeAfter lists polecats (in the same namespace) whose branches must be
+	// merged before the Refinery considers this polecat's branch.
+	// +optional
+	MergeAfter []string `json:"mergeAfter,omitempty"`
+
+	// Urgent starts the polecat outside its rig's execution windows
+	// +optional
+	Urgent bool `json:"urgent,omitempty"`
+
+	// SplitOf makes this a planning polecat: instead of working on the bead,
+	// its agent proposes splitting it into smaller beads, because the named
+	// polecat ran out of time or budget on it. Planning polecats are created
+	// by the operator for rigs with settings.suggestSplits and are never merged.
+	// +optional
+	SplitOf string `json:"splitOf,omitempty"`
+
+	// Repositories names the rig repositories (the Rig's spec.repositories)
+	// the task spans. Each is cloned into /workspace/<name> on the work
+	// branch, and the Refinery merges the branch in each.
+	// +listType=set
+	// +optional
+	Repositories []string `json:"repositories,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:shortName=pc
+// +kubebuilder:printcolumn:name="Rig",type="string",JSONPath=".spec.rig"
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Bead",type="string",JSONPath=".status.assignedBead"
+// +kubebuilder:printcolumn:name="Pod",type="string",JSONPath=".status.podName"
+// +kubebuilder:printcolumn:name="Active",type="boolean",JSONPath=".status.podActive"
+// +kubebuilder:printcolumn:name="Model",type="string",JSONPath=".status.agentModel",priority=1
+// +kubebuilder:printcolumn:name="Est. Cost",type="string",JSONPath=".status.costEstimate.usd",priority=1
+// +kubebuilder:printcolumn:name="Cost",type="string",JSONPath=".status.usage.usd",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Polecat is the Schema for the polecats API.
+// A Polecat is an autonomous worker agent within a Rig.
+type Polecat struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   PolecatSpec            `json:"spec,omitempty"`
+	Status v1alpha1.PolecatStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// PolecatList contains a list of Polecat
+type PolecatList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Polecat `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Polecat{}, &PolecatList{})
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"k8s.io/apimachinery/pkg/api/equality"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/conversion"
+
+	"github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// SetupRigWebhookWithManager registers the Rig conversion webhook with the
+// manager.
+func SetupRigWebhookWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewWebhookManagedBy(mgr, &Rig{}).Complete()
+}
+
+var _ conversion.Convertible = &Rig{}
+
+// ConvertTo converts this Rig to the hub version (v1alpha1).
+func (src *Rig) ConvertTo(dstRaw conversion.Hub) error {
+	dst := dstRaw.(*v1alpha1.Rig)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = v1alpha1.RigSpec{
+		GitURL:              src.Spec.GitURL,
+		BeadsPrefix:         src.Spec.BeadsPrefix,
+		Settings:            src.Spec.Settings,
+		SyncIntervalSeconds: src.Spec.SyncIntervalSeconds,
+		ChildNamespace:      src.Spec.ChildNamespace,
+		CreateNamespace:     src.Spec.CreateNamespace,
+		NamespaceQuota:      src.Spec.NamespaceQuota,
+		Suspend:             src.Spec.Suspend,
+		LogArchive:          src.Spec.LogArchive,
+		BranchCleanup:       src.Spec.BranchCleanup,
+		ExecutionWindows:    src.Spec.ExecutionWindows,
+		Budget:              src.Spec.Budget,
+		AgentServiceAccount: src.Spec.AgentServiceAccount,
+		NetworkPolicy:       src.Spec.NetworkPolicy,
+		Signing:             src.Spec.Signing,
+		Repositories:        src.Spec.Repositories,
+		WarmPool:            src.Spec.WarmPool,
+	}
+	if defaults := src.Spec.PolecatDefaults; defaults != nil && defaults.Runtime != nil {
+		runtime := defaults.Runtime
+		dst.Spec.ImagePullSecrets = runtime.ImagePullSecrets
+		hubDefaults := v1alpha1.PolecatDefaults{
+			Image:             runtime.Image,
+			Resources:         runtime.Resources,
+			PriorityClassName: runtime.PriorityClassName,
+			NodeSelector:      runtime.NodeSelector,
+			Tolerations:       runtime.Tolerations,
+		}
+		if !equality.Semantic.DeepEqual(hubDefaults, v1alpha1.PolecatDefaults{}) {
+			dst.Spec.PolecatDefaults = &hubDefaults
+		}
+	}
+	dst.Status = src.Status
+	return nil
+}
+
+// ConvertFrom converts the hub version (v1alpha1) to this Rig.
+func (dst *Rig) ConvertFrom(srcRaw conversion.Hub) error {
+	src := srcRaw.(*v1alpha1.Rig)
+	dst.ObjectMeta = src.ObjectMeta
+	dst.Spec = RigSpec{
+		GitURL:              src.Spec.GitURL,
+		BeadsPrefix:         src.Spec.BeadsPrefix,
+		Settings:            src.Spec.Settings,
+		SyncIntervalSeconds: src.Spec.SyncIntervalSeconds,
+		ChildNamespace:      src.Spec.ChildNamespace,
+		CreateNamespace:     src.Spec.CreateNamespace,
+		NamespaceQuota:      src.Spec.NamespaceQuota,
+		Suspend:             src.Spec.Suspend,
+		LogArchive:          src.Spec.LogArchive,
+		BranchCleanup:       src.Spec.BranchCleanup,
+		ExecutionWindows:    src.Spec.ExecutionWindows,
+		Budget:              src.Spec.Budget,
+		AgentServiceAccount: src.Spec.AgentServiceAccount,
+		NetworkPolicy:       src.Spec.NetworkPolicy,
+		Signing:             src.Spec.Signing,
+		Repositories:        src.Spec.Repositories,
+		WarmPool:            src.Spec.WarmPool,
+	}
+	runtime := RuntimeDefaults{ImagePullSecrets: src.Spec.ImagePullSecrets}
+	if defaults := src.Spec.PolecatDefaults; defaults != nil {
+		runtime.Image = defaults.Image
+		runtime.Resources = defaults.Resources
+		runtime.PriorityClassName = defaults.PriorityClassName
+		runtime.NodeSelector = defaults.NodeSelector
+		runtime.Tolerations = defaults.Tolerations
+	}
+	if !equality.Semantic.DeepEqual(runtime, RuntimeDefaults{}) {
+		dst.Spec.PolecatDefaults = &PolecatDefaults{Runtime: &runtime}
+	}
+	dst.Status = src.Status
+	return nil
+}
@@ -0,0 +1,117 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// fullHubRig returns a v1alpha1 Rig setting every spec field.
+func fullHubRig() *v1alpha1.Rig {
+	sync, afterDays := int32(60), int32(7)
+	return &v1alpha1.Rig{
+		ObjectMeta: metav1.ObjectMeta{Name: "wasteland"},
+		Spec: v1alpha1.RigSpec{
+			GitURL:              "git@github.com:org/repo.git",
+			BeadsPrefix:         "wl",
+			Settings:            v1alpha1.RigSettings{MaxPolecats: 4},
+			SyncIntervalSeconds: &sync,
+			ChildNamespace:      "gt-wasteland",
+			CreateNamespace:     true,
+			NamespaceQuota:      corev1.ResourceList{corev1.ResourceRequestsCPU: resource.MustParse("8")},
+			Suspend:             true,
+			LogArchive: &v1alpha1.LogArchiveSpec{
+				Bucket:               "logs",
+				CredentialsSecretRef: v1alpha1.SecretReference{Name: "s3"},
+			},
+			BranchCleanup: &v1alpha1.BranchCleanupSpec{AfterDays: &afterDays},
+			ExecutionWindows: []v1alpha1.ExecutionWindow{
+				{Schedule: "0 22 * * *", Duration: metav1.Duration{Duration: 8 * time.Hour}},
+			},
+			Budget:              &v1alpha1.RigBudget{MaxUSDPerDay: "50"},
+			AgentServiceAccount: &v1alpha1.AgentServiceAccountSpec{ClusterRole: "view"},
+			NetworkPolicy: &v1alpha1.RigNetworkPolicy{
+				Egress: []v1alpha1.RigEgressRule{{CIDR: "0.0.0.0/0", Ports: []int32{443}}},
+			},
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+			PolecatDefaults: &v1alpha1.PolecatDefaults{
+				Image:             "registry.example.com/agent:v1",
+				Resources:         &corev1.ResourceRequirements{Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("2")}},
+				PriorityClassName: "agents",
+				NodeSelector:      map[string]string{"pool": "agents"},
+				Tolerations:       []corev1.Toleration{{Key: "agents", Operator: corev1.TolerationOpExists}},
+			},
+			Signing:      &v1alpha1.CommitSigningSpec{KeySecretRef: v1alpha1.SecretReference{Name: "signing"}},
+			Repositories: []v1alpha1.RigRepository{{Name: "frontend", GitURL: "git@github.com:org/frontend.git"}},
+			WarmPool:     &v1alpha1.WarmPoolSpec{Size: 2, GitBranch: "main"},
+		},
+		Status: v1alpha1.RigStatus{Phase: v1alpha1.RigPhaseReady, ChildNamespace: "gt-wasteland"},
+	}
+}
+
+func TestRigConversionRoundTrip(t *testing.T) {
+	hub := fullHubRig()
+	assertAllFieldsSet(t, hub.Spec)
+	assertAllFieldsSet(t, *hub.Spec.PolecatDefaults)
+
+	var spoke Rig
+	require.NoError(t, spoke.ConvertFrom(hub))
+	runtime := spoke.Spec.PolecatDefaults.Runtime
+	assert.Equal(t, "registry.example.com/agent:v1", runtime.Image)
+	assert.Equal(t, []corev1.LocalObjectReference{{Name: "registry"}}, runtime.ImagePullSecrets)
+
+	var back v1alpha1.Rig
+	require.NoError(t, spoke.ConvertTo(&back))
+	assert.Equal(t, hub.ObjectMeta, back.ObjectMeta)
+	assert.Equal(t, hub.Spec, back.Spec)
+	assert.Equal(t, hub.Status, back.Status)
+}
+
+func TestRigConversionImagePullSecretsOnly(t *testing.T) {
+	hub := &v1alpha1.Rig{
+		ObjectMeta: metav1.ObjectMeta{Name: "wasteland"},
+		Spec: v1alpha1.RigSpec{
+			GitURL:           "git@github.com:org/repo.git",
+			BeadsPrefix:      "wl",
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+		},
+	}
+
+	var spoke Rig
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Equal(t, &PolecatDefaults{Runtime: &RuntimeDefaults{
+		ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+	}}, spoke.Spec.PolecatDefaults)
+
+	// The v1alpha1 polecatDefaults stay unset when only imagePullSecrets are
+	var back v1alpha1.Rig
+	require.NoError(t, spoke.ConvertTo(&back))
+	assert.Equal(t, hub.Spec, back.Spec)
+
+	hub.Spec.ImagePullSecrets = nil
+	require.NoError(t, spoke.ConvertFrom(hub))
+	assert.Nil(t, spoke.Spec.PolecatDefaults)
+}
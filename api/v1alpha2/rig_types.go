@@ -0,0 +1,214 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package v1alpha2
+
+import (
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// RigSpec defines the desired state of Rig
+type RigSpec struct {
+	// GitURL is the remote repository URL
+	// +kubebuilder:validation:Required
+	GitURL string `json:"gitURL"`
+
+	// BeadsPrefix is the prefix for beads issues (e.g., "ap" for ap-*)
+	// +kubebuilder:validation:Required
+	// +kubebuilder:validation:Pattern=`^[a-z]{2,10}$`
+	BeadsPrefix string `json:"beadsPrefix"`
+
+	// Settings for the rig
+	// +optional
+	Settings v1alpha1.RigSettings `json:"settings,omitempty"`
+
+	// SyncIntervalSeconds is how often the rig's status is re-synced, e.g.
+	// shorter for busy rigs and longer for quiet ones. Defaults to the
+	// GastownConfig's requeue.default.
+	// +kubebuilder:validation:Minimum=5
+	// +kubebuilder:validation:Maximum=3600
+	// +optional
+	SyncIntervalSeconds *int32 `json:"syncIntervalSeconds,omitempty"`
+
+	// ChildNamespace is the namespace the rig's Witness, Refinery and
+	// Polecats are placed in. Defaults to the operator-wide namespace
+	// (GASTOWN_NAMESPACE, gastown-system). Immutable.
+	// +kubebuilder:validation:MaxLength=63
+	// +kubebuilder:validation:Pattern=`^[a-z0-9]([-a-z0-9]*[a-z0-9])?$`
+	// +optional
+	ChildNamespace string `json:"childNamespace,omitempty"`
+
+	// CreateNamespace provisions ChildNamespace for the rig: the namespace is
+	// labeled with the rig, limited by a ResourceQuota and isolated by a
+	// NetworkPolicy, and is deleted with the rig. Requires childNamespace.
+	// +optional
+	CreateNamespace bool `json:"createNamespace,omitempty"`
+
+	// NamespaceQuota is the hard limits of the provisioned namespace's
+	// ResourceQuota (e.g., requests.cpu, limits.memory). "pods" defaults to
+	// settings.maxPolecats plus headroom for the rig's Jobs.
+	// +optional
+	NamespaceQuota corev1.ResourceList `json:"namespaceQuota,omitempty"`
+
+	// Suspend freezes the rig: new polecats do not start and the Refinery
+	// stops merging. Polecats already running finish their current work.
+	// +optional
+	Suspend bool `json:"suspend,omitempty"`
+
+	// LogArchive uploads the full agent log of failed polecats to an
+	// object storage bucket. The last few KB are always kept in the
+	// Polecat's status.lastLogs.
+	// +optional
+	LogArchive *v1alpha1.LogArchiveSpec `json:"logArchive,omitempty"`
+
+	// BranchCleanup deletes polecat branches that stay on the remote after
+	// they merged (pull request merges, or merges that kept the source branch).
+	// Executed periodically by the rig's Refinery.
+	// +optional
+	BranchCleanup *v1alpha1.BranchCleanupSpec `json:"branchCleanup,omitempty"`
+
+	// ExecutionWindows restricts when new polecats start, e.g. to off-peak
+	// hours when API rates and cluster capacity are cheap. A polecat created
+	// outside every window waits with a WaitingForWindow condition unless it
+	// is urgent. Empty allows polecats to start at any time.
+	// +optional
+	ExecutionWindows []v1alpha1.ExecutionWindow `json:"executionWindows,omitempty"`
+
+	// Budget caps what the rig's agents spend, as measured from the token
+	// usage they report
+	// +optional
+	Budget *v1alpha1.RigBudget `json:"budget,omitempty"`
+
+	// AgentServiceAccount provisions a ServiceAccount for the rig's agent
+	// Pods in the child namespace, e.g. for agents that deploy preview
+	// environments with kubectl. Polecats without
+	// spec.kubernetes.runtime.serviceAccountName run as it.
+	// +optional
+	AgentServiceAccount *v1alpha1.AgentServiceAccountSpec `json:"agentServiceAccount,omitempty"`
+
+	// NetworkPolicy restricts the egress of the rig's Pods, those labeled
+	// gastown.io/rig=<rig> in the child namespace, to the destinations it
+	// allows
+	// +optional
+	NetworkPolicy *v1alpha1.RigNetworkPolicy `json:"networkPolicy,omitempty"`
+
+	// PolecatDefaults are the settings of the rig's polecats that do not set
+	// their own
+	// +optional
+	PolecatDefaults *PolecatDefaults `json:"polecatDefaults,omitempty"`
+
+	// Signing signs the commits of the rig's agents and the tags and
+	// rebased commits of its Refinery, which merges only branches whose
+	// commits carry a good signature of the key
+	// +optional
+	Signing *v1alpha1.CommitSigningSpec `json:"signing,omitempty"`
+
+	// Repositories are further repositories of the rig, e.g. the frontend of
+	// a rig whose gitURL is the backend. Polecats whose task spans them list
+	// them in spec.repositories; the Refinery merges the polecat's branch in
+	// each of them.
+	// +listType=map
+	// +listMapKey=name
+	// +kubebuilder:validation:MaxItems=16
+	// +optional
+	Repositories []v1alpha1.RigRepository `json:"repositories,omitempty"`
+
+	// WarmPool keeps agent Pods of the rig cloned and waiting for work in
+	// the child namespace. A polecat whose Pod would be built like them is
+	// assigned to one instead of starting a Pod.
+	// +optional
+	WarmPool *v1alpha1.WarmPoolSpec `json:"warmPool,omitempty"`
+}
+
+// PolecatDefaults are the settings a rig's polecats inherit, in the sections
+// of a Polecat's spec.kubernetes
+type PolecatDefaults struct {
+	// Runtime are the agent Pod settings of polecats leaving the matching
+	// spec.kubernetes.runtime field unset
+	// +optional
+	Runtime *RuntimeDefaults `json:"runtime,omitempty"`
+}
+
+// RuntimeDefaults are the agent Pod settings a rig's polecats inherit
+type RuntimeDefaults struct {
+	// Image is the agent container image, unless the polecat's agentConfig
+	// sets one
+	// +optional
+	Image string `json:"image,omitempty"`
+
+	// Resources are the resource requirements of the agent container
+	// +optional
+	Resources *corev1.ResourceRequirements `json:"resources,omitempty"`
+
+	// PriorityClassName is the PriorityClass of the agent Pods
+	// +optional
+	PriorityClassName string `json:"priorityClassName,omitempty"`
+
+	// NodeSelector constrains the agent Pods to matching nodes
+	// +optional
+	NodeSelector map[string]string `json:"nodeSelector,omitempty"`
+
+	// Tolerations let the agent Pods schedule onto tainted nodes
+	// +optional
+	Tolerations []corev1.Toleration `json:"tolerations,omitempty"`
+
+	// ImagePullSecrets pull the images of the agent Pods. The Secrets must
+	// exist in the polecats' namespace.
+	// +optional
+	ImagePullSecrets []corev1.LocalObjectReference `json:"imagePullSecrets,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+// +kubebuilder:subresource:status
+// +kubebuilder:resource:scope=Cluster
+// +kubebuilder:printcolumn:name="Phase",type="string",JSONPath=".status.phase"
+// +kubebuilder:printcolumn:name="Suspended",type="boolean",JSONPath=".spec.suspend"
+// +kubebuilder:printcolumn:name="Polecats",type="integer",JSONPath=".status.polecatCount"
+// +kubebuilder:printcolumn:name="Working",type="integer",JSONPath=".status.polecats.working"
+// +kubebuilder:printcolumn:name="Idle",type="integer",JSONPath=".status.polecats.idle",priority=1
+// +kubebuilder:printcolumn:name="Stuck",type="integer",JSONPath=".status.polecats.stuck"
+// +kubebuilder:printcolumn:name="Done",type="integer",JSONPath=".status.polecats.done",priority=1
+// +kubebuilder:printcolumn:name="Terminated",type="integer",JSONPath=".status.polecats.terminated",priority=1
+// +kubebuilder:printcolumn:name="Convoys",type="integer",JSONPath=".status.activeConvoys"
+// +kubebuilder:printcolumn:name="Last Merge",type="date",JSONPath=".status.lastMergeTime"
+// +kubebuilder:printcolumn:name="Spent Today",type="string",JSONPath=".status.usage.dayUSD",priority=1
+// +kubebuilder:printcolumn:name="Age",type="date",JSONPath=".metadata.creationTimestamp"
+
+// Rig is the Schema for the rigs API.
+// A Rig represents a project workspace containing crew and polecats.
+type Rig struct {
+	metav1.TypeMeta   `json:",inline"`
+	metav1.ObjectMeta `json:"metadata,omitempty"`
+
+	Spec   RigSpec            `json:"spec,omitempty"`
+	Status v1alpha1.RigStatus `json:"status,omitempty"`
+}
+
+// +kubebuilder:object:root=true
+
+// RigList contains a list of Rig
+type RigList struct {
+	metav1.TypeMeta `json:",inline"`
+	metav1.ListMeta `json:"metadata,omitempty"`
+	Items           []Rig `json:"items"`
+}
+
+func init() {
+	SchemeBuilder.Register(&Rig{}, &RigList{})
+}
@@ -22,9 +22,10 @@ func newAuthCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "auth",
 		Short: "Manage Claude authentication",
-		Long:  `Commands for syncing and checking Claude credentials.`,
+		Long:  `Commands for logging in, syncing and checking Claude credentials.`,
 	}
 
+	cmd.AddCommand(newAuthLoginCmd())
 	cmd.AddCommand(newAuthSyncCmd())
 	cmd.AddCommand(newAuthStatusCmd())
 
@@ -84,30 +85,9 @@ func runAuthSync(claudeDir string, force bool) error {
 		return fmt.Errorf("claude config directory not found: %s (run 'claude login' first)", claudeDir)
 	}
 
-	// Read all files in Claude directory
-	data := make(map[string][]byte)
-	err := filepath.Walk(claudeDir, func(path string, info os.FileInfo, err error) error {
-		if err != nil {
-			return err
-		}
-		if info.IsDir() {
-			return nil
-		}
-		relPath, _ := filepath.Rel(claudeDir, path)
-		// nolint:gosec // G304: path is constrained to claudeDir by filepath.Walk
-		content, err := os.ReadFile(path)
-		if err != nil {
-			return err
-		}
-		data[relPath] = content
-		return nil
-	})
+	data, err := readClaudeDir(claudeDir)
 	if err != nil {
-		return fmt.Errorf("failed to read Claude directory: %w", err)
-	}
-
-	if len(data) == 0 {
-		return fmt.Errorf("no files found in %s", claudeDir)
+		return err
 	}
 
 	// Get kubernetes client
@@ -147,9 +127,9 @@ func runAuthSync(claudeDir string, force bool) error {
 	}
 
 	if existing != nil && existing.Name != "" {
-		_, err = clientset.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{})
+		_, err = clientset.CoreV1().Secrets(namespace).Update(ctx, secret, metav1.UpdateOptions{FieldManager: fieldManager})
 	} else {
-		_, err = clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{})
+		_, err = clientset.CoreV1().Secrets(namespace).Create(ctx, secret, metav1.CreateOptions{FieldManager: fieldManager})
 	}
 
 	if err != nil {
@@ -206,6 +186,36 @@ func runAuthStatus() error {
 	return nil
 }
 
+// readClaudeDir reads the files of the Claude config directory, keyed by
+// their path relative to it.
+func readClaudeDir(claudeDir string) (map[string][]byte, error) {
+	data := make(map[string][]byte)
+	err := filepath.Walk(claudeDir, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			return nil
+		}
+		relPath, _ := filepath.Rel(claudeDir, path)
+		// nolint:gosec // G304: path is constrained to claudeDir by filepath.Walk
+		content, err := os.ReadFile(path)
+		if err != nil {
+			return err
+		}
+		data[relPath] = content
+		return nil
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to read Claude directory: %w", err)
+	}
+
+	if len(data) == 0 {
+		return nil, fmt.Errorf("no files found in %s", claudeDir)
+	}
+	return data, nil
+}
+
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
 		return fmt.Sprintf("%ds", int(d.Seconds()))
@@ -0,0 +1,331 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// claudeCredentialsFile is the file the Claude CLI stores its OAuth
+// credentials in, and the Secret key the agent Pods copy them from.
+const claudeCredentialsFile = ".credentials.json"
+
+// verifyPrompt is the prompt of the validation Pod.
+const verifyPrompt = "Reply with the single word hello."
+
+// claudeLogin runs the interactive Claude OAuth login, storing the
+// credentials in configDir. Replaced in tests.
+var claudeLogin = func(ctx context.Context, claudeBin, configDir string) error {
+	// nolint:gosec // G204: the binary is the user's own --claude-bin
+	login := exec.CommandContext(ctx, claudeBin, "/login")
+	login.Env = append(os.Environ(), "CLAUDE_CONFIG_DIR="+configDir)
+	login.Stdin = os.Stdin
+	login.Stdout = os.Stdout
+	login.Stderr = os.Stderr
+	if err := login.Run(); err != nil {
+		return fmt.Errorf("claude login failed: %w", err)
+	}
+	return nil
+}
+
+// authLoginOptions holds the flags of kubectl gt auth login.
+type authLoginOptions struct {
+	rig             string
+	claudeBin       string
+	credentialsFile string
+	verify          bool
+	verifyImage     string
+	verifyTimeout   time.Duration
+}
+
+func newAuthLoginCmd() *cobra.Command {
+	var opts authLoginOptions
+
+	cmd := &cobra.Command{
+		Use:   "login",
+		Short: "Log in to Claude and store the credentials in the cluster",
+		Long: `Run the Claude OAuth login locally and store the resulting .credentials.json
+in the claude-creds Secret, creating or updating it.
+
+The login runs in a temporary config directory, so your own ~/.claude/ is left
+untouched: complete the login in the browser, then exit Claude. The Secret is
+created in the rig's childNamespace with --rig, else in the target namespace.
+Other keys of an existing Secret, such as settings.json, are kept.
+
+With --verify a short-lived Pod asks Claude for a "hello" with the new
+credentials, exactly as polecats will use them, and is deleted afterwards.`,
+		Example: `  # Log in and store the credentials for a rig's polecats
+  kubectl gt auth login --rig my-rig
+
+  # Also check the credentials work in the cluster
+  kubectl gt auth login --rig my-rig --verify
+
+  # Upload credentials from an earlier login instead
+  kubectl gt auth login -n gastown --credentials-file ~/.claude/.credentials.json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runAuthLogin(context.Background(), cmd.OutOrStdout(), opts)
+		},
+	}
+
+	cmd.Flags().StringVar(&opts.rig, "rig", "", "Store the Secret in the namespace of this rig's polecats")
+	cmd.Flags().StringVar(&opts.claudeBin, "claude-bin", "claude", "Claude CLI used for the login")
+	cmd.Flags().StringVar(&opts.credentialsFile, "credentials-file", "",
+		"Upload this .credentials.json instead of logging in")
+	cmd.Flags().BoolVar(&opts.verify, "verify", false, "Check the credentials with a short-lived Pod")
+	cmd.Flags().StringVar(&opts.verifyImage, "verify-image", "",
+		"Image of the validation Pod (default: the polecat agent image)")
+	cmd.Flags().DurationVar(&opts.verifyTimeout, "verify-timeout", 5*time.Minute,
+		"How long to wait for the validation Pod")
+
+	return cmd
+}
+
+func runAuthLogin(ctx context.Context, out io.Writer, opts authLoginOptions) error {
+	namespace := GetNamespace()
+	if opts.rig != "" {
+		client, err := newDynamicClient()
+		if err != nil {
+			return err
+		}
+		rig, err := client.Resource(rigGVR).Get(ctx, opts.rig, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("rig %s not found: %w", opts.rig, err)
+		}
+		namespace = rigNamespace(rig)
+	}
+
+	credentials, err := captureClaudeCredentials(ctx, opts)
+	if err != nil {
+		return err
+	}
+
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if err := storeClaudeCredentials(ctx, clientset, namespace, credentials); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintf(out, "Stored Claude credentials in Secret %s/%s\n", namespace, claudeCredsSecretName)
+
+	if !opts.verify {
+		return nil
+	}
+	image := opts.verifyImage
+	if image == "" {
+		image = pod.GetClaudeImage()
+	}
+	_, _ = fmt.Fprintf(out, "Verifying the credentials with %s...\n", image)
+	if err := verifyClaudeCredentials(ctx, clientset, namespace, image, opts.verifyTimeout, 2*time.Second, out); err != nil {
+		return err
+	}
+	_, _ = fmt.Fprintln(out, "Credentials verified: polecats can use them.")
+	return nil
+}
+
+// captureClaudeCredentials returns the .credentials.json of --credentials-file,
+// else of a fresh Claude login.
+func captureClaudeCredentials(ctx context.Context, opts authLoginOptions) ([]byte, error) {
+	path := opts.credentialsFile
+	if path == "" {
+		configDir, err := os.MkdirTemp("", "gt-auth-login-*")
+		if err != nil {
+			return nil, fmt.Errorf("failed to create temp dir: %w", err)
+		}
+		defer func() {
+			_ = os.RemoveAll(configDir) //nolint:errcheck // best-effort cleanup
+		}()
+
+		if err := claudeLogin(ctx, opts.claudeBin, configDir); err != nil {
+			return nil, err
+		}
+		path = filepath.Join(configDir, claudeCredentialsFile)
+	}
+
+	// nolint:gosec // G304: the path is the login's own or the user's flag
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && opts.credentialsFile == "" {
+		return nil, fmt.Errorf("the login wrote no %s; on macOS the Claude CLI keeps credentials "+
+			"in the Keychain, export them to a file and pass --credentials-file", claudeCredentialsFile)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read credentials: %w", err)
+	}
+	if err := validateClaudeCredentials(data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// validateClaudeCredentials checks data is a .credentials.json with an OAuth
+// access token.
+func validateClaudeCredentials(data []byte) error {
+	var credentials struct {
+		ClaudeAiOauth struct {
+			AccessToken string `json:"accessToken"`
+		} `json:"claudeAiOauth"`
+	}
+	if err := json.Unmarshal(data, &credentials); err != nil {
+		return fmt.Errorf("invalid %s: %w", claudeCredentialsFile, err)
+	}
+	if credentials.ClaudeAiOauth.AccessToken == "" {
+		return fmt.Errorf("%s has no OAuth access token; did the login complete?", claudeCredentialsFile)
+	}
+	return nil
+}
+
+// storeClaudeCredentials creates the claude-creds Secret in namespace, or
+// replaces the credentials of the existing one, keeping its other keys.
+func storeClaudeCredentials(ctx context.Context, clientset kubernetes.Interface, namespace string, credentials []byte) error {
+	secrets := clientset.CoreV1().Secrets(namespace)
+	now := time.Now().UTC().Format(time.RFC3339)
+
+	secret, err := secrets.Get(ctx, claudeCredsSecretName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		secret = &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        claudeCredsSecretName,
+				Namespace:   namespace,
+				Annotations: map[string]string{syncTimestampKey: now},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: map[string][]byte{claudeCredentialsFile: credentials},
+		}
+		if _, err := secrets.Create(ctx, secret, metav1.CreateOptions{FieldManager: fieldManager}); err != nil {
+			return fmt.Errorf("failed to create Secret: %w", err)
+		}
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get Secret: %w", err)
+	}
+
+	if secret.Data == nil {
+		secret.Data = map[string][]byte{}
+	}
+	secret.Data[claudeCredentialsFile] = credentials
+	if secret.Annotations == nil {
+		secret.Annotations = map[string]string{}
+	}
+	secret.Annotations[syncTimestampKey] = now
+	if _, err := secrets.Update(ctx, secret, metav1.UpdateOptions{FieldManager: fieldManager}); err != nil {
+		return fmt.Errorf("failed to update Secret: %w", err)
+	}
+	return nil
+}
+
+// verifyClaudeCredentials runs a validation Pod asking Claude for a hello
+// with the claude-creds Secret and waits for it to succeed, printing its
+// output. The Pod is deleted afterwards.
+func verifyClaudeCredentials(ctx context.Context, clientset kubernetes.Interface, namespace, image string,
+	timeout, interval time.Duration, out io.Writer) error {
+	pods := clientset.CoreV1().Pods(namespace)
+	created, err := pods.Create(ctx, newCredentialsVerifyPod(namespace, image, timeout), metav1.CreateOptions{FieldManager: fieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to create validation Pod: %w", err)
+	}
+	name := created.Name
+	defer func() {
+		_ = pods.Delete(context.Background(), name, metav1.DeleteOptions{}) //nolint:errcheck // best-effort cleanup
+	}()
+
+	var phase corev1.PodPhase
+	err = wait.PollUntilContextTimeout(ctx, interval, timeout, true, func(ctx context.Context) (bool, error) {
+		p, err := pods.Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			return false, nil
+		}
+		phase = p.Status.Phase
+		return phase == corev1.PodSucceeded || phase == corev1.PodFailed, nil
+	})
+	if err != nil {
+		return fmt.Errorf("timeout waiting for validation Pod %s (phase %s)", name, phase)
+	}
+
+	logs, err := pods.GetLogs(name, &corev1.PodLogOptions{}).DoRaw(ctx)
+	if err == nil && len(logs) > 0 {
+		_, _ = fmt.Fprintf(out, "  %s\n", strings.ReplaceAll(strings.TrimSpace(string(logs)), "\n", "\n  "))
+	}
+	if phase == corev1.PodFailed {
+		return fmt.Errorf("validation Pod %s failed: Claude rejected the credentials", name)
+	}
+	return nil
+}
+
+// newCredentialsVerifyPod returns the validation Pod: the agent image with
+// the claude-creds Secret mounted and set up like a polecat's, running a
+// single prompt.
+func newCredentialsVerifyPod(namespace, image string, timeout time.Duration) *corev1.Pod {
+	script := fmt.Sprintf(`mkdir -p "$HOME/.claude"
+cp "%s/%s" "$HOME/.claude/%s"
+claude --print %q`, pod.ClaudeCredsMountPath, claudeCredentialsFile, claudeCredentialsFile, verifyPrompt)
+	deadline := int64(timeout.Seconds())
+	nonRoot, noEscalation, readOnly := true, false, true
+	agentID := int64(65532)
+
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      generatePolecatName(claudeCredsSecretName + "-verify"),
+			Namespace: namespace,
+			Labels:    map[string]string{"app.kubernetes.io/managed-by": fieldManager},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:         corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds: &deadline,
+			SecurityContext: &corev1.PodSecurityContext{
+				RunAsNonRoot: &nonRoot,
+				RunAsUser:    &agentID,
+				RunAsGroup:   &agentID,
+				FSGroup:      &agentID,
+				SeccompProfile: &corev1.SeccompProfile{
+					Type: corev1.SeccompProfileTypeRuntimeDefault,
+				},
+			},
+			Containers: []corev1.Container{{
+				Name:    "verify",
+				Image:   image,
+				Command: []string{"/bin/sh", "-c", script},
+				Env:     []corev1.EnvVar{{Name: "HOME", Value: "/home/agent"}},
+				SecurityContext: &corev1.SecurityContext{
+					AllowPrivilegeEscalation: &noEscalation,
+					ReadOnlyRootFilesystem:   &readOnly,
+					Capabilities:             &corev1.Capabilities{Drop: []corev1.Capability{"ALL"}},
+				},
+				VolumeMounts: []corev1.VolumeMount{
+					{Name: pod.ClaudeCredsVolumeName, MountPath: pod.ClaudeCredsMountPath, ReadOnly: true},
+					{Name: "home", MountPath: "/home/agent"},
+				},
+			}},
+			Volumes: []corev1.Volume{
+				{
+					Name: pod.ClaudeCredsVolumeName,
+					VolumeSource: corev1.VolumeSource{
+						Secret: &corev1.SecretVolumeSource{SecretName: claudeCredsSecretName},
+					},
+				},
+				{Name: "home", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+			},
+		},
+	}
+}
@@ -0,0 +1,173 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+const testCredentials = `{"claudeAiOauth":{"accessToken":"sk-ant-oat01-test","refreshToken":"sk-ant-ort01-test"}}`
+
+func TestNewAuthLoginCmd(t *testing.T) {
+	cmd := newAuthLoginCmd()
+	for _, flag := range []string{"rig", "claude-bin", "credentials-file", "verify", "verify-image", "verify-timeout"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag --%s to exist", flag)
+		}
+	}
+
+	found := false
+	for _, sub := range newAuthCmd().Commands() {
+		found = found || sub.Name() == "login"
+	}
+	if !found {
+		t.Error("expected auth login subcommand")
+	}
+}
+
+func TestValidateClaudeCredentials(t *testing.T) {
+	tests := []struct {
+		name    string
+		data    string
+		wantErr string
+	}{
+		{"valid", testCredentials, ""},
+		{"not json", "token", "invalid .credentials.json"},
+		{"no token", `{"claudeAiOauth":{}}`, "no OAuth access token"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateClaudeCredentials([]byte(tt.data))
+			if tt.wantErr == "" && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if tt.wantErr != "" && (err == nil || !strings.Contains(err.Error(), tt.wantErr)) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestCaptureClaudeCredentials(t *testing.T) {
+	login := claudeLogin
+	defer func() { claudeLogin = login }()
+
+	var loginDir string
+	claudeLogin = func(_ context.Context, claudeBin, configDir string) error {
+		if claudeBin != "claude" {
+			t.Errorf("expected claude binary, got %s", claudeBin)
+		}
+		loginDir = configDir
+		return os.WriteFile(filepath.Join(configDir, claudeCredentialsFile), []byte(testCredentials), 0o600)
+	}
+	data, err := captureClaudeCredentials(context.Background(), authLoginOptions{claudeBin: "claude"})
+	if err != nil {
+		t.Fatalf("capture failed: %v", err)
+	}
+	if string(data) != testCredentials {
+		t.Errorf("unexpected credentials: %s", data)
+	}
+	if _, err := os.Stat(loginDir); !os.IsNotExist(err) {
+		t.Errorf("expected the login directory to be removed, got %v", err)
+	}
+
+	// A login that stored no file, e.g. in the macOS Keychain
+	claudeLogin = func(context.Context, string, string) error { return nil }
+	_, err = captureClaudeCredentials(context.Background(), authLoginOptions{claudeBin: "claude"})
+	if err == nil || !strings.Contains(err.Error(), "--credentials-file") {
+		t.Errorf("expected a hint at --credentials-file, got %v", err)
+	}
+
+	// --credentials-file skips the login
+	path := filepath.Join(t.TempDir(), claudeCredentialsFile)
+	if err := os.WriteFile(path, []byte(testCredentials), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := captureClaudeCredentials(context.Background(), authLoginOptions{credentialsFile: path}); err != nil {
+		t.Errorf("capture from file failed: %v", err)
+	}
+}
+
+func TestStoreClaudeCredentials(t *testing.T) {
+	ctx := context.Background()
+	clientset := fake.NewClientset()
+
+	if err := storeClaudeCredentials(ctx, clientset, "gt-rig", []byte("first")); err != nil {
+		t.Fatalf("create failed: %v", err)
+	}
+	secret, err := clientset.CoreV1().Secrets("gt-rig").Get(ctx, claudeCredsSecretName, metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	if string(secret.Data[claudeCredentialsFile]) != "first" || secret.Annotations[syncTimestampKey] == "" {
+		t.Errorf("unexpected Secret: %+v", secret)
+	}
+
+	// Updating replaces the credentials and keeps the other files
+	secret.Data["settings.json"] = []byte("{}")
+	if _, err := clientset.CoreV1().Secrets("gt-rig").Update(ctx, secret, metav1.UpdateOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if err := storeClaudeCredentials(ctx, clientset, "gt-rig", []byte("second")); err != nil {
+		t.Fatalf("update failed: %v", err)
+	}
+	secret, _ = clientset.CoreV1().Secrets("gt-rig").Get(ctx, claudeCredsSecretName, metav1.GetOptions{})
+	if string(secret.Data[claudeCredentialsFile]) != "second" || string(secret.Data["settings.json"]) != "{}" {
+		t.Errorf("unexpected Secret data after update: %v", secret.Data)
+	}
+}
+
+func TestVerifyClaudeCredentials(t *testing.T) {
+	for _, phase := range []corev1.PodPhase{corev1.PodSucceeded, corev1.PodFailed} {
+		t.Run(string(phase), func(t *testing.T) {
+			ctx := context.Background()
+			clientset := fake.NewClientset()
+			var created *corev1.Pod
+			// The validation Pod runs to completion as soon as it is created
+			clientset.PrependReactor("create", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+				created = action.(k8stesting.CreateAction).GetObject().(*corev1.Pod)
+				created.Status.Phase = phase
+				return false, nil, nil
+			})
+
+			var out bytes.Buffer
+			err := verifyClaudeCredentials(ctx, clientset, "gt-rig", "agent:latest", time.Minute, time.Millisecond, &out)
+			if phase == corev1.PodSucceeded && err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+			if phase == corev1.PodFailed && (err == nil || !strings.Contains(err.Error(), "rejected the credentials")) {
+				t.Errorf("expected a rejection, got %v", err)
+			}
+
+			if created == nil {
+				t.Fatal("expected a validation Pod")
+			}
+			if created.Spec.Containers[0].Image != "agent:latest" {
+				t.Errorf("unexpected image %s", created.Spec.Containers[0].Image)
+			}
+			if created.Spec.Volumes[0].Secret.SecretName != claudeCredsSecretName {
+				t.Errorf("expected the %s Secret mounted, got %+v", claudeCredsSecretName, created.Spec.Volumes[0])
+			}
+			if created.Spec.Containers[0].VolumeMounts[0].MountPath != pod.ClaudeCredsMountPath {
+				t.Errorf("unexpected mount %+v", created.Spec.Containers[0].VolumeMounts[0])
+			}
+			_, err = clientset.CoreV1().Pods("gt-rig").Get(ctx, created.Name, metav1.GetOptions{})
+			if !apierrors.IsNotFound(err) {
+				t.Errorf("expected the validation Pod to be deleted, got %v", err)
+			}
+		})
+	}
+}
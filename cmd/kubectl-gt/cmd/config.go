@@ -0,0 +1,298 @@
+package cmd
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	"sigs.k8s.io/yaml"
+)
+
+// configPathEnv overrides the location of the plugin config file.
+const configPathEnv = "KUBECTL_GT_CONFIG"
+
+// PluginConfig is the plugin config file: named profiles of the defaults
+// used across calls, one of which is current.
+type PluginConfig struct {
+	// CurrentContext names the profile in use
+	CurrentContext string `json:"currentContext,omitempty"`
+	// Contexts are the profiles by name
+	Contexts map[string]Profile `json:"contexts,omitempty"`
+}
+
+// Profile holds the defaults of one cluster or rig. Explicit flags win.
+type Profile struct {
+	// Namespace replaces the default gastown namespace
+	Namespace string `json:"namespace,omitempty"`
+	// Rig is used by commands that need a rig when none is given
+	Rig string `json:"rig,omitempty"`
+	// Output is the default of the -o flag
+	Output string `json:"output,omitempty"`
+	// Town is the kubeconfig context of the cluster hosting the town
+	Town string `json:"town,omitempty"`
+}
+
+// activeProfile is the current profile, loaded before each command runs.
+var activeProfile Profile
+
+// configPath returns the path of the plugin config file:
+// $KUBECTL_GT_CONFIG, else $XDG_CONFIG_HOME/kubectl-gt/config.yaml, else
+// ~/.config/kubectl-gt/config.yaml.
+func configPath() (string, error) {
+	if path := os.Getenv(configPathEnv); path != "" {
+		return path, nil
+	}
+	dir := os.Getenv("XDG_CONFIG_HOME")
+	if dir == "" {
+		home, err := os.UserHomeDir()
+		if err != nil {
+			return "", fmt.Errorf("failed to find home directory: %w", err)
+		}
+		dir = filepath.Join(home, ".config")
+	}
+	return filepath.Join(dir, "kubectl-gt", "config.yaml"), nil
+}
+
+// loadPluginConfig reads the plugin config file. A missing file is an empty
+// config.
+func loadPluginConfig() (*PluginConfig, error) {
+	path, err := configPath()
+	if err != nil {
+		return nil, err
+	}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return &PluginConfig{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	cfg := &PluginConfig{}
+	if err := yaml.UnmarshalStrict(data, cfg); err != nil {
+		return nil, fmt.Errorf("failed to parse %s: %w", path, err)
+	}
+	return cfg, nil
+}
+
+// savePluginConfig writes the plugin config file, readable by the user only.
+func savePluginConfig(cfg *PluginConfig) error {
+	path, err := configPath()
+	if err != nil {
+		return err
+	}
+	data, err := yaml.Marshal(cfg)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return fmt.Errorf("failed to create %s: %w", filepath.Dir(path), err)
+	}
+	if err := os.WriteFile(path, data, 0o600); err != nil {
+		return fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	return nil
+}
+
+// current returns the current profile, empty when there is none.
+func (c *PluginConfig) current() (Profile, error) {
+	if c.CurrentContext == "" {
+		return Profile{}, nil
+	}
+	profile, ok := c.Contexts[c.CurrentContext]
+	if !ok {
+		return Profile{}, fmt.Errorf("current context %q is not defined; run kubectl gt config use-context", c.CurrentContext)
+	}
+	return profile, nil
+}
+
+// applyProfile loads the current profile and fills in the flags of cmd the
+// user did not set: the kubeconfig context and -o.
+func applyProfile(cmd *cobra.Command, _ []string) error {
+	cfg, err := loadPluginConfig()
+	if err != nil {
+		return err
+	}
+	if activeProfile, err = cfg.current(); err != nil {
+		return err
+	}
+
+	if activeProfile.Town != "" && KubeFlags.Context != nil && *KubeFlags.Context == "" {
+		*KubeFlags.Context = activeProfile.Town
+	}
+	if output := cmd.Flags().Lookup("output"); output != nil && !output.Changed && activeProfile.Output != "" {
+		if err := output.Value.Set(activeProfile.Output); err != nil {
+			return fmt.Errorf("invalid output %q in context %q: %w", activeProfile.Output, cfg.CurrentContext, err)
+		}
+	}
+	return nil
+}
+
+// rigArg returns the rig given as args[i], else the profile's default rig.
+func rigArg(args []string, i int) (string, error) {
+	if i < len(args) {
+		return args[i], nil
+	}
+	if activeProfile.Rig != "" {
+		return activeProfile.Rig, nil
+	}
+	return "", fmt.Errorf("no rig given and the current context has no default rig")
+}
+
+func newConfigCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "Manage kubectl-gt context profiles",
+		Long: `Manage the named profiles of ~/.config/kubectl-gt/config.yaml (or
+$KUBECTL_GT_CONFIG), so that working across clusters and rigs does not take
+the same flags on every call.
+
+A profile sets the default namespace, rig, output format and town: the
+kubeconfig context of the cluster hosting the town. Explicit flags win.`,
+		// The profile is what these commands edit; it does not apply to them
+		PersistentPreRunE: func(*cobra.Command, []string) error { return nil },
+	}
+
+	cmd.AddCommand(newConfigSetContextCmd())
+	cmd.AddCommand(newConfigUseContextCmd())
+	cmd.AddCommand(newConfigGetContextsCmd())
+	cmd.AddCommand(newConfigCurrentContextCmd())
+
+	return cmd
+}
+
+func newConfigSetContextCmd() *cobra.Command {
+	var rig, output, town string
+
+	cmd := &cobra.Command{
+		Use:   "set-context <name>",
+		Short: "Create or update a context profile",
+		Example: `  # Work on the api rig of the production town by default
+  kubectl gt config set-context prod --town prod-cluster --namespace gastown --rig api
+
+  # Default to JSON output
+  kubectl gt config set-context prod --output json`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadPluginConfig()
+			if err != nil {
+				return err
+			}
+			profile := cfg.Contexts[args[0]]
+			if cmd.Flags().Changed("namespace") {
+				profile.Namespace = *KubeFlags.Namespace
+			}
+			if cmd.Flags().Changed("rig") {
+				profile.Rig = rig
+			}
+			if cmd.Flags().Changed("output") {
+				if err := validateOutputFormat(output); err != nil {
+					return err
+				}
+				profile.Output = output
+			}
+			if cmd.Flags().Changed("town") {
+				profile.Town = town
+			}
+			if cfg.Contexts == nil {
+				cfg.Contexts = map[string]Profile{}
+			}
+			cfg.Contexts[args[0]] = profile
+			if err := savePluginConfig(cfg); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Context %q set\n", args[0])
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&rig, "rig", "", "Default rig")
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Default output format (table, json, yaml)")
+	cmd.Flags().StringVar(&town, "town", "", "Kubeconfig context of the cluster hosting the town")
+
+	return cmd
+}
+
+func newConfigUseContextCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:     "use-context <name>",
+		Short:   "Switch to a context profile",
+		Example: `  kubectl gt config use-context prod`,
+		Args:    cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			cfg, err := loadPluginConfig()
+			if err != nil {
+				return err
+			}
+			if _, ok := cfg.Contexts[args[0]]; !ok {
+				return fmt.Errorf("context %q is not defined; create it with kubectl gt config set-context", args[0])
+			}
+			cfg.CurrentContext = args[0]
+			if err := savePluginConfig(cfg); err != nil {
+				return err
+			}
+			_, _ = fmt.Fprintf(cmd.OutOrStdout(), "Switched to context %q\n", args[0])
+			return nil
+		},
+	}
+}
+
+func newConfigGetContextsCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "get-contexts",
+		Short: "List the context profiles",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadPluginConfig()
+			if err != nil {
+				return err
+			}
+			printContexts(cmd.OutOrStdout(), cfg)
+			return nil
+		},
+	}
+}
+
+func newConfigCurrentContextCmd() *cobra.Command {
+	return &cobra.Command{
+		Use:   "current-context",
+		Short: "Print the current context profile",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			cfg, err := loadPluginConfig()
+			if err != nil {
+				return err
+			}
+			if cfg.CurrentContext == "" {
+				return fmt.Errorf("current context is not set")
+			}
+			_, _ = fmt.Fprintln(cmd.OutOrStdout(), cfg.CurrentContext)
+			return nil
+		},
+	}
+}
+
+// printContexts lists the profiles, marking the current one like kubectl.
+func printContexts(out io.Writer, cfg *PluginConfig) {
+	names := make([]string, 0, len(cfg.Contexts))
+	for name := range cfg.Contexts {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CURRENT\tNAME\tTOWN\tNAMESPACE\tRIG\tOUTPUT")
+	for _, name := range names {
+		p := cfg.Contexts[name]
+		current := ""
+		if name == cfg.CurrentContext {
+			current = "*"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", current, name, p.Town, p.Namespace, p.Rig, p.Output)
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,154 @@
+package cmd
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/cobra"
+)
+
+// useConfigFile points the plugin at a config file in a temp dir and resets
+// the profile state afterwards.
+func useConfigFile(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "kubectl-gt", "config.yaml")
+	if content != "" {
+		if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+			t.Fatal(err)
+		}
+		if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv(configPathEnv, path)
+
+	context, namespace := *KubeFlags.Context, *KubeFlags.Namespace
+	t.Cleanup(func() {
+		activeProfile = Profile{}
+		*KubeFlags.Context, *KubeFlags.Namespace = context, namespace
+	})
+	return path
+}
+
+func runConfigCmd(t *testing.T, args ...string) (string, error) {
+	t.Helper()
+	var out bytes.Buffer
+	cmd := newConfigCmd()
+	cmd.SetOut(&out)
+	cmd.SetErr(&out)
+	cmd.SetArgs(args)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigPath(t *testing.T) {
+	t.Setenv(configPathEnv, "")
+	t.Setenv("XDG_CONFIG_HOME", "/xdg")
+	path, err := configPath()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if path != "/xdg/kubectl-gt/config.yaml" {
+		t.Errorf("expected XDG config path, got %s", path)
+	}
+}
+
+func TestConfigContexts(t *testing.T) {
+	path := useConfigFile(t, "")
+
+	if _, err := runConfigCmd(t, "use-context", "prod"); err == nil {
+		t.Fatal("expected an error for an undefined context")
+	}
+	if _, err := runConfigCmd(t, "set-context", "prod", "--town", "prod-cluster", "--rig", "api", "-o", "json"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if _, err := runConfigCmd(t, "set-context", "dev", "--rig", "web", "-o", "wide"); err == nil {
+		t.Fatal("expected an error for an unknown output format")
+	}
+	if _, err := runConfigCmd(t, "use-context", "prod"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{"currentContext: prod", "town: prod-cluster", "rig: api", "output: json"} {
+		if !strings.Contains(string(data), want) {
+			t.Errorf("expected %q in config file:\n%s", want, data)
+		}
+	}
+
+	out, err := runConfigCmd(t, "get-contexts")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.Contains(out, "*        prod") {
+		t.Errorf("expected prod marked current, got:\n%s", out)
+	}
+}
+
+func TestApplyProfile(t *testing.T) {
+	useConfigFile(t, `currentContext: prod
+contexts:
+  prod:
+    namespace: gastown-prod
+    rig: api
+    output: yaml
+    town: prod-cluster
+`)
+	*KubeFlags.Context, *KubeFlags.Namespace = "", ""
+
+	var output string
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "")
+	if err := applyProfile(cmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output != "yaml" {
+		t.Errorf("expected profile output yaml, got %s", output)
+	}
+	if *KubeFlags.Context != "prod-cluster" {
+		t.Errorf("expected kubeconfig context prod-cluster, got %s", *KubeFlags.Context)
+	}
+	if ns := GetNamespace(); ns != "gastown-prod" {
+		t.Errorf("expected profile namespace, got %s", ns)
+	}
+	if rig, err := rigArg([]string{"dm-0001"}, 1); err != nil || rig != "api" {
+		t.Errorf("expected default rig api, got %q (%v)", rig, err)
+	}
+	if rig, _ := rigArg([]string{"dm-0001", "web"}, 1); rig != "web" {
+		t.Errorf("expected explicit rig web, got %q", rig)
+	}
+}
+
+func TestApplyProfileKeepsFlags(t *testing.T) {
+	useConfigFile(t, `currentContext: prod
+contexts:
+  prod:
+    output: yaml
+    town: prod-cluster
+`)
+	*KubeFlags.Context = "staging"
+
+	var output string
+	cmd := &cobra.Command{Use: "list"}
+	cmd.Flags().StringVarP(&output, "output", "o", "table", "")
+	if err := cmd.Flags().Set("output", "json"); err != nil {
+		t.Fatal(err)
+	}
+	if err := applyProfile(cmd, nil); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if output != "json" || *KubeFlags.Context != "staging" {
+		t.Errorf("expected explicit flags to win, got output %s and context %s", output, *KubeFlags.Context)
+	}
+	if _, err := rigArg(nil, 0); err == nil {
+		t.Error("expected an error without a rig or default rig")
+	}
+}
@@ -4,16 +4,21 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"io"
 	"os"
-	"strings"
+	"os/signal"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/yaml"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
 )
 
 var convoyGVR = schema.GroupVersionResource{
@@ -31,6 +36,7 @@ func newConvoyCmd() *cobra.Command {
 
 	cmd.AddCommand(newConvoyListCmd())
 	cmd.AddCommand(newConvoyStatusCmd())
+	cmd.AddCommand(newConvoyOutcomesCmd())
 	cmd.AddCommand(newConvoyCreateCmd())
 
 	return cmd
@@ -38,6 +44,7 @@ func newConvoyCmd() *cobra.Command {
 
 func newConvoyListCmd() *cobra.Command {
 	var outputFormat string
+	var selectors listSelectors
 
 	cmd := &cobra.Command{
 		Use:   "list",
@@ -45,58 +52,109 @@ func newConvoyListCmd() *cobra.Command {
 		Example: `  # List all convoys
   kubectl gt convoy list
 
+  # List convoys that are still in progress
+  kubectl gt convoy list --field-selector status.phase=InProgress
+
   # Output as JSON
   kubectl gt convoy list -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConvoyList(outputFormat)
+			return runConvoyList(selectors, outputFormat)
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+	selectors.addFlags(cmd)
 
 	return cmd
 }
 
 func newConvoyStatusCmd() *cobra.Command {
 	var outputFormat string
+	var watch bool
 
 	cmd := &cobra.Command{
 		Use:   "status <id>",
 		Short: "Show convoy details",
-		Args:  cobra.ExactArgs(1),
+		Long: `Show the progress of a convoy: a bar of its beads by state (merged, done,
+working, failed, pending) and, for each bead, its polecat, state, elapsed
+time and a timeline of when it was worked on. With --watch the view is
+redrawn every few seconds until the convoy completes or fails.`,
+		Args: cobra.ExactArgs(1),
 		Example: `  # Show convoy status
   kubectl gt convoy status cv-abc123
 
+  # Follow a large batch live
+  kubectl gt convoy status cv-abc123 --watch
+
   # Output as JSON
   kubectl gt convoy status cv-abc123 -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runConvoyStatus(args[0], outputFormat)
+			return runConvoyStatus(args[0], outputFormat, watch)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+	cmd.Flags().BoolVarP(&watch, "watch", "w", false, "Redraw the progress until the convoy completes or fails")
+
+	return cmd
+}
+
+func newConvoyOutcomesCmd() *cobra.Command {
+	var outputFormat string
+	var limit, offset int
+
+	cmd := &cobra.Command{
+		Use:   "outcomes <id>",
+		Short: "Show the outcome of each bead of a convoy",
+		Long: `Show the outcome matrix of a convoy: for each tracked bead, its polecat,
+phase, merge commit, cost, duration and failure reason. Outcomes are paged
+with --limit and --offset.`,
+		Args: cobra.ExactArgs(1),
+		Example: `  # Show the first 50 bead outcomes
+  kubectl gt convoy outcomes cv-abc123
+
+  # Show the next page
+  kubectl gt convoy outcomes cv-abc123 --offset 50
+
+  # Export all outcomes for reporting
+  kubectl gt convoy outcomes cv-abc123 --limit 0 -o json`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runConvoyOutcomes(args[0], limit, offset, outputFormat)
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+	cmd.Flags().IntVar(&limit, "limit", 50, "Maximum number of outcomes to show (0 for all)")
+	cmd.Flags().IntVar(&offset, "offset", 0, "Number of outcomes to skip")
 
 	return cmd
 }
 
 func newConvoyCreateCmd() *cobra.Command {
+	var outputFormat string
+
 	cmd := &cobra.Command{
 		Use:   "create <description> <bead1> [bead2] ...",
 		Short: "Create a convoy to track beads",
 		Args:  cobra.MinimumNArgs(2),
 		Example: `  # Create a convoy
-  kubectl gt convoy create "Wave 1" dm-0001 dm-0002 dm-0003`,
+  kubectl gt convoy create "Wave 1" dm-0001 dm-0002 dm-0003
+
+  # Capture the convoy ID in a script
+  kubectl gt convoy create "Wave 1" dm-0001 dm-0002 -o json | jq -r .convoyID`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			description := args[0]
 			beads := args[1:]
-			return runConvoyCreate(description, beads)
+			return runConvoyCreate(description, beads, outputFormat)
 		},
 	}
 
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+
 	return cmd
 }
 
-func runConvoyList(outputFormat string) error {
+func runConvoyList(selectors listSelectors, outputFormat string) error {
 	config, err := KubeFlags.ToRESTConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -108,12 +166,16 @@ func runConvoyList(outputFormat string) error {
 	}
 
 	namespace := GetNamespace()
-	list, err := client.Resource(convoyGVR).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+	list, err := client.Resource(convoyGVR).Namespace(namespace).List(context.Background(), selectors.listOptions())
 	if err != nil {
 		return fmt.Errorf("failed to list convoys: %w", err)
 	}
+	items, err := selectors.filter(list.Items)
+	if err != nil {
+		return err
+	}
 
-	if len(list.Items) == 0 {
+	if len(items) == 0 {
 		if outputFormat == OutputFormatJSON {
 			fmt.Println("[]")
 			return nil
@@ -128,7 +190,7 @@ func runConvoyList(outputFormat string) error {
 
 	switch outputFormat {
 	case OutputFormatYAML:
-		for i, item := range list.Items {
+		for i, item := range items {
 			if i > 0 {
 				fmt.Println("---")
 			}
@@ -140,8 +202,8 @@ func runConvoyList(outputFormat string) error {
 		}
 	case OutputFormatJSON:
 		// Output as JSON array
-		objects := make([]map[string]any, len(list.Items))
-		for i, item := range list.Items {
+		objects := make([]map[string]any, len(items))
+		for i, item := range items {
 			objects[i] = item.Object
 		}
 		data, err := json.MarshalIndent(objects, "", "  ")
@@ -152,7 +214,7 @@ func runConvoyList(outputFormat string) error {
 	default:
 		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
 		_, _ = fmt.Fprintln(w, "ID\tDESCRIPTION\tCOMPLETED\tPENDING\tPHASE\tAGE")
-		for _, item := range list.Items {
+		for _, item := range items {
 			name := item.GetName()
 			description, _, _ := unstructured.NestedString(item.Object, "spec", "description")
 			phase, _, _ := unstructured.NestedString(item.Object, "status", "phase")
@@ -171,7 +233,11 @@ func runConvoyList(outputFormat string) error {
 	return nil
 }
 
-func runConvoyStatus(id, outputFormat string) error {
+func runConvoyStatus(id, outputFormat string, watch bool) error {
+	if watch && outputFormat != OutputFormatTable {
+		return fmt.Errorf("--watch only applies to table output")
+	}
+
 	config, err := KubeFlags.ToRESTConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -183,78 +249,199 @@ func runConvoyStatus(id, outputFormat string) error {
 	}
 
 	namespace := GetNamespace()
-	convoy, err := client.Resource(convoyGVR).Namespace(namespace).Get(context.Background(), id, metav1.GetOptions{})
+	if !watch {
+		_, err := showConvoyStatus(context.Background(), client, namespace, id, outputFormat)
+		return err
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	ticker := time.NewTicker(convoyWatchInterval)
+	defer ticker.Stop()
+	for {
+		fmt.Print(clearScreen)
+		finished, err := showConvoyStatus(ctx, client, namespace, id, outputFormat)
+		if err != nil || finished {
+			return err
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// showConvoyStatus prints the convoy, reporting whether it has finished:
+// completed or failed.
+func showConvoyStatus(ctx context.Context, client dynamic.Interface, namespace, id, outputFormat string) (bool, error) {
+	item, err := client.Resource(convoyGVR).Namespace(namespace).Get(ctx, id, metav1.GetOptions{})
 	if err != nil {
-		return fmt.Errorf("failed to get convoy %s: %w", id, err)
+		return false, fmt.Errorf("failed to get convoy %s: %w", id, err)
 	}
 
 	switch outputFormat {
 	case OutputFormatYAML:
-		data, err := yaml.Marshal(convoy.Object)
+		data, err := yaml.Marshal(item.Object)
 		if err != nil {
-			return fmt.Errorf("failed to marshal convoy: %w", err)
+			return false, fmt.Errorf("failed to marshal convoy: %w", err)
 		}
 		fmt.Print(string(data))
+		return false, nil
 	case OutputFormatJSON:
-		data, err := json.MarshalIndent(convoy.Object, "", "  ")
+		data, err := json.MarshalIndent(item.Object, "", "  ")
 		if err != nil {
-			return fmt.Errorf("failed to marshal convoy: %w", err)
+			return false, fmt.Errorf("failed to marshal convoy: %w", err)
 		}
 		fmt.Println(string(data))
-	default:
-		// Print convoy details
-		fmt.Printf("ID:          %s\n", convoy.GetName())
+		return false, nil
+	}
 
-		if desc, ok, _ := unstructured.NestedString(convoy.Object, "spec", "description"); ok {
-			fmt.Printf("Description: %s\n", desc)
-		}
+	var convoy gastownv1alpha1.Convoy
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &convoy); err != nil {
+		return false, fmt.Errorf("failed to decode convoy %s: %w", id, err)
+	}
+	var polecats []gastownv1alpha1.Polecat
+	if err := listTyped(ctx, client, polecatGVR, namespace, "", &polecats); err != nil {
+		return false, err
+	}
 
-		// Tracked beads from spec
-		if beads, ok, _ := unstructured.NestedSlice(convoy.Object, "spec", "trackedBeads"); ok {
-			beadIDs := make([]string, 0, len(beads))
-			for _, b := range beads {
-				if beadID, ok := b.(string); ok {
-					beadIDs = append(beadIDs, beadID)
-				}
-			}
-			fmt.Printf("Beads:       %s\n", strings.Join(beadIDs, ", "))
-		}
+	now := time.Now()
+	printConvoyProgress(os.Stdout, &convoy, newConvoyProgress(&convoy, polecats, now), now)
+	phase := convoy.Status.Phase
+	return phase == gastownv1alpha1.ConvoyPhaseComplete || phase == gastownv1alpha1.ConvoyPhaseFailed, nil
+}
 
-		fmt.Println()
+// ConvoyOutcomesPage is the structured output of kubectl gt convoy outcomes.
+type ConvoyOutcomesPage struct {
+	// Convoy is the name of the Convoy
+	Convoy string `json:"convoy"`
+	// Total is the number of outcomes the convoy reports
+	Total  int `json:"total"`
+	Offset int `json:"offset"`
+	// Next is the offset of the next page, absent on the last page
+	Next     int                           `json:"next,omitempty"`
+	Outcomes []gastownv1alpha1.BeadOutcome `json:"outcomes"`
+}
 
-		// Status
-		if phase, ok, _ := unstructured.NestedString(convoy.Object, "status", "phase"); ok {
-			fmt.Printf("Phase:       %s\n", phase)
-		}
+// newConvoyOutcomesPage pages the outcomes of the convoy. A zero limit
+// returns all outcomes from offset on.
+func newConvoyOutcomesPage(convoy *gastownv1alpha1.Convoy, limit, offset int) ConvoyOutcomesPage {
+	outcomes := convoy.Status.Outcomes
+	page := ConvoyOutcomesPage{Convoy: convoy.Name, Total: len(outcomes), Offset: offset}
+	start := min(max(offset, 0), len(outcomes))
+	end := len(outcomes)
+	if limit > 0 {
+		end = min(start+limit, end)
+	}
+	page.Outcomes = append([]gastownv1alpha1.BeadOutcome{}, outcomes[start:end]...)
+	if end < len(outcomes) {
+		page.Next = end
+	}
+	return page
+}
 
-		// Progress
-		completed, _, _ := unstructured.NestedSlice(convoy.Object, "status", "completedBeads")
-		pending, _, _ := unstructured.NestedSlice(convoy.Object, "status", "pendingBeads")
-		total := len(completed) + len(pending)
-		if total > 0 {
-			pct := float64(len(completed)) / float64(total) * 100
-			fmt.Printf("Progress:    %d/%d (%.0f%%)\n", len(completed), total, pct)
-		}
+func runConvoyOutcomes(id string, limit, offset int, outputFormat string) error {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+	if limit < 0 || offset < 0 {
+		return fmt.Errorf("--limit and --offset must not be negative")
+	}
 
-		if len(completed) > 0 {
-			fmt.Println("\nCompleted:")
-			for _, b := range completed {
-				fmt.Printf("  ✓ %v\n", b)
-			}
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	namespace := GetNamespace()
+	item, err := client.Resource(convoyGVR).Namespace(namespace).Get(context.Background(), id, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("failed to get convoy %s: %w", id, err)
+	}
+	var convoy gastownv1alpha1.Convoy
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &convoy); err != nil {
+		return fmt.Errorf("failed to decode convoy %s: %w", id, err)
+	}
+
+	page := newConvoyOutcomesPage(&convoy, limit, offset)
+	if outputFormat != OutputFormatTable {
+		return printStructured(os.Stdout, outputFormat, page)
+	}
+	printConvoyOutcomes(os.Stdout, page, len(convoy.Spec.TrackedBeads))
+	return nil
+}
+
+// printConvoyOutcomes prints a page of outcomes as a table. tracked is the
+// number of beads of the convoy, which exceeds the outcomes when they were
+// capped.
+func printConvoyOutcomes(out io.Writer, page ConvoyOutcomesPage, tracked int) {
+	if page.Total == 0 {
+		_, _ = fmt.Fprintf(out, "No bead outcomes reported for convoy %s yet\n", page.Convoy)
+		return
+	}
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "BEAD\tPOLECAT\tPHASE\tMERGE COMMIT\tCOST\tDURATION\tFAILURE")
+	for _, o := range page.Outcomes {
+		duration := "-"
+		if o.Duration != nil {
+			duration = o.Duration.Duration.String()
+		}
+		cost := "-"
+		if o.CostUSD != "" {
+			cost = "$" + o.CostUSD
 		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\t%s\n", o.Bead, orDash(o.Polecat), orDash(string(o.Phase)),
+			orDash(shortCommit(o.MergeCommit)), cost, duration, orDash(o.FailureReason))
+	}
+	_ = w.Flush()
 
-		if len(pending) > 0 {
-			fmt.Println("\nPending:")
-			for _, b := range pending {
-				fmt.Printf("  ○ %v\n", b)
-			}
+	if len(page.Outcomes) > 0 && (page.Offset > 0 || page.Next > 0) {
+		_, _ = fmt.Fprintf(out, "\nShowing %d-%d of %d", page.Offset+1, page.Offset+len(page.Outcomes), page.Total)
+		if page.Next > 0 {
+			_, _ = fmt.Fprintf(out, "; next page: --offset %d", page.Next)
 		}
+		_, _ = fmt.Fprintln(out)
 	}
+	if tracked > page.Total {
+		_, _ = fmt.Fprintf(out, "Outcomes are reported for the first %d of %d tracked beads\n", page.Total, tracked)
+	}
+}
 
-	return nil
+// ConvoyCreateResult is the structured output of kubectl gt convoy create.
+type ConvoyCreateResult struct {
+	// ConvoyID is the name of the created Convoy
+	ConvoyID    string   `json:"convoyID"`
+	Namespace   string   `json:"namespace"`
+	UID         string   `json:"uid"`
+	Description string   `json:"description"`
+	Beads       []string `json:"beads"`
+}
+
+// newConvoyCreateResult builds the result from the Convoy returned by the API server.
+func newConvoyCreateResult(created *unstructured.Unstructured) ConvoyCreateResult {
+	description, _, _ := unstructured.NestedString(created.Object, "spec", "description")
+	beads, _, _ := unstructured.NestedStringSlice(created.Object, "spec", "trackedBeads")
+	return ConvoyCreateResult{
+		ConvoyID:    created.GetName(),
+		Namespace:   created.GetNamespace(),
+		UID:         string(created.GetUID()),
+		Description: description,
+		Beads:       beads,
+	}
 }
 
-func runConvoyCreate(description string, beads []string) error {
+func runConvoyCreate(description string, beads []string, outputFormat string) error {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+
 	config, err := KubeFlags.ToRESTConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -292,11 +479,16 @@ func runConvoyCreate(description string, beads []string) error {
 	}
 
 	ctx := context.Background()
-	created, err := client.Resource(convoyGVR).Namespace(namespace).Create(ctx, convoy, metav1.CreateOptions{})
+	created, err := client.Resource(convoyGVR).Namespace(namespace).Create(ctx, convoy, metav1.CreateOptions{FieldManager: fieldManager})
 	if err != nil {
 		return fmt.Errorf("failed to create convoy: %w", err)
 	}
 
-	fmt.Printf("Convoy %s created tracking %d beads\n", created.GetName(), len(beads))
+	result := newConvoyCreateResult(created)
+	if outputFormat != OutputFormatTable {
+		return printStructured(os.Stdout, outputFormat, result)
+	}
+
+	fmt.Printf("Convoy %s created tracking %d beads\n", result.ConvoyID, len(result.Beads))
 	return nil
 }
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"io"
+	"math"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// States of a tracked bead in kubectl gt convoy status, in bar order
+const (
+	beadMerged  = "Merged"
+	beadDone    = "Done"
+	beadWorking = "Working"
+	beadFailed  = "Failed"
+	beadPending = "Pending"
+)
+
+// beadStates are the bead states in bar order
+var beadStates = []string{beadMerged, beadDone, beadWorking, beadFailed, beadPending}
+
+// beadStateChars draw the beads of a state in the progress and timeline bars
+var beadStateChars = map[string]byte{
+	beadMerged:  '=',
+	beadDone:    '#',
+	beadWorking: '>',
+	beadFailed:  'x',
+	beadPending: '.',
+}
+
+const (
+	// progressBarWidth is the width of the convoy progress bar
+	progressBarWidth = 40
+
+	// timelineWidth is the width of the per-bead timeline
+	timelineWidth = 30
+
+	// convoyWatchInterval is how often convoy status --watch redraws
+	convoyWatchInterval = 2 * time.Second
+
+	// clearScreen moves the cursor home and clears the terminal
+	clearScreen = "\033[H\033[2J"
+)
+
+// beadProgress is the progress of a tracked bead
+type beadProgress struct {
+	Bead    string
+	Polecat string
+	State   string
+	// Start and End are when the polecat was created and finished; End is
+	// zero while it works, Start while no polecat stands for the bead
+	Start, End time.Time
+}
+
+// convoyProgress is the progress of the tracked beads of a convoy
+type convoyProgress struct {
+	Beads  []beadProgress
+	Counts map[string]int
+	// Start and End span the timeline, from the start of the convoy until it
+	// completed, else now
+	Start, End time.Time
+}
+
+// beadState classifies the bead from the polecat standing for it, if any.
+func beadState(polecat *gastownv1alpha1.Polecat, completed bool) string {
+	switch {
+	case polecat != nil && polecat.Status.MergedCommit != "":
+		return beadMerged
+	case completed, polecat != nil && polecat.Status.Phase == gastownv1alpha1.PolecatPhaseDone:
+		return beadDone
+	case polecat == nil:
+		return beadPending
+	}
+	switch polecat.Status.Phase {
+	case gastownv1alpha1.PolecatPhaseWorking:
+		return beadWorking
+	case gastownv1alpha1.PolecatPhaseStuck, gastownv1alpha1.PolecatPhaseTerminated:
+		return beadFailed
+	default:
+		return beadPending
+	}
+}
+
+// newConvoyProgress returns the progress of the tracked beads from the
+// polecats of the namespace. Like the convoy's outcomes, a bead is matched
+// to the polecat assigned it, else to the convoy's polecat for it.
+func newConvoyProgress(convoy *gastownv1alpha1.Convoy, polecats []gastownv1alpha1.Polecat, now time.Time) convoyProgress {
+	byBead := make(map[string]*gastownv1alpha1.Polecat)
+	for i := range polecats {
+		if bead := polecats[i].Status.AssignedBead; bead != "" {
+			byBead[bead] = &polecats[i]
+		}
+	}
+	for i := range polecats {
+		polecat := &polecats[i]
+		if polecat.Labels[convoyLabel] == convoy.Name && byBead[polecat.Spec.BeadID] == nil {
+			byBead[polecat.Spec.BeadID] = polecat
+		}
+	}
+	completed := make(map[string]bool, len(convoy.Status.CompletedBeads))
+	for _, bead := range convoy.Status.CompletedBeads {
+		completed[bead] = true
+	}
+
+	progress := convoyProgress{
+		Counts: make(map[string]int, len(beadStates)),
+		Start:  convoy.CreationTimestamp.Time,
+		End:    now,
+	}
+	if convoy.Status.StartedAt != nil {
+		progress.Start = convoy.Status.StartedAt.Time
+	}
+	if convoy.Status.CompletedAt != nil {
+		progress.End = convoy.Status.CompletedAt.Time
+	}
+
+	for _, bead := range convoy.Spec.TrackedBeads {
+		polecat := byBead[bead]
+		p := beadProgress{Bead: bead, State: beadState(polecat, completed[bead])}
+		if polecat != nil {
+			p.Polecat = polecat.Name
+			p.Start = polecat.CreationTimestamp.Time
+			if polecat.Status.FinishedAt != nil {
+				p.End = polecat.Status.FinishedAt.Time
+			}
+			// Polecats created before the convoy started widen the timeline
+			if !p.Start.IsZero() && p.Start.Before(progress.Start) {
+				progress.Start = p.Start
+			}
+		}
+		progress.Counts[p.State]++
+		progress.Beads = append(progress.Beads, p)
+	}
+	return progress
+}
+
+// progressBar draws the counts of the bead states as segments of a bar of
+// width characters.
+func progressBar(counts map[string]int, width int) string {
+	total := 0
+	for _, state := range beadStates {
+		total += counts[state]
+	}
+	if total == 0 {
+		return "[" + strings.Repeat(" ", width) + "]"
+	}
+
+	var bar strings.Builder
+	bar.WriteByte('[')
+	cumulative, drawn := 0, 0
+	for _, state := range beadStates {
+		// Round the segment ends, not the lengths, so the bar fills exactly
+		cumulative += counts[state]
+		end := int(math.Round(float64(cumulative) * float64(width) / float64(total)))
+		bar.WriteString(strings.Repeat(string(beadStateChars[state]), end-drawn))
+		drawn = end
+	}
+	bar.WriteByte(']')
+	return bar.String()
+}
+
+// timelineBar draws when the bead was worked on within the convoy's
+// timeline, at least one character for a bead whose polecat started.
+func timelineBar(p beadProgress, start, end time.Time, width int) string {
+	line := []byte(strings.Repeat(" ", width))
+	span := end.Sub(start)
+	if p.Start.IsZero() || span <= 0 {
+		return "|" + string(line) + "|"
+	}
+
+	finish := p.End
+	if finish.IsZero() || finish.After(end) {
+		finish = end
+	}
+	column := func(t time.Time) int {
+		return int(float64(t.Sub(start)) / float64(span) * float64(width))
+	}
+	from := min(max(column(p.Start), 0), width-1)
+	to := min(max(column(finish), from+1), width)
+	for i := from; i < to; i++ {
+		line[i] = beadStateChars[p.State]
+	}
+	return "|" + string(line) + "|"
+}
+
+// printConvoyProgress prints the progress bar with the counts of the bead
+// states, and a table of the beads with their timelines.
+func printConvoyProgress(out io.Writer, convoy *gastownv1alpha1.Convoy, progress convoyProgress, now time.Time) {
+	_, _ = fmt.Fprintf(out, "ID:          %s\n", convoy.Name)
+	if convoy.Spec.Description != "" {
+		_, _ = fmt.Fprintf(out, "Description: %s\n", convoy.Spec.Description)
+	}
+	if convoy.Spec.RigRef != "" {
+		_, _ = fmt.Fprintf(out, "Rig:         %s\n", convoy.Spec.RigRef)
+	}
+	_, _ = fmt.Fprintf(out, "Phase:       %s\n", orDash(string(convoy.Status.Phase)))
+
+	total := len(progress.Beads)
+	if total == 0 {
+		_, _ = fmt.Fprintln(out, "\nNo beads tracked")
+		return
+	}
+	finished := progress.Counts[beadMerged] + progress.Counts[beadDone]
+	_, _ = fmt.Fprintf(out, "Progress:    %s %d/%d (%.0f%%)\n", progressBar(progress.Counts, progressBarWidth),
+		finished, total, float64(finished)/float64(total)*100)
+	counts := make([]string, 0, len(beadStates))
+	for _, state := range beadStates {
+		counts = append(counts, fmt.Sprintf("%c %s %d", beadStateChars[state], state, progress.Counts[state]))
+	}
+	_, _ = fmt.Fprintf(out, "             %s\n\n", strings.Join(counts, "  "))
+
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintf(w, "BEAD\tPOLECAT\tSTATE\tELAPSED\tTIMELINE (%s)\n", progress.End.Sub(progress.Start).Round(time.Second))
+	for _, p := range progress.Beads {
+		elapsed := "-"
+		if !p.Start.IsZero() {
+			finish := p.End
+			if finish.IsZero() {
+				finish = now
+			}
+			elapsed = finish.Sub(p.Start).Round(time.Second).String()
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", p.Bead, orDash(p.Polecat), p.State, elapsed,
+			timelineBar(p, progress.Start, progress.End, timelineWidth))
+	}
+	_ = w.Flush()
+}
@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func progressPolecat(name, bead string, created time.Time, phase gastownv1alpha1.PolecatPhase) gastownv1alpha1.Polecat {
+	return gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              name,
+			CreationTimestamp: metav1.NewTime(created),
+			Labels:            map[string]string{convoyLabel: "cv-1"},
+		},
+		Spec:   gastownv1alpha1.PolecatSpec{BeadID: bead},
+		Status: gastownv1alpha1.PolecatStatus{Phase: phase, AssignedBead: bead},
+	}
+}
+
+func TestNewConvoyProgress(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	now := start.Add(time.Hour)
+	convoy := &gastownv1alpha1.Convoy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cv-1"},
+		Spec:       gastownv1alpha1.ConvoySpec{TrackedBeads: []string{"gt-1", "gt-2", "gt-3", "gt-4", "gt-5", "gt-6"}},
+		Status: gastownv1alpha1.ConvoyStatus{
+			StartedAt:      &metav1.Time{Time: start},
+			CompletedBeads: []string{"gt-5"},
+		},
+	}
+
+	merged := progressPolecat("nux", "gt-1", start, gastownv1alpha1.PolecatPhaseDone)
+	merged.Status.MergedCommit = "abc1234"
+	merged.Status.FinishedAt = &metav1.Time{Time: start.Add(30 * time.Minute)}
+	done := progressPolecat("slit", "gt-2", start, gastownv1alpha1.PolecatPhaseDone)
+	working := progressPolecat("toast", "gt-3", start.Add(30*time.Minute), gastownv1alpha1.PolecatPhaseWorking)
+	stuck := progressPolecat("ace", "gt-4", start, gastownv1alpha1.PolecatPhaseStuck)
+	// Held for its dependencies, not assigned its bead yet
+	held := progressPolecat("capable", "gt-6", start, gastownv1alpha1.PolecatPhaseIdle)
+	held.Status.AssignedBead = ""
+
+	progress := newConvoyProgress(convoy, []gastownv1alpha1.Polecat{merged, done, working, stuck, held}, now)
+	want := map[string]string{
+		"gt-1": beadMerged, "gt-2": beadDone, "gt-3": beadWorking,
+		"gt-4": beadFailed, "gt-5": beadDone, "gt-6": beadPending,
+	}
+	for _, p := range progress.Beads {
+		if p.State != want[p.Bead] {
+			t.Errorf("expected %s %s, got %s", p.Bead, want[p.Bead], p.State)
+		}
+	}
+	if progress.Beads[5].Polecat != "capable" {
+		t.Errorf("expected the held polecat to stand for gt-6, got %q", progress.Beads[5].Polecat)
+	}
+	if progress.Counts[beadDone] != 2 || progress.Counts[beadMerged] != 1 {
+		t.Errorf("unexpected counts %v", progress.Counts)
+	}
+	if !progress.Start.Equal(start) || !progress.End.Equal(now) {
+		t.Errorf("expected the timeline from the start until now, got %s-%s", progress.Start, progress.End)
+	}
+
+	if got := timelineBar(progress.Beads[0], progress.Start, progress.End, 10); got != "|=====     |" {
+		t.Errorf("expected the merged bead in the first half, got %q", got)
+	}
+	if got := timelineBar(progress.Beads[2], progress.Start, progress.End, 10); got != "|     >>>>>|" {
+		t.Errorf("expected the working bead until now, got %q", got)
+	}
+	if got := timelineBar(progress.Beads[4], progress.Start, progress.End, 10); got != "|          |" {
+		t.Errorf("expected an empty timeline without polecat, got %q", got)
+	}
+}
+
+func TestProgressBar(t *testing.T) {
+	counts := map[string]int{beadMerged: 1, beadDone: 1, beadWorking: 1, beadPending: 1}
+	if got := progressBar(counts, 8); got != "[==##>>..]" {
+		t.Errorf("unexpected bar %q", got)
+	}
+	// Rounding never over- or underfills the bar
+	counts = map[string]int{beadMerged: 1, beadWorking: 1, beadPending: 1}
+	if got := progressBar(counts, 10); len(got) != 12 {
+		t.Errorf("expected a bar of 10, got %q", got)
+	}
+	if got := progressBar(map[string]int{}, 4); got != "[    ]" {
+		t.Errorf("expected an empty bar, got %q", got)
+	}
+}
+
+func TestPrintConvoyProgress(t *testing.T) {
+	start := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	convoy := &gastownv1alpha1.Convoy{
+		ObjectMeta: metav1.ObjectMeta{Name: "cv-1"},
+		Spec:       gastownv1alpha1.ConvoySpec{Description: "Wave 1", TrackedBeads: []string{"gt-1", "gt-2"}},
+		Status:     gastownv1alpha1.ConvoyStatus{Phase: gastownv1alpha1.ConvoyPhaseInProgress, StartedAt: &metav1.Time{Time: start}},
+	}
+	polecat := progressPolecat("nux", "gt-1", start, gastownv1alpha1.PolecatPhaseWorking)
+	now := start.Add(10 * time.Minute)
+
+	var out bytes.Buffer
+	printConvoyProgress(&out, convoy, newConvoyProgress(convoy, []gastownv1alpha1.Polecat{polecat}, now), now)
+	for _, want := range []string{
+		"Description: Wave 1",
+		"Progress:    [" + strings.Repeat(">", 20) + strings.Repeat(".", 20) + "] 0/2 (0%)",
+		"> Working 1  x Failed 0  . Pending 1",
+		"TIMELINE (10m0s)",
+	} {
+		if !strings.Contains(out.String(), want) {
+			t.Errorf("expected %q in:\n%s", want, out.String())
+		}
+	}
+	lines := strings.Split(out.String(), "\n")
+	var row string
+	for _, line := range lines {
+		if strings.HasPrefix(line, "gt-1") {
+			row = line
+		}
+	}
+	if !strings.Contains(row, "nux") || !strings.Contains(row, "Working") || !strings.Contains(row, "10m0s") {
+		t.Errorf("unexpected row for gt-1: %q", row)
+	}
+}
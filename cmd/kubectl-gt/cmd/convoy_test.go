@@ -1,7 +1,17 @@
 package cmd
 
 import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
 	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
 )
 
 func TestNewConvoyCmd(t *testing.T) {
@@ -12,7 +22,7 @@ func TestNewConvoyCmd(t *testing.T) {
 	}
 
 	// Check subcommands
-	expectedSubs := []string{"list", "status", "create"}
+	expectedSubs := []string{"list", "status", "outcomes", "create"}
 	for _, sub := range expectedSubs {
 		found := false
 		for _, c := range cmd.Commands() {
@@ -34,9 +44,11 @@ func TestNewConvoyListCmd(t *testing.T) {
 		t.Errorf("expected Use to be 'list', got %s", cmd.Use)
 	}
 
-	// Check output flag
-	if cmd.Flags().Lookup("output") == nil {
-		t.Error("expected --output flag to exist")
+	// Check output and selector flags
+	for _, flag := range []string{"output", "selector", "field-selector"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected --%s flag to exist", flag)
+		}
 	}
 }
 
@@ -47,9 +59,11 @@ func TestNewConvoyStatusCmd(t *testing.T) {
 		t.Errorf("expected Use to be 'status <id>', got %s", cmd.Use)
 	}
 
-	// Check output flag
-	if cmd.Flags().Lookup("output") == nil {
-		t.Error("expected --output flag to exist")
+	// Check output and watch flags
+	for _, flag := range []string{"output", "watch"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected --%s flag to exist", flag)
+		}
 	}
 }
 
@@ -59,4 +73,81 @@ func TestNewConvoyCreateCmd(t *testing.T) {
 	if cmd.Use != "create <description> <bead1> [bead2] ..." {
 		t.Errorf("expected Use to be 'create <description> <bead1> [bead2] ...', got %s", cmd.Use)
 	}
+
+	if cmd.Flags().Lookup("output") == nil {
+		t.Error("expected --output flag to exist")
+	}
+}
+
+func TestConvoyCreateResult(t *testing.T) {
+	created := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      "cv-1a2b",
+			"namespace": "gastown-system",
+			"uid":       "0000-1111",
+		},
+		"spec": map[string]any{
+			"description":  "Wave 1",
+			"trackedBeads": []any{"dm-0001", "dm-0002"},
+		},
+	}}
+
+	var buf bytes.Buffer
+	if err := printStructured(&buf, OutputFormatJSON, newConvoyCreateResult(created)); err != nil {
+		t.Fatal(err)
+	}
+
+	var got map[string]any
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("output is not JSON: %v\n%s", err, buf.String())
+	}
+	if got["convoyID"] != "cv-1a2b" || got["namespace"] != "gastown-system" || got["uid"] != "0000-1111" {
+		t.Errorf("unexpected identity in %v", got)
+	}
+	if beads, _ := got["beads"].([]any); len(beads) != 2 {
+		t.Errorf("expected 2 beads, got %v", got["beads"])
+	}
+}
+
+func TestConvoyOutcomesPage(t *testing.T) {
+	convoy := &gastownv1alpha1.Convoy{ObjectMeta: metav1.ObjectMeta{Name: "cv-1a2b"}}
+	for i := range 5 {
+		convoy.Status.Outcomes = append(convoy.Status.Outcomes, gastownv1alpha1.BeadOutcome{Bead: fmt.Sprintf("dm-000%d", i)})
+	}
+
+	page := newConvoyOutcomesPage(convoy, 2, 0)
+	if page.Total != 5 || len(page.Outcomes) != 2 || page.Outcomes[0].Bead != "dm-0000" || page.Next != 2 {
+		t.Errorf("unexpected first page %+v", page)
+	}
+	page = newConvoyOutcomesPage(convoy, 2, 4)
+	if len(page.Outcomes) != 1 || page.Outcomes[0].Bead != "dm-0004" || page.Next != 0 {
+		t.Errorf("unexpected last page %+v", page)
+	}
+	if page = newConvoyOutcomesPage(convoy, 0, 1); len(page.Outcomes) != 4 || page.Next != 0 {
+		t.Errorf("expected all outcomes after the offset without a limit, got %+v", page)
+	}
+	if page = newConvoyOutcomesPage(convoy, 2, 9); len(page.Outcomes) != 0 || page.Outcomes == nil {
+		t.Errorf("expected an empty page past the end, got %+v", page)
+	}
+}
+
+func TestPrintConvoyOutcomes(t *testing.T) {
+	page := ConvoyOutcomesPage{Convoy: "cv-1a2b", Total: 3, Offset: 0, Next: 2, Outcomes: []gastownv1alpha1.BeadOutcome{
+		{Bead: "dm-0001", Polecat: "nux", Phase: gastownv1alpha1.PolecatPhaseDone, MergeCommit: "0123456789abcdef",
+			CostUSD: "1.25", Duration: &metav1.Duration{Duration: 90 * time.Second}},
+		{Bead: "dm-0002", Polecat: "slit", Phase: gastownv1alpha1.PolecatPhaseStuck, FailureReason: "TaskIncomplete"},
+	}}
+
+	var buf bytes.Buffer
+	printConvoyOutcomes(&buf, page, 300)
+	out := buf.String()
+	for _, want := range []string{
+		"0123456", "$1.25", "1m30s", "TaskIncomplete",
+		"Showing 1-2 of 3; next page: --offset 2",
+		"Outcomes are reported for the first 3 of 300 tracked beads",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("expected %q in output:\n%s", want, out)
+		}
+	}
 }
@@ -0,0 +1,591 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"maps"
+	"slices"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// Doctor check results
+const (
+	DoctorPass = "pass"
+	DoctorWarn = "warn"
+	DoctorFail = "fail"
+)
+
+// gastownResources are the resources the operator's CRDs serve
+var gastownResources = []string{
+	"beadstores", "convoys", "emergencystops", "gastownconfigs",
+	"polecats", "refineries", "rigs", "witnesses",
+}
+
+// operatorSelector selects the operator's Deployment, whether installed with
+// kustomize or Helm
+const operatorSelector = "control-plane=controller-manager"
+
+// imagePullReasons are the waiting reasons of containers whose image cannot
+// be pulled
+var imagePullReasons = []string{"ErrImagePull", "ImagePullBackOff", "InvalidImageName"}
+
+func newDoctorCmd() *cobra.Command {
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "doctor",
+		Short: "Diagnose the cluster and Gas Town configuration",
+		Long: `Check that Gas Town can run in the current cluster and namespace:
+
+  crds       the Gas Town CRDs are installed and served
+  operator   the operator Deployment is available
+  webhooks   the admission webhooks have a CA bundle and a ready endpoint
+  images     no Gas Town Pod is failing to pull its image
+  rig/<name> the Secrets the rig, its Refinery and its Polecats reference
+             exist, with the referenced keys
+
+Every check that does not pass prints a hint on how to fix it. The command
+exits non-zero when a check fails.`,
+		Example: `  # Diagnose the current namespace
+  kubectl gt doctor
+
+  # Report as JSON
+  kubectl gt doctor -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runDoctor(cmd.OutOrStdout(), outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+
+	return cmd
+}
+
+// DoctorCheck is the result of a check run by kubectl gt doctor.
+type DoctorCheck struct {
+	Name    string `json:"name"`
+	Status  string `json:"status"`
+	Message string `json:"message"`
+
+	// Hint tells how to fix a check that did not pass
+	Hint string `json:"hint,omitempty"`
+}
+
+func runDoctor(out io.Writer, outputFormat string) error {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	checks := runDoctorChecks(context.Background(), clientset, client, GetNamespace())
+
+	if outputFormat != OutputFormatTable {
+		if err := printStructured(out, outputFormat, checks); err != nil {
+			return err
+		}
+	} else if err := printDoctorChecks(out, checks); err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, c := range checks {
+		if c.Status == DoctorFail {
+			failed++
+		}
+	}
+	if failed > 0 {
+		return fmt.Errorf("%d of %d checks failed", failed, len(checks))
+	}
+	return nil
+}
+
+// runDoctorChecks runs the checks against the cluster. The checks of the
+// rigs are skipped when the CRDs are not installed.
+func runDoctorChecks(ctx context.Context, clientset kubernetes.Interface, client dynamic.Interface, namespace string) []DoctorCheck {
+	crds := checkCRDs(clientset)
+	operator, operatorNamespace := checkOperator(ctx, clientset)
+	checks := []DoctorCheck{crds, operator}
+	checks = append(checks, checkWebhooks(ctx, clientset)...)
+
+	namespaces := []string{namespace}
+	if operatorNamespace != "" {
+		namespaces = append(namespaces, operatorNamespace)
+	}
+	var rigChecks []DoctorCheck
+	if crds.Status != DoctorFail {
+		var rigNamespaces []string
+		rigChecks, rigNamespaces = checkRigs(ctx, clientset, client)
+		namespaces = append(namespaces, rigNamespaces...)
+	}
+	slices.Sort(namespaces)
+	checks = append(checks, checkImagePulls(ctx, clientset, slices.Compact(namespaces)))
+	return append(checks, rigChecks...)
+}
+
+// printDoctorChecks writes the checks as a table, followed by the hints of
+// the checks that did not pass.
+func printDoctorChecks(out io.Writer, checks []DoctorCheck) error {
+	w := tabwriter.NewWriter(out, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "CHECK\tSTATUS\tMESSAGE")
+	for _, c := range checks {
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", c.Name, c.Status, c.Message)
+	}
+	if err := w.Flush(); err != nil {
+		return err
+	}
+
+	var hints []string
+	for _, c := range checks {
+		if c.Status != DoctorPass && c.Hint != "" {
+			hints = append(hints, fmt.Sprintf("  %s: %s", c.Name, c.Hint))
+		}
+	}
+	if len(hints) > 0 {
+		_, err := fmt.Fprintf(out, "\nTo fix:\n%s\n", strings.Join(hints, "\n"))
+		return err
+	}
+	return nil
+}
+
+// checkCRDs checks that the API server serves every Gas Town resource.
+func checkCRDs(clientset kubernetes.Interface) DoctorCheck {
+	check := DoctorCheck{
+		Name: "crds",
+		Hint: "install the CRDs with 'make install' or the Helm chart, which ships them in crds/",
+	}
+	groupVersion := gastownv1alpha1.GroupVersion.String()
+	list, err := clientset.Discovery().ServerResourcesForGroupVersion(groupVersion)
+	if err != nil {
+		check.Status = DoctorFail
+		if apierrors.IsNotFound(err) {
+			check.Message = fmt.Sprintf("%s is not served", groupVersion)
+		} else {
+			check.Message = fmt.Sprintf("cannot discover %s: %v", groupVersion, err)
+		}
+		return check
+	}
+
+	var missing []string
+	for _, resource := range gastownResources {
+		if !slices.ContainsFunc(list.APIResources, func(r metav1.APIResource) bool { return r.Name == resource }) {
+			missing = append(missing, resource)
+		}
+	}
+	if len(missing) > 0 {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("missing %s", strings.Join(missing, ", "))
+		check.Hint = "upgrade the CRDs to the operator's version with 'make install' or the Helm chart"
+		return check
+	}
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("%d resources served by %s", len(gastownResources), groupVersion)
+	return check
+}
+
+// checkOperator checks that the operator Deployment is available. It also
+// returns the operator's namespace, or "" if it was not found.
+func checkOperator(ctx context.Context, clientset kubernetes.Interface) (DoctorCheck, string) {
+	check := DoctorCheck{Name: "operator"}
+	list, err := clientset.AppsV1().Deployments(metav1.NamespaceAll).List(ctx, metav1.ListOptions{LabelSelector: operatorSelector})
+	if err != nil {
+		check.Status = DoctorWarn
+		check.Message = fmt.Sprintf("cannot list Deployments: %v", err)
+		check.Hint = "run doctor with permission to list Deployments in all namespaces"
+		return check, ""
+	}
+
+	var operator *appsv1.Deployment
+	for i := range list.Items {
+		if strings.Contains(list.Items[i].Name, "gastown-operator") {
+			operator = &list.Items[i]
+			break
+		}
+	}
+	if operator == nil {
+		check.Status = DoctorFail
+		check.Message = "operator Deployment not found"
+		check.Hint = "deploy the operator with 'make deploy' or 'helm install gastown-operator helm/gastown-operator'"
+		return check, ""
+	}
+
+	desired := int32(1)
+	if operator.Spec.Replicas != nil {
+		desired = *operator.Spec.Replicas
+	}
+	name := operator.Namespace + "/" + operator.Name
+	if operator.Status.AvailableReplicas < desired || desired == 0 {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("%s has %d of %d replicas available", name, operator.Status.AvailableReplicas, desired)
+		for _, cond := range operator.Status.Conditions {
+			if cond.Type == appsv1.DeploymentAvailable && cond.Message != "" {
+				check.Message += ": " + cond.Message
+			}
+		}
+		check.Hint = fmt.Sprintf("inspect it with 'kubectl -n %s describe deployment %s' and 'kubectl -n %s logs deployment/%s'",
+			operator.Namespace, operator.Name, operator.Namespace, operator.Name)
+		return check, operator.Namespace
+	}
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("%s has %d of %d replicas available", name, operator.Status.AvailableReplicas, desired)
+	return check, operator.Namespace
+}
+
+// webhookClient is the part of a webhook the health check looks at
+type webhookClient struct {
+	service  *admissionregistrationv1.ServiceReference
+	caBundle []byte
+}
+
+// checkWebhooks checks each admission webhook configuration with webhooks
+// for Gas Town resources: the configuration needs a CA bundle, and the
+// service it calls a ready endpoint.
+func checkWebhooks(ctx context.Context, clientset kubernetes.Interface) []DoctorCheck {
+	configs := map[string][]webhookClient{}
+
+	mutating, err := clientset.AdmissionregistrationV1().MutatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return []DoctorCheck{webhookListFailure(err)}
+	}
+	for _, config := range mutating.Items {
+		for _, webhook := range config.Webhooks {
+			if targetsGastown(webhook.Rules) {
+				configs[config.Name] = append(configs[config.Name], webhookClient{webhook.ClientConfig.Service, webhook.ClientConfig.CABundle})
+			}
+		}
+	}
+	validating, err := clientset.AdmissionregistrationV1().ValidatingWebhookConfigurations().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return []DoctorCheck{webhookListFailure(err)}
+	}
+	for _, config := range validating.Items {
+		for _, webhook := range config.Webhooks {
+			if targetsGastown(webhook.Rules) {
+				configs[config.Name] = append(configs[config.Name], webhookClient{webhook.ClientConfig.Service, webhook.ClientConfig.CABundle})
+			}
+		}
+	}
+
+	if len(configs) == 0 {
+		return []DoctorCheck{{
+			Name:    "webhooks",
+			Status:  DoctorWarn,
+			Message: "no admission webhooks registered for Gas Town resources",
+			Hint:    "defaults and validation are not applied on admission; deploy with 'make deploy' to enable them",
+		}}
+	}
+	names := slices.Sorted(maps.Keys(configs))
+
+	checks := make([]DoctorCheck, 0, len(names))
+	for _, name := range names {
+		checks = append(checks, checkWebhookConfig(ctx, clientset, name, configs[name]))
+	}
+	return checks
+}
+
+func webhookListFailure(err error) DoctorCheck {
+	return DoctorCheck{
+		Name:    "webhooks",
+		Status:  DoctorWarn,
+		Message: fmt.Sprintf("cannot list webhook configurations: %v", err),
+		Hint:    "run doctor with permission to list admission webhook configurations",
+	}
+}
+
+// targetsGastown reports whether any rule matches the Gas Town API group.
+func targetsGastown(rules []admissionregistrationv1.RuleWithOperations) bool {
+	return slices.ContainsFunc(rules, func(rule admissionregistrationv1.RuleWithOperations) bool {
+		return slices.Contains(rule.APIGroups, gastownv1alpha1.GroupVersion.Group)
+	})
+}
+
+// checkWebhookConfig checks the webhooks of one configuration.
+func checkWebhookConfig(ctx context.Context, clientset kubernetes.Interface, name string, webhooks []webhookClient) DoctorCheck {
+	check := DoctorCheck{Name: "webhook/" + name}
+	services := map[string]bool{}
+	for _, webhook := range webhooks {
+		if webhook.service == nil {
+			continue
+		}
+		if len(webhook.caBundle) == 0 {
+			check.Status = DoctorFail
+			check.Message = "no CA bundle; the API server cannot trust the webhook server"
+			check.Hint = "check that cert-manager is installed and injecting the CA into " + name
+			return check
+		}
+		services[webhook.service.Namespace+"/"+webhook.service.Name] = true
+	}
+
+	for service := range services {
+		namespace, serviceName, _ := strings.Cut(service, "/")
+		ready, err := readyEndpoints(ctx, clientset, namespace, serviceName)
+		if err != nil {
+			check.Status = DoctorWarn
+			check.Message = fmt.Sprintf("cannot list the endpoints of service %s: %v", service, err)
+			return check
+		}
+		if ready == 0 {
+			check.Status = DoctorFail
+			check.Message = fmt.Sprintf("service %s has no ready endpoints; creating and updating Gas Town resources fails", service)
+			check.Hint = "the operator's webhook server is not running; fix the operator check first"
+			return check
+		}
+	}
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("%d webhooks served", len(webhooks))
+	return check
+}
+
+// readyEndpoints counts the ready endpoints of a service.
+func readyEndpoints(ctx context.Context, clientset kubernetes.Interface, namespace, service string) (int, error) {
+	list, err := clientset.DiscoveryV1().EndpointSlices(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: discoveryv1.LabelServiceName + "=" + service,
+	})
+	if err != nil {
+		return 0, err
+	}
+	ready := 0
+	for _, slice := range list.Items {
+		for _, endpoint := range slice.Endpoints {
+			// A nil ready condition means ready
+			if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+				ready++
+			}
+		}
+	}
+	return ready, nil
+}
+
+// checkImagePulls checks that no Pod in the namespaces fails to pull its
+// image.
+func checkImagePulls(ctx context.Context, clientset kubernetes.Interface, namespaces []string) DoctorCheck {
+	check := DoctorCheck{Name: "images"}
+	var failures []string
+	for _, namespace := range namespaces {
+		pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			check.Status = DoctorWarn
+			check.Message = fmt.Sprintf("cannot list Pods in %s: %v", namespace, err)
+			return check
+		}
+		for _, p := range pods.Items {
+			statuses := append(slices.Clone(p.Status.InitContainerStatuses), p.Status.ContainerStatuses...)
+			for _, status := range statuses {
+				if waiting := status.State.Waiting; waiting != nil && slices.Contains(imagePullReasons, waiting.Reason) {
+					failures = append(failures, fmt.Sprintf("%s/%s cannot pull %s (%s)", p.Namespace, p.Name, status.Image, waiting.Reason))
+				}
+			}
+		}
+	}
+
+	if len(failures) > 0 {
+		check.Status = DoctorFail
+		check.Message = strings.Join(failures, "; ")
+		check.Hint = "check the image names, the rig's imagePullSecrets, and the imageMirrors of the GastownConfig"
+		return check
+	}
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("no image pull failures in %s", strings.Join(namespaces, ", "))
+	return check
+}
+
+// secretUse is a Secret, and optionally a key of it, that a rig depends on
+type secretUse struct {
+	name   string
+	key    string
+	usedBy string
+}
+
+// checkRigs checks the Secrets of every rig. It also returns the namespaces
+// of the rigs' polecats.
+func checkRigs(ctx context.Context, clientset kubernetes.Interface, client dynamic.Interface) ([]DoctorCheck, []string) {
+	list, err := client.Resource(rigGVR).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return []DoctorCheck{{
+			Name:    "rigs",
+			Status:  DoctorWarn,
+			Message: fmt.Sprintf("cannot list rigs: %v", err),
+		}}, nil
+	}
+	if len(list.Items) == 0 {
+		return []DoctorCheck{{
+			Name:    "rigs",
+			Status:  DoctorWarn,
+			Message: "no rigs found",
+			Hint:    "bootstrap one with 'kubectl gt rig init <name> --git-url <url>'",
+		}}, nil
+	}
+
+	var checks []DoctorCheck
+	var namespaces []string
+	for i := range list.Items {
+		var rig gastownv1alpha1.Rig
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(list.Items[i].Object, &rig); err != nil {
+			checks = append(checks, DoctorCheck{
+				Name:    "rig/" + list.Items[i].GetName(),
+				Status:  DoctorFail,
+				Message: fmt.Sprintf("cannot decode the rig: %v", err),
+			})
+			continue
+		}
+		namespace := rigNamespace(&list.Items[i])
+		namespaces = append(namespaces, namespace)
+		checks = append(checks, checkRigSecrets(ctx, clientset, client, &rig, namespace))
+	}
+	return checks, namespaces
+}
+
+// checkRigSecrets checks that the Secrets the rig, its Refineries and its
+// active Polecats reference exist in namespace. A rig without polecats is
+// checked for the Secrets kubectl gt sling mounts by default.
+func checkRigSecrets(ctx context.Context, clientset kubernetes.Interface, client dynamic.Interface,
+	rig *gastownv1alpha1.Rig, namespace string) DoctorCheck {
+	check := DoctorCheck{Name: "rig/" + rig.Name}
+
+	var refineries []gastownv1alpha1.Refinery
+	var polecats []gastownv1alpha1.Polecat
+	if err := listTyped(ctx, client, refineryGVR, namespace, "", &refineries); err != nil {
+		check.Status = DoctorWarn
+		check.Message = err.Error()
+		return check
+	}
+	if err := listTyped(ctx, client, polecatGVR, namespace, "", &polecats); err != nil {
+		check.Status = DoctorWarn
+		check.Message = err.Error()
+		return check
+	}
+
+	uses := rigSecretUses(rig, refineries, polecats)
+	var problems []string
+	for _, use := range uses {
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(ctx, use.name, metav1.GetOptions{})
+		switch {
+		case apierrors.IsNotFound(err):
+			problems = append(problems, fmt.Sprintf("Secret %s (%s) not found", use.name, use.usedBy))
+		case err != nil:
+			check.Status = DoctorWarn
+			check.Message = fmt.Sprintf("cannot read Secret %s: %v", use.name, err)
+			return check
+		case use.key != "" && len(secret.Data[use.key]) == 0:
+			problems = append(problems, fmt.Sprintf("Secret %s (%s) has no key %s", use.name, use.usedBy, use.key))
+		}
+	}
+	if len(problems) > 0 {
+		check.Status = DoctorFail
+		check.Message = fmt.Sprintf("in %s: %s", namespace, strings.Join(problems, "; "))
+		check.Hint = fmt.Sprintf("create the Secrets in %s; 'kubectl gt rig init %s' creates the git and Claude credentials", namespace, rig.Name)
+		return check
+	}
+
+	hasPolecats := slices.ContainsFunc(polecats, func(p gastownv1alpha1.Polecat) bool { return p.Spec.Rig == rig.Name })
+	if !hasPolecats {
+		if missing := missingSlingSecrets(ctx, clientset, rig, namespace); len(missing) > 0 {
+			check.Status = DoctorWarn
+			check.Message = fmt.Sprintf("in %s: %s", namespace, strings.Join(missing, "; "))
+			check.Hint = fmt.Sprintf("run 'kubectl gt rig init %s' or 'kubectl gt auth sync' before slinging work", rig.Name)
+			return check
+		}
+	}
+
+	check.Status = DoctorPass
+	check.Message = fmt.Sprintf("%d referenced Secrets present in %s", len(uses), namespace)
+	return check
+}
+
+// rigSecretUses returns the Secrets referenced by the rig, by the
+// Refineries processing it and by its active Polecats, each once.
+func rigSecretUses(rig *gastownv1alpha1.Rig, refineries []gastownv1alpha1.Refinery, polecats []gastownv1alpha1.Polecat) []secretUse {
+	var uses []secretUse
+	add := func(name, key, usedBy string) {
+		if name == "" || slices.ContainsFunc(uses, func(u secretUse) bool { return u.name == name && u.key == key }) {
+			return
+		}
+		uses = append(uses, secretUse{name: name, key: key, usedBy: usedBy})
+	}
+
+	for _, ref := range rig.Spec.ImagePullSecrets {
+		add(ref.Name, "", "imagePullSecrets")
+	}
+	if rig.Spec.LogArchive != nil {
+		add(rig.Spec.LogArchive.CredentialsSecretRef.Name, "", "logArchive")
+	}
+
+	for _, refinery := range refineries {
+		if refinery.Spec.RigRef != rig.Name {
+			continue
+		}
+		usedBy := "refinery " + refinery.Name
+		if ref := refinery.Spec.GitSecretRef; ref != nil {
+			add(ref.Name, "", usedBy)
+		}
+		for _, ref := range []*gastownv1alpha1.SecretKeyRef{
+			refinery.Spec.GitHubTokenSecretRef, refinery.Spec.GitLabTokenSecretRef, refinery.Spec.BitbucketTokenSecretRef,
+		} {
+			if ref != nil {
+				add(ref.Name, ref.Key, usedBy)
+			}
+		}
+	}
+
+	for _, polecat := range polecats {
+		spec := polecat.Spec.Kubernetes
+		if polecat.Spec.Rig != rig.Name || spec == nil ||
+			polecat.Status.Phase == gastownv1alpha1.PolecatPhaseDone || polecat.Status.Phase == gastownv1alpha1.PolecatPhaseTerminated {
+			continue
+		}
+		usedBy := "polecat " + polecat.Name
+		add(spec.GitSecretRef.Name, "", usedBy)
+		if spec.ClaudeCredsSecretRef != nil {
+			add(spec.ClaudeCredsSecretRef.Name, "", usedBy)
+		}
+		if spec.ApiKeySecretRef != nil {
+			add(spec.ApiKeySecretRef.Name, spec.ApiKeySecretRef.Key, usedBy)
+		}
+	}
+	return uses
+}
+
+// missingSlingSecrets returns the default Secrets of kubectl gt sling that
+// are missing: the git credentials of SSH repositories and either the Claude
+// credentials or an API key.
+func missingSlingSecrets(ctx context.Context, clientset kubernetes.Interface, rig *gastownv1alpha1.Rig, namespace string) []string {
+	exists := func(name string) bool {
+		_, err := clientset.CoreV1().Secrets(namespace).Get(ctx, name, metav1.GetOptions{})
+		return err == nil
+	}
+
+	var missing []string
+	if isSSHURL(rig.Spec.GitURL) && !exists("git-creds") {
+		missing = append(missing, "no git-creds Secret to clone "+rig.Spec.GitURL)
+	}
+	if !exists(claudeCredsSecretName) && !exists(apiKeySecretName) {
+		missing = append(missing, fmt.Sprintf("no %s or %s Secret", claudeCredsSecretName, apiKeySecretName))
+	}
+	return missing
+}
@@ -0,0 +1,232 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	admissionregistrationv1 "k8s.io/api/admissionregistration/v1"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/fake"
+)
+
+func TestNewDoctorCmd(t *testing.T) {
+	if newDoctorCmd().Flags().Lookup("output") == nil {
+		t.Error("expected flag --output to exist")
+	}
+}
+
+// servedResources makes the fake clientset's discovery serve resources.
+func servedResources(clientset *fake.Clientset, resources ...string) {
+	list := &metav1.APIResourceList{GroupVersion: "gastown.gastown.io/v1alpha1"}
+	for _, r := range resources {
+		list.APIResources = append(list.APIResources, metav1.APIResource{Name: r})
+	}
+	clientset.Discovery().(*fakediscovery.FakeDiscovery).Resources = []*metav1.APIResourceList{list}
+}
+
+func TestCheckCRDs(t *testing.T) {
+	clientset := fake.NewClientset()
+	if check := checkCRDs(clientset); check.Status != DoctorFail {
+		t.Errorf("expected a failure without the API group, got %+v", check)
+	}
+
+	servedResources(clientset, "rigs", "polecats")
+	check := checkCRDs(clientset)
+	if check.Status != DoctorFail || !strings.Contains(check.Message, "convoys") || strings.Contains(check.Message, "rigs") {
+		t.Errorf("expected the missing resources, got %+v", check)
+	}
+
+	servedResources(clientset, gastownResources...)
+	if check := checkCRDs(clientset); check.Status != DoctorPass {
+		t.Errorf("expected a pass, got %+v", check)
+	}
+}
+
+func operatorDeployment(available int32) *appsv1.Deployment {
+	replicas := int32(1)
+	return &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "gastown-operator-controller-manager",
+			Namespace: "gastown-operator-system",
+			Labels:    map[string]string{"control-plane": "controller-manager"},
+		},
+		Spec:   appsv1.DeploymentSpec{Replicas: &replicas},
+		Status: appsv1.DeploymentStatus{AvailableReplicas: available},
+	}
+}
+
+func TestCheckOperator(t *testing.T) {
+	ctx := context.Background()
+	if check, _ := checkOperator(ctx, fake.NewClientset()); check.Status != DoctorFail || check.Hint == "" {
+		t.Errorf("expected a missing operator to fail with a hint, got %+v", check)
+	}
+
+	check, namespace := checkOperator(ctx, fake.NewClientset(operatorDeployment(0)))
+	if check.Status != DoctorFail || !strings.Contains(check.Hint, "logs deployment/gastown-operator-controller-manager") {
+		t.Errorf("expected an unavailable operator to fail, got %+v", check)
+	}
+	if namespace != "gastown-operator-system" {
+		t.Errorf("expected the operator's namespace, got %q", namespace)
+	}
+
+	if check, _ := checkOperator(ctx, fake.NewClientset(operatorDeployment(1))); check.Status != DoctorPass {
+		t.Errorf("expected a pass, got %+v", check)
+	}
+}
+
+func TestCheckWebhooks(t *testing.T) {
+	ctx := context.Background()
+	if checks := checkWebhooks(ctx, fake.NewClientset()); len(checks) != 1 || checks[0].Status != DoctorWarn {
+		t.Errorf("expected a warning without webhooks, got %+v", checks)
+	}
+
+	config := func(caBundle []byte) *admissionregistrationv1.ValidatingWebhookConfiguration {
+		return &admissionregistrationv1.ValidatingWebhookConfiguration{
+			ObjectMeta: metav1.ObjectMeta{Name: "gastown-operator-validating-webhook-configuration"},
+			Webhooks: []admissionregistrationv1.ValidatingWebhook{{
+				Name: "vrig.kb.io",
+				ClientConfig: admissionregistrationv1.WebhookClientConfig{
+					Service:  &admissionregistrationv1.ServiceReference{Namespace: "gastown-operator-system", Name: "webhook-service"},
+					CABundle: caBundle,
+				},
+				Rules: []admissionregistrationv1.RuleWithOperations{{
+					Rule: admissionregistrationv1.Rule{APIGroups: []string{"gastown.gastown.io"}},
+				}},
+			}},
+		}
+	}
+	endpoints := func(ready bool) *discoveryv1.EndpointSlice {
+		return &discoveryv1.EndpointSlice{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "webhook-service-abcde",
+				Namespace: "gastown-operator-system",
+				Labels:    map[string]string{discoveryv1.LabelServiceName: "webhook-service"},
+			},
+			Endpoints: []discoveryv1.Endpoint{{Conditions: discoveryv1.EndpointConditions{Ready: &ready}}},
+		}
+	}
+
+	for name, tc := range map[string]struct {
+		objects []runtime.Object
+		want    string
+	}{
+		"no CA bundle":       {[]runtime.Object{config(nil), endpoints(true)}, DoctorFail},
+		"no ready endpoints": {[]runtime.Object{config([]byte("ca")), endpoints(false)}, DoctorFail},
+		"healthy":            {[]runtime.Object{config([]byte("ca")), endpoints(true)}, DoctorPass},
+	} {
+		checks := checkWebhooks(ctx, fake.NewClientset(tc.objects...))
+		if len(checks) != 1 || checks[0].Status != tc.want {
+			t.Errorf("%s: expected %s, got %+v", name, tc.want, checks)
+		}
+	}
+}
+
+func TestCheckImagePulls(t *testing.T) {
+	ctx := context.Background()
+	stuck := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{Name: "polecat-furiosa", Namespace: "gastown"},
+		Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{{
+			Image: "registry.internal/polecat-agent:0.4.0",
+			State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+		}}},
+	}
+
+	check := checkImagePulls(ctx, fake.NewClientset(stuck), []string{"gastown"})
+	if check.Status != DoctorFail || !strings.Contains(check.Message, "gastown/polecat-furiosa cannot pull registry.internal/polecat-agent:0.4.0") {
+		t.Errorf("expected the pull failure, got %+v", check)
+	}
+	if check := checkImagePulls(ctx, fake.NewClientset(stuck), []string{"other"}); check.Status != DoctorPass {
+		t.Errorf("expected other namespaces to pass, got %+v", check)
+	}
+}
+
+func TestCheckRigs(t *testing.T) {
+	ctx := context.Background()
+	polecat := fsckObject("gastown.gastown.io/v1alpha1", "Polecat", "furiosa", nil, map[string]any{
+		"spec": map[string]any{
+			"rig": "widgets",
+			"kubernetes": map[string]any{
+				"gitRepository":   "git@github.com:org/widgets.git",
+				"gitSecretRef":    map[string]any{"name": "git-creds"},
+				"apiKeySecretRef": map[string]any{"name": "claude-api-key", "key": "api-key"},
+			},
+		},
+	}).(*unstructured.Unstructured)
+	polecat.SetNamespace("widgets")
+
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			rigGVR:      "RigList",
+			refineryGVR: "RefineryList",
+			polecatGVR:  "PolecatList",
+		},
+		fsckObject("gastown.gastown.io/v1alpha1", "Rig", "widgets", nil, map[string]any{
+			"spec": map[string]any{
+				"gitURL": "git@github.com:org/widgets.git", "beadsPrefix": "wi", "childNamespace": "widgets",
+				"imagePullSecrets": []any{map[string]any{"name": "registry"}},
+			},
+		}),
+		fsckObject("gastown.gastown.io/v1alpha1", "Rig", "gadgets", nil, map[string]any{
+			"spec": map[string]any{"gitURL": "git@github.com:org/gadgets.git", "beadsPrefix": "ga"},
+		}),
+		polecat,
+	)
+
+	clientset := fake.NewClientset(
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "git-creds", Namespace: "widgets"}},
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "claude-api-key", Namespace: "widgets"}, Data: map[string][]byte{"other": []byte("x")}},
+	)
+
+	checks, namespaces := checkRigs(ctx, clientset, client)
+	byName := map[string]DoctorCheck{}
+	for _, c := range checks {
+		byName[c.Name] = c
+	}
+
+	widgets := byName["rig/widgets"]
+	if widgets.Status != DoctorFail {
+		t.Fatalf("expected the widgets rig to fail, got %+v", widgets)
+	}
+	for _, want := range []string{"Secret registry (imagePullSecrets) not found", "Secret claude-api-key (polecat furiosa) has no key api-key"} {
+		if !strings.Contains(widgets.Message, want) {
+			t.Errorf("expected %q in %q", want, widgets.Message)
+		}
+	}
+	if strings.Contains(widgets.Message, "git-creds") {
+		t.Errorf("expected the existing git-creds to pass, got %q", widgets.Message)
+	}
+
+	// Without polecats, the Secrets kubectl gt sling mounts are checked
+	gadgets := byName["rig/gadgets"]
+	if gadgets.Status != DoctorWarn || !strings.Contains(gadgets.Message, "no git-creds Secret") || !strings.Contains(gadgets.Hint, "rig init gadgets") {
+		t.Errorf("expected a warning for the sling defaults, got %+v", gadgets)
+	}
+
+	if len(namespaces) != 2 || !strings.Contains(strings.Join(namespaces, ","), "widgets") {
+		t.Errorf("expected the rig namespaces, got %v", namespaces)
+	}
+}
+
+func TestPrintDoctorChecks(t *testing.T) {
+	var out bytes.Buffer
+	err := printDoctorChecks(&out, []DoctorCheck{
+		{Name: "crds", Status: DoctorPass, Message: "8 resources served", Hint: "install the CRDs"},
+		{Name: "operator", Status: DoctorFail, Message: "operator Deployment not found", Hint: "deploy the operator"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "operator: deploy the operator") || strings.Contains(out.String(), "install the CRDs") {
+		t.Errorf("expected hints for the failed checks only, got:\n%s", out.String())
+	}
+}
@@ -0,0 +1,157 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+)
+
+var emergencyStopGVR = schema.GroupVersionResource{
+	Group:    "gastown.gastown.io",
+	Version:  "v1alpha1",
+	Resource: "emergencystops",
+}
+
+// defaultEmergencyStopName is the EmergencyStop engaged when --name is not given.
+const defaultEmergencyStopName = "emergency-stop"
+
+func newEstopCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "estop",
+		Short: "Engage or release the cluster-wide emergency stop",
+		Long: `The emergency stop holds all Gas Town work across every rig: no new
+polecats start and no Refinery merges until it is released.`,
+	}
+
+	cmd.AddCommand(newEstopEngageCmd())
+	cmd.AddCommand(newEstopReleaseCmd())
+
+	return cmd
+}
+
+func newEstopEngageCmd() *cobra.Command {
+	var name, reason string
+	var terminate bool
+
+	cmd := &cobra.Command{
+		Use:   "engage",
+		Short: "Stop all dispatching and merging on every rig",
+		Example: `  # Hold all new work
+  kubectl gt estop engage --reason "agent pushing secrets"
+
+  # Also kill every running agent Pod
+  kubectl gt estop engage --reason "agent deleting branches" --terminate`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newDynamicClient()
+			if err != nil {
+				return err
+			}
+			if err := engageEmergencyStop(context.Background(), client, name, reason, terminate); err != nil {
+				return err
+			}
+			fmt.Printf("Emergency stop %s engaged: no new polecats will start and merges are paused\n", name)
+			if terminate {
+				fmt.Println("Running agent Pods are being terminated")
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", defaultEmergencyStopName, "EmergencyStop resource name")
+	cmd.Flags().StringVar(&reason, "reason", "", "Why the stop is engaged (recorded in events)")
+	cmd.Flags().BoolVar(&terminate, "terminate", false, "Terminate running agent Pods")
+	_ = cmd.MarkFlagRequired("reason")
+
+	return cmd
+}
+
+func newEstopReleaseCmd() *cobra.Command {
+	var name string
+
+	cmd := &cobra.Command{
+		Use:   "release",
+		Short: "Release the emergency stop and resume work",
+		Example: `  # Resume work and merges on all rigs
+  kubectl gt estop release`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newDynamicClient()
+			if err != nil {
+				return err
+			}
+			if err := patchEmergencyStop(context.Background(), client, name, map[string]any{"engaged": false}); err != nil {
+				return err
+			}
+			fmt.Printf("Emergency stop %s released: polecats and merges resume\n", name)
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVar(&name, "name", defaultEmergencyStopName, "EmergencyStop resource name")
+
+	return cmd
+}
+
+// newDynamicClient creates a dynamic client from the kubeconfig flags.
+func newDynamicClient() (dynamic.Interface, error) {
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create client: %w", err)
+	}
+	return client, nil
+}
+
+// engageEmergencyStop creates the EmergencyStop, or re-engages an existing one.
+func engageEmergencyStop(ctx context.Context, client dynamic.Interface, name, reason string, terminate bool) error {
+	spec := map[string]any{
+		"reason":        reason,
+		"engaged":       true,
+		"terminatePods": terminate,
+	}
+
+	stop := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "gastown.gastown.io/v1alpha1",
+			"kind":       "EmergencyStop",
+			"metadata": map[string]any{
+				"name": name,
+			},
+			"spec": spec,
+		},
+	}
+
+	_, err := client.Resource(emergencyStopGVR).Create(ctx, stop, metav1.CreateOptions{FieldManager: fieldManager})
+	if apierrors.IsAlreadyExists(err) {
+		return patchEmergencyStop(ctx, client, name, spec)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to create emergency stop: %w", err)
+	}
+	return nil
+}
+
+// patchEmergencyStop merges spec into the EmergencyStop's spec.
+func patchEmergencyStop(ctx context.Context, client dynamic.Interface, name string, spec map[string]any) error {
+	patch, err := json.Marshal(map[string]any{"spec": spec})
+	if err != nil {
+		return fmt.Errorf("failed to build patch: %w", err)
+	}
+
+	_, err = client.Resource(emergencyStopGVR).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: fieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to update emergency stop %s: %w", name, err)
+	}
+	return nil
+}
@@ -0,0 +1,61 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestNewEstopCmd(t *testing.T) {
+	cmd := newEstopCmd()
+
+	subcommands := map[string]bool{}
+	for _, sub := range cmd.Commands() {
+		subcommands[sub.Name()] = true
+	}
+	for _, name := range []string{"engage", "release"} {
+		if !subcommands[name] {
+			t.Errorf("expected subcommand %s", name)
+		}
+	}
+
+	for _, flag := range []string{"name", "reason", "terminate"} {
+		if newEstopEngageCmd().Flags().Lookup(flag) == nil {
+			t.Errorf("expected engage flag --%s to exist", flag)
+		}
+	}
+}
+
+func TestEngageEmergencyStop(t *testing.T) {
+	ctx := context.Background()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{emergencyStopGVR: "EmergencyStopList"})
+
+	if err := engageEmergencyStop(ctx, client, "stop", "runaway agent", false); err != nil {
+		t.Fatalf("engage failed: %v", err)
+	}
+	if err := patchEmergencyStop(ctx, client, "stop", map[string]any{"engaged": false}); err != nil {
+		t.Fatalf("release failed: %v", err)
+	}
+
+	// Engaging again re-arms the existing stop with the new reason
+	if err := engageEmergencyStop(ctx, client, "stop", "second incident", true); err != nil {
+		t.Fatalf("re-engage failed: %v", err)
+	}
+
+	stop, err := client.Resource(emergencyStopGVR).Get(ctx, "stop", metav1.GetOptions{})
+	if err != nil {
+		t.Fatalf("get failed: %v", err)
+	}
+	engaged, _, _ := unstructured.NestedBool(stop.Object, "spec", "engaged")
+	terminate, _, _ := unstructured.NestedBool(stop.Object, "spec", "terminatePods")
+	reason, _, _ := unstructured.NestedString(stop.Object, "spec", "reason")
+	if !engaged || !terminate || reason != "second incident" {
+		t.Errorf("unexpected spec after re-engage: engaged=%v terminate=%v reason=%q", engaged, terminate, reason)
+	}
+}
@@ -0,0 +1,220 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+
+	"github.com/org/gastown-operator/pkg/fsck"
+)
+
+var podGVR = schema.GroupVersionResource{
+	Version:  "v1",
+	Resource: "pods",
+}
+
+var beadStoreGVR = schema.GroupVersionResource{
+	Group:    "gastown.gastown.io",
+	Version:  "v1alpha1",
+	Resource: "beadstores",
+}
+
+func newFsckCmd() *cobra.Command {
+	var repair bool
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "fsck",
+		Short: "Check Gas Town resources for inconsistencies",
+		Long: `Cross-check the state recorded in Gas Town resources against reality:
+
+  PolecatMissingPod        a Working polecat whose Pod no longer exists
+  OrphanedPod              a polecat Pod whose Polecat no longer exists
+  BeadOnTerminatedPolecat  a bead still in progress whose polecats all
+                           terminated without merging
+  StaleQueueEntry          a Refinery merge queue entry whose polecat is gone
+                           or whose branch was deleted or replaced
+
+With --repair, orphaned Pods are deleted and the Polecats and Refineries of
+the other issues are requeued so their controllers reconcile them again.
+Beads are never changed; their issues are left for you to triage.`,
+		Example: `  # Report inconsistencies in the current namespace
+  kubectl gt fsck
+
+  # Repair what can be repaired
+  kubectl gt fsck --repair
+
+  # Report as JSON
+  kubectl gt fsck -o json`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runFsck(repair, outputFormat)
+		},
+	}
+
+	cmd.Flags().BoolVar(&repair, "repair", false, "Repair the inconsistencies that can be repaired")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+
+	return cmd
+}
+
+// FsckResult is an inconsistency found by kubectl gt fsck.
+type FsckResult struct {
+	fsck.Issue
+
+	// Repaired is true when --repair fixed the issue
+	Repaired bool `json:"repaired,omitempty"`
+}
+
+func runFsck(repair bool, outputFormat string) error {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+
+	client, err := newDynamicClient()
+	if err != nil {
+		return err
+	}
+
+	ctx := context.Background()
+	namespace := GetNamespace()
+	state, err := collectFsckState(ctx, client, namespace)
+	if err != nil {
+		return err
+	}
+
+	results := []FsckResult{}
+	for _, issue := range fsck.Check(state) {
+		results = append(results, FsckResult{Issue: issue})
+	}
+	if repair {
+		if err := repairFsckResults(ctx, client, results, time.Now()); err != nil {
+			return err
+		}
+	}
+
+	if outputFormat != OutputFormatTable {
+		return printStructured(os.Stdout, outputFormat, results)
+	}
+
+	if len(results) == 0 {
+		fmt.Printf("No inconsistencies found in namespace %s\n", namespace)
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "KIND\tRESOURCE\tNAME\tREPAIR\tMESSAGE")
+	for _, r := range results {
+		action := "-"
+		switch {
+		case r.Repaired:
+			action = "repaired"
+		case r.Repairable:
+			action = "--repair"
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\n", r.Kind, r.Resource, r.Name, action, r.Message)
+	}
+	return w.Flush()
+}
+
+// collectFsckState lists the resources fsck cross-checks in namespace.
+func collectFsckState(ctx context.Context, client dynamic.Interface, namespace string) (fsck.State, error) {
+	var state fsck.State
+	if err := listTyped(ctx, client, polecatGVR, namespace, "", &state.Polecats); err != nil {
+		return state, err
+	}
+	if err := listTyped(ctx, client, podGVR, namespace, fsck.PolecatLabel, &state.Pods); err != nil {
+		return state, err
+	}
+	if err := listTyped(ctx, client, refineryGVR, namespace, "", &state.Refineries); err != nil {
+		return state, err
+	}
+	if err := listTyped(ctx, client, beadStoreGVR, namespace, "", &state.BeadStores); err != nil {
+		return state, err
+	}
+	return state, nil
+}
+
+// listTyped lists the gvr resources in namespace matching selector into out.
+func listTyped[T any](ctx context.Context, client dynamic.Interface, gvr schema.GroupVersionResource,
+	namespace, selector string, out *[]T) error {
+	list, err := client.Resource(gvr).Namespace(namespace).List(ctx, metav1.ListOptions{LabelSelector: selector})
+	if err != nil {
+		return fmt.Errorf("failed to list %s: %w", gvr.Resource, err)
+	}
+	for _, item := range list.Items {
+		var obj T
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(item.Object, &obj); err != nil {
+			return fmt.Errorf("failed to decode %s %s: %w", gvr.Resource, item.GetName(), err)
+		}
+		*out = append(*out, obj)
+	}
+	return nil
+}
+
+// repairFsckResults repairs the repairable results and marks them repaired:
+// orphaned Pods are deleted, Polecats and Refineries are requeued by
+// stamping fsck.RequeueAnnotation. Each object is repaired once.
+func repairFsckResults(ctx context.Context, client dynamic.Interface, results []FsckResult, now time.Time) error {
+	done := map[string]bool{}
+	for i := range results {
+		r := &results[i]
+		if !r.Repairable {
+			continue
+		}
+		key := r.Resource + "/" + r.Namespace + "/" + r.Name
+		if !done[key] {
+			if err := repairFsckIssue(ctx, client, r.Issue, now); err != nil {
+				return err
+			}
+			done[key] = true
+		}
+		r.Repaired = true
+	}
+	return nil
+}
+
+// repairFsckIssue repairs a single repairable issue.
+func repairFsckIssue(ctx context.Context, client dynamic.Interface, issue fsck.Issue, now time.Time) error {
+	var gvr schema.GroupVersionResource
+	switch issue.Resource {
+	case "Pod":
+		err := client.Resource(podGVR).Namespace(issue.Namespace).Delete(ctx, issue.Name, metav1.DeleteOptions{})
+		if err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete pod %s: %w", issue.Name, err)
+		}
+		return nil
+	case "Polecat":
+		gvr = polecatGVR
+	case "Refinery":
+		gvr = refineryGVR
+	default:
+		return fmt.Errorf("cannot repair %s %s", issue.Resource, issue.Name)
+	}
+
+	patch, err := json.Marshal(map[string]any{
+		"metadata": map[string]any{
+			"annotations": map[string]any{fsck.RequeueAnnotation: now.UTC().Format(time.RFC3339)},
+		},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build patch: %w", err)
+	}
+	_, err = client.Resource(gvr).Namespace(issue.Namespace).Patch(ctx, issue.Name, types.MergePatchType, patch,
+		metav1.PatchOptions{FieldManager: fieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to requeue %s %s: %w", issue.Resource, issue.Name, err)
+	}
+	return nil
+}
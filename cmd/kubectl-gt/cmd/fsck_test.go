@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+
+	"github.com/org/gastown-operator/pkg/fsck"
+)
+
+func TestNewFsckCmd(t *testing.T) {
+	cmd := newFsckCmd()
+
+	for _, flag := range []string{"repair", "output"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag --%s to exist", flag)
+		}
+	}
+}
+
+func fsckObject(apiVersion, kind, name string, labels map[string]any, fields map[string]any) runtime.Object {
+	obj := map[string]any{
+		"apiVersion": apiVersion,
+		"kind":       kind,
+		"metadata":   map[string]any{"name": name, "namespace": "gastown", "labels": labels},
+	}
+	for k, v := range fields {
+		obj[k] = v
+	}
+	return &unstructured.Unstructured{Object: obj}
+}
+
+func TestFsckRepair(t *testing.T) {
+	ctx := context.Background()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			polecatGVR:   "PolecatList",
+			podGVR:       "PodList",
+			refineryGVR:  "RefineryList",
+			beadStoreGVR: "BeadStoreList",
+		},
+		fsckObject("gastown.gastown.io/v1alpha1", "Polecat", "furiosa", nil, map[string]any{
+			"spec":   map[string]any{"rig": "app"},
+			"status": map[string]any{"phase": "Working", "podName": "polecat-furiosa", "branch": "polecat/furiosa"},
+		}),
+		fsckObject("v1", "Pod", "polecat-toast", map[string]any{fsck.PolecatLabel: "toast"}, nil),
+		fsckObject("v1", "Pod", "unrelated", nil, nil),
+		fsckObject("gastown.gastown.io/v1alpha1", "Refinery", "app", nil, map[string]any{
+			"status": map[string]any{"queue": []any{
+				map[string]any{"polecat": "toast", "branch": "polecat/toast"},
+				map[string]any{"polecat": "slit", "branch": "polecat/slit"},
+			}},
+		}),
+	)
+
+	state, err := collectFsckState(ctx, client, "gastown")
+	if err != nil {
+		t.Fatalf("collect failed: %v", err)
+	}
+	if len(state.Pods) != 1 {
+		t.Errorf("expected only polecat pods to be listed, got %d", len(state.Pods))
+	}
+
+	results := []FsckResult{}
+	for _, issue := range fsck.Check(state) {
+		results = append(results, FsckResult{Issue: issue})
+	}
+	if len(results) != 4 {
+		t.Fatalf("expected 4 issues, got %+v", results)
+	}
+
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	if err := repairFsckResults(ctx, client, results, now); err != nil {
+		t.Fatalf("repair failed: %v", err)
+	}
+	for _, r := range results {
+		if !r.Repaired {
+			t.Errorf("expected %s %s to be repaired", r.Kind, r.Name)
+		}
+	}
+
+	if _, err := client.Resource(podGVR).Namespace("gastown").Get(ctx, "polecat-toast", metav1.GetOptions{}); !apierrors.IsNotFound(err) {
+		t.Errorf("expected orphaned pod to be deleted, got %v", err)
+	}
+	if _, err := client.Resource(podGVR).Namespace("gastown").Get(ctx, "unrelated", metav1.GetOptions{}); err != nil {
+		t.Errorf("expected unrelated pod to be kept, got %v", err)
+	}
+	for _, requeued := range []struct {
+		gvr  schema.GroupVersionResource
+		name string
+	}{{polecatGVR, "furiosa"}, {refineryGVR, "app"}} {
+		obj, err := client.Resource(requeued.gvr).Namespace("gastown").Get(ctx, requeued.name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get %s failed: %v", requeued.name, err)
+		}
+		if got := obj.GetAnnotations()[fsck.RequeueAnnotation]; got != "2026-03-01T12:00:00Z" {
+			t.Errorf("expected %s to be requeued, got annotation %q", requeued.name, got)
+		}
+	}
+}
@@ -1,8 +1,17 @@
 package cmd
 
 import (
+	"encoding/json"
 	"fmt"
+	"io"
+	"strings"
 	"time"
+
+	"github.com/spf13/cobra"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"sigs.k8s.io/yaml"
 )
 
 // Output format constants
@@ -23,6 +32,22 @@ func truncate(s string, maxLen int) string {
 	return s[:maxLen-3] + "..."
 }
 
+// orDash returns s, or "-" for an empty table cell.
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+// shortCommit abbreviates a commit SHA like git does.
+func shortCommit(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
+
 // formatAge returns a human-readable age string
 func formatAge(t time.Time) string {
 	if t.IsZero() {
@@ -42,3 +67,103 @@ func formatAge(t time.Time) string {
 	}
 	return fmt.Sprintf("%dd", int(d.Hours()/24))
 }
+
+// validateOutputFormat rejects output formats other than table, json and yaml.
+func validateOutputFormat(format string) error {
+	switch format {
+	case OutputFormatTable, OutputFormatJSON, OutputFormatYAML:
+		return nil
+	default:
+		return fmt.Errorf("unknown output format %q (want table, json or yaml)", format)
+	}
+}
+
+// printStructured writes v to w as indented JSON or YAML.
+func printStructured(w io.Writer, format string, v any) error {
+	var data []byte
+	var err error
+	if format == OutputFormatYAML {
+		data, err = yaml.Marshal(v)
+	} else {
+		data, err = json.MarshalIndent(v, "", "  ")
+		data = append(data, '\n')
+	}
+	if err != nil {
+		return fmt.Errorf("failed to marshal output: %w", err)
+	}
+	_, err = w.Write(data)
+	return err
+}
+
+// printManifests writes objs to w as a multi-document YAML stream.
+func printManifests(w io.Writer, objs ...*unstructured.Unstructured) error {
+	for _, obj := range objs {
+		data, err := yaml.Marshal(obj.Object)
+		if err != nil {
+			return fmt.Errorf("failed to marshal %s %s: %w", obj.GetKind(), obj.GetName(), err)
+		}
+		if _, err := fmt.Fprintf(w, "---\n%s", data); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// listSelectors holds the -l and --field-selector flags of the list commands.
+type listSelectors struct {
+	labels string
+	fields string
+}
+
+// addFlags registers the selector flags on a list command.
+func (s *listSelectors) addFlags(cmd *cobra.Command) {
+	cmd.Flags().StringVarP(&s.labels, "selector", "l", "",
+		"Label selector to filter on (e.g. gastown.io/convoy=cv-1)")
+	cmd.Flags().StringVar(&s.fields, "field-selector", "",
+		"Field selector to filter on (e.g. status.phase=Stuck)")
+}
+
+// listOptions returns the server-side list options. Only the label selector
+// is sent: custom resources support field selectors on metadata.name and
+// metadata.namespace only, so fields are matched client-side by filter.
+func (s listSelectors) listOptions() metav1.ListOptions {
+	return metav1.ListOptions{LabelSelector: s.labels}
+}
+
+// filter returns the items matching the field selector.
+func (s listSelectors) filter(items []unstructured.Unstructured) ([]unstructured.Unstructured, error) {
+	if s.fields == "" {
+		return items, nil
+	}
+	selector, err := fields.ParseSelector(s.fields)
+	if err != nil {
+		return nil, fmt.Errorf("invalid field selector: %w", err)
+	}
+
+	matched := make([]unstructured.Unstructured, 0, len(items))
+	for _, item := range items {
+		set := fields.Set{}
+		for _, req := range selector.Requirements() {
+			set[req.Field] = fieldValue(item.Object, req.Field)
+		}
+		if selector.Matches(set) {
+			matched = append(matched, item)
+		}
+	}
+	return matched, nil
+}
+
+// fieldValue returns the value at a dotted path (e.g. "status.phase") as a
+// string, or "" if the field is missing or not a scalar.
+func fieldValue(obj map[string]any, path string) string {
+	value, found, err := unstructured.NestedFieldNoCopy(obj, strings.Split(path, ".")...)
+	if !found || err != nil {
+		return ""
+	}
+	switch value.(type) {
+	case map[string]any, []any:
+		return ""
+	default:
+		return fmt.Sprint(value)
+	}
+}
@@ -0,0 +1,135 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+var crdGVR = schema.GroupVersionResource{
+	Group:    "apiextensions.k8s.io",
+	Version:  "v1",
+	Resource: "customresourcedefinitions",
+}
+
+// migratedResources are the resources served in more than one version, by
+// the name of their CRD.
+var migratedResources = []struct {
+	crd string
+	gvr schema.GroupVersionResource
+}{
+	{crd: "rigs.gastown.gastown.io", gvr: rigGVR},
+	{crd: "polecats.gastown.gastown.io", gvr: polecatGVR},
+}
+
+func newMigrateCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "migrate",
+		Short: "Migrate Gas Town resources between API versions",
+	}
+
+	cmd.AddCommand(newMigrateStorageCmd())
+
+	return cmd
+}
+
+func newMigrateStorageCmd() *cobra.Command {
+	var dryRun bool
+
+	cmd := &cobra.Command{
+		Use:   "storage",
+		Short: "Rewrite Rigs and Polecats in the storage version",
+		Long: `Rewrite every Rig and Polecat so etcd holds them in the CRD's storage
+version, then drop the other versions from the CRD's status.storedVersions.
+
+Run it after upgrading the operator to a release that changes the storage
+version, and before a release that stops serving the old one.`,
+		Example: `  # Show what would be migrated
+  kubectl gt migrate storage --dry-run
+
+  # Migrate
+  kubectl gt migrate storage`,
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			client, err := newDynamicClient()
+			if err != nil {
+				return err
+			}
+			return migrateStorage(context.Background(), client, os.Stdout, dryRun)
+		},
+	}
+
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only report what would be migrated")
+
+	return cmd
+}
+
+// migrateStorage rewrites the objects of each migrated resource with no-op
+// updates, which the API server stores in the storage version, then records
+// that version as the only one stored.
+func migrateStorage(ctx context.Context, client dynamic.Interface, out io.Writer, dryRun bool) error {
+	for _, r := range migratedResources {
+		crd, err := client.Resource(crdGVR).Get(ctx, r.crd, metav1.GetOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to get CRD %s: %w", r.crd, err)
+		}
+		storage, err := storageVersion(crd)
+		if err != nil {
+			return err
+		}
+		stored, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+
+		list, err := client.Resource(r.gvr).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to list %s: %w", r.gvr.Resource, err)
+		}
+		if dryRun {
+			_, _ = fmt.Fprintf(out, "%s: would rewrite %d objects in %s (stored versions: %v)\n",
+				r.crd, len(list.Items), storage, stored)
+			continue
+		}
+
+		for i := range list.Items {
+			obj := &list.Items[i]
+			_, err := client.Resource(r.gvr).Namespace(obj.GetNamespace()).Update(ctx, obj, metav1.UpdateOptions{FieldManager: fieldManager})
+			// A conflicting write stored the object already, a deleted one
+			// needs no migration
+			if err != nil && !apierrors.IsConflict(err) && !apierrors.IsNotFound(err) {
+				return fmt.Errorf("failed to rewrite %s %s: %w", r.gvr.Resource, obj.GetName(), err)
+			}
+		}
+
+		if err := unstructured.SetNestedStringSlice(crd.Object, []string{storage}, "status", "storedVersions"); err != nil {
+			return fmt.Errorf("failed to set stored versions of %s: %w", r.crd, err)
+		}
+		if _, err := client.Resource(crdGVR).UpdateStatus(ctx, crd, metav1.UpdateOptions{FieldManager: fieldManager}); err != nil {
+			return fmt.Errorf("failed to update stored versions of %s: %w", r.crd, err)
+		}
+		_, _ = fmt.Fprintf(out, "%s: rewrote %d objects in %s\n", r.crd, len(list.Items), storage)
+	}
+	return nil
+}
+
+// storageVersion returns the name of the CRD's storage version.
+func storageVersion(crd *unstructured.Unstructured) (string, error) {
+	versions, _, _ := unstructured.NestedSlice(crd.Object, "spec", "versions")
+	for _, v := range versions {
+		version, ok := v.(map[string]any)
+		if !ok {
+			continue
+		}
+		if storage, _ := version["storage"].(bool); storage {
+			name, _ := version["name"].(string)
+			return name, nil
+		}
+	}
+	return "", fmt.Errorf("CRD %s has no storage version", crd.GetName())
+}
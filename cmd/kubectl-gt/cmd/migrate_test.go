@@ -0,0 +1,86 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func migrateCRD(name string) *unstructured.Unstructured {
+	return &unstructured.Unstructured{Object: map[string]any{
+		"apiVersion": "apiextensions.k8s.io/v1",
+		"kind":       "CustomResourceDefinition",
+		"metadata":   map[string]any{"name": name},
+		"spec": map[string]any{"versions": []any{
+			map[string]any{"name": "v1alpha1", "served": true, "storage": true},
+			map[string]any{"name": "v1alpha2", "served": true, "storage": false},
+		}},
+		"status": map[string]any{"storedVersions": []any{"v1alpha1", "v1alpha2"}},
+	}}
+}
+
+func TestMigrateStorage(t *testing.T) {
+	ctx := context.Background()
+	newClient := func() *dynamicfake.FakeDynamicClient {
+		return dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+			map[schema.GroupVersionResource]string{
+				crdGVR:     "CustomResourceDefinitionList",
+				rigGVR:     "RigList",
+				polecatGVR: "PolecatList",
+			},
+			migrateCRD("rigs.gastown.gastown.io"),
+			migrateCRD("polecats.gastown.gastown.io"),
+			fsckObject("gastown.gastown.io/v1alpha1", "Polecat", "furiosa", nil, nil),
+			fsckObject("gastown.gastown.io/v1alpha1", "Polecat", "nux", nil, nil),
+		)
+	}
+	storedVersions := func(client *dynamicfake.FakeDynamicClient, name string) []string {
+		crd, err := client.Resource(crdGVR).Get(ctx, name, metav1.GetOptions{})
+		if err != nil {
+			t.Fatalf("get CRD failed: %v", err)
+		}
+		stored, _, _ := unstructured.NestedStringSlice(crd.Object, "status", "storedVersions")
+		return stored
+	}
+
+	// A dry run changes nothing
+	client := newClient()
+	var out bytes.Buffer
+	if err := migrateStorage(ctx, client, &out, true); err != nil {
+		t.Fatalf("dry run failed: %v", err)
+	}
+	if !strings.Contains(out.String(), "polecats.gastown.gastown.io: would rewrite 2 objects in v1alpha1") {
+		t.Errorf("unexpected dry run output:\n%s", out.String())
+	}
+	for _, action := range client.Actions() {
+		if action.GetVerb() != "get" && action.GetVerb() != "list" {
+			t.Errorf("dry run made a %s of %s", action.GetVerb(), action.GetResource().Resource)
+		}
+	}
+
+	out.Reset()
+	if err := migrateStorage(ctx, client, &out, false); err != nil {
+		t.Fatalf("migrate failed: %v", err)
+	}
+	updates := 0
+	for _, action := range client.Actions() {
+		if action.GetVerb() == "update" && action.GetResource() == polecatGVR {
+			updates++
+		}
+	}
+	if updates != 2 {
+		t.Errorf("expected both polecats to be rewritten, got %d updates", updates)
+	}
+	for _, name := range []string{"rigs.gastown.gastown.io", "polecats.gastown.gastown.io"} {
+		if stored := storedVersions(client, name); len(stored) != 1 || stored[0] != "v1alpha1" {
+			t.Errorf("expected %s to store only v1alpha1, got %v", name, stored)
+		}
+	}
+}
@@ -25,6 +25,13 @@ var polecatGVR = schema.GroupVersionResource{
 	Resource: "polecats",
 }
 
+// protectAnnotation blocks termination of an in-flight polecat.
+const protectAnnotation = "gastown.io/protect"
+
+// forceDeleteAnnotation has the operator remove a deleted polecat's finalizer
+// without cleaning up after it.
+const forceDeleteAnnotation = "gastown.io/force-delete"
+
 func newPolecatCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "polecat",
@@ -43,6 +50,7 @@ func newPolecatCmd() *cobra.Command {
 func newPolecatListCmd() *cobra.Command {
 	var rig string
 	var outputFormat string
+	var selectors listSelectors
 
 	cmd := &cobra.Command{
 		Use:   "list [rig]",
@@ -53,17 +61,21 @@ func newPolecatListCmd() *cobra.Command {
   # List polecats for a specific rig
   kubectl gt polecat list my-rig
 
+  # List the stuck polecats of a convoy
+  kubectl gt polecat list -l gastown.io/convoy=cv-1 --field-selector status.phase=Stuck
+
   # Output as JSON
   kubectl gt polecat list -o json`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			if len(args) > 0 {
 				rig = args[0]
 			}
-			return runPolecatList(rig, outputFormat)
+			return runPolecatList(rig, selectors, outputFormat)
 		},
 	}
 
 	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+	selectors.addFlags(cmd)
 
 	return cmd
 }
@@ -123,7 +135,7 @@ func newPolecatLogsCmd() *cobra.Command {
 }
 
 func newPolecatNukeCmd() *cobra.Command {
-	var force bool
+	var force, skipCleanup, overrideProtection bool
 
 	cmd := &cobra.Command{
 		Use:   "nuke <rig>/<name>",
@@ -133,22 +145,36 @@ func newPolecatNukeCmd() *cobra.Command {
   kubectl gt polecat nuke my-rig/toast-001
 
   # Force terminate
-  kubectl gt polecat nuke my-rig/toast-001 --force`,
+  kubectl gt polecat nuke my-rig/toast-001 --force
+
+  # Delete a polecat stuck in Terminating, skipping its cleanup
+  kubectl gt polecat nuke my-rig/toast-001 --force --skip-cleanup
+
+  # Terminate a polecat carrying the gastown.io/protect annotation
+  kubectl gt polecat nuke my-rig/toast-001 --override-protection`,
 		RunE: func(cmd *cobra.Command, args []string) error {
 			parts := strings.SplitN(args[0], "/", 2)
 			if len(parts) != 2 {
 				return fmt.Errorf("invalid format: use <rig>/<name>")
 			}
-			return runPolecatNuke(parts[0], parts[1], force)
+			if skipCleanup && !force {
+				return fmt.Errorf("--skip-cleanup requires --force")
+			}
+			return runPolecatNuke(parts[0], parts[1], force, skipCleanup, overrideProtection)
 		},
 	}
 
 	cmd.Flags().BoolVar(&force, "force", false, "Force termination without cleanup")
+	cmd.Flags().BoolVar(&skipCleanup, "skip-cleanup", false,
+		"Delete the polecat with the "+forceDeleteAnnotation+" annotation, so the operator removes its "+
+			"finalizer without cleaning up; for polecats stuck in Terminating")
+	cmd.Flags().BoolVar(&overrideProtection, "override-protection", false,
+		"Remove the "+protectAnnotation+" annotation and terminate anyway")
 
 	return cmd
 }
 
-func runPolecatList(rig, outputFormat string) error {
+func runPolecatList(rig string, selectors listSelectors, outputFormat string) error {
 	config, err := KubeFlags.ToRESTConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -160,14 +186,18 @@ func runPolecatList(rig, outputFormat string) error {
 	}
 
 	namespace := GetNamespace()
-	list, err := client.Resource(polecatGVR).Namespace(namespace).List(context.Background(), metav1.ListOptions{})
+	list, err := client.Resource(polecatGVR).Namespace(namespace).List(context.Background(), selectors.listOptions())
 	if err != nil {
 		return fmt.Errorf("failed to list polecats: %w", err)
 	}
+	matched, err := selectors.filter(list.Items)
+	if err != nil {
+		return err
+	}
 
 	// Filter by rig if specified
-	items := make([]unstructured.Unstructured, 0, len(list.Items))
-	for _, item := range list.Items {
+	items := make([]unstructured.Unstructured, 0, len(matched))
+	for _, item := range matched {
 		if rig != "" {
 			itemRig, _, _ := unstructured.NestedString(item.Object, "spec", "rig")
 			if itemRig != rig {
@@ -301,6 +331,9 @@ func runPolecatStatus(rig, name, outputFormat string) error {
 		if branch, ok, _ := unstructured.NestedString(polecat.Object, "status", "branch"); ok && branch != "" {
 			fmt.Printf("Branch:         %s\n", branch)
 		}
+		if commit, ok, _ := unstructured.NestedString(polecat.Object, "status", "resumedFrom"); ok && commit != "" {
+			fmt.Printf("Resumed From:   %s\n", shortCommit(commit))
+		}
 
 		// Conditions
 		if conditions, ok, _ := unstructured.NestedSlice(polecat.Object, "status", "conditions"); ok && len(conditions) > 0 {
@@ -378,7 +411,7 @@ func streamPodLogs(clientset *kubernetes.Clientset, namespace, podName, containe
 	return nil
 }
 
-func runPolecatNuke(rig, name string, force bool) error {
+func runPolecatNuke(rig, name string, force, skipCleanup, overrideProtection bool) error {
 	config, err := KubeFlags.ToRESTConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -403,14 +436,35 @@ func runPolecatNuke(rig, name string, force bool) error {
 		return fmt.Errorf("polecat %s belongs to rig %s, not %s", name, actualRig, rig)
 	}
 
+	if err := checkProtection(polecat, overrideProtection); err != nil {
+		return err
+	}
+
 	// Update desiredState to Terminated
 	_ = unstructured.SetNestedField(polecat.Object, "Terminated", "spec", "desiredState")
+	if skipCleanup {
+		annotations := polecat.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[forceDeleteAnnotation] = "true"
+		polecat.SetAnnotations(annotations)
+	}
 
-	_, err = client.Resource(polecatGVR).Namespace(namespace).Update(context.Background(), polecat, metav1.UpdateOptions{})
+	_, err = client.Resource(polecatGVR).Namespace(namespace).Update(context.Background(), polecat, metav1.UpdateOptions{FieldManager: fieldManager})
 	if err != nil {
 		return fmt.Errorf("failed to update polecat: %w", err)
 	}
 
+	if skipCleanup {
+		err = client.Resource(polecatGVR).Namespace(namespace).Delete(context.Background(), name, metav1.DeleteOptions{})
+		if err != nil {
+			return fmt.Errorf("failed to delete polecat: %w", err)
+		}
+		fmt.Printf("Polecat %s/%s deleted without cleanup\n", rig, name)
+		return nil
+	}
+
 	if force {
 		fmt.Printf("Polecat %s/%s marked for forced termination\n", rig, name)
 	} else {
@@ -419,3 +473,27 @@ func runPolecatNuke(rig, name string, force bool) error {
 
 	return nil
 }
+
+// checkProtection refuses to terminate a protected polecat that is still in
+// flight. With override set, the annotation is removed from the object so the
+// update that terminates the polecat also lifts the protection.
+func checkProtection(polecat *unstructured.Unstructured, override bool) error {
+	annotations := polecat.GetAnnotations()
+	if annotations[protectAnnotation] != "true" {
+		return nil
+	}
+
+	phase, _, _ := unstructured.NestedString(polecat.Object, "status", "phase")
+	if phase == "Done" || phase == "Terminated" {
+		return nil
+	}
+
+	if !override {
+		return fmt.Errorf("polecat %s is protected by the %s annotation (phase %s); use --override-protection to terminate it",
+			polecat.GetName(), protectAnnotation, phase)
+	}
+
+	delete(annotations, protectAnnotation)
+	polecat.SetAnnotations(annotations)
+	return nil
+}
@@ -1,7 +1,10 @@
 package cmd
 
 import (
+	"strings"
 	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestNewPolecatCmd(t *testing.T) {
@@ -34,9 +37,14 @@ func TestNewPolecatListCmd(t *testing.T) {
 		t.Errorf("expected Use to be 'list [rig]', got %s", cmd.Use)
 	}
 
-	// Check output flag
-	if cmd.Flags().Lookup("output") == nil {
-		t.Error("expected --output flag to exist")
+	// Check output and selector flags
+	for _, flag := range []string{"output", "selector", "field-selector"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected --%s flag to exist", flag)
+		}
+	}
+	if cmd.Flags().ShorthandLookup("l") == nil {
+		t.Error("expected -l shorthand for --selector")
 	}
 }
 
@@ -80,4 +88,97 @@ func TestNewPolecatNukeCmd(t *testing.T) {
 	if cmd.Flags().Lookup("force") == nil {
 		t.Error("expected --force flag to exist")
 	}
+	if cmd.Flags().Lookup("override-protection") == nil {
+		t.Error("expected --override-protection flag to exist")
+	}
+
+	cmd.SetArgs([]string{"my-rig/toast-001", "--skip-cleanup"})
+	cmd.SilenceUsage = true
+	cmd.SilenceErrors = true
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "requires --force") {
+		t.Errorf("expected --skip-cleanup without --force to be refused, got %v", err)
+	}
+}
+
+func TestCheckProtection(t *testing.T) {
+	polecat := func(protect, phase string) *unstructured.Unstructured {
+		u := &unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": "toast-001"},
+			"status":   map[string]any{"phase": phase},
+		}}
+		if protect != "" {
+			u.SetAnnotations(map[string]string{protectAnnotation: protect, "team": "a"})
+		}
+		return u
+	}
+
+	if err := checkProtection(polecat("", "Working"), false); err != nil {
+		t.Errorf("unprotected polecat: unexpected error: %v", err)
+	}
+	if err := checkProtection(polecat("true", "Done"), false); err != nil {
+		t.Errorf("finished polecat: unexpected error: %v", err)
+	}
+	if err := checkProtection(polecat("true", "Working"), false); err == nil {
+		t.Error("expected protected polecat to be refused")
+	}
+
+	p := polecat("true", "Working")
+	if err := checkProtection(p, true); err != nil {
+		t.Fatalf("override: unexpected error: %v", err)
+	}
+	if _, ok := p.GetAnnotations()[protectAnnotation]; ok {
+		t.Error("expected override to remove the protect annotation")
+	}
+	if p.GetAnnotations()["team"] != "a" {
+		t.Error("expected other annotations to be kept")
+	}
+}
+
+func TestListSelectors(t *testing.T) {
+	polecat := func(name, phase string) unstructured.Unstructured {
+		return unstructured.Unstructured{Object: map[string]any{
+			"metadata": map[string]any{"name": name},
+			"spec":     map[string]any{"rig": "my-rig"},
+			"status":   map[string]any{"phase": phase},
+		}}
+	}
+	items := []unstructured.Unstructured{polecat("furiosa", "Stuck"), polecat("nux", "Working")}
+
+	t.Run("sends the label selector to the server", func(t *testing.T) {
+		opts := listSelectors{labels: "gastown.io/convoy=cv-1"}.listOptions()
+		if opts.LabelSelector != "gastown.io/convoy=cv-1" || opts.FieldSelector != "" {
+			t.Errorf("unexpected list options: %+v", opts)
+		}
+	})
+
+	t.Run("filters on status fields", func(t *testing.T) {
+		matched, err := listSelectors{fields: "status.phase=Stuck,spec.rig=my-rig"}.filter(items)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(matched) != 1 || matched[0].GetName() != "furiosa" {
+			t.Errorf("expected only furiosa, got %d items", len(matched))
+		}
+
+		matched, err = listSelectors{fields: "status.phase!=Stuck"}.filter(items)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(matched) != 1 || matched[0].GetName() != "nux" {
+			t.Errorf("expected only nux, got %d items", len(matched))
+		}
+	})
+
+	t.Run("keeps all items without a field selector", func(t *testing.T) {
+		matched, err := listSelectors{}.filter(items)
+		if err != nil || len(matched) != 2 {
+			t.Errorf("expected 2 items, got %d (err %v)", len(matched), err)
+		}
+	})
+
+	t.Run("rejects malformed selectors", func(t *testing.T) {
+		if _, err := (listSelectors{fields: "status.phase"}).filter(items); err == nil {
+			t.Error("expected error for malformed field selector")
+		}
+	})
 }
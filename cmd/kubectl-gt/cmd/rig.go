@@ -6,11 +6,13 @@ import (
 	"fmt"
 	"os"
 	"text/tabwriter"
+	"time"
 
 	"github.com/spf13/cobra"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/dynamic"
 	"sigs.k8s.io/yaml"
 )
@@ -21,16 +23,28 @@ var rigGVR = schema.GroupVersionResource{
 	Resource: "rigs",
 }
 
+var refineryGVR = schema.GroupVersionResource{
+	Group:    "gastown.gastown.io",
+	Version:  "v1alpha1",
+	Resource: "refineries",
+}
+
+// freezePollInterval is how often freeze --wait re-checks in-flight work.
+const freezePollInterval = 5 * time.Second
+
 func newRigCmd() *cobra.Command {
 	cmd := &cobra.Command{
 		Use:   "rig",
 		Short: "Manage Gas Town rigs",
-		Long:  `Commands for listing, viewing, and creating Gas Town rigs.`,
+		Long:  `Commands for listing, viewing, creating, bootstrapping, and freezing Gas Town rigs.`,
 	}
 
 	cmd.AddCommand(newRigListCmd())
 	cmd.AddCommand(newRigStatusCmd())
 	cmd.AddCommand(newRigCreateCmd())
+	cmd.AddCommand(newRigInitCmd())
+	cmd.AddCommand(newRigFreezeCmd())
+	cmd.AddCommand(newRigUnfreezeCmd())
 
 	return cmd
 }
@@ -105,6 +119,48 @@ func newRigCreateCmd() *cobra.Command {
 	return cmd
 }
 
+func newRigFreezeCmd() *cobra.Command {
+	var wait bool
+	var timeout time.Duration
+
+	cmd := &cobra.Command{
+		Use:   "freeze <name>",
+		Short: "Suspend a rig for a code freeze",
+		Long: `Suspend a rig: new polecats do not start and the Refinery stops merging.
+Polecats that are already working finish their current bead. Prints the work
+still in flight, and with --wait blocks until it has drained.`,
+		Args: cobra.ExactArgs(1),
+		Example: `  # Freeze a rig and show what is still running
+  kubectl gt rig freeze my-rig
+
+  # Freeze and wait for running work to drain
+  kubectl gt rig freeze my-rig --wait --timeout 1h`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRigFreeze(args[0], wait, timeout)
+		},
+	}
+
+	cmd.Flags().BoolVar(&wait, "wait", false, "Wait until in-flight work has drained")
+	cmd.Flags().DurationVar(&timeout, "timeout", 30*time.Minute, "Maximum time to wait with --wait")
+
+	return cmd
+}
+
+func newRigUnfreezeCmd() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "unfreeze <name>",
+		Short: "Resume a frozen rig",
+		Args:  cobra.ExactArgs(1),
+		Example: `  # Resume work and merges on a rig
+  kubectl gt rig unfreeze my-rig`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return runRigUnfreeze(args[0])
+		},
+	}
+
+	return cmd
+}
+
 func runRigList(outputFormat string) error {
 	config, err := KubeFlags.ToRESTConfig()
 	if err != nil {
@@ -262,7 +318,7 @@ func runRigCreate(name, gitURL, prefix, localPath string) error {
 		},
 	}
 
-	_, err = client.Resource(rigGVR).Create(context.Background(), rig, metav1.CreateOptions{})
+	_, err = client.Resource(rigGVR).Create(context.Background(), rig, metav1.CreateOptions{FieldManager: fieldManager})
 	if err != nil {
 		return fmt.Errorf("failed to create rig: %w", err)
 	}
@@ -270,3 +326,148 @@ func runRigCreate(name, gitURL, prefix, localPath string) error {
 	fmt.Printf("Rig %s created\n", name)
 	return nil
 }
+
+func runRigFreeze(name string, wait bool, timeout time.Duration) error {
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx := context.Background()
+	if err := setRigSuspend(ctx, client, name, true); err != nil {
+		return err
+	}
+	fmt.Printf("Rig %s frozen: no new polecats will start and merges are paused\n", name)
+
+	deadline := time.Now().Add(timeout)
+	for {
+		inFlight, err := listInFlightWork(ctx, client, name)
+		if err != nil {
+			return err
+		}
+
+		if len(inFlight) == 0 {
+			fmt.Println("No work in flight")
+			return nil
+		}
+
+		fmt.Printf("\n%d item(s) still in flight:\n", len(inFlight))
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		_, _ = fmt.Fprintln(w, "KIND\tNAME\tDETAIL")
+		for _, item := range inFlight {
+			_, _ = fmt.Fprintf(w, "%s\t%s\t%s\n", item.Kind, item.Name, item.Detail)
+		}
+		_ = w.Flush()
+
+		if !wait {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out after %s waiting for rig %s to drain", timeout, name)
+		}
+		time.Sleep(freezePollInterval)
+	}
+}
+
+func runRigUnfreeze(name string) error {
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	if err := setRigSuspend(context.Background(), client, name, false); err != nil {
+		return err
+	}
+
+	fmt.Printf("Rig %s unfrozen: polecats and merges resume\n", name)
+	return nil
+}
+
+// setRigSuspend patches spec.suspend on the rig.
+func setRigSuspend(ctx context.Context, client dynamic.Interface, name string, suspend bool) error {
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{"suspend": suspend},
+	})
+	if err != nil {
+		return fmt.Errorf("failed to build patch: %w", err)
+	}
+
+	_, err = client.Resource(rigGVR).Patch(ctx, name, types.MergePatchType, patch, metav1.PatchOptions{FieldManager: fieldManager})
+	if err != nil {
+		return fmt.Errorf("failed to update rig %s: %w", name, err)
+	}
+	return nil
+}
+
+// inFlightItem is a piece of rig work that a freeze does not interrupt.
+type inFlightItem struct {
+	Kind   string
+	Name   string
+	Detail string
+}
+
+// listInFlightWork lists working polecats and active merges for the rig.
+func listInFlightWork(ctx context.Context, client dynamic.Interface, rig string) ([]inFlightItem, error) {
+	polecats, err := client.Resource(polecatGVR).Namespace(GetNamespace()).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list polecats: %w", err)
+	}
+
+	// Refineries live in the rig's child namespace
+	refineries, err := client.Resource(refineryGVR).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list refineries: %w", err)
+	}
+
+	return inFlightWork(rig, polecats.Items, refineries.Items), nil
+}
+
+// inFlightWork returns the rig's polecats that are still working and the
+// merges its refineries have in progress.
+func inFlightWork(rig string, polecats, refineries []unstructured.Unstructured) []inFlightItem {
+	var items []inFlightItem
+
+	for _, polecat := range polecats {
+		polecatRig, _, _ := unstructured.NestedString(polecat.Object, "spec", "rig")
+		phase, _, _ := unstructured.NestedString(polecat.Object, "status", "phase")
+		if polecatRig != rig || phase != "Working" {
+			continue
+		}
+		beadID, _, _ := unstructured.NestedString(polecat.Object, "spec", "beadID")
+		items = append(items, inFlightItem{
+			Kind:   "polecat",
+			Name:   polecat.GetName(),
+			Detail: "working on " + beadID,
+		})
+	}
+
+	for _, refinery := range refineries {
+		refineryRig, _, _ := unstructured.NestedString(refinery.Object, "spec", "rigRef")
+		if refineryRig != rig {
+			continue
+		}
+		merges, _, _ := unstructured.NestedSlice(refinery.Object, "status", "activeMerges")
+		for _, m := range merges {
+			merge, _ := m.(map[string]any)
+			polecat, _ := merge["polecat"].(string)
+			branch, _ := merge["branch"].(string)
+			items = append(items, inFlightItem{
+				Kind:   "merge",
+				Name:   polecat,
+				Detail: fmt.Sprintf("merging %s (refinery %s)", branch, refinery.GetName()),
+			})
+		}
+	}
+
+	return items
+}
@@ -0,0 +1,431 @@
+package cmd
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+const (
+	// gitSSHKeyEnv holds an SSH private key for rig init instead of --ssh-key
+	gitSSHKeyEnv = "GT_GIT_SSH_KEY"
+
+	// anthropicAPIKeyEnv holds an API key rig init stores when there is no
+	// Claude config directory
+	anthropicAPIKeyEnv = "ANTHROPIC_API_KEY"
+
+	// apiKeySecretName and apiKeySecretKey are where rig init stores the API key
+	apiKeySecretName = "claude-api-key"
+	apiKeySecretKey  = "api-key"
+
+	// cloneCheckPollInterval is how often rig init checks the clone Pod
+	cloneCheckPollInterval = 2 * time.Second
+)
+
+// beadsPrefixPattern is the pattern of spec.beadsPrefix of a Rig
+var beadsPrefixPattern = regexp.MustCompile(`^[a-z]{2,10}$`)
+
+// rigInitOptions are the settings of kubectl gt rig init, from flags or prompts
+type rigInitOptions struct {
+	GitURL     string
+	Prefix     string
+	GitSecret  string
+	SSHKeyFile string
+	ClaudeDir  string
+	Image      string
+	Timeout    time.Duration
+	SkipCheck  bool
+	Force      bool
+}
+
+func newRigInitCmd() *cobra.Command {
+	var opts rigInitOptions
+
+	cmd := &cobra.Command{
+		Use:   "init <name>",
+		Short: "Bootstrap a rig with its secrets and check the repository",
+		Long: `Create a Rig and the Secrets its polecats need, then clone the repository
+from a throwaway Pod to check the credentials from inside the cluster.
+
+Settings missing from the flags are asked for when run in a terminal. The git
+SSH key is read from --ssh-key, else from $` + gitSSHKeyEnv + `. Claude
+credentials are read from --claude-dir, else the API key from
+$` + anthropicAPIKeyEnv + ` is stored. Existing Secrets are kept unless --force is
+given.`,
+		Args: cobra.ExactArgs(1),
+		Example: `  # Answer the questions interactively
+  kubectl gt rig init my-rig
+
+  # Bootstrap without prompts
+  kubectl gt rig init my-rig --git-url git@github.com:org/repo.git --prefix mr \
+    --ssh-key ~/.ssh/gastown_ed25519
+
+  # Take the credentials from the environment, e.g. in CI
+  GT_GIT_SSH_KEY="$(cat deploy-key)" ANTHROPIC_API_KEY=sk-... \
+    kubectl gt rig init my-rig --git-url git@github.com:org/repo.git --prefix mr`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			interactive := isTerminal(os.Stdin)
+			return runRigInit(cmd.InOrStdin(), cmd.OutOrStdout(), args[0], opts, interactive)
+		},
+	}
+
+	homeDir, _ := os.UserHomeDir()
+	cmd.Flags().StringVar(&opts.GitURL, "git-url", "", "Git repository URL")
+	cmd.Flags().StringVar(&opts.Prefix, "prefix", "", "Beads prefix, 2-10 lowercase letters")
+	cmd.Flags().StringVar(&opts.GitSecret, "git-secret", "git-creds", "Name of the Secret holding the git SSH key")
+	cmd.Flags().StringVar(&opts.SSHKeyFile, "ssh-key", "", "SSH private key file to store in the git Secret")
+	cmd.Flags().StringVar(&opts.ClaudeDir, "claude-dir", filepath.Join(homeDir, ".claude"), "Path to Claude config directory")
+	cmd.Flags().StringVar(&opts.Image, "image", "", "Image of the clone check Pod (default: the agent git image)")
+	cmd.Flags().DurationVar(&opts.Timeout, "timeout", 5*time.Minute, "Maximum time to wait for the clone check")
+	cmd.Flags().BoolVar(&opts.SkipCheck, "skip-check", false, "Do not check the repository with a test clone")
+	cmd.Flags().BoolVar(&opts.Force, "force", false, "Overwrite existing Secrets")
+
+	return cmd
+}
+
+// isTerminal reports whether f is an interactive terminal.
+func isTerminal(f *os.File) bool {
+	info, err := f.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
+
+func runRigInit(in io.Reader, out io.Writer, name string, opts rigInitOptions, interactive bool) error {
+	if interactive {
+		if err := promptRigInit(bufio.NewReader(in), out, name, &opts); err != nil {
+			return err
+		}
+	}
+	if opts.GitURL == "" {
+		return fmt.Errorf("--git-url is required")
+	}
+	if !beadsPrefixPattern.MatchString(opts.Prefix) {
+		return fmt.Errorf("beads prefix %q must be 2-10 lowercase letters", opts.Prefix)
+	}
+
+	gitSecret, err := gitCredsSecret(opts)
+	if err != nil {
+		return err
+	}
+	claudeSecret, err := claudeSecret(opts)
+	if err != nil {
+		return err
+	}
+
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+	clientset, err := kubernetes.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx := context.Background()
+	namespace := GetNamespace()
+	if err := createRig(ctx, client, out, name, opts.GitURL, opts.Prefix); err != nil {
+		return err
+	}
+	for _, secret := range []*corev1.Secret{gitSecret, claudeSecret} {
+		if secret == nil {
+			continue
+		}
+		secret.Namespace = namespace
+		if err := ensureSecret(ctx, clientset, out, secret, opts.Force); err != nil {
+			return err
+		}
+	}
+
+	if !opts.SkipCheck {
+		// Check with whatever git Secret the polecats will mount, new or not
+		secretName := ""
+		if _, err := clientset.CoreV1().Secrets(namespace).Get(ctx, opts.GitSecret, metav1.GetOptions{}); err == nil {
+			secretName = opts.GitSecret
+		}
+		check := pod.CloneCheckPod(pod.CloneCheckOptions{
+			Name:          name + "-clone-check",
+			Namespace:     namespace,
+			Rig:           name,
+			Image:         opts.Image,
+			GitURL:        opts.GitURL,
+			GitSecretName: secretName,
+		})
+		if err := runCloneCheck(ctx, clientset, out, check, opts.Timeout); err != nil {
+			return err
+		}
+	}
+
+	printRigNextSteps(out, name, opts.GitSecret, claudeSecret)
+	return nil
+}
+
+// promptRigInit asks for the settings missing from the flags.
+func promptRigInit(in *bufio.Reader, out io.Writer, name string, opts *rigInitOptions) error {
+	var err error
+	if opts.GitURL == "" {
+		if opts.GitURL, err = prompt(in, out, "Git repository URL", ""); err != nil {
+			return err
+		}
+	}
+	if opts.Prefix == "" {
+		if opts.Prefix, err = prompt(in, out, "Beads prefix", defaultBeadsPrefix(name)); err != nil {
+			return err
+		}
+	}
+	if opts.SSHKeyFile == "" && os.Getenv(gitSSHKeyEnv) == "" && isSSHURL(opts.GitURL) {
+		if opts.SSHKeyFile, err = prompt(in, out, "SSH private key file (empty to keep the existing Secret)", ""); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// prompt asks a question and returns the trimmed answer, else def.
+func prompt(in *bufio.Reader, out io.Writer, question, def string) (string, error) {
+	if def != "" {
+		_, _ = fmt.Fprintf(out, "%s [%s]: ", question, def)
+	} else {
+		_, _ = fmt.Fprintf(out, "%s: ", question)
+	}
+	answer, err := in.ReadString('\n')
+	if err != nil && err != io.EOF {
+		return "", fmt.Errorf("failed to read answer: %w", err)
+	}
+	if answer = strings.TrimSpace(answer); answer != "" {
+		return answer, nil
+	}
+	return def, nil
+}
+
+// defaultBeadsPrefix proposes the first letters of the rig name as prefix.
+func defaultBeadsPrefix(name string) string {
+	var prefix strings.Builder
+	for _, r := range strings.ToLower(name) {
+		if r >= 'a' && r <= 'z' {
+			prefix.WriteRune(r)
+		}
+		if prefix.Len() == 2 {
+			break
+		}
+	}
+	if prefix.Len() < 2 {
+		return ""
+	}
+	return prefix.String()
+}
+
+// isSSHURL reports whether the repository is cloned over SSH.
+func isSSHURL(gitURL string) bool {
+	return strings.HasPrefix(gitURL, "ssh://") ||
+		(!strings.Contains(gitURL, "://") && strings.Contains(gitURL, "@"))
+}
+
+// gitCredsSecret returns the git Secret with the key of --ssh-key, else of
+// $GT_GIT_SSH_KEY, or nil without either.
+func gitCredsSecret(opts rigInitOptions) (*corev1.Secret, error) {
+	var key []byte
+	switch {
+	case opts.SSHKeyFile != "":
+		data, err := os.ReadFile(opts.SSHKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read SSH key: %w", err)
+		}
+		key = data
+	case os.Getenv(gitSSHKeyEnv) != "":
+		key = []byte(os.Getenv(gitSSHKeyEnv))
+	default:
+		return nil, nil
+	}
+	if !strings.Contains(string(key), "PRIVATE KEY") {
+		return nil, fmt.Errorf("the SSH key is not a private key")
+	}
+	// ssh reads keys up to their trailing newline
+	if !strings.HasSuffix(string(key), "\n") {
+		key = append(key, '\n')
+	}
+
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: opts.GitSecret},
+		Type:       corev1.SecretTypeSSHAuth,
+		Data:       map[string][]byte{corev1.SSHAuthPrivateKey: key},
+	}, nil
+}
+
+// claudeSecret returns the Claude credentials Secret from --claude-dir, else
+// the API key Secret from $ANTHROPIC_API_KEY, or nil without either.
+func claudeSecret(opts rigInitOptions) (*corev1.Secret, error) {
+	if _, err := os.Stat(opts.ClaudeDir); err == nil {
+		data, err := readClaudeDir(opts.ClaudeDir)
+		if err != nil {
+			return nil, err
+		}
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        claudeCredsSecretName,
+				Annotations: map[string]string{syncTimestampKey: time.Now().UTC().Format(time.RFC3339)},
+			},
+			Type: corev1.SecretTypeOpaque,
+			Data: data,
+		}, nil
+	}
+	if apiKey := os.Getenv(anthropicAPIKeyEnv); apiKey != "" {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: apiKeySecretName},
+			Type:       corev1.SecretTypeOpaque,
+			Data:       map[string][]byte{apiKeySecretKey: []byte(apiKey)},
+		}, nil
+	}
+	return nil, nil
+}
+
+// createRig creates the Rig, leaving an existing one alone.
+func createRig(ctx context.Context, client dynamic.Interface, out io.Writer, name, gitURL, prefix string) error {
+	rig := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "gastown.gastown.io/v1alpha1",
+			"kind":       "Rig",
+			"metadata": map[string]any{
+				"name": name,
+			},
+			"spec": map[string]any{
+				"gitURL":      gitURL,
+				"beadsPrefix": prefix,
+			},
+		},
+	}
+
+	_, err := client.Resource(rigGVR).Create(ctx, rig, metav1.CreateOptions{FieldManager: fieldManager})
+	switch {
+	case apierrors.IsAlreadyExists(err):
+		_, _ = fmt.Fprintf(out, "✓ Rig %s already exists\n", name)
+	case err != nil:
+		return fmt.Errorf("failed to create rig: %w", err)
+	default:
+		_, _ = fmt.Fprintf(out, "✓ Rig %s created\n", name)
+	}
+	return nil
+}
+
+// ensureSecret creates the Secret, keeping an existing one unless force.
+func ensureSecret(ctx context.Context, clientset kubernetes.Interface, out io.Writer, secret *corev1.Secret, force bool) error {
+	secrets := clientset.CoreV1().Secrets(secret.Namespace)
+	_, err := secrets.Get(ctx, secret.Name, metav1.GetOptions{})
+	switch {
+	case err == nil && !force:
+		_, _ = fmt.Fprintf(out, "✓ Secret %s/%s already exists (use --force to overwrite)\n", secret.Namespace, secret.Name)
+		return nil
+	case err == nil:
+		_, err = secrets.Update(ctx, secret, metav1.UpdateOptions{FieldManager: fieldManager})
+	case apierrors.IsNotFound(err):
+		_, err = secrets.Create(ctx, secret, metav1.CreateOptions{FieldManager: fieldManager})
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write Secret %s: %w", secret.Name, err)
+	}
+	_, _ = fmt.Fprintf(out, "✓ Secret %s/%s written (%d keys)\n", secret.Namespace, secret.Name, len(secret.Data))
+	return nil
+}
+
+// runCloneCheck runs the clone check Pod until it finishes and deletes it.
+// A failed clone returns an error with the tail of its output.
+func runCloneCheck(ctx context.Context, clientset kubernetes.Interface, out io.Writer, check *corev1.Pod, timeout time.Duration) error {
+	pods := clientset.CoreV1().Pods(check.Namespace)
+
+	// A check left behind by an interrupted run would block the name
+	_ = pods.Delete(ctx, check.Name, metav1.DeleteOptions{})
+	if err := waitForPodGone(ctx, clientset, check.Namespace, check.Name, timeout); err != nil {
+		return err
+	}
+
+	if _, err := pods.Create(ctx, check, metav1.CreateOptions{FieldManager: fieldManager}); err != nil {
+		return fmt.Errorf("failed to create clone check pod: %w", err)
+	}
+	defer func() {
+		_ = pods.Delete(context.Background(), check.Name, metav1.DeleteOptions{})
+	}()
+	_, _ = fmt.Fprintf(out, "  Cloning the repository from pod %s/%s...\n", check.Namespace, check.Name)
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	ticker := time.NewTicker(cloneCheckPollInterval)
+	defer ticker.Stop()
+
+	for {
+		current, err := pods.Get(ctx, check.Name, metav1.GetOptions{})
+		if err == nil {
+			switch current.Status.Phase {
+			case corev1.PodSucceeded:
+				_, _ = fmt.Fprintln(out, "✓ Repository cloned from inside the cluster")
+				return nil
+			case corev1.PodFailed:
+				return fmt.Errorf("clone check failed: %s", cloneCheckFailure(current))
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("timed out after %s waiting for the clone check; inspect it with: kubectl describe pod %s -n %s",
+				timeout, check.Name, check.Namespace)
+		case <-ticker.C:
+		}
+	}
+}
+
+// waitForPodGone waits until the named Pod no longer exists.
+func waitForPodGone(ctx context.Context, clientset kubernetes.Interface, namespace, name string, timeout time.Duration) error {
+	deadline := time.Now().Add(timeout)
+	for {
+		_, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		if time.Now().After(deadline) {
+			return fmt.Errorf("timed out waiting for the previous clone check pod %s to be deleted", name)
+		}
+		time.Sleep(cloneCheckPollInterval)
+	}
+}
+
+// cloneCheckFailure explains why the clone check Pod failed.
+func cloneCheckFailure(p *corev1.Pod) string {
+	for _, status := range p.Status.ContainerStatuses {
+		if t := status.State.Terminated; t != nil && t.Message != "" {
+			return strings.TrimSpace(t.Message)
+		}
+	}
+	if p.Status.Message != "" {
+		return p.Status.Message
+	}
+	return "pod " + p.Name + " failed"
+}
+
+// printRigNextSteps prints the commands to continue with once the rig is set up.
+func printRigNextSteps(out io.Writer, name, gitSecret string, claudeSecret *corev1.Secret) {
+	_, _ = fmt.Fprintf(out, "\nRig %s is ready. Next steps:\n\n", name)
+	_, _ = fmt.Fprintf(out, "  # Check the rig\n  kubectl gt rig status %s\n\n", name)
+	if claudeSecret != nil && claudeSecret.Name == apiKeySecretName {
+		_, _ = fmt.Fprintf(out, "  # Polecats authenticate with the API key through\n")
+		_, _ = fmt.Fprintf(out, "  #   spec.kubernetes.apiKeySecretRef: {name: %s, key: %s}\n\n", apiKeySecretName, apiKeySecretKey)
+	}
+	_, _ = fmt.Fprintf(out, "  # Dispatch a bead to a polecat\n  kubectl gt sling <bead-id> %s --git-secret %s\n\n", name, gitSecret)
+	_, _ = fmt.Fprintf(out, "  # Watch it work\n  kubectl gt polecat list %s\n", name)
+}
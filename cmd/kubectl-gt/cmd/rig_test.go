@@ -2,6 +2,8 @@ package cmd
 
 import (
 	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestNewRigCmd(t *testing.T) {
@@ -12,7 +14,7 @@ func TestNewRigCmd(t *testing.T) {
 	}
 
 	// Check subcommands
-	expectedSubs := []string{"list", "status", "create"}
+	expectedSubs := []string{"list", "status", "create", "init", "freeze", "unfreeze"}
 	for _, sub := range expectedSubs {
 		found := false
 		for _, c := range cmd.Commands() {
@@ -68,3 +70,61 @@ func TestNewRigCreateCmd(t *testing.T) {
 		}
 	}
 }
+
+func TestNewRigFreezeCmd(t *testing.T) {
+	cmd := newRigFreezeCmd()
+
+	if cmd.Use != "freeze <name>" {
+		t.Errorf("expected Use to be 'freeze <name>', got %s", cmd.Use)
+	}
+
+	for _, flag := range []string{"wait", "timeout"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag --%s to exist", flag)
+		}
+	}
+}
+
+func TestNewRigUnfreezeCmd(t *testing.T) {
+	cmd := newRigUnfreezeCmd()
+
+	if cmd.Use != "unfreeze <name>" {
+		t.Errorf("expected Use to be 'unfreeze <name>', got %s", cmd.Use)
+	}
+}
+
+func TestInFlightWork(t *testing.T) {
+	polecat := func(name, rig, phase string) unstructured.Unstructured {
+		u := unstructured.Unstructured{Object: map[string]any{
+			"spec":   map[string]any{"rig": rig, "beadID": name + "-bead"},
+			"status": map[string]any{"phase": phase},
+		}}
+		u.SetName(name)
+		return u
+	}
+
+	polecats := []unstructured.Unstructured{
+		polecat("busy", "my-rig", "Working"),
+		polecat("done", "my-rig", "Done"),
+		polecat("elsewhere", "other-rig", "Working"),
+	}
+
+	refinery := unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"rigRef": "my-rig"},
+		"status": map[string]any{"activeMerges": []any{
+			map[string]any{"lane": int64(0), "polecat": "merging", "branch": "feature/x"},
+		}},
+	}}
+	refinery.SetName("my-rig-refinery")
+
+	items := inFlightWork("my-rig", polecats, []unstructured.Unstructured{refinery})
+	if len(items) != 2 {
+		t.Fatalf("expected 2 in-flight items, got %d: %+v", len(items), items)
+	}
+	if items[0].Kind != "polecat" || items[0].Name != "busy" {
+		t.Errorf("expected working polecat 'busy', got %+v", items[0])
+	}
+	if items[1].Kind != "merge" || items[1].Name != "merging" {
+		t.Errorf("expected active merge 'merging', got %+v", items[1])
+	}
+}
@@ -25,6 +25,9 @@ const Banner = `
   ════════════════════════
 `
 
+// fieldManager is the field manager recorded for the plugin's writes.
+const fieldManager = "kubectl-gt"
+
 // KubeFlags holds the kubernetes configuration flags
 var KubeFlags *genericclioptions.ConfigFlags
 
@@ -41,7 +44,10 @@ var rootCmd = &cobra.Command{
     polecat   Manage worker pods
     sling     Dispatch work to a polecat
     convoy    Track batch operations
+    watch     Stream a rig's activity
     auth      Manage Claude credentials
+    config    Manage context profiles
+    doctor    Diagnose the installation
 
   ` + "\033[1mQUICK START\033[0m" + `
     # Create a rig for your project
@@ -59,8 +65,9 @@ var rootCmd = &cobra.Command{
     --theme wasteland  Rust, Chrome, Nitro...
 
   Ride eternal, shiny and chrome.`,
-	SilenceUsage:  true,
-	SilenceErrors: true,
+	SilenceUsage:      true,
+	SilenceErrors:     true,
+	PersistentPreRunE: applyProfile,
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
@@ -80,6 +87,13 @@ func init() {
 	rootCmd.AddCommand(newSlingCmd())
 	rootCmd.AddCommand(newConvoyCmd())
 	rootCmd.AddCommand(newAuthCmd())
+	rootCmd.AddCommand(newEstopCmd())
+	rootCmd.AddCommand(newTopCmd())
+	rootCmd.AddCommand(newFsckCmd())
+	rootCmd.AddCommand(newDoctorCmd())
+	rootCmd.AddCommand(newWatchCmd())
+	rootCmd.AddCommand(newConfigCmd())
+	rootCmd.AddCommand(newMigrateCmd())
 }
 
 // newVersionCmd creates the version command
@@ -101,11 +115,15 @@ func GetKubeClient() error {
 	return nil
 }
 
-// GetNamespace returns the namespace from flags or default
+// GetNamespace returns the namespace from flags, else the current profile's,
+// else the default
 func GetNamespace() string {
 	if KubeFlags.Namespace != nil && *KubeFlags.Namespace != "" {
 		return *KubeFlags.Namespace
 	}
+	if activeProfile.Namespace != "" {
+		return activeProfile.Namespace
+	}
 	return "gastown"
 }
 
@@ -4,6 +4,8 @@ import (
 	"context"
 	"crypto/rand"
 	"fmt"
+	"io"
+	"os"
 	"time"
 
 	"github.com/spf13/cobra"
@@ -22,20 +24,56 @@ func newSlingCmd() *cobra.Command {
 	var polecatName string
 	var nameTheme string
 	var gitSecret string
+	var outputFormat string
+	var fromFile string
+	var dryRun string
 
 	cmd := &cobra.Command{
-		Use:   "sling <bead-id> <rig>",
+		Use:   "sling <bead-id> [rig]",
 		Short: "Dispatch work to a polecat",
 		Long: `Dispatch a bead to be worked on by a polecat in the specified rig.
 
 This creates a Polecat CR with the given bead ID and desiredState=Working.
 The operator will reconcile the Polecat and create a Pod to execute the work.
 
-The git repository URL is automatically fetched from the Rig's gitURL field.`,
-		Args: cobra.ExactArgs(2),
+The git repository URL is automatically fetched from the Rig's gitURL field.
+The rig defaults to the current context profile's (see kubectl gt config).
+
+With -o json or -o yaml, the created polecat's identity (name, namespace, UID,
+and with --wait its pod) is printed as a single document for scripts, and
+progress messages go to stderr.
+
+With --from-file, a batch of beads is read from a YAML file (or stdin with
+"-") and dispatched as a Convoy plus one Polecat per bead; only the rig is
+given as an argument, if any:
+
+  description: Auth refactor
+  beads:
+    - id: dm-0001
+      task: Fix the login redirect
+    - id: dm-0002
+      dependsOn: [dm-0001]
+
+A bead with dependsOn waits until the polecats of the beads it depends on are
+done or merged.
+
+With --dry-run, the generated resources are printed as YAML and nothing is
+created. --dry-run=server also submits the polecat to the API server as a
+dry run, applying its defaults and validation, and prints the Pod the
+operator would create for it, for reviewing its security context, volumes
+and environment before enabling a rig.`,
+		Args: func(cmd *cobra.Command, args []string) error {
+			if fromFile != "" {
+				return cobra.MaximumNArgs(1)(cmd, args)
+			}
+			return cobra.RangeArgs(1, 2)(cmd, args)
+		},
 		Example: `  # Sling a bead to a rig
   kubectl gt sling dm-0001 my-rig
 
+  # Sling to the current context's default rig
+  kubectl gt sling dm-0001
+
   # Sling and wait for scheduling
   kubectl gt sling dm-0001 my-rig --wait
 
@@ -49,9 +87,38 @@ The git repository URL is automatically fetched from the Rig's gitURL field.`,
   kubectl gt sling dm-0001 my-rig --wait-ready --timeout=5m
 
   # Sling with custom git secret
-  kubectl gt sling dm-0001 my-rig --git-secret my-git-creds`,
+  kubectl gt sling dm-0001 my-rig --git-secret my-git-creds
+
+  # Sling and capture the polecat name in a script
+  kubectl gt sling dm-0001 my-rig --wait -o json | jq -r .polecat
+
+  # Sling a batch of beads as a convoy
+  kubectl gt sling --from-file beads.yaml my-rig
+
+  # Preview the convoy and polecats of a batch read from stdin
+  cat beads.yaml | kubectl gt sling --from-file - my-rig --dry-run
+
+  # Preview the Pod the operator would create
+  kubectl gt sling dm-0001 my-rig --dry-run=server`,
 		RunE: func(cmd *cobra.Command, args []string) error {
-			return runSling(args[0], args[1], wait, waitReady, timeout, polecatName, nameTheme, gitSecret)
+			if err := validateDryRun(dryRun); err != nil {
+				return err
+			}
+			if fromFile != "" {
+				if dryRun == dryRunServer {
+					return fmt.Errorf("--dry-run=server previews a single bead; use --dry-run with --from-file")
+				}
+				rig, err := rigArg(args, 0)
+				if err != nil {
+					return err
+				}
+				return runSlingBatch(cmd.InOrStdin(), fromFile, rig, nameTheme, gitSecret, outputFormat, dryRun == dryRunClient)
+			}
+			rig, err := rigArg(args, 1)
+			if err != nil {
+				return err
+			}
+			return runSling(args[0], rig, wait, waitReady, timeout, polecatName, nameTheme, gitSecret, outputFormat, dryRun)
 		},
 	}
 
@@ -61,12 +128,62 @@ The git repository URL is automatically fetched from the Rig's gitURL field.`,
 	cmd.Flags().StringVar(&polecatName, "name", "", "Explicit polecat name (e.g., furiosa)")
 	cmd.Flags().StringVar(&nameTheme, "theme", "", "Naming theme (mad-max, minerals, wasteland)")
 	cmd.Flags().StringVar(&gitSecret, "git-secret", "git-creds", "Name of Secret containing git credentials")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+	cmd.Flags().StringVarP(&fromFile, "from-file", "f", "", "YAML file of beads to dispatch as a convoy (- for stdin)")
+	cmd.Flags().StringVar(&dryRun, "dry-run", dryRunNone,
+		`Print the resources that would be created without creating them: "client", or "server" to also render the Pod`)
+	cmd.Flags().Lookup("dry-run").NoOptDefVal = dryRunClient
+	cmd.MarkFlagsMutuallyExclusive("from-file", "name")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "wait")
+	cmd.MarkFlagsMutuallyExclusive("from-file", "wait-ready")
 
 	return cmd
 }
 
+// SlingResult is the structured output of kubectl gt sling.
+type SlingResult struct {
+	// Polecat is the name of the created Polecat
+	Polecat   string `json:"polecat"`
+	Namespace string `json:"namespace"`
+	UID       string `json:"uid"`
+	Rig       string `json:"rig"`
+	Bead      string `json:"bead"`
+
+	// Pod is the pod running the polecat's agent session; set with --wait
+	Pod string `json:"pod,omitempty"`
+
+	// Phase is the polecat phase when the command returned
+	Phase string `json:"phase,omitempty"`
+}
+
+// newSlingResult builds the sling result from the Polecat returned by the API server.
+func newSlingResult(created *unstructured.Unstructured) SlingResult {
+	rig, _, _ := unstructured.NestedString(created.Object, "spec", "rig")
+	bead, _, _ := unstructured.NestedString(created.Object, "spec", "beadID")
+	pod, _, _ := unstructured.NestedString(created.Object, "status", "podName")
+	phase, _, _ := unstructured.NestedString(created.Object, "status", "phase")
+	return SlingResult{
+		Polecat:   created.GetName(),
+		Namespace: created.GetNamespace(),
+		UID:       string(created.GetUID()),
+		Rig:       rig,
+		Bead:      bead,
+		Pod:       pod,
+		Phase:     phase,
+	}
+}
+
 func runSling(beadID, rigName string, wait, waitReady bool, timeout time.Duration,
-	explicitName, theme, gitSecret string) error {
+	explicitName, theme, gitSecret, outputFormat, dryRun string) error {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+	// Structured output owns stdout; progress goes to stderr
+	var progress io.Writer = os.Stdout
+	if outputFormat != OutputFormatTable {
+		progress = os.Stderr
+	}
+
 	config, err := KubeFlags.ToRESTConfig()
 	if err != nil {
 		return fmt.Errorf("failed to get kubeconfig: %w", err)
@@ -77,15 +194,9 @@ func runSling(beadID, rigName string, wait, waitReady bool, timeout time.Duratio
 		return fmt.Errorf("failed to create client: %w", err)
 	}
 
-	// Fetch rig to get gitURL
-	rig, err := client.Resource(rigGVR).Get(context.Background(), rigName, metav1.GetOptions{})
+	gitURL, namespace, err := rigSlingTarget(context.Background(), client, rigName)
 	if err != nil {
-		return fmt.Errorf("rig %s not found: %w", rigName, err)
-	}
-
-	gitURL, _, err := unstructured.NestedString(rig.Object, "spec", "gitURL")
-	if err != nil || gitURL == "" {
-		return fmt.Errorf("rig %s has no gitURL configured", rigName)
+		return err
 	}
 
 	// Generate polecat name based on flags
@@ -97,68 +208,124 @@ func runSling(beadID, rigName string, wait, waitReady bool, timeout time.Duratio
 	} else {
 		polecatName = generatePolecatName(rigName)
 	}
-	namespace := GetNamespace()
-
-	// Create Polecat CR
-	polecat := &unstructured.Unstructured{
-		Object: map[string]any{
-			"apiVersion": "gastown.gastown.io/v1alpha1",
-			"kind":       "Polecat",
-			"metadata": map[string]any{
-				"name":      polecatName,
-				"namespace": namespace,
-			},
-			"spec": map[string]any{
-				"rig":           rigName,
-				"beadID":        beadID,
-				"desiredState":  "Working",
-				"executionMode": "kubernetes",
-				"kubernetes": map[string]any{
-					"gitRepository": gitURL,
-					"gitSecretRef": map[string]any{
-						"name": gitSecret,
-					},
-					"claudeCredsSecretRef": map[string]any{
-						"name": "claude-creds",
-					},
-				},
-			},
-		},
+	polecat := newPolecatObject(polecatName, namespace, rigName, beadID, "", gitURL, gitSecret)
+	ctx := context.Background()
+	switch dryRun {
+	case dryRunClient:
+		return printManifests(os.Stdout, polecat)
+	case dryRunServer:
+		submitted, podYAML, err := previewPolecatPod(ctx, client, polecat)
+		if err != nil {
+			return err
+		}
+		if err := printManifests(os.Stdout, submitted); err != nil {
+			return err
+		}
+		_, err = fmt.Fprintf(os.Stdout, "---\n%s", podYAML)
+		return err
 	}
 
-	ctx := context.Background()
-	created, err := client.Resource(polecatGVR).Namespace(namespace).Create(ctx, polecat, metav1.CreateOptions{})
+	created, err := client.Resource(polecatGVR).Namespace(namespace).Create(ctx, polecat, metav1.CreateOptions{FieldManager: fieldManager})
 	if err != nil {
 		return fmt.Errorf("failed to create polecat: %w", err)
 	}
 
+	// The created object is the source of truth for the polecat's identity
+	result := newSlingResult(created)
+
 	// Themed success message
-	fmt.Println()
-	fmt.Printf("  \033[1m⚡ WITNESSED!\033[0m\n")
-	fmt.Printf("  Polecat \033[36m%s\033[0m dispatched to rig \033[33m%s\033[0m\n", created.GetName(), rigName)
-	fmt.Printf("  Bead: %s\n", beadID)
-	fmt.Println()
+	_, _ = fmt.Fprintln(progress)
+	_, _ = fmt.Fprintf(progress, "  \033[1m⚡ WITNESSED!\033[0m\n")
+	_, _ = fmt.Fprintf(progress, "  Polecat \033[36m%s\033[0m dispatched to rig \033[33m%s\033[0m\n", result.Polecat, rigName)
+	_, _ = fmt.Fprintf(progress, "  Bead: %s\n", beadID)
+	_, _ = fmt.Fprintln(progress)
 
 	if wait || waitReady {
-		fmt.Printf("  Awaiting the Fury Road (timeout: %s)...\n", timeout)
-		podName, err := waitForPolecatScheduled(client, namespace, polecatName, timeout)
+		_, _ = fmt.Fprintf(progress, "  Awaiting the Fury Road (timeout: %s)...\n", timeout)
+		podName, err := waitForPolecatScheduled(client, namespace, result.Polecat, timeout, progress)
 		if err != nil {
 			return err
 		}
+		result.Pod = podName
+		result.Phase = "Working"
 
 		if waitReady && podName != "" {
-			fmt.Printf("  Waiting for pod %s to be battle-ready...\n", podName)
-			err = waitForPodReady(config, namespace, podName, timeout)
+			_, _ = fmt.Fprintf(progress, "  Waiting for pod %s to be battle-ready...\n", podName)
+			err = waitForPodReady(config, namespace, podName, timeout, progress)
 			if err != nil {
 				return err
 			}
-			fmt.Printf("  \033[32m✓ RIDE ETERNAL!\033[0m Pod %s is ready\n", podName)
+			_, _ = fmt.Fprintf(progress, "  \033[32m✓ RIDE ETERNAL!\033[0m Pod %s is ready\n", podName)
 		}
 	}
 
+	if outputFormat != OutputFormatTable {
+		return printStructured(os.Stdout, outputFormat, result)
+	}
 	return nil
 }
 
+// newPolecatObject builds a Polecat working on beadID in the given rig.
+func newPolecatObject(name, namespace, rigName, beadID, task, gitURL, gitSecret string) *unstructured.Unstructured {
+	spec := map[string]any{
+		"rig":           rigName,
+		"beadID":        beadID,
+		"desiredState":  "Working",
+		"executionMode": "kubernetes",
+		"kubernetes": map[string]any{
+			"gitRepository": gitURL,
+			"gitSecretRef": map[string]any{
+				"name": gitSecret,
+			},
+			"claudeCredsSecretRef": map[string]any{
+				"name": "claude-creds",
+			},
+		},
+	}
+	if task != "" {
+		spec["taskDescription"] = task
+	}
+
+	return &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "gastown.gastown.io/v1alpha1",
+			"kind":       "Polecat",
+			"metadata": map[string]any{
+				"name":      name,
+				"namespace": namespace,
+			},
+			"spec": spec,
+		},
+	}
+}
+
+// rigSlingTarget returns the git URL of the rig polecats are slung to and
+// the namespace they are created in: the --namespace flag if given, else the
+// rig's childNamespace, else the default namespace.
+func rigSlingTarget(ctx context.Context, client dynamic.Interface, rigName string) (string, string, error) {
+	rig, err := client.Resource(rigGVR).Get(ctx, rigName, metav1.GetOptions{})
+	if err != nil {
+		return "", "", fmt.Errorf("rig %s not found: %w", rigName, err)
+	}
+
+	gitURL, _, err := unstructured.NestedString(rig.Object, "spec", "gitURL")
+	if err != nil || gitURL == "" {
+		return "", "", fmt.Errorf("rig %s has no gitURL configured", rigName)
+	}
+	return gitURL, rigNamespace(rig), nil
+}
+
+// rigNamespace returns the namespace for the rig's polecats, honoring an
+// explicit --namespace over the rig's spec.childNamespace.
+func rigNamespace(rig *unstructured.Unstructured) string {
+	if KubeFlags.Namespace == nil || *KubeFlags.Namespace == "" {
+		if ns, _, _ := unstructured.NestedString(rig.Object, "spec", "childNamespace"); ns != "" {
+			return ns
+		}
+	}
+	return GetNamespace()
+}
+
 func generatePolecatName(rig string) string {
 	// Simple name generation: rig-<random>
 	b := make([]byte, 2)
@@ -200,7 +367,9 @@ func generateThemedName(rig, theme string) string {
 	return names[idx]
 }
 
-func waitForPolecatScheduled(client dynamic.Interface, namespace, name string, timeout time.Duration) (string, error) {
+func waitForPolecatScheduled(
+	client dynamic.Interface, namespace, name string, timeout time.Duration, progress io.Writer,
+) (string, error) {
 	ctx, cancel := context.WithTimeout(context.Background(), timeout)
 	defer cancel()
 
@@ -221,7 +390,7 @@ func waitForPolecatScheduled(client dynamic.Interface, namespace, name string, t
 			switch phase {
 			case "Working":
 				podName, _, _ := unstructured.NestedString(polecat.Object, "status", "podName")
-				fmt.Printf("  \033[32m✓ SHINY AND CHROME!\033[0m Polecat %s is working (pod: %s)\n", name, podName)
+				_, _ = fmt.Fprintf(progress, "  \033[32m✓ SHINY AND CHROME!\033[0m Polecat %s is working (pod: %s)\n", name, podName)
 				return podName, nil
 			case "Stuck", "Failed":
 				conditions, _, _ := unstructured.NestedSlice(polecat.Object, "status", "conditions")
@@ -236,13 +405,13 @@ func waitForPolecatScheduled(client dynamic.Interface, namespace, name string, t
 				}
 				return "", fmt.Errorf("polecat %s is %s: %s", name, phase, msg)
 			default:
-				fmt.Printf("  Phase: %s...\n", phase)
+				_, _ = fmt.Fprintf(progress, "  Phase: %s...\n", phase)
 			}
 		}
 	}
 }
 
-func waitForPodReady(config *rest.Config, namespace, podName string, timeout time.Duration) error {
+func waitForPodReady(config *rest.Config, namespace, podName string, timeout time.Duration, progress io.Writer) error {
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
 		return fmt.Errorf("failed to create kubernetes client: %w", err)
@@ -266,7 +435,7 @@ func waitForPodReady(config *rest.Config, namespace, podName string, timeout tim
 
 			// Check if pod is running
 			if pod.Status.Phase != corev1.PodRunning {
-				fmt.Printf("  Pod phase: %s...\n", pod.Status.Phase)
+				_, _ = fmt.Fprintf(progress, "  Pod phase: %s...\n", pod.Status.Phase)
 				continue
 			}
 
@@ -282,7 +451,7 @@ func waitForPodReady(config *rest.Config, namespace, podName string, timeout tim
 			if allReady {
 				return nil
 			}
-			fmt.Printf("  Pod running, waiting for Ready condition...\n")
+			_, _ = fmt.Fprintf(progress, "  Pod running, waiting for Ready condition...\n")
 		}
 	}
 }
@@ -0,0 +1,242 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/client-go/dynamic"
+	"sigs.k8s.io/yaml"
+)
+
+// convoyLabel marks a Polecat as part of a convoy
+const convoyLabel = "gastown.io/convoy"
+
+// maxThemedNameAttempts bounds the retries for a themed name not yet used in
+// the batch before falling back to a random name.
+const maxThemedNameAttempts = 10
+
+// slingBatch is the --from-file input of kubectl gt sling. A bare list of
+// entries is accepted as well.
+type slingBatch struct {
+	// Description of the convoy; defaults to a summary of the batch
+	Description string `json:"description,omitempty"`
+
+	Beads []slingBatchEntry `json:"beads"`
+}
+
+// slingBatchEntry is one bead to dispatch.
+type slingBatchEntry struct {
+	ID string `json:"id"`
+
+	// Task is passed to the polecat as its task description
+	Task string `json:"task,omitempty"`
+
+	// DependsOn lists beads of the batch that must be done or merged before
+	// this bead's polecat starts
+	DependsOn []string `json:"dependsOn,omitempty"`
+}
+
+// SlingBatchResult is the structured output of kubectl gt sling --from-file.
+type SlingBatchResult struct {
+	// Convoy is the name of the created Convoy
+	Convoy    string        `json:"convoy"`
+	Namespace string        `json:"namespace"`
+	Polecats  []SlingResult `json:"polecats"`
+}
+
+// readSlingBatch reads the batch file, or stdin if path is "-".
+func readSlingBatch(stdin io.Reader, path string) (*slingBatch, error) {
+	var data []byte
+	var err error
+	if path == "-" {
+		data, err = io.ReadAll(stdin)
+	} else {
+		data, err = os.ReadFile(path)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return parseSlingBatch(data)
+}
+
+// parseSlingBatch parses and validates a batch.
+func parseSlingBatch(data []byte) (*slingBatch, error) {
+	batch := &slingBatch{}
+	if err := yaml.UnmarshalStrict(data, batch); err != nil {
+		if listErr := yaml.UnmarshalStrict(data, &batch.Beads); listErr != nil {
+			return nil, fmt.Errorf("invalid batch file: %w", err)
+		}
+	}
+
+	if len(batch.Beads) == 0 {
+		return nil, fmt.Errorf("batch file lists no beads")
+	}
+	seen := map[string]bool{}
+	for i, entry := range batch.Beads {
+		if entry.ID == "" {
+			return nil, fmt.Errorf("bead %d has no id", i+1)
+		}
+		if seen[entry.ID] {
+			return nil, fmt.Errorf("bead %s is listed more than once", entry.ID)
+		}
+		seen[entry.ID] = true
+	}
+	for _, entry := range batch.Beads {
+		for _, dep := range entry.DependsOn {
+			if dep == entry.ID {
+				return nil, fmt.Errorf("bead %s depends on itself", entry.ID)
+			}
+			if !seen[dep] {
+				return nil, fmt.Errorf("bead %s depends on %s, which is not in the batch", entry.ID, dep)
+			}
+		}
+	}
+	return batch, nil
+}
+
+// buildSlingBatch builds the Convoy tracking the batch and its Polecats.
+func buildSlingBatch(batch *slingBatch, namespace, rigName, theme, gitURL, gitSecret string) (
+	*unstructured.Unstructured, []*unstructured.Unstructured) {
+	convoyName := fmt.Sprintf("cv-%s", generatePolecatName(""))
+	description := batch.Description
+	if description == "" {
+		description = fmt.Sprintf("Sling %d beads to %s", len(batch.Beads), rigName)
+	}
+
+	beads := make([]any, len(batch.Beads))
+	var dependencies []any
+	for i, entry := range batch.Beads {
+		beads[i] = entry.ID
+		if len(entry.DependsOn) > 0 {
+			dependsOn := make([]any, len(entry.DependsOn))
+			for j, dep := range entry.DependsOn {
+				dependsOn[j] = dep
+			}
+			dependencies = append(dependencies, map[string]any{"bead": entry.ID, "dependsOn": dependsOn})
+		}
+	}
+	convoy := &unstructured.Unstructured{
+		Object: map[string]any{
+			"apiVersion": "gastown.gastown.io/v1alpha1",
+			"kind":       "Convoy",
+			"metadata": map[string]any{
+				"name":      convoyName,
+				"namespace": namespace,
+			},
+			"spec": map[string]any{
+				"description":  description,
+				"trackedBeads": beads,
+				"rigRef":       rigName,
+			},
+		},
+	}
+	if len(dependencies) > 0 {
+		_ = unstructured.SetNestedSlice(convoy.Object, dependencies, "spec", "dependencies")
+	}
+
+	used := map[string]bool{}
+	polecats := make([]*unstructured.Unstructured, 0, len(batch.Beads))
+	for _, entry := range batch.Beads {
+		name := uniquePolecatName(rigName, theme, used)
+		polecat := newPolecatObject(name, namespace, rigName, entry.ID, entry.Task, gitURL, gitSecret)
+		polecat.SetLabels(map[string]string{convoyLabel: convoyName})
+		polecats = append(polecats, polecat)
+	}
+	return convoy, polecats
+}
+
+// uniquePolecatName generates a polecat name not yet in used and records it.
+// Themed pools are small, so a batch may exhaust them and fall back to
+// random names.
+func uniquePolecatName(rigName, theme string, used map[string]bool) string {
+	name := generatePolecatName(rigName)
+	if theme != "" {
+		for range maxThemedNameAttempts {
+			if themed := generateThemedName(rigName, theme); !used[themed] {
+				name = themed
+				break
+			}
+		}
+	}
+	for used[name] {
+		name = generatePolecatName(rigName)
+	}
+	used[name] = true
+	return name
+}
+
+func runSlingBatch(stdin io.Reader, path, rigName, theme, gitSecret, outputFormat string, dryRun bool) error {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+	batch, err := readSlingBatch(stdin, path)
+	if err != nil {
+		return err
+	}
+
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	ctx := context.Background()
+	gitURL, namespace, err := rigSlingTarget(ctx, client, rigName)
+	if err != nil {
+		return err
+	}
+
+	convoy, polecats := buildSlingBatch(batch, namespace, rigName, theme, gitURL, gitSecret)
+	if dryRun {
+		return printManifests(os.Stdout, append([]*unstructured.Unstructured{convoy}, polecats...)...)
+	}
+
+	result, err := createSlingBatch(ctx, client, convoy, polecats)
+	if err != nil {
+		return err
+	}
+
+	if outputFormat != OutputFormatTable {
+		return printStructured(os.Stdout, outputFormat, result)
+	}
+
+	fmt.Println()
+	fmt.Printf("  \033[1m⚡ WITNESSED!\033[0m\n")
+	fmt.Printf("  Convoy \033[36m%s\033[0m dispatched %d polecats to rig \033[33m%s\033[0m\n",
+		result.Convoy, len(result.Polecats), rigName)
+	for _, polecat := range result.Polecats {
+		fmt.Printf("  %-24s %s\n", polecat.Polecat, polecat.Bead)
+	}
+	fmt.Println()
+	return nil
+}
+
+// createSlingBatch creates the Convoy, then its Polecats. A failure stops the
+// batch and reports what was already created.
+func createSlingBatch(ctx context.Context, client dynamic.Interface,
+	convoy *unstructured.Unstructured, polecats []*unstructured.Unstructured) (*SlingBatchResult, error) {
+	namespace := convoy.GetNamespace()
+	created, err := client.Resource(convoyGVR).Namespace(namespace).Create(ctx, convoy, metav1.CreateOptions{FieldManager: fieldManager})
+	if err != nil {
+		return nil, fmt.Errorf("failed to create convoy: %w", err)
+	}
+
+	result := &SlingBatchResult{Convoy: created.GetName(), Namespace: namespace}
+	for _, polecat := range polecats {
+		createdPolecat, err := client.Resource(polecatGVR).Namespace(namespace).Create(ctx, polecat, metav1.CreateOptions{FieldManager: fieldManager})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create polecat %s (convoy %s and %d of %d polecats were created): %w",
+				polecat.GetName(), result.Convoy, len(result.Polecats), len(polecats), err)
+		}
+		result.Polecats = append(result.Polecats, newSlingResult(createdPolecat))
+	}
+	return result, nil
+}
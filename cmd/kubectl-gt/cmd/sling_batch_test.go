@@ -0,0 +1,152 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestParseSlingBatch(t *testing.T) {
+	batch, err := parseSlingBatch([]byte(`
+description: Auth refactor
+beads:
+  - id: dm-0001
+    task: Fix the login redirect
+  - id: dm-0002
+`))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if batch.Description != "Auth refactor" || len(batch.Beads) != 2 || batch.Beads[0].Task != "Fix the login redirect" {
+		t.Errorf("unexpected batch: %+v", batch)
+	}
+
+	batch, err = parseSlingBatch([]byte("- id: dm-0001\n- id: dm-0002\n"))
+	if err != nil {
+		t.Fatalf("unexpected error for a bare list: %v", err)
+	}
+	if len(batch.Beads) != 2 || batch.Description != "" {
+		t.Errorf("unexpected batch: %+v", batch)
+	}
+
+	for name, input := range map[string]string{
+		"empty":     "beads: []\n",
+		"no id":     "beads:\n  - task: orphan\n",
+		"duplicate": "- id: dm-0001\n- id: dm-0001\n",
+		"unknown":   "beads:\n  - bead: dm-0001\n",
+		"self":      "- id: dm-0001\n  dependsOn: [dm-0001]\n",
+		"outside":   "- id: dm-0001\n  dependsOn: [dm-0009]\n",
+	} {
+		if _, err := parseSlingBatch([]byte(input)); err == nil {
+			t.Errorf("%s: expected an error", name)
+		}
+	}
+}
+
+func TestReadSlingBatchFromStdin(t *testing.T) {
+	batch, err := readSlingBatch(strings.NewReader("- id: dm-0001\n"), "-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(batch.Beads) != 1 || batch.Beads[0].ID != "dm-0001" {
+		t.Errorf("unexpected batch: %+v", batch)
+	}
+}
+
+func TestBuildSlingBatch(t *testing.T) {
+	batch := &slingBatch{Beads: []slingBatchEntry{
+		{ID: "dm-0001", Task: "Fix the login redirect"},
+		{ID: "dm-0002", DependsOn: []string{"dm-0001"}},
+	}}
+	convoy, polecats := buildSlingBatch(batch, "gastown", "my-rig", "", "git@github.com:org/repo.git", "git-creds")
+
+	beads, _, _ := unstructured.NestedStringSlice(convoy.Object, "spec", "trackedBeads")
+	description, _, _ := unstructured.NestedString(convoy.Object, "spec", "description")
+	if len(beads) != 2 || description != "Sling 2 beads to my-rig" {
+		t.Errorf("unexpected convoy spec: beads=%v description=%q", beads, description)
+	}
+
+	dependencies, _, _ := unstructured.NestedSlice(convoy.Object, "spec", "dependencies")
+	if len(dependencies) != 1 || dependencies[0].(map[string]any)["bead"] != "dm-0002" {
+		t.Errorf("expected dm-0002 to depend on dm-0001, got %v", dependencies)
+	}
+
+	if len(polecats) != 2 {
+		t.Fatalf("expected 2 polecats, got %d", len(polecats))
+	}
+	if polecats[0].GetName() == polecats[1].GetName() {
+		t.Errorf("expected unique polecat names, got %s twice", polecats[0].GetName())
+	}
+	for _, polecat := range polecats {
+		if polecat.GetLabels()[convoyLabel] != convoy.GetName() {
+			t.Errorf("expected polecat %s to be labeled with convoy %s", polecat.GetName(), convoy.GetName())
+		}
+	}
+	task, _, _ := unstructured.NestedString(polecats[0].Object, "spec", "taskDescription")
+	if task != "Fix the login redirect" {
+		t.Errorf("expected task description to be set, got %q", task)
+	}
+	if _, found, _ := unstructured.NestedString(polecats[1].Object, "spec", "taskDescription"); found {
+		t.Error("expected no task description for a bead without a task")
+	}
+
+	var buf bytes.Buffer
+	if err := printManifests(&buf, append([]*unstructured.Unstructured{convoy}, polecats...)...); err != nil {
+		t.Fatal(err)
+	}
+	if n := strings.Count(buf.String(), "---\n"); n != 3 {
+		t.Errorf("expected 3 documents, got %d:\n%s", n, buf.String())
+	}
+}
+
+func TestUniquePolecatName(t *testing.T) {
+	used := map[string]bool{}
+	pool := len(nameThemes["minerals"])
+	for range pool + 5 {
+		uniquePolecatName("my-rig", "minerals", used)
+	}
+	if len(used) != pool+5 {
+		t.Errorf("expected %d unique names, got %d", pool+5, len(used))
+	}
+}
+
+func TestCreateSlingBatch(t *testing.T) {
+	ctx := context.Background()
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{convoyGVR: "ConvoyList", polecatGVR: "PolecatList"})
+
+	batch := &slingBatch{Beads: []slingBatchEntry{{ID: "dm-0001"}, {ID: "dm-0002"}}}
+	convoy, polecats := buildSlingBatch(batch, "gastown", "my-rig", "mad-max", "git@github.com:org/repo.git", "git-creds")
+
+	result, err := createSlingBatch(ctx, client, convoy, polecats)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.Convoy != convoy.GetName() || len(result.Polecats) != 2 || result.Polecats[1].Bead != "dm-0002" {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	list, err := client.Resource(polecatGVR).Namespace("gastown").List(ctx, metav1.ListOptions{
+		LabelSelector: convoyLabel + "=" + convoy.GetName(),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(list.Items) != 2 {
+		t.Errorf("expected 2 polecats in the convoy, got %d", len(list.Items))
+	}
+
+	// Re-creating the same polecats fails after the convoy is created
+	convoy.SetName("cv-retry")
+	if _, err := createSlingBatch(ctx, client, convoy, polecats); err == nil ||
+		!strings.Contains(err.Error(), "0 of 2 polecats were created") {
+		t.Errorf("expected a partial failure error, got %v", err)
+	}
+}
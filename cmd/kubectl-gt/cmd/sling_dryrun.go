@@ -0,0 +1,114 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+var gastownConfigGVR = schema.GroupVersionResource{
+	Group:    "gastown.gastown.io",
+	Version:  "v1alpha1",
+	Resource: "gastownconfigs",
+}
+
+// Values of sling's --dry-run flag, as kubectl's
+const (
+	dryRunNone   = "none"
+	dryRunClient = "client"
+	dryRunServer = "server"
+)
+
+// validateDryRun rejects --dry-run values other than none, client and server.
+func validateDryRun(dryRun string) error {
+	switch dryRun {
+	case dryRunNone, dryRunClient, dryRunServer:
+		return nil
+	default:
+		return fmt.Errorf("unknown --dry-run value %q (want none, client or server)", dryRun)
+	}
+}
+
+// previewPolecatPod submits the polecat to the API server as a dry run, so
+// its webhooks default and validate it without persisting it, and renders
+// the Pod the operator would create for the result: with the cluster's
+// GastownConfig and the rig's agent ServiceAccount and image pull secrets.
+// The bead's metadata, resolved from the rig's BeadStore when the Pod is
+// created, is left out.
+func previewPolecatPod(ctx context.Context, client dynamic.Interface, polecat *unstructured.Unstructured) (*unstructured.Unstructured, []byte, error) {
+	submitted, err := client.Resource(polecatGVR).Namespace(polecat.GetNamespace()).Create(ctx, polecat,
+		metav1.CreateOptions{DryRun: []string{metav1.DryRunAll}, FieldManager: fieldManager})
+	if err != nil {
+		return nil, nil, fmt.Errorf("polecat rejected by the server: %w", err)
+	}
+	submitted.SetManagedFields(nil)
+
+	if err := loadClusterConfig(ctx, client); err != nil {
+		return nil, nil, err
+	}
+
+	var typed gastownv1alpha1.Polecat
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(submitted.Object, &typed); err != nil {
+		return nil, nil, fmt.Errorf("failed to decode polecat: %w", err)
+	}
+	builder := pod.NewBuilder(&typed)
+
+	// As the operator does: the rig's defaults apply to polecats without
+	// their own
+	rigObj, err := client.Resource(rigGVR).Get(ctx, typed.Spec.Rig, metav1.GetOptions{})
+	if err != nil && !apierrors.IsNotFound(err) {
+		return nil, nil, fmt.Errorf("failed to get rig %s: %w", typed.Spec.Rig, err)
+	}
+	if err == nil {
+		var rig gastownv1alpha1.Rig
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(rigObj.Object, &rig); err != nil {
+			return nil, nil, fmt.Errorf("failed to decode rig %s: %w", typed.Spec.Rig, err)
+		}
+		if typed.Spec.Kubernetes != nil && typed.Spec.Kubernetes.ServiceAccountName == "" &&
+			rig.Status.ChildNamespace == typed.Namespace {
+			builder.WithServiceAccount(rig.Status.AgentServiceAccount)
+		}
+		if typed.Spec.Kubernetes != nil && len(typed.Spec.Kubernetes.ImagePullSecrets) == 0 {
+			builder.WithImagePullSecrets(rig.Spec.ImagePullSecrets)
+		}
+		builder.WithPolecatDefaults(rig.Spec.PolecatDefaults)
+		builder.WithCommitSigning(rig.Spec.Signing)
+		builder.WithRigRepositories(rig.Spec.Repositories)
+	}
+
+	podYAML, err := builder.BuildYAML()
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to render pod: %w", err)
+	}
+	return submitted, podYAML, nil
+}
+
+// loadClusterConfig makes the operator-wide configuration of the cluster's
+// GastownConfig, such as images, mirrors and resources, the one in effect,
+// or the defaults without a GastownConfig.
+func loadClusterConfig(ctx context.Context, client dynamic.Interface) error {
+	obj, err := client.Resource(gastownConfigGVR).Get(ctx, gastownv1alpha1.GastownConfigName, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		config.Set(nil)
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to get GastownConfig %s: %w", gastownv1alpha1.GastownConfigName, err)
+	}
+	var cfg gastownv1alpha1.GastownConfig
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &cfg); err != nil {
+		return fmt.Errorf("failed to decode GastownConfig %s: %w", gastownv1alpha1.GastownConfigName, err)
+	}
+	config.Set(config.FromSpec(&cfg.Spec))
+	return nil
+}
@@ -0,0 +1,71 @@
+package cmd
+
+import (
+	"context"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"sigs.k8s.io/yaml"
+
+	"github.com/org/gastown-operator/pkg/config"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+func TestSlingDryRunFlag(t *testing.T) {
+	flag := newSlingCmd().Flags().Lookup("dry-run")
+	if flag.DefValue != dryRunNone || flag.NoOptDefVal != dryRunClient {
+		t.Errorf("expected --dry-run to default to none and mean client, got %q and %q", flag.DefValue, flag.NoOptDefVal)
+	}
+	for _, value := range []string{dryRunNone, dryRunClient, dryRunServer} {
+		if err := validateDryRun(value); err != nil {
+			t.Errorf("expected %q to be valid: %v", value, err)
+		}
+	}
+	if err := validateDryRun("true"); err == nil {
+		t.Error("expected an unknown value to be rejected")
+	}
+}
+
+func TestPreviewPolecatPod(t *testing.T) {
+	t.Cleanup(func() { config.Set(nil) })
+	ctx := context.Background()
+
+	rig := fsckObject("gastown.gastown.io/v1alpha1", "Rig", "app", nil, map[string]any{
+		"spec":   map[string]any{"gitURL": "git@github.com:org/app.git", "imagePullSecrets": []any{map[string]any{"name": "registry"}}},
+		"status": map[string]any{"childNamespace": "gastown", "agentServiceAccount": "app-agent"},
+	}).(*unstructured.Unstructured)
+	rig.SetNamespace("")
+	gastownConfig := fsckObject("gastown.gastown.io/v1alpha1", "GastownConfig", "default", nil, map[string]any{
+		"spec": map[string]any{"images": map[string]any{"claude": "registry.internal/polecat-agent:0.4.0"}},
+	}).(*unstructured.Unstructured)
+	gastownConfig.SetNamespace("")
+	client := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme(), rig, gastownConfig)
+
+	polecat := newPolecatObject("furiosa", "gastown", "app", "gt-1", "", "git@github.com:org/app.git", "git-creds")
+	submitted, podYAML, err := previewPolecatPod(ctx, client, polecat)
+	if err != nil {
+		t.Fatalf("preview failed: %v", err)
+	}
+	if submitted.GetName() != "furiosa" {
+		t.Errorf("expected the submitted polecat, got %s", submitted.GetName())
+	}
+
+	var p corev1.Pod
+	if err := yaml.Unmarshal(podYAML, &p); err != nil {
+		t.Fatalf("expected a Pod manifest: %v", err)
+	}
+	if p.Namespace != "gastown" || p.Labels["gastown.io/polecat"] != "furiosa" {
+		t.Errorf("unexpected pod metadata: %v", p.ObjectMeta)
+	}
+	if p.Spec.ServiceAccountName != "app-agent" || len(p.Spec.ImagePullSecrets) != 1 || p.Spec.ImagePullSecrets[0].Name != "registry" {
+		t.Errorf("expected the rig's defaults, got %q and %v", p.Spec.ServiceAccountName, p.Spec.ImagePullSecrets)
+	}
+	for _, c := range p.Spec.Containers {
+		if c.Name == pod.ClaudeContainerName && c.Image != "registry.internal/polecat-agent:0.4.0" {
+			t.Errorf("expected the GastownConfig's image, got %s", c.Image)
+		}
+	}
+}
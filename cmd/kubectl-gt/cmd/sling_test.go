@@ -1,14 +1,18 @@
 package cmd
 
 import (
+	"bytes"
+	"strings"
 	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 )
 
 func TestNewSlingCmd(t *testing.T) {
 	cmd := newSlingCmd()
 
-	if cmd.Use != "sling <bead-id> <rig>" {
-		t.Errorf("expected Use to be 'sling <bead-id> <rig>', got %s", cmd.Use)
+	if cmd.Use != "sling <bead-id> [rig]" {
+		t.Errorf("expected Use to be 'sling <bead-id> [rig]', got %s", cmd.Use)
 	}
 
 	if cmd.Short != "Dispatch work to a polecat" {
@@ -16,7 +20,7 @@ func TestNewSlingCmd(t *testing.T) {
 	}
 
 	// Check flags exist
-	flags := []string{"wait", "wait-ready", "timeout", "name", "theme", "git-secret"}
+	flags := []string{"wait", "wait-ready", "timeout", "name", "theme", "git-secret", "output", "from-file", "dry-run"}
 	for _, flag := range flags {
 		if cmd.Flags().Lookup(flag) == nil {
 			t.Errorf("expected flag --%s to exist", flag)
@@ -72,3 +76,74 @@ func TestGenerateThemedName(t *testing.T) {
 		})
 	}
 }
+
+func TestSlingResult(t *testing.T) {
+	created := &unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{
+			"name":      "furiosa",
+			"namespace": "gastown-system",
+			"uid":       "0000-2222",
+		},
+		"spec": map[string]any{
+			"rig":    "my-rig",
+			"beadID": "dm-0001",
+		},
+	}}
+
+	result := newSlingResult(created)
+	want := SlingResult{
+		Polecat:   "furiosa",
+		Namespace: "gastown-system",
+		UID:       "0000-2222",
+		Rig:       "my-rig",
+		Bead:      "dm-0001",
+	}
+	if result != want {
+		t.Errorf("newSlingResult() = %+v, want %+v", result, want)
+	}
+
+	var buf bytes.Buffer
+	if err := printStructured(&buf, OutputFormatYAML, result); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "polecat: furiosa") {
+		t.Errorf("unexpected YAML output:\n%s", buf.String())
+	}
+	if strings.Contains(buf.String(), "pod:") {
+		t.Errorf("expected pod to be omitted before scheduling:\n%s", buf.String())
+	}
+}
+
+func TestValidateOutputFormat(t *testing.T) {
+	for _, format := range []string{OutputFormatTable, OutputFormatJSON, OutputFormatYAML} {
+		if err := validateOutputFormat(format); err != nil {
+			t.Errorf("validateOutputFormat(%q) = %v", format, err)
+		}
+	}
+	if err := validateOutputFormat("xml"); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
+
+func TestRigNamespace(t *testing.T) {
+	rig := &unstructured.Unstructured{Object: map[string]any{
+		"spec": map[string]any{"childNamespace": "rig-alpha"},
+	}}
+	plain := &unstructured.Unstructured{Object: map[string]any{"spec": map[string]any{}}}
+
+	saved := *KubeFlags.Namespace
+	defer func() { *KubeFlags.Namespace = saved }()
+
+	*KubeFlags.Namespace = ""
+	if got := rigNamespace(rig); got != "rig-alpha" {
+		t.Errorf("expected the rig's childNamespace, got %q", got)
+	}
+	if got := rigNamespace(plain); got != "gastown" {
+		t.Errorf("expected the default namespace, got %q", got)
+	}
+
+	*KubeFlags.Namespace = "team"
+	if got := rigNamespace(rig); got != "team" {
+		t.Errorf("expected --namespace to win, got %q", got)
+	}
+}
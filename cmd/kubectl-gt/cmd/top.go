@@ -0,0 +1,239 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+	"text/tabwriter"
+
+	"github.com/spf13/cobra"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+)
+
+// podMetricsGVR is the metrics-server PodMetrics resource
+var podMetricsGVR = schema.GroupVersionResource{
+	Group:    "metrics.k8s.io",
+	Version:  "v1beta1",
+	Resource: "pods",
+}
+
+// polecatPodLabel names the polecat on its agent Pod
+const polecatPodLabel = "gastown.io/polecat"
+
+// Sort keys of kubectl gt top
+const (
+	topSortCPU    = "cpu"
+	topSortMemory = "memory"
+	topSortRig    = "rig"
+	topSortBead   = "bead"
+)
+
+func newTopCmd() *cobra.Command {
+	var sortBy string
+	var outputFormat string
+
+	cmd := &cobra.Command{
+		Use:   "top [rig]",
+		Short: "Show CPU and memory usage of running polecats",
+		Long: `Show the CPU and memory usage of the Pods of running polecats, as
+reported by metrics-server, to spot runaway agents.
+
+Usage is summed over the containers of each Pod. Polecats whose Pod has no
+metrics yet (e.g., just started) are listed last without usage.`,
+		Example: `  # Show the busiest polecats first
+  kubectl gt top
+
+  # Show the polecats of one rig by memory
+  kubectl gt top my-rig --sort-by memory
+
+  # Group by bead
+  kubectl gt top --sort-by bead -o json`,
+		Args: cobra.MaximumNArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			rig := ""
+			if len(args) > 0 {
+				rig = args[0]
+			}
+			return runTop(rig, sortBy, outputFormat)
+		},
+	}
+
+	cmd.Flags().StringVar(&sortBy, "sort-by", topSortCPU, "Sort by cpu, memory, rig or bead")
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json, yaml)")
+
+	return cmd
+}
+
+// PolecatUsage is the resource usage of a running polecat's Pod.
+type PolecatUsage struct {
+	Polecat string `json:"polecat"`
+	Rig     string `json:"rig"`
+	Bead    string `json:"bead"`
+	Pod     string `json:"pod"`
+
+	// CPUMillicores and MemoryBytes are summed over the Pod's containers
+	CPUMillicores int64 `json:"cpuMillicores"`
+	MemoryBytes   int64 `json:"memoryBytes"`
+
+	// HasMetrics is false when metrics-server has no sample for the Pod yet
+	HasMetrics bool `json:"hasMetrics"`
+}
+
+func runTop(rig, sortBy, outputFormat string) error {
+	if err := validateOutputFormat(outputFormat); err != nil {
+		return err
+	}
+	if err := validateTopSort(sortBy); err != nil {
+		return err
+	}
+
+	config, err := KubeFlags.ToRESTConfig()
+	if err != nil {
+		return fmt.Errorf("failed to get kubeconfig: %w", err)
+	}
+
+	client, err := dynamic.NewForConfig(config)
+	if err != nil {
+		return fmt.Errorf("failed to create client: %w", err)
+	}
+
+	usages, err := collectPolecatUsage(context.Background(), client, GetNamespace(), rig)
+	if err != nil {
+		return err
+	}
+	sortPolecatUsage(usages, sortBy)
+
+	if outputFormat != OutputFormatTable {
+		return printStructured(os.Stdout, outputFormat, usages)
+	}
+
+	if len(usages) == 0 {
+		fmt.Println("No running polecats found")
+		return nil
+	}
+
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	_, _ = fmt.Fprintln(w, "NAME\tRIG\tBEAD\tPOD\tCPU(cores)\tMEMORY(bytes)")
+	for _, u := range usages {
+		cpu, memory := "<unknown>", "<unknown>"
+		if u.HasMetrics {
+			cpu = fmt.Sprintf("%dm", u.CPUMillicores)
+			memory = fmt.Sprintf("%dMi", u.MemoryBytes/(1024*1024))
+		}
+		_, _ = fmt.Fprintf(w, "%s\t%s\t%s\t%s\t%s\t%s\n", u.Polecat, u.Rig, u.Bead, u.Pod, cpu, memory)
+	}
+	return w.Flush()
+}
+
+// validateTopSort rejects unknown sort keys.
+func validateTopSort(sortBy string) error {
+	switch sortBy {
+	case topSortCPU, topSortMemory, topSortRig, topSortBead:
+		return nil
+	default:
+		return fmt.Errorf("unknown sort key %q (want cpu, memory, rig or bead)", sortBy)
+	}
+}
+
+// collectPolecatUsage lists the polecats with an active Pod and joins them
+// with the metrics of their Pods.
+func collectPolecatUsage(ctx context.Context, client dynamic.Interface, namespace, rig string) ([]PolecatUsage, error) {
+	polecats, err := client.Resource(polecatGVR).Namespace(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to list polecats: %w", err)
+	}
+
+	podMetrics, err := client.Resource(podMetricsGVR).Namespace(namespace).List(ctx, metav1.ListOptions{
+		LabelSelector: polecatPodLabel,
+	})
+	if apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("metrics API not available; is metrics-server installed?")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to list pod metrics: %w", err)
+	}
+
+	return joinPolecatUsage(polecats.Items, podMetrics.Items, rig), nil
+}
+
+// joinPolecatUsage matches polecats with an active Pod to the PodMetrics of
+// that Pod. Polecats of other rigs are skipped when rig is set.
+func joinPolecatUsage(polecats, podMetrics []unstructured.Unstructured, rig string) []PolecatUsage {
+	byPod := make(map[string]unstructured.Unstructured, len(podMetrics))
+	for _, m := range podMetrics {
+		byPod[m.GetName()] = m
+	}
+
+	usages := []PolecatUsage{}
+	for _, polecat := range polecats {
+		polecatRig, _, _ := unstructured.NestedString(polecat.Object, "spec", "rig")
+		podName, _, _ := unstructured.NestedString(polecat.Object, "status", "podName")
+		active, _, _ := unstructured.NestedBool(polecat.Object, "status", "podActive")
+		if !active || podName == "" || (rig != "" && polecatRig != rig) {
+			continue
+		}
+
+		bead, _, _ := unstructured.NestedString(polecat.Object, "spec", "beadID")
+		usage := PolecatUsage{Polecat: polecat.GetName(), Rig: polecatRig, Bead: bead, Pod: podName}
+		if m, ok := byPod[podName]; ok {
+			usage.CPUMillicores, usage.MemoryBytes = podUsage(m)
+			usage.HasMetrics = true
+		}
+		usages = append(usages, usage)
+	}
+	return usages
+}
+
+// podUsage sums the CPU (millicores) and memory (bytes) of a PodMetrics'
+// containers. Unparsable quantities count as zero.
+func podUsage(podMetrics unstructured.Unstructured) (int64, int64) {
+	containers, _, _ := unstructured.NestedSlice(podMetrics.Object, "containers")
+	var cpu, memory int64
+	for _, c := range containers {
+		container, _ := c.(map[string]any)
+		usage, _ := container["usage"].(map[string]any)
+		if q, err := resource.ParseQuantity(fmt.Sprint(usage["cpu"])); err == nil {
+			cpu += q.MilliValue()
+		}
+		if q, err := resource.ParseQuantity(fmt.Sprint(usage["memory"])); err == nil {
+			memory += q.Value()
+		}
+	}
+	return cpu, memory
+}
+
+// sortPolecatUsage orders usages by sortBy. CPU and memory sort the heaviest
+// first; rig and bead sort by name, heaviest CPU first within a group.
+// Polecats without metrics always come last.
+func sortPolecatUsage(usages []PolecatUsage, sortBy string) {
+	sort.SliceStable(usages, func(i, j int) bool {
+		a, b := usages[i], usages[j]
+		if a.HasMetrics != b.HasMetrics {
+			return a.HasMetrics
+		}
+		switch sortBy {
+		case topSortMemory:
+			if a.MemoryBytes != b.MemoryBytes {
+				return a.MemoryBytes > b.MemoryBytes
+			}
+		case topSortRig:
+			if a.Rig != b.Rig {
+				return a.Rig < b.Rig
+			}
+		case topSortBead:
+			if a.Bead != b.Bead {
+				return a.Bead < b.Bead
+			}
+		}
+		if a.CPUMillicores != b.CPUMillicores {
+			return a.CPUMillicores > b.CPUMillicores
+		}
+		return a.Polecat < b.Polecat
+	})
+}
@@ -0,0 +1,90 @@
+package cmd
+
+import (
+	"reflect"
+	"testing"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+func TestNewTopCmd(t *testing.T) {
+	cmd := newTopCmd()
+
+	for _, flag := range []string{"sort-by", "output"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag --%s to exist", flag)
+		}
+	}
+	if err := validateTopSort("uptime"); err == nil {
+		t.Error("expected error for unknown sort key")
+	}
+}
+
+func topPolecat(name, rig, bead string, active bool) unstructured.Unstructured {
+	return unstructured.Unstructured{Object: map[string]any{
+		"metadata": map[string]any{"name": name},
+		"spec":     map[string]any{"rig": rig, "beadID": bead},
+		"status":   map[string]any{"podName": "polecat-" + name, "podActive": active},
+	}}
+}
+
+func topPodMetrics(pod string, usages ...[2]string) unstructured.Unstructured {
+	containers := []any{}
+	for _, u := range usages {
+		containers = append(containers, map[string]any{
+			"name":  "c",
+			"usage": map[string]any{"cpu": u[0], "memory": u[1]},
+		})
+	}
+	return unstructured.Unstructured{Object: map[string]any{
+		"metadata":   map[string]any{"name": pod},
+		"containers": containers,
+	}}
+}
+
+func TestJoinPolecatUsage(t *testing.T) {
+	polecats := []unstructured.Unstructured{
+		topPolecat("furiosa", "alpha", "gt-2", true),
+		topPolecat("nux", "alpha", "gt-1", true),
+		topPolecat("toast", "beta", "gt-3", true),
+		topPolecat("slit", "alpha", "gt-4", false),
+		topPolecat("fresh", "alpha", "gt-5", true),
+	}
+	metrics := []unstructured.Unstructured{
+		topPodMetrics("polecat-furiosa", [2]string{"1500m", "1Gi"}, [2]string{"10m", "16Mi"}),
+		topPodMetrics("polecat-nux", [2]string{"250m", "2Gi"}),
+		topPodMetrics("polecat-toast", [2]string{"2", "128Mi"}),
+	}
+
+	usages := joinPolecatUsage(polecats, metrics, "alpha")
+	if len(usages) != 3 {
+		t.Fatalf("expected 3 running alpha polecats, got %+v", usages)
+	}
+	if usages[0].CPUMillicores != 1510 || usages[0].MemoryBytes != (1024+16)*1024*1024 {
+		t.Errorf("expected container usage to be summed, got %+v", usages[0])
+	}
+	if usages[2].Polecat != "fresh" || usages[2].HasMetrics {
+		t.Errorf("expected fresh to have no metrics, got %+v", usages[2])
+	}
+
+	names := func(usages []PolecatUsage) []string {
+		out := []string{}
+		for _, u := range usages {
+			out = append(out, u.Polecat)
+		}
+		return out
+	}
+
+	all := joinPolecatUsage(polecats, metrics, "")
+	for sortBy, want := range map[string][]string{
+		topSortCPU:    {"toast", "furiosa", "nux", "fresh"},
+		topSortMemory: {"nux", "furiosa", "toast", "fresh"},
+		topSortRig:    {"furiosa", "nux", "toast", "fresh"},
+		topSortBead:   {"nux", "furiosa", "toast", "fresh"},
+	} {
+		sortPolecatUsage(all, sortBy)
+		if got := names(all); !reflect.DeepEqual(got, want) {
+			t.Errorf("sort by %s: got %v, want %v", sortBy, got, want)
+		}
+	}
+}
@@ -0,0 +1,439 @@
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+	"strings"
+	"time"
+
+	"github.com/spf13/cobra"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/dynamic"
+)
+
+var eventGVR = schema.GroupVersionResource{
+	Version:  "v1",
+	Resource: "events",
+}
+
+// watchRetryInterval is how long a closed or failed watch waits before it
+// is restarted
+const watchRetryInterval = 2 * time.Second
+
+// Sources of a RigWatchEvent
+const (
+	WatchSourceStatus = "status"
+	WatchSourceEvent  = "event"
+)
+
+func newWatchCmd() *cobra.Command {
+	var outputFormat string
+	var noColor bool
+
+	cmd := &cobra.Command{
+		Use:   "watch <rig>",
+		Short: "Stream status changes and events of a rig",
+		Long: `Stream what happens in a rig as it happens: the phase changes of its
+Polecats, Refinery and Convoys, and the Kubernetes events recorded on them,
+their Pods and the Rig itself.
+
+The current state of each resource is printed first. Phases are colored
+when writing to a terminal; with -o json every change is written as one
+JSON object per line, for piping into jq or other tooling.`,
+		Example: `  # Watch a rig
+  kubectl gt watch my-rig
+
+  # Only the warnings, as JSON lines
+  kubectl gt watch my-rig -o json | jq -c 'select(.type == "Warning")'`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if outputFormat != OutputFormatTable && outputFormat != OutputFormatJSON {
+				return fmt.Errorf("unknown output format %q (want table or json)", outputFormat)
+			}
+			client, err := newDynamicClient()
+			if err != nil {
+				return err
+			}
+
+			ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+			defer stop()
+			printer := &watchPrinter{
+				out:   cmd.OutOrStdout(),
+				json:  outputFormat == OutputFormatJSON,
+				color: !noColor && isTerminal(os.Stdout),
+			}
+			return runRigWatch(ctx, client, args[0], printer.print)
+		},
+	}
+
+	cmd.Flags().StringVarP(&outputFormat, "output", "o", "table", "Output format (table, json)")
+	cmd.Flags().BoolVar(&noColor, "no-color", false, "Do not color phases")
+
+	return cmd
+}
+
+// RigWatchEvent is a change streamed by kubectl gt watch: a resource's
+// status changed, or an event was recorded.
+type RigWatchEvent struct {
+	Time time.Time `json:"time"`
+
+	// Source is WatchSourceStatus or WatchSourceEvent
+	Source string `json:"source"`
+
+	// Type is ADDED, MODIFIED or DELETED for status changes, and Normal or
+	// Warning for events
+	Type string `json:"type"`
+
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+	Phase     string `json:"phase,omitempty"`
+	Reason    string `json:"reason,omitempty"`
+	Message   string `json:"message,omitempty"`
+}
+
+// rigStream is a resource kind kubectl gt watch lists and watches.
+type rigStream struct {
+	gvr           schema.GroupVersionResource
+	namespace     string
+	fieldSelector string
+
+	// skipExisting drops the listed objects and only watches for new ones
+	skipExisting bool
+
+	// optional streams are dropped when they cannot be listed
+	optional bool
+}
+
+// rigWatcher turns the watch events of a rig's resources into
+// RigWatchEvents.
+type rigWatcher struct {
+	rig string
+	now func() time.Time
+
+	// last is the phase and summary last reported per kind/name; only the
+	// tracked objects are in it
+	last map[string]string
+
+	// pods maps the Pods of the tracked polecats to their polecat
+	pods map[string]string
+}
+
+// runRigWatch streams the changes of the rig to emit until ctx is done.
+func runRigWatch(ctx context.Context, client dynamic.Interface, rigName string, emit func(RigWatchEvent) error) error {
+	rig, err := client.Resource(rigGVR).Get(ctx, rigName, metav1.GetOptions{})
+	if err != nil {
+		return fmt.Errorf("rig %s not found: %w", rigName, err)
+	}
+	namespace := rigNamespace(rig)
+
+	streams := []*rigStream{
+		{gvr: polecatGVR, namespace: namespace},
+		{gvr: refineryGVR, namespace: namespace},
+		{gvr: convoyGVR, namespace: namespace},
+		{gvr: eventGVR, namespace: namespace, skipExisting: true},
+		// Events of the cluster-scoped Rig are recorded in the default namespace
+		{gvr: eventGVR, namespace: metav1.NamespaceDefault, skipExisting: true, optional: true, fieldSelector: fields.Set{
+			"involvedObject.kind": "Rig",
+			"involvedObject.name": rigName,
+		}.String()},
+	}
+
+	events := make(chan watch.Event)
+	errs := make(chan error, len(streams))
+	for _, s := range streams {
+		go func() { errs <- s.run(ctx, client, events) }()
+	}
+
+	w := &rigWatcher{rig: rigName, now: time.Now, last: map[string]string{}, pods: map[string]string{}}
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-errs:
+			if err != nil && ctx.Err() == nil {
+				return err
+			}
+		case e := <-events:
+			for _, change := range w.handle(e) {
+				if err := emit(change); err != nil {
+					return err
+				}
+			}
+		}
+	}
+}
+
+// run lists the stream's objects, then watches them until ctx is done. A
+// closed watch is resumed; a failed one, most likely for an expired resource
+// version, is restarted with a new list. It returns only the error of the
+// first list, and none for optional streams.
+func (s *rigStream) run(ctx context.Context, client dynamic.Interface, events chan<- watch.Event) error {
+	resource := client.Resource(s.gvr).Namespace(s.namespace)
+	opts := metav1.ListOptions{FieldSelector: s.fieldSelector}
+
+	first := true
+	for ctx.Err() == nil {
+		if opts.ResourceVersion == "" {
+			list, err := resource.List(ctx, metav1.ListOptions{FieldSelector: s.fieldSelector})
+			switch {
+			case err != nil && first && s.optional:
+				return nil
+			case err != nil && first:
+				return fmt.Errorf("failed to list %s: %w", s.gvr.Resource, err)
+			case err != nil:
+				sleepContext(ctx, watchRetryInterval)
+				continue
+			}
+			// Relisted objects are reported again, but unchanged ones are
+			// dropped by the watcher
+			if !s.skipExisting {
+				for i := range list.Items {
+					if !s.send(ctx, events, watch.Event{Type: watch.Added, Object: &list.Items[i]}) {
+						return nil
+					}
+				}
+			}
+			opts.ResourceVersion = list.GetResourceVersion()
+			first = false
+		}
+
+		w, err := resource.Watch(ctx, opts)
+		if err != nil {
+			opts.ResourceVersion = ""
+			sleepContext(ctx, watchRetryInterval)
+			continue
+		}
+		for e := range w.ResultChan() {
+			if e.Type == watch.Error {
+				opts.ResourceVersion = ""
+				break
+			}
+			if obj, ok := e.Object.(*unstructured.Unstructured); ok && obj.GetResourceVersion() != "" {
+				opts.ResourceVersion = obj.GetResourceVersion()
+			}
+			if !s.send(ctx, events, e) {
+				w.Stop()
+				return nil
+			}
+		}
+		w.Stop()
+	}
+	return nil
+}
+
+// send passes e on, unless ctx is done first.
+func (s *rigStream) send(ctx context.Context, events chan<- watch.Event, e watch.Event) bool {
+	select {
+	case events <- e:
+		return true
+	case <-ctx.Done():
+		return false
+	}
+}
+
+// sleepContext sleeps for d, or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) {
+	select {
+	case <-time.After(d):
+	case <-ctx.Done():
+	}
+}
+
+// handle returns the changes a watch event amounts to. Objects of other
+// rigs, unchanged objects and events of untracked objects are dropped.
+func (w *rigWatcher) handle(e watch.Event) []RigWatchEvent {
+	obj, ok := e.Object.(*unstructured.Unstructured)
+	if !ok {
+		return nil
+	}
+	if obj.GetKind() == "Event" {
+		return w.handleEvent(obj)
+	}
+	if !w.belongsToRig(obj) {
+		return nil
+	}
+
+	key := obj.GetKind() + "/" + obj.GetName()
+	phase, _, _ := unstructured.NestedString(obj.Object, "status", "phase")
+	summary := statusSummary(obj)
+	if obj.GetKind() == "Polecat" {
+		if podName, _, _ := unstructured.NestedString(obj.Object, "status", "podName"); podName != "" {
+			w.pods[podName] = obj.GetName()
+		}
+	}
+
+	if e.Type == watch.Deleted {
+		delete(w.last, key)
+		return []RigWatchEvent{w.statusEvent(e.Type, obj, phase, summary)}
+	}
+	state := phase + "\x00" + summary
+	if last, seen := w.last[key]; seen && last == state {
+		return nil
+	}
+	eventType := watch.Modified
+	if _, seen := w.last[key]; !seen {
+		eventType = watch.Added
+	}
+	w.last[key] = state
+	return []RigWatchEvent{w.statusEvent(eventType, obj, phase, summary)}
+}
+
+func (w *rigWatcher) statusEvent(eventType watch.EventType, obj *unstructured.Unstructured, phase, summary string) RigWatchEvent {
+	return RigWatchEvent{
+		Time:      w.now(),
+		Source:    WatchSourceStatus,
+		Type:      string(eventType),
+		Kind:      obj.GetKind(),
+		Namespace: obj.GetNamespace(),
+		Name:      obj.GetName(),
+		Phase:     phase,
+		Message:   summary,
+	}
+}
+
+// belongsToRig reports whether a Polecat, Refinery or Convoy works for the
+// watched rig.
+func (w *rigWatcher) belongsToRig(obj *unstructured.Unstructured) bool {
+	field := "rigRef"
+	if obj.GetKind() == "Polecat" {
+		field = "rig"
+	}
+	rig, _, _ := unstructured.NestedString(obj.Object, "spec", field)
+	return rig == w.rig
+}
+
+// handleEvent reports events recorded on the rig, on its tracked objects
+// and on the Pods of its polecats.
+func (w *rigWatcher) handleEvent(obj *unstructured.Unstructured) []RigWatchEvent {
+	var event corev1.Event
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(obj.Object, &event); err != nil {
+		return nil
+	}
+	involved := event.InvolvedObject
+	switch involved.Kind {
+	case "Rig":
+		if involved.Name != w.rig {
+			return nil
+		}
+	case "Pod":
+		if _, tracked := w.pods[involved.Name]; !tracked {
+			return nil
+		}
+	default:
+		if _, tracked := w.last[involved.Kind+"/"+involved.Name]; !tracked {
+			return nil
+		}
+	}
+
+	at := event.LastTimestamp.Time
+	if at.IsZero() {
+		at = event.EventTime.Time
+	}
+	if at.IsZero() {
+		at = w.now()
+	}
+	return []RigWatchEvent{{
+		Time:      at,
+		Source:    WatchSourceEvent,
+		Type:      event.Type,
+		Kind:      involved.Kind,
+		Namespace: involved.Namespace,
+		Name:      involved.Name,
+		Reason:    event.Reason,
+		Message:   event.Message,
+	}}
+}
+
+// statusSummary describes the status of a Polecat, Refinery or Convoy
+// besides its phase.
+func statusSummary(obj *unstructured.Unstructured) string {
+	var parts []string
+	switch obj.GetKind() {
+	case "Polecat":
+		if bead, _, _ := unstructured.NestedString(obj.Object, "spec", "beadID"); bead != "" {
+			parts = append(parts, "bead "+bead)
+		}
+	case "Refinery":
+		queue, _, _ := unstructured.NestedInt64(obj.Object, "status", "queueLength")
+		parts = append(parts, fmt.Sprintf("queue %d", queue))
+		if merge, _, _ := unstructured.NestedString(obj.Object, "status", "currentMerge"); merge != "" {
+			parts = append(parts, "merging "+merge)
+		}
+	case "Convoy":
+		if progress, _, _ := unstructured.NestedString(obj.Object, "status", "progress"); progress != "" {
+			parts = append(parts, "progress "+progress)
+		}
+	}
+
+	// Explain what is not ready
+	conditions, _, _ := unstructured.NestedSlice(obj.Object, "status", "conditions")
+	for _, c := range conditions {
+		cond, _ := c.(map[string]any)
+		if cond["type"] == "Ready" && cond["status"] == string(metav1.ConditionFalse) {
+			if message, _ := cond["message"].(string); message != "" {
+				parts = append(parts, message)
+			}
+		}
+	}
+	return strings.Join(parts, ", ")
+}
+
+// watchPrinter writes RigWatchEvents as colored lines or JSON lines.
+type watchPrinter struct {
+	out   io.Writer
+	json  bool
+	color bool
+}
+
+func (p *watchPrinter) print(e RigWatchEvent) error {
+	if p.json {
+		return json.NewEncoder(p.out).Encode(e)
+	}
+
+	state := e.Phase
+	message := e.Message
+	switch {
+	case e.Source == WatchSourceEvent:
+		state = e.Type
+		message = e.Reason + ": " + e.Message
+	case e.Type == string(watch.Deleted):
+		state = "Deleted"
+	}
+	column := fmt.Sprintf("%-10s", orDash(state))
+	if p.color {
+		column = colorize(column, state)
+	}
+	_, err := fmt.Fprintf(p.out, "%s  %-32s %s  %s\n",
+		e.Time.Local().Format("15:04:05"), e.Kind+"/"+e.Name, column, message)
+	return err
+}
+
+// colorize colors s by the phase or event type it shows: red for failures,
+// green for success, cyan for work in progress and yellow for waiting.
+func colorize(s, state string) string {
+	var code string
+	switch state {
+	case "Stuck", "Terminated", "Error", "Failed", "Warning":
+		code = "31"
+	case "Done", "Complete":
+		code = "32"
+	case "Working", "Processing", "InProgress":
+		code = "36"
+	case "Idle", "Pending":
+		code = "33"
+	default:
+		return s
+	}
+	return "\033[" + code + "m" + s + "\033[0m"
+}
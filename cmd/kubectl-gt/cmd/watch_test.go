@@ -0,0 +1,178 @@
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/watch"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+)
+
+func TestNewWatchCmd(t *testing.T) {
+	cmd := newWatchCmd()
+	for _, flag := range []string{"output", "no-color"} {
+		if cmd.Flags().Lookup(flag) == nil {
+			t.Errorf("expected flag --%s to exist", flag)
+		}
+	}
+	cmd.SetArgs([]string{"my-rig", "-o", "yaml"})
+	cmd.SetOut(io.Discard)
+	cmd.SetErr(io.Discard)
+	if err := cmd.Execute(); err == nil || !strings.Contains(err.Error(), "want table or json") {
+		t.Errorf("expected yaml to be rejected, got %v", err)
+	}
+}
+
+func watchPolecat(rig, phase, podName string) *unstructured.Unstructured {
+	return fsckObject("gastown.gastown.io/v1alpha1", "Polecat", "furiosa", nil, map[string]any{
+		"spec":   map[string]any{"rig": rig, "beadID": "gt-1"},
+		"status": map[string]any{"phase": phase, "podName": podName},
+	}).(*unstructured.Unstructured)
+}
+
+func watchEvent(kind, name, eventType, reason string) *unstructured.Unstructured {
+	return fsckObject("v1", "Event", name+".1", nil, map[string]any{
+		"involvedObject": map[string]any{"kind": kind, "name": name, "namespace": "gastown"},
+		"type":           eventType,
+		"reason":         reason,
+		"message":        reason + " happened",
+	}).(*unstructured.Unstructured)
+}
+
+func TestRigWatcherHandle(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+	w := &rigWatcher{rig: "app", now: func() time.Time { return now }, last: map[string]string{}, pods: map[string]string{}}
+
+	steps := []struct {
+		name  string
+		event watch.Event
+		want  []string
+	}{
+		{"other rig", watch.Event{Type: watch.Added, Object: watchPolecat("other", "Working", "")}, nil},
+		{"untracked event", watch.Event{Type: watch.Added, Object: watchEvent("Polecat", "furiosa", "Normal", "PodCreated")}, nil},
+		{"added", watch.Event{Type: watch.Added, Object: watchPolecat("app", "Idle", "")}, []string{"status ADDED Polecat/furiosa Idle"}},
+		{"unchanged", watch.Event{Type: watch.Modified, Object: watchPolecat("app", "Idle", "")}, nil},
+		{"phase change", watch.Event{Type: watch.Modified, Object: watchPolecat("app", "Working", "polecat-furiosa")},
+			[]string{"status MODIFIED Polecat/furiosa Working"}},
+		{"polecat event", watch.Event{Type: watch.Added, Object: watchEvent("Polecat", "furiosa", "Normal", "PodCreated")},
+			[]string{"event Normal Polecat/furiosa PodCreated"}},
+		{"pod event", watch.Event{Type: watch.Added, Object: watchEvent("Pod", "polecat-furiosa", "Warning", "BackOff")},
+			[]string{"event Warning Pod/polecat-furiosa BackOff"}},
+		{"other pod event", watch.Event{Type: watch.Added, Object: watchEvent("Pod", "unrelated", "Warning", "BackOff")}, nil},
+		{"rig event", watch.Event{Type: watch.Added, Object: watchEvent("Rig", "app", "Normal", "Suspended")},
+			[]string{"event Normal Rig/app Suspended"}},
+		{"deleted", watch.Event{Type: watch.Deleted, Object: watchPolecat("app", "Done", "polecat-furiosa")},
+			[]string{"status DELETED Polecat/furiosa Done"}},
+	}
+	for _, step := range steps {
+		var got []string
+		for _, e := range w.handle(step.event) {
+			phase := e.Phase
+			if e.Source == WatchSourceEvent {
+				phase = e.Reason
+			}
+			got = append(got, strings.Join([]string{e.Source, e.Type, e.Kind + "/" + e.Name, phase}, " "))
+		}
+		if strings.Join(got, "\n") != strings.Join(step.want, "\n") {
+			t.Errorf("%s: got %v, want %v", step.name, got, step.want)
+		}
+	}
+}
+
+func TestStatusSummary(t *testing.T) {
+	refinery := fsckObject("gastown.gastown.io/v1alpha1", "Refinery", "app-refinery", nil, map[string]any{
+		"status": map[string]any{
+			"queueLength":  int64(2),
+			"currentMerge": "polecat/furiosa",
+			"conditions": []any{map[string]any{
+				"type": "Ready", "status": "False", "message": "branch lease held by gastown/other",
+			}},
+		},
+	}).(*unstructured.Unstructured)
+	want := "queue 2, merging polecat/furiosa, branch lease held by gastown/other"
+	if got := statusSummary(refinery); got != want {
+		t.Errorf("statusSummary() = %q, want %q", got, want)
+	}
+}
+
+func TestRunRigWatch(t *testing.T) {
+	rig := fsckObject("gastown.gastown.io/v1alpha1", "Rig", "app", nil, nil).(*unstructured.Unstructured)
+	rig.SetNamespace("")
+	client := dynamicfake.NewSimpleDynamicClientWithCustomListKinds(runtime.NewScheme(),
+		map[schema.GroupVersionResource]string{
+			rigGVR:      "RigList",
+			polecatGVR:  "PolecatList",
+			refineryGVR: "RefineryList",
+			convoyGVR:   "ConvoyList",
+			eventGVR:    "EventList",
+		},
+		rig,
+		watchPolecat("app", "Working", "polecat-furiosa"),
+		watchEvent("Polecat", "furiosa", "Normal", "PodCreated"),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	var got []RigWatchEvent
+	err := runRigWatch(ctx, client, "app", func(e RigWatchEvent) error {
+		got = append(got, e)
+		cancel()
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("watch failed: %v", err)
+	}
+	// Existing events are not replayed
+	if len(got) != 1 || got[0].Kind != "Polecat" || got[0].Phase != "Working" || got[0].Message != "bead gt-1" {
+		t.Errorf("expected the polecat's current state, got %+v", got)
+	}
+
+	if err := runRigWatch(context.Background(), client, "missing", nil); err == nil {
+		t.Error("expected an unknown rig to fail")
+	}
+}
+
+func TestWatchPrinter(t *testing.T) {
+	e := RigWatchEvent{
+		Time:   time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC),
+		Source: WatchSourceStatus, Type: "MODIFIED", Kind: "Polecat", Name: "furiosa",
+		Phase: "Stuck", Message: "bead gt-1",
+	}
+
+	var out bytes.Buffer
+	if err := (&watchPrinter{out: &out, json: true}).print(e); err != nil {
+		t.Fatal(err)
+	}
+	var decoded RigWatchEvent
+	if err := json.Unmarshal(out.Bytes(), &decoded); err != nil || decoded.Phase != "Stuck" {
+		t.Errorf("expected a JSON line, got %q: %v", out.String(), err)
+	}
+	if strings.Count(out.String(), "\n") != 1 {
+		t.Errorf("expected exactly one line, got %q", out.String())
+	}
+
+	out.Reset()
+	if err := (&watchPrinter{out: &out, color: true}).print(e); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(out.String(), "\033[31mStuck") || !strings.Contains(out.String(), "Polecat/furiosa") {
+		t.Errorf("expected Stuck in red, got %q", out.String())
+	}
+
+	out.Reset()
+	e.Source, e.Type, e.Reason, e.Message = WatchSourceEvent, "Warning", "BackOff", "restarting"
+	if err := (&watchPrinter{out: &out}).print(e); err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(out.String(), "\033[") || !strings.Contains(out.String(), "Warning     BackOff: restarting") {
+		t.Errorf("expected an uncolored event line, got %q", out.String())
+	}
+}
@@ -17,27 +17,47 @@ limitations under the License.
 package main
 
 import (
+	"context"
 	"crypto/tls"
 	"flag"
 	"os"
+	"path/filepath"
+	"strings"
+	"time"
 
 	// Import all Kubernetes client auth plugins (e.g. Azure, GCP, OIDC, etc.)
 	// to ensure that exec-entrypoint and run can make use of them.
 	_ "k8s.io/client-go/plugin/pkg/client/auth"
 
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
 	utilruntime "k8s.io/apimachinery/pkg/util/runtime"
+	"k8s.io/client-go/kubernetes"
 	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/cache"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
 	"sigs.k8s.io/controller-runtime/pkg/metrics/filters"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
 	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	gastownv1alpha2 "github.com/org/gastown-operator/api/v1alpha2"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/chatops"
 	"github.com/org/gastown-operator/internal/controller"
+	"github.com/org/gastown-operator/internal/gitwebhook"
+	"github.com/org/gastown-operator/internal/telemetry"
+	"github.com/org/gastown-operator/pkg/config"
 	gterrors "github.com/org/gastown-operator/pkg/errors"
+	"github.com/org/gastown-operator/pkg/metrics"
 	"github.com/org/gastown-operator/pkg/version"
 	// +kubebuilder:scaffold:imports
 )
@@ -49,8 +69,10 @@ var (
 
 func init() {
 	utilruntime.Must(clientgoscheme.AddToScheme(scheme))
+	utilruntime.Must(apiextensionsv1.AddToScheme(scheme))
 
 	utilruntime.Must(gastownv1alpha1.AddToScheme(scheme))
+	utilruntime.Must(gastownv1alpha2.AddToScheme(scheme))
 	// +kubebuilder:scaffold:scheme
 }
 
@@ -60,10 +82,21 @@ func main() {
 	var metricsCertPath, metricsCertName, metricsCertKey string
 	var webhookCertPath, webhookCertName, webhookCertKey string
 	var enableLeaderElection bool
+	var gracefulShutdownTimeout time.Duration
 	var probeAddr string
 	var secureMetrics bool
 	var enableHTTP2 bool
 	var disableWebhooks bool
+	var conversionService string
+	var gitWebhookAddr string
+	var chatOpsAddr string
+	var polecatTTLCleanup bool
+	var consistencyCheckInterval time.Duration
+	var otlpEndpoint string
+	var otlpInsecure bool
+	var auditLogFile string
+	var auditEvents bool
+	var tuningFlags config.TuningFlags
 	var tlsOpts []func(*tls.Config)
 	flag.StringVar(&metricsAddr, "metrics-bind-address", "0", "The address the metrics endpoint binds to. "+
 		"Use :8443 for HTTPS or :8080 for HTTP, or leave as 0 to disable the metrics service.")
@@ -71,6 +104,9 @@ func main() {
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.DurationVar(&gracefulShutdownTimeout, "graceful-shutdown-timeout", 2*time.Minute,
+		"How long a stopping manager waits for in-flight merges to finish before it exits and "+
+			"hands the leader lease to another replica. Keep it below the Pod's terminationGracePeriodSeconds.")
 	flag.BoolVar(&secureMetrics, "metrics-secure", true,
 		"If set, the metrics endpoint is served securely via HTTPS. Use --metrics-secure=false to use HTTP instead.")
 	flag.StringVar(&webhookCertPath, "webhook-cert-path", "", "The directory that contains the webhook certificate.")
@@ -84,9 +120,34 @@ func main() {
 		"If set, HTTP/2 will be enabled for the metrics and webhook servers")
 	flag.BoolVar(&disableWebhooks, "disable-webhooks", false,
 		"If set, webhooks will be disabled. Use for E2E tests or deployments without cert-manager.")
+	flag.StringVar(&conversionService, "conversion-webhook-service", "",
+		"The namespace/name of the webhook Service to point the conversion of the Rig and Polecat CRDs at, "+
+			"with ca.crt from the webhook certificate directory as CA bundle. Leave empty when the CRDs "+
+			"are installed with their conversion already set, as with Kustomize.")
+	flag.StringVar(&gitWebhookAddr, "git-webhook-bind-address", "0",
+		"The address the git webhook receiver (GitHub/GitLab push and pull request events) binds to, "+
+			"e.g. :9443. Requires "+gitwebhook.EnvSecret+". Leave as 0 to disable and rely on polling.")
+	flag.StringVar(&chatOpsAddr, "chatops-bind-address", "0",
+		"The address the Slack endpoint (slash commands and app mentions such as \"sling ap-123 my-rig\") "+
+			"binds to, e.g. :9444. Requires "+chatops.EnvSigningSecret+". Leave as 0 to disable.")
+	flag.BoolVar(&polecatTTLCleanup, "polecat-ttl-cleanup", true,
+		"If set, finished Polecats are deleted once their spec.ttlSecondsAfterFinished expires.")
+	flag.DurationVar(&consistencyCheckInterval, "consistency-check-interval", controller.DefaultConsistencyCheckInterval,
+		"How often to check for polecats without Pods, orphaned Pods, abandoned beads and stale merge queue "+
+			"entries, as kubectl gt fsck does. Set to 0 to disable.")
+	flag.StringVar(&otlpEndpoint, "otlp-endpoint", "",
+		"The host:port of an OTLP gRPC receiver (e.g. an OpenTelemetry Collector) to export polecat Pod "+
+			"phases, events and merge outcomes to as spans and metrics. Leave empty to disable.")
+	flag.BoolVar(&otlpInsecure, "otlp-insecure", false, "If set, the OTLP receiver is reached without TLS.")
+	flag.StringVar(&auditLogFile, "audit-log-file", "",
+		"The file to append an audit log of the operator's Pod, sling, clone, merge and push actions to, "+
+			"as JSON lines. Leave empty to only count them in gastown_audit_events_total.")
+	flag.BoolVar(&auditEvents, "audit-events", false,
+		"If set, each audited action is also emitted as a Kubernetes Event on the object it was taken for.")
 	opts := zap.Options{
 		Development: true,
 	}
+	tuningFlags.BindFlags(flag.CommandLine)
 	opts.BindFlags(flag.CommandLine)
 	flag.Parse()
 
@@ -167,33 +228,116 @@ func main() {
 		metricsServerOptions.KeyName = metricsCertKey
 	}
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
+	// Workqueues and caches are set up once, from the GastownConfig the
+	// operator starts with and the flags overriding it
+	restConfig := ctrl.GetConfigOrDie()
+	tuning, cacheSyncPeriod := tuningFlags.Tuning(startupConfig(restConfig))
+	var cacheOptions cache.Options
+	if cacheSyncPeriod > 0 {
+		cacheOptions.SyncPeriod = &cacheSyncPeriod
+	}
+
+	// The holder of the merges this replica runs, as recorded in Refinery status
+	identity, err := os.Hostname()
+	if err != nil {
+		setupLog.Error(err, "unable to determine the replica identity")
+		os.Exit(1)
+	}
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
 		Scheme:                 scheme,
+		Cache:                  cacheOptions,
 		Metrics:                metricsServerOptions,
 		WebhookServer:          webhookServer,
 		HealthProbeBindAddress: probeAddr,
 		LeaderElection:         enableLeaderElection,
 		LeaderElectionID:       "9a7b81dd.gastown.io",
-		// LeaderElectionReleaseOnCancel defines if the leader should step down voluntarily
-		// when the Manager ends. This requires the binary to immediately end when the
-		// Manager is stopped, otherwise, this setting is unsafe. Setting this significantly
-		// speeds up voluntary leader transitions as the new leader don't have to wait
-		// LeaseDuration time first.
-		//
-		// In the default scaffold provided, the program ends immediately after
-		// the manager stops, so would be fine to enable this option. However,
-		// if you are doing or is intended to do any operation such as perform cleanups
-		// after the manager stops then its usage might be unsafe.
-		// LeaderElectionReleaseOnCancel: true,
+		// The leader steps down as soon as its in-flight merges have finished, so
+		// a standby replica takes over without waiting out the lease. main
+		// does nothing after the manager stops, which keeps this safe.
+		LeaderElectionReleaseOnCancel: true,
+		GracefulShutdownTimeout:       &gracefulShutdownTimeout,
 	})
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
 	}
 
+	// Mutating git and Pod actions are counted, and optionally logged to a
+	// file and as Events
+	var auditRecorder record.EventRecorder
+	if auditEvents {
+		//nolint:staticcheck // TODO: migrate to events.EventRecorder
+		auditRecorder = mgr.GetEventRecorderFor("gastown-audit")
+	}
+	auditLog, err := audit.NewLog(auditLogFile, auditRecorder)
+	if err != nil {
+		setupLog.Error(err, "unable to open audit log", "path", auditLogFile)
+		os.Exit(1)
+	}
+	defer auditLog.Close() //nolint:errcheck // best-effort on exit
+
+	// Git webhooks trigger Rig, Refinery and BeadStore reconciles between
+	// requeue intervals
+	var gitReceiver *gitwebhook.Receiver
+	if gitWebhookAddr != "" && gitWebhookAddr != "0" {
+		secret := os.Getenv(gitwebhook.EnvSecret)
+		if secret == "" {
+			setupLog.Error(nil, "git webhook receiver requires a secret", "env", gitwebhook.EnvSecret)
+			os.Exit(1)
+		}
+		gitReceiver = gitwebhook.NewReceiver(mgr.GetClient(), []byte(secret), gitWebhookAddr)
+		if err := mgr.Add(gitReceiver); err != nil {
+			setupLog.Error(err, "unable to add git webhook receiver")
+			os.Exit(1)
+		}
+	}
+
+	// Slack commands create Polecats and report their progress
+	if chatOpsAddr != "" && chatOpsAddr != "0" {
+		secret := os.Getenv(chatops.EnvSigningSecret)
+		if secret == "" {
+			setupLog.Error(nil, "Slack endpoint requires a signing secret", "env", chatops.EnvSigningSecret)
+			os.Exit(1)
+		}
+		slack := chatops.NewReceiver(mgr.GetClient(), []byte(secret), chatOpsAddr)
+		slack.BotToken = os.Getenv(chatops.EnvBotToken)
+		slack.Audit = auditLog
+		if channels := os.Getenv(chatops.EnvAllowedChannels); channels != "" {
+			slack.AllowedChannels = strings.Split(channels, ",")
+		}
+		if err := mgr.Add(slack); err != nil {
+			setupLog.Error(err, "unable to add Slack endpoint")
+			os.Exit(1)
+		}
+	}
+
+	// Polecat Pod lifecycles and merge outcomes are exported over OTLP
+	var exporter *telemetry.Exporter
+	var mergeTelemetry controller.MergeTelemetry
+	if otlpEndpoint != "" {
+		exporter, err = telemetry.New(context.Background(), telemetry.Options{
+			Endpoint: otlpEndpoint,
+			Insecure: otlpInsecure,
+		})
+		if err != nil {
+			setupLog.Error(err, "unable to create OTLP exporter")
+			os.Exit(1)
+		}
+		if err := mgr.Add(exporter); err != nil {
+			setupLog.Error(err, "unable to add OTLP exporter")
+			os.Exit(1)
+		}
+		mergeTelemetry = exporter
+	}
+
 	if err := (&controller.RigReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // TODO: migrate to events.EventRecorder
+		Recorder: mgr.GetEventRecorderFor("rig-controller"),
+		Tuning:   tuning[config.ControllerRig],
+		Triggers: gitReceiver.RigTriggers(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Rig")
 		os.Exit(1)
@@ -201,6 +345,13 @@ func main() {
 	if err := (&controller.PolecatReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // TODO: migrate to events.EventRecorder
+		Recorder:  mgr.GetEventRecorderFor("polecat-controller"),
+		LogReader: controller.NewPodLogReader(kubernetes.NewForConfigOrDie(mgr.GetConfig())),
+
+		Tuning:            tuning[config.ControllerPolecat],
+		DisableTTLCleanup: !polecatTTLCleanup,
+		Audit:             auditLog,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Polecat")
 		os.Exit(1)
@@ -208,6 +359,10 @@ func main() {
 	if err := (&controller.ConvoyReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // TODO: migrate to events.EventRecorder
+		Recorder: mgr.GetEventRecorderFor("convoy-controller"),
+		Tuning:   tuning[config.ControllerConvoy],
+		Audit:    auditLog,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Convoy")
 		os.Exit(1)
@@ -217,6 +372,7 @@ func main() {
 		Scheme: mgr.GetScheme(),
 		//nolint:staticcheck // TODO: migrate to events.EventRecorder
 		Recorder: mgr.GetEventRecorderFor("witness-controller"),
+		Tuning:   tuning[config.ControllerWitness],
 		Backoff:  gterrors.NewBackoffCalculator(),
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Witness")
@@ -226,7 +382,12 @@ func main() {
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
 		//nolint:staticcheck // TODO: migrate to events.EventRecorder
-		Recorder: mgr.GetEventRecorderFor("refinery-controller"),
+		Recorder:  mgr.GetEventRecorderFor("refinery-controller"),
+		Triggers:  gitReceiver.RefineryTriggers(),
+		Telemetry: mergeTelemetry,
+		Tuning:    tuning[config.ControllerRefinery],
+		Identity:  identity,
+		Audit:     auditLog,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "Refinery")
 		os.Exit(1)
@@ -234,12 +395,106 @@ func main() {
 	if err := (&controller.BeadStoreReconciler{
 		Client: mgr.GetClient(),
 		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // TODO: migrate to events.EventRecorder
+		Recorder: mgr.GetEventRecorderFor("beadstore-controller"),
+		Triggers: gitReceiver.BeadStoreTriggers(),
+		Tuning:   tuning[config.ControllerBeadStore],
+		Audit:    auditLog,
 	}).SetupWithManager(mgr); err != nil {
 		setupLog.Error(err, "unable to create controller", "controller", "BeadStore")
 		os.Exit(1)
 	}
+	if err := (&controller.EmergencyStopReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // TODO: migrate to events.EventRecorder
+		Recorder: mgr.GetEventRecorderFor("emergencystop-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "EmergencyStop")
+		os.Exit(1)
+	}
+	if err := (&controller.GastownConfigReconciler{
+		Client: mgr.GetClient(),
+		Scheme: mgr.GetScheme(),
+		//nolint:staticcheck // TODO: migrate to events.EventRecorder
+		Recorder: mgr.GetEventRecorderFor("gastownconfig-controller"),
+	}).SetupWithManager(mgr); err != nil {
+		setupLog.Error(err, "unable to create controller", "controller", "GastownConfig")
+		os.Exit(1)
+	}
+	if exporter != nil {
+		if err := (&controller.PolecatTelemetryReconciler{
+			Client:    mgr.GetClient(),
+			APIReader: mgr.GetAPIReader(),
+			Telemetry: exporter,
+			Tuning:    tuning[config.ControllerPolecatTelemetry],
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "PolecatTelemetry")
+			os.Exit(1)
+		}
+	}
+	// Defaulting and validation of v1alpha1 objects, and conversion of
+	// v1alpha2 Rigs and Polecats to the v1alpha1 storage version
+	if !disableWebhooks {
+		if err := gastownv1alpha1.SetupRigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Rig")
+			os.Exit(1)
+		}
+		if err := gastownv1alpha1.SetupPolecatWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Polecat")
+			os.Exit(1)
+		}
+		if err := gastownv1alpha1.SetupConvoyWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Convoy")
+			os.Exit(1)
+		}
+		if err := gastownv1alpha2.SetupRigWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Rig")
+			os.Exit(1)
+		}
+		if err := gastownv1alpha2.SetupPolecatWebhookWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "Polecat")
+			os.Exit(1)
+		}
+		if conversionService != "" {
+			namespace, name, ok := strings.Cut(conversionService, "/")
+			if !ok {
+				setupLog.Error(nil, "--conversion-webhook-service must be namespace/name", "value", conversionService)
+				os.Exit(1)
+			}
+			certDir := webhookCertPath
+			if certDir == "" {
+				certDir = filepath.Join(os.TempDir(), "k8s-webhook-server", "serving-certs")
+			}
+			if err := mgr.Add(&controller.ConversionConfigurator{
+				Client:       mgr.GetClient(),
+				Service:      types.NamespacedName{Namespace: namespace, Name: name},
+				CABundlePath: filepath.Join(certDir, "ca.crt"),
+			}); err != nil {
+				setupLog.Error(err, "unable to add conversion configurator")
+				os.Exit(1)
+			}
+		}
+	}
 	// +kubebuilder:scaffold:builder
 
+	// Report drift between recorded state and reality as events and metrics
+	if consistencyCheckInterval > 0 {
+		if err := mgr.Add(&controller.ConsistencyChecker{
+			Client: mgr.GetClient(),
+			//nolint:staticcheck // TODO: migrate to events.EventRecorder
+			Recorder: mgr.GetEventRecorderFor("consistency-checker"),
+			Interval: consistencyCheckInterval,
+		}); err != nil {
+			setupLog.Error(err, "unable to add consistency checker")
+			os.Exit(1)
+		}
+	}
+
+	// Export custom resource state (polecat phases, queue lengths, convoy
+	// progress) from the cache at scrape time
+	ctrlmetrics.Registry.MustRegister(metrics.NewStateCollector(mgr.GetClient()))
+
 	if err := mgr.AddHealthzCheck("healthz", healthz.Ping); err != nil {
 		setupLog.Error(err, "unable to set up health check")
 		os.Exit(1)
@@ -255,3 +510,22 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// startupConfig reads the default GastownConfig directly from the API
+// server, before the manager and its caches exist. A missing or unreadable
+// GastownConfig leaves every setting to its default.
+func startupConfig(restConfig *rest.Config) *config.Config {
+	c, err := client.New(restConfig, client.Options{Scheme: scheme})
+	if err != nil {
+		setupLog.Error(err, "unable to read GastownConfig, using defaults")
+		return &config.Config{}
+	}
+	var cfg gastownv1alpha1.GastownConfig
+	if err := c.Get(context.Background(), client.ObjectKey{Name: gastownv1alpha1.GastownConfigName}, &cfg); err != nil {
+		if !apierrors.IsNotFound(err) {
+			setupLog.Error(err, "unable to read GastownConfig, using defaults")
+		}
+		return &config.Config{}
+	}
+	return config.FromSpec(&cfg.Spec)
+}
@@ -58,3 +58,27 @@ Create the name of the service account to use
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Render a map as comma-separated key=value pairs, sorted by key
+*/}}
+{{- define "gastown-operator.keyValues" -}}
+{{- $pairs := list }}
+{{- range $key, $value := . }}
+{{- $pairs = append $pairs (printf "%s=%v" $key $value) }}
+{{- end }}
+{{- join "," $pairs }}
+{{- end }}
+
+{{/*
+Name of the TLS Secret the webhook server is served with
+*/}}
+{{- define "gastown-operator.webhookCertSecretName" -}}
+{{- if .Values.webhooks.certSecretName }}
+{{- .Values.webhooks.certSecretName }}
+{{- else if .Values.webhooks.certManager.enabled }}
+{{- include "gastown-operator.fullname" . }}-webhook-cert
+{{- else }}
+{{- fail "webhooks.certSecretName or webhooks.certManager.enabled is required with webhooks enabled" }}
+{{- end }}
+{{- end }}
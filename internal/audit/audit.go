@@ -0,0 +1,175 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package audit records the mutating actions the operator takes outside of
+// its own resources: agent Pods it starts and deletes, polecats slung from
+// chat, and the repositories it clones, merges into and pushes to.
+//
+// Every action is counted in the gastown_audit_events_total metric. A Log
+// can additionally write each one as a line of JSON to a file and as a
+// Kubernetes Event on the object it was taken for.
+package audit
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"maps"
+	"os"
+	"reflect"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+// Audited actions
+const (
+	ActionSling        = "sling"
+	ActionStartPod     = "start-pod"
+	ActionDeletePod    = "delete-pod"
+	ActionClone        = "clone"
+	ActionMerge        = "merge"
+	ActionPush         = "push"
+	ActionPushTag      = "push-tag"
+	ActionDeleteBranch = "delete-branch"
+)
+
+// Results of audited actions
+const (
+	ResultSuccess = "success"
+	ResultFailure = "failure"
+)
+
+// Entry is an audited action, as written to the JSON-lines file.
+type Entry struct {
+	Time time.Time `json:"time"`
+
+	// Controller is the actor: the controller or receiver taking the action
+	Controller string `json:"controller"`
+
+	// Action is one of the Action constants
+	Action string `json:"action"`
+
+	// Kind, Namespace and Name identify the object the action was taken for
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace,omitempty"`
+	Name      string `json:"name"`
+
+	// Args are the action's arguments, e.g. the repository and branch
+	Args map[string]string `json:"args,omitempty"`
+
+	// Result is ResultSuccess or ResultFailure, with the Error of a failure
+	Result string `json:"result"`
+	Error  string `json:"error,omitempty"`
+}
+
+// Log records audited actions. A nil Log only counts them in the metric.
+type Log struct {
+	mu  sync.Mutex
+	out io.WriteCloser
+
+	// recorder emits an Event per action on its object. Optional.
+	recorder record.EventRecorder
+}
+
+// NewLog returns a Log appending to the JSON-lines file at path, if not
+// empty, and emitting Events with recorder, if not nil.
+func NewLog(path string, recorder record.EventRecorder) (*Log, error) {
+	l := &Log{recorder: recorder}
+	if path != "" {
+		f, err := os.OpenFile(path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0o600)
+		if err != nil {
+			return nil, fmt.Errorf("failed to open audit log: %w", err)
+		}
+		l.out = f
+	}
+	return l, nil
+}
+
+// Record records that controller took action on obj with args, failing with
+// err if it is not nil.
+func (l *Log) Record(controller, action string, obj client.Object, args map[string]string, err error) {
+	entry := Entry{
+		Time:       time.Now().UTC(),
+		Controller: controller,
+		Action:     action,
+		Kind:       reflect.Indirect(reflect.ValueOf(obj)).Type().Name(),
+		Namespace:  obj.GetNamespace(),
+		Name:       obj.GetName(),
+		Args:       args,
+		Result:     ResultSuccess,
+	}
+	if err != nil {
+		entry.Result = ResultFailure
+		entry.Error = err.Error()
+	}
+	metrics.AuditEventsTotal.WithLabelValues(controller, action, entry.Result).Inc()
+
+	if l == nil {
+		return
+	}
+	if l.recorder != nil {
+		if err != nil {
+			l.recorder.Event(obj, "Warning", "AuditFailure", entry.message())
+		} else {
+			l.recorder.Event(obj, "Normal", "Audit", entry.message())
+		}
+	}
+	if l.out != nil {
+		l.write(entry)
+	}
+}
+
+// write appends the entry to the file. Failures to write are not the
+// audited action's, so they are dropped.
+func (l *Log) write(entry Entry) {
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, _ = l.out.Write(append(line, '\n')) //nolint:errcheck // best-effort, see above
+}
+
+// message describes the entry in an Event.
+func (e Entry) message() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%s %s", e.Controller, e.Action)
+	for _, key := range slices.Sorted(maps.Keys(e.Args)) {
+		fmt.Fprintf(&b, " %s=%s", key, e.Args[key])
+	}
+	if e.Error != "" {
+		fmt.Fprintf(&b, " failed: %s", e.Error)
+	}
+	return b.String()
+}
+
+// Close closes the file of the Log, if any.
+func (l *Log) Close() error {
+	if l == nil || l.out == nil {
+		return nil
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.out.Close()
+}
@@ -0,0 +1,86 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package audit
+
+import (
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+func TestRecord(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "audit.log")
+	recorder := record.NewFakeRecorder(10)
+	l, err := NewLog(path, recorder)
+	require.NoError(t, err)
+
+	polecat := &gastownv1alpha1.Polecat{ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "gt-rig"}}
+	args := map[string]string{"sourceBranch": "polecat/furiosa", "targetBranch": "main"}
+	l.Record("refinery", ActionMerge, polecat, args, nil)
+	l.Record("refinery", ActionPush, polecat, map[string]string{"branch": "polecat/furiosa"}, errors.New("rejected"))
+	require.NoError(t, l.Close())
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	require.Len(t, lines, 2)
+
+	var entry Entry
+	require.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "refinery", entry.Controller)
+	assert.Equal(t, ActionMerge, entry.Action)
+	assert.Equal(t, "Polecat", entry.Kind)
+	assert.Equal(t, "gt-rig", entry.Namespace)
+	assert.Equal(t, "furiosa", entry.Name)
+	assert.Equal(t, args, entry.Args)
+	assert.Equal(t, ResultSuccess, entry.Result)
+	assert.False(t, entry.Time.IsZero())
+
+	require.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, ResultFailure, entry.Result)
+	assert.Equal(t, "rejected", entry.Error)
+
+	assert.Equal(t, "Normal Audit refinery merge sourceBranch=polecat/furiosa targetBranch=main", <-recorder.Events)
+	assert.Equal(t, "Warning AuditFailure refinery push branch=polecat/furiosa failed: rejected", <-recorder.Events)
+}
+
+func TestRecordNilLog(t *testing.T) {
+	var l *Log
+	counter := metrics.AuditEventsTotal.WithLabelValues("chatops", ActionSling, ResultSuccess)
+	before := testutil.ToFloat64(counter)
+
+	l.Record("chatops", ActionSling, &gastownv1alpha1.Polecat{}, nil, nil)
+	assert.Equal(t, before+1, testutil.ToFloat64(counter))
+	assert.NoError(t, l.Close())
+}
+
+func TestNewLogInvalidPath(t *testing.T) {
+	_, err := NewLog(filepath.Join(t.TempDir(), "missing", "audit.log"), nil)
+	assert.ErrorContains(t, err, "failed to open audit log")
+}
@@ -0,0 +1,252 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package beads reads and reconciles beads issue databases (issues.jsonl).
+//
+// Sync is a three-way compare-and-swap: each bead's revision (a hash of its
+// content) is compared against the revision recorded at the previous sync.
+// A side may only overwrite a bead the other side has not touched since then;
+// beads changed on both sides are reported as conflicts instead of clobbered.
+package beads
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Bead is a single issue line from issues.jsonl.
+// The raw JSON is preserved so fields this package does not know survive a sync.
+type Bead struct {
+	// ID is the bead identifier (e.g., "gt-123")
+	ID string
+
+	// Raw is the compact JSON encoding of the bead
+	Raw json.RawMessage
+}
+
+// Revision returns a short content hash identifying this version of the bead.
+func (b Bead) Revision() string {
+	sum := sha256.Sum256(b.Raw)
+	return hex.EncodeToString(sum[:])[:12]
+}
+
+// Set is a collection of beads keyed by ID.
+type Set map[string]Bead
+
+// Parse reads issues.jsonl content. Blank lines are skipped; every other
+// line must be a JSON object with a non-empty "id".
+func Parse(data []byte) (Set, error) {
+	beads := Set{}
+	for i, line := range bytes.Split(data, []byte("\n")) {
+		line = bytes.TrimSpace(line)
+		if len(line) == 0 {
+			continue
+		}
+
+		var header struct {
+			ID string `json:"id"`
+		}
+		if err := json.Unmarshal(line, &header); err != nil {
+			return nil, fmt.Errorf("line %d: invalid JSON: %w", i+1, err)
+		}
+		if header.ID == "" {
+			return nil, fmt.Errorf("line %d: missing bead id", i+1)
+		}
+
+		var compact bytes.Buffer
+		if err := json.Compact(&compact, line); err != nil {
+			return nil, fmt.Errorf("line %d: invalid JSON: %w", i+1, err)
+		}
+		beads[header.ID] = Bead{ID: header.ID, Raw: compact.Bytes()}
+	}
+	return beads, nil
+}
+
+// Encode writes the set as issues.jsonl, one bead per line sorted by ID.
+func (s Set) Encode() []byte {
+	var buf bytes.Buffer
+	for _, id := range s.IDs() {
+		buf.Write(s[id].Raw)
+		buf.WriteByte('\n')
+	}
+	return buf.Bytes()
+}
+
+// IDs returns the bead IDs in sorted order.
+func (s Set) IDs() []string {
+	ids := make([]string, 0, len(s))
+	for id := range s {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// revision returns the revision of id in the set, or "" if absent.
+func (s Set) revision(id string) string {
+	if b, ok := s[id]; ok {
+		return b.Revision()
+	}
+	return ""
+}
+
+// Conflict is a bead changed by both the operator and the remote since the last sync.
+// An empty revision means the bead was deleted (or never existed) on that side.
+type Conflict struct {
+	ID             string
+	BaseRevision   string
+	LocalRevision  string
+	RemoteRevision string
+}
+
+// Result is the outcome of a three-way sync.
+type Result struct {
+	// Remote is the content to publish to the remote. Conflicted beads keep
+	// their remote version.
+	Remote Set
+
+	// Local is the operator's new working copy. Conflicted beads keep their
+	// local version so the operator's change is not lost.
+	Local Set
+
+	// Base is the new sync point. Conflicted beads keep their old base so
+	// the conflict is detected again until it is resolved.
+	Base Set
+
+	// RemoteChanged reports whether Remote differs from the remote input,
+	// i.e. whether local changes need to be pushed.
+	RemoteChanged bool
+
+	// Conflicts lists beads changed on both sides, sorted by ID.
+	Conflicts []Conflict
+}
+
+// Sync reconciles the operator's local copy with the remote using base, the
+// content both sides agreed on at the previous sync.
+func Sync(base, local, remote Set) Result {
+	result := Result{Remote: Set{}, Local: Set{}, Base: Set{}}
+
+	ids := map[string]struct{}{}
+	for _, s := range []Set{base, local, remote} {
+		for id := range s {
+			ids[id] = struct{}{}
+		}
+	}
+	sorted := make([]string, 0, len(ids))
+	for id := range ids {
+		sorted = append(sorted, id)
+	}
+	sort.Strings(sorted)
+
+	for _, id := range sorted {
+		b, l, r := base.revision(id), local.revision(id), remote.revision(id)
+
+		var winner Set
+		switch {
+		case l == r:
+			// Both sides agree (including both deleted)
+			winner = remote
+		case l == b:
+			// Only the remote changed
+			winner = remote
+		case r == b:
+			// Only the operator changed; the remote still holds the base
+			// revision, so the swap is safe
+			winner = local
+			result.RemoteChanged = true
+		default:
+			result.Conflicts = append(result.Conflicts, Conflict{
+				ID:             id,
+				BaseRevision:   b,
+				LocalRevision:  l,
+				RemoteRevision: r,
+			})
+			copyBead(result.Remote, remote, id)
+			copyBead(result.Local, local, id)
+			copyBead(result.Base, base, id)
+			continue
+		}
+
+		copyBead(result.Remote, winner, id)
+		copyBead(result.Local, winner, id)
+		copyBead(result.Base, winner, id)
+	}
+
+	return result
+}
+
+// Resolution selects which side wins a conflicted bead.
+type Resolution string
+
+const (
+	// ResolveLocal keeps the operator's version and overwrites the remote.
+	ResolveLocal Resolution = "local"
+
+	// ResolveRemote keeps the remote version and discards the operator's change.
+	ResolveRemote Resolution = "remote"
+)
+
+// Resolve applies a resolution by rewriting the base or local copy so that
+// the next Sync no longer sees a conflict for id.
+func Resolve(base, local, remote Set, id string, resolution Resolution) error {
+	switch resolution {
+	case ResolveLocal:
+		// Pretend the remote version was the agreed base: only local changed
+		delete(base, id)
+		copyBead(base, remote, id)
+	case ResolveRemote:
+		// Adopt the remote version locally: both sides agree
+		delete(local, id)
+		copyBead(local, remote, id)
+	default:
+		return fmt.Errorf("unknown resolution %q for bead %s (want %q or %q)",
+			resolution, id, ResolveLocal, ResolveRemote)
+	}
+	return nil
+}
+
+// ParseResolutions parses "id=local,id2=remote" into a map.
+func ParseResolutions(value string) (map[string]Resolution, error) {
+	resolutions := map[string]Resolution{}
+	for _, part := range strings.Split(value, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		id, side, ok := strings.Cut(part, "=")
+		if !ok || id == "" {
+			return nil, fmt.Errorf("invalid resolution %q (want <bead-id>=local|remote)", part)
+		}
+		res := Resolution(strings.TrimSpace(side))
+		if res != ResolveLocal && res != ResolveRemote {
+			return nil, fmt.Errorf("invalid resolution %q for bead %s (want local or remote)", side, id)
+		}
+		resolutions[strings.TrimSpace(id)] = res
+	}
+	return resolutions, nil
+}
+
+// copyBead copies id from src to dst if present.
+func copyBead(dst, src Set, id string) {
+	if b, ok := src[id]; ok {
+		dst[id] = b
+	}
+}
@@ -0,0 +1,283 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package beads
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func mustParse(t *testing.T, data string) Set {
+	t.Helper()
+	s, err := Parse([]byte(data))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	return s
+}
+
+func TestParseAndEncode(t *testing.T) {
+	s := mustParse(t, `{"id":"gt-2","title":"second"}
+
+{ "id": "gt-1", "title": "first", "extra": {"kept": true} }
+`)
+
+	if len(s) != 2 {
+		t.Fatalf("expected 2 beads, got %d", len(s))
+	}
+
+	want := `{"id":"gt-1","title":"first","extra":{"kept":true}}` + "\n" +
+		`{"id":"gt-2","title":"second"}` + "\n"
+	if got := string(s.Encode()); got != want {
+		t.Errorf("Encode() = %q, want %q", got, want)
+	}
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]string{
+		"invalid json": `{"id":`,
+		"missing id":   `{"title":"no id"}`,
+	}
+	for name, data := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(data)); err == nil {
+				t.Error("expected error")
+			}
+		})
+	}
+}
+
+func TestRevisionIgnoresFormatting(t *testing.T) {
+	a := mustParse(t, `{"id":"gt-1","status":"open"}`)
+	b := mustParse(t, `{ "id" : "gt-1", "status" : "open" }`)
+	c := mustParse(t, `{"id":"gt-1","status":"closed"}`)
+
+	if a["gt-1"].Revision() != b["gt-1"].Revision() {
+		t.Error("expected whitespace-only differences to share a revision")
+	}
+	if a["gt-1"].Revision() == c["gt-1"].Revision() {
+		t.Error("expected content changes to change the revision")
+	}
+}
+
+func TestSync(t *testing.T) {
+	base := mustParse(t, `{"id":"gt-1","status":"open"}
+{"id":"gt-2","status":"open"}
+{"id":"gt-3","status":"open"}
+{"id":"gt-4","status":"open"}`)
+	local := mustParse(t, `{"id":"gt-1","status":"closed"}
+{"id":"gt-2","status":"open"}
+{"id":"gt-3","status":"closed"}
+{"id":"gt-4","status":"open"}
+{"id":"gt-5","status":"open"}`)
+	remote := mustParse(t, `{"id":"gt-1","status":"open"}
+{"id":"gt-2","status":"in_progress"}
+{"id":"gt-3","status":"blocked"}
+{"id":"gt-6","status":"open"}`)
+
+	result := Sync(base, local, remote)
+
+	// gt-1: operator-only change is swapped in
+	if result.Remote["gt-1"].Revision() != local["gt-1"].Revision() {
+		t.Error("gt-1: expected local change to be published")
+	}
+	// gt-2: remote-only change is adopted locally
+	if result.Local["gt-2"].Revision() != remote["gt-2"].Revision() {
+		t.Error("gt-2: expected remote change to be adopted")
+	}
+	// gt-3: both changed, conflict keeps each side
+	if len(result.Conflicts) != 1 || result.Conflicts[0].ID != "gt-3" {
+		t.Fatalf("expected single conflict on gt-3, got %+v", result.Conflicts)
+	}
+	if result.Remote["gt-3"].Revision() != remote["gt-3"].Revision() {
+		t.Error("gt-3: remote must not be clobbered")
+	}
+	if result.Local["gt-3"].Revision() != local["gt-3"].Revision() {
+		t.Error("gt-3: local change must be kept")
+	}
+	if result.Base["gt-3"].Revision() != base["gt-3"].Revision() {
+		t.Error("gt-3: base must be kept so the conflict persists")
+	}
+	// gt-4: deleted remotely, untouched locally
+	if _, ok := result.Local["gt-4"]; ok {
+		t.Error("gt-4: expected remote deletion to apply locally")
+	}
+	// gt-5: created locally
+	if _, ok := result.Remote["gt-5"]; !ok {
+		t.Error("gt-5: expected local creation to be published")
+	}
+	// gt-6: created remotely
+	if _, ok := result.Local["gt-6"]; !ok {
+		t.Error("gt-6: expected remote creation to be adopted")
+	}
+	if !result.RemoteChanged {
+		t.Error("expected RemoteChanged")
+	}
+}
+
+func TestSyncNoLocalChanges(t *testing.T) {
+	base := mustParse(t, `{"id":"gt-1","status":"open"}`)
+	remote := mustParse(t, `{"id":"gt-1","status":"closed"}`)
+
+	result := Sync(base, base, remote)
+	if result.RemoteChanged {
+		t.Error("expected no push when only the remote changed")
+	}
+	if len(result.Conflicts) != 0 {
+		t.Errorf("expected no conflicts, got %+v", result.Conflicts)
+	}
+}
+
+func TestResolve(t *testing.T) {
+	base := mustParse(t, `{"id":"gt-1","status":"open"}`)
+	local := mustParse(t, `{"id":"gt-1","status":"closed"}`)
+	remote := mustParse(t, `{"id":"gt-1","status":"blocked"}`)
+
+	t.Run("local wins", func(t *testing.T) {
+		b, l := mustParse(t, string(base.Encode())), mustParse(t, string(local.Encode()))
+		if err := Resolve(b, l, remote, "gt-1", ResolveLocal); err != nil {
+			t.Fatal(err)
+		}
+		result := Sync(b, l, remote)
+		if len(result.Conflicts) != 0 {
+			t.Fatalf("expected conflict resolved, got %+v", result.Conflicts)
+		}
+		if result.Remote["gt-1"].Revision() != local["gt-1"].Revision() {
+			t.Error("expected local version to be published")
+		}
+	})
+
+	t.Run("remote wins", func(t *testing.T) {
+		b, l := mustParse(t, string(base.Encode())), mustParse(t, string(local.Encode()))
+		if err := Resolve(b, l, remote, "gt-1", ResolveRemote); err != nil {
+			t.Fatal(err)
+		}
+		result := Sync(b, l, remote)
+		if len(result.Conflicts) != 0 || result.RemoteChanged {
+			t.Fatalf("expected clean sync, got %+v", result)
+		}
+		if result.Local["gt-1"].Revision() != remote["gt-1"].Revision() {
+			t.Error("expected remote version to be adopted")
+		}
+	})
+
+	t.Run("unknown resolution", func(t *testing.T) {
+		if err := Resolve(Set{}, Set{}, Set{}, "gt-1", "mine"); err == nil {
+			t.Error("expected error")
+		}
+	})
+}
+
+func TestParseResolutions(t *testing.T) {
+	got, err := ParseResolutions("gt-1=local, gt-2=remote,")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got["gt-1"] != ResolveLocal || got["gt-2"] != ResolveRemote || len(got) != 2 {
+		t.Errorf("unexpected resolutions: %v", got)
+	}
+
+	for _, bad := range []string{"gt-1", "=local", "gt-1=theirs"} {
+		if _, err := ParseResolutions(bad); err == nil {
+			t.Errorf("ParseResolutions(%q): expected error", bad)
+		}
+	}
+}
+
+func TestMetadataGrouping(t *testing.T) {
+	s := mustParse(t, `{"id":"gt-2","labels":["area:payments","sprint-42"],"estimated_minutes":90,`+
+		`"dependencies":[{"issue_id":"gt-2","depends_on_id":"gt-9","type":"blocks"},`+
+		`{"issue_id":"gt-2","depends_on_id":"gt-1","type":"parent-child"}]}`)
+
+	m, err := s["gt-2"].Metadata()
+	if err != nil {
+		t.Fatalf("Metadata() error = %v", err)
+	}
+	if len(m.Labels) != 2 || m.Labels[0] != "area:payments" || m.EstimatedMinutes == nil || *m.EstimatedMinutes != 90 {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+	if got := m.Epic(); got != "gt-1" {
+		t.Errorf("Epic() = %q, want gt-1", got)
+	}
+	if got := (Metadata{}).Epic(); got != "" {
+		t.Errorf("expected no epic, got %q", got)
+	}
+}
+
+func TestCloseBead(t *testing.T) {
+	s := mustParse(t, `{"id":"gt-1","title":"Fix login","status":"in_progress","comments":[{"text":"started"}],"extra":1}`)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	closed, err := CloseBead(s["gt-1"], "Merged in commit abc123", at)
+	if err != nil {
+		t.Fatalf("CloseBead() error = %v", err)
+	}
+
+	var got struct {
+		Title       string `json:"title"`
+		Status      string `json:"status"`
+		CloseReason string `json:"close_reason"`
+		ClosedAt    string `json:"closed_at"`
+		Extra       int    `json:"extra"`
+		Comments    []struct {
+			Author string `json:"author"`
+			Text   string `json:"text"`
+		} `json:"comments"`
+	}
+	if err := json.Unmarshal(closed.Raw, &got); err != nil {
+		t.Fatalf("invalid bead JSON: %v", err)
+	}
+	if got.Title != "Fix login" || got.Extra != 1 {
+		t.Errorf("expected other fields preserved, got %s", closed.Raw)
+	}
+	if got.Status != StatusClosed || got.CloseReason != "Merged in commit abc123" || got.ClosedAt != "2026-03-01T12:00:00Z" {
+		t.Errorf("expected the bead closed, got %s", closed.Raw)
+	}
+	if len(got.Comments) != 2 || got.Comments[1].Author != CommentAuthor || got.Comments[1].Text != "Merged in commit abc123" {
+		t.Errorf("expected the reason appended as a comment, got %s", closed.Raw)
+	}
+	if closed.Revision() == s["gt-1"].Revision() {
+		t.Error("expected a new revision")
+	}
+}
+
+func TestCommentBead(t *testing.T) {
+	s := mustParse(t, `{"id":"gt-1","title":"Fix login","status":"open"}`)
+	at := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	commented, err := CommentBead(s["gt-1"], "Proposed split", at)
+	if err != nil {
+		t.Fatalf("CommentBead() error = %v", err)
+	}
+	m, err := commented.Metadata()
+	if err != nil || m.Status != StatusOpen || m.Title != "Fix login" {
+		t.Errorf("expected the bead otherwise unchanged, got %s", commented.Raw)
+	}
+	if commented.Revision() == s["gt-1"].Revision() {
+		t.Error("expected a new revision")
+	}
+
+	// The same comment is made once
+	again, err := CommentBead(commented, "Proposed split", at.Add(time.Hour))
+	if err != nil {
+		t.Fatalf("CommentBead() error = %v", err)
+	}
+	if again.Revision() != commented.Revision() {
+		t.Errorf("expected the comment not to be repeated, got %s", again.Raw)
+	}
+}
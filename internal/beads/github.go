@@ -0,0 +1,217 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package beads
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// DefaultGitHubAPIURL is the public GitHub REST API endpoint.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// githubPageSize is the largest page the issues API returns
+const githubPageSize = 100
+
+// GitHubSource imports the issues of a GitHub repository as beads. Issue 42
+// becomes bead <Prefix>42.
+type GitHubSource struct {
+	// APIURL is the API endpoint. Empty means DefaultGitHubAPIURL.
+	APIURL string
+
+	// Token authenticates requests. Optional for public repositories.
+	Token string
+
+	// Owner and Repo name the repository.
+	Owner, Repo string
+
+	// Labels restricts the import to issues carrying all of these labels.
+	Labels []string
+
+	// IncludeClosed also imports closed issues.
+	IncludeClosed bool
+
+	// Prefix is prepended to issue numbers to form bead IDs.
+	Prefix string
+
+	// HTTPClient performs requests. If nil, a client with a 30s timeout is used.
+	HTTPClient *http.Client
+}
+
+type githubIssue struct {
+	Number      int    `json:"number"`
+	Title       string `json:"title"`
+	Body        string `json:"body"`
+	State       string `json:"state"`
+	HTMLURL     string `json:"html_url"`
+	PullRequest *struct {
+		URL string `json:"url"`
+	} `json:"pull_request"`
+	Labels []struct {
+		Name string `json:"name"`
+	} `json:"labels"`
+	Assignees []struct {
+		Login string `json:"login"`
+	} `json:"assignees"`
+}
+
+// List returns the repository's issues, most recently updated first, up to
+// MaxImportedIssues. Pull requests are skipped.
+func (s *GitHubSource) List(ctx context.Context) (Set, error) {
+	query := url.Values{
+		"state":     {"open"},
+		"sort":      {"updated"},
+		"direction": {"desc"},
+		"per_page":  {strconv.Itoa(githubPageSize)},
+	}
+	if s.IncludeClosed {
+		query.Set("state", "all")
+	}
+	if len(s.Labels) > 0 {
+		query.Set("labels", strings.Join(s.Labels, ","))
+	}
+
+	set := Set{}
+	for page := 1; len(set) < MaxImportedIssues; page++ {
+		query.Set("page", strconv.Itoa(page))
+		endpoint := s.repoURL() + "/issues?" + query.Encode()
+
+		var issues []githubIssue
+		if err := getJSON(ctx, s.HTTPClient, endpoint, s.authorize, &issues); err != nil {
+			return nil, fmt.Errorf("failed to list issues of %s/%s: %w", s.Owner, s.Repo, err)
+		}
+		for _, issue := range issues {
+			if issue.PullRequest != nil || len(set) >= MaxImportedIssues {
+				continue
+			}
+			if err := addImported(set, s.toMetadata(issue)); err != nil {
+				return nil, err
+			}
+		}
+		if len(issues) < githubPageSize {
+			break
+		}
+	}
+	return set, nil
+}
+
+// Close comments on the issue behind bead id and closes it as completed.
+func (s *GitHubSource) Close(ctx context.Context, id, comment string) error {
+	number, err := strconv.Atoi(strings.TrimPrefix(id, s.Prefix))
+	if err != nil || !strings.HasPrefix(id, s.Prefix) {
+		return fmt.Errorf("bead %s is not a GitHub issue of %s/%s", id, s.Owner, s.Repo)
+	}
+	issueURL := s.repoURL() + "/issues/" + strconv.Itoa(number)
+
+	if err := sendJSON(ctx, s.HTTPClient, http.MethodPost, issueURL+"/comments", s.authorize,
+		map[string]string{"body": comment}, nil); err != nil {
+		return fmt.Errorf("failed to comment on issue %d of %s/%s: %w", number, s.Owner, s.Repo, err)
+	}
+	if err := sendJSON(ctx, s.HTTPClient, http.MethodPatch, issueURL, s.authorize,
+		map[string]string{"state": "closed", "state_reason": "completed"}, nil); err != nil {
+		return fmt.Errorf("failed to close issue %d of %s/%s: %w", number, s.Owner, s.Repo, err)
+	}
+	return nil
+}
+
+// File creates an issue for m carrying the source's labels, so the next
+// import picks it up.
+func (s *GitHubSource) File(ctx context.Context, m Metadata, _ string) (string, error) {
+	request := map[string]any{"title": m.Title, "body": m.Description}
+	if len(s.Labels) > 0 {
+		request["labels"] = s.Labels
+	}
+	var issue githubIssue
+	if err := sendJSON(ctx, s.HTTPClient, http.MethodPost, s.repoURL()+"/issues", s.authorize,
+		request, &issue); err != nil {
+		return "", fmt.Errorf("failed to file issue in %s/%s: %w", s.Owner, s.Repo, err)
+	}
+	return s.Prefix + strconv.Itoa(issue.Number), nil
+}
+
+// repoURL returns the API URL of the repository.
+func (s *GitHubSource) repoURL() string {
+	apiURL := strings.TrimRight(s.APIURL, "/")
+	if apiURL == "" {
+		apiURL = DefaultGitHubAPIURL
+	}
+	return fmt.Sprintf("%s/repos/%s/%s", apiURL, url.PathEscape(s.Owner), url.PathEscape(s.Repo))
+}
+
+// authorize adds the API version and credentials to a request.
+func (s *GitHubSource) authorize(req *http.Request) {
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if s.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.Token)
+	}
+}
+
+// toMetadata maps an issue to a bead. Priority comes from a "P0".."P4" or
+// "priority/N" label, the type from a bug, feature or enhancement label.
+func (s *GitHubSource) toMetadata(issue githubIssue) Metadata {
+	m := Metadata{
+		ID:          s.Prefix + strconv.Itoa(issue.Number),
+		Title:       issue.Title,
+		Description: issue.Body,
+		Status:      StatusOpen,
+		IssueType:   "task",
+		ExternalRef: issue.HTMLURL,
+	}
+	if issue.State == "closed" {
+		m.Status = StatusClosed
+	} else if len(issue.Assignees) > 0 {
+		m.Status = StatusInProgress
+	}
+	for _, label := range issue.Labels {
+		m.Labels = append(m.Labels, label.Name)
+		name := strings.ToLower(label.Name)
+		if p, ok := labelPriority(name); ok {
+			m.Priority = &p
+		}
+		switch name {
+		case "bug":
+			m.IssueType = "bug"
+		case "feature", "enhancement":
+			m.IssueType = "feature"
+		}
+	}
+	return m
+}
+
+// labelPriority parses "p0".."p4", "priority/N" and "priority:N" labels.
+func labelPriority(label string) (int32, bool) {
+	digit := ""
+	switch {
+	case len(label) == 2 && label[0] == 'p':
+		digit = label[1:]
+	case strings.HasPrefix(label, "priority/"), strings.HasPrefix(label, "priority:"):
+		digit = strings.TrimSpace(label[len("priority/"):])
+		digit = strings.TrimPrefix(digit, "p")
+	default:
+		return 0, false
+	}
+	p, err := strconv.Atoi(digit)
+	if err != nil || p < 0 || p > 4 {
+		return 0, false
+	}
+	return int32(p), true // #nosec G115 -- bounds checked above
+}
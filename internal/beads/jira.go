@@ -0,0 +1,217 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package beads
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// jiraPageSize is the page size requested from the search API
+const jiraPageSize = 100
+
+// JiraSource imports the issues matching a JQL query as beads. Issue PROJ-42
+// becomes bead <Prefix>proj-42.
+type JiraSource struct {
+	// URL is the Jira site (e.g., https://example.atlassian.net)
+	URL string
+
+	// JQL selects the issues to import.
+	JQL string
+
+	// Email and APIToken authenticate to Jira Cloud with basic
+	// authentication. With an empty Email, APIToken is sent as a bearer
+	// token (a Data Center personal access token).
+	Email, APIToken string
+
+	// Prefix is prepended to lowercased issue keys to form bead IDs.
+	Prefix string
+
+	// HTTPClient performs requests. If nil, a client with a 30s timeout is used.
+	HTTPClient *http.Client
+}
+
+type jiraSearchResponse struct {
+	Total  int         `json:"total"`
+	Issues []jiraIssue `json:"issues"`
+}
+
+type jiraIssue struct {
+	Key    string `json:"key"`
+	Fields struct {
+		Summary     string `json:"summary"`
+		Description string `json:"description"`
+		Status      struct {
+			StatusCategory struct {
+				Key string `json:"key"`
+			} `json:"statusCategory"`
+		} `json:"status"`
+		Priority *struct {
+			Name string `json:"name"`
+		} `json:"priority"`
+		IssueType struct {
+			Name string `json:"name"`
+		} `json:"issuetype"`
+	} `json:"fields"`
+}
+
+// jiraPriorities maps Jira's default priority scheme to bead priorities
+var jiraPriorities = map[string]int32{
+	"highest": 0, "blocker": 0,
+	"high": 1, "critical": 1,
+	"medium": 2, "major": 2,
+	"low": 3, "minor": 3,
+	"lowest": 4, "trivial": 4,
+}
+
+// List returns the issues matching the JQL query, up to MaxImportedIssues.
+func (s *JiraSource) List(ctx context.Context) (Set, error) {
+	base := strings.TrimRight(s.URL, "/")
+
+	set := Set{}
+	for startAt := 0; startAt < MaxImportedIssues; startAt += jiraPageSize {
+		query := url.Values{
+			"jql":        {s.JQL},
+			"startAt":    {strconv.Itoa(startAt)},
+			"maxResults": {strconv.Itoa(jiraPageSize)},
+			"fields":     {"summary,description,status,priority,issuetype"},
+		}
+		var page jiraSearchResponse
+		if err := getJSON(ctx, s.HTTPClient, base+"/rest/api/2/search?"+query.Encode(), s.authorize, &page); err != nil {
+			return nil, fmt.Errorf("failed to search Jira issues: %w", err)
+		}
+		for _, issue := range page.Issues {
+			if len(set) >= MaxImportedIssues {
+				break
+			}
+			if err := addImported(set, s.toMetadata(base, issue)); err != nil {
+				return nil, err
+			}
+		}
+		if len(page.Issues) < jiraPageSize || startAt+len(page.Issues) >= page.Total {
+			break
+		}
+	}
+	return set, nil
+}
+
+// Close comments on the issue behind bead id and moves it to a done status,
+// using the first transition the issue's workflow offers into one.
+func (s *JiraSource) Close(ctx context.Context, id, comment string) error {
+	if !strings.HasPrefix(id, s.Prefix) || id == s.Prefix {
+		return fmt.Errorf("bead %s is not a Jira issue", id)
+	}
+	key := strings.ToUpper(strings.TrimPrefix(id, s.Prefix))
+	issueURL := strings.TrimRight(s.URL, "/") + "/rest/api/2/issue/" + url.PathEscape(key)
+
+	var transitions struct {
+		Transitions []struct {
+			ID string `json:"id"`
+			To struct {
+				StatusCategory struct {
+					Key string `json:"key"`
+				} `json:"statusCategory"`
+			} `json:"to"`
+		} `json:"transitions"`
+	}
+	if err := getJSON(ctx, s.HTTPClient, issueURL+"/transitions", s.authorize, &transitions); err != nil {
+		return fmt.Errorf("failed to get transitions of %s: %w", key, err)
+	}
+	transitionID := ""
+	for _, t := range transitions.Transitions {
+		if t.To.StatusCategory.Key == "done" {
+			transitionID = t.ID
+			break
+		}
+	}
+	if transitionID == "" {
+		return fmt.Errorf("issue %s has no transition to a done status", key)
+	}
+
+	if err := sendJSON(ctx, s.HTTPClient, http.MethodPost, issueURL+"/comment", s.authorize,
+		map[string]string{"body": comment}, nil); err != nil {
+		return fmt.Errorf("failed to comment on %s: %w", key, err)
+	}
+	if err := sendJSON(ctx, s.HTTPClient, http.MethodPost, issueURL+"/transitions", s.authorize,
+		map[string]any{"transition": map[string]string{"id": transitionID}}, nil); err != nil {
+		return fmt.Errorf("failed to close %s: %w", key, err)
+	}
+	return nil
+}
+
+// File creates a task for m in the project of the issue behind bead relatedID.
+func (s *JiraSource) File(ctx context.Context, m Metadata, relatedID string) (string, error) {
+	project, _, ok := strings.Cut(strings.ToUpper(strings.TrimPrefix(relatedID, s.Prefix)), "-")
+	if !ok || project == "" || !strings.HasPrefix(relatedID, s.Prefix) {
+		return "", fmt.Errorf("bead %s is not a Jira issue", relatedID)
+	}
+
+	var created struct {
+		Key string `json:"key"`
+	}
+	request := map[string]any{"fields": map[string]any{
+		"project":     map[string]string{"key": project},
+		"summary":     m.Title,
+		"description": m.Description,
+		"issuetype":   map[string]string{"name": "Task"},
+	}}
+	if err := sendJSON(ctx, s.HTTPClient, http.MethodPost, strings.TrimRight(s.URL, "/")+"/rest/api/2/issue",
+		s.authorize, request, &created); err != nil {
+		return "", fmt.Errorf("failed to file issue in %s: %w", project, err)
+	}
+	if created.Key == "" {
+		return "", fmt.Errorf("jira returned no key for the issue filed in %s", project)
+	}
+	return s.Prefix + strings.ToLower(created.Key), nil
+}
+
+// authorize adds credentials to a request.
+func (s *JiraSource) authorize(req *http.Request) {
+	if s.Email != "" {
+		req.SetBasicAuth(s.Email, s.APIToken)
+	} else if s.APIToken != "" {
+		req.Header.Set("Authorization", "Bearer "+s.APIToken)
+	}
+}
+
+// toMetadata maps an issue to a bead.
+func (s *JiraSource) toMetadata(base string, issue jiraIssue) Metadata {
+	m := Metadata{
+		ID:          s.Prefix + strings.ToLower(issue.Key),
+		Title:       issue.Fields.Summary,
+		Description: issue.Fields.Description,
+		Status:      StatusOpen,
+		IssueType:   strings.ToLower(issue.Fields.IssueType.Name),
+		ExternalRef: base + "/browse/" + issue.Key,
+	}
+	switch issue.Fields.Status.StatusCategory.Key {
+	case "done":
+		m.Status = StatusClosed
+	case "indeterminate":
+		m.Status = StatusInProgress
+	}
+	if issue.Fields.Priority != nil {
+		if p, ok := jiraPriorities[strings.ToLower(issue.Fields.Priority.Name)]; ok {
+			m.Priority = &p
+		}
+	}
+	return m
+}
@@ -0,0 +1,172 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package beads
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// CommentAuthor is the author of the comments the operator adds to beads.
+const CommentAuthor = "gastown-operator"
+
+// Bead statuses used by the beads database.
+const (
+	StatusOpen       = "open"
+	StatusInProgress = "in_progress"
+	StatusClosed     = "closed"
+)
+
+// Metadata is what the operator reads from a bead. Other fields of the bead
+// are kept in Raw untouched.
+type Metadata struct {
+	ID          string `json:"id"`
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+	Status      string `json:"status,omitempty"`
+
+	// Priority ranges from 0 (critical) to 4 (backlog); nil when unset
+	Priority *int32 `json:"priority,omitempty"`
+
+	IssueType string `json:"issue_type,omitempty"`
+
+	// ExternalRef links a bead imported from an issue tracker to its issue
+	ExternalRef string `json:"external_ref,omitempty"`
+
+	// Labels group the bead, e.g. by product area ("area:payments") or sprint
+	Labels []string `json:"labels,omitempty"`
+
+	// EstimatedMinutes is the estimated effort; nil when unset
+	EstimatedMinutes *int32 `json:"estimated_minutes,omitempty"`
+
+	// Dependencies link the bead to other beads, including its epic
+	Dependencies []Dependency `json:"dependencies,omitempty"`
+}
+
+// DependencyParentChild links a bead to its parent, e.g. its epic.
+const DependencyParentChild = "parent-child"
+
+// Dependency links a bead to the bead it depends on.
+type Dependency struct {
+	DependsOnID string `json:"depends_on_id"`
+	Type        string `json:"type,omitempty"`
+}
+
+// Epic returns the ID of the bead's parent, or "" if it has none.
+func (m Metadata) Epic() string {
+	for _, d := range m.Dependencies {
+		if d.Type == DependencyParentChild {
+			return d.DependsOnID
+		}
+	}
+	return ""
+}
+
+// Metadata decodes the bead's metadata.
+func (b Bead) Metadata() (Metadata, error) {
+	var m Metadata
+	if err := json.Unmarshal(b.Raw, &m); err != nil {
+		return Metadata{}, fmt.Errorf("bead %s: %w", b.ID, err)
+	}
+	return m, nil
+}
+
+// NewBead encodes metadata as a bead.
+func NewBead(m Metadata) (Bead, error) {
+	raw, err := json.Marshal(m)
+	if err != nil {
+		return Bead{}, fmt.Errorf("bead %s: %w", m.ID, err)
+	}
+	return Bead{ID: m.ID, Raw: raw}, nil
+}
+
+// CloseBead returns b closed at the given time, with reason recorded as the
+// close reason and appended to the bead's comments. Fields this package does
+// not know are preserved.
+func CloseBead(b Bead, reason string, at time.Time) (Bead, error) {
+	timestamp := at.UTC().Format(time.RFC3339)
+	return updateBead(b, reason, at, false, map[string]any{
+		"status":       StatusClosed,
+		"close_reason": reason,
+		"closed_at":    timestamp,
+	})
+}
+
+// CommentBead returns b with text appended to its comments at the given
+// time, unless the operator already made the same comment. Fields this
+// package does not know are preserved.
+func CommentBead(b Bead, text string, at time.Time) (Bead, error) {
+	return updateBead(b, text, at, true, nil)
+}
+
+// updateBead appends an operator comment to b and sets the given fields and
+// updated_at. With once, a comment already on the bead leaves it unchanged.
+func updateBead(b Bead, text string, at time.Time, once bool, set map[string]any) (Bead, error) {
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(b.Raw, &fields); err != nil {
+		return Bead{}, fmt.Errorf("bead %s: %w", b.ID, err)
+	}
+
+	var comments []json.RawMessage
+	if raw, ok := fields["comments"]; ok {
+		if err := json.Unmarshal(raw, &comments); err != nil {
+			return Bead{}, fmt.Errorf("bead %s: invalid comments: %w", b.ID, err)
+		}
+	}
+	if once {
+		for _, raw := range comments {
+			var c struct {
+				Author string `json:"author"`
+				Text   string `json:"text"`
+			}
+			if json.Unmarshal(raw, &c) == nil && c.Author == CommentAuthor && c.Text == text {
+				return b, nil
+			}
+		}
+	}
+	timestamp := at.UTC().Format(time.RFC3339)
+	comment, err := json.Marshal(map[string]string{
+		"issue_id":   b.ID,
+		"author":     CommentAuthor,
+		"text":       text,
+		"created_at": timestamp,
+	})
+	if err != nil {
+		return Bead{}, fmt.Errorf("bead %s: %w", b.ID, err)
+	}
+	comments = append(comments, comment)
+
+	if set == nil {
+		set = map[string]any{}
+	}
+	set["updated_at"] = timestamp
+	set["comments"] = comments
+	for key, value := range set {
+		encoded, err := json.Marshal(value)
+		if err != nil {
+			return Bead{}, fmt.Errorf("bead %s: %w", b.ID, err)
+		}
+		fields[key] = encoded
+	}
+
+	raw, err := json.Marshal(fields)
+	if err != nil {
+		return Bead{}, fmt.Errorf("bead %s: %w", b.ID, err)
+	}
+	return Bead{ID: b.ID, Raw: raw}, nil
+}
@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package beads
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+const (
+	// MaxImportedIssues caps the issues imported from a tracker per sync
+	MaxImportedIssues = 1000
+
+	// MaxDescriptionBytes caps the description of an imported issue, so a
+	// store of imported beads fits in its ConfigMap
+	MaxDescriptionBytes = 8 * 1024
+
+	// truncatedMarker ends descriptions cut to MaxDescriptionBytes
+	truncatedMarker = "\n...(truncated)"
+)
+
+// Source lists the beads of an issue tracker. The tracker stays the source
+// of truth and the beads are replaced on every sync.
+type Source interface {
+	List(ctx context.Context) (Set, error)
+}
+
+// Closer is implemented by sources that can close an issue in the tracker,
+// e.g. once the work on its bead has been merged.
+type Closer interface {
+	// Close comments on the issue behind bead id and closes it.
+	Close(ctx context.Context, id, comment string) error
+}
+
+// Filer is implemented by sources that can file new issues in the tracker,
+// e.g. a follow-up for work that was given up on.
+type Filer interface {
+	// File creates an issue for m, related to the issue behind bead
+	// relatedID, and returns the new issue's bead ID. m.ID is ignored.
+	File(ctx context.Context, m Metadata, relatedID string) (string, error)
+}
+
+// getJSON fetches url and decodes the JSON response into out. authorize adds
+// credentials to the request.
+func getJSON(ctx context.Context, httpClient *http.Client, url string, authorize func(*http.Request), out any) error {
+	return sendJSON(ctx, httpClient, http.MethodGet, url, authorize, nil, out)
+}
+
+// sendJSON sends body as JSON and decodes the JSON response into out. Any
+// 2xx status is a success; out may be nil.
+func sendJSON(
+	ctx context.Context, httpClient *http.Client, method, url string, authorize func(*http.Request), body, out any,
+) error {
+	var reader io.Reader
+	if body != nil {
+		encoded, err := json.Marshal(body)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		reader = bytes.NewReader(encoded)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, url, reader)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if authorize != nil {
+		authorize(req)
+	}
+
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // best-effort close
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 16<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return fmt.Errorf("%s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// truncateDescription cuts text to MaxDescriptionBytes at a rune boundary.
+func truncateDescription(text string) string {
+	if len(text) <= MaxDescriptionBytes {
+		return text
+	}
+	cut := MaxDescriptionBytes - len(truncatedMarker)
+	for cut > 0 && !utf8.RuneStart(text[cut]) {
+		cut--
+	}
+	return text[:cut] + truncatedMarker
+}
+
+// addImported adds an imported issue to set.
+func addImported(set Set, m Metadata) error {
+	m.Description = truncateDescription(m.Description)
+	b, err := NewBead(m)
+	if err != nil {
+		return err
+	}
+	set[b.ID] = b
+	return nil
+}
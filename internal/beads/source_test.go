@@ -0,0 +1,280 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package beads
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"unicode/utf8"
+)
+
+func TestGitHubSourceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/org/repo/issues" {
+			http.NotFound(w, r)
+			return
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer secret" {
+			t.Errorf("unexpected Authorization %q", got)
+		}
+		if got := r.URL.Query().Get("labels"); got != "agent" {
+			t.Errorf("unexpected labels %q", got)
+		}
+		if got := r.URL.Query().Get("state"); got != "open" {
+			t.Errorf("unexpected state %q", got)
+		}
+		_, _ = fmt.Fprint(w, `[
+			{"number": 7, "title": "Fix login", "body": "Users cannot log in.", "state": "open",
+			 "html_url": "https://github.com/org/repo/issues/7",
+			 "labels": [{"name": "bug"}, {"name": "P1"}], "assignees": [{"login": "dev"}]},
+			{"number": 8, "title": "A pull request", "state": "open",
+			 "pull_request": {"url": "https://api.github.com/repos/org/repo/pulls/8"}},
+			{"number": 9, "title": "Dark mode", "state": "open", "labels": [{"name": "priority/3"}]}
+		]`)
+	}))
+	defer server.Close()
+
+	source := &GitHubSource{
+		APIURL: server.URL,
+		Token:  "secret",
+		Owner:  "org",
+		Repo:   "repo",
+		Labels: []string{"agent"},
+		Prefix: "gt-",
+	}
+	set, err := source.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+	if len(set) != 2 {
+		t.Fatalf("expected 2 beads (pull requests skipped), got %v", set.IDs())
+	}
+
+	m, err := set["gt-7"].Metadata()
+	if err != nil {
+		t.Fatalf("Metadata() error = %v", err)
+	}
+	if m.Title != "Fix login" || m.Description != "Users cannot log in." || m.Status != StatusInProgress ||
+		m.IssueType != "bug" || m.Priority == nil || *m.Priority != 1 ||
+		m.ExternalRef != "https://github.com/org/repo/issues/7" {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+	if len(m.Labels) != 2 || m.Labels[0] != "bug" || m.Labels[1] != "P1" {
+		t.Errorf("expected the issue labels, got %v", m.Labels)
+	}
+
+	m, _ = set["gt-9"].Metadata()
+	if m.Status != StatusOpen || m.IssueType != "task" || m.Priority == nil || *m.Priority != 3 {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+}
+
+func TestGitHubSourceListError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, `{"message":"Bad credentials"}`, http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	source := &GitHubSource{APIURL: server.URL, Owner: "org", Repo: "repo"}
+	if _, err := source.List(context.Background()); err == nil || !strings.Contains(err.Error(), "Bad credentials") {
+		t.Errorf("expected the API error, got %v", err)
+	}
+}
+
+func TestJiraSourceList(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/rest/api/2/search" {
+			http.NotFound(w, r)
+			return
+		}
+		if user, pass, ok := r.BasicAuth(); !ok || user != "bot@example.com" || pass != "token" {
+			t.Errorf("unexpected credentials %q/%q", user, pass)
+		}
+		if got := r.URL.Query().Get("jql"); got != "project = OPS" {
+			t.Errorf("unexpected jql %q", got)
+		}
+		_, _ = fmt.Fprint(w, `{"total": 2, "issues": [
+			{"key": "OPS-12", "fields": {"summary": "Rotate keys", "description": "Quarterly rotation.",
+			 "status": {"statusCategory": {"key": "indeterminate"}},
+			 "priority": {"name": "High"}, "issuetype": {"name": "Task"}}},
+			{"key": "OPS-13", "fields": {"summary": "Old outage",
+			 "status": {"statusCategory": {"key": "done"}}, "issuetype": {"name": "Bug"}}}
+		]}`)
+	}))
+	defer server.Close()
+
+	source := &JiraSource{
+		URL:      server.URL + "/",
+		JQL:      "project = OPS",
+		Email:    "bot@example.com",
+		APIToken: "token",
+		Prefix:   "ops-",
+	}
+	set, err := source.List(context.Background())
+	if err != nil {
+		t.Fatalf("List() error = %v", err)
+	}
+
+	m, err := set["ops-ops-12"].Metadata()
+	if err != nil {
+		t.Fatalf("Metadata() error = %v", err)
+	}
+	if m.Title != "Rotate keys" || m.Status != StatusInProgress || m.IssueType != "task" ||
+		m.Priority == nil || *m.Priority != 1 || m.ExternalRef != server.URL+"/browse/OPS-12" {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+
+	m, _ = set["ops-ops-13"].Metadata()
+	if m.Status != StatusClosed || m.Priority != nil || m.IssueType != "bug" {
+		t.Errorf("unexpected metadata %+v", m)
+	}
+}
+
+func TestGitHubSourceClose(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(body))
+		if r.Method == http.MethodPost {
+			w.WriteHeader(http.StatusCreated)
+		}
+		_, _ = fmt.Fprint(w, `{}`)
+	}))
+	defer server.Close()
+
+	source := &GitHubSource{APIURL: server.URL, Owner: "org", Repo: "repo", Prefix: "gh-"}
+	if err := source.Close(context.Background(), "gh-7", "Merged in commit abc123"); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	want := []string{
+		`POST /repos/org/repo/issues/7/comments {"body":"Merged in commit abc123"}`,
+		`PATCH /repos/org/repo/issues/7 {"state":"closed","state_reason":"completed"}`,
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests:\n%s", strings.Join(calls, "\n"))
+	}
+
+	if err := source.Close(context.Background(), "gt-7", "comment"); err == nil {
+		t.Error("expected an error for a bead of another prefix")
+	}
+}
+
+func TestJiraSourceClose(t *testing.T) {
+	var calls []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+string(body))
+		switch {
+		case r.Method == http.MethodGet:
+			_, _ = fmt.Fprint(w, `{"transitions": [
+				{"id": "21", "to": {"statusCategory": {"key": "indeterminate"}}},
+				{"id": "31", "to": {"statusCategory": {"key": "done"}}}
+			]}`)
+		case strings.HasSuffix(r.URL.Path, "/comment"):
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprint(w, `{}`)
+		default:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	source := &JiraSource{URL: server.URL, APIToken: "pat", Prefix: "ops-"}
+	if err := source.Close(context.Background(), "ops-ops-12", "Merged in commit abc123"); err != nil {
+		t.Fatalf("Close() error = %v", err)
+	}
+	want := []string{
+		`GET /rest/api/2/issue/OPS-12/transitions `,
+		`POST /rest/api/2/issue/OPS-12/comment {"body":"Merged in commit abc123"}`,
+		`POST /rest/api/2/issue/OPS-12/transitions {"transition":{"id":"31"}}`,
+	}
+	if strings.Join(calls, "\n") != strings.Join(want, "\n") {
+		t.Errorf("unexpected requests:\n%s", strings.Join(calls, "\n"))
+	}
+}
+
+func TestGitHubSourceFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Path != "/repos/org/repo/issues" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		if got := string(body); got != `{"body":"Context","labels":["agent"],"title":"Follow up"}` {
+			t.Errorf("unexpected body %s", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"number": 12}`)
+	}))
+	defer server.Close()
+
+	source := &GitHubSource{APIURL: server.URL, Owner: "org", Repo: "repo", Labels: []string{"agent"}, Prefix: "gh-"}
+	id, err := source.File(context.Background(), Metadata{Title: "Follow up", Description: "Context"}, "gh-7")
+	if err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	if id != "gh-12" {
+		t.Errorf("File() = %q, want gh-12", id)
+	}
+}
+
+func TestJiraSourceFile(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		if r.Method != http.MethodPost || r.URL.Path != "/rest/api/2/issue" {
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+		}
+		want := `{"fields":{"description":"Context","issuetype":{"name":"Task"},"project":{"key":"OPS"},"summary":"Follow up"}}`
+		if got := string(body); got != want {
+			t.Errorf("unexpected body %s", got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprint(w, `{"key": "OPS-20"}`)
+	}))
+	defer server.Close()
+
+	source := &JiraSource{URL: server.URL, Prefix: "ops-"}
+	id, err := source.File(context.Background(), Metadata{Title: "Follow up", Description: "Context"}, "ops-ops-12")
+	if err != nil {
+		t.Fatalf("File() error = %v", err)
+	}
+	if id != "ops-ops-20" {
+		t.Errorf("File() = %q, want ops-ops-20", id)
+	}
+
+	if _, err := source.File(context.Background(), Metadata{Title: "Follow up"}, "gt-1"); err == nil {
+		t.Error("expected an error for a bead of another prefix")
+	}
+}
+
+func TestTruncateDescription(t *testing.T) {
+	long := strings.Repeat("é", MaxDescriptionBytes)
+	got := truncateDescription(long)
+	if len(got) > MaxDescriptionBytes || !strings.HasSuffix(got, truncatedMarker) {
+		t.Errorf("expected a truncated description of at most %d bytes, got %d", MaxDescriptionBytes, len(got))
+	}
+	if !utf8.ValidString(got) {
+		t.Error("expected truncation at a rune boundary")
+	}
+	if short := "short"; truncateDescription(short) != short {
+		t.Error("expected short descriptions untouched")
+	}
+}
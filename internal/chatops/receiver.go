@@ -0,0 +1,282 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package chatops serves a Slack endpoint for dispatching work from chat:
+// `/gt sling ap-123 my-rig` (slash command) or `@gastown sling ap-123 my-rig`
+// (Events API mention) creates the Polecat, as kubectl gt sling does, and
+// reports its progress back to the channel.
+package chatops
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/org/gastown-operator/internal/audit"
+)
+
+const (
+	// Path is where Slack delivers slash commands and events
+	Path = "/hooks/slack"
+
+	// EnvSigningSecret holds the Slack app's signing secret
+	EnvSigningSecret = "GASTOWN_SLACK_SIGNING_SECRET"
+
+	// EnvBotToken holds the bot token used to answer mentions. Without it
+	// only slash commands are served.
+	EnvBotToken = "GASTOWN_SLACK_BOT_TOKEN"
+
+	// EnvAllowedChannels is a comma-separated list of the channel IDs
+	// commands are accepted from; all channels when unset
+	EnvAllowedChannels = "GASTOWN_SLACK_CHANNELS"
+
+	// DefaultSlackAPIURL is the Slack Web API base URL
+	DefaultSlackAPIURL = "https://slack.com/api"
+
+	// maxPayloadBytes bounds request bodies
+	maxPayloadBytes = 1 << 20
+)
+
+// Receiver serves the Slack endpoint. It implements manager.Runnable.
+type Receiver struct {
+	// Client reads Rigs and creates and follows Polecats
+	Client client.Client
+
+	// SigningSecret verifies Slack requests
+	SigningSecret []byte
+
+	// BotToken answers mentions in their thread
+	BotToken string
+
+	// AllowedChannels restricts the channels commands are accepted from
+	AllowedChannels []string
+
+	// BindAddress is the address the HTTP server listens on
+	BindAddress string
+
+	// SlackAPIURL is the Slack Web API base URL
+	SlackAPIURL string
+
+	// HTTPClient posts replies to Slack
+	HTTPClient *http.Client
+
+	// ProgressInterval is how often the progress of a slung polecat is checked
+	ProgressInterval time.Duration
+
+	// Audit records the polecats slung from Slack. Optional.
+	Audit *audit.Log
+
+	// ctx is cancelled when the receiver stops, ending progress reports
+	ctx context.Context
+}
+
+// NewReceiver creates a Receiver.
+func NewReceiver(c client.Client, signingSecret []byte, bindAddress string) *Receiver {
+	return &Receiver{
+		Client:           c,
+		SigningSecret:    signingSecret,
+		BindAddress:      bindAddress,
+		SlackAPIURL:      DefaultSlackAPIURL,
+		HTTPClient:       &http.Client{Timeout: 10 * time.Second},
+		ProgressInterval: defaultProgressInterval,
+		ctx:              context.Background(),
+	}
+}
+
+// NeedLeaderElection serves commands only on the leader, so that progress
+// is reported once.
+func (r *Receiver) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the HTTP server until ctx is cancelled.
+func (r *Receiver) Start(ctx context.Context) error {
+	r.ctx = ctx
+	mux := http.NewServeMux()
+	mux.Handle(Path, r)
+	server := &http.Server{
+		Addr:              r.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logf.FromContext(ctx).Info("Starting Slack receiver", "address", r.BindAddress, "path", Path)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// ServeHTTP verifies a Slack request and runs the command it carries.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	if err := verifyRequest(req.Header, body, r.SigningSecret, time.Now()); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+
+	if strings.HasPrefix(req.Header.Get("Content-Type"), "application/x-www-form-urlencoded") {
+		r.serveSlashCommand(req.Context(), w, body)
+		return
+	}
+	r.serveEvent(w, req, body)
+}
+
+// serveSlashCommand runs a slash command and answers with its result.
+// Progress is reported through the command's response_url.
+func (r *Receiver) serveSlashCommand(ctx context.Context, w http.ResponseWriter, body []byte) {
+	form, err := url.ParseQuery(string(body))
+	if err != nil {
+		http.Error(w, "invalid slash command", http.StatusBadRequest)
+		return
+	}
+	reply := &responseURLReplier{httpClient: r.HTTPClient, url: form.Get("response_url")}
+	text := r.run(ctx, form.Get("text"), form.Get("user_name"), form.Get("channel_id"), reply)
+
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]string{"response_type": "in_channel", "text": text})
+}
+
+// serveEvent answers the Events API handshake and runs the commands of
+// app mentions. Slack expects an answer within 3 seconds, so commands run
+// after the event is acknowledged and reply in the mention's thread.
+func (r *Receiver) serveEvent(w http.ResponseWriter, req *http.Request, body []byte) {
+	log := logf.FromContext(req.Context()).WithName("chatops")
+
+	var envelope eventEnvelope
+	if err := json.Unmarshal(body, &envelope); err != nil {
+		http.Error(w, "invalid event", http.StatusBadRequest)
+		return
+	}
+	if envelope.Type == "url_verification" {
+		w.Header().Set("Content-Type", "text/plain")
+		_, _ = io.WriteString(w, envelope.Challenge)
+		return
+	}
+
+	evt := envelope.Event
+	w.WriteHeader(http.StatusOK)
+	// Retries of an event already acknowledged would sling twice
+	if req.Header.Get("X-Slack-Retry-Num") != "" {
+		return
+	}
+	if envelope.Type != "event_callback" || evt.Type != "app_mention" || evt.BotID != "" {
+		return
+	}
+	if r.BotToken == "" {
+		log.Info("Ignoring Slack mention, no bot token configured", "env", EnvBotToken)
+		return
+	}
+
+	threadTS := evt.ThreadTS
+	if threadTS == "" {
+		threadTS = evt.TS
+	}
+	reply := &threadReplier{
+		httpClient: r.HTTPClient,
+		apiURL:     r.SlackAPIURL,
+		token:      r.BotToken,
+		channel:    evt.Channel,
+		threadTS:   threadTS,
+	}
+	go func() {
+		text := r.run(r.ctx, evt.Text, evt.User, evt.Channel, reply)
+		if err := reply.reply(r.ctx, text); err != nil {
+			log.Error(err, "Failed to answer Slack mention", "channel", evt.Channel)
+		}
+	}()
+}
+
+// run executes a command and returns the answer. Slung polecats are
+// followed and their progress posted with reply.
+func (r *Receiver) run(ctx context.Context, text, user, channel string, reply replier) string {
+	log := logf.FromContext(ctx).WithName("chatops")
+
+	if len(r.AllowedChannels) > 0 && !slices.Contains(r.AllowedChannels, channel) {
+		return "Gastown commands are not enabled in this channel."
+	}
+
+	args := commandArgs(text)
+	if len(args) == 0 || args[0] == "help" {
+		return usage
+	}
+	switch args[0] {
+	case "sling":
+		if len(args) != 3 {
+			return "Usage: `sling <bead-id> <rig>`"
+		}
+		polecat, err := r.sling(ctx, args[1], args[2], user)
+		var userErr userError
+		if errors.As(err, &userErr) {
+			return userErr.Error()
+		}
+		if err != nil {
+			log.Error(err, "Failed to sling from Slack", "bead", args[1], "rig", args[2], "user", user)
+			return fmt.Sprintf("Failed to sling %s to %s, see the operator logs.", args[1], args[2])
+		}
+		log.Info("Slung from Slack", "polecat", polecat.Name, "namespace", polecat.Namespace,
+			"bead", args[1], "rig", args[2], "user", user)
+		go r.follow(r.ctx, client.ObjectKeyFromObject(polecat), reply)
+		return fmt.Sprintf("Polecat `%s` dispatched to rig `%s` for bead %s.", polecat.Name, args[2], args[1])
+	default:
+		return fmt.Sprintf("Unknown command `%s`.\n%s", args[0], usage)
+	}
+}
+
+// usage lists the commands
+const usage = "Commands:\n" +
+	"• `sling <bead-id> <rig>` dispatches the bead to a new polecat in the rig\n" +
+	"• `help` shows this message"
+
+// commandArgs splits a command into its words, dropping the user mentions
+// (<@U123>) that address the bot.
+func commandArgs(text string) []string {
+	var args []string
+	for _, word := range strings.Fields(text) {
+		if strings.HasPrefix(word, "<@") && strings.HasSuffix(word, ">") {
+			continue
+		}
+		args = append(args, word)
+	}
+	return args
+}
@@ -0,0 +1,297 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chatops
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+const testSecret = "s3cret"
+
+func newTestReceiver(t *testing.T) *Receiver {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, gastownv1alpha1.AddToScheme(scheme))
+
+	rig := &gastownv1alpha1.Rig{
+		ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+		Spec:       gastownv1alpha1.RigSpec{GitURL: "git@github.com:acme/widgets.git"},
+		Status:     gastownv1alpha1.RigStatus{ChildNamespace: "gastown"},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig).
+		WithStatusSubresource(&gastownv1alpha1.Polecat{}).Build()
+	r := NewReceiver(c, []byte(testSecret), ":0")
+	r.ProgressInterval = 10 * time.Millisecond
+	return r
+}
+
+// signedRequest builds a Slack request signed with testSecret at ts.
+func signedRequest(t *testing.T, contentType, body string, ts time.Time) *http.Request {
+	t.Helper()
+	timestamp := strconv.FormatInt(ts.Unix(), 10)
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte("v0:" + timestamp + ":" + body))
+
+	req := httptest.NewRequest(http.MethodPost, Path, strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Slack-Request-Timestamp", timestamp)
+	req.Header.Set("X-Slack-Signature", "v0="+hex.EncodeToString(mac.Sum(nil)))
+	return req
+}
+
+func slashCommand(t *testing.T, text, channel, responseURL string) *http.Request {
+	t.Helper()
+	form := url.Values{
+		"command":      {"/gt"},
+		"text":         {text},
+		"user_name":    {"alice"},
+		"channel_id":   {channel},
+		"response_url": {responseURL},
+	}
+	return signedRequest(t, "application/x-www-form-urlencoded", form.Encode(), time.Now())
+}
+
+// slackServer records the messages posted to it.
+type slackServer struct {
+	*httptest.Server
+	mu       sync.Mutex
+	messages []string
+}
+
+func newSlackServer(t *testing.T) *slackServer {
+	t.Helper()
+	s := &slackServer{}
+	s.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var payload struct {
+			Text string `json:"text"`
+		}
+		_ = json.NewDecoder(req.Body).Decode(&payload)
+		s.mu.Lock()
+		s.messages = append(s.messages, payload.Text)
+		s.mu.Unlock()
+		_, _ = w.Write([]byte(`{"ok":true}`))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func (s *slackServer) Messages() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.messages...)
+}
+
+func answer(t *testing.T, w *httptest.ResponseRecorder) string {
+	t.Helper()
+	require.Equal(t, http.StatusOK, w.Code)
+	var resp struct {
+		Text string `json:"text"`
+	}
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &resp))
+	return resp.Text
+}
+
+func TestVerifyRequest(t *testing.T) {
+	now := time.Now()
+	body := "text=help"
+
+	req := signedRequest(t, "application/x-www-form-urlencoded", body, now)
+	assert.NoError(t, verifyRequest(req.Header, []byte(body), []byte(testSecret), now))
+	assert.ErrorIs(t, verifyRequest(req.Header, []byte(body+"&x=1"), []byte(testSecret), now), errUnauthorized)
+	assert.ErrorIs(t, verifyRequest(req.Header, []byte(body), []byte("other"), now), errUnauthorized)
+
+	// A captured request cannot be replayed later
+	assert.ErrorIs(t, verifyRequest(req.Header, []byte(body), []byte(testSecret), now.Add(10*time.Minute)),
+		errUnauthorized)
+
+	req.Header.Del("X-Slack-Signature")
+	assert.ErrorIs(t, verifyRequest(req.Header, []byte(body), []byte(testSecret), now), errUnauthorized)
+}
+
+func TestServeHTTP_RejectsUnsignedRequests(t *testing.T) {
+	r := newTestReceiver(t)
+	req := slashCommand(t, "sling ap-123 widgets", "C1", "")
+	req.Header.Set("X-Slack-Signature", "v0=00")
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+
+	var polecats gastownv1alpha1.PolecatList
+	require.NoError(t, r.Client.List(context.Background(), &polecats))
+	assert.Empty(t, polecats.Items)
+}
+
+func TestServeHTTP_SlashCommandSlings(t *testing.T) {
+	r := newTestReceiver(t)
+	slack := newSlackServer(t)
+	ctx := context.Background()
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, slashCommand(t, "sling ap-123 widgets", "C1", slack.URL))
+	assert.Contains(t, answer(t, w), "dispatched to rig `widgets` for bead ap-123")
+
+	var polecats gastownv1alpha1.PolecatList
+	require.NoError(t, r.Client.List(ctx, &polecats))
+	require.Len(t, polecats.Items, 1)
+	polecat := polecats.Items[0]
+	assert.Equal(t, "gastown", polecat.Namespace)
+	assert.True(t, strings.HasPrefix(polecat.Name, "widgets-"))
+	assert.Equal(t, "widgets", polecat.Spec.Rig)
+	assert.Equal(t, "ap-123", polecat.Spec.BeadID)
+	assert.Equal(t, gastownv1alpha1.PolecatDesiredWorking, polecat.Spec.DesiredState)
+	assert.Equal(t, "git@github.com:acme/widgets.git", polecat.Spec.Kubernetes.GitRepository)
+	assert.Equal(t, "widgets", polecat.Labels["gastown.io/rig"])
+	assert.Equal(t, "slack:alice", polecat.Annotations[RequestedByAnnotation])
+
+	// Progress is posted to the response URL
+	polecat.Status.Phase = gastownv1alpha1.PolecatPhaseWorking
+	polecat.Status.PodName = "polecat-pod"
+	require.NoError(t, r.Client.Status().Update(ctx, &polecat))
+	assert.Eventually(t, func() bool {
+		msgs := slack.Messages()
+		return len(msgs) == 1 && strings.Contains(msgs[0], "is working on ap-123 in pod `polecat-pod`")
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestServeHTTP_SlashCommandErrors(t *testing.T) {
+	r := newTestReceiver(t)
+	r.AllowedChannels = []string{"C1"}
+
+	tests := []struct {
+		name    string
+		text    string
+		channel string
+		want    string
+	}{
+		{"unknown rig", "sling ap-123 gadgets", "C1", "Rig `gadgets` not found."},
+		{"missing arguments", "sling ap-123", "C1", "Usage: `sling <bead-id> <rig>`"},
+		{"unknown command", "nuke widgets", "C1", "Unknown command `nuke`"},
+		{"help", "", "C1", "Commands:"},
+		{"channel not allowed", "sling ap-123 widgets", "C2", "not enabled in this channel"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			w := httptest.NewRecorder()
+			r.ServeHTTP(w, slashCommand(t, tt.text, tt.channel, ""))
+			assert.Contains(t, answer(t, w), tt.want)
+		})
+	}
+
+	var polecats gastownv1alpha1.PolecatList
+	require.NoError(t, r.Client.List(context.Background(), &polecats))
+	assert.Empty(t, polecats.Items)
+}
+
+func TestServeHTTP_Events(t *testing.T) {
+	r := newTestReceiver(t)
+	slack := newSlackServer(t)
+	r.SlackAPIURL = slack.URL
+	r.BotToken = "xoxb-test"
+
+	t.Run("url verification", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, signedRequest(t, "application/json",
+			`{"type":"url_verification","challenge":"abc"}`, time.Now()))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Equal(t, "abc", w.Body.String())
+	})
+
+	t.Run("mention slings and answers in the thread", func(t *testing.T) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, signedRequest(t, "application/json", `{"type":"event_callback","event":{
+			"type":"app_mention","user":"U1","text":"<@UBOT> sling ap-7 widgets","channel":"C1","ts":"1.2"}}`,
+			time.Now()))
+		assert.Equal(t, http.StatusOK, w.Code)
+		assert.Eventually(t, func() bool {
+			msgs := slack.Messages()
+			return len(msgs) > 0 && strings.Contains(msgs[0], "for bead ap-7")
+		}, 5*time.Second, 10*time.Millisecond)
+	})
+
+	t.Run("retries are ignored", func(t *testing.T) {
+		req := signedRequest(t, "application/json", `{"type":"event_callback","event":{
+			"type":"app_mention","user":"U1","text":"<@UBOT> sling ap-8 widgets","channel":"C1","ts":"1.3"}}`,
+			time.Now())
+		req.Header.Set("X-Slack-Retry-Num", "1")
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, req)
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var polecats gastownv1alpha1.PolecatList
+		require.NoError(t, r.Client.List(context.Background(), &polecats,
+			client.MatchingLabels{"gastown.io/bead": "ap-8"}))
+		assert.Empty(t, polecats.Items)
+	})
+}
+
+func TestCommandArgs(t *testing.T) {
+	assert.Equal(t, []string{"sling", "ap-1", "widgets"}, commandArgs("<@U123>  sling ap-1 widgets "))
+	assert.Empty(t, commandArgs("<@U123>"))
+}
+
+func TestProgressMessage(t *testing.T) {
+	newPolecat := func(phase gastownv1alpha1.PolecatPhase) *gastownv1alpha1.Polecat {
+		return &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa"},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: "widgets", BeadID: "ap-123"},
+			Status:     gastownv1alpha1.PolecatStatus{Phase: phase, Branch: "feature/ap-123"},
+		}
+	}
+
+	msg, final := progressMessage(newPolecat(gastownv1alpha1.PolecatPhaseWorking))
+	assert.Empty(t, msg, "no progress before the pod is scheduled")
+	assert.False(t, final)
+
+	msg, final = progressMessage(newPolecat(gastownv1alpha1.PolecatPhaseDone))
+	assert.Equal(t, "Polecat `furiosa` finished ap-123 on branch `feature/ap-123`, waiting to merge.", msg)
+	assert.False(t, final)
+
+	merged := newPolecat(gastownv1alpha1.PolecatPhaseDone)
+	merged.Status.MergedCommit = "0123456789abcdef"
+	merged.Status.Conditions = []metav1.Condition{{Type: conditionMerged, Status: metav1.ConditionTrue}}
+	msg, final = progressMessage(merged)
+	assert.Equal(t, "Polecat `furiosa` merged ap-123 as `0123456`.", msg)
+	assert.True(t, final)
+
+	msg, final = progressMessage(newPolecat(gastownv1alpha1.PolecatPhaseStuck))
+	assert.Equal(t, fmt.Sprintf("Polecat `furiosa` is stuck on ap-123. Check `%s`.",
+		"kubectl gt polecat logs widgets/furiosa"), msg)
+	assert.True(t, final)
+}
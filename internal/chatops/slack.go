@@ -0,0 +1,154 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chatops
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// maxRequestAge bounds the clock skew of a Slack request timestamp, so a
+// captured request cannot be replayed later.
+const maxRequestAge = 5 * time.Minute
+
+// errUnauthorized means the request signature did not match.
+var errUnauthorized = errors.New("slack signature verification failed")
+
+// verifyRequest checks the Slack request signature: v0=HMAC-SHA256 of
+// "v0:<timestamp>:<body>" keyed with the app's signing secret.
+func verifyRequest(header http.Header, body, secret []byte, now time.Time) error {
+	ts, err := strconv.ParseInt(header.Get("X-Slack-Request-Timestamp"), 10, 64)
+	if err != nil {
+		return errUnauthorized
+	}
+	if age := now.Sub(time.Unix(ts, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return errUnauthorized
+	}
+	signature, ok := strings.CutPrefix(header.Get("X-Slack-Signature"), "v0=")
+	if !ok {
+		return errUnauthorized
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return errUnauthorized
+	}
+	mac := hmac.New(sha256.New, secret)
+	_, _ = fmt.Fprintf(mac, "v0:%d:", ts)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return errUnauthorized
+	}
+	return nil
+}
+
+// eventEnvelope is an Events API request.
+type eventEnvelope struct {
+	// Type is url_verification for the endpoint handshake, event_callback
+	// for events
+	Type      string `json:"type"`
+	Challenge string `json:"challenge"`
+	Event     struct {
+		Type     string `json:"type"`
+		User     string `json:"user"`
+		BotID    string `json:"bot_id"`
+		Text     string `json:"text"`
+		Channel  string `json:"channel"`
+		TS       string `json:"ts"`
+		ThreadTS string `json:"thread_ts"`
+	} `json:"event"`
+}
+
+// replier posts messages back to where a command came from.
+type replier interface {
+	reply(ctx context.Context, text string) error
+}
+
+// responseURLReplier answers a slash command through its response_url,
+// which Slack accepts for 30 minutes.
+type responseURLReplier struct {
+	httpClient *http.Client
+	url        string
+}
+
+func (r *responseURLReplier) reply(ctx context.Context, text string) error {
+	return postJSON(ctx, r.httpClient, r.url, "", map[string]any{
+		"response_type":    "in_channel",
+		"replace_original": false,
+		"text":             text,
+	})
+}
+
+// threadReplier answers a mention in its thread with chat.postMessage.
+type threadReplier struct {
+	httpClient *http.Client
+	apiURL     string
+	token      string
+	channel    string
+	threadTS   string
+}
+
+func (r *threadReplier) reply(ctx context.Context, text string) error {
+	return postJSON(ctx, r.httpClient, r.apiURL+"/chat.postMessage", r.token, map[string]any{
+		"channel":   r.channel,
+		"thread_ts": r.threadTS,
+		"text":      text,
+	})
+}
+
+// postJSON posts payload to url and checks the Slack response.
+func postJSON(ctx context.Context, httpClient *http.Client, url, token string, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("slack returned %s", resp.Status)
+	}
+
+	// The Web API reports errors in the body of a 200 response
+	var result struct {
+		OK    *bool  `json:"ok"`
+		Error string `json:"error"`
+	}
+	if json.NewDecoder(resp.Body).Decode(&result) == nil && result.OK != nil && !*result.OK {
+		return fmt.Errorf("slack API error: %s", result.Error)
+	}
+	return nil
+}
@@ -0,0 +1,188 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package chatops
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+)
+
+const (
+	// RequestedByAnnotation records the Slack user who slung a polecat
+	RequestedByAnnotation = "gastown.io/requested-by"
+
+	// gitSecretName and claudeCredsSecretName are the credentials of slung
+	// polecats, the kubectl gt sling defaults
+	gitSecretName         = "git-creds"
+	claudeCredsSecretName = "claude-creds"
+
+	// conditionMerged is set on a polecat once the Refinery merged its branch
+	conditionMerged = "Merged"
+
+	// defaultProgressInterval is how often a slung polecat is checked
+	defaultProgressInterval = 15 * time.Second
+
+	// progressTimeout is how long a slung polecat is followed; Slack
+	// accepts replies to a response_url for 30 minutes
+	progressTimeout = 30 * time.Minute
+
+	// maxProgressReplies is how many progress replies a response_url accepts
+	maxProgressReplies = 5
+)
+
+// userError is an error whose message is meant for the Slack user.
+type userError string
+
+func (e userError) Error() string { return string(e) }
+
+// sling creates a Polecat working on beadID in the rig, like kubectl gt sling.
+func (r *Receiver) sling(ctx context.Context, beadID, rigName, user string) (*gastownv1alpha1.Polecat, error) {
+	var rig gastownv1alpha1.Rig
+	if err := r.Client.Get(ctx, types.NamespacedName{Name: rigName}, &rig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, userError(fmt.Sprintf("Rig `%s` not found.", rigName))
+		}
+		return nil, fmt.Errorf("failed to get rig %s: %w", rigName, err)
+	}
+	namespace := rig.Status.ChildNamespace
+	if namespace == "" {
+		namespace = rig.Spec.ChildNamespace
+	}
+	if namespace == "" {
+		return nil, userError(fmt.Sprintf("Rig `%s` is not ready yet.", rigName))
+	}
+
+	polecat := &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: rigName + "-",
+			Namespace:    namespace,
+			Labels: map[string]string{
+				"gastown.io/rig":  rigName,
+				"gastown.io/bead": beadID,
+			},
+			Annotations: map[string]string{RequestedByAnnotation: "slack:" + user},
+		},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:           rigName,
+			BeadID:        beadID,
+			DesiredState:  gastownv1alpha1.PolecatDesiredWorking,
+			ExecutionMode: gastownv1alpha1.ExecutionModeKubernetes,
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository:        rig.Spec.GitURL,
+				GitSecretRef:         gastownv1alpha1.SecretReference{Name: gitSecretName},
+				ClaudeCredsSecretRef: &gastownv1alpha1.SecretReference{Name: claudeCredsSecretName},
+			},
+		},
+	}
+	err := r.Client.Create(ctx, polecat)
+	r.Audit.Record("chatops", audit.ActionSling, polecat, map[string]string{"bead": beadID, "rig": rigName, "user": user}, err)
+	if err != nil {
+		if apierrors.IsInvalid(err) || apierrors.IsForbidden(err) {
+			// Admission webhooks explain rejections, e.g. a suspended rig
+			return nil, userError(fmt.Sprintf("Polecat rejected: %s", err.Error()))
+		}
+		return nil, fmt.Errorf("failed to create polecat: %w", err)
+	}
+	return polecat, nil
+}
+
+// follow posts the progress of a slung polecat with reply until it merges,
+// gets stuck or is terminated, or progressTimeout passes.
+func (r *Receiver) follow(ctx context.Context, key types.NamespacedName, reply replier) {
+	log := logf.FromContext(ctx).WithName("chatops").WithValues("polecat", key.Name, "namespace", key.Namespace)
+	ctx, cancel := context.WithTimeout(ctx, progressTimeout)
+	defer cancel()
+
+	ticker := time.NewTicker(r.ProgressInterval)
+	defer ticker.Stop()
+	var last string
+	for replies := 0; replies < maxProgressReplies; {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+
+		var polecat gastownv1alpha1.Polecat
+		if err := r.Client.Get(ctx, key, &polecat); err != nil {
+			if apierrors.IsNotFound(err) {
+				_ = reply.reply(ctx, fmt.Sprintf("Polecat `%s` was deleted.", key.Name))
+				return
+			}
+			log.V(1).Info("Failed to get slung polecat", "error", err.Error())
+			continue
+		}
+		message, final := progressMessage(&polecat)
+		if message != "" && message != last {
+			if err := reply.reply(ctx, message); err != nil {
+				log.Error(err, "Failed to post polecat progress to Slack")
+			}
+			last = message
+			replies++
+		}
+		if final {
+			return
+		}
+	}
+}
+
+// progressMessage describes how far a polecat has come, and whether it has
+// come as far as it will.
+func progressMessage(polecat *gastownv1alpha1.Polecat) (string, bool) {
+	name, bead := polecat.Name, polecat.Spec.BeadID
+	if meta.IsStatusConditionTrue(polecat.Status.Conditions, conditionMerged) {
+		if polecat.Status.PullRequestURL != "" {
+			return fmt.Sprintf("Polecat `%s` merged %s: %s", name, bead, polecat.Status.PullRequestURL), true
+		}
+		return fmt.Sprintf("Polecat `%s` merged %s as `%s`.", name, bead, shortCommit(polecat.Status.MergedCommit)), true
+	}
+
+	switch polecat.Status.Phase {
+	case gastownv1alpha1.PolecatPhaseWorking:
+		if polecat.Status.PodName == "" {
+			return "", false
+		}
+		return fmt.Sprintf("Polecat `%s` is working on %s in pod `%s`.", name, bead, polecat.Status.PodName), false
+	case gastownv1alpha1.PolecatPhaseDone:
+		return fmt.Sprintf("Polecat `%s` finished %s on branch `%s`, waiting to merge.",
+			name, bead, polecat.Status.Branch), false
+	case gastownv1alpha1.PolecatPhaseStuck:
+		return fmt.Sprintf("Polecat `%s` is stuck on %s. Check `kubectl gt polecat logs %s/%s`.",
+			name, bead, polecat.Spec.Rig, name), true
+	case gastownv1alpha1.PolecatPhaseTerminated:
+		return fmt.Sprintf("Polecat `%s` was terminated.", name), true
+	}
+	return "", false
+}
+
+// shortCommit abbreviates a commit SHA like git does.
+func shortCommit(sha string) string {
+	if len(sha) > 7 {
+		return sha[:7]
+	}
+	return sha
+}
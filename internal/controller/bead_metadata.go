@@ -0,0 +1,178 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/beads"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// beadStoreFor returns the BeadStore whose prefix the bead ID carries, among
+// the BeadStores of rig (of any rig when rig is empty), or nil.
+func beadStoreFor(stores []gastownv1alpha1.BeadStore, rig, beadID string) *gastownv1alpha1.BeadStore {
+	for i := range stores {
+		store := &stores[i]
+		if (rig == "" || store.Spec.RigRef == rig) && strings.HasPrefix(beadID, store.Spec.Prefix) {
+			return store
+		}
+	}
+	return nil
+}
+
+// lookupBead resolves the metadata of a bead from the beads cache of its
+// BeadStore. It returns nil when no BeadStore holds the bead.
+func lookupBead(ctx context.Context, c client.Reader, namespace, rig, beadID string) (*beads.Metadata, error) {
+	var stores gastownv1alpha1.BeadStoreList
+	if err := c.List(ctx, &stores, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list beadstores: %w", err)
+	}
+	store := beadStoreFor(stores.Items, rig, beadID)
+	if store == nil {
+		return nil, nil
+	}
+
+	cache := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: beadsCacheName(store), Namespace: namespace}
+	if err := c.Get(ctx, key, cache); err != nil {
+		if apierrors.IsNotFound(err) {
+			// Not synced yet
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to get beads cache: %w", err)
+	}
+	set, err := beads.Parse([]byte(cache.Data[beadsCacheLocalKey]))
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s in ConfigMap %s: %w", beadsCacheLocalKey, cache.Name, err)
+	}
+	bead, ok := set[beadID]
+	if !ok {
+		return nil, nil
+	}
+	m, err := bead.Metadata()
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// trackedBeadSummaries returns the metadata of the given beads from the status
+// of their BeadStores. Beads no BeadStore lists are left out.
+func trackedBeadSummaries(
+	ctx context.Context, c client.Reader, namespace, rig string, beadIDs []string,
+) ([]gastownv1alpha1.BeadSummary, error) {
+	var stores gastownv1alpha1.BeadStoreList
+	if err := c.List(ctx, &stores, client.InNamespace(namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list beadstores: %w", err)
+	}
+
+	var summaries []gastownv1alpha1.BeadSummary
+	for _, id := range beadIDs {
+		store := beadStoreFor(stores.Items, rig, id)
+		if store == nil {
+			continue
+		}
+		for _, summary := range store.Status.Beads {
+			if summary.ID == id {
+				summaries = append(summaries, summary)
+				break
+			}
+		}
+	}
+	return summaries, nil
+}
+
+// beadSummary converts bead metadata to its status form.
+func beadSummary(m beads.Metadata) gastownv1alpha1.BeadSummary {
+	return gastownv1alpha1.BeadSummary{
+		ID:          m.ID,
+		Title:       m.Title,
+		Status:      m.Status,
+		Priority:    m.Priority,
+		Type:        m.IssueType,
+		ExternalRef: m.ExternalRef,
+	}
+}
+
+// beadGrouping returns the bead metadata agent work is grouped by.
+func beadGrouping(m beads.Metadata) pod.BeadGrouping {
+	return pod.BeadGrouping{
+		Labels:           m.Labels,
+		Epic:             m.Epic(),
+		EstimatedMinutes: m.EstimatedMinutes,
+	}
+}
+
+// annotateBead copies the bead's grouping from the annotations of its Pod to
+// the Polecat, removing those of a previous bead. Only the metadata of the
+// Polecat is written; its pending status changes are kept.
+func annotateBead(ctx context.Context, c client.Client, polecat *gastownv1alpha1.Polecat, p *corev1.Pod) error {
+	annotations := make(map[string]string, len(polecat.Annotations))
+	for k, v := range polecat.Annotations {
+		annotations[k] = v
+	}
+	for _, key := range pod.BeadAnnotations {
+		if value, ok := p.Annotations[key]; ok {
+			annotations[key] = value
+		} else {
+			delete(annotations, key)
+		}
+	}
+	if maps.Equal(annotations, polecat.Annotations) {
+		return nil
+	}
+
+	annotated := polecat.DeepCopy()
+	patch := client.MergeFrom(polecat.DeepCopy())
+	annotated.Annotations = annotations
+	if err := c.Patch(ctx, annotated, patch); err != nil {
+		return err
+	}
+	polecat.Annotations = annotated.Annotations
+	polecat.ResourceVersion = annotated.ResourceVersion
+	return nil
+}
+
+// beadSummaries lists the beads of a set sorted by ID, capped at
+// MaxBeadSummaries. A bead whose metadata does not decode is listed by ID only.
+func beadSummaries(set beads.Set) []gastownv1alpha1.BeadSummary {
+	ids := set.IDs()
+	if len(ids) > gastownv1alpha1.MaxBeadSummaries {
+		ids = ids[:gastownv1alpha1.MaxBeadSummaries]
+	}
+	summaries := make([]gastownv1alpha1.BeadSummary, 0, len(ids))
+	for _, id := range ids {
+		m, err := set[id].Metadata()
+		if err != nil {
+			summaries = append(summaries, gastownv1alpha1.BeadSummary{ID: id})
+			continue
+		}
+		m.ID = id
+		summaries = append(summaries, beadSummary(m))
+	}
+	return summaries
+}
@@ -0,0 +1,218 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+var _ = Describe("Bead metadata", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		server *httptest.Server
+		store  *gastownv1alpha1.BeadStore
+		rig    *gastownv1alpha1.Rig
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/repos/org/app/issues" {
+				http.NotFound(w, r)
+				return
+			}
+			_, _ = fmt.Fprint(w, `[
+				{"number": 3, "title": "Fix login", "body": "Users cannot log in.", "state": "open",
+				 "labels": [{"name": "P1"}, {"name": "bug"}]},
+				{"number": 4, "title": "Dark mode", "state": "open"}
+			]`)
+		}))
+
+		rig = &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec:       gastownv1alpha1.RigSpec{GitURL: "git@github.com:org/app.git", BeadsPrefix: "gh"},
+		}
+		store = &gastownv1alpha1.BeadStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-issues", Namespace: "default"},
+			Spec: gastownv1alpha1.BeadStoreSpec{
+				RigRef:  "app",
+				Prefix:  "gh-",
+				Backend: gastownv1alpha1.BeadBackendGitHub,
+				GitHub:  &gastownv1alpha1.GitHubBeadSource{APIURL: server.URL},
+			},
+		}
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// importIssues reconciles the BeadStore until its issues are imported
+	importIssues := func(c client.Client) {
+		r := &BeadStoreReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: store.Name, Namespace: store.Namespace}}
+		for range 2 { // the first reconcile adds the finalizer
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	It("should import GitHub issues into the cache and the status list", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(rig, store).
+			WithStatusSubresource(&gastownv1alpha1.BeadStore{}).
+			Build()
+		importIssues(c)
+
+		var updated gastownv1alpha1.BeadStore
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(store), &updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal(PhaseSynced))
+		Expect(updated.Status.IssueCount).To(Equal(int32(2)))
+		Expect(updated.Status.Beads).To(HaveLen(2))
+		Expect(updated.Status.Beads[0].ID).To(Equal("gh-3"))
+		Expect(updated.Status.Beads[0].Title).To(Equal("Fix login"))
+		Expect(updated.Status.Beads[0].Type).To(Equal("bug"))
+		Expect(*updated.Status.Beads[0].Priority).To(Equal(int32(1)))
+
+		var cache corev1.ConfigMap
+		Expect(c.Get(ctx, types.NamespacedName{Name: beadsCacheName(store), Namespace: "default"}, &cache)).To(Succeed())
+		Expect(cache.Data[beadsCacheLocalKey]).To(ContainSubstring("Users cannot log in."))
+		Expect(cache.Data[beadsCacheBaseKey]).To(Equal(cache.Data[beadsCacheLocalKey]))
+	})
+
+	It("should resolve polecat and convoy beads from the BeadStore", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(rig, store).
+			WithStatusSubresource(&gastownv1alpha1.BeadStore{}).
+			Build()
+		importIssues(c)
+
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:    "app",
+				BeadID: "gh-3",
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository: "git@github.com:org/app.git",
+					GitBranch:     "main",
+					GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-creds"},
+				},
+			},
+		}
+		r := &PolecatReconciler{Client: c, Scheme: scheme}
+		p, err := r.buildPod(ctx, polecat)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(polecat.Status.Bead).NotTo(BeNil())
+		Expect(polecat.Status.Bead.Title).To(Equal("Fix login"))
+		Expect(polecat.Status.Branch).To(Equal("feature/gh-3"))
+		Expect(p.Spec.Containers[0].Env).To(ContainElement(corev1.EnvVar{
+			Name: "GT_TASK_DESCRIPTION", Value: "Fix login\n\nUsers cannot log in.",
+		}))
+
+		Expect(p.Labels).To(HaveKeyWithValue("beads.gastown.io/bug", "true"))
+		Expect(p.Annotations).To(HaveKeyWithValue(pod.BeadLabelsAnnotation, "P1,bug"))
+
+		summaries, err := trackedBeadSummaries(ctx, c, "default", "app", []string{"gh-4", "gt-1", "gh-99"})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(summaries).To(HaveLen(1))
+		Expect(summaries[0].ID).To(Equal("gh-4"))
+		Expect(summaries[0].Title).To(Equal("Dark mode"))
+	})
+
+	It("should title merge commits after the bead", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(rig, store).
+			WithStatusSubresource(&gastownv1alpha1.BeadStore{}).
+			Build()
+		importIssues(c)
+
+		r := &RefineryReconciler{Client: c, Scheme: scheme}
+		refinery := &gastownv1alpha1.Refinery{Spec: gastownv1alpha1.RefinerySpec{RigRef: "app"}}
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: "app", BeadID: "gh-3", TaskDescription: "Fix the login form"},
+		}
+		message, err := r.mergeCommitMessage(ctx, refinery, polecat, "feature/gh-3", "main")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("gh-3: Fix login"))
+
+		// Beads no BeadStore holds are titled after the task
+		polecat.Spec.BeadID = "gt-7"
+		refinery.Spec.CommitMessageTemplate = "{{.Title}} ({{.BeadID}}, {{.Polecat}})"
+		message, err = r.mergeCommitMessage(ctx, refinery, polecat, "feature/gt-7", "main")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(message).To(Equal("Fix the login form (gt-7, furiosa)"))
+
+		refinery.Spec.CommitMessageTemplate = "{{.Bead}}"
+		_, err = r.mergeCommitMessage(ctx, refinery, polecat, "feature/gt-7", "main")
+		Expect(err).To(MatchError(ContainSubstring("commitMessageTemplate")))
+	})
+
+	It("should carry the bead's grouping onto the Polecat", func() {
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{
+				Name: "furiosa", Namespace: "default",
+				Annotations: map[string]string{pod.BeadEpicAnnotation: "gh-1", "team": "payments"},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(polecat).Build()
+		polecat.Status.Branch = "feature/gh-3"
+
+		p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{
+			pod.BeadLabelsAnnotation: "area:payments", pod.BeadEstimateAnnotation: "90",
+		}}}
+		Expect(annotateBead(ctx, c, polecat, p)).To(Succeed())
+		Expect(polecat.Status.Branch).To(Equal("feature/gh-3"), "pending status changes are kept")
+
+		var updated gastownv1alpha1.Polecat
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), &updated)).To(Succeed())
+		Expect(updated.Annotations).To(Equal(map[string]string{
+			pod.BeadLabelsAnnotation: "area:payments", pod.BeadEstimateAnnotation: "90", "team": "payments",
+		}))
+		Expect(polecat.Annotations).To(Equal(updated.Annotations))
+	})
+
+	It("should leave beads of rigs without a BeadStore unresolved", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).Build()
+		bead, err := lookupBead(ctx, c, "default", "app", "gh-3")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(bead).To(BeNil())
+	})
+})
@@ -18,19 +18,30 @@ package controller
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/http"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/pkg/config"
 	gterrors "github.com/org/gastown-operator/pkg/errors"
 	"github.com/org/gastown-operator/pkg/metrics"
 )
@@ -54,16 +65,42 @@ const (
 
 // BeadStoreReconciler reconciles a BeadStore object.
 // In Kubernetes-only mode, it validates Rig existence and tracks metadata.
+// With spec.gitSync, it also syncs beads with the rig repository; with the
+// github or jira backend, it imports the tracker's issues as beads. In both
+// cases, beads whose polecat branches have been merged are closed, and beads
+// the Witness gave up on get a follow-up bead.
 type BeadStoreReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// GitClientFactory creates git clients. If nil, uses git.DefaultGitClientFactory.
+	GitClientFactory git.GitClientFactory
+
+	// Triggers enqueues BeadStores on demand (e.g., git webhooks). Optional.
+	Triggers <-chan event.GenericEvent
+
+	// HTTPClient calls the issue tracker APIs. If nil, a client with a 30s timeout is used.
+	HTTPClient *http.Client
+
+	// Audit records the clones of and pushes to the beads repository. Optional.
+	Audit *audit.Log
+
+	// Tuning overrides the controller's workqueue settings. Optional.
+	Tuning config.ControllerTuning
 }
 
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=beadstores,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=beadstores/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=beadstores/finalizers,verbs=update
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=rigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=convoys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=convoys/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile manages the BeadStore lifecycle.
 // It validates the referenced Rig exists.
@@ -78,6 +115,15 @@ func (r *BeadStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave resources processed by a newer operator alone
+	if skip, err := checkSchemaVersion(ctx, r.Client, r.Recorder, &beadstore, &beadstore.Status.Conditions); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, err
+	} else if skip {
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
 	log.Info("Reconciling BeadStore",
 		"name", beadstore.Name,
 		"rig", beadstore.Spec.RigRef,
@@ -91,8 +137,9 @@ func (r *BeadStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	// Add finalizer if not present
 	if !controllerutil.ContainsFinalizer(&beadstore, beadstoreFinalizer) {
 		log.Info("Adding finalizer to BeadStore")
-		controllerutil.AddFinalizer(&beadstore, beadstoreFinalizer)
-		if err := r.Update(ctx, &beadstore); err != nil {
+		if err := gterrors.UpdateOnConflict(ctx, r.Client, &beadstore, func() bool {
+			return controllerutil.AddFinalizer(&beadstore, beadstoreFinalizer)
+		}); err != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(err, "failed to add finalizer")
 		}
@@ -103,10 +150,11 @@ func (r *BeadStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 	rigExists, err := r.validateRig(ctx, beadstore.Spec.RigRef)
 	if err != nil {
 		log.Error(err, "Failed to validate rig")
+		r.Recorder.Event(&beadstore, corev1.EventTypeWarning, "RigValidationFailed", err.Error())
 		r.setCondition(&beadstore, ConditionBeadStoreReady, metav1.ConditionFalse, "RigValidationFailed",
 			err.Error())
 		beadstore.Status.Phase = PhaseError
-		if updateErr := r.Status().Update(ctx, &beadstore); updateErr != nil {
+		if updateErr := applyStatus(ctx, r.Client, &beadstore, fieldManagerBeadStore); updateErr != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update status")
 		}
@@ -116,10 +164,12 @@ func (r *BeadStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 
 	if !rigExists {
 		log.Info("Rig not found", "rig", beadstore.Spec.RigRef)
+		r.Recorder.Event(&beadstore, corev1.EventTypeWarning, "RigNotFound",
+			"Rig "+beadstore.Spec.RigRef+" not found")
 		r.setCondition(&beadstore, ConditionBeadStoreReady, metav1.ConditionFalse, "RigNotFound",
 			"Rig "+beadstore.Spec.RigRef+" not found")
 		beadstore.Status.Phase = PhasePending
-		if err := r.Status().Update(ctx, &beadstore); err != nil {
+		if err := applyStatus(ctx, r.Client, &beadstore, fieldManagerBeadStore); err != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
 		}
@@ -128,17 +178,53 @@ func (r *BeadStoreReconciler) Reconcile(ctx context.Context, req ctrl.Request) (
 		return ctrl.Result{RequeueAfter: 30 * time.Second}, nil
 	}
 
+	// Sync beads with the rig repository if enabled, or import them from
+	// the issue tracker
+	var syncErr error
+	switch {
+	case beadstore.Spec.GitSync:
+		syncErr = r.syncBeads(ctx, &beadstore)
+	case beadstore.Spec.Backend == gastownv1alpha1.BeadBackendGitHub,
+		beadstore.Spec.Backend == gastownv1alpha1.BeadBackendJira:
+		syncErr = r.importBeads(ctx, &beadstore)
+	}
+	if syncErr != nil {
+		requeue := requeueLong()
+		reason := "SyncFailed"
+		if errors.Is(syncErr, git.ErrTargetMoved) {
+			// Someone pushed between our clone and push; retry from their commit
+			requeue = requeueShort()
+			reason = "RemoteMoved"
+		} else {
+			log.Error(syncErr, "Failed to sync beads")
+			beadstore.Status.Phase = PhaseError
+		}
+		r.Recorder.Event(&beadstore, corev1.EventTypeWarning, reason, syncErr.Error())
+		r.setCondition(&beadstore, ConditionBeadStoreSynced, metav1.ConditionFalse, reason, syncErr.Error())
+		if updateErr := applyStatus(ctx, r.Client, &beadstore, fieldManagerBeadStore); updateErr != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeue}, nil
+	}
+
 	// Mark as synced - in K8s-only mode, we just validate Rig existence
 	now := metav1.Now()
 	beadstore.Status.Phase = PhaseSynced
 	beadstore.Status.LastSyncTime = &now
 
-	r.setCondition(&beadstore, ConditionBeadStoreSynced, metav1.ConditionTrue, "SyncSucceeded",
-		"BeadStore synced successfully")
+	if len(beadstore.Status.Conflicts) > 0 {
+		r.setCondition(&beadstore, ConditionBeadStoreSynced, metav1.ConditionFalse, "Conflict",
+			fmt.Sprintf("%d beads changed on both sides need resolution", len(beadstore.Status.Conflicts)))
+	} else {
+		r.setCondition(&beadstore, ConditionBeadStoreSynced, metav1.ConditionTrue, "SyncSucceeded",
+			"BeadStore synced successfully")
+	}
 	r.setCondition(&beadstore, ConditionBeadStoreReady, metav1.ConditionTrue, "Ready",
 		"BeadStore is ready")
 
-	if err := r.Status().Update(ctx, &beadstore); err != nil {
+	if err := applyStatus(ctx, r.Client, &beadstore, fieldManagerBeadStore); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
 	}
@@ -166,8 +252,9 @@ func (r *BeadStoreReconciler) handleDeletion(ctx context.Context, beadstore *gas
 	if controllerutil.ContainsFinalizer(beadstore, beadstoreFinalizer) {
 		log.Info("Deleting BeadStore")
 		// No external resources to clean up, just remove finalizer
-		controllerutil.RemoveFinalizer(beadstore, beadstoreFinalizer)
-		if err := r.Update(ctx, beadstore); err != nil {
+		if err := gterrors.UpdateOnConflict(ctx, r.Client, beadstore, func() bool {
+			return controllerutil.RemoveFinalizer(beadstore, beadstoreFinalizer)
+		}); err != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(err, "failed to remove finalizer")
 		}
@@ -217,12 +304,25 @@ func (r *BeadStoreReconciler) setCondition(beadstore *gastownv1alpha1.BeadStore,
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *BeadStoreReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
-		For(&gastownv1alpha1.BeadStore{}).
+	b := ctrl.NewControllerManagedBy(mgr).
+		// Status-only updates do not trigger a sync; the resolution
+		// annotation does
+		For(&gastownv1alpha1.BeadStore{}, builder.WithPredicates(predicate.Or(
+			predicate.GenerationChangedPredicate{}, predicate.AnnotationChangedPredicate{}))).
 		Owns(&corev1.Secret{}).
+		// Close beads as soon as the Refinery merges their branches, and file
+		// follow-ups as soon as the Witness gives up on them
+		Watches(&gastownv1alpha1.Polecat{},
+			handler.EnqueueRequestsFromMapFunc(r.beadStoresForPolecat),
+			builder.WithPredicates(polecatWritebackChanged)).
+		// Open summary beads as soon as convoys asking for one complete
+		Watches(&gastownv1alpha1.Convoy{},
+			handler.EnqueueRequestsFromMapFunc(r.beadStoresForConvoy),
+			builder.WithPredicates(convoySummaryDue)).
 		Named("beadstore").
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 1, // BeadStore is a singleton config
-		}).
-		Complete(r)
+		WithOptions(controllerOptions(r.Tuning, 1)) // BeadStore is a singleton config
+	if r.Triggers != nil {
+		b = b.WatchesRawSource(source.Channel(r.Triggers, &handler.EnqueueRequestForObject{}))
+	}
+	return b.Complete(r)
 }
@@ -18,18 +18,58 @@ package controller
 
 import (
 	"context"
+	"io/fs"
 	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
 )
 
+// fakeBeadsRepo implements git.GitClient and git.FileCommitter over an
+// in-memory issues.jsonl.
+type fakeBeadsRepo struct {
+	content []byte
+	pushed  []byte
+}
+
+func (f *fakeBeadsRepo) Clone(ctx context.Context) error {
+	return nil
+}
+
+func (f *fakeBeadsRepo) MergeBranch(ctx context.Context, opts git.MergeOptions) (*git.MergeResult, error) {
+	return &git.MergeResult{Success: true}, nil
+}
+
+func (f *fakeBeadsRepo) ReadFile(path string) ([]byte, error) {
+	if f.content == nil {
+		return nil, fs.ErrNotExist
+	}
+	return f.content, nil
+}
+
+func (f *fakeBeadsRepo) CommitFile(ctx context.Context, path string, content []byte, message string) (string, error) {
+	f.pushed = content
+	return "c0ffee", nil
+}
+
+func (f *fakeBeadsRepo) GetCommitSHA(ctx context.Context) (string, error) {
+	return "base123", nil
+}
+
+func (f *fakeBeadsRepo) Push(ctx context.Context) error {
+	f.content = f.pushed
+	return nil
+}
+
 var _ = Describe("BeadStore Controller", func() {
 	var (
 		ctx           context.Context
@@ -41,8 +81,9 @@ var _ = Describe("BeadStore Controller", func() {
 	BeforeEach(func() {
 		ctx = context.Background()
 		reconciler = &BeadStoreReconciler{
-			Client: k8sClient,
-			Scheme: k8sClient.Scheme(),
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: &record.FakeRecorder{},
 		}
 
 		testBeadStore = &gastownv1alpha1.BeadStore{
@@ -243,6 +284,78 @@ var _ = Describe("BeadStore Controller", func() {
 		})
 	})
 
+	Context("When git sync is enabled", func() {
+		var repo *fakeBeadsRepo
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, testRig)).To(Succeed())
+
+			repo = &fakeBeadsRepo{content: []byte(
+				`{"id":"gt-1","status":"open"}` + "\n" + `{"id":"gt-2","status":"blocked"}` + "\n")}
+			reconciler.Recorder = record.NewFakeRecorder(10)
+			reconciler.GitClientFactory = func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+				return repo
+			}
+			testBeadStore.Spec.GitSync = true
+		})
+
+		AfterEach(func() {
+			_ = k8sClient.Delete(ctx, &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+				Name: beadsCacheName(testBeadStore), Namespace: testBeadStore.Namespace,
+			}})
+		})
+
+		It("should push operator changes and report conflicting beads", func() {
+			Expect(k8sClient.Create(ctx, testBeadStore)).To(Succeed())
+
+			// The operator's copy closed gt-1 and gt-2; the repository blocked gt-2
+			base := `{"id":"gt-1","status":"open"}` + "\n" + `{"id":"gt-2","status":"open"}` + "\n"
+			local := `{"id":"gt-1","status":"closed"}` + "\n" + `{"id":"gt-2","status":"closed"}` + "\n"
+			Expect(k8sClient.Create(ctx, &corev1.ConfigMap{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      beadsCacheName(testBeadStore),
+					Namespace: testBeadStore.Namespace,
+				},
+				Data: map[string]string{beadsCacheBaseKey: base, beadsCacheLocalKey: local},
+			})).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{
+				Name:      testBeadStore.Name,
+				Namespace: testBeadStore.Namespace,
+			}}
+
+			// First reconcile adds finalizer
+			_, err := reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			_, err = reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			// gt-1 is swapped in, gt-2 keeps the repository's version
+			Expect(string(repo.content)).To(Equal(
+				`{"id":"gt-1","status":"closed"}` + "\n" + `{"id":"gt-2","status":"blocked"}` + "\n"))
+
+			var updated gastownv1alpha1.BeadStore
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updated)).To(Succeed())
+			Expect(updated.Status.Revision).To(Equal("c0ffee"))
+			Expect(updated.Status.IssueCount).To(Equal(int32(2)))
+			Expect(updated.Status.Conflicts).To(HaveLen(1))
+			Expect(updated.Status.Conflicts[0].ID).To(Equal("gt-2"))
+
+			By("resolving the conflict in favour of the operator")
+			updated.Annotations = map[string]string{BeadResolutionAnnotation: "gt-2=local"}
+			Expect(k8sClient.Update(ctx, &updated)).To(Succeed())
+
+			_, err = reconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(string(repo.content)).To(Equal(local))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updated)).To(Succeed())
+			Expect(updated.Status.Conflicts).To(BeEmpty())
+			Expect(updated.Annotations).NotTo(HaveKey(BeadResolutionAnnotation))
+		})
+	})
+
 	Context("When beadstore is deleted", func() {
 		It("should remove finalizer and allow deletion", func() {
 			Expect(k8sClient.Create(ctx, testRig)).To(Succeed())
@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/beads"
+	"github.com/org/gastown-operator/internal/git/provider"
+	gterrors "github.com/org/gastown-operator/pkg/errors"
+)
+
+// Keys of the Secret referenced by spec.jira.credentialsSecretRef
+const (
+	jiraEmailKey    = "email"
+	jiraAPITokenKey = "apiToken"
+	jiraTokenKey    = "token"
+)
+
+// importBeads replaces the beads cache with the issues of the BeadStore's
+// tracker. The tracker is the source of truth, so local and base are both set
+// to the import; the only writes back are closing the issues of beads whose
+// polecat branches have been merged, filing follow-ups for beads the
+// Witness gave up on and summaries of completed convoys and, when the tracker can do both, filing approved splits
+// and closing the beads they replace.
+func (r *BeadStoreReconciler) importBeads(ctx context.Context, beadstore *gastownv1alpha1.BeadStore) error {
+	source, err := r.beadSource(ctx, beadstore)
+	if err != nil {
+		return err
+	}
+	imported, err := source.List(ctx)
+	if err != nil {
+		return err
+	}
+
+	var closed []mergedBead
+	if closer, ok := source.(beads.Closer); ok {
+		closed, err = r.closeMergedBeads(ctx, beadstore, imported, closer)
+		if err != nil {
+			return err
+		}
+	}
+	if filer, ok := source.(beads.Filer); ok {
+		// Filed issues exist in the tracker now; record them before anything
+		// else can fail so they are not filed again
+		followUps, fileErr := r.fileFollowUpBeads(ctx, beadstore, imported, filer)
+		if err := errors.Join(fileErr, r.recordFollowUps(ctx, beadstore, followUps)); err != nil {
+			return err
+		}
+		summaries, fileErr := r.fileSummaryBeads(ctx, beadstore, imported, filer)
+		if err := errors.Join(fileErr, r.recordSummaries(ctx, beadstore, summaries)); err != nil {
+			return err
+		}
+		if closer, ok := source.(beads.Closer); ok {
+			splits, fileErr := r.fileSplitBeads(ctx, beadstore, imported, filer, closer)
+			if err := errors.Join(fileErr, r.recordSplits(ctx, beadstore, splits)); err != nil {
+				return err
+			}
+		}
+	}
+
+	cache, err := r.ensureBeadsCache(ctx, beadstore)
+	if err != nil {
+		return err
+	}
+	encoded := string(imported.Encode())
+	if err := gterrors.UpdateOnConflict(ctx, r.Client, cache, func() bool {
+		return setBeadsCache(cache, encoded, encoded)
+	}); err != nil {
+		return fmt.Errorf("failed to update beads cache: %w", err)
+	}
+	r.recordClosedBeads(ctx, beadstore, closed)
+
+	issueCount := len(imported)
+	if issueCount > math.MaxInt32 {
+		issueCount = math.MaxInt32
+	}
+	beadstore.Status.IssueCount = int32(issueCount) // #nosec G115 -- bounds checked above
+	beadstore.Status.Beads = beadSummaries(imported)
+	beadstore.Status.Revision = ""
+	beadstore.Status.Conflicts = nil
+	return nil
+}
+
+// beadSource builds the issue tracker client for the BeadStore's backend.
+func (r *BeadStoreReconciler) beadSource(ctx context.Context, beadstore *gastownv1alpha1.BeadStore) (beads.Source, error) {
+	switch beadstore.Spec.Backend {
+	case gastownv1alpha1.BeadBackendGitHub:
+		return r.githubSource(ctx, beadstore)
+	case gastownv1alpha1.BeadBackendJira:
+		return r.jiraSource(ctx, beadstore)
+	default:
+		return nil, fmt.Errorf("backend %q does not import beads", beadstore.Spec.Backend)
+	}
+}
+
+func (r *BeadStoreReconciler) githubSource(ctx context.Context, beadstore *gastownv1alpha1.BeadStore) (beads.Source, error) {
+	spec := beadstore.Spec.GitHub
+	if spec == nil {
+		return nil, fmt.Errorf("spec.github is required for the github backend")
+	}
+
+	repository := spec.Repository
+	if repository == "" {
+		rig := &gastownv1alpha1.Rig{}
+		if err := r.Get(ctx, types.NamespacedName{Name: beadstore.Spec.RigRef}, rig); err != nil {
+			return nil, fmt.Errorf("failed to get rig %s: %w", beadstore.Spec.RigRef, err)
+		}
+		host, path, err := provider.ParseRepoPath(rig.Spec.GitURL)
+		if err != nil {
+			return nil, err
+		}
+		if host != "github.com" {
+			return nil, fmt.Errorf("rig repository %s is not on github.com; set spec.github.repository", rig.Spec.GitURL)
+		}
+		repository = path
+	}
+	owner, repo, ok := strings.Cut(repository, "/")
+	if !ok || owner == "" || repo == "" || strings.Contains(repo, "/") {
+		return nil, fmt.Errorf("invalid GitHub repository %q, expected owner/repo", repository)
+	}
+
+	source := &beads.GitHubSource{
+		APIURL:        spec.APIURL,
+		Owner:         owner,
+		Repo:          repo,
+		Labels:        spec.Labels,
+		IncludeClosed: spec.IncludeClosed,
+		Prefix:        beadstore.Spec.Prefix,
+		HTTPClient:    r.HTTPClient,
+	}
+	if ref := spec.TokenSecretRef; ref != nil {
+		secret, err := r.getSecret(ctx, beadstore.Namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		token, ok := secret.Data[ref.Key]
+		if !ok {
+			return nil, fmt.Errorf("key %s not found in secret %s", ref.Key, ref.Name)
+		}
+		source.Token = strings.TrimSpace(string(token))
+	}
+	return source, nil
+}
+
+func (r *BeadStoreReconciler) jiraSource(ctx context.Context, beadstore *gastownv1alpha1.BeadStore) (beads.Source, error) {
+	spec := beadstore.Spec.Jira
+	if spec == nil {
+		return nil, fmt.Errorf("spec.jira is required for the jira backend")
+	}
+
+	source := &beads.JiraSource{
+		URL:        spec.URL,
+		JQL:        spec.JQL,
+		Prefix:     beadstore.Spec.Prefix,
+		HTTPClient: r.HTTPClient,
+	}
+	if ref := spec.CredentialsSecretRef; ref != nil {
+		secret, err := r.getSecret(ctx, beadstore.Namespace, ref.Name)
+		if err != nil {
+			return nil, err
+		}
+		if token, ok := secret.Data[jiraTokenKey]; ok {
+			source.APIToken = strings.TrimSpace(string(token))
+		} else {
+			email, hasEmail := secret.Data[jiraEmailKey]
+			apiToken, hasToken := secret.Data[jiraAPITokenKey]
+			if !hasEmail || !hasToken {
+				return nil, fmt.Errorf("secret %s needs keys %s and %s, or %s",
+					ref.Name, jiraEmailKey, jiraAPITokenKey, jiraTokenKey)
+			}
+			source.Email = strings.TrimSpace(string(email))
+			source.APIToken = strings.TrimSpace(string(apiToken))
+		}
+	}
+	return source, nil
+}
+
+func (r *BeadStoreReconciler) getSecret(ctx context.Context, namespace, name string) (*corev1.Secret, error) {
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, secret); err != nil {
+		return nil, fmt.Errorf("failed to get secret %s: %w", name, err)
+	}
+	return secret, nil
+}
@@ -0,0 +1,334 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"math"
+	"os"
+	"path/filepath"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/beads"
+	"github.com/org/gastown-operator/internal/git"
+	gterrors "github.com/org/gastown-operator/pkg/errors"
+)
+
+const (
+	// BeadResolutionAnnotation resolves bead conflicts on a BeadStore.
+	// Value: "<bead-id>=local|remote,..." — removed once applied.
+	BeadResolutionAnnotation = "gastown.io/resolve-beads"
+
+	// defaultBeadsPath is the beads database location in the rig repository.
+	defaultBeadsPath = ".beads/issues.jsonl"
+
+	// Keys in the beads cache ConfigMap.
+	beadsCacheLocalKey = "issues.jsonl"
+	beadsCacheBaseKey  = "base.jsonl"
+)
+
+// beadsCacheName returns the name of the ConfigMap holding the operator's beads.
+func beadsCacheName(beadstore *gastownv1alpha1.BeadStore) string {
+	return beadstore.Name + "-beads"
+}
+
+// syncBeads performs a three-way sync between the operator's beads cache and
+// the rig repository. Beads changed only by the operator are pushed, beads
+// changed only in the repository are adopted, and beads changed on both sides
+// are recorded as conflicts. Beads whose polecat branches have been merged are
+// closed, follow-ups for given-up beads and summaries of completed convoys are
+// added and proposed splits are commented or, once approved, filed first, so
+// all are pushed with the sync. A push rejected because the repository moved
+// returns an error wrapping git.ErrTargetMoved; nothing is written in that case.
+func (r *BeadStoreReconciler) syncBeads(ctx context.Context, beadstore *gastownv1alpha1.BeadStore) error {
+	log := logf.FromContext(ctx)
+
+	cache, err := r.ensureBeadsCache(ctx, beadstore)
+	if err != nil {
+		return err
+	}
+	base, err := beads.Parse([]byte(cache.Data[beadsCacheBaseKey]))
+	if err != nil {
+		return fmt.Errorf("invalid %s in ConfigMap %s: %w", beadsCacheBaseKey, cache.Name, err)
+	}
+	local, err := beads.Parse([]byte(cache.Data[beadsCacheLocalKey]))
+	if err != nil {
+		return fmt.Errorf("invalid %s in ConfigMap %s: %w", beadsCacheLocalKey, cache.Name, err)
+	}
+
+	repo, cleanup, err := r.openBeadsRepository(ctx, beadstore)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	beadsPath := beadstore.Spec.BeadsPath
+	if beadsPath == "" {
+		beadsPath = defaultBeadsPath
+	}
+	content, err := repo.ReadFile(beadsPath)
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to read %s: %w", beadsPath, err)
+	}
+	remote, err := beads.Parse(content)
+	if err != nil {
+		return fmt.Errorf("invalid %s in repository: %w", beadsPath, err)
+	}
+
+	// Apply requested conflict resolutions before comparing
+	resolutionValue, hasResolutions := beadstore.Annotations[BeadResolutionAnnotation]
+	if hasResolutions {
+		resolutions, err := beads.ParseResolutions(resolutionValue)
+		if err != nil {
+			return fmt.Errorf("invalid %s annotation: %w", BeadResolutionAnnotation, err)
+		}
+		for id, resolution := range resolutions {
+			if err := beads.Resolve(base, local, remote, id, resolution); err != nil {
+				return err
+			}
+		}
+	}
+
+	closed, err := r.closeMergedBeads(ctx, beadstore, local, nil)
+	if err != nil {
+		return err
+	}
+	followUps, err := r.fileFollowUpBeads(ctx, beadstore, local, nil)
+	if err != nil {
+		return err
+	}
+	if err := r.proposeSplits(ctx, beadstore, local); err != nil {
+		return err
+	}
+	splits, err := r.fileSplitBeads(ctx, beadstore, local, nil, nil)
+	if err != nil {
+		return err
+	}
+	summaries, err := r.fileSummaryBeads(ctx, beadstore, local, nil)
+	if err != nil {
+		return err
+	}
+
+	result := beads.Sync(base, local, remote)
+
+	revision, err := repo.GetCommitSHA(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to read repository revision: %w", err)
+	}
+	if result.RemoteChanged {
+		revision, err = repo.CommitFile(ctx, beadsPath, result.Remote.Encode(), "beads: sync from gastown-operator")
+		if err != nil {
+			return fmt.Errorf("failed to commit beads: %w", err)
+		}
+		// The push is the compare-and-swap: it fails if anyone else pushed
+		// since our clone, and the next sync starts over from their commit
+		err = repo.Push(ctx)
+		r.Audit.Record("beadstore", audit.ActionPush, beadstore, map[string]string{"path": beadsPath, "revision": revision}, err)
+		if err != nil {
+			return fmt.Errorf("failed to push beads: %w", err)
+		}
+		log.Info("Pushed bead changes", "revision", revision)
+		r.Recorder.Event(beadstore, corev1.EventTypeNormal, "BeadsPushed",
+			fmt.Sprintf("Pushed bead changes to %s at %s", beadsPath, revision))
+	}
+
+	newLocal, newBase := string(result.Local.Encode()), string(result.Base.Encode())
+	if err := gterrors.UpdateOnConflict(ctx, r.Client, cache, func() bool {
+		return setBeadsCache(cache, newLocal, newBase)
+	}); err != nil {
+		return fmt.Errorf("failed to update beads cache: %w", err)
+	}
+	r.recordClosedBeads(ctx, beadstore, closed)
+	if err := errors.Join(r.recordFollowUps(ctx, beadstore, followUps), r.recordSplits(ctx, beadstore, splits),
+		r.recordSummaries(ctx, beadstore, summaries)); err != nil {
+		return err
+	}
+
+	// Drop applied resolutions before touching status (the patch response
+	// overwrites the in-memory object)
+	if hasResolutions {
+		patch := client.MergeFrom(beadstore.DeepCopy())
+		delete(beadstore.Annotations, BeadResolutionAnnotation)
+		if err := r.Patch(ctx, beadstore, patch); err != nil {
+			return fmt.Errorf("failed to clear %s annotation: %w", BeadResolutionAnnotation, err)
+		}
+	}
+
+	issueCount := len(result.Remote)
+	if issueCount > math.MaxInt32 {
+		issueCount = math.MaxInt32
+	}
+	beadstore.Status.IssueCount = int32(issueCount) // #nosec G115 -- bounds checked above
+	beadstore.Status.Revision = revision
+	beadstore.Status.Conflicts = r.recordConflicts(beadstore, result.Conflicts)
+	beadstore.Status.Beads = beadSummaries(result.Local)
+
+	return nil
+}
+
+// recordConflicts converts sync conflicts to status entries, keeping the
+// detection time of conflicts already reported and emitting an event for new ones.
+func (r *BeadStoreReconciler) recordConflicts(
+	beadstore *gastownv1alpha1.BeadStore, conflicts []beads.Conflict,
+) []gastownv1alpha1.BeadConflict {
+	previous := make(map[string]*metav1.Time, len(beadstore.Status.Conflicts))
+	for _, c := range beadstore.Status.Conflicts {
+		previous[c.ID] = c.DetectedAt
+	}
+
+	now := metav1.Now()
+	entries := make([]gastownv1alpha1.BeadConflict, 0, len(conflicts))
+	for _, c := range conflicts {
+		detectedAt, seen := previous[c.ID]
+		if !seen || detectedAt == nil {
+			detectedAt = &now
+			r.Recorder.Event(beadstore, corev1.EventTypeWarning, "BeadConflict",
+				fmt.Sprintf("Bead %s changed by both the operator and the repository; resolve with %s: %s=local|remote",
+					c.ID, BeadResolutionAnnotation, c.ID))
+		}
+		entries = append(entries, gastownv1alpha1.BeadConflict{
+			ID:             c.ID,
+			BaseRevision:   c.BaseRevision,
+			LocalRevision:  c.LocalRevision,
+			RemoteRevision: c.RemoteRevision,
+			DetectedAt:     detectedAt,
+		})
+	}
+	return entries
+}
+
+// ensureBeadsCache returns the ConfigMap holding the operator's copy of the
+// beads, creating an empty one owned by the BeadStore if needed.
+func (r *BeadStoreReconciler) ensureBeadsCache(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore,
+) (*corev1.ConfigMap, error) {
+	cache := &corev1.ConfigMap{}
+	key := types.NamespacedName{Name: beadsCacheName(beadstore), Namespace: beadstore.Namespace}
+	err := r.Get(ctx, key, cache)
+	if err == nil {
+		if cache.Data == nil {
+			cache.Data = map[string]string{}
+		}
+		return cache, nil
+	}
+	if !apierrors.IsNotFound(err) {
+		return nil, fmt.Errorf("failed to get beads cache: %w", err)
+	}
+
+	cache = &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      key.Name,
+			Namespace: key.Namespace,
+			Labels: map[string]string{
+				"gastown.io/rig":       beadstore.Spec.RigRef,
+				"gastown.io/beadstore": beadstore.Name,
+			},
+		},
+		Data: map[string]string{
+			beadsCacheLocalKey: "",
+			beadsCacheBaseKey:  "",
+		},
+	}
+	if err := controllerutil.SetControllerReference(beadstore, cache, r.Scheme); err != nil {
+		return nil, fmt.Errorf("failed to set owner reference: %w", err)
+	}
+	if err := r.Create(ctx, cache); err != nil {
+		return nil, fmt.Errorf("failed to create beads cache: %w", err)
+	}
+	return cache, nil
+}
+
+// setBeadsCache stores the local and base snapshots in the cache, reporting
+// whether they changed.
+func setBeadsCache(cache *corev1.ConfigMap, local, base string) bool {
+	if cache.Data[beadsCacheLocalKey] == local && cache.Data[beadsCacheBaseKey] == base {
+		return false
+	}
+	if cache.Data == nil {
+		cache.Data = map[string]string{}
+	}
+	cache.Data[beadsCacheLocalKey] = local
+	cache.Data[beadsCacheBaseKey] = base
+	return true
+}
+
+// openBeadsRepository clones the Rig's repository into a temporary directory.
+// The returned cleanup function removes the clone and any credential files.
+func (r *BeadStoreReconciler) openBeadsRepository(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore,
+) (git.FileCommitter, func(), error) {
+	rig := &gastownv1alpha1.Rig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: beadstore.Spec.RigRef}, rig); err != nil {
+		return nil, nil, fmt.Errorf("failed to get rig %s: %w", beadstore.Spec.RigRef, err)
+	}
+
+	var sshKeyPath string
+	credsCleanup := func() {}
+	if beadstore.Spec.GitSecretRef != nil {
+		keyPath, cleanup, err := writeSSHKeyFromSecret(ctx, r.Client, types.NamespacedName{
+			Name:      beadstore.Spec.GitSecretRef.Name,
+			Namespace: beadstore.Namespace,
+		})
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to setup git credentials: %w", err)
+		}
+		sshKeyPath = keyPath
+		credsCleanup = cleanup
+	}
+
+	workDir, err := os.MkdirTemp("", "beadstore-sync-*")
+	if err != nil {
+		credsCleanup()
+		return nil, nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(workDir) //nolint:errcheck // best-effort cleanup
+		credsCleanup()
+	}
+
+	factory := r.GitClientFactory
+	if factory == nil {
+		factory = git.DefaultGitClientFactory
+	}
+	gitClient := factory(filepath.Join(workDir, "repo"), rig.Spec.GitURL, sshKeyPath)
+
+	repo, ok := gitClient.(git.FileCommitter)
+	if !ok {
+		cleanup()
+		return nil, nil, fmt.Errorf("git client does not support committing files")
+	}
+	err = gitClient.Clone(ctx)
+	r.Audit.Record("beadstore", audit.ActionClone, beadstore, map[string]string{"repository": rig.Spec.GitURL}, err)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to clone repository: %w", err)
+	}
+
+	return repo, cleanup, nil
+}
@@ -0,0 +1,636 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/beads"
+)
+
+// followUpLogExcerptBytes caps the agent log quoted in a follow-up bead
+const followUpLogExcerptBytes = 2048
+
+// mergedBead is a bead whose polecat branch the Refinery has merged.
+type mergedBead struct {
+	ID             string
+	Polecat        string
+	Commit         string
+	PullRequestURL string
+}
+
+// closeComment is the comment recorded when a merged bead is closed.
+func (m mergedBead) closeComment() string {
+	comment := "Merged by polecat " + m.Polecat
+	if m.Commit != "" {
+		comment += " in commit " + m.Commit
+	}
+	if m.PullRequestURL != "" {
+		comment += " (" + m.PullRequestURL + ")"
+	}
+	return comment
+}
+
+// storePolecats lists the polecats working on beads of the BeadStore, sorted
+// by bead ID.
+func (r *BeadStoreReconciler) storePolecats(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore,
+) ([]gastownv1alpha1.Polecat, error) {
+	var polecats gastownv1alpha1.PolecatList
+	if err := r.List(ctx, &polecats, client.InNamespace(beadstore.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list polecats: %w", err)
+	}
+
+	var matching []gastownv1alpha1.Polecat
+	for _, polecat := range polecats.Items {
+		if polecat.Spec.Rig == beadstore.Spec.RigRef && polecat.Status.AssignedBead != "" &&
+			strings.HasPrefix(polecat.Status.AssignedBead, beadstore.Spec.Prefix) {
+			matching = append(matching, polecat)
+		}
+	}
+	sort.Slice(matching, func(i, j int) bool {
+		return matching[i].Status.AssignedBead < matching[j].Status.AssignedBead
+	})
+	return matching, nil
+}
+
+// mergedBeads lists the beads of the BeadStore whose polecat branches have
+// been merged, sorted by bead ID.
+func (r *BeadStoreReconciler) mergedBeads(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore,
+) ([]mergedBead, error) {
+	polecats, err := r.storePolecats(ctx, beadstore)
+	if err != nil {
+		return nil, err
+	}
+
+	var merged []mergedBead
+	for _, polecat := range polecats {
+		if !meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionMerged) {
+			continue
+		}
+		merged = append(merged, mergedBead{
+			ID:             polecat.Status.AssignedBead,
+			Polecat:        polecat.Name,
+			Commit:         polecat.Status.MergedCommit,
+			PullRequestURL: polecat.Status.PullRequestURL,
+		})
+	}
+	return merged, nil
+}
+
+// closeMergedBeads closes the beads in set whose polecat branches have been
+// merged and that are still open. If closer is set, the issue is closed in
+// the tracker first. It returns the merged beads it closed.
+func (r *BeadStoreReconciler) closeMergedBeads(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, set beads.Set, closer beads.Closer,
+) ([]mergedBead, error) {
+	merged, err := r.mergedBeads(ctx, beadstore)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var closed []mergedBead
+	for _, m := range merged {
+		bead, ok := set[m.ID]
+		if !ok {
+			continue
+		}
+		metadata, err := bead.Metadata()
+		if err != nil {
+			return nil, err
+		}
+		if metadata.Status == beads.StatusClosed {
+			continue
+		}
+
+		if closer != nil {
+			if err := closer.Close(ctx, m.ID, m.closeComment()); err != nil {
+				return nil, err
+			}
+		}
+		bead, err = beads.CloseBead(bead, m.closeComment(), now)
+		if err != nil {
+			return nil, err
+		}
+		set[m.ID] = bead
+		closed = append(closed, m)
+	}
+	return closed, nil
+}
+
+// recordClosedBeads reports beads closed by the writeback.
+func (r *BeadStoreReconciler) recordClosedBeads(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, closed []mergedBead,
+) {
+	for _, m := range closed {
+		logf.FromContext(ctx).Info("Closed merged bead", "bead", m.ID, "polecat", m.Polecat, "commit", m.Commit)
+		r.Recorder.Event(beadstore, corev1.EventTypeNormal, "BeadClosed",
+			fmt.Sprintf("Closed bead %s: %s", m.ID, m.closeComment()))
+	}
+}
+
+// followUp is a follow-up bead filed for a polecat the Witness gave up on.
+type followUp struct {
+	ID      string
+	Polecat *gastownv1alpha1.Polecat
+}
+
+// fileFollowUpBeads adds a follow-up bead to set for each polecat the Witness
+// gave up on that has none yet. If filer is set, the issue is filed in the
+// tracker first; otherwise the bead gets an ID derived from the polecat, so a
+// retried sync files the same bead. It returns the follow-ups added, even on
+// error, since issues already filed in the tracker must still be recorded.
+func (r *BeadStoreReconciler) fileFollowUpBeads(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, set beads.Set, filer beads.Filer,
+) ([]followUp, error) {
+	polecats, err := r.storePolecats(ctx, beadstore)
+	if err != nil {
+		return nil, err
+	}
+
+	var filed []followUp
+	for i := range polecats {
+		polecat := &polecats[i]
+		if polecat.Status.FollowUpBead != "" ||
+			!meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionGaveUp) {
+			continue
+		}
+
+		var original *beads.Metadata
+		if b, ok := set[polecat.Status.AssignedBead]; ok {
+			if m, err := b.Metadata(); err == nil {
+				original = &m
+			}
+		}
+		m := followUpMetadata(polecat, original)
+
+		if filer != nil {
+			if m.ID, err = filer.File(ctx, m, polecat.Status.AssignedBead); err != nil {
+				return filed, err
+			}
+		} else {
+			sum := sha256.Sum256([]byte(string(polecat.UID) + "/" + polecat.Status.AssignedBead))
+			m.ID = beadstore.Spec.Prefix + hex.EncodeToString(sum[:])[:6]
+		}
+		if _, exists := set[m.ID]; !exists {
+			bead, err := beads.NewBead(m)
+			if err != nil {
+				return filed, err
+			}
+			set[m.ID] = bead
+		}
+		filed = append(filed, followUp{ID: m.ID, Polecat: polecat})
+	}
+	return filed, nil
+}
+
+// followUpMetadata describes the work a polecat gave up on, so the next
+// attempt starts with the context of the failed one.
+func followUpMetadata(polecat *gastownv1alpha1.Polecat, original *beads.Metadata) beads.Metadata {
+	bead := polecat.Status.AssignedBead
+	m := beads.Metadata{
+		Title:     "Follow up on " + bead,
+		Status:    beads.StatusOpen,
+		IssueType: "task",
+	}
+	if original != nil {
+		if original.Title != "" {
+			m.Title = "Follow up: " + original.Title
+		}
+		m.Priority = original.Priority
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "The Witness gave up on polecat %s/%s working on bead %s.\n",
+		polecat.Namespace, polecat.Name, bead)
+	if gaveUp := meta.FindStatusCondition(polecat.Status.Conditions, ConditionGaveUp); gaveUp != nil {
+		fmt.Fprintf(&b, "\n%s\n", gaveUp.Message)
+	}
+	if polecat.Status.Branch != "" {
+		fmt.Fprintf(&b, "\nBranch: %s\n", polecat.Status.Branch)
+	}
+	if f := polecat.Status.LastFailure; f != nil {
+		fmt.Fprintf(&b, "Last failure: attempt %d, %s: %s\n", f.Attempt, f.Reason, f.Message)
+	}
+	if polecat.Status.LogsArtifact != "" {
+		fmt.Fprintf(&b, "Full log: %s\n", polecat.Status.LogsArtifact)
+	}
+	if logs := polecat.Status.LastLogs; logs != "" {
+		if len(logs) > followUpLogExcerptBytes {
+			logs = logs[len(logs)-followUpLogExcerptBytes:]
+			// Start at a line, or at least a rune, boundary
+			if i := strings.IndexByte(logs, '\n'); i >= 0 {
+				logs = logs[i+1:]
+			} else {
+				logs = strings.ToValidUTF8(logs, "")
+			}
+		}
+		fmt.Fprintf(&b, "\nLog excerpt:\n```\n%s\n```\n", strings.TrimRight(logs, "\n"))
+	}
+	m.Description = b.String()
+	return m
+}
+
+// recordFollowUps stores the follow-up beads on their polecats and reports them.
+func (r *BeadStoreReconciler) recordFollowUps(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, filed []followUp,
+) error {
+	var errs []error
+	for _, f := range filed {
+		f.Polecat.Status.FollowUpBead = f.ID
+		if err := applyFields(ctx, r.Client, f.Polecat, fieldManagerBeadStore, beadStorePolecatFields); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update polecat %s: %w", f.Polecat.Name, err))
+			continue
+		}
+		logf.FromContext(ctx).Info("Filed follow-up bead", "bead", f.ID, "polecat", f.Polecat.Name)
+		r.Recorder.Event(beadstore, corev1.EventTypeNormal, "FollowUpFiled",
+			fmt.Sprintf("Filed bead %s to follow up on %s, given up on with polecat %s",
+				f.ID, f.Polecat.Status.AssignedBead, f.Polecat.Name))
+	}
+	return errors.Join(errs...)
+}
+
+// beadSplit is an approved split of a bead, filed for its planning polecat.
+type beadSplit struct {
+	Beads   []string
+	Planner *gastownv1alpha1.Polecat
+}
+
+// splitComment describes the split a planning polecat proposes.
+func splitComment(planner *gastownv1alpha1.Polecat) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "Polecat %s ran out of time or budget on this bead. Planning polecat %s/%s proposes splitting it into:\n",
+		planner.Spec.SplitOf, planner.Namespace, planner.Name)
+	for i, bead := range planner.Status.SplitProposal {
+		fmt.Fprintf(&b, "\n%d. %s", i+1, bead.Title)
+		if bead.Description != "" {
+			fmt.Fprintf(&b, "\n   %s", strings.ReplaceAll(bead.Description, "\n", "\n   "))
+		}
+	}
+	fmt.Fprintf(&b, "\n\nApprove with: kubectl annotate polecat -n %s %s %s=true\n",
+		planner.Namespace, planner.Name, ApproveSplitAnnotation)
+	return b.String()
+}
+
+// proposeSplits comments the split proposed by each planning polecat on the
+// bead it would split, for review alongside the bead.
+func (r *BeadStoreReconciler) proposeSplits(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, set beads.Set,
+) error {
+	polecats, err := r.storePolecats(ctx, beadstore)
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for i := range polecats {
+		planner := &polecats[i]
+		bead, ok := set[planner.Status.AssignedBead]
+		if planner.Spec.SplitOf == "" || len(planner.Status.SplitProposal) == 0 || !ok {
+			continue
+		}
+		if set[bead.ID], err = beads.CommentBead(bead, splitComment(planner), now); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// fileSplitBeads adds the beads of each approved split proposal not filed
+// yet to set, and closes the bead they replace. If filer is set, the issues
+// are filed and closed in the tracker first; otherwise the beads get IDs
+// derived from the planner, so a retried sync files the same beads. It
+// returns the splits filed, even on error, since issues already filed in the
+// tracker must still be recorded.
+func (r *BeadStoreReconciler) fileSplitBeads(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, set beads.Set, filer beads.Filer, closer beads.Closer,
+) ([]beadSplit, error) {
+	polecats, err := r.storePolecats(ctx, beadstore)
+	if err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+	var filed []beadSplit
+	for i := range polecats {
+		planner := &polecats[i]
+		if !splitApproved(planner) || len(planner.Status.SplitBeads) > 0 {
+			continue
+		}
+		original := planner.Status.AssignedBead
+		var priority *int32
+		if b, ok := set[original]; ok {
+			if m, err := b.Metadata(); err == nil {
+				priority = m.Priority
+			}
+		}
+
+		split := beadSplit{Planner: planner}
+		for j, proposed := range planner.Status.SplitProposal {
+			m := beads.Metadata{
+				Title:     proposed.Title,
+				Status:    beads.StatusOpen,
+				Priority:  priority,
+				IssueType: "task",
+				Description: strings.TrimSpace(proposed.Description + fmt.Sprintf("\n\nSplit from %s by planning polecat %s/%s.",
+					original, planner.Namespace, planner.Name)),
+			}
+			if filer != nil {
+				if m.ID, err = filer.File(ctx, m, original); err != nil {
+					if len(split.Beads) > 0 {
+						filed = append(filed, split)
+					}
+					return filed, err
+				}
+			} else {
+				sum := sha256.Sum256([]byte(fmt.Sprintf("%s/%s/%d", planner.UID, original, j)))
+				m.ID = beadstore.Spec.Prefix + hex.EncodeToString(sum[:])[:6]
+			}
+			if _, exists := set[m.ID]; !exists {
+				bead, err := beads.NewBead(m)
+				if err != nil {
+					return filed, err
+				}
+				set[m.ID] = bead
+			}
+			split.Beads = append(split.Beads, m.ID)
+		}
+		filed = append(filed, split)
+
+		reason := fmt.Sprintf("Split into %s", strings.Join(split.Beads, ", "))
+		if closer != nil {
+			if err := closer.Close(ctx, original, reason); err != nil {
+				return filed, err
+			}
+		}
+		if bead, ok := set[original]; ok {
+			if set[original], err = beads.CloseBead(bead, reason, now); err != nil {
+				return filed, err
+			}
+		}
+	}
+	return filed, nil
+}
+
+// recordSplits stores the beads filed for approved splits on their planning
+// polecats and reports them.
+func (r *BeadStoreReconciler) recordSplits(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, filed []beadSplit,
+) error {
+	var errs []error
+	for _, s := range filed {
+		s.Planner.Status.SplitBeads = s.Beads
+		if err := applyFields(ctx, r.Client, s.Planner, fieldManagerBeadStore, beadStorePolecatFields); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update polecat %s: %w", s.Planner.Name, err))
+			continue
+		}
+		logf.FromContext(ctx).Info("Split bead", "bead", s.Planner.Status.AssignedBead, "beads", s.Beads,
+			"polecat", s.Planner.Name)
+		r.Recorder.Event(beadstore, corev1.EventTypeNormal, "BeadSplit",
+			fmt.Sprintf("Split bead %s into %s, as approved on polecat %s",
+				s.Planner.Status.AssignedBead, strings.Join(s.Beads, ", "), s.Planner.Name))
+	}
+	return errors.Join(errs...)
+}
+
+// convoySummary is a summary bead opened for a completed convoy.
+type convoySummary struct {
+	ID     string
+	Convoy *gastownv1alpha1.Convoy
+}
+
+// summaryBeadAction returns the summaryBead completion action of the convoy,
+// or nil if it has none.
+func summaryBeadAction(convoy *gastownv1alpha1.Convoy) *gastownv1alpha1.SummaryBeadAction {
+	for _, action := range convoy.Spec.OnComplete {
+		if action.SummaryBead != nil {
+			return action.SummaryBead
+		}
+	}
+	return nil
+}
+
+// fileSummaryBeads adds a summary bead to set for each Complete convoy of the
+// BeadStore's rig that asks for one and has none yet. If filer is set, the
+// issue is filed in the tracker first, related to the convoy's first bead;
+// otherwise the bead gets an ID derived from the convoy, so a retried sync
+// files the same bead. It returns the summaries added, even on error, since
+// issues already filed in the tracker must still be recorded.
+func (r *BeadStoreReconciler) fileSummaryBeads(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, set beads.Set, filer beads.Filer,
+) ([]convoySummary, error) {
+	var convoys gastownv1alpha1.ConvoyList
+	if err := r.List(ctx, &convoys, client.InNamespace(beadstore.Namespace)); err != nil {
+		return nil, fmt.Errorf("failed to list convoys: %w", err)
+	}
+	sort.Slice(convoys.Items, func(i, j int) bool { return convoys.Items[i].Name < convoys.Items[j].Name })
+
+	var filed []convoySummary
+	for i := range convoys.Items {
+		convoy := &convoys.Items[i]
+		action := summaryBeadAction(convoy)
+		if action == nil || convoy.Status.Phase != gastownv1alpha1.ConvoyPhaseComplete ||
+			convoy.Status.SummaryBead != "" || convoy.Spec.RigRef != beadstore.Spec.RigRef ||
+			len(convoy.Spec.TrackedBeads) == 0 || !strings.HasPrefix(convoy.Spec.TrackedBeads[0], beadstore.Spec.Prefix) {
+			continue
+		}
+
+		m := summaryMetadata(convoy, action)
+		if filer != nil {
+			var err error
+			if m.ID, err = filer.File(ctx, m, convoy.Spec.TrackedBeads[0]); err != nil {
+				return filed, err
+			}
+		} else {
+			sum := sha256.Sum256([]byte(string(convoy.UID) + "/summary"))
+			m.ID = beadstore.Spec.Prefix + hex.EncodeToString(sum[:])[:6]
+		}
+		if _, exists := set[m.ID]; !exists {
+			bead, err := beads.NewBead(m)
+			if err != nil {
+				return filed, err
+			}
+			set[m.ID] = bead
+		}
+		filed = append(filed, convoySummary{ID: m.ID, Convoy: convoy})
+	}
+	return filed, nil
+}
+
+// summaryMetadata describes a completed convoy with the outcome of each of
+// its beads.
+func summaryMetadata(convoy *gastownv1alpha1.Convoy, action *gastownv1alpha1.SummaryBeadAction) beads.Metadata {
+	m := beads.Metadata{
+		Title:     action.Title,
+		Status:    beads.StatusOpen,
+		IssueType: "task",
+	}
+	if m.Title == "" {
+		m.Title = fmt.Sprintf("Convoy %s complete", convoy.Name)
+	}
+
+	var b strings.Builder
+	fmt.Fprintf(&b, "Convoy %s/%s completed %s beads: %s\n", convoy.Namespace, convoy.Name,
+		convoy.Status.Progress, convoy.Spec.Description)
+	if len(convoy.Status.Outcomes) > 0 {
+		b.WriteString("\n")
+	}
+	for _, outcome := range convoy.Status.Outcomes {
+		fmt.Fprintf(&b, "- %s", outcome.Bead)
+		if outcome.Polecat != "" {
+			fmt.Fprintf(&b, ": polecat %s", outcome.Polecat)
+		}
+		if outcome.MergeCommit != "" {
+			fmt.Fprintf(&b, ", merged in %s", outcome.MergeCommit)
+		}
+		if outcome.CostUSD != "" {
+			fmt.Fprintf(&b, ", $%s", outcome.CostUSD)
+		}
+		if outcome.Duration != nil {
+			fmt.Fprintf(&b, ", took %s", outcome.Duration.Duration)
+		}
+		b.WriteString("\n")
+	}
+	m.Description = b.String()
+	return m
+}
+
+// recordSummaries stores the summary beads on their convoys and reports them.
+func (r *BeadStoreReconciler) recordSummaries(
+	ctx context.Context, beadstore *gastownv1alpha1.BeadStore, filed []convoySummary,
+) error {
+	var errs []error
+	for _, s := range filed {
+		s.Convoy.Status.SummaryBead = s.ID
+		if err := applyFields(ctx, r.Client, s.Convoy, fieldManagerBeadStore, beadStoreConvoyFields); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update convoy %s: %w", s.Convoy.Name, err))
+			continue
+		}
+		logf.FromContext(ctx).Info("Filed convoy summary bead", "bead", s.ID, "convoy", s.Convoy.Name)
+		r.Recorder.Event(beadstore, corev1.EventTypeNormal, "SummaryFiled",
+			fmt.Sprintf("Filed bead %s summarizing convoy %s", s.ID, s.Convoy.Name))
+	}
+	return errors.Join(errs...)
+}
+
+// beadStoresForConvoy maps a Convoy to the BeadStores of its rig
+func (r *BeadStoreReconciler) beadStoresForConvoy(ctx context.Context, obj client.Object) []reconcile.Request {
+	convoy, ok := obj.(*gastownv1alpha1.Convoy)
+	if !ok || convoy.Spec.RigRef == "" {
+		return nil
+	}
+
+	var stores gastownv1alpha1.BeadStoreList
+	if err := r.List(ctx, &stores, client.InNamespace(convoy.Namespace)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list beadstores for convoy", "convoy", convoy.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, store := range stores.Items {
+		if store.Spec.RigRef == convoy.Spec.RigRef {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: store.Name, Namespace: store.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// convoySummaryDue filters Convoy updates down to convoys asking for a
+// summary bead becoming Complete.
+var convoySummaryDue = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	DeleteFunc: func(event.DeleteEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldConvoy, okOld := e.ObjectOld.(*gastownv1alpha1.Convoy)
+		newConvoy, okNew := e.ObjectNew.(*gastownv1alpha1.Convoy)
+		if !okOld || !okNew {
+			return false
+		}
+		return summaryBeadAction(newConvoy) != nil &&
+			oldConvoy.Status.Phase != gastownv1alpha1.ConvoyPhaseComplete &&
+			newConvoy.Status.Phase == gastownv1alpha1.ConvoyPhaseComplete
+	},
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
+// beadStoresForPolecat maps a Polecat to the BeadStores of its rig
+func (r *BeadStoreReconciler) beadStoresForPolecat(ctx context.Context, obj client.Object) []reconcile.Request {
+	polecat, ok := obj.(*gastownv1alpha1.Polecat)
+	if !ok || polecat.Status.AssignedBead == "" {
+		return nil
+	}
+
+	var stores gastownv1alpha1.BeadStoreList
+	if err := r.List(ctx, &stores, client.InNamespace(polecat.Namespace)); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list beadstores for polecat", "polecat", polecat.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, store := range stores.Items {
+		if store.Spec.RigRef == polecat.Spec.Rig && strings.HasPrefix(polecat.Status.AssignedBead, store.Spec.Prefix) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: store.Name, Namespace: store.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// polecatWritebackChanged filters Polecat updates down to branches becoming
+// merged, the Witness giving up and splits being proposed or approved, the
+// only changes the bead writeback acts on.
+var polecatWritebackChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	DeleteFunc: func(event.DeleteEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPolecat, okOld := e.ObjectOld.(*gastownv1alpha1.Polecat)
+		newPolecat, okNew := e.ObjectNew.(*gastownv1alpha1.Polecat)
+		if !okOld || !okNew {
+			return false
+		}
+		became := func(condType string) bool {
+			return !meta.IsStatusConditionTrue(oldPolecat.Status.Conditions, condType) &&
+				meta.IsStatusConditionTrue(newPolecat.Status.Conditions, condType)
+		}
+		return became(ConditionMerged) || became(ConditionGaveUp) ||
+			len(newPolecat.Status.SplitProposal) > len(oldPolecat.Status.SplitProposal) ||
+			splitApproved(newPolecat) && !splitApproved(oldPolecat)
+	},
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
@@ -0,0 +1,317 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/beads"
+)
+
+var _ = Describe("Bead writeback", func() {
+	var (
+		ctx      context.Context
+		scheme   *runtime.Scheme
+		server   *httptest.Server
+		mu       sync.Mutex
+		comments []string
+		closed   bool
+		filed    []string
+		rig      *gastownv1alpha1.Rig
+		store    *gastownv1alpha1.BeadStore
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		comments, closed, filed = nil, false, nil
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			switch {
+			case r.Method == http.MethodPost && r.URL.Path == "/repos/org/app/issues":
+				body, _ := io.ReadAll(r.Body)
+				filed = append(filed, string(body))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = fmt.Fprint(w, `{"number": 5}`)
+			case r.Method == http.MethodGet && r.URL.Path == "/repos/org/app/issues":
+				state := "open"
+				if closed {
+					state = "closed"
+				}
+				_, _ = fmt.Fprintf(w, `[{"number": 3, "title": "Fix login", "state": %q}]`, state)
+			case r.Method == http.MethodPost && r.URL.Path == "/repos/org/app/issues/3/comments":
+				body, _ := io.ReadAll(r.Body)
+				comments = append(comments, string(body))
+				w.WriteHeader(http.StatusCreated)
+				_, _ = fmt.Fprint(w, `{}`)
+			case r.Method == http.MethodPatch && r.URL.Path == "/repos/org/app/issues/3":
+				closed = true
+				_, _ = fmt.Fprint(w, `{}`)
+			default:
+				http.NotFound(w, r)
+			}
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	BeforeEach(func() {
+		rig = &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec:       gastownv1alpha1.RigSpec{GitURL: "git@github.com:org/app.git", BeadsPrefix: "gh"},
+		}
+		store = &gastownv1alpha1.BeadStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-issues", Namespace: "default"},
+			Spec: gastownv1alpha1.BeadStoreSpec{
+				RigRef:  "app",
+				Prefix:  "gh-",
+				Backend: gastownv1alpha1.BeadBackendGitHub,
+				GitHub:  &gastownv1alpha1.GitHubBeadSource{APIURL: server.URL, IncludeClosed: true},
+			},
+		}
+	})
+
+	// syncStore reconciles the BeadStore until its issues are imported, then once more
+	syncStore := func(c client.Client, recorder record.EventRecorder) {
+		r := &BeadStoreReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: store.Name, Namespace: store.Namespace}}
+		for range 3 { // the first reconcile adds the finalizer
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+		}
+	}
+
+	It("should close the issue of a merged polecat's bead with the commit and pull request", func() {
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: "app", BeadID: "gh-3"},
+			Status: gastownv1alpha1.PolecatStatus{
+				AssignedBead:   "gh-3",
+				MergedCommit:   "abc123",
+				PullRequestURL: "https://github.com/org/app/pull/9",
+				Conditions: []metav1.Condition{{
+					Type:               ConditionMerged,
+					Status:             metav1.ConditionTrue,
+					Reason:             "PullRequestMerged",
+					LastTransitionTime: metav1.Now(),
+				}},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(rig, store, polecat).
+			WithStatusSubresource(&gastownv1alpha1.BeadStore{}).
+			Build()
+
+		recorder := record.NewFakeRecorder(10)
+		syncStore(c, recorder)
+
+		Expect(comments).To(ConsistOf(
+			`{"body":"Merged by polecat furiosa in commit abc123 (https://github.com/org/app/pull/9)"}`))
+		Expect(closed).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("BeadClosed")))
+
+		var updated gastownv1alpha1.BeadStore
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(store), &updated)).To(Succeed())
+		Expect(updated.Status.Beads).To(HaveLen(1))
+		Expect(updated.Status.Beads[0].Status).To(Equal("closed"))
+	})
+
+	It("should file a follow-up issue for a polecat the Witness gave up on", func() {
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "default"},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: "app", BeadID: "gh-3"},
+			Status: gastownv1alpha1.PolecatStatus{
+				AssignedBead: "gh-3",
+				Branch:       "polecat/nux",
+				LastLogs:     "cloning\nerror: tests failed\n",
+				Conditions: []metav1.Condition{{
+					Type:               ConditionGaveUp,
+					Status:             metav1.ConditionTrue,
+					Reason:             "TaskIncomplete",
+					Message:            "Gave up on bead gh-3 after 1h0m0s degraded (TaskIncomplete): tests fail",
+					LastTransitionTime: metav1.Now(),
+				}},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(rig, store, polecat).
+			WithStatusSubresource(&gastownv1alpha1.BeadStore{}, &gastownv1alpha1.Polecat{}).
+			Build()
+		recorder := record.NewFakeRecorder(10)
+		syncStore(c, recorder)
+
+		Expect(filed).To(HaveLen(1), "the follow-up is filed once")
+		Expect(filed[0]).To(ContainSubstring(`"title":"Follow up: Fix login"`))
+		Expect(filed[0]).To(ContainSubstring("Branch: polecat/nux"))
+		Expect(filed[0]).To(ContainSubstring("error: tests failed"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("FollowUpFiled")))
+
+		var updated gastownv1alpha1.Polecat
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), &updated)).To(Succeed())
+		Expect(updated.Status.FollowUpBead).To(Equal("gh-5"))
+	})
+
+	It("should derive stable follow-up bead IDs for git-backed stores", func() {
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "default", UID: "1234"},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: "app"},
+			Status: gastownv1alpha1.PolecatStatus{
+				AssignedBead: "gh-3",
+				Conditions: []metav1.Condition{{
+					Type:               ConditionGaveUp,
+					Status:             metav1.ConditionTrue,
+					Reason:             "Stalled",
+					LastTransitionTime: metav1.Now(),
+				}},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(polecat).Build()
+		r := &BeadStoreReconciler{Client: c, Scheme: scheme}
+
+		set := beads.Set{}
+		first, err := r.fileFollowUpBeads(ctx, store, set, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(first).To(HaveLen(1))
+		Expect(set).To(HaveKey(first[0].ID))
+		Expect(first[0].ID).To(HavePrefix("gh-"))
+
+		// A retried sync files the same bead again, not a second one
+		again, err := r.fileFollowUpBeads(ctx, store, set, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again[0].ID).To(Equal(first[0].ID))
+		Expect(set).To(HaveLen(1))
+	})
+	It("should comment a proposed split and file it once approved for git-backed stores", func() {
+		planner := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "nux-split", Namespace: "default", UID: "5678"},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: "app", BeadID: "gh-3", SplitOf: "nux"},
+			Status: gastownv1alpha1.PolecatStatus{
+				AssignedBead: "gh-3",
+				SplitProposal: []gastownv1alpha1.ProposedBead{
+					{Title: "Add the login form"},
+					{Title: "Validate credentials", Description: "Check them against the API"},
+				},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(planner).
+			WithStatusSubresource(&gastownv1alpha1.Polecat{}).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &BeadStoreReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+		original, err := beads.NewBead(beads.Metadata{ID: "gh-3", Title: "Fix login", Status: beads.StatusOpen})
+		Expect(err).NotTo(HaveOccurred())
+		set := beads.Set{"gh-3": original}
+
+		Expect(r.proposeSplits(ctx, store, set)).To(Succeed())
+		Expect(string(set["gh-3"].Raw)).To(ContainSubstring("1. Add the login form"))
+		Expect(string(set["gh-3"].Raw)).To(ContainSubstring(ApproveSplitAnnotation + "=true"))
+
+		// Nothing is filed until the split is approved
+		filed, err := r.fileSplitBeads(ctx, store, set, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filed).To(BeEmpty())
+
+		planner.Annotations = map[string]string{ApproveSplitAnnotation: "true"}
+		Expect(c.Update(ctx, planner)).To(Succeed())
+		filed, err = r.fileSplitBeads(ctx, store, set, nil, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filed).To(HaveLen(1))
+		Expect(filed[0].Beads).To(HaveLen(2))
+		Expect(set).To(HaveLen(3))
+		m, err := set["gh-3"].Metadata()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Status).To(Equal(beads.StatusClosed))
+		m, err = set[filed[0].Beads[1]].Metadata()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Title).To(Equal("Validate credentials"))
+		Expect(m.Description).To(ContainSubstring("Split from gh-3"))
+
+		Expect(r.recordSplits(ctx, store, filed)).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring("BeadSplit")))
+		var updated gastownv1alpha1.Polecat
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(planner), &updated)).To(Succeed())
+		Expect(updated.Status.SplitBeads).To(Equal(filed[0].Beads))
+	})
+
+	It("should open a summary bead for a completed convoy asking for one", func() {
+		convoy := &gastownv1alpha1.Convoy{
+			ObjectMeta: metav1.ObjectMeta{Name: "wave-1", Namespace: "default", UID: "9012"},
+			Spec: gastownv1alpha1.ConvoySpec{
+				Description:  "Login fixes",
+				TrackedBeads: []string{"gh-3"},
+				RigRef:       "app",
+				OnComplete: []gastownv1alpha1.ConvoyCompletionAction{
+					{Name: "summary", SummaryBead: &gastownv1alpha1.SummaryBeadAction{}},
+				},
+			},
+			Status: gastownv1alpha1.ConvoyStatus{
+				Phase:    gastownv1alpha1.ConvoyPhaseComplete,
+				Progress: "1/1",
+				Outcomes: []gastownv1alpha1.BeadOutcome{
+					{Bead: "gh-3", Polecat: "nux", MergeCommit: "abc123", CostUSD: "0.42"},
+				},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(convoy).
+			WithStatusSubresource(&gastownv1alpha1.Convoy{}).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &BeadStoreReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+		set := beads.Set{}
+		filed, err := r.fileSummaryBeads(ctx, store, set, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filed).To(HaveLen(1))
+		m, err := set[filed[0].ID].Metadata()
+		Expect(err).NotTo(HaveOccurred())
+		Expect(m.Title).To(Equal("Convoy wave-1 complete"))
+		Expect(m.Description).To(ContainSubstring("- gh-3: polecat nux, merged in abc123, $0.42"))
+
+		Expect(r.recordSummaries(ctx, store, filed)).To(Succeed())
+		Expect(recorder.Events).To(Receive(ContainSubstring("SummaryFiled")))
+		var updated gastownv1alpha1.Convoy
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(convoy), &updated)).To(Succeed())
+		Expect(updated.Status.SummaryBead).To(Equal(filed[0].ID))
+
+		// The convoy has its summary; nothing is filed again
+		filed, err = r.fileSummaryBeads(ctx, store, set, nil)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(filed).To(BeEmpty())
+	})
+})
@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// signingGitClient records the signing key it is given and its cleanup.
+type signingGitClient struct {
+	mockGitClient
+	format  string
+	key     string
+	cleaned bool
+}
+
+func (m *signingGitClient) SetSigningKey(format string, key []byte) error {
+	m.format = format
+	m.key = string(key)
+	return nil
+}
+
+func (m *signingGitClient) Cleanup() {
+	m.cleaned = true
+}
+
+var _ = Describe("Commit signing", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	newRig := func(signing *gastownv1alpha1.CommitSigningSpec) *gastownv1alpha1.Rig {
+		return &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec: gastownv1alpha1.RigSpec{
+				GitURL:  "git@github.com:org/app.git",
+				Signing: signing,
+			},
+		}
+	}
+
+	It("should sign the Refinery's commits with the rig's key", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newRig(&gastownv1alpha1.CommitSigningSpec{
+				Format:       gastownv1alpha1.CommitSigningSSH,
+				KeySecretRef: gastownv1alpha1.SecretReference{Name: "signing-key"},
+			}),
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "signing-key", Namespace: "default"},
+				Data:       map[string][]byte{pod.SigningKeyKey: []byte("ssh-private-key")},
+			},
+		).Build()
+
+		gitClient := &signingGitClient{}
+		r := &RefineryReconciler{
+			Client: c,
+			Scheme: scheme,
+			GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+				return gitClient
+			},
+		}
+		refinery := &gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       gastownv1alpha1.RefinerySpec{RigRef: "app"},
+		}
+
+		_, cleanup, err := r.openRepository(ctx, refinery)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(gitClient.format).To(Equal(git.SigningFormatSSH))
+		Expect(gitClient.key).To(Equal("ssh-private-key"))
+		cleanup()
+		Expect(gitClient.cleaned).To(BeTrue(), "the key material should be removed with the clone")
+	})
+
+	It("should refuse to merge with a client that cannot sign", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			newRig(&gastownv1alpha1.CommitSigningSpec{KeySecretRef: gastownv1alpha1.SecretReference{Name: "signing-key"}}),
+		).Build()
+		r := &RefineryReconciler{
+			Client: c,
+			Scheme: scheme,
+			GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+				return &mockGitClient{}
+			},
+		}
+		refinery := &gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       gastownv1alpha1.RefinerySpec{RigRef: "app"},
+		}
+
+		_, _, err := r.openRepository(ctx, refinery)
+		Expect(err).To(MatchError(ContainSubstring("does not support commit signing")))
+	})
+
+	It("should report unverified signatures on the SignaturesVerified condition", func() {
+		rig := newRig(&gastownv1alpha1.CommitSigningSpec{KeySecretRef: gastownv1alpha1.SecretReference{Name: "signing-key"}})
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &RefineryReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		refinery := &gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       gastownv1alpha1.RefinerySpec{RigRef: "app"},
+		}
+
+		unverified := fmt.Errorf("merge failed: %w", fmt.Errorf("%w: commit 0123abcd on polecat/furiosa", git.ErrUnverifiedSignature))
+		r.recordSignatureVerification(ctx, refinery, "furiosa", unverified)
+		cond := meta.FindStatusCondition(refinery.Status.Conditions, RefineryConditionSignaturesVerified)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("UnverifiedSignature"))
+		Expect(cond.Message).To(ContainSubstring("commit 0123abcd"))
+		Expect(recorder.Events).To(Receive(ContainSubstring("UnverifiedSignature")))
+
+		// Other failures say nothing about the signatures
+		r.recordSignatureVerification(ctx, refinery, "nux", fmt.Errorf("rebase failed"))
+		Expect(meta.IsStatusConditionFalse(refinery.Status.Conditions, RefineryConditionSignaturesVerified)).To(BeTrue())
+
+		r.recordSignatureVerification(ctx, refinery, "nux", nil)
+		Expect(meta.IsStatusConditionTrue(refinery.Status.Conditions, RefineryConditionSignaturesVerified)).To(BeTrue())
+
+		rig.Spec.Signing = nil
+		Expect(c.Update(ctx, rig)).To(Succeed())
+		r.recordSignatureVerification(ctx, refinery, "nux", nil)
+		Expect(meta.FindStatusCondition(refinery.Status.Conditions, RefineryConditionSignaturesVerified)).To(BeNil())
+	})
+})
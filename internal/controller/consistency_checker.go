@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/fsck"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+// DefaultConsistencyCheckInterval is how often the consistency checker runs
+// unless configured otherwise
+const DefaultConsistencyCheckInterval = 10 * time.Minute
+
+// ConsistencyChecker periodically runs the kubectl gt fsck checks against
+// the cache. It only reports: each inconsistency is logged, recorded as a
+// Warning event on the object it concerns and counted in the
+// gastown_fsck_issues metric. Repairs are left to kubectl gt fsck --repair.
+type ConsistencyChecker struct {
+	client.Client
+	Recorder record.EventRecorder
+
+	// Interval between checks, DefaultConsistencyCheckInterval when zero
+	Interval time.Duration
+}
+
+// NeedLeaderElection runs the checker only on the leader, so that events
+// are not recorded once per replica.
+func (c *ConsistencyChecker) NeedLeaderElection() bool {
+	return true
+}
+
+// Start checks consistency every Interval until ctx is cancelled.
+func (c *ConsistencyChecker) Start(ctx context.Context) error {
+	interval := c.Interval
+	if interval == 0 {
+		interval = DefaultConsistencyCheckInterval
+	}
+	log := logf.FromContext(ctx).WithName("fsck")
+	log.Info("Starting consistency checker", "interval", interval)
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if _, err := c.Check(ctx); err != nil {
+				log.Error(err, "Consistency check failed")
+			}
+		}
+	}
+}
+
+// Check runs the consistency checks once and reports the issues found.
+func (c *ConsistencyChecker) Check(ctx context.Context) ([]fsck.Issue, error) {
+	log := logf.FromContext(ctx).WithName("fsck")
+
+	var polecats gastownv1alpha1.PolecatList
+	if err := c.List(ctx, &polecats); err != nil {
+		return nil, fmt.Errorf("failed to list polecats: %w", err)
+	}
+	var pods corev1.PodList
+	if err := c.List(ctx, &pods, client.HasLabels{fsck.PolecatLabel}); err != nil {
+		return nil, fmt.Errorf("failed to list polecat pods: %w", err)
+	}
+	var refineries gastownv1alpha1.RefineryList
+	if err := c.List(ctx, &refineries); err != nil {
+		return nil, fmt.Errorf("failed to list refineries: %w", err)
+	}
+	var stores gastownv1alpha1.BeadStoreList
+	if err := c.List(ctx, &stores); err != nil {
+		return nil, fmt.Errorf("failed to list bead stores: %w", err)
+	}
+	state := fsck.State{
+		Polecats:   polecats.Items,
+		Pods:       pods.Items,
+		Refineries: refineries.Items,
+		BeadStores: stores.Items,
+	}
+
+	issues := fsck.Check(state)
+	counts := make(map[fsck.Kind]int, len(fsck.Kinds))
+	for _, issue := range issues {
+		counts[issue.Kind]++
+		log.Info("Inconsistency found", "kind", issue.Kind, "namespace", issue.Namespace,
+			"resource", issue.Resource, "name", issue.Name, "message", issue.Message)
+		if obj := issueObject(state, issue); obj != nil && c.Recorder != nil {
+			c.Recorder.Event(obj, "Warning", string(issue.Kind), issue.Message)
+		}
+	}
+	for _, kind := range fsck.Kinds {
+		metrics.UpdateFsckIssues(string(kind), float64(counts[kind]))
+	}
+	return issues, nil
+}
+
+// issueObject returns the object of state an issue is about
+func issueObject(state fsck.State, issue fsck.Issue) runtime.Object {
+	switch issue.Resource {
+	case "Polecat":
+		for i := range state.Polecats {
+			if p := &state.Polecats[i]; p.Namespace == issue.Namespace && p.Name == issue.Name {
+				return p
+			}
+		}
+	case "Pod":
+		for i := range state.Pods {
+			if p := &state.Pods[i]; p.Namespace == issue.Namespace && p.Name == issue.Name {
+				return p
+			}
+		}
+	case "Refinery":
+		for i := range state.Refineries {
+			if r := &state.Refineries[i]; r.Namespace == issue.Namespace && r.Name == issue.Name {
+				return r
+			}
+		}
+	case "BeadStore":
+		for i := range state.BeadStores {
+			if s := &state.BeadStores[i]; s.Namespace == issue.Namespace && s.Name == issue.Name {
+				return s
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,70 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/fsck"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+var _ = Describe("Consistency checker", func() {
+	It("should report inconsistencies as events and metrics", func() {
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+				Spec:       gastownv1alpha1.PolecatSpec{Rig: "app"},
+				Status: gastownv1alpha1.PolecatStatus{
+					Phase:   gastownv1alpha1.PolecatPhaseWorking,
+					PodName: "polecat-furiosa",
+				},
+			},
+			&corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+				Name:      "polecat-toast",
+				Namespace: "default",
+				Labels:    map[string]string{fsck.PolecatLabel: "toast"},
+			}},
+		).Build()
+
+		recorder := record.NewFakeRecorder(10)
+		checker := &ConsistencyChecker{Client: c, Recorder: recorder}
+		issues, err := checker.Check(context.Background())
+		Expect(err).NotTo(HaveOccurred())
+		Expect(issues).To(HaveLen(2))
+
+		Expect(recorder.Events).To(Receive(ContainSubstring("PolecatMissingPod")))
+		Expect(recorder.Events).To(Receive(ContainSubstring("OrphanedPod")))
+		Expect(testutil.ToFloat64(metrics.FsckIssuesGauge.WithLabelValues(string(fsck.OrphanedPod)))).To(Equal(1.0))
+		Expect(testutil.ToFloat64(metrics.FsckIssuesGauge.WithLabelValues(string(fsck.StaleQueueEntry)))).To(Equal(0.0))
+	})
+})
@@ -19,6 +19,8 @@ package controller
 import (
 	"context"
 	"time"
+
+	"github.com/org/gastown-operator/pkg/config"
 )
 
 // Requeue intervals for controller reconciliation.
@@ -41,6 +43,39 @@ const (
 	RequeueRetryTransient = 10 * time.Second
 )
 
+// requeueShort returns RequeueShort unless overridden by the GastownConfig.
+func requeueShort() time.Duration {
+	if d := config.Current().RequeueShort; d > 0 {
+		return d
+	}
+	return RequeueShort
+}
+
+// requeueDefault returns RequeueDefault unless overridden by the GastownConfig.
+func requeueDefault() time.Duration {
+	if d := config.Current().RequeueDefault; d > 0 {
+		return d
+	}
+	return RequeueDefault
+}
+
+// requeueLong returns RequeueLong unless overridden by the GastownConfig.
+func requeueLong() time.Duration {
+	if d := config.Current().RequeueLong; d > 0 {
+		return d
+	}
+	return RequeueLong
+}
+
+// syncInterval returns a resource's spec.syncIntervalSeconds, or def when
+// it is unset.
+func syncInterval(seconds *int32, def time.Duration) time.Duration {
+	if seconds != nil && *seconds > 0 {
+		return time.Duration(*seconds) * time.Second
+	}
+	return def
+}
+
 // Timeout constants for external system calls.
 const (
 	// GTClientTimeout is the maximum time to wait for gt CLI operations.
@@ -71,6 +106,9 @@ const (
 //   - Exists: External resource exists in gt CLI (Rig)
 //   - Healthy: Monitoring is functioning (Witness)
 //   - NotificationSent: Completion notification delivered (Convoy)
+//   - Suspended: Rig is frozen and not starting new work (Rig)
+//   - PullRequest: Pull request opened for the branch (Polecat)
+//   - ChecksPassed: Checks on the branch's pull request passed (Polecat)
 //
 // When adding new condition types:
 //  1. Prefer standard Kubernetes names when semantically appropriate
@@ -0,0 +1,302 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+const (
+	// maxCompletionActionAttempts is how many times a failing completion
+	// action runs before it is given up as Failed
+	maxCompletionActionAttempts = 5
+
+	// completionWebhookTimeout bounds a completion webhook post when the
+	// reconciler has no HTTP client of its own
+	completionWebhookTimeout = 10 * time.Second
+
+	// FollowUpOfLabel marks a Convoy created by a followUpConvoy action; the
+	// value is the Convoy that completed
+	FollowUpOfLabel = "gastown.io/follow-up-of"
+
+	// completionDeliveryHeader identifies a completion webhook post, so that
+	// receivers can drop a post retried after a lost response
+	completionDeliveryHeader = "X-Gastown-Delivery"
+)
+
+// convoyCompletion is the JSON body posted by a webhook completion action.
+type convoyCompletion struct {
+	Convoy      string                        `json:"convoy"`
+	Namespace   string                        `json:"namespace"`
+	Description string                        `json:"description"`
+	Rig         string                        `json:"rig,omitempty"`
+	Progress    string                        `json:"progress"`
+	StartedAt   *metav1.Time                  `json:"startedAt,omitempty"`
+	CompletedAt *metav1.Time                  `json:"completedAt,omitempty"`
+	Outcomes    []gastownv1alpha1.BeadOutcome `json:"outcomes"`
+}
+
+// runCompletionActions runs the spec.onComplete actions of a Complete convoy
+// that have not succeeded or failed yet, and records their results. An action
+// that succeeded never runs again; a failing one is retried, reported by
+// retry, until it has run maxCompletionActionAttempts times. A summaryBead
+// action stays Pending until the rig's BeadStore opened the bead.
+func (r *ConvoyReconciler) runCompletionActions(
+	ctx context.Context, convoy *gastownv1alpha1.Convoy,
+) (retry bool, err error) {
+	if len(convoy.Spec.OnComplete) == 0 {
+		return false, nil
+	}
+	log := logf.FromContext(ctx)
+
+	previous := make(map[string]gastownv1alpha1.CompletionActionResult, len(convoy.Status.OnComplete))
+	for _, result := range convoy.Status.OnComplete {
+		previous[result.Name] = result
+	}
+
+	results := make([]gastownv1alpha1.CompletionActionResult, 0, len(convoy.Spec.OnComplete))
+	for _, action := range convoy.Spec.OnComplete {
+		result, ok := previous[action.Name]
+		if !ok {
+			result = gastownv1alpha1.CompletionActionResult{Name: action.Name, Phase: gastownv1alpha1.CompletionActionPending}
+		}
+		if result.Phase != gastownv1alpha1.CompletionActionPending {
+			results = append(results, result)
+			continue
+		}
+
+		if action.SummaryBead != nil {
+			// Filed by the BeadStore controller, which owns the beads
+			if convoy.Status.SummaryBead != "" {
+				result.Phase = gastownv1alpha1.CompletionActionSucceeded
+				result.Ref = convoy.Status.SummaryBead
+				r.Recorder.Event(convoy, corev1.EventTypeNormal, "CompletionActionSucceeded",
+					fmt.Sprintf("Completion action %s opened bead %s", action.Name, result.Ref))
+			}
+			results = append(results, result)
+			continue
+		}
+
+		now := metav1.Now()
+		result.Attempts++
+		result.LastAttemptTime = &now
+		ref, actionErr := r.runCompletionAction(ctx, convoy, action)
+		switch {
+		case actionErr == nil:
+			result.Phase = gastownv1alpha1.CompletionActionSucceeded
+			result.Ref = ref
+			result.Message = ""
+			log.Info("Completion action succeeded", "action", action.Name, "ref", ref)
+			r.Recorder.Event(convoy, corev1.EventTypeNormal, "CompletionActionSucceeded",
+				fmt.Sprintf("Completion action %s succeeded: %s", action.Name, ref))
+		case result.Attempts >= maxCompletionActionAttempts:
+			result.Phase = gastownv1alpha1.CompletionActionFailed
+			result.Message = actionErr.Error()
+			log.Error(actionErr, "Completion action failed", "action", action.Name, "attempts", result.Attempts)
+			r.Recorder.Event(convoy, corev1.EventTypeWarning, "CompletionActionFailed",
+				fmt.Sprintf("Completion action %s gave up after %d attempts: %v", action.Name, result.Attempts, actionErr))
+		default:
+			result.Message = actionErr.Error()
+			retry = true
+			log.Error(actionErr, "Completion action failed, will retry", "action", action.Name, "attempts", result.Attempts)
+		}
+		results = append(results, result)
+	}
+
+	if equality.Semantic.DeepEqual(results, convoy.Status.OnComplete) {
+		return retry, nil
+	}
+	convoy.Status.OnComplete = results
+	return retry, applyStatusWithout(ctx, r.Client, convoy, fieldManagerConvoy, beadStoreConvoyFields)
+}
+
+// runCompletionAction runs a completion action other than summaryBead,
+// returning what it produced.
+func (r *ConvoyReconciler) runCompletionAction(
+	ctx context.Context, convoy *gastownv1alpha1.Convoy, action gastownv1alpha1.ConvoyCompletionAction,
+) (string, error) {
+	switch {
+	case action.FollowUpConvoy != nil:
+		return r.createFollowUpConvoy(ctx, convoy, action)
+	case action.Tag != nil:
+		return r.tagConvoy(ctx, convoy, action.Tag)
+	case action.Webhook != nil:
+		return r.postConvoyCompletion(ctx, convoy, action)
+	default:
+		return "", fmt.Errorf("action %s has no kind", action.Name)
+	}
+}
+
+// createFollowUpConvoy creates the convoy of a followUpConvoy action, on the
+// rig of the completed convoy. A follow-up already created, e.g. by an
+// attempt whose result was lost, counts as created.
+func (r *ConvoyReconciler) createFollowUpConvoy(
+	ctx context.Context, convoy *gastownv1alpha1.Convoy, action gastownv1alpha1.ConvoyCompletionAction,
+) (string, error) {
+	spec := action.FollowUpConvoy
+	name := spec.Name
+	if name == "" {
+		name = convoy.Name + "-" + action.Name
+	}
+
+	followUp := &gastownv1alpha1.Convoy{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: convoy.Namespace,
+			Labels:    map[string]string{FollowUpOfLabel: convoy.Name},
+		},
+		Spec: gastownv1alpha1.ConvoySpec{
+			Description:  spec.Description,
+			TrackedBeads: spec.TrackedBeads,
+			Parallelism:  spec.Parallelism,
+			RigRef:       convoy.Spec.RigRef,
+			Dependencies: spec.Dependencies,
+		},
+	}
+	if err := r.Create(ctx, followUp); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			return "", fmt.Errorf("failed to create convoy %s: %w", name, err)
+		}
+		existing := &gastownv1alpha1.Convoy{}
+		if err := r.Get(ctx, client.ObjectKeyFromObject(followUp), existing); err != nil {
+			return "", fmt.Errorf("failed to get convoy %s: %w", name, err)
+		}
+		if existing.Labels[FollowUpOfLabel] != convoy.Name {
+			return "", fmt.Errorf("convoy %s already exists and is not a follow-up of %s", name, convoy.Name)
+		}
+	}
+	return name, nil
+}
+
+// tagConvoy tags the target branch of the Refinery of the convoy's rig,
+// returning the tagged commit.
+func (r *ConvoyReconciler) tagConvoy(
+	ctx context.Context, convoy *gastownv1alpha1.Convoy, spec *gastownv1alpha1.TagAction,
+) (string, error) {
+	var refineries gastownv1alpha1.RefineryList
+	if err := r.List(ctx, &refineries, client.InNamespace(convoy.Namespace)); err != nil {
+		return "", fmt.Errorf("failed to list refineries: %w", err)
+	}
+	var refinery *gastownv1alpha1.Refinery
+	for i := range refineries.Items {
+		if refineries.Items[i].Spec.RigRef == convoy.Spec.RigRef {
+			refinery = &refineries.Items[i]
+			break
+		}
+	}
+	if refinery == nil {
+		return "", fmt.Errorf("no refinery for rig %s", convoy.Spec.RigRef)
+	}
+
+	// Tags go through the Refinery's repository and credentials, as its
+	// releases do
+	gitClient, cleanup, err := (&RefineryReconciler{
+		Client:           r.Client,
+		GitClientFactory: r.GitClientFactory,
+		AppTokens:        r.AppTokens,
+	}).openRepository(ctx, refinery)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	tagger, ok := gitClient.(git.Tagger)
+	if !ok {
+		return "", fmt.Errorf("git client does not support tagging")
+	}
+	targetBranch := refinery.Spec.TargetBranch
+	if targetBranch == "" {
+		targetBranch = "main"
+	}
+	message := spec.Message
+	if message == "" {
+		message = fmt.Sprintf("Convoy %s complete", convoy.Name)
+	}
+
+	commit, err := tagger.CreateTag(ctx, spec.Name, targetBranch, message)
+	if err != nil {
+		return "", fmt.Errorf("failed to create tag %s: %w", spec.Name, err)
+	}
+	err = tagger.PushTag(ctx, spec.Name)
+	r.Audit.Record("convoy", audit.ActionPushTag, convoy, map[string]string{"tag": spec.Name, "commit": commit}, err)
+	if err != nil {
+		return "", fmt.Errorf("failed to push tag %s: %w", spec.Name, err)
+	}
+	return commit, nil
+}
+
+// postConvoyCompletion posts the summary of the convoy to the URL of a
+// webhook action, returning the HTTP status.
+func (r *ConvoyReconciler) postConvoyCompletion(
+	ctx context.Context, convoy *gastownv1alpha1.Convoy, action gastownv1alpha1.ConvoyCompletionAction,
+) (string, error) {
+	outcomes := convoy.Status.Outcomes
+	if outcomes == nil {
+		outcomes = []gastownv1alpha1.BeadOutcome{}
+	}
+	body, err := json.Marshal(convoyCompletion{
+		Convoy:      convoy.Name,
+		Namespace:   convoy.Namespace,
+		Description: convoy.Spec.Description,
+		Rig:         convoy.Spec.RigRef,
+		Progress:    convoy.Status.Progress,
+		StartedAt:   convoy.Status.StartedAt,
+		CompletedAt: convoy.Status.CompletedAt,
+		Outcomes:    outcomes,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to encode convoy summary: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, action.Webhook.URL, bytes.NewReader(body))
+	if err != nil {
+		return "", fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(completionDeliveryHeader, string(convoy.UID)+"/"+action.Name)
+
+	httpClient := r.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: completionWebhookTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to post to webhook: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return "", fmt.Errorf("webhook returned %s", resp.Status)
+	}
+	return strconv.Itoa(resp.StatusCode), nil
+}
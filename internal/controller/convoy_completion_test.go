@@ -0,0 +1,287 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// taggingGitClient records the tags it creates and pushes.
+type taggingGitClient struct {
+	mockGitClient
+	created []string
+	pushed  []string
+}
+
+func (m *taggingGitClient) LatestTag(ctx context.Context, prefix string) (string, error) {
+	return "", nil
+}
+
+func (m *taggingGitClient) CreateTag(ctx context.Context, tag, branch, message string) (string, error) {
+	m.created = append(m.created, tag+"@"+branch+": "+message)
+	return "abc123", nil
+}
+
+func (m *taggingGitClient) PushTag(ctx context.Context, tag string) error {
+	m.pushed = append(m.pushed, tag)
+	return nil
+}
+
+var _ = Describe("Convoy completion actions", func() {
+	var (
+		ctx      context.Context
+		scheme   *runtime.Scheme
+		server   *httptest.Server
+		mu       sync.Mutex
+		posts    []convoyCompletion
+		delivery []string
+		status   int
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		posts, delivery, status = nil, nil, http.StatusNoContent
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			mu.Lock()
+			defer mu.Unlock()
+			var body convoyCompletion
+			Expect(json.NewDecoder(r.Body).Decode(&body)).To(Succeed())
+			posts = append(posts, body)
+			delivery = append(delivery, r.Header.Get(completionDeliveryHeader))
+			w.WriteHeader(status)
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	// completedConvoy returns a convoy whose only bead gt-1 is done, with the
+	// given completion actions
+	completedConvoy := func(actions ...gastownv1alpha1.ConvoyCompletionAction) (*gastownv1alpha1.Convoy, *gastownv1alpha1.Polecat) {
+		convoy := &gastownv1alpha1.Convoy{
+			ObjectMeta: metav1.ObjectMeta{Name: "wave-1", Namespace: "default", UID: "1234"},
+			Spec: gastownv1alpha1.ConvoySpec{
+				Description:  "Login fixes",
+				TrackedBeads: []string{"gt-1"},
+				RigRef:       "app",
+				OnComplete:   actions,
+			},
+		}
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "default"},
+			Status: gastownv1alpha1.PolecatStatus{
+				AssignedBead: "gt-1",
+				Phase:        gastownv1alpha1.PolecatPhaseDone,
+			},
+		}
+		return convoy, polecat
+	}
+
+	newReconciler := func(objs ...client.Object) (*ConvoyReconciler, client.Client, *record.FakeRecorder) {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).
+			WithStatusSubresource(&gastownv1alpha1.Convoy{}).Build()
+		recorder := record.NewFakeRecorder(20)
+		return &ConvoyReconciler{Client: c, Scheme: scheme, Recorder: recorder}, c, recorder
+	}
+
+	reconcileConvoy := func(r *ConvoyReconciler, convoy *gastownv1alpha1.Convoy) (ctrl.Result, *gastownv1alpha1.Convoy) {
+		req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(convoy)}
+		result, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		updated := &gastownv1alpha1.Convoy{}
+		Expect(r.Get(ctx, req.NamespacedName, updated)).To(Succeed())
+		return result, updated
+	}
+
+	It("should run the actions once when the convoy completes", func() {
+		convoy, polecat := completedConvoy(
+			gastownv1alpha1.ConvoyCompletionAction{Name: "next", FollowUpConvoy: &gastownv1alpha1.FollowUpConvoyAction{
+				Description: "Login cleanup", TrackedBeads: []string{"gt-2"},
+			}},
+			gastownv1alpha1.ConvoyCompletionAction{Name: "notify", Webhook: &gastownv1alpha1.WebhookAction{URL: server.URL}},
+		)
+		r, c, recorder := newReconciler(convoy, polecat)
+
+		result, updated := reconcileConvoy(r, convoy)
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(updated.Status.Phase).To(Equal(gastownv1alpha1.ConvoyPhaseComplete))
+		Expect(updated.Status.OnComplete).To(HaveLen(2))
+		Expect(updated.Status.OnComplete[0].Phase).To(Equal(gastownv1alpha1.CompletionActionSucceeded))
+		Expect(updated.Status.OnComplete[0].Ref).To(Equal("wave-1-next"))
+		Expect(updated.Status.OnComplete[1].Phase).To(Equal(gastownv1alpha1.CompletionActionSucceeded))
+		Expect(updated.Status.OnComplete[1].Ref).To(Equal("204"))
+
+		var followUp gastownv1alpha1.Convoy
+		Expect(c.Get(ctx, client.ObjectKey{Name: "wave-1-next", Namespace: "default"}, &followUp)).To(Succeed())
+		Expect(followUp.Spec.RigRef).To(Equal("app"))
+		Expect(followUp.Spec.TrackedBeads).To(Equal([]string{"gt-2"}))
+		Expect(followUp.Labels).To(HaveKeyWithValue(FollowUpOfLabel, "wave-1"))
+
+		Expect(posts).To(HaveLen(1))
+		Expect(posts[0].Convoy).To(Equal("wave-1"))
+		Expect(posts[0].Progress).To(Equal("1/1"))
+		Expect(posts[0].Outcomes).To(ConsistOf(HaveField("Bead", "gt-1")))
+		Expect(delivery).To(Equal([]string{"1234/notify"}))
+
+		var events []string
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		Expect(events).To(ContainElement("Normal CompletionActionSucceeded Completion action next succeeded: wave-1-next"))
+
+		// Later reconciles of the complete convoy run nothing again
+		reconcileConvoy(r, updated)
+		Expect(posts).To(HaveLen(1))
+	})
+
+	It("should retry a failing action and give up after the last attempt", func() {
+		status = http.StatusBadGateway
+		convoy, polecat := completedConvoy(
+			gastownv1alpha1.ConvoyCompletionAction{Name: "notify", Webhook: &gastownv1alpha1.WebhookAction{URL: server.URL}},
+		)
+		r, _, recorder := newReconciler(convoy, polecat)
+
+		result, updated := reconcileConvoy(r, convoy)
+		Expect(result.RequeueAfter).NotTo(BeZero())
+		Expect(updated.Status.OnComplete[0].Phase).To(Equal(gastownv1alpha1.CompletionActionPending))
+		Expect(updated.Status.OnComplete[0].Attempts).To(Equal(int32(1)))
+		Expect(updated.Status.OnComplete[0].Message).To(ContainSubstring("502"))
+
+		for range maxCompletionActionAttempts - 1 {
+			result, updated = reconcileConvoy(r, updated)
+		}
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(updated.Status.OnComplete[0].Phase).To(Equal(gastownv1alpha1.CompletionActionFailed))
+		Expect(updated.Status.OnComplete[0].Attempts).To(Equal(int32(maxCompletionActionAttempts)))
+		Expect(posts).To(HaveLen(maxCompletionActionAttempts))
+
+		var events []string
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		Expect(events).To(ContainElement(HavePrefix("Warning CompletionActionFailed Completion action notify gave up after 5 attempts")))
+
+		reconcileConvoy(r, updated)
+		Expect(posts).To(HaveLen(maxCompletionActionAttempts))
+	})
+
+	It("should tag the target branch of the rig's refinery", func() {
+		convoy, polecat := completedConvoy(
+			gastownv1alpha1.ConvoyCompletionAction{Name: "release", Tag: &gastownv1alpha1.TagAction{Name: "login-fixes"}},
+		)
+		rig := &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec:       gastownv1alpha1.RigSpec{GitURL: "git@github.com:org/app.git", BeadsPrefix: "gt"},
+		}
+		refinery := &gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-refinery", Namespace: "default"},
+			Spec:       gastownv1alpha1.RefinerySpec{RigRef: "app", TargetBranch: "release"},
+		}
+		r, _, _ := newReconciler(convoy, polecat, rig, refinery)
+		tagger := &taggingGitClient{}
+		r.GitClientFactory = func(repoDir, gitURL, sshKeyPath string) git.GitClient { return tagger }
+
+		_, updated := reconcileConvoy(r, convoy)
+		Expect(tagger.created).To(Equal([]string{"login-fixes@release: Convoy wave-1 complete"}))
+		Expect(tagger.pushed).To(Equal([]string{"login-fixes"}))
+		Expect(updated.Status.OnComplete[0].Phase).To(Equal(gastownv1alpha1.CompletionActionSucceeded))
+		Expect(updated.Status.OnComplete[0].Ref).To(Equal("abc123"))
+	})
+
+	It("should wait for the rig's BeadStore to open the summary bead", func() {
+		convoy, polecat := completedConvoy(
+			gastownv1alpha1.ConvoyCompletionAction{Name: "summary", SummaryBead: &gastownv1alpha1.SummaryBeadAction{}},
+		)
+		r, c, _ := newReconciler(convoy, polecat)
+
+		result, updated := reconcileConvoy(r, convoy)
+		Expect(result.RequeueAfter).To(BeZero(), "the BeadStore's write triggers the convoy")
+		Expect(updated.Status.OnComplete[0].Phase).To(Equal(gastownv1alpha1.CompletionActionPending))
+
+		updated.Status.SummaryBead = "gt-abc123"
+		Expect(applyFields(ctx, c, updated, fieldManagerBeadStore, beadStoreConvoyFields)).To(Succeed())
+
+		_, updated = reconcileConvoy(r, updated)
+		Expect(updated.Status.OnComplete[0].Phase).To(Equal(gastownv1alpha1.CompletionActionSucceeded))
+		Expect(updated.Status.OnComplete[0].Ref).To(Equal("gt-abc123"))
+	})
+
+	It("should not create a follow-up over an unrelated convoy", func() {
+		convoy, polecat := completedConvoy(
+			gastownv1alpha1.ConvoyCompletionAction{Name: "next", FollowUpConvoy: &gastownv1alpha1.FollowUpConvoyAction{
+				Name: "wave-2", Description: "Login cleanup", TrackedBeads: []string{"gt-2"},
+			}},
+		)
+		existing := &gastownv1alpha1.Convoy{
+			ObjectMeta: metav1.ObjectMeta{Name: "wave-2", Namespace: "default"},
+			Spec:       gastownv1alpha1.ConvoySpec{Description: "other", TrackedBeads: []string{"gt-9"}},
+		}
+		r, _, _ := newReconciler(convoy, polecat, existing)
+
+		_, updated := reconcileConvoy(r, convoy)
+		Expect(updated.Status.OnComplete[0].Phase).To(Equal(gastownv1alpha1.CompletionActionPending))
+		Expect(updated.Status.OnComplete[0].Message).To(ContainSubstring("is not a follow-up of wave-1"))
+	})
+
+	It("should only open summaries for convoys becoming complete", func() {
+		old := &gastownv1alpha1.Convoy{
+			Spec: gastownv1alpha1.ConvoySpec{OnComplete: []gastownv1alpha1.ConvoyCompletionAction{
+				{Name: "summary", SummaryBead: &gastownv1alpha1.SummaryBeadAction{}},
+			}},
+			Status: gastownv1alpha1.ConvoyStatus{Phase: gastownv1alpha1.ConvoyPhaseInProgress},
+		}
+		complete := old.DeepCopy()
+		complete.Status.Phase = gastownv1alpha1.ConvoyPhaseComplete
+		Expect(convoySummaryDue.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: complete})).To(BeTrue())
+		Expect(convoySummaryDue.Update(event.UpdateEvent{ObjectOld: complete, ObjectNew: complete.DeepCopy()})).To(BeFalse())
+
+		complete.Spec.OnComplete = nil
+		Expect(convoySummaryDue.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: complete})).To(BeFalse())
+	})
+
+	It("should leave convoys without actions alone once complete", func() {
+		convoy, polecat := completedConvoy()
+		r, _, _ := newReconciler(convoy, polecat)
+
+		result, updated := reconcileConvoy(r, convoy)
+		Expect(result.RequeueAfter).To(BeZero())
+		Expect(updated.Status.OnComplete).To(BeEmpty())
+	})
+})
@@ -19,24 +19,41 @@ package controller
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"slices"
+	"time"
 
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/pkg/config"
 	gterrors "github.com/org/gastown-operator/pkg/errors"
 	"github.com/org/gastown-operator/pkg/metrics"
+	"github.com/org/gastown-operator/pkg/pod"
 )
 
 const (
 	// ConvoySyncInterval is how often we re-sync convoy status.
-	// Uses RequeueDefault for normal sync operations.
-	ConvoySyncInterval = RequeueDefault
+	// Polecat bead progress triggers convoys directly, so this is only a
+	// fallback for missed events.
+	ConvoySyncInterval = 5 * time.Minute
+
+	// convoyTrackedBeadsField indexes convoys by tracked bead ID
+	convoyTrackedBeadsField = "spec.trackedBeads"
 
 	// Condition types for Convoy
 	ConditionConvoyReady    = "Ready"
@@ -47,13 +64,35 @@ const (
 // It tracks progress by watching Polecat status.
 type ConvoyReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+	// Tuning overrides the controller's workqueue settings. Optional.
+	Tuning config.ControllerTuning
+
+	// GitClientFactory creates git clients for tag completion actions. If
+	// nil, uses git.DefaultGitClientFactory.
+	GitClientFactory git.GitClientFactory
+
+	// AppTokens caches GitHub App installation tokens. If nil, uses git.DefaultAppTokens.
+	AppTokens *git.AppTokenCache
+
+	// HTTPClient posts webhook completion actions. If nil, a client with a
+	// 10s timeout is used.
+	HTTPClient *http.Client
+
+	// Audit records the tags pushed on completion. Optional.
+	Audit *audit.Log
 }
 
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=convoys,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=convoys/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=convoys/finalizers,verbs=update
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=beadstores,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=refineries,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=rigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile tracks convoy progress by watching Polecat status.
 func (r *ConvoyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -67,13 +106,22 @@ func (r *ConvoyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave resources processed by a newer operator alone
+	if skip, err := checkSchemaVersion(ctx, r.Client, r.Recorder, &convoy, &convoy.Status.Conditions); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, err
+	} else if skip {
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
 	log.Info("Reconciling Convoy",
 		"name", convoy.Name,
 		"trackedBeads", len(convoy.Spec.TrackedBeads))
 
-	// If convoy is complete, don't requeue
+	// A complete convoy only runs its completion actions
 	if convoy.Status.Phase == gastownv1alpha1.ConvoyPhaseComplete {
-		return ctrl.Result{}, nil
+		return r.completeConvoy(ctx, &convoy, timer)
 	}
 
 	// Initialize convoy if not started
@@ -87,35 +135,43 @@ func (r *ConvoyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		r.setCondition(&convoy, ConditionConvoyReady, metav1.ConditionTrue, "Started",
 			"Convoy started tracking beads")
 
-		if err := r.Status().Update(ctx, &convoy); err != nil {
+		if err := applyStatusWithout(ctx, r.Client, &convoy, fieldManagerConvoy, beadStoreConvoyFields); err != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(err, "failed to update convoy status")
 		}
 
 		log.Info("Convoy initialized", "trackedBeads", len(convoy.Spec.TrackedBeads))
+		r.Recorder.Event(&convoy, "Normal", "Started",
+			fmt.Sprintf("Tracking %d beads", len(convoy.Spec.TrackedBeads)))
 	}
 
 	// Get all polecats to check their assigned beads
 	var polecatList gastownv1alpha1.PolecatList
 	if err := r.List(ctx, &polecatList); err != nil {
 		log.Error(err, "Failed to list polecats")
+		r.Recorder.Event(&convoy, "Warning", "ListFailed", err.Error())
 		r.setCondition(&convoy, ConditionConvoyReady, metav1.ConditionFalse, "ListFailed",
 			err.Error())
 
-		if updateErr := r.Status().Update(ctx, &convoy); updateErr != nil {
+		if updateErr := applyStatusWithout(ctx, r.Client, &convoy, fieldManagerConvoy, beadStoreConvoyFields); updateErr != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update convoy status")
 		}
 
 		timer.RecordResult(metrics.ResultRequeue)
-		return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+		return ctrl.Result{RequeueAfter: requeueDefault()}, nil
 	}
 
-	// Build a map of bead ID -> polecat phase
+	// Build a map of bead ID -> polecat phase, and the beads done or merged
 	beadStatus := make(map[string]gastownv1alpha1.PolecatPhase)
+	beadDone := make(map[string]bool)
 	for _, polecat := range polecatList.Items {
 		if polecat.Status.AssignedBead != "" {
 			beadStatus[polecat.Status.AssignedBead] = polecat.Status.Phase
+			if polecat.Status.Phase == gastownv1alpha1.PolecatPhaseDone ||
+				meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionMerged) {
+				beadDone[polecat.Status.AssignedBead] = true
+			}
 		}
 	}
 
@@ -130,6 +186,32 @@ func (r *ConvoyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		}
 	}
 
+	for _, beadID := range completed {
+		if !slices.Contains(convoy.Status.CompletedBeads, beadID) {
+			r.Recorder.Event(&convoy, "Normal", "BeadCompleted", "Bead "+beadID+" completed")
+		}
+	}
+
+	convoy.Status.Outcomes = beadOutcomes(&convoy, polecatList.Items)
+
+	// Dispatch the beads whose dependencies are done
+	dispatched, blocked := dispatchBeads(&convoy, beadDone)
+	for _, beadID := range dispatched {
+		if len(beadDependencies(&convoy, beadID)) > 0 && !slices.Contains(convoy.Status.DispatchedBeads, beadID) {
+			r.Recorder.Event(&convoy, "Normal", "BeadDispatched", "Dependencies of bead "+beadID+" are done")
+		}
+	}
+	convoy.Status.DispatchedBeads = dispatched
+	convoy.Status.BlockedBeads = blocked
+
+	// Bead metadata is informational; a failed lookup keeps the last known
+	if summaries, err := trackedBeadSummaries(ctx, r.Client, convoy.Namespace, convoy.Spec.RigRef,
+		convoy.Spec.TrackedBeads); err != nil {
+		log.Error(err, "Failed to resolve bead metadata")
+	} else {
+		convoy.Status.Beads = summaries
+	}
+
 	// Update status
 	convoy.Status.CompletedBeads = completed
 	convoy.Status.PendingBeads = pending
@@ -147,12 +229,13 @@ func (r *ConvoyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 			"All tracked beads completed")
 
 		log.Info("Convoy completed", "completed", len(completed))
+		r.Recorder.Event(&convoy, "Normal", "Completed", "All tracked beads completed")
 	} else {
 		r.setCondition(&convoy, ConditionConvoyComplete, metav1.ConditionFalse, "InProgress",
 			fmt.Sprintf("Progress: %s", convoy.Status.Progress))
 	}
 
-	if err := r.Status().Update(ctx, &convoy); err != nil {
+	if err := applyStatusWithout(ctx, r.Client, &convoy, fieldManagerConvoy, beadStoreConvoyFields); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to update convoy status")
 	}
@@ -161,16 +244,100 @@ func (r *ConvoyReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 		"phase", convoy.Status.Phase,
 		"progress", convoy.Status.Progress)
 
-	timer.RecordResult(metrics.ResultSuccess)
-
-	// Don't requeue if complete
 	if convoy.Status.Phase == gastownv1alpha1.ConvoyPhaseComplete {
-		return ctrl.Result{}, nil
+		return r.completeConvoy(ctx, &convoy, timer)
 	}
 
+	timer.RecordResult(metrics.ResultSuccess)
 	return ctrl.Result{RequeueAfter: ConvoySyncInterval}, nil
 }
 
+// completeConvoy runs the completion actions of a Complete convoy. It does
+// not requeue once they all succeeded or failed.
+func (r *ConvoyReconciler) completeConvoy(
+	ctx context.Context, convoy *gastownv1alpha1.Convoy, timer *metrics.ReconcileTimer,
+) (ctrl.Result, error) {
+	retry, err := r.runCompletionActions(ctx, convoy)
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to update convoy status")
+	}
+	if retry {
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueDefault()}, nil
+	}
+	timer.RecordResult(metrics.ResultSuccess)
+	return ctrl.Result{}, nil
+}
+
+// dispatchBeads splits the tracked beads into those dispatched, whose
+// dependencies are done, and those blocked. A dispatched bead stays
+// dispatched, even once the polecats it waited for are deleted.
+func dispatchBeads(convoy *gastownv1alpha1.Convoy, done map[string]bool) (dispatched, blocked []string) {
+	for _, beadID := range convoy.Spec.TrackedBeads {
+		ready := slices.Contains(convoy.Status.DispatchedBeads, beadID)
+		if !ready {
+			ready = true
+			for _, dep := range beadDependencies(convoy, beadID) {
+				ready = ready && done[dep]
+			}
+		}
+		if ready {
+			dispatched = append(dispatched, beadID)
+		} else {
+			blocked = append(blocked, beadID)
+		}
+	}
+	return dispatched, blocked
+}
+
+// beadOutcomes returns the outcomes of the tracked beads, capped at
+// MaxConvoyOutcomes, from the polecats assigned to them. A polecat of the
+// convoy not assigned its bead yet, e.g. one held for its dependencies, still
+// stands for it.
+func beadOutcomes(convoy *gastownv1alpha1.Convoy, polecats []gastownv1alpha1.Polecat) []gastownv1alpha1.BeadOutcome {
+	byBead := make(map[string]*gastownv1alpha1.Polecat)
+	for i := range polecats {
+		polecat := &polecats[i]
+		if polecat.Status.AssignedBead != "" {
+			byBead[polecat.Status.AssignedBead] = polecat
+		}
+	}
+	for i := range polecats {
+		polecat := &polecats[i]
+		if polecat.Namespace == convoy.Namespace && polecat.Labels[pod.ConvoyLabel] == convoy.Name &&
+			byBead[polecat.Spec.BeadID] == nil {
+			byBead[polecat.Spec.BeadID] = polecat
+		}
+	}
+
+	beads := convoy.Spec.TrackedBeads
+	if len(beads) > gastownv1alpha1.MaxConvoyOutcomes {
+		beads = beads[:gastownv1alpha1.MaxConvoyOutcomes]
+	}
+	outcomes := make([]gastownv1alpha1.BeadOutcome, 0, len(beads))
+	for _, beadID := range beads {
+		outcome := gastownv1alpha1.BeadOutcome{Bead: beadID}
+		if polecat := byBead[beadID]; polecat != nil {
+			outcome.Polecat = polecat.Name
+			outcome.Phase = polecat.Status.Phase
+			outcome.MergeCommit = polecat.Status.MergedCommit
+			if polecat.Status.Usage != nil {
+				outcome.CostUSD = polecat.Status.Usage.USD
+			}
+			if polecat.Status.FinishedAt != nil {
+				took := polecat.Status.FinishedAt.Sub(polecat.CreationTimestamp.Time).Round(time.Second)
+				outcome.Duration = &metav1.Duration{Duration: took}
+			}
+			if polecat.Status.Phase != gastownv1alpha1.PolecatPhaseDone && polecat.Status.LastFailure != nil {
+				outcome.FailureReason = polecat.Status.LastFailure.Reason
+			}
+		}
+		outcomes = append(outcomes, outcome)
+	}
+	return outcomes
+}
+
 // setCondition sets or updates a condition on the Convoy using the standard meta.SetStatusCondition helper.
 func (r *ConvoyReconciler) setCondition(convoy *gastownv1alpha1.Convoy, condType string, status metav1.ConditionStatus, reason, message string) {
 	meta.SetStatusCondition(&convoy.Status.Conditions, metav1.Condition{
@@ -183,13 +350,63 @@ func (r *ConvoyReconciler) setCondition(convoy *gastownv1alpha1.Convoy, condType
 	})
 }
 
+// convoysForPolecat maps a Polecat to the convoys tracking its assigned bead
+func (r *ConvoyReconciler) convoysForPolecat(ctx context.Context, obj client.Object) []reconcile.Request {
+	polecat, ok := obj.(*gastownv1alpha1.Polecat)
+	if !ok || polecat.Status.AssignedBead == "" {
+		return nil
+	}
+
+	var convoys gastownv1alpha1.ConvoyList
+	if err := r.List(ctx, &convoys,
+		client.MatchingFields{convoyTrackedBeadsField: polecat.Status.AssignedBead}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list convoys for polecat", "polecat", polecat.Name)
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(convoys.Items))
+	for _, convoy := range convoys.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: convoy.Name, Namespace: convoy.Namespace},
+		})
+	}
+	return requests
+}
+
+// polecatBeadProgressChanged filters Polecat updates down to bead progress
+// (assigned bead, phase or merge changes), the only fields convoys read.
+var polecatBeadProgressChanged = predicate.Funcs{
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldPolecat, okOld := e.ObjectOld.(*gastownv1alpha1.Polecat)
+		newPolecat, okNew := e.ObjectNew.(*gastownv1alpha1.Polecat)
+		if !okOld || !okNew {
+			return false
+		}
+		return oldPolecat.Status.AssignedBead != newPolecat.Status.AssignedBead ||
+			oldPolecat.Status.Phase != newPolecat.Status.Phase ||
+			meta.IsStatusConditionTrue(oldPolecat.Status.Conditions, ConditionMerged) !=
+				meta.IsStatusConditionTrue(newPolecat.Status.Conditions, ConditionMerged)
+	},
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *ConvoyReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Add index for looking up convoys by tracked bead
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gastownv1alpha1.Convoy{}, convoyTrackedBeadsField, func(rawObj client.Object) []string {
+		convoy := rawObj.(*gastownv1alpha1.Convoy)
+		return convoy.Spec.TrackedBeads
+	}); err != nil {
+		return err
+	}
+
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&gastownv1alpha1.Convoy{}).
+		// Bead progress is reported through Polecat status; enqueue only
+		// the convoys tracking the changed bead
+		Watches(&gastownv1alpha1.Polecat{},
+			handler.EnqueueRequestsFromMapFunc(r.convoysForPolecat),
+			builder.WithPredicates(polecatBeadProgressChanged)).
 		Named("convoy").
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 3, // Limit concurrent convoy processing
-		}).
+		WithOptions(controllerOptions(r.Tuning, 3)). // Limit concurrent convoy processing
 		Complete(r)
 }
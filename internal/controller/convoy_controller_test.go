@@ -18,14 +18,23 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
 )
 
 var _ = Describe("Convoy Controller", func() {
@@ -38,8 +47,9 @@ var _ = Describe("Convoy Controller", func() {
 	BeforeEach(func() {
 		ctx = context.Background()
 		reconciler = &ConvoyReconciler{
-			Client: k8sClient,
-			Scheme: k8sClient.Scheme(),
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: &record.FakeRecorder{},
 		}
 
 		testConvoy = &gastownv1alpha1.Convoy{
@@ -245,3 +255,224 @@ var _ = Describe("Convoy Controller", func() {
 		})
 	})
 })
+
+var _ = Describe("Convoy bead triggers", func() {
+	Context("When mapping polecats to convoys", func() {
+		newReconciler := func(objs ...client.Object) *ConvoyReconciler {
+			scheme := runtime.NewScheme()
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithObjects(objs...).
+				WithIndex(&gastownv1alpha1.Convoy{}, convoyTrackedBeadsField, func(obj client.Object) []string {
+					return obj.(*gastownv1alpha1.Convoy).Spec.TrackedBeads
+				}).
+				Build()
+			return &ConvoyReconciler{Client: c, Scheme: scheme, Recorder: &record.FakeRecorder{}}
+		}
+
+		convoy := func(name string, beads ...string) *gastownv1alpha1.Convoy {
+			return &gastownv1alpha1.Convoy{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       gastownv1alpha1.ConvoySpec{Description: name, TrackedBeads: beads},
+			}
+		}
+
+		It("should enqueue only convoys tracking the polecat's bead", func() {
+			r := newReconciler(convoy("wave-1", "gt-1", "gt-2"), convoy("wave-2", "gt-3"), convoy("wave-3", "gt-1"))
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+				Status:     gastownv1alpha1.PolecatStatus{AssignedBead: "gt-1"},
+			}
+
+			Expect(r.convoysForPolecat(context.Background(), polecat)).To(ConsistOf(
+				reconcile.Request{NamespacedName: types.NamespacedName{Name: "wave-1", Namespace: "default"}},
+				reconcile.Request{NamespacedName: types.NamespacedName{Name: "wave-3", Namespace: "default"}},
+			))
+
+			polecat.Status.AssignedBead = ""
+			Expect(r.convoysForPolecat(context.Background(), polecat)).To(BeEmpty())
+		})
+
+		It("should only react to bead progress changes", func() {
+			old := &gastownv1alpha1.Polecat{Status: gastownv1alpha1.PolecatStatus{
+				AssignedBead: "gt-1", Phase: gastownv1alpha1.PolecatPhaseWorking}}
+
+			heartbeat := old.DeepCopy()
+			now := metav1.Now()
+			heartbeat.Status.LastActivity = &now
+			Expect(polecatBeadProgressChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: heartbeat})).To(BeFalse())
+
+			done := old.DeepCopy()
+			done.Status.Phase = gastownv1alpha1.PolecatPhaseDone
+			Expect(polecatBeadProgressChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: done})).To(BeTrue())
+		})
+	})
+})
+
+var _ = Describe("Convoy events", func() {
+	It("should record start, bead completion and convoy completion", func() {
+		scheme := runtime.NewScheme()
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+		convoy := &gastownv1alpha1.Convoy{
+			ObjectMeta: metav1.ObjectMeta{Name: "wave-1", Namespace: "default"},
+			Spec:       gastownv1alpha1.ConvoySpec{Description: "wave-1", TrackedBeads: []string{"gt-1"}},
+		}
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+			Status: gastownv1alpha1.PolecatStatus{
+				AssignedBead: "gt-1",
+				Phase:        gastownv1alpha1.PolecatPhaseDone,
+			},
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(convoy, polecat).
+			WithStatusSubresource(convoy).
+			Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &ConvoyReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+		_, err := r.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(convoy)})
+		Expect(err).NotTo(HaveOccurred())
+
+		Expect(recorder.Events).To(Receive(Equal("Normal Started Tracking 1 beads")))
+		Expect(recorder.Events).To(Receive(Equal("Normal BeadCompleted Bead gt-1 completed")))
+		Expect(recorder.Events).To(Receive(Equal("Normal Completed All tracked beads completed")))
+	})
+})
+
+var _ = Describe("Convoy dependencies", func() {
+	It("should dispatch beads once the beads they depend on are done or merged", func() {
+		scheme := runtime.NewScheme()
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+		convoy := &gastownv1alpha1.Convoy{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "default"},
+			Spec: gastownv1alpha1.ConvoySpec{
+				Description:  "pipeline",
+				TrackedBeads: []string{"gt-1", "gt-2", "gt-3"},
+				Dependencies: []gastownv1alpha1.BeadDependency{
+					{Bead: "gt-2", DependsOn: []string{"gt-1"}},
+					{Bead: "gt-3", DependsOn: []string{"gt-1", "gt-2"}},
+				},
+			},
+		}
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+			Status: gastownv1alpha1.PolecatStatus{
+				AssignedBead: "gt-1",
+				Phase:        gastownv1alpha1.PolecatPhaseWorking,
+			},
+		}
+		c := fake.NewClientBuilder().
+			WithScheme(scheme).
+			WithObjects(convoy, polecat).
+			WithStatusSubresource(convoy, polecat).
+			Build()
+		recorder := record.NewFakeRecorder(20)
+		r := &ConvoyReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(convoy)}
+
+		_, err := r.Reconcile(context.Background(), req)
+		Expect(err).NotTo(HaveOccurred())
+		var updated gastownv1alpha1.Convoy
+		Expect(c.Get(context.Background(), req.NamespacedName, &updated)).To(Succeed())
+		Expect(updated.Status.DispatchedBeads).To(Equal([]string{"gt-1"}))
+		Expect(updated.Status.BlockedBeads).To(Equal([]string{"gt-2", "gt-3"}))
+
+		// A merged polecat releases the beads waiting for it
+		Expect(c.Get(context.Background(), client.ObjectKeyFromObject(polecat), polecat)).To(Succeed())
+		polecat.Status.Conditions = []metav1.Condition{{
+			Type: ConditionMerged, Status: metav1.ConditionTrue, Reason: "Merged", LastTransitionTime: metav1.Now(),
+		}}
+		Expect(c.Status().Update(context.Background(), polecat)).To(Succeed())
+
+		_, err = r.Reconcile(context.Background(), req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(context.Background(), req.NamespacedName, &updated)).To(Succeed())
+		Expect(updated.Status.DispatchedBeads).To(Equal([]string{"gt-1", "gt-2"}))
+		Expect(updated.Status.BlockedBeads).To(Equal([]string{"gt-3"}))
+
+		var events []string
+		for len(recorder.Events) > 0 {
+			events = append(events, <-recorder.Events)
+		}
+		Expect(events).To(ContainElement("Normal BeadDispatched Dependencies of bead gt-2 are done"))
+		Expect(events).NotTo(ContainElement(ContainSubstring("bead gt-1 are done")))
+
+		// Dispatched beads stay dispatched when the polecats they waited for go away
+		Expect(c.Delete(context.Background(), polecat)).To(Succeed())
+		_, err = r.Reconcile(context.Background(), req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(c.Get(context.Background(), req.NamespacedName, &updated)).To(Succeed())
+		Expect(updated.Status.DispatchedBeads).To(Equal([]string{"gt-1", "gt-2"}))
+	})
+
+	It("should react to polecats being merged", func() {
+		old := &gastownv1alpha1.Polecat{Status: gastownv1alpha1.PolecatStatus{
+			AssignedBead: "gt-1", Phase: gastownv1alpha1.PolecatPhaseDone}}
+		merged := old.DeepCopy()
+		merged.Status.Conditions = []metav1.Condition{{Type: ConditionMerged, Status: metav1.ConditionTrue}}
+		Expect(polecatBeadProgressChanged.Update(event.UpdateEvent{ObjectOld: old, ObjectNew: merged})).To(BeTrue())
+	})
+})
+
+var _ = Describe("Convoy bead outcomes", func() {
+	convoy := func(beads ...string) *gastownv1alpha1.Convoy {
+		return &gastownv1alpha1.Convoy{
+			ObjectMeta: metav1.ObjectMeta{Name: "wave-1", Namespace: "default"},
+			Spec:       gastownv1alpha1.ConvoySpec{Description: "wave-1", TrackedBeads: beads},
+		}
+	}
+
+	It("should report the outcome of each bead from its polecat", func() {
+		created := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+		finished := metav1.NewTime(created.Add(42 * time.Minute))
+		polecats := []gastownv1alpha1.Polecat{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "default", CreationTimestamp: created},
+				Status: gastownv1alpha1.PolecatStatus{
+					AssignedBead: "gt-1",
+					Phase:        gastownv1alpha1.PolecatPhaseDone,
+					MergedCommit: "abc123",
+					Usage:        &gastownv1alpha1.TokenUsage{USD: "0.87"},
+					FinishedAt:   &finished,
+					LastFailure:  &gastownv1alpha1.PolecatFailure{Bead: "gt-1", Attempt: 1, Reason: "RateLimited"},
+				},
+			},
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "slit", Namespace: "default"},
+				Status: gastownv1alpha1.PolecatStatus{
+					AssignedBead: "gt-2",
+					Phase:        gastownv1alpha1.PolecatPhaseStuck,
+					LastFailure:  &gastownv1alpha1.PolecatFailure{Bead: "gt-2", Attempt: 3, Reason: "TaskIncomplete"},
+				},
+			},
+			{
+				// Held for its dependencies, not assigned its bead yet
+				ObjectMeta: metav1.ObjectMeta{Name: "ace", Namespace: "default",
+					Labels: map[string]string{pod.ConvoyLabel: "wave-1"}},
+				Spec: gastownv1alpha1.PolecatSpec{BeadID: "gt-3"},
+			},
+		}
+
+		outcomes := beadOutcomes(convoy("gt-1", "gt-2", "gt-3", "gt-4"), polecats)
+		Expect(outcomes).To(Equal([]gastownv1alpha1.BeadOutcome{
+			{Bead: "gt-1", Polecat: "nux", Phase: gastownv1alpha1.PolecatPhaseDone, MergeCommit: "abc123",
+				CostUSD: "0.87", Duration: &metav1.Duration{Duration: 42 * time.Minute}},
+			{Bead: "gt-2", Polecat: "slit", Phase: gastownv1alpha1.PolecatPhaseStuck, FailureReason: "TaskIncomplete"},
+			{Bead: "gt-3", Polecat: "ace"},
+			{Bead: "gt-4"},
+		}))
+	})
+
+	It("should cap the outcomes", func() {
+		beads := make([]string, gastownv1alpha1.MaxConvoyOutcomes+10)
+		for i := range beads {
+			beads[i] = fmt.Sprintf("gt-%d", i)
+		}
+		outcomes := beadOutcomes(convoy(beads...), nil)
+		Expect(outcomes).To(HaveLen(gastownv1alpha1.MaxConvoyOutcomes))
+		Expect(outcomes[0].Bead).To(Equal("gt-0"))
+	})
+})
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"time"
+
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// ConversionCRDs are the CRDs whose v1alpha2 version is converted to the
+// v1alpha1 storage version by the webhook server
+var ConversionCRDs = []string{"rigs.gastown.gastown.io", "polecats.gastown.gastown.io"}
+
+// conversionCheckInterval is how often the CA bundle is re-read, so that a
+// renewed certificate reaches the CRDs
+const conversionCheckInterval = time.Minute
+
+// +kubebuilder:rbac:groups=apiextensions.k8s.io,resources=customresourcedefinitions,resourceNames=rigs.gastown.gastown.io;polecats.gastown.gastown.io,verbs=patch
+
+// ConversionConfigurator points the conversion of ConversionCRDs at the
+// webhook Service. Kustomize installs get it from patches and cert-manager's
+// CA injection; Helm cannot template the CRDs it installs, so the chart has
+// the manager set it instead.
+type ConversionConfigurator struct {
+	client.Client
+
+	// Service the API server sends conversions to, on port 443
+	Service types.NamespacedName
+
+	// CABundlePath is the PEM file the Service's certificate is verified
+	// with, typically ca.crt next to the webhook server's certificate
+	CABundlePath string
+
+	// applied is the CA bundle the CRDs were last patched with
+	applied []byte
+}
+
+// NeedLeaderElection patches the CRDs only from the leader.
+func (c *ConversionConfigurator) NeedLeaderElection() bool {
+	return true
+}
+
+// Start patches the CRDs, then again whenever the CA bundle changes, until
+// ctx is cancelled.
+func (c *ConversionConfigurator) Start(ctx context.Context) error {
+	log := logf.FromContext(ctx).WithName("conversion")
+	log.Info("Configuring CRD conversion", "service", c.Service, "crds", ConversionCRDs)
+
+	if err := c.Configure(ctx); err != nil {
+		log.Error(err, "Failed to configure CRD conversion")
+	}
+	ticker := time.NewTicker(conversionCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			if err := c.Configure(ctx); err != nil {
+				log.Error(err, "Failed to configure CRD conversion")
+			}
+		}
+	}
+}
+
+// Configure patches the conversion of every CRD in ConversionCRDs, unless
+// they were already patched with the current CA bundle.
+func (c *ConversionConfigurator) Configure(ctx context.Context) error {
+	caBundle, err := os.ReadFile(c.CABundlePath)
+	if err != nil {
+		return fmt.Errorf("reading CA bundle: %w", err)
+	}
+	if bytes.Equal(caBundle, c.applied) {
+		return nil
+	}
+
+	path, port := "/convert", int32(443)
+	patch, err := json.Marshal(map[string]any{
+		"spec": map[string]any{
+			"conversion": apiextensionsv1.CustomResourceConversion{
+				Strategy: apiextensionsv1.WebhookConverter,
+				Webhook: &apiextensionsv1.WebhookConversion{
+					ClientConfig: &apiextensionsv1.WebhookClientConfig{
+						Service: &apiextensionsv1.ServiceReference{
+							Namespace: c.Service.Namespace,
+							Name:      c.Service.Name,
+							Path:      &path,
+							Port:      &port,
+						},
+						CABundle: caBundle,
+					},
+					ConversionReviewVersions: []string{"v1"},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return err
+	}
+	for _, name := range ConversionCRDs {
+		crd := &apiextensionsv1.CustomResourceDefinition{ObjectMeta: metav1.ObjectMeta{Name: name}}
+		if err := c.Patch(ctx, crd, client.RawPatch(types.MergePatchType, patch)); err != nil {
+			return fmt.Errorf("patching conversion of %s: %w", name, err)
+		}
+	}
+	c.applied = caBundle
+	logf.FromContext(ctx).Info("Configured CRD conversion", "service", c.Service)
+	return nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apiextensionsv1 "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+var _ = Describe("CRD conversion configurator", func() {
+	It("should point the CRDs' conversion at the webhook Service with the current CA bundle", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(apiextensionsv1.AddToScheme(scheme)).To(Succeed())
+
+		builder := fake.NewClientBuilder().WithScheme(scheme)
+		for _, name := range ConversionCRDs {
+			builder = builder.WithObjects(&apiextensionsv1.CustomResourceDefinition{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: apiextensionsv1.CustomResourceDefinitionSpec{
+					Conversion: &apiextensionsv1.CustomResourceConversion{Strategy: apiextensionsv1.NoneConverter},
+				},
+			})
+		}
+		c := builder.Build()
+
+		caFile := filepath.Join(GinkgoT().TempDir(), "ca.crt")
+		Expect(os.WriteFile(caFile, []byte("first CA"), 0o600)).To(Succeed())
+		configurator := &ConversionConfigurator{
+			Client:       c,
+			Service:      types.NamespacedName{Namespace: "gastown-system", Name: "gastown-operator-webhook-service"},
+			CABundlePath: caFile,
+		}
+
+		conversion := func(name string) *apiextensionsv1.CustomResourceConversion {
+			crd := &apiextensionsv1.CustomResourceDefinition{}
+			Expect(c.Get(ctx, client.ObjectKey{Name: name}, crd)).To(Succeed())
+			return crd.Spec.Conversion
+		}
+
+		Expect(configurator.Configure(ctx)).To(Succeed())
+		for _, name := range ConversionCRDs {
+			conv := conversion(name)
+			Expect(conv.Strategy).To(Equal(apiextensionsv1.WebhookConverter))
+			Expect(conv.Webhook.ConversionReviewVersions).To(Equal([]string{"v1"}))
+			service := conv.Webhook.ClientConfig.Service
+			Expect(service.Namespace).To(Equal("gastown-system"))
+			Expect(service.Name).To(Equal("gastown-operator-webhook-service"))
+			Expect(*service.Path).To(Equal("/convert"))
+			Expect(conv.Webhook.ClientConfig.CABundle).To(Equal([]byte("first CA")))
+		}
+
+		By("picking up a renewed certificate")
+		Expect(os.WriteFile(caFile, []byte("renewed CA"), 0o600)).To(Succeed())
+		Expect(configurator.Configure(ctx)).To(Succeed())
+		for _, name := range ConversionCRDs {
+			Expect(conversion(name).Webhook.ClientConfig.CABundle).To(Equal([]byte("renewed CA")))
+		}
+	})
+
+	It("should leave the CRDs alone while there is no CA bundle", func() {
+		c := fake.NewClientBuilder().Build()
+		configurator := &ConversionConfigurator{
+			Client:       c,
+			Service:      types.NamespacedName{Namespace: "gastown-system", Name: "webhook-service"},
+			CABundlePath: filepath.Join(GinkgoT().TempDir(), "ca.crt"),
+		}
+		Expect(configurator.Configure(context.Background())).To(MatchError(ContainSubstring("reading CA bundle")))
+	})
+})
@@ -0,0 +1,225 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+// ConditionCredentialExpired is True when the Claude OAuth credentials a
+// polecat, or any polecat of a rig, would start with have expired and cannot
+// be renewed.
+const ConditionCredentialExpired = "CredentialExpired"
+
+const (
+	// claudeCredentialsKey is the key of the Claude CLI's credentials file in
+	// a Claude credentials Secret, as kubectl gt auth stores it
+	claudeCredentialsKey = ".credentials.json"
+
+	// defaultClaudeCredsSecret is the Claude credentials Secret polecats are
+	// slung with by default
+	defaultClaudeCredsSecret = "claude-creds"
+)
+
+// credentialExpiry is the expiry of the Claude credentials of a Secret:
+// that of their short-lived access token, which the agent renews itself when
+// the credentials hold a refresh token.
+type credentialExpiry struct {
+	namespace, secret string
+	expiresAt         time.Time
+	renewable         bool
+}
+
+// tokenExpired reports whether the access token has expired at now.
+func (e credentialExpiry) tokenExpired(now time.Time) bool {
+	return !e.expiresAt.After(now)
+}
+
+// expired reports whether the credentials have expired at now: their access
+// token has, and they cannot renew it.
+func (e credentialExpiry) expired(now time.Time) bool {
+	return !e.renewable && e.tokenExpired(now)
+}
+
+// precedes reports whether e is reported rather than o: expired credentials
+// first, then those whose access token expires first.
+func (e credentialExpiry) precedes(o credentialExpiry, now time.Time) bool {
+	if e.expired(now) != o.expired(now) {
+		return e.expired(now)
+	}
+	return e.expiresAt.Before(o.expiresAt)
+}
+
+// message describes the expiry for conditions and events.
+func (e credentialExpiry) message(now time.Time) string {
+	expiresAt := e.expiresAt.UTC().Format(time.RFC3339)
+	switch {
+	case e.expired(now):
+		return fmt.Sprintf("Claude credentials in Secret %s/%s expired at %s; run 'kubectl gt auth login'",
+			e.namespace, e.secret, expiresAt)
+	case e.tokenExpired(now):
+		return fmt.Sprintf("Claude access token in Secret %s/%s expired at %s; agents renew it with its refresh token",
+			e.namespace, e.secret, expiresAt)
+	case e.renewable:
+		return fmt.Sprintf("Claude access token in Secret %s/%s is valid until %s and renewable",
+			e.namespace, e.secret, expiresAt)
+	}
+	return fmt.Sprintf("Claude credentials in Secret %s/%s are valid until %s",
+		e.namespace, e.secret, expiresAt)
+}
+
+// reason returns the reason of a False CredentialExpired condition.
+func (e credentialExpiry) reason(now time.Time) string {
+	if e.tokenExpired(now) {
+		return "CredentialRenewable"
+	}
+	return "CredentialValid"
+}
+
+// parseCredentialExpiry returns when the OAuth access token of a Claude
+// credentials file expires, and whether the file holds a refresh token to
+// renew it. False for API keys and files without an expiry.
+func parseCredentialExpiry(data []byte) (time.Time, bool, bool) {
+	var credentials struct {
+		ClaudeAiOauth struct {
+			// ExpiresAt is in Unix milliseconds
+			ExpiresAt    int64  `json:"expiresAt"`
+			RefreshToken string `json:"refreshToken"`
+		} `json:"claudeAiOauth"`
+	}
+	if err := json.Unmarshal(data, &credentials); err != nil || credentials.ClaudeAiOauth.ExpiresAt <= 0 {
+		return time.Time{}, false, false
+	}
+	return time.UnixMilli(credentials.ClaudeAiOauth.ExpiresAt), credentials.ClaudeAiOauth.RefreshToken != "", true
+}
+
+// readCredentialExpiry decodes the expiry of the Claude credentials in the
+// named Secret and records it in the credentials expiry gauge, unless the
+// agent renews them itself. False when the Secret is missing or holds no
+// expiring credentials.
+func readCredentialExpiry(ctx context.Context, c client.Reader, namespace, name string) (credentialExpiry, bool, error) {
+	var secret corev1.Secret
+	if err := c.Get(ctx, client.ObjectKey{Name: name, Namespace: namespace}, &secret); err != nil {
+		if apierrors.IsNotFound(err) {
+			metrics.CredentialsExpiry.DeleteLabelValues(namespace, name)
+			return credentialExpiry{}, false, nil
+		}
+		return credentialExpiry{}, false, err
+	}
+
+	expiresAt, renewable, ok := parseCredentialExpiry(secret.Data[claudeCredentialsKey])
+	if !ok {
+		metrics.CredentialsExpiry.DeleteLabelValues(namespace, name)
+		return credentialExpiry{}, false, nil
+	}
+	// An access token renewed with the refresh token expires every hour or
+	// so without anyone having to act
+	if renewable {
+		metrics.CredentialsExpiry.DeleteLabelValues(namespace, name)
+	} else {
+		metrics.CredentialsExpiry.WithLabelValues(namespace, name).Set(float64(expiresAt.Unix()))
+	}
+	return credentialExpiry{namespace: namespace, secret: name, expiresAt: expiresAt, renewable: renewable}, true, nil
+}
+
+// checkCredentials sets the CredentialExpired condition of a polecat from its
+// Claude credentials Secret and reports whether they have expired. Expired
+// credentials fail the agent minutes into its run, so the polecat is held
+// until the Secret is refreshed instead. An expired access token the agent
+// can renew with its refresh token does not hold the polecat.
+func (r *PolecatReconciler) checkCredentials(ctx context.Context, polecat *gastownv1alpha1.Polecat, now time.Time) (bool, error) {
+	ref := polecat.Spec.Kubernetes.ClaudeCredsSecretRef
+	if ref == nil {
+		return false, nil
+	}
+	expiry, ok, err := readCredentialExpiry(ctx, r.Client, polecat.Namespace, ref.Name)
+	if err != nil || !ok {
+		return false, err
+	}
+
+	if !expiry.expired(now) {
+		r.setCondition(polecat, ConditionCredentialExpired, metav1.ConditionFalse, expiry.reason(now), expiry.message(now))
+		return false, nil
+	}
+	if !meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionCredentialExpired) {
+		r.Recorder.Event(polecat, "Warning", "CredentialExpired", expiry.message(now))
+	}
+	r.setCondition(polecat, ConditionCredentialExpired, metav1.ConditionTrue, "CredentialExpired", expiry.message(now))
+	r.setCondition(polecat, ConditionProgressing, metav1.ConditionFalse, "CredentialExpired", expiry.message(now))
+	return true, nil
+}
+
+// checkCredentials sets the CredentialExpired condition of a rig from the
+// Claude credentials its polecats start with: the default claude-creds Secret
+// of its namespace and those of its unfinished polecats. The condition
+// reports expired credentials first, else the Secret expiring first.
+func (r *RigReconciler) checkCredentials(ctx context.Context, rig *gastownv1alpha1.Rig, polecats []gastownv1alpha1.Polecat, now time.Time) {
+	type secretKey struct{ namespace, name string }
+	var keys []secretKey
+	if rig.Status.ChildNamespace != "" {
+		keys = append(keys, secretKey{rig.Status.ChildNamespace, defaultClaudeCredsSecret})
+	}
+	for _, p := range polecats {
+		if p.Spec.Kubernetes == nil || p.Spec.Kubernetes.ClaudeCredsSecretRef == nil ||
+			p.Status.Phase == gastownv1alpha1.PolecatPhaseDone || p.Status.Phase == gastownv1alpha1.PolecatPhaseTerminated {
+			continue
+		}
+		key := secretKey{p.Namespace, p.Spec.Kubernetes.ClaudeCredsSecretRef.Name}
+		if !slices.Contains(keys, key) {
+			keys = append(keys, key)
+		}
+	}
+
+	var first *credentialExpiry
+	for _, key := range keys {
+		expiry, ok, err := readCredentialExpiry(ctx, r.Client, key.namespace, key.name)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "Failed to read Claude credentials", "namespace", key.namespace, "secret", key.name)
+			continue
+		}
+		if ok && (first == nil || expiry.precedes(*first, now)) {
+			first = &expiry
+		}
+	}
+	if first == nil {
+		meta.RemoveStatusCondition(&rig.Status.Conditions, ConditionCredentialExpired)
+		return
+	}
+
+	if !first.expired(now) {
+		r.setCondition(rig, ConditionCredentialExpired, metav1.ConditionFalse, first.reason(now), first.message(now))
+		return
+	}
+	if !meta.IsStatusConditionTrue(rig.Status.Conditions, ConditionCredentialExpired) {
+		r.Recorder.Event(rig, "Warning", "CredentialExpired", first.message(now))
+	}
+	r.setCondition(rig, ConditionCredentialExpired, metav1.ConditionTrue, "CredentialExpired", first.message(now))
+}
@@ -0,0 +1,204 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+// claudeCredentials returns a Claude credentials file expiring at expiresAt.
+func claudeCredentials(expiresAt time.Time) []byte {
+	return fmt.Appendf(nil, `{"claudeAiOauth":{"accessToken":"sk-ant-oat01-test","expiresAt":%d}}`, expiresAt.UnixMilli())
+}
+
+// renewableClaudeCredentials returns a Claude credentials file whose access
+// token expires at expiresAt, with a refresh token to renew it.
+func renewableClaudeCredentials(expiresAt time.Time) []byte {
+	return fmt.Appendf(nil, `{"claudeAiOauth":{"accessToken":"sk-ant-oat01-test","refreshToken":"sk-ant-ort01-test","expiresAt":%d}}`,
+		expiresAt.UnixMilli())
+}
+
+var _ = Describe("Claude credential expiry", func() {
+	It("should decode the expiry of OAuth credentials only", func() {
+		expiresAt := time.UnixMilli(1790000000000)
+		got, renewable, ok := parseCredentialExpiry(claudeCredentials(expiresAt))
+		Expect(ok).To(BeTrue())
+		Expect(renewable).To(BeFalse())
+		Expect(got).To(BeTemporally("==", expiresAt))
+
+		_, renewable, ok = parseCredentialExpiry(renewableClaudeCredentials(expiresAt))
+		Expect(ok).To(BeTrue())
+		Expect(renewable).To(BeTrue())
+
+		_, _, ok = parseCredentialExpiry([]byte(`{"claudeAiOauth":{"accessToken":"sk-ant-oat01-test"}}`))
+		Expect(ok).To(BeFalse())
+		_, _, ok = parseCredentialExpiry([]byte("sk-ant-api03-key"))
+		Expect(ok).To(BeFalse())
+	})
+
+	It("should hold a polecat with expired credentials until they are refreshed", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "gt-creds"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:          "creds-rig",
+				DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository:        "git@github.com:org/repo.git",
+					ClaudeCredsSecretRef: &gastownv1alpha1.SecretReference{Name: "claude-creds"},
+				},
+			},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "claude-creds", Namespace: "gt-creds"},
+			Data:       map[string][]byte{claudeCredentialsKey: claudeCredentials(time.Now().Add(-time.Hour))},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(polecat, secret).
+			WithStatusSubresource(polecat).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+		result, err := r.ensureWorking(ctx, polecat, metrics.NewReconcileTimer("polecat"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(requeueLong()))
+		Expect(meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionCredentialExpired)).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("CredentialExpired")))
+
+		var pods corev1.PodList
+		Expect(c.List(ctx, &pods, client.InNamespace("gt-creds"))).To(Succeed())
+		Expect(pods.Items).To(BeEmpty())
+		expiry := testutil.ToFloat64(metrics.CredentialsExpiry.WithLabelValues("gt-creds", "claude-creds"))
+		Expect(expiry).To(BeNumerically("<", float64(time.Now().Unix())))
+
+		// Refreshing the Secret wakes the held polecat, which then starts
+		current := &gastownv1alpha1.Polecat{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), current)).To(Succeed())
+		Expect(r.polecatsForSecret(ctx, secret)).To(HaveLen(1))
+		expired, err := r.checkCredentials(ctx, current, time.Now().Add(-2*time.Hour))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expired).To(BeFalse())
+		Expect(meta.FindStatusCondition(current.Status.Conditions, ConditionCredentialExpired).Reason).
+			To(Equal("CredentialValid"))
+	})
+
+	It("should not hold a polecat whose expired access token is renewable", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "capable", Namespace: "gt-creds-renew"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig: "creds-rig",
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					ClaudeCredsSecretRef: &gastownv1alpha1.SecretReference{Name: "claude-creds"},
+				},
+			},
+		}
+		now := time.Now()
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "claude-creds", Namespace: "gt-creds-renew"},
+			Data:       map[string][]byte{claudeCredentialsKey: renewableClaudeCredentials(now.Add(-time.Hour))},
+		}).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		// Reported before the refresh token was added
+		metrics.CredentialsExpiry.WithLabelValues("gt-creds-renew", "claude-creds").Set(float64(now.Unix()))
+
+		expired, err := r.checkCredentials(ctx, polecat, now)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(expired).To(BeFalse())
+		cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionCredentialExpired)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Reason).To(Equal("CredentialRenewable"))
+		Expect(cond.Message).To(ContainSubstring("refresh token"))
+		Expect(recorder.Events).To(BeEmpty())
+
+		// Nor is there an expiry to alert on
+		Expect(metrics.CredentialsExpiry.DeleteLabelValues("gt-creds-renew", "claude-creds")).To(BeFalse())
+	})
+
+	It("should report the rig's credentials expiring first", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		now := time.Now()
+		rig := &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "creds-rig"},
+			Status:     gastownv1alpha1.RigStatus{ChildNamespace: "gt-rig-creds"},
+		}
+		polecats := []gastownv1alpha1.Polecat{
+			{
+				ObjectMeta: metav1.ObjectMeta{Name: "slit", Namespace: "gt-rig-creds"},
+				Spec: gastownv1alpha1.PolecatSpec{Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					ClaudeCredsSecretRef: &gastownv1alpha1.SecretReference{Name: "team-creds"},
+				}},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig,
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "claude-creds", Namespace: "gt-rig-creds"},
+				Data:       map[string][]byte{claudeCredentialsKey: claudeCredentials(now.Add(24 * time.Hour))},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "team-creds", Namespace: "gt-rig-creds"},
+				Data:       map[string][]byte{claudeCredentialsKey: claudeCredentials(now.Add(time.Hour))},
+			},
+		).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &RigReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+		r.checkCredentials(ctx, rig, polecats, now)
+		cond := meta.FindStatusCondition(rig.Status.Conditions, ConditionCredentialExpired)
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("gt-rig-creds/team-creds"))
+
+		r.checkCredentials(ctx, rig, polecats, now.Add(2*time.Hour))
+		cond = meta.FindStatusCondition(rig.Status.Conditions, ConditionCredentialExpired)
+		Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+		Expect(recorder.Events).To(Receive(ContainSubstring("team-creds")))
+
+		// Finished polecats no longer count
+		polecats[0].Status.Phase = gastownv1alpha1.PolecatPhaseDone
+		r.checkCredentials(ctx, rig, polecats, now.Add(2*time.Hour))
+		Expect(meta.IsStatusConditionTrue(rig.Status.Conditions, ConditionCredentialExpired)).To(BeFalse())
+	})
+})
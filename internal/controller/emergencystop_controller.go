@@ -0,0 +1,239 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	gterrors "github.com/org/gastown-operator/pkg/errors"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+const (
+	// ConditionEmergencyStopEngaged indicates the stop is holding all rigs.
+	ConditionEmergencyStopEngaged = "Engaged"
+
+	// emergencyStopFinalizer records the release of a stop that is deleted while engaged
+	emergencyStopFinalizer = "gastown.io/emergencystop-release"
+)
+
+// EmergencyStopReconciler reconciles an EmergencyStop object.
+// Holding work is enforced by the Polecat, Refinery and Rig controllers
+// through activeEmergencyStop; this controller keeps the audit trail and
+// terminates agent Pods when requested.
+type EmergencyStopReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=emergencystops,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=emergencystops/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=emergencystops/finalizers,verbs=update
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile records engage and release transitions and terminates agent Pods.
+func (r *EmergencyStopReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	timer := metrics.NewReconcileTimer("emergencystop")
+	defer timer.ObserveDuration()
+
+	var stop gastownv1alpha1.EmergencyStop
+	if err := r.Get(ctx, req.NamespacedName, &stop); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	// Leave resources processed by a newer operator alone
+	if skip, err := checkSchemaVersion(ctx, r.Client, r.Recorder, &stop, &stop.Status.Conditions); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, err
+	} else if skip {
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
+	// Deleting an engaged stop releases it
+	if !stop.DeletionTimestamp.IsZero() {
+		if controllerutil.ContainsFinalizer(&stop, emergencyStopFinalizer) {
+			if stop.Status.Phase == gastownv1alpha1.EmergencyStopPhaseEngaged {
+				r.Recorder.Event(&stop, "Normal", "Released", "Emergency stop deleted; rigs resume work")
+				log.Info("Emergency stop released by deletion", "name", stop.Name)
+			}
+			if err := gterrors.UpdateOnConflict(ctx, r.Client, &stop, func() bool {
+				return controllerutil.RemoveFinalizer(&stop, emergencyStopFinalizer)
+			}); err != nil {
+				timer.RecordResult(metrics.ResultError)
+				return ctrl.Result{}, gterrors.Wrap(err, "failed to remove finalizer")
+			}
+		}
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
+	if !controllerutil.ContainsFinalizer(&stop, emergencyStopFinalizer) {
+		if err := gterrors.UpdateOnConflict(ctx, r.Client, &stop, func() bool {
+			return controllerutil.AddFinalizer(&stop, emergencyStopFinalizer)
+		}); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to add finalizer")
+		}
+		return ctrl.Result{RequeueAfter: time.Millisecond}, nil
+	}
+
+	now := metav1.Now()
+	if !stop.Spec.Engaged {
+		if stop.Status.Phase != gastownv1alpha1.EmergencyStopPhaseReleased {
+			log.Info("Emergency stop released", "name", stop.Name)
+			r.Recorder.Event(&stop, "Normal", "Released", "Emergency stop released; rigs resume work")
+			stop.Status.Phase = gastownv1alpha1.EmergencyStopPhaseReleased
+			stop.Status.ReleasedAt = &now
+		}
+		r.setCondition(&stop, metav1.ConditionFalse, "Released", "Rigs are accepting work")
+		if err := applyStatus(ctx, r.Client, &stop, fieldManagerEmergencyStop); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
+	if stop.Status.Phase != gastownv1alpha1.EmergencyStopPhaseEngaged {
+		log.Info("Emergency stop engaged", "name", stop.Name, "reason", stop.Spec.Reason)
+		r.Recorder.Event(&stop, "Warning", "Engaged", "Emergency stop engaged: "+stop.Spec.Reason)
+		stop.Status.Phase = gastownv1alpha1.EmergencyStopPhaseEngaged
+		stop.Status.EngagedAt = &now
+		stop.Status.TerminatedPolecats = nil
+	}
+	r.setCondition(&stop, metav1.ConditionTrue, "Engaged",
+		"New polecats and merges are held on all rigs: "+stop.Spec.Reason)
+
+	var terminateErr error
+	if stop.Spec.TerminatePods {
+		terminateErr = r.terminateAgentPods(ctx, &stop)
+	}
+
+	if err := applyStatus(ctx, r.Client, &stop, fieldManagerEmergencyStop); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+	}
+	if terminateErr != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, terminateErr
+	}
+
+	timer.RecordResult(metrics.ResultSuccess)
+	return ctrl.Result{}, nil
+}
+
+// terminateAgentPods deletes the agent Pod of every polecat in the cluster.
+// Each termination is recorded as an event on the stop and on the Polecat.
+func (r *EmergencyStopReconciler) terminateAgentPods(ctx context.Context, stop *gastownv1alpha1.EmergencyStop) error {
+	log := logf.FromContext(ctx)
+
+	var polecats gastownv1alpha1.PolecatList
+	if err := r.List(ctx, &polecats); err != nil {
+		return gterrors.Wrap(err, "failed to list polecats")
+	}
+
+	var failed int
+	for i := range polecats.Items {
+		polecat := &polecats.Items[i]
+		p := &corev1.Pod{}
+		podKey := client.ObjectKey{Name: polecatPodName(polecat), Namespace: polecat.Namespace}
+		if err := r.Get(ctx, podKey, p); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to get agent Pod", "polecat", polecat.Name, "namespace", polecat.Namespace)
+				failed++
+			}
+			continue
+		}
+		if !p.DeletionTimestamp.IsZero() {
+			continue
+		}
+		if err := r.Delete(ctx, p); err != nil {
+			if !apierrors.IsNotFound(err) {
+				log.Error(err, "Failed to delete agent Pod", "polecat", polecat.Name, "namespace", polecat.Namespace)
+				failed++
+			}
+			continue
+		}
+
+		key := polecat.Namespace + "/" + polecat.Name
+		if !slices.Contains(stop.Status.TerminatedPolecats, key) {
+			stop.Status.TerminatedPolecats = append(stop.Status.TerminatedPolecats, key)
+		}
+		r.Recorder.Event(stop, "Warning", "PodTerminated", "Terminated agent Pod of polecat "+key)
+		r.Recorder.Event(polecat, "Warning", "EmergencyStop",
+			fmt.Sprintf("Agent Pod terminated by emergency stop %s: %s", stop.Name, stop.Spec.Reason))
+	}
+
+	if failed > 0 {
+		return fmt.Errorf("failed to terminate %d agent pods", failed)
+	}
+	return nil
+}
+
+// setCondition updates the Engaged condition of the EmergencyStop.
+func (r *EmergencyStopReconciler) setCondition(stop *gastownv1alpha1.EmergencyStop, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&stop.Status.Conditions, metav1.Condition{
+		Type:               ConditionEmergencyStopEngaged,
+		Status:             status,
+		ObservedGeneration: stop.Generation,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// activeEmergencyStop returns an engaged EmergencyStop, or nil if none is.
+func activeEmergencyStop(ctx context.Context, c client.Reader) (*gastownv1alpha1.EmergencyStop, error) {
+	var stops gastownv1alpha1.EmergencyStopList
+	if err := c.List(ctx, &stops); err != nil {
+		return nil, err
+	}
+	for i := range stops.Items {
+		if stops.Items[i].Spec.Engaged && stops.Items[i].DeletionTimestamp.IsZero() {
+			return &stops.Items[i], nil
+		}
+	}
+	return nil, nil
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *EmergencyStopReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gastownv1alpha1.EmergencyStop{}).
+		Named("emergencystop").
+		Complete(r)
+}
@@ -0,0 +1,118 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var _ = Describe("EmergencyStop Controller", func() {
+	Context("When engaging an emergency stop", func() {
+		var (
+			ctx      context.Context
+			c        client.Client
+			recorder *record.FakeRecorder
+			r        *EmergencyStopReconciler
+		)
+
+		stopKey := types.NamespacedName{Name: "big-red-button"}
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			c = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&gastownv1alpha1.EmergencyStop{}).
+				WithObjects(
+					&gastownv1alpha1.EmergencyStop{
+						ObjectMeta: metav1.ObjectMeta{Name: stopKey.Name},
+						Spec:       gastownv1alpha1.EmergencyStopSpec{Reason: "runaway agent", Engaged: true, TerminatePods: true},
+					},
+					&gastownv1alpha1.Polecat{ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "rig-a"}},
+					&gastownv1alpha1.Polecat{ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "rig-b"}},
+					&corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "polecat-furiosa", Namespace: "rig-a"}},
+				).
+				Build()
+			recorder = record.NewFakeRecorder(10)
+			r = &EmergencyStopReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		})
+
+		reconcileStop := func() {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: stopKey})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("should terminate agent pods and record the engagement", func() {
+			reconcileStop() // adds the finalizer
+			reconcileStop()
+
+			stop := &gastownv1alpha1.EmergencyStop{}
+			Expect(c.Get(ctx, stopKey, stop)).To(Succeed())
+			Expect(stop.Status.Phase).To(Equal(gastownv1alpha1.EmergencyStopPhaseEngaged))
+			Expect(stop.Status.EngagedAt).NotTo(BeNil())
+			Expect(stop.Status.TerminatedPolecats).To(Equal([]string{"rig-a/furiosa"}))
+
+			err := c.Get(ctx, types.NamespacedName{Name: "polecat-furiosa", Namespace: "rig-a"}, &corev1.Pod{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+			Expect(recorder.Events).To(Receive(ContainSubstring("Engaged Emergency stop engaged: runaway agent")))
+			Expect(recorder.Events).To(Receive(ContainSubstring("PodTerminated")))
+
+			active, err := activeEmergencyStop(ctx, c)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).NotTo(BeNil())
+			Expect(active.Name).To(Equal(stopKey.Name))
+		})
+
+		It("should record the release", func() {
+			reconcileStop()
+			reconcileStop()
+
+			stop := &gastownv1alpha1.EmergencyStop{}
+			Expect(c.Get(ctx, stopKey, stop)).To(Succeed())
+			stop.Spec.Engaged = false
+			Expect(c.Update(ctx, stop)).To(Succeed())
+			reconcileStop()
+
+			Expect(c.Get(ctx, stopKey, stop)).To(Succeed())
+			Expect(stop.Status.Phase).To(Equal(gastownv1alpha1.EmergencyStopPhaseReleased))
+			Expect(stop.Status.ReleasedAt).NotTo(BeNil())
+
+			active, err := activeEmergencyStop(ctx, c)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(active).To(BeNil())
+		})
+	})
+})
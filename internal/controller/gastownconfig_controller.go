@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+	gterrors "github.com/org/gastown-operator/pkg/errors"
+	"github.com/org/gastown-operator/pkg/metrics"
+	"github.com/org/gastown-operator/pkg/version"
+)
+
+// GastownConfigReconciler reconciles the GastownConfig object.
+// It publishes the "default" GastownConfig through the config package,
+// which the other controllers and the Pod builder read on every use.
+type GastownConfigReconciler struct {
+	client.Client
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+}
+
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=gastownconfigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=gastownconfigs/status,verbs=get;update;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+
+// Reconcile applies the default GastownConfig, or restores the built-in
+// defaults when it is deleted.
+func (r *GastownConfigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+	timer := metrics.NewReconcileTimer("gastownconfig")
+	defer timer.ObserveDuration()
+
+	var cfg gastownv1alpha1.GastownConfig
+	if err := r.Get(ctx, req.NamespacedName, &cfg); err != nil {
+		if !apierrors.IsNotFound(err) {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, err
+		}
+		if req.Name == gastownv1alpha1.GastownConfigName {
+			log.Info("GastownConfig deleted, restoring defaults")
+			config.Set(nil)
+		}
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
+	// Leave the status to a newer operator, but keep applying its
+	// configuration: reading the spec drops nothing
+	if skip, err := checkSchemaVersion(ctx, r.Client, r.Recorder, &cfg, &cfg.Status.Conditions); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, err
+	} else if skip {
+		if cfg.Name == gastownv1alpha1.GastownConfigName && cfg.DeletionTimestamp.IsZero() {
+			config.Set(config.FromSpec(&cfg.Spec))
+		}
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
+	if cfg.Name != gastownv1alpha1.GastownConfigName {
+		r.setCondition(&cfg, metav1.ConditionFalse, "Ignored",
+			"Only the GastownConfig named "+gastownv1alpha1.GastownConfigName+" configures the operator")
+	} else if !cfg.DeletionTimestamp.IsZero() {
+		config.Set(nil)
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	} else {
+		applied := config.FromSpec(&cfg.Spec)
+		config.Set(applied)
+
+		message := "Configuration applied"
+		if unknown := applied.UnknownFeatureGates(); len(unknown) > 0 {
+			message += "; ignoring unknown feature gates: " + strings.Join(unknown, ", ")
+		}
+		if unknown := applied.UnknownControllers(); len(unknown) > 0 {
+			message += "; ignoring tuning of unknown controllers: " + strings.Join(unknown, ", ")
+		}
+		if cfg.Status.ObservedGeneration != cfg.Generation {
+			log.Info("Applied GastownConfig", "generation", cfg.Generation)
+			r.Recorder.Event(&cfg, "Normal", "Applied", message)
+		}
+		r.setCondition(&cfg, metav1.ConditionTrue, "Applied", message)
+		r.recordOperatorVersion(&cfg)
+	}
+
+	cfg.Status.ObservedGeneration = cfg.Generation
+	if err := applyStatus(ctx, r.Client, &cfg, fieldManagerGastownConfig); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+	}
+
+	timer.RecordResult(metrics.ResultSuccess)
+	return ctrl.Result{}, nil
+}
+
+// setCondition updates the Ready condition of the GastownConfig.
+func (r *GastownConfigReconciler) setCondition(cfg *gastownv1alpha1.GastownConfig, status metav1.ConditionStatus, reason, message string) {
+	meta.SetStatusCondition(&cfg.Status.Conditions, metav1.Condition{
+		Type:               ConditionReady,
+		Status:             status,
+		ObservedGeneration: cfg.Generation,
+		Reason:             reason,
+		Message:            message,
+		LastTransitionTime: metav1.Now(),
+	})
+}
+
+// recordOperatorVersion records the running operator's version and schema
+// version in the status, announcing an upgrade or downgrade.
+func (r *GastownConfigReconciler) recordOperatorVersion(cfg *gastownv1alpha1.GastownConfig) {
+	previous, previousSchema := cfg.Status.OperatorVersion, cfg.Status.SchemaVersion
+	if previous != "" && (previous != version.Version || previousSchema != gastownv1alpha1.SchemaVersion) {
+		r.Recorder.Event(cfg, "Normal", "OperatorVersionChanged",
+			fmt.Sprintf("Operator changed from %s (schema version %d) to %s (schema version %d)",
+				previous, previousSchema, version.Version, gastownv1alpha1.SchemaVersion))
+	}
+	cfg.Status.OperatorVersion = version.Version
+	cfg.Status.APIVersion = gastownv1alpha1.GroupVersion.String()
+	cfg.Status.SchemaVersion = gastownv1alpha1.SchemaVersion
+}
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *GastownConfigReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&gastownv1alpha1.GastownConfig{}).
+		Named("gastownconfig").
+		Complete(r)
+}
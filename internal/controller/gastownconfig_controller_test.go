@@ -0,0 +1,153 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+	"github.com/org/gastown-operator/pkg/version"
+)
+
+var _ = Describe("GastownConfig Controller", func() {
+	Context("When reconciling GastownConfigs", func() {
+		var (
+			ctx context.Context
+			c   client.Client
+			r   *GastownConfigReconciler
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+			c = fake.NewClientBuilder().
+				WithScheme(scheme).
+				WithStatusSubresource(&gastownv1alpha1.GastownConfig{}).
+				WithObjects(
+					&gastownv1alpha1.GastownConfig{
+						ObjectMeta: metav1.ObjectMeta{Name: gastownv1alpha1.GastownConfigName},
+						Spec: gastownv1alpha1.GastownConfigSpec{
+							ChildNamespace: "agents",
+							Requeue: gastownv1alpha1.RequeueIntervals{
+								Long: &metav1.Duration{Duration: 5 * time.Minute},
+							},
+							FeatureGates: map[string]bool{"Bogus": true},
+						},
+					},
+					&gastownv1alpha1.GastownConfig{ObjectMeta: metav1.ObjectMeta{Name: "staging"}},
+				).
+				Build()
+			r = &GastownConfigReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+		})
+
+		AfterEach(func() {
+			config.Set(nil)
+		})
+
+		reconcileConfig := func(name string) {
+			_, err := r.Reconcile(ctx, reconcile.Request{NamespacedName: types.NamespacedName{Name: name}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+
+		It("should apply the default config and report unknown feature gates", func() {
+			reconcileConfig(gastownv1alpha1.GastownConfigName)
+
+			Expect(config.Current().ChildNamespace).To(Equal("agents"))
+			Expect(requeueLong()).To(Equal(5 * time.Minute))
+			Expect(requeueShort()).To(Equal(RequeueShort))
+
+			// A resource's spec.syncIntervalSeconds wins over the config
+			seconds := int32(20)
+			Expect(syncInterval(&seconds, requeueLong())).To(Equal(20 * time.Second))
+			Expect(syncInterval(nil, requeueLong())).To(Equal(5 * time.Minute))
+
+			cfg := &gastownv1alpha1.GastownConfig{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: gastownv1alpha1.GastownConfigName}, cfg)).To(Succeed())
+			ready := meta.FindStatusCondition(cfg.Status.Conditions, ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Status).To(Equal(metav1.ConditionTrue))
+			Expect(ready.Message).To(ContainSubstring("Bogus"))
+		})
+
+		It("should record the operator and schema versions", func() {
+			reconcileConfig(gastownv1alpha1.GastownConfigName)
+
+			cfg := &gastownv1alpha1.GastownConfig{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: gastownv1alpha1.GastownConfigName}, cfg)).To(Succeed())
+			Expect(cfg.Status.OperatorVersion).To(Equal(version.Version))
+			Expect(cfg.Status.APIVersion).To(Equal("gastown.gastown.io/v1alpha1"))
+			Expect(cfg.Status.SchemaVersion).To(Equal(int32(gastownv1alpha1.SchemaVersion)))
+			Expect(gastownv1alpha1.ObjectSchemaVersion(cfg)).To(Equal(gastownv1alpha1.SchemaVersion))
+		})
+
+		It("should keep applying a config of a newer schema version without writing its status", func() {
+			cfg := &gastownv1alpha1.GastownConfig{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: gastownv1alpha1.GastownConfigName}, cfg)).To(Succeed())
+			cfg.Annotations = map[string]string{gastownv1alpha1.SchemaVersionAnnotation: "99"}
+			Expect(c.Update(ctx, cfg)).To(Succeed())
+
+			reconcileConfig(gastownv1alpha1.GastownConfigName)
+
+			Expect(config.Current().ChildNamespace).To(Equal("agents"))
+			Expect(c.Get(ctx, types.NamespacedName{Name: gastownv1alpha1.GastownConfigName}, cfg)).To(Succeed())
+			Expect(cfg.Status.OperatorVersion).To(BeEmpty())
+			supported := meta.FindStatusCondition(cfg.Status.Conditions, ConditionSchemaSupported)
+			Expect(supported).NotTo(BeNil())
+			Expect(supported.Reason).To(Equal("NewerSchemaVersion"))
+		})
+
+		It("should ignore configs with other names", func() {
+			reconcileConfig("staging")
+
+			Expect(config.Current().ChildNamespace).To(BeEmpty())
+
+			cfg := &gastownv1alpha1.GastownConfig{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "staging"}, cfg)).To(Succeed())
+			ready := meta.FindStatusCondition(cfg.Status.Conditions, ConditionReady)
+			Expect(ready).NotTo(BeNil())
+			Expect(ready.Reason).To(Equal("Ignored"))
+		})
+
+		It("should restore the defaults when the default config is deleted", func() {
+			reconcileConfig(gastownv1alpha1.GastownConfigName)
+			Expect(c.Delete(ctx, &gastownv1alpha1.GastownConfig{
+				ObjectMeta: metav1.ObjectMeta{Name: gastownv1alpha1.GastownConfigName},
+			})).To(Succeed())
+			reconcileConfig(gastownv1alpha1.GastownConfigName)
+
+			Expect(config.Current().ChildNamespace).To(BeEmpty())
+			Expect(requeueLong()).To(Equal(RequeueLong))
+		})
+	})
+})
@@ -0,0 +1,175 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/internal/git/provider"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// Keys of a git Secret holding a GitHub App credential instead of an SSH key
+const (
+	githubAppIDKey             = "github-app-id"
+	githubAppInstallationIDKey = "github-app-installation-id"
+	githubAppPrivateKeyKey     = "github-app-private-key"
+	githubAppAPIURLKey         = "github-api-url"
+)
+
+// gitCredentials authenticate to a rig repository: an SSH key file, or a
+// token or password for HTTPS, with an optional username
+type gitCredentials struct {
+	SSHKeyPath string
+	Username   string
+	Token      string
+}
+
+// gitCredentialsFromSecret reads the credentials in a git Secret. A Secret
+// with a GitHub App credential is exchanged for an installation token through
+// tokens, which reuses it until it nears expiry; a Secret with a token or
+// password is used as is for HTTPS; any other Secret must hold an SSH key,
+// which is written to a temp file removed by the cleanup function.
+func gitCredentialsFromSecret(
+	ctx context.Context, c client.Reader, secretKey types.NamespacedName, tokens *git.AppTokenCache,
+) (gitCredentials, func(), error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, secretKey, secret); err != nil {
+		return gitCredentials{}, nil, fmt.Errorf("failed to get git secret %s: %w", secretKey, err)
+	}
+	if creds, ok := httpsCredentialsFromSecret(secret); ok {
+		return creds, func() {}, nil
+	}
+	if _, ok := secret.Data[githubAppIDKey]; !ok {
+		keyPath, cleanup, err := writeSSHKeyFromSecret(ctx, c, secretKey)
+		if err != nil {
+			return gitCredentials{}, nil, err
+		}
+		return gitCredentials{SSHKeyPath: keyPath}, cleanup, nil
+	}
+
+	installationID, err := strconv.ParseInt(strings.TrimSpace(string(secret.Data[githubAppInstallationIDKey])), 10, 64)
+	if err != nil {
+		return gitCredentials{}, nil, fmt.Errorf("invalid %s in secret %s: %w", githubAppInstallationIDKey, secretKey, err)
+	}
+	privateKey, ok := secret.Data[githubAppPrivateKeyKey]
+	if !ok {
+		return gitCredentials{}, nil, fmt.Errorf("no %s found in secret %s", githubAppPrivateKeyKey, secretKey)
+	}
+	if tokens == nil {
+		tokens = git.DefaultAppTokens
+	}
+	token, err := tokens.Token(ctx, git.AppCredentials{
+		APIURL:         strings.TrimSpace(string(secret.Data[githubAppAPIURLKey])),
+		AppID:          strings.TrimSpace(string(secret.Data[githubAppIDKey])),
+		InstallationID: installationID,
+		PrivateKey:     privateKey,
+	})
+	if err != nil {
+		return gitCredentials{}, nil, err
+	}
+	return gitCredentials{Token: token}, func() {}, nil
+}
+
+// httpsCredentialsFromSecret reads the HTTPS credentials of a git Secret
+// with a token or password key, as agent Pods do: a token wins over a
+// password.
+func httpsCredentialsFromSecret(secret *corev1.Secret) (gitCredentials, bool) {
+	password, ok := secret.Data[pod.GitTokenKey]
+	if !ok {
+		password, ok = secret.Data[pod.GitPasswordKey]
+	}
+	if !ok {
+		return gitCredentials{}, false
+	}
+	return gitCredentials{
+		Username: strings.TrimSpace(string(secret.Data[pod.GitUsernameKey])),
+		Token:    strings.TrimSpace(string(password)),
+	}, true
+}
+
+// httpsGitURL rewrites an SSH git URL to HTTPS, for token authentication.
+func httpsGitURL(gitURL string) (string, error) {
+	if strings.HasPrefix(gitURL, "https://") {
+		return gitURL, nil
+	}
+	host, path, err := provider.ParseRepoPath(gitURL)
+	if err != nil {
+		return "", err
+	}
+	return "https://" + host + "/" + path + ".git", nil
+}
+
+// writeSSHKeyFromSecret extracts an SSH key from the secret and writes it to a
+// temp file. Returns the path to the key file and a cleanup function.
+func writeSSHKeyFromSecret(
+	ctx context.Context, c client.Reader, secretKey types.NamespacedName,
+) (string, func(), error) {
+	secret := &corev1.Secret{}
+	if err := c.Get(ctx, secretKey, secret); err != nil {
+		return "", nil, fmt.Errorf("failed to get git secret %s: %w", secretKey, err)
+	}
+
+	// Look for SSH key in common key names
+	var sshKey []byte
+	for _, keyName := range []string{"ssh-privatekey", "id_rsa", "id_ed25519", "identity"} {
+		if key, ok := secret.Data[keyName]; ok {
+			sshKey = key
+			break
+		}
+	}
+
+	if sshKey == nil {
+		return "", nil, fmt.Errorf("no SSH key found in secret %s", secretKey)
+	}
+
+	// Write key to temp file
+	keyFile, err := os.CreateTemp("", "git-ssh-key-*")
+	if err != nil {
+		return "", nil, fmt.Errorf("failed to create temp key file: %w", err)
+	}
+
+	if _, err := keyFile.Write(sshKey); err != nil {
+		_ = os.Remove(keyFile.Name()) //nolint:errcheck // best-effort cleanup on error path
+		return "", nil, fmt.Errorf("failed to write SSH key: %w", err)
+	}
+
+	if err := keyFile.Chmod(0o600); err != nil {
+		_ = os.Remove(keyFile.Name()) //nolint:errcheck // best-effort cleanup on error path
+		return "", nil, fmt.Errorf("failed to chmod SSH key: %w", err)
+	}
+
+	if err := keyFile.Close(); err != nil {
+		_ = os.Remove(keyFile.Name()) //nolint:errcheck // best-effort cleanup on error path
+		return "", nil, fmt.Errorf("failed to close SSH key file: %w", err)
+	}
+
+	cleanup := func() {
+		_ = os.Remove(keyFile.Name()) //nolint:errcheck // best-effort cleanup
+	}
+
+	return keyFile.Name(), cleanup, nil
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// tokenGitClient records the token, and username, it is authenticated with.
+type tokenGitClient struct {
+	mockGitClient
+	username string
+	token    string
+}
+
+func (m *tokenGitClient) SetToken(token string) {
+	m.token = token
+}
+
+func (m *tokenGitClient) SetBasicAuth(username, password string) {
+	m.username = username
+	m.token = password
+}
+
+var _ = Describe("Git credentials", func() {
+	var (
+		ctx       context.Context
+		scheme    *runtime.Scheme
+		server    *httptest.Server
+		exchanges int
+		keyPEM    []byte
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		key, err := rsa.GenerateKey(rand.Reader, 2048)
+		Expect(err).NotTo(HaveOccurred())
+		keyPEM = pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+
+		exchanges = 0
+		server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path != "/app/installations/42/access_tokens" {
+				http.NotFound(w, r)
+				return
+			}
+			exchanges++
+			w.WriteHeader(http.StatusCreated)
+			_, _ = fmt.Fprintf(w, `{"token":"ghs_installation","expires_at":%q}`,
+				time.Now().Add(time.Hour).Format(time.RFC3339))
+		}))
+	})
+
+	AfterEach(func() {
+		server.Close()
+	})
+
+	It("should clone over HTTPS with a cached GitHub App installation token", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec:       gastownv1alpha1.RigSpec{GitURL: "git@github.com:org/app.git", BeadsPrefix: "gt"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "github-app", Namespace: "default"},
+				Data: map[string][]byte{
+					githubAppIDKey:             []byte("12345"),
+					githubAppInstallationIDKey: []byte("42"),
+					githubAppPrivateKeyKey:     keyPEM,
+					githubAppAPIURLKey:         []byte(server.URL),
+				},
+			},
+		).Build()
+
+		var clonedURL string
+		gitClient := &tokenGitClient{}
+		r := &RefineryReconciler{
+			Client:    c,
+			Scheme:    scheme,
+			AppTokens: &git.AppTokenCache{},
+			GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+				clonedURL = gitURL
+				Expect(sshKeyPath).To(BeEmpty())
+				return gitClient
+			},
+		}
+		refinery := &gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: gastownv1alpha1.RefinerySpec{
+				RigRef:       "app",
+				GitSecretRef: &gastownv1alpha1.SecretReference{Name: "github-app"},
+			},
+		}
+
+		for range 2 {
+			_, cleanup, err := r.openRepository(ctx, refinery)
+			Expect(err).NotTo(HaveOccurred())
+			cleanup()
+		}
+		Expect(clonedURL).To(Equal("https://github.com/org/app.git"))
+		Expect(gitClient.token).To(Equal("ghs_installation"))
+		Expect(exchanges).To(Equal(1), "the token should be reused until it nears expiry")
+	})
+
+	It("should clone over HTTPS with the token or password of the Secret", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+			&gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec:       gastownv1alpha1.RigSpec{GitURL: "ssh://git@gitlab.com/org/app.git", BeadsPrefix: "gt"},
+			},
+			&corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "gitlab-token", Namespace: "default"},
+				Data: map[string][]byte{
+					pod.GitUsernameKey: []byte("oauth2\n"),
+					pod.GitPasswordKey: []byte("unused"),
+					pod.GitTokenKey:    []byte("glpat-secret\n"),
+				},
+			},
+		).Build()
+
+		var clonedURL string
+		gitClient := &tokenGitClient{}
+		r := &RefineryReconciler{
+			Client: c,
+			Scheme: scheme,
+			GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+				clonedURL = gitURL
+				Expect(sshKeyPath).To(BeEmpty())
+				return gitClient
+			},
+		}
+		refinery := &gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec: gastownv1alpha1.RefinerySpec{
+				RigRef:       "app",
+				GitSecretRef: &gastownv1alpha1.SecretReference{Name: "gitlab-token"},
+			},
+		}
+
+		_, cleanup, err := r.openRepository(ctx, refinery)
+		Expect(err).NotTo(HaveOccurred())
+		cleanup()
+		Expect(clonedURL).To(Equal("https://gitlab.com/org/app.git"))
+		Expect(gitClient.username).To(Equal("oauth2"))
+		Expect(gitClient.token).To(Equal("glpat-secret"), "a token should win over a password")
+	})
+
+	It("should read a password without a username as a token", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-creds", Namespace: "default"},
+			Data:       map[string][]byte{pod.GitPasswordKey: []byte("secret")},
+		}).Build()
+
+		creds, cleanup, err := gitCredentialsFromSecret(ctx, c,
+			types.NamespacedName{Name: "git-creds", Namespace: "default"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		defer cleanup()
+		Expect(creds).To(Equal(gitCredentials{Token: "secret"}))
+	})
+
+	It("should keep writing SSH keys for Secrets without a GitHub App", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "git-creds", Namespace: "default"},
+			Data:       map[string][]byte{"ssh-privatekey": []byte("key")},
+		}).Build()
+
+		creds, cleanup, err := gitCredentialsFromSecret(ctx, c,
+			types.NamespacedName{Name: "git-creds", Namespace: "default"}, nil)
+		Expect(err).NotTo(HaveOccurred())
+		defer cleanup()
+		Expect(creds.Token).To(BeEmpty())
+		Expect(creds.SSHKeyPath).To(BeAnExistingFile())
+		content, err := os.ReadFile(creds.SSHKeyPath)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(string(content)).To(Equal("key"))
+	})
+})
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// activityProbeInterval is how stale status.lastActivity may get before the
+// agent log is checked for new output. Keeps log reads to one per minute.
+const activityProbeInterval = time.Minute
+
+// PodLogProber is implemented by PodLogReaders that can tell cheaply whether
+// a container wrote output since a point in time.
+type PodLogProber interface {
+	LoggedSince(ctx context.Context, namespace, podName, container string, since time.Time) (bool, error)
+}
+
+// LoggedSince reads at most one byte of the container log written since since.
+func (r *clientsetLogReader) LoggedSince(
+	ctx context.Context, namespace, podName, container string, since time.Time,
+) (bool, error) {
+	sinceTime := metav1.NewTime(since)
+	limit := int64(1)
+	stream, err := r.clientset.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container:  container,
+		SinceTime:  &sinceTime,
+		LimitBytes: &limit,
+	}).Stream(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer func() { _ = stream.Close() }()
+
+	n, err := stream.Read(make([]byte, 1))
+	if n > 0 {
+		return true, nil
+	}
+	if err != nil && !errors.Is(err, io.EOF) {
+		return false, err
+	}
+	return false, nil
+}
+
+// updateActivity advances status.lastActivity to the latest sign of life of
+// the agent: the Pod starting, the agent container (re)starting, or new agent
+// log output. The Witness flags polecats whose lastActivity goes stale.
+func (r *PolecatReconciler) updateActivity(ctx context.Context, polecat *gastownv1alpha1.Polecat, p *corev1.Pod) {
+	last := polecat.Status.LastActivity
+	observed := []*metav1.Time{p.Status.StartTime}
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name == pod.AgentContainerName(polecat.Spec.Agent) && cs.State.Running != nil {
+			observed = append(observed, &cs.State.Running.StartedAt)
+		}
+	}
+	for _, t := range observed {
+		if t != nil && !t.IsZero() && (last == nil || t.After(last.Time)) {
+			last = t.DeepCopy()
+		}
+	}
+	polecat.Status.LastActivity = last
+
+	if p.Status.Phase != corev1.PodRunning || last == nil || time.Since(last.Time) < activityProbeInterval {
+		return
+	}
+	prober, ok := r.LogReader.(PodLogProber)
+	if !ok {
+		return
+	}
+	logged, err := prober.LoggedSince(ctx, p.Namespace, p.Name, pod.AgentContainerName(polecat.Spec.Agent), last.Time)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info("Failed to check agent log for activity", "podName", p.Name, "error", err.Error())
+		return
+	}
+	if logged {
+		now := metav1.Now()
+		polecat.Status.LastActivity = &now
+	}
+}
+
+// heartbeatStalled reports whether the agent stopped touching its heartbeat
+// file for spec.heartbeatTimeoutSeconds: the heartbeat readiness probe of its
+// running container fails after it ran at least that long, so a slow start
+// does not count.
+func heartbeatStalled(polecat *gastownv1alpha1.Polecat, p *corev1.Pod) (string, bool) {
+	if polecat.Spec.HeartbeatTimeoutSeconds == nil {
+		return "", false
+	}
+	timeout := time.Duration(*polecat.Spec.HeartbeatTimeoutSeconds) * time.Second
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name != pod.AgentContainerName(polecat.Spec.Agent) || cs.State.Running == nil {
+			continue
+		}
+		if cs.Ready || time.Since(cs.State.Running.StartedAt.Time) < timeout {
+			return "", false
+		}
+		return fmt.Sprintf("No agent heartbeat for %s", timeout), true
+	}
+	return "", false
+}
+
+// isStalledDegraded reports whether the polecat is degraded only because the
+// Witness found it stalled.
+func isStalledDegraded(polecat *gastownv1alpha1.Polecat) bool {
+	degraded := meta.FindStatusCondition(polecat.Status.Conditions, ConditionDegraded)
+	return degraded != nil && degraded.Status == metav1.ConditionTrue && degraded.Reason == "Stalled"
+}
@@ -0,0 +1,190 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/metrics"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// fakePodLogProber reports fixed log activity and counts probes.
+type fakePodLogProber struct {
+	fakePodLogReader
+	logged bool
+	probes int
+}
+
+func (f *fakePodLogProber) LoggedSince(_ context.Context, _, _, _ string, _ time.Time) (bool, error) {
+	f.probes++
+	return f.logged, nil
+}
+
+var _ = Describe("Polecat activity tracking", func() {
+	Context("When syncing activity from the agent Pod", func() {
+		ctx := context.Background()
+
+		newPod := func(started time.Time) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "polecat-active", Namespace: "default"},
+				Status: corev1.PodStatus{
+					Phase:     corev1.PodRunning,
+					StartTime: &metav1.Time{Time: started},
+				},
+			}
+		}
+
+		It("should count an agent restart as activity", func() {
+			polecat := &gastownv1alpha1.Polecat{}
+			p := newPod(time.Now().Add(-time.Hour))
+			restarted := metav1.NewTime(time.Now().Add(-30 * time.Second))
+			p.Status.ContainerStatuses = []corev1.ContainerStatus{{
+				Name:  pod.AgentContainerName(polecat.Spec.Agent),
+				State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{StartedAt: restarted}},
+			}}
+
+			r := &PolecatReconciler{}
+			r.updateActivity(ctx, polecat, p)
+			Expect(polecat.Status.LastActivity.Time).To(BeTemporally("==", restarted.Time))
+		})
+
+		It("should count new agent log output as activity", func() {
+			polecat := &gastownv1alpha1.Polecat{}
+			prober := &fakePodLogProber{logged: true}
+			r := &PolecatReconciler{LogReader: prober}
+
+			r.updateActivity(ctx, polecat, newPod(time.Now().Add(-time.Hour)))
+			Expect(prober.probes).To(Equal(1))
+			Expect(polecat.Status.LastActivity.Time).To(BeTemporally("~", time.Now(), time.Second))
+
+			// Recent activity is not probed again
+			r.updateActivity(ctx, polecat, newPod(time.Now().Add(-time.Hour)))
+			Expect(prober.probes).To(Equal(1))
+		})
+
+		It("should keep the last activity of a quiet agent", func() {
+			started := time.Now().Add(-time.Hour)
+			polecat := &gastownv1alpha1.Polecat{}
+			prober := &fakePodLogProber{}
+			r := &PolecatReconciler{LogReader: prober}
+
+			r.updateActivity(ctx, polecat, newPod(started))
+			Expect(prober.probes).To(Equal(1))
+			Expect(polecat.Status.LastActivity.Time).To(BeTemporally("==", started))
+		})
+
+		It("should report a stalled agent as stuck", func() {
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "active", Namespace: "default"},
+				Spec:       gastownv1alpha1.PolecatSpec{Rig: "test-rig", BeadID: "act-1"},
+				Status: gastownv1alpha1.PolecatStatus{
+					Phase: gastownv1alpha1.PolecatPhaseWorking,
+					Conditions: []metav1.Condition{{
+						Type:               ConditionStalled,
+						Status:             metav1.ConditionTrue,
+						Reason:             "NoActivity",
+						Message:            "No agent activity",
+						LastTransitionTime: metav1.Now(),
+					}},
+				},
+			}
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(polecat).
+				WithStatusSubresource(&gastownv1alpha1.Polecat{}).
+				Build()
+			r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+			_, err := r.syncStatusFromPod(ctx, polecat, newPod(time.Now()), metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(polecat.Status.Phase).To(Equal(gastownv1alpha1.PolecatPhaseStuck))
+			degraded := meta.FindStatusCondition(polecat.Status.Conditions, ConditionDegraded)
+			Expect(degraded.Status).To(Equal(metav1.ConditionTrue))
+			Expect(degraded.Reason).To(Equal("Stalled"))
+		})
+
+		It("should report an agent without heartbeat as stuck", func() {
+			timeout := int32(60)
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "heartbeat", Namespace: "default"},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:                     "test-rig",
+					BeadID:                  "act-2",
+					HeartbeatTimeoutSeconds: &timeout,
+				},
+				Status: gastownv1alpha1.PolecatStatus{Phase: gastownv1alpha1.PolecatPhaseWorking},
+			}
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(polecat).
+				WithStatusSubresource(&gastownv1alpha1.Polecat{}).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+			agentPod := func(started time.Time, ready bool) *corev1.Pod {
+				p := newPod(started)
+				p.Status.ContainerStatuses = []corev1.ContainerStatus{{
+					Name:  pod.AgentContainerName(polecat.Spec.Agent),
+					Ready: ready,
+					State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{
+						StartedAt: metav1.NewTime(started),
+					}},
+				}}
+				return p
+			}
+
+			By("ignoring a heartbeat not written yet")
+			_, err := r.syncStatusFromPod(ctx, polecat, agentPod(time.Now(), false), metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(polecat.Status.Phase).To(Equal(gastownv1alpha1.PolecatPhaseWorking))
+
+			By("flipping to Stuck once the heartbeat probe fails")
+			started := time.Now().Add(-10 * time.Minute)
+			_, err = r.syncStatusFromPod(ctx, polecat, agentPod(started, false), metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(polecat.Status.Phase).To(Equal(gastownv1alpha1.PolecatPhaseStuck))
+			degraded := meta.FindStatusCondition(polecat.Status.Conditions, ConditionDegraded)
+			Expect(degraded.Reason).To(Equal("Stalled"))
+			Expect(degraded.Message).To(ContainSubstring("No agent heartbeat for 1m0s"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("HeartbeatTimeout")))
+
+			By("recovering when the heartbeat resumes")
+			_, err = r.syncStatusFromPod(ctx, polecat, agentPod(started, true), metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(polecat.Status.Phase).To(Equal(gastownv1alpha1.PolecatPhaseWorking))
+			Expect(meta.IsStatusConditionFalse(polecat.Status.Conditions, ConditionDegraded)).To(BeTrue())
+		})
+	})
+})
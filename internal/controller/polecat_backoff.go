@@ -0,0 +1,46 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// maxFailureBackoff caps the requeue backoff of a polecat whose Pod keeps
+// failing to be built, created or deleted.
+const maxFailureBackoff = 10 * time.Minute
+
+// recordPolecatFailure counts a failed attempt at the polecat's Pod in
+// status.consecutiveFailures and returns how long to wait before the next:
+// requeue.default, doubling with every failure in a row up to
+// maxFailureBackoff, so a broken cluster or configuration is not hammered.
+func recordPolecatFailure(polecat *gastownv1alpha1.Polecat) time.Duration {
+	polecat.Status.ConsecutiveFailures++
+	return failureBackoff(polecat.Status.ConsecutiveFailures)
+}
+
+// failureBackoff returns the requeue delay after the given number of
+// failures in a row.
+func failureBackoff(failures int32) time.Duration {
+	backoff := requeueDefault()
+	for i := int32(1); i < failures && backoff < maxFailureBackoff; i++ {
+		backoff *= 2
+	}
+	return min(backoff, maxFailureBackoff)
+}
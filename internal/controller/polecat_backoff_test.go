@@ -0,0 +1,96 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+var _ = Describe("Polecat failure backoff", func() {
+	It("should double the requeue with every failure in a row up to the cap", func() {
+		Expect(failureBackoff(1)).To(Equal(requeueDefault()))
+		Expect(failureBackoff(2)).To(Equal(2 * requeueDefault()))
+		Expect(failureBackoff(3)).To(Equal(4 * requeueDefault()))
+		Expect(failureBackoff(10)).To(Equal(maxFailureBackoff))
+		Expect(failureBackoff(1000)).To(Equal(maxFailureBackoff))
+	})
+
+	It("should back off while the Pod cannot be deleted and reset once it is", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "broken", Namespace: "default"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:          "test-rig",
+				DesiredState: gastownv1alpha1.PolecatDesiredTerminated,
+			},
+		}
+		agent := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "polecat-broken", Namespace: "default"}}
+		broken := true
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(polecat, agent).
+			WithStatusSubresource(polecat).
+			WithInterceptorFuncs(interceptor.Funcs{
+				Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+					if _, ok := obj.(*corev1.Pod); ok && broken {
+						return errors.New("admission webhook denied the request")
+					}
+					return c.Delete(ctx, obj, opts...)
+				},
+			}).Build()
+		r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+		terminate := func() time.Duration {
+			current := &gastownv1alpha1.Polecat{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), current)).To(Succeed())
+			result, err := r.ensureTerminated(ctx, current, metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			return result.RequeueAfter
+		}
+		failures := func() int32 {
+			current := &gastownv1alpha1.Polecat{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), current)).To(Succeed())
+			return current.Status.ConsecutiveFailures
+		}
+
+		Expect(terminate()).To(Equal(requeueDefault()))
+		Expect(terminate()).To(Equal(2 * requeueDefault()))
+		Expect(failures()).To(Equal(int32(2)))
+
+		broken = false
+		Expect(terminate()).To(BeZero())
+		Expect(failures()).To(BeZero())
+	})
+})
@@ -0,0 +1,150 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+)
+
+// ConditionWaitingForCapacity is True while a polecat waits for its rig's
+// share of the operator-wide polecat capacity before starting.
+const ConditionWaitingForCapacity = "WaitingForCapacity"
+
+// rigShare is the scheduling state of one rig
+type rigShare struct {
+	rig     string
+	weight  int
+	active  int
+	waiting []*gastownv1alpha1.Polecat
+}
+
+// waitingForCapacity reports whether the polecat waits for capacity: it
+// should be working, has not started, and was held by the capacity gate.
+func waitingForCapacity(polecat *gastownv1alpha1.Polecat) bool {
+	return polecat.Spec.DesiredState == gastownv1alpha1.PolecatDesiredWorking &&
+		polecat.Status.Phase != gastownv1alpha1.PolecatPhaseWorking &&
+		meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionWaitingForCapacity)
+}
+
+// grantCapacity hands the free capacity to the waiting polecats by weighted
+// fair share and reports whether the polecat got a slot, along with the
+// number of working polecats. Each free slot goes to the oldest waiting
+// polecat of the rig with the fewest active polecats per unit of weight,
+// ties going to the rig first by name, so a busy rig cannot starve the
+// others.
+func grantCapacity(all *gastownv1alpha1.PolecatList, polecat *gastownv1alpha1.Polecat, cfg *config.Config) (bool, int) {
+	shares := map[string]*rigShare{}
+	share := func(rig string) *rigShare {
+		s, ok := shares[rig]
+		if !ok {
+			s = &rigShare{rig: rig, weight: int(cfg.RigWeight(rig))}
+			shares[rig] = s
+		}
+		return s
+	}
+
+	active := 0
+	for i := range all.Items {
+		p := &all.Items[i]
+		if p.Namespace == polecat.Namespace && p.Name == polecat.Name {
+			// The listed copy may be older than the one being reconciled
+			continue
+		}
+		switch {
+		case p.Status.Phase == gastownv1alpha1.PolecatPhaseWorking:
+			share(p.Spec.Rig).active++
+			active++
+		case waitingForCapacity(p):
+			s := share(p.Spec.Rig)
+			s.waiting = append(s.waiting, p)
+		}
+	}
+	self := share(polecat.Spec.Rig)
+	self.waiting = append(self.waiting, polecat)
+
+	ordered := make([]*rigShare, 0, len(shares))
+	for _, s := range shares {
+		slices.SortFunc(s.waiting, func(a, b *gastownv1alpha1.Polecat) int {
+			if c := a.CreationTimestamp.Compare(b.CreationTimestamp.Time); c != 0 {
+				return c
+			}
+			return strings.Compare(a.Namespace+"/"+a.Name, b.Namespace+"/"+b.Name)
+		})
+		ordered = append(ordered, s)
+	}
+	slices.SortFunc(ordered, func(a, b *rigShare) int { return strings.Compare(a.rig, b.rig) })
+
+	for free := int(cfg.MaxActivePolecats) - active; free > 0; free-- {
+		var next *rigShare
+		for _, s := range ordered {
+			if len(s.waiting) == 0 {
+				continue
+			}
+			// s.active/s.weight < next.active/next.weight
+			if next == nil || s.active*next.weight < next.active*s.weight {
+				next = s
+			}
+		}
+		if next == nil {
+			break
+		}
+		if next.waiting[0] == polecat {
+			return true, active
+		}
+		next.waiting = next.waiting[1:]
+		next.active++
+	}
+	return false, active
+}
+
+// capacityAvailable reports whether the polecat may start within the
+// operator-wide capacity of GastownConfig spec.scheduling. The
+// WaitingForCapacity condition is set to match.
+func (r *PolecatReconciler) capacityAvailable(ctx context.Context, polecat *gastownv1alpha1.Polecat) (bool, error) {
+	cfg := config.Current()
+	if cfg.MaxActivePolecats == 0 {
+		if meta.FindStatusCondition(polecat.Status.Conditions, ConditionWaitingForCapacity) != nil {
+			r.setCondition(polecat, ConditionWaitingForCapacity, metav1.ConditionFalse, "Unlimited",
+				"Polecat capacity is not limited")
+		}
+		return true, nil
+	}
+
+	var all gastownv1alpha1.PolecatList
+	if err := r.List(ctx, &all); err != nil {
+		return false, err
+	}
+	granted, active := grantCapacity(&all, polecat, cfg)
+	if !granted {
+		r.setCondition(polecat, ConditionWaitingForCapacity, metav1.ConditionTrue, "CapacityExhausted",
+			fmt.Sprintf("%d of %d polecats are working; waiting for the share of rig %s (weight %d)",
+				active, cfg.MaxActivePolecats, polecat.Spec.Rig, cfg.RigWeight(polecat.Spec.Rig)))
+		return false, nil
+	}
+	r.setCondition(polecat, ConditionWaitingForCapacity, metav1.ConditionFalse, "CapacityGranted",
+		fmt.Sprintf("Started as polecat %d of %d", active+1, cfg.MaxActivePolecats))
+	return true, nil
+}
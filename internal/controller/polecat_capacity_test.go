@@ -0,0 +1,141 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+)
+
+var _ = Describe("Polecat capacity", func() {
+	Context("When sharing capacity between rigs", func() {
+		created := time.Now()
+
+		working := func(name, rig string) gastownv1alpha1.Polecat {
+			return gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       gastownv1alpha1.PolecatSpec{Rig: rig, DesiredState: gastownv1alpha1.PolecatDesiredWorking},
+				Status:     gastownv1alpha1.PolecatStatus{Phase: gastownv1alpha1.PolecatPhaseWorking},
+			}
+		}
+		waiting := func(name, rig string) gastownv1alpha1.Polecat {
+			created = created.Add(time.Second)
+			return gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(created),
+				},
+				Spec: gastownv1alpha1.PolecatSpec{Rig: rig, DesiredState: gastownv1alpha1.PolecatDesiredWorking},
+				Status: gastownv1alpha1.PolecatStatus{Conditions: []metav1.Condition{{
+					Type:   ConditionWaitingForCapacity,
+					Status: metav1.ConditionTrue,
+					Reason: "CapacityExhausted",
+				}}},
+			}
+		}
+		granted := func(all *gastownv1alpha1.PolecatList, name string, cfg *config.Config) bool {
+			for i := range all.Items {
+				if all.Items[i].Name == name {
+					ok, _ := grantCapacity(all, &all.Items[i], cfg)
+					return ok
+				}
+			}
+			Fail("no polecat " + name)
+			return false
+		}
+
+		It("should give free capacity to the rig with the fewest active polecats", func() {
+			all := &gastownv1alpha1.PolecatList{Items: []gastownv1alpha1.Polecat{
+				working("busy-1", "busy"),
+				working("busy-2", "busy"),
+				waiting("busy-3", "busy"),
+				waiting("quiet-1", "quiet"),
+				waiting("quiet-2", "quiet"),
+			}}
+			cfg := &config.Config{MaxActivePolecats: 4}
+
+			// Two free slots: quiet has none active, so it gets both
+			Expect(granted(all, "quiet-1", cfg)).To(BeTrue())
+			Expect(granted(all, "quiet-2", cfg)).To(BeTrue())
+			Expect(granted(all, "busy-3", cfg)).To(BeFalse())
+
+			ok, active := grantCapacity(all, &all.Items[2], &config.Config{MaxActivePolecats: 2})
+			Expect(ok).To(BeFalse())
+			Expect(active).To(Equal(2))
+		})
+
+		It("should share capacity in proportion to rig weights", func() {
+			all := &gastownv1alpha1.PolecatList{Items: []gastownv1alpha1.Polecat{
+				waiting("heavy-1", "heavy"),
+				waiting("heavy-2", "heavy"),
+				waiting("heavy-3", "heavy"),
+				waiting("light-1", "light"),
+				waiting("light-2", "light"),
+			}}
+			cfg := &config.Config{MaxActivePolecats: 3, RigWeights: map[string]int32{"heavy": 2}}
+
+			Expect(granted(all, "heavy-1", cfg)).To(BeTrue())
+			Expect(granted(all, "heavy-2", cfg)).To(BeTrue())
+			Expect(granted(all, "light-1", cfg)).To(BeTrue())
+			Expect(granted(all, "heavy-3", cfg)).To(BeFalse())
+			Expect(granted(all, "light-2", cfg)).To(BeFalse())
+		})
+
+		It("should hold a new polecat and record why", func() {
+			defer config.Set(nil)
+			config.Set(&config.Config{MaxActivePolecats: 1})
+
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			busy := working("busy-1", "busy")
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(&busy).Build()
+			r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: &record.FakeRecorder{}}
+
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "quiet-1", Namespace: "default"},
+				Spec:       gastownv1alpha1.PolecatSpec{Rig: "quiet", DesiredState: gastownv1alpha1.PolecatDesiredWorking},
+			}
+			ok, err := r.capacityAvailable(context.Background(), polecat)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeFalse())
+			cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionWaitingForCapacity)
+			Expect(cond).NotTo(BeNil())
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Message).To(ContainSubstring("1 of 1 polecats are working"))
+
+			Expect(c.Delete(context.Background(), &busy)).To(Succeed())
+			ok, err = r.capacityAvailable(context.Background(), polecat)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(ok).To(BeTrue())
+			Expect(meta.IsStatusConditionFalse(polecat.Status.Conditions, ConditionWaitingForCapacity)).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,87 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/metrics"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+var _ = Describe("Polecat checkpoints", func() {
+	Context("When the agent Pod resumed from a checkpoint", func() {
+		const commit = "0123456789abcdef0123456789abcdef01234567"
+
+		It("should record the commit and report it once", func() {
+			ctx := context.Background()
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "resumed", Namespace: "default"},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:    "test-rig",
+					BeadID: "gt-9",
+					Kubernetes: &gastownv1alpha1.KubernetesSpec{
+						Checkpoint: &gastownv1alpha1.CheckpointSpec{},
+					},
+				},
+				Status: gastownv1alpha1.PolecatStatus{
+					Phase:  gastownv1alpha1.PolecatPhaseWorking,
+					Branch: "feature/gt-9",
+				},
+			}
+			p := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "polecat-resumed", Namespace: "default"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodRunning,
+					InitContainerStatuses: []corev1.ContainerStatus{{
+						Name: pod.GitInitContainerName,
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							Message: commit + "\n",
+						}},
+					}},
+				},
+			}
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(polecat).
+				WithStatusSubresource(&gastownv1alpha1.Polecat{}).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+			for range 2 {
+				_, err := r.syncStatusFromPod(ctx, polecat, p, metrics.NewReconcileTimer("polecat"))
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(polecat.Status.ResumedFrom).To(Equal(commit))
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(recorder.Events).To(Receive(ContainSubstring("ResumedFromCheckpoint")))
+		})
+	})
+})
@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+)
+
+// ForceDeleteAnnotation on a deleted polecat removes its finalizer without
+// any cleanup, for when the cleanup cannot complete, e.g. because the cluster
+// is broken. Whatever the polecat left behind is left to garbage collection.
+const ForceDeleteAnnotation = "gastown.io/force-delete"
+
+// defaultCleanupTimeout is how long the cleanup of a deleted polecat is
+// retried when the GastownConfig does not say.
+const defaultCleanupTimeout = 10 * time.Minute
+
+// cleanupTimeout returns how long the cleanup of a deleted polecat is retried.
+func cleanupTimeout() time.Duration {
+	if d := config.Current().CleanupTimeout; d > 0 {
+		return d
+	}
+	return defaultCleanupTimeout
+}
+
+// shouldForceCleanup reports whether the finalizer of a polecat whose
+// cleanup failed is removed anyway: the cleanup timeout expired and the
+// GastownConfig does not ask to keep waiting.
+func shouldForceCleanup(polecat *gastownv1alpha1.Polecat, now time.Time) bool {
+	if config.Current().CleanupOnTimeout == gastownv1alpha1.CleanupOnTimeoutWait {
+		return false
+	}
+	return polecat.DeletionTimestamp != nil && now.Sub(polecat.DeletionTimestamp.Time) >= cleanupTimeout()
+}
+
+// forceCleanup gives up on the graceful cleanup of a polecat. Its Pod is
+// deleted without a grace period, on a best-effort basis, and a
+// FinalizerForced event records what was left behind.
+func (r *PolecatReconciler) forceCleanup(ctx context.Context, polecat *gastownv1alpha1.Polecat, cleanupErr error) {
+	log := logf.FromContext(ctx)
+
+	p := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      polecatPodName(polecat),
+		Namespace: polecat.Namespace,
+	}}
+	if err := r.deletePod(ctx, polecat, p, "cleanup-timeout", client.GracePeriodSeconds(0)); err != nil {
+		log.Error(err, "Failed to force-delete Pod", "podName", p.Name)
+	}
+
+	log.Info("Cleanup timed out, removing finalizer anyway", "timeout", cleanupTimeout(), "error", cleanupErr.Error())
+	r.Recorder.Event(polecat, "Warning", "FinalizerForced",
+		fmt.Sprintf("Cleanup did not succeed within %s (%v); removed finalizer %s, "+
+			"Pod %s is left to garbage collection", cleanupTimeout(), cleanupErr, polecatFinalizer, p.Name))
+}
+
+// skipCleanup gives up on the cleanup of a polecat carrying the
+// ForceDeleteAnnotation without attempting it, recording a FinalizerForced
+// event.
+func (r *PolecatReconciler) skipCleanup(ctx context.Context, polecat *gastownv1alpha1.Polecat) {
+	podName := polecatPodName(polecat)
+	logf.FromContext(ctx).Info("Force delete requested, removing finalizer without cleanup",
+		"annotation", ForceDeleteAnnotation)
+	r.Recorder.Event(polecat, "Warning", "FinalizerForced",
+		fmt.Sprintf("Skipped cleanup for the %s annotation; removed finalizer %s, "+
+			"Pod %s is left to garbage collection", ForceDeleteAnnotation, polecatFinalizer, podName))
+}
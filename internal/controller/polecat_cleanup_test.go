@@ -0,0 +1,140 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+var _ = Describe("Polecat cleanup", func() {
+	Context("When the Pod of a deleted polecat cannot be deleted", func() {
+		var (
+			ctx      context.Context
+			c        client.Client
+			r        *PolecatReconciler
+			recorder *record.FakeRecorder
+		)
+
+		deleting := func(deletedAgo time.Duration) *gastownv1alpha1.Polecat {
+			deleted := metav1.NewTime(time.Now().Add(-deletedAgo))
+			return &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              "stuck",
+					Namespace:         "default",
+					Finalizers:        []string{polecatFinalizer},
+					DeletionTimestamp: &deleted,
+				},
+				Spec: gastownv1alpha1.PolecatSpec{Rig: "test-rig"},
+			}
+		}
+
+		newReconciler := func(polecat *gastownv1alpha1.Polecat) {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			agent := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{Name: "polecat-stuck", Namespace: "default"}}
+			c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(polecat, agent).
+				WithInterceptorFuncs(interceptor.Funcs{
+					Delete: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+						if _, ok := obj.(*corev1.Pod); ok {
+							deleteOpts := &client.DeleteOptions{}
+							deleteOpts.ApplyOptions(opts)
+							if deleteOpts.GracePeriodSeconds == nil || *deleteOpts.GracePeriodSeconds != 0 {
+								return errors.New("admission webhook denied the request")
+							}
+						}
+						return c.Delete(ctx, obj, opts...)
+					},
+				}).Build()
+			recorder = record.NewFakeRecorder(10)
+			r = &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		}
+
+		AfterEach(func() {
+			config.Set(nil)
+		})
+
+		It("should retry the cleanup until the timeout", func() {
+			polecat := deleting(time.Minute)
+			newReconciler(polecat)
+
+			result, err := r.handleDeletion(ctx, polecat, metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(requeueDefault()))
+			Expect(recorder.Events).To(Receive(ContainSubstring("CleanupFailed")))
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), &gastownv1alpha1.Polecat{})).To(Succeed())
+		})
+
+		It("should force-delete the Pod and remove the finalizer after the timeout", func() {
+			polecat := deleting(time.Hour)
+			newReconciler(polecat)
+
+			_, err := r.handleDeletion(ctx, polecat, metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("FinalizerForced")))
+			err = c.Get(ctx, client.ObjectKeyFromObject(polecat), &gastownv1alpha1.Polecat{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			err = c.Get(ctx, client.ObjectKey{Name: "polecat-stuck", Namespace: "default"}, &corev1.Pod{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		})
+
+		It("should remove the finalizer without cleanup when force-deleted", func() {
+			config.Set(&config.Config{CleanupOnTimeout: gastownv1alpha1.CleanupOnTimeoutWait})
+			polecat := deleting(time.Minute)
+			polecat.Annotations = map[string]string{ForceDeleteAnnotation: "true"}
+			newReconciler(polecat)
+
+			_, err := r.handleDeletion(ctx, polecat, metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring(ForceDeleteAnnotation)))
+			err = c.Get(ctx, client.ObjectKeyFromObject(polecat), &gastownv1alpha1.Polecat{})
+			Expect(apierrors.IsNotFound(err)).To(BeTrue())
+			Expect(c.Get(ctx, client.ObjectKey{Name: "polecat-stuck", Namespace: "default"}, &corev1.Pod{})).
+				To(Succeed(), "the Pod is left to garbage collection")
+		})
+
+		It("should keep retrying after the timeout when asked to wait", func() {
+			config.Set(&config.Config{CleanupTimeout: time.Minute, CleanupOnTimeout: gastownv1alpha1.CleanupOnTimeoutWait})
+			polecat := deleting(time.Hour)
+			newReconciler(polecat)
+
+			_, err := r.handleDeletion(ctx, polecat, metrics.NewReconcileTimer("polecat"))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("CleanupFailed")))
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), &gastownv1alpha1.Polecat{})).To(Succeed())
+		})
+	})
+})
@@ -19,6 +19,7 @@ package controller
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -26,21 +27,26 @@ import (
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/pkg/config"
 	gterrors "github.com/org/gastown-operator/pkg/errors"
 	"github.com/org/gastown-operator/pkg/metrics"
 	"github.com/org/gastown-operator/pkg/pod"
 )
 
 const (
-	// PolecatSyncInterval is how often we re-sync Pod status.
-	// Uses RequeueShort for active polecat monitoring.
+	// PolecatSyncInterval is how often we re-sync Pod status by default.
+	// The GastownConfig's requeue.short overrides it (see requeueShort), and
+	// a polecat's spec.syncIntervalSeconds overrides both.
 	PolecatSyncInterval = RequeueShort
 
 	// Condition types for Polecat
@@ -55,14 +61,39 @@ const (
 // Polecats run as Pods in the cluster.
 type PolecatReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// LogReader captures agent logs of failed Pods and reads the token usage
+	// reported by the telemetry sidecar. Optional; neither is read when nil.
+	LogReader PodLogReader
+
+	// DisableTTLCleanup keeps finished Polecats regardless of their
+	// spec.ttlSecondsAfterFinished
+	DisableTTLCleanup bool
+	// Tuning overrides the controller's workqueue settings. Optional.
+	Tuning config.ControllerTuning
+
+	// Audit records the agent Pods the controller starts and deletes.
+	// Optional.
+	Audit *audit.Log
 }
 
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats/finalizers,verbs=update
 // +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=pods/log,verbs=get
+// +kubebuilder:rbac:groups="",resources=pods/status,verbs=patch
+// +kubebuilder:rbac:groups=batch,resources=jobs,verbs=get;list;watch;create
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=rigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=refineries,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=emergencystops,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=beadstores,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=convoys,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile implements the state machine for Polecat lifecycle.
 func (r *PolecatReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
@@ -76,6 +107,15 @@ func (r *PolecatReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave resources processed by a newer operator alone
+	if skip, err := checkSchemaVersion(ctx, r.Client, r.Recorder, &polecat, &polecat.Status.Conditions); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, err
+	} else if skip {
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
 	log.Info("Reconciling Polecat",
 		"name", polecat.Name,
 		"rig", polecat.Spec.Rig,
@@ -89,8 +129,9 @@ func (r *PolecatReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	// Add finalizer if not present
 	if !controllerutil.ContainsFinalizer(&polecat, polecatFinalizer) {
 		log.Info("Adding finalizer to Polecat")
-		controllerutil.AddFinalizer(&polecat, polecatFinalizer)
-		if err := r.Update(ctx, &polecat); err != nil {
+		if err := gterrors.UpdateOnConflict(ctx, r.Client, &polecat, func() bool {
+			return controllerutil.AddFinalizer(&polecat, polecatFinalizer)
+		}); err != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(err, "failed to add finalizer")
 		}
@@ -98,18 +139,42 @@ func (r *PolecatReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{RequeueAfter: time.Millisecond}, nil
 	}
 
+	// Delete finished polecats whose TTL has expired
+	if r.ttlCleanupEnabled() {
+		deleted, err := r.expireFinished(ctx, &polecat)
+		if err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, err
+		}
+		if deleted {
+			timer.RecordResult(metrics.ResultSuccess)
+			return ctrl.Result{}, nil
+		}
+	}
+
 	// Handle based on desired state
+	var result ctrl.Result
+	var err error
 	switch polecat.Spec.DesiredState {
 	case gastownv1alpha1.PolecatDesiredWorking:
-		return r.ensureWorking(ctx, &polecat, timer)
+		result, err = r.ensureWorking(ctx, &polecat, timer)
 	case gastownv1alpha1.PolecatDesiredIdle:
-		return r.ensureIdle(ctx, &polecat, timer)
+		result, err = r.ensureIdle(ctx, &polecat, timer)
 	case gastownv1alpha1.PolecatDesiredTerminated:
-		return r.ensureTerminated(ctx, &polecat, timer)
+		result, err = r.ensureTerminated(ctx, &polecat, timer)
 	default:
 		log.Info("Unknown desired state, defaulting to idle")
-		return r.ensureIdle(ctx, &polecat, timer)
+		result, err = r.ensureIdle(ctx, &polecat, timer)
 	}
+
+	// Come back when the TTL expires
+	if remaining, ok := polecatTTLRemaining(&polecat, time.Now()); ok && err == nil && r.ttlCleanupEnabled() &&
+		(result.RequeueAfter == 0 || remaining < result.RequeueAfter) {
+		result.RequeueAfter = remaining
+	}
+	// Stuck and flapping polecats go first under backlog
+	withPriority(&result, &polecat, polecatPriority(&polecat))
+	return result, err
 }
 
 // ensureWorking ensures the polecat Pod is running.
@@ -121,20 +186,37 @@ func (r *PolecatReconciler) ensureWorking(ctx context.Context, polecat *gastownv
 		r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionFalse, "MissingKubernetesSpec",
 			"kubernetes spec is required")
 		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
-		if err := r.Status().Update(ctx, polecat); err != nil {
+		if err := r.updateStatus(ctx, polecat); err != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
 		}
 		timer.RecordResult(metrics.ResultRequeue)
-		return ctrl.Result{RequeueAfter: RequeueLong}, nil
+		return ctrl.Result{RequeueAfter: requeueLong()}, nil
 	}
 
-	podName := fmt.Sprintf("polecat-%s", polecat.Name)
+	podName := polecatPodName(polecat)
 
 	// Check if Pod already exists
 	var existingPod corev1.Pod
 	err := r.Get(ctx, client.ObjectKey{Name: podName, Namespace: polecat.Namespace}, &existingPod)
 	if err == nil {
+		// A Pod restarted for a rotated Secret is replaced once it is gone
+		if existingPod.Annotations[secretRestartAnnotation] != "" {
+			timer.RecordResult(metrics.ResultRequeue)
+			return ctrl.Result{RequeueAfter: requeueShort()}, nil
+		}
+		if polecat.Spec.RestartOnSecretChange && existingPod.DeletionTimestamp == nil &&
+			existingPod.Status.Phase != corev1.PodSucceeded && existingPod.Status.Phase != corev1.PodFailed {
+			secret, err := r.rotatedSecret(ctx, &existingPod)
+			if err != nil {
+				timer.RecordResult(metrics.ResultError)
+				return ctrl.Result{}, gterrors.Wrap(err, "failed to check secrets of pod")
+			}
+			if secret != "" {
+				return r.restartForSecret(ctx, polecat, &existingPod, secret, timer)
+			}
+		}
+
 		// Pod exists, sync status from it
 		return r.syncStatusFromPod(ctx, polecat, &existingPod, timer)
 	}
@@ -144,49 +226,283 @@ func (r *PolecatReconciler) ensureWorking(ctx context.Context, polecat *gastownv
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to get existing pod")
 	}
 
+	// An emergency stop holds new work on every rig
+	stop, err := activeEmergencyStop(ctx, r.Client)
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to list emergency stops")
+	}
+	if stop != nil {
+		log.Info("Emergency stop engaged, not starting Pod", "emergencyStop", stop.Name)
+		r.setCondition(polecat, ConditionProgressing, metav1.ConditionFalse, "EmergencyStop",
+			fmt.Sprintf("Emergency stop %s engaged: %s", stop.Name, stop.Spec.Reason))
+		if err := r.updateStatus(ctx, polecat); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueLong()}, nil
+	}
+
+	// A frozen rig does not start new work
+	suspended, err := isRigSuspended(ctx, r.Client, polecat.Spec.Rig)
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to get rig")
+	}
+	if suspended {
+		log.Info("Rig is suspended, not starting Pod", "rig", polecat.Spec.Rig)
+		r.setCondition(polecat, ConditionProgressing, metav1.ConditionFalse, "RigSuspended",
+			fmt.Sprintf("Rig %s is suspended; work starts when it is unfrozen", polecat.Spec.Rig))
+		if err := r.updateStatus(ctx, polecat); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueLong()}, nil
+	}
+
+	// Convoy beads wait for the beads they depend on; the convoy dispatching
+	// them triggers the polecat
+	convoy, err := heldByConvoy(ctx, r.Client, polecat)
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to get convoy")
+	}
+	if convoy != nil {
+		deps := beadDependencies(convoy, polecat.Spec.BeadID)
+		log.Info("Waiting for bead dependencies, not starting Pod", "convoy", convoy.Name, "dependsOn", deps)
+		r.setCondition(polecat, ConditionProgressing, metav1.ConditionFalse, "WaitingForDependencies",
+			fmt.Sprintf("Convoy %s holds bead %s until %s are done", convoy.Name, polecat.Spec.BeadID,
+				strings.Join(deps, ", ")))
+		if err := r.updateStatus(ctx, polecat); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueLong()}, nil
+	}
+
+	// Non-urgent work waits for an execution window of the rig. Requeue at
+	// least every requeueLong() to notice changes to the rig's windows.
+	wait, err := r.waitForWindow(ctx, polecat, time.Now())
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to get rig")
+	}
+	if wait > 0 {
+		log.Info("Outside the rig's execution windows, not starting Pod", "rig", polecat.Spec.Rig, "wait", wait)
+		if err := r.updateStatus(ctx, polecat); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: min(wait, requeueLong())}, nil
+	}
+
+	// Hold tasks estimated over the rig's per-task budget, so oversized
+	// work can be split before it is paid for, and all new tasks once the
+	// rig spent its daily budget
+	overBudget, err := r.checkBudget(ctx, polecat)
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to check the rig's budget")
+	}
+	if overBudget {
+		cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionOverBudget)
+		log.Info("Over the rig's budget, not starting Pod",
+			"rig", polecat.Spec.Rig, "reason", cond.Reason, "estimatedUSD", polecat.Status.CostEstimate.USD)
+		r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionFalse, "OverBudget", cond.Message)
+		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
+		if err := r.updateStatus(ctx, polecat); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueLong()}, nil
+	}
+
+	// Expired Claude credentials would fail the agent; hold the polecat until
+	// its Secret is refreshed
+	expired, err := r.checkCredentials(ctx, polecat, time.Now())
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to check claude credentials")
+	}
+	if expired {
+		log.Info("Claude credentials expired, not starting Pod", "secret", polecat.Spec.Kubernetes.ClaudeCredsSecretRef.Name)
+		if err := r.updateStatus(ctx, polecat); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueLong()}, nil
+	}
+
+	// Verify a custom agent image provides the agent's tools before starting
+	// work in it, instead of failing minutes into the startup script
+	defaults, err := rigPolecatDefaults(ctx, r.Client, polecat.Spec.Rig)
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to get polecat defaults of rig")
+	}
+	if builder := pod.NewBuilder(polecat).WithPolecatDefaults(defaults); builder.NeedsImageProbe() && config.Current().Enabled(config.FeatureAgentImageProbe) {
+		secrets, err := rigImagePullSecrets(ctx, r.Client, polecat.Spec.Rig)
+		if err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to get image pull secrets of rig")
+		}
+		result, message, err := r.probeImage(ctx, builder.WithImagePullSecrets(secrets))
+		if err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to probe agent image")
+		}
+		switch result {
+		case imageProbePending:
+			r.setCondition(polecat, ConditionImageIncompatible, metav1.ConditionUnknown, "Probing",
+				"Verifying that the agent image provides the required tools")
+			if err := r.updateStatus(ctx, polecat); err != nil {
+				timer.RecordResult(metrics.ResultError)
+				return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+			}
+			timer.RecordResult(metrics.ResultRequeue)
+			return ctrl.Result{RequeueAfter: requeueShort()}, nil
+		case imageProbeFailed:
+			log.Info("Agent image is incompatible", "reason", message)
+			r.Recorder.Event(polecat, "Warning", "ImageIncompatible", message)
+			r.setCondition(polecat, ConditionImageIncompatible, metav1.ConditionTrue, "ImageIncompatible", message)
+			r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionFalse, "ImageIncompatible", message)
+			polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
+			if err := r.updateStatus(ctx, polecat); err != nil {
+				timer.RecordResult(metrics.ResultError)
+				return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+			}
+			timer.RecordResult(metrics.ResultRequeue)
+			return ctrl.Result{RequeueAfter: requeueLong()}, nil
+		}
+		r.setCondition(polecat, ConditionImageIncompatible, metav1.ConditionFalse, "ImageCompatible",
+			"Agent image provides the required tools")
+	}
+
+	// Start only within the rig's share of the operator-wide capacity
+	available, err := r.capacityAvailable(ctx, polecat)
+	if err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to list polecats")
+	}
+	if !available {
+		log.Info("Polecat capacity exhausted, not starting Pod", "rig", polecat.Spec.Rig)
+		if err := r.updateStatus(ctx, polecat); err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+		}
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueDefault()}, nil
+	}
+
 	// Pod doesn't exist, create it
 	log.Info("Creating Pod for Polecat",
 		"podName", podName,
 		"beadID", polecat.Spec.BeadID,
 		"gitRepo", polecat.Spec.Kubernetes.GitRepository)
 
-	builder := pod.NewBuilder(polecat)
-	newPod, err := builder.Build()
+	newPod, err := r.buildPod(ctx, polecat)
 	if err != nil {
+		r.Recorder.Event(polecat, "Warning", "PodBuildFailed", err.Error())
 		r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionFalse, "PodBuildFailed",
 			err.Error())
 		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
-		if updateErr := r.Status().Update(ctx, polecat); updateErr != nil {
+		backoff := recordPolecatFailure(polecat)
+		if updateErr := r.updateStatus(ctx, polecat); updateErr != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update status")
 		}
 		timer.RecordResult(metrics.ResultRequeue)
-		return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+		return ctrl.Result{RequeueAfter: backoff}, nil
+	}
+
+	// Record the Secrets the Pod was started with, to restart it when they
+	// are rotated
+	if polecat.Spec.RestartOnSecretChange {
+		versions, err := r.secretVersions(ctx, polecat.Namespace, podSecretNames(newPod))
+		if err != nil {
+			timer.RecordResult(metrics.ResultError)
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to get secrets of pod")
+		}
+		metav1.SetMetaDataAnnotation(&newPod.ObjectMeta, SecretVersionsAnnotation, versions)
 	}
 
-	// Set owner reference for garbage collection
-	if err := controllerutil.SetControllerReference(polecat, newPod, r.Scheme); err != nil {
+	// Hand the bead to a warm Pod of the rig built like newPod, if one is
+	// waiting, instead of cold-starting a Pod
+	claimed, err := r.claimWarmPod(ctx, polecat, newPod)
+	if err != nil {
 		timer.RecordResult(metrics.ResultError)
-		return ctrl.Result{}, gterrors.Wrap(err, "failed to set owner reference")
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to claim warm pod")
 	}
+	if claimed != nil {
+		r.Audit.Record("polecat", audit.ActionStartPod, polecat, map[string]string{
+			"pod": claimed.Name, "bead": polecat.Spec.BeadID, "image": claimed.Spec.Containers[0].Image, "warmPool": "true",
+		}, nil)
+		newPod, podName = claimed, claimed.Name
+		// The assignment is the agent's first sign of life, not the warm
+		// Pod's start
+		now := metav1.Now()
+		polecat.Status.LastActivity = &now
+	} else {
+		// podName may still name a warm Pod the polecat claimed earlier, since gone
+		podName = newPod.Name
 
-	if err := r.Create(ctx, newPod); err != nil {
-		log.Error(err, "Failed to create Pod")
-		r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionFalse, "PodCreateFailed",
-			err.Error())
-		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
-		if updateErr := r.Status().Update(ctx, polecat); updateErr != nil {
+		// Set owner reference for garbage collection
+		if err := controllerutil.SetControllerReference(polecat, newPod, r.Scheme); err != nil {
 			timer.RecordResult(metrics.ResultError)
-			return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update status")
+			return ctrl.Result{}, gterrors.Wrap(err, "failed to set owner reference")
 		}
-		timer.RecordResult(metrics.ResultRequeue)
-		return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+
+		err = r.Create(ctx, newPod)
+		r.Audit.Record("polecat", audit.ActionStartPod, polecat, map[string]string{
+			"pod": podName, "bead": polecat.Spec.BeadID, "image": newPod.Spec.Containers[0].Image,
+		}, err)
+		if err != nil {
+			log.Error(err, "Failed to create Pod")
+			r.Recorder.Event(polecat, "Warning", "PodCreateFailed", err.Error())
+			r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionFalse, "PodCreateFailed",
+				err.Error())
+			polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
+			backoff := recordPolecatFailure(polecat)
+			if updateErr := r.updateStatus(ctx, polecat); updateErr != nil {
+				timer.RecordResult(metrics.ResultError)
+				return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update status")
+			}
+			timer.RecordResult(metrics.ResultRequeue)
+			return ctrl.Result{RequeueAfter: backoff}, nil
+		}
+	}
+
+	// Downstream systems group agent work by the bead's labels and epic
+	if err := annotateBead(ctx, r.Client, polecat, newPod); err != nil {
+		log.Error(err, "Failed to annotate Polecat with bead metadata")
 	}
 
-	// Update status with pod info
+	// Update status with pod info; logs of a previous Pod no longer apply
+	if retriedFailure(polecat) != nil || polecat.Status.AssignedBead == polecat.Spec.BeadID {
+		polecat.Status.Attempts++
+	} else {
+		polecat.Status.Attempts = 1
+		polecat.Status.LastFailure = nil
+	}
 	polecat.Status.PodName = podName
+	polecat.Status.LastLogs = ""
+	polecat.Status.LogsArtifact = ""
+	polecat.Status.ResumedFrom = ""
 	polecat.Status.Phase = gastownv1alpha1.PolecatPhaseWorking
 	polecat.Status.AssignedBead = polecat.Spec.BeadID
+	polecat.Status.FinishedAt = nil
+	polecat.Status.Agent = polecat.Spec.Agent
+	polecat.Status.AgentImage = newPod.Spec.Containers[0].Image
+	polecat.Status.AgentModel = ""
+	if polecat.Spec.AgentConfig != nil {
+		polecat.Status.AgentModel = polecat.Spec.AgentConfig.Model
+	}
 	// Old conditions (backward compatibility)
 	r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionTrue, "PodCreated",
 		"Pod created successfully")
@@ -199,15 +515,183 @@ func (r *PolecatReconciler) ensureWorking(ctx context.Context, polecat *gastownv
 		"Work in progress")
 	r.setCondition(polecat, ConditionDegraded, metav1.ConditionFalse, "Healthy",
 		"No issues detected")
+	polecat.Status.ConsecutiveFailures = 0
 
-	if err := r.Status().Update(ctx, polecat); err != nil {
+	if err := r.updateStatus(ctx, polecat); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
 	}
 
-	log.Info("Pod created for Polecat", "podName", podName)
+	if claimed != nil {
+		log.Info("Warm Pod claimed for Polecat", "podName", podName)
+		r.Recorder.Event(polecat, "Normal", "WarmPodClaimed",
+			fmt.Sprintf("Assigned bead %s to warm agent Pod %s", polecat.Spec.BeadID, podName))
+	} else {
+		log.Info("Pod created for Polecat", "podName", podName)
+		r.Recorder.Event(polecat, "Normal", "PodCreated",
+			fmt.Sprintf("Created agent Pod %s for bead %s", podName, polecat.Spec.BeadID))
+	}
 	timer.RecordResult(metrics.ResultSuccess)
-	return ctrl.Result{RequeueAfter: PolecatSyncInterval}, nil
+	return ctrl.Result{RequeueAfter: syncInterval(polecat.Spec.SyncIntervalSeconds, requeueShort())}, nil
+}
+
+// polecatPodName returns the name of the polecat's agent Pod: the Pod it
+// started, which may be a claimed warm Pod, else the one it would create.
+func polecatPodName(polecat *gastownv1alpha1.Polecat) string {
+	if polecat.Status.PodName != "" {
+		return polecat.Status.PodName
+	}
+	return fmt.Sprintf("polecat-%s", polecat.Name)
+}
+
+// buildPod builds the agent Pod, rendering the prompt template ConfigMap if one is referenced.
+func (r *PolecatReconciler) buildPod(ctx context.Context, polecat *gastownv1alpha1.Polecat) (*corev1.Pod, error) {
+	builder, err := podBuilder(ctx, r.Client, polecat)
+	if err != nil {
+		return nil, err
+	}
+	newPod, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+	// The Refinery merges the work branch of the primary repository
+	polecat.Status.Branch = builder.WorkBranch()
+	return newPod, nil
+}
+
+// podBuilder returns the builder of the polecat's agent Pod, set up with the
+// settings of its rig, prompt template and bead.
+func podBuilder(ctx context.Context, c client.Reader, polecat *gastownv1alpha1.Polecat) (*pod.Builder, error) {
+	builder := pod.NewBuilder(polecat)
+
+	if ref := polecat.Spec.Kubernetes.PromptTemplateRef; ref != nil {
+		var cm corev1.ConfigMap
+		if err := c.Get(ctx, client.ObjectKey{Name: ref.Name, Namespace: polecat.Namespace}, &cm); err != nil {
+			return nil, fmt.Errorf("failed to get prompt template ConfigMap %s: %w", ref.Name, err)
+		}
+		text, ok := cm.Data[pod.PromptTemplateKey]
+		if !ok {
+			return nil, fmt.Errorf("prompt template ConfigMap %s has no %q key", ref.Name, pod.PromptTemplateKey)
+		}
+		builder.WithPromptTemplate(text)
+	}
+
+	// Without its own ServiceAccount, the agent runs as the one its rig
+	// provisions
+	if polecat.Spec.Kubernetes.ServiceAccountName == "" {
+		sa, err := rigAgentServiceAccount(ctx, c, polecat)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get agent service account of rig %s: %w", polecat.Spec.Rig, err)
+		}
+		builder.WithServiceAccount(sa)
+	}
+	if len(polecat.Spec.Kubernetes.ImagePullSecrets) == 0 {
+		secrets, err := rigImagePullSecrets(ctx, c, polecat.Spec.Rig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get image pull secrets of rig %s: %w", polecat.Spec.Rig, err)
+		}
+		builder.WithImagePullSecrets(secrets)
+	}
+
+	defaults, err := rigPolecatDefaults(ctx, c, polecat.Spec.Rig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get polecat defaults of rig %s: %w", polecat.Spec.Rig, err)
+	}
+	builder.WithPolecatDefaults(defaults)
+
+	signing, err := rigCommitSigning(ctx, c, polecat.Spec.Rig)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get commit signing of rig %s: %w", polecat.Spec.Rig, err)
+	}
+	builder.WithCommitSigning(signing)
+
+	if len(polecat.Spec.Repositories) > 0 {
+		repos, err := rigRepositories(ctx, c, polecat.Spec.Rig)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get repositories of rig %s: %w", polecat.Spec.Rig, err)
+		}
+		builder.WithRigRepositories(repos)
+	}
+
+	// A retry of a failed bead starts from what went wrong last time
+	if failure := retriedFailure(polecat); failure != nil {
+		builder.WithPreviousAttempt(failure, polecat.Status.LastLogs, polecat.Status.LogsArtifact)
+	}
+
+	// Bead metadata is best-effort: rigs without a BeadStore work from the
+	// task description alone
+	polecat.Status.Bead = nil
+	bead, err := lookupBead(ctx, c, polecat.Namespace, polecat.Spec.Rig, polecat.Spec.BeadID)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to resolve bead metadata", "beadID", polecat.Spec.BeadID)
+	} else if bead != nil {
+		builder.WithBead(bead.Title, bead.Description, bead.Priority)
+		builder.WithBeadGrouping(beadGrouping(*bead))
+		summary := beadSummary(*bead)
+		polecat.Status.Bead = &summary
+	}
+	return builder, nil
+}
+
+// rigImagePullSecrets returns the default image pull secrets of a rig's
+// agent Pods, or nil if the rig does not exist.
+func rigImagePullSecrets(ctx context.Context, c client.Reader, rigName string) ([]corev1.LocalObjectReference, error) {
+	var rig gastownv1alpha1.Rig
+	if err := c.Get(ctx, client.ObjectKey{Name: rigName}, &rig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rig.Spec.ImagePullSecrets, nil
+}
+
+// rigPolecatDefaults returns the polecat defaults of the named rig, or nil
+// when it sets none or does not exist.
+func rigPolecatDefaults(ctx context.Context, c client.Reader, rigName string) (*gastownv1alpha1.PolecatDefaults, error) {
+	var rig gastownv1alpha1.Rig
+	if err := c.Get(ctx, client.ObjectKey{Name: rigName}, &rig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rig.Spec.PolecatDefaults, nil
+}
+
+// rigCommitSigning returns the commit signing key of the named rig, or nil
+// when it signs nothing or does not exist.
+func rigCommitSigning(ctx context.Context, c client.Reader, rigName string) (*gastownv1alpha1.CommitSigningSpec, error) {
+	var rig gastownv1alpha1.Rig
+	if err := c.Get(ctx, client.ObjectKey{Name: rigName}, &rig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rig.Spec.Signing, nil
+}
+
+// rigRepositories returns the repositories of the named rig besides its
+// gitURL, or none when it does not exist.
+func rigRepositories(ctx context.Context, c client.Reader, rigName string) ([]gastownv1alpha1.RigRepository, error) {
+	var rig gastownv1alpha1.Rig
+	if err := c.Get(ctx, client.ObjectKey{Name: rigName}, &rig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return rig.Spec.Repositories, nil
+}
+
+// retriedFailure returns the last failure when the polecat's next Pod retries
+// the failed bead, or nil.
+func retriedFailure(polecat *gastownv1alpha1.Polecat) *gastownv1alpha1.PolecatFailure {
+	if failure := polecat.Status.LastFailure; failure != nil && failure.Bead == polecat.Spec.BeadID {
+		return failure
+	}
+	return nil
 }
 
 // syncStatusFromPod updates Polecat status based on Pod status.
@@ -216,9 +700,23 @@ func (r *PolecatReconciler) ensureWorking(ctx context.Context, polecat *gastownv
 func (r *PolecatReconciler) syncStatusFromPod(ctx context.Context, polecat *gastownv1alpha1.Polecat, p *corev1.Pod, timer *metrics.ReconcileTimer) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
+	previousPhase := polecat.Status.Phase
 	polecat.Status.PodName = p.Name
 	polecat.Status.AssignedBead = polecat.Spec.BeadID
 
+	// The kubelet restarts a probed agent in place, but one that gave up
+	// under the exit code contract would only fail again: fail its Pod so
+	// the bead is retried like after any other failure
+	if p.Spec.RestartPolicy == corev1.RestartPolicyOnFailure &&
+		(p.Status.Phase == corev1.PodPending || p.Status.Phase == corev1.PodRunning) {
+		if t := pod.AgentTermination(p, polecat.Spec.Agent); t != nil && pod.IsTerminalExit(t) {
+			if err := r.failAgentPod(ctx, p, t); err != nil {
+				timer.RecordResult(metrics.ResultError)
+				return ctrl.Result{}, gterrors.Wrap(err, "failed to fail pod")
+			}
+		}
+	}
+
 	// Map Pod phase to Polecat phase and set conditions.
 	// We set BOTH old conditions (Ready, Working) and new standard conditions
 	// (Available, Progressing, Degraded) for backward compatibility during transition.
@@ -249,11 +747,28 @@ func (r *PolecatReconciler) syncStatusFromPod(ctx context.Context, polecat *gast
 			"Pod is running")
 		r.setCondition(polecat, ConditionAvailable, metav1.ConditionFalse, "NotReady",
 			"Work in progress")
-		r.setCondition(polecat, ConditionDegraded, metav1.ConditionFalse, "Healthy",
-			"No issues detected")
+		// The Witness marks agents that stopped showing activity
+		if stalled := meta.FindStatusCondition(polecat.Status.Conditions, ConditionStalled); stalled != nil &&
+			stalled.Status == metav1.ConditionTrue {
+			polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
+			r.setCondition(polecat, ConditionDegraded, metav1.ConditionTrue, "Stalled", stalled.Message)
+		} else if message, stalled := heartbeatStalled(polecat, p); stalled {
+			if previousPhase != gastownv1alpha1.PolecatPhaseStuck {
+				r.Recorder.Event(polecat, "Warning", "HeartbeatTimeout", message)
+			}
+			polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
+			r.setCondition(polecat, ConditionDegraded, metav1.ConditionTrue, "Stalled", message)
+		} else {
+			r.setCondition(polecat, ConditionDegraded, metav1.ConditionFalse, "Healthy",
+				"No issues detected")
+		}
 	case corev1.PodSucceeded:
 		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseDone
 		polecat.Status.PodActive = false
+		if polecat.Status.FinishedAt == nil {
+			now := metav1.Now()
+			polecat.Status.FinishedAt = &now
+		}
 		// Old conditions (backward compatibility)
 		r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionTrue, "PodSucceeded",
 			"Pod completed successfully")
@@ -266,6 +781,11 @@ func (r *PolecatReconciler) syncStatusFromPod(ctx context.Context, polecat *gast
 			"Work complete, ready for merge")
 		r.setCondition(polecat, ConditionDegraded, metav1.ConditionFalse, "Healthy",
 			"No issues detected")
+		polecat.Status.LastFailure = nil
+		if previousPhase != gastownv1alpha1.PolecatPhaseDone {
+			r.Recorder.Event(polecat, "Normal", "WorkComplete",
+				fmt.Sprintf("Finished bead %s, ready for merge", polecat.Spec.BeadID))
+		}
 	case corev1.PodFailed:
 		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
 		polecat.Status.PodActive = false
@@ -279,16 +799,66 @@ func (r *PolecatReconciler) syncStatusFromPod(ctx context.Context, polecat *gast
 			"Work failed")
 		r.setCondition(polecat, ConditionAvailable, metav1.ConditionFalse, "Failed",
 			"Work failed")
-		r.setCondition(polecat, ConditionDegraded, metav1.ConditionTrue, "PodFailed",
-			"Pod failed")
+		// Report why the agent failed when it exited under the exit code contract
+		reason, message := "PodFailed", "Pod failed"
+		if t := pod.AgentTermination(p, polecat.Spec.Agent); t != nil {
+			reason, message = pod.DescribeTermination(t)
+		}
+		// A stalled agent is already Stuck while its Pod runs; its failure is new
+		wasFailed := previousPhase == gastownv1alpha1.PolecatPhaseStuck && !isStalledDegraded(polecat)
+		r.setCondition(polecat, ConditionDegraded, metav1.ConditionTrue, reason, message)
+		if !wasFailed {
+			r.Recorder.Event(polecat, "Warning", reason, message)
+		}
+		if !wasFailed || polecat.Status.LastFailure == nil {
+			now := metav1.Now()
+			polecat.Status.LastFailure = &gastownv1alpha1.PolecatFailure{
+				Bead:     polecat.Spec.BeadID,
+				Attempt:  max(polecat.Status.Attempts, 1),
+				PodName:  p.Name,
+				Reason:   reason,
+				Message:  message,
+				FailedAt: &now,
+			}
+		}
+
+		// Keep the agent's last words before the Pod is garbage collected
+		if r.LogReader != nil && polecat.Status.LastLogs == "" {
+			r.captureLogs(ctx, polecat, p)
+		}
+	}
+
+	// A workspace resumed from a checkpoint reports its commit once the git
+	// init container finished
+	if commit := pod.ResumedFrom(p); commit != "" && commit != polecat.Status.ResumedFrom {
+		polecat.Status.ResumedFrom = commit
+		r.Recorder.Event(polecat, "Normal", "ResumedFromCheckpoint",
+			fmt.Sprintf("Resumed work branch %s from checkpoint %s", polecat.Status.Branch, commit))
 	}
 
-	// Update last activity from Pod start time
-	if p.Status.StartTime != nil {
-		polecat.Status.LastActivity = p.Status.StartTime
+	// Track in-place agent restarts (e.g., after a failed liveness probe)
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name == pod.AgentContainerName(polecat.Spec.Agent) {
+			polecat.Status.AgentRestarts = cs.RestartCount
+		}
+	}
+
+	r.updateActivity(ctx, polecat, p)
+	r.updateUsage(ctx, polecat, p)
+
+	// Offer to split beads too big to finish, and read the split a planner
+	// proposed
+	switch p.Status.Phase {
+	case corev1.PodFailed:
+		if err := r.suggestSplit(ctx, polecat, p); err != nil {
+			log.Error(err, "Failed to suggest splitting the bead")
+		}
+	case corev1.PodSucceeded:
+		r.readSplitProposal(ctx, polecat, p)
 	}
+	polecat.Status.ConsecutiveFailures = 0
 
-	if err := r.Status().Update(ctx, polecat); err != nil {
+	if err := r.updateStatus(ctx, polecat); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
 	}
@@ -305,14 +875,28 @@ func (r *PolecatReconciler) syncStatusFromPod(ctx context.Context, polecat *gast
 		return ctrl.Result{}, nil
 	}
 
-	return ctrl.Result{RequeueAfter: PolecatSyncInterval}, nil
+	return ctrl.Result{RequeueAfter: syncInterval(polecat.Spec.SyncIntervalSeconds, requeueShort())}, nil
+}
+
+// failAgentPod marks a Pod whose agent the kubelet would restart in place as
+// Failed with the agent's exit, which stops the restarts and its containers.
+func (r *PolecatReconciler) failAgentPod(ctx context.Context, p *corev1.Pod, t *corev1.ContainerStateTerminated) error {
+	failed := p.DeepCopy()
+	failed.Status.Phase = corev1.PodFailed
+	failed.Status.Reason, failed.Status.Message = pod.DescribeTermination(t)
+	if err := r.Status().Patch(ctx, failed, client.MergeFrom(p)); err != nil {
+		return err
+	}
+	logf.FromContext(ctx).Info("Failed Pod of agent that gave up", "podName", p.Name, "exitCode", t.ExitCode)
+	*p = *failed
+	return nil
 }
 
 // ensureIdle ensures the polecat is in idle state (no Pod running).
 func (r *PolecatReconciler) ensureIdle(ctx context.Context, polecat *gastownv1alpha1.Polecat, timer *metrics.ReconcileTimer) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	podName := fmt.Sprintf("polecat-%s", polecat.Name)
+	podName := polecatPodName(polecat)
 
 	// Check if Pod exists and delete it if so
 	var existingPod corev1.Pod
@@ -320,11 +904,19 @@ func (r *PolecatReconciler) ensureIdle(ctx context.Context, polecat *gastownv1al
 	if err == nil {
 		// Pod exists, delete it
 		log.Info("Deleting Pod to transition to idle", "podName", podName)
-		if err := r.Delete(ctx, &existingPod); err != nil && !apierrors.IsNotFound(err) {
+		if err := r.deletePod(ctx, polecat, &existingPod, "idle"); err != nil {
 			log.Error(err, "Failed to delete Pod")
+			r.Recorder.Event(polecat, "Warning", "PodDeleteFailed", err.Error())
+			backoff := recordPolecatFailure(polecat)
+			if updateErr := r.updateStatus(ctx, polecat); updateErr != nil {
+				timer.RecordResult(metrics.ResultError)
+				return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update status")
+			}
 			timer.RecordResult(metrics.ResultRequeue)
-			return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+			return ctrl.Result{RequeueAfter: backoff}, nil
 		}
+		r.Recorder.Event(polecat, "Normal", "Reset",
+			fmt.Sprintf("Deleted agent Pod %s; polecat is idle", podName))
 	} else if !apierrors.IsNotFound(err) {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to check pod existence")
@@ -347,8 +939,9 @@ func (r *PolecatReconciler) ensureIdle(ctx context.Context, polecat *gastownv1al
 		"Idle, no work to merge")
 	r.setCondition(polecat, ConditionDegraded, metav1.ConditionFalse, "Healthy",
 		"No issues detected")
+	polecat.Status.ConsecutiveFailures = 0
 
-	if err := r.Status().Update(ctx, polecat); err != nil {
+	if err := r.updateStatus(ctx, polecat); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to update polecat status")
 	}
@@ -362,7 +955,7 @@ func (r *PolecatReconciler) ensureIdle(ctx context.Context, polecat *gastownv1al
 func (r *PolecatReconciler) ensureTerminated(ctx context.Context, polecat *gastownv1alpha1.Polecat, timer *metrics.ReconcileTimer) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
-	podName := fmt.Sprintf("polecat-%s", polecat.Name)
+	podName := polecatPodName(polecat)
 
 	// Check if Pod exists
 	var existingPod corev1.Pod
@@ -370,26 +963,36 @@ func (r *PolecatReconciler) ensureTerminated(ctx context.Context, polecat *gasto
 	if err == nil {
 		// Pod exists, delete it
 		log.Info("Deleting Pod for terminated Polecat", "podName", podName)
-		if err := r.Delete(ctx, &existingPod); err != nil && !apierrors.IsNotFound(err) {
+		if err := r.deletePod(ctx, polecat, &existingPod, "terminated"); err != nil {
 			log.Error(err, "Failed to delete Pod")
+			r.Recorder.Event(polecat, "Warning", "PodDeleteFailed", err.Error())
 			r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionFalse, "PodDeleteFailed",
 				err.Error())
-			if updateErr := r.Status().Update(ctx, polecat); updateErr != nil {
+			backoff := recordPolecatFailure(polecat)
+			if updateErr := r.updateStatus(ctx, polecat); updateErr != nil {
 				timer.RecordResult(metrics.ResultError)
 				return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update status")
 			}
 			timer.RecordResult(metrics.ResultRequeue)
-			return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+			return ctrl.Result{RequeueAfter: backoff}, nil
 		}
 	} else if !apierrors.IsNotFound(err) {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to check pod existence")
 	}
 
+	if polecat.Status.Phase != gastownv1alpha1.PolecatPhaseTerminated {
+		r.Recorder.Event(polecat, "Normal", "Terminated", "Polecat terminated")
+	}
+
 	// Update status to terminated
 	polecat.Status.Phase = gastownv1alpha1.PolecatPhaseTerminated
 	polecat.Status.PodActive = false
 	polecat.Status.PodName = ""
+	if polecat.Status.FinishedAt == nil {
+		now := metav1.Now()
+		polecat.Status.FinishedAt = &now
+	}
 	// Old conditions (backward compatibility)
 	r.setCondition(polecat, ConditionPolecatReady, metav1.ConditionTrue, "Terminated",
 		"Polecat has been terminated")
@@ -400,8 +1003,9 @@ func (r *PolecatReconciler) ensureTerminated(ctx context.Context, polecat *gasto
 		"Polecat terminated")
 	r.setCondition(polecat, ConditionDegraded, metav1.ConditionFalse, "Terminated",
 		"Polecat terminated gracefully")
+	polecat.Status.ConsecutiveFailures = 0
 
-	if err := r.Status().Update(ctx, polecat); err != nil {
+	if err := r.updateStatus(ctx, polecat); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
 	}
@@ -423,6 +1027,12 @@ func (r *PolecatReconciler) setCondition(polecat *gastownv1alpha1.Polecat, condT
 	})
 }
 
+// updateStatus applies the Polecat status, leaving the Refinery's merge
+// conditions and fields and the BeadStore's fields untouched.
+func (r *PolecatReconciler) updateStatus(ctx context.Context, polecat *gastownv1alpha1.Polecat) error {
+	return applyStatusWithout(ctx, r.Client, polecat, fieldManagerPolecat, otherPolecatFields, polecatConditionTypes...)
+}
+
 // handleDeletion handles cleanup when a Polecat is being deleted.
 func (r *PolecatReconciler) handleDeletion(ctx context.Context, polecat *gastownv1alpha1.Polecat, timer *metrics.ReconcileTimer) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
@@ -434,17 +1044,24 @@ func (r *PolecatReconciler) handleDeletion(ctx context.Context, polecat *gastown
 
 	log.Info("Handling Polecat deletion, cleaning up resources")
 
-	// Cleanup Pod
-	if err := r.cleanupPod(ctx, polecat); err != nil {
-		log.Error(err, "Failed to cleanup Polecat Pod")
-		timer.RecordResult(metrics.ResultRequeue)
-		return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+	// Cleanup Pod, retrying until the cleanup timeout, unless asked to skip it
+	if polecat.Annotations[ForceDeleteAnnotation] == "true" {
+		r.skipCleanup(ctx, polecat)
+	} else if err := r.cleanupPod(ctx, polecat); err != nil {
+		if !shouldForceCleanup(polecat, time.Now()) {
+			log.Error(err, "Failed to cleanup Polecat Pod")
+			r.Recorder.Event(polecat, "Warning", "CleanupFailed", err.Error())
+			timer.RecordResult(metrics.ResultRequeue)
+			return ctrl.Result{RequeueAfter: requeueDefault()}, nil
+		}
+		r.forceCleanup(ctx, polecat, err)
 	}
 
-	// Remove finalizer after successful cleanup
+	// Remove finalizer after cleanup
 	log.Info("Cleanup complete, removing finalizer")
-	controllerutil.RemoveFinalizer(polecat, polecatFinalizer)
-	if err := r.Update(ctx, polecat); err != nil {
+	if err := gterrors.UpdateOnConflict(ctx, r.Client, polecat, func() bool {
+		return controllerutil.RemoveFinalizer(polecat, polecatFinalizer)
+	}); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to remove finalizer")
 	}
@@ -456,7 +1073,7 @@ func (r *PolecatReconciler) handleDeletion(ctx context.Context, polecat *gastown
 // cleanupPod deletes the Pod for a Polecat.
 func (r *PolecatReconciler) cleanupPod(ctx context.Context, polecat *gastownv1alpha1.Polecat) error {
 	log := logf.FromContext(ctx)
-	podName := fmt.Sprintf("polecat-%s", polecat.Name)
+	podName := polecatPodName(polecat)
 
 	var existingPod corev1.Pod
 	err := r.Get(ctx, client.ObjectKey{Name: podName, Namespace: polecat.Namespace}, &existingPod)
@@ -469,20 +1086,36 @@ func (r *PolecatReconciler) cleanupPod(ctx context.Context, polecat *gastownv1al
 	}
 
 	log.Info("Deleting Pod for Polecat cleanup", "podName", podName)
-	if err := r.Delete(ctx, &existingPod); err != nil && !apierrors.IsNotFound(err) {
+	if err := r.deletePod(ctx, polecat, &existingPod, "deleted"); err != nil {
 		return gterrors.Wrap(err, "failed to delete pod")
 	}
 
 	return nil
 }
 
+// deletePod deletes the agent Pod of the polecat for reason and audits it.
+// A Pod that is already gone is not an error.
+func (r *PolecatReconciler) deletePod(
+	ctx context.Context, polecat *gastownv1alpha1.Polecat, p *corev1.Pod, reason string, opts ...client.DeleteOption,
+) error {
+	err := client.IgnoreNotFound(r.Delete(ctx, p, opts...))
+	r.Audit.Record("polecat", audit.ActionDeletePod, polecat, map[string]string{"pod": p.Name, "reason": reason}, err)
+	return err
+}
+
 // SetupWithManager sets up the controller with the Manager.
 func (r *PolecatReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&gastownv1alpha1.Polecat{}).
+		// Convoys hold polecats until they dispatch their beads
+		Watches(&gastownv1alpha1.Convoy{},
+			handler.EnqueueRequestsFromMapFunc(r.polecatsForConvoy),
+			builder.WithPredicates(convoyDispatchChanged)).
+		// Rotated Secrets restart the Pods of restartOnSecretChange polecats
+		Watches(&corev1.Secret{},
+			handler.EnqueueRequestsFromMapFunc(r.polecatsForSecret),
+			builder.WithPredicates(secretDataChanged)).
 		Named("polecat").
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 5, // Limit concurrent pod creations
-		}).
+		WithOptions(controllerOptions(r.Tuning, 5)). // Limit concurrent pod creations
 		Complete(r)
 }
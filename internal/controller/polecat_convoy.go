@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"slices"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// beadDependencies returns the beads the tracked bead depends on in the
+// convoy.
+func beadDependencies(convoy *gastownv1alpha1.Convoy, bead string) []string {
+	var deps []string
+	for _, dep := range convoy.Spec.Dependencies {
+		if dep.Bead == bead {
+			deps = append(deps, dep.DependsOn...)
+		}
+	}
+	return deps
+}
+
+// heldByConvoy returns the convoy of the polecat while it holds the polecat's
+// bead for its dependencies, nil once the convoy dispatched it. Polecats
+// outside convoys, or whose bead has no dependencies, are never held.
+func heldByConvoy(ctx context.Context, c client.Reader, polecat *gastownv1alpha1.Polecat) (*gastownv1alpha1.Convoy, error) {
+	name := polecat.Labels[pod.ConvoyLabel]
+	if name == "" {
+		return nil, nil
+	}
+	var convoy gastownv1alpha1.Convoy
+	if err := c.Get(ctx, types.NamespacedName{Name: name, Namespace: polecat.Namespace}, &convoy); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if len(beadDependencies(&convoy, polecat.Spec.BeadID)) == 0 ||
+		slices.Contains(convoy.Status.DispatchedBeads, polecat.Spec.BeadID) {
+		return nil, nil
+	}
+	return &convoy, nil
+}
+
+// polecatsForConvoy maps a Convoy to its polecats, so that they start as soon
+// as the convoy dispatches their beads.
+func (r *PolecatReconciler) polecatsForConvoy(ctx context.Context, obj client.Object) []reconcile.Request {
+	var polecats gastownv1alpha1.PolecatList
+	if err := r.List(ctx, &polecats, client.InNamespace(obj.GetNamespace()),
+		client.MatchingLabels{pod.ConvoyLabel: obj.GetName()}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list polecats for convoy", "convoy", obj.GetName())
+		return nil
+	}
+
+	requests := make([]reconcile.Request, 0, len(polecats.Items))
+	for _, polecat := range polecats.Items {
+		requests = append(requests, reconcile.Request{
+			NamespacedName: types.NamespacedName{Name: polecat.Name, Namespace: polecat.Namespace},
+		})
+	}
+	return requests
+}
+
+// convoyDispatchChanged filters Convoy updates down to changes of the
+// dispatched beads, the only field polecats read.
+var convoyDispatchChanged = predicate.Funcs{
+	CreateFunc: func(event.CreateEvent) bool { return false },
+	DeleteFunc: func(event.DeleteEvent) bool { return true },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldConvoy, okOld := e.ObjectOld.(*gastownv1alpha1.Convoy)
+		newConvoy, okNew := e.ObjectNew.(*gastownv1alpha1.Convoy)
+		if !okOld || !okNew {
+			return false
+		}
+		return !slices.Equal(oldConvoy.Status.DispatchedBeads, newConvoy.Status.DispatchedBeads)
+	},
+}
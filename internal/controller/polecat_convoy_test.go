@@ -0,0 +1,146 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+var _ = Describe("Polecat convoy dependencies", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+		convoy *gastownv1alpha1.Convoy
+	)
+
+	polecatOn := func(name, bead string) *gastownv1alpha1.Polecat {
+		return &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       name,
+				Namespace:  "default",
+				Labels:     map[string]string{pod.ConvoyLabel: "pipeline"},
+				Finalizers: []string{polecatFinalizer},
+			},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:           "test-rig",
+				BeadID:        bead,
+				DesiredState:  gastownv1alpha1.PolecatDesiredWorking,
+				ExecutionMode: gastownv1alpha1.ExecutionModeKubernetes,
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository: "git@github.com:org/repo.git",
+					GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+				},
+			},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+		convoy = &gastownv1alpha1.Convoy{
+			ObjectMeta: metav1.ObjectMeta{Name: "pipeline", Namespace: "default"},
+			Spec: gastownv1alpha1.ConvoySpec{
+				Description:  "pipeline",
+				TrackedBeads: []string{"gt-1", "gt-2"},
+				Dependencies: []gastownv1alpha1.BeadDependency{{Bead: "gt-2", DependsOn: []string{"gt-1"}}},
+			},
+			Status: gastownv1alpha1.ConvoyStatus{DispatchedBeads: []string{"gt-1"}},
+		}
+	})
+
+	It("should hold only convoy beads not yet dispatched", func() {
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(convoy).Build()
+
+		held, err := heldByConvoy(ctx, c, polecatOn("nux", "gt-2"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(held).NotTo(BeNil())
+		Expect(held.Name).To(Equal("pipeline"))
+
+		held, err = heldByConvoy(ctx, c, polecatOn("furiosa", "gt-1"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(held).To(BeNil())
+
+		loose := polecatOn("slit", "gt-2")
+		loose.Labels = nil
+		held, err = heldByConvoy(ctx, c, loose)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(held).To(BeNil())
+	})
+
+	It("should not start the Pod of a held polecat", func() {
+		polecat := polecatOn("nux", "gt-2")
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(convoy, polecat).
+			WithStatusSubresource(polecat).
+			Build()
+		r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+		result, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(polecat)})
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(requeueLong()))
+
+		var pods corev1.PodList
+		Expect(c.List(ctx, &pods)).To(Succeed())
+		Expect(pods.Items).To(BeEmpty())
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), polecat)).To(Succeed())
+		cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionProgressing)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Reason).To(Equal("WaitingForDependencies"))
+		Expect(cond.Message).To(Equal("Convoy pipeline holds bead gt-2 until gt-1 are done"))
+	})
+
+	It("should trigger the convoy's polecats when it dispatches beads", func() {
+		other := polecatOn("slit", "gt-9")
+		other.Labels[pod.ConvoyLabel] = "other"
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(polecatOn("nux", "gt-2"), other).
+			Build()
+		r := &PolecatReconciler{Client: c, Scheme: scheme}
+
+		Expect(r.polecatsForConvoy(ctx, convoy)).To(ConsistOf(
+			reconcile.Request{NamespacedName: types.NamespacedName{Name: "nux", Namespace: "default"}},
+		))
+
+		dispatched := convoy.DeepCopy()
+		dispatched.Status.DispatchedBeads = []string{"gt-1", "gt-2"}
+		Expect(convoyDispatchChanged.Update(event.UpdateEvent{ObjectOld: convoy, ObjectNew: dispatched})).To(BeTrue())
+		progress := convoy.DeepCopy()
+		progress.Status.Progress = "1/2"
+		Expect(convoyDispatchChanged.Update(event.UpdateEvent{ObjectOld: convoy, ObjectNew: progress})).To(BeFalse())
+	})
+})
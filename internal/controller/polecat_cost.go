@@ -0,0 +1,184 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+	"github.com/org/gastown-operator/pkg/cost"
+)
+
+// ConditionOverBudget is True when the estimated cost of a polecat's task
+// exceeds its rig's per-task budget.
+const ConditionOverBudget = "OverBudget"
+
+// modelPrice returns the polecat's model, empty for the agent's default
+// model, and its price. Local (ollama) models are free.
+func modelPrice(polecat *gastownv1alpha1.Polecat) (string, cost.Price) {
+	var model string
+	price := cost.DefaultPrice
+	if cfg := polecat.Spec.AgentConfig; cfg != nil {
+		model = cfg.Model
+		if model != "" {
+			price, _ = cost.PriceFor(model, config.Current().ModelPrices)
+		}
+		if cfg.Provider == gastownv1alpha1.LLMProviderOllama {
+			price = cost.Price{}
+		}
+	}
+	return model, price
+}
+
+// estimateCost estimates the cost of the polecat's task from its size and
+// the price of its model.
+func estimateCost(polecat *gastownv1alpha1.Polecat) *gastownv1alpha1.CostEstimate {
+	model, price := modelPrice(polecat)
+	estimate := cost.EstimateTask(polecat.Spec.BeadID+"\n"+polecat.Spec.TaskDescription, price)
+	return &gastownv1alpha1.CostEstimate{
+		Model:        model,
+		InputTokens:  estimate.InputTokens,
+		OutputTokens: estimate.OutputTokens,
+		USD:          strconv.FormatFloat(estimate.USD, 'f', 2, 64),
+	}
+}
+
+// checkBudget records the polecat's cost estimate and compares it with the
+// budgets of its rig. It reports whether the polecat must be held: the rig
+// spent its daily budget, or the polecat is over the per-task budget of a
+// rig whose overBudgetAction is Reject. The OverBudget condition is set to
+// match.
+func (r *PolecatReconciler) checkBudget(ctx context.Context, polecat *gastownv1alpha1.Polecat) (bool, error) {
+	estimate := estimateCost(polecat)
+	polecat.Status.CostEstimate = estimate
+
+	var rig gastownv1alpha1.Rig
+	if err := r.Get(ctx, client.ObjectKey{Name: polecat.Spec.Rig}, &rig); err != nil && !apierrors.IsNotFound(err) {
+		return false, err
+	}
+	if held, err := r.checkDailyBudget(ctx, polecat, &rig, time.Now()); held || err != nil {
+		return held, err
+	}
+
+	settings := rig.Spec.Settings
+	budget, err := strconv.ParseFloat(settings.MaxTaskCostUSD, 64)
+	if settings.MaxTaskCostUSD == "" || err != nil {
+		if meta.FindStatusCondition(polecat.Status.Conditions, ConditionOverBudget) != nil {
+			r.setCondition(polecat, ConditionOverBudget, metav1.ConditionFalse, "NoBudget",
+				"The rig has no per-task budget")
+		}
+		return false, nil
+	}
+
+	usd, _ := strconv.ParseFloat(estimate.USD, 64)
+	if usd <= budget {
+		r.setCondition(polecat, ConditionOverBudget, metav1.ConditionFalse, "WithinBudget",
+			fmt.Sprintf("Estimated cost $%s is within the per-task budget of $%s", estimate.USD, settings.MaxTaskCostUSD))
+		return false, nil
+	}
+
+	reject := settings.OverBudgetAction != gastownv1alpha1.OverBudgetFlag
+	message := fmt.Sprintf("Estimated cost $%s exceeds the per-task budget of $%s of rig %s; consider splitting the bead",
+		estimate.USD, settings.MaxTaskCostUSD, rig.Name)
+	reason := "Flagged"
+	if reject {
+		reason = "Rejected"
+	}
+	if !meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionOverBudget) {
+		r.Recorder.Event(polecat, "Warning", "OverBudget", message)
+	}
+	r.setCondition(polecat, ConditionOverBudget, metav1.ConditionTrue, reason, message)
+	return reject, nil
+}
+
+// checkDailyBudget holds the polecat while the rig's polecats created today
+// (UTC) have spent the rig's daily budget.
+func (r *PolecatReconciler) checkDailyBudget(
+	ctx context.Context, polecat *gastownv1alpha1.Polecat, rig *gastownv1alpha1.Rig, now time.Time,
+) (bool, error) {
+	if rig.Spec.Budget == nil || rig.Spec.Budget.MaxUSDPerDay == "" {
+		return false, nil
+	}
+	budget, err := strconv.ParseFloat(rig.Spec.Budget.MaxUSDPerDay, 64)
+	if err != nil {
+		return false, nil
+	}
+
+	var polecats gastownv1alpha1.PolecatList
+	if err := r.List(ctx, &polecats); err != nil {
+		return false, err
+	}
+	var rigPolecats []gastownv1alpha1.Polecat
+	for _, p := range polecats.Items {
+		if p.Spec.Rig == rig.Name {
+			rigPolecats = append(rigPolecats, p)
+		}
+	}
+	usage := rigUsage(rigPolecats, now)
+	spent, _ := strconv.ParseFloat(usage.DayUSD, 64)
+	if spent < budget {
+		return false, nil
+	}
+
+	message := fmt.Sprintf("Rig %s spent $%s of its daily budget of $%s on %s; new polecats wait for the next day (UTC)",
+		rig.Name, usage.DayUSD, rig.Spec.Budget.MaxUSDPerDay, usage.Day)
+	cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionOverBudget)
+	if cond == nil || cond.Status != metav1.ConditionTrue || cond.Reason != "DailyBudgetExceeded" {
+		r.Recorder.Event(polecat, "Warning", "DailyBudgetExceeded", message)
+	}
+	r.setCondition(polecat, ConditionOverBudget, metav1.ConditionTrue, "DailyBudgetExceeded", message)
+	return true, nil
+}
+
+// usageUSD prices the token usage of the polecat's agent
+func usageUSD(polecat *gastownv1alpha1.Polecat, usage cost.Usage) string {
+	_, price := modelPrice(polecat)
+	return strconv.FormatFloat(usage.USD(price), 'f', 2, 64)
+}
+
+// rigUsage sums the usage of a rig's polecats. The daily spend counts the
+// polecats created on now's UTC day, wherever their usage falls.
+func rigUsage(polecats []gastownv1alpha1.Polecat, now time.Time) *gastownv1alpha1.RigUsage {
+	day := now.UTC().Format(time.DateOnly)
+	usage := &gastownv1alpha1.RigUsage{Day: day}
+	var dayUSD, totalUSD float64
+	for _, p := range polecats {
+		u := p.Status.Usage
+		if u == nil {
+			continue
+		}
+		usd, _ := strconv.ParseFloat(u.USD, 64)
+		totalUSD += usd
+		if p.CreationTimestamp.UTC().Format(time.DateOnly) == day {
+			dayUSD += usd
+		}
+		usage.InputTokens += u.InputTokens + u.CacheReadTokens
+		usage.OutputTokens += u.OutputTokens
+	}
+	usage.DayUSD = strconv.FormatFloat(dayUSD, 'f', 2, 64)
+	usage.TotalUSD = strconv.FormatFloat(totalUSD, 'f', 2, 64)
+	return usage
+}
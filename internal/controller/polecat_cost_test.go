@@ -0,0 +1,217 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+	"github.com/org/gastown-operator/pkg/cost"
+)
+
+var _ = Describe("Polecat cost estimation", func() {
+	Context("When checking a polecat against its rig's budget", func() {
+		var (
+			ctx      context.Context
+			r        *PolecatReconciler
+			recorder *record.FakeRecorder
+		)
+
+		newPolecat := func(task string) *gastownv1alpha1.Polecat {
+			return &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "costly", Namespace: "default"},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:             "budget-rig",
+					BeadID:          "bud-1",
+					TaskDescription: task,
+					AgentConfig:     &gastownv1alpha1.AgentConfig{Model: "claude-opus-4"},
+				},
+			}
+		}
+
+		newReconciler := func(settings gastownv1alpha1.RigSettings) {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			rig := &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "budget-rig"},
+				Spec:       gastownv1alpha1.RigSpec{Settings: settings},
+			}
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig).Build()
+			recorder = record.NewFakeRecorder(10)
+			r = &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		}
+
+		AfterEach(func() {
+			config.Set(nil)
+		})
+
+		It("should price the estimate for the polecat's model", func() {
+			opus := estimateCost(newPolecat("Add a flag"))
+			Expect(opus.Model).To(Equal("claude-opus-4"))
+			Expect(opus.InputTokens).To(BeNumerically(">", 0))
+
+			local := newPolecat("Add a flag")
+			local.Spec.AgentConfig.Provider = gastownv1alpha1.LLMProviderOllama
+			Expect(estimateCost(local).USD).To(Equal("0.00"))
+
+			config.Set(&config.Config{ModelPrices: map[string]cost.Price{"claude-opus": {}}})
+			Expect(estimateCost(newPolecat("Add a flag")).USD).To(Equal("0.00"))
+		})
+
+		It("should record the estimate without a budget", func() {
+			newReconciler(gastownv1alpha1.RigSettings{})
+			polecat := newPolecat("Add a flag")
+
+			held, err := r.checkBudget(ctx, polecat)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeFalse())
+			Expect(polecat.Status.CostEstimate).NotTo(BeNil())
+			Expect(meta.FindStatusCondition(polecat.Status.Conditions, ConditionOverBudget)).To(BeNil())
+		})
+
+		It("should pass polecats within the budget", func() {
+			newReconciler(gastownv1alpha1.RigSettings{MaxTaskCostUSD: "500"})
+			polecat := newPolecat("Add a flag")
+
+			held, err := r.checkBudget(ctx, polecat)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeFalse())
+			Expect(meta.IsStatusConditionFalse(polecat.Status.Conditions, ConditionOverBudget)).To(BeTrue())
+		})
+
+		It("should hold polecats over the budget by default", func() {
+			newReconciler(gastownv1alpha1.RigSettings{MaxTaskCostUSD: "1.00"})
+			polecat := newPolecat(strings.Repeat("Rewrite the module. ", 500))
+
+			held, err := r.checkBudget(ctx, polecat)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeTrue())
+			cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionOverBudget)
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("Rejected"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("OverBudget")))
+
+			// Reported once, not on every reconcile
+			_, err = r.checkBudget(ctx, polecat)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).NotTo(Receive())
+		})
+
+		It("should only flag polecats over the budget with the Flag action", func() {
+			newReconciler(gastownv1alpha1.RigSettings{
+				MaxTaskCostUSD:   "1.00",
+				OverBudgetAction: gastownv1alpha1.OverBudgetFlag,
+			})
+			polecat := newPolecat(strings.Repeat("Rewrite the module. ", 500))
+
+			held, err := r.checkBudget(ctx, polecat)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeFalse())
+			cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionOverBudget)
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("Flagged"))
+		})
+	})
+
+	Context("When checking a polecat against its rig's daily budget", func() {
+		ctx := context.Background()
+		now := time.Date(2026, 3, 14, 15, 0, 0, 0, time.UTC)
+
+		spent := func(name string, created time.Time, usd string) *gastownv1alpha1.Polecat {
+			return &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:              name,
+					Namespace:         "default",
+					CreationTimestamp: metav1.NewTime(created),
+				},
+				Spec:   gastownv1alpha1.PolecatSpec{Rig: "daily-rig", BeadID: "day-1"},
+				Status: gastownv1alpha1.PolecatStatus{Usage: &gastownv1alpha1.TokenUsage{USD: usd}},
+			}
+		}
+
+		It("should sum the rig's usage by day", func() {
+			usage := rigUsage([]gastownv1alpha1.Polecat{
+				*spent("today", now.Add(-time.Hour), "4.25"),
+				*spent("yesterday", now.Add(-24*time.Hour), "10.00"),
+				{ObjectMeta: metav1.ObjectMeta{Name: "unstarted", CreationTimestamp: metav1.NewTime(now)}},
+			}, now)
+			Expect(usage.Day).To(Equal("2026-03-14"))
+			Expect(usage.DayUSD).To(Equal("4.25"))
+			Expect(usage.TotalUSD).To(Equal("14.25"))
+		})
+
+		It("should hold new polecats once the rig spent its daily budget", func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			rig := &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "daily-rig"},
+				Spec: gastownv1alpha1.RigSpec{
+					Budget: &gastownv1alpha1.RigBudget{MaxUSDPerDay: "10.00"},
+				},
+			}
+			other := spent("other-rig", now, "100.00")
+			other.Spec.Rig = "other"
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(rig, spent("early", now.Add(-time.Hour), "6.00"), other).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "late", Namespace: "default"},
+				Spec:       gastownv1alpha1.PolecatSpec{Rig: "daily-rig", BeadID: "day-2"},
+			}
+
+			held, err := r.checkDailyBudget(ctx, polecat, rig, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeFalse())
+
+			Expect(c.Create(ctx, spent("busy", now.Add(-time.Minute), "4.00"))).To(Succeed())
+			held, err = r.checkDailyBudget(ctx, polecat, rig, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeTrue())
+			cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionOverBudget)
+			Expect(cond.Status).To(Equal(metav1.ConditionTrue))
+			Expect(cond.Reason).To(Equal("DailyBudgetExceeded"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("DailyBudgetExceeded")))
+
+			// Reported once, not on every reconcile
+			_, err = r.checkDailyBudget(ctx, polecat, rig, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).NotTo(Receive())
+
+			// A new day starts with a fresh budget
+			held, err = r.checkDailyBudget(ctx, polecat, rig, now.Add(12*time.Hour))
+			Expect(err).NotTo(HaveOccurred())
+			Expect(held).To(BeFalse())
+		})
+	})
+})
@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// ConditionImageIncompatible is True when a custom agent image lacks tools
+// the agent needs, Unknown while the image is being probed.
+const ConditionImageIncompatible = "ImageIncompatible"
+
+// imageProbeResult is the outcome of an agent image capability probe
+type imageProbeResult int
+
+const (
+	imageProbePending imageProbeResult = iota
+	imageProbePassed
+	imageProbeFailed
+)
+
+// imagePullFailures are waiting reasons that fail the probe without waiting
+// for its deadline.
+var imagePullFailures = map[string]bool{
+	"ErrImagePull":      true,
+	"ImagePullBackOff":  true,
+	"InvalidImageName":  true,
+	"ErrImageNeverPull": true,
+}
+
+// probeImage runs, or reads the cached result of, the capability probe Job
+// for the polecat's agent image. The returned message explains a failure.
+func (r *PolecatReconciler) probeImage(ctx context.Context, builder *pod.Builder) (imageProbeResult, string, error) {
+	log := logf.FromContext(ctx)
+
+	job, err := builder.ImageProbeJob()
+	if err != nil {
+		return imageProbeFailed, err.Error(), nil
+	}
+
+	var existing batchv1.Job
+	err = r.Get(ctx, client.ObjectKeyFromObject(job), &existing)
+	if apierrors.IsNotFound(err) {
+		log.Info("Probing agent image", "image", job.Spec.Template.Spec.Containers[0].Image, "job", job.Name)
+		if err := r.Create(ctx, job); err != nil && !apierrors.IsAlreadyExists(err) {
+			return imageProbePending, "", fmt.Errorf("failed to create image probe job: %w", err)
+		}
+		return imageProbePending, "", nil
+	}
+	if err != nil {
+		return imageProbePending, "", fmt.Errorf("failed to get image probe job: %w", err)
+	}
+
+	for _, cond := range existing.Status.Conditions {
+		if cond.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch cond.Type {
+		case batchv1.JobComplete:
+			return imageProbePassed, "", nil
+		case batchv1.JobFailed:
+			message, err := r.imageProbeMessage(ctx, &existing)
+			if err != nil {
+				return imageProbePending, "", err
+			}
+			if message == "" {
+				message = cond.Message
+			}
+			return imageProbeFailed, message, nil
+		}
+	}
+
+	// Unpullable images never finish the probe; fail fast instead of
+	// waiting for the Job deadline
+	message, err := r.imageProbeMessage(ctx, &existing)
+	if err != nil {
+		return imageProbePending, "", err
+	}
+	if message != "" {
+		return imageProbeFailed, message, nil
+	}
+	return imageProbePending, "", nil
+}
+
+// imageProbeMessage returns the probe Pod's termination message, or the
+// reason its image cannot be pulled. Empty while the probe is running.
+func (r *PolecatReconciler) imageProbeMessage(ctx context.Context, job *batchv1.Job) (string, error) {
+	var pods corev1.PodList
+	if err := r.List(ctx, &pods, client.InNamespace(job.Namespace),
+		client.MatchingLabels{batchv1.JobNameLabel: job.Name}); err != nil {
+		return "", fmt.Errorf("failed to list image probe pods: %w", err)
+	}
+
+	for _, p := range pods.Items {
+		for _, cs := range p.Status.ContainerStatuses {
+			if cs.Name != pod.ImageProbeContainerName {
+				continue
+			}
+			if t := cs.State.Terminated; t != nil && t.ExitCode != 0 {
+				return strings.TrimSpace(t.Message), nil
+			}
+			if w := cs.State.Waiting; w != nil && imagePullFailures[w.Reason] {
+				return fmt.Sprintf("cannot pull image %s: %s", cs.Image, w.Message), nil
+			}
+		}
+	}
+	return "", nil
+}
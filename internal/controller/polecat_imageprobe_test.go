@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+var _ = Describe("Polecat image probe", func() {
+	Context("When probing agent images", func() {
+		var (
+			ctx     context.Context
+			c       client.Client
+			r       *PolecatReconciler
+			builder *pod.Builder
+			job     *batchv1.Job
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c = fake.NewClientBuilder().WithScheme(scheme).WithStatusSubresource(&batchv1.Job{}).Build()
+			r = &PolecatReconciler{Client: c, Scheme: scheme, Recorder: &record.FakeRecorder{}}
+
+			builder = pod.NewBuilder(&gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig: "test-rig",
+					Kubernetes: &gastownv1alpha1.KubernetesSpec{
+						GitRepository: "git@github.com:org/repo.git",
+						GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+						Image:         "example.com/custom-agent:v1",
+					},
+				},
+			})
+
+			var err error
+			job, err = builder.ImageProbeJob()
+			Expect(err).NotTo(HaveOccurred())
+		})
+
+		finishJob := func(condType batchv1.JobConditionType) {
+			existing := &batchv1.Job{}
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(job), existing)).To(Succeed())
+			existing.Status.Conditions = append(existing.Status.Conditions,
+				batchv1.JobCondition{Type: condType, Status: corev1.ConditionTrue, Message: "BackoffLimitExceeded"})
+			Expect(c.Status().Update(ctx, existing)).To(Succeed())
+		}
+
+		probePod := func(state corev1.ContainerState) {
+			Expect(c.Create(ctx, &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      job.Name + "-abcde",
+					Namespace: job.Namespace,
+					Labels:    map[string]string{batchv1.JobNameLabel: job.Name},
+				},
+				Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{{
+					Name:  pod.ImageProbeContainerName,
+					Image: "example.com/custom-agent:v1",
+					State: state,
+				}}},
+			})).To(Succeed())
+		}
+
+		It("should start a probe Job and wait for it", func() {
+			result, _, err := r.probeImage(ctx, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(imageProbePending))
+			Expect(c.Get(ctx, client.ObjectKeyFromObject(job), &batchv1.Job{})).To(Succeed())
+
+			finishJob(batchv1.JobComplete)
+			result, _, err = r.probeImage(ctx, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(imageProbePassed))
+		})
+
+		It("should report the missing tools", func() {
+			_, _, err := r.probeImage(ctx, builder)
+			Expect(err).NotTo(HaveOccurred())
+			probePod(corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: 1, Message: "missing required tools: claude gh\n"}})
+			finishJob(batchv1.JobFailed)
+
+			result, message, err := r.probeImage(ctx, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(imageProbeFailed))
+			Expect(message).To(Equal("missing required tools: claude gh"))
+		})
+
+		It("should fail fast on unpullable images", func() {
+			_, _, err := r.probeImage(ctx, builder)
+			Expect(err).NotTo(HaveOccurred())
+			probePod(corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{
+				Reason: "ImagePullBackOff", Message: "manifest unknown"}})
+
+			result, message, err := r.probeImage(ctx, builder)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(imageProbeFailed))
+			Expect(message).To(ContainSubstring("cannot pull image example.com/custom-agent:v1"))
+		})
+	})
+})
@@ -0,0 +1,223 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"path"
+	"time"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/logarchive"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+const (
+	// PolecatLastLogsBytes is how much of the agent log tail is kept in
+	// status.lastLogs. Kept small: status lives in etcd.
+	PolecatLastLogsBytes = 4 * 1024
+
+	// PolecatArchivedLogBytes caps the log uploaded to the log archive;
+	// longer logs keep their tail.
+	PolecatArchivedLogBytes = 10 * 1024 * 1024
+
+	// polecatLogCaptureTimeout bounds reading and uploading the log
+	polecatLogCaptureTimeout = time.Minute
+
+	// truncatedLogMarker prefixes logs that were cut
+	truncatedLogMarker = "...(truncated)\n"
+)
+
+// PodLogReader streams container logs. The controller-runtime client cannot
+// read logs, so this is backed by a clientset.
+type PodLogReader interface {
+	StreamLogs(ctx context.Context, namespace, podName, container string) (io.ReadCloser, error)
+}
+
+// clientsetLogReader reads logs through the Kubernetes API.
+type clientsetLogReader struct {
+	clientset kubernetes.Interface
+}
+
+// NewPodLogReader creates a PodLogReader backed by clientset.
+func NewPodLogReader(clientset kubernetes.Interface) PodLogReader {
+	return &clientsetLogReader{clientset: clientset}
+}
+
+func (r *clientsetLogReader) StreamLogs(ctx context.Context, namespace, podName, container string) (io.ReadCloser, error) {
+	return r.clientset.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{Container: container}).Stream(ctx)
+}
+
+// tailBuffer is an io.Writer that keeps the last max bytes written.
+type tailBuffer struct {
+	max       int
+	buf       []byte
+	truncated bool
+}
+
+func (t *tailBuffer) Write(p []byte) (int, error) {
+	t.buf = append(t.buf, p...)
+	// Compact only once twice the limit is buffered to keep writes amortized O(1)
+	if len(t.buf) > 2*t.max {
+		t.buf = append(t.buf[:0], t.buf[len(t.buf)-t.max:]...)
+		t.truncated = true
+	}
+	return len(p), nil
+}
+
+// Bytes returns the last max bytes written and whether earlier output was dropped.
+func (t *tailBuffer) Bytes() ([]byte, bool) {
+	if len(t.buf) > t.max {
+		return t.buf[len(t.buf)-t.max:], true
+	}
+	return t.buf, t.truncated
+}
+
+// tailString returns the last n bytes of log, cut at a rune boundary and
+// marked when truncated.
+func tailString(log []byte, n int, truncated bool) string {
+	if len(log) > n {
+		log = log[len(log)-n:]
+		truncated = true
+	}
+	for len(log) > 0 && !utf8.RuneStart(log[0]) {
+		log = log[1:]
+	}
+	if truncated {
+		return truncatedLogMarker + string(log)
+	}
+	return string(log)
+}
+
+// captureLogs keeps the tail of the failed agent's log in status.lastLogs and
+// uploads the full log when the rig has a log archive. Secrets are redacted
+// first. Failures are recorded in the status instead of retried: the Pod may
+// already be gone.
+func (r *PolecatReconciler) captureLogs(ctx context.Context, polecat *gastownv1alpha1.Polecat, p *corev1.Pod) {
+	log := logf.FromContext(ctx)
+	ctx, cancel := context.WithTimeout(ctx, polecatLogCaptureTimeout)
+	defer cancel()
+
+	stream, err := r.LogReader.StreamLogs(ctx, p.Namespace, p.Name, pod.AgentContainerName(polecat.Spec.Agent))
+	if err != nil {
+		log.Error(err, "Failed to read agent logs", "podName", p.Name)
+		polecat.Status.LastLogs = fmt.Sprintf("(logs unavailable: %v)", err)
+		return
+	}
+	defer func() { _ = stream.Close() }()
+
+	tail := &tailBuffer{max: PolecatArchivedLogBytes}
+	if _, err := io.Copy(tail, stream); err != nil {
+		log.Error(err, "Failed to read agent logs", "podName", p.Name)
+	}
+	logs, truncated := tail.Bytes()
+
+	// Without the rig's own rules the log is redacted with the default
+	// rules, kept in the status but not archived
+	rig := &gastownv1alpha1.Rig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: polecat.Spec.Rig}, rig); err != nil {
+		log.Error(err, "Failed to get rig, not archiving agent logs", "rig", polecat.Spec.Rig)
+		rig = nil
+	}
+	redactor, err := logRedactor(rig)
+	if err != nil {
+		log.Error(err, "Invalid log redaction rules, not archiving agent logs", "rig", polecat.Spec.Rig)
+		rig = nil
+		redactor, _ = logRedactor(nil)
+	}
+	logs = redactor.Redact(logs)
+
+	polecat.Status.LastLogs = tailString(logs, PolecatLastLogsBytes, truncated)
+	if polecat.Status.LastLogs == "" {
+		polecat.Status.LastLogs = "(no output)"
+	}
+	if rig == nil {
+		return
+	}
+
+	artifact, err := r.archiveLogs(ctx, polecat, rig, p, logs, truncated)
+	if err != nil {
+		log.Error(err, "Failed to archive agent logs", "podName", p.Name)
+		return
+	}
+	polecat.Status.LogsArtifact = artifact
+}
+
+// logRedactor returns the redactor for the rig's agent logs: the default
+// rules unless the rig disables them, then the rig's own rules. A nil rig
+// gets the default rules.
+func logRedactor(rig *gastownv1alpha1.Rig) (*logarchive.Redactor, error) {
+	var redaction *gastownv1alpha1.LogRedactionSpec
+	if rig != nil && rig.Spec.LogArchive != nil {
+		redaction = rig.Spec.LogArchive.Redaction
+	}
+	var rules []logarchive.Rule
+	if redaction == nil || !redaction.DisableDefaultRules {
+		rules = append(rules, logarchive.DefaultRules...)
+	}
+	if redaction != nil {
+		for _, rule := range redaction.Rules {
+			rules = append(rules, logarchive.Rule{Name: rule.Name, Pattern: rule.Pattern, Replacement: rule.Replacement})
+		}
+	}
+	return logarchive.NewRedactor(rules)
+}
+
+// archiveLogs uploads the log to the rig's log archive, if configured.
+// Returns the object URI, or "" when the rig has no archive.
+func (r *PolecatReconciler) archiveLogs(
+	ctx context.Context, polecat *gastownv1alpha1.Polecat, rig *gastownv1alpha1.Rig, p *corev1.Pod,
+	logs []byte, truncated bool,
+) (string, error) {
+	archive := rig.Spec.LogArchive
+	if archive == nil {
+		return "", nil
+	}
+
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Name: archive.CredentialsSecretRef.Name, Namespace: polecat.Namespace}
+	if err := r.Get(ctx, secretKey, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", secretKey, err)
+	}
+
+	uploader, err := logarchive.New(logarchive.Config{
+		Provider:        string(archive.Provider),
+		Endpoint:        archive.Endpoint,
+		Region:          archive.Region,
+		Bucket:          archive.Bucket,
+		AccessKeyID:     string(secret.Data["accessKeyID"]),
+		SecretAccessKey: string(secret.Data["secretAccessKey"]),
+	})
+	if err != nil {
+		return "", err
+	}
+
+	body := logs
+	if truncated {
+		body = append([]byte(truncatedLogMarker), logs...)
+	}
+	key := path.Join(archive.Prefix, polecat.Spec.Rig, polecat.Namespace, polecat.Name, string(p.UID)+".log")
+	return uploader.Upload(ctx, key, "text/plain; charset=utf-8", body)
+}
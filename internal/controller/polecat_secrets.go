@@ -0,0 +1,213 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	gterrors "github.com/org/gastown-operator/pkg/errors"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+// SecretVersionsAnnotation on the agent Pod of a polecat with
+// spec.restartOnSecretChange records the resourceVersion of every Secret the
+// Pod mounts or reads, as comma-separated name=version pairs.
+const SecretVersionsAnnotation = "gastown.io/secret-versions"
+
+// secretRestartAnnotation marks an agent Pod deleted for a rotated Secret, so
+// that it is replaced rather than synced while it terminates.
+const secretRestartAnnotation = "gastown.io/restarted-for-secret"
+
+// podSecretNames returns the Secrets the Pod mounts as volumes or reads into
+// the environment of its containers, sorted.
+func podSecretNames(p *corev1.Pod) []string {
+	names := map[string]bool{}
+	for _, v := range p.Spec.Volumes {
+		if v.Secret != nil {
+			names[v.Secret.SecretName] = true
+		}
+		if v.Projected != nil {
+			for _, source := range v.Projected.Sources {
+				if source.Secret != nil {
+					names[source.Secret.Name] = true
+				}
+			}
+		}
+	}
+	for _, c := range append(slices.Clone(p.Spec.InitContainers), p.Spec.Containers...) {
+		for _, env := range c.Env {
+			if env.ValueFrom != nil && env.ValueFrom.SecretKeyRef != nil {
+				names[env.ValueFrom.SecretKeyRef.Name] = true
+			}
+		}
+		for _, from := range c.EnvFrom {
+			if from.SecretRef != nil {
+				names[from.SecretRef.Name] = true
+			}
+		}
+	}
+	delete(names, "")
+	return slices.Sorted(maps.Keys(names))
+}
+
+// secretVersions returns the SecretVersionsAnnotation of the named Secrets
+// as they are now. Missing Secrets are left out.
+func (r *PolecatReconciler) secretVersions(ctx context.Context, namespace string, names []string) (string, error) {
+	pairs := make([]string, 0, len(names))
+	for _, name := range names {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: namespace}, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+		}
+		pairs = append(pairs, name+"="+secret.ResourceVersion)
+	}
+	return strings.Join(pairs, ","), nil
+}
+
+// parseSecretVersions returns the Secrets and their versions of a
+// SecretVersionsAnnotation.
+func parseSecretVersions(annotation string) map[string]string {
+	versions := map[string]string{}
+	for _, pair := range strings.Split(annotation, ",") {
+		if name, version, ok := strings.Cut(pair, "="); ok {
+			versions[name] = version
+		}
+	}
+	return versions
+}
+
+// rotatedSecret returns the first Secret recorded on the Pod whose
+// resourceVersion has changed since the Pod was created, or "" if none has.
+// A Secret that was deleted counts as unchanged until it is recreated.
+func (r *PolecatReconciler) rotatedSecret(ctx context.Context, p *corev1.Pod) (string, error) {
+	recorded := parseSecretVersions(p.Annotations[SecretVersionsAnnotation])
+	for _, name := range slices.Sorted(maps.Keys(recorded)) {
+		var secret corev1.Secret
+		if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: p.Namespace}, &secret); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return "", fmt.Errorf("failed to get secret %s: %w", name, err)
+		}
+		if secret.ResourceVersion != recorded[name] {
+			return name, nil
+		}
+	}
+	return "", nil
+}
+
+// restartForSecret deletes the agent Pod after the Secret was rotated. The
+// next reconcile creates a new Pod with the new credentials.
+func (r *PolecatReconciler) restartForSecret(
+	ctx context.Context, polecat *gastownv1alpha1.Polecat, p *corev1.Pod, secret string, timer *metrics.ReconcileTimer,
+) (ctrl.Result, error) {
+	logf.FromContext(ctx).Info("Secret rotated, restarting agent Pod", "podName", p.Name, "secret", secret)
+
+	patch := client.MergeFrom(p.DeepCopy())
+	metav1.SetMetaDataAnnotation(&p.ObjectMeta, secretRestartAnnotation, secret)
+	if err := r.Patch(ctx, p, patch); err != nil && !apierrors.IsNotFound(err) {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to mark pod for restart")
+	}
+	if err := r.deletePod(ctx, polecat, p, "secret-rotated"); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to delete pod")
+	}
+
+	r.Recorder.Event(polecat, "Normal", "SecretRotated",
+		fmt.Sprintf("Secret %s changed; restarting agent Pod %s with the new credentials", secret, p.Name))
+	r.setCondition(polecat, ConditionProgressing, metav1.ConditionTrue, "SecretRotated",
+		fmt.Sprintf("Restarting agent Pod for the rotated secret %s", secret))
+	if err := r.updateStatus(ctx, polecat); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, gterrors.Wrap(err, "failed to update status")
+	}
+	timer.RecordResult(metrics.ResultRequeue)
+	return ctrl.Result{RequeueAfter: requeueShort()}, nil
+}
+
+// polecatsForSecret maps a Secret to the polecats whose agent Pod recorded
+// it for spec.restartOnSecretChange, and to those held for its expired
+// Claude credentials.
+func (r *PolecatReconciler) polecatsForSecret(ctx context.Context, obj client.Object) []reconcile.Request {
+	var polecats gastownv1alpha1.PolecatList
+	if err := r.List(ctx, &polecats, client.InNamespace(obj.GetNamespace())); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list polecats for secret", "secret", obj.GetName())
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for _, polecat := range polecats.Items {
+		// Polecats held for expired credentials start once they are refreshed
+		if k8s := polecat.Spec.Kubernetes; k8s != nil && k8s.ClaudeCredsSecretRef != nil &&
+			k8s.ClaudeCredsSecretRef.Name == obj.GetName() &&
+			meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionCredentialExpired) {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: polecat.Name, Namespace: polecat.Namespace},
+			})
+			continue
+		}
+		if !polecat.Spec.RestartOnSecretChange || polecat.Status.PodName == "" {
+			continue
+		}
+		var p corev1.Pod
+		if err := r.Get(ctx, types.NamespacedName{Name: polecat.Status.PodName, Namespace: polecat.Namespace}, &p); err != nil {
+			continue
+		}
+		if _, ok := parseSecretVersions(p.Annotations[SecretVersionsAnnotation])[obj.GetName()]; ok {
+			requests = append(requests, reconcile.Request{
+				NamespacedName: types.NamespacedName{Name: polecat.Name, Namespace: polecat.Namespace},
+			})
+		}
+	}
+	return requests
+}
+
+// secretDataChanged filters Secret events down to updates of their data.
+var secretDataChanged = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+	UpdateFunc: func(e event.UpdateEvent) bool {
+		oldSecret, okOld := e.ObjectOld.(*corev1.Secret)
+		newSecret, okNew := e.ObjectNew.(*corev1.Secret)
+		if !okOld || !okNew {
+			return false
+		}
+		return !maps.EqualFunc(oldSecret.Data, newSecret.Data, slices.Equal)
+	},
+}
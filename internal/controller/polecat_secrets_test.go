@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+var _ = Describe("Polecat secret rotation", func() {
+	It("should find the Secrets a Pod mounts or reads", func() {
+		p := &corev1.Pod{Spec: corev1.PodSpec{
+			ImagePullSecrets: []corev1.LocalObjectReference{{Name: "registry"}},
+			Volumes: []corev1.Volume{
+				{Name: "ssh", VolumeSource: corev1.VolumeSource{Secret: &corev1.SecretVolumeSource{SecretName: "git-creds"}}},
+				{Name: "projected", VolumeSource: corev1.VolumeSource{Projected: &corev1.ProjectedVolumeSource{
+					Sources: []corev1.VolumeProjection{{Secret: &corev1.SecretProjection{
+						LocalObjectReference: corev1.LocalObjectReference{Name: "signing-key"},
+					}}},
+				}}},
+			},
+			InitContainers: []corev1.Container{{EnvFrom: []corev1.EnvFromSource{{
+				SecretRef: &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: "init-env"}},
+			}}}},
+			Containers: []corev1.Container{{Env: []corev1.EnvVar{{
+				Name: "ANTHROPIC_API_KEY",
+				ValueFrom: &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: "api-key"}, Key: "key",
+				}},
+			}}}},
+		}}
+		Expect(podSecretNames(p)).To(Equal([]string{"api-key", "git-creds", "init-env", "signing-key"}))
+	})
+
+	It("should restart the agent Pod when a Secret it reads is rotated", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:                   "test-rig",
+				DesiredState:          gastownv1alpha1.PolecatDesiredWorking,
+				RestartOnSecretChange: true,
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository: "git@github.com:org/repo.git",
+				},
+			},
+			Status: gastownv1alpha1.PolecatStatus{PodName: "polecat-furiosa"},
+		}
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "api-key", Namespace: "default"},
+			Data:       map[string][]byte{"key": []byte("old")},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(polecat, secret).
+			WithStatusSubresource(polecat).Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(secret), secret)).To(Succeed())
+		agent := &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:        "polecat-furiosa",
+				Namespace:   "default",
+				Annotations: map[string]string{SecretVersionsAnnotation: "api-key=" + secret.ResourceVersion},
+			},
+			Status: corev1.PodStatus{Phase: corev1.PodRunning},
+		}
+		Expect(c.Create(ctx, agent)).To(Succeed())
+
+		// Only Pods that recorded the Secret are affected by it
+		Expect(r.polecatsForSecret(ctx, secret)).To(HaveLen(1))
+		Expect(r.polecatsForSecret(ctx, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{Name: "other", Namespace: "default"},
+		})).To(BeEmpty())
+
+		unchanged, err := r.rotatedSecret(ctx, agent)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(unchanged).To(BeEmpty())
+
+		secret.Data["key"] = []byte("new")
+		Expect(c.Update(ctx, secret)).To(Succeed())
+
+		current := &gastownv1alpha1.Polecat{}
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), current)).To(Succeed())
+		result, err := r.ensureWorking(ctx, current, metrics.NewReconcileTimer("polecat"))
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).To(Equal(requeueShort()))
+		Expect(apierrors.IsNotFound(c.Get(ctx, client.ObjectKeyFromObject(agent), &corev1.Pod{}))).To(BeTrue())
+		Expect(recorder.Events).To(Receive(ContainSubstring("SecretRotated")))
+	})
+})
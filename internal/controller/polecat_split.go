@@ -0,0 +1,195 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+const (
+	// ApproveSplitAnnotation approves the split proposed by a planning
+	// polecat when set to "true" on it. The BeadStore then files the
+	// proposed beads and closes the original one.
+	ApproveSplitAnnotation = "gastown.io/approve-split"
+
+	// splitLogTailLines is how much of a planner's log is searched for the
+	// beads it proposes
+	splitLogTailLines = 500
+
+	// Reasons a polecat ran out before finishing its bead
+	exhaustedDeadline = "DeadlineExceeded"
+	exhaustedBudget   = "BudgetExhausted"
+)
+
+// splitPlannerName names the planning polecat proposing to split the bead of
+// the polecat.
+func splitPlannerName(polecat *gastownv1alpha1.Polecat) string {
+	return polecat.Name + "-split"
+}
+
+// splitApproved reports whether the split proposed by the planning polecat
+// was approved.
+func splitApproved(planner *gastownv1alpha1.Polecat) bool {
+	return planner.Spec.SplitOf != "" && len(planner.Status.SplitProposal) > 0 &&
+		planner.Annotations[ApproveSplitAnnotation] == "true"
+}
+
+// exhaustion returns why the failed Pod ran out before finishing: its active
+// deadline expired, or the agent spent the rig's per-task budget. Empty when
+// it failed for another reason.
+func exhaustion(polecat *gastownv1alpha1.Polecat, p *corev1.Pod, rig *gastownv1alpha1.Rig) string {
+	if p.Status.Reason == exhaustedDeadline {
+		return exhaustedDeadline
+	}
+	budget, err := strconv.ParseFloat(rig.Spec.Settings.MaxTaskCostUSD, 64)
+	if err != nil || polecat.Status.Usage == nil {
+		return ""
+	}
+	if usd, err := strconv.ParseFloat(polecat.Status.Usage.USD, 64); err == nil && usd >= budget {
+		return exhaustedBudget
+	}
+	return ""
+}
+
+// suggestSplit creates a planning polecat for a polecat whose Pod ran out of
+// time or budget, when its rig suggests splits. The planner is owned by the
+// polecat and created once.
+func (r *PolecatReconciler) suggestSplit(ctx context.Context, polecat *gastownv1alpha1.Polecat, p *corev1.Pod) error {
+	if polecat.Spec.SplitOf != "" || polecat.Spec.Kubernetes == nil {
+		return nil
+	}
+	var rig gastownv1alpha1.Rig
+	if err := r.Get(ctx, client.ObjectKey{Name: polecat.Spec.Rig}, &rig); err != nil {
+		return client.IgnoreNotFound(err)
+	}
+	if !rig.Spec.Settings.SuggestSplits {
+		return nil
+	}
+	reason := exhaustion(polecat, p, &rig)
+	if reason == "" {
+		return nil
+	}
+
+	name := splitPlannerName(polecat)
+	if err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: polecat.Namespace}, &gastownv1alpha1.Polecat{}); err == nil {
+		return nil
+	} else if !apierrors.IsNotFound(err) {
+		return err
+	}
+
+	planner := newSplitPlanner(polecat)
+	if err := controllerutil.SetControllerReference(polecat, planner, r.Scheme); err != nil {
+		return err
+	}
+	if err := r.Create(ctx, planner); err != nil && !apierrors.IsAlreadyExists(err) {
+		return fmt.Errorf("failed to create planning polecat %s: %w", name, err)
+	}
+	logf.FromContext(ctx).Info("Created planning polecat to split the bead", "planner", name, "reason", reason)
+	r.Recorder.Event(polecat, corev1.EventTypeNormal, "SplitSuggested",
+		fmt.Sprintf("Polecat %s proposes splitting bead %s (%s)", name, polecat.Spec.BeadID, reason))
+	return nil
+}
+
+// newSplitPlanner returns a planning polecat for the bead of the polecat. It
+// runs the same agent on the same repository with the built-in planning
+// prompt, outside of any convoy.
+func newSplitPlanner(polecat *gastownv1alpha1.Polecat) *gastownv1alpha1.Polecat {
+	k8sSpec := polecat.Spec.Kubernetes.DeepCopy()
+	k8sSpec.PromptTemplateRef = nil
+
+	return &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      splitPlannerName(polecat),
+			Namespace: polecat.Namespace,
+		},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:             polecat.Spec.Rig,
+			DesiredState:    gastownv1alpha1.PolecatDesiredWorking,
+			BeadID:          polecat.Spec.BeadID,
+			TaskDescription: polecat.Spec.TaskDescription,
+			ExecutionMode:   polecat.Spec.ExecutionMode,
+			Kubernetes:      k8sSpec,
+			Agent:           polecat.Spec.Agent,
+			AgentConfig:     polecat.Spec.AgentConfig.DeepCopy(),
+			Resources:       polecat.Spec.Resources.DeepCopy(),
+			Urgent:          polecat.Spec.Urgent,
+			SplitOf:         polecat.Name,
+		},
+	}
+}
+
+// readSplitProposal records the beads a finished planning polecat proposed.
+func (r *PolecatReconciler) readSplitProposal(ctx context.Context, polecat *gastownv1alpha1.Polecat, p *corev1.Pod) {
+	tailer, ok := r.LogReader.(PodLogTailer)
+	if !ok || polecat.Spec.SplitOf == "" || len(polecat.Status.SplitProposal) > 0 {
+		return
+	}
+	log, err := tailer.TailLogs(ctx, p.Namespace, p.Name, pod.AgentContainerName(polecat.Spec.Agent), splitLogTailLines)
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to read split proposal", "podName", p.Name)
+		return
+	}
+	proposal := parseSplitLog(log)
+	if len(proposal) == 0 {
+		r.Recorder.Event(polecat, corev1.EventTypeWarning, "NoSplitProposed",
+			"The planner finished without proposing any bead")
+		return
+	}
+	polecat.Status.SplitProposal = proposal
+	r.Recorder.Event(polecat, corev1.EventTypeNormal, "SplitProposed",
+		fmt.Sprintf("Proposed splitting bead %s into %d beads; approve with the %s=true annotation",
+			polecat.Spec.BeadID, len(proposal), ApproveSplitAnnotation))
+}
+
+// parseSplitLog returns the beads proposed in a planner's log, in order.
+// Lines that do not decode to a titled bead are skipped, as are repeated
+// titles.
+func parseSplitLog(log string) []gastownv1alpha1.ProposedBead {
+	var proposal []gastownv1alpha1.ProposedBead
+	seen := map[string]bool{}
+	for _, line := range strings.Split(log, "\n") {
+		fields, ok := strings.CutPrefix(strings.TrimSpace(line), pod.SplitLogPrefix+" ")
+		if !ok {
+			continue
+		}
+		var bead gastownv1alpha1.ProposedBead
+		if err := json.Unmarshal([]byte(fields), &bead); err != nil {
+			continue
+		}
+		bead.Title = strings.TrimSpace(bead.Title)
+		if bead.Title == "" || seen[bead.Title] {
+			continue
+		}
+		seen[bead.Title] = true
+		proposal = append(proposal, bead)
+	}
+	return proposal
+}
@@ -0,0 +1,116 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var _ = Describe("Polecat bead splitting", func() {
+	Context("When parsing the planner log", func() {
+		It("should return the proposed beads in order", func() {
+			proposal := parseSplitLog("Reading the repository\n" +
+				`gastown-split {"title": "Add the login form", "description": "A form"}` + "\n" +
+				"gastown-split not json\n" +
+				`gastown-split {"description": "untitled"}` + "\n" +
+				`gastown-split {"title": "Validate credentials"}` + "\n" +
+				`gastown-split {"title": "Add the login form"}` + "\n")
+			Expect(proposal).To(Equal([]gastownv1alpha1.ProposedBead{
+				{Title: "Add the login form", Description: "A form"},
+				{Title: "Validate credentials"},
+			}))
+		})
+	})
+
+	Context("When a polecat runs out of time", func() {
+		var (
+			ctx      context.Context
+			c        client.Client
+			r        *PolecatReconciler
+			recorder *record.FakeRecorder
+			polecat  *gastownv1alpha1.Polecat
+			rig      *gastownv1alpha1.Rig
+		)
+
+		BeforeEach(func() {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			rig = &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "app"},
+				Spec: gastownv1alpha1.RigSpec{
+					Settings: gastownv1alpha1.RigSettings{SuggestSplits: true},
+				},
+			}
+			polecat = &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "default", UID: "1234"},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:    "app",
+					BeadID: "gt-3",
+					Kubernetes: &gastownv1alpha1.KubernetesSpec{
+						GitRepository: "git@github.com:org/app.git",
+					},
+				},
+			}
+			c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig, polecat).Build()
+			recorder = record.NewFakeRecorder(10)
+			r = &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		})
+
+		deadlinePod := &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed, Reason: "DeadlineExceeded"}}
+
+		It("should create one planning polecat owned by it", func() {
+			for range 2 {
+				Expect(r.suggestSplit(ctx, polecat, deadlinePod)).To(Succeed())
+			}
+
+			var planner gastownv1alpha1.Polecat
+			Expect(c.Get(ctx, client.ObjectKey{Name: "nux-split", Namespace: "default"}, &planner)).To(Succeed())
+			Expect(planner.Spec.SplitOf).To(Equal("nux"))
+			Expect(planner.Spec.BeadID).To(Equal("gt-3"))
+			Expect(metav1.IsControlledBy(&planner, polecat)).To(BeTrue())
+			Expect(recorder.Events).To(HaveLen(1))
+			Expect(recorder.Events).To(Receive(ContainSubstring("SplitSuggested")))
+		})
+
+		It("should not suggest splits for other failures or when the rig does not", func() {
+			Expect(r.suggestSplit(ctx, polecat, &corev1.Pod{Status: corev1.PodStatus{Phase: corev1.PodFailed}})).
+				To(Succeed())
+
+			rig.Spec.Settings.SuggestSplits = false
+			Expect(c.Update(ctx, rig)).To(Succeed())
+			Expect(r.suggestSplit(ctx, polecat, deadlinePod)).To(Succeed())
+
+			var planners gastownv1alpha1.PolecatList
+			Expect(c.List(ctx, &planners)).To(Succeed())
+			Expect(planners.Items).To(HaveLen(1))
+		})
+	})
+})
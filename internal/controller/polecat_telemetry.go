@@ -0,0 +1,115 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/fields"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/builder"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/predicate"
+
+	"github.com/org/gastown-operator/pkg/config"
+)
+
+// TelemetryExportedAnnotation marks polecat Pods whose telemetry was
+// exported, so that it is exported once across operator restarts.
+const TelemetryExportedAnnotation = "gastown.io/telemetry-exported"
+
+// PodTelemetry exports the lifecycle of finished polecat Pods
+type PodTelemetry interface {
+	PodFinished(ctx context.Context, pod *corev1.Pod, events []corev1.Event)
+}
+
+// MergeTelemetry exports the outcome of merges of polecat branches
+type MergeTelemetry interface {
+	MergeFinished(ctx context.Context, rig, polecat string, started time.Time, err error)
+}
+
+// PolecatTelemetryReconciler exports the telemetry of polecat Pods once they
+// finished: their phases, exit and Events.
+type PolecatTelemetryReconciler struct {
+	client.Client
+
+	// APIReader lists the Events of a Pod without caching every Event of
+	// the cluster
+	APIReader client.Reader
+
+	Telemetry PodTelemetry
+
+	// Tuning overrides the controller's workqueue settings. Optional.
+	Tuning config.ControllerTuning
+}
+
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;patch
+// +kubebuilder:rbac:groups="",resources=events,verbs=get;list
+
+// Reconcile exports a finished polecat Pod and marks it exported.
+func (r *PolecatTelemetryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	var p corev1.Pod
+	if err := r.Get(ctx, req.NamespacedName, &p); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+	if !podFinished(&p) || p.Annotations[TelemetryExportedAnnotation] != "" {
+		return ctrl.Result{}, nil
+	}
+
+	// Events are best-effort: they may have expired already
+	var events corev1.EventList
+	if err := r.APIReader.List(ctx, &events, client.InNamespace(p.Namespace),
+		client.MatchingFieldsSelector{Selector: fields.OneTermEqualSelector("involvedObject.uid", string(p.UID))}); err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to list Pod events", "podName", p.Name)
+	}
+	r.Telemetry.PodFinished(ctx, &p, events.Items)
+
+	patch := client.MergeFrom(p.DeepCopy())
+	if p.Annotations == nil {
+		p.Annotations = map[string]string{}
+	}
+	p.Annotations[TelemetryExportedAnnotation] = time.Now().UTC().Format(time.RFC3339)
+	if err := r.Patch(ctx, &p, patch); err != nil && !apierrors.IsNotFound(err) {
+		return ctrl.Result{}, fmt.Errorf("failed to mark Pod %s exported: %w", p.Name, err)
+	}
+	return ctrl.Result{}, nil
+}
+
+// podFinished reports whether the Pod ran to completion.
+func podFinished(p *corev1.Pod) bool {
+	return p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed
+}
+
+// isPolecatPod selects the agent Pods of polecats
+var isPolecatPod = predicate.NewPredicateFuncs(func(obj client.Object) bool {
+	_, ok := obj.GetLabels()["gastown.io/polecat"]
+	return ok
+})
+
+// SetupWithManager sets up the controller with the Manager.
+func (r *PolecatTelemetryReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return ctrl.NewControllerManagedBy(mgr).
+		For(&corev1.Pod{}, builder.WithPredicates(isPolecatPod)).
+		Named("polecat-telemetry").
+		WithOptions(controllerOptions(r.Tuning, 1)).
+		Complete(r)
+}
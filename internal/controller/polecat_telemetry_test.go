@@ -0,0 +1,108 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakePodTelemetry records the Pods it was given.
+type fakePodTelemetry struct {
+	pods   []string
+	events int
+}
+
+func (f *fakePodTelemetry) PodFinished(_ context.Context, pod *corev1.Pod, events []corev1.Event) {
+	f.pods = append(f.pods, pod.Name)
+	f.events += len(events)
+}
+
+var _ = Describe("Polecat telemetry", func() {
+	const ns = "gt-telemetry"
+
+	var (
+		ctx       context.Context
+		c         client.Client
+		r         *PolecatTelemetryReconciler
+		telemetry *fakePodTelemetry
+	)
+
+	newPod := func(name string, phase corev1.PodPhase) *corev1.Pod {
+		return &corev1.Pod{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: ns,
+				UID:       types.UID(name + "-uid"),
+				Labels:    map[string]string{"gastown.io/polecat": name},
+			},
+			Status: corev1.PodStatus{Phase: phase},
+		}
+	}
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		event := &corev1.Event{
+			ObjectMeta:     metav1.ObjectMeta{Name: "done.1", Namespace: ns},
+			InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "done", UID: "done-uid"},
+			Reason:         "Pulled",
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(newPod("done", corev1.PodSucceeded), newPod("busy", corev1.PodRunning), event).
+			WithIndex(&corev1.Event{}, "involvedObject.uid", func(obj client.Object) []string {
+				return []string{string(obj.(*corev1.Event).InvolvedObject.UID)}
+			}).Build()
+		telemetry = &fakePodTelemetry{}
+		r = &PolecatTelemetryReconciler{Client: c, APIReader: c, Telemetry: telemetry}
+	})
+
+	It("should export a finished Pod once", func() {
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "done", Namespace: ns}}
+		_, err := r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(telemetry.pods).To(Equal([]string{"done"}))
+		Expect(telemetry.events).To(Equal(1))
+
+		var p corev1.Pod
+		Expect(c.Get(ctx, req.NamespacedName, &p)).To(Succeed())
+		Expect(p.Annotations).To(HaveKey(TelemetryExportedAnnotation))
+
+		_, err = r.Reconcile(ctx, req)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(telemetry.pods).To(HaveLen(1))
+	})
+
+	It("should not export running or deleted Pods", func() {
+		for _, name := range []string{"busy", "gone"} {
+			_, err := r.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: ns}})
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(telemetry.pods).To(BeEmpty())
+	})
+})
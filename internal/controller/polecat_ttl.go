@@ -0,0 +1,96 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+	gterrors "github.com/org/gastown-operator/pkg/errors"
+)
+
+// ttlCleanupEnabled reports whether finished polecats are deleted after
+// their TTL: neither the manager flag nor the PolecatTTLCleanup feature gate
+// turns it off.
+func (r *PolecatReconciler) ttlCleanupEnabled() bool {
+	return !r.DisableTTLCleanup && config.Current().Enabled(config.FeaturePolecatTTLCleanup)
+}
+
+// polecatTTLRemaining returns how long a finished polecat is kept before
+// deletion. ok is false when the polecat has no TTL or is not finished.
+func polecatTTLRemaining(polecat *gastownv1alpha1.Polecat, now time.Time) (remaining time.Duration, ok bool) {
+	ttl := polecat.Spec.TTLSecondsAfterFinished
+	finished := polecat.Status.FinishedAt
+	if ttl == nil || finished == nil {
+		return 0, false
+	}
+	if polecat.Status.Phase != gastownv1alpha1.PolecatPhaseDone &&
+		polecat.Status.Phase != gastownv1alpha1.PolecatPhaseTerminated {
+		return 0, false
+	}
+	return finished.Add(time.Duration(*ttl) * time.Second).Sub(now), true
+}
+
+// expireFinished deletes the polecat once its TTL has expired. A Done
+// polecat whose rig has a Refinery is kept until its branch is merged, so
+// the TTL never drops work from the merge queue.
+func (r *PolecatReconciler) expireFinished(ctx context.Context, polecat *gastownv1alpha1.Polecat) (bool, error) {
+	remaining, ok := polecatTTLRemaining(polecat, time.Now())
+	if !ok || remaining > 0 {
+		return false, nil
+	}
+
+	if polecat.Status.Phase == gastownv1alpha1.PolecatPhaseDone && !isPolecatMerged(polecat) {
+		awaiting, err := r.hasRefinery(ctx, polecat)
+		if err != nil {
+			return false, err
+		}
+		if awaiting {
+			return false, nil
+		}
+	}
+
+	logf.FromContext(ctx).Info("Deleting finished Polecat after TTL",
+		"phase", polecat.Status.Phase, "ttlSecondsAfterFinished", *polecat.Spec.TTLSecondsAfterFinished)
+	if err := r.Delete(ctx, polecat); err != nil && !apierrors.IsNotFound(err) {
+		return false, gterrors.Wrap(err, "failed to delete expired polecat")
+	}
+	r.Recorder.Event(polecat, "Normal", "Expired",
+		fmt.Sprintf("Deleted %d seconds after finishing", *polecat.Spec.TTLSecondsAfterFinished))
+	return true, nil
+}
+
+// hasRefinery reports whether a Refinery merges the polecat's rig.
+func (r *PolecatReconciler) hasRefinery(ctx context.Context, polecat *gastownv1alpha1.Polecat) (bool, error) {
+	var refineries gastownv1alpha1.RefineryList
+	if err := r.List(ctx, &refineries, client.InNamespace(polecat.Namespace)); err != nil {
+		return false, gterrors.Wrap(err, "failed to list refineries")
+	}
+	for _, refinery := range refineries.Items {
+		if refinery.Spec.RigRef == polecat.Spec.Rig {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,119 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var _ = Describe("Polecat TTL", func() {
+	Context("When expiring finished polecats", func() {
+		var (
+			ctx context.Context
+			r   *PolecatReconciler
+		)
+
+		ttl := int32(60)
+		finishedPolecat := func(name string, phase gastownv1alpha1.PolecatPhase, finishedAgo time.Duration) *gastownv1alpha1.Polecat {
+			finished := metav1.NewTime(time.Now().Add(-finishedAgo))
+			return &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       gastownv1alpha1.PolecatSpec{Rig: "test-rig", TTLSecondsAfterFinished: &ttl},
+				Status:     gastownv1alpha1.PolecatStatus{Phase: phase, FinishedAt: &finished},
+			}
+		}
+
+		newReconciler := func(objs ...client.Object) {
+			ctx = context.Background()
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+			r = &PolecatReconciler{Client: c, Scheme: scheme, Recorder: &record.FakeRecorder{}}
+		}
+
+		exists := func(p *gastownv1alpha1.Polecat) bool {
+			err := r.Get(ctx, client.ObjectKeyFromObject(p), &gastownv1alpha1.Polecat{})
+			if apierrors.IsNotFound(err) {
+				return false
+			}
+			Expect(err).NotTo(HaveOccurred())
+			return true
+		}
+
+		It("should count the TTL from when the polecat finished", func() {
+			now := time.Now()
+			remaining, ok := polecatTTLRemaining(finishedPolecat("a", gastownv1alpha1.PolecatPhaseDone, 20*time.Second), now)
+			Expect(ok).To(BeTrue())
+			Expect(remaining).To(BeNumerically("~", 40*time.Second, time.Second))
+
+			_, ok = polecatTTLRemaining(finishedPolecat("b", gastownv1alpha1.PolecatPhaseWorking, time.Hour), now)
+			Expect(ok).To(BeFalse())
+
+			noTTL := finishedPolecat("c", gastownv1alpha1.PolecatPhaseDone, time.Hour)
+			noTTL.Spec.TTLSecondsAfterFinished = nil
+			_, ok = polecatTTLRemaining(noTTL, now)
+			Expect(ok).To(BeFalse())
+		})
+
+		It("should delete expired Done and Terminated polecats", func() {
+			done := finishedPolecat("done", gastownv1alpha1.PolecatPhaseDone, 2*time.Minute)
+			terminated := finishedPolecat("terminated", gastownv1alpha1.PolecatPhaseTerminated, 2*time.Minute)
+			fresh := finishedPolecat("fresh", gastownv1alpha1.PolecatPhaseDone, 10*time.Second)
+			newReconciler(done, terminated, fresh)
+
+			for _, p := range []*gastownv1alpha1.Polecat{done, terminated, fresh} {
+				_, err := r.expireFinished(ctx, p)
+				Expect(err).NotTo(HaveOccurred())
+			}
+			Expect(exists(done)).To(BeFalse())
+			Expect(exists(terminated)).To(BeFalse())
+			Expect(exists(fresh)).To(BeTrue())
+		})
+
+		It("should keep unmerged Done polecats of a rig with a Refinery", func() {
+			unmerged := finishedPolecat("unmerged", gastownv1alpha1.PolecatPhaseDone, 2*time.Minute)
+			merged := finishedPolecat("merged", gastownv1alpha1.PolecatPhaseDone, 2*time.Minute)
+			merged.Status.Conditions = []metav1.Condition{{
+				Type: ConditionMerged, Status: metav1.ConditionTrue, Reason: "Merged", LastTransitionTime: metav1.Now()}}
+			newReconciler(unmerged, merged, &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{Name: "refinery", Namespace: "default"},
+				Spec:       gastownv1alpha1.RefinerySpec{RigRef: "test-rig"},
+			})
+
+			deleted, err := r.expireFinished(ctx, unmerged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeFalse())
+			deleted, err = r.expireFinished(ctx, merged)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(deleted).To(BeTrue())
+		})
+	})
+})
@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/cost"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+const (
+	// usageRefreshInterval is how often the usage of a running agent is read
+	usageRefreshInterval = time.Minute
+
+	// usageLogTailLines is how much of the telemetry log is searched for the
+	// last usage report. The sidecar logs little else.
+	usageLogTailLines = 20
+)
+
+// PodLogTailer is implemented by PodLogReaders that can read the last lines
+// of a container log.
+type PodLogTailer interface {
+	TailLogs(ctx context.Context, namespace, podName, container string, lines int64) (string, error)
+}
+
+// TailLogs reads the last lines of the container log.
+func (r *clientsetLogReader) TailLogs(
+	ctx context.Context, namespace, podName, container string, lines int64,
+) (string, error) {
+	stream, err := r.clientset.CoreV1().Pods(namespace).GetLogs(podName, &corev1.PodLogOptions{
+		Container: container,
+		TailLines: &lines,
+	}).Stream(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = stream.Close() }()
+
+	tail := &tailBuffer{max: PolecatLastLogsBytes}
+	if _, err := io.Copy(tail, stream); err != nil {
+		return "", err
+	}
+	log, _ := tail.Bytes()
+	return string(log), nil
+}
+
+// parseUsageLog returns the last token usage reported in the telemetry log.
+func parseUsageLog(log string) (cost.Usage, bool) {
+	lines := strings.Split(strings.TrimSpace(log), "\n")
+	for i := len(lines) - 1; i >= 0; i-- {
+		fields, ok := strings.CutPrefix(lines[i], pod.UsageLogPrefix+" ")
+		if !ok {
+			continue
+		}
+		var u cost.Usage
+		if _, err := fmt.Sscanf(fields, "input=%d cache_read=%d output=%d",
+			&u.InputTokens, &u.CacheReadTokens, &u.OutputTokens); err == nil {
+			return u, true
+		}
+	}
+	return cost.Usage{}, false
+}
+
+// hasContainer reports whether the Pod runs a container of that name.
+func hasContainer(p *corev1.Pod, name string) bool {
+	for _, c := range p.Spec.Containers {
+		if c.Name == name {
+			return true
+		}
+	}
+	return false
+}
+
+// updateUsage records the token usage the telemetry sidecar last reported
+// and what it cost. A running agent is read at most every
+// usageRefreshInterval; a finished one on every sync, to get its final usage.
+func (r *PolecatReconciler) updateUsage(ctx context.Context, polecat *gastownv1alpha1.Polecat, p *corev1.Pod) {
+	tailer, ok := r.LogReader.(PodLogTailer)
+	if !ok || !hasContainer(p, pod.TelemetryContainerName) {
+		return
+	}
+	finished := p.Status.Phase == corev1.PodSucceeded || p.Status.Phase == corev1.PodFailed
+	if u := polecat.Status.Usage; u != nil && u.UpdatedAt != nil && !finished &&
+		time.Since(u.UpdatedAt.Time) < usageRefreshInterval {
+		return
+	}
+
+	log, err := tailer.TailLogs(ctx, p.Namespace, p.Name, pod.TelemetryContainerName, usageLogTailLines)
+	if err != nil {
+		logf.FromContext(ctx).V(1).Info("Failed to read agent token usage", "podName", p.Name, "error", err.Error())
+		return
+	}
+	usage, ok := parseUsageLog(log)
+	if !ok {
+		return
+	}
+	now := metav1.Now()
+	polecat.Status.Usage = &gastownv1alpha1.TokenUsage{
+		InputTokens:     usage.InputTokens,
+		CacheReadTokens: usage.CacheReadTokens,
+		OutputTokens:    usage.OutputTokens,
+		USD:             usageUSD(polecat, usage),
+		UpdatedAt:       &now,
+	}
+}
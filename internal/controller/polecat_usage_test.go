@@ -0,0 +1,140 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/cost"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// fakePodLogTailer returns a fixed log tail and counts reads.
+type fakePodLogTailer struct {
+	fakePodLogReader
+	tail      string
+	container string
+	tails     int
+}
+
+func (f *fakePodLogTailer) TailLogs(_ context.Context, _, _, container string, _ int64) (string, error) {
+	f.tails++
+	f.container = container
+	return f.tail, nil
+}
+
+var _ = Describe("Polecat token usage", func() {
+	Context("When parsing the telemetry log", func() {
+		It("should return the last usage reported", func() {
+			usage, ok := parseUsageLog("gastown-usage input=0 cache_read=0 output=0\n" +
+				"nc: bind: Address in use\n" +
+				"gastown-usage input=1200 cache_read=50000 output=800\n")
+			Expect(ok).To(BeTrue())
+			Expect(usage).To(Equal(cost.Usage{InputTokens: 1200, CacheReadTokens: 50000, OutputTokens: 800}))
+		})
+
+		It("should report logs without usage", func() {
+			_, ok := parseUsageLog("")
+			Expect(ok).To(BeFalse())
+			_, ok = parseUsageLog("gastown-usage input=garbage\n")
+			Expect(ok).To(BeFalse())
+		})
+	})
+
+	Context("When syncing usage from the agent Pod", func() {
+		ctx := context.Background()
+
+		newPolecat := func() *gastownv1alpha1.Polecat {
+			return &gastownv1alpha1.Polecat{
+				Spec: gastownv1alpha1.PolecatSpec{
+					AgentConfig: &gastownv1alpha1.AgentConfig{Model: "claude-sonnet-4"},
+				},
+			}
+		}
+		newPod := func(phase corev1.PodPhase) *corev1.Pod {
+			return &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "polecat-usage", Namespace: "default"},
+				Spec: corev1.PodSpec{Containers: []corev1.Container{
+					{Name: "claude"}, {Name: pod.TelemetryContainerName},
+				}},
+				Status: corev1.PodStatus{Phase: phase},
+			}
+		}
+
+		It("should record and price the usage from the telemetry sidecar", func() {
+			tailer := &fakePodLogTailer{tail: "gastown-usage input=1000000 cache_read=10000000 output=100000\n"}
+			r := &PolecatReconciler{LogReader: tailer}
+			polecat := newPolecat()
+
+			r.updateUsage(ctx, polecat, newPod(corev1.PodRunning))
+			Expect(tailer.container).To(Equal(pod.TelemetryContainerName))
+			Expect(polecat.Status.Usage).NotTo(BeNil())
+			Expect(polecat.Status.Usage.InputTokens).To(Equal(int64(1000000)))
+			Expect(polecat.Status.Usage.CacheReadTokens).To(Equal(int64(10000000)))
+			Expect(polecat.Status.Usage.OutputTokens).To(Equal(int64(100000)))
+			Expect(polecat.Status.Usage.USD).To(Equal("7.50"))
+		})
+
+		It("should read a running agent at most every refresh interval", func() {
+			tailer := &fakePodLogTailer{tail: "gastown-usage input=10 cache_read=0 output=5\n"}
+			r := &PolecatReconciler{LogReader: tailer}
+			polecat := newPolecat()
+
+			r.updateUsage(ctx, polecat, newPod(corev1.PodRunning))
+			r.updateUsage(ctx, polecat, newPod(corev1.PodRunning))
+			Expect(tailer.tails).To(Equal(1))
+
+			// The final usage of a finished agent is always read
+			tailer.tail = "gastown-usage input=20 cache_read=0 output=9\n"
+			r.updateUsage(ctx, polecat, newPod(corev1.PodSucceeded))
+			Expect(tailer.tails).To(Equal(2))
+			Expect(polecat.Status.Usage.OutputTokens).To(Equal(int64(9)))
+
+			stale := metav1.NewTime(time.Now().Add(-2 * usageRefreshInterval))
+			polecat.Status.Usage.UpdatedAt = &stale
+			r.updateUsage(ctx, polecat, newPod(corev1.PodRunning))
+			Expect(tailer.tails).To(Equal(3))
+		})
+
+		It("should not read usage of Pods without the telemetry sidecar", func() {
+			tailer := &fakePodLogTailer{tail: "gastown-usage input=10 cache_read=0 output=5\n"}
+			r := &PolecatReconciler{LogReader: tailer}
+			polecat := newPolecat()
+			p := newPod(corev1.PodRunning)
+			p.Spec.Containers = p.Spec.Containers[:1]
+
+			r.updateUsage(ctx, polecat, p)
+			Expect(tailer.tails).To(BeZero())
+			Expect(polecat.Status.Usage).To(BeNil())
+		})
+
+		It("should not read usage without a log tailer", func() {
+			r := &PolecatReconciler{LogReader: &fakePodLogReader{}}
+			polecat := newPolecat()
+
+			r.updateUsage(ctx, polecat, newPod(corev1.PodRunning))
+			Expect(polecat.Status.Usage).To(BeNil())
+		})
+	})
+})
@@ -0,0 +1,195 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// warmPoolPolecat returns the Polecat a rig's warm Pods are built for: one
+// slung onto the rig with its warm pool's settings and nothing else, like
+// `kubectl gt sling` creates them.
+func warmPoolPolecat(rig *gastownv1alpha1.Rig, namespace string) *gastownv1alpha1.Polecat {
+	pool := rig.Spec.WarmPool
+	branch := pool.GitBranch
+	if branch == "" {
+		branch = "main"
+	}
+	gitSecret := gastownv1alpha1.SecretReference{Name: "git-creds"}
+	if pool.GitSecretRef != nil {
+		gitSecret = *pool.GitSecretRef
+	}
+	claudeSecret := gastownv1alpha1.SecretReference{Name: "claude-creds"}
+	if pool.ClaudeCredsSecretRef != nil {
+		claudeSecret = *pool.ClaudeCredsSecretRef
+	}
+
+	return &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: "warm", Namespace: namespace},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:           rig.Name,
+			Agent:         gastownv1alpha1.AgentTypeClaudeCode,
+			ExecutionMode: gastownv1alpha1.ExecutionModeKubernetes,
+			DesiredState:  gastownv1alpha1.PolecatDesiredWorking,
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository:            rig.Spec.GitURL,
+				GitBranch:                branch,
+				GitSecretRef:             gitSecret,
+				ClaudeCredsSecretRef:     &claudeSecret,
+				SSHStrictHostKeyChecking: "yes",
+			},
+		},
+	}
+}
+
+// claimWarmPod hands the polecat's bead to a warm Pod of its rig whose spec
+// matches the polecat's, and returns it; nil when none is waiting. The
+// claimed Pod takes the labels, annotations and owner newPod would have, and
+// the polecat's active deadline counted from the claim.
+func (r *PolecatReconciler) claimWarmPod(ctx context.Context, polecat *gastownv1alpha1.Polecat, newPod *corev1.Pod) (*corev1.Pod, error) {
+	log := logf.FromContext(ctx)
+
+	// A warm Pod claimed before the polecat's status recorded it is still
+	// the polecat's
+	var owned corev1.PodList
+	if err := r.List(ctx, &owned, client.InNamespace(polecat.Namespace),
+		client.MatchingLabels{"gastown.io/polecat": polecat.Name}); err != nil {
+		return nil, err
+	}
+	for i := range owned.Items {
+		p := &owned.Items[i]
+		if p.Annotations[pod.AssignmentAnnotation] != "" && metav1.IsControlledBy(p, polecat) && warmPodUsable(p) {
+			return p, nil
+		}
+	}
+
+	var warmPods corev1.PodList
+	if err := r.List(ctx, &warmPods, client.InNamespace(polecat.Namespace),
+		client.MatchingLabels{pod.WarmPoolLabel: polecat.Spec.Rig}); err != nil {
+		return nil, err
+	}
+	candidates := make([]*corev1.Pod, 0, len(warmPods.Items))
+	for i := range warmPods.Items {
+		if warmPodUsable(&warmPods.Items[i]) {
+			candidates = append(candidates, &warmPods.Items[i])
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	builder, err := podBuilder(ctx, r.Client, polecat)
+	if err != nil {
+		return nil, err
+	}
+	standby, err := builder.BuildStandby()
+	if err != nil {
+		return nil, err
+	}
+	hash := standby.Annotations[pod.StandbyHashAnnotation]
+	assignment, err := builder.Assignment()
+	if err != nil {
+		return nil, err
+	}
+	if len(assignment) > pod.MaxAssignmentSize {
+		log.Info("Assignment too large for a warm Pod, cold-starting", "size", len(assignment))
+		return nil, nil
+	}
+
+	// Running Pods first, those waiting longest first among them
+	sort.SliceStable(candidates, func(i, j int) bool {
+		ri, rj := candidates[i].Status.Phase == corev1.PodRunning, candidates[j].Status.Phase == corev1.PodRunning
+		if ri != rj {
+			return ri
+		}
+		return candidates[i].CreationTimestamp.Before(&candidates[j].CreationTimestamp)
+	})
+
+	now := time.Now()
+	matched := false
+	for _, warm := range candidates {
+		if warm.Annotations[pod.StandbyHashAnnotation] != hash {
+			continue
+		}
+		matched = true
+
+		claimed := warm.DeepCopy()
+		claimed.Labels = maps.Clone(newPod.Labels)
+		claimed.Annotations = maps.Clone(newPod.Annotations)
+		if claimed.Annotations == nil {
+			claimed.Annotations = map[string]string{}
+		}
+		claimed.Annotations[pod.StandbyHashAnnotation] = hash
+		claimed.Annotations[pod.AssignmentAnnotation] = assignment
+		claimed.OwnerReferences = nil
+		if err := controllerutil.SetControllerReference(polecat, claimed, r.Scheme); err != nil {
+			return nil, err
+		}
+		if deadline := newPod.Spec.ActiveDeadlineSeconds; deadline != nil {
+			started := warm.CreationTimestamp.Time
+			if warm.Status.StartTime != nil {
+				started = warm.Status.StartTime.Time
+			}
+			idle := int64(now.Sub(started).Seconds())
+			claimed.Spec.ActiveDeadlineSeconds = new(int64)
+			*claimed.Spec.ActiveDeadlineSeconds = *deadline + max(idle, 0)
+		}
+
+		// Another polecat may be claiming the same Pod, or the rig removing it
+		err := r.Patch(ctx, claimed, client.MergeFromWithOptions(warm, client.MergeFromWithOptimisticLock{}))
+		if apierrors.IsConflict(err) || apierrors.IsNotFound(err) {
+			log.V(1).Info("Warm Pod taken, trying the next one", "pod", warm.Name)
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return claimed, nil
+	}
+
+	log.V(1).Info("No matching warm Pod, cold-starting", "rig", polecat.Spec.Rig, "standbyHash", hash)
+	if !matched {
+		// The polecat differs from the pool's template, e.g. in its image,
+		// branch or Secrets, so the pool never serves it
+		r.Recorder.Event(polecat, "Normal", "WarmPoolMismatch",
+			fmt.Sprintf("No warm Pod of rig %s is built like this polecat's Pod; starting a new one", polecat.Spec.Rig))
+	}
+	return nil, nil
+}
+
+// warmPodUsable reports whether a warm Pod can still take on a bead: it is
+// not being deleted and has not finished.
+func warmPodUsable(p *corev1.Pod) bool {
+	if p.DeletionTimestamp != nil {
+		return false
+	}
+	return p.Status.Phase != corev1.PodSucceeded && p.Status.Phase != corev1.PodFailed
+}
@@ -0,0 +1,151 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+var _ = Describe("Rig warm pool", func() {
+	const ns = "gt-warm"
+
+	It("should keep warm Pods for polecats of the rig to claim", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+		rig := &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "warm-rig", UID: "rig-uid"},
+			Spec: gastownv1alpha1.RigSpec{
+				GitURL:         "git@github.com:org/repo.git",
+				ChildNamespace: ns,
+				WarmPool:       &gastownv1alpha1.WarmPoolSpec{Size: 2},
+			},
+		}
+		slung := func(name, bead string) *gastownv1alpha1.Polecat {
+			return &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns, UID: types.UID("uid-" + name)},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:           rig.Name,
+					BeadID:        bead,
+					Agent:         gastownv1alpha1.AgentTypeClaudeCode,
+					ExecutionMode: gastownv1alpha1.ExecutionModeKubernetes,
+					DesiredState:  gastownv1alpha1.PolecatDesiredWorking,
+					Kubernetes: &gastownv1alpha1.KubernetesSpec{
+						GitRepository:            rig.Spec.GitURL,
+						GitBranch:                "main",
+						GitSecretRef:             gastownv1alpha1.SecretReference{Name: "git-creds"},
+						ClaudeCredsSecretRef:     &gastownv1alpha1.SecretReference{Name: "claude-creds"},
+						SSHStrictHostKeyChecking: "yes",
+						ActiveDeadlineSeconds:    new(int64),
+					},
+				},
+			}
+		}
+		nux, slit := slung("nux", "gt-7"), slung("slit", "gt-8")
+		*nux.Spec.Kubernetes.ActiveDeadlineSeconds = 3600
+		*slit.Spec.Kubernetes.ActiveDeadlineSeconds = 3600
+		slit.Spec.Kubernetes.Image = "registry.example.com/agent:v2"
+
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig, nux, slit).Build()
+		recorder := record.NewFakeRecorder(10)
+		rigReconciler := &RigReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+		warmPods := func() []corev1.Pod {
+			var pods corev1.PodList
+			Expect(c.List(ctx, &pods, client.InNamespace(ns), client.MatchingLabels{pod.WarmPoolLabel: rig.Name})).To(Succeed())
+			return pods.Items
+		}
+		startAll := func() {
+			for _, p := range warmPods() {
+				p.Status.Phase = corev1.PodRunning
+				Expect(c.Status().Update(ctx, &p)).To(Succeed())
+			}
+		}
+
+		// The pool fills up, and counts its Pods once they run
+		ready, err := rigReconciler.ensureWarmPool(ctx, rig, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(BeZero())
+		Expect(warmPods()).To(HaveLen(2))
+		Expect(recorder.Events).To(Receive(ContainSubstring("WarmPodsCreated")))
+		startAll()
+		ready, err = rigReconciler.ensureWarmPool(ctx, rig, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(Equal(int32(2)))
+
+		// A polecat slung like the pool's template claims a warm Pod
+		newPod, err := r.buildPod(ctx, nux)
+		Expect(err).NotTo(HaveOccurred())
+		claimed, err := r.claimWarmPod(ctx, nux, newPod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).NotTo(BeNil())
+		Expect(claimed.Labels).To(HaveKeyWithValue("gastown.io/polecat", "nux"))
+		Expect(claimed.Labels).NotTo(HaveKey(pod.WarmPoolLabel))
+		Expect(metav1.IsControlledBy(claimed, nux)).To(BeTrue())
+		Expect(claimed.Annotations[pod.AssignmentAnnotation]).To(ContainSubstring("export GT_ISSUE='gt-7'"))
+		Expect(*claimed.Spec.ActiveDeadlineSeconds).To(BeNumerically(">=", 3600))
+
+		// Claiming again finds the same Pod rather than another one
+		again, err := r.claimWarmPod(ctx, nux, newPod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(again.Name).To(Equal(claimed.Name))
+
+		// Another image has no warm Pod to claim
+		newPod, err = r.buildPod(ctx, slit)
+		Expect(err).NotTo(HaveOccurred())
+		claimed, err = r.claimWarmPod(ctx, slit, newPod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeNil())
+		Expect(recorder.Events).To(Receive(ContainSubstring("WarmPoolMismatch")))
+
+		// Nor does a bead too large to hand over in an annotation
+		rictus := slung("rictus", "gt-9")
+		*rictus.Spec.Kubernetes.ActiveDeadlineSeconds = 3600
+		rictus.Spec.TaskDescription = strings.Repeat("x", pod.MaxAssignmentSize)
+		newPod, err = r.buildPod(ctx, rictus)
+		Expect(err).NotTo(HaveOccurred())
+		claimed, err = r.claimWarmPod(ctx, rictus, newPod)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(claimed).To(BeNil())
+		Expect(recorder.Events).NotTo(Receive())
+
+		// The claimed Pod is replaced, and the pool emptied while paused
+		ready, err = rigReconciler.ensureWarmPool(ctx, rig, false)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(ready).To(Equal(int32(1)))
+		Expect(warmPods()).To(HaveLen(2))
+		_, err = rigReconciler.ensureWarmPool(ctx, rig, true)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(warmPods()).To(BeEmpty())
+	})
+})
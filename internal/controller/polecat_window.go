@@ -0,0 +1,97 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/schedule"
+)
+
+// ConditionWaitingForWindow is True while a polecat waits for one of its
+// rig's execution windows to open before starting.
+const ConditionWaitingForWindow = "WaitingForWindow"
+
+// rigExecutionWindows returns the parsed execution windows of the named rig.
+// A missing rig has none. Invalid windows, normally rejected by the rig
+// webhook, are skipped.
+func rigExecutionWindows(ctx context.Context, c client.Reader, rigName string) ([]*schedule.Window, error) {
+	var rig gastownv1alpha1.Rig
+	if err := c.Get(ctx, client.ObjectKey{Name: rigName}, &rig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+
+	windows := make([]*schedule.Window, 0, len(rig.Spec.ExecutionWindows))
+	for i, w := range rig.Spec.ExecutionWindows {
+		window, err := schedule.NewWindow(w.Schedule, w.Duration.Duration, w.TimeZone)
+		if err != nil {
+			logf.FromContext(ctx).Error(err, "Ignoring invalid execution window", "rig", rigName, "index", i)
+			continue
+		}
+		windows = append(windows, window)
+	}
+	return windows, nil
+}
+
+// waitForWindow reports how long the polecat must wait for an execution
+// window of its rig, zero if it may start now. Urgent polecats and rigs
+// without windows never wait. The WaitingForWindow condition is set to match.
+func (r *PolecatReconciler) waitForWindow(ctx context.Context, polecat *gastownv1alpha1.Polecat, now time.Time) (time.Duration, error) {
+	windows, err := rigExecutionWindows(ctx, r.Client, polecat.Spec.Rig)
+	if err != nil {
+		return 0, err
+	}
+
+	if polecat.Spec.Urgent || len(windows) == 0 {
+		if meta.FindStatusCondition(polecat.Status.Conditions, ConditionWaitingForWindow) != nil {
+			r.setCondition(polecat, ConditionWaitingForWindow, metav1.ConditionFalse, "NotRestricted",
+				"Polecat is urgent or its rig has no execution windows")
+		}
+		return 0, nil
+	}
+
+	open, at := schedule.OpenAt(windows, now)
+	if open {
+		r.setCondition(polecat, ConditionWaitingForWindow, metav1.ConditionFalse, "InsideExecutionWindow",
+			fmt.Sprintf("Execution window open until %s", at.UTC().Format(time.RFC3339)))
+		return 0, nil
+	}
+
+	// Windows that never open hold the polecat until the rig changes
+	if at.IsZero() {
+		r.setCondition(polecat, ConditionWaitingForWindow, metav1.ConditionTrue, "OutsideExecutionWindow",
+			fmt.Sprintf("No execution window of rig %s ever opens", polecat.Spec.Rig))
+		return requeueLong(), nil
+	}
+
+	r.setCondition(polecat, ConditionWaitingForWindow, metav1.ConditionTrue, "OutsideExecutionWindow",
+		fmt.Sprintf("Waiting for the next execution window of rig %s at %s",
+			polecat.Spec.Rig, at.UTC().Format(time.RFC3339)))
+	return at.Sub(now), nil
+}
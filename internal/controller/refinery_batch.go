@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/schedule"
+)
+
+// assignMergeBatches records in each queue entry the batch it lands in: the
+// first firing of the batch schedule after the branch became ready. Branches
+// that have been waiting since before the last firing are due. Returns when
+// the next batch after now lands.
+func assignMergeBatches(
+	queue []gastownv1alpha1.MergeQueueEntry, batch *gastownv1alpha1.MergeBatchSpec, now time.Time,
+) (time.Time, error) {
+	s, err := schedule.Parse(batch.Schedule)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid batch schedule %q: %w", batch.Schedule, err)
+	}
+	loc, err := time.LoadLocation(batch.TimeZone)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("unknown batch time zone %q", batch.TimeZone)
+	}
+	next := s.Next(now.In(loc))
+	if next.IsZero() {
+		return time.Time{}, fmt.Errorf("batch schedule %q never fires", batch.Schedule)
+	}
+
+	for i := range queue {
+		ready := now
+		if queue[i].ReadySince != nil {
+			ready = queue[i].ReadySince.Time
+		}
+		at := metav1.NewTime(s.Next(ready.In(loc)))
+		queue[i].BatchTime = &at
+	}
+	return next, nil
+}
+
+// waitingForBatch reports whether the entry's batch has not landed yet.
+func waitingForBatch(entry gastownv1alpha1.MergeQueueEntry, now time.Time) bool {
+	return entry.BatchTime != nil && entry.BatchTime.After(now)
+}
+
+// countWaitingForBatch counts the queue entries whose batch has not landed yet.
+func countWaitingForBatch(queue []gastownv1alpha1.MergeQueueEntry, now time.Time) int {
+	waiting := 0
+	for _, entry := range queue {
+		if waitingForBatch(entry, now) {
+			waiting++
+		}
+	}
+	return waiting
+}
@@ -0,0 +1,290 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/internal/git/provider"
+	"github.com/org/gastown-operator/pkg/metrics"
+)
+
+// defaultRequiredChecksTimeout is how long a lane waits for required checks
+// when spec.requiredChecksTimeout is unset.
+const defaultRequiredChecksTimeout = 30 * time.Minute
+
+// reconcileCheckedMerges moves each polecat through the test Pod and required
+// checks gates of the push strategy and returns the lanes still waiting.
+func (r *RefineryReconciler) reconcileCheckedMerges(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+	polecats []*gastownv1alpha1.Polecat, lanes []gastownv1alpha1.ActiveMerge,
+) []gastownv1alpha1.ActiveMerge {
+	var waiting []gastownv1alpha1.ActiveMerge
+	var waitingOn []string
+	for i, polecat := range polecats {
+		merged, pending, err := r.processCheckedMerge(ctx, refinery, polecat, &lanes[i])
+		if refinery.Spec.TestImage != "" && (err != nil || merged) {
+			r.deleteTestPod(ctx, refinery, lanes[i].CheckedCommit)
+		}
+		switch {
+		case err != nil:
+			r.recordMergeFailure(ctx, refinery, polecat, lanes[i], err)
+		case merged:
+			r.recordMergeSuccess(ctx, refinery, polecat, lanes[i])
+		default:
+			waiting = append(waiting, lanes[i])
+			waitingOn = append(waitingOn, pending...)
+		}
+	}
+	slices.Sort(waitingOn)
+	refinery.Status.WaitingOnChecks = slices.Compact(waitingOn)
+	return waiting
+}
+
+// processCheckedMerge publishes the rebased polecat branch, waits for its test
+// Pod and required checks and then merges exactly that commit. The lane
+// records the published commit, so the wait spans reconciles. It reports
+// whether the branch was merged, and else the required checks still pending.
+func (r *RefineryReconciler) processCheckedMerge(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+	polecat *gastownv1alpha1.Polecat, lane *gastownv1alpha1.ActiveMerge,
+) (bool, []string, error) {
+	log := logf.FromContext(ctx)
+
+	if lane.CheckedCommit == "" {
+		if err := r.publishForChecks(ctx, refinery, polecat, lane); err != nil {
+			return false, nil, err
+		}
+	}
+
+	if refinery.Spec.TestImage != "" {
+		passed, err := r.awaitTestPod(ctx, refinery, polecat, lane)
+		if err != nil || !passed {
+			return false, nil, err
+		}
+	}
+
+	if gatesOnChecks(refinery) {
+		prov, err := r.changeRequestProvider(ctx, refinery)
+		if err != nil {
+			return false, nil, err
+		}
+		required, err := requiredChecks(ctx, refinery, prov)
+		if err != nil {
+			return false, nil, err
+		}
+		if len(required) > 0 {
+			pending, err := r.awaitRequiredChecks(ctx, refinery, polecat, lane, prov, required)
+			if err != nil || len(pending) > 0 {
+				return false, pending, err
+			}
+		}
+	}
+
+	mergeTimer := metrics.NewRefineryMergeTimer(refinery.Spec.RigRef)
+	err := r.processMerge(ctx, refinery, polecat, lane.CheckedCommit)
+	if errors.Is(err, git.ErrHeadChanged) {
+		// The target moved since the checks ran; check the new head instead
+		log.Info("Target branch moved after checks, republishing",
+			"polecat", polecat.Name, "checkedCommit", lane.CheckedCommit)
+		r.Recorder.Event(refinery, "Normal", "ChecksRestarted",
+			fmt.Sprintf("Target branch moved after the checks on %s passed; republishing %s",
+				lane.CheckedCommit, polecat.Name))
+		if refinery.Spec.TestImage != "" {
+			r.deleteTestPod(ctx, refinery, lane.CheckedCommit)
+		}
+		lane.CheckedCommit = ""
+		lane.ChecksStartedAt = nil
+		return false, nil, nil
+	}
+	if err != nil {
+		mergeTimer.RecordError()
+		return false, nil, err
+	}
+	mergeTimer.RecordSuccess()
+	return true, nil, nil
+}
+
+// awaitRequiredChecks reads the required checks of the lane's published
+// commit onto the polecat's ChecksPassed condition and returns the ones
+// still pending. A failed check, or checks pending past the timeout, fail
+// the merge.
+func (r *RefineryReconciler) awaitRequiredChecks(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	lane *gastownv1alpha1.ActiveMerge, prov provider.Provider, required []string,
+) ([]string, error) {
+	results, err := prov.GetCommitChecks(ctx, lane.CheckedCommit)
+	if err != nil {
+		return nil, err
+	}
+	checks := git.SummarizeRequiredChecks(results, required)
+	if err := r.setChecksCondition(ctx, polecat, checksCondition(checks, metav1.Now())); err != nil {
+		return nil, err
+	}
+
+	switch checks.State {
+	case git.ChecksFailure:
+		return nil, fmt.Errorf("%d of %d required checks failed on %s",
+			checks.Failed, checks.Total, lane.CheckedCommit)
+	case git.ChecksPending:
+		timeout := requiredChecksTimeout(refinery)
+		if lane.ChecksStartedAt != nil && time.Since(lane.ChecksStartedAt.Time) > timeout {
+			return nil, fmt.Errorf("required checks %s on %s did not complete within %s",
+				strings.Join(checks.Pending, ", "), lane.CheckedCommit, timeout)
+		}
+		return checks.Pending, nil
+	}
+	return nil, nil
+}
+
+// gatesOnChecks reports whether the push strategy waits for provider checks
+// before it merges.
+func gatesOnChecks(refinery *gastownv1alpha1.Refinery) bool {
+	return len(refinery.Spec.RequiredChecks) > 0 || refinery.Spec.BranchProtectionChecks
+}
+
+// requiredChecks returns the checks a branch must pass before it merges: the
+// Refinery's requiredChecks, followed by those the provider's protection of
+// the target branch requires when branchProtectionChecks is set.
+func requiredChecks(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, prov provider.Provider,
+) ([]string, error) {
+	required := slices.Clone(refinery.Spec.RequiredChecks)
+	if !refinery.Spec.BranchProtectionChecks {
+		return required, nil
+	}
+	protection, ok := prov.(provider.BranchProtection)
+	if !ok {
+		return nil, fmt.Errorf("provider %s does not report branch protection", refinery.Spec.Provider)
+	}
+	targetBranch := refinery.Spec.TargetBranch
+	if targetBranch == "" {
+		targetBranch = "main"
+	}
+	protected, err := protection.RequiredChecks(ctx, targetBranch)
+	if err != nil {
+		return nil, err
+	}
+	for _, name := range protected {
+		if !slices.Contains(required, name) {
+			required = append(required, name)
+		}
+	}
+	return required, nil
+}
+
+// publishForChecks rebases the polecat branch onto the target, runs the test
+// command unless a test Pod runs it, and pushes the branch, recording the
+// pushed commit in the lane.
+func (r *RefineryReconciler) publishForChecks(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+	polecat *gastownv1alpha1.Polecat, lane *gastownv1alpha1.ActiveMerge,
+) error {
+	sourceBranch := polecat.Status.Branch
+	if sourceBranch == "" {
+		return fmt.Errorf("polecat %s has no branch in status", polecat.Name)
+	}
+
+	targetBranch := refinery.Spec.TargetBranch
+	if targetBranch == "" {
+		targetBranch = "main"
+	}
+
+	gitClient, cleanup, err := r.openRepository(ctx, refinery)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	publisher, ok := gitClient.(git.BranchPublisher)
+	if !ok {
+		return fmt.Errorf("git client cannot publish branches for required checks")
+	}
+	testCommand := refinery.Spec.TestCommand
+	if refinery.Spec.TestImage != "" {
+		testCommand = ""
+	}
+	sha, err := publisher.PublishBranch(ctx, git.MergeOptions{
+		SourceBranch: sourceBranch,
+		TargetBranch: targetBranch,
+		TestCommand:  testCommand,
+	})
+	r.Audit.Record("refinery", audit.ActionPush, polecat,
+		map[string]string{"branch": sourceBranch, "reason": "checks"}, err)
+	if err != nil {
+		return fmt.Errorf("publishing branch for checks failed: %w", err)
+	}
+
+	now := metav1.Now()
+	lane.CheckedCommit = sha
+	lane.ChecksStartedAt = &now
+	if !gatesOnChecks(refinery) {
+		return nil
+	}
+
+	waitingFor := strings.Join(refinery.Spec.RequiredChecks, ", ")
+	if refinery.Spec.BranchProtectionChecks {
+		waitingFor = strings.TrimPrefix(waitingFor+", the checks "+targetBranch+" requires", ", ")
+	}
+	logf.FromContext(ctx).Info("Waiting for required checks",
+		"polecat", polecat.Name, "commit", sha, "checks", waitingFor)
+	r.Recorder.Event(refinery, "Normal", "AwaitingChecks",
+		fmt.Sprintf("Pushed %s at %s; waiting for %s", sourceBranch, sha, waitingFor))
+
+	return r.setChecksCondition(ctx, polecat, metav1.Condition{
+		Type:               ConditionChecksPassed,
+		Status:             metav1.ConditionUnknown,
+		Reason:             "Pending",
+		Message:            "Waiting for required checks on " + sha,
+		LastTransitionTime: now,
+	})
+}
+
+// setChecksCondition records the ChecksPassed condition on the polecat,
+// writing only when it changed since checks are polled every reconcile.
+func (r *RefineryReconciler) setChecksCondition(
+	ctx context.Context, polecat *gastownv1alpha1.Polecat, cond metav1.Condition,
+) error {
+	before := polecat.DeepCopy()
+	meta.SetStatusCondition(&polecat.Status.Conditions, cond)
+	if equality.Semantic.DeepEqual(before.Status, polecat.Status) {
+		return nil
+	}
+	return r.applyPolecatStatus(ctx, polecat)
+}
+
+// requiredChecksTimeout returns how long to wait for the required checks of
+// one head.
+func requiredChecksTimeout(refinery *gastownv1alpha1.Refinery) time.Duration {
+	if t := refinery.Spec.RequiredChecksTimeout; t != nil && t.Duration > 0 {
+		return t.Duration
+	}
+	return defaultRequiredChecksTimeout
+}
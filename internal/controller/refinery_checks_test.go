@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git/provider"
+)
+
+// protectedProvider reports the checks its protected branches require.
+type protectedProvider struct {
+	provider.Provider
+	required map[string][]string
+}
+
+func (p *protectedProvider) RequiredChecks(ctx context.Context, branch string) ([]string, error) {
+	return p.required[branch], nil
+}
+
+var _ = Describe("Required checks", func() {
+	prov := &protectedProvider{required: map[string][]string{
+		"main":    {"build", "ci", "lint"},
+		"release": nil,
+	}}
+
+	It("should only gate on the Refinery's checks without branch protection", func() {
+		refinery := &gastownv1alpha1.Refinery{Spec: gastownv1alpha1.RefinerySpec{RequiredChecks: []string{"ci"}}}
+		Expect(gatesOnChecks(refinery)).To(BeTrue())
+		Expect(requiredChecks(context.Background(), refinery, prov)).To(Equal([]string{"ci"}))
+	})
+
+	It("should add the checks the target branch's protection requires", func() {
+		refinery := &gastownv1alpha1.Refinery{Spec: gastownv1alpha1.RefinerySpec{
+			RequiredChecks:         []string{"ci", "e2e"},
+			BranchProtectionChecks: true,
+		}}
+		Expect(requiredChecks(context.Background(), refinery, prov)).
+			To(Equal([]string{"ci", "e2e", "build", "lint"}))
+
+		refinery.Spec = gastownv1alpha1.RefinerySpec{TargetBranch: "release", BranchProtectionChecks: true}
+		Expect(gatesOnChecks(refinery)).To(BeTrue())
+		Expect(requiredChecks(context.Background(), refinery, prov)).To(BeEmpty(),
+			"an unprotected branch merges without waiting")
+	})
+
+	It("should fail with a provider that does not report branch protection", func() {
+		refinery := &gastownv1alpha1.Refinery{Spec: gastownv1alpha1.RefinerySpec{
+			Provider:               "gitlab",
+			BranchProtectionChecks: true,
+		}}
+		_, err := requiredChecks(context.Background(), refinery, struct{ provider.Provider }{})
+		Expect(err).To(MatchError(ContainSubstring("does not report branch protection")))
+	})
+})
@@ -0,0 +1,179 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+const (
+	// ConditionBranchDeleted is set on a merged Polecat once the branch
+	// cleanup removed its branch from the remote.
+	ConditionBranchDeleted = "BranchDeleted"
+
+	// refineryBranchCleanupInterval is how often an idle Refinery applies
+	// the rig's branch cleanup policy.
+	refineryBranchCleanupInterval = time.Hour
+)
+
+// branchCleanupDue reports whether the cleanup has not run within the interval.
+func branchCleanupDue(refinery *gastownv1alpha1.Refinery, now time.Time) bool {
+	last := refinery.Status.LastBranchCleanup
+	return last == nil || last.Time == nil || now.Sub(last.Time.Time) >= refineryBranchCleanupInterval
+}
+
+// staleBranchPolecats returns the merged polecats whose branches are due for
+// deletion under policy. completedConvoys holds the names of complete convoys
+// and completedBeads the beads they track.
+func staleBranchPolecats(
+	polecats []gastownv1alpha1.Polecat, policy *gastownv1alpha1.BranchCleanupSpec,
+	completedConvoys, completedBeads map[string]bool, now time.Time,
+) []*gastownv1alpha1.Polecat {
+	var stale []*gastownv1alpha1.Polecat
+	for i := range polecats {
+		polecat := &polecats[i]
+		merged := meta.FindStatusCondition(polecat.Status.Conditions, ConditionMerged)
+		if merged == nil || merged.Status != metav1.ConditionTrue || polecat.Status.Branch == "" ||
+			meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionBranchDeleted) {
+			continue
+		}
+
+		due := false
+		if policy.AfterDays != nil {
+			retention := time.Duration(*policy.AfterDays) * 24 * time.Hour
+			due = !now.Before(merged.LastTransitionTime.Add(retention))
+		}
+		if policy.AfterConvoyComplete {
+			due = due || completedConvoys[polecat.Labels[pod.ConvoyLabel]] || completedBeads[polecat.Spec.BeadID]
+		}
+		if due {
+			stale = append(stale, polecat)
+		}
+	}
+	return stale
+}
+
+// cleanupBranches applies the rig's branch cleanup policy to the merged
+// polecats of the rig. Deleted branches are marked on their Polecat so they
+// are not revisited; a dry run only reports them.
+func (r *RefineryReconciler) cleanupBranches(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecats []gastownv1alpha1.Polecat,
+) (*gastownv1alpha1.BranchCleanupStatus, error) {
+	log := logf.FromContext(ctx)
+	now := time.Now()
+	result := &gastownv1alpha1.BranchCleanupStatus{Time: &metav1.Time{Time: now}}
+
+	rig := &gastownv1alpha1.Rig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: refinery.Spec.RigRef}, rig); err != nil {
+		return nil, fmt.Errorf("failed to get rig %s: %w", refinery.Spec.RigRef, err)
+	}
+	policy := rig.Spec.BranchCleanup
+	if policy == nil {
+		return nil, nil
+	}
+	result.DryRun = policy.DryRun
+
+	completedConvoys := map[string]bool{}
+	completedBeads := map[string]bool{}
+	if policy.AfterConvoyComplete {
+		convoys := &gastownv1alpha1.ConvoyList{}
+		if err := r.List(ctx, convoys, client.InNamespace(refinery.Namespace)); err != nil {
+			return nil, fmt.Errorf("failed to list convoys: %w", err)
+		}
+		for _, convoy := range convoys.Items {
+			if convoy.Status.Phase != gastownv1alpha1.ConvoyPhaseComplete {
+				continue
+			}
+			completedConvoys[convoy.Name] = true
+			for _, bead := range convoy.Spec.TrackedBeads {
+				completedBeads[bead] = true
+			}
+		}
+	}
+
+	stale := staleBranchPolecats(polecats, policy, completedConvoys, completedBeads, now)
+	if len(stale) == 0 {
+		return result, nil
+	}
+
+	if policy.DryRun {
+		for _, polecat := range stale {
+			result.Branches = append(result.Branches, polecat.Status.Branch)
+		}
+		log.Info("Branch cleanup dry run", "branches", result.Branches)
+		return result, nil
+	}
+
+	gitClient, cleanup, err := r.openRepository(ctx, refinery)
+	if err != nil {
+		return result, err
+	}
+	defer cleanup()
+
+	deleter, ok := gitClient.(git.BranchDeleter)
+	if !ok {
+		return result, fmt.Errorf("git client does not support deleting branches")
+	}
+
+	var failed []string
+	for _, polecat := range stale {
+		branch := polecat.Status.Branch
+		exists, err := deleter.BranchExists(ctx, branch)
+		if err == nil && exists {
+			err = deleter.DeleteRemoteBranch(ctx, branch)
+			r.Audit.Record("refinery", audit.ActionDeleteBranch, polecat, map[string]string{"branch": branch}, err)
+		}
+		if err != nil {
+			log.Error(err, "Failed to delete merged branch", "branch", branch)
+			failed = append(failed, branch)
+			continue
+		}
+		if exists {
+			result.Branches = append(result.Branches, branch)
+		}
+
+		meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+			Type:               ConditionBranchDeleted,
+			Status:             metav1.ConditionTrue,
+			Reason:             "BranchCleanup",
+			Message:            fmt.Sprintf("Merged branch %s removed from the remote", branch),
+			LastTransitionTime: metav1.Now(),
+		})
+		if err := r.applyPolecatStatus(ctx, polecat); err != nil {
+			log.Error(err, "Failed to update Polecat status", "polecat", polecat.Name)
+		}
+	}
+
+	if len(failed) > 0 {
+		return result, fmt.Errorf("failed to delete branches: %s", strings.Join(failed, ", "))
+	}
+	return result, nil
+}
@@ -0,0 +1,177 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// ConflictResolverLabel marks a Polecat created to resolve the rebase
+// conflicts of another; the value is the original Polecat's name.
+const ConflictResolverLabel = "gastown.io/resolves"
+
+// spawnConflictResolver creates a Polecat resolving the rebase conflicts of
+// the polecat's branch, when spec.autoResolveConflicts is set and mergeErr is
+// a conflict. Each polecat gets one resolver; resolvers get none.
+func (r *RefineryReconciler) spawnConflictResolver(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat, mergeErr error,
+) {
+	if !refinery.Spec.AutoResolveConflicts || !errors.Is(mergeErr, git.ErrRebaseConflict) {
+		return
+	}
+	if _, ok := polecat.Labels[ConflictResolverLabel]; ok {
+		return
+	}
+	log := logf.FromContext(ctx).WithValues("polecat", polecat.Name)
+	if polecat.Spec.Kubernetes == nil || polecat.Status.Branch == "" {
+		log.Info("Not resolving conflicts of a polecat without a Kubernetes branch")
+		return
+	}
+
+	resolver := conflictResolver(polecat, refineryTargetBranch(refinery))
+	if err := r.Create(ctx, resolver); err != nil {
+		if !apierrors.IsAlreadyExists(err) {
+			log.Error(err, "Failed to create conflict resolver")
+			r.Recorder.Event(refinery, "Warning", "ConflictResolverFailed",
+				fmt.Sprintf("Failed to create a polecat resolving the conflicts of %s: %v", polecat.Name, err))
+		}
+		return
+	}
+
+	log.Info("Created conflict resolver", "resolver", resolver.Name, "branch", polecat.Status.Branch)
+	r.Recorder.Event(refinery, "Normal", "ConflictResolverCreated",
+		fmt.Sprintf("Created polecat %s to resolve the conflicts of %s with %s",
+			resolver.Name, polecat.Status.Branch, refineryTargetBranch(refinery)))
+}
+
+// conflictResolver builds the Polecat resolving the conflicts of the
+// polecat's branch. It runs like the original, but checks out the conflicted
+// branch itself and force-pushes it once rebased.
+func conflictResolver(polecat *gastownv1alpha1.Polecat, target string) *gastownv1alpha1.Polecat {
+	branch := polecat.Status.Branch
+
+	labels := make(map[string]string, len(polecat.Labels)+1)
+	for k, v := range polecat.Labels {
+		labels[k] = v
+	}
+	labels[ConflictResolverLabel] = polecat.Name
+
+	spec := polecat.Spec.DeepCopy()
+	spec.DesiredState = gastownv1alpha1.PolecatDesiredWorking
+	spec.MergeAfter = nil
+	spec.Kubernetes.GitBranch = branch
+	spec.Kubernetes.WorkBranch = branch
+	spec.TaskDescription = conflictResolutionTask(polecat, branch, target)
+
+	return &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      polecat.Name + "-resolve",
+			Namespace: polecat.Namespace,
+			Labels:    labels,
+		},
+		Spec: *spec,
+	}
+}
+
+// conflictResolutionTask is the task of a conflict resolver.
+func conflictResolutionTask(polecat *gastownv1alpha1.Polecat, branch, target string) string {
+	var task strings.Builder
+	fmt.Fprintf(&task, "Resolve rebase conflicts between %s and %s\n\n", branch, target)
+	fmt.Fprintf(&task, "The Refinery could not rebase %s onto %s. You are on %s, in a shallow clone:\n\n",
+		branch, target, branch)
+	fmt.Fprintf(&task, "    git fetch --unshallow origin\n")
+	fmt.Fprintf(&task, "    git fetch origin %s\n", target)
+	fmt.Fprintf(&task, "    git rebase origin/%s\n\n", target)
+	fmt.Fprintf(&task, "Resolve every conflict keeping the intent of both sides, make sure the "+
+		"tests pass, then publish the rebased branch:\n\n")
+	fmt.Fprintf(&task, "    git push --force-with-lease origin %s\n", branch)
+	if polecat.Spec.TaskDescription != "" {
+		fmt.Fprintf(&task, "\nThe branch was written for this task:\n\n%s\n", polecat.Spec.TaskDescription)
+	} else if polecat.Spec.BeadID != "" {
+		fmt.Fprintf(&task, "\nThe branch was written for bead %s.\n", polecat.Spec.BeadID)
+	}
+	return task.String()
+}
+
+// markResolvedMerged records the merge of a conflict resolver's branch on the
+// polecat it resolved, whose work landed with it.
+func (r *RefineryReconciler) markResolvedMerged(
+	ctx context.Context, resolver *gastownv1alpha1.Polecat, target, mergedCommit string,
+) error {
+	name, ok := resolver.Labels[ConflictResolverLabel]
+	if !ok {
+		return nil
+	}
+	original := &gastownv1alpha1.Polecat{}
+	if err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: resolver.Namespace}, original); err != nil {
+		if apierrors.IsNotFound(err) {
+			return nil
+		}
+		return fmt.Errorf("failed to get resolved polecat %s: %w", name, err)
+	}
+
+	original.Status.MergedCommit = mergedCommit
+	meta.SetStatusCondition(&original.Status.Conditions, metav1.Condition{
+		Type:   ConditionMerged,
+		Status: metav1.ConditionTrue,
+		Reason: "ConflictsResolved",
+		Message: fmt.Sprintf("Branch %s merged to %s by conflict resolver %s (commit: %s)",
+			resolver.Status.Branch, target, resolver.Name, mergedCommit),
+		LastTransitionTime: metav1.Now(),
+	})
+	return r.applyPolecatStatus(ctx, original)
+}
+
+// resolvingConflicts returns the polecats that have a conflict resolver. The
+// resolver takes their place in the merge queue; if it gets stuck or is
+// terminated, they return to it.
+func resolvingConflicts(all *gastownv1alpha1.PolecatList) map[string]bool {
+	resolving := make(map[string]bool)
+	for i := range all.Items {
+		resolver := &all.Items[i]
+		name, ok := resolver.Labels[ConflictResolverLabel]
+		if !ok {
+			continue
+		}
+		switch resolver.Status.Phase {
+		case gastownv1alpha1.PolecatPhaseStuck, gastownv1alpha1.PolecatPhaseTerminated:
+			continue
+		}
+		resolving[name] = true
+	}
+	return resolving
+}
+
+// refineryTargetBranch returns the branch the Refinery merges into.
+func refineryTargetBranch(refinery *gastownv1alpha1.Refinery) string {
+	if refinery.Spec.TargetBranch == "" {
+		return "main"
+	}
+	return refinery.Spec.TargetBranch
+}
@@ -18,11 +18,13 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"os"
 	"path/filepath"
 	"strings"
+	"sync"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
@@ -33,12 +35,17 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
 	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/pkg/config"
 	"github.com/org/gastown-operator/pkg/metrics"
+	"github.com/org/gastown-operator/pkg/pod"
 )
 
 const (
@@ -48,13 +55,21 @@ const (
 	// RefineryConditionProcessing indicates a merge is in progress.
 	RefineryConditionProcessing = "Processing"
 
-	// Default requeue interval for idle refinery.
-	// Uses RequeueDefault for normal idle monitoring.
-	refineryIdleRequeueInterval = RequeueDefault
+	// RefineryConditionSignaturesVerified indicates whether the last branch
+	// merged or refused by the refinery of a signing rig carried good
+	// signatures of the rig's key.
+	RefineryConditionSignaturesVerified = "SignaturesVerified"
 
 	// Requeue interval during active processing.
 	// Uses a shorter interval for active merge monitoring.
 	refineryProcessingRequeueInterval = 5 * time.Second
+
+	// Requeue interval while pull requests are open or required checks run.
+	// Their state is polled through the provider API, so poll less often.
+	refineryPullRequestRequeueInterval = RequeueDefault
+
+	// Maximum merge attempts per branch when the target branch keeps moving.
+	refineryMaxMergeAttempts = 3
 )
 
 // RefineryReconciler reconciles a Refinery object
@@ -65,18 +80,40 @@ type RefineryReconciler struct {
 
 	// GitClientFactory creates git clients. If nil, uses git.DefaultGitClientFactory.
 	GitClientFactory git.GitClientFactory
+
+	// AppTokens caches GitHub App installation tokens. If nil, uses git.DefaultAppTokens.
+	AppTokens *git.AppTokenCache
+
+	// Triggers enqueues Refineries on demand (e.g., git webhooks). Optional.
+	Triggers <-chan event.GenericEvent
+
+	// Telemetry exports merge outcomes. Optional.
+	Telemetry MergeTelemetry
+
+	// Audit records the clones, merges and pushes of the Refinery. Optional.
+	Audit *audit.Log
+
+	// Tuning overrides the controller's workqueue settings. Optional.
+	Tuning config.ControllerTuning
+
+	// Identity names this operator replica, e.g. its Pod name, as the holder
+	// of the merges it runs. Optional.
+	Identity string
 }
 
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=refineries,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=refineries/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=refineries/finalizers,verbs=update
-// +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats,verbs=get;list;watch;create;update;patch
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=rigs,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=convoys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=emergencystops,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;delete
 
 // Reconcile processes the merge queue for the Refinery's Rig.
-func (r *RefineryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+func (r *RefineryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (result ctrl.Result, err error) {
 	log := logf.FromContext(ctx)
 
 	// Fetch the Refinery instance
@@ -84,6 +121,13 @@ func (r *RefineryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 	if err := r.Get(ctx, req.NamespacedName, refinery); err != nil {
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
+	// Refineries with deep merge queues go first under backlog
+	defer func() { withPriority(&result, refinery, refineryPriority(refinery)) }()
+
+	// Leave resources processed by a newer operator alone
+	if skip, err := checkSchemaVersion(ctx, r.Client, r.Recorder, refinery, &refinery.Status.Conditions); skip || err != nil {
+		return ctrl.Result{}, err
+	}
 
 	log.Info("Reconciling Refinery", "rigRef", refinery.Spec.RigRef)
 
@@ -97,71 +141,232 @@ func (r *RefineryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 		log.Error(err, "Failed to list Polecats")
 		r.setCondition(refinery, RefineryConditionReady, metav1.ConditionFalse,
 			"ListFailed", "Failed to list Polecats")
-		return ctrl.Result{RequeueAfter: refineryIdleRequeueInterval}, r.Status().Update(ctx, refinery)
+		return ctrl.Result{RequeueAfter: requeueDefault()}, applyStatus(ctx, r.Client, refinery, fieldManagerRefinery)
 	}
 
-	// Find polecats that are ready for merge
-	mergeQueue := r.findMergeReadyPolecats(polecatList)
+	// Find polecats that are ready for merge and order them by queue policy
+	policy := refinery.Spec.QueuePolicy
+	if policy == "" {
+		policy = gastownv1alpha1.MergeQueuePolicyFIFO
+	}
+	readyPolecats := r.findMergeReadyPolecats(polecatList)
+	queue := buildMergeQueue(readyPolecats, polecatList, policy, refinery.Status.Queue)
+	if policy == gastownv1alpha1.MergeQueuePolicySmallestDiffFirst {
+		r.measureDiffSizes(ctx, refinery, queue)
+		sortMergeQueue(queue, policy)
+	}
+	refinery.Status.Queue = queue
+	// Set again by the lanes still waiting on required checks
+	refinery.Status.WaitingOnChecks = nil
+
+	// Scheduled batches hold each branch until the first batch after it
+	// became ready
+	now := time.Now()
+	refinery.Status.NextBatchTime = nil
+	if refinery.Spec.Batch != nil {
+		next, err := assignMergeBatches(queue, refinery.Spec.Batch, now)
+		if err != nil {
+			log.Error(err, "Invalid merge batch")
+			refinery.Status.Phase = "Error"
+			r.setCondition(refinery, RefineryConditionReady, metav1.ConditionFalse,
+				"InvalidBatchSchedule", err.Error())
+			return ctrl.Result{}, applyStatus(ctx, r.Client, refinery, fieldManagerRefinery)
+		}
+		refinery.Status.NextBatchTime = &metav1.Time{Time: next}
+	}
 
 	// Update queue statistics (cap at MaxInt32 to avoid overflow)
-	queueLen := len(mergeQueue)
+	queueLen := len(queue)
 	if queueLen > math.MaxInt32 {
 		queueLen = math.MaxInt32
 	}
 	refinery.Status.QueueLength = int32(queueLen)           // #nosec G115 -- bounds checked above
 	refinery.Status.MergesSummary.Pending = int32(queueLen) // #nosec G115 -- bounds checked above
 
-	// Update queue length metric
-	metrics.UpdateQueueLength(refinery.Spec.RigRef, float64(queueLen))
+	// A frozen rig (or an emergency stop) keeps the queue but merges nothing
+	stop, err := activeEmergencyStop(ctx, r.Client)
+	if err != nil {
+		log.Error(err, "Failed to list EmergencyStops")
+		return ctrl.Result{RequeueAfter: requeueDefault()}, err
+	}
+	suspended, err := isRigSuspended(ctx, r.Client, refinery.Spec.RigRef)
+	if err != nil {
+		log.Error(err, "Failed to get Rig")
+		return ctrl.Result{RequeueAfter: requeueDefault()}, err
+	}
+	if stop != nil || suspended {
+		refinery.Status.Phase = "Idle"
+		refinery.Status.CurrentMerge = ""
+		if stop != nil {
+			r.setCondition(refinery, RefineryConditionReady, metav1.ConditionTrue,
+				"EmergencyStop", fmt.Sprintf("Emergency stop %s engaged; %d branches held", stop.Name, len(queue)))
+		} else {
+			r.setCondition(refinery, RefineryConditionReady, metav1.ConditionTrue,
+				"Suspended", fmt.Sprintf("Rig is suspended; %d branches held", len(queue)))
+		}
+
+		if err := applyStatus(ctx, r.Client, refinery, fieldManagerRefinery); err != nil {
+			log.Error(err, "Failed to update Refinery status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: requeueDefault()}, nil
+	}
 
 	// If no work, mark as Idle
-	if len(mergeQueue) == 0 {
+	if len(queue) == 0 {
 		refinery.Status.Phase = "Idle"
 		refinery.Status.CurrentMerge = ""
 		r.setCondition(refinery, RefineryConditionReady, metav1.ConditionTrue,
 			"Idle", "No merges pending")
 
-		if err := r.Status().Update(ctx, refinery); err != nil {
+		// The batch has drained; release it if configured
+		r.releaseBatch(ctx, refinery)
+
+		// Apply the rig's retention policy to merged branches left on the remote
+		if branchCleanupDue(refinery, now) {
+			cleanup, err := r.cleanupBranches(ctx, refinery, polecatList.Items)
+			if cleanup != nil {
+				refinery.Status.LastBranchCleanup = cleanup
+				if len(cleanup.Branches) > 0 {
+					reason, verb := "BranchesDeleted", "Deleted"
+					if cleanup.DryRun {
+						reason, verb = "BranchCleanupDryRun", "Would delete"
+					}
+					r.Recorder.Event(refinery, "Normal", reason,
+						fmt.Sprintf("%s %d merged branches: %s", verb, len(cleanup.Branches), strings.Join(cleanup.Branches, ", ")))
+				}
+			}
+			if err != nil {
+				log.Error(err, "Failed to clean up merged branches")
+				r.Recorder.Event(refinery, "Warning", "BranchCleanupFailed", err.Error())
+			}
+		}
+
+		if err := applyStatus(ctx, r.Client, refinery, fieldManagerRefinery); err != nil {
 			log.Error(err, "Failed to update Refinery status")
 			return ctrl.Result{}, err
 		}
-		return ctrl.Result{RequeueAfter: refineryIdleRequeueInterval}, nil
-	}
-
-	// Process the first item in queue (sequential processing)
-	if refinery.Spec.Parallelism <= 1 && len(mergeQueue) > 0 {
-		targetPolecat := mergeQueue[0]
-		refinery.Status.Phase = "Processing"
-		refinery.Status.CurrentMerge = targetPolecat.Name
-
-		r.setCondition(refinery, RefineryConditionProcessing, metav1.ConditionTrue,
-			"Processing", "Processing merge for "+targetPolecat.Name)
-
-		// Process the merge with timing
-		mergeTimer := metrics.NewRefineryMergeTimer(refinery.Spec.RigRef)
-		if err := r.processMerge(ctx, refinery, &targetPolecat); err != nil {
-			log.Error(err, "Failed to process merge", "polecat", targetPolecat.Name)
-			refinery.Status.MergesSummary.Failed++
-			r.Recorder.Event(refinery, "Warning", "MergeFailed",
-				"Merge failed for "+targetPolecat.Name+": "+err.Error())
-			mergeTimer.RecordError()
-
-			// Track conflicts (rebase failures typically indicate conflicts)
-			if strings.Contains(err.Error(), "rebase failed") || strings.Contains(err.Error(), "conflict") {
-				metrics.RecordConflict(refinery.Spec.RigRef)
-			}
+		return ctrl.Result{RequeueAfter: syncInterval(refinery.Spec.SyncIntervalSeconds, requeueDefault())}, nil
+	}
+
+	// Keep other Refineries, e.g. of another cluster, off the target branch
+	// while this one has branches to land
+	if err := r.acquireBranchLease(ctx, refinery, now); err != nil {
+		return r.holdQueue(ctx, refinery, len(queue), err)
+	}
+
+	// Rebase and retest branches that fell too far behind the target branch
+	r.refreshDriftedBranches(ctx, refinery, queue)
+
+	// Pick up to one unblocked branch per merge lane
+	lanes := int(refinery.Spec.Parallelism)
+	if lanes < 1 {
+		lanes = 1
+	}
+	candidates := nextMergeCandidates(queue, lanes, now)
+
+	// Every queued branch is waiting for its batch or on a dependency that
+	// has not merged yet
+	if len(candidates) == 0 {
+		refinery.Status.Phase = "Idle"
+		refinery.Status.CurrentMerge = ""
+		requeue := requeueDefault()
+		if waiting := countWaitingForBatch(queue, now); waiting > 0 {
+			next := refinery.Status.NextBatchTime.Time
+			r.setCondition(refinery, RefineryConditionReady, metav1.ConditionTrue,
+				"WaitingForBatch", fmt.Sprintf("%d branches wait for the batch at %s", waiting, next.Format(time.RFC3339)))
+			// The previous batch has landed; release it if configured
+			r.releaseBatch(ctx, refinery)
+			requeue = min(requeue, next.Sub(now))
 		} else {
-			refinery.Status.MergesSummary.Succeeded++
-			refinery.Status.MergesSummary.Total++
-			refinery.Status.LastMergeTime = &metav1.Time{Time: time.Now()}
-			r.Recorder.Event(refinery, "Normal", "MergeSucceeded",
-				"Successfully merged "+targetPolecat.Name)
-			mergeTimer.RecordSuccess()
+			r.setCondition(refinery, RefineryConditionReady, metav1.ConditionTrue,
+				"Blocked", fmt.Sprintf("%d branches waiting on mergeAfter dependencies", len(queue)))
+		}
+
+		if err := applyStatus(ctx, r.Client, refinery, fieldManagerRefinery); err != nil {
+			log.Error(err, "Failed to update Refinery status")
+			return ctrl.Result{}, err
+		}
+		return ctrl.Result{RequeueAfter: requeue}, nil
+	}
+
+	targets := make([]*gastownv1alpha1.Polecat, 0, len(candidates))
+	active := make([]gastownv1alpha1.ActiveMerge, 0, len(candidates))
+	startedAt := metav1.Now()
+	for _, idx := range candidates {
+		for i := range readyPolecats {
+			if readyPolecats[i].Name == queue[idx].Polecat {
+				targets = append(targets, &readyPolecats[i])
+				break
+			}
+		}
+		entry := gastownv1alpha1.ActiveMerge{
+			Lane:      int32(len(active)), // #nosec G115 -- bounded by spec.parallelism
+			Polecat:   queue[idx].Polecat,
+			Branch:    queue[idx].Branch,
+			StartedAt: &startedAt,
+			Holder:    r.Identity,
+		}
+		// Pull requests and checked merges span reconciles; keep their state
+		for _, prev := range refinery.Status.ActiveMerges {
+			if prev.Polecat == entry.Polecat && prev.StartedAt != nil {
+				entry.StartedAt = prev.StartedAt
+				entry.CheckedCommit = prev.CheckedCommit
+				entry.ChecksStartedAt = prev.ChecksStartedAt
+				if prev.Holder != "" && prev.Holder != r.Identity {
+					// The previous leader stopped with the merge in flight
+					r.Recorder.Event(refinery, "Normal", "MergeResumed",
+						fmt.Sprintf("Resuming the merge of %s started by %s", entry.Polecat, prev.Holder))
+				}
+			}
+		}
+		active = append(active, entry)
+	}
+
+	refinery.Status.Phase = "Processing"
+	refinery.Status.CurrentMerge = targets[0].Name
+	refinery.Status.ActiveMerges = active
+	r.setCondition(refinery, RefineryConditionProcessing, metav1.ConditionTrue,
+		"Processing", fmt.Sprintf("Processing %d merge(s) in %d lane(s)", len(targets), lanes))
+
+	// Publish the busy lanes before the merges start
+	if err := applyStatus(ctx, r.Client, refinery, fieldManagerRefinery); err != nil {
+		log.Error(err, "Failed to update Refinery status")
+		return ctrl.Result{}, err
+	}
+
+	// Started merges run to completion even when the manager stops: a merge
+	// cancelled between its push and its bookkeeping would land a branch
+	// that stays queued. The manager waits for them up to its graceful
+	// shutdown timeout before releasing the leader lease.
+	ctx = context.WithoutCancel(ctx)
+
+	pullRequests := refinery.Spec.MergeStrategy == gastownv1alpha1.MergeStrategyPullRequest
+	checked := !pullRequests && (gatesOnChecks(refinery) || refinery.Spec.TestImage != "")
+	if pullRequests {
+		// Lanes holding an open pull request stay busy until it merges
+		refinery.Status.ActiveMerges = r.reconcilePullRequests(ctx, refinery, targets, active)
+	} else if checked {
+		// Lanes stay busy until the test Pod and required checks of their head pass
+		refinery.Status.ActiveMerges = r.reconcileCheckedMerges(ctx, refinery, targets, active)
+	} else {
+		errs := r.runMergeLanes(ctx, refinery, targets)
+		for i, polecat := range targets {
+			if err := errs[i]; err != nil {
+				r.recordMergeFailure(ctx, refinery, polecat, active[i], err)
+				// Track conflicts (rebase failures typically indicate conflicts)
+				if strings.Contains(err.Error(), "rebase failed") || strings.Contains(err.Error(), "conflict") {
+					metrics.RecordConflict(refinery.Spec.RigRef)
+				}
+			} else {
+				r.recordMergeSuccess(ctx, refinery, polecat, active[i])
+			}
 		}
+		refinery.Status.ActiveMerges = nil
 	}
 
 	// Update status
-	if err := r.Status().Update(ctx, refinery); err != nil {
+	if err := applyStatus(ctx, r.Client, refinery, fieldManagerRefinery); err != nil {
 		log.Error(err, "Failed to update Refinery status")
 		return ctrl.Result{}, err
 	}
@@ -174,9 +379,141 @@ func (r *RefineryReconciler) Reconcile(ctx context.Context, req ctrl.Request) (c
 
 	// Requeue quickly if there's work to do
 	if refinery.Status.QueueLength > 0 {
+		if pullRequests || checked {
+			return ctrl.Result{RequeueAfter: syncInterval(refinery.Spec.SyncIntervalSeconds, refineryPullRequestRequeueInterval)}, nil
+		}
 		return ctrl.Result{RequeueAfter: refineryProcessingRequeueInterval}, nil
 	}
-	return ctrl.Result{RequeueAfter: refineryIdleRequeueInterval}, nil
+	return ctrl.Result{RequeueAfter: syncInterval(refinery.Spec.SyncIntervalSeconds, requeueDefault())}, nil
+}
+
+// releaseBatch tags a release once a batch of merges has landed, if configured.
+func (r *RefineryReconciler) releaseBatch(ctx context.Context, refinery *gastownv1alpha1.Refinery) {
+	if !releaseDue(refinery) {
+		return
+	}
+	release, err := r.cutRelease(ctx, refinery)
+	if release != nil {
+		refinery.Status.LastRelease = release
+		r.Recorder.Event(refinery, "Normal", "Released",
+			"Tagged release "+release.Tag+" at "+release.Commit)
+	}
+	if err != nil {
+		logf.FromContext(ctx).Error(err, "Failed to cut release")
+		r.Recorder.Event(refinery, "Warning", "ReleaseFailed", err.Error())
+	}
+}
+
+// recordMergeFailure counts a failed merge of the lane, adds it to the merge
+// history and reports it as an event.
+func (r *RefineryReconciler) recordMergeFailure(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	lane gastownv1alpha1.ActiveMerge, err error,
+) {
+	logf.FromContext(ctx).Error(err, "Failed to process merge", "polecat", polecat.Name, "lane", lane.Lane)
+	refinery.Status.MergesSummary.Failed++
+	appendMergeHistory(refinery, polecat, lane, err, time.Now())
+	r.Recorder.Event(refinery, "Warning", "MergeFailed",
+		"Merge failed for "+polecat.Name+": "+err.Error())
+	r.recordSignatureVerification(ctx, refinery, polecat.Name, err)
+	r.exportMerge(ctx, refinery, polecat, lane, err)
+	r.spawnConflictResolver(ctx, refinery, polecat, err)
+}
+
+// recordMergeSuccess counts a landed merge of the lane, adds it to the merge
+// history and reports it as an event.
+func (r *RefineryReconciler) recordMergeSuccess(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	lane gastownv1alpha1.ActiveMerge,
+) {
+	now := time.Now()
+	refinery.Status.MergesSummary.Succeeded++
+	refinery.Status.MergesSummary.Total++
+	refinery.Status.LastMergeTime = &metav1.Time{Time: now}
+	appendMergeHistory(refinery, polecat, lane, nil, now)
+	r.Recorder.Event(refinery, "Normal", "MergeSucceeded",
+		"Successfully merged "+polecat.Name)
+	if refinery.Spec.MergeStrategy != gastownv1alpha1.MergeStrategyPullRequest {
+		// Pull requests are merged by the provider, which verifies nothing for us
+		r.recordSignatureVerification(ctx, refinery, polecat.Name, nil)
+	}
+	r.exportMerge(ctx, refinery, polecat, lane, nil)
+}
+
+// recordSignatureVerification reports on the SignaturesVerified condition
+// whether the polecat's branch carried good signatures of the rig's signing
+// key, going by the outcome of its merge or refresh. Other errors say nothing
+// about the signatures and leave the condition as it is; a rig that signs
+// nothing has no condition.
+func (r *RefineryReconciler) recordSignatureVerification(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecatName string, err error,
+) {
+	signing, getErr := rigCommitSigning(ctx, r.Client, refinery.Spec.RigRef)
+	if getErr != nil {
+		logf.FromContext(ctx).Error(getErr, "Failed to get rig signing key", "rig", refinery.Spec.RigRef)
+		return
+	}
+	switch {
+	case signing == nil:
+		meta.RemoveStatusCondition(&refinery.Status.Conditions, RefineryConditionSignaturesVerified)
+	case errors.Is(err, git.ErrUnverifiedSignature):
+		r.setCondition(refinery, RefineryConditionSignaturesVerified, metav1.ConditionFalse,
+			"UnverifiedSignature", fmt.Sprintf("Refused the branch of %s: %v", polecatName, err))
+		r.Recorder.Event(refinery, "Warning", "UnverifiedSignature",
+			"Refused the branch of "+polecatName+": "+err.Error())
+	case err == nil:
+		r.setCondition(refinery, RefineryConditionSignaturesVerified, metav1.ConditionTrue,
+			"Verified", "The commits of "+polecatName+" carried good signatures")
+	}
+}
+
+// exportMerge hands the outcome of the lane's merge to the telemetry
+// exporter, if any.
+func (r *RefineryReconciler) exportMerge(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	lane gastownv1alpha1.ActiveMerge, err error,
+) {
+	if r.Telemetry == nil {
+		return
+	}
+	var started time.Time
+	if lane.StartedAt != nil {
+		started = lane.StartedAt.Time
+	}
+	r.Telemetry.MergeFinished(ctx, refinery.Spec.RigRef, polecat.Name, started, err)
+}
+
+// applyPolecatStatus writes the Polecat status fields and conditions owned by the Refinery.
+func (r *RefineryReconciler) applyPolecatStatus(ctx context.Context, polecat *gastownv1alpha1.Polecat) error {
+	return applyFields(ctx, r.Client, polecat, fieldManagerRefinery, refineryPolecatFields, refineryPolecatConditionTypes...)
+}
+
+// runMergeLanes merges each polecat in its own lane and waits for all lanes.
+// Lanes clone into separate working directories, so they only contend on the
+// push to the target branch; processMerge retries the rebase when it loses.
+// The returned errors are indexed like polecats.
+func (r *RefineryReconciler) runMergeLanes(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecats []*gastownv1alpha1.Polecat,
+) []error {
+	errs := make([]error, len(polecats))
+
+	var wg sync.WaitGroup
+	for i, polecat := range polecats {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			mergeTimer := metrics.NewRefineryMergeTimer(refinery.Spec.RigRef)
+			errs[i] = r.processMerge(ctx, refinery, polecat, "")
+			if errs[i] != nil {
+				mergeTimer.RecordError()
+			} else {
+				mergeTimer.RecordSuccess()
+			}
+		}()
+	}
+	wg.Wait()
+
+	return errs
 }
 
 // findMergeReadyPolecats finds polecats that have completed successfully and are ready for merge.
@@ -185,6 +522,11 @@ func (r *RefineryReconciler) findMergeReadyPolecats(polecats *gastownv1alpha1.Po
 	var ready []gastownv1alpha1.Polecat
 
 	for _, polecat := range polecats.Items {
+		// Planning polecats propose splits and have no work to merge
+		if polecat.Spec.SplitOf != "" {
+			continue
+		}
+
 		var hasAvailable, hasOldReady bool
 		var availableTrue, oldReadySucceeded bool
 
@@ -223,8 +565,12 @@ func (r *RefineryReconciler) findMergeReadyPolecats(polecats *gastownv1alpha1.Po
 //  5. Run tests if TestCommand is configured
 //  6. Push to target branch
 //  7. Clean up polecat branch
+//  8. Merge the branch in the rig repositories of spec.repositories
+//
+// A non-empty expectedHead is a commit already tested and checked: it is
+// merged only if the rebase reproduces it, without running tests again.
 func (r *RefineryReconciler) processMerge(
-	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat, expectedHead string,
 ) error {
 	log := logf.FromContext(ctx)
 
@@ -245,49 +591,66 @@ func (r *RefineryReconciler) processMerge(
 		"targetBranch", targetBranch,
 		"testCommand", refinery.Spec.TestCommand)
 
-	// Get the Rig to find the git URL
-	rig := &gastownv1alpha1.Rig{}
-	if err := r.Get(ctx, types.NamespacedName{Name: refinery.Spec.RigRef}, rig); err != nil {
-		return fmt.Errorf("failed to get rig %s: %w", refinery.Spec.RigRef, err)
-	}
-
-	gitURL := rig.Spec.GitURL
-	if gitURL == "" {
-		return fmt.Errorf("rig %s has no gitURL", refinery.Spec.RigRef)
+	message, err := r.mergeCommitMessage(ctx, refinery, polecat, sourceBranch, targetBranch)
+	if err != nil {
+		return err
 	}
 
-	// Set up git credentials if specified
-	var sshKeyPath string
-	if refinery.Spec.GitSecretRef != nil {
-		keyPath, cleanup, err := r.setupGitCredentials(ctx, refinery)
+	// The primary repository is tested and merged first, and the rig
+	// repositories only once it landed. A retry after one of them failed
+	// finds the primary merged and skips it.
+	if polecat.Status.MergedCommit == "" {
+		mergedCommit, err := r.mergePrimary(ctx, refinery, polecat, sourceBranch, targetBranch, message, expectedHead)
 		if err != nil {
-			return fmt.Errorf("failed to setup git credentials: %w", err)
+			return err
+		}
+		polecat.Status.MergedCommit = mergedCommit
+		if len(polecat.Spec.Repositories) > 0 {
+			if err := r.applyPolecatStatus(ctx, polecat); err != nil {
+				return err
+			}
 		}
-		defer cleanup()
-		sshKeyPath = keyPath
 	}
 
-	// Create a temp directory for the clone
-	workDir, err := os.MkdirTemp("", "refinery-merge-*")
-	if err != nil {
-		return fmt.Errorf("failed to create temp dir: %w", err)
+	if err := r.mergeRepositories(ctx, refinery, polecat, sourceBranch, message); err != nil {
+		return err
 	}
-	defer func() { _ = os.RemoveAll(workDir) }() //nolint:errcheck // best-effort cleanup
 
-	repoDir := filepath.Join(workDir, "repo")
+	// Update polecat status to indicate merge complete
+	meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+		Type:               ConditionMerged,
+		Status:             metav1.ConditionTrue,
+		Reason:             "MergeComplete",
+		Message:            fmt.Sprintf("Branch %s merged to %s (commit: %s)", sourceBranch, targetBranch, polecat.Status.MergedCommit),
+		LastTransitionTime: metav1.Now(),
+	})
 
-	// Create git client using factory (allows test injection)
-	factory := r.GitClientFactory
-	if factory == nil {
-		factory = git.DefaultGitClientFactory
+	if err := r.applyPolecatStatus(ctx, polecat); err != nil {
+		return err
 	}
-	gitClient := factory(repoDir, gitURL, sshKeyPath)
 
-	// Clone the repository
-	log.Info("Cloning repository", "url", gitURL)
-	if err := gitClient.Clone(ctx); err != nil {
-		return fmt.Errorf("failed to clone repository: %w", err)
+	// The branch carries the work of the polecat whose conflicts it resolved
+	if err := r.markResolvedMerged(ctx, polecat, targetBranch, polecat.Status.MergedCommit); err != nil {
+		log.Error(err, "Failed to record merge on resolved polecat", "polecat", polecat.Name)
+	}
+
+	return nil
+}
+
+// mergePrimary merges sourceBranch into targetBranch of the rig's gitURL,
+// running the Refinery's testCommand unless expectedHead was already tested,
+// and returns the merged commit.
+func (r *RefineryReconciler) mergePrimary(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	sourceBranch, targetBranch, message, expectedHead string,
+) (string, error) {
+	log := logf.FromContext(ctx)
+
+	gitClient, cleanup, err := r.openRepository(ctx, refinery)
+	if err != nil {
+		return "", err
 	}
+	defer cleanup()
 
 	// Perform the merge
 	mergeOpts := git.MergeOptions{
@@ -295,100 +658,261 @@ func (r *RefineryReconciler) processMerge(
 		TargetBranch:       targetBranch,
 		TestCommand:        refinery.Spec.TestCommand,
 		DeleteSourceBranch: true,
+		Method:             string(mergeMethod(refinery)),
+		CommitMessage:      message,
+	}
+	if expectedHead != "" {
+		mergeOpts.TestCommand = ""
+		mergeOpts.ExpectedHead = expectedHead
 	}
 
 	log.Info("Executing merge workflow",
 		"sourceBranch", sourceBranch,
 		"targetBranch", targetBranch)
 
-	result, err := gitClient.MergeBranch(ctx, mergeOpts)
-	if err != nil {
-		return fmt.Errorf("merge failed: %w", err)
+	result, err := mergeWithRetries(ctx, gitClient, mergeOpts)
+	if err == nil && !result.Success {
+		err = errors.New(result.Error)
 	}
-
-	if !result.Success {
-		return fmt.Errorf("merge failed: %s", result.Error)
+	r.Audit.Record("refinery", audit.ActionMerge, polecat,
+		map[string]string{"sourceBranch": sourceBranch, "targetBranch": targetBranch}, err)
+	if err != nil {
+		return "", fmt.Errorf("merge failed: %w", err)
 	}
 
 	log.Info("Merge completed successfully",
 		"mergedCommit", result.MergedCommit,
 		"sourceBranch", sourceBranch,
 		"targetBranch", targetBranch)
+	return result.MergedCommit, nil
+}
 
-	// Update polecat status to indicate merge complete
-	meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
-		Type:               "Merged",
-		Status:             metav1.ConditionTrue,
-		Reason:             "MergeComplete",
-		Message:            fmt.Sprintf("Branch %s merged to %s (commit: %s)", sourceBranch, targetBranch, result.MergedCommit),
-		LastTransitionTime: metav1.Now(),
+// mergeCommitMessage renders the Refinery's commitMessageTemplate for the
+// polecat's branch. The title is the bead's, from its BeadStore, else the
+// first line of the polecat's task.
+func (r *RefineryReconciler) mergeCommitMessage(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	sourceBranch, targetBranch string,
+) (string, error) {
+	title, _, _ := strings.Cut(strings.TrimSpace(polecat.Spec.TaskDescription), "\n")
+	if polecat.Spec.BeadID != "" {
+		bead, err := lookupBead(ctx, r.Client, polecat.Namespace, polecat.Spec.Rig, polecat.Spec.BeadID)
+		if err != nil {
+			// Not fatal: the task titles the commit instead
+			logf.FromContext(ctx).Error(err, "Failed to look up bead", "bead", polecat.Spec.BeadID)
+		} else if bead != nil && bead.Title != "" {
+			title = bead.Title
+		}
+	}
+	message, err := git.RenderCommitMessage(refinery.Spec.CommitMessageTemplate, git.CommitMessageData{
+		BeadID:       polecat.Spec.BeadID,
+		Title:        title,
+		Polecat:      polecat.Name,
+		Branch:       sourceBranch,
+		TargetBranch: targetBranch,
 	})
+	if err != nil {
+		return "", fmt.Errorf("commitMessageTemplate: %w", err)
+	}
+	return message, nil
+}
 
-	if err := r.Status().Update(ctx, polecat); err != nil {
-		return err
+// openRepository clones the Rig's repository into a temporary directory.
+// The returned cleanup function removes the clone and any credential files.
+func (r *RefineryReconciler) openRepository(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+) (git.GitClient, func(), error) {
+	// Get the Rig to find the git URL
+	rig := &gastownv1alpha1.Rig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: refinery.Spec.RigRef}, rig); err != nil {
+		return nil, nil, fmt.Errorf("failed to get rig %s: %w", refinery.Spec.RigRef, err)
 	}
 
-	return nil
+	if rig.Spec.GitURL == "" {
+		return nil, nil, fmt.Errorf("rig %s has no gitURL", refinery.Spec.RigRef)
+	}
+	return r.cloneRepository(ctx, refinery, rig, rig.Spec.GitURL, refinery.Spec.GitSecretRef)
 }
 
-// setupGitCredentials extracts SSH key from secret and writes to temp file.
-// Returns the path to the key file and a cleanup function.
-func (r *RefineryReconciler) setupGitCredentials(
-	ctx context.Context, refinery *gastownv1alpha1.Refinery,
-) (string, func(), error) {
-	if refinery.Spec.GitSecretRef == nil {
-		return "", func() {}, nil
+// cloneRepository clones a repository of the rig with the credentials of
+// secretRef, or none, into a temporary directory. The returned cleanup
+// function removes the clone and any credential files.
+func (r *RefineryReconciler) cloneRepository(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, rig *gastownv1alpha1.Rig,
+	gitURL string, secretRef *gastownv1alpha1.SecretReference,
+) (git.GitClient, func(), error) {
+	log := logf.FromContext(ctx)
+
+	// Set up git credentials if specified
+	creds, credsCleanup, err := r.setupGitCredentials(ctx, refinery.Namespace, secretRef)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to setup git credentials: %w", err)
+	}
+	if creds.Token != "" {
+		// Tokens and passwords authenticate over HTTPS only
+		if gitURL, err = httpsGitURL(gitURL); err != nil {
+			credsCleanup()
+			return nil, nil, err
+		}
 	}
 
-	secret := &corev1.Secret{}
-	secretKey := types.NamespacedName{
-		Name:      refinery.Spec.GitSecretRef.Name,
-		Namespace: refinery.Namespace,
+	// Create a temp directory for the clone
+	workDir, err := os.MkdirTemp("", "refinery-merge-*")
+	if err != nil {
+		credsCleanup()
+		return nil, nil, fmt.Errorf("failed to create temp dir: %w", err)
+	}
+	cleanup := func() {
+		_ = os.RemoveAll(workDir) //nolint:errcheck // best-effort cleanup
+		credsCleanup()
 	}
 
-	if err := r.Get(ctx, secretKey, secret); err != nil {
-		return "", nil, fmt.Errorf("failed to get git secret %s: %w", secretKey, err)
+	repoDir := filepath.Join(workDir, "repo")
+
+	// Create git client using factory (allows test injection)
+	factory := r.GitClientFactory
+	if factory == nil {
+		factory = git.DefaultGitClientFactory
+	}
+	gitClient := factory(repoDir, gitURL, creds.SSHKeyPath)
+	if closer, ok := gitClient.(interface{ Cleanup() }); ok {
+		cleanup = func() {
+			closer.Cleanup()
+			_ = os.RemoveAll(workDir) //nolint:errcheck // best-effort cleanup
+			credsCleanup()
+		}
+	}
+	switch {
+	case creds.Username != "":
+		authenticator, ok := gitClient.(git.BasicAuthenticator)
+		if !ok {
+			cleanup()
+			return nil, nil, fmt.Errorf("git client does not support username and password authentication")
+		}
+		authenticator.SetBasicAuth(creds.Username, creds.Token)
+	case creds.Token != "":
+		authenticator, ok := gitClient.(git.TokenAuthenticator)
+		if !ok {
+			cleanup()
+			return nil, nil, fmt.Errorf("git client does not support token authentication")
+		}
+		authenticator.SetToken(creds.Token)
+	}
+	if rig.Spec.Signing != nil {
+		if err := r.setSigningKey(ctx, gitClient, refinery, rig.Spec.Signing); err != nil {
+			cleanup()
+			return nil, nil, err
+		}
+	}
+
+	// Clone the repository
+	log.Info("Cloning repository", "url", gitURL)
+	err = gitClient.Clone(ctx)
+	r.Audit.Record("refinery", audit.ActionClone, refinery, map[string]string{"repository": gitURL}, err)
+	if err != nil {
+		cleanup()
+		return nil, nil, fmt.Errorf("failed to clone repository: %w", err)
 	}
 
-	// Look for SSH key in common key names
-	var sshKey []byte
-	for _, keyName := range []string{"ssh-privatekey", "id_rsa", "id_ed25519", "identity"} {
-		if key, ok := secret.Data[keyName]; ok {
-			sshKey = key
+	return gitClient, cleanup, nil
+}
+
+// measureDiffSizes fills in missing diff sizes for the smallest-diff-first policy.
+// Sizes are cached in the queue entries, so a clone is only needed when new
+// branches enter the queue. Entries that cannot be measured keep a nil size
+// and sort last.
+func (r *RefineryReconciler) measureDiffSizes(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, queue []gastownv1alpha1.MergeQueueEntry,
+) {
+	log := logf.FromContext(ctx)
+
+	needed := false
+	for _, entry := range queue {
+		if entry.DiffSize == nil && entry.Branch != "" {
+			needed = true
 			break
 		}
 	}
-
-	if sshKey == nil {
-		return "", nil, fmt.Errorf("no SSH key found in secret %s", secretKey)
+	if !needed {
+		return
 	}
 
-	// Write key to temp file
-	keyFile, err := os.CreateTemp("", "git-ssh-key-*")
+	gitClient, cleanup, err := r.openRepository(ctx, refinery)
 	if err != nil {
-		return "", nil, fmt.Errorf("failed to create temp key file: %w", err)
+		log.Error(err, "Failed to open repository for diff sizing")
+		return
 	}
+	defer cleanup()
 
-	if _, err := keyFile.Write(sshKey); err != nil {
-		_ = os.Remove(keyFile.Name()) //nolint:errcheck // best-effort cleanup on error path
-		return "", nil, fmt.Errorf("failed to write SSH key: %w", err)
+	sizer, ok := gitClient.(git.DiffSizer)
+	if !ok {
+		return
 	}
 
-	if err := keyFile.Chmod(0o600); err != nil {
-		_ = os.Remove(keyFile.Name()) //nolint:errcheck // best-effort cleanup on error path
-		return "", nil, fmt.Errorf("failed to chmod SSH key: %w", err)
+	targetBranch := refinery.Spec.TargetBranch
+	if targetBranch == "" {
+		targetBranch = "main"
 	}
 
-	if err := keyFile.Close(); err != nil {
-		_ = os.Remove(keyFile.Name()) //nolint:errcheck // best-effort cleanup on error path
-		return "", nil, fmt.Errorf("failed to close SSH key file: %w", err)
+	for i := range queue {
+		if queue[i].DiffSize != nil || queue[i].Branch == "" {
+			continue
+		}
+		size, err := sizer.DiffSize(ctx, targetBranch, queue[i].Branch)
+		if err != nil {
+			log.Error(err, "Failed to measure diff size", "polecat", queue[i].Polecat)
+			continue
+		}
+		if size > math.MaxInt32 {
+			size = math.MaxInt32
+		}
+		diffSize := int32(size) // #nosec G115 -- bounds checked above
+		queue[i].DiffSize = &diffSize
 	}
+}
 
-	cleanup := func() {
-		_ = os.Remove(keyFile.Name()) //nolint:errcheck // best-effort cleanup
+// setupGitCredentials reads the git credentials of a secret in the
+// Refinery's namespace: an SSH key written to a temp file, a token or
+// password for HTTPS, or a GitHub App credential exchanged for a short-lived
+// installation token (cached and renewed before it expires).
+// Returns the credentials and a cleanup function.
+func (r *RefineryReconciler) setupGitCredentials(
+	ctx context.Context, namespace string, secretRef *gastownv1alpha1.SecretReference,
+) (gitCredentials, func(), error) {
+	if secretRef == nil {
+		return gitCredentials{}, func() {}, nil
 	}
 
-	return keyFile.Name(), cleanup, nil
+	return gitCredentialsFromSecret(ctx, r.Client, types.NamespacedName{
+		Name:      secretRef.Name,
+		Namespace: namespace,
+	}, r.AppTokens)
+}
+
+// setSigningKey has the git client sign with the rig's signing key, read from
+// the Secret of that name in the Refinery's namespace, and so refuse branches
+// that are not signed with it.
+func (r *RefineryReconciler) setSigningKey(
+	ctx context.Context, gitClient git.GitClient, refinery *gastownv1alpha1.Refinery,
+	signing *gastownv1alpha1.CommitSigningSpec,
+) error {
+	signer, ok := gitClient.(git.CommitSigner)
+	if !ok {
+		return fmt.Errorf("git client does not support commit signing")
+	}
+	secretKey := types.NamespacedName{Name: signing.KeySecretRef.Name, Namespace: refinery.Namespace}
+	secret := &corev1.Secret{}
+	if err := r.Get(ctx, secretKey, secret); err != nil {
+		return fmt.Errorf("failed to get signing key secret %s: %w", secretKey, err)
+	}
+	key, ok := secret.Data[pod.SigningKeyKey]
+	if !ok {
+		return fmt.Errorf("no %s found in secret %s", pod.SigningKeyKey, secretKey)
+	}
+	if err := signer.SetSigningKey(string(signing.Format), key); err != nil {
+		return fmt.Errorf("failed to set signing key: %w", err)
+	}
+	return nil
 }
 
 // setCondition updates or adds a condition to the Refinery status.
@@ -404,11 +928,13 @@ func (r *RefineryReconciler) setCondition(refinery *gastownv1alpha1.Refinery, co
 
 // SetupWithManager sets up the controller with the Manager.
 func (r *RefineryReconciler) SetupWithManager(mgr ctrl.Manager) error {
-	return ctrl.NewControllerManagedBy(mgr).
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&gastownv1alpha1.Refinery{}).
+		Owns(&corev1.Pod{}). // Test Pods
 		Named("refinery").
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 2, // Merges should be serialized per rig anyway
-		}).
-		Complete(r)
+		WithOptions(controllerOptions(r.Tuning, 2)) // Merges should be serialized per rig anyway
+	if r.Triggers != nil {
+		b = b.WatchesRawSource(source.Channel(r.Triggers, &handler.EnqueueRequestForObject{}))
+	}
+	return b.Complete(r)
 }
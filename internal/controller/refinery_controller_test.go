@@ -18,10 +18,19 @@ package controller
 
 import (
 	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
 	"k8s.io/client-go/tools/record"
@@ -55,6 +64,45 @@ func (m *mockGitClient) MergeBranch(ctx context.Context, opts git.MergeOptions)
 	}, nil
 }
 
+// movingTargetGitClient reports a moved target branch on the first merge
+// attempt it sees, then succeeds.
+type movingTargetGitClient struct {
+	attempts atomic.Int32
+}
+
+func (m *movingTargetGitClient) Clone(ctx context.Context) error {
+	return nil
+}
+
+func (m *movingTargetGitClient) MergeBranch(ctx context.Context, opts git.MergeOptions) (*git.MergeResult, error) {
+	if m.attempts.Add(1) == 1 {
+		return &git.MergeResult{Error: "push failed"}, git.ErrTargetMoved
+	}
+	return &git.MergeResult{Success: true, MergedCommit: "def456"}, nil
+}
+
+// checkedGitClient publishes branches for required checks. MergeBranch
+// reports a changed head when the expected head is not the published one.
+type checkedGitClient struct {
+	mockGitClient
+	head      string
+	published []git.MergeOptions
+	merged    []git.MergeOptions
+}
+
+func (m *checkedGitClient) PublishBranch(ctx context.Context, opts git.MergeOptions) (string, error) {
+	m.published = append(m.published, opts)
+	return m.head, nil
+}
+
+func (m *checkedGitClient) MergeBranch(ctx context.Context, opts git.MergeOptions) (*git.MergeResult, error) {
+	m.merged = append(m.merged, opts)
+	if opts.ExpectedHead != m.head {
+		return &git.MergeResult{Error: "head changed"}, git.ErrHeadChanged
+	}
+	return &git.MergeResult{Success: true, MergedCommit: m.head}, nil
+}
+
 var _ = Describe("Refinery Controller", func() {
 	Context("When reconciling a resource", func() {
 		const resourceName = "test-refinery"
@@ -312,6 +360,411 @@ var _ = Describe("Refinery Controller", func() {
 			ready := r.findMergeReadyPolecats(polecats)
 			Expect(ready).To(BeEmpty())
 		})
+
+		It("should not find planning polecats", func() {
+			r := &RefineryReconciler{}
+
+			polecats := &gastownv1alpha1.PolecatList{
+				Items: []gastownv1alpha1.Polecat{
+					{
+						ObjectMeta: metav1.ObjectMeta{Name: "furiosa-split"},
+						Spec:       gastownv1alpha1.PolecatSpec{SplitOf: "furiosa"},
+						Status: gastownv1alpha1.PolecatStatus{
+							Conditions: []metav1.Condition{
+								{
+									Type:   ConditionAvailable,
+									Status: metav1.ConditionTrue,
+									Reason: "WorkComplete",
+								},
+							},
+						},
+					},
+				},
+			}
+
+			Expect(r.findMergeReadyPolecats(polecats)).To(BeEmpty())
+		})
+	})
+
+	Context("When ordering the merge queue", func() {
+		readyPolecat := func(name string, readyAt time.Time, priority int32, mergeAfter ...string) gastownv1alpha1.Polecat {
+			return gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec: gastownv1alpha1.PolecatSpec{
+					MergePriority: priority,
+					MergeAfter:    mergeAfter,
+				},
+				Status: gastownv1alpha1.PolecatStatus{
+					Branch: "feature/" + name,
+					Conditions: []metav1.Condition{
+						{
+							Type:               ConditionAvailable,
+							Status:             metav1.ConditionTrue,
+							Reason:             "WorkComplete",
+							LastTransitionTime: metav1.NewTime(readyAt),
+						},
+					},
+				},
+			}
+		}
+		queueNames := func(queue []gastownv1alpha1.MergeQueueEntry) []string {
+			var names []string
+			for _, entry := range queue {
+				names = append(names, entry.Polecat)
+			}
+			return names
+		}
+		base := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+
+		It("should order by readiness time for fifo", func() {
+			ready := []gastownv1alpha1.Polecat{
+				readyPolecat("late", base.Add(2*time.Minute), 0),
+				readyPolecat("early", base, 10),
+				readyPolecat("middle", base.Add(time.Minute), 5),
+			}
+			all := &gastownv1alpha1.PolecatList{Items: ready}
+
+			queue := buildMergeQueue(ready, all, gastownv1alpha1.MergeQueuePolicyFIFO, nil)
+			Expect(queueNames(queue)).To(Equal([]string{"early", "middle", "late"}))
+		})
+
+		It("should order by priority then readiness for priority", func() {
+			ready := []gastownv1alpha1.Polecat{
+				readyPolecat("low", base, 1),
+				readyPolecat("high-late", base.Add(time.Minute), 10),
+				readyPolecat("high-early", base, 10),
+			}
+			all := &gastownv1alpha1.PolecatList{Items: ready}
+
+			queue := buildMergeQueue(ready, all, gastownv1alpha1.MergeQueuePolicyPriority, nil)
+			Expect(queueNames(queue)).To(Equal([]string{"high-early", "high-late", "low"}))
+		})
+
+		It("should order by diff size and put unmeasured branches last", func() {
+			small, large := int32(5), int32(500)
+			ready := []gastownv1alpha1.Polecat{
+				readyPolecat("unknown", base, 0),
+				readyPolecat("large", base, 0),
+				readyPolecat("small", base.Add(time.Minute), 0),
+			}
+			all := &gastownv1alpha1.PolecatList{Items: ready}
+			previous := []gastownv1alpha1.MergeQueueEntry{
+				{Polecat: "large", Branch: "feature/large", DiffSize: &large},
+				{Polecat: "small", Branch: "feature/small", DiffSize: &small},
+			}
+
+			queue := buildMergeQueue(ready, all, gastownv1alpha1.MergeQueuePolicySmallestDiffFirst, previous)
+			Expect(queueNames(queue)).To(Equal([]string{"small", "large", "unknown"}))
+		})
+
+		It("should block entries until mergeAfter dependencies are merged", func() {
+			merged := readyPolecat("merged-dep", base, 0)
+			merged.Status.Conditions = append(merged.Status.Conditions, metav1.Condition{
+				Type:               ConditionMerged,
+				Status:             metav1.ConditionTrue,
+				Reason:             "MergeComplete",
+				LastTransitionTime: metav1.NewTime(base),
+			})
+			ready := []gastownv1alpha1.Polecat{
+				readyPolecat("waits", base, 0, "pending-dep"),
+				readyPolecat("pending-dep", base.Add(time.Minute), 0),
+				readyPolecat("unblocked", base.Add(2*time.Minute), 0, "merged-dep"),
+				merged,
+			}
+			all := &gastownv1alpha1.PolecatList{Items: ready}
+
+			queue := buildMergeQueue(ready, all, gastownv1alpha1.MergeQueuePolicyFIFO, nil)
+			Expect(queueNames(queue)).To(Equal([]string{"pending-dep", "unblocked", "waits"}))
+			Expect(queue[2].BlockedBy).To(Equal([]string{"pending-dep"}))
+			Expect(nextMergeCandidates(queue, 1, time.Now())).To(Equal([]int{0}))
+			Expect(nextMergeCandidates(queue, 3, time.Now())).To(Equal([]int{0, 1}))
+		})
+
+		It("should report no candidate when every entry is blocked", func() {
+			ready := []gastownv1alpha1.Polecat{
+				readyPolecat("a", base, 0, "missing"),
+			}
+			all := &gastownv1alpha1.PolecatList{Items: ready}
+
+			queue := buildMergeQueue(ready, all, gastownv1alpha1.MergeQueuePolicyFIFO, nil)
+			Expect(nextMergeCandidates(queue, 2, time.Now())).To(BeEmpty())
+		})
+
+		It("should replace conflicted branches by their resolver until it fails", func() {
+			resolver := readyPolecat("conflicted-resolve", base.Add(time.Minute), 0)
+			resolver.Labels = map[string]string{ConflictResolverLabel: "conflicted"}
+			resolver.Status.Phase = gastownv1alpha1.PolecatPhaseDone
+			ready := []gastownv1alpha1.Polecat{readyPolecat("conflicted", base, 0), resolver}
+			all := &gastownv1alpha1.PolecatList{Items: ready}
+
+			queue := buildMergeQueue(ready, all, gastownv1alpha1.MergeQueuePolicyFIFO, nil)
+			Expect(queueNames(queue)).To(Equal([]string{"conflicted-resolve"}))
+
+			all.Items[1].Status.Phase = gastownv1alpha1.PolecatPhaseStuck
+			queue = buildMergeQueue(ready[:1], all, gastownv1alpha1.MergeQueuePolicyFIFO, nil)
+			Expect(queueNames(queue)).To(Equal([]string{"conflicted"}))
+		})
+	})
+
+	Context("When resolving conflicts", func() {
+		It("should work on the conflicted branch with a resolution task", func() {
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "furiosa",
+					Namespace: "default",
+					Labels:    map[string]string{"gastown.io/rig": "war-rig"},
+				},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:             "war-rig",
+					BeadID:          "wr-7",
+					DesiredState:    gastownv1alpha1.PolecatDesiredTerminated,
+					TaskDescription: "Add the nitro button",
+					MergeAfter:      []string{"nux"},
+					Kubernetes: &gastownv1alpha1.KubernetesSpec{
+						GitRepository: "git@github.com:test/repo.git",
+						GitBranch:     "main",
+					},
+				},
+				Status: gastownv1alpha1.PolecatStatus{Branch: "feature/wr-7"},
+			}
+
+			resolver := conflictResolver(polecat, "main")
+			Expect(resolver.Name).To(Equal("furiosa-resolve"))
+			Expect(resolver.Labels).To(HaveKeyWithValue("gastown.io/rig", "war-rig"))
+			Expect(resolver.Labels).To(HaveKeyWithValue(ConflictResolverLabel, "furiosa"))
+			Expect(resolver.Spec.DesiredState).To(Equal(gastownv1alpha1.PolecatDesiredWorking))
+			Expect(resolver.Spec.MergeAfter).To(BeEmpty())
+			Expect(resolver.Spec.Kubernetes.GitBranch).To(Equal("feature/wr-7"))
+			Expect(resolver.Spec.Kubernetes.WorkBranch).To(Equal("feature/wr-7"))
+			Expect(resolver.Spec.TaskDescription).To(HavePrefix("Resolve rebase conflicts between feature/wr-7 and main\n"))
+			Expect(resolver.Spec.TaskDescription).To(ContainSubstring("git push --force-with-lease origin feature/wr-7"))
+			Expect(resolver.Spec.TaskDescription).To(ContainSubstring("Add the nitro button"))
+
+			// The original is left alone
+			Expect(polecat.Spec.Kubernetes.GitBranch).To(Equal("main"))
+			Expect(polecat.Labels).NotTo(HaveKey(ConflictResolverLabel))
+		})
+	})
+
+	Context("When batching merges", func() {
+		base := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+		entry := func(name string, ready time.Time) gastownv1alpha1.MergeQueueEntry {
+			t := metav1.NewTime(ready)
+			return gastownv1alpha1.MergeQueueEntry{Polecat: name, Branch: "polecat/" + name, ReadySince: &t}
+		}
+		every30m := &gastownv1alpha1.MergeBatchSpec{Schedule: "*/30 * * * *"}
+
+		It("should land each branch in the first batch after it became ready", func() {
+			queue := []gastownv1alpha1.MergeQueueEntry{
+				entry("early", base.Add(5*time.Minute)),
+				entry("late", base.Add(35*time.Minute)),
+			}
+			now := base.Add(40 * time.Minute)
+
+			next, err := assignMergeBatches(queue, every30m, now)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(next).To(BeTemporally("==", base.Add(time.Hour)))
+			Expect(queue[0].BatchTime.Time).To(BeTemporally("==", base.Add(30*time.Minute)))
+			Expect(queue[1].BatchTime.Time).To(BeTemporally("==", base.Add(time.Hour)))
+
+			// Only the landed batch is merged, in queue order
+			Expect(nextMergeCandidates(queue, 2, now)).To(Equal([]int{0}))
+			Expect(countWaitingForBatch(queue, now)).To(Equal(1))
+			Expect(nextMergeCandidates(queue, 2, next)).To(Equal([]int{0, 1}))
+		})
+
+		It("should evaluate the schedule in its time zone", func() {
+			queue := []gastownv1alpha1.MergeQueueEntry{entry("a", base)}
+			daily := &gastownv1alpha1.MergeBatchSpec{Schedule: "0 9 * * *", TimeZone: "Europe/Berlin"}
+
+			next, err := assignMergeBatches(queue, daily, base)
+			Expect(err).NotTo(HaveOccurred())
+			// 09:00 in Berlin is 08:00 UTC in winter
+			Expect(next.UTC()).To(Equal(time.Date(2026, 1, 2, 8, 0, 0, 0, time.UTC)))
+		})
+
+		It("should reject invalid schedules", func() {
+			_, err := assignMergeBatches(nil, &gastownv1alpha1.MergeBatchSpec{Schedule: "every 30 minutes"}, base)
+			Expect(err).To(HaveOccurred())
+			_, err = assignMergeBatches(nil, &gastownv1alpha1.MergeBatchSpec{Schedule: "0 0 30 2 *"}, base)
+			Expect(err).To(MatchError(ContainSubstring("never fires")))
+			_, err = assignMergeBatches(nil, &gastownv1alpha1.MergeBatchSpec{Schedule: "*/30 * * * *", TimeZone: "Mars/Olympus"}, base)
+			Expect(err).To(MatchError(ContainSubstring("unknown batch time zone")))
+		})
+	})
+
+	Context("When recording merge history", func() {
+		started := time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC)
+		lane := gastownv1alpha1.ActiveMerge{Polecat: "p", StartedAt: &metav1.Time{Time: started}}
+		newPolecat := func(name string) *gastownv1alpha1.Polecat {
+			polecat := &gastownv1alpha1.Polecat{ObjectMeta: metav1.ObjectMeta{Name: name}}
+			polecat.Status.Branch = "polecat/" + name
+			return polecat
+		}
+
+		It("should record the commit of a landed merge and the reason of a failed one", func() {
+			refinery := &gastownv1alpha1.Refinery{}
+			landed := newPolecat("landed")
+			landed.Status.MergedCommit = "abc123"
+			appendMergeHistory(refinery, landed, lane, nil, started.Add(90*time.Second))
+			appendMergeHistory(refinery, newPolecat("conflicted"), lane,
+				fmt.Errorf("merge failed: rebase failed"), started.Add(time.Minute))
+
+			Expect(refinery.Status.MergeHistory).To(HaveLen(2))
+			first := refinery.Status.MergeHistory[0]
+			Expect(first.Polecat).To(Equal("landed"))
+			Expect(first.Branch).To(Equal("polecat/landed"))
+			Expect(first.Result).To(Equal(gastownv1alpha1.MergeResultSucceeded))
+			Expect(first.Commit).To(Equal("abc123"))
+			Expect(first.Method).To(Equal(gastownv1alpha1.MergeMethodRebase))
+			Expect(first.Duration.Duration).To(Equal(90 * time.Second))
+			Expect(first.Reason).To(BeEmpty())
+
+			second := refinery.Status.MergeHistory[1]
+			Expect(second.Result).To(Equal(gastownv1alpha1.MergeResultFailed))
+			Expect(second.Commit).To(BeEmpty())
+			Expect(second.Method).To(BeEmpty())
+			Expect(second.Reason).To(Equal("merge failed: rebase failed"))
+		})
+
+		It("should record the merge method the branch landed with", func() {
+			refinery := &gastownv1alpha1.Refinery{}
+			refinery.Spec.MergeMethod = gastownv1alpha1.MergeMethodSquash
+			Expect(mergeMethod(refinery)).To(Equal(gastownv1alpha1.MergeMethodSquash))
+
+			// Pull requests land with their auto-merge method, or by hand
+			refinery.Spec.MergeStrategy = gastownv1alpha1.MergeStrategyPullRequest
+			Expect(mergeMethod(refinery)).To(BeEmpty())
+			refinery.Spec.PullRequest = &gastownv1alpha1.PullRequestSpec{AutoMerge: true}
+			Expect(mergeMethod(refinery)).To(Equal(gastownv1alpha1.MergeMethodSquash))
+			refinery.Spec.PullRequest.MergeMethod = gastownv1alpha1.PullRequestMergeMethodMerge
+			Expect(mergeMethod(refinery)).To(Equal(gastownv1alpha1.MergeMethodMerge))
+		})
+
+		It("should keep only the most recent attempts", func() {
+			refinery := &gastownv1alpha1.Refinery{}
+			for i := range mergeHistoryLimit + 5 {
+				appendMergeHistory(refinery, newPolecat(fmt.Sprintf("p%d", i)), lane, nil, started)
+			}
+			Expect(refinery.Status.MergeHistory).To(HaveLen(mergeHistoryLimit))
+			Expect(refinery.Status.MergeHistory[0].Polecat).To(Equal("p5"))
+			Expect(refinery.Status.MergeHistory[mergeHistoryLimit-1].Polecat).To(Equal(fmt.Sprintf("p%d", mergeHistoryLimit+4)))
+		})
+
+		It("should truncate long failure reasons", func() {
+			refinery := &gastownv1alpha1.Refinery{}
+			appendMergeHistory(refinery, newPolecat("p"), lane,
+				fmt.Errorf("tests failed: %s", strings.Repeat("x", 2*mergeReasonMaxLength)), started)
+			Expect(refinery.Status.MergeHistory[0].Reason).To(HaveLen(mergeReasonMaxLength + len("...")))
+		})
+	})
+
+	Context("When deciding whether to release", func() {
+		earlier := metav1.NewTime(time.Date(2026, 1, 1, 10, 0, 0, 0, time.UTC))
+		later := metav1.NewTime(earlier.Add(time.Hour))
+
+		newRefinery := func(release *gastownv1alpha1.ReleaseSpec) *gastownv1alpha1.Refinery {
+			return &gastownv1alpha1.Refinery{
+				Spec: gastownv1alpha1.RefinerySpec{RigRef: "test-rig", Release: release},
+			}
+		}
+
+		It("should not release when no release is configured", func() {
+			refinery := newRefinery(nil)
+			refinery.Status.LastMergeTime = &later
+			Expect(releaseDue(refinery)).To(BeFalse())
+		})
+
+		It("should not release before anything has merged", func() {
+			Expect(releaseDue(newRefinery(&gastownv1alpha1.ReleaseSpec{}))).To(BeFalse())
+		})
+
+		It("should release the first merged batch", func() {
+			refinery := newRefinery(&gastownv1alpha1.ReleaseSpec{})
+			refinery.Status.LastMergeTime = &earlier
+			Expect(releaseDue(refinery)).To(BeTrue())
+		})
+
+		It("should release only when a merge landed after the last release", func() {
+			refinery := newRefinery(&gastownv1alpha1.ReleaseSpec{})
+			refinery.Status.LastMergeTime = &earlier
+			refinery.Status.LastRelease = &gastownv1alpha1.ReleaseStatus{Tag: "v0.1.0", Time: &later}
+			Expect(releaseDue(refinery)).To(BeFalse())
+
+			refinery.Status.LastMergeTime = &metav1.Time{Time: later.Add(time.Minute)}
+			Expect(releaseDue(refinery)).To(BeTrue())
+		})
+	})
+
+	Context("When describing pull requests", func() {
+		It("should title pull requests after the bead and task", func() {
+			polecat := &gastownv1alpha1.Polecat{
+				Spec: gastownv1alpha1.PolecatSpec{
+					BeadID:          "gt-7",
+					TaskDescription: "Fix the flux capacitor\n\nDetails follow.",
+				},
+				Status: gastownv1alpha1.PolecatStatus{Branch: "polecat/gt-7"},
+			}
+			Expect(pullRequestTitle(polecat)).To(Equal("gt-7: Fix the flux capacitor"))
+
+			polecat.Spec.TaskDescription = ""
+			Expect(pullRequestTitle(polecat)).To(Equal("gt-7"))
+
+			polecat.Spec.BeadID = ""
+			Expect(pullRequestTitle(polecat)).To(Equal("Merge polecat/gt-7"))
+		})
+
+		It("should map checks to the ChecksPassed condition", func() {
+			now := metav1.Now()
+			Expect(checksCondition(&git.ChecksStatus{State: git.ChecksSuccess, Total: 2, Completed: 2}, now).Status).
+				To(Equal(metav1.ConditionTrue))
+			Expect(checksCondition(&git.ChecksStatus{State: git.ChecksPending, Total: 2, Completed: 1}, now).Status).
+				To(Equal(metav1.ConditionUnknown))
+
+			failed := checksCondition(&git.ChecksStatus{State: git.ChecksFailure, Total: 2, Completed: 2, Failed: 1}, now)
+			Expect(failed.Status).To(Equal(metav1.ConditionFalse))
+			Expect(failed.Message).To(Equal("1 of 2 checks failed"))
+		})
+	})
+
+	Context("When reading test pods", func() {
+		It("should name test pods after the refinery and commit", func() {
+			refinery := &gastownv1alpha1.Refinery{ObjectMeta: metav1.ObjectMeta{Name: "refinery"}}
+			Expect(testPodName(refinery, "c0ffee")).To(Equal("refinery-test-c0ffee"))
+			Expect(testPodName(refinery, "0123456789abcdef0123")).To(Equal("refinery-test-0123456789ab"))
+		})
+
+		It("should explain why a test pod failed", func() {
+			failed := &corev1.Pod{
+				ObjectMeta: metav1.ObjectMeta{Name: "refinery-test-c0ffee"},
+				Status: corev1.PodStatus{
+					Phase: corev1.PodFailed,
+					ContainerStatuses: []corev1.ContainerStatus{{
+						Name: "test",
+						State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+							ExitCode: 2,
+							Message:  "--- FAIL: TestLogin\nFAIL\n",
+						}},
+					}},
+				},
+			}
+			Expect(testPodFailure(failed, "c0ffee")).To(MatchError(
+				"tests failed on c0ffee (exit code 2): --- FAIL: TestLogin\nFAIL"))
+
+			failed.Status.InitContainerStatuses = []corev1.ContainerStatus{{
+				Name: "checkout",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode: 1,
+					Message:  "branch feature/x is at beef, expected c0ffee",
+				}},
+			}}
+			Expect(testPodFailure(failed, "c0ffee")).To(MatchError(
+				"checkout of c0ffee failed: branch feature/x is at beef, expected c0ffee"))
+
+			failed.Status.Reason = "DeadlineExceeded"
+			Expect(testPodFailure(failed, "c0ffee")).To(MatchError(
+				"tests on c0ffee exceeded the deadline of pod refinery-test-c0ffee"))
+		})
 	})
 
 	Context("When processing merges", func() {
@@ -410,6 +863,9 @@ var _ = Describe("Refinery Controller", func() {
 			Expect(updatedRefinery.Status.MergesSummary.Succeeded).To(Equal(int32(1)))
 			Expect(updatedRefinery.Status.CurrentMerge).To(Equal("merge-ready-polecat"))
 			Expect(updatedRefinery.Status.LastMergeTime).NotTo(BeNil())
+			Expect(updatedRefinery.Status.MergeHistory).To(HaveLen(1))
+			Expect(updatedRefinery.Status.MergeHistory[0].Polecat).To(Equal("merge-ready-polecat"))
+			Expect(updatedRefinery.Status.MergeHistory[0].Result).To(Equal(gastownv1alpha1.MergeResultSucceeded))
 
 			// Verify polecat got Merged condition
 			var updatedPolecat gastownv1alpha1.Polecat
@@ -433,80 +889,152 @@ var _ = Describe("Refinery Controller", func() {
 			Expect(k8sClient.Delete(ctx, polecat)).To(Succeed())
 		})
 
-		It("should handle non-existent refinery gracefully", func() {
+		It("should hold merge-ready branches until their batch lands", func() {
 			ctx := context.Background()
 
+			rig := &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "batch-test-rig"},
+				Spec: gastownv1alpha1.RigSpec{
+					GitURL:      "git@github.com:test/repo.git",
+					BeadsPrefix: "batch",
+				},
+			}
+			Expect(k8sClient.Create(ctx, rig)).To(Succeed())
+
+			refinery := &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{Name: "batch-test-refinery", Namespace: "default"},
+				Spec: gastownv1alpha1.RefinerySpec{
+					RigRef:       "batch-test-rig",
+					TargetBranch: "main",
+					Parallelism:  1,
+					// Yearly, so the branch below waits
+					Batch: &gastownv1alpha1.MergeBatchSpec{Schedule: "0 0 1 1 *"},
+				},
+			}
+			Expect(k8sClient.Create(ctx, refinery)).To(Succeed())
+
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "batch-ready-polecat",
+					Namespace: "default",
+					Labels:    map[string]string{"gastown.io/rig": "batch-test-rig"},
+				},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:          "batch-test-rig",
+					DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+					BeadID:       "batch-1",
+				},
+			}
+			Expect(k8sClient.Create(ctx, polecat)).To(Succeed())
+			polecat.Status.Phase = gastownv1alpha1.PolecatPhaseDone
+			polecat.Status.Branch = "feature/batch-1"
+			polecat.Status.Conditions = []metav1.Condition{{
+				Type:               "Available",
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "Polecat completed work",
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(k8sClient.Status().Update(ctx, polecat)).To(Succeed())
+
 			controllerReconciler := &RefineryReconciler{
 				Client:   k8sClient,
 				Scheme:   k8sClient.Scheme(),
 				Recorder: record.NewFakeRecorder(10),
+				GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+					Fail("no merge is expected before the batch lands")
+					return nil
+				},
 			}
+			key := types.NamespacedName{Name: refinery.Name, Namespace: refinery.Namespace}
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{NamespacedName: key})
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(BeNumerically(">", 0))
 
-			// Reconcile non-existent refinery
-			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
-				NamespacedName: types.NamespacedName{
-					Name:      "non-existent-refinery",
-					Namespace: "default",
-				},
-			})
+			var updated gastownv1alpha1.Refinery
+			Expect(k8sClient.Get(ctx, key, &updated)).To(Succeed())
+			Expect(updated.Status.MergesSummary.Succeeded).To(BeZero())
+			Expect(updated.Status.NextBatchTime).NotTo(BeNil())
+			Expect(updated.Status.Queue).To(HaveLen(1))
+			Expect(updated.Status.Queue[0].BatchTime.Time).To(BeTemporally("==", updated.Status.NextBatchTime.Time))
+			cond := meta.FindStatusCondition(updated.Status.Conditions, RefineryConditionReady)
+			Expect(cond.Reason).To(Equal("WaitingForBatch"))
 
-			// Should not error, just return empty result
-			Expect(err).NotTo(HaveOccurred())
-			Expect(result).To(Equal(reconcile.Result{}))
+			Expect(k8sClient.Delete(ctx, rig)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, refinery)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, polecat)).To(Succeed())
 		})
 
-		It("should requeue quickly when queue has pending items", func() {
+		It("should merge in parallel lanes and retry when the target moves", func() {
 			ctx := context.Background()
 
-			// Create a refinery
+			rig := &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{
+					Name: "lanes-test-rig",
+				},
+				Spec: gastownv1alpha1.RigSpec{
+					GitURL:      "git@github.com:test/repo.git",
+					BeadsPrefix: "lanes",
+				},
+			}
+			Expect(k8sClient.Create(ctx, rig)).To(Succeed())
+
 			refinery := &gastownv1alpha1.Refinery{
 				ObjectMeta: metav1.ObjectMeta{
-					Name:      "queue-test-refinery",
+					Name:      "lanes-test-refinery",
 					Namespace: "default",
 				},
 				Spec: gastownv1alpha1.RefinerySpec{
-					RigRef:       "queue-rig",
+					RigRef:       "lanes-test-rig",
 					TargetBranch: "main",
-					Parallelism:  1,
+					Parallelism:  2,
 				},
 			}
 			Expect(k8sClient.Create(ctx, refinery)).To(Succeed())
 
-			// Create multiple merge-ready polecats
-			for i := 1; i <= 3; i++ {
+			names := []string{"lane-polecat-a", "lane-polecat-b"}
+			for _, name := range names {
 				polecat := &gastownv1alpha1.Polecat{
 					ObjectMeta: metav1.ObjectMeta{
-						Name:      "queue-polecat-" + string(rune('0'+i)),
+						Name:      name,
 						Namespace: "default",
 						Labels: map[string]string{
-							"gastown.io/rig": "queue-rig",
+							"gastown.io/rig": "lanes-test-rig",
 						},
 					},
 					Spec: gastownv1alpha1.PolecatSpec{
-						Rig:          "queue-rig",
+						Rig:          "lanes-test-rig",
 						DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+						BeadID:       name,
 					},
 				}
 				Expect(k8sClient.Create(ctx, polecat)).To(Succeed())
 
+				polecat.Status.Phase = gastownv1alpha1.PolecatPhaseDone
+				polecat.Status.Branch = "feature/" + name
 				polecat.Status.Conditions = []metav1.Condition{
 					{
 						Type:               "Available",
 						Status:             metav1.ConditionTrue,
 						Reason:             "Ready",
+						Message:            "Polecat completed work",
 						LastTransitionTime: metav1.Now(),
 					},
 				}
 				Expect(k8sClient.Status().Update(ctx, polecat)).To(Succeed())
 			}
 
+			mockClient := &movingTargetGitClient{}
 			controllerReconciler := &RefineryReconciler{
 				Client:   k8sClient,
 				Scheme:   k8sClient.Scheme(),
 				Recorder: record.NewFakeRecorder(10),
+				GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+					return mockClient
+				},
 			}
 
-			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+			_, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Name:      refinery.Name,
 					Namespace: refinery.Namespace,
@@ -514,19 +1042,820 @@ var _ = Describe("Refinery Controller", func() {
 			})
 			Expect(err).NotTo(HaveOccurred())
 
-			// Should requeue at processing interval since there's more work
-			Expect(result.RequeueAfter).To(Equal(refineryProcessingRequeueInterval))
+			// Both lanes merged; one of them needed a second attempt
+			Expect(mockClient.attempts.Load()).To(Equal(int32(3)))
+
+			var updatedRefinery gastownv1alpha1.Refinery
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      refinery.Name,
+				Namespace: refinery.Namespace,
+			}, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.MergesSummary.Succeeded).To(Equal(int32(2)))
+			Expect(updatedRefinery.Status.MergesSummary.Failed).To(Equal(int32(0)))
+			Expect(updatedRefinery.Status.ActiveMerges).To(BeEmpty())
 
 			// Cleanup
 			Expect(k8sClient.Delete(ctx, refinery)).To(Succeed())
-			for i := 1; i <= 3; i++ {
+			Expect(k8sClient.Delete(ctx, rig)).To(Succeed())
+			for _, name := range names {
 				polecat := &gastownv1alpha1.Polecat{}
-				_ = k8sClient.Get(ctx, types.NamespacedName{
-					Name:      "queue-polecat-" + string(rune('0'+i)),
-					Namespace: "default",
-				}, polecat)
+				_ = k8sClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, polecat)
 				_ = k8sClient.Delete(ctx, polecat)
 			}
 		})
+
+		It("should open a pull request and track it until merged", func() {
+			ctx := context.Background()
+
+			// Fake GitHub: no pull request until one is created, merged once flagged
+			var mu sync.Mutex
+			var created, merged bool
+			github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				pr := `{"number":42,"html_url":"https://github.com/test/repo/pull/42","node_id":"PR_42",` +
+					`"state":"open","merged_at":null,"auto_merge":null,"head":{"sha":"h3ad"}}`
+				if merged {
+					pr = `{"number":42,"html_url":"https://github.com/test/repo/pull/42","node_id":"PR_42",` +
+						`"state":"closed","merged_at":"2026-01-01T00:00:00Z","merge_commit_sha":"m3rg3d","head":{"sha":"h3ad"}}`
+				}
+				switch {
+				case r.Method == http.MethodGet && r.URL.Path == "/repos/test/repo/pulls":
+					if !created {
+						_, _ = w.Write([]byte(`[]`))
+						return
+					}
+					_, _ = w.Write([]byte("[" + pr + "]"))
+				case r.Method == http.MethodPost && r.URL.Path == "/repos/test/repo/pulls":
+					created = true
+					w.WriteHeader(http.StatusCreated)
+					_, _ = w.Write([]byte(pr))
+				case r.URL.Path == "/repos/test/repo/commits/h3ad/check-runs":
+					_, _ = w.Write([]byte(`{"check_runs":[{"status":"in_progress"}]}`))
+				case r.URL.Path == "/repos/test/repo/commits/h3ad/status":
+					_, _ = w.Write([]byte(`{"statuses":[]}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer github.Close()
+
+			rig := &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "pr-test-rig"},
+				Spec: gastownv1alpha1.RigSpec{
+					GitURL:      "git@github.com:test/repo.git",
+					BeadsPrefix: "pr",
+				},
+			}
+			Expect(k8sClient.Create(ctx, rig)).To(Succeed())
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "pr-test-token", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("secret")},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			refinery := &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pr-test-refinery",
+					Namespace: "default",
+				},
+				Spec: gastownv1alpha1.RefinerySpec{
+					RigRef:               "pr-test-rig",
+					TargetBranch:         "main",
+					Parallelism:          1,
+					MergeStrategy:        gastownv1alpha1.MergeStrategyPullRequest,
+					GitHubTokenSecretRef: &gastownv1alpha1.SecretKeyRef{Name: "pr-test-token", Key: "token"},
+					PullRequest:          &gastownv1alpha1.PullRequestSpec{GitHubAPIURL: github.URL},
+				},
+			}
+			Expect(k8sClient.Create(ctx, refinery)).To(Succeed())
+
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "pr-polecat",
+					Namespace: "default",
+					Labels:    map[string]string{"gastown.io/rig": "pr-test-rig"},
+				},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:          "pr-test-rig",
+					DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+					BeadID:       "pr-1",
+				},
+			}
+			Expect(k8sClient.Create(ctx, polecat)).To(Succeed())
+			polecat.Status.Branch = "feature/pr-polecat"
+			polecat.Status.Conditions = []metav1.Condition{{
+				Type:               ConditionAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "Polecat completed work",
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(k8sClient.Status().Update(ctx, polecat)).To(Succeed())
+
+			controllerReconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+				Identity: "gastown-operator-a",
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      refinery.Name,
+				Namespace: refinery.Namespace,
+			}}
+			polecatKey := types.NamespacedName{Name: polecat.Name, Namespace: "default"}
+
+			By("opening the pull request")
+			result, err := controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(refineryPullRequestRequeueInterval))
+
+			var updated gastownv1alpha1.Polecat
+			Expect(k8sClient.Get(ctx, polecatKey, &updated)).To(Succeed())
+			prCond := meta.FindStatusCondition(updated.Status.Conditions, ConditionPullRequest)
+			Expect(prCond).NotTo(BeNil())
+			Expect(prCond.Reason).To(Equal("Open"))
+			Expect(prCond.Message).To(ContainSubstring("#42"))
+			Expect(prCond.Message).To(ContainSubstring("https://github.com/test/repo/pull/42"))
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, ConditionChecksPassed).Status).
+				To(Equal(metav1.ConditionUnknown))
+
+			var updatedRefinery gastownv1alpha1.Refinery
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.ActiveMerges).To(HaveLen(1))
+			Expect(updatedRefinery.Status.ActiveMerges[0].Holder).To(Equal("gastown-operator-a"))
+			startedAt := updatedRefinery.Status.ActiveMerges[0].StartedAt
+
+			By("resuming the lane on a new leader")
+			recorder := record.NewFakeRecorder(10)
+			newLeader := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				Identity: "gastown-operator-b",
+			}
+			_, err = newLeader.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).To(Receive(ContainSubstring("MergeResumed")))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.ActiveMerges).To(HaveLen(1))
+			Expect(updatedRefinery.Status.ActiveMerges[0].Holder).To(Equal("gastown-operator-b"))
+			Expect(updatedRefinery.Status.ActiveMerges[0].StartedAt).To(Equal(startedAt))
+
+			By("recording the merge once the pull request merges")
+			mu.Lock()
+			merged = true
+			mu.Unlock()
+			_, err = newLeader.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			Expect(k8sClient.Get(ctx, polecatKey, &updated)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionMerged)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.MergesSummary.Succeeded).To(Equal(int32(1)))
+			Expect(updatedRefinery.Status.ActiveMerges).To(BeEmpty())
+
+			// Cleanup
+			Expect(k8sClient.Delete(ctx, refinery)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, polecat)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, rig)).To(Succeed())
+		})
+
+		It("should push only heads whose required checks passed", func() {
+			ctx := context.Background()
+
+			// Fake GitHub: the required "ci" check stays pending until flagged
+			var mu sync.Mutex
+			var passed bool
+			github := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				mu.Lock()
+				defer mu.Unlock()
+				switch r.URL.Path {
+				case "/repos/test/repo/commits/c0ffee/check-runs":
+					run := `{"name":"ci","status":"in_progress"}`
+					if passed {
+						run = `{"name":"ci","status":"completed","conclusion":"success"}`
+					}
+					_, _ = w.Write([]byte(`{"check_runs":[` + run + `,{"name":"lint","status":"queued"}]}`))
+				case "/repos/test/repo/commits/c0ffee/status":
+					_, _ = w.Write([]byte(`{"statuses":[]}`))
+				default:
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer github.Close()
+
+			rig := &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "checks-test-rig"},
+				Spec: gastownv1alpha1.RigSpec{
+					GitURL:      "git@github.com:test/repo.git",
+					BeadsPrefix: "chk",
+				},
+			}
+			Expect(k8sClient.Create(ctx, rig)).To(Succeed())
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "checks-test-token", Namespace: "default"},
+				Data:       map[string][]byte{"token": []byte("secret")},
+			}
+			Expect(k8sClient.Create(ctx, secret)).To(Succeed())
+
+			refinery := &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "checks-test-refinery",
+					Namespace: "default",
+				},
+				Spec: gastownv1alpha1.RefinerySpec{
+					RigRef:               "checks-test-rig",
+					TargetBranch:         "main",
+					TestCommand:          "make test",
+					Parallelism:          1,
+					RequiredChecks:       []string{"ci"},
+					GitHubTokenSecretRef: &gastownv1alpha1.SecretKeyRef{Name: "checks-test-token", Key: "token"},
+					PullRequest:          &gastownv1alpha1.PullRequestSpec{GitHubAPIURL: github.URL},
+				},
+			}
+			Expect(k8sClient.Create(ctx, refinery)).To(Succeed())
+
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "checks-polecat",
+					Namespace: "default",
+					Labels:    map[string]string{"gastown.io/rig": "checks-test-rig"},
+				},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:          "checks-test-rig",
+					DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+					BeadID:       "chk-1",
+				},
+			}
+			Expect(k8sClient.Create(ctx, polecat)).To(Succeed())
+			polecat.Status.Branch = "feature/checks-polecat"
+			polecat.Status.Conditions = []metav1.Condition{{
+				Type:               ConditionAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "Polecat completed work",
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(k8sClient.Status().Update(ctx, polecat)).To(Succeed())
+
+			mockClient := &checkedGitClient{head: "c0ffee"}
+			controllerReconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+				GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+					return mockClient
+				},
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      refinery.Name,
+				Namespace: refinery.Namespace,
+			}}
+			polecatKey := types.NamespacedName{Name: polecat.Name, Namespace: "default"}
+
+			By("publishing the rebased branch")
+			result, err := controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(refineryPullRequestRequeueInterval))
+			Expect(mockClient.published).To(HaveLen(1))
+			Expect(mockClient.published[0].TestCommand).To(Equal("make test"))
+			Expect(mockClient.merged).To(BeEmpty())
+
+			var updatedRefinery gastownv1alpha1.Refinery
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.ActiveMerges).To(HaveLen(1))
+			Expect(updatedRefinery.Status.ActiveMerges[0].CheckedCommit).To(Equal("c0ffee"))
+
+			By("waiting while the required check is pending")
+			_, err = controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockClient.published).To(HaveLen(1))
+			Expect(mockClient.merged).To(BeEmpty())
+
+			var updated gastownv1alpha1.Polecat
+			Expect(k8sClient.Get(ctx, polecatKey, &updated)).To(Succeed())
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, ConditionChecksPassed).Status).
+				To(Equal(metav1.ConditionUnknown))
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.WaitingOnChecks).To(ConsistOf("ci"))
+
+			By("merging the checked head once the check passes")
+			mu.Lock()
+			passed = true
+			mu.Unlock()
+			_, err = controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockClient.merged).To(HaveLen(1))
+			Expect(mockClient.merged[0].ExpectedHead).To(Equal("c0ffee"))
+			Expect(mockClient.merged[0].TestCommand).To(BeEmpty())
+
+			Expect(k8sClient.Get(ctx, polecatKey, &updated)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionChecksPassed)).To(BeTrue())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionMerged)).To(BeTrue())
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.MergesSummary.Succeeded).To(Equal(int32(1)))
+			Expect(updatedRefinery.Status.ActiveMerges).To(BeEmpty())
+			Expect(updatedRefinery.Status.WaitingOnChecks).To(BeEmpty())
+
+			// Cleanup
+			Expect(k8sClient.Delete(ctx, refinery)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, polecat)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, secret)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, rig)).To(Succeed())
+		})
+
+		It("should merge a head only after its test pod succeeds", func() {
+			ctx := context.Background()
+
+			rig := &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "testpod-rig"},
+				Spec: gastownv1alpha1.RigSpec{
+					GitURL:      "git@github.com:test/repo.git",
+					BeadsPrefix: "tpd",
+				},
+			}
+			Expect(k8sClient.Create(ctx, rig)).To(Succeed())
+
+			refinery := &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testpod-refinery",
+					Namespace: "default",
+				},
+				Spec: gastownv1alpha1.RefinerySpec{
+					RigRef:       "testpod-rig",
+					TargetBranch: "main",
+					TestCommand:  "go test ./...",
+					TestImage:    "golang:1.25",
+					Parallelism:  1,
+				},
+			}
+			Expect(k8sClient.Create(ctx, refinery)).To(Succeed())
+
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "testpod-polecat",
+					Namespace: "default",
+					Labels:    map[string]string{"gastown.io/rig": "testpod-rig"},
+				},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:          "testpod-rig",
+					DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+					BeadID:       "tpd-1",
+				},
+			}
+			Expect(k8sClient.Create(ctx, polecat)).To(Succeed())
+			polecat.Status.Branch = "feature/testpod-polecat"
+			polecat.Status.Conditions = []metav1.Condition{{
+				Type:               ConditionAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "Polecat completed work",
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(k8sClient.Status().Update(ctx, polecat)).To(Succeed())
+
+			mockClient := &checkedGitClient{head: "c0ffee"}
+			controllerReconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+				GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+					return mockClient
+				},
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      refinery.Name,
+				Namespace: refinery.Namespace,
+			}}
+			podKey := types.NamespacedName{Name: "testpod-refinery-test-c0ffee", Namespace: "default"}
+
+			By("publishing the rebased branch without running the tests locally")
+			result, err := controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result.RequeueAfter).To(Equal(refineryPullRequestRequeueInterval))
+			Expect(mockClient.published).To(HaveLen(1))
+			Expect(mockClient.published[0].TestCommand).To(BeEmpty())
+			Expect(mockClient.merged).To(BeEmpty())
+
+			By("starting a test pod on the published commit")
+			var testPod corev1.Pod
+			Expect(k8sClient.Get(ctx, podKey, &testPod)).To(Succeed())
+			Expect(testPod.Spec.Containers[0].Image).To(Equal("golang:1.25"))
+			Expect(testPod.Spec.Containers[0].Args).To(Equal([]string{"go test ./..."}))
+			Expect(testPod.OwnerReferences).To(HaveLen(1))
+
+			By("waiting while the test pod runs")
+			_, err = controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockClient.published).To(HaveLen(1))
+			Expect(mockClient.merged).To(BeEmpty())
+
+			By("merging the tested head once the test pod succeeds")
+			testPod.Status.Phase = corev1.PodSucceeded
+			Expect(k8sClient.Status().Update(ctx, &testPod)).To(Succeed())
+			_, err = controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(mockClient.merged).To(HaveLen(1))
+			Expect(mockClient.merged[0].ExpectedHead).To(Equal("c0ffee"))
+			Expect(mockClient.merged[0].TestCommand).To(BeEmpty())
+
+			var updatedRefinery gastownv1alpha1.Refinery
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.MergesSummary.Succeeded).To(Equal(int32(1)))
+			Expect(updatedRefinery.Status.ActiveMerges).To(BeEmpty())
+
+			By("deleting the finished test pod")
+			err = k8sClient.Get(ctx, podKey, &testPod)
+			Expect(errors.IsNotFound(err) || testPod.DeletionTimestamp != nil).To(BeTrue())
+
+			// Cleanup
+			Expect(k8sClient.Delete(ctx, refinery)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, polecat)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, rig)).To(Succeed())
+		})
+
+		It("should create a resolver when a branch conflicts", func() {
+			ctx := context.Background()
+
+			rig := &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "conflict-rig"},
+				Spec: gastownv1alpha1.RigSpec{
+					GitURL:      "git@github.com:test/repo.git",
+					BeadsPrefix: "cfl",
+				},
+			}
+			Expect(k8sClient.Create(ctx, rig)).To(Succeed())
+
+			refinery := &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "conflict-refinery",
+					Namespace: "default",
+				},
+				Spec: gastownv1alpha1.RefinerySpec{
+					RigRef:               "conflict-rig",
+					TargetBranch:         "main",
+					Parallelism:          1,
+					AutoResolveConflicts: true,
+				},
+			}
+			Expect(k8sClient.Create(ctx, refinery)).To(Succeed())
+
+			polecat := &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "conflict-polecat",
+					Namespace: "default",
+					Labels:    map[string]string{"gastown.io/rig": "conflict-rig"},
+				},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:          "conflict-rig",
+					DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+					BeadID:       "cfl-1",
+					Kubernetes: &gastownv1alpha1.KubernetesSpec{
+						GitRepository: "git@github.com:test/repo.git",
+						GitBranch:     "main",
+						GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-creds"},
+					},
+				},
+			}
+			Expect(k8sClient.Create(ctx, polecat)).To(Succeed())
+			polecat.Status.Branch = "feature/cfl-1"
+			polecat.Status.Conditions = []metav1.Condition{{
+				Type:               ConditionAvailable,
+				Status:             metav1.ConditionTrue,
+				Reason:             "Ready",
+				Message:            "Polecat completed work",
+				LastTransitionTime: metav1.Now(),
+			}}
+			Expect(k8sClient.Status().Update(ctx, polecat)).To(Succeed())
+
+			mockClient := &mockGitClient{
+				mergeErr: fmt.Errorf("%w: could not apply 1a2b3c", git.ErrRebaseConflict),
+			}
+			controllerReconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+				GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+					return mockClient
+				},
+			}
+			req := reconcile.Request{NamespacedName: types.NamespacedName{
+				Name:      refinery.Name,
+				Namespace: refinery.Namespace,
+			}}
+
+			_, err := controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+
+			var resolver gastownv1alpha1.Polecat
+			resolverKey := types.NamespacedName{Name: "conflict-polecat-resolve", Namespace: "default"}
+			Expect(k8sClient.Get(ctx, resolverKey, &resolver)).To(Succeed())
+			Expect(resolver.Labels).To(HaveKeyWithValue(ConflictResolverLabel, "conflict-polecat"))
+			Expect(resolver.Spec.Kubernetes.WorkBranch).To(Equal("feature/cfl-1"))
+
+			By("taking the conflicted branch out of the queue")
+			_, err = controllerReconciler.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+			var updatedRefinery gastownv1alpha1.Refinery
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updatedRefinery)).To(Succeed())
+			Expect(updatedRefinery.Status.Queue).To(BeEmpty())
+			Expect(updatedRefinery.Status.MergesSummary.Failed).To(Equal(int32(1)))
+
+			// Cleanup
+			Expect(k8sClient.Delete(ctx, refinery)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, &resolver)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, polecat)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, rig)).To(Succeed())
+		})
+
+		It("should handle non-existent refinery gracefully", func() {
+			ctx := context.Background()
+
+			controllerReconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			// Reconcile non-existent refinery
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      "non-existent-refinery",
+					Namespace: "default",
+				},
+			})
+
+			// Should not error, just return empty result
+			Expect(err).NotTo(HaveOccurred())
+			Expect(result).To(Equal(reconcile.Result{}))
+		})
+
+		It("should requeue quickly when queue has pending items", func() {
+			ctx := context.Background()
+
+			// Create a refinery
+			refinery := &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "queue-test-refinery",
+					Namespace: "default",
+				},
+				Spec: gastownv1alpha1.RefinerySpec{
+					RigRef:       "queue-rig",
+					TargetBranch: "main",
+					Parallelism:  1,
+				},
+			}
+			Expect(k8sClient.Create(ctx, refinery)).To(Succeed())
+
+			// Create multiple merge-ready polecats
+			for i := 1; i <= 3; i++ {
+				polecat := &gastownv1alpha1.Polecat{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:      "queue-polecat-" + string(rune('0'+i)),
+						Namespace: "default",
+						Labels: map[string]string{
+							"gastown.io/rig": "queue-rig",
+						},
+					},
+					Spec: gastownv1alpha1.PolecatSpec{
+						Rig:          "queue-rig",
+						DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+					},
+				}
+				Expect(k8sClient.Create(ctx, polecat)).To(Succeed())
+
+				polecat.Status.Conditions = []metav1.Condition{
+					{
+						Type:               "Available",
+						Status:             metav1.ConditionTrue,
+						Reason:             "Ready",
+						LastTransitionTime: metav1.Now(),
+					},
+				}
+				Expect(k8sClient.Status().Update(ctx, polecat)).To(Succeed())
+			}
+
+			controllerReconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+			}
+
+			result, err := controllerReconciler.Reconcile(ctx, reconcile.Request{
+				NamespacedName: types.NamespacedName{
+					Name:      refinery.Name,
+					Namespace: refinery.Namespace,
+				},
+			})
+			Expect(err).NotTo(HaveOccurred())
+
+			// Should requeue at processing interval since there's more work
+			Expect(result.RequeueAfter).To(Equal(refineryProcessingRequeueInterval))
+
+			// Cleanup
+			Expect(k8sClient.Delete(ctx, refinery)).To(Succeed())
+			for i := 1; i <= 3; i++ {
+				polecat := &gastownv1alpha1.Polecat{}
+				_ = k8sClient.Get(ctx, types.NamespacedName{
+					Name:      "queue-polecat-" + string(rune('0'+i)),
+					Namespace: "default",
+				}, polecat)
+				_ = k8sClient.Delete(ctx, polecat)
+			}
+		})
+	})
+})
+
+var _ = Describe("Refinery branch cleanup", func() {
+	Context("When selecting merged branches for cleanup", func() {
+		now := time.Date(2026, 3, 10, 12, 0, 0, 0, time.UTC)
+
+		mergedPolecat := func(name, bead string, mergedAt time.Time, extra ...metav1.Condition) gastownv1alpha1.Polecat {
+			conditions := append([]metav1.Condition{{
+				Type:               ConditionMerged,
+				Status:             metav1.ConditionTrue,
+				LastTransitionTime: metav1.NewTime(mergedAt),
+			}}, extra...)
+			return gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: name},
+				Spec:       gastownv1alpha1.PolecatSpec{BeadID: bead},
+				Status:     gastownv1alpha1.PolecatStatus{Branch: "feature/" + bead, Conditions: conditions},
+			}
+		}
+		names := func(polecats []*gastownv1alpha1.Polecat) []string {
+			var out []string
+			for _, p := range polecats {
+				out = append(out, p.Name)
+			}
+			return out
+		}
+
+		It("should select branches merged longer ago than the retention", func() {
+			days := int32(7)
+			polecats := []gastownv1alpha1.Polecat{
+				mergedPolecat("old", "gt-1", now.Add(-8*24*time.Hour)),
+				mergedPolecat("recent", "gt-2", now.Add(-24*time.Hour)),
+				{ObjectMeta: metav1.ObjectMeta{Name: "unmerged"}, Status: gastownv1alpha1.PolecatStatus{Branch: "feature/gt-3"}},
+				mergedPolecat("deleted", "gt-4", now.Add(-30*24*time.Hour),
+					metav1.Condition{Type: ConditionBranchDeleted, Status: metav1.ConditionTrue}),
+			}
+
+			policy := &gastownv1alpha1.BranchCleanupSpec{AfterDays: &days}
+			Expect(names(staleBranchPolecats(polecats, policy, nil, nil, now))).To(Equal([]string{"old"}))
+		})
+
+		It("should select branches of complete convoys", func() {
+			polecats := []gastownv1alpha1.Polecat{
+				mergedPolecat("by-bead", "gt-1", now),
+				mergedPolecat("by-label", "gt-2", now),
+				mergedPolecat("open", "gt-3", now),
+			}
+			polecats[1].Labels = map[string]string{"gastown.io/convoy": "batch-1"}
+
+			policy := &gastownv1alpha1.BranchCleanupSpec{AfterConvoyComplete: true}
+			stale := staleBranchPolecats(polecats, policy,
+				map[string]bool{"batch-1": true}, map[string]bool{"gt-1": true}, now)
+			Expect(names(stale)).To(Equal([]string{"by-bead", "by-label"}))
+		})
+
+		It("should select nothing without an enabled rule", func() {
+			polecats := []gastownv1alpha1.Polecat{mergedPolecat("old", "gt-1", now.Add(-365*24*time.Hour))}
+			Expect(staleBranchPolecats(polecats, &gastownv1alpha1.BranchCleanupSpec{}, nil, nil, now)).To(BeEmpty())
+		})
+
+		It("should run the cleanup at most once per interval", func() {
+			refinery := &gastownv1alpha1.Refinery{}
+			Expect(branchCleanupDue(refinery, now)).To(BeTrue())
+
+			refinery.Status.LastBranchCleanup = &gastownv1alpha1.BranchCleanupStatus{
+				Time: &metav1.Time{Time: now.Add(-time.Minute)},
+			}
+			Expect(branchCleanupDue(refinery, now)).To(BeFalse())
+			Expect(branchCleanupDue(refinery, now.Add(refineryBranchCleanupInterval))).To(BeTrue())
+		})
+	})
+})
+
+// driftGitClient reports a fixed drift per branch and records refreshes.
+type driftGitClient struct {
+	mockGitClient
+	behind     map[string]int
+	refreshErr error
+	refreshed  []git.MergeOptions
+}
+
+func (m *driftGitClient) CommitsBehind(ctx context.Context, baseBranch, headBranch string) (int, error) {
+	return m.behind[headBranch], nil
+}
+
+func (m *driftGitClient) RefreshBranch(ctx context.Context, opts git.MergeOptions) error {
+	m.refreshed = append(m.refreshed, opts)
+	return m.refreshErr
+}
+
+var _ = Describe("Refinery drift detection", func() {
+	Context("When queued branches fall behind the target branch", func() {
+		ctx := context.Background()
+
+		var rig *gastownv1alpha1.Rig
+		BeforeEach(func() {
+			rig = &gastownv1alpha1.Rig{
+				ObjectMeta: metav1.ObjectMeta{Name: "drift-test-rig"},
+				Spec: gastownv1alpha1.RigSpec{
+					GitURL:      "git@github.com:test/repo.git",
+					BeadsPrefix: "drift",
+				},
+			}
+			Expect(k8sClient.Create(ctx, rig)).To(Succeed())
+		})
+		AfterEach(func() {
+			Expect(k8sClient.Delete(ctx, rig)).To(Succeed())
+		})
+
+		newQueue := func() []gastownv1alpha1.MergeQueueEntry {
+			return []gastownv1alpha1.MergeQueueEntry{
+				{Polecat: "fresh", Branch: "feature/fresh"},
+				{Polecat: "stale", Branch: "feature/stale"},
+			}
+		}
+		newRefinery := func(maxBehind int32) *gastownv1alpha1.Refinery {
+			return &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{Name: "drift-refinery", Namespace: "default"},
+				Spec: gastownv1alpha1.RefinerySpec{
+					RigRef:           "drift-test-rig",
+					TargetBranch:     "main",
+					TestCommand:      "make test",
+					MaxCommitsBehind: maxBehind,
+				},
+			}
+		}
+
+		It("should refresh only branches past maxCommitsBehind", func() {
+			mockClient := &driftGitClient{behind: map[string]int{"feature/fresh": 2, "feature/stale": 40}}
+			reconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+				GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+					return mockClient
+				},
+			}
+
+			queue := newQueue()
+			reconciler.refreshDriftedBranches(ctx, newRefinery(10), queue)
+
+			Expect(mockClient.refreshed).To(HaveLen(1))
+			Expect(mockClient.refreshed[0].SourceBranch).To(Equal("feature/stale"))
+			Expect(mockClient.refreshed[0].TestCommand).To(Equal("make test"))
+			Expect(*queue[0].CommitsBehind).To(Equal(int32(2)))
+			Expect(queue[0].LastRefreshTime).To(BeNil())
+			Expect(*queue[1].CommitsBehind).To(Equal(int32(0)))
+			Expect(queue[1].LastRefreshTime).NotTo(BeNil())
+		})
+
+		It("should keep the drift of branches that fail to refresh", func() {
+			mockClient := &driftGitClient{
+				behind:     map[string]int{"feature/stale": 40},
+				refreshErr: git.ErrTargetMoved,
+			}
+			recorder := record.NewFakeRecorder(10)
+			reconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: recorder,
+				GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+					return mockClient
+				},
+			}
+
+			queue := newQueue()
+			reconciler.refreshDriftedBranches(ctx, newRefinery(10), queue)
+
+			Expect(*queue[1].CommitsBehind).To(Equal(int32(40)))
+			Expect(queue[1].LastRefreshTime).To(BeNil())
+			Expect(recorder.Events).To(Receive(ContainSubstring("RefreshFailed")))
+		})
+
+		It("should not measure drift when maxCommitsBehind is unset", func() {
+			mockClient := &driftGitClient{behind: map[string]int{"feature/stale": 40}}
+			reconciler := &RefineryReconciler{
+				Client:   k8sClient,
+				Scheme:   k8sClient.Scheme(),
+				Recorder: record.NewFakeRecorder(10),
+				GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+					return mockClient
+				},
+			}
+
+			queue := newQueue()
+			reconciler.refreshDriftedBranches(ctx, newRefinery(0), queue)
+
+			Expect(mockClient.refreshed).To(BeEmpty())
+			Expect(queue[1].CommitsBehind).To(BeNil())
+		})
 	})
 })
@@ -0,0 +1,126 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"math"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// driftedEntries returns the indexes of queue entries that are more than
+// maxBehind commits behind the target branch.
+func driftedEntries(queue []gastownv1alpha1.MergeQueueEntry, maxBehind int32) []int {
+	var drifted []int
+	for i, entry := range queue {
+		if entry.CommitsBehind != nil && *entry.CommitsBehind > maxBehind {
+			drifted = append(drifted, i)
+		}
+	}
+	return drifted
+}
+
+// refreshDriftedBranches measures how far each queued branch is behind the
+// target branch and refreshes the ones past spec.maxCommitsBehind: they are
+// rebased onto the target, retested and force-pushed. The target moves with
+// every merge, so drift is measured on every reconcile. Branches that fail
+// to refresh stay queued; the merge itself will report the conflict or test
+// failure.
+func (r *RefineryReconciler) refreshDriftedBranches(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, queue []gastownv1alpha1.MergeQueueEntry,
+) {
+	log := logf.FromContext(ctx)
+
+	maxBehind := refinery.Spec.MaxCommitsBehind
+	if maxBehind <= 0 || len(queue) == 0 {
+		return
+	}
+
+	gitClient, cleanup, err := r.openRepository(ctx, refinery)
+	if err != nil {
+		log.Error(err, "Failed to open repository for drift detection")
+		return
+	}
+	defer cleanup()
+
+	refresher, ok := gitClient.(git.DriftRefresher)
+	if !ok {
+		return
+	}
+
+	targetBranch := refinery.Spec.TargetBranch
+	if targetBranch == "" {
+		targetBranch = "main"
+	}
+
+	for i := range queue {
+		if queue[i].Branch == "" {
+			continue
+		}
+		behind, err := refresher.CommitsBehind(ctx, targetBranch, queue[i].Branch)
+		if err != nil {
+			log.Error(err, "Failed to measure drift", "polecat", queue[i].Polecat)
+			continue
+		}
+		if behind > math.MaxInt32 {
+			behind = math.MaxInt32
+		}
+		commitsBehind := int32(behind) // #nosec G115 -- bounds checked above
+		queue[i].CommitsBehind = &commitsBehind
+	}
+
+	// Pull requests are retested by the repository's checks when the branch
+	// is pushed, and test Pods retest the branch before it merges
+	testCommand := refinery.Spec.TestCommand
+	if refinery.Spec.MergeStrategy == gastownv1alpha1.MergeStrategyPullRequest || refinery.Spec.TestImage != "" {
+		testCommand = ""
+	}
+
+	for _, idx := range driftedEntries(queue, maxBehind) {
+		entry := &queue[idx]
+		behind := *entry.CommitsBehind
+		err := refresher.RefreshBranch(ctx, git.MergeOptions{
+			SourceBranch: entry.Branch,
+			TargetBranch: targetBranch,
+			TestCommand:  testCommand,
+		})
+		r.Audit.Record("refinery", audit.ActionPush, refinery,
+			map[string]string{"branch": entry.Branch, "reason": "refresh"}, err)
+		if err != nil {
+			log.Error(err, "Failed to refresh drifted branch", "polecat", entry.Polecat, "commitsBehind", behind)
+			r.Recorder.Event(refinery, "Warning", "RefreshFailed",
+				fmt.Sprintf("Refresh of %s (%d commits behind %s) failed: %v", entry.Branch, behind, targetBranch, err))
+			r.recordSignatureVerification(ctx, refinery, entry.Polecat, err)
+			continue
+		}
+
+		log.Info("Refreshed drifted branch", "polecat", entry.Polecat, "commitsBehind", behind)
+		r.Recorder.Event(refinery, "Normal", "BranchRefreshed",
+			fmt.Sprintf("Rebased and retested %s, which was %d commits behind %s", entry.Branch, behind, targetBranch))
+		var zero int32
+		now := metav1.Now()
+		entry.CommitsBehind = &zero
+		entry.LastRefreshTime = &now
+	}
+}
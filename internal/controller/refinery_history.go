@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+const (
+	// mergeHistoryLimit is how many merge attempts status.mergeHistory keeps
+	mergeHistoryLimit = 20
+
+	// mergeReasonMaxLength bounds the failure reason kept per attempt; merge
+	// errors can carry test output.
+	mergeReasonMaxLength = 512
+)
+
+// appendMergeHistory records a finished merge attempt of the lane in the
+// Refinery's merge history, dropping the oldest attempts past
+// mergeHistoryLimit. mergeErr is nil when the branch landed.
+func appendMergeHistory(
+	refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	lane gastownv1alpha1.ActiveMerge, mergeErr error, now time.Time,
+) {
+	finished := metav1.NewTime(now)
+	record := gastownv1alpha1.MergeRecord{
+		Polecat: polecat.Name,
+		Branch:  polecat.Status.Branch,
+		Result:  gastownv1alpha1.MergeResultSucceeded,
+		Time:    &finished,
+	}
+	if lane.StartedAt != nil {
+		record.Duration = &metav1.Duration{Duration: now.Sub(lane.StartedAt.Time).Round(time.Second)}
+	}
+	if mergeErr != nil {
+		record.Result = gastownv1alpha1.MergeResultFailed
+		record.Reason = mergeErr.Error()
+		if len(record.Reason) > mergeReasonMaxLength {
+			record.Reason = record.Reason[:mergeReasonMaxLength] + "..."
+		}
+	} else {
+		record.Commit = polecat.Status.MergedCommit
+		record.Method = mergeMethod(refinery)
+	}
+
+	history := append(refinery.Status.MergeHistory, record)
+	if len(history) > mergeHistoryLimit {
+		history = history[len(history)-mergeHistoryLimit:]
+	}
+	refinery.Status.MergeHistory = history
+}
+
+// mergeMethod returns the method the Refinery lands branches with: its
+// mergeMethod for the push strategy, the auto-merge method of its pull
+// requests, or empty when pull requests are merged by hand.
+func mergeMethod(refinery *gastownv1alpha1.Refinery) gastownv1alpha1.MergeMethod {
+	if refinery.Spec.MergeStrategy == gastownv1alpha1.MergeStrategyPullRequest {
+		spec := refinery.Spec.PullRequest
+		if spec == nil || !spec.AutoMerge {
+			return ""
+		}
+		if spec.MergeMethod == "" {
+			return gastownv1alpha1.MergeMethodSquash
+		}
+		return gastownv1alpha1.MergeMethod(spec.MergeMethod)
+	}
+	if refinery.Spec.MergeMethod == "" {
+		return gastownv1alpha1.MergeMethodRebase
+	}
+	return refinery.Spec.MergeMethod
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// defaultBranchLeaseDuration is how long a Refinery's lease on its target
+// branch stays valid when spec.ownership sets no leaseDuration
+const defaultBranchLeaseDuration = 10 * time.Minute
+
+// acquireBranchLease takes or renews the Refinery's lease on its target
+// branch when spec.ownership is set. The lease is owned by the Refinery's
+// UID, so Refineries of different clusters, or two Refineries of one cluster
+// on the same repository, never hold it at once. It returns an error
+// wrapping git.ErrLeaseHeld while another Refinery holds it.
+func (r *RefineryReconciler) acquireBranchLease(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, now time.Time,
+) error {
+	if refinery.Spec.Ownership == nil {
+		return nil
+	}
+
+	gitClient, cleanup, err := r.openRepository(ctx, refinery)
+	if err != nil {
+		return err
+	}
+	defer cleanup()
+
+	holder, ok := gitClient.(git.LeaseHolder)
+	if !ok {
+		return fmt.Errorf("git client does not support leases")
+	}
+
+	targetBranch := refinery.Spec.TargetBranch
+	if targetBranch == "" {
+		targetBranch = "main"
+	}
+	duration := defaultBranchLeaseDuration
+	if d := refinery.Spec.Ownership.LeaseDuration; d != nil && d.Duration > 0 {
+		duration = d.Duration
+	}
+	return holder.AcquireLease(ctx, git.LeaseRefPrefix+targetBranch, git.Lease{
+		Owner:   string(refinery.UID),
+		Holder:  refinery.Namespace + "/" + refinery.Name,
+		Expires: now.Add(duration),
+	}, now)
+}
+
+// holdQueue holds the merge queue of a Refinery that could not take the
+// lease on its target branch, until the next reconcile.
+func (r *RefineryReconciler) holdQueue(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, queued int, leaseErr error,
+) (ctrl.Result, error) {
+	log := logf.FromContext(ctx)
+
+	refinery.Status.Phase = "Idle"
+	refinery.Status.CurrentMerge = ""
+	if errors.Is(leaseErr, git.ErrLeaseHeld) {
+		log.Info("Target branch is leased by another refinery", "reason", leaseErr.Error())
+		r.setCondition(refinery, RefineryConditionReady, metav1.ConditionFalse,
+			"LeaseHeld", fmt.Sprintf("%v; %d branches held", leaseErr, queued))
+		r.Recorder.Event(refinery, "Warning", "LeaseHeld", leaseErr.Error())
+	} else {
+		log.Error(leaseErr, "Failed to acquire the target branch lease")
+		r.setCondition(refinery, RefineryConditionReady, metav1.ConditionFalse,
+			"LeaseFailed", fmt.Sprintf("Failed to acquire the target branch lease: %v; %d branches held", leaseErr, queued))
+	}
+
+	if err := applyStatus(ctx, r.Client, refinery, fieldManagerRefinery); err != nil {
+		log.Error(err, "Failed to update Refinery status")
+		return ctrl.Result{}, err
+	}
+	return ctrl.Result{RequeueAfter: requeueDefault()}, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// leasingGitClient records the leases it acquires, failing with err.
+type leasingGitClient struct {
+	mockGitClient
+	refs   []string
+	leases []git.Lease
+	err    error
+}
+
+func (m *leasingGitClient) AcquireLease(ctx context.Context, ref string, lease git.Lease, now time.Time) error {
+	m.refs = append(m.refs, ref)
+	m.leases = append(m.leases, lease)
+	return m.err
+}
+
+var _ = Describe("Refinery ownership", func() {
+	var (
+		ctx      context.Context
+		scheme   *runtime.Scheme
+		refinery *gastownv1alpha1.Refinery
+		leaser   *leasingGitClient
+		recorder *record.FakeRecorder
+		r        *RefineryReconciler
+		now      time.Time
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		rig := &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec:       gastownv1alpha1.RigSpec{GitURL: "git@github.com:org/app.git", BeadsPrefix: "gt"},
+		}
+		refinery = &gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-refinery", Namespace: "staging", UID: "uid-staging"},
+			Spec: gastownv1alpha1.RefinerySpec{
+				RigRef:       "app",
+				TargetBranch: "release",
+				Ownership:    &gastownv1alpha1.OwnershipSpec{},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig, refinery).
+			WithStatusSubresource(&gastownv1alpha1.Refinery{}).Build()
+		leaser = &leasingGitClient{}
+		recorder = record.NewFakeRecorder(10)
+		r = &RefineryReconciler{
+			Client:           c,
+			Scheme:           scheme,
+			Recorder:         recorder,
+			GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient { return leaser },
+		}
+		now = time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC)
+	})
+
+	It("should not lease without ownership", func() {
+		refinery.Spec.Ownership = nil
+		Expect(r.acquireBranchLease(ctx, refinery, now)).To(Succeed())
+		Expect(leaser.refs).To(BeEmpty())
+	})
+
+	It("should lease the target branch for the refinery", func() {
+		Expect(r.acquireBranchLease(ctx, refinery, now)).To(Succeed())
+		Expect(leaser.refs).To(Equal([]string{"refs/gastown/leases/release"}))
+		Expect(leaser.leases).To(Equal([]git.Lease{{
+			Owner:   "uid-staging",
+			Holder:  "staging/app-refinery",
+			Expires: now.Add(defaultBranchLeaseDuration),
+		}}))
+
+		refinery.Spec.Ownership.LeaseDuration = &metav1.Duration{Duration: 2 * time.Minute}
+		Expect(r.acquireBranchLease(ctx, refinery, now)).To(Succeed())
+		Expect(leaser.leases[1].Expires).To(Equal(now.Add(2 * time.Minute)))
+	})
+
+	It("should hold the queue while another refinery holds the lease", func() {
+		leaser.err = fmt.Errorf("%w: production/app-refinery until 2026-03-07T15:10:00Z", git.ErrLeaseHeld)
+		err := r.acquireBranchLease(ctx, refinery, now)
+		Expect(err).To(MatchError(git.ErrLeaseHeld))
+
+		result, err := r.holdQueue(ctx, refinery, 3, err)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(result.RequeueAfter).NotTo(BeZero())
+
+		updated := &gastownv1alpha1.Refinery{}
+		Expect(r.Get(ctx, client.ObjectKeyFromObject(refinery), updated)).To(Succeed())
+		Expect(updated.Status.Phase).To(Equal("Idle"))
+		ready := meta.FindStatusCondition(updated.Status.Conditions, RefineryConditionReady)
+		Expect(ready).NotTo(BeNil())
+		Expect(ready.Reason).To(Equal("LeaseHeld"))
+		Expect(ready.Message).To(ContainSubstring("production/app-refinery"))
+		Expect(ready.Message).To(ContainSubstring("3 branches held"))
+		Expect(<-recorder.Events).To(HavePrefix("Warning LeaseHeld"))
+	})
+})
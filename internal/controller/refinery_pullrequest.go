@@ -0,0 +1,270 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/equality"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/internal/git/provider"
+)
+
+const (
+	// ConditionPullRequest is set on a Polecat once the Refinery opens a pull
+	// request (or GitLab merge request) for its branch. The message carries
+	// its number and URL.
+	ConditionPullRequest = "PullRequest"
+
+	// ConditionChecksPassed reports the checks on a Polecat's pull request:
+	// True when all passed, False when any failed, Unknown while pending.
+	ConditionChecksPassed = "ChecksPassed"
+)
+
+// processPullRequest opens a pull request for the polecat branch, or refreshes
+// the state of the one already open, and records it in the polecat's
+// conditions. It reports whether the pull request has been merged.
+func (r *RefineryReconciler) processPullRequest(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+) (bool, error) {
+	log := logf.FromContext(ctx)
+
+	sourceBranch := polecat.Status.Branch
+	if sourceBranch == "" {
+		return false, fmt.Errorf("polecat %s has no branch in status", polecat.Name)
+	}
+
+	targetBranch := refinery.Spec.TargetBranch
+	if targetBranch == "" {
+		targetBranch = "main"
+	}
+
+	spec := refinery.Spec.PullRequest
+	if spec == nil {
+		spec = &gastownv1alpha1.PullRequestSpec{}
+	}
+
+	prov, err := r.changeRequestProvider(ctx, refinery)
+	if err != nil {
+		return false, err
+	}
+
+	pr, err := prov.FindChangeRequest(ctx, sourceBranch, targetBranch)
+	if err != nil {
+		return false, err
+	}
+	if pr == nil {
+		pr, err = prov.CreateChangeRequest(ctx, sourceBranch, targetBranch,
+			pullRequestTitle(polecat), pullRequestBody(polecat))
+		if err != nil {
+			return false, err
+		}
+		log.Info("Opened pull request", "polecat", polecat.Name, "ref", pr.Ref, "url", pr.URL)
+		r.Recorder.Event(refinery, "Normal", "PullRequestOpened",
+			fmt.Sprintf("Opened %s for %s: %s", pr.Ref, polecat.Name, pr.URL))
+	}
+
+	before := polecat.DeepCopy()
+	now := metav1.Now()
+	polecat.Status.PullRequestURL = pr.URL
+	var merged bool
+	var closedErr error
+	switch pr.State {
+	case provider.StateMerged:
+		merged = true
+		polecat.Status.MergedCommit = pr.MergeCommitSHA
+		meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+			Type:               ConditionPullRequest,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Merged",
+			Message:            fmt.Sprintf("%s merged: %s", pr.Ref, pr.URL),
+			LastTransitionTime: now,
+		})
+		meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+			Type:   ConditionMerged,
+			Status: metav1.ConditionTrue,
+			Reason: "PullRequestMerged",
+			Message: fmt.Sprintf("Branch %s merged to %s via %s (commit: %s)",
+				sourceBranch, targetBranch, pr.Ref, pr.MergeCommitSHA),
+			LastTransitionTime: now,
+		})
+
+	case provider.StateClosed:
+		closedErr = fmt.Errorf("%s closed without merging", pr.Ref)
+		meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+			Type:               ConditionPullRequest,
+			Status:             metav1.ConditionFalse,
+			Reason:             "Closed",
+			Message:            fmt.Sprintf("%s closed without merging: %s", pr.Ref, pr.URL),
+			LastTransitionTime: now,
+		})
+
+	default:
+		if spec.AutoMerge && !pr.AutoMerge {
+			method := spec.MergeMethod
+			if method == "" {
+				method = gastownv1alpha1.PullRequestMergeMethodSquash
+			}
+			// Not fatal: the pull request can still be merged by hand
+			if err := prov.EnableAutoMerge(ctx, pr, string(method)); err != nil {
+				log.Error(err, "Failed to enable auto-merge", "ref", pr.Ref)
+				r.Recorder.Event(refinery, "Warning", "AutoMergeFailed",
+					fmt.Sprintf("%s: %s", pr.Ref, err.Error()))
+			}
+		}
+
+		checks, err := prov.GetChecksStatus(ctx, pr)
+		if err != nil {
+			return false, err
+		}
+		meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+			Type:               ConditionPullRequest,
+			Status:             metav1.ConditionTrue,
+			Reason:             "Open",
+			Message:            fmt.Sprintf("%s open: %s", pr.Ref, pr.URL),
+			LastTransitionTime: now,
+		})
+		meta.SetStatusCondition(&polecat.Status.Conditions, checksCondition(checks, now))
+	}
+
+	// Polled every reconcile; only write when something changed
+	if !equality.Semantic.DeepEqual(before.Status, polecat.Status) {
+		if err := r.applyPolecatStatus(ctx, polecat); err != nil {
+			return false, err
+		}
+	}
+	return merged, closedErr
+}
+
+// reconcilePullRequests processes the pull request of each polecat and
+// returns the lanes still waiting on an open pull request.
+func (r *RefineryReconciler) reconcilePullRequests(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+	polecats []*gastownv1alpha1.Polecat, lanes []gastownv1alpha1.ActiveMerge,
+) []gastownv1alpha1.ActiveMerge {
+	var open []gastownv1alpha1.ActiveMerge
+	for i, polecat := range polecats {
+		merged, err := r.processPullRequest(ctx, refinery, polecat)
+		switch {
+		case err != nil:
+			r.recordMergeFailure(ctx, refinery, polecat, lanes[i], err)
+		case merged:
+			r.recordMergeSuccess(ctx, refinery, polecat, lanes[i])
+		default:
+			open = append(open, lanes[i])
+		}
+	}
+	return open
+}
+
+// changeRequestProvider returns the git hosting provider selected by
+// spec.provider, authenticated with that provider's token secret.
+func (r *RefineryReconciler) changeRequestProvider(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+) (provider.Provider, error) {
+	spec := refinery.Spec.PullRequest
+	if spec == nil {
+		spec = &gastownv1alpha1.PullRequestSpec{}
+	}
+
+	var tokenRef *gastownv1alpha1.SecretKeyRef
+	var apiURL string
+	switch refinery.Spec.Provider {
+	case gastownv1alpha1.GitProviderGitLab:
+		tokenRef, apiURL = refinery.Spec.GitLabTokenSecretRef, spec.GitLabAPIURL
+	case gastownv1alpha1.GitProviderBitbucket:
+		tokenRef, apiURL = refinery.Spec.BitbucketTokenSecretRef, spec.BitbucketAPIURL
+	default:
+		tokenRef, apiURL = refinery.Spec.GitHubTokenSecretRef, spec.GitHubAPIURL
+	}
+	if tokenRef == nil {
+		return nil, fmt.Errorf("no token secret configured for provider %q", refinery.Spec.Provider)
+	}
+	token, err := r.readSecretKey(ctx, refinery.Namespace, tokenRef)
+	if err != nil {
+		return nil, err
+	}
+
+	rig := &gastownv1alpha1.Rig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: refinery.Spec.RigRef}, rig); err != nil {
+		return nil, fmt.Errorf("failed to get rig %s: %w", refinery.Spec.RigRef, err)
+	}
+
+	return provider.New(provider.Config{
+		Type:    string(refinery.Spec.Provider),
+		APIURL:  apiURL,
+		Token:   token,
+		RepoURL: rig.Spec.GitURL,
+	})
+}
+
+// checksCondition converts a checks summary into the ChecksPassed condition.
+func checksCondition(checks *git.ChecksStatus, now metav1.Time) metav1.Condition {
+	cond := metav1.Condition{Type: ConditionChecksPassed, LastTransitionTime: now}
+	switch checks.State {
+	case git.ChecksFailure:
+		cond.Status = metav1.ConditionFalse
+		cond.Reason = "Failed"
+		cond.Message = fmt.Sprintf("%d of %d checks failed", checks.Failed, checks.Total)
+	case git.ChecksPending:
+		cond.Status = metav1.ConditionUnknown
+		cond.Reason = "Pending"
+		cond.Message = fmt.Sprintf("%d of %d checks complete", checks.Completed, checks.Total)
+	default:
+		cond.Status = metav1.ConditionTrue
+		cond.Reason = "Succeeded"
+		cond.Message = fmt.Sprintf("All %d checks passed", checks.Total)
+	}
+	return cond
+}
+
+// pullRequestTitle titles a pull request after the polecat's bead and the
+// first line of its task.
+func pullRequestTitle(polecat *gastownv1alpha1.Polecat) string {
+	task, _, _ := strings.Cut(strings.TrimSpace(polecat.Spec.TaskDescription), "\n")
+	switch {
+	case polecat.Spec.BeadID != "" && task != "":
+		return polecat.Spec.BeadID + ": " + task
+	case task != "":
+		return task
+	case polecat.Spec.BeadID != "":
+		return polecat.Spec.BeadID
+	default:
+		return "Merge " + polecat.Status.Branch
+	}
+}
+
+// pullRequestBody describes the polecat's work in the pull request.
+func pullRequestBody(polecat *gastownv1alpha1.Polecat) string {
+	body := fmt.Sprintf("Opened by the Gas Town Refinery for polecat `%s`", polecat.Name)
+	if polecat.Spec.BeadID != "" {
+		body += fmt.Sprintf(" (bead `%s`)", polecat.Spec.BeadID)
+	}
+	body += "."
+	if task := strings.TrimSpace(polecat.Spec.TaskDescription); task != "" {
+		body += "\n\n" + task
+	}
+	return body
+}
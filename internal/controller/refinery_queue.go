@@ -0,0 +1,169 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"math"
+	"sort"
+	"time"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// ConditionMerged is set on a Polecat by the Refinery once its branch is merged.
+const ConditionMerged = "Merged"
+
+// buildMergeQueue turns merge-ready polecats into ordered merge queue entries.
+// Polecats that are already merged, or whose conflicts a resolver is working
+// on, are dropped. Dependencies declared with
+// spec.mergeAfter are resolved against all polecats of the rig, and diff sizes
+// and drift measured on a previous reconcile are carried over while the branch
+// is unchanged.
+func buildMergeQueue(
+	ready []gastownv1alpha1.Polecat,
+	all *gastownv1alpha1.PolecatList,
+	policy gastownv1alpha1.MergeQueuePolicy,
+	previous []gastownv1alpha1.MergeQueueEntry,
+) []gastownv1alpha1.MergeQueueEntry {
+	merged := make(map[string]bool)
+	for i := range all.Items {
+		if isPolecatMerged(&all.Items[i]) {
+			merged[all.Items[i].Name] = true
+		}
+	}
+
+	resolving := resolvingConflicts(all)
+
+	prev := make(map[string]gastownv1alpha1.MergeQueueEntry, len(previous))
+	for _, entry := range previous {
+		prev[entry.Polecat] = entry
+	}
+
+	queue := make([]gastownv1alpha1.MergeQueueEntry, 0, len(ready))
+	for i := range ready {
+		polecat := &ready[i]
+		if merged[polecat.Name] || resolving[polecat.Name] {
+			continue
+		}
+
+		entry := gastownv1alpha1.MergeQueueEntry{
+			Polecat:    polecat.Name,
+			Branch:     polecat.Status.Branch,
+			Priority:   polecat.Spec.MergePriority,
+			ReadySince: readySince(polecat),
+		}
+
+		for _, dep := range polecat.Spec.MergeAfter {
+			if !merged[dep] {
+				entry.BlockedBy = append(entry.BlockedBy, dep)
+			}
+		}
+
+		if old, ok := prev[polecat.Name]; ok && old.Branch == entry.Branch {
+			entry.DiffSize = old.DiffSize
+			entry.CommitsBehind = old.CommitsBehind
+			entry.LastRefreshTime = old.LastRefreshTime
+		}
+
+		queue = append(queue, entry)
+	}
+
+	sortMergeQueue(queue, policy)
+	return queue
+}
+
+// sortMergeQueue orders the queue according to the policy.
+// Unblocked entries always come before blocked ones; ties fall back to FIFO
+// order and finally polecat name so the order is deterministic.
+func sortMergeQueue(queue []gastownv1alpha1.MergeQueueEntry, policy gastownv1alpha1.MergeQueuePolicy) {
+	sort.SliceStable(queue, func(i, j int) bool {
+		a, b := queue[i], queue[j]
+
+		if (len(a.BlockedBy) == 0) != (len(b.BlockedBy) == 0) {
+			return len(a.BlockedBy) == 0
+		}
+
+		switch policy {
+		case gastownv1alpha1.MergeQueuePolicyPriority:
+			if a.Priority != b.Priority {
+				return a.Priority > b.Priority
+			}
+		case gastownv1alpha1.MergeQueuePolicySmallestDiffFirst:
+			if sa, sb := diffSizeOrMax(a), diffSizeOrMax(b); sa != sb {
+				return sa < sb
+			}
+		}
+
+		ta, tb := readySinceTime(a), readySinceTime(b)
+		if !ta.Equal(&tb) {
+			return ta.Before(&tb)
+		}
+		return a.Polecat < b.Polecat
+	})
+}
+
+// nextMergeCandidates returns the indexes of up to lanes unblocked entries
+// whose batch has landed by now, in queue order.
+func nextMergeCandidates(queue []gastownv1alpha1.MergeQueueEntry, lanes int, now time.Time) []int {
+	var candidates []int
+	for i, entry := range queue {
+		if len(candidates) >= lanes {
+			break
+		}
+		if len(entry.BlockedBy) == 0 && !waitingForBatch(entry, now) {
+			candidates = append(candidates, i)
+		}
+	}
+	return candidates
+}
+
+// isPolecatMerged reports whether the Refinery already merged the polecat's branch.
+func isPolecatMerged(polecat *gastownv1alpha1.Polecat) bool {
+	return meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionMerged)
+}
+
+// readySince returns when the polecat became ready for merge.
+// Uses the Available condition, then the old Ready condition, then creation time.
+func readySince(polecat *gastownv1alpha1.Polecat) *metav1.Time {
+	for _, condType := range []string{ConditionAvailable, ConditionPolecatReady} {
+		if cond := meta.FindStatusCondition(polecat.Status.Conditions, condType); cond != nil &&
+			cond.Status == metav1.ConditionTrue {
+			t := cond.LastTransitionTime
+			return &t
+		}
+	}
+	t := polecat.CreationTimestamp
+	return &t
+}
+
+func readySinceTime(entry gastownv1alpha1.MergeQueueEntry) metav1.Time {
+	if entry.ReadySince == nil {
+		return metav1.Time{}
+	}
+	return *entry.ReadySince
+}
+
+// diffSizeOrMax sorts entries with unknown diff size last.
+func diffSizeOrMax(entry gastownv1alpha1.MergeQueueEntry) int32 {
+	if entry.DiffSize == nil {
+		return math.MaxInt32
+	}
+	return *entry.DiffSize
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// releaseDue reports whether a merged batch is waiting to be released:
+// a release is configured and a merge has landed since the last release.
+func releaseDue(refinery *gastownv1alpha1.Refinery) bool {
+	if refinery.Spec.Release == nil || refinery.Status.LastMergeTime == nil {
+		return false
+	}
+	last := refinery.Status.LastRelease
+	if last == nil || last.Time == nil {
+		return true
+	}
+	return refinery.Status.LastMergeTime.After(last.Time.Time)
+}
+
+// cutRelease tags the target branch with the next version and, if configured,
+// creates a matching GitHub release.
+func (r *RefineryReconciler) cutRelease(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+) (*gastownv1alpha1.ReleaseStatus, error) {
+	log := logf.FromContext(ctx)
+	spec := refinery.Spec.Release
+
+	targetBranch := refinery.Spec.TargetBranch
+	if targetBranch == "" {
+		targetBranch = "main"
+	}
+
+	gitClient, cleanup, err := r.openRepository(ctx, refinery)
+	if err != nil {
+		return nil, err
+	}
+	defer cleanup()
+
+	tagger, ok := gitClient.(git.Tagger)
+	if !ok {
+		return nil, fmt.Errorf("git client does not support tagging")
+	}
+
+	latest, err := tagger.LatestTag(ctx, spec.TagPrefix)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tags: %w", err)
+	}
+
+	now := time.Now()
+	tag, err := git.NextVersion(latest, string(spec.VersionPolicy), spec.TagPrefix, now)
+	if err != nil {
+		return nil, err
+	}
+
+	log.Info("Cutting release", "tag", tag, "previous", latest, "targetBranch", targetBranch)
+
+	commit, err := tagger.CreateTag(ctx, tag, targetBranch, "Release "+tag)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create tag %s: %w", tag, err)
+	}
+	err = tagger.PushTag(ctx, tag)
+	r.Audit.Record("refinery", audit.ActionPushTag, refinery, map[string]string{"tag": tag, "commit": commit}, err)
+	if err != nil {
+		return nil, fmt.Errorf("failed to push tag %s: %w", tag, err)
+	}
+
+	release := &gastownv1alpha1.ReleaseStatus{
+		Tag:    tag,
+		Commit: commit,
+		Time:   &metav1.Time{Time: now},
+	}
+
+	if spec.GitHubRelease {
+		releaseURL, err := r.createGitHubRelease(ctx, refinery, tag, commit)
+		if err != nil {
+			// The tag is already pushed; record it so the next reconcile does
+			// not cut a second version for the same batch.
+			return release, fmt.Errorf("tag %s pushed but GitHub release failed: %w", tag, err)
+		}
+		release.URL = releaseURL
+	}
+
+	return release, nil
+}
+
+// createGitHubRelease creates a GitHub release for tag using the token from
+// the release spec's githubTokenSecretRef.
+func (r *RefineryReconciler) createGitHubRelease(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, tag, commit string,
+) (string, error) {
+	spec := refinery.Spec.Release
+	gh, owner, repo, err := r.githubRepoClient(ctx, refinery, spec.GitHubTokenSecretRef, spec.GitHubAPIURL)
+	if err != nil {
+		return "", err
+	}
+	return gh.CreateRelease(ctx, owner, repo, tag, commit)
+}
+
+// githubRepoClient returns a GitHub client authenticated with the token in
+// tokenRef, along with the owner and name of the Rig's repository.
+func (r *RefineryReconciler) githubRepoClient(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, tokenRef *gastownv1alpha1.SecretKeyRef, apiURL string,
+) (*git.GitHubClient, string, string, error) {
+	if tokenRef == nil {
+		return nil, "", "", fmt.Errorf("githubTokenSecretRef is required for GitHub operations")
+	}
+	token, err := r.readSecretKey(ctx, refinery.Namespace, tokenRef)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	rig := &gastownv1alpha1.Rig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: refinery.Spec.RigRef}, rig); err != nil {
+		return nil, "", "", fmt.Errorf("failed to get rig %s: %w", refinery.Spec.RigRef, err)
+	}
+	owner, repo, err := git.ParseGitHubRepo(rig.Spec.GitURL)
+	if err != nil {
+		return nil, "", "", err
+	}
+
+	return git.NewGitHubClient(apiURL, token), owner, repo, nil
+}
+
+// readSecretKey returns the whitespace-trimmed value of a Secret key in namespace.
+func (r *RefineryReconciler) readSecretKey(
+	ctx context.Context, namespace string, ref *gastownv1alpha1.SecretKeyRef,
+) (string, error) {
+	secret := &corev1.Secret{}
+	secretKey := types.NamespacedName{Name: ref.Name, Namespace: namespace}
+	if err := r.Get(ctx, secretKey, secret); err != nil {
+		return "", fmt.Errorf("failed to get secret %s: %w", secretKey, err)
+	}
+	value, ok := secret.Data[ref.Key]
+	if !ok {
+		return "", fmt.Errorf("key %s not found in secret %s", ref.Key, secretKey)
+	}
+	return strings.TrimSpace(string(value)), nil
+}
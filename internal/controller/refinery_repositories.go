@@ -0,0 +1,152 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/types"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/audit"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// mergeRepositories merges the polecat's branch in each rig repository of
+// its spec.repositories, once the rig's gitURL merged and passed the
+// Refinery's tests. Repositories recorded in status.mergedCommits are skipped
+// on retries, and so are repositories the agent pushed no branch to.
+func (r *RefineryReconciler) mergeRepositories(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, polecat *gastownv1alpha1.Polecat,
+	sourceBranch, message string,
+) error {
+	if len(polecat.Spec.Repositories) == 0 {
+		return nil
+	}
+
+	rig := &gastownv1alpha1.Rig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: refinery.Spec.RigRef}, rig); err != nil {
+		return fmt.Errorf("failed to get rig %s: %w", refinery.Spec.RigRef, err)
+	}
+
+	for _, name := range polecat.Spec.Repositories {
+		if _, merged := polecat.Status.MergedCommits[name]; merged {
+			continue
+		}
+		i := slices.IndexFunc(rig.Spec.Repositories, func(repo gastownv1alpha1.RigRepository) bool {
+			return repo.Name == name
+		})
+		if i < 0 {
+			return fmt.Errorf("rig %s has no repository %q", rig.Name, name)
+		}
+
+		commit, err := r.mergeRepository(ctx, refinery, rig, rig.Spec.Repositories[i], sourceBranch, message)
+		if err != nil {
+			return fmt.Errorf("merge failed in repository %s: %w", name, err)
+		}
+		if commit == "" {
+			continue
+		}
+		if polecat.Status.MergedCommits == nil {
+			polecat.Status.MergedCommits = map[string]string{}
+		}
+		polecat.Status.MergedCommits[name] = commit
+		if err := r.applyPolecatStatus(ctx, polecat); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// mergeRepository merges sourceBranch into the branch of a rig repository
+// and returns the merged commit, or "" when the repository has no
+// sourceBranch. The Refinery's testCommand is not run there.
+func (r *RefineryReconciler) mergeRepository(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery, rig *gastownv1alpha1.Rig,
+	repo gastownv1alpha1.RigRepository, sourceBranch, message string,
+) (string, error) {
+	log := logf.FromContext(ctx)
+
+	secretRef := repo.GitSecretRef
+	if secretRef == nil {
+		secretRef = refinery.Spec.GitSecretRef
+	}
+	gitClient, cleanup, err := r.cloneRepository(ctx, refinery, rig, repo.GitURL, secretRef)
+	if err != nil {
+		return "", err
+	}
+	defer cleanup()
+
+	if deleter, ok := gitClient.(git.BranchDeleter); ok {
+		exists, err := deleter.BranchExists(ctx, sourceBranch)
+		if err != nil {
+			return "", err
+		}
+		if !exists {
+			log.Info("Branch not pushed to repository, nothing to merge",
+				"repository", repo.Name, "sourceBranch", sourceBranch)
+			return "", nil
+		}
+	}
+
+	targetBranch := repo.Branch
+	if targetBranch == "" {
+		targetBranch = "main"
+	}
+	result, err := mergeWithRetries(ctx, gitClient, git.MergeOptions{
+		SourceBranch:       sourceBranch,
+		TargetBranch:       targetBranch,
+		DeleteSourceBranch: true,
+		Method:             string(mergeMethod(refinery)),
+		CommitMessage:      message,
+	})
+	if err == nil && !result.Success {
+		err = errors.New(result.Error)
+	}
+	r.Audit.Record("refinery", audit.ActionMerge, refinery, map[string]string{
+		"repository": repo.Name, "sourceBranch": sourceBranch, "targetBranch": targetBranch,
+	}, err)
+	if err != nil {
+		return "", err
+	}
+
+	log.Info("Merged branch in repository",
+		"repository", repo.Name,
+		"mergedCommit", result.MergedCommit,
+		"sourceBranch", sourceBranch,
+		"targetBranch", targetBranch)
+	return result.MergedCommit, nil
+}
+
+// mergeWithRetries runs MergeBranch, rebasing again when another lane (or a
+// human) pushed to the target branch between the fetch and the push.
+func mergeWithRetries(ctx context.Context, gitClient git.GitClient, opts git.MergeOptions) (*git.MergeResult, error) {
+	for attempt := 1; ; attempt++ {
+		result, err := gitClient.MergeBranch(ctx, opts)
+		if !errors.Is(err, git.ErrTargetMoved) || attempt >= refineryMaxMergeAttempts {
+			return result, err
+		}
+		logf.FromContext(ctx).Info("Target branch moved, retrying rebase",
+			"sourceBranch", opts.SourceBranch,
+			"targetBranch", opts.TargetBranch,
+			"attempt", attempt)
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// repositoryGitClient merges into one repository of a multi-repository rig,
+// recording its merges in the shared merged map by git URL, and their order.
+type repositoryGitClient struct {
+	gitURL   string
+	branches map[string]bool
+	merged   map[string][]git.MergeOptions
+	order    *[]string
+	mergeErr error
+	testErr  error
+}
+
+func (m *repositoryGitClient) Clone(ctx context.Context) error { return nil }
+
+func (m *repositoryGitClient) MergeBranch(ctx context.Context, opts git.MergeOptions) (*git.MergeResult, error) {
+	if m.mergeErr != nil {
+		return &git.MergeResult{Error: m.mergeErr.Error()}, m.mergeErr
+	}
+	if m.testErr != nil && opts.TestCommand != "" {
+		return &git.MergeResult{Error: "tests failed: " + m.testErr.Error()}, m.testErr
+	}
+	m.merged[m.gitURL] = append(m.merged[m.gitURL], opts)
+	*m.order = append(*m.order, m.gitURL)
+	return &git.MergeResult{Success: true, MergedCommit: "merged-" + m.gitURL}, nil
+}
+
+func (m *repositoryGitClient) BranchExists(ctx context.Context, branch string) (bool, error) {
+	return m.branches[branch], nil
+}
+
+func (m *repositoryGitClient) DeleteRemoteBranch(ctx context.Context, branch string) error {
+	return nil
+}
+
+var _ = Describe("Multi-repository merges", func() {
+	const (
+		apiURL = "git@github.com:org/api.git"
+		webURL = "git@github.com:org/web.git"
+		docURL = "git@github.com:org/docs.git"
+	)
+	var (
+		ctx         context.Context
+		r           *RefineryReconciler
+		refinery    *gastownv1alpha1.Refinery
+		polecat     *gastownv1alpha1.Polecat
+		merged      map[string][]git.MergeOptions
+		mergeOrder  []string
+		failing     map[string]error
+		failingTest map[string]error
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		rig := &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "app"},
+			Spec: gastownv1alpha1.RigSpec{
+				GitURL: apiURL,
+				Repositories: []gastownv1alpha1.RigRepository{
+					{Name: "web", GitURL: webURL, Branch: "trunk"},
+					{Name: "docs", GitURL: docURL},
+				},
+			},
+		}
+		polecat = &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:             "app",
+				BeadID:          "ap-1",
+				TaskDescription: "Share the login form",
+				Repositories:    []string{"web", "docs"},
+			},
+			Status: gastownv1alpha1.PolecatStatus{Branch: "polecat/furiosa"},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(rig, polecat).
+			WithStatusSubresource(&gastownv1alpha1.Polecat{}).
+			Build()
+
+		merged = map[string][]git.MergeOptions{}
+		mergeOrder = nil
+		failing = map[string]error{}
+		failingTest = map[string]error{}
+		r = &RefineryReconciler{
+			Client:   c,
+			Scheme:   scheme,
+			Recorder: record.NewFakeRecorder(10),
+			GitClientFactory: func(repoDir, gitURL, sshKeyPath string) git.GitClient {
+				return &repositoryGitClient{
+					gitURL: gitURL,
+					// The agent changed nothing in the docs
+					branches: map[string]bool{"polecat/furiosa": gitURL != docURL},
+					merged:   merged,
+					order:    &mergeOrder,
+					mergeErr: failing[gitURL],
+					testErr:  failingTest[gitURL],
+				}
+			},
+		}
+		refinery = &gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "default"},
+			Spec:       gastownv1alpha1.RefinerySpec{RigRef: "app", TestCommand: "make test"},
+		}
+	})
+
+	It("should merge the branch in each rig repository after the primary one", func() {
+		Expect(r.processMerge(ctx, refinery, polecat, "")).To(Succeed())
+
+		Expect(mergeOrder).To(Equal([]string{apiURL, webURL}))
+		Expect(merged[webURL]).To(HaveLen(1))
+		Expect(merged[webURL][0].TargetBranch).To(Equal("trunk"))
+		Expect(merged[webURL][0].TestCommand).To(BeEmpty())
+		Expect(merged[webURL][0].CommitMessage).To(Equal("ap-1: Share the login form"))
+		Expect(merged).NotTo(HaveKey(docURL))
+		Expect(merged[apiURL]).To(HaveLen(1))
+		Expect(merged[apiURL][0].TestCommand).To(Equal("make test"))
+
+		Expect(polecat.Status.MergedCommit).To(Equal("merged-" + apiURL))
+		Expect(polecat.Status.MergedCommits).To(Equal(map[string]string{"web": "merged-" + webURL}))
+		Expect(meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionMerged)).To(BeTrue())
+	})
+
+	It("should leave every repository unmerged when the primary fails its tests", func() {
+		failingTest[apiURL] = fmt.Errorf("exit status 2")
+		err := r.processMerge(ctx, refinery, polecat, "")
+		Expect(err).To(MatchError(ContainSubstring("merge failed: exit status 2")))
+		Expect(merged).To(BeEmpty())
+		Expect(polecat.Status.MergedCommit).To(BeEmpty())
+		Expect(polecat.Status.MergedCommits).To(BeEmpty())
+		Expect(meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionMerged)).To(BeFalse())
+	})
+
+	It("should finish merging the other repositories on the retry", func() {
+		failing[webURL] = fmt.Errorf("rebase conflict")
+		err := r.processMerge(ctx, refinery, polecat, "")
+		Expect(err).To(MatchError(ContainSubstring("merge failed in repository web: rebase conflict")))
+		Expect(merged[apiURL]).To(HaveLen(1))
+		Expect(polecat.Status.MergedCommit).To(Equal("merged-" + apiURL))
+		Expect(meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionMerged)).To(BeFalse())
+
+		By("skipping the primary repository on the retry")
+		delete(failing, webURL)
+		Expect(r.processMerge(ctx, refinery, polecat, "")).To(Succeed())
+		Expect(merged[apiURL]).To(HaveLen(1))
+		Expect(merged[webURL]).To(HaveLen(1))
+		Expect(meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionMerged)).To(BeTrue())
+
+		By("skipping repositories already merged")
+		polecat.Status.MergedCommit = ""
+		Expect(r.processMerge(ctx, refinery, polecat, "")).To(Succeed())
+		Expect(merged[webURL]).To(HaveLen(1))
+	})
+
+	It("should fail for a repository the rig does not have", func() {
+		polecat.Spec.Repositories = []string{"mobile"}
+		Expect(r.processMerge(ctx, refinery, polecat, "")).
+			To(MatchError(ContainSubstring(`rig app has no repository "mobile"`)))
+	})
+})
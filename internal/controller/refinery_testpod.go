@@ -0,0 +1,194 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/internal/git"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+const (
+	// defaultTestTimeout is how long a test Pod may take when
+	// spec.testTimeout is unset.
+	defaultTestTimeout = 30 * time.Minute
+
+	// testFailureMessageBytes bounds the log tail quoted in a test failure
+	testFailureMessageBytes = 1024
+)
+
+// awaitTestPod runs spec.testCommand on the lane's published commit in a test
+// Pod, creating the Pod on first call. It reports whether the tests passed;
+// a failed or timed out Pod is an error.
+func (r *RefineryReconciler) awaitTestPod(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+	polecat *gastownv1alpha1.Polecat, lane *gastownv1alpha1.ActiveMerge,
+) (bool, error) {
+	name := testPodName(refinery, lane.CheckedCommit)
+	var p corev1.Pod
+	err := r.Get(ctx, types.NamespacedName{Name: name, Namespace: refinery.Namespace}, &p)
+	if apierrors.IsNotFound(err) {
+		return false, r.createTestPod(ctx, refinery, polecat, lane)
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get test pod %s: %w", name, err)
+	}
+
+	switch p.Status.Phase {
+	case corev1.PodSucceeded:
+		return true, nil
+	case corev1.PodFailed:
+		return false, testPodFailure(&p, lane.CheckedCommit)
+	}
+
+	// Pods that never start are not bounded by their deadline
+	timeout := testTimeout(refinery)
+	if lane.ChecksStartedAt != nil && time.Since(lane.ChecksStartedAt.Time) > timeout {
+		return false, fmt.Errorf("tests on %s did not complete within %s", lane.CheckedCommit, timeout)
+	}
+	return false, nil
+}
+
+// createTestPod creates the test Pod of the lane's published commit. The Pod
+// clones with the SSH key of spec.gitSecretRef; the test command never sees it.
+func (r *RefineryReconciler) createTestPod(
+	ctx context.Context, refinery *gastownv1alpha1.Refinery,
+	polecat *gastownv1alpha1.Polecat, lane *gastownv1alpha1.ActiveMerge,
+) error {
+	if err := git.ValidateTestCommand(refinery.Spec.TestCommand); err != nil {
+		return fmt.Errorf("test command validation failed: %w", err)
+	}
+
+	rig := &gastownv1alpha1.Rig{}
+	if err := r.Get(ctx, types.NamespacedName{Name: refinery.Spec.RigRef}, rig); err != nil {
+		return fmt.Errorf("failed to get rig %s: %w", refinery.Spec.RigRef, err)
+	}
+
+	var secretName string
+	if ref := refinery.Spec.GitSecretRef; ref != nil {
+		secret := &corev1.Secret{}
+		if err := r.Get(ctx, types.NamespacedName{Name: ref.Name, Namespace: refinery.Namespace}, secret); err != nil {
+			return fmt.Errorf("failed to get git secret %s: %w", ref.Name, err)
+		}
+		if _, ok := secret.Data[githubAppIDKey]; ok {
+			return fmt.Errorf("test pods clone with an SSH key; the GitHub App credentials in secret %s "+
+				"are not supported with testImage", ref.Name)
+		}
+		secretName = ref.Name
+	}
+
+	timeout := testTimeout(refinery)
+	testPod := pod.TestPod(pod.TestPodOptions{
+		Name:            testPodName(refinery, lane.CheckedCommit),
+		Namespace:       refinery.Namespace,
+		Labels:          map[string]string{"gastown.io/polecat": polecat.Name},
+		Refinery:        refinery.Name,
+		Image:           refinery.Spec.TestImage,
+		Command:         refinery.Spec.TestCommand,
+		GitURL:          rig.Spec.GitURL,
+		Branch:          polecat.Status.Branch,
+		Commit:          lane.CheckedCommit,
+		GitSecretName:   secretName,
+		Resources:       refinery.Spec.TestResources,
+		DeadlineSeconds: int64(timeout.Seconds()),
+	})
+	if err := controllerutil.SetControllerReference(refinery, testPod, r.Scheme); err != nil {
+		return fmt.Errorf("failed to set owner reference on test pod: %w", err)
+	}
+	if err := r.Create(ctx, testPod); err != nil {
+		if apierrors.IsAlreadyExists(err) {
+			// Created by a reconcile the cache has not caught up with
+			return nil
+		}
+		return fmt.Errorf("failed to create test pod: %w", err)
+	}
+
+	logf.FromContext(ctx).Info("Started test pod",
+		"polecat", polecat.Name, "commit", lane.CheckedCommit, "pod", testPod.Name)
+	r.Recorder.Event(refinery, "Normal", "TestsStarted",
+		fmt.Sprintf("Testing %s at %s in pod %s", polecat.Status.Branch, lane.CheckedCommit, testPod.Name))
+	return nil
+}
+
+// deleteTestPod removes the test Pod of a commit once its lane is done with it.
+func (r *RefineryReconciler) deleteTestPod(ctx context.Context, refinery *gastownv1alpha1.Refinery, commit string) {
+	if commit == "" {
+		return
+	}
+	testPod := &corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      testPodName(refinery, commit),
+		Namespace: refinery.Namespace,
+	}}
+	if err := r.Delete(ctx, testPod, client.PropagationPolicy(metav1.DeletePropagationBackground)); err != nil &&
+		!apierrors.IsNotFound(err) {
+		logf.FromContext(ctx).Error(err, "Failed to delete test pod", "pod", testPod.Name)
+	}
+}
+
+// testPodFailure explains why a test Pod failed, quoting the tail of the
+// failing container's log.
+func testPodFailure(p *corev1.Pod, commit string) error {
+	if p.Status.Reason == "DeadlineExceeded" {
+		return fmt.Errorf("tests on %s exceeded the deadline of pod %s", commit, p.Name)
+	}
+	for _, cs := range p.Status.InitContainerStatuses {
+		if t := cs.State.Terminated; t != nil && t.ExitCode != 0 {
+			return fmt.Errorf("checkout of %s failed: %s", commit, failureMessage(t.Message))
+		}
+	}
+	for _, cs := range p.Status.ContainerStatuses {
+		if t := cs.State.Terminated; cs.Name == pod.TestContainerName && t != nil {
+			return fmt.Errorf("tests failed on %s (exit code %d): %s", commit, t.ExitCode, failureMessage(t.Message))
+		}
+	}
+	return fmt.Errorf("test pod %s failed: %s", p.Name, p.Status.Message)
+}
+
+// failureMessage trims a termination message to its tail.
+func failureMessage(message string) string {
+	return tailString([]byte(strings.TrimSpace(message)), testFailureMessageBytes, false)
+}
+
+// testPodName names the test Pod of a commit, so that creation is idempotent
+// across reconciles.
+func testPodName(refinery *gastownv1alpha1.Refinery, commit string) string {
+	if len(commit) > 12 {
+		commit = commit[:12]
+	}
+	return refinery.Name + "-test-" + commit
+}
+
+// testTimeout returns how long a test Pod may take.
+func testTimeout(refinery *gastownv1alpha1.Refinery) time.Duration {
+	if t := refinery.Spec.TestTimeout; t != nil && t.Duration > 0 {
+		return t.Duration
+	}
+	return defaultTestTimeout
+}
@@ -20,25 +20,32 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"time"
 
+	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/source"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
 	gterrors "github.com/org/gastown-operator/pkg/errors"
 	"github.com/org/gastown-operator/pkg/metrics"
 )
 
 const (
-	// RigSyncInterval is how often we re-sync rig status.
-	// Uses RequeueDefault for normal sync operations.
+	// RigSyncInterval is how often we re-sync rig status by default.
+	// The GastownConfig's requeue.default overrides it (see requeueDefault),
+	// and a rig's spec.syncIntervalSeconds overrides both.
 	RigSyncInterval = RequeueDefault
 
 	// Condition types for Rig.
@@ -47,11 +54,14 @@ const (
 	// ConditionRigReady uses the standard Ready condition.
 	ConditionRigReady = ConditionReady
 
+	// ConditionRigSuspended indicates the rig is frozen (spec.suspend).
+	ConditionRigSuspended = "Suspended"
+
 	// rigFinalizer ensures cleanup of child resources (Witness, Refinery)
 	rigFinalizer = "gastown.io/rig-cleanup"
 
 	// defaultChildNamespace is where Witness/Refinery CRs are created
-	// Can be overridden by GASTOWN_NAMESPACE env var
+	// Can be overridden by GASTOWN_NAMESPACE env var, or per rig by spec.childNamespace
 	defaultChildNamespace = "gastown-system"
 )
 
@@ -59,7 +69,13 @@ const (
 // It aggregates status from child Polecats and Convoys.
 type RigReconciler struct {
 	client.Client
-	Scheme *runtime.Scheme
+	Scheme   *runtime.Scheme
+	Recorder record.EventRecorder
+
+	// Triggers enqueues Rigs on demand (e.g., git webhooks). Optional.
+	Triggers <-chan event.GenericEvent
+	// Tuning overrides the controller's workqueue settings. Optional.
+	Tuning config.ControllerTuning
 }
 
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=rigs,verbs=get;list;watch;create;update;patch;delete
@@ -67,8 +83,20 @@ type RigReconciler struct {
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=rigs/finalizers,verbs=update
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats,verbs=get;list;watch
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=convoys,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=emergencystops,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=beadstores,verbs=get;list;watch
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=witnesses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=refineries,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups="",resources=resourcequotas,verbs=get;list;watch;create;update;patch
+// +kubebuilder:rbac:groups=networking.k8s.io,resources=networkpolicies,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=serviceaccounts,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch
+// +kubebuilder:rbac:groups="",resources=pods,verbs=get;list;watch;create;delete
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;rolebindings,verbs=get;list;watch;create;update;patch;delete
+// +kubebuilder:rbac:groups=rbac.authorization.k8s.io,resources=roles;clusterroles,verbs=bind;escalate
 
 // Reconcile aggregates status from Polecats and Convoys in the Rig.
 // It also auto-provisions Witness and Refinery CRs when a Rig is created.
@@ -84,6 +112,15 @@ func (r *RigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave resources processed by a newer operator alone
+	if skip, err := checkSchemaVersion(ctx, r.Client, r.Recorder, &rig, &rig.Status.Conditions); err != nil {
+		timer.RecordResult(metrics.ResultError)
+		return ctrl.Result{}, err
+	} else if skip {
+		timer.RecordResult(metrics.ResultSuccess)
+		return ctrl.Result{}, nil
+	}
+
 	log.Info("Reconciling Rig", "name", rig.Name)
 
 	// Handle deletion with finalizer
@@ -94,8 +131,9 @@ func (r *RigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	// Add finalizer if not present
 	if !controllerutil.ContainsFinalizer(&rig, rigFinalizer) {
 		log.Info("Adding finalizer to Rig")
-		controllerutil.AddFinalizer(&rig, rigFinalizer)
-		if err := r.Update(ctx, &rig); err != nil {
+		if err := gterrors.UpdateOnConflict(ctx, r.Client, &rig, func() bool {
+			return controllerutil.AddFinalizer(&rig, rigFinalizer)
+		}); err != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(err, "failed to add finalizer")
 		}
@@ -106,32 +144,34 @@ func (r *RigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 	// Ensure Witness and Refinery children exist
 	if err := r.ensureChildren(ctx, &rig); err != nil {
 		log.Error(err, "Failed to ensure child resources")
+		r.Recorder.Event(&rig, "Warning", "ChildCreationFailed", err.Error())
 		r.setCondition(&rig, ConditionRigReady, metav1.ConditionFalse, "ChildCreationFailed",
 			err.Error())
 		rig.Status.Phase = gastownv1alpha1.RigPhaseDegraded
-		if updateErr := r.Status().Update(ctx, &rig); updateErr != nil {
+		if updateErr := applyStatus(ctx, r.Client, &rig, fieldManagerRig); updateErr != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update rig status")
 		}
 		timer.RecordResult(metrics.ResultRequeue)
-		return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+		return ctrl.Result{RequeueAfter: requeueDefault()}, nil
 	}
 
 	// Count polecats for this rig
 	var polecatList gastownv1alpha1.PolecatList
 	if err := r.List(ctx, &polecatList, client.MatchingFields{"spec.rig": rig.Name}); err != nil {
 		log.Error(err, "Failed to list polecats for rig")
+		r.Recorder.Event(&rig, "Warning", "ListFailed", err.Error())
 		r.setCondition(&rig, ConditionRigReady, metav1.ConditionFalse, "ListFailed",
 			err.Error())
 		rig.Status.Phase = gastownv1alpha1.RigPhaseDegraded
 
-		if updateErr := r.Status().Update(ctx, &rig); updateErr != nil {
+		if updateErr := applyStatus(ctx, r.Client, &rig, fieldManagerRig); updateErr != nil {
 			timer.RecordResult(metrics.ResultError)
 			return ctrl.Result{}, gterrors.Wrap(updateErr, "failed to update rig status")
 		}
 
 		timer.RecordResult(metrics.ResultRequeue)
-		return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+		return ctrl.Result{RequeueAfter: requeueDefault()}, nil
 	}
 
 	// Count convoys for this rig using field index
@@ -145,17 +185,59 @@ func (r *RigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 				activeConvoys++
 			}
 		}
+		rig.Status.Convoys = rigConvoySummaries(convoyList.Items)
+	}
+
+	if last, err := r.lastMergeTime(ctx, rig.Name); err != nil {
+		log.Error(err, "Failed to list refineries for rig")
+	} else if last != nil {
+		rig.Status.LastMergeTime = last
 	}
 
 	// Update status
 	rig.Status.Phase = gastownv1alpha1.RigPhaseReady
 	rig.Status.PolecatCount = len(polecatList.Items)
+	rig.Status.Polecats = rigPolecatCounts(polecatList.Items)
 	rig.Status.ActiveConvoys = activeConvoys
+	rig.Status.Usage = rigUsage(polecatList.Items, time.Now())
 
 	r.setCondition(&rig, ConditionRigReady, metav1.ConditionTrue, "Ready",
 		"Rig is ready")
+	wasSuspended := meta.IsStatusConditionTrue(rig.Status.Conditions, ConditionRigSuspended)
+	stop, err := activeEmergencyStop(ctx, r.Client)
+	if err != nil {
+		log.Error(err, "Failed to list emergency stops")
+	}
+	if stop != nil {
+		r.setCondition(&rig, ConditionRigSuspended, metav1.ConditionTrue, "EmergencyStop",
+			fmt.Sprintf("Emergency stop %s engaged: %s", stop.Name, stop.Spec.Reason))
+	} else if rig.Spec.Suspend {
+		r.setCondition(&rig, ConditionRigSuspended, metav1.ConditionTrue, "Frozen",
+			"New work and merges are paused")
+	} else {
+		r.setCondition(&rig, ConditionRigSuspended, metav1.ConditionFalse, "Active",
+			"Rig is accepting work")
+	}
+	if suspended := meta.FindStatusCondition(rig.Status.Conditions, ConditionRigSuspended); suspended != nil {
+		switch {
+		case suspended.Status == metav1.ConditionTrue && !wasSuspended:
+			r.Recorder.Event(&rig, "Normal", "Suspended", suspended.Message)
+		case suspended.Status == metav1.ConditionFalse && wasSuspended:
+			r.Recorder.Event(&rig, "Normal", "Resumed", suspended.Message)
+		}
+	}
+
+	// Warm Pods wait for beads only while the rig accepts work
+	warmPods, err := r.ensureWarmPool(ctx, &rig, stop != nil || rig.Spec.Suspend)
+	if err != nil {
+		log.Error(err, "Failed to ensure warm pool")
+		r.Recorder.Event(&rig, "Warning", "WarmPoolFailed", err.Error())
+	}
+	rig.Status.WarmPods = warmPods
 
-	if err := r.Status().Update(ctx, &rig); err != nil {
+	r.checkCredentials(ctx, &rig, polecatList.Items, time.Now())
+
+	if err := applyStatus(ctx, r.Client, &rig, fieldManagerRig); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to update rig status")
 	}
@@ -165,14 +247,19 @@ func (r *RigReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.R
 		"convoys", rig.Status.ActiveConvoys)
 
 	timer.RecordResult(metrics.ResultSuccess)
-	return ctrl.Result{RequeueAfter: RigSyncInterval}, nil
+	return ctrl.Result{RequeueAfter: syncInterval(rig.Spec.SyncIntervalSeconds, requeueDefault())}, nil
 }
 
-// ensureChildren creates Witness and Refinery CRs for the Rig if they don't exist.
+// ensureChildren creates Witness and Refinery CRs for the Rig if they don't exist,
+// in the rig's namespace (provisioned first when spec.createNamespace is set).
 // This is the key auto-provisioning logic: creating a Rig gives you full Gas Town functionality.
 func (r *RigReconciler) ensureChildren(ctx context.Context, rig *gastownv1alpha1.Rig) error {
 	log := logf.FromContext(ctx)
-	ns := r.getChildNamespace()
+	ns := r.childNamespaceFor(rig)
+
+	if err := r.ensureNamespace(ctx, rig); err != nil {
+		return err
+	}
 
 	// Track if we made changes
 	statusChanged := false
@@ -183,6 +270,19 @@ func (r *RigReconciler) ensureChildren(ctx context.Context, rig *gastownv1alpha1
 		statusChanged = true
 	}
 
+	serviceAccount, err := r.ensureAgentServiceAccount(ctx, rig, ns)
+	if err != nil {
+		return err
+	}
+	if rig.Status.AgentServiceAccount != serviceAccount {
+		rig.Status.AgentServiceAccount = serviceAccount
+		statusChanged = true
+	}
+
+	if err := r.ensureAgentNetworkPolicy(ctx, rig, ns); err != nil {
+		return err
+	}
+
 	// Ensure Witness
 	if !rig.Status.WitnessCreated {
 		witnessName := rig.Name + "-witness"
@@ -210,6 +310,7 @@ func (r *RigReconciler) ensureChildren(ctx context.Context, rig *gastownv1alpha1
 			log.Info("Witness already exists", "name", witnessName)
 		} else {
 			log.Info("Created Witness for Rig", "witness", witnessName, "rig", rig.Name)
+			r.Recorder.Event(rig, "Normal", "WitnessCreated", "Created Witness "+ns+"/"+witnessName)
 		}
 		rig.Status.WitnessCreated = true
 		statusChanged = true
@@ -240,6 +341,7 @@ func (r *RigReconciler) ensureChildren(ctx context.Context, rig *gastownv1alpha1
 			log.Info("Refinery already exists", "name", refineryName)
 		} else {
 			log.Info("Created Refinery for Rig", "refinery", refineryName, "rig", rig.Name)
+			r.Recorder.Event(rig, "Normal", "RefineryCreated", "Created Refinery "+ns+"/"+refineryName)
 		}
 		rig.Status.RefineryCreated = true
 		statusChanged = true
@@ -247,7 +349,7 @@ func (r *RigReconciler) ensureChildren(ctx context.Context, rig *gastownv1alpha1
 
 	// Update status if changed
 	if statusChanged {
-		if err := r.Status().Update(ctx, rig); err != nil {
+		if err := applyStatus(ctx, r.Client, rig, fieldManagerRig); err != nil {
 			return fmt.Errorf("failed to update rig status after child creation: %w", err)
 		}
 	}
@@ -256,7 +358,8 @@ func (r *RigReconciler) ensureChildren(ctx context.Context, rig *gastownv1alpha1
 }
 
 // handleDeletion handles cleanup when a Rig is being deleted.
-// It deletes the auto-provisioned Witness and Refinery CRs.
+// It deletes the auto-provisioned Witness and Refinery CRs and the agent
+// ServiceAccount and NetworkPolicy.
 func (r *RigReconciler) handleDeletion(ctx context.Context, rig *gastownv1alpha1.Rig, timer *metrics.ReconcileTimer) (ctrl.Result, error) {
 	log := logf.FromContext(ctx)
 
@@ -267,10 +370,7 @@ func (r *RigReconciler) handleDeletion(ctx context.Context, rig *gastownv1alpha1
 
 	log.Info("Handling Rig deletion, cleaning up child resources", "rig", rig.Name)
 
-	ns := rig.Status.ChildNamespace
-	if ns == "" {
-		ns = r.getChildNamespace()
-	}
+	ns := r.childNamespaceFor(rig)
 
 	// Delete Witness
 	witnessName := rig.Name + "-witness"
@@ -280,7 +380,7 @@ func (r *RigReconciler) handleDeletion(ctx context.Context, rig *gastownv1alpha1
 		if err := r.Delete(ctx, witness); err != nil && !apierrors.IsNotFound(err) {
 			log.Error(err, "Failed to delete Witness", "name", witnessName)
 			timer.RecordResult(metrics.ResultRequeue)
-			return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+			return ctrl.Result{RequeueAfter: requeueDefault()}, nil
 		}
 	} else if !apierrors.IsNotFound(err) {
 		log.Error(err, "Failed to get Witness for deletion", "name", witnessName)
@@ -294,16 +394,31 @@ func (r *RigReconciler) handleDeletion(ctx context.Context, rig *gastownv1alpha1
 		if err := r.Delete(ctx, refinery); err != nil && !apierrors.IsNotFound(err) {
 			log.Error(err, "Failed to delete Refinery", "name", refineryName)
 			timer.RecordResult(metrics.ResultRequeue)
-			return ctrl.Result{RequeueAfter: RequeueDefault}, nil
+			return ctrl.Result{RequeueAfter: requeueDefault()}, nil
 		}
 	} else if !apierrors.IsNotFound(err) {
 		log.Error(err, "Failed to get Refinery for deletion", "name", refineryName)
 	}
 
+	// Delete the agent ServiceAccount and its RBAC
+	if err := r.removeAgentServiceAccount(ctx, rig, ns); err != nil {
+		log.Error(err, "Failed to delete agent service account")
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueDefault()}, nil
+	}
+
+	// Delete the agent NetworkPolicy
+	if err := r.removeAgentNetworkPolicy(ctx, rig, ns); err != nil {
+		log.Error(err, "Failed to delete agent network policy")
+		timer.RecordResult(metrics.ResultRequeue)
+		return ctrl.Result{RequeueAfter: requeueDefault()}, nil
+	}
+
 	// Remove finalizer after successful cleanup
 	log.Info("Cleanup complete, removing finalizer", "rig", rig.Name)
-	controllerutil.RemoveFinalizer(rig, rigFinalizer)
-	if err := r.Update(ctx, rig); err != nil {
+	if err := gterrors.UpdateOnConflict(ctx, r.Client, rig, func() bool {
+		return controllerutil.RemoveFinalizer(rig, rigFinalizer)
+	}); err != nil {
 		timer.RecordResult(metrics.ResultError)
 		return ctrl.Result{}, gterrors.Wrap(err, "failed to remove finalizer")
 	}
@@ -312,9 +427,26 @@ func (r *RigReconciler) handleDeletion(ctx context.Context, rig *gastownv1alpha1
 	return ctrl.Result{}, nil
 }
 
+// isRigSuspended reports whether the named rig has spec.suspend set.
+// A missing rig is treated as not suspended.
+func isRigSuspended(ctx context.Context, c client.Reader, rigName string) (bool, error) {
+	var rig gastownv1alpha1.Rig
+	if err := c.Get(ctx, client.ObjectKey{Name: rigName}, &rig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	return rig.Spec.Suspend, nil
+}
+
 // getChildNamespace returns the namespace where child resources should be created.
-// Uses GASTOWN_NAMESPACE env var if set, otherwise defaults to gastown-system.
+// Uses the GastownConfig's childNamespace, then the GASTOWN_NAMESPACE env var,
+// otherwise defaults to gastown-system.
 func (r *RigReconciler) getChildNamespace() string {
+	if ns := config.Current().ChildNamespace; ns != "" {
+		return ns
+	}
 	if ns := os.Getenv("GASTOWN_NAMESPACE"); ns != "" {
 		return ns
 	}
@@ -354,11 +486,21 @@ func (r *RigReconciler) SetupWithManager(mgr ctrl.Manager) error {
 		return err
 	}
 
-	return ctrl.NewControllerManagedBy(mgr).
+	// Add index for looking up the last merge of the rig's refineries
+	if err := mgr.GetFieldIndexer().IndexField(context.Background(), &gastownv1alpha1.Refinery{}, refineryRigField, func(rawObj client.Object) []string {
+		refinery := rawObj.(*gastownv1alpha1.Refinery)
+		return []string{refinery.Spec.RigRef}
+	}); err != nil {
+		return err
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
 		For(&gastownv1alpha1.Rig{}).
+		Owns(&corev1.Pod{}).
 		Named("rig").
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 3, // Rigs are cluster-scoped, limit concurrency
-		}).
-		Complete(r)
+		WithOptions(controllerOptions(r.Tuning, 3)) // Rigs are cluster-scoped, limit concurrency
+	if r.Triggers != nil {
+		b = b.WatchesRawSource(source.Channel(r.Triggers, &handler.EnqueueRequestForObject{}))
+	}
+	return b.Complete(r)
 }
@@ -21,9 +21,13 @@ import (
 
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
 
@@ -41,8 +45,9 @@ var _ = Describe("Rig Controller", func() {
 		ctx = context.Background()
 
 		reconciler = &RigReconciler{
-			Client: k8sClient,
-			Scheme: k8sClient.Scheme(),
+			Client:   k8sClient,
+			Scheme:   k8sClient.Scheme(),
+			Recorder: &record.FakeRecorder{},
 		}
 
 		testRig = &gastownv1alpha1.Rig{
@@ -203,6 +208,53 @@ var _ = Describe("Rig Controller", func() {
 			Expect(witnessCount).To(Equal(1))
 		})
 
+		It("should provision the rig namespace and place children there", func() {
+			testRig.Spec.ChildNamespace = "rig-provisioned"
+			testRig.Spec.CreateNamespace = true
+			testRig.Spec.Settings.MaxPolecats = 3
+			testRig.Spec.NamespaceQuota = corev1.ResourceList{
+				corev1.ResourceLimitsMemory: resource.MustParse("16Gi"),
+			}
+			Expect(k8sClient.Create(ctx, testRig)).To(Succeed())
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: testRig.Name}}
+			for range 2 {
+				_, err := reconciler.Reconcile(ctx, req)
+				Expect(err).NotTo(HaveOccurred())
+			}
+
+			var ns corev1.Namespace
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "rig-provisioned"}, &ns)).To(Succeed())
+			Expect(ns.Labels).To(HaveKeyWithValue("gastown.io/rig", testRig.Name))
+			Expect(metav1.IsControlledBy(&ns, testRig)).To(BeTrue())
+
+			var quota corev1.ResourceQuota
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "gastown-rig", Namespace: "rig-provisioned"}, &quota)).To(Succeed())
+			Expect(quota.Spec.Hard.Pods().Value()).To(Equal(int64(3 + 4)))
+			Expect(quota.Spec.Hard).To(HaveKey(corev1.ResourceLimitsMemory))
+
+			var policy networkingv1.NetworkPolicy
+			Expect(k8sClient.Get(ctx, types.NamespacedName{Name: "gastown-rig", Namespace: "rig-provisioned"}, &policy)).To(Succeed())
+			Expect(policy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeIngress))
+
+			witness := &gastownv1alpha1.Witness{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      testRig.Name + "-witness",
+				Namespace: "rig-provisioned",
+			}, witness)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, witness) }()
+			refinery := &gastownv1alpha1.Refinery{}
+			Expect(k8sClient.Get(ctx, types.NamespacedName{
+				Name:      testRig.Name + "-refinery",
+				Namespace: "rig-provisioned",
+			}, refinery)).To(Succeed())
+			defer func() { _ = k8sClient.Delete(ctx, refinery) }()
+
+			var updated gastownv1alpha1.Rig
+			Expect(k8sClient.Get(ctx, req.NamespacedName, &updated)).To(Succeed())
+			Expect(updated.Status.ChildNamespace).To(Equal("rig-provisioned"))
+		})
+
 		// Note: Tests for Ready condition and Phase=Ready are skipped in envtest because
 		// they require field indexers which are only set up when using a full manager.
 		// When the polecat list fails due to missing indexer, the rig goes to Degraded.
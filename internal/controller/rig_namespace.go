@@ -0,0 +1,145 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+const (
+	// rigNamespaceLabel names the rig on its provisioned namespace
+	rigNamespaceLabel = "gastown.io/rig"
+
+	// rigNamespacePolicy names the ResourceQuota and NetworkPolicy of a
+	// provisioned rig namespace
+	rigNamespacePolicy = "gastown-rig"
+
+	// rigNamespacePodHeadroom is added to maxPolecats for the default pod
+	// quota, leaving room for image probe and merge Jobs
+	rigNamespacePodHeadroom = 4
+)
+
+// childNamespaceFor returns the namespace of the rig's Witness, Refinery and
+// Polecats: the one recorded in status once children exist, else
+// spec.childNamespace, else the operator-wide default.
+func (r *RigReconciler) childNamespaceFor(rig *gastownv1alpha1.Rig) string {
+	if rig.Status.ChildNamespace != "" {
+		return rig.Status.ChildNamespace
+	}
+	if rig.Spec.ChildNamespace != "" {
+		return rig.Spec.ChildNamespace
+	}
+	return r.getChildNamespace()
+}
+
+// ensureNamespace provisions the rig's namespace when spec.createNamespace
+// is set: the namespace itself, owned by the Rig so it is deleted with it,
+// a ResourceQuota and a NetworkPolicy. An existing namespace is labeled but
+// not adopted, so it outlives the rig.
+func (r *RigReconciler) ensureNamespace(ctx context.Context, rig *gastownv1alpha1.Rig) error {
+	if !rig.Spec.CreateNamespace || rig.Spec.ChildNamespace == "" {
+		return nil
+	}
+	log := logf.FromContext(ctx)
+	name := rig.Spec.ChildNamespace
+	labels := map[string]string{
+		rigNamespaceLabel:              rig.Name,
+		"app.kubernetes.io/managed-by": "rig-controller",
+	}
+
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: name}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, ns, func() error {
+		if ns.Labels == nil {
+			ns.Labels = map[string]string{}
+		}
+		maps.Copy(ns.Labels, labels)
+		if ns.CreationTimestamp.IsZero() {
+			return controllerutil.SetControllerReference(rig, ns, r.Scheme)
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure namespace %s: %w", name, err)
+	}
+	if op == controllerutil.OperationResultCreated {
+		log.Info("Created namespace for Rig", "namespace", name, "rig", rig.Name)
+		r.Recorder.Event(rig, "Normal", "NamespaceCreated", "Created namespace "+name)
+	}
+
+	quota := &corev1.ResourceQuota{ObjectMeta: metav1.ObjectMeta{Name: rigNamespacePolicy, Namespace: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, quota, func() error {
+		quota.Labels = labels
+		quota.Spec.Hard = rigNamespaceQuota(rig)
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to ensure resource quota in %s: %w", name, err)
+	}
+
+	policy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: rigNamespacePolicy, Namespace: name}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		policy.Labels = labels
+		policy.Spec = rigNetworkPolicySpec()
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to ensure network policy in %s: %w", name, err)
+	}
+
+	return nil
+}
+
+// rigNamespaceQuota returns the hard limits of a rig namespace's quota:
+// spec.namespaceQuota, with pods defaulting to maxPolecats plus headroom and
+// the warm pool.
+func rigNamespaceQuota(rig *gastownv1alpha1.Rig) corev1.ResourceList {
+	hard := corev1.ResourceList{}
+	for name, quantity := range rig.Spec.NamespaceQuota {
+		hard[name] = quantity.DeepCopy()
+	}
+	if _, ok := hard[corev1.ResourcePods]; !ok {
+		maxPolecats := rig.Spec.Settings.MaxPolecats
+		if maxPolecats == 0 {
+			maxPolecats = 8
+		}
+		pods := maxPolecats + rigNamespacePodHeadroom + warmPoolSize(rig, false)
+		hard[corev1.ResourcePods] = *resource.NewQuantity(int64(pods), resource.DecimalSI)
+	}
+	return hard
+}
+
+// rigNetworkPolicySpec isolates a rig namespace: Pods accept traffic only
+// from Pods of the same namespace. Egress stays open, since agents reach
+// git remotes and model APIs.
+func rigNetworkPolicySpec() networkingv1.NetworkPolicySpec {
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeIngress},
+		Ingress: []networkingv1.NetworkPolicyIngressRule{{
+			From: []networkingv1.NetworkPolicyPeer{{PodSelector: &metav1.LabelSelector{}}},
+		}},
+	}
+}
@@ -0,0 +1,105 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// agentNetworkPolicyName names the NetworkPolicy restricting the egress of a
+// rig's Pods.
+func agentNetworkPolicyName(rig *gastownv1alpha1.Rig) string {
+	return rig.Name + "-agent-egress"
+}
+
+// ensureAgentNetworkPolicy provisions the NetworkPolicy of
+// spec.networkPolicy in the child namespace, or removes it when the spec no
+// longer asks for one.
+func (r *RigReconciler) ensureAgentNetworkPolicy(ctx context.Context, rig *gastownv1alpha1.Rig, ns string) error {
+	if rig.Spec.NetworkPolicy == nil {
+		return r.removeAgentNetworkPolicy(ctx, rig, ns)
+	}
+
+	name := agentNetworkPolicyName(rig)
+	policy := &networkingv1.NetworkPolicy{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, policy, func() error {
+		policy.Labels = map[string]string{
+			"gastown.io/rig-owner":         rig.Name,
+			"app.kubernetes.io/managed-by": "rig-controller",
+		}
+		policy.Spec = agentNetworkPolicySpec(rig)
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("failed to ensure network policy %s/%s: %w", ns, name, err)
+	}
+	if op == controllerutil.OperationResultCreated {
+		logf.FromContext(ctx).Info("Created agent network policy", "namespace", ns, "name", name)
+		r.Recorder.Event(rig, "Normal", "NetworkPolicyCreated", "Created NetworkPolicy "+ns+"/"+name)
+	}
+	return nil
+}
+
+// removeAgentNetworkPolicy deletes the NetworkPolicy provisioned for the
+// rig's Pods.
+func (r *RigReconciler) removeAgentNetworkPolicy(ctx context.Context, rig *gastownv1alpha1.Rig, ns string) error {
+	return r.deleteIfExists(ctx, &networkingv1.NetworkPolicy{
+		ObjectMeta: metav1.ObjectMeta{Name: agentNetworkPolicyName(rig), Namespace: ns},
+	})
+}
+
+// agentNetworkPolicySpec allows the rig's Pods egress to DNS and to the IP
+// blocks of spec.networkPolicy.egress only.
+func agentNetworkPolicySpec(rig *gastownv1alpha1.Rig) networkingv1.NetworkPolicySpec {
+	udp, tcp := corev1.ProtocolUDP, corev1.ProtocolTCP
+	dns := intstr.FromInt32(53)
+	egress := []networkingv1.NetworkPolicyEgressRule{{
+		Ports: []networkingv1.NetworkPolicyPort{
+			{Protocol: &udp, Port: &dns},
+			{Protocol: &tcp, Port: &dns},
+		},
+	}}
+	for _, rule := range rig.Spec.NetworkPolicy.Egress {
+		allowed := networkingv1.NetworkPolicyEgressRule{
+			To: []networkingv1.NetworkPolicyPeer{{
+				IPBlock: &networkingv1.IPBlock{CIDR: rule.CIDR, Except: rule.Except},
+			}},
+		}
+		for _, port := range rule.Ports {
+			p := intstr.FromInt32(port)
+			allowed.Ports = append(allowed.Ports, networkingv1.NetworkPolicyPort{Protocol: &tcp, Port: &p})
+		}
+		egress = append(egress, allowed)
+	}
+
+	return networkingv1.NetworkPolicySpec{
+		PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"gastown.io/rig": rig.Name}},
+		PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+		Egress:      egress,
+	}
+}
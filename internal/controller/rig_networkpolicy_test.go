@@ -0,0 +1,77 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var _ = Describe("Rig agent network policy", func() {
+	const ns = "gt-np-rig"
+
+	It("should restrict the egress of the rig's Pods and remove the policy with the spec", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+		rig := &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "np-rig"},
+			Spec: gastownv1alpha1.RigSpec{
+				NetworkPolicy: &gastownv1alpha1.RigNetworkPolicy{
+					Egress: []gastownv1alpha1.RigEgressRule{
+						{CIDR: "140.82.112.0/20", Ports: []int32{22, 443}},
+						{CIDR: "0.0.0.0/0", Except: []string{"169.254.169.254/32"}, Ports: []int32{443}},
+					},
+				},
+			},
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig).Build()
+		r := &RigReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+
+		Expect(r.ensureAgentNetworkPolicy(ctx, rig, ns)).To(Succeed())
+		var policy networkingv1.NetworkPolicy
+		key := client.ObjectKey{Name: "np-rig-agent-egress", Namespace: ns}
+		Expect(c.Get(ctx, key, &policy)).To(Succeed())
+		Expect(policy.Spec.PodSelector.MatchLabels).To(Equal(map[string]string{"gastown.io/rig": "np-rig"}))
+		Expect(policy.Spec.PolicyTypes).To(ConsistOf(networkingv1.PolicyTypeEgress))
+
+		// DNS, then one rule per allowed block
+		Expect(policy.Spec.Egress).To(HaveLen(3))
+		Expect(policy.Spec.Egress[0].To).To(BeEmpty())
+		Expect(policy.Spec.Egress[0].Ports[0].Port.IntValue()).To(Equal(53))
+		Expect(policy.Spec.Egress[1].To[0].IPBlock.CIDR).To(Equal("140.82.112.0/20"))
+		Expect(policy.Spec.Egress[1].Ports).To(HaveLen(2))
+		Expect(policy.Spec.Egress[2].To[0].IPBlock.Except).To(Equal([]string{"169.254.169.254/32"}))
+
+		rig.Spec.NetworkPolicy = nil
+		Expect(r.ensureAgentNetworkPolicy(ctx, rig, ns)).To(Succeed())
+		Expect(apierrors.IsNotFound(c.Get(ctx, key, &policy))).To(BeTrue())
+	})
+})
@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// agentServiceAccountName names the ServiceAccount of a rig's agents, and
+// the Role and RoleBinding granting it spec.agentServiceAccount.rules.
+func agentServiceAccountName(rig *gastownv1alpha1.Rig) string {
+	return rig.Name + "-agent"
+}
+
+// agentClusterRoleBindingName names the RoleBinding granting a rig's agents
+// spec.agentServiceAccount.clusterRole.
+func agentClusterRoleBindingName(rig *gastownv1alpha1.Rig) string {
+	return rig.Name + "-agent-clusterrole"
+}
+
+// ensureAgentServiceAccount provisions the ServiceAccount of the rig's agents
+// in the child namespace, with a Role of spec.agentServiceAccount.rules and a
+// binding of its clusterRole. Whatever the spec no longer asks for is
+// removed. It returns the ServiceAccount name, empty when there is none.
+func (r *RigReconciler) ensureAgentServiceAccount(ctx context.Context, rig *gastownv1alpha1.Rig, ns string) (string, error) {
+	spec := rig.Spec.AgentServiceAccount
+	if spec == nil {
+		return "", r.removeAgentServiceAccount(ctx, rig, ns)
+	}
+
+	name := agentServiceAccountName(rig)
+	labels := map[string]string{
+		"gastown.io/rig-owner":         rig.Name,
+		"app.kubernetes.io/managed-by": "rig-controller",
+	}
+	subjects := []rbacv1.Subject{{Kind: rbacv1.ServiceAccountKind, Name: name, Namespace: ns}}
+
+	sa := &corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+	op, err := controllerutil.CreateOrUpdate(ctx, r.Client, sa, func() error {
+		sa.Labels = labels
+		return nil
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to ensure agent service account %s/%s: %w", ns, name, err)
+	}
+	if op == controllerutil.OperationResultCreated {
+		logf.FromContext(ctx).Info("Created agent service account", "namespace", ns, "name", name)
+		r.Recorder.Event(rig, "Normal", "AgentServiceAccountCreated", "Created ServiceAccount "+ns+"/"+name)
+	}
+
+	if len(spec.Rules) > 0 {
+		role := &rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+		if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, role, func() error {
+			role.Labels = labels
+			role.Rules = spec.Rules
+			return nil
+		}); err != nil {
+			return "", fmt.Errorf("failed to ensure agent role %s/%s: %w", ns, name, err)
+		}
+		roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "Role", Name: name}
+		if err := r.ensureRoleBinding(ctx, name, ns, labels, roleRef, subjects); err != nil {
+			return "", err
+		}
+	} else if err := r.deleteIfExists(ctx,
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}},
+	); err != nil {
+		return "", err
+	}
+
+	bindingName := agentClusterRoleBindingName(rig)
+	if spec.ClusterRole != "" {
+		roleRef := rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: spec.ClusterRole}
+		if err := r.ensureRoleBinding(ctx, bindingName, ns, labels, roleRef, subjects); err != nil {
+			return "", err
+		}
+	} else if err := r.deleteIfExists(ctx,
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: bindingName, Namespace: ns}},
+	); err != nil {
+		return "", err
+	}
+
+	return name, nil
+}
+
+// ensureRoleBinding creates or updates a RoleBinding. Since its roleRef is
+// immutable, a binding to another role is replaced.
+func (r *RigReconciler) ensureRoleBinding(
+	ctx context.Context, name, ns string, labels map[string]string, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject,
+) error {
+	var existing rbacv1.RoleBinding
+	err := r.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, &existing)
+	if err == nil && existing.RoleRef != roleRef {
+		if err := r.Delete(ctx, &existing); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to replace role binding %s/%s: %w", ns, name, err)
+		}
+	} else if err != nil && !apierrors.IsNotFound(err) {
+		return fmt.Errorf("failed to get role binding %s/%s: %w", ns, name, err)
+	}
+
+	binding := &rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}}
+	if _, err := controllerutil.CreateOrUpdate(ctx, r.Client, binding, func() error {
+		binding.Labels = labels
+		binding.RoleRef = roleRef
+		binding.Subjects = subjects
+		return nil
+	}); err != nil {
+		return fmt.Errorf("failed to ensure role binding %s/%s: %w", ns, name, err)
+	}
+	return nil
+}
+
+// removeAgentServiceAccount deletes the ServiceAccount, Role and RoleBindings
+// provisioned for the rig's agents.
+func (r *RigReconciler) removeAgentServiceAccount(ctx context.Context, rig *gastownv1alpha1.Rig, ns string) error {
+	name := agentServiceAccountName(rig)
+	return r.deleteIfExists(ctx,
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: agentClusterRoleBindingName(rig), Namespace: ns}},
+		&rbacv1.RoleBinding{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}},
+		&rbacv1.Role{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}},
+		&corev1.ServiceAccount{ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: ns}},
+	)
+}
+
+// deleteIfExists deletes the objects, ignoring those already gone. The
+// objects are looked up in the cache first, so that reconciles of rigs
+// without them do not call the API server.
+func (r *RigReconciler) deleteIfExists(ctx context.Context, objs ...client.Object) error {
+	for _, obj := range objs {
+		if err := r.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+			if apierrors.IsNotFound(err) {
+				continue
+			}
+			return fmt.Errorf("failed to get %T %s/%s: %w", obj, obj.GetNamespace(), obj.GetName(), err)
+		}
+		if err := r.Delete(ctx, obj); err != nil && !apierrors.IsNotFound(err) {
+			return fmt.Errorf("failed to delete %T %s/%s: %w", obj, obj.GetNamespace(), obj.GetName(), err)
+		}
+	}
+	return nil
+}
+
+// rigAgentServiceAccount returns the ServiceAccount provisioned for the
+// agents of the polecat's rig, when it lives in the polecat's namespace.
+func rigAgentServiceAccount(ctx context.Context, c client.Reader, polecat *gastownv1alpha1.Polecat) (string, error) {
+	var rig gastownv1alpha1.Rig
+	if err := c.Get(ctx, client.ObjectKey{Name: polecat.Spec.Rig}, &rig); err != nil {
+		if apierrors.IsNotFound(err) {
+			return "", nil
+		}
+		return "", err
+	}
+	if rig.Status.ChildNamespace != polecat.Namespace {
+		return "", nil
+	}
+	return rig.Status.AgentServiceAccount, nil
+}
@@ -0,0 +1,138 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	corev1 "k8s.io/api/core/v1"
+	rbacv1 "k8s.io/api/rbac/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var _ = Describe("Rig agent service account", func() {
+	const ns = "gt-sa-rig"
+
+	var (
+		ctx context.Context
+		c   client.Client
+		r   *RigReconciler
+		rig *gastownv1alpha1.Rig
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+		rig = &gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "sa-rig"},
+			Spec: gastownv1alpha1.RigSpec{
+				AgentServiceAccount: &gastownv1alpha1.AgentServiceAccountSpec{
+					Rules: []rbacv1.PolicyRule{{
+						APIGroups: []string{""},
+						Resources: []string{"configmaps"},
+						Verbs:     []string{"get", "list"},
+					}},
+					ClusterRole: "view",
+				},
+			},
+		}
+		c = fake.NewClientBuilder().WithScheme(scheme).WithObjects(rig).
+			WithStatusSubresource(&gastownv1alpha1.Rig{}).Build()
+		r = &RigReconciler{Client: c, Scheme: scheme, Recorder: record.NewFakeRecorder(10)}
+	})
+
+	It("should provision the ServiceAccount with its Role and bindings", func() {
+		name, err := r.ensureAgentServiceAccount(ctx, rig, ns)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("sa-rig-agent"))
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, &corev1.ServiceAccount{})).To(Succeed())
+
+		var role rbacv1.Role
+		Expect(c.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, &role)).To(Succeed())
+		Expect(role.Rules).To(Equal(rig.Spec.AgentServiceAccount.Rules))
+
+		var binding rbacv1.RoleBinding
+		Expect(c.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, &binding)).To(Succeed())
+		Expect(binding.RoleRef.Kind).To(Equal("Role"))
+		Expect(binding.Subjects).To(ConsistOf(rbacv1.Subject{Kind: "ServiceAccount", Name: name, Namespace: ns}))
+
+		Expect(c.Get(ctx, client.ObjectKey{Name: "sa-rig-agent-clusterrole", Namespace: ns}, &binding)).To(Succeed())
+		Expect(binding.RoleRef).To(Equal(rbacv1.RoleRef{APIGroup: rbacv1.GroupName, Kind: "ClusterRole", Name: "view"}))
+	})
+
+	It("should replace the binding when the cluster role changes", func() {
+		_, err := r.ensureAgentServiceAccount(ctx, rig, ns)
+		Expect(err).NotTo(HaveOccurred())
+
+		rig.Spec.AgentServiceAccount.ClusterRole = "edit"
+		_, err = r.ensureAgentServiceAccount(ctx, rig, ns)
+		Expect(err).NotTo(HaveOccurred())
+
+		var binding rbacv1.RoleBinding
+		Expect(c.Get(ctx, client.ObjectKey{Name: "sa-rig-agent-clusterrole", Namespace: ns}, &binding)).To(Succeed())
+		Expect(binding.RoleRef.Name).To(Equal("edit"))
+	})
+
+	It("should remove what the spec no longer asks for", func() {
+		_, err := r.ensureAgentServiceAccount(ctx, rig, ns)
+		Expect(err).NotTo(HaveOccurred())
+
+		rig.Spec.AgentServiceAccount.Rules = nil
+		name, err := r.ensureAgentServiceAccount(ctx, rig, ns)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(Equal("sa-rig-agent"))
+		err = c.Get(ctx, client.ObjectKey{Name: name, Namespace: ns}, &rbacv1.Role{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+
+		rig.Spec.AgentServiceAccount = nil
+		name, err = r.ensureAgentServiceAccount(ctx, rig, ns)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(name).To(BeEmpty())
+		err = c.Get(ctx, client.ObjectKey{Name: "sa-rig-agent", Namespace: ns}, &corev1.ServiceAccount{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+		err = c.Get(ctx, client.ObjectKey{Name: "sa-rig-agent-clusterrole", Namespace: ns}, &rbacv1.RoleBinding{})
+		Expect(apierrors.IsNotFound(err)).To(BeTrue())
+	})
+
+	It("should hand the rig's ServiceAccount to polecats in its namespace", func() {
+		rig.Status.ChildNamespace = ns
+		rig.Status.AgentServiceAccount = "sa-rig-agent"
+		Expect(c.Status().Update(ctx, rig)).To(Succeed())
+
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "p", Namespace: ns},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: "sa-rig"},
+		}
+		Expect(rigAgentServiceAccount(ctx, c, polecat)).To(Equal("sa-rig-agent"))
+
+		polecat.Namespace = "elsewhere"
+		Expect(rigAgentServiceAccount(ctx, c, polecat)).To(BeEmpty())
+	})
+})
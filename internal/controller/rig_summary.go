@@ -0,0 +1,96 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// refineryRigField indexes Refineries by spec.rigRef.
+const refineryRigField = "spec.rigRef"
+
+// rigPolecatCounts counts the polecats in each phase.
+func rigPolecatCounts(polecats []gastownv1alpha1.Polecat) *gastownv1alpha1.RigPolecatCounts {
+	counts := &gastownv1alpha1.RigPolecatCounts{}
+	for _, p := range polecats {
+		switch p.Status.Phase {
+		case gastownv1alpha1.PolecatPhaseWorking:
+			counts.Working++
+		case gastownv1alpha1.PolecatPhaseIdle:
+			counts.Idle++
+		case gastownv1alpha1.PolecatPhaseStuck:
+			counts.Stuck++
+		case gastownv1alpha1.PolecatPhaseDone:
+			counts.Done++
+		case gastownv1alpha1.PolecatPhaseTerminated:
+			counts.Terminated++
+		}
+	}
+	return counts
+}
+
+// rigConvoySummaries summarizes the pending and in-progress convoys, by
+// namespace and name, capped at MaxRigConvoySummaries.
+func rigConvoySummaries(convoys []gastownv1alpha1.Convoy) []gastownv1alpha1.RigConvoySummary {
+	var summaries []gastownv1alpha1.RigConvoySummary
+	for _, convoy := range convoys {
+		phase := convoy.Status.Phase
+		if phase == "" {
+			phase = gastownv1alpha1.ConvoyPhasePending
+		}
+		if phase != gastownv1alpha1.ConvoyPhasePending && phase != gastownv1alpha1.ConvoyPhaseInProgress {
+			continue
+		}
+		summaries = append(summaries, gastownv1alpha1.RigConvoySummary{
+			Name:      convoy.Name,
+			Namespace: convoy.Namespace,
+			Phase:     phase,
+			Progress:  convoy.Status.Progress,
+		})
+	}
+	slices.SortFunc(summaries, func(a, b gastownv1alpha1.RigConvoySummary) int {
+		return cmp.Or(cmp.Compare(a.Namespace, b.Namespace), cmp.Compare(a.Name, b.Name))
+	})
+	if len(summaries) > gastownv1alpha1.MaxRigConvoySummaries {
+		summaries = summaries[:gastownv1alpha1.MaxRigConvoySummaries]
+	}
+	return summaries
+}
+
+// lastMergeTime returns the latest successful merge of the Refineries
+// processing the rig, nil before the first.
+func (r *RigReconciler) lastMergeTime(ctx context.Context, rigName string) (*metav1.Time, error) {
+	var refineries gastownv1alpha1.RefineryList
+	if err := r.List(ctx, &refineries, client.MatchingFields{refineryRigField: rigName}); err != nil {
+		return nil, err
+	}
+
+	var last *metav1.Time
+	for _, refinery := range refineries.Items {
+		if t := refinery.Status.LastMergeTime; t != nil && (last == nil || t.After(last.Time)) {
+			last = t.DeepCopy()
+		}
+	}
+	return last, nil
+}
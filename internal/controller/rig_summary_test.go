@@ -0,0 +1,109 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var _ = Describe("Rig status summary", func() {
+	It("should count the rig's polecats by phase", func() {
+		polecat := func(phase gastownv1alpha1.PolecatPhase) gastownv1alpha1.Polecat {
+			return gastownv1alpha1.Polecat{Status: gastownv1alpha1.PolecatStatus{Phase: phase}}
+		}
+		counts := rigPolecatCounts([]gastownv1alpha1.Polecat{
+			polecat(gastownv1alpha1.PolecatPhaseWorking),
+			polecat(gastownv1alpha1.PolecatPhaseWorking),
+			polecat(gastownv1alpha1.PolecatPhaseStuck),
+			polecat(gastownv1alpha1.PolecatPhaseDone),
+			polecat(""),
+		})
+		Expect(*counts).To(Equal(gastownv1alpha1.RigPolecatCounts{Working: 2, Stuck: 1, Done: 1}))
+	})
+
+	It("should summarize the unfinished convoys by name", func() {
+		convoy := func(name string, phase gastownv1alpha1.ConvoyPhase) gastownv1alpha1.Convoy {
+			return gastownv1alpha1.Convoy{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gt-summary"},
+				Status:     gastownv1alpha1.ConvoyStatus{Phase: phase, Progress: "1/3"},
+			}
+		}
+		summaries := rigConvoySummaries([]gastownv1alpha1.Convoy{
+			convoy("sprint-b", gastownv1alpha1.ConvoyPhaseInProgress),
+			convoy("sprint-a", ""),
+			convoy("sprint-0", gastownv1alpha1.ConvoyPhaseComplete),
+		})
+		Expect(summaries).To(Equal([]gastownv1alpha1.RigConvoySummary{
+			{Name: "sprint-a", Namespace: "gt-summary", Phase: gastownv1alpha1.ConvoyPhasePending, Progress: "1/3"},
+			{Name: "sprint-b", Namespace: "gt-summary", Phase: gastownv1alpha1.ConvoyPhaseInProgress, Progress: "1/3"},
+		}))
+
+		var many []gastownv1alpha1.Convoy
+		for i := range gastownv1alpha1.MaxRigConvoySummaries + 5 {
+			many = append(many, convoy(fmt.Sprintf("c-%02d", i), gastownv1alpha1.ConvoyPhaseInProgress))
+		}
+		Expect(rigConvoySummaries(many)).To(HaveLen(gastownv1alpha1.MaxRigConvoySummaries))
+	})
+
+	It("should report the last merge of the rig's refineries", func() {
+		ctx := context.Background()
+		scheme := runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+
+		earlier := metav1.NewTime(time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC))
+		later := metav1.NewTime(earlier.Add(time.Hour))
+		refinery := func(name, rig string, last *metav1.Time) *gastownv1alpha1.Refinery {
+			return &gastownv1alpha1.Refinery{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gt-summary"},
+				Spec:       gastownv1alpha1.RefinerySpec{RigRef: rig},
+				Status:     gastownv1alpha1.RefineryStatus{LastMergeTime: last},
+			}
+		}
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(
+				refinery("main", "summary-rig", &earlier),
+				refinery("docs", "summary-rig", &later),
+				refinery("other", "other-rig", nil),
+			).
+			WithIndex(&gastownv1alpha1.Refinery{}, refineryRigField, func(obj client.Object) []string {
+				return []string{obj.(*gastownv1alpha1.Refinery).Spec.RigRef}
+			}).
+			Build()
+		r := &RigReconciler{Client: c, Scheme: scheme}
+
+		last, err := r.lastMergeTime(ctx, "summary-rig")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(last.Time).To(BeTemporally("==", later.Time))
+
+		last, err = r.lastMergeTime(ctx, "other-rig")
+		Expect(err).NotTo(HaveOccurred())
+		Expect(last).To(BeNil())
+	})
+})
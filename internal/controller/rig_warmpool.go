@@ -0,0 +1,130 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/pod"
+)
+
+// warmPoolSize returns how many warm Pods the rig keeps: spec.warmPool.size,
+// or none while the rig is paused.
+func warmPoolSize(rig *gastownv1alpha1.Rig, paused bool) int {
+	if rig.Spec.WarmPool == nil || paused {
+		return 0
+	}
+	return int(rig.Spec.WarmPool.Size)
+}
+
+// ensureWarmPool keeps spec.warmPool.size warm Pods waiting in the rig's
+// namespace for polecats to claim, replacing those that finished or were
+// built from an outdated spec, and returns how many are running.
+func (r *RigReconciler) ensureWarmPool(ctx context.Context, rig *gastownv1alpha1.Rig, paused bool) (int32, error) {
+	log := logf.FromContext(ctx)
+	ns := r.childNamespaceFor(rig)
+	size := warmPoolSize(rig, paused)
+
+	var warmPods corev1.PodList
+	if err := r.List(ctx, &warmPods, client.InNamespace(ns),
+		client.MatchingLabels{pod.WarmPoolLabel: rig.Name}); err != nil {
+		return 0, fmt.Errorf("failed to list warm pods: %w", err)
+	}
+
+	var template *corev1.Pod
+	if size > 0 {
+		builder, err := podBuilder(ctx, r.Client, warmPoolPolecat(rig, ns))
+		if err != nil {
+			return 0, fmt.Errorf("failed to build warm pod: %w", err)
+		}
+		if template, err = builder.BuildStandby(); err != nil {
+			return 0, fmt.Errorf("failed to build warm pod: %w", err)
+		}
+		if err := controllerutil.SetControllerReference(rig, template, r.Scheme); err != nil {
+			return 0, fmt.Errorf("failed to set owner reference: %w", err)
+		}
+	}
+
+	// Keep the usable Pods built from the current spec, running and longest
+	// waiting first
+	var keep, remove []*corev1.Pod
+	for i := range warmPods.Items {
+		p := &warmPods.Items[i]
+		switch {
+		case p.DeletionTimestamp != nil:
+		case !warmPodUsable(p), template == nil,
+			p.Annotations[pod.StandbyHashAnnotation] != template.Annotations[pod.StandbyHashAnnotation]:
+			remove = append(remove, p)
+		default:
+			keep = append(keep, p)
+		}
+	}
+	sort.SliceStable(keep, func(i, j int) bool {
+		ri, rj := keep[i].Status.Phase == corev1.PodRunning, keep[j].Status.Phase == corev1.PodRunning
+		if ri != rj {
+			return ri
+		}
+		return keep[i].CreationTimestamp.Before(&keep[j].CreationTimestamp)
+	})
+	if len(keep) > size {
+		remove = append(remove, keep[size:]...)
+		keep = keep[:size]
+	}
+
+	for _, p := range remove {
+		if err := r.Delete(ctx, p); client.IgnoreNotFound(err) != nil {
+			return 0, fmt.Errorf("failed to delete warm pod %s: %w", p.Name, err)
+		}
+		log.Info("Deleted warm Pod", "pod", p.Name, "phase", p.Status.Phase)
+	}
+
+	created := 0
+	for range size - len(keep) {
+		p := template.DeepCopy()
+		if err := r.Create(ctx, p); err != nil {
+			if apierrors.IsForbidden(err) {
+				// Most likely the namespace's pod quota; polecats come first
+				log.Info("Warm Pod not created", "reason", err.Error())
+				break
+			}
+			return 0, fmt.Errorf("failed to create warm pod: %w", err)
+		}
+		created++
+	}
+	if created > 0 {
+		log.Info("Created warm Pods", "count", created, "namespace", ns)
+		r.Recorder.Event(rig, "Normal", "WarmPodsCreated",
+			fmt.Sprintf("Created %d warm agent Pods in %s", created, ns))
+	}
+
+	var running int32
+	for _, p := range keep {
+		if p.Status.Phase == corev1.PodRunning {
+			running++
+		}
+	}
+	return running, nil
+}
@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/version"
+)
+
+// ConditionSchemaSupported is False on a resource last processed by an
+// operator with a newer schema version. The controllers leave such a
+// resource alone, since rewriting it would drop the fields they don't know.
+const ConditionSchemaSupported = "SchemaSupported"
+
+// checkSchemaVersion guards a resource against an operator older than the
+// newest one that processed it. A resource stamped with a newer
+// SchemaVersionAnnotation gets SchemaSupported=False and true is returned:
+// the caller must stop reconciling it. Otherwise the condition is cleared
+// and the resource is stamped with this operator's schema version.
+func checkSchemaVersion(
+	ctx context.Context, c client.Client, recorder record.EventRecorder, obj client.Object, conditions *[]metav1.Condition,
+) (bool, error) {
+	objVersion := gastownv1alpha1.ObjectSchemaVersion(obj)
+	if objVersion > gastownv1alpha1.SchemaVersion {
+		message := fmt.Sprintf("Processed by an operator with schema version %d; operator %s supports up to %d. "+
+			"Upgrade the operator to resume, or remove the %s annotation to accept losing newer fields",
+			objVersion, version.Version, gastownv1alpha1.SchemaVersion, gastownv1alpha1.SchemaVersionAnnotation)
+		changed := meta.SetStatusCondition(conditions, metav1.Condition{
+			Type:               ConditionSchemaSupported,
+			Status:             metav1.ConditionFalse,
+			ObservedGeneration: obj.GetGeneration(),
+			Reason:             "NewerSchemaVersion",
+			Message:            message,
+		})
+		if changed {
+			logf.FromContext(ctx).Info("Skipping resource of a newer schema version",
+				"schemaVersion", objVersion, "supported", gastownv1alpha1.SchemaVersion)
+			if recorder != nil {
+				recorder.Event(obj, corev1.EventTypeWarning, "NewerSchemaVersion", message)
+			}
+			if err := applyConditions(ctx, c, obj, fieldManagerSchema, ConditionSchemaSupported); err != nil {
+				return true, fmt.Errorf("failed to set %s condition: %w", ConditionSchemaSupported, err)
+			}
+		}
+		return true, nil
+	}
+
+	// Clear the condition left by an older operator before the upgrade
+	if meta.RemoveStatusCondition(conditions, ConditionSchemaSupported) {
+		if err := applyConditions(ctx, c, obj, fieldManagerSchema, ConditionSchemaSupported); err != nil {
+			return false, fmt.Errorf("failed to clear %s condition: %w", ConditionSchemaSupported, err)
+		}
+	}
+
+	if objVersion < gastownv1alpha1.SchemaVersion {
+		patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+		annotations := obj.GetAnnotations()
+		if annotations == nil {
+			annotations = map[string]string{}
+		}
+		annotations[gastownv1alpha1.SchemaVersionAnnotation] = strconv.Itoa(gastownv1alpha1.SchemaVersion)
+		obj.SetAnnotations(annotations)
+		if err := c.Patch(ctx, obj, patch); err != nil {
+			return false, fmt.Errorf("failed to stamp %s: %w", gastownv1alpha1.SchemaVersionAnnotation, err)
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,112 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/record"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/controller/controllerutil"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var _ = Describe("Schema version skew", func() {
+	var (
+		ctx    context.Context
+		scheme *runtime.Scheme
+	)
+
+	BeforeEach(func() {
+		ctx = context.Background()
+		scheme = runtime.NewScheme()
+		Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+		Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+	})
+
+	newPolecat := func(schemaVersion string) *gastownv1alpha1.Polecat {
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "furiosa", Namespace: "default"},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: "app", DesiredState: gastownv1alpha1.PolecatDesiredWorking},
+		}
+		if schemaVersion != "" {
+			polecat.Annotations = map[string]string{gastownv1alpha1.SchemaVersionAnnotation: schemaVersion}
+		}
+		return polecat
+	}
+
+	It("should stamp resources with this operator's schema version", func() {
+		polecat := newPolecat("")
+		c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(polecat).Build()
+
+		skip, err := checkSchemaVersion(ctx, c, nil, polecat, &polecat.Status.Conditions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skip).To(BeFalse())
+
+		var updated gastownv1alpha1.Polecat
+		Expect(c.Get(ctx, client.ObjectKeyFromObject(polecat), &updated)).To(Succeed())
+		Expect(gastownv1alpha1.ObjectSchemaVersion(&updated)).To(Equal(gastownv1alpha1.SchemaVersion))
+	})
+
+	It("should leave resources of a newer schema version alone until the operator catches up", func() {
+		newer := strconv.Itoa(gastownv1alpha1.SchemaVersion + 1)
+		c := fake.NewClientBuilder().WithScheme(scheme).
+			WithObjects(newPolecat(newer)).
+			WithStatusSubresource(&gastownv1alpha1.Polecat{}).
+			Build()
+		recorder := record.NewFakeRecorder(10)
+		r := &PolecatReconciler{Client: c, Scheme: scheme, Recorder: recorder}
+
+		req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "furiosa", Namespace: "default"}}
+		for range 2 {
+			_, err := r.Reconcile(ctx, req)
+			Expect(err).NotTo(HaveOccurred())
+		}
+		Expect(recorder.Events).To(HaveLen(1), "the skew is reported once")
+		Expect(<-recorder.Events).To(ContainSubstring("NewerSchemaVersion"))
+
+		var polecat gastownv1alpha1.Polecat
+		Expect(c.Get(ctx, req.NamespacedName, &polecat)).To(Succeed())
+		Expect(controllerutil.ContainsFinalizer(&polecat, polecatFinalizer)).To(BeFalse(), "the polecat is not processed")
+		Expect(polecat.Annotations[gastownv1alpha1.SchemaVersionAnnotation]).To(Equal(newer))
+		cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionSchemaSupported)
+		Expect(cond).NotTo(BeNil())
+		Expect(cond.Status).To(Equal(metav1.ConditionFalse))
+		Expect(cond.Message).To(ContainSubstring("Upgrade the operator"))
+
+		// Removing the annotation hands the polecat back to this operator
+		polecat.Annotations = nil
+		Expect(c.Update(ctx, &polecat)).To(Succeed())
+		skip, err := checkSchemaVersion(ctx, c, recorder, &polecat, &polecat.Status.Conditions)
+		Expect(err).NotTo(HaveOccurred())
+		Expect(skip).To(BeFalse())
+		Expect(c.Get(ctx, req.NamespacedName, &polecat)).To(Succeed())
+		Expect(meta.FindStatusCondition(polecat.Status.Conditions, ConditionSchemaSupported)).To(BeNil())
+		Expect(gastownv1alpha1.ObjectSchemaVersion(&polecat)).To(Equal(gastownv1alpha1.SchemaVersion))
+	})
+})
@@ -0,0 +1,183 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/apiutil"
+)
+
+// Field managers of the controllers writing status. Status is written with
+// server-side apply, so each writer owns only the fields it sends: writers
+// sharing a resource (the Polecat and Refinery controllers both write Polecat
+// conditions) no longer overwrite each other or conflict on resourceVersion.
+// Finalizers and caches are still updated, through gterrors.UpdateOnConflict.
+const (
+	fieldManagerPolecat       = "polecat-controller"
+	fieldManagerRig           = "rig-controller"
+	fieldManagerConvoy        = "convoy-controller"
+	fieldManagerWitness       = "witness-controller"
+	fieldManagerRefinery      = "refinery-controller"
+	fieldManagerBeadStore     = "beadstore-controller"
+	fieldManagerEmergencyStop = "emergencystop-controller"
+	fieldManagerGastownConfig = "gastownconfig-controller"
+
+	// fieldManagerSchema owns the SchemaSupported condition set on any
+	// resource by checkSchemaVersion
+	fieldManagerSchema = "schema-version-check"
+)
+
+var (
+	// polecatConditionTypes are the Polecat conditions owned by the Polecat controller
+	polecatConditionTypes = []string{
+		ConditionPolecatReady, ConditionPolecatWorking,
+		ConditionProgressing, ConditionAvailable, ConditionDegraded,
+		ConditionImageIncompatible, ConditionWaitingForWindow, ConditionOverBudget, ConditionCredentialExpired,
+	}
+
+	// refineryPolecatConditionTypes are the Polecat conditions owned by the Refinery controller
+	refineryPolecatConditionTypes = []string{
+		ConditionMerged, ConditionPullRequest, ConditionChecksPassed, ConditionBranchDeleted,
+	}
+
+	// refineryPolecatFields are the Polecat status fields owned by the Refinery controller
+	refineryPolecatFields = []string{"mergedCommit", "mergedCommits", "pullRequestURL"}
+
+	// witnessPolecatConditionTypes are the Polecat conditions owned by the Witness controller
+	witnessPolecatConditionTypes = []string{ConditionStalled, ConditionGaveUp}
+
+	// beadStorePolecatFields are the Polecat status fields owned by the BeadStore controller
+	beadStorePolecatFields = []string{"followUpBead", "splitBeads"}
+
+	// beadStoreConvoyFields are the Convoy status fields owned by the BeadStore controller
+	beadStoreConvoyFields = []string{"summaryBead"}
+
+	// otherPolecatFields are the Polecat status fields the Polecat controller
+	// leaves to the controllers owning them
+	otherPolecatFields = slices.Concat(refineryPolecatFields, beadStorePolecatFields)
+)
+
+// applyStatus writes the status of obj with server-side apply as fieldManager.
+// If conditionTypes are given, only those conditions are applied and the
+// others are left to the controllers owning them.
+func applyStatus(ctx context.Context, c client.Client, obj client.Object, fieldManager string, conditionTypes ...string) error {
+	return applyStatusWithout(ctx, c, obj, fieldManager, nil, conditionTypes...)
+}
+
+// applyStatusWithout is applyStatus leaving out the top-level status fields
+// owned by other controllers, which obj may hold stale copies of.
+func applyStatusWithout(
+	ctx context.Context, c client.Client, obj client.Object, fieldManager string, otherFields []string, conditionTypes ...string,
+) error {
+	status, err := statusOf(obj)
+	if err != nil {
+		return err
+	}
+	for _, field := range otherFields {
+		delete(status, field)
+	}
+	if len(conditionTypes) > 0 {
+		status["conditions"] = filterConditions(status["conditions"], conditionTypes)
+	}
+	return apply(ctx, c, obj, fieldManager, status)
+}
+
+// applyConditions writes only the given condition types of obj's status with
+// server-side apply as fieldManager, for controllers that annotate a resource
+// owned by another controller.
+func applyConditions(ctx context.Context, c client.Client, obj client.Object, fieldManager string, conditionTypes ...string) error {
+	return applyFields(ctx, c, obj, fieldManager, nil, conditionTypes...)
+}
+
+// applyFields writes the given top-level status fields and condition types of
+// obj's status with server-side apply as fieldManager. A field missing from
+// obj is released, so every apply by fieldManager must list all the fields it owns.
+func applyFields(
+	ctx context.Context, c client.Client, obj client.Object, fieldManager string, fields []string, conditionTypes ...string,
+) error {
+	status, err := statusOf(obj)
+	if err != nil {
+		return err
+	}
+	applied := map[string]any{}
+	if len(conditionTypes) > 0 {
+		applied["conditions"] = filterConditions(status["conditions"], conditionTypes)
+	}
+	for _, field := range fields {
+		if value, ok := status[field]; ok {
+			applied[field] = value
+		}
+	}
+	return apply(ctx, c, obj, fieldManager, applied)
+}
+
+// statusOf returns the status of obj as an unstructured map.
+func statusOf(obj client.Object) (map[string]any, error) {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert %T: %w", obj, err)
+	}
+	status, _ := u["status"].(map[string]any)
+	if status == nil {
+		status = map[string]any{}
+	}
+	return status, nil
+}
+
+// filterConditions keeps the conditions of the given types.
+func filterConditions(conditions any, conditionTypes []string) []any {
+	list, _ := conditions.([]any)
+	filtered := []any{}
+	for _, cond := range list {
+		if m, ok := cond.(map[string]any); ok && slices.Contains(conditionTypes, fmt.Sprint(m["type"])) {
+			filtered = append(filtered, cond)
+		}
+	}
+	return filtered
+}
+
+// apply sends status as a server-side apply of obj's status subresource.
+// The resourceVersion is not sent, so the apply never conflicts; the new
+// resourceVersion is copied back to obj for later metadata updates.
+func apply(ctx context.Context, c client.Client, obj client.Object, fieldManager string, status map[string]any) error {
+	gvk, err := apiutil.GVKForObject(obj, c.Scheme())
+	if err != nil {
+		return err
+	}
+
+	u := &unstructured.Unstructured{}
+	u.SetGroupVersionKind(gvk)
+	u.SetName(obj.GetName())
+	u.SetNamespace(obj.GetNamespace())
+	u.Object["status"] = status
+
+	if err := c.Status().Apply(ctx, client.ApplyConfigurationFromUnstructured(u),
+		client.FieldOwner(fieldManager), client.ForceOwnership); err != nil {
+		return err
+	}
+
+	if rv := u.GetResourceVersion(); rv != "" {
+		obj.SetResourceVersion(rv)
+	}
+	return nil
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var _ = Describe("Status writes", func() {
+	Context("When two controllers write the same Polecat status", func() {
+		key := types.NamespacedName{Name: "shared-status", Namespace: "default"}
+
+		BeforeEach(func() {
+			Expect(k8sClient.Create(ctx, &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: key.Name, Namespace: key.Namespace},
+				Spec: gastownv1alpha1.PolecatSpec{
+					Rig:          "test-rig",
+					DesiredState: gastownv1alpha1.PolecatDesiredWorking,
+					BeadID:       "test-bead",
+				},
+			})).To(Succeed())
+		})
+
+		AfterEach(func() {
+			polecat := &gastownv1alpha1.Polecat{}
+			Expect(k8sClient.Get(ctx, key, polecat)).To(Succeed())
+			Expect(k8sClient.Delete(ctx, polecat)).To(Succeed())
+		})
+
+		setCondition := func(polecat *gastownv1alpha1.Polecat, condType string) {
+			meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+				Type: condType, Status: metav1.ConditionTrue, Reason: "Test",
+			})
+		}
+
+		It("should keep both writers' conditions when applied from stale copies", func() {
+			polecatCopy := &gastownv1alpha1.Polecat{}
+			Expect(k8sClient.Get(ctx, key, polecatCopy)).To(Succeed())
+			refineryCopy := polecatCopy.DeepCopy()
+
+			polecatCopy.Status.Phase = gastownv1alpha1.PolecatPhaseWorking
+			setCondition(polecatCopy, ConditionPolecatReady)
+			Expect(applyStatus(ctx, k8sClient, polecatCopy, fieldManagerPolecat, polecatConditionTypes...)).To(Succeed())
+
+			setCondition(refineryCopy, ConditionMerged)
+			Expect(applyConditions(ctx, k8sClient, refineryCopy, fieldManagerRefinery, refineryPolecatConditionTypes...)).To(Succeed())
+
+			// The Polecat controller's copy predates the Merged condition
+			setCondition(polecatCopy, ConditionPolecatWorking)
+			Expect(applyStatus(ctx, k8sClient, polecatCopy, fieldManagerPolecat, polecatConditionTypes...)).To(Succeed())
+
+			polecat := &gastownv1alpha1.Polecat{}
+			Expect(k8sClient.Get(ctx, key, polecat)).To(Succeed())
+			Expect(polecat.Status.Phase).To(Equal(gastownv1alpha1.PolecatPhaseWorking))
+			for _, condType := range []string{ConditionPolecatReady, ConditionPolecatWorking, ConditionMerged} {
+				Expect(meta.IsStatusConditionTrue(polecat.Status.Conditions, condType)).To(BeTrue(), condType)
+			}
+		})
+
+		It("should remove conditions a writer stops sending", func() {
+			polecat := &gastownv1alpha1.Polecat{}
+			Expect(k8sClient.Get(ctx, key, polecat)).To(Succeed())
+			setCondition(polecat, ConditionDegraded)
+			Expect(applyStatus(ctx, k8sClient, polecat, fieldManagerPolecat, polecatConditionTypes...)).To(Succeed())
+
+			meta.RemoveStatusCondition(&polecat.Status.Conditions, ConditionDegraded)
+			Expect(applyStatus(ctx, k8sClient, polecat, fieldManagerPolecat, polecatConditionTypes...)).To(Succeed())
+
+			Expect(k8sClient.Get(ctx, key, polecat)).To(Succeed())
+			Expect(meta.FindStatusCondition(polecat.Status.Conditions, ConditionDegraded)).To(BeNil())
+		})
+	})
+
+	Context("When the Refinery merged a Polecat's work", func() {
+		It("should leave the merge fields to the Refinery", func() {
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(&gastownv1alpha1.Polecat{ObjectMeta: metav1.ObjectMeta{Name: "merged", Namespace: "default"}}).
+				WithStatusSubresource(&gastownv1alpha1.Polecat{}).
+				Build()
+			r := &PolecatReconciler{Client: c}
+			key := types.NamespacedName{Name: "merged", Namespace: "default"}
+
+			polecatCopy := &gastownv1alpha1.Polecat{}
+			Expect(c.Get(ctx, key, polecatCopy)).To(Succeed())
+			refineryCopy := polecatCopy.DeepCopy()
+			polecatCopy.Status.Phase = gastownv1alpha1.PolecatPhaseDone
+			Expect(r.updateStatus(ctx, polecatCopy)).To(Succeed())
+
+			refineryCopy.Status.MergedCommit = "abc123"
+			Expect(applyFields(ctx, c, refineryCopy, fieldManagerRefinery,
+				refineryPolecatFields, refineryPolecatConditionTypes...)).To(Succeed())
+
+			// The Polecat controller's copy holds a stale merge
+			polecatCopy.Status.MergedCommit = "stale"
+			Expect(r.updateStatus(ctx, polecatCopy)).To(Succeed())
+
+			polecat := &gastownv1alpha1.Polecat{}
+			Expect(c.Get(ctx, key, polecat)).To(Succeed())
+			Expect(polecat.Status.Phase).To(Equal(gastownv1alpha1.PolecatPhaseDone))
+			Expect(polecat.Status.MergedCommit).To(Equal("abc123"))
+
+			// Cleared by the Refinery, the merge is gone
+			refineryCopy.Status.MergedCommit = ""
+			Expect(applyFields(ctx, c, refineryCopy, fieldManagerRefinery,
+				refineryPolecatFields, refineryPolecatConditionTypes...)).To(Succeed())
+			Expect(c.Get(ctx, key, polecat)).To(Succeed())
+			Expect(polecat.Status.MergedCommit).To(BeEmpty())
+		})
+	})
+})
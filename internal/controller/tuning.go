@@ -0,0 +1,139 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	"golang.org/x/time/rate"
+	"k8s.io/client-go/util/workqueue"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/handler"
+	"sigs.k8s.io/controller-runtime/pkg/reconcile"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+)
+
+// Retry delays and overall rate of controller-runtime's default rate limiter
+const (
+	defaultRateLimiterBaseDelay = 5 * time.Millisecond
+	defaultRateLimiterMaxDelay  = 1000 * time.Second
+	defaultRateLimiterQPS       = 10
+	defaultRateLimiterBurst     = 100
+)
+
+// controllerOptions returns the options of a controller reconciling
+// concurrency resources at once unless tuned otherwise.
+func controllerOptions(tuning config.ControllerTuning, concurrency int) controller.Options {
+	opts := controller.Options{MaxConcurrentReconciles: concurrency}
+	if tuning.MaxConcurrentReconciles > 0 {
+		opts.MaxConcurrentReconciles = tuning.MaxConcurrentReconciles
+	}
+	if tuning.RateLimiterBaseDelay > 0 || tuning.RateLimiterMaxDelay > 0 ||
+		tuning.RateLimiterQPS > 0 || tuning.RateLimiterBurst > 0 {
+		base, limit := defaultRateLimiterBaseDelay, defaultRateLimiterMaxDelay
+		if tuning.RateLimiterBaseDelay > 0 {
+			base = tuning.RateLimiterBaseDelay
+		}
+		if tuning.RateLimiterMaxDelay > 0 {
+			limit = tuning.RateLimiterMaxDelay
+		}
+		qps, burst := defaultRateLimiterQPS, defaultRateLimiterBurst
+		if tuning.RateLimiterQPS > 0 {
+			qps = tuning.RateLimiterQPS
+		}
+		if tuning.RateLimiterBurst > 0 {
+			burst = tuning.RateLimiterBurst
+		}
+		opts.RateLimiter = workqueue.NewTypedMaxOfRateLimiter(
+			workqueue.NewTypedItemExponentialFailureRateLimiter[reconcile.Request](base, max(base, limit)),
+			&workqueue.TypedBucketRateLimiter[reconcile.Request]{
+				Limiter: rate.NewLimiter(rate.Limit(qps), burst),
+			},
+		)
+	}
+	return opts
+}
+
+// ReconcilePriorityAnnotation overrides the priority hint of a resource:
+// "high" or "low". Other values are ignored.
+const ReconcilePriorityAnnotation = "gastown.io/reconcile-priority"
+
+// Priorities of requeued resources. The workqueue hands out higher
+// priorities first, so under backlog critical resources are reconciled ahead
+// of idle ones; without backlog they make no difference.
+const (
+	priorityHigh   = 100
+	priorityNormal = 0
+	priorityLow    = handler.LowPriority
+)
+
+const (
+	// deepMergeQueue is the merge queue length from which a Refinery is
+	// reconciled with high priority
+	deepMergeQueue = 5
+
+	// flappingAgentRestarts is the number of agent restarts from which a
+	// working polecat is reconciled with high priority
+	flappingAgentRestarts = 3
+)
+
+// withPriority sets the priority the resource is requeued with, unless obj
+// overrides it with ReconcilePriorityAnnotation.
+func withPriority(result *ctrl.Result, obj client.Object, priority int) {
+	switch obj.GetAnnotations()[ReconcilePriorityAnnotation] {
+	case "high":
+		priority = priorityHigh
+	case "low":
+		priority = priorityLow
+	}
+	result.Priority = &priority
+}
+
+// polecatPriority is high for stuck and flapping polecats, whose Pods need
+// attention, and low for polecats without work.
+func polecatPriority(polecat *gastownv1alpha1.Polecat) int {
+	switch polecat.Status.Phase {
+	case gastownv1alpha1.PolecatPhaseStuck:
+		return priorityHigh
+	case gastownv1alpha1.PolecatPhaseWorking:
+		if polecat.Status.AgentRestarts >= flappingAgentRestarts {
+			return priorityHigh
+		}
+		return priorityNormal
+	case gastownv1alpha1.PolecatPhaseIdle, gastownv1alpha1.PolecatPhaseDone, gastownv1alpha1.PolecatPhaseTerminated:
+		return priorityLow
+	default:
+		return priorityNormal
+	}
+}
+
+// refineryPriority is high for refineries with deep merge queues and low for
+// refineries with nothing to merge.
+func refineryPriority(refinery *gastownv1alpha1.Refinery) int {
+	switch {
+	case refinery.Status.QueueLength >= deepMergeQueue:
+		return priorityHigh
+	case refinery.Status.QueueLength == 0 && len(refinery.Status.ActiveMerges) == 0:
+		return priorityLow
+	default:
+		return priorityNormal
+	}
+}
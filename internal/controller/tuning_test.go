@@ -0,0 +1,110 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"time"
+
+	. "github.com/onsi/ginkgo/v2"
+	. "github.com/onsi/gomega"
+	"k8s.io/apimachinery/pkg/types"
+	ctrl "sigs.k8s.io/controller-runtime"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+)
+
+var _ = Describe("Controller tuning", func() {
+	It("should keep the built-in concurrency and rate limiter when untuned", func() {
+		opts := controllerOptions(config.ControllerTuning{}, 5)
+		Expect(opts.MaxConcurrentReconciles).To(Equal(5))
+		Expect(opts.RateLimiter).To(BeNil())
+	})
+
+	It("should apply the tuned concurrency and retry delays", func() {
+		opts := controllerOptions(config.ControllerTuning{
+			MaxConcurrentReconciles: 50,
+			RateLimiterBaseDelay:    time.Second,
+			RateLimiterMaxDelay:     time.Minute,
+		}, 5)
+		Expect(opts.MaxConcurrentReconciles).To(Equal(50))
+		Expect(opts.RateLimiter).NotTo(BeNil())
+
+		req := ctrl.Request{}
+		Expect(opts.RateLimiter.When(req)).To(Equal(time.Second))
+		for range 10 {
+			opts.RateLimiter.When(req)
+		}
+		Expect(opts.RateLimiter.When(req)).To(Equal(time.Minute))
+	})
+
+	It("should apply the tuned retry bucket", func() {
+		opts := controllerOptions(config.ControllerTuning{RateLimiterQPS: 1, RateLimiterBurst: 2}, 5)
+		Expect(opts.RateLimiter).NotTo(BeNil())
+
+		// Distinct resources share the bucket; the third waits for a token
+		Expect(opts.RateLimiter.When(ctrl.Request{NamespacedName: types.NamespacedName{Name: "a"}})).
+			To(Equal(defaultRateLimiterBaseDelay))
+		Expect(opts.RateLimiter.When(ctrl.Request{NamespacedName: types.NamespacedName{Name: "b"}})).
+			To(Equal(defaultRateLimiterBaseDelay))
+		Expect(opts.RateLimiter.When(ctrl.Request{NamespacedName: types.NamespacedName{Name: "c"}})).
+			To(BeNumerically(">", 500*time.Millisecond))
+	})
+})
+
+var _ = Describe("Reconcile priority", func() {
+	It("should put stuck and flapping polecats ahead of idle ones", func() {
+		polecat := &gastownv1alpha1.Polecat{}
+		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseWorking
+		Expect(polecatPriority(polecat)).To(Equal(priorityNormal))
+
+		polecat.Status.AgentRestarts = flappingAgentRestarts
+		Expect(polecatPriority(polecat)).To(Equal(priorityHigh))
+
+		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseStuck
+		Expect(polecatPriority(polecat)).To(Equal(priorityHigh))
+
+		polecat.Status.Phase = gastownv1alpha1.PolecatPhaseIdle
+		Expect(polecatPriority(polecat)).To(Equal(priorityLow))
+	})
+
+	It("should put refineries with deep merge queues ahead of idle ones", func() {
+		refinery := &gastownv1alpha1.Refinery{}
+		Expect(refineryPriority(refinery)).To(Equal(priorityLow))
+
+		refinery.Status.QueueLength = 1
+		Expect(refineryPriority(refinery)).To(Equal(priorityNormal))
+
+		refinery.Status.QueueLength = deepMergeQueue
+		Expect(refineryPriority(refinery)).To(Equal(priorityHigh))
+	})
+
+	It("should let the annotation override the hint", func() {
+		refinery := &gastownv1alpha1.Refinery{}
+		var result ctrl.Result
+		withPriority(&result, refinery, priorityHigh)
+		Expect(*result.Priority).To(Equal(priorityHigh))
+
+		refinery.Annotations = map[string]string{ReconcilePriorityAnnotation: "low"}
+		withPriority(&result, refinery, priorityHigh)
+		Expect(*result.Priority).To(Equal(priorityLow))
+
+		refinery.Annotations[ReconcilePriorityAnnotation] = "high"
+		withPriority(&result, refinery, priorityLow)
+		Expect(*result.Priority).To(Equal(priorityHigh))
+	})
+})
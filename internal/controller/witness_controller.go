@@ -18,6 +18,7 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -27,11 +28,12 @@ import (
 	"k8s.io/client-go/tools/record"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
-	"sigs.k8s.io/controller-runtime/pkg/controller"
 	logf "sigs.k8s.io/controller-runtime/pkg/log"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
 	gterrors "github.com/org/gastown-operator/pkg/errors"
+	"github.com/org/gastown-operator/pkg/metrics"
 )
 
 const (
@@ -44,10 +46,23 @@ const (
 	// "Degraded" is a standard Kubernetes condition type.
 	ConditionWitnessDegraded = ConditionDegraded
 
-	// Default intervals if not specified in spec.
-	// Uses RequeueDefault for health check frequency.
-	defaultHealthCheckInterval = RequeueDefault
-	defaultStuckThreshold      = 15 * time.Minute
+	// ConditionStalled is set on Polecats by the Witness when a running agent
+	// showed no activity for longer than the stuck threshold. The Polecat
+	// controller reports stalled polecats as Stuck.
+	ConditionStalled = "Stalled"
+
+	// ConditionGaveUp is set on Polecats by the Witness, with spec.autoReport,
+	// once a polecat stayed failed or stalled for longer than giveUpAfter. The
+	// BeadStore of the polecat's bead then files a follow-up bead.
+	ConditionGaveUp = "GaveUp"
+
+	// Default stuck threshold if not specified in spec.
+	// The health check interval defaults to requeueDefault().
+	defaultStuckThreshold = 15 * time.Minute
+
+	// Default time before giving up on a failed or stalled polecat if
+	// spec.autoReport does not set one.
+	defaultGiveUpAfter = time.Hour
 )
 
 // WitnessReconciler reconciles a Witness object
@@ -62,12 +77,15 @@ type WitnessReconciler struct {
 	// If nil, escalation always proceeds. When configured, prevents
 	// excessive escalation when issues persist.
 	Backoff *gterrors.BackoffCalculator
+	// Tuning overrides the controller's workqueue settings. Optional.
+	Tuning config.ControllerTuning
 }
 
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=witnesses,verbs=get;list;watch;create;update;patch;delete
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=witnesses/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=witnesses/finalizers,verbs=update
 // +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats,verbs=get;list;watch
+// +kubebuilder:rbac:groups=gastown.gastown.io,resources=polecats/status,verbs=get;update;patch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
 // Reconcile monitors Polecat health for the Witness's Rig and updates status.
@@ -80,10 +98,15 @@ func (r *WitnessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
+	// Leave resources processed by a newer operator alone
+	if skip, err := checkSchemaVersion(ctx, r.Client, r.Recorder, witness, &witness.Status.Conditions); skip || err != nil {
+		return ctrl.Result{}, err
+	}
+
 	log.Info("Reconciling Witness", "rigRef", witness.Spec.RigRef)
 
 	// Get health check interval from spec or use default
-	healthCheckInterval := defaultHealthCheckInterval
+	healthCheckInterval := requeueDefault()
 	if witness.Spec.HealthCheckInterval != nil {
 		healthCheckInterval = witness.Spec.HealthCheckInterval.Duration
 	}
@@ -104,16 +127,30 @@ func (r *WitnessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		log.Error(err, "Failed to list Polecats")
 		r.setCondition(witness, ConditionWitnessDegraded, metav1.ConditionTrue,
 			"ListFailed", "Failed to list Polecats")
-		return ctrl.Result{RequeueAfter: healthCheckInterval}, r.Status().Update(ctx, witness)
+		return ctrl.Result{RequeueAfter: healthCheckInterval}, applyStatus(ctx, r.Client, witness, fieldManagerWitness)
 	}
 
 	// Calculate summary
 	summary := r.calculateSummary(polecatList, stuckThreshold)
 
+	// Flag stalled agents so the Polecat controller reports them as Stuck
+	stalled, markErr := r.markStalledPolecats(ctx, polecatList, stuckThreshold)
+
+	// Give up on polecats that stayed failed or stalled, so their beads get
+	// a follow-up
+	if autoReport := witness.Spec.AutoReport; autoReport != nil {
+		giveUpAfter := defaultGiveUpAfter
+		if autoReport.GiveUpAfter != nil {
+			giveUpAfter = autoReport.GiveUpAfter.Duration
+		}
+		markErr = errors.Join(markErr, r.giveUpOnPolecats(ctx, polecatList, giveUpAfter))
+	}
+
 	// Update status
 	witness.Status.Phase = r.determinePhase(summary)
 	witness.Status.LastCheckTime = &metav1.Time{Time: time.Now()}
 	witness.Status.PolecatsSummary = summary
+	witness.Status.StalledPolecats = stalled
 
 	// Key for backoff tracking
 	backoffKey := fmt.Sprintf("%s/%s", witness.Namespace, witness.Name)
@@ -126,7 +163,7 @@ func (r *WitnessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 		// Emit event for stuck polecats
 		if summary.Stuck > 0 {
 			r.Recorder.Event(witness, "Warning", "StuckPolecats",
-				"Detected polecats with no progress")
+				fmt.Sprintf("Detected %d polecats with no progress", summary.Stuck))
 
 			// Escalate to configured target (with circuit breaker)
 			if r.GTClient != nil {
@@ -162,10 +199,14 @@ func (r *WitnessReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ct
 	}
 
 	// Update status
-	if err := r.Status().Update(ctx, witness); err != nil {
+	if err := applyStatus(ctx, r.Client, witness, fieldManagerWitness); err != nil {
 		log.Error(err, "Failed to update Witness status")
 		return ctrl.Result{}, err
 	}
+	if markErr != nil {
+		log.Error(markErr, "Failed to update Polecat conditions")
+		return ctrl.Result{}, markErr
+	}
 
 	log.Info("Witness health check complete",
 		"total", summary.Total,
@@ -205,13 +246,14 @@ func (r *WitnessReconciler) calculateSummary(polecats *gastownv1alpha1.PolecatLi
 				hasProgressing = true
 				if cond.Status == metav1.ConditionTrue {
 					summary.Running++
-					// Check if stuck (no update for too long)
-					if time.Since(cond.LastTransitionTime.Time) > stuckThreshold {
+					// Check if stuck (no activity for too long)
+					if time.Since(lastActivity(&polecat, cond)) > stuckThreshold {
 						summary.Stuck++
 					}
 				}
 			case ConditionDegraded:
-				if cond.Status == metav1.ConditionTrue {
+				// Stalled polecats are counted as stuck, not failed
+				if cond.Status == metav1.ConditionTrue && cond.Reason != "Stalled" {
 					summary.Failed++
 				}
 			// Old conditions (backward compatibility)
@@ -233,7 +275,7 @@ func (r *WitnessReconciler) calculateSummary(polecats *gastownv1alpha1.PolecatLi
 		if !hasProgressing && hasOldWorking && workingCond.Status == metav1.ConditionTrue {
 			summary.Running++
 			// Check if stuck using old Working condition
-			if time.Since(workingCond.LastTransitionTime.Time) > stuckThreshold {
+			if time.Since(lastActivity(&polecat, workingCond)) > stuckThreshold {
 				summary.Stuck++
 			}
 		}
@@ -245,6 +287,123 @@ func (r *WitnessReconciler) calculateSummary(polecats *gastownv1alpha1.PolecatLi
 	return summary
 }
 
+// lastActivity returns when a running polecat last showed activity: its
+// status.lastActivity, or when it started running if that is later.
+func lastActivity(polecat *gastownv1alpha1.Polecat, running metav1.Condition) time.Time {
+	last := running.LastTransitionTime.Time
+	if a := polecat.Status.LastActivity; a != nil && a.After(last) {
+		last = a.Time
+	}
+	return last
+}
+
+// runningCondition returns the condition showing the polecat is running:
+// Progressing, or the old Working condition. It returns false when the
+// polecat is not running.
+func runningCondition(polecat *gastownv1alpha1.Polecat) (metav1.Condition, bool) {
+	cond := meta.FindStatusCondition(polecat.Status.Conditions, ConditionProgressing)
+	if cond == nil {
+		cond = meta.FindStatusCondition(polecat.Status.Conditions, ConditionPolecatWorking)
+	}
+	if cond == nil || cond.Status != metav1.ConditionTrue {
+		return metav1.Condition{}, false
+	}
+	return *cond, true
+}
+
+// markStalledPolecats sets the Stalled condition on running polecats without
+// activity for longer than stuckThreshold and clears it once they show
+// activity again or stop running. It returns the stalled polecats.
+func (r *WitnessReconciler) markStalledPolecats(
+	ctx context.Context, polecats *gastownv1alpha1.PolecatList, stuckThreshold time.Duration,
+) ([]gastownv1alpha1.StalledPolecat, error) {
+	var stalled []gastownv1alpha1.StalledPolecat
+	var errs []error
+	for i := range polecats.Items {
+		polecat := &polecats.Items[i]
+		wasStalled := meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionStalled)
+
+		running, isRunning := runningCondition(polecat)
+		if !isRunning {
+			if wasStalled {
+				errs = append(errs, r.setStalled(ctx, polecat, metav1.ConditionFalse, "NotRunning",
+					"Polecat is no longer running"))
+			}
+			continue
+		}
+
+		last := metav1.NewTime(lastActivity(polecat, running))
+		if time.Since(last.Time) <= stuckThreshold {
+			if wasStalled {
+				errs = append(errs, r.setStalled(ctx, polecat, metav1.ConditionFalse, "Active",
+					fmt.Sprintf("Agent active again at %s", last.UTC().Format(time.RFC3339))))
+				r.Recorder.Event(polecat, "Normal", "Recovered", "Agent is active again")
+			}
+			continue
+		}
+
+		stalled = append(stalled, gastownv1alpha1.StalledPolecat{Name: polecat.Name, LastActivity: &last})
+		if !wasStalled {
+			message := fmt.Sprintf("No agent activity since %s (threshold %s)",
+				last.UTC().Format(time.RFC3339), stuckThreshold)
+			errs = append(errs, r.setStalled(ctx, polecat, metav1.ConditionTrue, "NoActivity", message))
+			r.Recorder.Event(polecat, "Warning", "Stalled", message)
+		}
+	}
+	return stalled, errors.Join(errs...)
+}
+
+// setStalled writes the Stalled condition of a polecat.
+func (r *WitnessReconciler) setStalled(
+	ctx context.Context, polecat *gastownv1alpha1.Polecat, status metav1.ConditionStatus, reason, message string,
+) error {
+	meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+		Type:    ConditionStalled,
+		Status:  status,
+		Reason:  reason,
+		Message: message,
+	})
+	if err := applyConditions(ctx, r.Client, polecat, fieldManagerWitness, witnessPolecatConditionTypes...); err != nil {
+		return fmt.Errorf("failed to update polecat %s: %w", polecat.Name, err)
+	}
+	return nil
+}
+
+// giveUpOnPolecats sets the GaveUp condition on polecats degraded (failed or
+// stalled) for longer than giveUpAfter. Giving up is permanent: the condition
+// is never cleared.
+func (r *WitnessReconciler) giveUpOnPolecats(
+	ctx context.Context, polecats *gastownv1alpha1.PolecatList, giveUpAfter time.Duration,
+) error {
+	var errs []error
+	for i := range polecats.Items {
+		polecat := &polecats.Items[i]
+		if meta.IsStatusConditionTrue(polecat.Status.Conditions, ConditionGaveUp) {
+			continue
+		}
+		degraded := meta.FindStatusCondition(polecat.Status.Conditions, ConditionDegraded)
+		if degraded == nil || degraded.Status != metav1.ConditionTrue ||
+			time.Since(degraded.LastTransitionTime.Time) <= giveUpAfter {
+			continue
+		}
+
+		message := fmt.Sprintf("Gave up on bead %s after %s degraded (%s): %s",
+			polecat.Status.AssignedBead, giveUpAfter, degraded.Reason, degraded.Message)
+		meta.SetStatusCondition(&polecat.Status.Conditions, metav1.Condition{
+			Type:    ConditionGaveUp,
+			Status:  metav1.ConditionTrue,
+			Reason:  degraded.Reason,
+			Message: message,
+		})
+		if err := applyConditions(ctx, r.Client, polecat, fieldManagerWitness, witnessPolecatConditionTypes...); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update polecat %s: %w", polecat.Name, err))
+			continue
+		}
+		r.Recorder.Event(polecat, "Warning", "GaveUp", message)
+	}
+	return errors.Join(errs...)
+}
+
 // determinePhase returns the Witness phase based on summary.
 func (r *WitnessReconciler) determinePhase(summary gastownv1alpha1.PolecatsSummary) string {
 	if summary.Stuck > 0 || summary.Failed > 0 {
@@ -288,11 +447,17 @@ func (r *WitnessReconciler) escalateIssues(ctx context.Context, witness *gastown
 	subject := fmt.Sprintf("Health Alert: Witness %s.%s detected issues", witness.Namespace, witness.Name)
 	message := fmt.Sprintf("Rig: %s\nPhase: %s\nStuck Polecats: %d\nFailed Polecats: %d\nRunning: %d/%d",
 		witness.Spec.RigRef, witness.Status.Phase, summary.Stuck, summary.Failed, summary.Running, summary.Total)
+	for _, p := range witness.Status.StalledPolecats {
+		message += fmt.Sprintf("\nStalled: %s", p.Name)
+		if p.LastActivity != nil {
+			message += fmt.Sprintf(" (last activity %s)", p.LastActivity.UTC().Format(time.RFC3339))
+		}
+	}
 
 	switch target {
 	case "mayor":
 		if r.GTClient != nil {
-			if err := r.GTClient.MailSend(ctx, target, subject, message); err != nil {
+			if err := r.sendMail(ctx, target, subject, message); err != nil {
 				log.Error(err, "Failed to send escalation mail to mayor", "witness", witness.Name)
 				r.Recorder.Event(witness, "Warning", "EscalationFailed",
 					fmt.Sprintf("Failed to send alert to mayor: %v", err))
@@ -320,8 +485,18 @@ func (r *WitnessReconciler) SetupWithManager(mgr ctrl.Manager) error {
 	return ctrl.NewControllerManagedBy(mgr).
 		For(&gastownv1alpha1.Witness{}).
 		Named("witness").
-		WithOptions(controller.Options{
-			MaxConcurrentReconciles: 2, // Witnesses are lightweight monitors
-		}).
+		WithOptions(controllerOptions(r.Tuning, 2)). // Witnesses are lightweight monitors
 		Complete(r)
 }
+
+// sendMail sends gt mail within the gt client timeout, recording the call in
+// the gastown_gt_cli_* metrics.
+func (r *WitnessReconciler) sendMail(ctx context.Context, address, subject, message string) error {
+	ctx, cancel := WithGTClientTimeout(ctx)
+	defer cancel()
+
+	timer := metrics.NewGTCLITimer("mail")
+	err := r.GTClient.MailSend(ctx, address, subject, message)
+	timer.Record(err)
+	return err
+}
@@ -23,9 +23,13 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
 	"k8s.io/client-go/tools/record"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/reconcile"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
@@ -403,4 +407,138 @@ var _ = Describe("Witness Controller", func() {
 			Expect(mockMailSent).To(BeTrue())
 		})
 	})
+
+	Context("When marking stalled polecats", func() {
+		const threshold = 15 * time.Minute
+
+		newRunning := func(name string, lastActivity time.Time) *gastownv1alpha1.Polecat {
+			started := metav1.NewTime(time.Now().Add(-time.Hour))
+			active := metav1.NewTime(lastActivity)
+			return &gastownv1alpha1.Polecat{
+				ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+				Spec:       gastownv1alpha1.PolecatSpec{Rig: "test-rig", BeadID: name},
+				Status: gastownv1alpha1.PolecatStatus{
+					Phase:        gastownv1alpha1.PolecatPhaseWorking,
+					PodActive:    true,
+					LastActivity: &active,
+					Conditions: []metav1.Condition{{
+						Type:               ConditionProgressing,
+						Status:             metav1.ConditionTrue,
+						Reason:             "PodRunning",
+						LastTransitionTime: started,
+					}},
+				},
+			}
+		}
+
+		It("should flag idle agents and clear the flag once they are active again", func() {
+			ctx := context.Background()
+			idle := newRunning("idle", time.Now().Add(-20*time.Minute))
+			busy := newRunning("busy", time.Now().Add(-time.Minute))
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(idle, busy).
+				WithStatusSubresource(&gastownv1alpha1.Polecat{}).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &WitnessReconciler{Client: c, Scheme: c.Scheme(), Recorder: recorder}
+
+			list := &gastownv1alpha1.PolecatList{Items: []gastownv1alpha1.Polecat{*idle, *busy}}
+			stalled, err := r.markStalledPolecats(ctx, list, threshold)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stalled).To(HaveLen(1))
+			Expect(stalled[0].Name).To(Equal("idle"))
+			Expect(recorder.Events).To(Receive(ContainSubstring("Stalled")))
+
+			updated := &gastownv1alpha1.Polecat{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "idle", Namespace: "default"}, updated)).To(Succeed())
+			Expect(meta.IsStatusConditionTrue(updated.Status.Conditions, ConditionStalled)).To(BeTrue())
+
+			// Flagged once, not on every check
+			_, err = r.markStalledPolecats(ctx, list, threshold)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(recorder.Events).NotTo(Receive())
+
+			now := metav1.Now()
+			list.Items[0].Status.LastActivity = &now
+			stalled, err = r.markStalledPolecats(ctx, list, threshold)
+			Expect(err).NotTo(HaveOccurred())
+			Expect(stalled).To(BeEmpty())
+			Expect(recorder.Events).To(Receive(ContainSubstring("Recovered")))
+			Expect(c.Get(ctx, types.NamespacedName{Name: "idle", Namespace: "default"}, updated)).To(Succeed())
+			Expect(meta.IsStatusConditionFalse(updated.Status.Conditions, ConditionStalled)).To(BeTrue())
+		})
+
+		It("should not count stalled polecats as failed", func() {
+			r := &WitnessReconciler{}
+			polecat := newRunning("idle", time.Now().Add(-20*time.Minute))
+			polecat.Status.Conditions = append(polecat.Status.Conditions, metav1.Condition{
+				Type:   ConditionDegraded,
+				Status: metav1.ConditionTrue,
+				Reason: "Stalled",
+			})
+
+			summary := r.calculateSummary(&gastownv1alpha1.PolecatList{Items: []gastownv1alpha1.Polecat{*polecat}}, threshold)
+			Expect(summary.Stuck).To(Equal(int32(1)))
+			Expect(summary.Failed).To(BeZero())
+		})
+
+		It("should give up on polecats degraded for longer than giveUpAfter", func() {
+			ctx := context.Background()
+			failed := newRunning("failed", time.Now())
+			failed.Status.AssignedBead = "gt-1"
+			failed.Status.Conditions = []metav1.Condition{{
+				Type:               ConditionDegraded,
+				Status:             metav1.ConditionTrue,
+				Reason:             "TaskIncomplete",
+				Message:            "Agent could not finish",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-2 * time.Hour)),
+			}}
+			recent := newRunning("recent", time.Now())
+			recent.Status.Conditions = []metav1.Condition{{
+				Type:               ConditionDegraded,
+				Status:             metav1.ConditionTrue,
+				Reason:             "PodFailed",
+				LastTransitionTime: metav1.NewTime(time.Now().Add(-time.Minute)),
+			}}
+			scheme := runtime.NewScheme()
+			Expect(clientgoscheme.AddToScheme(scheme)).To(Succeed())
+			Expect(gastownv1alpha1.AddToScheme(scheme)).To(Succeed())
+			c := fake.NewClientBuilder().WithScheme(scheme).
+				WithObjects(failed, recent).
+				WithStatusSubresource(&gastownv1alpha1.Polecat{}).
+				Build()
+			recorder := record.NewFakeRecorder(10)
+			r := &WitnessReconciler{Client: c, Scheme: c.Scheme(), Recorder: recorder}
+
+			list := &gastownv1alpha1.PolecatList{Items: []gastownv1alpha1.Polecat{*failed, *recent}}
+			Expect(r.giveUpOnPolecats(ctx, list, time.Hour)).To(Succeed())
+			Expect(recorder.Events).To(Receive(ContainSubstring("Gave up on bead gt-1")))
+			Expect(recorder.Events).NotTo(Receive())
+
+			updated := &gastownv1alpha1.Polecat{}
+			Expect(c.Get(ctx, types.NamespacedName{Name: "failed", Namespace: "default"}, updated)).To(Succeed())
+			gaveUp := meta.FindStatusCondition(updated.Status.Conditions, ConditionGaveUp)
+			Expect(gaveUp).NotTo(BeNil())
+			Expect(gaveUp.Status).To(Equal(metav1.ConditionTrue))
+			Expect(gaveUp.Reason).To(Equal("TaskIncomplete"))
+			Expect(c.Get(ctx, types.NamespacedName{Name: "recent", Namespace: "default"}, updated)).To(Succeed())
+			Expect(meta.FindStatusCondition(updated.Status.Conditions, ConditionGaveUp)).To(BeNil())
+
+			// Given up once, not on every check
+			Expect(r.giveUpOnPolecats(ctx, list, time.Hour)).To(Succeed())
+			Expect(recorder.Events).NotTo(Receive())
+		})
+
+		It("should judge recently active polecats by their last activity", func() {
+			r := &WitnessReconciler{}
+			polecat := newRunning("busy", time.Now().Add(-time.Minute))
+
+			summary := r.calculateSummary(&gastownv1alpha1.PolecatList{Items: []gastownv1alpha1.Polecat{*polecat}}, threshold)
+			Expect(summary.Running).To(Equal(int32(1)))
+			Expect(summary.Stuck).To(BeZero())
+		})
+	})
 })
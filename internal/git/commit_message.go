@@ -0,0 +1,68 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+// DefaultCommitMessageTemplate titles squash and merge commits
+// "<bead>: <title>", or just the title without a bead.
+const DefaultCommitMessageTemplate = "{{with .BeadID}}{{.}}: {{end}}{{.Title}}"
+
+// CommitMessageData is what commit message templates are rendered with.
+type CommitMessageData struct {
+	// BeadID is the bead the branch works on
+	BeadID string
+
+	// Title is the bead's title, or the first line of the task
+	Title string
+
+	// Polecat is the polecat that wrote the branch
+	Polecat string
+
+	// Branch is the merged branch
+	Branch string
+
+	// TargetBranch is the branch it is merged into
+	TargetBranch string
+}
+
+// RenderCommitMessage renders a Go template of a commit message, or
+// DefaultCommitMessageTemplate when tmpl is empty. A message that renders
+// empty falls back to "Merge branch '<branch>'".
+func RenderCommitMessage(tmpl string, data CommitMessageData) (string, error) {
+	if tmpl == "" {
+		tmpl = DefaultCommitMessageTemplate
+	}
+	t, err := template.New("commit").Option("missingkey=error").Parse(tmpl)
+	if err != nil {
+		return "", fmt.Errorf("invalid commit message template: %w", err)
+	}
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("failed to render commit message: %w", err)
+	}
+	message := strings.TrimSpace(buf.String())
+	if message == "" {
+		message = "Merge branch '" + data.Branch + "'"
+	}
+	return message, nil
+}
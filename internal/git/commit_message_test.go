@@ -0,0 +1,59 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenderCommitMessage(t *testing.T) {
+	data := CommitMessageData{
+		BeadID:       "gt-42",
+		Title:        "Add retries",
+		Polecat:      "furiosa",
+		Branch:       "polecat/furiosa",
+		TargetBranch: "main",
+	}
+
+	tests := []struct {
+		name string
+		tmpl string
+		data CommitMessageData
+		want string
+	}{
+		{"default", "", data, "gt-42: Add retries"},
+		{"default without bead", "", CommitMessageData{Title: "Add retries"}, "Add retries"},
+		{"custom", "{{.Title}} ({{.BeadID}})\n\nMerged {{.Branch}} into {{.TargetBranch}} for {{.Polecat}}", data,
+			"Add retries (gt-42)\n\nMerged polecat/furiosa into main for furiosa"},
+		{"empty", "{{.BeadID}}", CommitMessageData{Branch: "polecat/nux"}, "Merge branch 'polecat/nux'"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := RenderCommitMessage(tt.tmpl, tt.data)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	_, err := RenderCommitMessage("{{.Title", data)
+	assert.ErrorContains(t, err, "invalid commit message template")
+	_, err = RenderCommitMessage("{{.Author}}", data)
+	assert.ErrorContains(t, err, "failed to render commit message")
+}
@@ -24,11 +24,31 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strconv"
 	"strings"
 
 	"github.com/org/gastown-operator/pkg/pod"
 )
 
+const (
+	// operatorCommitterName is the identity used for commits and tags the operator creates.
+	operatorCommitterName = "Gas Town Operator"
+
+	// operatorCommitterEmail is the email used for commits and tags the operator creates.
+	operatorCommitterEmail = "operator@gastown.io"
+)
+
+// Environment variables the credential helper of token authentication reads
+const (
+	gitUsernameEnv = "GT_GIT_USERNAME"
+	gitPasswordEnv = "GT_GIT_PASSWORD"
+)
+
+// envCredentialHelper is a git credential helper answering with the
+// credentials in the environment
+const envCredentialHelper = `!f() { test "$1" = get || return 0; ` +
+	`echo "username=$` + gitUsernameEnv + `"; echo "password=$` + gitPasswordEnv + `"; }; f`
+
 // Client provides git operations for a repository.
 type Client struct {
 	// RepoDir is the path to the git repository
@@ -40,8 +60,20 @@ type Client struct {
 	// GitURL is the remote repository URL
 	GitURL string
 
+	// Token authenticates HTTPS remotes (e.g., a GitHub App installation
+	// token or a personal access token), as the password of Username. It is
+	// passed to git in the environment, never written to disk.
+	Token string
+
+	// Username is the HTTPS username of Token (optional, defaults to
+	// pod.DefaultGitUsername)
+	Username string
+
 	// knownHostsPath is the path to a temporary known_hosts file (created on demand)
 	knownHostsPath string
+
+	// signing signs and verifies commits (optional, see SetSigningKey)
+	signing *commitSigning
 }
 
 // NewClient creates a new git client for the given repository directory.
@@ -58,6 +90,18 @@ func (c *Client) WithSSHKey(keyPath string) *Client {
 	return c
 }
 
+// SetToken sets the token used to authenticate to HTTPS remotes.
+func (c *Client) SetToken(token string) {
+	c.Token = token
+}
+
+// SetBasicAuth sets the username and password, or token, used to
+// authenticate to HTTPS remotes.
+func (c *Client) SetBasicAuth(username, password string) {
+	c.Username = username
+	c.Token = password
+}
+
 // ensureKnownHosts creates a temporary known_hosts file with pre-verified SSH host keys
 // for common Git hosting providers (GitHub, GitLab, Bitbucket).
 // This prevents MITM attacks by verifying host keys against known-good values.
@@ -96,6 +140,7 @@ func (c *Client) Cleanup() {
 		_ = os.Remove(c.knownHostsPath) //nolint:errcheck // best-effort cleanup
 		c.knownHostsPath = ""
 	}
+	c.removeSigning()
 }
 
 // buildSSHCommand constructs a secure SSH command string with proper host key verification.
@@ -116,26 +161,70 @@ func (c *Client) buildSSHCommand() (string, error) {
 		c.SSHKeyPath, knownHostsPath), nil
 }
 
+// authEnv returns the environment git runs with: the operator's, plus the
+// SSH command or token authentication and commit signing when configured. It
+// returns nil when none is set, so git inherits the environment.
+func (c *Client) authEnv() ([]string, error) {
+	var env []string
+	var gitConfig []string // keys and values, alternating
+	if c.SSHKeyPath != "" {
+		sshCmd, err := c.buildSSHCommand()
+		if err != nil {
+			return nil, fmt.Errorf("failed to configure SSH: %w", err)
+		}
+		env = append(env, "GIT_SSH_COMMAND="+sshCmd)
+	}
+	if c.Token != "" {
+		// A credential helper configured from the environment answers with
+		// the credentials, which keeps them off disk; the empty helper first
+		// drops any helper of the operator's own git config
+		username := c.Username
+		if username == "" {
+			username = pod.DefaultGitUsername
+		}
+		gitConfig = append(gitConfig, "credential.helper", "", "credential.helper", envCredentialHelper)
+		env = append(env,
+			"GIT_TERMINAL_PROMPT=0",
+			gitUsernameEnv+"="+username,
+			gitPasswordEnv+"="+c.Token,
+		)
+	}
+	if c.signing != nil {
+		gitConfig = append(gitConfig, c.signing.config...)
+		env = append(env, c.signing.env...)
+	}
+	if len(gitConfig) > 0 {
+		env = append(env, "GIT_CONFIG_COUNT="+strconv.Itoa(len(gitConfig)/2))
+		for i := 0; i < len(gitConfig); i += 2 {
+			env = append(env,
+				fmt.Sprintf("GIT_CONFIG_KEY_%d=%s", i/2, gitConfig[i]),
+				fmt.Sprintf("GIT_CONFIG_VALUE_%d=%s", i/2, gitConfig[i+1]),
+			)
+		}
+	}
+	if env == nil {
+		return nil, nil
+	}
+	return append(os.Environ(), env...), nil
+}
+
 // runGit executes a git command in the repository directory.
 func (c *Client) runGit(ctx context.Context, args ...string) (string, error) {
 	cmd := exec.CommandContext(ctx, "git", args...)
 	cmd.Dir = c.RepoDir
 
-	// Set up SSH authentication if key is provided
-	if c.SSHKeyPath != "" {
-		sshCmd, err := c.buildSSHCommand()
-		if err != nil {
-			return "", fmt.Errorf("failed to configure SSH: %w", err)
-		}
-		cmd.Env = append(os.Environ(), "GIT_SSH_COMMAND="+sshCmd)
+	// Set up SSH or token authentication if configured
+	env, err := c.authEnv()
+	if err != nil {
+		return "", err
 	}
+	cmd.Env = env
 
 	var stdout, stderr bytes.Buffer
 	cmd.Stdout = &stdout
 	cmd.Stderr = &stderr
 
-	err := cmd.Run()
-	if err != nil {
+	if err := cmd.Run(); err != nil {
 		return "", fmt.Errorf("git %s failed: %w\nstderr: %s",
 			strings.Join(args, " "), err, stderr.String())
 	}
@@ -153,14 +242,12 @@ func (c *Client) Clone(ctx context.Context) error {
 
 	cmd := exec.CommandContext(ctx, "git", "clone", c.GitURL, c.RepoDir)
 
-	// Set up SSH authentication if key is provided
-	if c.SSHKeyPath != "" {
-		sshCmd, err := c.buildSSHCommand()
-		if err != nil {
-			return fmt.Errorf("failed to configure SSH: %w", err)
-		}
-		cmd.Env = append(os.Environ(), "GIT_SSH_COMMAND="+sshCmd)
+	// Set up SSH or token authentication if configured
+	env, err := c.authEnv()
+	if err != nil {
+		return err
 	}
+	cmd.Env = env
 
 	var stderr bytes.Buffer
 	cmd.Stderr = &stderr
@@ -197,8 +284,12 @@ func (c *Client) RebaseOnto(ctx context.Context, ontoBranch string) error {
 }
 
 // Push pushes the current branch to the remote.
+// A push rejected because the remote moved wraps ErrTargetMoved.
 func (c *Client) Push(ctx context.Context) error {
 	_, err := c.runGit(ctx, "push")
+	if err != nil && isPushRejected(err) {
+		return fmt.Errorf("%w: %v", ErrTargetMoved, err)
+	}
 	return err
 }
 
@@ -214,9 +305,30 @@ func (c *Client) Merge(ctx context.Context, branch string) error {
 	return err
 }
 
-// MergeNoFF merges a branch with a merge commit.
+// MergeNoFF merges a branch with a merge commit by the operator.
 func (c *Client) MergeNoFF(ctx context.Context, branch, message string) error {
-	_, err := c.runGit(ctx, "merge", "--no-ff", "-m", message, branch)
+	_, err := c.runGit(ctx,
+		"-c", "user.name="+operatorCommitterName,
+		"-c", "user.email="+operatorCommitterEmail,
+		"merge", "--no-ff", "-m", message, branch)
+	return err
+}
+
+// SquashMerge commits the changes of a branch onto the current branch as a
+// single commit, authored by the author of the branch's last commit and
+// committed by the operator.
+func (c *Client) SquashMerge(ctx context.Context, branch, message string) error {
+	author, err := c.runGit(ctx, "log", "-1", "--format=%an <%ae>", branch)
+	if err != nil {
+		return err
+	}
+	if _, err := c.runGit(ctx, "merge", "--squash", branch); err != nil {
+		return err
+	}
+	_, err = c.runGit(ctx,
+		"-c", "user.name="+operatorCommitterName,
+		"-c", "user.email="+operatorCommitterEmail,
+		"commit", "--author", author, "-m", message)
 	return err
 }
 
@@ -275,3 +387,71 @@ func (c *Client) ResetHard(ctx context.Context, ref string) error {
 	_, err := c.runGit(ctx, "reset", "--hard", ref)
 	return err
 }
+
+// ReadFile returns the content of path relative to the repository root.
+func (c *Client) ReadFile(path string) ([]byte, error) {
+	return os.ReadFile(filepath.Join(c.RepoDir, filepath.Clean(path)))
+}
+
+// CommitFile writes content to path, commits it as the operator, and returns
+// the new HEAD SHA. Parent directories are created as needed.
+func (c *Client) CommitFile(ctx context.Context, path string, content []byte, message string) (string, error) {
+	fullPath := filepath.Join(c.RepoDir, filepath.Clean(path))
+	if err := os.MkdirAll(filepath.Dir(fullPath), 0o750); err != nil {
+		return "", fmt.Errorf("failed to create directory for %s: %w", path, err)
+	}
+	if err := os.WriteFile(fullPath, content, 0o600); err != nil {
+		return "", fmt.Errorf("failed to write %s: %w", path, err)
+	}
+	if _, err := c.runGit(ctx, "add", "--", path); err != nil {
+		return "", err
+	}
+	if _, err := c.runGit(ctx,
+		"-c", "user.name="+operatorCommitterName,
+		"-c", "user.email="+operatorCommitterEmail,
+		"commit", "-m", message); err != nil {
+		return "", err
+	}
+	return c.GetCommitSHA(ctx)
+}
+
+// DiffSize returns the number of changed lines (added + deleted) on headBranch
+// since it diverged from baseBranch. Both branches are resolved on origin.
+// Binary files are counted as a single changed line.
+func (c *Client) DiffSize(ctx context.Context, baseBranch, headBranch string) (int, error) {
+	output, err := c.runGit(ctx, "diff", "--numstat", "origin/"+baseBranch+"...origin/"+headBranch)
+	if err != nil {
+		return 0, err
+	}
+	return parseNumstat(output), nil
+}
+
+// CommitsBehind returns the number of commits on baseBranch that headBranch
+// does not contain. Both branches are resolved on origin.
+func (c *Client) CommitsBehind(ctx context.Context, baseBranch, headBranch string) (int, error) {
+	output, err := c.runGit(ctx, "rev-list", "--count", "origin/"+headBranch+"..origin/"+baseBranch)
+	if err != nil {
+		return 0, err
+	}
+	return strconv.Atoi(output)
+}
+
+// parseNumstat sums added and deleted line counts from `git diff --numstat` output.
+func parseNumstat(output string) int {
+	total := 0
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) < 3 {
+			continue
+		}
+		added, addErr := strconv.Atoi(fields[0])
+		deleted, delErr := strconv.Atoi(fields[1])
+		if addErr != nil || delErr != nil {
+			// Binary files report "-" for both counts
+			total++
+			continue
+		}
+		total += added + deleted
+	}
+	return total
+}
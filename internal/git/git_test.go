@@ -18,6 +18,7 @@ package git
 
 import (
 	"context"
+	"errors"
 	"os"
 	"os/exec"
 	"path/filepath"
@@ -361,3 +362,77 @@ func TestValidateTestCommand(t *testing.T) {
 		})
 	}
 }
+
+func TestParseNumstat(t *testing.T) {
+	tests := []struct {
+		name   string
+		output string
+		want   int
+	}{
+		{name: "empty", output: "", want: 0},
+		{name: "single file", output: "10\t2\tmain.go", want: 12},
+		{name: "multiple files", output: "10\t2\tmain.go\n3\t0\tREADME.md", want: 15},
+		{name: "binary file", output: "-\t-\tlogo.png\n1\t1\tmain.go", want: 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := parseNumstat(tt.output); got != tt.want {
+				t.Errorf("parseNumstat() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestCommitFileAndPush(t *testing.T) {
+	skipIfNoGit(t)
+
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	originDir := filepath.Join(tmpDir, "origin.git")
+	seedDir := filepath.Join(tmpDir, "seed")
+
+	for _, args := range [][]string{
+		{"init", "--bare", "-b", "main", originDir},
+		{"clone", originDir, seedDir},
+		{"-C", seedDir, "-c", "user.name=Test", "-c", "user.email=test@test.com", "commit", "--allow-empty", "-m", "initial"},
+		{"-C", seedDir, "push", "origin", "HEAD:main"},
+	} {
+		if out, err := exec.Command("git", args...).CombinedOutput(); err != nil {
+			t.Fatalf("git %v failed: %v\n%s", args, err, out)
+		}
+	}
+
+	clientA := NewClient(filepath.Join(tmpDir, "a"), originDir)
+	clientB := NewClient(filepath.Join(tmpDir, "b"), originDir)
+	for _, c := range []*Client{clientA, clientB} {
+		if err := c.Clone(ctx); err != nil {
+			t.Fatalf("Clone() error = %v", err)
+		}
+	}
+
+	shaA, err := clientA.CommitFile(ctx, ".beads/issues.jsonl", []byte("{\"id\":\"gt-1\"}\n"), "beads: sync")
+	if err != nil {
+		t.Fatalf("CommitFile() error = %v", err)
+	}
+	if err := clientA.Push(ctx); err != nil {
+		t.Fatalf("Push() error = %v", err)
+	}
+	content, err := clientA.ReadFile(".beads/issues.jsonl")
+	if err != nil || string(content) != "{\"id\":\"gt-1\"}\n" {
+		t.Errorf("ReadFile() = %q, %v", content, err)
+	}
+
+	// B committed on the old tip, so its push must be rejected as a moved target
+	if _, err := clientB.CommitFile(ctx, ".beads/issues.jsonl", []byte("{\"id\":\"gt-2\"}\n"), "beads: sync"); err != nil {
+		t.Fatalf("CommitFile() error = %v", err)
+	}
+	if err := clientB.Push(ctx); !errors.Is(err, ErrTargetMoved) {
+		t.Errorf("Push() error = %v, want ErrTargetMoved", err)
+	}
+
+	head, err := NewClient(originDir, "").runGit(ctx, "rev-parse", "main")
+	if err != nil || head != shaA {
+		t.Errorf("origin main = %s (%v), want %s", head, err, shaA)
+	}
+}
@@ -0,0 +1,496 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// DefaultGitHubAPIURL is the public GitHub REST API endpoint.
+const DefaultGitHubAPIURL = "https://api.github.com"
+
+// GitHubClient is a minimal GitHub API client for release and pull request operations.
+type GitHubClient struct {
+	// BaseURL is the API endpoint (e.g., https://api.github.com or a GitHub Enterprise URL)
+	BaseURL string
+
+	// Token is the bearer token used for authentication
+	Token string
+
+	// HTTPClient performs requests. If nil, a client with a 30s timeout is used.
+	HTTPClient *http.Client
+}
+
+// NewGitHubClient creates a GitHub client. An empty baseURL uses DefaultGitHubAPIURL.
+func NewGitHubClient(baseURL, token string) *GitHubClient {
+	if baseURL == "" {
+		baseURL = DefaultGitHubAPIURL
+	}
+	return &GitHubClient{
+		BaseURL:    strings.TrimRight(baseURL, "/"),
+		Token:      token,
+		HTTPClient: &http.Client{Timeout: 30 * time.Second},
+	}
+}
+
+// CreateRelease creates a GitHub release for an existing tag with
+// auto-generated release notes and returns the release's HTML URL.
+func (g *GitHubClient) CreateRelease(ctx context.Context, owner, repo, tag, targetCommit string) (string, error) {
+	var release struct {
+		HTMLURL string `json:"html_url"`
+	}
+	err := g.do(ctx, http.MethodPost, repoPath(owner, repo, "releases"), map[string]any{
+		"tag_name":               tag,
+		"target_commitish":       targetCommit,
+		"name":                   tag,
+		"generate_release_notes": true,
+	}, http.StatusCreated, &release)
+	if err != nil {
+		return "", fmt.Errorf("github release for %s/%s %s failed: %w", owner, repo, tag, err)
+	}
+	return release.HTMLURL, nil
+}
+
+// PullRequest is the subset of a GitHub pull request the operator tracks.
+type PullRequest struct {
+	Number int
+	URL    string
+
+	// NodeID is the GraphQL ID, needed to enable auto-merge
+	NodeID string
+
+	// State is "open" or "closed"; a merged pull request is closed with Merged set
+	State          string
+	Merged         bool
+	MergeCommitSHA string
+	HeadSHA        string
+
+	// AutoMerge reports whether auto-merge is already enabled
+	AutoMerge bool
+}
+
+// pullRequestResponse is the REST representation of a pull request.
+type pullRequestResponse struct {
+	Number         int     `json:"number"`
+	HTMLURL        string  `json:"html_url"`
+	NodeID         string  `json:"node_id"`
+	State          string  `json:"state"`
+	MergedAt       *string `json:"merged_at"`
+	MergeCommitSHA string  `json:"merge_commit_sha"`
+	AutoMerge      *struct {
+		MergeMethod string `json:"merge_method"`
+	} `json:"auto_merge"`
+	Head struct {
+		SHA string `json:"sha"`
+	} `json:"head"`
+}
+
+func (p pullRequestResponse) toPullRequest() *PullRequest {
+	return &PullRequest{
+		Number:         p.Number,
+		URL:            p.HTMLURL,
+		NodeID:         p.NodeID,
+		State:          p.State,
+		Merged:         p.MergedAt != nil,
+		MergeCommitSHA: p.MergeCommitSHA,
+		HeadSHA:        p.Head.SHA,
+		AutoMerge:      p.AutoMerge != nil,
+	}
+}
+
+// FindPullRequest returns the most recent pull request (open or closed) from
+// head into base in the same repository, or nil if there is none.
+func (g *GitHubClient) FindPullRequest(ctx context.Context, owner, repo, head, base string) (*PullRequest, error) {
+	query := url.Values{
+		"head":      {owner + ":" + head},
+		"base":      {base},
+		"state":     {"all"},
+		"sort":      {"created"},
+		"direction": {"desc"},
+	}
+	var prs []pullRequestResponse
+	if err := g.do(ctx, http.MethodGet, repoPath(owner, repo, "pulls")+"?"+query.Encode(),
+		nil, http.StatusOK, &prs); err != nil {
+		return nil, fmt.Errorf("listing pull requests for %s failed: %w", head, err)
+	}
+	if len(prs) == 0 {
+		return nil, nil
+	}
+	return prs[0].toPullRequest(), nil
+}
+
+// CreatePullRequest opens a pull request from head into base.
+func (g *GitHubClient) CreatePullRequest(
+	ctx context.Context, owner, repo, head, base, title, body string,
+) (*PullRequest, error) {
+	var pr pullRequestResponse
+	err := g.do(ctx, http.MethodPost, repoPath(owner, repo, "pulls"), map[string]any{
+		"head":  head,
+		"base":  base,
+		"title": title,
+		"body":  body,
+	}, http.StatusCreated, &pr)
+	if err != nil {
+		return nil, fmt.Errorf("creating pull request for %s failed: %w", head, err)
+	}
+	return pr.toPullRequest(), nil
+}
+
+// EnableAutoMerge enables auto-merge on a pull request using the GraphQL API
+// (auto-merge is not available over REST). method is merge, squash or rebase.
+func (g *GitHubClient) EnableAutoMerge(ctx context.Context, nodeID, method string) error {
+	const mutation = `mutation($id: ID!, $method: PullRequestMergeMethod!) {
+  enablePullRequestAutoMerge(input: {pullRequestId: $id, mergeMethod: $method}) { clientMutationId }
+}`
+	var resp struct {
+		Errors []struct {
+			Message string `json:"message"`
+		} `json:"errors"`
+	}
+	err := g.do(ctx, http.MethodPost, g.graphQLURL(), map[string]any{
+		"query":     mutation,
+		"variables": map[string]string{"id": nodeID, "method": strings.ToUpper(method)},
+	}, http.StatusOK, &resp)
+	if err != nil {
+		return fmt.Errorf("enabling auto-merge failed: %w", err)
+	}
+	if len(resp.Errors) > 0 {
+		return fmt.Errorf("enabling auto-merge failed: %s", resp.Errors[0].Message)
+	}
+	return nil
+}
+
+// Check states reported by ChecksStatus.
+const (
+	ChecksPending = "pending"
+	ChecksSuccess = "success"
+	ChecksFailure = "failure"
+)
+
+// ChecksStatus summarizes the checks and commit statuses reported for a commit.
+type ChecksStatus struct {
+	// State is ChecksPending, ChecksSuccess or ChecksFailure. A commit
+	// without any checks is reported as ChecksSuccess.
+	State string
+
+	// Total is the number of check runs and commit statuses
+	Total int
+
+	// Completed is the number that have finished
+	Completed int
+
+	// Failed is the number that finished unsuccessfully
+	Failed int
+
+	// Pending names the checks that have not finished
+	Pending []string
+}
+
+// CheckResult is the state of one named check or commit status on a commit.
+type CheckResult struct {
+	// Name is the check run name, or the commit status context
+	Name string
+
+	// State is ChecksPending, ChecksSuccess or ChecksFailure
+	State string
+}
+
+// SummarizeChecks combines check results into a ChecksStatus.
+func SummarizeChecks(results []CheckResult) *ChecksStatus {
+	status := &ChecksStatus{}
+	for _, result := range results {
+		status.Total++
+		switch result.State {
+		case ChecksPending:
+			status.Pending = append(status.Pending, result.Name)
+		case ChecksSuccess:
+			status.Completed++
+		default:
+			status.Completed++
+			status.Failed++
+		}
+	}
+
+	switch {
+	case status.Failed > 0:
+		status.State = ChecksFailure
+	case status.Completed < status.Total:
+		status.State = ChecksPending
+	default:
+		status.State = ChecksSuccess
+	}
+	return status
+}
+
+// SummarizeRequiredChecks combines the results of the required checks only.
+// A required check that has not reported yet is pending; when a name was
+// reported more than once, the first result is used, as providers list the
+// most recent first.
+func SummarizeRequiredChecks(results []CheckResult, required []string) *ChecksStatus {
+	latest := make(map[string]string, len(results))
+	for _, result := range results {
+		if _, ok := latest[result.Name]; !ok {
+			latest[result.Name] = result.State
+		}
+	}
+
+	selected := make([]CheckResult, 0, len(required))
+	for _, name := range required {
+		state, ok := latest[name]
+		if !ok {
+			state = ChecksPending
+		}
+		selected = append(selected, CheckResult{Name: name, State: state})
+	}
+	return SummarizeChecks(selected)
+}
+
+// RequiredStatusChecks returns the names of the status checks that must pass
+// before changes land on branch: those of its classic branch protection and
+// of the repository rulesets applying to it. An unprotected branch requires
+// none. Reading classic protection needs the token to have administration
+// read permission.
+func (g *GitHubClient) RequiredStatusChecks(ctx context.Context, owner, repo, branch string) ([]string, error) {
+	var protection struct {
+		Contexts []string `json:"contexts"`
+		Checks   []struct {
+			Context string `json:"context"`
+		} `json:"checks"`
+	}
+	err := g.do(ctx, http.MethodGet, repoPath(owner, repo, "branches", branch, "protection", "required_status_checks"),
+		nil, http.StatusOK, &protection)
+	var apiErr *APIError
+	if err != nil && !(errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusNotFound) {
+		return nil, fmt.Errorf("getting branch protection of %s failed: %w", branch, err)
+	}
+
+	var rules []struct {
+		Type       string `json:"type"`
+		Parameters struct {
+			RequiredStatusChecks []struct {
+				Context string `json:"context"`
+			} `json:"required_status_checks"`
+		} `json:"parameters"`
+	}
+	if err := g.do(ctx, http.MethodGet, repoPath(owner, repo, "rules", "branches", branch)+"?per_page=100",
+		nil, http.StatusOK, &rules); err != nil {
+		return nil, fmt.Errorf("getting rules of %s failed: %w", branch, err)
+	}
+
+	var required []string
+	seen := map[string]bool{}
+	add := func(name string) {
+		if name != "" && !seen[name] {
+			seen[name] = true
+			required = append(required, name)
+		}
+	}
+	for _, name := range protection.Contexts {
+		add(name)
+	}
+	for _, check := range protection.Checks {
+		add(check.Context)
+	}
+	for _, rule := range rules {
+		if rule.Type != "required_status_checks" {
+			continue
+		}
+		for _, check := range rule.Parameters.RequiredStatusChecks {
+			add(check.Context)
+		}
+	}
+	return required, nil
+}
+
+// GetChecksStatus combines the check runs and commit statuses of ref.
+func (g *GitHubClient) GetChecksStatus(ctx context.Context, owner, repo, ref string) (*ChecksStatus, error) {
+	results, err := g.ListChecks(ctx, owner, repo, ref)
+	if err != nil {
+		return nil, err
+	}
+	return SummarizeChecks(results), nil
+}
+
+// ListChecks returns the check runs and commit statuses of ref.
+func (g *GitHubClient) ListChecks(ctx context.Context, owner, repo, ref string) ([]CheckResult, error) {
+	var runs struct {
+		CheckRuns []struct {
+			Name       string `json:"name"`
+			Status     string `json:"status"`
+			Conclusion string `json:"conclusion"`
+		} `json:"check_runs"`
+	}
+	if err := g.do(ctx, http.MethodGet, repoPath(owner, repo, "commits", ref, "check-runs")+"?per_page=100",
+		nil, http.StatusOK, &runs); err != nil {
+		return nil, fmt.Errorf("listing check runs for %s failed: %w", ref, err)
+	}
+
+	var combined struct {
+		Statuses []struct {
+			Context string `json:"context"`
+			State   string `json:"state"`
+		} `json:"statuses"`
+	}
+	if err := g.do(ctx, http.MethodGet, repoPath(owner, repo, "commits", ref, "status"),
+		nil, http.StatusOK, &combined); err != nil {
+		return nil, fmt.Errorf("getting commit status for %s failed: %w", ref, err)
+	}
+
+	results := make([]CheckResult, 0, len(runs.CheckRuns)+len(combined.Statuses))
+	for _, run := range runs.CheckRuns {
+		result := CheckResult{Name: run.Name, State: ChecksPending}
+		if run.Status == "completed" {
+			switch run.Conclusion {
+			case "success", "neutral", "skipped":
+				result.State = ChecksSuccess
+			default:
+				result.State = ChecksFailure
+			}
+		}
+		results = append(results, result)
+	}
+	for _, st := range combined.Statuses {
+		result := CheckResult{Name: st.Context, State: ChecksFailure}
+		switch st.State {
+		case "pending":
+			result.State = ChecksPending
+		case "success":
+			result.State = ChecksSuccess
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// APIError is a GitHub API response with an unexpected status.
+type APIError struct {
+	StatusCode int
+	Status     string
+	Body       string
+}
+
+func (e *APIError) Error() string {
+	return e.Status + ": " + e.Body
+}
+
+// do sends a JSON request to path (relative to BaseURL, or absolute) and
+// decodes the response into out. Any status other than want is an error.
+func (g *GitHubClient) do(ctx context.Context, method, path string, in any, want int, out any) error {
+	var body io.Reader
+	if in != nil {
+		data, err := json.Marshal(in)
+		if err != nil {
+			return fmt.Errorf("failed to encode request: %w", err)
+		}
+		body = bytes.NewReader(data)
+	}
+
+	endpoint := path
+	if !strings.Contains(path, "://") {
+		endpoint = g.BaseURL + path
+	}
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, body)
+	if err != nil {
+		return fmt.Errorf("failed to build request: %w", err)
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("X-GitHub-Api-Version", "2022-11-28")
+	if in != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if g.Token != "" {
+		req.Header.Set("Authorization", "Bearer "+g.Token)
+	}
+
+	httpClient := g.HTTPClient
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: 30 * time.Second}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }() //nolint:errcheck // best-effort close
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, 1<<20))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+	if resp.StatusCode != want {
+		return &APIError{StatusCode: resp.StatusCode, Status: resp.Status, Body: strings.TrimSpace(string(respBody))}
+	}
+	if out == nil {
+		return nil
+	}
+	if err := json.Unmarshal(respBody, out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
+}
+
+// graphQLURL returns the GraphQL endpoint for BaseURL. GitHub Enterprise
+// serves REST under /api/v3 and GraphQL under /api/graphql.
+func (g *GitHubClient) graphQLURL() string {
+	if base, ok := strings.CutSuffix(g.BaseURL, "/api/v3"); ok {
+		return base + "/api/graphql"
+	}
+	return g.BaseURL + "/graphql"
+}
+
+// repoPath builds /repos/owner/repo/<elems...> with each element escaped.
+func repoPath(owner, repo string, elems ...string) string {
+	path := "/repos/" + url.PathEscape(owner) + "/" + url.PathEscape(repo)
+	for _, elem := range elems {
+		path += "/" + url.PathEscape(elem)
+	}
+	return path
+}
+
+// ParseGitHubRepo extracts the owner and repository name from a git URL.
+// Supports https://host/owner/repo(.git), ssh://git@host/owner/repo(.git)
+// and scp-style git@host:owner/repo(.git) forms.
+func ParseGitHubRepo(gitURL string) (string, string, error) {
+	var path string
+	switch {
+	case strings.Contains(gitURL, "://"):
+		u, err := url.Parse(gitURL)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid git URL %q: %w", gitURL, err)
+		}
+		path = u.Path
+	case strings.Contains(gitURL, ":"):
+		path = gitURL[strings.Index(gitURL, ":")+1:]
+	default:
+		return "", "", fmt.Errorf("unsupported git URL %q", gitURL)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 || parts[0] == "" || parts[1] == "" {
+		return "", "", fmt.Errorf("git URL %q does not name an owner/repo", gitURL)
+	}
+	return parts[0], parts[1], nil
+}
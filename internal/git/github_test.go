@@ -0,0 +1,299 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGitHubFindPullRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodGet, r.Method)
+		assert.Equal(t, "/repos/acme/widgets/pulls", r.URL.Path)
+		assert.Equal(t, "acme:polecat/gt-1", r.URL.Query().Get("head"))
+		assert.Equal(t, "main", r.URL.Query().Get("base"))
+		assert.Equal(t, "all", r.URL.Query().Get("state"))
+		_, _ = w.Write([]byte(`[{"number":7,"html_url":"https://github.com/acme/widgets/pull/7",
+			"node_id":"PR_7","state":"closed","merged_at":"2026-01-02T03:04:05Z",
+			"merge_commit_sha":"m3rg3d","auto_merge":{"merge_method":"squash"},"head":{"sha":"h3ad"}}]`))
+	}))
+	defer server.Close()
+
+	pr, err := NewGitHubClient(server.URL, "secret").
+		FindPullRequest(context.Background(), "acme", "widgets", "polecat/gt-1", "main")
+	require.NoError(t, err)
+	require.NotNil(t, pr)
+	assert.Equal(t, &PullRequest{
+		Number:         7,
+		URL:            "https://github.com/acme/widgets/pull/7",
+		NodeID:         "PR_7",
+		State:          "closed",
+		Merged:         true,
+		MergeCommitSHA: "m3rg3d",
+		HeadSHA:        "h3ad",
+		AutoMerge:      true,
+	}, pr)
+}
+
+func TestGitHubFindPullRequestNone(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	pr, err := NewGitHubClient(server.URL, "secret").
+		FindPullRequest(context.Background(), "acme", "widgets", "polecat/gt-1", "main")
+	require.NoError(t, err)
+	assert.Nil(t, pr)
+}
+
+func TestGitHubCreatePullRequest(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/acme/widgets/pulls", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"number":8,"html_url":"https://github.com/acme/widgets/pull/8",
+			"state":"open","merged_at":null,"auto_merge":null,"head":{"sha":"h3ad"}}`))
+	}))
+	defer server.Close()
+
+	pr, err := NewGitHubClient(server.URL, "secret").CreatePullRequest(context.Background(),
+		"acme", "widgets", "polecat/gt-1", "main", "gt-1: Fix it", "body")
+	require.NoError(t, err)
+	assert.Equal(t, 8, pr.Number)
+	assert.Equal(t, "open", pr.State)
+	assert.False(t, pr.Merged)
+	assert.False(t, pr.AutoMerge)
+	assert.Equal(t, "polecat/gt-1", got["head"])
+	assert.Equal(t, "main", got["base"])
+	assert.Equal(t, "gt-1: Fix it", got["title"])
+}
+
+func TestGitHubEnableAutoMerge(t *testing.T) {
+	tests := []struct {
+		name     string
+		baseURL  string
+		wantPath string
+		response string
+		wantErr  bool
+	}{
+		{
+			name:     "github.com",
+			baseURL:  "",
+			wantPath: "/graphql",
+			response: `{"data":{}}`,
+		},
+		{
+			name:     "enterprise",
+			baseURL:  "/api/v3",
+			wantPath: "/api/graphql",
+			response: `{"data":{}}`,
+		},
+		{
+			name:     "graphql error",
+			wantPath: "/graphql",
+			response: `{"errors":[{"message":"Pull request is in clean status"}]}`,
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got struct {
+				Variables map[string]string `json:"variables"`
+			}
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, tt.wantPath, r.URL.Path)
+				assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			err := NewGitHubClient(server.URL+tt.baseURL, "secret").
+				EnableAutoMerge(context.Background(), "PR_7", "squash")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "PR_7", got.Variables["id"])
+			assert.Equal(t, "SQUASH", got.Variables["method"])
+		})
+	}
+}
+
+func TestGitHubGetChecksStatus(t *testing.T) {
+	tests := []struct {
+		name      string
+		checkRuns string
+		statuses  string
+		want      ChecksStatus
+	}{
+		{
+			name:      "no checks",
+			checkRuns: `[]`,
+			statuses:  `[]`,
+			want:      ChecksStatus{State: ChecksSuccess},
+		},
+		{
+			name:      "all passed",
+			checkRuns: `[{"status":"completed","conclusion":"success"},{"status":"completed","conclusion":"skipped"}]`,
+			statuses:  `[{"state":"success"}]`,
+			want:      ChecksStatus{State: ChecksSuccess, Total: 3, Completed: 3},
+		},
+		{
+			name:      "pending",
+			checkRuns: `[{"name":"e2e","status":"in_progress","conclusion":null}]`,
+			statuses:  `[{"state":"success"}]`,
+			want:      ChecksStatus{State: ChecksPending, Total: 2, Completed: 1, Pending: []string{"e2e"}},
+		},
+		{
+			name:      "failure wins over pending",
+			checkRuns: `[{"status":"completed","conclusion":"failure"},{"name":"lint","status":"queued","conclusion":null}]`,
+			statuses:  `[{"state":"error"}]`,
+			want:      ChecksStatus{State: ChecksFailure, Total: 3, Completed: 2, Failed: 2, Pending: []string{"lint"}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/acme/widgets/commits/h3ad/check-runs":
+					_, _ = w.Write([]byte(`{"check_runs":` + tt.checkRuns + `}`))
+				case "/repos/acme/widgets/commits/h3ad/status":
+					_, _ = w.Write([]byte(`{"statuses":` + tt.statuses + `}`))
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			got, err := NewGitHubClient(server.URL, "secret").
+				GetChecksStatus(context.Background(), "acme", "widgets", "h3ad")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, *got)
+		})
+	}
+}
+
+func TestGitHubRequiredStatusChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		protection string
+		rules      string
+		want       []string
+	}{
+		{
+			name:  "unprotected",
+			rules: `[]`,
+		},
+		{
+			name:       "branch protection and rulesets",
+			protection: `{"contexts":["build","lint"],"checks":[{"context":"build","app_id":15368}]}`,
+			rules: `[{"type":"pull_request"},` +
+				`{"type":"required_status_checks","parameters":{"required_status_checks":[{"context":"e2e"},{"context":"lint"}]}}]`,
+			want: []string{"build", "lint", "e2e"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repos/acme/widgets/branches/main/protection/required_status_checks":
+					if tt.protection == "" {
+						w.WriteHeader(http.StatusNotFound)
+						_, _ = w.Write([]byte(`{"message":"Branch not protected"}`))
+						return
+					}
+					_, _ = w.Write([]byte(tt.protection))
+				case "/repos/acme/widgets/rules/branches/main":
+					_, _ = w.Write([]byte(tt.rules))
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+
+			got, err := NewGitHubClient(server.URL, "secret").
+				RequiredStatusChecks(context.Background(), "acme", "widgets", "main")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+
+	t.Run("forbidden", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(http.StatusForbidden)
+			_, _ = w.Write([]byte(`{"message":"Resource not accessible by integration"}`))
+		}))
+		defer server.Close()
+
+		_, err := NewGitHubClient(server.URL, "secret").
+			RequiredStatusChecks(context.Background(), "acme", "widgets", "main")
+		assert.ErrorContains(t, err, "403 Forbidden")
+	})
+}
+
+func TestSummarizeRequiredChecks(t *testing.T) {
+	results := []CheckResult{
+		{Name: "build", State: ChecksSuccess},
+		{Name: "e2e", State: ChecksFailure},
+		{Name: "lint", State: ChecksFailure},
+		{Name: "build", State: ChecksFailure}, // older run of build
+	}
+
+	tests := []struct {
+		name     string
+		required []string
+		want     ChecksStatus
+	}{
+		{
+			name:     "required checks passed",
+			required: []string{"build"},
+			want:     ChecksStatus{State: ChecksSuccess, Total: 1, Completed: 1},
+		},
+		{
+			name:     "unreported check is pending",
+			required: []string{"build", "security-scan"},
+			want:     ChecksStatus{State: ChecksPending, Total: 2, Completed: 1, Pending: []string{"security-scan"}},
+		},
+		{
+			name:     "required check failed",
+			required: []string{"build", "e2e"},
+			want:     ChecksStatus{State: ChecksFailure, Total: 2, Completed: 2, Failed: 1},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, *SummarizeRequiredChecks(results, tt.required))
+		})
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// appJWTLifetime is how long an app JWT is valid; GitHub allows at most 10 minutes
+	appJWTLifetime = 9 * time.Minute
+
+	// appJWTClockSkew backdates the JWT issue time to tolerate clock drift
+	appJWTClockSkew = time.Minute
+
+	// installationTokenRenewBefore renews cached installation tokens this long
+	// before they expire, so a token never expires in the middle of a merge
+	installationTokenRenewBefore = 10 * time.Minute
+)
+
+// AppCredentials identify a GitHub App installation.
+type AppCredentials struct {
+	// APIURL is the API endpoint. Empty means DefaultGitHubAPIURL.
+	APIURL string
+
+	// AppID is the GitHub App ID (or client ID).
+	AppID string
+
+	// InstallationID is the app's installation on the repository owner.
+	InstallationID int64
+
+	// PrivateKey is the app's PEM-encoded RSA private key.
+	PrivateKey []byte
+}
+
+// InstallationToken is a short-lived token for a GitHub App installation.
+type InstallationToken struct {
+	Token     string
+	ExpiresAt time.Time
+}
+
+// AppJWT signs the JWT a GitHub App authenticates with, valid from now.
+func AppJWT(appID string, privateKey []byte, now time.Time) (string, error) {
+	key, err := parseRSAPrivateKey(privateKey)
+	if err != nil {
+		return "", err
+	}
+
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]any{
+		"iat": now.Add(-appJWTClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": appID,
+	})
+	if err != nil {
+		return "", err
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+	digest := sha256.Sum256([]byte(signingInput))
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA256, digest[:])
+	if err != nil {
+		return "", fmt.Errorf("failed to sign app JWT: %w", err)
+	}
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parseRSAPrivateKey decodes a PKCS#1 (as downloaded from GitHub) or PKCS#8 PEM key.
+func parseRSAPrivateKey(data []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, fmt.Errorf("app private key is not PEM encoded")
+	}
+	if key, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return key, nil
+	}
+	parsed, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, fmt.Errorf("invalid app private key: %w", err)
+	}
+	key, ok := parsed.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("app private key is not an RSA key")
+	}
+	return key, nil
+}
+
+// CreateInstallationToken exchanges the client's app JWT for an installation token.
+func (g *GitHubClient) CreateInstallationToken(ctx context.Context, installationID int64) (InstallationToken, error) {
+	var resp struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	path := "/app/installations/" + strconv.FormatInt(installationID, 10) + "/access_tokens"
+	if err := g.do(ctx, http.MethodPost, path, nil, http.StatusCreated, &resp); err != nil {
+		return InstallationToken{}, fmt.Errorf("github installation token for %d failed: %w", installationID, err)
+	}
+	if resp.Token == "" {
+		return InstallationToken{}, fmt.Errorf("github returned an empty installation token for %d", installationID)
+	}
+	return InstallationToken{Token: resp.Token, ExpiresAt: resp.ExpiresAt}, nil
+}
+
+// AppTokenCache hands out installation tokens, reusing a token until it is
+// close to expiry. It is safe for concurrent use.
+type AppTokenCache struct {
+	// HTTPClient performs requests. If nil, a client with a 30s timeout is used.
+	HTTPClient *http.Client
+
+	// now returns the current time; overridden in tests
+	now func() time.Time
+
+	mu     sync.Mutex
+	tokens map[string]InstallationToken
+}
+
+// DefaultAppTokens is the token cache shared by the controllers.
+var DefaultAppTokens = &AppTokenCache{}
+
+// Token returns a valid installation token for creds, exchanging a new one
+// when none is cached or the cached one expires within
+// installationTokenRenewBefore.
+func (c *AppTokenCache) Token(ctx context.Context, creds AppCredentials) (string, error) {
+	now := time.Now
+	if c.now != nil {
+		now = c.now
+	}
+	apiURL := strings.TrimRight(creds.APIURL, "/")
+	if apiURL == "" {
+		apiURL = DefaultGitHubAPIURL
+	}
+	key := apiURL + "|" + creds.AppID + "|" + strconv.FormatInt(creds.InstallationID, 10)
+
+	// Holding the lock across the exchange keeps concurrent reconciles from
+	// minting several tokens for the same installation
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if cached, ok := c.tokens[key]; ok && now().Add(installationTokenRenewBefore).Before(cached.ExpiresAt) {
+		return cached.Token, nil
+	}
+
+	jwt, err := AppJWT(creds.AppID, creds.PrivateKey, now())
+	if err != nil {
+		return "", err
+	}
+	client := NewGitHubClient(apiURL, jwt)
+	if c.HTTPClient != nil {
+		client.HTTPClient = c.HTTPClient
+	}
+	token, err := client.CreateInstallationToken(ctx, creds.InstallationID)
+	if err != nil {
+		return "", err
+	}
+
+	if c.tokens == nil {
+		c.tokens = make(map[string]InstallationToken)
+	}
+	c.tokens[key] = token
+	return token.Token, nil
+}
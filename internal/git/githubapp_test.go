@@ -0,0 +1,155 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"os/exec"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newAppKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	return key, pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(key)})
+}
+
+func TestAppJWT(t *testing.T) {
+	key, keyPEM := newAppKey(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	jwt, err := AppJWT("12345", keyPEM, now)
+	require.NoError(t, err)
+
+	parts := strings.Split(jwt, ".")
+	require.Len(t, parts, 3)
+
+	claimsJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	var claims struct {
+		Iat int64  `json:"iat"`
+		Exp int64  `json:"exp"`
+		Iss string `json:"iss"`
+	}
+	require.NoError(t, json.Unmarshal(claimsJSON, &claims))
+	assert.Equal(t, "12345", claims.Iss)
+	assert.Equal(t, now.Add(-time.Minute).Unix(), claims.Iat)
+	assert.Equal(t, now.Add(9*time.Minute).Unix(), claims.Exp)
+
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+	digest := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+	assert.NoError(t, rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, digest[:], signature))
+
+	// PKCS#8 keys are accepted too
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(key)
+	require.NoError(t, err)
+	_, err = AppJWT("12345", pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8}), now)
+	assert.NoError(t, err)
+
+	_, err = AppJWT("12345", []byte("not a key"), now)
+	assert.Error(t, err)
+}
+
+func TestAppTokenCache(t *testing.T) {
+	_, keyPEM := newAppKey(t)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	exchanges := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/app/installations/42/access_tokens", r.URL.Path)
+		assert.True(t, strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ey"))
+		exchanges++
+		w.WriteHeader(http.StatusCreated)
+		_, _ = fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q}`,
+			exchanges, now.Add(time.Hour).Format(time.RFC3339))
+	}))
+	defer server.Close()
+
+	cache := &AppTokenCache{now: func() time.Time { return now }}
+	creds := AppCredentials{APIURL: server.URL, AppID: "12345", InstallationID: 42, PrivateKey: keyPEM}
+
+	token, err := cache.Token(context.Background(), creds)
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_1", token)
+
+	// Reused while far from expiry
+	now = now.Add(45 * time.Minute)
+	token, err = cache.Token(context.Background(), creds)
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_1", token)
+
+	// Renewed shortly before it expires
+	now = now.Add(6 * time.Minute)
+	token, err = cache.Token(context.Background(), creds)
+	require.NoError(t, err)
+	assert.Equal(t, "ghs_2", token)
+	assert.Equal(t, 2, exchanges)
+}
+
+func TestClientTokenAuthEnv(t *testing.T) {
+	client := NewClient("/tmp/repo", "https://github.com/example/repo.git")
+	env, err := client.authEnv()
+	require.NoError(t, err)
+	assert.Nil(t, env, "no credentials should inherit the environment")
+
+	client.SetToken("ghs_secret")
+	assert.Equal(t, "username=x-access-token\npassword=ghs_secret", fillCredential(t, client))
+	assert.NotContains(t, client.GitURL, "ghs_secret")
+
+	client.SetBasicAuth("oauth2", "glpat_secret")
+	assert.Equal(t, "username=oauth2\npassword=glpat_secret", fillCredential(t, client))
+}
+
+// fillCredential asks git for the credentials of an HTTPS remote in the
+// client's environment.
+func fillCredential(t *testing.T, client *Client) string {
+	t.Helper()
+	env, err := client.authEnv()
+	require.NoError(t, err)
+	cmd := exec.Command("git", "credential", "fill")
+	cmd.Env = env
+	cmd.Stdin = strings.NewReader("protocol=https\nhost=github.com\n\n")
+	out, err := cmd.Output()
+	require.NoError(t, err)
+
+	var creds []string
+	for _, line := range strings.Split(strings.TrimSpace(string(out)), "\n") {
+		if strings.HasPrefix(line, "username=") || strings.HasPrefix(line, "password=") {
+			creds = append(creds, line)
+		}
+	}
+	return strings.Join(creds, "\n")
+}
@@ -16,7 +16,10 @@ limitations under the License.
 
 package git
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // GitClient defines the interface for git operations needed by the refinery.
 type GitClient interface {
@@ -27,6 +30,105 @@ type GitClient interface {
 	MergeBranch(ctx context.Context, opts MergeOptions) (*MergeResult, error)
 }
 
+// DiffSizer is implemented by git clients that can measure branch diffs.
+// The refinery uses it to order the merge queue by diff size.
+type DiffSizer interface {
+	// DiffSize returns the number of changed lines (added + deleted) on
+	// headBranch since it diverged from baseBranch.
+	DiffSize(ctx context.Context, baseBranch, headBranch string) (int, error)
+}
+
+// DriftRefresher is implemented by git clients that can detect and repair
+// queued branches that fell behind the target branch. The refinery uses it to
+// refresh stale merge queue entries before merging them.
+type DriftRefresher interface {
+	// CommitsBehind returns the number of commits on baseBranch that
+	// headBranch does not contain.
+	CommitsBehind(ctx context.Context, baseBranch, headBranch string) (int, error)
+
+	// RefreshBranch rebases the source branch onto the target branch, runs
+	// the test command and force-pushes the source branch.
+	RefreshBranch(ctx context.Context, opts MergeOptions) error
+}
+
+// BranchPublisher is implemented by git clients that can publish a rebased
+// branch for external CI. The refinery uses it to wait for required checks
+// on the exact commit it will push to the target branch.
+type BranchPublisher interface {
+	// PublishBranch rebases the source branch onto the target branch, runs
+	// the test command, force-pushes the source branch and returns its head.
+	PublishBranch(ctx context.Context, opts MergeOptions) (string, error)
+}
+
+// Tagger is implemented by git clients that can cut release tags.
+// The refinery uses it to tag the target branch after a merged batch.
+type Tagger interface {
+	// LatestTag returns the highest version tag matching prefix, or "" if none exist.
+	LatestTag(ctx context.Context, prefix string) (string, error)
+
+	// CreateTag creates an annotated tag on origin/branch and returns the tagged commit SHA.
+	CreateTag(ctx context.Context, tag, branch, message string) (string, error)
+
+	// PushTag pushes the tag to origin.
+	PushTag(ctx context.Context, tag string) error
+}
+
+// LeaseHolder is implemented by git clients that can hold a lease in a ref
+// of the remote. The refinery uses it to keep other refineries, e.g. of
+// another cluster, from merging into the same target branch.
+type LeaseHolder interface {
+	// AcquireLease takes or renews the lease on ref for lease.Owner. A lease
+	// of another owner that has not expired returns an error wrapping
+	// ErrLeaseHeld.
+	AcquireLease(ctx context.Context, ref string, lease Lease, now time.Time) error
+}
+
+// BranchDeleter is implemented by git clients that can remove remote branches.
+// The refinery uses it to clean up merged polecat branches.
+type BranchDeleter interface {
+	// BranchExists checks if a branch exists locally or on origin.
+	BranchExists(ctx context.Context, branch string) (bool, error)
+
+	// DeleteRemoteBranch deletes the branch on origin.
+	DeleteRemoteBranch(ctx context.Context, branch string) error
+}
+
+// FileCommitter is implemented by git clients that can read and commit files
+// on the checked-out branch. The BeadStore uses it to sync issues.jsonl.
+type FileCommitter interface {
+	// ReadFile returns the content of path (relative to the repository root).
+	ReadFile(path string) ([]byte, error)
+
+	// CommitFile writes content to path and commits it, returning the new HEAD SHA.
+	CommitFile(ctx context.Context, path string, content []byte, message string) (string, error)
+
+	// GetCommitSHA returns the SHA of the current HEAD.
+	GetCommitSHA(ctx context.Context) (string, error)
+
+	// Push pushes the current branch to the remote. A push rejected because
+	// the remote moved returns an error wrapping ErrTargetMoved.
+	Push(ctx context.Context) error
+}
+
+// TokenAuthenticator is implemented by git clients that can authenticate to
+// HTTPS remotes with a token instead of an SSH key.
+type TokenAuthenticator interface {
+	SetToken(token string)
+}
+
+// CommitSigner is implemented by git clients that can sign the commits they
+// create with a private key of the given format (SigningFormatGPG or
+// SigningFormatSSH), and merge only branches whose commits it signed.
+type CommitSigner interface {
+	SetSigningKey(format string, key []byte) error
+}
+
+// BasicAuthenticator is implemented by git clients that can authenticate to
+// HTTPS remotes with a username and a password or token.
+type BasicAuthenticator interface {
+	SetBasicAuth(username, password string)
+}
+
 // GitClientFactory creates git clients for merge operations.
 type GitClientFactory func(repoDir, gitURL, sshKeyPath string) GitClient
 
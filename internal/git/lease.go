@@ -0,0 +1,117 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+)
+
+// LeaseRefPrefix is where leases on branches are kept on the remote, e.g.
+// refs/gastown/leases/main for the lease on main.
+const LeaseRefPrefix = "refs/gastown/leases/"
+
+// ErrLeaseHeld is returned when another owner holds an unexpired lease.
+var ErrLeaseHeld = errors.New("lease held by another owner")
+
+// Lease is a claim on a ref of the remote. It is stored as the message of a
+// commit with an empty tree, which the ref points to.
+type Lease struct {
+	// Owner identifies the holder; a lease is renewed by its owner
+	Owner string
+
+	// Holder describes the owner for humans, e.g. namespace/name
+	Holder string
+
+	// Expires is when other owners may take the lease over
+	Expires time.Time
+}
+
+// String formats the lease as the commit message stored on the remote.
+func (l Lease) String() string {
+	return fmt.Sprintf("Gas Town lease\n\nOwner: %s\nHolder: %s\nExpires: %s\n",
+		l.Owner, l.Holder, l.Expires.UTC().Format(time.RFC3339))
+}
+
+// parseLease parses a lease commit message. Unparseable leases have a zero
+// expiry, so they count as expired.
+func parseLease(message string) Lease {
+	var lease Lease
+	for _, line := range strings.Split(message, "\n") {
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			continue
+		}
+		switch key {
+		case "Owner":
+			lease.Owner = value
+		case "Holder":
+			lease.Holder = value
+		case "Expires":
+			lease.Expires, _ = time.Parse(time.RFC3339, value)
+		}
+	}
+	return lease
+}
+
+// AcquireLease writes lease to ref on origin unless ref holds an unexpired
+// lease of another owner, which returns an error wrapping ErrLeaseHeld. The
+// push only succeeds if ref did not move since it was read, so of two
+// concurrent writers one fails the same way.
+func (c *Client) AcquireLease(ctx context.Context, ref string, lease Lease, now time.Time) error {
+	output, err := c.runGit(ctx, "ls-remote", "origin", ref)
+	if err != nil {
+		return err
+	}
+
+	expected := ""
+	if fields := strings.Fields(output); len(fields) > 0 {
+		expected = fields[0]
+		if _, err := c.runGit(ctx, "fetch", "--no-tags", "origin", ref); err != nil {
+			return err
+		}
+		message, err := c.runGit(ctx, "log", "-1", "--format=%B", expected)
+		if err != nil {
+			return err
+		}
+		held := parseLease(message)
+		if held.Owner != lease.Owner && now.Before(held.Expires) {
+			return fmt.Errorf("%w: %s until %s", ErrLeaseHeld, held.Holder, held.Expires.UTC().Format(time.RFC3339))
+		}
+	}
+
+	tree, err := c.runGit(ctx, "hash-object", "-t", "tree", "-w", os.DevNull)
+	if err != nil {
+		return err
+	}
+	commit, err := c.runGit(ctx,
+		"-c", "user.name="+operatorCommitterName,
+		"-c", "user.email="+operatorCommitterEmail,
+		"commit-tree", tree, "-m", lease.String())
+	if err != nil {
+		return err
+	}
+	_, err = c.runGit(ctx, "push", "--force-with-lease="+ref+":"+expected, "origin", commit+":"+ref)
+	if err != nil && isPushRejected(err) {
+		return fmt.Errorf("%w: %s was updated concurrently: %v", ErrLeaseHeld, ref, err)
+	}
+	return err
+}
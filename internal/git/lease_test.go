@@ -0,0 +1,82 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLease(t *testing.T) {
+	expires := time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC)
+	lease := Lease{Owner: "uid-1", Holder: "default/refinery", Expires: expires}
+	assert.Equal(t, lease, parseLease(lease.String()))
+
+	assert.True(t, parseLease("not a lease").Expires.IsZero())
+}
+
+func TestAcquireLease(t *testing.T) {
+	skipIfNoGit(t)
+
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	remoteDir := tmpDir + "/remote.git"
+	seedDir := tmpDir + "/seed"
+
+	require.NoError(t, runGitCmd(t, "", "init", "--bare", "-b", "main", remoteDir))
+	require.NoError(t, runGitCmd(t, "", "clone", remoteDir, seedDir))
+	require.NoError(t, runGitCmd(t, seedDir, "-c", "user.name=Test", "-c", "user.email=test@test.com",
+		"commit", "--allow-empty", "-m", "initial"))
+	require.NoError(t, runGitCmd(t, seedDir, "push", "origin", "HEAD:main"))
+
+	staging := NewClient(tmpDir+"/staging", remoteDir)
+	production := NewClient(tmpDir+"/production", remoteDir)
+	for _, c := range []*Client{staging, production} {
+		require.NoError(t, c.Clone(ctx))
+	}
+
+	ref := LeaseRefPrefix + "main"
+	now := time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC)
+	stagingLease := Lease{Owner: "uid-staging", Holder: "staging/refinery", Expires: now.Add(10 * time.Minute)}
+	productionLease := Lease{Owner: "uid-production", Holder: "production/refinery", Expires: now.Add(10 * time.Minute)}
+
+	// The first owner takes the lease and renews it
+	require.NoError(t, staging.AcquireLease(ctx, ref, stagingLease, now))
+	require.NoError(t, staging.AcquireLease(ctx, ref, stagingLease, now.Add(time.Minute)))
+
+	// Another owner is kept out until it expires
+	err := production.AcquireLease(ctx, ref, productionLease, now.Add(5*time.Minute))
+	require.ErrorIs(t, err, ErrLeaseHeld)
+	assert.Contains(t, err.Error(), "staging/refinery")
+
+	later := now.Add(15 * time.Minute)
+	productionLease.Expires = later.Add(10 * time.Minute)
+	require.NoError(t, production.AcquireLease(ctx, ref, productionLease, later))
+	require.ErrorIs(t, staging.AcquireLease(ctx, ref, stagingLease, later), ErrLeaseHeld)
+
+	// The lease lives outside the branches
+	message, err := NewClient(remoteDir, "").runGit(ctx, "log", "-1", "--format=%B", ref)
+	require.NoError(t, err)
+	assert.Equal(t, "uid-production", parseLease(message).Owner)
+	branches, err := NewClient(remoteDir, "").runGit(ctx, "branch", "--list")
+	require.NoError(t, err)
+	assert.Equal(t, "main", branches[2:])
+}
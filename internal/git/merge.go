@@ -19,6 +19,7 @@ package git
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"os"
 	"os/exec"
@@ -26,6 +27,33 @@ import (
 	"strings"
 )
 
+// ErrTargetMoved is returned by MergeBranch when the push is rejected because
+// the target branch advanced on the remote after it was fetched. The local
+// target branch is reset, so calling MergeBranch again rebases onto the new tip.
+var ErrTargetMoved = errors.New("target branch moved during merge")
+
+// ErrHeadChanged is returned by MergeBranch when the rebased source branch is
+// not MergeOptions.ExpectedHead, because the target branch moved after that
+// commit was published. Nothing is pushed.
+var ErrHeadChanged = errors.New("rebased branch differs from the expected head")
+
+// ErrRebaseConflict is returned when the source branch does not rebase
+// cleanly onto the target branch. The rebase is aborted.
+var ErrRebaseConflict = errors.New("rebase failed")
+
+// Methods MergeBranch lands the rebased source branch with
+const (
+	// MergeMethodRebase fast-forwards the target branch to the source
+	// branch's commits
+	MergeMethodRebase = "rebase"
+
+	// MergeMethodSquash lands the source branch as a single commit
+	MergeMethodSquash = "squash"
+
+	// MergeMethodMerge lands the source branch with a merge commit
+	MergeMethodMerge = "merge"
+)
+
 // MergeOptions configures the merge workflow.
 type MergeOptions struct {
 	// SourceBranch is the branch to merge (e.g., feature/ap-1234)
@@ -39,6 +67,18 @@ type MergeOptions struct {
 
 	// DeleteSourceBranch deletes the source branch after successful merge
 	DeleteSourceBranch bool
+
+	// ExpectedHead, if set, is the commit the rebased source branch must be
+	// for MergeBranch to push it, e.g. the commit external checks ran on
+	ExpectedHead string
+
+	// Method is how the rebased source branch lands on the target branch:
+	// MergeMethodRebase (the default), MergeMethodSquash or MergeMethodMerge
+	Method string
+
+	// CommitMessage is the message of the squash or merge commit, see
+	// RenderCommitMessage
+	CommitMessage string
 }
 
 // MergeResult contains the result of a merge operation.
@@ -60,7 +100,7 @@ type MergeResult struct {
 // 4. Checkout source branch
 // 5. Rebase onto target
 // 6. Run tests if configured
-// 7. Checkout target and merge (fast-forward)
+// 7. Checkout target and merge (fast-forward, squash or merge commit)
 // 8. Push target
 // 9. Delete source branch if configured
 //
@@ -68,6 +108,13 @@ type MergeResult struct {
 func (c *Client) MergeBranch(ctx context.Context, opts MergeOptions) (*MergeResult, error) {
 	result := &MergeResult{}
 
+	switch opts.Method {
+	case "", MergeMethodRebase, MergeMethodSquash, MergeMethodMerge:
+	default:
+		result.Error = fmt.Sprintf("unknown merge method %q", opts.Method)
+		return result, fmt.Errorf("unknown merge method %q", opts.Method)
+	}
+
 	// Step 1: Fetch latest
 	if err := c.Fetch(ctx); err != nil {
 		result.Error = fmt.Sprintf("fetch failed: %v", err)
@@ -100,12 +147,34 @@ func (c *Client) MergeBranch(ctx context.Context, opts MergeOptions) (*MergeResu
 		}
 	}
 
+	// With a signing key, the commits are verified as pushed, before the
+	// rebase re-signs them
+	if c.signing != nil {
+		if err := c.VerifyCommits(ctx, opts.TargetBranch, opts.SourceBranch); err != nil {
+			result.Error = fmt.Sprintf("signature verification failed: %v", err)
+			return result, err
+		}
+	}
+
 	// Step 5: Rebase onto target
 	if err := c.RebaseOnto(ctx, opts.TargetBranch); err != nil {
 		// Abort the rebase if it failed
 		_ = c.AbortRebase(ctx) //nolint:errcheck // best-effort abort on rebase failure
 		result.Error = fmt.Sprintf("rebase failed: %v", err)
-		return result, err
+		return result, fmt.Errorf("%w: %w", ErrRebaseConflict, err)
+	}
+
+	// Only land the commit that was validated
+	if opts.ExpectedHead != "" {
+		head, err := c.GetCommitSHA(ctx)
+		if err != nil {
+			result.Error = fmt.Sprintf("reading rebased head failed: %v", err)
+			return result, err
+		}
+		if head != opts.ExpectedHead {
+			result.Error = fmt.Sprintf("rebased head %s is not %s", head, opts.ExpectedHead)
+			return result, ErrHeadChanged
+		}
 	}
 
 	// Step 6: Run tests if configured
@@ -116,19 +185,25 @@ func (c *Client) MergeBranch(ctx context.Context, opts MergeOptions) (*MergeResu
 		}
 	}
 
-	// Step 7: Checkout target and merge (fast-forward)
+	// Step 7: Checkout target and merge (fast-forward, squash or merge commit)
 	if err := c.Checkout(ctx, opts.TargetBranch); err != nil {
 		result.Error = fmt.Sprintf("checkout target for merge failed: %v", err)
 		return result, err
 	}
 
-	if err := c.Merge(ctx, opts.SourceBranch); err != nil {
+	if err := c.land(ctx, opts); err != nil {
+		// Drop a half-made squash or merge commit
+		_ = c.ResetHard(ctx, opts.TargetBranch) //nolint:errcheck // best-effort reset on merge failure
 		result.Error = fmt.Sprintf("merge failed: %v", err)
 		return result, err
 	}
 
 	// Step 8: Push target
 	if err := c.Push(ctx); err != nil {
+		if errors.Is(err, ErrTargetMoved) {
+			// Drop the unpublished merge so a retry starts from the remote tip
+			_ = c.ResetHard(ctx, "origin/"+opts.TargetBranch) //nolint:errcheck // best-effort reset before retry
+		}
 		result.Error = fmt.Sprintf("push failed: %v", err)
 		return result, err
 	}
@@ -160,6 +235,94 @@ func (c *Client) MergeBranch(ctx context.Context, opts MergeOptions) (*MergeResu
 	return result, nil
 }
 
+// land merges the rebased source branch into the checked out target branch
+// with the method of opts. A squash commit lists the squashed commits below
+// the message.
+func (c *Client) land(ctx context.Context, opts MergeOptions) error {
+	message := opts.CommitMessage
+	if message == "" {
+		message = "Merge branch '" + opts.SourceBranch + "'"
+	}
+	switch opts.Method {
+	case MergeMethodSquash:
+		squashed, err := c.runGit(ctx, "log", "--reverse", "--format=* %s", opts.TargetBranch+".."+opts.SourceBranch)
+		if err != nil {
+			return err
+		}
+		if squashed != "" {
+			message += "\n\n" + squashed
+		}
+		return c.SquashMerge(ctx, opts.SourceBranch, message)
+	case MergeMethodMerge:
+		return c.MergeNoFF(ctx, opts.SourceBranch, message)
+	default:
+		return c.Merge(ctx, opts.SourceBranch)
+	}
+}
+
+// RefreshBranch rebases the source branch onto the latest target branch,
+// runs the test command if configured, and force-pushes the source branch so
+// it is retested against a recent target. The target branch is not modified.
+// With a signing key, the branch's commits are verified before the rebase
+// re-signs them, as in MergeBranch.
+func (c *Client) RefreshBranch(ctx context.Context, opts MergeOptions) error {
+	if err := c.Fetch(ctx); err != nil {
+		return fmt.Errorf("fetch failed: %w", err)
+	}
+
+	if err := c.Checkout(ctx, opts.SourceBranch); err != nil {
+		if _, err := c.runGit(ctx, "checkout", "-B", opts.SourceBranch, "origin/"+opts.SourceBranch); err != nil {
+			return fmt.Errorf("checkout source branch failed: %w", err)
+		}
+	} else if err := c.ResetHard(ctx, "origin/"+opts.SourceBranch); err != nil {
+		return fmt.Errorf("reset source branch failed: %w", err)
+	}
+
+	if c.signing != nil {
+		if err := c.VerifyCommits(ctx, "origin/"+opts.TargetBranch, opts.SourceBranch); err != nil {
+			return err
+		}
+	}
+
+	if err := c.RebaseOnto(ctx, "origin/"+opts.TargetBranch); err != nil {
+		_ = c.AbortRebase(ctx) //nolint:errcheck // best-effort abort on rebase failure
+		return fmt.Errorf("%w: %w", ErrRebaseConflict, err)
+	}
+
+	if opts.TestCommand != "" {
+		if err := c.runTests(ctx, opts.TestCommand); err != nil {
+			return fmt.Errorf("tests failed: %w", err)
+		}
+	}
+
+	if _, err := c.runGit(ctx, "push", "--force-with-lease", "origin", opts.SourceBranch); err != nil {
+		return fmt.Errorf("push failed: %w", err)
+	}
+	return nil
+}
+
+// PublishBranch refreshes the source branch like RefreshBranch and returns
+// the commit it pushed, so external checks can be awaited on that commit.
+func (c *Client) PublishBranch(ctx context.Context, opts MergeOptions) (string, error) {
+	if err := c.RefreshBranch(ctx, opts); err != nil {
+		return "", err
+	}
+	sha, err := c.GetCommitSHA(ctx)
+	if err != nil {
+		return "", fmt.Errorf("reading published head failed: %w", err)
+	}
+	return sha, nil
+}
+
+// isPushRejected reports whether a push failed because the remote branch
+// is ahead of the local one (another writer pushed first).
+func isPushRejected(err error) bool {
+	msg := err.Error()
+	return strings.Contains(msg, "non-fast-forward") ||
+		strings.Contains(msg, "fetch first") ||
+		strings.Contains(msg, "[rejected]")
+}
+
 // allowedTestCommands defines patterns for safe test commands.
 // These patterns are intentionally restrictive to prevent command injection.
 var allowedTestCommands = []string{
@@ -21,6 +21,7 @@ import (
 	"os"
 	"os/exec"
 	"path/filepath"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -186,7 +187,7 @@ func TestMergeBranch_ConflictHandling(t *testing.T) {
 		})
 
 		// Should fail with rebase error
-		require.Error(t, err)
+		require.ErrorIs(t, err, ErrRebaseConflict)
 		assert.False(t, result.Success)
 		assert.Contains(t, result.Error, "rebase failed")
 
@@ -304,6 +305,279 @@ func TestMergeBranch_WithBranchDeletion(t *testing.T) {
 	})
 }
 
+// TestMergeBranch_Methods tests that squash and merge land the rebased
+// branch as one commit and with a merge commit, titled with the message.
+func TestMergeBranch_Methods(t *testing.T) {
+	skipIfNoGit(t)
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	originDir := filepath.Join(tempDir, "origin.git")
+	require.NoError(t, runGitCmd(t, "", "init", "--bare", originDir))
+
+	repoDir := filepath.Join(tempDir, "repo")
+	require.NoError(t, runGitCmd(t, "", "clone", originDir, repoDir))
+	require.NoError(t, runGitCmd(t, repoDir, "config", "user.email", "polecat@test.com"))
+	require.NoError(t, runGitCmd(t, repoDir, "config", "user.name", "Polecat Worker"))
+	require.NoError(t, os.WriteFile(filepath.Join(repoDir, "README.md"), []byte("# Test\n"), 0o600))
+	require.NoError(t, runGitCmd(t, repoDir, "add", "README.md"))
+	require.NoError(t, runGitCmd(t, repoDir, "commit", "-m", "Initial commit"))
+	require.NoError(t, runGitCmd(t, repoDir, "branch", "-M", "main"))
+	require.NoError(t, runGitCmd(t, repoDir, "push", "-u", "origin", "main"))
+
+	for _, branch := range []string{"feature/squash", "feature/merge"} {
+		require.NoError(t, runGitCmd(t, repoDir, "checkout", "-b", branch, "main"))
+		for _, file := range []string{"a.txt", "b.txt"} {
+			path := filepath.Join(strings.TrimPrefix(branch, "feature/"), file)
+			require.NoError(t, os.MkdirAll(filepath.Join(repoDir, filepath.Dir(path)), 0o750))
+			require.NoError(t, os.WriteFile(filepath.Join(repoDir, path), []byte(file+"\n"), 0o600))
+			require.NoError(t, runGitCmd(t, repoDir, "add", path))
+			require.NoError(t, runGitCmd(t, repoDir, "commit", "-m", "add "+path))
+		}
+		require.NoError(t, runGitCmd(t, repoDir, "push", "-u", "origin", branch))
+	}
+	require.NoError(t, runGitCmd(t, repoDir, "checkout", "main"))
+
+	client := NewClient(repoDir, originDir)
+
+	t.Run("squash", func(t *testing.T) {
+		result, err := client.MergeBranch(ctx, MergeOptions{
+			SourceBranch:  "feature/squash",
+			TargetBranch:  "main",
+			Method:        MergeMethodSquash,
+			CommitMessage: "gt-1: Squash the work",
+		})
+		require.NoError(t, err)
+		require.True(t, result.Success)
+
+		message, err := runGitCmdOutput(t, repoDir, "log", "-1", "--format=%B", "origin/main")
+		require.NoError(t, err)
+		assert.Equal(t, "gt-1: Squash the work\n\n* add squash/a.txt\n* add squash/b.txt", strings.TrimSpace(message))
+		author, err := runGitCmdOutput(t, repoDir, "log", "-1", "--format=%an|%cn", "origin/main")
+		require.NoError(t, err)
+		assert.Equal(t, "Polecat Worker|"+operatorCommitterName, strings.TrimSpace(author))
+		parents, err := runGitCmdOutput(t, repoDir, "rev-list", "--count", "origin/main")
+		require.NoError(t, err)
+		assert.Equal(t, "2", strings.TrimSpace(parents), "the branch should land as one commit")
+	})
+
+	t.Run("merge", func(t *testing.T) {
+		result, err := client.MergeBranch(ctx, MergeOptions{
+			SourceBranch:  "feature/merge",
+			TargetBranch:  "main",
+			Method:        MergeMethodMerge,
+			CommitMessage: "gt-2: Merge the work",
+		})
+		require.NoError(t, err)
+		require.True(t, result.Success)
+
+		parents, err := runGitCmdOutput(t, repoDir, "log", "-1", "--format=%P|%s", "origin/main")
+		require.NoError(t, err)
+		hashes, subject, _ := strings.Cut(strings.TrimSpace(parents), "|")
+		assert.Len(t, strings.Fields(hashes), 2, "expected a merge commit")
+		assert.Equal(t, "gt-2: Merge the work", subject)
+		assert.Equal(t, result.MergedCommit, strings.TrimSpace(mustRevParse(t, repoDir, "origin/main")))
+	})
+
+	t.Run("unknown method", func(t *testing.T) {
+		_, err := client.MergeBranch(ctx, MergeOptions{SourceBranch: "feature/merge", TargetBranch: "main", Method: "octopus"})
+		assert.ErrorContains(t, err, "unknown merge method")
+	})
+}
+
+func mustRevParse(t *testing.T, dir, ref string) string {
+	t.Helper()
+	out, err := runGitCmdOutput(t, dir, "rev-parse", ref)
+	require.NoError(t, err)
+	return out
+}
+
+// TestMergeBranch_TargetMoved tests that a push rejected because another writer
+// advanced the target branch is reported as ErrTargetMoved and can be retried.
+func TestMergeBranch_TargetMoved(t *testing.T) {
+	skipIfNoGit(t)
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	originDir := filepath.Join(tempDir, "origin.git")
+	require.NoError(t, runGitCmd(t, "", "init", "--bare", originDir))
+
+	// A second writer that pushes to main while the refinery is testing
+	otherDir := filepath.Join(tempDir, "other")
+	require.NoError(t, runGitCmd(t, "", "clone", originDir, otherDir))
+	require.NoError(t, runGitCmd(t, otherDir, "config", "user.email", "other@test.com"))
+	require.NoError(t, runGitCmd(t, otherDir, "config", "user.name", "Other Writer"))
+
+	// The test target pushes a concurrent commit to main once
+	marker := filepath.Join(tempDir, "moved")
+	makefile := "test:\n\t@test -f " + marker + " || (touch " + marker +
+		" && cd " + otherDir + " && git pull -q origin main && git commit -q --allow-empty -m concurrent" +
+		" && git push -q origin main)\n"
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "Makefile"), []byte(makefile), 0o600))
+	require.NoError(t, runGitCmd(t, otherDir, "add", "Makefile"))
+	require.NoError(t, runGitCmd(t, otherDir, "commit", "-m", "Initial commit"))
+	require.NoError(t, runGitCmd(t, otherDir, "branch", "-M", "main"))
+	require.NoError(t, runGitCmd(t, otherDir, "push", "-u", "origin", "main"))
+
+	require.NoError(t, runGitCmd(t, otherDir, "checkout", "-b", "feature/moved"))
+	require.NoError(t, os.WriteFile(filepath.Join(otherDir, "feature.txt"), []byte("feature\n"), 0o600))
+	require.NoError(t, runGitCmd(t, otherDir, "add", "feature.txt"))
+	require.NoError(t, runGitCmd(t, otherDir, "commit", "-m", "feat: add feature"))
+	require.NoError(t, runGitCmd(t, otherDir, "push", "-u", "origin", "feature/moved"))
+	require.NoError(t, runGitCmd(t, otherDir, "checkout", "main"))
+
+	refineryDir := filepath.Join(tempDir, "refinery")
+	require.NoError(t, runGitCmd(t, "", "clone", originDir, refineryDir))
+	require.NoError(t, runGitCmd(t, refineryDir, "config", "user.email", "test@test.com"))
+	require.NoError(t, runGitCmd(t, refineryDir, "config", "user.name", "Test User"))
+
+	client := NewClient(refineryDir, originDir)
+	opts := MergeOptions{
+		SourceBranch: "feature/moved",
+		TargetBranch: "main",
+		TestCommand:  "make test",
+	}
+
+	result, err := client.MergeBranch(ctx, opts)
+	require.Error(t, err)
+	assert.ErrorIs(t, err, ErrTargetMoved)
+	assert.False(t, result.Success)
+
+	// Retrying rebases onto the concurrent commit and succeeds
+	result, err = client.MergeBranch(ctx, opts)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	log, err := runGitCmdOutput(t, refineryDir, "log", "--format=%s", "origin/main")
+	require.NoError(t, err)
+	assert.Contains(t, log, "concurrent")
+	assert.Contains(t, log, "feat: add feature")
+}
+
+// TestRefreshBranch tests that a branch behind the target is detected,
+// rebased onto the new tip and force-pushed without touching the target.
+func TestRefreshBranch(t *testing.T) {
+	skipIfNoGit(t)
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	originDir := filepath.Join(tempDir, "origin.git")
+	require.NoError(t, runGitCmd(t, "", "init", "--bare", originDir))
+
+	seedDir := filepath.Join(tempDir, "seed")
+	require.NoError(t, runGitCmd(t, "", "clone", originDir, seedDir))
+	require.NoError(t, runGitCmd(t, seedDir, "config", "user.email", "seed@test.com"))
+	require.NoError(t, runGitCmd(t, seedDir, "config", "user.name", "Seed"))
+	require.NoError(t, runGitCmd(t, seedDir, "commit", "--allow-empty", "-m", "Initial commit"))
+	require.NoError(t, runGitCmd(t, seedDir, "branch", "-M", "main"))
+	require.NoError(t, runGitCmd(t, seedDir, "push", "-u", "origin", "main"))
+
+	require.NoError(t, runGitCmd(t, seedDir, "checkout", "-b", "feature/stale"))
+	require.NoError(t, os.WriteFile(filepath.Join(seedDir, "feature.txt"), []byte("feature\n"), 0o600))
+	require.NoError(t, runGitCmd(t, seedDir, "add", "feature.txt"))
+	require.NoError(t, runGitCmd(t, seedDir, "commit", "-m", "feat: stale feature"))
+	require.NoError(t, runGitCmd(t, seedDir, "push", "-u", "origin", "feature/stale"))
+
+	require.NoError(t, runGitCmd(t, seedDir, "checkout", "main"))
+	for _, msg := range []string{"main 1", "main 2", "main 3"} {
+		require.NoError(t, runGitCmd(t, seedDir, "commit", "--allow-empty", "-m", msg))
+	}
+	require.NoError(t, runGitCmd(t, seedDir, "push", "origin", "main"))
+	mainBefore, err := runGitCmdOutput(t, seedDir, "rev-parse", "main")
+	require.NoError(t, err)
+
+	refineryDir := filepath.Join(tempDir, "refinery")
+	require.NoError(t, runGitCmd(t, "", "clone", originDir, refineryDir))
+	require.NoError(t, runGitCmd(t, refineryDir, "config", "user.email", "test@test.com"))
+	require.NoError(t, runGitCmd(t, refineryDir, "config", "user.name", "Test User"))
+
+	client := NewClient(refineryDir, originDir)
+	behind, err := client.CommitsBehind(ctx, "main", "feature/stale")
+	require.NoError(t, err)
+	assert.Equal(t, 3, behind)
+
+	require.NoError(t, client.RefreshBranch(ctx, MergeOptions{
+		SourceBranch: "feature/stale",
+		TargetBranch: "main",
+	}))
+
+	require.NoError(t, client.Fetch(ctx))
+	behind, err = client.CommitsBehind(ctx, "main", "feature/stale")
+	require.NoError(t, err)
+	assert.Equal(t, 0, behind)
+
+	mainAfter, err := runGitCmdOutput(t, originDir, "rev-parse", "main")
+	require.NoError(t, err)
+	assert.Equal(t, mainBefore, mainAfter, "target branch must not move")
+}
+
+// TestPublishBranch_ExpectedHead tests that a published branch is merged
+// only while it is still the commit that was published.
+func TestPublishBranch_ExpectedHead(t *testing.T) {
+	skipIfNoGit(t)
+
+	ctx := context.Background()
+	tempDir := t.TempDir()
+
+	originDir := filepath.Join(tempDir, "origin.git")
+	require.NoError(t, runGitCmd(t, "", "init", "--bare", originDir))
+
+	seedDir := filepath.Join(tempDir, "seed")
+	require.NoError(t, runGitCmd(t, "", "clone", originDir, seedDir))
+	require.NoError(t, runGitCmd(t, seedDir, "config", "user.email", "seed@test.com"))
+	require.NoError(t, runGitCmd(t, seedDir, "config", "user.name", "Seed"))
+	require.NoError(t, runGitCmd(t, seedDir, "commit", "--allow-empty", "-m", "Initial commit"))
+	require.NoError(t, runGitCmd(t, seedDir, "branch", "-M", "main"))
+	require.NoError(t, runGitCmd(t, seedDir, "push", "-u", "origin", "main"))
+
+	require.NoError(t, runGitCmd(t, seedDir, "checkout", "-b", "feature/checked"))
+	require.NoError(t, os.WriteFile(filepath.Join(seedDir, "feature.txt"), []byte("feature\n"), 0o600))
+	require.NoError(t, runGitCmd(t, seedDir, "add", "feature.txt"))
+	require.NoError(t, runGitCmd(t, seedDir, "commit", "-m", "feat: checked feature"))
+	require.NoError(t, runGitCmd(t, seedDir, "push", "-u", "origin", "feature/checked"))
+	require.NoError(t, runGitCmd(t, seedDir, "checkout", "main"))
+	require.NoError(t, runGitCmd(t, seedDir, "commit", "--allow-empty", "-m", "main 1"))
+	require.NoError(t, runGitCmd(t, seedDir, "push", "origin", "main"))
+
+	refineryDir := filepath.Join(tempDir, "refinery")
+	require.NoError(t, runGitCmd(t, "", "clone", originDir, refineryDir))
+	require.NoError(t, runGitCmd(t, refineryDir, "config", "user.email", "test@test.com"))
+	require.NoError(t, runGitCmd(t, refineryDir, "config", "user.name", "Test User"))
+
+	client := NewClient(refineryDir, originDir)
+	opts := MergeOptions{SourceBranch: "feature/checked", TargetBranch: "main"}
+
+	published, err := client.PublishBranch(ctx, opts)
+	require.NoError(t, err)
+	remote, err := runGitCmdOutput(t, originDir, "rev-parse", "feature/checked")
+	require.NoError(t, err)
+	assert.Equal(t, published, strings.TrimSpace(remote))
+
+	// The target moves after the checks started: the merge must not land
+	require.NoError(t, runGitCmd(t, seedDir, "commit", "--allow-empty", "-m", "main 2"))
+	require.NoError(t, runGitCmd(t, seedDir, "push", "origin", "main"))
+
+	opts.ExpectedHead = published
+	_, err = client.MergeBranch(ctx, opts)
+	assert.ErrorIs(t, err, ErrHeadChanged)
+
+	// Republished, the new head lands as is
+	opts.ExpectedHead = ""
+	published, err = client.PublishBranch(ctx, opts)
+	require.NoError(t, err)
+	opts.ExpectedHead = published
+	result, err := client.MergeBranch(ctx, opts)
+	require.NoError(t, err)
+	assert.True(t, result.Success)
+
+	mainAfter, err := runGitCmdOutput(t, originDir, "rev-parse", "main")
+	require.NoError(t, err)
+	assert.Equal(t, published, strings.TrimSpace(mainAfter))
+}
+
 // runGitCmd is a test helper to run git commands.
 func runGitCmd(t *testing.T, dir string, args ...string) error {
 	t.Helper()
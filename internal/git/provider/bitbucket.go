@@ -0,0 +1,214 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// DefaultBitbucketAPIURL is the Bitbucket Cloud REST API endpoint.
+const DefaultBitbucketAPIURL = "https://api.bitbucket.org/2.0"
+
+// bitbucket opens pull requests through the Bitbucket Cloud REST API (2.0).
+type bitbucket struct {
+	api       *apiClient
+	workspace string
+	repo      string
+}
+
+func newBitbucket(cfg Config) (*bitbucket, error) {
+	_, path, err := ParseRepoPath(cfg.RepoURL)
+	if err != nil {
+		return nil, err
+	}
+	workspace, repo, ok := strings.Cut(path, "/")
+	if !ok || workspace == "" || repo == "" || strings.Contains(repo, "/") {
+		return nil, fmt.Errorf("git URL %q does not name a workspace/repository", cfg.RepoURL)
+	}
+
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = DefaultBitbucketAPIURL
+	}
+	token := cfg.Token
+	return &bitbucket{
+		api: newAPIClient(apiURL, cfg.HTTPClient, func(req *http.Request) {
+			if user, password, ok := strings.Cut(token, ":"); ok {
+				req.SetBasicAuth(user, password)
+			} else if token != "" {
+				req.Header.Set("Authorization", "Bearer "+token)
+			}
+		}),
+		workspace: workspace,
+		repo:      repo,
+	}, nil
+}
+
+// bitbucketPullRequest is the REST representation of a pull request.
+type bitbucketPullRequest struct {
+	ID    int    `json:"id"`
+	State string `json:"state"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+	MergeCommit *struct {
+		Hash string `json:"hash"`
+	} `json:"merge_commit"`
+	Source struct {
+		Commit struct {
+			Hash string `json:"hash"`
+		} `json:"commit"`
+	} `json:"source"`
+}
+
+func (p bitbucketPullRequest) toChangeRequest() *ChangeRequest {
+	cr := &ChangeRequest{
+		Number:  p.ID,
+		Ref:     fmt.Sprintf("PR #%d", p.ID),
+		URL:     p.Links.HTML.Href,
+		State:   StateOpen,
+		HeadSHA: p.Source.Commit.Hash,
+	}
+	switch p.State {
+	case "MERGED":
+		cr.State = StateMerged
+	case "DECLINED", "SUPERSEDED":
+		cr.State = StateClosed
+	}
+	if p.MergeCommit != nil {
+		cr.MergeCommitSHA = p.MergeCommit.Hash
+	}
+	return cr
+}
+
+func (b *bitbucket) FindChangeRequest(ctx context.Context, source, target string) (*ChangeRequest, error) {
+	query := url.Values{
+		"q":     {fmt.Sprintf("source.branch.name=%q AND destination.branch.name=%q", source, target)},
+		"state": {"OPEN", "MERGED", "DECLINED", "SUPERSEDED"},
+		"sort":  {"-created_on"},
+	}
+	var page struct {
+		Values []bitbucketPullRequest `json:"values"`
+	}
+	if err := b.api.do(ctx, http.MethodGet, b.path("pullrequests")+"?"+query.Encode(),
+		nil, http.StatusOK, &page); err != nil {
+		return nil, fmt.Errorf("listing pull requests for %s failed: %w", source, err)
+	}
+	if len(page.Values) == 0 {
+		return nil, nil
+	}
+	return page.Values[0].toChangeRequest(), nil
+}
+
+func (b *bitbucket) CreateChangeRequest(ctx context.Context, source, target, title, body string) (*ChangeRequest, error) {
+	var pr bitbucketPullRequest
+	err := b.api.do(ctx, http.MethodPost, b.path("pullrequests"), map[string]any{
+		"title":               title,
+		"description":         body,
+		"source":              map[string]any{"branch": map[string]string{"name": source}},
+		"destination":         map[string]any{"branch": map[string]string{"name": target}},
+		"close_source_branch": true,
+	}, http.StatusCreated, &pr)
+	if err != nil {
+		return nil, fmt.Errorf("creating pull request for %s failed: %w", source, err)
+	}
+	return pr.toChangeRequest(), nil
+}
+
+// EnableAutoMerge merges the pull request once its checks have passed.
+// Bitbucket Cloud has no auto-merge API, so this does nothing while checks
+// are pending or failing and must be called again on later polls.
+func (b *bitbucket) EnableAutoMerge(ctx context.Context, cr *ChangeRequest, method string) error {
+	checks, err := b.GetChecksStatus(ctx, cr)
+	if err != nil {
+		return err
+	}
+	if checks.State != git.ChecksSuccess {
+		return nil
+	}
+
+	strategy := "merge_commit"
+	switch method {
+	case MergeMethodSquash:
+		strategy = "squash"
+	case MergeMethodRebase:
+		strategy = "fast_forward"
+	}
+	err = b.api.do(ctx, http.MethodPost, b.path("pullrequests", strconv.Itoa(cr.Number), "merge"), map[string]any{
+		"merge_strategy":      strategy,
+		"close_source_branch": true,
+	}, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("merging %s failed: %w", cr.Ref, err)
+	}
+	return nil
+}
+
+// GetChecksStatus combines the build statuses reported on the head commit.
+func (b *bitbucket) GetChecksStatus(ctx context.Context, cr *ChangeRequest) (*git.ChecksStatus, error) {
+	results, err := b.GetCommitChecks(ctx, cr.HeadSHA)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", cr.Ref, err)
+	}
+	return git.SummarizeChecks(results), nil
+}
+
+// GetCommitChecks lists the build statuses of sha, named by their keys.
+func (b *bitbucket) GetCommitChecks(ctx context.Context, sha string) ([]git.CheckResult, error) {
+	var page struct {
+		Values []struct {
+			Key   string `json:"key"`
+			State string `json:"state"`
+		} `json:"values"`
+	}
+	if err := b.api.do(ctx, http.MethodGet, b.path("commit", sha, "statuses")+"?pagelen=100&sort=-updated_on",
+		nil, http.StatusOK, &page); err != nil {
+		return nil, fmt.Errorf("listing build statuses for %s failed: %w", sha, err)
+	}
+
+	results := make([]git.CheckResult, 0, len(page.Values))
+	for _, s := range page.Values {
+		result := git.CheckResult{Name: s.Key, State: git.ChecksFailure}
+		switch s.State {
+		case "INPROGRESS":
+			result.State = git.ChecksPending
+		case "SUCCESSFUL":
+			result.State = git.ChecksSuccess
+		}
+		// FAILED, STOPPED
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// path builds /repositories/<workspace>/<repo>/<elems...>.
+func (b *bitbucket) path(elems ...string) string {
+	path := "/repositories/" + url.PathEscape(b.workspace) + "/" + url.PathEscape(b.repo)
+	for _, elem := range elems {
+		path += "/" + url.PathEscape(elem)
+	}
+	return path
+}
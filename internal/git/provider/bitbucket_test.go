@@ -0,0 +1,140 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/org/gastown-operator/internal/git"
+)
+
+func newTestBitbucket(t *testing.T, token string, handler http.HandlerFunc) Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	p, err := New(Config{
+		Type:    TypeBitbucket,
+		APIURL:  server.URL,
+		Token:   token,
+		RepoURL: "git@bitbucket.org:acme/widgets.git",
+	})
+	require.NoError(t, err)
+	return p
+}
+
+func TestBitbucketFindChangeRequest(t *testing.T) {
+	p := newTestBitbucket(t, "bbtoken", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/repositories/acme/widgets/pullrequests", r.URL.Path)
+		assert.Equal(t, "Bearer bbtoken", r.Header.Get("Authorization"))
+		assert.Equal(t, `source.branch.name="polecat/gt-1" AND destination.branch.name="main"`, r.URL.Query().Get("q"))
+		assert.ElementsMatch(t, []string{"OPEN", "MERGED", "DECLINED", "SUPERSEDED"}, r.URL.Query()["state"])
+		_, _ = w.Write([]byte(`{"values":[{"id":5,"state":"DECLINED",
+			"links":{"html":{"href":"https://bitbucket.org/acme/widgets/pull-requests/5"}},
+			"source":{"commit":{"hash":"h3ad"}}}]}`))
+	})
+
+	cr, err := p.FindChangeRequest(context.Background(), "polecat/gt-1", "main")
+	require.NoError(t, err)
+	assert.Equal(t, &ChangeRequest{
+		Number:  5,
+		Ref:     "PR #5",
+		URL:     "https://bitbucket.org/acme/widgets/pull-requests/5",
+		State:   StateClosed,
+		HeadSHA: "h3ad",
+	}, cr)
+}
+
+func TestBitbucketAppPasswordAuth(t *testing.T) {
+	p := newTestBitbucket(t, "deploy-bot:app-pass", func(w http.ResponseWriter, r *http.Request) {
+		user, password, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "deploy-bot", user)
+		assert.Equal(t, "app-pass", password)
+		_, _ = w.Write([]byte(`{"values":[]}`))
+	})
+
+	cr, err := p.FindChangeRequest(context.Background(), "polecat/gt-1", "main")
+	require.NoError(t, err)
+	assert.Nil(t, cr)
+}
+
+func TestBitbucketCreateChangeRequest(t *testing.T) {
+	var got map[string]any
+	p := newTestBitbucket(t, "bbtoken", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"id":6,"state":"OPEN","source":{"commit":{"hash":"h3ad"}}}`))
+	})
+
+	cr, err := p.CreateChangeRequest(context.Background(), "polecat/gt-1", "main", "gt-1: Fix", "body")
+	require.NoError(t, err)
+	assert.Equal(t, StateOpen, cr.State)
+	assert.Equal(t, map[string]any{"branch": map[string]any{"name": "polecat/gt-1"}}, got["source"])
+	assert.Equal(t, map[string]any{"branch": map[string]any{"name": "main"}}, got["destination"])
+}
+
+func TestBitbucketAutoMergeWaitsForChecks(t *testing.T) {
+	tests := []struct {
+		name       string
+		statuses   string
+		wantChecks string
+		wantMerge  bool
+	}{
+		{name: "pending", statuses: `[{"state":"SUCCESSFUL"},{"state":"INPROGRESS"}]`, wantChecks: git.ChecksPending},
+		{name: "failed", statuses: `[{"state":"FAILED"}]`, wantChecks: git.ChecksFailure},
+		{name: "passed", statuses: `[{"state":"SUCCESSFUL"}]`, wantChecks: git.ChecksSuccess, wantMerge: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var merge map[string]any
+			p := newTestBitbucket(t, "bbtoken", func(w http.ResponseWriter, r *http.Request) {
+				switch r.URL.Path {
+				case "/repositories/acme/widgets/commit/h3ad/statuses":
+					_, _ = w.Write([]byte(`{"values":` + tt.statuses + `}`))
+				case "/repositories/acme/widgets/pullrequests/6/merge":
+					assert.NoError(t, json.NewDecoder(r.Body).Decode(&merge))
+					_, _ = w.Write([]byte(`{"id":6,"state":"MERGED"}`))
+				default:
+					t.Errorf("unexpected request %s", r.URL.Path)
+					w.WriteHeader(http.StatusNotFound)
+				}
+			})
+			cr := &ChangeRequest{Number: 6, Ref: "PR #6", HeadSHA: "h3ad"}
+
+			checks, err := p.GetChecksStatus(context.Background(), cr)
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantChecks, checks.State)
+
+			require.NoError(t, p.EnableAutoMerge(context.Background(), cr, MergeMethodRebase))
+			if tt.wantMerge {
+				assert.Equal(t, "fast_forward", merge["merge_strategy"])
+			} else {
+				assert.Nil(t, merge)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// gitHub opens pull requests through git.GitHubClient.
+type gitHub struct {
+	client *git.GitHubClient
+	owner  string
+	repo   string
+}
+
+func newGitHub(cfg Config) (*gitHub, error) {
+	owner, repo, err := git.ParseGitHubRepo(cfg.RepoURL)
+	if err != nil {
+		return nil, err
+	}
+	client := git.NewGitHubClient(cfg.APIURL, cfg.Token)
+	if cfg.HTTPClient != nil {
+		client.HTTPClient = cfg.HTTPClient
+	}
+	return &gitHub{client: client, owner: owner, repo: repo}, nil
+}
+
+func (g *gitHub) FindChangeRequest(ctx context.Context, source, target string) (*ChangeRequest, error) {
+	pr, err := g.client.FindPullRequest(ctx, g.owner, g.repo, source, target)
+	if err != nil || pr == nil {
+		return nil, err
+	}
+	return fromGitHub(pr), nil
+}
+
+func (g *gitHub) CreateChangeRequest(ctx context.Context, source, target, title, body string) (*ChangeRequest, error) {
+	pr, err := g.client.CreatePullRequest(ctx, g.owner, g.repo, source, target, title, body)
+	if err != nil {
+		return nil, err
+	}
+	return fromGitHub(pr), nil
+}
+
+func (g *gitHub) EnableAutoMerge(ctx context.Context, cr *ChangeRequest, method string) error {
+	return g.client.EnableAutoMerge(ctx, cr.nodeID, method)
+}
+
+func (g *gitHub) GetChecksStatus(ctx context.Context, cr *ChangeRequest) (*git.ChecksStatus, error) {
+	return g.client.GetChecksStatus(ctx, g.owner, g.repo, cr.HeadSHA)
+}
+
+func (g *gitHub) GetCommitChecks(ctx context.Context, sha string) ([]git.CheckResult, error) {
+	return g.client.ListChecks(ctx, g.owner, g.repo, sha)
+}
+
+func (g *gitHub) RequiredChecks(ctx context.Context, branch string) ([]string, error) {
+	return g.client.RequiredStatusChecks(ctx, g.owner, g.repo, branch)
+}
+
+// fromGitHub converts a GitHub pull request to a ChangeRequest.
+func fromGitHub(pr *git.PullRequest) *ChangeRequest {
+	state := StateOpen
+	switch {
+	case pr.Merged:
+		state = StateMerged
+	case pr.State == "closed":
+		state = StateClosed
+	}
+	return &ChangeRequest{
+		Number:         pr.Number,
+		Ref:            fmt.Sprintf("PR #%d", pr.Number),
+		URL:            pr.URL,
+		State:          state,
+		MergeCommitSHA: pr.MergeCommitSHA,
+		HeadSHA:        pr.HeadSHA,
+		AutoMerge:      pr.AutoMerge,
+		nodeID:         pr.NodeID,
+	}
+}
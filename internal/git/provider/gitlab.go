@@ -0,0 +1,199 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// gitLab opens merge requests through the GitLab REST API (v4).
+// Works with gitlab.com and self-hosted instances.
+type gitLab struct {
+	api *apiClient
+
+	// project is the URL-encoded project path (e.g., "group%2Fsub%2Frepo")
+	project string
+}
+
+func newGitLab(cfg Config) (*gitLab, error) {
+	host, path, err := ParseRepoPath(cfg.RepoURL)
+	if err != nil {
+		return nil, err
+	}
+	apiURL := cfg.APIURL
+	if apiURL == "" {
+		apiURL = "https://" + host + "/api/v4"
+	}
+	token := cfg.Token
+	return &gitLab{
+		api: newAPIClient(apiURL, cfg.HTTPClient, func(req *http.Request) {
+			if token != "" {
+				req.Header.Set("PRIVATE-TOKEN", token)
+			}
+		}),
+		project: url.PathEscape(path),
+	}, nil
+}
+
+// gitLabMergeRequest is the REST representation of a merge request.
+type gitLabMergeRequest struct {
+	IID             int    `json:"iid"`
+	WebURL          string `json:"web_url"`
+	State           string `json:"state"`
+	SHA             string `json:"sha"`
+	MergeCommitSHA  string `json:"merge_commit_sha"`
+	SquashCommitSHA string `json:"squash_commit_sha"`
+	AutoMerge       bool   `json:"merge_when_pipeline_succeeds"`
+	HeadPipeline    *struct {
+		Status string `json:"status"`
+	} `json:"head_pipeline"`
+}
+
+func (m gitLabMergeRequest) toChangeRequest() *ChangeRequest {
+	state := StateOpen
+	switch m.State {
+	case "merged":
+		state = StateMerged
+	case "closed":
+		state = StateClosed
+	}
+	mergeCommit := m.MergeCommitSHA
+	if mergeCommit == "" {
+		mergeCommit = m.SquashCommitSHA
+	}
+	return &ChangeRequest{
+		Number:         m.IID,
+		Ref:            fmt.Sprintf("MR !%d", m.IID),
+		URL:            m.WebURL,
+		State:          state,
+		MergeCommitSHA: mergeCommit,
+		HeadSHA:        m.SHA,
+		AutoMerge:      m.AutoMerge,
+	}
+}
+
+func (g *gitLab) FindChangeRequest(ctx context.Context, source, target string) (*ChangeRequest, error) {
+	query := url.Values{
+		"source_branch": {source},
+		"target_branch": {target},
+		"order_by":      {"created_at"},
+		"sort":          {"desc"},
+	}
+	var mrs []gitLabMergeRequest
+	if err := g.api.do(ctx, http.MethodGet, g.path("merge_requests")+"?"+query.Encode(),
+		nil, http.StatusOK, &mrs); err != nil {
+		return nil, fmt.Errorf("listing merge requests for %s failed: %w", source, err)
+	}
+	if len(mrs) == 0 {
+		return nil, nil
+	}
+	return mrs[0].toChangeRequest(), nil
+}
+
+func (g *gitLab) CreateChangeRequest(ctx context.Context, source, target, title, body string) (*ChangeRequest, error) {
+	var mr gitLabMergeRequest
+	err := g.api.do(ctx, http.MethodPost, g.path("merge_requests"), map[string]any{
+		"source_branch":        source,
+		"target_branch":        target,
+		"title":                title,
+		"description":          body,
+		"remove_source_branch": true,
+	}, http.StatusCreated, &mr)
+	if err != nil {
+		return nil, fmt.Errorf("creating merge request for %s failed: %w", source, err)
+	}
+	return mr.toChangeRequest(), nil
+}
+
+// EnableAutoMerge sets "merge when pipeline succeeds". GitLab has no rebase
+// merge method per request; rebase merges like merge (the project's merge
+// method setting applies).
+func (g *gitLab) EnableAutoMerge(ctx context.Context, cr *ChangeRequest, method string) error {
+	err := g.api.do(ctx, http.MethodPut, g.path("merge_requests", strconv.Itoa(cr.Number), "merge"), map[string]any{
+		"merge_when_pipeline_succeeds": true,
+		"squash":                       method == MergeMethodSquash,
+		"sha":                          cr.HeadSHA,
+	}, http.StatusOK, nil)
+	if err != nil {
+		return fmt.Errorf("enabling auto-merge on %s failed: %w", cr.Ref, err)
+	}
+	return nil
+}
+
+// GetChecksStatus reports the head pipeline of the merge request.
+// A merge request without a pipeline has no checks.
+func (g *gitLab) GetChecksStatus(ctx context.Context, cr *ChangeRequest) (*git.ChecksStatus, error) {
+	var mr gitLabMergeRequest
+	if err := g.api.do(ctx, http.MethodGet, g.path("merge_requests", strconv.Itoa(cr.Number)),
+		nil, http.StatusOK, &mr); err != nil {
+		return nil, fmt.Errorf("getting pipeline for %s failed: %w", cr.Ref, err)
+	}
+	if mr.HeadPipeline == nil {
+		return &git.ChecksStatus{State: git.ChecksSuccess}, nil
+	}
+
+	switch mr.HeadPipeline.Status {
+	case "success", "skipped":
+		return &git.ChecksStatus{State: git.ChecksSuccess, Total: 1, Completed: 1}, nil
+	case "failed", "canceled":
+		return &git.ChecksStatus{State: git.ChecksFailure, Total: 1, Completed: 1, Failed: 1}, nil
+	default:
+		// created, pending, running, manual, scheduled, ...
+		return &git.ChecksStatus{State: git.ChecksPending, Total: 1}, nil
+	}
+}
+
+// GetCommitChecks lists the latest commit status of each job on sha.
+func (g *gitLab) GetCommitChecks(ctx context.Context, sha string) ([]git.CheckResult, error) {
+	var statuses []struct {
+		Name   string `json:"name"`
+		Status string `json:"status"`
+	}
+	if err := g.api.do(ctx, http.MethodGet, g.path("repository", "commits", sha, "statuses")+"?per_page=100",
+		nil, http.StatusOK, &statuses); err != nil {
+		return nil, fmt.Errorf("listing commit statuses for %s failed: %w", sha, err)
+	}
+
+	results := make([]git.CheckResult, 0, len(statuses))
+	for _, s := range statuses {
+		result := git.CheckResult{Name: s.Name, State: git.ChecksPending}
+		switch s.Status {
+		case "success", "skipped":
+			result.State = git.ChecksSuccess
+		case "failed", "canceled":
+			result.State = git.ChecksFailure
+		}
+		// created, pending, running, manual, scheduled, ...
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// path builds /projects/<project>/<elems...>.
+func (g *gitLab) path(elems ...string) string {
+	path := "/projects/" + g.project
+	for _, elem := range elems {
+		path += "/" + url.PathEscape(elem)
+	}
+	return path
+}
@@ -0,0 +1,143 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/org/gastown-operator/internal/git"
+)
+
+const gitLabRepoURL = "git@gitlab.example.com:platform/tools/widgets.git"
+
+func newTestGitLab(t *testing.T, handler http.HandlerFunc) Provider {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	p, err := New(Config{Type: TypeGitLab, APIURL: server.URL, Token: "glpat", RepoURL: gitLabRepoURL})
+	require.NoError(t, err)
+	return p
+}
+
+func TestGitLabDefaultAPIURL(t *testing.T) {
+	p, err := newGitLab(Config{RepoURL: gitLabRepoURL})
+	require.NoError(t, err)
+	assert.Equal(t, "https://gitlab.example.com/api/v4", p.api.baseURL)
+	assert.Equal(t, "platform%2Ftools%2Fwidgets", p.project)
+}
+
+func TestGitLabFindChangeRequest(t *testing.T) {
+	p := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/platform%2Ftools%2Fwidgets/merge_requests", r.URL.EscapedPath())
+		assert.Equal(t, "glpat", r.Header.Get("PRIVATE-TOKEN"))
+		assert.Equal(t, "polecat/gt-1", r.URL.Query().Get("source_branch"))
+		assert.Equal(t, "main", r.URL.Query().Get("target_branch"))
+		_, _ = w.Write([]byte(`[{"iid":12,"web_url":"https://gitlab.example.com/mr/12","state":"merged",
+			"sha":"h3ad","merge_commit_sha":null,"squash_commit_sha":"squ4sh"}]`))
+	})
+
+	cr, err := p.FindChangeRequest(context.Background(), "polecat/gt-1", "main")
+	require.NoError(t, err)
+	assert.Equal(t, &ChangeRequest{
+		Number:         12,
+		Ref:            "MR !12",
+		URL:            "https://gitlab.example.com/mr/12",
+		State:          StateMerged,
+		MergeCommitSHA: "squ4sh",
+		HeadSHA:        "h3ad",
+	}, cr)
+}
+
+func TestGitLabCreateAndAutoMerge(t *testing.T) {
+	var created, merge map[string]any
+	p := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodPost && r.URL.EscapedPath() == "/projects/platform%2Ftools%2Fwidgets/merge_requests":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&created))
+			w.WriteHeader(http.StatusCreated)
+			_, _ = w.Write([]byte(`{"iid":13,"state":"opened","sha":"h3ad"}`))
+		case r.Method == http.MethodPut && r.URL.EscapedPath() == "/projects/platform%2Ftools%2Fwidgets/merge_requests/13/merge":
+			assert.NoError(t, json.NewDecoder(r.Body).Decode(&merge))
+			_, _ = w.Write([]byte(`{"iid":13,"state":"opened","merge_when_pipeline_succeeds":true}`))
+		default:
+			t.Errorf("unexpected request %s %s", r.Method, r.URL.EscapedPath())
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+
+	cr, err := p.CreateChangeRequest(context.Background(), "polecat/gt-1", "main", "gt-1: Fix", "body")
+	require.NoError(t, err)
+	assert.Equal(t, StateOpen, cr.State)
+	assert.Equal(t, "polecat/gt-1", created["source_branch"])
+	assert.Equal(t, "gt-1: Fix", created["title"])
+	assert.Equal(t, "body", created["description"])
+
+	require.NoError(t, p.EnableAutoMerge(context.Background(), cr, MergeMethodSquash))
+	assert.Equal(t, true, merge["merge_when_pipeline_succeeds"])
+	assert.Equal(t, true, merge["squash"])
+	assert.Equal(t, "h3ad", merge["sha"])
+}
+
+func TestGitLabGetChecksStatus(t *testing.T) {
+	tests := []struct {
+		pipeline string
+		want     string
+	}{
+		{pipeline: `null`, want: git.ChecksSuccess},
+		{pipeline: `{"status":"success"}`, want: git.ChecksSuccess},
+		{pipeline: `{"status":"running"}`, want: git.ChecksPending},
+		{pipeline: `{"status":"manual"}`, want: git.ChecksPending},
+		{pipeline: `{"status":"failed"}`, want: git.ChecksFailure},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.pipeline, func(t *testing.T) {
+			p := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, "/projects/platform%2Ftools%2Fwidgets/merge_requests/12", r.URL.EscapedPath())
+				_, _ = w.Write([]byte(`{"iid":12,"head_pipeline":` + tt.pipeline + `}`))
+			})
+
+			checks, err := p.GetChecksStatus(context.Background(), &ChangeRequest{Number: 12})
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, checks.State)
+		})
+	}
+}
+
+func TestGitLabGetCommitChecks(t *testing.T) {
+	p := newTestGitLab(t, func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/projects/platform%2Ftools%2Fwidgets/repository/commits/h3ad/statuses", r.URL.EscapedPath())
+		_, _ = w.Write([]byte(`[{"name":"build","status":"success"},{"name":"e2e","status":"running"},` +
+			`{"name":"lint","status":"failed"}]`))
+	})
+
+	checks, err := p.GetCommitChecks(context.Background(), "h3ad")
+	require.NoError(t, err)
+	assert.Equal(t, []git.CheckResult{
+		{Name: "build", State: git.ChecksSuccess},
+		{Name: "e2e", State: git.ChecksPending},
+		{Name: "lint", State: git.ChecksFailure},
+	}, checks)
+}
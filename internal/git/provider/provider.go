@@ -0,0 +1,174 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package provider abstracts the git hosting services the Refinery opens
+// change requests on: GitHub pull requests, GitLab merge requests and
+// Bitbucket Cloud pull requests.
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/org/gastown-operator/internal/git"
+)
+
+// Supported provider types.
+const (
+	TypeGitHub    = "github"
+	TypeGitLab    = "gitlab"
+	TypeBitbucket = "bitbucket"
+)
+
+// Change request states.
+const (
+	StateOpen   = "open"
+	StateMerged = "merged"
+	StateClosed = "closed"
+)
+
+// Merge methods accepted by EnableAutoMerge.
+const (
+	MergeMethodMerge  = "merge"
+	MergeMethodSquash = "squash"
+	MergeMethodRebase = "rebase"
+)
+
+// ChangeRequest is a pull request (GitHub, Bitbucket) or merge request (GitLab).
+type ChangeRequest struct {
+	// Number is the per-repository number (the iid on GitLab)
+	Number int
+
+	// Ref is the short display form, e.g. "PR #42" or "MR !42"
+	Ref string
+
+	// URL is the web URL of the change request
+	URL string
+
+	// State is StateOpen, StateMerged or StateClosed
+	State string
+
+	// MergeCommitSHA is the commit that landed on the target branch, once merged
+	MergeCommitSHA string
+
+	// HeadSHA is the latest commit of the source branch
+	HeadSHA string
+
+	// AutoMerge reports whether the provider will merge the change request
+	// on its own once checks pass
+	AutoMerge bool
+
+	// nodeID is the GitHub GraphQL ID, needed to enable auto-merge
+	nodeID string
+}
+
+// Provider opens and tracks change requests on a git hosting service.
+type Provider interface {
+	// FindChangeRequest returns the most recent change request (in any state)
+	// from source into target, or nil if there is none.
+	FindChangeRequest(ctx context.Context, source, target string) (*ChangeRequest, error)
+
+	// CreateChangeRequest opens a change request from source into target.
+	CreateChangeRequest(ctx context.Context, source, target, title, body string) (*ChangeRequest, error)
+
+	// EnableAutoMerge arranges for cr to merge once its checks pass, using
+	// method (MergeMethodMerge, MergeMethodSquash or MergeMethodRebase).
+	// Providers without native auto-merge merge cr directly once the checks
+	// have passed, so callers should retry on later polls while cr.AutoMerge
+	// is false.
+	EnableAutoMerge(ctx context.Context, cr *ChangeRequest, method string) error
+
+	// GetChecksStatus summarizes the CI checks on cr's head commit.
+	GetChecksStatus(ctx context.Context, cr *ChangeRequest) (*git.ChecksStatus, error)
+
+	// GetCommitChecks lists the CI checks reported on the commit sha, most
+	// recent first.
+	GetCommitChecks(ctx context.Context, sha string) ([]git.CheckResult, error)
+}
+
+// BranchProtection is implemented by providers that report the status
+// checks a protected branch requires before changes land on it.
+type BranchProtection interface {
+	// RequiredChecks returns the names of the checks branch requires; none
+	// when it is not protected.
+	RequiredChecks(ctx context.Context, branch string) ([]string, error)
+}
+
+// Config selects and configures a provider.
+type Config struct {
+	// Type is TypeGitHub, TypeGitLab or TypeBitbucket. Empty means TypeGitHub.
+	Type string
+
+	// APIURL overrides the provider's API endpoint. If empty, GitHub and
+	// Bitbucket use their public APIs and GitLab uses the repository host.
+	APIURL string
+
+	// Token authenticates API requests. For Bitbucket, "username:app-password"
+	// uses basic authentication; anything else is sent as a bearer token.
+	Token string
+
+	// RepoURL is the git URL of the repository
+	RepoURL string
+
+	// HTTPClient performs requests. If nil, a client with a 30s timeout is used.
+	HTTPClient *http.Client
+}
+
+// New creates the provider selected by cfg.Type.
+func New(cfg Config) (Provider, error) {
+	switch cfg.Type {
+	case TypeGitHub, "":
+		return newGitHub(cfg)
+	case TypeGitLab:
+		return newGitLab(cfg)
+	case TypeBitbucket:
+		return newBitbucket(cfg)
+	default:
+		return nil, fmt.Errorf("unknown git provider %q", cfg.Type)
+	}
+}
+
+// ParseRepoPath splits a git URL into its host and repository path (without
+// the .git suffix). Supports https://host/path, ssh://git@host[:port]/path and
+// scp-style git@host:path forms.
+func ParseRepoPath(gitURL string) (string, string, error) {
+	var host, path string
+	switch {
+	case strings.Contains(gitURL, "://"):
+		u, err := url.Parse(gitURL)
+		if err != nil {
+			return "", "", fmt.Errorf("invalid git URL %q: %w", gitURL, err)
+		}
+		host, path = u.Hostname(), u.Path
+	case strings.Contains(gitURL, ":"):
+		hostPart, pathPart, _ := strings.Cut(gitURL, ":")
+		if i := strings.LastIndex(hostPart, "@"); i >= 0 {
+			hostPart = hostPart[i+1:]
+		}
+		host, path = hostPart, pathPart
+	default:
+		return "", "", fmt.Errorf("unsupported git URL %q", gitURL)
+	}
+
+	path = strings.TrimSuffix(strings.Trim(path, "/"), ".git")
+	if host == "" || path == "" {
+		return "", "", fmt.Errorf("git URL %q does not name a repository", gitURL)
+	}
+	return host, path, nil
+}
@@ -0,0 +1,99 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package provider
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseRepoPath(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantHost string
+		wantPath string
+		wantErr  bool
+	}{
+		{url: "https://gitlab.example.com/group/sub/repo.git", wantHost: "gitlab.example.com", wantPath: "group/sub/repo"},
+		{url: "ssh://git@gitlab.example.com:2222/group/repo.git", wantHost: "gitlab.example.com", wantPath: "group/repo"},
+		{url: "git@bitbucket.org:acme/widgets.git", wantHost: "bitbucket.org", wantPath: "acme/widgets"},
+		{url: "gitlab.example.com:group/repo", wantHost: "gitlab.example.com", wantPath: "group/repo"},
+		{url: "https://gitlab.example.com/", wantErr: true},
+		{url: "/local/path", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			host, path, err := ParseRepoPath(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantHost, host)
+			assert.Equal(t, tt.wantPath, path)
+		})
+	}
+}
+
+func TestNew(t *testing.T) {
+	for _, typ := range []string{"", TypeGitHub, TypeGitLab, TypeBitbucket} {
+		p, err := New(Config{Type: typ, RepoURL: "git@example.com:acme/widgets.git"})
+		require.NoError(t, err, "type %q", typ)
+		assert.NotNil(t, p)
+	}
+
+	_, err := New(Config{Type: "gitea", RepoURL: "git@example.com:acme/widgets.git"})
+	assert.Error(t, err)
+
+	_, err = New(Config{Type: TypeBitbucket, RepoURL: "git@bitbucket.org:acme/group/widgets.git"})
+	assert.Error(t, err, "bitbucket repositories are workspace/repo")
+}
+
+func TestGitHubChangeRequestState(t *testing.T) {
+	tests := []struct {
+		name     string
+		response string
+		want     string
+	}{
+		{name: "open", response: `[{"number":1,"state":"open","merged_at":null}]`, want: StateOpen},
+		{name: "merged", response: `[{"number":1,"state":"closed","merged_at":"2026-01-01T00:00:00Z"}]`, want: StateMerged},
+		{name: "closed", response: `[{"number":1,"state":"closed","merged_at":null}]`, want: StateClosed},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				_, _ = w.Write([]byte(tt.response))
+			}))
+			defer server.Close()
+
+			p, err := New(Config{Type: TypeGitHub, APIURL: server.URL, RepoURL: "git@github.com:acme/widgets.git"})
+			require.NoError(t, err)
+
+			cr, err := p.FindChangeRequest(context.Background(), "polecat/gt-1", "main")
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, cr.State)
+			assert.Equal(t, "PR #1", cr.Ref)
+		})
+	}
+}
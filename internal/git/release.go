@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Version policies understood by NextVersion.
+const (
+	VersionPolicyPatch  = "patch"
+	VersionPolicyMinor  = "minor"
+	VersionPolicyMajor  = "major"
+	VersionPolicyCalVer = "calver"
+)
+
+// LatestTag returns the highest version tag matching prefix, or "" if none exist.
+// Tags are sorted by git's version ordering, so v1.10.0 sorts after v1.9.0.
+func (c *Client) LatestTag(ctx context.Context, prefix string) (string, error) {
+	output, err := c.runGit(ctx, "tag", "--list", "--sort=-v:refname", prefix+"*")
+	if err != nil {
+		return "", err
+	}
+	for _, line := range strings.Split(output, "\n") {
+		tag := strings.TrimSpace(line)
+		if tag != "" {
+			return tag, nil
+		}
+	}
+	return "", nil
+}
+
+// CreateTag creates an annotated tag on origin/branch and returns the tagged commit SHA.
+func (c *Client) CreateTag(ctx context.Context, tag, branch, message string) (string, error) {
+	ref := "origin/" + branch
+	if _, err := c.runGit(ctx,
+		"-c", "user.name="+operatorCommitterName,
+		"-c", "user.email="+operatorCommitterEmail,
+		"tag", "-a", tag, "-m", message, ref); err != nil {
+		return "", err
+	}
+	return c.runGit(ctx, "rev-parse", ref)
+}
+
+// PushTag pushes the tag to origin.
+func (c *Client) PushTag(ctx context.Context, tag string) error {
+	_, err := c.runGit(ctx, "push", "origin", "refs/tags/"+tag)
+	return err
+}
+
+// NextVersion computes the next release tag from the latest existing tag.
+// For semver policies the latest tag is parsed as prefix + MAJOR.MINOR.PATCH
+// (pre-release and build suffixes are ignored); with no prior tag the first
+// release is 0.1.0 (or 1.0.0 for major). The calver policy uses the UTC date
+// as YYYY.MM.DD and appends a counter (.1, .2, ...) for additional releases
+// on the same day.
+func NextVersion(latest, policy, prefix string, now time.Time) (string, error) {
+	if policy == VersionPolicyCalVer {
+		return nextCalVer(latest, prefix, now), nil
+	}
+
+	if latest == "" {
+		switch policy {
+		case VersionPolicyMajor:
+			return prefix + "1.0.0", nil
+		case VersionPolicyMinor, VersionPolicyPatch, "":
+			return prefix + "0.1.0", nil
+		default:
+			return "", fmt.Errorf("unknown version policy %q", policy)
+		}
+	}
+
+	major, minor, patch, err := parseSemver(strings.TrimPrefix(latest, prefix))
+	if err != nil {
+		return "", fmt.Errorf("cannot parse latest tag %q: %w", latest, err)
+	}
+
+	switch policy {
+	case VersionPolicyMajor:
+		major, minor, patch = major+1, 0, 0
+	case VersionPolicyMinor:
+		minor, patch = minor+1, 0
+	case VersionPolicyPatch, "":
+		patch++
+	default:
+		return "", fmt.Errorf("unknown version policy %q", policy)
+	}
+
+	return fmt.Sprintf("%s%d.%d.%d", prefix, major, minor, patch), nil
+}
+
+// nextCalVer returns prefix + YYYY.MM.DD, adding a counter when latest
+// already used today's date.
+func nextCalVer(latest, prefix string, now time.Time) string {
+	date := now.UTC().Format("2006.01.02")
+	base := prefix + date
+
+	if latest != base && !strings.HasPrefix(latest, base+".") {
+		return base
+	}
+
+	counter := 0
+	if suffix := strings.TrimPrefix(latest, base+"."); suffix != latest {
+		if n, err := strconv.Atoi(suffix); err == nil {
+			counter = n
+		}
+	}
+	return fmt.Sprintf("%s.%d", base, counter+1)
+}
+
+// parseSemver parses MAJOR.MINOR.PATCH, ignoring any -prerelease or +build suffix.
+func parseSemver(version string) (int, int, int, error) {
+	if i := strings.IndexAny(version, "-+"); i >= 0 {
+		version = version[:i]
+	}
+	parts := strings.Split(version, ".")
+	if len(parts) != 3 {
+		return 0, 0, 0, fmt.Errorf("expected MAJOR.MINOR.PATCH, got %q", version)
+	}
+	nums := make([]int, 3)
+	for i, part := range parts {
+		n, err := strconv.Atoi(part)
+		if err != nil || n < 0 {
+			return 0, 0, 0, fmt.Errorf("invalid version component %q", part)
+		}
+		nums[i] = n
+	}
+	return nums[0], nums[1], nums[2], nil
+}
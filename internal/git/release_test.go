@@ -0,0 +1,165 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNextVersion(t *testing.T) {
+	now := time.Date(2026, 3, 7, 15, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name    string
+		latest  string
+		policy  string
+		prefix  string
+		want    string
+		wantErr bool
+	}{
+		{name: "first patch release", latest: "", policy: "patch", prefix: "v", want: "v0.1.0"},
+		{name: "first major release", latest: "", policy: "major", prefix: "v", want: "v1.0.0"},
+		{name: "patch bump", latest: "v1.2.3", policy: "patch", prefix: "v", want: "v1.2.4"},
+		{name: "minor bump resets patch", latest: "v1.2.3", policy: "minor", prefix: "v", want: "v1.3.0"},
+		{name: "major bump resets minor and patch", latest: "v1.2.3", policy: "major", prefix: "v", want: "v2.0.0"},
+		{name: "empty policy defaults to patch", latest: "v1.2.3", policy: "", prefix: "v", want: "v1.2.4"},
+		{name: "prerelease suffix ignored", latest: "v1.2.3-rc.1", policy: "patch", prefix: "v", want: "v1.2.4"},
+		{name: "custom prefix", latest: "release-0.9.9", policy: "minor", prefix: "release-", want: "release-0.10.0"},
+		{name: "unparseable latest", latest: "vnext", policy: "patch", prefix: "v", wantErr: true},
+		{name: "unknown policy", latest: "v1.0.0", policy: "weekly", prefix: "v", wantErr: true},
+		{name: "calver first of day", latest: "v2026.03.06", policy: "calver", prefix: "v", want: "v2026.03.07"},
+		{name: "calver second of day", latest: "v2026.03.07", policy: "calver", prefix: "v", want: "v2026.03.07.1"},
+		{name: "calver third of day", latest: "v2026.03.07.1", policy: "calver", prefix: "v", want: "v2026.03.07.2"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := NextVersion(tt.latest, tt.policy, tt.prefix, now)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestTagging(t *testing.T) {
+	skipIfNoGit(t)
+
+	ctx := context.Background()
+	tmpDir := t.TempDir()
+	remoteDir := tmpDir + "/remote.git"
+	workDir := tmpDir + "/work"
+
+	require.NoError(t, runGitCmd(t, "", "init", "--bare", "-b", "main", remoteDir))
+	require.NoError(t, runGitCmd(t, "", "clone", remoteDir, workDir))
+	require.NoError(t, runGitCmd(t, workDir, "config", "user.email", "test@test.com"))
+	require.NoError(t, runGitCmd(t, workDir, "config", "user.name", "Test User"))
+	require.NoError(t, runGitCmd(t, workDir, "commit", "--allow-empty", "-m", "initial"))
+	require.NoError(t, runGitCmd(t, workDir, "push", "origin", "HEAD:main"))
+	require.NoError(t, runGitCmd(t, workDir, "tag", "v0.9.0"))
+	require.NoError(t, runGitCmd(t, workDir, "tag", "v0.10.0"))
+
+	client := NewClient(workDir, remoteDir)
+
+	latest, err := client.LatestTag(ctx, "v")
+	require.NoError(t, err)
+	assert.Equal(t, "v0.10.0", latest)
+
+	require.NoError(t, client.Fetch(ctx))
+	sha, err := client.CreateTag(ctx, "v0.10.1", "main", "Release v0.10.1")
+	require.NoError(t, err)
+	assert.Len(t, sha, 40)
+	require.NoError(t, client.PushTag(ctx, "v0.10.1"))
+
+	tagged, err := NewClient(remoteDir, "").runGit(ctx, "rev-parse", "v0.10.1^{commit}")
+	require.NoError(t, err)
+	assert.Equal(t, sha, tagged)
+}
+
+func TestGitHubCreateRelease(t *testing.T) {
+	var got map[string]any
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		assert.Equal(t, "/repos/acme/widgets/releases", r.URL.Path)
+		assert.Equal(t, "Bearer secret", r.Header.Get("Authorization"))
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&got))
+		w.WriteHeader(http.StatusCreated)
+		_, _ = w.Write([]byte(`{"html_url":"https://github.com/acme/widgets/releases/tag/v1.0.0"}`))
+	}))
+	defer server.Close()
+
+	gh := NewGitHubClient(server.URL, "secret")
+	releaseURL, err := gh.CreateRelease(context.Background(), "acme", "widgets", "v1.0.0", "abc123")
+	require.NoError(t, err)
+	assert.Equal(t, "https://github.com/acme/widgets/releases/tag/v1.0.0", releaseURL)
+	assert.Equal(t, "v1.0.0", got["tag_name"])
+	assert.Equal(t, "abc123", got["target_commitish"])
+	assert.Equal(t, true, got["generate_release_notes"])
+}
+
+func TestGitHubCreateReleaseError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		_, _ = w.Write([]byte(`{"message":"Validation Failed"}`))
+	}))
+	defer server.Close()
+
+	_, err := NewGitHubClient(server.URL, "secret").
+		CreateRelease(context.Background(), "acme", "widgets", "v1.0.0", "abc123")
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "Validation Failed")
+}
+
+func TestParseGitHubRepo(t *testing.T) {
+	tests := []struct {
+		url       string
+		wantOwner string
+		wantRepo  string
+		wantErr   bool
+	}{
+		{url: "https://github.com/acme/widgets.git", wantOwner: "acme", wantRepo: "widgets"},
+		{url: "https://github.com/acme/widgets", wantOwner: "acme", wantRepo: "widgets"},
+		{url: "git@github.com:acme/widgets.git", wantOwner: "acme", wantRepo: "widgets"},
+		{url: "ssh://git@github.example.com/acme/widgets.git", wantOwner: "acme", wantRepo: "widgets"},
+		{url: "https://github.com/acme", wantErr: true},
+		{url: "/srv/git/widgets", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.url, func(t *testing.T) {
+			owner, repo, err := ParseGitHubRepo(tt.url)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantOwner, owner)
+			assert.Equal(t, tt.wantRepo, repo)
+		})
+	}
+}
@@ -0,0 +1,182 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+)
+
+// Formats of commit signing keys, as in gpg.format
+const (
+	SigningFormatGPG = "gpg"
+	SigningFormatSSH = "ssh"
+)
+
+// ErrUnverifiedSignature is returned by MergeBranch and RefreshBranch when a
+// commit of the source branch does not carry a good signature of the signing
+// key. Nothing is pushed.
+var ErrUnverifiedSignature = errors.New("commit signature not verified")
+
+// commitSigning is the configuration git signs and verifies commits with
+type commitSigning struct {
+	// dir holds the key material; removed by Cleanup
+	dir string
+
+	// config are the git config keys and values, alternating
+	config []string
+
+	// env is the environment of gpg, e.g. GNUPGHOME
+	env []string
+}
+
+// SetSigningKey signs the commits and annotated tags the client creates,
+// including rebased commits, with the given private key: an ASCII-armored
+// OpenPGP key (SigningFormatGPG) or an OpenSSH key (SigningFormatSSH),
+// without a passphrase. MergeBranch and RefreshBranch then refuse source
+// branches with commits that do not carry a good signature of that key. The
+// key material is written to a private temp directory removed by Cleanup.
+func (c *Client) SetSigningKey(format string, key []byte) error {
+	dir, err := os.MkdirTemp("", "git-signing-*")
+	if err != nil {
+		return fmt.Errorf("failed to create signing directory: %w", err)
+	}
+	signing := &commitSigning{dir: dir}
+	switch format {
+	case SigningFormatGPG, "":
+		err = signing.importGPGKey(key)
+	case SigningFormatSSH:
+		err = signing.writeSSHKey(key)
+	default:
+		err = fmt.Errorf("unknown signing format %q", format)
+	}
+	if err != nil {
+		_ = os.RemoveAll(dir) //nolint:errcheck // best-effort cleanup on error path
+		return err
+	}
+	signing.config = append(signing.config, "commit.gpgSign", "true", "tag.gpgSign", "true")
+
+	c.removeSigning()
+	c.signing = signing
+	return nil
+}
+
+// importGPGKey imports the key into a keyring of its own, trusted
+// ultimately so that its signatures verify as good
+func (s *commitSigning) importGPGKey(key []byte) error {
+	s.env = []string{"GNUPGHOME=" + s.dir}
+	if _, err := s.gpg(key, "--import"); err != nil {
+		return fmt.Errorf("failed to import signing key: %w", err)
+	}
+	out, err := s.gpg(nil, "--with-colons", "--list-secret-keys")
+	if err != nil {
+		return fmt.Errorf("failed to list signing key: %w", err)
+	}
+	fingerprint := ""
+	for _, line := range strings.Split(out, "\n") {
+		if fields := strings.Split(line, ":"); len(fields) > 9 && fields[0] == "fpr" {
+			fingerprint = fields[9]
+			break
+		}
+	}
+	if fingerprint == "" {
+		return fmt.Errorf("no OpenPGP secret key in signing key")
+	}
+	if _, err := s.gpg([]byte(fingerprint+":6:\n"), "--import-ownertrust"); err != nil {
+		return fmt.Errorf("failed to trust signing key: %w", err)
+	}
+	s.config = []string{"gpg.format", "openpgp", "user.signingKey", fingerprint}
+	return nil
+}
+
+// gpg runs gpg in batch mode on the signing keyring
+func (s *commitSigning) gpg(stdin []byte, args ...string) (string, error) {
+	cmd := exec.Command("gpg", append([]string{"--batch", "--no-tty"}, args...)...)
+	cmd.Env = append(os.Environ(), s.env...)
+	cmd.Stdin = bytes.NewReader(stdin)
+	var stdout, stderr bytes.Buffer
+	cmd.Stdout = &stdout
+	cmd.Stderr = &stderr
+	if err := cmd.Run(); err != nil {
+		return "", fmt.Errorf("gpg %s: %w: %s", args[0], err, strings.TrimSpace(stderr.String()))
+	}
+	return stdout.String(), nil
+}
+
+// writeSSHKey writes the key and an allowed signers file of its public key
+func (s *commitSigning) writeSSHKey(key []byte) error {
+	keyPath := filepath.Join(s.dir, "signing_key")
+	if err := os.WriteFile(keyPath, key, 0o600); err != nil {
+		return fmt.Errorf("failed to write signing key: %w", err)
+	}
+	publicKey, err := exec.Command("ssh-keygen", "-y", "-f", keyPath).Output()
+	if err != nil {
+		return fmt.Errorf("failed to read public signing key: %w", err)
+	}
+	allowedSigners := filepath.Join(s.dir, "allowed_signers")
+	line := `* namespaces="git" ` + strings.TrimSpace(string(publicKey)) + "\n"
+	if err := os.WriteFile(allowedSigners, []byte(line), 0o600); err != nil {
+		return fmt.Errorf("failed to write allowed signers: %w", err)
+	}
+	s.config = []string{
+		"gpg.format", "ssh",
+		"user.signingKey", keyPath,
+		"gpg.ssh.allowedSignersFile", allowedSigners,
+	}
+	return nil
+}
+
+// removeSigning removes the key material of SetSigningKey, if any
+func (c *Client) removeSigning() {
+	if c.signing != nil {
+		_ = os.RemoveAll(c.signing.dir) //nolint:errcheck // best-effort cleanup
+		c.signing = nil
+	}
+}
+
+// VerifyCommits checks that every commit reachable from head but not from
+// base carries a good signature of the signing key. The first commit that
+// does not is returned in an error wrapping ErrUnverifiedSignature.
+func (c *Client) VerifyCommits(ctx context.Context, base, head string) error {
+	if c.signing == nil {
+		return fmt.Errorf("no signing key to verify commits with")
+	}
+	out, err := c.runGit(ctx, "rev-list", "--reverse", base+".."+head)
+	if err != nil {
+		return err
+	}
+	for _, sha := range strings.Fields(out) {
+		if _, err := c.runGit(ctx, "verify-commit", sha); err != nil {
+			return fmt.Errorf("%w: commit %s on %s", ErrUnverifiedSignature, shortSHA(sha), head)
+		}
+	}
+	return nil
+}
+
+// shortSHA abbreviates a commit SHA for messages
+func shortSHA(sha string) string {
+	if len(sha) > 12 {
+		return sha[:12]
+	}
+	return sha
+}
@@ -0,0 +1,169 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package git
+
+import (
+	"context"
+	"errors"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// signingKey is a generated key and the git config and environment an agent
+// signs its commits with
+type signingKey struct {
+	key    []byte
+	config []string
+	env    []string
+}
+
+func newSSHSigningKey(t *testing.T) signingKey {
+	t.Helper()
+	if _, err := exec.LookPath("ssh-keygen"); err != nil {
+		t.Skip("ssh-keygen not installed")
+	}
+	path := filepath.Join(t.TempDir(), "id_ed25519")
+	require.NoError(t, exec.Command("ssh-keygen", "-q", "-t", "ed25519", "-N", "", "-f", path).Run())
+	key, err := os.ReadFile(path)
+	require.NoError(t, err)
+	return signingKey{key: key, config: []string{"-c", "gpg.format=ssh", "-c", "user.signingKey=" + path}}
+}
+
+func newGPGSigningKey(t *testing.T) signingKey {
+	t.Helper()
+	if _, err := exec.LookPath("gpg"); err != nil {
+		t.Skip("gpg not installed")
+	}
+	home, err := os.MkdirTemp("", "gnupg-*")
+	require.NoError(t, err)
+	t.Cleanup(func() { _ = os.RemoveAll(home) })
+	env := append(os.Environ(), "GNUPGHOME="+home)
+
+	gen := exec.Command("gpg", "--batch", "--passphrase", "", "--quick-gen-key", "Polecat <polecat@test.com>", "ed25519", "sign", "never")
+	gen.Env = env
+	require.NoError(t, gen.Run())
+	export := exec.Command("gpg", "--batch", "--armor", "--export-secret-keys")
+	export.Env = env
+	key, err := export.Output()
+	require.NoError(t, err)
+	return signingKey{
+		key:    key,
+		config: []string{"-c", "user.signingKey=polecat@test.com"},
+		env:    []string{"GNUPGHOME=" + home},
+	}
+}
+
+func TestMergeBranch_VerifySignatures(t *testing.T) {
+	skipIfNoGit(t)
+
+	for _, tc := range []struct {
+		format string
+		newKey func(*testing.T) signingKey
+	}{
+		{SigningFormatSSH, newSSHSigningKey},
+		{SigningFormatGPG, newGPGSigningKey},
+	} {
+		t.Run(tc.format, func(t *testing.T) {
+			ctx := context.Background()
+			key := tc.newKey(t)
+			tempDir := t.TempDir()
+
+			originDir := filepath.Join(tempDir, "origin.git")
+			require.NoError(t, runGitCmd(t, "", "init", "--bare", originDir))
+			refineryDir := filepath.Join(tempDir, "refinery-repo")
+			require.NoError(t, runGitCmd(t, "", "clone", originDir, refineryDir))
+			require.NoError(t, runGitCmd(t, refineryDir, "config", "user.email", "test@test.com"))
+			require.NoError(t, runGitCmd(t, refineryDir, "config", "user.name", "Test User"))
+			require.NoError(t, os.WriteFile(filepath.Join(refineryDir, "README.md"), []byte("# Test\n"), 0o600))
+			require.NoError(t, runGitCmd(t, refineryDir, "add", "README.md"))
+			require.NoError(t, runGitCmd(t, refineryDir, "commit", "-m", "Initial commit"))
+			require.NoError(t, runGitCmd(t, refineryDir, "branch", "-M", "main"))
+			require.NoError(t, runGitCmd(t, refineryDir, "push", "-u", "origin", "main"))
+
+			polecatDir := filepath.Join(tempDir, "polecat-repo")
+			require.NoError(t, runGitCmd(t, "", "clone", originDir, polecatDir))
+			require.NoError(t, runGitCmd(t, polecatDir, "config", "user.email", "polecat@test.com"))
+			require.NoError(t, runGitCmd(t, polecatDir, "config", "user.name", "Polecat Worker"))
+			commit := func(branch, file string, sign bool) {
+				require.NoError(t, runGitCmd(t, polecatDir, "checkout", "-B", branch, "origin/main"))
+				require.NoError(t, os.WriteFile(filepath.Join(polecatDir, file), []byte(file+"\n"), 0o600))
+				require.NoError(t, runGitCmd(t, polecatDir, "add", file))
+				args := []string{"commit", "-m", "add " + file}
+				if sign {
+					args = append(append(append([]string{}, key.config...), "commit", "-S"), "-m", "add "+file)
+				}
+				cmd := exec.Command("git", args...)
+				cmd.Dir = polecatDir
+				cmd.Env = append(os.Environ(), key.env...)
+				out, err := cmd.CombinedOutput()
+				require.NoError(t, err, string(out))
+				require.NoError(t, runGitCmd(t, polecatDir, "push", "origin", branch))
+			}
+			commit("feature/signed", "signed.txt", true)
+			commit("feature/unsigned", "unsigned.txt", false)
+
+			// The target moves, so the signed branch is rebased and re-signed
+			require.NoError(t, os.WriteFile(filepath.Join(refineryDir, "moved.txt"), []byte("moved\n"), 0o600))
+			require.NoError(t, runGitCmd(t, refineryDir, "add", "moved.txt"))
+			require.NoError(t, runGitCmd(t, refineryDir, "commit", "-m", "Move main"))
+			require.NoError(t, runGitCmd(t, refineryDir, "push", "origin", "main"))
+
+			client := NewClient(refineryDir, originDir)
+			require.NoError(t, client.SetSigningKey(tc.format, key.key))
+			defer client.Cleanup()
+
+			result, err := client.MergeBranch(ctx, MergeOptions{
+				SourceBranch: "feature/unsigned", TargetBranch: "main",
+			})
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, ErrUnverifiedSignature), "got %v", err)
+			assert.Contains(t, result.Error, "signature verification failed")
+
+			require.NoError(t, runGitCmd(t, refineryDir, "checkout", "main"))
+			result, err = client.MergeBranch(ctx, MergeOptions{
+				SourceBranch: "feature/signed", TargetBranch: "main",
+			})
+			require.NoError(t, err)
+			require.True(t, result.Success)
+			_, err = client.runGit(ctx, "verify-commit", result.MergedCommit)
+			assert.NoError(t, err, "the rebased commit should be signed with the key")
+		})
+	}
+}
+
+func TestSetSigningKey(t *testing.T) {
+	client := NewClient(t.TempDir(), "")
+	assert.Error(t, client.SetSigningKey("x509", nil))
+	assert.Nil(t, client.signing)
+
+	key := newSSHSigningKey(t)
+	require.NoError(t, client.SetSigningKey(SigningFormatSSH, key.key))
+	dir := client.signing.dir
+	env, err := client.authEnv()
+	require.NoError(t, err)
+	assert.Contains(t, env, "GIT_CONFIG_KEY_0=gpg.format")
+	assert.Contains(t, env, "GIT_CONFIG_VALUE_0=ssh")
+	assert.Contains(t, env, "GIT_CONFIG_KEY_3=commit.gpgSign")
+
+	client.Cleanup()
+	assert.NoDirExists(t, dir, "the key material should be removed")
+}
@@ -0,0 +1,160 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitwebhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+
+	"github.com/org/gastown-operator/internal/git/provider"
+)
+
+// Kind classifies a hosting provider event by what it can change.
+type Kind string
+
+const (
+	// KindPush is a push to a branch; rigs, refineries and synced
+	// bead stores may be affected.
+	KindPush Kind = "push"
+
+	// KindChangeRequest is a pull/merge request or CI status change;
+	// only refineries (pull request merge strategy) are affected.
+	KindChangeRequest Kind = "changeRequest"
+)
+
+// Event is a verified hosting provider event.
+type Event struct {
+	// Kind of the event
+	Kind Kind
+
+	// Repos are the keys (see RepoKey) of the repository URLs in the payload
+	Repos []string
+}
+
+// errUnauthorized means the request signature or token did not match.
+var errUnauthorized = errors.New("webhook signature verification failed")
+
+// parseEvent verifies and decodes a webhook request body.
+// Returns a nil event for verified events that cannot trigger a reconcile
+// (e.g., GitHub "ping").
+func parseEvent(header http.Header, body, secret []byte) (*Event, error) {
+	switch {
+	case header.Get("X-GitHub-Event") != "":
+		return parseGitHubEvent(header, body, secret)
+	case header.Get("X-Gitlab-Event") != "":
+		return parseGitLabEvent(header, body, secret)
+	default:
+		return nil, fmt.Errorf("missing X-GitHub-Event or X-Gitlab-Event header")
+	}
+}
+
+func parseGitHubEvent(header http.Header, body, secret []byte) (*Event, error) {
+	signature, ok := strings.CutPrefix(header.Get("X-Hub-Signature-256"), "sha256=")
+	if !ok {
+		return nil, errUnauthorized
+	}
+	got, err := hex.DecodeString(signature)
+	if err != nil {
+		return nil, errUnauthorized
+	}
+	mac := hmac.New(sha256.New, secret)
+	mac.Write(body)
+	if !hmac.Equal(got, mac.Sum(nil)) {
+		return nil, errUnauthorized
+	}
+
+	var kind Kind
+	switch header.Get("X-GitHub-Event") {
+	case "push":
+		kind = KindPush
+	case "pull_request", "pull_request_review", "check_suite", "check_run", "status":
+		kind = KindChangeRequest
+	default:
+		return nil, nil
+	}
+
+	var payload struct {
+		Repository struct {
+			CloneURL string `json:"clone_url"`
+			SSHURL   string `json:"ssh_url"`
+			HTMLURL  string `json:"html_url"`
+		} `json:"repository"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid GitHub payload: %w", err)
+	}
+	repo := payload.Repository
+	return newEvent(kind, repo.CloneURL, repo.SSHURL, repo.HTMLURL), nil
+}
+
+func parseGitLabEvent(header http.Header, body, secret []byte) (*Event, error) {
+	if subtle.ConstantTimeCompare([]byte(header.Get("X-Gitlab-Token")), secret) != 1 {
+		return nil, errUnauthorized
+	}
+
+	var kind Kind
+	switch header.Get("X-Gitlab-Event") {
+	case "Push Hook":
+		kind = KindPush
+	case "Merge Request Hook", "Pipeline Hook":
+		kind = KindChangeRequest
+	default:
+		return nil, nil
+	}
+
+	var payload struct {
+		Project struct {
+			GitSSHURL  string `json:"git_ssh_url"`
+			GitHTTPURL string `json:"git_http_url"`
+			WebURL     string `json:"web_url"`
+		} `json:"project"`
+	}
+	if err := json.Unmarshal(body, &payload); err != nil {
+		return nil, fmt.Errorf("invalid GitLab payload: %w", err)
+	}
+	project := payload.Project
+	return newEvent(kind, project.GitSSHURL, project.GitHTTPURL, project.WebURL), nil
+}
+
+func newEvent(kind Kind, urls ...string) *Event {
+	event := &Event{Kind: kind}
+	for _, u := range urls {
+		if key := RepoKey(u); key != "" && !slices.Contains(event.Repos, key) {
+			event.Repos = append(event.Repos, key)
+		}
+	}
+	return event
+}
+
+// RepoKey normalizes a git URL to "host/path" so that the https, ssh and
+// scp-style URLs of one repository compare equal. Returns "" for URLs that
+// do not name a repository.
+func RepoKey(gitURL string) string {
+	host, path, err := provider.ParseRepoPath(gitURL)
+	if err != nil {
+		return ""
+	}
+	return strings.ToLower(host + "/" + path)
+}
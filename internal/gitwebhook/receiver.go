@@ -0,0 +1,239 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package gitwebhook receives GitHub and GitLab webhooks and turns push and
+// pull request events into reconcile triggers for the matching Rigs,
+// Refineries and BeadStores, so changes are picked up in seconds instead of
+// at the next requeue interval.
+package gitwebhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+	logf "sigs.k8s.io/controller-runtime/pkg/log"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+const (
+	// Path is where hosting providers deliver webhooks
+	Path = "/hooks/git"
+
+	// EnvSecret holds the shared webhook secret (GitHub secret / GitLab token)
+	EnvSecret = "GASTOWN_GIT_WEBHOOK_SECRET"
+
+	// maxPayloadBytes bounds webhook bodies (GitHub caps payloads at 25MB)
+	maxPayloadBytes = 25 << 20
+
+	// triggerBuffer is the capacity of each trigger channel
+	triggerBuffer = 100
+)
+
+// Receiver serves the git webhook endpoint. It implements manager.Runnable.
+type Receiver struct {
+	// Client reads Rigs, Refineries and BeadStores
+	Client client.Reader
+
+	// Secret verifies webhook deliveries
+	Secret []byte
+
+	// BindAddress is the address the HTTP server listens on
+	BindAddress string
+
+	rigs       chan event.GenericEvent
+	refineries chan event.GenericEvent
+	beadStores chan event.GenericEvent
+}
+
+// NewReceiver creates a Receiver. Pass the trigger channels to the Rig,
+// Refinery and BeadStore reconcilers; they are nil on a nil Receiver.
+func NewReceiver(c client.Reader, secret []byte, bindAddress string) *Receiver {
+	return &Receiver{
+		Client:      c,
+		Secret:      secret,
+		BindAddress: bindAddress,
+		rigs:        make(chan event.GenericEvent, triggerBuffer),
+		refineries:  make(chan event.GenericEvent, triggerBuffer),
+		beadStores:  make(chan event.GenericEvent, triggerBuffer),
+	}
+}
+
+// RigTriggers returns the channel of Rigs to reconcile
+func (r *Receiver) RigTriggers() <-chan event.GenericEvent {
+	if r == nil {
+		return nil
+	}
+	return r.rigs
+}
+
+// RefineryTriggers returns the channel of Refineries to reconcile
+func (r *Receiver) RefineryTriggers() <-chan event.GenericEvent {
+	if r == nil {
+		return nil
+	}
+	return r.refineries
+}
+
+// BeadStoreTriggers returns the channel of BeadStores to reconcile
+func (r *Receiver) BeadStoreTriggers() <-chan event.GenericEvent {
+	if r == nil {
+		return nil
+	}
+	return r.beadStores
+}
+
+// NeedLeaderElection serves webhooks only on the leader, whose controllers
+// consume the triggers.
+func (r *Receiver) NeedLeaderElection() bool {
+	return true
+}
+
+// Start runs the HTTP server until ctx is cancelled.
+func (r *Receiver) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.Handle(Path, r)
+	server := &http.Server{
+		Addr:              r.BindAddress,
+		Handler:           mux,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	errCh := make(chan error, 1)
+	go func() {
+		logf.FromContext(ctx).Info("Starting git webhook receiver", "address", r.BindAddress, "path", Path)
+		errCh <- server.ListenAndServe()
+	}()
+
+	select {
+	case err := <-errCh:
+		return err
+	case <-ctx.Done():
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		return server.Shutdown(shutdownCtx)
+	}
+}
+
+// ServeHTTP verifies a webhook delivery and triggers the matching resources.
+func (r *Receiver) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	log := logf.FromContext(req.Context()).WithName("gitwebhook")
+
+	if req.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, req.Body, maxPayloadBytes))
+	if err != nil {
+		http.Error(w, "payload too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+
+	evt, err := parseEvent(req.Header, body, r.Secret)
+	if errors.Is(err, errUnauthorized) {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if evt == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+
+	triggered, err := r.Trigger(req.Context(), evt)
+	if err != nil {
+		log.Error(err, "Failed to map webhook to resources", "repos", evt.Repos)
+		http.Error(w, "failed to look up resources", http.StatusInternalServerError)
+		return
+	}
+	log.V(1).Info("Webhook received", "kind", evt.Kind, "repos", evt.Repos, "triggered", triggered)
+	w.WriteHeader(http.StatusAccepted)
+	_, _ = fmt.Fprintf(w, "triggered %d reconcile(s)\n", triggered)
+}
+
+// Trigger enqueues reconciles for the resources affected by evt and returns
+// how many were enqueued:
+//   - push: Rigs of the repository, their Refineries and git-synced BeadStores
+//   - change request: Refineries of the repository's Rigs
+func (r *Receiver) Trigger(ctx context.Context, evt *Event) (int, error) {
+	var rigs gastownv1alpha1.RigList
+	if err := r.Client.List(ctx, &rigs); err != nil {
+		return 0, fmt.Errorf("failed to list rigs: %w", err)
+	}
+	matched := make(map[string]bool)
+	for i := range rigs.Items {
+		if key := RepoKey(rigs.Items[i].Spec.GitURL); key != "" && slices.Contains(evt.Repos, key) {
+			matched[rigs.Items[i].Name] = true
+		}
+	}
+	if len(matched) == 0 {
+		return 0, nil
+	}
+
+	triggered := 0
+	if evt.Kind == KindPush {
+		for i := range rigs.Items {
+			if matched[rigs.Items[i].Name] {
+				triggered += r.send(ctx, r.rigs, &rigs.Items[i])
+			}
+		}
+
+		var beadStores gastownv1alpha1.BeadStoreList
+		if err := r.Client.List(ctx, &beadStores); err != nil {
+			return triggered, fmt.Errorf("failed to list beadstores: %w", err)
+		}
+		for i := range beadStores.Items {
+			if beadStores.Items[i].Spec.GitSync && matched[beadStores.Items[i].Spec.RigRef] {
+				triggered += r.send(ctx, r.beadStores, &beadStores.Items[i])
+			}
+		}
+	}
+
+	var refineries gastownv1alpha1.RefineryList
+	if err := r.Client.List(ctx, &refineries); err != nil {
+		return triggered, fmt.Errorf("failed to list refineries: %w", err)
+	}
+	for i := range refineries.Items {
+		if matched[refineries.Items[i].Spec.RigRef] {
+			triggered += r.send(ctx, r.refineries, &refineries.Items[i])
+		}
+	}
+
+	return triggered, nil
+}
+
+// send enqueues obj without blocking. When the buffer is full the trigger is
+// dropped; the periodic requeue still picks the change up.
+func (r *Receiver) send(ctx context.Context, ch chan event.GenericEvent, obj client.Object) int {
+	select {
+	case ch <- event.GenericEvent{Object: obj}:
+		return 1
+	default:
+		logf.FromContext(ctx).Info("Dropping webhook trigger, buffer full",
+			"kind", fmt.Sprintf("%T", obj), "name", obj.GetName(), "namespace", obj.GetNamespace())
+		return 0
+	}
+}
@@ -0,0 +1,201 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package gitwebhook
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+const testSecret = "s3cret"
+
+const gitHubPush = `{"ref":"refs/heads/main","repository":{
+	"clone_url":"https://github.com/Acme/Widgets.git",
+	"ssh_url":"git@github.com:Acme/Widgets.git",
+	"html_url":"https://github.com/Acme/Widgets"}}`
+
+func newTestReceiver(t *testing.T) *Receiver {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	require.NoError(t, gastownv1alpha1.AddToScheme(scheme))
+
+	objs := []client.Object{
+		&gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "widgets"},
+			Spec:       gastownv1alpha1.RigSpec{GitURL: "git@github.com:acme/widgets.git"},
+		},
+		&gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "gadgets"},
+			Spec:       gastownv1alpha1.RigSpec{GitURL: "git@github.com:acme/gadgets.git"},
+		},
+		&gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "widgets-refinery", Namespace: "gastown"},
+			Spec:       gastownv1alpha1.RefinerySpec{RigRef: "widgets"},
+		},
+		&gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "gadgets-refinery", Namespace: "gastown"},
+			Spec:       gastownv1alpha1.RefinerySpec{RigRef: "gadgets"},
+		},
+		&gastownv1alpha1.BeadStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "widgets-beads", Namespace: "gastown"},
+			Spec:       gastownv1alpha1.BeadStoreSpec{RigRef: "widgets", GitSync: true},
+		},
+		&gastownv1alpha1.BeadStore{
+			ObjectMeta: metav1.ObjectMeta{Name: "widgets-local", Namespace: "gastown"},
+			Spec:       gastownv1alpha1.BeadStoreSpec{RigRef: "widgets"},
+		},
+	}
+	c := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objs...).Build()
+	return NewReceiver(c, []byte(testSecret), ":0")
+}
+
+func sign(body string) string {
+	mac := hmac.New(sha256.New, []byte(testSecret))
+	mac.Write([]byte(body))
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+func deliver(r *Receiver, header map[string]string, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, Path, bytes.NewBufferString(body))
+	for k, v := range header {
+		req.Header.Set(k, v)
+	}
+	rec := httptest.NewRecorder()
+	r.ServeHTTP(rec, req)
+	return rec
+}
+
+func drain(ch <-chan event.GenericEvent) []string {
+	var names []string
+	for {
+		select {
+		case evt := <-ch:
+			names = append(names, evt.Object.GetName())
+		default:
+			return names
+		}
+	}
+}
+
+func TestRepoKey(t *testing.T) {
+	for _, u := range []string{
+		"https://github.com/acme/widgets.git",
+		"https://github.com/Acme/Widgets",
+		"git@github.com:acme/widgets.git",
+		"ssh://git@github.com/acme/widgets.git",
+	} {
+		assert.Equal(t, "github.com/acme/widgets", RepoKey(u), u)
+	}
+	assert.Empty(t, RepoKey("not a repo"))
+}
+
+func TestGitHubPushTriggersRigResources(t *testing.T) {
+	r := newTestReceiver(t)
+
+	rec := deliver(r, map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-Hub-Signature-256": sign(gitHubPush),
+	}, gitHubPush)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Equal(t, []string{"widgets"}, drain(r.RigTriggers()))
+	assert.Equal(t, []string{"widgets-refinery"}, drain(r.RefineryTriggers()))
+	assert.Equal(t, []string{"widgets-beads"}, drain(r.BeadStoreTriggers()), "only git-synced bead stores")
+}
+
+func TestGitLabMergeRequestTriggersRefineryOnly(t *testing.T) {
+	r := newTestReceiver(t)
+	body := `{"object_kind":"merge_request","project":{
+		"git_ssh_url":"git@github.com:acme/gadgets.git",
+		"git_http_url":"https://github.com/acme/gadgets.git"}}`
+
+	rec := deliver(r, map[string]string{
+		"X-Gitlab-Event": "Merge Request Hook",
+		"X-Gitlab-Token": testSecret,
+	}, body)
+
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, drain(r.RigTriggers()))
+	assert.Equal(t, []string{"gadgets-refinery"}, drain(r.RefineryTriggers()))
+	assert.Empty(t, drain(r.BeadStoreTriggers()))
+}
+
+func TestRejectsUnverifiedDeliveries(t *testing.T) {
+	tests := []struct {
+		name   string
+		header map[string]string
+		want   int
+	}{
+		{name: "missing signature", header: map[string]string{"X-GitHub-Event": "push"}, want: http.StatusUnauthorized},
+		{name: "wrong signature", header: map[string]string{
+			"X-GitHub-Event": "push", "X-Hub-Signature-256": sign("other")}, want: http.StatusUnauthorized},
+		{name: "wrong token", header: map[string]string{
+			"X-Gitlab-Event": "Push Hook", "X-Gitlab-Token": "guess"}, want: http.StatusUnauthorized},
+		{name: "unknown provider", header: map[string]string{}, want: http.StatusBadRequest},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := newTestReceiver(t)
+			rec := deliver(r, tt.header, gitHubPush)
+			assert.Equal(t, tt.want, rec.Code)
+			assert.Empty(t, drain(r.RigTriggers()))
+			assert.Empty(t, drain(r.RefineryTriggers()))
+		})
+	}
+}
+
+func TestIgnoresUnrelatedEvents(t *testing.T) {
+	r := newTestReceiver(t)
+
+	rec := deliver(r, map[string]string{
+		"X-GitHub-Event":      "ping",
+		"X-Hub-Signature-256": sign(gitHubPush),
+	}, gitHubPush)
+	assert.Equal(t, http.StatusNoContent, rec.Code)
+
+	other := `{"repository":{"clone_url":"https://github.com/acme/unknown.git"}}`
+	rec = deliver(r, map[string]string{
+		"X-GitHub-Event":      "push",
+		"X-Hub-Signature-256": sign(other),
+	}, other)
+	assert.Equal(t, http.StatusAccepted, rec.Code)
+	assert.Empty(t, drain(r.RigTriggers()))
+	assert.Empty(t, drain(r.RefineryTriggers()))
+}
+
+func TestNilReceiverHasNoTriggers(t *testing.T) {
+	var r *Receiver
+	assert.Nil(t, r.RigTriggers())
+	assert.Nil(t, r.RefineryTriggers())
+	assert.Nil(t, r.BeadStoreTriggers())
+}
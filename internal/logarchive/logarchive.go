@@ -0,0 +1,242 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package logarchive uploads agent logs to S3 or GCS.
+//
+// Both providers are spoken to through the S3 XML API with AWS Signature
+// Version 4 (GCS via HMAC keys), so no cloud SDK is needed.
+package logarchive
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+const (
+	// ProviderS3 is Amazon S3 or an S3-compatible store
+	ProviderS3 = "s3"
+	// ProviderGCS is Google Cloud Storage
+	ProviderGCS = "gcs"
+
+	// DefaultRegion is used for S3 when no region is configured
+	DefaultRegion = "us-east-1"
+
+	// gcsEndpoint is the GCS XML API (S3 interoperability)
+	gcsEndpoint = "https://storage.googleapis.com"
+
+	amzDateFormat = "20060102T150405Z"
+)
+
+// Config configures an Uploader.
+type Config struct {
+	// Provider is ProviderS3 (default) or ProviderGCS
+	Provider string
+
+	// Endpoint overrides the service URL (e.g., MinIO)
+	Endpoint string
+
+	// Region is the bucket region (S3 only)
+	Region string
+
+	// Bucket receives the logs
+	Bucket string
+
+	// AccessKeyID and SecretAccessKey sign requests (GCS: HMAC key)
+	AccessKeyID     string
+	SecretAccessKey string
+
+	// HTTPClient is used for uploads. Defaults to a client with a 60s timeout.
+	HTTPClient *http.Client
+}
+
+// Uploader writes objects to a bucket.
+type Uploader struct {
+	cfg      Config
+	endpoint string
+	region   string
+	now      func() time.Time
+}
+
+// New creates an Uploader.
+func New(cfg Config) (*Uploader, error) {
+	if cfg.Bucket == "" {
+		return nil, fmt.Errorf("bucket is required")
+	}
+	if cfg.AccessKeyID == "" || cfg.SecretAccessKey == "" {
+		return nil, fmt.Errorf("access key ID and secret access key are required")
+	}
+	if cfg.HTTPClient == nil {
+		cfg.HTTPClient = &http.Client{Timeout: 60 * time.Second}
+	}
+
+	u := &Uploader{cfg: cfg, endpoint: cfg.Endpoint, region: cfg.Region, now: time.Now}
+	switch cfg.Provider {
+	case "", ProviderS3:
+		if u.region == "" {
+			u.region = DefaultRegion
+		}
+		if u.endpoint == "" {
+			u.endpoint = fmt.Sprintf("https://s3.%s.amazonaws.com", u.region)
+		}
+	case ProviderGCS:
+		// GCS ignores the region but requires one in the signature scope
+		u.region = "auto"
+		if u.endpoint == "" {
+			u.endpoint = gcsEndpoint
+		}
+	default:
+		return nil, fmt.Errorf("unsupported log archive provider %q", cfg.Provider)
+	}
+	u.endpoint = strings.TrimSuffix(u.endpoint, "/")
+	return u, nil
+}
+
+// Upload stores body under key and returns the object URI
+// (s3://bucket/key or gs://bucket/key).
+func (u *Uploader) Upload(ctx context.Context, key, contentType string, body []byte) (string, error) {
+	key = strings.TrimPrefix(key, "/")
+	objectURL := u.endpoint + "/" + escapePath(u.cfg.Bucket+"/"+key)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, objectURL, bytes.NewReader(body))
+	if err != nil {
+		return "", err
+	}
+	req.Header.Set("Content-Type", contentType)
+	payloadHash := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(payloadHash[:]))
+	u.sign(req, u.now())
+
+	resp, err := u.cfg.HTTPClient.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("uploading %s failed: %w", key, err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	if resp.StatusCode != http.StatusOK {
+		msg, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return "", fmt.Errorf("uploading %s failed: %s: %s", key, resp.Status, strings.TrimSpace(string(msg)))
+	}
+
+	scheme := "s3"
+	if u.cfg.Provider == ProviderGCS {
+		scheme = "gs"
+	}
+	return fmt.Sprintf("%s://%s/%s", scheme, u.cfg.Bucket, key), nil
+}
+
+// sign adds an AWS Signature Version 4 Authorization header covering the
+// Host header and every header already set on req. X-Amz-Content-Sha256
+// must be set.
+func (u *Uploader) sign(req *http.Request, now time.Time) {
+	amzDate := now.UTC().Format(amzDateFormat)
+	date := amzDate[:8]
+	req.Header.Set("X-Amz-Date", amzDate)
+
+	headers := map[string]string{"host": req.URL.Host}
+	for name, values := range req.Header {
+		headers[strings.ToLower(name)] = strings.TrimSpace(strings.Join(values, ","))
+	}
+	names := make([]string, 0, len(headers))
+	for name := range headers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var canonicalHeaders strings.Builder
+	for _, name := range names {
+		canonicalHeaders.WriteString(name + ":" + headers[name] + "\n")
+	}
+	signedHeaders := strings.Join(names, ";")
+
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQuery(req.URL.Query()),
+		canonicalHeaders.String(),
+		signedHeaders,
+		req.Header.Get("X-Amz-Content-Sha256"),
+	}, "\n")
+
+	scope := date + "/" + u.region + "/s3/aws4_request"
+	requestHash := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + scope + "\n" + hex.EncodeToString(requestHash[:])
+
+	key := hmacSHA256([]byte("AWS4"+u.cfg.SecretAccessKey), date)
+	key = hmacSHA256(key, u.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	signature := hex.EncodeToString(hmacSHA256(key, stringToSign))
+
+	req.Header.Set("Authorization", fmt.Sprintf("AWS4-HMAC-SHA256 Credential=%s/%s,SignedHeaders=%s,Signature=%s",
+		u.cfg.AccessKeyID, scope, signedHeaders, signature))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQuery sorts and encodes query parameters for signing.
+func canonicalQuery(values url.Values) string {
+	keys := make([]string, 0, len(values))
+	for k := range values {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var parts []string
+	for _, k := range keys {
+		vals := append([]string(nil), values[k]...)
+		sort.Strings(vals)
+		for _, v := range vals {
+			parts = append(parts, escape(k)+"="+escape(v))
+		}
+	}
+	return strings.Join(parts, "&")
+}
+
+// escapePath URI-encodes each segment of an object path as SigV4 requires.
+func escapePath(path string) string {
+	segments := strings.Split(path, "/")
+	for i, s := range segments {
+		segments[i] = escape(s)
+	}
+	return strings.Join(segments, "/")
+}
+
+// escape percent-encodes everything except RFC 3986 unreserved characters.
+func escape(s string) string {
+	var b strings.Builder
+	for _, c := range []byte(s) {
+		if ('A' <= c && c <= 'Z') || ('a' <= c && c <= 'z') || ('0' <= c && c <= '9') ||
+			c == '-' || c == '_' || c == '.' || c == '~' {
+			b.WriteByte(c)
+		} else {
+			fmt.Fprintf(&b, "%%%02X", c)
+		}
+	}
+	return b.String()
+}
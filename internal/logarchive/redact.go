@@ -0,0 +1,120 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package logarchive
+
+import (
+	"bytes"
+	"fmt"
+	"regexp"
+)
+
+// Rule masks the matches of a regular expression.
+type Rule struct {
+	// Name identifies the rule in the default replacement
+	Name string
+
+	// Pattern is an RE2 regular expression
+	Pattern string
+
+	// Replacement replaces each match; $1 or ${name} expand capture groups.
+	// Defaults to [REDACTED:<Name>].
+	Replacement string
+
+	// keep lists prefixes of matches that are left alone
+	keep []string
+}
+
+// DefaultRules mask common credentials and email addresses. Rules apply in
+// order, so the specific token formats come before the generic patterns.
+var DefaultRules = []Rule{
+	{Name: "private-key", Pattern: `-----BEGIN [A-Z ]*PRIVATE KEY-----[\s\S]*?-----END [A-Z ]*PRIVATE KEY-----`},
+	{Name: "anthropic-key", Pattern: `sk-ant-[A-Za-z0-9_-]{20,}`},
+	{Name: "openai-key", Pattern: `\bsk-(?:proj-)?[A-Za-z0-9_-]{20,}`},
+	{Name: "github-token", Pattern: `\b(?:gh[pousr]_[A-Za-z0-9]{36,}|github_pat_[A-Za-z0-9_]{22,})`},
+	{Name: "gitlab-token", Pattern: `\bglpat-[A-Za-z0-9_-]{20,}`},
+	{Name: "slack-token", Pattern: `\bxox[abposr]-[A-Za-z0-9-]{10,}`},
+	{Name: "aws-access-key", Pattern: `\b(?:AKIA|ASIA)[A-Z0-9]{16}\b`},
+	{Name: "jwt", Pattern: `\beyJ[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}\.[A-Za-z0-9_-]{10,}`},
+	{
+		Name:        "bearer-token",
+		Pattern:     `(?i)(bearer\s+)[A-Za-z0-9._~+/=-]{16,}`,
+		Replacement: "${1}[REDACTED:bearer-token]",
+	},
+	{
+		Name:        "url-credentials",
+		Pattern:     `(://[^/\s:@]+:)[^/\s@]+@`,
+		Replacement: "${1}[REDACTED:url-credentials]@",
+	},
+	{
+		Name:        "secret",
+		Pattern:     `(?i)((?:password|passwd|secret|token|api[_-]?key)["']?\s*[:=]\s*["']?)[^\s"'\[][^\s"']{7,}`,
+		Replacement: "${1}[REDACTED:secret]",
+	},
+	{
+		Name:    "email",
+		Pattern: `[A-Za-z0-9._%+-]+@[A-Za-z0-9-]+(?:\.[A-Za-z0-9-]+)*\.[A-Za-z]{2,}`,
+		// The user of SSH remotes (git@github.com:org/repo.git)
+		keep: []string{"git@"},
+	},
+}
+
+// Redactor masks secrets in logs before they are stored.
+type Redactor struct {
+	rules []compiledRule
+}
+
+type compiledRule struct {
+	re          *regexp.Regexp
+	replacement []byte
+	keep        []string
+}
+
+// NewRedactor compiles the rules. The default rules are not included.
+func NewRedactor(rules []Rule) (*Redactor, error) {
+	r := &Redactor{rules: make([]compiledRule, 0, len(rules))}
+	for _, rule := range rules {
+		re, err := regexp.Compile(rule.Pattern)
+		if err != nil {
+			return nil, fmt.Errorf("redaction rule %s: %w", rule.Name, err)
+		}
+		replacement := rule.Replacement
+		if replacement == "" {
+			replacement = "[REDACTED:" + rule.Name + "]"
+		}
+		r.rules = append(r.rules, compiledRule{re: re, replacement: []byte(replacement), keep: rule.keep})
+	}
+	return r, nil
+}
+
+// Redact returns log with the matches of every rule replaced.
+func (r *Redactor) Redact(log []byte) []byte {
+	for _, rule := range r.rules {
+		if len(rule.keep) == 0 {
+			log = rule.re.ReplaceAll(log, rule.replacement)
+			continue
+		}
+		log = rule.re.ReplaceAllFunc(log, func(match []byte) []byte {
+			for _, prefix := range rule.keep {
+				if bytes.HasPrefix(match, []byte(prefix)) {
+					return match
+				}
+			}
+			return rule.re.Expand(nil, rule.replacement, match, rule.re.FindSubmatchIndex(match))
+		})
+	}
+	return log
+}
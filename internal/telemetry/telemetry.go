@@ -0,0 +1,351 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package telemetry exports the lifecycle of polecat Pods and the outcome of
+// merges as OpenTelemetry spans and metrics over OTLP.
+//
+// It replaces the telemetry sidecar of agent Pods: everything is observed by
+// the operator from the Kubernetes API, so agent Pods need no extra container
+// for it.
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlpmetric/otlpmetricgrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/metric"
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
+	"go.opentelemetry.io/otel/trace"
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// ServiceName is the OpenTelemetry service of the exported telemetry
+	ServiceName = "gastown-operator"
+
+	// instrumentationName scopes the tracer and meter
+	instrumentationName = "github.com/org/gastown-operator/internal/telemetry"
+
+	// shutdownTimeout bounds the final flush when the operator stops
+	shutdownTimeout = 10 * time.Second
+)
+
+// Phases of a polecat Pod
+const (
+	// PhaseScheduling lasts from the creation of the Pod until it is
+	// scheduled to a node
+	PhaseScheduling = "scheduling"
+
+	// PhaseInitializing lasts until the init containers (the git clone) are
+	// done
+	PhaseInitializing = "initializing"
+
+	// PhaseRunning lasts until the agent exits
+	PhaseRunning = "running"
+)
+
+// Merge outcomes
+const (
+	MergeSucceeded = "success"
+	MergeFailed    = "failure"
+)
+
+// Labels of polecat Pods carried over as attributes
+const (
+	polecatLabel = "gastown.io/polecat"
+	rigLabel     = "gastown.io/rig"
+	beadLabel    = "gastown.io/bead"
+	convoyLabel  = "gastown.io/convoy"
+)
+
+// Options configure the OTLP exporter
+type Options struct {
+	// Endpoint is the host:port of the OTLP gRPC receiver, e.g. an
+	// OpenTelemetry Collector at otel-collector.observability:4317
+	Endpoint string
+
+	// Insecure disables TLS to the receiver
+	Insecure bool
+
+	// MetricInterval is how often metrics are pushed (default 60s)
+	MetricInterval time.Duration
+}
+
+// Exporter exports polecat and merge telemetry. It is a manager Runnable
+// that flushes pending spans and metrics when the operator stops.
+type Exporter struct {
+	tracer trace.Tracer
+
+	phaseDuration metric.Float64Histogram
+	pods          metric.Int64Counter
+	merges        metric.Int64Counter
+	mergeDuration metric.Float64Histogram
+
+	shutdown func(context.Context) error
+}
+
+// New creates an Exporter pushing to an OTLP gRPC receiver. Headers and
+// other exporter settings can be set through the standard
+// OTEL_EXPORTER_OTLP_* environment variables.
+func New(ctx context.Context, opts Options) (*Exporter, error) {
+	traceOpts := []otlptracegrpc.Option{otlptracegrpc.WithEndpoint(opts.Endpoint)}
+	metricOpts := []otlpmetricgrpc.Option{otlpmetricgrpc.WithEndpoint(opts.Endpoint)}
+	if opts.Insecure {
+		traceOpts = append(traceOpts, otlptracegrpc.WithInsecure())
+		metricOpts = append(metricOpts, otlpmetricgrpc.WithInsecure())
+	}
+
+	spanExporter, err := otlptracegrpc.New(ctx, traceOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP trace exporter: %w", err)
+	}
+	metricExporter, err := otlpmetricgrpc.New(ctx, metricOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create OTLP metric exporter: %w", err)
+	}
+
+	interval := opts.MetricInterval
+	if interval <= 0 {
+		interval = time.Minute
+	}
+	res := resource.NewSchemaless(semconv.ServiceName(ServiceName))
+	tp := sdktrace.NewTracerProvider(sdktrace.WithBatcher(spanExporter), sdktrace.WithResource(res))
+	mp := sdkmetric.NewMeterProvider(
+		sdkmetric.WithReader(sdkmetric.NewPeriodicReader(metricExporter, sdkmetric.WithInterval(interval))),
+		sdkmetric.WithResource(res),
+	)
+
+	e, err := NewWithProviders(tp, mp)
+	if err != nil {
+		return nil, err
+	}
+	e.shutdown = func(ctx context.Context) error {
+		return errors.Join(tp.Shutdown(ctx), mp.Shutdown(ctx))
+	}
+	return e, nil
+}
+
+// NewWithProviders creates an Exporter recording to the given providers.
+func NewWithProviders(tp trace.TracerProvider, mp metric.MeterProvider) (*Exporter, error) {
+	meter := mp.Meter(instrumentationName)
+	e := &Exporter{tracer: tp.Tracer(instrumentationName)}
+
+	var err error
+	if e.phaseDuration, err = meter.Float64Histogram("gastown.polecat.phase.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of the phases of polecat Pods")); err != nil {
+		return nil, err
+	}
+	if e.pods, err = meter.Int64Counter("gastown.polecat.pods",
+		metric.WithDescription("Finished polecat Pods by result")); err != nil {
+		return nil, err
+	}
+	if e.merges, err = meter.Int64Counter("gastown.refinery.merges",
+		metric.WithDescription("Merges of polecat branches by outcome")); err != nil {
+		return nil, err
+	}
+	if e.mergeDuration, err = meter.Float64Histogram("gastown.refinery.merge.duration",
+		metric.WithUnit("s"),
+		metric.WithDescription("Duration of merges of polecat branches")); err != nil {
+		return nil, err
+	}
+	return e, nil
+}
+
+// Start blocks until the operator stops, then flushes pending telemetry.
+func (e *Exporter) Start(ctx context.Context) error {
+	<-ctx.Done()
+	if e.shutdown == nil {
+		return nil
+	}
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+	return e.shutdown(shutdownCtx)
+}
+
+// NeedLeaderElection reports that the exporter runs on every replica, so
+// that each flushes what it recorded.
+func (e *Exporter) NeedLeaderElection() bool {
+	return false
+}
+
+// Phase is a span of a polecat Pod's lifetime
+type Phase struct {
+	Name       string
+	Start, End time.Time
+}
+
+// PodPhases returns the phases a finished polecat Pod went through, from its
+// conditions and the termination of its agent container. Phases whose
+// boundaries are unknown are left out.
+func PodPhases(pod *corev1.Pod) []Phase {
+	bounds := []time.Time{
+		pod.CreationTimestamp.Time,
+		conditionTime(pod, corev1.PodScheduled),
+		conditionTime(pod, corev1.PodInitialized),
+		agentFinishedAt(pod),
+	}
+	names := []string{PhaseScheduling, PhaseInitializing, PhaseRunning}
+
+	var phases []Phase
+	for i, name := range names {
+		start, end := bounds[i], bounds[i+1]
+		if start.IsZero() || end.IsZero() || end.Before(start) {
+			continue
+		}
+		phases = append(phases, Phase{Name: name, Start: start, End: end})
+	}
+	return phases
+}
+
+// PodFinished exports a finished polecat Pod: a span over its lifetime with
+// a child span per phase and its Events as span events, and the phase
+// durations as metrics.
+func (e *Exporter) PodFinished(ctx context.Context, pod *corev1.Pod, events []corev1.Event) {
+	result := string(pod.Status.Phase)
+	attrs := podAttributes(pod)
+
+	start := pod.CreationTimestamp.Time
+	end := agentFinishedAt(pod)
+	if end.IsZero() || end.Before(start) {
+		end = time.Now()
+	}
+
+	ctx, span := e.tracer.Start(ctx, "polecat "+pod.Labels[polecatLabel],
+		trace.WithTimestamp(start),
+		trace.WithAttributes(attrs...),
+		trace.WithAttributes(attribute.String("gastown.pod.result", result)))
+	if agent := agentStatus(pod); agent != nil && agent.State.Terminated != nil {
+		span.SetAttributes(
+			attribute.Int("gastown.agent.exit_code", int(agent.State.Terminated.ExitCode)),
+			attribute.String("gastown.agent.reason", agent.State.Terminated.Reason),
+			attribute.Int("gastown.agent.restarts", int(agent.RestartCount)))
+	}
+	if pod.Status.Phase == corev1.PodFailed {
+		span.SetStatus(codes.Error, pod.Status.Reason)
+	}
+
+	for _, ev := range events {
+		span.AddEvent(ev.Reason, trace.WithTimestamp(eventTime(ev)), trace.WithAttributes(
+			attribute.String("k8s.event.type", ev.Type),
+			attribute.String("k8s.event.message", ev.Message),
+			attribute.Int("k8s.event.count", int(ev.Count))))
+	}
+
+	rig := attribute.String("gastown.rig", pod.Labels[rigLabel])
+	for _, phase := range PodPhases(pod) {
+		_, child := e.tracer.Start(ctx, phase.Name, trace.WithTimestamp(phase.Start))
+		child.End(trace.WithTimestamp(phase.End))
+		e.phaseDuration.Record(ctx, phase.End.Sub(phase.Start).Seconds(), metric.WithAttributes(
+			rig, attribute.String("gastown.phase", phase.Name), attribute.String("gastown.pod.result", result)))
+	}
+	e.pods.Add(ctx, 1, metric.WithAttributes(rig, attribute.String("gastown.pod.result", result)))
+
+	span.End(trace.WithTimestamp(end))
+}
+
+// MergeFinished exports the outcome of a merge of a polecat's branch that
+// started at the given time (zero when unknown).
+func (e *Exporter) MergeFinished(ctx context.Context, rig, polecat string, started time.Time, mergeErr error) {
+	outcome := MergeSucceeded
+	if mergeErr != nil {
+		outcome = MergeFailed
+	}
+	attrs := metric.WithAttributes(attribute.String("gastown.rig", rig), attribute.String("gastown.merge.outcome", outcome))
+	e.merges.Add(ctx, 1, attrs)
+	if started.IsZero() {
+		return
+	}
+
+	now := time.Now()
+	e.mergeDuration.Record(ctx, now.Sub(started).Seconds(), attrs)
+	_, span := e.tracer.Start(ctx, "merge "+polecat, trace.WithTimestamp(started), trace.WithAttributes(
+		attribute.String("gastown.rig", rig),
+		attribute.String("gastown.polecat", polecat),
+		attribute.String("gastown.merge.outcome", outcome)))
+	if mergeErr != nil {
+		span.SetStatus(codes.Error, mergeErr.Error())
+	}
+	span.End(trace.WithTimestamp(now))
+}
+
+// podAttributes identifies a polecat Pod
+func podAttributes(pod *corev1.Pod) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		semconv.K8SNamespaceName(pod.Namespace),
+		semconv.K8SPodName(pod.Name),
+		semconv.K8SPodUID(string(pod.UID)),
+		attribute.String("gastown.polecat", pod.Labels[polecatLabel]),
+		attribute.String("gastown.rig", pod.Labels[rigLabel]),
+		attribute.String("gastown.bead", pod.Labels[beadLabel]),
+	}
+	if convoy := pod.Labels[convoyLabel]; convoy != "" {
+		attrs = append(attrs, attribute.String("gastown.convoy", convoy))
+	}
+	return attrs
+}
+
+// conditionTime returns when the Pod condition last turned true, or zero.
+func conditionTime(pod *corev1.Pod, condType corev1.PodConditionType) time.Time {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == condType && c.Status == corev1.ConditionTrue {
+			return c.LastTransitionTime.Time
+		}
+	}
+	return time.Time{}
+}
+
+// agentStatus returns the status of the agent container, the Pod's first.
+func agentStatus(pod *corev1.Pod) *corev1.ContainerStatus {
+	if len(pod.Spec.Containers) == 0 {
+		return nil
+	}
+	for i := range pod.Status.ContainerStatuses {
+		if pod.Status.ContainerStatuses[i].Name == pod.Spec.Containers[0].Name {
+			return &pod.Status.ContainerStatuses[i]
+		}
+	}
+	return nil
+}
+
+// agentFinishedAt returns when the agent container exited, or zero.
+func agentFinishedAt(pod *corev1.Pod) time.Time {
+	if agent := agentStatus(pod); agent != nil && agent.State.Terminated != nil {
+		return agent.State.Terminated.FinishedAt.Time
+	}
+	return time.Time{}
+}
+
+// eventTime returns when an Event last happened.
+func eventTime(ev corev1.Event) time.Time {
+	switch {
+	case !ev.LastTimestamp.IsZero():
+		return ev.LastTimestamp.Time
+	case !ev.EventTime.IsZero():
+		return ev.EventTime.Time
+	default:
+		return ev.FirstTimestamp.Time
+	}
+}
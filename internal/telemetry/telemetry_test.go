@@ -0,0 +1,185 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package telemetry
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	sdkmetric "go.opentelemetry.io/otel/sdk/metric"
+	"go.opentelemetry.io/otel/sdk/metric/metricdata"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newTestExporter(t *testing.T) (*Exporter, *tracetest.SpanRecorder, *sdkmetric.ManualReader) {
+	t.Helper()
+	spans := tracetest.NewSpanRecorder()
+	reader := sdkmetric.NewManualReader()
+	e, err := NewWithProviders(
+		sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(spans)),
+		sdkmetric.NewMeterProvider(sdkmetric.WithReader(reader)))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	return e, spans, reader
+}
+
+// finishedPod is a polecat Pod created at t0 whose agent exited at t0+10m
+func finishedPod(t0 time.Time, phase corev1.PodPhase, exitCode int32) *corev1.Pod {
+	at := func(d time.Duration) metav1.Time { return metav1.NewTime(t0.Add(d)) }
+	return &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "polecat-furiosa",
+			Namespace:         "default",
+			UID:               "uid-1",
+			CreationTimestamp: at(0),
+			Labels: map[string]string{
+				polecatLabel: "furiosa",
+				rigLabel:     "my-rig",
+				beadLabel:    "gt-1",
+			},
+		},
+		Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "claude"}, {Name: "telemetry"}}},
+		Status: corev1.PodStatus{
+			Phase: phase,
+			Conditions: []corev1.PodCondition{
+				{Type: corev1.PodScheduled, Status: corev1.ConditionTrue, LastTransitionTime: at(5 * time.Second)},
+				{Type: corev1.PodInitialized, Status: corev1.ConditionTrue, LastTransitionTime: at(time.Minute)},
+			},
+			ContainerStatuses: []corev1.ContainerStatus{{
+				Name: "claude",
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+					ExitCode:   exitCode,
+					Reason:     "Completed",
+					FinishedAt: at(10 * time.Minute),
+				}},
+			}},
+		},
+	}
+}
+
+func TestPodPhases(t *testing.T) {
+	t0 := time.Date(2026, 1, 1, 12, 0, 0, 0, time.UTC)
+	phases := PodPhases(finishedPod(t0, corev1.PodSucceeded, 0))
+
+	want := []struct {
+		name     string
+		duration time.Duration
+	}{
+		{PhaseScheduling, 5 * time.Second},
+		{PhaseInitializing, 55 * time.Second},
+		{PhaseRunning, 9 * time.Minute},
+	}
+	if len(phases) != len(want) {
+		t.Fatalf("expected %d phases, got %+v", len(want), phases)
+	}
+	for i, w := range want {
+		if phases[i].Name != w.name || phases[i].End.Sub(phases[i].Start) != w.duration {
+			t.Errorf("expected %s of %s, got %s of %s", w.name, w.duration, phases[i].Name, phases[i].End.Sub(phases[i].Start))
+		}
+	}
+
+	// A Pod that never got initialized has no initializing or running phase
+	pod := finishedPod(t0, corev1.PodFailed, 1)
+	pod.Status.Conditions = pod.Status.Conditions[:1]
+	if phases := PodPhases(pod); len(phases) != 1 || phases[0].Name != PhaseScheduling {
+		t.Errorf("expected only the scheduling phase, got %+v", phases)
+	}
+}
+
+func TestPodFinished(t *testing.T) {
+	e, spans, reader := newTestExporter(t)
+	t0 := time.Now().Add(-time.Hour)
+	events := []corev1.Event{{
+		Reason:        "Pulled",
+		Type:          corev1.EventTypeNormal,
+		Message:       "Successfully pulled image",
+		LastTimestamp: metav1.NewTime(t0.Add(30 * time.Second)),
+	}}
+
+	e.PodFinished(context.Background(), finishedPod(t0, corev1.PodFailed, 2), events)
+
+	ended := spans.Ended()
+	if len(ended) != 4 {
+		t.Fatalf("expected a pod span and 3 phase spans, got %d", len(ended))
+	}
+	root := ended[len(ended)-1]
+	if root.Name() != "polecat furiosa" || root.Status().Code.String() != "Error" {
+		t.Errorf("unexpected pod span %s (%s)", root.Name(), root.Status().Code)
+	}
+	if !root.StartTime().Equal(t0) || !root.EndTime().Equal(t0.Add(10*time.Minute)) {
+		t.Errorf("expected the span to cover the pod's lifetime, got %s - %s", root.StartTime(), root.EndTime())
+	}
+	if evs := root.Events(); len(evs) != 1 || evs[0].Name != "Pulled" {
+		t.Errorf("expected the Pulled event, got %+v", evs)
+	}
+	for _, child := range ended[:3] {
+		if child.Parent().SpanID() != root.SpanContext().SpanID() {
+			t.Errorf("expected phase span %s under the pod span", child.Name())
+		}
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	found := map[string]bool{}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			found[m.Name] = true
+		}
+	}
+	for _, name := range []string{"gastown.polecat.phase.duration", "gastown.polecat.pods"} {
+		if !found[name] {
+			t.Errorf("expected metric %s, got %v", name, found)
+		}
+	}
+}
+
+func TestMergeFinished(t *testing.T) {
+	e, spans, reader := newTestExporter(t)
+
+	e.MergeFinished(context.Background(), "my-rig", "furiosa", time.Now().Add(-time.Minute), errors.New("conflict"))
+	e.MergeFinished(context.Background(), "my-rig", "nux", time.Time{}, nil)
+
+	if ended := spans.Ended(); len(ended) != 1 || ended[0].Name() != "merge furiosa" {
+		t.Fatalf("expected one merge span, got %d", len(ended))
+	}
+
+	var rm metricdata.ResourceMetrics
+	if err := reader.Collect(context.Background(), &rm); err != nil {
+		t.Fatal(err)
+	}
+	for _, sm := range rm.ScopeMetrics {
+		for _, m := range sm.Metrics {
+			if m.Name != "gastown.refinery.merges" {
+				continue
+			}
+			sum := m.Data.(metricdata.Sum[int64])
+			if len(sum.DataPoints) != 2 {
+				t.Errorf("expected a success and a failure data point, got %d", len(sum.DataPoints))
+			}
+			return
+		}
+	}
+	t.Error("expected the gastown.refinery.merges metric")
+}
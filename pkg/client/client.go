@@ -51,6 +51,10 @@ var (
 	}
 )
 
+// FieldManager is the field manager recorded for writes made through this
+// client, distinguishing them from the operator's controllers.
+const FieldManager = "gastown-client"
+
 // Client provides typed access to Gas Town CRDs
 type Client struct {
 	dynamic dynamic.Interface
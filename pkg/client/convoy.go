@@ -72,7 +72,7 @@ func (c *convoyClient) Create(ctx context.Context, convoy *gastownv1alpha1.Convo
 	if err != nil {
 		return nil, err
 	}
-	created, err := c.client.Create(ctx, unstr, metav1.CreateOptions{})
+	created, err := c.client.Create(ctx, unstr, metav1.CreateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
@@ -84,7 +84,7 @@ func (c *convoyClient) Update(ctx context.Context, convoy *gastownv1alpha1.Convo
 	if err != nil {
 		return nil, err
 	}
-	updated, err := c.client.Update(ctx, unstr, metav1.UpdateOptions{})
+	updated, err := c.client.Update(ctx, unstr, metav1.UpdateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
@@ -98,7 +98,7 @@ func (c *convoyClient) UpdateStatus(
 	if err != nil {
 		return nil, err
 	}
-	updated, err := c.client.UpdateStatus(ctx, unstr, metav1.UpdateOptions{})
+	updated, err := c.client.UpdateStatus(ctx, unstr, metav1.UpdateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
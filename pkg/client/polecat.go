@@ -74,7 +74,7 @@ func (c *polecatClient) Create(
 	if err != nil {
 		return nil, err
 	}
-	created, err := c.client.Create(ctx, unstr, metav1.CreateOptions{})
+	created, err := c.client.Create(ctx, unstr, metav1.CreateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
@@ -88,7 +88,7 @@ func (c *polecatClient) Update(
 	if err != nil {
 		return nil, err
 	}
-	updated, err := c.client.Update(ctx, unstr, metav1.UpdateOptions{})
+	updated, err := c.client.Update(ctx, unstr, metav1.UpdateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
@@ -102,7 +102,7 @@ func (c *polecatClient) UpdateStatus(
 	if err != nil {
 		return nil, err
 	}
-	updated, err := c.client.UpdateStatus(ctx, unstr, metav1.UpdateOptions{})
+	updated, err := c.client.UpdateStatus(ctx, unstr, metav1.UpdateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
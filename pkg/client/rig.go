@@ -71,7 +71,7 @@ func (c *rigClient) Create(ctx context.Context, rig *gastownv1alpha1.Rig) (*gast
 	if err != nil {
 		return nil, err
 	}
-	created, err := c.client.Create(ctx, unstr, metav1.CreateOptions{})
+	created, err := c.client.Create(ctx, unstr, metav1.CreateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
@@ -83,7 +83,7 @@ func (c *rigClient) Update(ctx context.Context, rig *gastownv1alpha1.Rig) (*gast
 	if err != nil {
 		return nil, err
 	}
-	updated, err := c.client.Update(ctx, unstr, metav1.UpdateOptions{})
+	updated, err := c.client.Update(ctx, unstr, metav1.UpdateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +95,7 @@ func (c *rigClient) UpdateStatus(ctx context.Context, rig *gastownv1alpha1.Rig)
 	if err != nil {
 		return nil, err
 	}
-	updated, err := c.client.UpdateStatus(ctx, unstr, metav1.UpdateOptions{})
+	updated, err := c.client.UpdateStatus(ctx, unstr, metav1.UpdateOptions{FieldManager: FieldManager})
 	if err != nil {
 		return nil, err
 	}
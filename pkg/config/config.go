@@ -0,0 +1,273 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package config holds the operator-wide configuration of the GastownConfig
+// resource. The GastownConfig controller replaces it whenever the resource
+// changes; readers call Current on every use so changes apply without a
+// restart.
+package config
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/cost"
+)
+
+// Feature gates known to the operator
+const (
+	// FeaturePolecatTTLCleanup deletes finished polecats after their
+	// spec.ttlSecondsAfterFinished (default on)
+	FeaturePolecatTTLCleanup = "PolecatTTLCleanup"
+
+	// FeatureAgentImageProbe verifies custom agent images before starting
+	// work in them (default on)
+	FeatureAgentImageProbe = "AgentImageProbe"
+
+	// FeatureTelemetrySidecar adds the deprecated telemetry sidecar, which
+	// reports token usage, to agent Pods (default on)
+	FeatureTelemetrySidecar = "TelemetrySidecar"
+)
+
+// featureDefaults are the states of the known gates when not configured
+var featureDefaults = map[string]bool{
+	FeaturePolecatTTLCleanup: true,
+	FeatureAgentImageProbe:   true,
+	FeatureTelemetrySidecar:  true,
+}
+
+// Config is a snapshot of the operator-wide configuration. The zero value
+// leaves every setting to its environment variable or built-in default.
+type Config struct {
+	// ChildNamespace is the default namespace of rig children
+	ChildNamespace string
+
+	// Images are the default agent Pod images by container
+	GitImage, ClaudeImage, OpenCodeImage, AiderImage, TelemetryImage string
+
+	// ImageMirrors map image prefixes to the registry mirrors replacing them
+	ImageMirrors map[string]string
+
+	// RequeueShort, RequeueDefault and RequeueLong override the controllers'
+	// requeue intervals when non-zero
+	RequeueShort, RequeueDefault, RequeueLong time.Duration
+
+	// AgentResources are the default resources of agent containers
+	AgentResources *corev1.ResourceRequirements
+
+	// ModelPrices override the built-in model prices of cost estimates
+	ModelPrices map[string]cost.Price
+
+	// FeatureGates are the configured feature gates
+	FeatureGates map[string]bool
+
+	// MaxActivePolecats caps the polecats working at once across all rigs;
+	// 0 is unlimited
+	MaxActivePolecats int32
+
+	// RigWeights are the shares of MaxActivePolecats by rig name
+	RigWeights map[string]int32
+
+	// CleanupTimeout bounds the cleanup of deleted polecats when non-zero
+	CleanupTimeout time.Duration
+
+	// CleanupOnTimeout is the action once CleanupTimeout expired, Force
+	// when empty
+	CleanupOnTimeout string
+
+	// Controllers tune the workqueues of controllers by name. Read when the
+	// operator starts.
+	Controllers map[string]ControllerTuning
+
+	// CacheSyncPeriod is the resync period of the informer caches when
+	// non-zero. Read when the operator starts.
+	CacheSyncPeriod time.Duration
+}
+
+var current atomic.Pointer[Config]
+
+// Current returns the configuration in effect. It is never nil.
+func Current() *Config {
+	if c := current.Load(); c != nil {
+		return c
+	}
+	return &Config{}
+}
+
+// Set replaces the configuration in effect; nil restores the defaults.
+func Set(c *Config) {
+	current.Store(c)
+}
+
+// FromSpec converts a GastownConfig spec into a Config.
+func FromSpec(spec *gastownv1alpha1.GastownConfigSpec) *Config {
+	c := &Config{
+		ChildNamespace: spec.ChildNamespace,
+		GitImage:       spec.Images.Git,
+		ClaudeImage:    spec.Images.Claude,
+		OpenCodeImage:  spec.Images.OpenCode,
+		AiderImage:     spec.Images.Aider,
+		TelemetryImage: spec.Images.Telemetry,
+		FeatureGates:   spec.FeatureGates,
+
+		MaxActivePolecats: spec.Scheduling.MaxActivePolecats,
+		RigWeights:        spec.Scheduling.RigWeights,
+
+		CleanupOnTimeout: spec.PolecatCleanup.OnTimeout,
+	}
+	if d := spec.Requeue.Short; d != nil {
+		c.RequeueShort = d.Duration
+	}
+	if d := spec.Requeue.Default; d != nil {
+		c.RequeueDefault = d.Duration
+	}
+	if d := spec.Requeue.Long; d != nil {
+		c.RequeueLong = d.Duration
+	}
+	if d := spec.PolecatCleanup.Timeout; d != nil {
+		c.CleanupTimeout = d.Duration
+	}
+	if d := spec.CacheSyncPeriod; d != nil {
+		c.CacheSyncPeriod = d.Duration
+	}
+	for name, tuning := range spec.Controllers {
+		t := ControllerTuning{
+			MaxConcurrentReconciles: int(tuning.MaxConcurrentReconciles),
+			RateLimiterQPS:          int(tuning.RateLimiterQPS),
+			RateLimiterBurst:        int(tuning.RateLimiterBurst),
+		}
+		if d := tuning.RateLimiterBaseDelay; d != nil {
+			t.RateLimiterBaseDelay = d.Duration
+		}
+		if d := tuning.RateLimiterMaxDelay; d != nil {
+			t.RateLimiterMaxDelay = d.Duration
+		}
+		if c.Controllers == nil {
+			c.Controllers = map[string]ControllerTuning{}
+		}
+		c.Controllers[name] = t
+	}
+	if spec.AgentResources != nil {
+		c.AgentResources = spec.AgentResources.DeepCopy()
+	}
+	for _, mirror := range spec.ImageMirrors {
+		if c.ImageMirrors == nil {
+			c.ImageMirrors = map[string]string{}
+		}
+		c.ImageMirrors[mirror.Prefix] = mirror.Mirror
+	}
+	for model, price := range spec.ModelPricing {
+		// Both are validated as decimals by the CRD
+		input, inErr := strconv.ParseFloat(price.Input, 64)
+		output, outErr := strconv.ParseFloat(price.Output, 64)
+		if inErr != nil || outErr != nil {
+			continue
+		}
+		if c.ModelPrices == nil {
+			c.ModelPrices = map[string]cost.Price{}
+		}
+		c.ModelPrices[model] = cost.Price{Input: input, Output: output}
+	}
+	return c
+}
+
+// Enabled reports whether the named feature gate is on.
+func (c *Config) Enabled(feature string) bool {
+	if enabled, ok := c.FeatureGates[feature]; ok {
+		return enabled
+	}
+	return featureDefaults[feature]
+}
+
+// MirrorImage rewrites image through the ImageMirrors entry with the longest
+// matching prefix, returning it unchanged when none matches. Prefixes match
+// whole path components, and images without a registry also match as their
+// docker.io name.
+func (c *Config) MirrorImage(image string) string {
+	refs := []string{image}
+	if qualified := qualifiedImage(image); qualified != image {
+		refs = append(refs, qualified)
+	}
+
+	var best, matched string
+	for _, ref := range refs {
+		for prefix := range c.ImageMirrors {
+			if len(prefix) > len(best) && hasImagePrefix(ref, prefix) {
+				best, matched = prefix, ref
+			}
+		}
+	}
+	if best == "" {
+		return image
+	}
+	return c.ImageMirrors[best] + matched[len(best):]
+}
+
+// hasImagePrefix reports whether prefix is the registry, or a leading
+// repository path, of ref.
+func hasImagePrefix(ref, prefix string) bool {
+	rest, ok := strings.CutPrefix(ref, prefix)
+	if !ok {
+		return false
+	}
+	if rest == "" || rest[0] == '/' {
+		return true
+	}
+	// A tag or digest may follow a repository, but not a bare registry,
+	// where a colon starts the port
+	return strings.Contains(prefix, "/") && (rest[0] == ':' || rest[0] == '@')
+}
+
+// qualifiedImage returns the docker.io name of an image without a registry,
+// e.g. docker.io/library/alpine for alpine, and other images unchanged.
+func qualifiedImage(image string) string {
+	first, _, hasPath := strings.Cut(image, "/")
+	if hasPath && (strings.ContainsAny(first, ".:") || first == "localhost") {
+		return image
+	}
+	if !hasPath {
+		return "docker.io/library/" + image
+	}
+	return "docker.io/" + image
+}
+
+// RigWeight returns the capacity share of the named rig, 1 when not
+// configured or not positive.
+func (c *Config) RigWeight(rig string) int32 {
+	if w := c.RigWeights[rig]; w > 0 {
+		return w
+	}
+	return 1
+}
+
+// UnknownFeatureGates returns the configured gates the operator does not
+// know, sorted.
+func (c *Config) UnknownFeatureGates() []string {
+	var unknown []string
+	for name := range c.FeatureGates {
+		if _, ok := featureDefaults[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
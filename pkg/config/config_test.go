@@ -0,0 +1,154 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/cost"
+)
+
+func TestCurrent(t *testing.T) {
+	Set(nil)
+	if c := Current(); c == nil || c.ChildNamespace != "" {
+		t.Fatalf("expected empty default config, got %+v", c)
+	}
+
+	Set(&Config{ChildNamespace: "gastown"})
+	defer Set(nil)
+	if got := Current().ChildNamespace; got != "gastown" {
+		t.Errorf("expected gastown, got %q", got)
+	}
+}
+
+func TestFromSpec(t *testing.T) {
+	spec := &gastownv1alpha1.GastownConfigSpec{
+		ChildNamespace: "agents",
+		Images:         gastownv1alpha1.ImageDefaults{Claude: "registry.example.com/agent:v1"},
+		Requeue: gastownv1alpha1.RequeueIntervals{
+			Default: &metav1.Duration{Duration: time.Minute},
+		},
+		ModelPricing: map[string]gastownv1alpha1.ModelPrice{
+			"devstral": {Input: "0.10", Output: "0.30"},
+		},
+		ImageMirrors: []gastownv1alpha1.ImageMirror{{Prefix: "ghcr.io", Mirror: "mirror.internal/ghcr"}},
+	}
+
+	c := FromSpec(spec)
+	if c.ChildNamespace != "agents" {
+		t.Errorf("expected childNamespace agents, got %q", c.ChildNamespace)
+	}
+	if c.ClaudeImage != "registry.example.com/agent:v1" {
+		t.Errorf("expected claude image override, got %q", c.ClaudeImage)
+	}
+	if c.GitImage != "" {
+		t.Errorf("expected no git image, got %q", c.GitImage)
+	}
+	if c.RequeueDefault != time.Minute || c.RequeueShort != 0 || c.RequeueLong != 0 {
+		t.Errorf("unexpected requeue intervals %v/%v/%v", c.RequeueShort, c.RequeueDefault, c.RequeueLong)
+	}
+	if got := c.ModelPrices["devstral"]; got != (cost.Price{Input: 0.1, Output: 0.3}) {
+		t.Errorf("unexpected devstral price %+v", got)
+	}
+	if got := c.ImageMirrors["ghcr.io"]; got != "mirror.internal/ghcr" {
+		t.Errorf("expected the ghcr.io mirror, got %q", got)
+	}
+}
+
+func TestMirrorImage(t *testing.T) {
+	c := &Config{ImageMirrors: map[string]string{
+		"ghcr.io":                      "mirror.internal/ghcr",
+		"ghcr.io/boshu2/polecat-agent": "mirror.internal/agents/polecat",
+		"docker.io":                    "mirror.internal/dockerhub",
+		"registry.example.com:5000":    "mirror.internal/example",
+	}}
+	tests := []struct {
+		image, want string
+	}{
+		{"ghcr.io/boshu2/git:1.0", "mirror.internal/ghcr/boshu2/git:1.0"},
+		{"ghcr.io/boshu2/polecat-agent:0.4.0", "mirror.internal/agents/polecat:0.4.0"},
+		{"ghcr.io/boshu2/polecat-agent@sha256:abc", "mirror.internal/agents/polecat@sha256:abc"},
+		{"ghcr.io/boshu2/polecat-agent-extra:1", "mirror.internal/ghcr/boshu2/polecat-agent-extra:1"},
+		{"ghcr.iox/boshu2/git:1.0", "ghcr.iox/boshu2/git:1.0"},
+		{"alpine:3.20", "mirror.internal/dockerhub/library/alpine:3.20"},
+		{"bitnami/kubectl", "mirror.internal/dockerhub/bitnami/kubectl"},
+		{"docker.io/library/busybox", "mirror.internal/dockerhub/library/busybox"},
+		{"registry.example.com:5000/tools/gt:2", "mirror.internal/example/tools/gt:2"},
+		{"localhost:5000/tools/gt:2", "localhost:5000/tools/gt:2"},
+		{"quay.io/prometheus/node-exporter", "quay.io/prometheus/node-exporter"},
+	}
+	for _, tt := range tests {
+		if got := c.MirrorImage(tt.image); got != tt.want {
+			t.Errorf("MirrorImage(%q) = %q, want %q", tt.image, got, tt.want)
+		}
+	}
+
+	if got := (&Config{}).MirrorImage("alpine"); got != "alpine" {
+		t.Errorf("expected no rewrite without mirrors, got %q", got)
+	}
+}
+
+func TestEnabled(t *testing.T) {
+	c := &Config{}
+	if !c.Enabled(FeaturePolecatTTLCleanup) || !c.Enabled(FeatureAgentImageProbe) {
+		t.Error("expected known gates to default to enabled")
+	}
+	if c.Enabled("Unknown") {
+		t.Error("expected unknown gate to be disabled")
+	}
+
+	c = &Config{FeatureGates: map[string]bool{FeatureAgentImageProbe: false}}
+	if c.Enabled(FeatureAgentImageProbe) {
+		t.Error("expected AgentImageProbe to be disabled")
+	}
+	if !c.Enabled(FeaturePolecatTTLCleanup) {
+		t.Error("expected PolecatTTLCleanup to keep its default")
+	}
+}
+
+func TestUnknownFeatureGates(t *testing.T) {
+	c := &Config{FeatureGates: map[string]bool{
+		"Zeta":                   true,
+		FeaturePolecatTTLCleanup: false,
+		"Alpha":                  false,
+	}}
+	if got := c.UnknownFeatureGates(); !slices.Equal(got, []string{"Alpha", "Zeta"}) {
+		t.Errorf("expected [Alpha Zeta], got %v", got)
+	}
+}
+
+func TestRigWeight(t *testing.T) {
+	c := FromSpec(&gastownv1alpha1.GastownConfigSpec{
+		Scheduling: gastownv1alpha1.PolecatScheduling{
+			MaxActivePolecats: 10,
+			RigWeights:        map[string]int32{"widgets": 3, "broken": 0},
+		},
+	})
+	if c.MaxActivePolecats != 10 {
+		t.Errorf("expected 10 active polecats, got %d", c.MaxActivePolecats)
+	}
+	for rig, want := range map[string]int32{"widgets": 3, "broken": 1, "other": 1} {
+		if got := c.RigWeight(rig); got != want {
+			t.Errorf("expected weight %d for %s, got %d", want, rig, got)
+		}
+	}
+}
@@ -0,0 +1,192 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Controllers whose workqueues can be tuned
+const (
+	ControllerPolecat          = "polecat"
+	ControllerRig              = "rig"
+	ControllerRefinery         = "refinery"
+	ControllerWitness          = "witness"
+	ControllerConvoy           = "convoy"
+	ControllerBeadStore        = "beadstore"
+	ControllerPolecatTelemetry = "polecat-telemetry"
+)
+
+// tunableControllers are the controllers ControllerTuning applies to
+var tunableControllers = []string{
+	ControllerPolecat, ControllerRig, ControllerRefinery, ControllerWitness,
+	ControllerConvoy, ControllerBeadStore, ControllerPolecatTelemetry,
+}
+
+// ControllerTuning tunes the workqueue of a controller. Zero fields keep the
+// controller's built-in values.
+type ControllerTuning struct {
+	// MaxConcurrentReconciles is how many resources are reconciled at once
+	MaxConcurrentReconciles int
+
+	// RateLimiterBaseDelay and RateLimiterMaxDelay bound the exponential
+	// retry delay of failing resources
+	RateLimiterBaseDelay, RateLimiterMaxDelay time.Duration
+
+	// RateLimiterQPS and RateLimiterBurst are the refill rate and size of the
+	// token bucket limiting the controller's retries across all resources
+	RateLimiterQPS, RateLimiterBurst int
+}
+
+// override returns t with the non-zero fields of o.
+func (t ControllerTuning) override(o ControllerTuning) ControllerTuning {
+	if o.MaxConcurrentReconciles > 0 {
+		t.MaxConcurrentReconciles = o.MaxConcurrentReconciles
+	}
+	if o.RateLimiterBaseDelay > 0 {
+		t.RateLimiterBaseDelay = o.RateLimiterBaseDelay
+	}
+	if o.RateLimiterMaxDelay > 0 {
+		t.RateLimiterMaxDelay = o.RateLimiterMaxDelay
+	}
+	if o.RateLimiterQPS > 0 {
+		t.RateLimiterQPS = o.RateLimiterQPS
+	}
+	if o.RateLimiterBurst > 0 {
+		t.RateLimiterBurst = o.RateLimiterBurst
+	}
+	return t
+}
+
+// UnknownControllers returns the tuned controllers the operator does not
+// know, sorted.
+func (c *Config) UnknownControllers() []string {
+	var unknown []string
+	for name := range c.Controllers {
+		if !slices.Contains(tunableControllers, name) {
+			unknown = append(unknown, name)
+		}
+	}
+	slices.Sort(unknown)
+	return unknown
+}
+
+// TuningFlags tune the controllers from the command line. They take
+// precedence over the GastownConfig the operator started with.
+type TuningFlags struct {
+	MaxConcurrentReconciles                   map[string]int
+	RateLimiterBaseDelay, RateLimiterMaxDelay map[string]time.Duration
+	RateLimiterQPS, RateLimiterBurst          map[string]int
+	CacheSyncPeriod                           time.Duration
+}
+
+// BindFlags registers the tuning flags in fs.
+func (f *TuningFlags) BindFlags(fs *flag.FlagSet) {
+	f.MaxConcurrentReconciles = map[string]int{}
+	f.RateLimiterBaseDelay = map[string]time.Duration{}
+	f.RateLimiterMaxDelay = map[string]time.Duration{}
+	f.RateLimiterQPS = map[string]int{}
+	f.RateLimiterBurst = map[string]int{}
+	fs.Var(tuningFlag[int]{f.MaxConcurrentReconciles, parsePositive},
+		"max-concurrent-reconciles",
+		"Resources reconciled at once by controller, e.g. polecat=20,refinery=4. Controllers: "+
+			strings.Join(tunableControllers, ", "))
+	fs.Var(tuningFlag[time.Duration]{f.RateLimiterBaseDelay, time.ParseDuration},
+		"rate-limiter-base-delay",
+		"First retry delay of failing resources by controller, e.g. polecat=100ms (default 5ms)")
+	fs.Var(tuningFlag[time.Duration]{f.RateLimiterMaxDelay, time.ParseDuration},
+		"rate-limiter-max-delay",
+		"Maximum retry delay of failing resources by controller, e.g. polecat=5m (default 1000s)")
+	fs.Var(tuningFlag[int]{f.RateLimiterQPS, parsePositive},
+		"rate-limiter-qps",
+		"Retries per second across all resources by controller, e.g. polecat=50 (default 10)")
+	fs.Var(tuningFlag[int]{f.RateLimiterBurst, parsePositive},
+		"rate-limiter-burst",
+		"Retries made at once before the rate applies by controller, e.g. polecat=500 (default 100)")
+	fs.DurationVar(&f.CacheSyncPeriod, "cache-sync-period", 0,
+		"How often the informer caches resync every resource (default 10h)")
+}
+
+// Tuning returns the controller tunings of c overridden by the flags, and
+// the cache sync period; zero keeps the default.
+func (f *TuningFlags) Tuning(c *Config) (map[string]ControllerTuning, time.Duration) {
+	tuning := map[string]ControllerTuning{}
+	for _, name := range tunableControllers {
+		t := c.Controllers[name].override(ControllerTuning{
+			MaxConcurrentReconciles: f.MaxConcurrentReconciles[name],
+			RateLimiterBaseDelay:    f.RateLimiterBaseDelay[name],
+			RateLimiterMaxDelay:     f.RateLimiterMaxDelay[name],
+			RateLimiterQPS:          f.RateLimiterQPS[name],
+			RateLimiterBurst:        f.RateLimiterBurst[name],
+		})
+		if t != (ControllerTuning{}) {
+			tuning[name] = t
+		}
+	}
+	syncPeriod := c.CacheSyncPeriod
+	if f.CacheSyncPeriod > 0 {
+		syncPeriod = f.CacheSyncPeriod
+	}
+	return tuning, syncPeriod
+}
+
+// tuningFlag is a flag of comma-separated controller=value pairs
+type tuningFlag[T any] struct {
+	values map[string]T
+	parse  func(string) (T, error)
+}
+
+func (f tuningFlag[T]) String() string {
+	var pairs []string
+	for name, value := range f.values {
+		pairs = append(pairs, fmt.Sprintf("%s=%v", name, value))
+	}
+	slices.Sort(pairs)
+	return strings.Join(pairs, ",")
+}
+
+func (f tuningFlag[T]) Set(s string) error {
+	for _, pair := range strings.Split(s, ",") {
+		name, raw, ok := strings.Cut(strings.TrimSpace(pair), "=")
+		if !ok {
+			return fmt.Errorf("expected controller=value, got %q", pair)
+		}
+		if !slices.Contains(tunableControllers, name) {
+			return fmt.Errorf("unknown controller %q", name)
+		}
+		value, err := f.parse(raw)
+		if err != nil {
+			return fmt.Errorf("controller %s: %w", name, err)
+		}
+		f.values[name] = value
+	}
+	return nil
+}
+
+// parsePositive parses a positive integer.
+func parsePositive(s string) (int, error) {
+	n, err := strconv.Atoi(s)
+	if err == nil && n < 1 {
+		err = fmt.Errorf("%d is not positive", n)
+	}
+	return n, err
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"io"
+	"slices"
+	"testing"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func TestTuningFromSpec(t *testing.T) {
+	c := FromSpec(&gastownv1alpha1.GastownConfigSpec{
+		CacheSyncPeriod: &metav1.Duration{Duration: time.Hour},
+		Controllers: map[string]gastownv1alpha1.ControllerTuning{
+			ControllerPolecat: {
+				MaxConcurrentReconciles: 20,
+				RateLimiterMaxDelay:     &metav1.Duration{Duration: 5 * time.Minute},
+				RateLimiterBurst:        500,
+			},
+			"bogus": {MaxConcurrentReconciles: 1},
+		},
+	})
+	if c.CacheSyncPeriod != time.Hour {
+		t.Errorf("expected a cache sync period of 1h, got %s", c.CacheSyncPeriod)
+	}
+	want := ControllerTuning{MaxConcurrentReconciles: 20, RateLimiterMaxDelay: 5 * time.Minute, RateLimiterBurst: 500}
+	if got := c.Controllers[ControllerPolecat]; got != want {
+		t.Errorf("expected polecat tuning %+v, got %+v", want, got)
+	}
+	if got := c.UnknownControllers(); !slices.Equal(got, []string{"bogus"}) {
+		t.Errorf("expected [bogus], got %v", got)
+	}
+}
+
+func TestTuningFlags(t *testing.T) {
+	parse := func(args ...string) (*TuningFlags, error) {
+		var f TuningFlags
+		fs := flag.NewFlagSet("test", flag.ContinueOnError)
+		fs.SetOutput(io.Discard)
+		f.BindFlags(fs)
+		return &f, fs.Parse(args)
+	}
+
+	f, err := parse(
+		"--max-concurrent-reconciles=polecat=50,refinery=4",
+		"--rate-limiter-base-delay=polecat=100ms",
+		"--rate-limiter-qps=polecat=50,rig=1",
+		"--cache-sync-period=2h")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	c := &Config{
+		CacheSyncPeriod: time.Hour,
+		Controllers: map[string]ControllerTuning{
+			ControllerPolecat: {MaxConcurrentReconciles: 20, RateLimiterMaxDelay: time.Minute},
+			ControllerRig:     {MaxConcurrentReconciles: 6},
+		},
+	}
+	tuning, syncPeriod := f.Tuning(c)
+	if syncPeriod != 2*time.Hour {
+		t.Errorf("expected the flag's cache sync period, got %s", syncPeriod)
+	}
+	want := map[string]ControllerTuning{
+		ControllerPolecat: {
+			MaxConcurrentReconciles: 50,
+			RateLimiterBaseDelay:    100 * time.Millisecond,
+			RateLimiterMaxDelay:     time.Minute,
+			RateLimiterQPS:          50,
+		},
+		ControllerRefinery: {MaxConcurrentReconciles: 4},
+		ControllerRig:      {MaxConcurrentReconciles: 6, RateLimiterQPS: 1},
+	}
+	if len(tuning) != len(want) {
+		t.Errorf("expected %d tuned controllers, got %+v", len(want), tuning)
+	}
+	for name, w := range want {
+		if tuning[name] != w {
+			t.Errorf("expected %s tuning %+v, got %+v", name, w, tuning[name])
+		}
+	}
+
+	for _, args := range [][]string{
+		{"--max-concurrent-reconciles=bogus=1"},
+		{"--max-concurrent-reconciles=polecat=0"},
+		{"--rate-limiter-max-delay=polecat"},
+		{"--rate-limiter-max-delay=polecat=soon"},
+		{"--rate-limiter-burst=polecat=0"},
+	} {
+		if _, err := parse(args...); err == nil {
+			t.Errorf("expected %v to be rejected", args)
+		}
+	}
+}
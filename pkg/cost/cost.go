@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package cost estimates what an agent session will cost before it starts,
+// from the size of its task and the price of its model. The estimate is a
+// rough upper-end guess meant to catch oversized work, not a bill. It also
+// prices the tokens a session actually used.
+package cost
+
+import (
+	"math"
+	"strings"
+)
+
+// Price is the price of a model in USD per million tokens
+type Price struct {
+	Input, Output float64
+}
+
+// DefaultPrice prices models without a known price
+var DefaultPrice = Price{Input: 3, Output: 15}
+
+// builtinPrices are list prices by model name prefix
+var builtinPrices = map[string]Price{
+	"claude-opus":   {Input: 15, Output: 75},
+	"claude-sonnet": {Input: 3, Output: 15},
+	"claude-haiku":  {Input: 1, Output: 5},
+	"gpt-4o":        {Input: 2.5, Output: 10},
+	"gpt-4o-mini":   {Input: 0.15, Output: 0.6},
+	"gpt-4.1":       {Input: 2, Output: 8},
+	"gpt-4.1-mini":  {Input: 0.4, Output: 1.6},
+}
+
+// Session model. An agent re-sends its growing context on every turn, so
+// input dominates; longer tasks take more turns.
+const (
+	// charsPerToken approximates the tokenization of English and code
+	charsPerToken = 4
+
+	// baseContextTokens is the agent's system prompt, tools and the
+	// repository context it reads on a typical turn
+	baseContextTokens = 20000
+
+	// baseTurns is the number of turns of a small task
+	baseTurns = 20
+
+	// taskTokensPerTurn adds a turn per this many task tokens
+	taskTokensPerTurn = 100
+
+	// outputTokensPerTurn is the output of a typical turn
+	outputTokensPerTurn = 1500
+)
+
+// cacheReadFactor is the share of the input price charged for input tokens
+// read from the prompt cache
+const cacheReadFactor = 0.1
+
+// Estimate is the estimated token usage and cost of a task
+type Estimate struct {
+	InputTokens  int64
+	OutputTokens int64
+	USD          float64
+}
+
+// PriceFor returns the price of model, looking it up in overrides and then
+// in the built-in prices. A provider prefix ("anthropic/claude-sonnet-4") is
+// ignored and the longest matching name prefix wins. It reports false, with
+// DefaultPrice, for unknown models.
+func PriceFor(model string, overrides map[string]Price) (Price, bool) {
+	name := strings.ToLower(model[strings.LastIndex(model, "/")+1:])
+	if name == "" {
+		return DefaultPrice, false
+	}
+	for _, prices := range []map[string]Price{overrides, builtinPrices} {
+		if price, ok := prices[name]; ok {
+			return price, true
+		}
+		best := ""
+		for prefix := range prices {
+			if strings.HasPrefix(name, strings.ToLower(prefix)) && len(prefix) > len(best) {
+				best = prefix
+			}
+		}
+		if best != "" {
+			return prices[best], true
+		}
+	}
+	return DefaultPrice, false
+}
+
+// EstimateTask estimates a session working on the task described by text.
+func EstimateTask(text string, price Price) Estimate {
+	taskTokens := int64(math.Ceil(float64(len(text)) / charsPerToken))
+	turns := baseTurns + taskTokens/taskTokensPerTurn
+
+	e := Estimate{
+		InputTokens:  (baseContextTokens + taskTokens) * turns,
+		OutputTokens: outputTokensPerTurn * turns,
+	}
+	e.USD = (float64(e.InputTokens)*price.Input + float64(e.OutputTokens)*price.Output) / 1e6
+	return e
+}
+
+// Usage is the token usage of a session. InputTokens excludes the tokens
+// read from the prompt cache.
+type Usage struct {
+	InputTokens     int64
+	CacheReadTokens int64
+	OutputTokens    int64
+}
+
+// USD prices the usage at price.
+func (u Usage) USD(price Price) float64 {
+	input := float64(u.InputTokens) + float64(u.CacheReadTokens)*cacheReadFactor
+	return (input*price.Input + float64(u.OutputTokens)*price.Output) / 1e6
+}
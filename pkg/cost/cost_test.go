@@ -0,0 +1,90 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package cost
+
+import (
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestPriceFor(t *testing.T) {
+	overrides := map[string]Price{
+		"devstral":      {Input: 0.1, Output: 0.3},
+		"claude-sonnet": {Input: 2, Output: 10},
+	}
+
+	tests := []struct {
+		model string
+		want  Price
+		known bool
+	}{
+		{"claude-opus-4", Price{Input: 15, Output: 75}, true},
+		{"anthropic/claude-haiku-4-5", Price{Input: 1, Output: 5}, true},
+		{"gpt-4o-mini-2024-07-18", Price{Input: 0.15, Output: 0.6}, true},
+		{"gpt-4o", Price{Input: 2.5, Output: 10}, true},
+		// Overrides win over built-in prices
+		{"claude-sonnet-4", Price{Input: 2, Output: 10}, true},
+		{"Devstral-123B", Price{Input: 0.1, Output: 0.3}, true},
+		{"mystery-model", DefaultPrice, false},
+		{"", DefaultPrice, false},
+	}
+	for _, tt := range tests {
+		got, known := PriceFor(tt.model, overrides)
+		if got != tt.want || known != tt.known {
+			t.Errorf("PriceFor(%q) = %v, %v; want %v, %v", tt.model, got, known, tt.want, tt.known)
+		}
+	}
+}
+
+func TestEstimateTask(t *testing.T) {
+	price := Price{Input: 3, Output: 15}
+
+	small := EstimateTask("Fix the typo in README", price)
+	if small.InputTokens != (baseContextTokens+6)*baseTurns {
+		t.Errorf("unexpected input tokens %d", small.InputTokens)
+	}
+	if small.OutputTokens != outputTokensPerTurn*baseTurns {
+		t.Errorf("unexpected output tokens %d", small.OutputTokens)
+	}
+	want := (float64(small.InputTokens)*3 + float64(small.OutputTokens)*15) / 1e6
+	if math.Abs(small.USD-want) > 1e-9 {
+		t.Errorf("USD = %f, want %f", small.USD, want)
+	}
+
+	// A long task takes more turns and costs more than proportionally
+	large := EstimateTask(strings.Repeat("x", 20000), price)
+	if large.USD <= 3*small.USD {
+		t.Errorf("expected a 20k character task to cost well above %f, got %f", small.USD, large.USD)
+	}
+
+	if free := EstimateTask("anything", Price{}); free.USD != 0 || free.InputTokens == 0 {
+		t.Errorf("expected tokens but no cost for a free model, got %+v", free)
+	}
+}
+
+func TestUsageUSD(t *testing.T) {
+	u := Usage{InputTokens: 1_000_000, CacheReadTokens: 10_000_000, OutputTokens: 100_000}
+
+	// 1M input at $3, 10M cache reads at a tenth of that, 100k output at $15
+	if got := u.USD(Price{Input: 3, Output: 15}); math.Abs(got-7.5) > 1e-9 {
+		t.Errorf("USD = %f, want 7.5", got)
+	}
+	if got := u.USD(Price{}); got != 0 {
+		t.Errorf("expected a free model to cost nothing, got %f", got)
+	}
+}
@@ -0,0 +1,60 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"context"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/client-go/util/retry"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// IsConflict checks if an error is a resource conflict, either a Conflict
+// GasTownError or an optimistic concurrency failure of the API server.
+func IsConflict(err error) bool {
+	return IsType(err, ErrorTypeConflict) || apierrors.IsConflict(err)
+}
+
+// RetryOnConflict runs fn until it does not fail with a conflict, backing off
+// between attempts. It gives up after a few attempts, returning the conflict.
+func RetryOnConflict(fn func() error) error {
+	return retry.OnError(retry.DefaultRetry, IsConflict, fn)
+}
+
+// UpdateOnConflict updates obj after mutate changed it. When the update
+// conflicts with a concurrent write, obj is read again and mutate reapplied,
+// so that the change lands on the latest resourceVersion. mutate reports
+// whether it changed obj; nothing is written when it did not.
+//
+// Status is written with server-side apply instead; this is for metadata and
+// objects without a status subresource, e.g. finalizers and caches.
+func UpdateOnConflict(ctx context.Context, c client.Client, obj client.Object, mutate func() bool) error {
+	first := true
+	return RetryOnConflict(func() error {
+		if !first {
+			if err := c.Get(ctx, client.ObjectKeyFromObject(obj), obj); err != nil {
+				return err
+			}
+		}
+		first = false
+		if !mutate() {
+			return nil
+		}
+		return c.Update(ctx, obj)
+	})
+}
@@ -0,0 +1,132 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package errors
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+)
+
+func TestIsConflict(t *testing.T) {
+	apiConflict := apierrors.NewConflict(schema.GroupResource{Resource: "polecats"}, "nux", errors.New("stale"))
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{"api conflict", apiConflict, true},
+		{"wrapped api conflict", Wrap(apiConflict, "failed to update"), true},
+		{"conflict type", &GasTownError{Message: "conflict", Type: ErrorTypeConflict}, true},
+		{"other error", New("boom"), false},
+		{"nil", nil, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := IsConflict(tt.err); got != tt.want {
+				t.Errorf("IsConflict() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRetryOnConflict(t *testing.T) {
+	conflict := apierrors.NewConflict(schema.GroupResource{Resource: "polecats"}, "nux", errors.New("stale"))
+
+	calls := 0
+	err := RetryOnConflict(func() error {
+		calls++
+		if calls < 3 {
+			return conflict
+		}
+		return nil
+	})
+	if err != nil || calls != 3 {
+		t.Errorf("expected success on the third call, got %v after %d calls", err, calls)
+	}
+
+	calls = 0
+	err = RetryOnConflict(func() error {
+		calls++
+		return New("boom")
+	})
+	if err == nil || calls != 1 {
+		t.Errorf("expected other errors returned at once, got %v after %d calls", err, calls)
+	}
+}
+
+func TestUpdateOnConflict(t *testing.T) {
+	ctx := context.Background()
+	cm := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Name: "cache", Namespace: "default"}}
+	conflicts := 0
+	c := fake.NewClientBuilder().WithObjects(cm).WithInterceptorFuncs(interceptor.Funcs{
+		Update: func(ctx context.Context, c client.WithWatch, obj client.Object, opts ...client.UpdateOption) error {
+			if conflicts == 0 {
+				conflicts++
+				// A concurrent writer gets there first
+				latest := &corev1.ConfigMap{}
+				if err := c.Get(ctx, client.ObjectKeyFromObject(obj), latest); err != nil {
+					return err
+				}
+				latest.Labels = map[string]string{"writer": "other"}
+				if err := c.Update(ctx, latest); err != nil {
+					return err
+				}
+				return apierrors.NewConflict(schema.GroupResource{Resource: "configmaps"}, obj.GetName(), errors.New("stale"))
+			}
+			return c.Update(ctx, obj, opts...)
+		},
+	}).Build()
+
+	stale := &corev1.ConfigMap{}
+	if err := c.Get(ctx, client.ObjectKeyFromObject(cm), stale); err != nil {
+		t.Fatal(err)
+	}
+	mutations := 0
+	err := UpdateOnConflict(ctx, c, stale, func() bool {
+		mutations++
+		stale.Data = map[string]string{"key": "value"}
+		return true
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if mutations != 2 {
+		t.Errorf("expected the mutation reapplied after the conflict, got %d mutations", mutations)
+	}
+
+	var got corev1.ConfigMap
+	if err := c.Get(ctx, client.ObjectKeyFromObject(cm), &got); err != nil {
+		t.Fatal(err)
+	}
+	if got.Data["key"] != "value" || got.Labels["writer"] != "other" {
+		t.Errorf("expected both writes kept, got labels %v data %v", got.Labels, got.Data)
+	}
+
+	unchanged := 0
+	if err := UpdateOnConflict(ctx, c, &got, func() bool { unchanged++; return false }); err != nil || unchanged != 1 {
+		t.Errorf("expected no update when nothing changed, got %v after %d mutations", err, unchanged)
+	}
+}
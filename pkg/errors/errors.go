@@ -5,6 +5,7 @@
 // - Context propagation
 // - Standardized error types for K8s conditions
 // - Error categorization (transient vs permanent)
+// - Retrying updates that conflict with concurrent writes
 package errors
 
 import (
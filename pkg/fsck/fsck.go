@@ -0,0 +1,262 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package fsck cross-checks the state recorded in Gas Town resources against
+// the objects it refers to: polecats whose Pod is gone, Pods whose polecat is
+// gone, beads left in progress by terminated polecats and merge queue entries
+// for branches that no longer exist. It is shared by kubectl gt fsck and the
+// operator's background consistency checker.
+package fsck
+
+import (
+	"fmt"
+	"sort"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// Kind identifies a type of inconsistency
+type Kind string
+
+const (
+	// PolecatMissingPod is a working polecat whose recorded Pod does not exist
+	PolecatMissingPod Kind = "PolecatMissingPod"
+
+	// OrphanedPod is a polecat Pod whose Polecat does not exist
+	OrphanedPod Kind = "OrphanedPod"
+
+	// BeadOnTerminatedPolecat is a bead still in progress although every
+	// polecat assigned to it has terminated without merging
+	BeadOnTerminatedPolecat Kind = "BeadOnTerminatedPolecat"
+
+	// StaleQueueEntry is a merge queue entry whose polecat or branch is gone
+	StaleQueueEntry Kind = "StaleQueueEntry"
+)
+
+// Kinds lists every kind of inconsistency, in report order
+var Kinds = []Kind{PolecatMissingPod, OrphanedPod, BeadOnTerminatedPolecat, StaleQueueEntry}
+
+// PolecatLabel names the polecat on its agent Pod
+const PolecatLabel = "gastown.io/polecat"
+
+// RequeueAnnotation is stamped on a resource to make its controller
+// reconcile it again. Its value is the time of the request.
+const RequeueAnnotation = "gastown.io/fsck-requeue"
+
+// These mirror the condition types and bead status set by the controllers
+const (
+	conditionMerged        = "Merged"
+	conditionBranchDeleted = "BranchDeleted"
+	beadStatusInProgress   = "in_progress"
+)
+
+// Issue is one inconsistency found by Check.
+type Issue struct {
+	Kind      Kind   `json:"kind"`
+	Namespace string `json:"namespace"`
+
+	// Resource is the kind of the object the issue is about
+	Resource string `json:"resource"`
+
+	// Name is the name of the object the issue is about
+	Name string `json:"name"`
+
+	Message string `json:"message"`
+
+	// Repairable is true when kubectl gt fsck --repair can fix the issue.
+	// Beads are never changed by fsck; they are left for a human to triage.
+	Repairable bool `json:"repairable"`
+}
+
+// State is a snapshot of the resources to cross-check. Pods only need to
+// include the Pods labeled with PolecatLabel.
+type State struct {
+	Polecats   []gastownv1alpha1.Polecat
+	Pods       []corev1.Pod
+	Refineries []gastownv1alpha1.Refinery
+	BeadStores []gastownv1alpha1.BeadStore
+}
+
+// objectKey identifies a namespaced object by namespace and name
+type objectKey struct {
+	namespace, name string
+}
+
+// Check returns the inconsistencies in state, sorted by kind, namespace and
+// name.
+func Check(state State) []Issue {
+	polecats := make(map[objectKey]*gastownv1alpha1.Polecat, len(state.Polecats))
+	for i := range state.Polecats {
+		p := &state.Polecats[i]
+		polecats[objectKey{p.Namespace, p.Name}] = p
+	}
+	pods := make(map[objectKey]bool, len(state.Pods))
+	for _, pod := range state.Pods {
+		pods[objectKey{pod.Namespace, pod.Name}] = true
+	}
+
+	issues := []Issue{}
+	issues = append(issues, checkPolecatPods(state.Polecats, pods)...)
+	issues = append(issues, checkOrphanedPods(state.Pods, polecats)...)
+	issues = append(issues, checkBeads(state.BeadStores, state.Polecats)...)
+	issues = append(issues, checkQueues(state.Refineries, polecats)...)
+
+	order := make(map[Kind]int, len(Kinds))
+	for i, kind := range Kinds {
+		order[kind] = i
+	}
+	sort.SliceStable(issues, func(i, j int) bool {
+		a, b := issues[i], issues[j]
+		if a.Kind != b.Kind {
+			return order[a.Kind] < order[b.Kind]
+		}
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		return a.Name < b.Name
+	})
+	return issues
+}
+
+// checkPolecatPods finds working polecats whose recorded Pod is gone. The
+// Polecat controller recreates the Pod once it reconciles the polecat again.
+func checkPolecatPods(polecats []gastownv1alpha1.Polecat, pods map[objectKey]bool) []Issue {
+	var issues []Issue
+	for _, p := range polecats {
+		if p.DeletionTimestamp != nil || p.Status.Phase != gastownv1alpha1.PolecatPhaseWorking ||
+			p.Status.PodName == "" || pods[objectKey{p.Namespace, p.Status.PodName}] {
+			continue
+		}
+		issues = append(issues, Issue{
+			Kind:       PolecatMissingPod,
+			Namespace:  p.Namespace,
+			Resource:   "Polecat",
+			Name:       p.Name,
+			Message:    fmt.Sprintf("polecat is Working but its pod %s does not exist", p.Status.PodName),
+			Repairable: true,
+		})
+	}
+	return issues
+}
+
+// checkOrphanedPods finds polecat Pods whose Polecat is gone, e.g. Pods left
+// behind when their owner reference was removed.
+func checkOrphanedPods(pods []corev1.Pod, polecats map[objectKey]*gastownv1alpha1.Polecat) []Issue {
+	var issues []Issue
+	for _, pod := range pods {
+		name := pod.Labels[PolecatLabel]
+		if name == "" || pod.DeletionTimestamp != nil || polecats[objectKey{pod.Namespace, name}] != nil {
+			continue
+		}
+		issues = append(issues, Issue{
+			Kind:       OrphanedPod,
+			Namespace:  pod.Namespace,
+			Resource:   "Pod",
+			Name:       pod.Name,
+			Message:    fmt.Sprintf("pod belongs to polecat %s, which does not exist", name),
+			Repairable: true,
+		})
+	}
+	return issues
+}
+
+// checkBeads finds beads still in progress whose polecats have all
+// terminated without merging. Terminated polecats keep their assigned bead,
+// so a bead is only reported when no live or merged polecat of the rig
+// holds it.
+func checkBeads(stores []gastownv1alpha1.BeadStore, polecats []gastownv1alpha1.Polecat) []Issue {
+	type beadKey struct {
+		namespace, rig, bead string
+	}
+	terminated := map[beadKey][]string{}
+	held := map[beadKey]bool{}
+	for _, p := range polecats {
+		if p.Status.AssignedBead == "" {
+			continue
+		}
+		key := beadKey{p.Namespace, p.Spec.Rig, p.Status.AssignedBead}
+		if p.Status.Phase == gastownv1alpha1.PolecatPhaseTerminated &&
+			!meta.IsStatusConditionTrue(p.Status.Conditions, conditionMerged) {
+			terminated[key] = append(terminated[key], p.Name)
+			continue
+		}
+		held[key] = true
+	}
+
+	var issues []Issue
+	for _, store := range stores {
+		for _, bead := range store.Status.Beads {
+			key := beadKey{store.Namespace, store.Spec.RigRef, bead.ID}
+			names := terminated[key]
+			if bead.Status != beadStatusInProgress || len(names) == 0 || held[key] {
+				continue
+			}
+			sort.Strings(names)
+			issues = append(issues, Issue{
+				Kind:      BeadOnTerminatedPolecat,
+				Namespace: store.Namespace,
+				Resource:  "BeadStore",
+				Name:      store.Name,
+				Message: fmt.Sprintf("bead %s is in progress but its polecats terminated without merging: %v",
+					bead.ID, names),
+			})
+		}
+	}
+	return issues
+}
+
+// checkQueues finds merge queue entries whose polecat is gone or whose
+// branch was deleted or replaced. The Refinery rebuilds its queue on every
+// reconcile, so requeueing it drops such entries.
+func checkQueues(refineries []gastownv1alpha1.Refinery, polecats map[objectKey]*gastownv1alpha1.Polecat) []Issue {
+	var issues []Issue
+	for _, refinery := range refineries {
+		for _, entry := range refinery.Status.Queue {
+			reason := staleQueueReason(entry, polecats[objectKey{refinery.Namespace, entry.Polecat}])
+			if reason == "" {
+				continue
+			}
+			issues = append(issues, Issue{
+				Kind:       StaleQueueEntry,
+				Namespace:  refinery.Namespace,
+				Resource:   "Refinery",
+				Name:       refinery.Name,
+				Message:    fmt.Sprintf("queued polecat %s: %s", entry.Polecat, reason),
+				Repairable: true,
+			})
+		}
+	}
+	return issues
+}
+
+// staleQueueReason explains why a merge queue entry can no longer be merged,
+// or returns "" when it still can.
+func staleQueueReason(entry gastownv1alpha1.MergeQueueEntry, polecat *gastownv1alpha1.Polecat) string {
+	switch {
+	case polecat == nil:
+		return "polecat does not exist"
+	case entry.Branch == "":
+		return "entry has no branch"
+	case meta.IsStatusConditionTrue(polecat.Status.Conditions, conditionBranchDeleted):
+		return fmt.Sprintf("branch %s was deleted", entry.Branch)
+	case polecat.Status.Branch != entry.Branch:
+		return fmt.Sprintf("branch %s is no longer the polecat's branch %q", entry.Branch, polecat.Status.Branch)
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package fsck
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func polecat(name string, phase gastownv1alpha1.PolecatPhase, conditions ...string) gastownv1alpha1.Polecat {
+	p := gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "gastown"},
+		Spec:       gastownv1alpha1.PolecatSpec{Rig: "app"},
+		Status: gastownv1alpha1.PolecatStatus{
+			Phase:   phase,
+			PodName: "polecat-" + name,
+			Branch:  "polecat/" + name,
+		},
+	}
+	for _, c := range conditions {
+		p.Status.Conditions = append(p.Status.Conditions, metav1.Condition{Type: c, Status: metav1.ConditionTrue})
+	}
+	return p
+}
+
+func polecatPod(polecat string) corev1.Pod {
+	return corev1.Pod{ObjectMeta: metav1.ObjectMeta{
+		Name:      "polecat-" + polecat,
+		Namespace: "gastown",
+		Labels:    map[string]string{PolecatLabel: polecat},
+	}}
+}
+
+func TestCheckConsistentState(t *testing.T) {
+	state := State{
+		Polecats: []gastownv1alpha1.Polecat{
+			polecat("furiosa", gastownv1alpha1.PolecatPhaseWorking),
+			polecat("nux", gastownv1alpha1.PolecatPhaseDone),
+		},
+		Pods: []corev1.Pod{polecatPod("furiosa"), polecatPod("nux")},
+		Refineries: []gastownv1alpha1.Refinery{{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "gastown"},
+			Status: gastownv1alpha1.RefineryStatus{Queue: []gastownv1alpha1.MergeQueueEntry{
+				{Polecat: "nux", Branch: "polecat/nux"},
+			}},
+		}},
+	}
+	if issues := Check(state); len(issues) != 0 {
+		t.Errorf("expected no issues, got %+v", issues)
+	}
+}
+
+func TestCheckPods(t *testing.T) {
+	state := State{
+		Polecats: []gastownv1alpha1.Polecat{
+			polecat("furiosa", gastownv1alpha1.PolecatPhaseWorking),
+			// Terminated polecats are expected to lose their Pod
+			polecat("slit", gastownv1alpha1.PolecatPhaseTerminated),
+		},
+		Pods: []corev1.Pod{polecatPod("toast")},
+	}
+
+	issues := Check(state)
+	if len(issues) != 2 {
+		t.Fatalf("expected 2 issues, got %+v", issues)
+	}
+	if issues[0].Kind != PolecatMissingPod || issues[0].Resource != "Polecat" || issues[0].Name != "furiosa" {
+		t.Errorf("expected furiosa to miss its pod, got %+v", issues[0])
+	}
+	if issues[1].Kind != OrphanedPod || issues[1].Resource != "Pod" || issues[1].Name != "polecat-toast" {
+		t.Errorf("expected polecat-toast to be orphaned, got %+v", issues[1])
+	}
+	for _, issue := range issues {
+		if !issue.Repairable {
+			t.Errorf("expected %s to be repairable", issue.Kind)
+		}
+	}
+}
+
+func TestCheckBeads(t *testing.T) {
+	terminated := polecat("slit", gastownv1alpha1.PolecatPhaseTerminated)
+	terminated.Status.AssignedBead = "gt-1"
+	merged := polecat("nux", gastownv1alpha1.PolecatPhaseTerminated, conditionMerged)
+	merged.Status.AssignedBead = "gt-2"
+	retried := polecat("toast", gastownv1alpha1.PolecatPhaseTerminated)
+	retried.Status.AssignedBead = "gt-3"
+	retry := polecat("toast-2", gastownv1alpha1.PolecatPhaseWorking)
+	retry.Status.AssignedBead = "gt-3"
+
+	state := State{
+		Polecats: []gastownv1alpha1.Polecat{terminated, merged, retried, retry},
+		Pods:     []corev1.Pod{polecatPod("toast-2")},
+		BeadStores: []gastownv1alpha1.BeadStore{{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-beads", Namespace: "gastown"},
+			Spec:       gastownv1alpha1.BeadStoreSpec{RigRef: "app"},
+			Status: gastownv1alpha1.BeadStoreStatus{Beads: []gastownv1alpha1.BeadSummary{
+				{ID: "gt-1", Status: beadStatusInProgress},
+				{ID: "gt-2", Status: beadStatusInProgress},
+				{ID: "gt-3", Status: beadStatusInProgress},
+			}},
+		}},
+	}
+
+	issues := Check(state)
+	if len(issues) != 1 {
+		t.Fatalf("expected only the abandoned bead to be reported, got %+v", issues)
+	}
+	issue := issues[0]
+	if issue.Kind != BeadOnTerminatedPolecat || issue.Name != "app-beads" || issue.Repairable {
+		t.Errorf("unexpected issue %+v", issue)
+	}
+	if !strings.Contains(issue.Message, "gt-1") || !strings.Contains(issue.Message, "slit") {
+		t.Errorf("expected the message to name the bead and polecat, got %q", issue.Message)
+	}
+}
+
+func TestCheckQueues(t *testing.T) {
+	state := State{
+		Polecats: []gastownv1alpha1.Polecat{
+			polecat("furiosa", gastownv1alpha1.PolecatPhaseDone),
+			polecat("nux", gastownv1alpha1.PolecatPhaseDone, conditionBranchDeleted),
+			polecat("slit", gastownv1alpha1.PolecatPhaseDone),
+		},
+		Pods: []corev1.Pod{polecatPod("furiosa"), polecatPod("nux"), polecatPod("slit")},
+		Refineries: []gastownv1alpha1.Refinery{{
+			ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: "gastown"},
+			Status: gastownv1alpha1.RefineryStatus{Queue: []gastownv1alpha1.MergeQueueEntry{
+				{Polecat: "furiosa", Branch: "polecat/furiosa"},
+				{Polecat: "nux", Branch: "polecat/nux"},
+				{Polecat: "slit", Branch: "polecat/slit-old"},
+				{Polecat: "toast", Branch: "polecat/toast"},
+			}},
+		}},
+	}
+
+	issues := Check(state)
+	if len(issues) != 3 {
+		t.Fatalf("expected 3 stale entries, got %+v", issues)
+	}
+	for i, want := range []string{"nux: branch polecat/nux was deleted", "slit: branch polecat/slit-old", "toast: polecat does not exist"} {
+		if issues[i].Kind != StaleQueueEntry || issues[i].Name != "app" || !strings.Contains(issues[i].Message, want) {
+			t.Errorf("issue %d: expected %q, got %+v", i, want, issues[i])
+		}
+	}
+}
@@ -2,6 +2,8 @@
 package metrics
 
 import (
+	"context"
+	"errors"
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
@@ -19,6 +21,7 @@ const (
 	ResultSuccess = "success"
 	ResultError   = "error"
 	ResultRequeue = "requeue"
+	ResultTimeout = "timeout"
 )
 
 var (
@@ -72,7 +75,7 @@ var (
 	GTCLICallsTotal = prometheus.NewCounterVec(
 		prometheus.CounterOpts{
 			Name: "gastown_gt_cli_calls_total",
-			Help: "Total number of gt CLI calls by command and result",
+			Help: "Total number of gt CLI calls by command and result (success, timeout, error)",
 		},
 		[]string{"command", labelResult},
 	)
@@ -124,13 +127,35 @@ var (
 		[]string{labelRig},
 	)
 
-	// RefineryQueueLength tracks the number of items in the merge queue per rig.
-	RefineryQueueLength = prometheus.NewGaugeVec(
+	// FsckIssuesGauge tracks the inconsistencies found by the last
+	// consistency check.
+	FsckIssuesGauge = prometheus.NewGaugeVec(
 		prometheus.GaugeOpts{
-			Name: "gastown_refinery_queue_length",
-			Help: "Number of items in the merge queue by rig",
+			Name: "gastown_fsck_issues",
+			Help: "Number of inconsistencies found by the last consistency check by kind",
 		},
-		[]string{labelRig},
+		[]string{"kind"},
+	)
+
+	// AuditEventsTotal counts the audited actions of the operator, e.g.
+	// merges and pushes, by controller, action and result.
+	AuditEventsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gastown_audit_events_total",
+			Help: "Total number of audited mutating actions by controller, action and result",
+		},
+		[]string{labelController, "action", labelResult},
+	)
+
+	// CredentialsExpiry tracks when the Claude OAuth credentials of a
+	// Secret expire, to alert before polecats are dispatched with them.
+	// Credentials the agent renews with their refresh token are left out.
+	CredentialsExpiry = prometheus.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "gastown_credentials_expiry_timestamp_seconds",
+			Help: "Unix time the Claude OAuth credentials in a Secret expire by namespace and secret",
+		},
+		[]string{"namespace", "secret"},
 	)
 )
 
@@ -148,7 +173,9 @@ func init() {
 		RefineryMergeTotal,
 		RefineryMergeDuration,
 		RefineryConflictsTotal,
-		RefineryQueueLength,
+		FsckIssuesGauge,
+		AuditEventsTotal,
+		CredentialsExpiry,
 	)
 }
 
@@ -210,6 +237,26 @@ func (t *GTCLITimer) RecordError() {
 	GTCLICallsTotal.WithLabelValues(t.command, ResultError).Inc()
 }
 
+// RecordTimeout records a gt CLI call that ran out of time.
+func (t *GTCLITimer) RecordTimeout() {
+	duration := time.Since(t.start).Seconds()
+	GTCLIDuration.WithLabelValues(t.command).Observe(duration)
+	GTCLICallsTotal.WithLabelValues(t.command, ResultTimeout).Inc()
+}
+
+// Record records the outcome of a gt CLI call from its error: success when
+// nil, timeout when the call's context deadline passed, error otherwise.
+func (t *GTCLITimer) Record(err error) {
+	switch {
+	case err == nil:
+		t.RecordSuccess()
+	case errors.Is(err, context.DeadlineExceeded):
+		t.RecordTimeout()
+	default:
+		t.RecordError()
+	}
+}
+
 // UpdateRigPhase updates the rig phase gauge.
 func UpdateRigPhase(phase string, count float64) {
 	RigPhaseGauge.WithLabelValues(phase).Set(count)
@@ -225,6 +272,11 @@ func UpdateConvoyPhase(phase string, count float64) {
 	ConvoyPhaseGauge.WithLabelValues(phase).Set(count)
 }
 
+// UpdateFsckIssues updates the consistency check gauge for an issue kind.
+func UpdateFsckIssues(kind string, count float64) {
+	FsckIssuesGauge.WithLabelValues(kind).Set(count)
+}
+
 // RefineryMergeTimer tracks merge operation timing for a specific rig.
 type RefineryMergeTimer struct {
 	rig   string
@@ -257,8 +309,3 @@ func (t *RefineryMergeTimer) RecordError() {
 func RecordConflict(rig string) {
 	RefineryConflictsTotal.WithLabelValues(rig).Inc()
 }
-
-// UpdateQueueLength updates the merge queue length for a rig.
-func UpdateQueueLength(rig string, length float64) {
-	RefineryQueueLength.WithLabelValues(rig).Set(length)
-}
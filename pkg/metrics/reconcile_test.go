@@ -17,6 +17,9 @@ limitations under the License.
 package metrics
 
 import (
+	"context"
+	"errors"
+	"fmt"
 	"testing"
 	"time"
 
@@ -126,6 +129,21 @@ func TestGTCLITimer_RecordError(t *testing.T) {
 	}
 }
 
+func TestGTCLITimer_Record(t *testing.T) {
+	GTCLICallsTotal.Reset()
+	GTCLIDuration.Reset()
+
+	NewGTCLITimer("mail").Record(nil)
+	NewGTCLITimer("mail").Record(fmt.Errorf("mail send: %w", context.DeadlineExceeded))
+	NewGTCLITimer("mail").Record(errors.New("exit status 1"))
+
+	for _, result := range []string{ResultSuccess, ResultTimeout, ResultError} {
+		if count := testutil.ToFloat64(GTCLICallsTotal.WithLabelValues("mail", result)); count != 1 {
+			t.Errorf("expected one %s call, got %f", result, count)
+		}
+	}
+}
+
 func TestUpdateRigPhase(t *testing.T) {
 	// Reset gauge before testing
 	RigPhaseGauge.Reset()
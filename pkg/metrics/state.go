@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"context"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// stateCollectTimeout bounds the cache reads of a single scrape.
+const stateCollectTimeout = 10 * time.Second
+
+var (
+	polecatsDesc = prometheus.NewDesc(
+		"gastown_polecats",
+		"Number of polecats by namespace, rig and phase",
+		[]string{"namespace", labelRig, labelPhase}, nil,
+	)
+
+	rigPolecatCountDesc = prometheus.NewDesc(
+		"gastown_rig_polecat_count",
+		"Number of polecats in the rig as reported in the Rig status",
+		[]string{labelRig}, nil,
+	)
+
+	convoyProgressDesc = prometheus.NewDesc(
+		"gastown_convoy_progress_ratio",
+		"Fraction of the convoy's tracked beads that are completed (0-1)",
+		[]string{"namespace", "convoy", labelPhase}, nil,
+	)
+
+	refineryQueueLengthDesc = prometheus.NewDesc(
+		"gastown_refinery_queue_length",
+		"Number of items in the merge queue by rig",
+		[]string{"namespace", "refinery", labelRig}, nil,
+	)
+)
+
+// StateCollector exports the state of the Gas Town custom resources as
+// gauges. It reads the controller cache at scrape time, so the values are
+// never stale and deleted resources disappear from the output.
+type StateCollector struct {
+	reader client.Reader
+}
+
+// NewStateCollector creates a collector reading resources through reader,
+// normally the manager's cache-backed client.
+func NewStateCollector(reader client.Reader) *StateCollector {
+	return &StateCollector{reader: reader}
+}
+
+// Describe implements prometheus.Collector.
+func (c *StateCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- polecatsDesc
+	ch <- rigPolecatCountDesc
+	ch <- convoyProgressDesc
+	ch <- refineryQueueLengthDesc
+}
+
+// Collect implements prometheus.Collector.
+// A resource kind that cannot be listed (e.g., before the cache has synced)
+// is left out of the scrape.
+func (c *StateCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), stateCollectTimeout)
+	defer cancel()
+
+	c.collectPolecats(ctx, ch)
+	c.collectRigs(ctx, ch)
+	c.collectConvoys(ctx, ch)
+	c.collectRefineries(ctx, ch)
+}
+
+func (c *StateCollector) collectPolecats(ctx context.Context, ch chan<- prometheus.Metric) {
+	polecats := &gastownv1alpha1.PolecatList{}
+	if err := c.reader.List(ctx, polecats); err != nil {
+		return
+	}
+
+	type key struct{ namespace, rig, phase string }
+	counts := map[key]int{}
+	for _, polecat := range polecats.Items {
+		phase := string(polecat.Status.Phase)
+		if phase == "" {
+			phase = "Unknown"
+		}
+		counts[key{polecat.Namespace, polecat.Spec.Rig, phase}]++
+	}
+	for k, n := range counts {
+		ch <- prometheus.MustNewConstMetric(polecatsDesc, prometheus.GaugeValue, float64(n), k.namespace, k.rig, k.phase)
+	}
+}
+
+func (c *StateCollector) collectRigs(ctx context.Context, ch chan<- prometheus.Metric) {
+	rigs := &gastownv1alpha1.RigList{}
+	if err := c.reader.List(ctx, rigs); err != nil {
+		return
+	}
+	for _, rig := range rigs.Items {
+		ch <- prometheus.MustNewConstMetric(rigPolecatCountDesc, prometheus.GaugeValue,
+			float64(rig.Status.PolecatCount), rig.Name)
+	}
+}
+
+func (c *StateCollector) collectConvoys(ctx context.Context, ch chan<- prometheus.Metric) {
+	convoys := &gastownv1alpha1.ConvoyList{}
+	if err := c.reader.List(ctx, convoys); err != nil {
+		return
+	}
+	for _, convoy := range convoys.Items {
+		ratio := 0.0
+		if total := len(convoy.Spec.TrackedBeads); total > 0 {
+			ratio = float64(len(convoy.Status.CompletedBeads)) / float64(total)
+		}
+		ch <- prometheus.MustNewConstMetric(convoyProgressDesc, prometheus.GaugeValue, ratio,
+			convoy.Namespace, convoy.Name, string(convoy.Status.Phase))
+	}
+}
+
+func (c *StateCollector) collectRefineries(ctx context.Context, ch chan<- prometheus.Metric) {
+	refineries := &gastownv1alpha1.RefineryList{}
+	if err := c.reader.List(ctx, refineries); err != nil {
+		return
+	}
+	for _, refinery := range refineries.Items {
+		ch <- prometheus.MustNewConstMetric(refineryQueueLengthDesc, prometheus.GaugeValue,
+			float64(refinery.Status.QueueLength), refinery.Namespace, refinery.Name, refinery.Spec.RigRef)
+	}
+}
@@ -0,0 +1,89 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package metrics
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func TestStateCollector(t *testing.T) {
+	scheme := runtime.NewScheme()
+	if err := gastownv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+
+	polecat := func(name, rig string, phase gastownv1alpha1.PolecatPhase) *gastownv1alpha1.Polecat {
+		return &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: name, Namespace: "default"},
+			Spec:       gastownv1alpha1.PolecatSpec{Rig: rig},
+			Status:     gastownv1alpha1.PolecatStatus{Phase: phase},
+		}
+	}
+
+	reader := fake.NewClientBuilder().WithScheme(scheme).WithObjects(
+		polecat("a", "alpha", gastownv1alpha1.PolecatPhaseWorking),
+		polecat("b", "alpha", gastownv1alpha1.PolecatPhaseWorking),
+		polecat("c", "alpha", gastownv1alpha1.PolecatPhaseDone),
+		polecat("d", "beta", ""),
+		&gastownv1alpha1.Rig{
+			ObjectMeta: metav1.ObjectMeta{Name: "alpha"},
+			Status:     gastownv1alpha1.RigStatus{PolecatCount: 3},
+		},
+		&gastownv1alpha1.Convoy{
+			ObjectMeta: metav1.ObjectMeta{Name: "wave-1", Namespace: "default"},
+			Spec:       gastownv1alpha1.ConvoySpec{TrackedBeads: []string{"gt-1", "gt-2", "gt-3", "gt-4"}},
+			Status: gastownv1alpha1.ConvoyStatus{
+				Phase:          gastownv1alpha1.ConvoyPhaseInProgress,
+				CompletedBeads: []string{"gt-1"},
+			},
+		},
+		&gastownv1alpha1.Refinery{
+			ObjectMeta: metav1.ObjectMeta{Name: "alpha-refinery", Namespace: "default"},
+			Spec:       gastownv1alpha1.RefinerySpec{RigRef: "alpha"},
+			Status:     gastownv1alpha1.RefineryStatus{QueueLength: 2},
+		},
+	).Build()
+
+	expected := `
+# HELP gastown_convoy_progress_ratio Fraction of the convoy's tracked beads that are completed (0-1)
+# TYPE gastown_convoy_progress_ratio gauge
+gastown_convoy_progress_ratio{convoy="wave-1",namespace="default",phase="InProgress"} 0.25
+# HELP gastown_polecats Number of polecats by namespace, rig and phase
+# TYPE gastown_polecats gauge
+gastown_polecats{namespace="default",phase="Done",rig="alpha"} 1
+gastown_polecats{namespace="default",phase="Unknown",rig="beta"} 1
+gastown_polecats{namespace="default",phase="Working",rig="alpha"} 2
+# HELP gastown_refinery_queue_length Number of items in the merge queue by rig
+# TYPE gastown_refinery_queue_length gauge
+gastown_refinery_queue_length{namespace="default",refinery="alpha-refinery",rig="alpha"} 2
+# HELP gastown_rig_polecat_count Number of polecats in the rig as reported in the Rig status
+# TYPE gastown_rig_polecat_count gauge
+gastown_rig_polecat_count{rig="alpha"} 3
+`
+
+	if err := testutil.CollectAndCompare(NewStateCollector(reader), strings.NewReader(expected)); err != nil {
+		t.Error(err)
+	}
+}
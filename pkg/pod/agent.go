@@ -0,0 +1,355 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+)
+
+const (
+	// Agent container names for the non-Claude runtimes
+	OpenCodeContainerName = "opencode"
+	AiderContainerName    = "aider"
+	CustomContainerName   = "agent"
+
+	// Environment variable names for agent image configuration
+	EnvOpenCodeImage = "GASTOWN_OPENCODE_IMAGE"
+	EnvAiderImage    = "GASTOWN_AIDER_IMAGE"
+
+	// Default agent images. The custom agent defaults to the polecat-agent
+	// image, which provides a shell, git and the gh CLI.
+	DefaultOpenCodeImage = "ghcr.io/sst/opencode:latest"
+	DefaultAiderImage    = "paulgauthier/aider:latest"
+)
+
+// AgentRuntime adapts the agent container to one coding agent CLI.
+//
+// The builder owns everything the runtimes share (workspace, git and SSH
+// setup, the prompt, the heartbeat loop); a runtime only contributes what
+// differs between agents. Launch scripts run with $PROMPT set and with
+// spec.agentConfig.args as positional parameters.
+type AgentRuntime interface {
+	// ContainerName is the name of the agent container in the Pod
+	ContainerName() string
+
+	// DefaultImage is used when neither spec.kubernetes.image nor
+	// spec.agentConfig.image is set
+	DefaultImage() string
+
+	// Setup returns shell run before the prompt is built (credentials, CLI checks)
+	Setup() string
+
+	// Launch returns the shell that starts the agent
+	Launch() string
+
+	// Env returns the model, endpoint and credential variables for the agent
+	Env(k8sSpec *gastownv1alpha1.KubernetesSpec, cfg *gastownv1alpha1.AgentConfig) []corev1.EnvVar
+
+	// CredentialMounts returns agent-specific credential volume mounts
+	CredentialMounts(k8sSpec *gastownv1alpha1.KubernetesSpec) []corev1.VolumeMount
+
+	// HeartbeatPaths are the directories whose writes count as agent activity
+	HeartbeatPaths() []string
+
+	// RequiredTools are the executables the agent image must provide
+	RequiredTools() []string
+}
+
+// RuntimeFor returns the runtime for the given agent type.
+// An empty type selects Claude Code, the API default.
+func RuntimeFor(agent gastownv1alpha1.AgentType) (AgentRuntime, error) {
+	switch agent {
+	case "", gastownv1alpha1.AgentTypeClaudeCode:
+		return claudeRuntime{}, nil
+	case gastownv1alpha1.AgentTypeOpenCode:
+		return openCodeRuntime{}, nil
+	case gastownv1alpha1.AgentTypeAider:
+		return aiderRuntime{}, nil
+	case gastownv1alpha1.AgentTypeCustom:
+		return customRuntime{}, nil
+	default:
+		return nil, fmt.Errorf("unsupported agent type %q", agent)
+	}
+}
+
+// AgentContainerName returns the name of the agent container for the agent type,
+// falling back to the Claude container for unknown types.
+func AgentContainerName(agent gastownv1alpha1.AgentType) string {
+	runtime, err := RuntimeFor(agent)
+	if err != nil {
+		return ClaudeContainerName
+	}
+	return runtime.ContainerName()
+}
+
+// GetOpenCodeImage returns the OpenCode image to use: the GastownConfig's, else the
+// environment variable's, else the default
+func GetOpenCodeImage() string {
+	if img := config.Current().OpenCodeImage; img != "" {
+		return img
+	}
+	if img := os.Getenv(EnvOpenCodeImage); img != "" {
+		return img
+	}
+	return DefaultOpenCodeImage
+}
+
+// GetAiderImage returns the Aider image to use: the GastownConfig's, else the
+// environment variable's, else the default
+func GetAiderImage() string {
+	if img := config.Current().AiderImage; img != "" {
+		return img
+	}
+	if img := os.Getenv(EnvAiderImage); img != "" {
+		return img
+	}
+	return DefaultAiderImage
+}
+
+// claudeRuntime runs Claude Code in headless print mode.
+type claudeRuntime struct{}
+
+func (claudeRuntime) ContainerName() string { return ClaudeContainerName }
+
+func (claudeRuntime) DefaultImage() string { return GetClaudeImage() }
+
+func (claudeRuntime) Setup() string {
+	return fmt.Sprintf(`
+# Configure npm for non-root global installs
+export NPM_CONFIG_PREFIX="$HOME/.npm-global"
+export PATH="$HOME/.npm-global/bin:$PATH"
+mkdir -p "$HOME/.npm-global"
+
+# Copy Claude credentials from read-only mount to writable HOME
+mkdir -p "$HOME/.claude"
+if [ -f "%s/.credentials.json" ]; then
+    cp "%s/.credentials.json" "$HOME/.claude/.credentials.json"
+    echo "Claude credentials copied to $HOME/.claude/"
+fi
+
+# Verify Claude Code is available (pre-installed in polecat-agent image)
+echo "Verifying Claude Code CLI..."
+claude --version || { echo "ERROR: Claude CLI not found. Use ghcr.io/boshu2/polecat-agent image."; exit %d; }
+`, ClaudeCredsMountPath, ClaudeCredsMountPath, ExitCodeToolError)
+}
+
+func (claudeRuntime) Launch() string {
+	return `
+# SECURITY: --dangerously-skip-permissions is required for headless operation.
+# This grants elevated privileges to the Claude agent. Mitigations:
+# - Pod runs as non-root with read-only root filesystem
+# - Network policies should restrict outbound traffic
+# - RBAC should limit polecat creation to trusted namespaces
+# See docs/SECURITY.md for full threat model.
+echo "Starting Claude Code agent..."
+if [ -n "$GT_AGENT_MODEL" ]; then
+    set -- --model "$GT_AGENT_MODEL" "$@"
+fi
+exec claude --print --dangerously-skip-permissions "$@" "$PROMPT"
+`
+}
+
+func (claudeRuntime) Env(k8sSpec *gastownv1alpha1.KubernetesSpec, cfg *gastownv1alpha1.AgentConfig) []corev1.EnvVar {
+	// Claude Code always speaks the Anthropic API; LiteLLM gateways proxy it
+	return credentialEnv(k8sSpec, cfg, "ANTHROPIC_API_KEY", "ANTHROPIC_BASE_URL")
+}
+
+func (claudeRuntime) CredentialMounts(k8sSpec *gastownv1alpha1.KubernetesSpec) []corev1.VolumeMount {
+	// OAuth credentials are only mounted when configured
+	if k8sSpec.ClaudeCredsSecretRef == nil && k8sSpec.ClaudeCredsCSI == nil {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      ClaudeCredsVolumeName,
+		MountPath: ClaudeCredsMountPath,
+		ReadOnly:  true,
+	}}
+}
+
+func (claudeRuntime) HeartbeatPaths() []string { return []string{"$HOME/.claude", "$PWD"} }
+
+func (claudeRuntime) RequiredTools() []string { return []string{"claude", "git", "gh"} }
+
+// openCodeRuntime runs OpenCode non-interactively with "opencode run".
+type openCodeRuntime struct{}
+
+func (openCodeRuntime) ContainerName() string { return OpenCodeContainerName }
+
+func (openCodeRuntime) DefaultImage() string { return GetOpenCodeImage() }
+
+func (openCodeRuntime) Setup() string {
+	return fmt.Sprintf(`
+echo "Verifying OpenCode CLI..."
+opencode --version || { echo "ERROR: OpenCode CLI not found in agent image."; exit %d; }
+`, ExitCodeToolError)
+}
+
+func (openCodeRuntime) Launch() string {
+	return `
+echo "Starting OpenCode agent..."
+if [ -n "$GT_AGENT_MODEL" ]; then
+    set -- --model "$GT_AGENT_MODEL" "$@"
+fi
+exec opencode run "$@" "$PROMPT"
+`
+}
+
+func (openCodeRuntime) Env(k8sSpec *gastownv1alpha1.KubernetesSpec, cfg *gastownv1alpha1.AgentConfig) []corev1.EnvVar {
+	switch provider(cfg) {
+	case gastownv1alpha1.LLMProviderAnthropic:
+		return credentialEnv(k8sSpec, cfg, "ANTHROPIC_API_KEY", "ANTHROPIC_BASE_URL")
+	case gastownv1alpha1.LLMProviderOllama:
+		return credentialEnv(k8sSpec, cfg, "", "OLLAMA_HOST")
+	default:
+		return credentialEnv(k8sSpec, cfg, "OPENAI_API_KEY", "OPENAI_BASE_URL")
+	}
+}
+
+func (openCodeRuntime) CredentialMounts(*gastownv1alpha1.KubernetesSpec) []corev1.VolumeMount {
+	return nil
+}
+
+func (openCodeRuntime) HeartbeatPaths() []string {
+	return []string{"$HOME/.local/share/opencode", "$PWD"}
+}
+
+func (openCodeRuntime) RequiredTools() []string { return []string{"opencode", "git", "gh"} }
+
+// aiderRuntime runs Aider for a single message. Aider commits its own edits
+// but does not push, so the launch script pushes the branch afterwards.
+type aiderRuntime struct{}
+
+func (aiderRuntime) ContainerName() string { return AiderContainerName }
+
+func (aiderRuntime) DefaultImage() string { return GetAiderImage() }
+
+func (aiderRuntime) Setup() string {
+	return fmt.Sprintf(`
+echo "Verifying Aider CLI..."
+aider --version || { echo "ERROR: Aider CLI not found in agent image."; exit %d; }
+`, ExitCodeToolError)
+}
+
+func (aiderRuntime) Launch() string {
+	return `
+echo "Starting Aider agent..."
+if [ -n "$GT_AGENT_MODEL" ]; then
+    set -- --model "$GT_AGENT_MODEL" "$@"
+fi
+aider --yes-always --no-check-update --no-show-model-warnings "$@" --message "$PROMPT"
+git push origin HEAD
+`
+}
+
+func (aiderRuntime) Env(k8sSpec *gastownv1alpha1.KubernetesSpec, cfg *gastownv1alpha1.AgentConfig) []corev1.EnvVar {
+	switch provider(cfg) {
+	case gastownv1alpha1.LLMProviderAnthropic:
+		return credentialEnv(k8sSpec, cfg, "ANTHROPIC_API_KEY", "ANTHROPIC_API_BASE")
+	case gastownv1alpha1.LLMProviderOllama:
+		return credentialEnv(k8sSpec, cfg, "", "OLLAMA_API_BASE")
+	default:
+		return credentialEnv(k8sSpec, cfg, "OPENAI_API_KEY", "OPENAI_API_BASE")
+	}
+}
+
+func (aiderRuntime) CredentialMounts(*gastownv1alpha1.KubernetesSpec) []corev1.VolumeMount {
+	return nil
+}
+
+func (aiderRuntime) HeartbeatPaths() []string { return []string{"$PWD"} }
+
+// Aider does not open pull requests, so gh is not required
+func (aiderRuntime) RequiredTools() []string { return []string{"aider", "git"} }
+
+// customRuntime execs spec.agentConfig.command with the prompt in $GT_PROMPT.
+// It is also used for any agent type whose command is overridden.
+type customRuntime struct{}
+
+func (customRuntime) ContainerName() string { return CustomContainerName }
+
+func (customRuntime) DefaultImage() string { return GetClaudeImage() }
+
+func (customRuntime) Setup() string { return "" }
+
+func (customRuntime) Launch() string {
+	return `
+echo "Starting agent: $1"
+export GT_PROMPT="$PROMPT"
+exec "$@"
+`
+}
+
+func (customRuntime) Env(k8sSpec *gastownv1alpha1.KubernetesSpec, cfg *gastownv1alpha1.AgentConfig) []corev1.EnvVar {
+	return credentialEnv(k8sSpec, cfg, "GT_AGENT_API_KEY", "GT_AGENT_ENDPOINT")
+}
+
+func (customRuntime) CredentialMounts(*gastownv1alpha1.KubernetesSpec) []corev1.VolumeMount {
+	return nil
+}
+
+func (customRuntime) HeartbeatPaths() []string { return []string{"$PWD"} }
+
+// The custom command itself is checked by the image probe
+func (customRuntime) RequiredTools() []string { return []string{"git"} }
+
+// provider returns the configured LLM provider, defaulting to LiteLLM.
+func provider(cfg *gastownv1alpha1.AgentConfig) gastownv1alpha1.LLMProvider {
+	if cfg == nil || cfg.Provider == "" {
+		return gastownv1alpha1.LLMProviderLiteLLM
+	}
+	return cfg.Provider
+}
+
+// apiKeySecretRef returns the agent's API key secret. The model provider's
+// key takes precedence over spec.kubernetes.apiKeySecretRef.
+func apiKeySecretRef(k8sSpec *gastownv1alpha1.KubernetesSpec, cfg *gastownv1alpha1.AgentConfig) *gastownv1alpha1.SecretKeyRef {
+	if cfg != nil && cfg.ModelProvider != nil && cfg.ModelProvider.APIKeySecretRef != nil {
+		return cfg.ModelProvider.APIKeySecretRef
+	}
+	return k8sSpec.ApiKeySecretRef
+}
+
+// credentialEnv exposes the API key and endpoint under the variable names
+// the agent reads. An empty name skips that variable.
+func credentialEnv(k8sSpec *gastownv1alpha1.KubernetesSpec, cfg *gastownv1alpha1.AgentConfig,
+	keyVar, endpointVar string) []corev1.EnvVar {
+	var envVars []corev1.EnvVar
+
+	if ref := apiKeySecretRef(k8sSpec, cfg); keyVar != "" && ref != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: keyVar,
+			ValueFrom: &corev1.EnvVarSource{
+				SecretKeyRef: &corev1.SecretKeySelector{
+					LocalObjectReference: corev1.LocalObjectReference{Name: ref.Name},
+					Key:                  ref.Key,
+				},
+			},
+		})
+	}
+
+	if endpointVar != "" && cfg != nil && cfg.ModelProvider != nil && cfg.ModelProvider.Endpoint != "" {
+		envVars = append(envVars, corev1.EnvVar{Name: endpointVar, Value: cfg.ModelProvider.Endpoint})
+	}
+
+	return envVars
+}
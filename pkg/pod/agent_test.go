@@ -0,0 +1,216 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"slices"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func agentPolecat(agent gastownv1alpha1.AgentType, cfg *gastownv1alpha1.AgentConfig) *gastownv1alpha1.Polecat {
+	return &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default"},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:         "test-rig",
+			BeadID:      "test-bead",
+			Agent:       agent,
+			AgentConfig: cfg,
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitBranch:     "main",
+				GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+			},
+		},
+	}
+}
+
+func envByName(c corev1.Container) map[string]corev1.EnvVar {
+	env := make(map[string]corev1.EnvVar)
+	for _, e := range c.Env {
+		env[e.Name] = e
+	}
+	return env
+}
+
+func TestAgentRuntimes(t *testing.T) {
+	apiKey := &gastownv1alpha1.ModelProviderConfig{
+		Endpoint:        "https://llm.example.com/v1",
+		APIKeySecretRef: &gastownv1alpha1.SecretKeyRef{Name: "llm-secret", Key: "token"},
+	}
+
+	tests := []struct {
+		name      string
+		agent     gastownv1alpha1.AgentType
+		cfg       *gastownv1alpha1.AgentConfig
+		container string
+		image     string
+		launch    string
+		keyVar    string
+		baseVar   string
+	}{
+		{
+			name:      "claude-code is the default",
+			container: ClaudeContainerName,
+			image:     DefaultClaudeImage,
+			launch:    "exec claude --print --dangerously-skip-permissions",
+			keyVar:    "ANTHROPIC_API_KEY",
+			baseVar:   "ANTHROPIC_BASE_URL",
+			cfg:       &gastownv1alpha1.AgentConfig{ModelProvider: apiKey},
+		},
+		{
+			name:      "opencode with litellm",
+			agent:     gastownv1alpha1.AgentTypeOpenCode,
+			cfg:       &gastownv1alpha1.AgentConfig{Provider: gastownv1alpha1.LLMProviderLiteLLM, ModelProvider: apiKey},
+			container: OpenCodeContainerName,
+			image:     DefaultOpenCodeImage,
+			launch:    `exec opencode run "$@" "$PROMPT"`,
+			keyVar:    "OPENAI_API_KEY",
+			baseVar:   "OPENAI_BASE_URL",
+		},
+		{
+			name:      "aider with anthropic",
+			agent:     gastownv1alpha1.AgentTypeAider,
+			cfg:       &gastownv1alpha1.AgentConfig{Provider: gastownv1alpha1.LLMProviderAnthropic, ModelProvider: apiKey},
+			container: AiderContainerName,
+			image:     DefaultAiderImage,
+			launch:    "git push origin HEAD",
+			keyVar:    "ANTHROPIC_API_KEY",
+			baseVar:   "ANTHROPIC_API_BASE",
+		},
+		{
+			name:  "custom command",
+			agent: gastownv1alpha1.AgentTypeCustom,
+			cfg: &gastownv1alpha1.AgentConfig{
+				Command:       []string{"/opt/agent/run"},
+				Image:         "example.com/agent:v1",
+				ModelProvider: apiKey,
+			},
+			container: CustomContainerName,
+			image:     "example.com/agent:v1",
+			launch:    `exec "$@"`,
+			keyVar:    "GT_AGENT_API_KEY",
+			baseVar:   "GT_AGENT_ENDPOINT",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod, err := NewBuilder(agentPolecat(tt.agent, tt.cfg)).Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			agent := pod.Spec.Containers[0]
+			if agent.Name != tt.container {
+				t.Errorf("expected container %s, got %s", tt.container, agent.Name)
+			}
+			if agent.Name != AgentContainerName(tt.agent) {
+				t.Errorf("AgentContainerName(%q) = %s, want %s", tt.agent, AgentContainerName(tt.agent), agent.Name)
+			}
+			if agent.Image != tt.image {
+				t.Errorf("expected image %s, got %s", tt.image, agent.Image)
+			}
+			if !strings.Contains(agent.Args[0], tt.launch) {
+				t.Errorf("expected script to contain %q", tt.launch)
+			}
+
+			env := envByName(agent)
+			key, ok := env[tt.keyVar]
+			if !ok || key.ValueFrom == nil || key.ValueFrom.SecretKeyRef.Name != "llm-secret" {
+				t.Errorf("expected %s from llm-secret, got %+v", tt.keyVar, key)
+			}
+			if env[tt.baseVar].Value != apiKey.Endpoint {
+				t.Errorf("expected %s=%s, got %q", tt.baseVar, apiKey.Endpoint, env[tt.baseVar].Value)
+			}
+		})
+	}
+}
+
+func TestAgentConfigWiring(t *testing.T) {
+	t.Run("passes model, args, command override and extra env", func(t *testing.T) {
+		cfg := &gastownv1alpha1.AgentConfig{
+			Model:   "gpt-5",
+			Command: []string{"opencode", "run"},
+			Args:    []string{"--verbose"},
+			Env:     []corev1.EnvVar{{Name: "OPENAI_BASE_URL", Value: "http://gateway"}},
+		}
+		pod, err := NewBuilder(agentPolecat(gastownv1alpha1.AgentTypeOpenCode, cfg)).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		agent := pod.Spec.Containers[0]
+		if want := []string{"gt-agent", "opencode", "run", "--verbose"}; !slices.Equal(agent.Args[1:], want) {
+			t.Errorf("expected positional args %v, got %v", want, agent.Args[1:])
+		}
+		if !strings.Contains(agent.Args[0], `exec "$@"`) {
+			t.Error("expected command override to replace the launcher")
+		}
+		env := envByName(agent)
+		if env["GT_AGENT_MODEL"].Value != "gpt-5" {
+			t.Errorf("expected GT_AGENT_MODEL=gpt-5, got %q", env["GT_AGENT_MODEL"].Value)
+		}
+		if env["OPENAI_BASE_URL"].Value != "http://gateway" {
+			t.Errorf("expected user env to be set, got %q", env["OPENAI_BASE_URL"].Value)
+		}
+	})
+
+	t.Run("falls back to kubernetes apiKeySecretRef", func(t *testing.T) {
+		polecat := agentPolecat(gastownv1alpha1.AgentTypeAider, nil)
+		polecat.Spec.Kubernetes.ApiKeySecretRef = &gastownv1alpha1.SecretKeyRef{Name: "api-key", Key: "key"}
+		pod, err := NewBuilder(polecat).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		key := envByName(pod.Spec.Containers[0])["OPENAI_API_KEY"]
+		if key.ValueFrom == nil || key.ValueFrom.SecretKeyRef.Name != "api-key" {
+			t.Errorf("expected OPENAI_API_KEY from api-key, got %+v", key)
+		}
+	})
+
+	t.Run("mounts claude credentials only for claude-code", func(t *testing.T) {
+		polecat := agentPolecat(gastownv1alpha1.AgentTypeOpenCode, nil)
+		polecat.Spec.Kubernetes.ClaudeCredsSecretRef = &gastownv1alpha1.SecretReference{Name: "claude-secret"}
+		pod, err := NewBuilder(polecat).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, vm := range pod.Spec.Containers[0].VolumeMounts {
+			if vm.Name == ClaudeCredsVolumeName {
+				t.Error("opencode container should not mount claude credentials")
+			}
+		}
+	})
+
+	t.Run("custom agent requires a command", func(t *testing.T) {
+		if _, err := NewBuilder(agentPolecat(gastownv1alpha1.AgentTypeCustom, nil)).Build(); err == nil {
+			t.Error("expected error for custom agent without command")
+		}
+	})
+
+	t.Run("rejects unknown agents", func(t *testing.T) {
+		if _, err := NewBuilder(agentPolecat("cursor", nil)).Build(); err == nil {
+			t.Error("expected error for unknown agent type")
+		}
+	})
+}
@@ -19,12 +19,17 @@ package pod
 import (
 	"fmt"
 	"os"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/yaml"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
 )
 
 const (
@@ -41,6 +46,7 @@ const (
 	HomeVolumeName          = "home"
 	MetricsVolumeName       = "metrics"
 	SSHKnownHostsVolumeName = "ssh-known-hosts"
+	PodInfoVolumeName       = "podinfo"
 
 	// Mount paths
 	WorkspaceMountPath     = "/workspace"
@@ -50,6 +56,20 @@ const (
 	HomeMountPath          = "/home/nonroot"
 	MetricsMountPath       = "/metrics"
 	SSHKnownHostsMountPath = "/ssh-known-hosts"
+	PodInfoMountPath       = "/podinfo" // Downward API: labels, annotations, name, namespace, uid
+
+	// SecretsStoreCSIDriver is the default driver of CSI credential volumes
+	SecretsStoreCSIDriver = "secrets-store.csi.k8s.io"
+
+	// UsageLogPrefix starts the lines the telemetry sidecar logs whenever the
+	// agent's token usage changes:
+	//   gastown-usage input=<tokens> cache_read=<tokens> output=<tokens>
+	UsageLogPrefix = "gastown-usage"
+
+	// SplitLogPrefix starts the lines a planning polecat's agent logs for
+	// each smaller bead it proposes:
+	//   gastown-split {"title": "...", "description": "..."}
+	SplitLogPrefix = "gastown-split"
 
 	// Environment variable names for image configuration
 	EnvGitImage       = "GASTOWN_GIT_IMAGE"
@@ -58,7 +78,7 @@ const (
 
 	// Default images (community edition - vanilla Kubernetes)
 	// Note: Git init uses polecat-agent because it has proper non-root user setup (UID 65532)
-	// For enterprise/FIPS, set env vars to UBI images:
+	// For enterprise/FIPS, set the GastownConfig's spec.images, or env vars, to UBI images:
 	//   GASTOWN_GIT_IMAGE=ghcr.io/boshu2/polecat-agent:0.4.0-fips
 	//   GASTOWN_CLAUDE_IMAGE=ghcr.io/boshu2/polecat-agent:0.4.0-fips
 	//   GASTOWN_TELEMETRY_IMAGE=registry.access.redhat.com/ubi9/ubi-minimal:9.3
@@ -72,6 +92,14 @@ const (
 	DefaultMemoryRequest = "1Gi"
 	DefaultMemoryLimit   = "4Gi"
 
+	// Agent probe defaults (used when spec.kubernetes.probes is set)
+	DefaultHeartbeatFile          = "/tmp/gt-heartbeat"
+	DefaultHeartbeatMaxAgeSeconds = 600
+	DefaultStartupTimeoutSeconds  = 300
+	DefaultProbePeriodSeconds     = 30
+	startupProbePeriodSeconds     = 10
+	heartbeatProbePeriodSeconds   = 15
+
 	// Telemetry sidecar resource defaults
 	TelemetryCPURequest    = "100m"
 	TelemetryCPULimit      = "200m"
@@ -106,7 +134,37 @@ bitbucket.org ssh-rsa AAAAB3NzaC1yc2EAAAADAQABAAABgQDQeJzhupRu0u0cdegZIa8e86EG2q
 
 // Builder constructs Pods for Polecat kubernetes execution
 type Builder struct {
-	polecat *gastownv1alpha1.Polecat
+	polecat        *gastownv1alpha1.Polecat
+	promptTemplate string
+
+	// previousAttempt and previousLogs hand a failed attempt to a retry
+	previousAttempt *ContextAttempt
+	previousLogs    string
+
+	// bead is the metadata of the assigned bead, when known
+	bead *ContextBead
+
+	// grouping labels and annotates the Pod with the bead's grouping
+	grouping BeadGrouping
+
+	// serviceAccount runs the Pod when the Polecat does not name one
+	serviceAccount string
+
+	// imagePullSecrets are used when the Polecat has none of its own
+	imagePullSecrets []corev1.LocalObjectReference
+
+	// signing is the rig's commit signing key, if any
+	signing *gastownv1alpha1.CommitSigningSpec
+
+	// rigRepositories are the repositories of the rig spec.repositories
+	// selects from
+	rigRepositories []gastownv1alpha1.RigRepository
+
+	// defaults are the rig's Pod settings for fields the Polecat leaves unset
+	defaults *gastownv1alpha1.PolecatDefaults
+
+	// standby builds a warm Pod, which waits for its bead (see BuildStandby)
+	standby bool
 }
 
 // NewBuilder creates a new Pod builder for the given Polecat
@@ -114,30 +172,189 @@ func NewBuilder(polecat *gastownv1alpha1.Polecat) *Builder {
 	return &Builder{polecat: polecat}
 }
 
-// GetGitImage returns the git image to use, checking environment variable first
+// WithPromptTemplate replaces the built-in agent prompt with a Go template,
+// rendered with the agent context when the Pod is built
+func (b *Builder) WithPromptTemplate(text string) *Builder {
+	b.promptTemplate = text
+	return b
+}
+
+// WithPreviousAttempt hands the last failed attempt at the bead to the new
+// Pod: its failure is added to the agent context and prompt, and logs (the
+// tail of its log) are written to PreviousAttemptLogFile in the workspace.
+func (b *Builder) WithPreviousAttempt(failure *gastownv1alpha1.PolecatFailure, logs, logsArtifact string) *Builder {
+	b.previousAttempt = &ContextAttempt{
+		Attempt:      failure.Attempt,
+		Reason:       failure.Reason,
+		Message:      failure.Message,
+		LogsArtifact: logsArtifact,
+	}
+	b.previousLogs = logs
+	if logs != "" {
+		b.previousAttempt.LogFile = PreviousAttemptLogFile
+	}
+	return b
+}
+
+// WithBead adds the assigned bead's metadata to the agent context. Its
+// description is the task when the Polecat has no TaskDescription.
+func (b *Builder) WithBead(title, description string, priority *int32) *Builder {
+	b.bead = &ContextBead{Title: title, Description: description, Priority: priority}
+	return b
+}
+
+// WithBeadGrouping labels and annotates the Pod with the assigned bead's
+// labels, epic and estimate.
+func (b *Builder) WithBeadGrouping(grouping BeadGrouping) *Builder {
+	b.grouping = grouping
+	return b
+}
+
+// WithServiceAccount runs the Pod as the given ServiceAccount, unless the
+// Polecat sets spec.kubernetes.serviceAccountName.
+func (b *Builder) WithServiceAccount(name string) *Builder {
+	b.serviceAccount = name
+	return b
+}
+
+// WithImagePullSecrets pulls the Pod's images with the given Secrets, unless
+// the Polecat sets spec.kubernetes.imagePullSecrets.
+func (b *Builder) WithImagePullSecrets(secrets []corev1.LocalObjectReference) *Builder {
+	b.imagePullSecrets = secrets
+	return b
+}
+
+// WithCommitSigning signs the agent's commits with the rig's key. Nil
+// leaves them unsigned.
+func (b *Builder) WithCommitSigning(signing *gastownv1alpha1.CommitSigningSpec) *Builder {
+	b.signing = signing
+	return b
+}
+
+// WithRigRepositories resolves the Polecat's spec.repositories against the
+// rig's repositories, cloning each like an additional repository.
+func (b *Builder) WithRigRepositories(repos []gastownv1alpha1.RigRepository) *Builder {
+	b.rigRepositories = repos
+	return b
+}
+
+// WithPolecatDefaults applies the rig's polecatDefaults to the Pod settings
+// the Polecat does not set in spec.kubernetes. Nil applies none.
+func (b *Builder) WithPolecatDefaults(defaults *gastownv1alpha1.PolecatDefaults) *Builder {
+	b.defaults = defaults
+	return b
+}
+
+// serviceAccountName returns the ServiceAccount running the Pod: the
+// Polecat's own, else the one set with WithServiceAccount. Empty leaves the
+// namespace default.
+func (b *Builder) serviceAccountName() string {
+	if name := b.polecat.Spec.Kubernetes.ServiceAccountName; name != "" {
+		return name
+	}
+	return b.serviceAccount
+}
+
+// imagePullSecretRefs returns the Secrets pulling the Pod's images: the
+// Polecat's own, else those set with WithImagePullSecrets
+func (b *Builder) imagePullSecretRefs() []corev1.LocalObjectReference {
+	if secrets := b.polecat.Spec.Kubernetes.ImagePullSecrets; len(secrets) > 0 {
+		return secrets
+	}
+	return b.imagePullSecrets
+}
+
+// priorityClassName returns the PriorityClass of the Pod: the Polecat's own,
+// else the rig default
+func (b *Builder) priorityClassName() string {
+	if name := b.polecat.Spec.Kubernetes.PriorityClassName; name != "" || b.defaults == nil {
+		return name
+	}
+	return b.defaults.PriorityClassName
+}
+
+// nodeSelector returns the node selector of the Pod: the Polecat's own, else
+// the rig default
+func (b *Builder) nodeSelector() map[string]string {
+	if selector := b.polecat.Spec.Kubernetes.NodeSelector; len(selector) > 0 || b.defaults == nil {
+		return selector
+	}
+	return b.defaults.NodeSelector
+}
+
+// tolerations returns the tolerations of the Pod: the Polecat's own, else
+// the rig default
+func (b *Builder) tolerations() []corev1.Toleration {
+	if tolerations := b.polecat.Spec.Kubernetes.Tolerations; len(tolerations) > 0 || b.defaults == nil {
+		return tolerations
+	}
+	return b.defaults.Tolerations
+}
+
+// task returns the task handed to the agent: the Polecat's TaskDescription,
+// else the bead's title and description
+func (b *Builder) task() string {
+	if b.polecat.Spec.TaskDescription != "" || b.bead == nil {
+		return b.polecat.Spec.TaskDescription
+	}
+	if b.bead.Description == "" {
+		return b.bead.Title
+	}
+	if b.bead.Title == "" {
+		return b.bead.Description
+	}
+	return b.bead.Title + "\n\n" + b.bead.Description
+}
+
+// GetGitImage returns the git image to use: the GastownConfig's, else the
+// environment variable's, else the default
 func GetGitImage() string {
+	if img := config.Current().GitImage; img != "" {
+		return img
+	}
 	if img := os.Getenv(EnvGitImage); img != "" {
 		return img
 	}
 	return DefaultGitImage
 }
 
-// GetClaudeImage returns the Claude image to use, checking environment variable first
+// GetClaudeImage returns the Claude image to use: the GastownConfig's, else the
+// environment variable's, else the default
 func GetClaudeImage() string {
+	if img := config.Current().ClaudeImage; img != "" {
+		return img
+	}
 	if img := os.Getenv(EnvClaudeImage); img != "" {
 		return img
 	}
 	return DefaultClaudeImage
 }
 
-// GetTelemetryImage returns the telemetry sidecar image to use, checking environment variable first
+// GetTelemetryImage returns the telemetry sidecar image to use: the GastownConfig's, else the
+// environment variable's, else the default
 func GetTelemetryImage() string {
+	if img := config.Current().TelemetryImage; img != "" {
+		return img
+	}
 	if img := os.Getenv(EnvTelemetryImage); img != "" {
 		return img
 	}
 	return DefaultTelemetryImage
 }
 
+// mirrorImages rewrites the container images of spec through the
+// GastownConfig's image mirrors, so that Pods pull from a registry the
+// cluster can reach.
+func mirrorImages(spec *corev1.PodSpec) {
+	cfg := config.Current()
+	for i := range spec.InitContainers {
+		spec.InitContainers[i].Image = cfg.MirrorImage(spec.InitContainers[i].Image)
+	}
+	for i := range spec.Containers {
+		spec.Containers[i].Image = cfg.MirrorImage(spec.Containers[i].Image)
+	}
+}
+
 // Build constructs the complete Pod spec for the Polecat
 func (b *Builder) Build() (*corev1.Pod, error) {
 	if b.polecat.Spec.Kubernetes == nil {
@@ -147,47 +364,138 @@ func (b *Builder) Build() (*corev1.Pod, error) {
 	k8sSpec := b.polecat.Spec.Kubernetes
 	podName := fmt.Sprintf("polecat-%s", b.polecat.Name)
 
+	runtime, err := RuntimeFor(b.polecat.Spec.Agent)
+	if err != nil {
+		return nil, err
+	}
+	if err := b.validateRepositories(); err != nil {
+		return nil, err
+	}
+	if b.polecat.Spec.Agent == gastownv1alpha1.AgentTypeCustom &&
+		(b.polecat.Spec.AgentConfig == nil || len(b.polecat.Spec.AgentConfig.Command) == 0) {
+		return nil, fmt.Errorf("agentConfig.command is required for the custom agent")
+	}
+
+	agentContext, err := b.contextJSON()
+	if err != nil {
+		return nil, fmt.Errorf("failed to render agent context: %w", err)
+	}
+
+	var prompt string
+	if b.promptTemplate != "" {
+		if prompt, err = b.RenderPrompt(b.promptTemplate); err != nil {
+			return nil, err
+		}
+	}
+
+	// The bead's grouping never overrides the labels the operator selects by
+	labels := b.grouping.PodLabels()
+	for k, v := range map[string]string{
+		"gastown.io/polecat": b.polecat.Name,
+		"gastown.io/rig":     b.polecat.Spec.Rig,
+		"gastown.io/bead":    b.polecat.Spec.BeadID,
+	} {
+		labels[k] = v
+	}
+	if convoy := b.polecat.Labels[ConvoyLabel]; convoy != "" {
+		labels[ConvoyLabel] = convoy
+	}
+
+	// With probes configured, let the kubelet restart a hung agent in place
+	// instead of failing the whole Pod.
+	restartPolicy := corev1.RestartPolicyNever
+	if k8sSpec.Probes != nil {
+		restartPolicy = corev1.RestartPolicyOnFailure
+	}
+
 	pod := &corev1.Pod{
 		ObjectMeta: metav1.ObjectMeta{
-			Name:      podName,
-			Namespace: b.polecat.Namespace,
-			Labels: map[string]string{
-				"gastown.io/polecat": b.polecat.Name,
-				"gastown.io/rig":     b.polecat.Spec.Rig,
-				"gastown.io/bead":    b.polecat.Spec.BeadID,
-			},
+			Name:        podName,
+			Namespace:   b.polecat.Namespace,
+			Labels:      labels,
+			Annotations: b.grouping.Annotations(),
 		},
 		Spec: corev1.PodSpec{
-			RestartPolicy:         corev1.RestartPolicyNever,
-			ActiveDeadlineSeconds: k8sSpec.ActiveDeadlineSeconds,
-			SecurityContext:       b.buildPodSecurityContext(),
+			RestartPolicy:             restartPolicy,
+			ActiveDeadlineSeconds:     k8sSpec.ActiveDeadlineSeconds,
+			PriorityClassName:         b.priorityClassName(),
+			NodeSelector:              b.nodeSelector(),
+			Tolerations:               b.tolerations(),
+			Affinity:                  k8sSpec.Affinity,
+			TopologySpreadConstraints: k8sSpec.TopologySpreadConstraints,
+			DNSPolicy:                 k8sSpec.DNSPolicy,
+			DNSConfig:                 k8sSpec.DNSConfig,
+			HostAliases:               k8sSpec.HostAliases,
+			ServiceAccountName:        b.serviceAccountName(),
+			ImagePullSecrets:          b.imagePullSecretRefs(),
+			SecurityContext:           b.buildPodSecurityContext(),
 			InitContainers: []corev1.Container{
-				b.buildGitInitContainer(),
+				b.buildGitInitContainer(agentContext),
 			},
 			Containers: []corev1.Container{
-				b.buildClaudeContainer(),
-				b.buildTelemetrySidecar(),
+				b.buildAgentContainer(runtime, prompt),
 			},
 			Volumes: b.buildVolumes(),
 		},
 	}
+	if telemetrySidecar() {
+		pod.Spec.Containers = append(pod.Spec.Containers, b.buildTelemetrySidecar())
+		pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+			Name:         MetricsVolumeName,
+			VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}},
+		})
+	}
+	mirrorImages(&pod.Spec)
 
 	return pod, nil
 }
 
-// buildGitInitContainer creates the git init container spec
-func (b *Builder) buildGitInitContainer() corev1.Container {
-	k8sSpec := b.polecat.Spec.Kubernetes
+// BuildYAML renders the Pod the operator would create for the Polecat as a
+// YAML manifest, owned by the Polecat like the created one, so it can be
+// reviewed before a rig is enabled. The bead metadata, prompt template and
+// rig defaults are the ones set on the builder.
+func (b *Builder) BuildYAML() ([]byte, error) {
+	p, err := b.Build()
+	if err != nil {
+		return nil, err
+	}
+	p.APIVersion = "v1"
+	p.Kind = "Pod"
+	if b.polecat.UID != "" {
+		p.OwnerReferences = []metav1.OwnerReference{*metav1.NewControllerRef(b.polecat,
+			gastownv1alpha1.GroupVersion.WithKind("Polecat"))}
+	}
 
-	// Determine work branch name
-	workBranch := k8sSpec.WorkBranch
-	if workBranch == "" {
-		if b.polecat.Spec.BeadID != "" {
-			workBranch = fmt.Sprintf("feature/%s", b.polecat.Spec.BeadID)
-		} else {
-			// Fallback to polecat name if no BeadID
-			workBranch = fmt.Sprintf("polecat/%s", b.polecat.Name)
-		}
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(p)
+	if err != nil {
+		return nil, fmt.Errorf("failed to convert pod: %w", err)
+	}
+	// Set by the API server
+	delete(obj, "status")
+	unstructured.RemoveNestedField(obj, "metadata", "creationTimestamp")
+	return yaml.Marshal(obj)
+}
+
+// WorkBranch returns the branch the agent commits to, in the primary and
+// every additional repository
+func (b *Builder) WorkBranch() string {
+	if b.polecat.Spec.Kubernetes.WorkBranch != "" {
+		return b.polecat.Spec.Kubernetes.WorkBranch
+	}
+	if b.polecat.Spec.BeadID != "" {
+		return fmt.Sprintf("feature/%s", b.polecat.Spec.BeadID)
+	}
+	// Fallback to polecat name if no BeadID
+	return fmt.Sprintf("polecat/%s", b.polecat.Name)
+}
+
+// buildGitInitContainer creates the git init container spec.
+// agentContext is written to ContextFile for the agent and its hooks.
+func (b *Builder) buildGitInitContainer(agentContext string) corev1.Container {
+	k8sSpec := b.polecat.Spec.Kubernetes
+	workBranch := b.WorkBranch()
+	if b.standby {
+		workBranch = "checked out once a bead is assigned"
 	}
 
 	// Determine SSH strict host key checking mode
@@ -225,59 +533,93 @@ cat > ~/.ssh/known_hosts << 'KNOWN_HOSTS_EOF'
 KNOWN_HOSTS_EOF
 chmod 644 ~/.ssh/known_hosts
 
-# Check if the Git host is in known_hosts
-HOSTNAME=$(echo "%s" | sed -E 's/.*@([^:\/]+).*/\1/' | sed -E 's/.*\/\/([^\/]+).*/\1/')
-if [ -n "$HOSTNAME" ] && ! grep -q "^$HOSTNAME " ~/.ssh/known_hosts; then
-    echo "ERROR: Host $HOSTNAME not in pre-verified known_hosts."
-    echo "For private Git servers, use SSHKnownHostsConfigMapRef to provide verified host keys."
-    echo "See: https://github.com/boshu2/gastown-operator/blob/main/docs/SECURITY.md"
-    exit 1
+# Check if the Git hosts are in known_hosts (not needed when cloning over HTTPS)
+if [ -z "$GT_GIT_HTTPS" ]; then
+for GIT_URL in %s; do
+    HOSTNAME=$(echo "$GIT_URL" | sed -E 's/.*@([^:\/]+).*/\1/' | sed -E 's/.*\/\/([^\/]+).*/\1/')
+    if [ -n "$HOSTNAME" ] && ! grep -q "^$HOSTNAME " ~/.ssh/known_hosts; then
+        echo "ERROR: Host $HOSTNAME not in pre-verified known_hosts."
+        echo "For private Git servers, use SSHKnownHostsConfigMapRef to provide verified host keys."
+        echo "See: https://github.com/boshu2/gastown-operator/blob/main/docs/SECURITY.md"
+        exit 1
+    fi
+done
 fi
-`, PreVerifiedSSHKnownHosts, k8sSpec.GitRepository)
+`, PreVerifiedSSHKnownHosts, strings.Join(b.repositoryURLs(), " "))
 	}
 
 	gitScript := fmt.Sprintf(`
 set -e
+%s
 
-# Setup SSH
+# Setup git credentials: HTTPS credentials are configured as a credential
+# helper and the repositories' SSH URLs rewritten to HTTPS; otherwise the
+# secret holds an SSH key
 mkdir -p ~/.ssh
-cp %s/ssh-privatekey ~/.ssh/id_rsa 2>/dev/null || cp %s/id_rsa ~/.ssh/id_rsa
-chmod 600 ~/.ssh/id_rsa
+if %s; then
+    git config --global credential.helper '%s'
+    for GIT_URL in %s; do
+        HTTPS_URL=$(https_url "$GIT_URL")
+        if [ "$HTTPS_URL" != "$GIT_URL" ]; then
+            git config --global --add url."$HTTPS_URL".insteadOf "$GIT_URL"
+        fi
+    done
+    GT_GIT_HTTPS=1
+    echo "Git HTTPS credentials configured"
+else
+    cp %s/ssh-privatekey ~/.ssh/id_rsa 2>/dev/null || cp %s/id_rsa ~/.ssh/id_rsa
+    chmod 600 ~/.ssh/id_rsa
+fi
 
 # Configure SSH strict host key checking
 echo "StrictHostKeyChecking %s" >> ~/.ssh/config
 %s
-
-# Clone the repository
-echo "Cloning %s branch %s..."
-git clone --depth=1 -b %s %s %s/repo
-
-# Create work branch
-cd %s/repo
-git checkout -b %s
+%s
+%s
 echo "Git setup complete. Working branch: %s"
+
+# Write assignment metadata for the agent and its hooks
+mkdir -p %s
+printf '%%s\n' "$GT_CONTEXT" > %s
+if [ -n "$GT_PREVIOUS_ATTEMPT_LOG" ]; then
+    printf '%%s\n' "$GT_PREVIOUS_ATTEMPT_LOG" > %s
+fi
 `,
+		httpsURLScript,
+		hasHTTPSCredentials(GitCredsMountPath), gitCredentialHelper(GitCredsMountPath),
+		strings.Join(b.polecatSecretURLs(), " "),
 		GitCredsMountPath, GitCredsMountPath,
 		strictHostKeyChecking,
 		knownHostsSetup,
-		k8sSpec.GitRepository, k8sSpec.GitBranch,
-		k8sSpec.GitBranch, k8sSpec.GitRepository, WorkspaceMountPath,
-		WorkspaceMountPath, workBranch, workBranch,
+		b.cloneScript(), b.additionalRepositoriesScript(), workBranch,
+		ContextDir, ContextFile, PreviousAttemptLogFile,
 	)
 
+	env := []corev1.EnvVar{
+		{
+			Name:  "HOME",
+			Value: HomeMountPath,
+		},
+		{
+			Name:  "GT_CONTEXT",
+			Value: agentContext,
+		},
+	}
+	if b.previousLogs != "" {
+		env = append(env, corev1.EnvVar{
+			Name:  "GT_PREVIOUS_ATTEMPT_LOG",
+			Value: b.previousLogs,
+		})
+	}
+
 	return corev1.Container{
 		Name:            GitInitContainerName,
 		Image:           GetGitImage(),
 		Command:         []string{"/bin/sh", "-c"},
 		Args:            []string{gitScript},
 		SecurityContext: b.buildSecurityContext(),
-		Env: []corev1.EnvVar{
-			{
-				Name:  "HOME",
-				Value: HomeMountPath,
-			},
-		},
-		VolumeMounts: b.buildGitInitVolumeMounts(),
+		Env:             env,
+		VolumeMounts:    b.buildGitInitVolumeMounts(),
 	}
 }
 
@@ -314,36 +656,55 @@ func (b *Builder) buildGitInitVolumeMounts() []corev1.VolumeMount {
 		})
 	}
 
-	return mounts
+	return append(mounts, b.additionalRepositoriesVolumeMounts()...)
+}
+
+// agentImage returns the agent container image. spec.kubernetes.image wins
+// over agentConfig.image, which wins over the rig default, then the runtime
+// default.
+func (b *Builder) agentImage(runtime AgentRuntime) string {
+	if b.polecat.Spec.Kubernetes.Image != "" {
+		return b.polecat.Spec.Kubernetes.Image
+	}
+	if cfg := b.polecat.Spec.AgentConfig; cfg != nil && cfg.Image != "" {
+		return cfg.Image
+	}
+	if b.defaults != nil && b.defaults.Image != "" {
+		return b.defaults.Image
+	}
+	return runtime.DefaultImage()
 }
 
-// buildClaudeContainer creates the Claude agent container spec
-func (b *Builder) buildClaudeContainer() corev1.Container {
+// buildAgentContainer creates the coding agent container spec.
+// A non-empty prompt replaces the built-in one.
+func (b *Builder) buildAgentContainer(runtime AgentRuntime, prompt string) corev1.Container {
 	k8sSpec := b.polecat.Spec.Kubernetes
+	cfg := b.polecat.Spec.AgentConfig
+
+	image := b.agentImage(runtime)
+
+	// A command override replaces the agent's launcher but keeps its env and credentials
+	launch := runtime.Launch()
+	var positional []string
+	if cfg != nil {
+		if len(cfg.Command) > 0 {
+			launch = customRuntime{}.Launch()
+			positional = append(positional, cfg.Command...)
+		}
+		positional = append(positional, cfg.Args...)
+	}
 
-	// Use custom image if specified, otherwise use configured default
-	image := GetClaudeImage()
-	if k8sSpec.Image != "" {
-		image = k8sSpec.Image
+	heartbeatPaths := ""
+	for _, path := range append(runtime.HeartbeatPaths(), b.additionalRepositoriesDirs()...) {
+		heartbeatPaths += fmt.Sprintf(" \"%s\"", path)
 	}
 
 	// Build the agent startup script
 	agentScript := fmt.Sprintf(`
 set -e
-
-# Configure npm for non-root global installs
-export NPM_CONFIG_PREFIX="$HOME/.npm-global"
-export PATH="$HOME/.npm-global/bin:$PATH"
-mkdir -p "$HOME/.npm-global"
-
-# Copy Claude credentials from read-only mount to writable HOME
-mkdir -p "$HOME/.claude"
-if [ -f "%s/.credentials.json" ]; then
-    cp "%s/.credentials.json" "$HOME/.claude/.credentials.json"
-    echo "Claude credentials copied to $HOME/.claude/"
-fi
-
-# Configure SSH for git operations (known_hosts already set up by init container)
+%s
+# Configure SSH for git operations (known_hosts and HTTPS credentials already
+# set up by init container)
 mkdir -p "$HOME/.ssh"
 if [ -f "%s/ssh-privatekey" ]; then
     cp "%s/ssh-privatekey" "$HOME/.ssh/id_rsa"
@@ -354,22 +715,28 @@ fi
 # Configure git user for commits
 git config --global user.name "Gas Town Polecat"
 git config --global user.email "polecat@gastown.io"
-
-# Verify Claude Code is available (pre-installed in polecat-agent image)
-echo "Verifying Claude Code CLI..."
-claude --version || { echo "ERROR: Claude CLI not found. Use ghcr.io/boshu2/polecat-agent image."; exit 1; }
-
-# SECURITY: --dangerously-skip-permissions is required for headless operation.
-# This grants elevated privileges to the Claude agent. Mitigations:
-# - Pod runs as non-root with read-only root filesystem
-# - Network policies should restrict outbound traffic
-# - RBAC should limit polecat creation to trusted namespaces
-# See docs/SECURITY.md for full threat model.
-echo "Starting Claude Code agent..."
+%s%s
 echo "Working on issue: $GT_ISSUE"
 
-# Build the prompt with task description if available
-if [ -n "$GT_TASK_DESCRIPTION" ]; then
+# Use the rendered prompt template if configured, otherwise build the prompt
+# with task description if available
+if [ -n "$GT_AGENT_PROMPT" ]; then
+    PROMPT="$GT_AGENT_PROMPT"
+elif [ -n "$GT_SPLIT_OF" ]; then
+    PROMPT="You are a Gas Town planner. Polecat $GT_SPLIT_OF ran out of time or budget
+before finishing this task:
+
+ISSUE: $GT_ISSUE
+TASK: $GT_TASK_DESCRIPTION
+
+INSTRUCTIONS:
+1. Read the repository to understand what the task involves. Do not change,
+   commit or push anything.
+2. Split the task into smaller issues, each small enough to finish on its own
+   and together covering the whole task.
+3. Print each issue on a line of its own, in order, as:
+   %s {\"title\": \"<title>\", \"description\": \"<what to do and how to verify it>\"}"
+elif [ -n "$GT_TASK_DESCRIPTION" ]; then
     echo "=== Task Description ==="
     echo "$GT_TASK_DESCRIPTION"
     echo "========================"
@@ -393,8 +760,84 @@ else
     PROMPT="${PROMPT}After completing: git add, commit, push, and gh pr create --fill."
 fi
 
-exec claude --print --dangerously-skip-permissions "$PROMPT"
-`, ClaudeCredsMountPath, ClaudeCredsMountPath, GitCredsMountPath, GitCredsMountPath)
+# On a retry, tell the agent why the last attempt failed (prompt templates
+# get it from the context file instead)
+if [ -z "$GT_AGENT_PROMPT" ] && [ -n "$GT_PREVIOUS_ATTEMPT" ]; then
+    PROMPT="${PROMPT}
+
+PREVIOUS ATTEMPT: an earlier attempt at this task failed: $GT_PREVIOUS_ATTEMPT
+The end of its log is in $GT_PREVIOUS_ATTEMPT_LOG_FILE (if present). Learn from it
+instead of repeating the same mistake."
+fi
+
+# Point the agent at the other repositories the task spans
+if [ -z "$GT_AGENT_PROMPT" ] && [ -n "$GT_ADDITIONAL_REPOSITORIES" ]; then
+    PROMPT="${PROMPT}
+
+ADDITIONAL REPOSITORIES: this task also spans $GT_ADDITIONAL_REPOSITORIES, each checked out
+on the same work branch. Commit and push your changes in every repository you modify."
+fi
+
+# Continue from the checkpoint the workspace resumed from
+if [ -z "$GT_AGENT_PROMPT" ] && [ -s "$GT_RESUMED_FROM_FILE" ]; then
+    PROMPT="${PROMPT}
+
+RESUMED: an earlier Pod working on this task was stopped. Its work in progress, up to
+commit $(cat "$GT_RESUMED_FROM_FILE"), is checked out on the work branch. Continue from
+it instead of starting over."
+fi
+
+# Heartbeat for liveness probes: touch the heartbeat file whenever the agent
+# writes to its session state or the workspace.
+if [ -n "$GT_HEARTBEAT_FILE" ]; then
+    touch "$GT_HEARTBEAT_FILE"
+    (
+        while sleep 15; do
+            if [ -n "$(find%s -path '*/.git' -prune -o -newer "$GT_HEARTBEAT_FILE" -print 2>/dev/null | head -n 1)" ]; then
+                touch "$GT_HEARTBEAT_FILE"
+            fi
+        done
+    ) &
+fi
+%s
+# Run the agent in its own shell and map its failures onto the exit code
+# contract (see exitcode.go) so the controller can tell them apart.
+export PROMPT
+cat > %s << 'GT_LAUNCH_EOF'
+%s
+GT_LAUNCH_EOF
+{
+    rc=0
+    sh -e %s "$@" || rc=$?
+    echo "$rc" > %s
+} 2>&1 | tee %s
+rc=$(cat %s 2>/dev/null || echo 1)
+
+if [ "$rc" -eq %d ]; then
+    # Planners propose work instead of committing it
+    if [ -z "$GT_SPLIT_OF" ] && [ "$(git rev-list --count "origin/$GT_BASE_BRANCH..HEAD" 2>/dev/null || echo 1)" -eq 0 ]; then
+        echo "ERROR: Agent exited without committing any work"
+        exit %d
+    fi
+elif [ "$rc" -lt %d ] || [ "$rc" -ge %d ]; then
+    if grep -qiE '%s' %s; then
+        echo "ERROR: Agent was rate limited"
+        exit %d
+    elif grep -qiE '%s' %s; then
+        echo "ERROR: Agent credentials were rejected"
+        exit %d
+    elif [ "$rc" -eq 127 ]; then
+        exit %d
+    fi
+fi
+exit "$rc"`, runtime.Setup(), GitCredsMountPath, GitCredsMountPath, b.commitSigningScript(), b.standbyScript(),
+		SplitLogPrefix, heartbeatPaths,
+		b.checkpointScript(), agentLaunchFile, launch, agentLaunchFile, agentExitFile, agentLogFile, agentExitFile,
+		ExitCodeSuccess, ExitCodeTaskIncomplete,
+		ExitCodeTaskIncomplete, ExitCodeToolError+10,
+		rateLimitPattern, agentLogFile, ExitCodeRateLimited,
+		authFailurePattern, agentLogFile, ExitCodeAuthFailure,
+		ExitCodeToolError)
 
 	// Build environment variables
 	envVars := []corev1.EnvVar{
@@ -412,29 +855,79 @@ exec claude --print --dangerously-skip-permissions "$PROMPT"
 		},
 		{
 			Name:  "GT_TASK_DESCRIPTION",
-			Value: b.polecat.Spec.TaskDescription,
+			Value: b.task(),
+		},
+		{
+			Name:  "GT_CONTEXT_FILE",
+			Value: ContextFile,
+		},
+		{
+			Name:  "GT_BASE_BRANCH",
+			Value: k8sSpec.GitBranch,
 		},
 		{
 			Name:  "HOME",
 			Value: HomeMountPath,
 		},
 	}
+	envVars = append(envVars, downwardAPIEnv()...)
 
-	// Add API key from secret if configured
-	if k8sSpec.ApiKeySecretRef != nil {
+	if dirs := b.additionalRepositoriesDirs(); len(dirs) > 0 {
 		envVars = append(envVars, corev1.EnvVar{
-			Name: "ANTHROPIC_API_KEY",
-			ValueFrom: &corev1.EnvVarSource{
-				SecretKeyRef: &corev1.SecretKeySelector{
-					LocalObjectReference: corev1.LocalObjectReference{
-						Name: k8sSpec.ApiKeySecretRef.Name,
-					},
-					Key: k8sSpec.ApiKeySecretRef.Key,
-				},
-			},
+			Name:  "GT_ADDITIONAL_REPOSITORIES",
+			Value: strings.Join(dirs, " "),
+		})
+	}
+
+	// Expose the heartbeat file location to the startup script
+	if k8sSpec.Probes != nil || b.polecat.Spec.HeartbeatTimeoutSeconds != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GT_HEARTBEAT_FILE",
+			Value: heartbeatFile(k8sSpec.Probes),
 		})
 	}
 
+	envVars = append(envVars, b.checkpointEnv()...)
+
+	if prompt != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GT_AGENT_PROMPT",
+			Value: prompt,
+		})
+	}
+
+	if splitOf := b.polecat.Spec.SplitOf; splitOf != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GT_SPLIT_OF",
+			Value: splitOf,
+		})
+	}
+
+	if attempt := b.previousAttempt; attempt != nil {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GT_PREVIOUS_ATTEMPT",
+			Value: fmt.Sprintf("%s (attempt %d): %s", attempt.Reason, attempt.Attempt, attempt.Message),
+		}, corev1.EnvVar{
+			Name:  "GT_PREVIOUS_ATTEMPT_LOG_FILE",
+			Value: PreviousAttemptLogFile,
+		})
+	}
+
+	if cfg != nil && cfg.Model != "" {
+		envVars = append(envVars, corev1.EnvVar{
+			Name:  "GT_AGENT_MODEL",
+			Value: cfg.Model,
+		})
+	}
+
+	// Model credentials and endpoint under the names the agent reads
+	envVars = append(envVars, runtime.Env(k8sSpec, cfg)...)
+
+	// User-provided variables come last so they can override the defaults
+	if cfg != nil {
+		envVars = append(envVars, cfg.Env...)
+	}
+
 	// Build volume mounts
 	volumeMounts := []corev1.VolumeMount{
 		{
@@ -454,22 +947,20 @@ exec claude --print --dangerously-skip-permissions "$PROMPT"
 			Name:      HomeVolumeName,
 			MountPath: HomeMountPath,
 		},
-	}
-
-	// Add claude creds mount only if configured (for OAuth auth)
-	if k8sSpec.ClaudeCredsSecretRef != nil {
-		volumeMounts = append(volumeMounts, corev1.VolumeMount{
-			Name:      ClaudeCredsVolumeName,
-			MountPath: ClaudeCredsMountPath,
+		{
+			Name:      PodInfoVolumeName,
+			MountPath: PodInfoMountPath,
 			ReadOnly:  true,
-		})
+		},
 	}
+	volumeMounts = append(volumeMounts, runtime.CredentialMounts(k8sSpec)...)
+	volumeMounts = append(volumeMounts, b.commitSigningVolumeMounts()...)
 
 	container := corev1.Container{
-		Name:            ClaudeContainerName,
+		Name:            runtime.ContainerName(),
 		Image:           image,
 		Command:         []string{"/bin/sh", "-c"},
-		Args:            []string{agentScript},
+		Args:            append([]string{agentScript, "gt-agent"}, positional...),
 		WorkingDir:      fmt.Sprintf("%s/repo", WorkspaceMountPath),
 		SecurityContext: b.buildSecurityContext(),
 		Env:             envVars,
@@ -477,9 +968,118 @@ exec claude --print --dangerously-skip-permissions "$PROMPT"
 		Resources:       b.buildResources(),
 	}
 
+	if k8sSpec.Probes != nil {
+		container.LivenessProbe = b.buildLivenessProbe()
+		container.StartupProbe = b.buildStartupProbe()
+	}
+	if timeout := b.polecat.Spec.HeartbeatTimeoutSeconds; timeout != nil {
+		container.ReadinessProbe = heartbeatProbe(heartbeatFile(k8sSpec.Probes), *timeout)
+	}
+
 	return container
 }
 
+// downwardAPIEnv exposes Pod fields to the agent through the downward API
+func downwardAPIEnv() []corev1.EnvVar {
+	fields := []struct{ name, path string }{
+		{"GT_POD_NAME", "metadata.name"},
+		{"GT_POD_NAMESPACE", "metadata.namespace"},
+		{"GT_POD_UID", "metadata.uid"},
+		{"GT_NODE_NAME", "spec.nodeName"},
+		{"GT_SERVICE_ACCOUNT", "spec.serviceAccountName"},
+	}
+
+	envVars := make([]corev1.EnvVar, 0, len(fields))
+	for _, f := range fields {
+		envVars = append(envVars, corev1.EnvVar{
+			Name: f.name,
+			ValueFrom: &corev1.EnvVarSource{
+				FieldRef: &corev1.ObjectFieldSelector{FieldPath: f.path},
+			},
+		})
+	}
+	return envVars
+}
+
+// heartbeatFile returns the configured heartbeat file path or the default
+func heartbeatFile(probes *gastownv1alpha1.AgentProbeSpec) string {
+	if probes != nil && probes.HeartbeatFile != "" {
+		return probes.HeartbeatFile
+	}
+	return DefaultHeartbeatFile
+}
+
+// buildLivenessProbe creates the agent liveness probe.
+// Fails when the heartbeat file has not been touched for HeartbeatMaxAgeSeconds.
+func (b *Builder) buildLivenessProbe() *corev1.Probe {
+	probes := b.polecat.Spec.Kubernetes.Probes
+	if probes.LivenessProbe != nil {
+		return probes.LivenessProbe.DeepCopy()
+	}
+
+	maxAge := probes.HeartbeatMaxAgeSeconds
+	if maxAge <= 0 {
+		maxAge = DefaultHeartbeatMaxAgeSeconds
+	}
+	period := probes.PeriodSeconds
+	if period <= 0 {
+		period = DefaultProbePeriodSeconds
+	}
+
+	probe := heartbeatProbe(heartbeatFile(probes), maxAge)
+	probe.PeriodSeconds = period
+	return probe
+}
+
+// heartbeatProbe fails when the heartbeat file is maxAge seconds old or
+// missing.
+func heartbeatProbe(path string, maxAge int32) *corev1.Probe {
+	check := fmt.Sprintf(`test $(( $(date +%%s) - $(stat -c %%Y %s) )) -lt %d`, path, maxAge)
+
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"/bin/sh", "-c", check},
+			},
+		},
+		PeriodSeconds:    heartbeatProbePeriodSeconds,
+		TimeoutSeconds:   5,
+		FailureThreshold: 1,
+	}
+}
+
+// buildStartupProbe creates the agent startup probe.
+// Succeeds once the startup script has written the first heartbeat.
+func (b *Builder) buildStartupProbe() *corev1.Probe {
+	probes := b.polecat.Spec.Kubernetes.Probes
+	if probes.StartupProbe != nil {
+		return probes.StartupProbe.DeepCopy()
+	}
+
+	timeout := probes.StartupTimeoutSeconds
+	if timeout <= 0 {
+		timeout = DefaultStartupTimeoutSeconds
+	}
+
+	return &corev1.Probe{
+		ProbeHandler: corev1.ProbeHandler{
+			Exec: &corev1.ExecAction{
+				Command: []string{"test", "-f", heartbeatFile(probes)},
+			},
+		},
+		PeriodSeconds:    startupProbePeriodSeconds,
+		TimeoutSeconds:   5,
+		FailureThreshold: (timeout + startupProbePeriodSeconds - 1) / startupProbePeriodSeconds,
+	}
+}
+
+// telemetrySidecar reports whether agent Pods get the telemetry sidecar.
+// Deprecated in favor of the operator's OTLP exporter, but still the only
+// source of token usage.
+func telemetrySidecar() bool {
+	return config.Current().Enabled(config.FeatureTelemetrySidecar)
+}
+
 // buildTelemetrySidecar creates the telemetry sidecar container spec
 //
 //nolint:lll // Prometheus metric lines in embedded shell script cannot be broken
@@ -497,10 +1097,26 @@ POLECAT_NAME="${POLECAT_NAME:-unknown}"
 POLECAT_RIG="${POLECAT_RIG:-unknown}"
 POLECAT_BEAD="${POLECAT_BEAD:-unknown}"
 START_TIME=$(date +%s)
+LAST_USAGE=""
+
+# Sum the token usage recorded in claude's session transcripts. A message is
+# recorded once per content block with the same usage, so count each once.
+# Prints: <input incl. cache writes> <cache reads> <output>
+usage() {
+  cat "$AGENT_HOME"/.claude/projects/*/*.jsonl 2>/dev/null | awk '
+    /"usage"/ {
+      if (match($0, /"id":"msg_[^"]*"/)) { id = substr($0, RSTART, RLENGTH); if (seen[id]++) next }
+      n = split("input_tokens cache_creation_input_tokens cache_read_input_tokens output_tokens", keys, " ")
+      for (i = 1; i <= n; i++) if (match($0, "\"" keys[i] "\":[0-9]+")) { v = substr($0, RSTART, RLENGTH); sub(/.*:/, "", v); t[keys[i]] += v }
+    }
+    END { printf "%d %d %d\n", t["input_tokens"] + t["cache_creation_input_tokens"], t["cache_read_input_tokens"], t["output_tokens"] }'
+}
 
 while true; do
   CURRENT_TIME=$(date +%s)
   ELAPSED=$((CURRENT_TIME - START_TIME))
+  USAGE=$(usage)
+  set -- $USAGE
 
   # Write basic metrics in Prometheus format
   {
@@ -516,8 +1132,20 @@ while true; do
     else
       echo "polecat_agent_running{polecat=\"$POLECAT_NAME\",rig=\"$POLECAT_RIG\",bead=\"$POLECAT_BEAD\"} 0"
     fi
+
+    echo "# HELP polecat_tokens_total Tokens used by the agent"
+    echo "# TYPE polecat_tokens_total counter"
+    echo "polecat_tokens_total{polecat=\"$POLECAT_NAME\",rig=\"$POLECAT_RIG\",bead=\"$POLECAT_BEAD\",type=\"input\"} $1"
+    echo "polecat_tokens_total{polecat=\"$POLECAT_NAME\",rig=\"$POLECAT_RIG\",bead=\"$POLECAT_BEAD\",type=\"cache_read\"} $2"
+    echo "polecat_tokens_total{polecat=\"$POLECAT_NAME\",rig=\"$POLECAT_RIG\",bead=\"$POLECAT_BEAD\",type=\"output\"} $3"
   } > /metrics/metrics.txt
 
+  # Report usage changes in the log, where the operator reads them
+  if [ "$USAGE" != "$LAST_USAGE" ]; then
+    echo "` + UsageLogPrefix + ` input=$1 cache_read=$2 output=$3"
+    LAST_USAGE=$USAGE
+  fi
+
   sleep 5
 done
 SCRIPT
@@ -558,12 +1186,22 @@ done
 				Name:  "POLECAT_BEAD",
 				Value: b.polecat.Spec.BeadID,
 			},
+			{
+				Name:  "AGENT_HOME",
+				Value: HomeMountPath,
+			},
 		},
 		VolumeMounts: []corev1.VolumeMount{
 			{
 				Name:      MetricsVolumeName,
 				MountPath: MetricsMountPath,
 			},
+			{
+				// The agent's session transcripts, for token usage
+				Name:      HomeVolumeName,
+				MountPath: HomeMountPath,
+				ReadOnly:  true,
+			},
 			{
 				Name:      TmpVolumeName,
 				MountPath: TmpMountPath,
@@ -594,13 +1232,8 @@ func (b *Builder) buildVolumes() []corev1.Volume {
 			},
 		},
 		{
-			Name: GitCredsVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName:  k8sSpec.GitSecretRef.Name,
-					DefaultMode: int32Ptr(0400),
-				},
-			},
+			Name:         GitCredsVolumeName,
+			VolumeSource: credentialsVolumeSource(k8sSpec.GitCredsCSI, k8sSpec.GitSecretRef.Name, int32Ptr(0400)),
 		},
 		{
 			Name: TmpVolumeName,
@@ -615,22 +1248,37 @@ func (b *Builder) buildVolumes() []corev1.Volume {
 			},
 		},
 		{
-			Name: MetricsVolumeName,
+			Name: PodInfoVolumeName,
 			VolumeSource: corev1.VolumeSource{
-				EmptyDir: &corev1.EmptyDirVolumeSource{},
+				DownwardAPI: &corev1.DownwardAPIVolumeSource{
+					Items: []corev1.DownwardAPIVolumeFile{
+						{Path: "labels", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.labels"}},
+						{Path: "annotations", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.annotations"}},
+						{Path: "name", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.name"}},
+						{Path: "namespace", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.namespace"}},
+						{Path: "uid", FieldRef: &corev1.ObjectFieldSelector{FieldPath: "metadata.uid"}},
+					},
+				},
 			},
 		},
 	}
+	if b.standby {
+		podInfo := volumes[len(volumes)-1].DownwardAPI
+		podInfo.Items = append(podInfo.Items, corev1.DownwardAPIVolumeFile{
+			Path:     assignmentPath,
+			FieldRef: &corev1.ObjectFieldSelector{FieldPath: fmt.Sprintf("metadata.annotations['%s']", AssignmentAnnotation)},
+		})
+	}
 
 	// Add claude creds volume only if configured (for OAuth auth)
-	if k8sSpec.ClaudeCredsSecretRef != nil {
+	if k8sSpec.ClaudeCredsSecretRef != nil || k8sSpec.ClaudeCredsCSI != nil {
+		secretName := ""
+		if k8sSpec.ClaudeCredsSecretRef != nil {
+			secretName = k8sSpec.ClaudeCredsSecretRef.Name
+		}
 		volumes = append(volumes, corev1.Volume{
-			Name: ClaudeCredsVolumeName,
-			VolumeSource: corev1.VolumeSource{
-				Secret: &corev1.SecretVolumeSource{
-					SecretName: k8sSpec.ClaudeCredsSecretRef.Name,
-				},
-			},
+			Name:         ClaudeCredsVolumeName,
+			VolumeSource: credentialsVolumeSource(k8sSpec.ClaudeCredsCSI, secretName, nil),
 		})
 	}
 
@@ -646,7 +1294,34 @@ func (b *Builder) buildVolumes() []corev1.Volume {
 		})
 	}
 
-	return volumes
+	volumes = append(volumes, b.commitSigningVolumes()...)
+	return append(volumes, b.additionalRepositoriesVolumes()...)
+}
+
+// credentialsVolumeSource mounts credentials from a Secrets Store CSI
+// volume when one is configured, else from the named Secret. The CSI driver
+// mounts read-only; file modes are set by its provider.
+func credentialsVolumeSource(csi *gastownv1alpha1.CSISecretVolume, secretName string, mode *int32) corev1.VolumeSource {
+	if csi != nil {
+		driver := csi.Driver
+		if driver == "" {
+			driver = SecretsStoreCSIDriver
+		}
+		return corev1.VolumeSource{
+			CSI: &corev1.CSIVolumeSource{
+				Driver:               driver,
+				ReadOnly:             boolPtr(true),
+				VolumeAttributes:     csi.VolumeAttributes,
+				NodePublishSecretRef: csi.NodePublishSecretRef,
+			},
+		}
+	}
+	return corev1.VolumeSource{
+		Secret: &corev1.SecretVolumeSource{
+			SecretName:  secretName,
+			DefaultMode: mode,
+		},
+	}
 }
 
 // buildResources creates resource requirements
@@ -658,6 +1333,16 @@ func (b *Builder) buildResources() corev1.ResourceRequirements {
 		return *k8sSpec.Resources
 	}
 
+	// The rig's default resources
+	if b.defaults != nil && b.defaults.Resources != nil {
+		return *b.defaults.Resources.DeepCopy()
+	}
+
+	// Operator-wide default resources
+	if resources := config.Current().AgentResources; resources != nil {
+		return *resources.DeepCopy()
+	}
+
 	// Default resources
 	return corev1.ResourceRequirements{
 		Requests: corev1.ResourceList{
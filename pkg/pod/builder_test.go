@@ -19,13 +19,16 @@ package pod
 
 import (
 	"os"
+	"strings"
 	"testing"
 
 	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/yaml"
 
 	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
 )
 
 func TestNewBuilder(t *testing.T) {
@@ -396,6 +399,43 @@ func TestBuildResources(t *testing.T) {
 			t.Errorf("expected memory request 2Gi, got %s", resources.Requests.Memory().String())
 		}
 	})
+
+	t.Run("uses GastownConfig resources", func(t *testing.T) {
+		config.Set(&config.Config{AgentResources: &corev1.ResourceRequirements{
+			Requests: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("250m")},
+		}})
+		defer config.Set(nil)
+
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-polecat",
+				Namespace: "default",
+			},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:    "test-rig",
+				BeadID: "test-bead",
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository:        "git@github.com:org/repo.git",
+					GitBranch:            "main",
+					GitSecretRef:         gastownv1alpha1.SecretReference{Name: "git-secret"},
+					ClaudeCredsSecretRef: &gastownv1alpha1.SecretReference{Name: "claude-secret"},
+				},
+			},
+		}
+
+		pod, err := NewBuilder(polecat).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		resources := pod.Spec.Containers[0].Resources
+		if !resources.Requests.Cpu().Equal(resource.MustParse("250m")) {
+			t.Errorf("expected CPU request 250m, got %s", resources.Requests.Cpu().String())
+		}
+		if _, ok := resources.Limits[corev1.ResourceMemory]; ok {
+			t.Errorf("expected no memory limit, got %s", resources.Limits.Memory().String())
+		}
+	})
 }
 
 func TestTelemetrySidecar(t *testing.T) {
@@ -474,6 +514,22 @@ func TestTelemetrySidecar(t *testing.T) {
 		}
 	})
 
+	t.Run("telemetry sidecar reads token usage from the agent home", func(t *testing.T) {
+		telemetrySidecar := pod.Spec.Containers[1]
+		found := false
+		for _, volumeMount := range telemetrySidecar.VolumeMounts {
+			if volumeMount.Name == HomeVolumeName && volumeMount.MountPath == HomeMountPath {
+				found = volumeMount.ReadOnly
+			}
+		}
+		if !found {
+			t.Error("telemetry sidecar missing read-only home volume mount")
+		}
+		if !strings.Contains(telemetrySidecar.Args[0], "echo \""+UsageLogPrefix+" input=") {
+			t.Error("telemetry sidecar does not log token usage")
+		}
+	})
+
 	t.Run("telemetry sidecar has resource limits", func(t *testing.T) {
 		telemetrySidecar := pod.Spec.Containers[1]
 		resources := telemetrySidecar.Resources
@@ -486,6 +542,24 @@ func TestTelemetrySidecar(t *testing.T) {
 			t.Error("telemetry sidecar missing resource limits")
 		}
 	})
+
+	t.Run("feature gate disables the sidecar", func(t *testing.T) {
+		config.Set(&config.Config{FeatureGates: map[string]bool{config.FeatureTelemetrySidecar: false}})
+		defer config.Set(nil)
+
+		pod, err := NewBuilder(polecat).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(pod.Spec.Containers) != 1 || pod.Spec.Containers[0].Name == TelemetryContainerName {
+			t.Errorf("expected only the agent container, got %d containers", len(pod.Spec.Containers))
+		}
+		for _, volume := range pod.Spec.Volumes {
+			if volume.Name == MetricsVolumeName {
+				t.Error("expected no metrics volume without the sidecar")
+			}
+		}
+	})
 }
 
 func TestGetGitImage(t *testing.T) {
@@ -506,6 +580,18 @@ func TestGetGitImage(t *testing.T) {
 			t.Errorf("expected custom/git:latest, got %s", img)
 		}
 	})
+
+	t.Run("GastownConfig image overrides env var", func(t *testing.T) {
+		_ = os.Setenv(EnvGitImage, "custom/git:latest")
+		defer func() { _ = os.Unsetenv(EnvGitImage) }()
+		config.Set(&config.Config{GitImage: "config/git:latest"})
+		defer config.Set(nil)
+
+		img := GetGitImage()
+		if img != "config/git:latest" {
+			t.Errorf("expected config/git:latest, got %s", img)
+		}
+	})
 }
 
 func TestGetClaudeImage(t *testing.T) {
@@ -548,6 +634,32 @@ func TestGetTelemetryImage(t *testing.T) {
 	})
 }
 
+func TestMirrorImages(t *testing.T) {
+	config.Set(&config.Config{ImageMirrors: map[string]string{
+		"ghcr.io":   "mirror.internal/ghcr",
+		"docker.io": "mirror.internal/dockerhub",
+	}})
+	defer config.Set(nil)
+
+	pod, err := NewBuilder(agentPolecat("", nil)).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := pod.Spec.InitContainers[0].Image; got != "mirror.internal/ghcr/boshu2/polecat-agent:0.4.0" {
+		t.Errorf("expected the git image through the mirror, got %s", got)
+	}
+	for _, c := range pod.Spec.Containers {
+		if !strings.HasPrefix(c.Image, "mirror.internal/") {
+			t.Errorf("expected container %s through a mirror, got %s", c.Name, c.Image)
+		}
+	}
+
+	test := TestPod(TestPodOptions{Name: "test", Namespace: "default", Image: "golang:1.25", Command: "go test ./..."})
+	if got := test.Spec.Containers[0].Image; got != "mirror.internal/dockerhub/library/golang:1.25" {
+		t.Errorf("expected the test image through the mirror, got %s", got)
+	}
+}
+
 func TestContainerEnvironment(t *testing.T) {
 	polecat := &gastownv1alpha1.Polecat{
 		ObjectMeta: metav1.ObjectMeta{
@@ -660,3 +772,669 @@ func TestClaudeCredsVolumeMount(t *testing.T) {
 		}
 	})
 }
+
+func TestCSICredentialVolumes(t *testing.T) {
+	polecat := &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-polecat",
+			Namespace: "default",
+		},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:    "test-rig",
+			BeadID: "test-bead",
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitBranch:     "main",
+				GitCredsCSI: &gastownv1alpha1.CSISecretVolume{
+					VolumeAttributes: map[string]string{"secretProviderClass": "vault-git"},
+				},
+				ClaudeCredsCSI: &gastownv1alpha1.CSISecretVolume{
+					Driver:               "example.csi.io",
+					VolumeAttributes:     map[string]string{"secretProviderClass": "vault-claude"},
+					NodePublishSecretRef: &corev1.LocalObjectReference{Name: "provider-creds"},
+				},
+			},
+		},
+	}
+
+	pod, err := NewBuilder(polecat).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	volumes := map[string]corev1.Volume{}
+	for _, v := range pod.Spec.Volumes {
+		volumes[v.Name] = v
+	}
+
+	git := volumes[GitCredsVolumeName].CSI
+	if git == nil {
+		t.Fatal("expected git creds from a CSI volume")
+	}
+	if git.Driver != SecretsStoreCSIDriver || git.VolumeAttributes["secretProviderClass"] != "vault-git" {
+		t.Errorf("unexpected git creds volume: %+v", git)
+	}
+	if git.ReadOnly == nil || !*git.ReadOnly {
+		t.Error("expected git creds volume to be read-only")
+	}
+
+	claude := volumes[ClaudeCredsVolumeName].CSI
+	if claude == nil {
+		t.Fatal("expected claude creds from a CSI volume")
+	}
+	if claude.Driver != "example.csi.io" || claude.NodePublishSecretRef.Name != "provider-creds" {
+		t.Errorf("unexpected claude creds volume: %+v", claude)
+	}
+
+	mounted := false
+	for _, vm := range pod.Spec.Containers[0].VolumeMounts {
+		mounted = mounted || vm.Name == ClaudeCredsVolumeName
+	}
+	if !mounted {
+		t.Error("expected claude creds to be mounted in the agent container")
+	}
+}
+
+func TestAgentProbes(t *testing.T) {
+	newPolecat := func(probes *gastownv1alpha1.AgentProbeSpec) *gastownv1alpha1.Polecat {
+		return &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-polecat",
+				Namespace: "default",
+			},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:    "test-rig",
+				BeadID: "test-bead",
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository:        "git@github.com:org/repo.git",
+					GitBranch:            "main",
+					GitSecretRef:         gastownv1alpha1.SecretReference{Name: "git-secret"},
+					ClaudeCredsSecretRef: &gastownv1alpha1.SecretReference{Name: "claude-secret"},
+					Probes:               probes,
+				},
+			},
+		}
+	}
+
+	t.Run("no probes by default", func(t *testing.T) {
+		pod, err := NewBuilder(newPolecat(nil)).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		claude := pod.Spec.Containers[0]
+		if claude.LivenessProbe != nil || claude.StartupProbe != nil {
+			t.Error("expected no probes when spec.kubernetes.probes is nil")
+		}
+		if pod.Spec.RestartPolicy != corev1.RestartPolicyNever {
+			t.Error("expected RestartPolicyNever without probes")
+		}
+	})
+
+	t.Run("heartbeat probes with defaults", func(t *testing.T) {
+		pod, err := NewBuilder(newPolecat(&gastownv1alpha1.AgentProbeSpec{})).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pod.Spec.RestartPolicy != corev1.RestartPolicyOnFailure {
+			t.Error("expected RestartPolicyOnFailure with probes")
+		}
+
+		claude := pod.Spec.Containers[0]
+		if claude.LivenessProbe == nil || claude.LivenessProbe.Exec == nil {
+			t.Fatal("expected exec liveness probe")
+		}
+		check := claude.LivenessProbe.Exec.Command[2]
+		if !strings.Contains(check, DefaultHeartbeatFile) || !strings.Contains(check, "-lt 600") {
+			t.Errorf("unexpected liveness check: %s", check)
+		}
+		if claude.LivenessProbe.PeriodSeconds != DefaultProbePeriodSeconds {
+			t.Errorf("expected period %d, got %d", DefaultProbePeriodSeconds, claude.LivenessProbe.PeriodSeconds)
+		}
+
+		if claude.StartupProbe == nil {
+			t.Fatal("expected startup probe")
+		}
+		if claude.StartupProbe.FailureThreshold != 30 {
+			t.Errorf("expected startup failure threshold 30, got %d", claude.StartupProbe.FailureThreshold)
+		}
+
+		found := false
+		for _, env := range claude.Env {
+			if env.Name == "GT_HEARTBEAT_FILE" && env.Value == DefaultHeartbeatFile {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("expected GT_HEARTBEAT_FILE env var")
+		}
+	})
+
+	t.Run("custom probe overrides heartbeat probe", func(t *testing.T) {
+		custom := &corev1.Probe{
+			ProbeHandler: corev1.ProbeHandler{
+				Exec: &corev1.ExecAction{Command: []string{"pgrep", "claude"}},
+			},
+			PeriodSeconds: 15,
+		}
+		pod, err := NewBuilder(newPolecat(&gastownv1alpha1.AgentProbeSpec{
+			HeartbeatFile: "/tmp/custom",
+			LivenessProbe: custom,
+		})).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		claude := pod.Spec.Containers[0]
+		if claude.LivenessProbe.Exec.Command[0] != "pgrep" {
+			t.Errorf("expected custom liveness probe, got %v", claude.LivenessProbe.Exec.Command)
+		}
+		if claude.StartupProbe.Exec.Command[2] != "/tmp/custom" {
+			t.Errorf("expected startup probe on custom heartbeat file, got %v", claude.StartupProbe.Exec.Command)
+		}
+	})
+
+	t.Run("heartbeat timeout adds a readiness probe", func(t *testing.T) {
+		polecat := newPolecat(nil)
+		timeout := int32(120)
+		polecat.Spec.HeartbeatTimeoutSeconds = &timeout
+		pod, err := NewBuilder(polecat).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if pod.Spec.RestartPolicy != corev1.RestartPolicyNever {
+			t.Error("expected a stalled agent not to be restarted without probes")
+		}
+
+		claude := pod.Spec.Containers[0]
+		if claude.LivenessProbe != nil {
+			t.Error("expected no liveness probe without spec.kubernetes.probes")
+		}
+		if claude.ReadinessProbe == nil || claude.ReadinessProbe.Exec == nil {
+			t.Fatal("expected exec readiness probe")
+		}
+		check := claude.ReadinessProbe.Exec.Command[2]
+		if !strings.Contains(check, DefaultHeartbeatFile) || !strings.Contains(check, "-lt 120") {
+			t.Errorf("unexpected readiness check: %s", check)
+		}
+		found := false
+		for _, env := range claude.Env {
+			if env.Name == "GT_HEARTBEAT_FILE" && env.Value == DefaultHeartbeatFile {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("expected GT_HEARTBEAT_FILE env var")
+		}
+	})
+}
+
+func TestAdditionalRepositories(t *testing.T) {
+	newPolecat := func(repos ...gastownv1alpha1.AdditionalRepository) *gastownv1alpha1.Polecat {
+		return &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:    "test-rig",
+				BeadID: "gt-1",
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository:          "git@github.com:org/api.git",
+					GitBranch:              "main",
+					GitSecretRef:           gastownv1alpha1.SecretReference{Name: "git-secret"},
+					AdditionalRepositories: repos,
+				},
+			},
+		}
+	}
+
+	t.Run("clones additional repositories on the work branch", func(t *testing.T) {
+		builder := NewBuilder(newPolecat(
+			gastownv1alpha1.AdditionalRepository{URL: "git@github.com:org/client.git", Path: "client"},
+			gastownv1alpha1.AdditionalRepository{
+				URL:          "git@gitlab.com:org/docs.git",
+				Branch:       "develop",
+				Path:         "docs",
+				GitSecretRef: &gastownv1alpha1.SecretReference{Name: "docs-secret"},
+			},
+		))
+		pod, err := builder.Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		script := pod.Spec.InitContainers[0].Args[0]
+		for _, want := range []string{
+			"for GIT_URL in git@github.com:org/api.git git@github.com:org/client.git git@gitlab.com:org/docs.git; do",
+			`GIT_SSH_COMMAND="ssh" git clone --depth=1 -b main git@github.com:org/client.git /workspace/client`,
+			"git -C /workspace/client checkout -b feature/gt-1",
+			"cp /git-creds-1/ssh-privatekey /home/nonroot/.ssh/id_rsa_1",
+			"git clone --depth=1 -b develop git@gitlab.com:org/docs.git /workspace/docs",
+			`git -C /workspace/docs config core.sshCommand "ssh -i /home/nonroot/.ssh/id_rsa_1 -o IdentitiesOnly=yes"`,
+			"git -C /workspace/docs checkout -b feature/gt-1",
+		} {
+			if !strings.Contains(script, want) {
+				t.Errorf("expected init script to contain %q", want)
+			}
+		}
+
+		var secretVolume *corev1.Volume
+		for i, vol := range pod.Spec.Volumes {
+			if vol.Name == GitCredsVolumeName+"-1" {
+				secretVolume = &pod.Spec.Volumes[i]
+			}
+			if vol.Name == GitCredsVolumeName+"-0" {
+				t.Error("expected no secret volume for a repository using the polecat's secret")
+			}
+		}
+		if secretVolume == nil || secretVolume.Secret.SecretName != "docs-secret" {
+			t.Fatalf("expected volume for docs-secret, got %+v", secretVolume)
+		}
+		mounted := false
+		for _, vm := range pod.Spec.InitContainers[0].VolumeMounts {
+			if vm.Name == secretVolume.Name && vm.MountPath == GitCredsMountPath+"-1" && vm.ReadOnly {
+				mounted = true
+			}
+		}
+		if !mounted {
+			t.Error("expected docs-secret to be mounted read-only in the git init container")
+		}
+
+		found := false
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == "GT_ADDITIONAL_REPOSITORIES" && env.Value == "/workspace/client /workspace/docs" {
+				found = true
+			}
+		}
+		if !found {
+			t.Error("expected GT_ADDITIONAL_REPOSITORIES env var")
+		}
+
+		repos := builder.Context().AdditionalRepositories
+		if len(repos) != 2 || repos[1] != (ContextRepository{
+			Repository: "git@gitlab.com:org/docs.git", Base: "develop", Dir: "/workspace/docs",
+		}) {
+			t.Errorf("unexpected context repositories: %+v", repos)
+		}
+	})
+
+	t.Run("rejects a path clashing with the primary repository", func(t *testing.T) {
+		_, err := NewBuilder(newPolecat(
+			gastownv1alpha1.AdditionalRepository{URL: "git@github.com:org/client.git", Path: "repo"},
+		)).Build()
+		if err == nil {
+			t.Error("expected error for additional repository path repo")
+		}
+	})
+
+	t.Run("clones the selected rig repositories", func(t *testing.T) {
+		polecat := newPolecat(gastownv1alpha1.AdditionalRepository{URL: "git@github.com:org/client.git", Path: "client"})
+		polecat.Spec.Repositories = []string{"web"}
+		rigRepos := []gastownv1alpha1.RigRepository{
+			{Name: "web", GitURL: "git@github.com:org/web.git", Branch: "trunk"},
+			{Name: "infra", GitURL: "git@github.com:org/infra.git"},
+		}
+		builder := NewBuilder(polecat).WithRigRepositories(rigRepos)
+		pod, err := builder.Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		script := pod.Spec.InitContainers[0].Args[0]
+		for _, want := range []string{
+			`GIT_SSH_COMMAND="ssh" git clone --depth=1 -b trunk git@github.com:org/web.git /workspace/web`,
+			"git -C /workspace/web checkout -b feature/gt-1",
+		} {
+			if !strings.Contains(script, want) {
+				t.Errorf("expected init script to contain %q", want)
+			}
+		}
+		if strings.Contains(script, "org/infra.git") {
+			t.Error("expected repositories the polecat did not select not to be cloned")
+		}
+		if repos := builder.Context().AdditionalRepositories; len(repos) != 2 || repos[1].Dir != "/workspace/web" {
+			t.Errorf("unexpected context repositories: %+v", repos)
+		}
+		if len(polecat.Spec.Kubernetes.AdditionalRepositories) != 1 {
+			t.Error("expected the polecat's additional repositories to be left alone")
+		}
+
+		polecat.Spec.Repositories = []string{"mobile"}
+		if _, err := NewBuilder(polecat).WithRigRepositories(rigRepos).Build(); err == nil ||
+			!strings.Contains(err.Error(), `rig test-rig has no repository "mobile"`) {
+			t.Errorf("expected error for an unknown rig repository, got %v", err)
+		}
+	})
+
+	t.Run("leaves single-repository polecats unchanged", func(t *testing.T) {
+		pod, err := NewBuilder(newPolecat()).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == "GT_ADDITIONAL_REPOSITORIES" {
+				t.Error("expected no GT_ADDITIONAL_REPOSITORIES env var")
+			}
+		}
+	})
+}
+
+func TestSchedulingControls(t *testing.T) {
+	polecat := &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default"},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:    "test-rig",
+			BeadID: "gt-1",
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository:     "git@github.com:org/api.git",
+				GitBranch:         "main",
+				GitSecretRef:      gastownv1alpha1.SecretReference{Name: "git-secret"},
+				PriorityClassName: "agents-low",
+				NodeSelector:      map[string]string{"node-pool": "spot"},
+				Tolerations: []corev1.Toleration{{
+					Key:      "spot",
+					Operator: corev1.TolerationOpExists,
+					Effect:   corev1.TaintEffectNoSchedule,
+				}},
+				Affinity: &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{{
+								MatchExpressions: []corev1.NodeSelectorRequirement{{
+									Key:      "nvidia.com/gpu.present",
+									Operator: corev1.NodeSelectorOpIn,
+									Values:   []string{"true"},
+								}},
+							}},
+						},
+					},
+				},
+				TopologySpreadConstraints: []corev1.TopologySpreadConstraint{{
+					MaxSkew:           1,
+					TopologyKey:       "topology.kubernetes.io/zone",
+					WhenUnsatisfiable: corev1.ScheduleAnyway,
+				}},
+			},
+		},
+	}
+
+	pod, err := NewBuilder(polecat).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.Spec.PriorityClassName != "agents-low" {
+		t.Errorf("expected priority class agents-low, got %q", pod.Spec.PriorityClassName)
+	}
+	if pod.Spec.NodeSelector["node-pool"] != "spot" {
+		t.Errorf("expected spot node selector, got %v", pod.Spec.NodeSelector)
+	}
+	if len(pod.Spec.Tolerations) != 1 || pod.Spec.Tolerations[0].Key != "spot" {
+		t.Errorf("expected spot toleration, got %v", pod.Spec.Tolerations)
+	}
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil {
+		t.Error("expected node affinity")
+	}
+	if len(pod.Spec.TopologySpreadConstraints) != 1 {
+		t.Errorf("expected a topology spread constraint, got %v", pod.Spec.TopologySpreadConstraints)
+	}
+}
+
+func TestDNSConfig(t *testing.T) {
+	ndots := "2"
+	polecat := &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default"},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:    "test-rig",
+			BeadID: "gt-1",
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository: "git@git.corp.example:org/api.git",
+				GitBranch:     "main",
+				GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+				DNSPolicy:     corev1.DNSNone,
+				DNSConfig: &corev1.PodDNSConfig{
+					Nameservers: []string{"10.0.0.53", "fd00::53"},
+					Searches:    []string{"corp.example"},
+					Options:     []corev1.PodDNSConfigOption{{Name: "ndots", Value: &ndots}},
+				},
+				HostAliases: []corev1.HostAlias{{IP: "10.0.0.10", Hostnames: []string{"git.corp.example"}}},
+			},
+		},
+	}
+
+	pod, err := NewBuilder(polecat).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.Spec.DNSPolicy != corev1.DNSNone {
+		t.Errorf("expected dns policy None, got %q", pod.Spec.DNSPolicy)
+	}
+	if pod.Spec.DNSConfig == nil || len(pod.Spec.DNSConfig.Nameservers) != 2 {
+		t.Errorf("expected both nameservers, got %+v", pod.Spec.DNSConfig)
+	}
+	if len(pod.Spec.HostAliases) != 1 || pod.Spec.HostAliases[0].Hostnames[0] != "git.corp.example" {
+		t.Errorf("expected git host alias, got %v", pod.Spec.HostAliases)
+	}
+}
+
+func TestServiceAccount(t *testing.T) {
+	newPolecat := func(serviceAccount string) *gastownv1alpha1.Polecat {
+		return &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:    "test-rig",
+				BeadID: "gt-1",
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository:      "git@github.com:org/repo.git",
+					GitBranch:          "main",
+					GitSecretRef:       gastownv1alpha1.SecretReference{Name: "git-secret"},
+					ServiceAccountName: serviceAccount,
+				},
+			},
+		}
+	}
+
+	tests := []struct {
+		name     string
+		polecat  string
+		rig      string
+		expected string
+	}{
+		{name: "namespace default", expected: ""},
+		{name: "rig agent account", rig: "test-rig-agent", expected: "test-rig-agent"},
+		{name: "polecat account wins", polecat: "deployer", rig: "test-rig-agent", expected: "deployer"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pod, err := NewBuilder(newPolecat(tt.polecat)).WithServiceAccount(tt.rig).Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if pod.Spec.ServiceAccountName != tt.expected {
+				t.Errorf("expected service account %q, got %q", tt.expected, pod.Spec.ServiceAccountName)
+			}
+		})
+	}
+}
+
+func TestImagePullSecrets(t *testing.T) {
+	newPolecat := func(secrets ...string) *gastownv1alpha1.Polecat {
+		polecat := &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:    "test-rig",
+				BeadID: "gt-1",
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository: "git@github.com:org/repo.git",
+					GitBranch:     "main",
+					GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+				},
+			},
+		}
+		for _, name := range secrets {
+			polecat.Spec.Kubernetes.ImagePullSecrets = append(polecat.Spec.Kubernetes.ImagePullSecrets,
+				corev1.LocalObjectReference{Name: name})
+		}
+		return polecat
+	}
+	rigSecrets := []corev1.LocalObjectReference{{Name: "rig-registry"}}
+
+	pod, err := NewBuilder(newPolecat()).WithImagePullSecrets(rigSecrets).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pod.Spec.ImagePullSecrets) != 1 || pod.Spec.ImagePullSecrets[0].Name != "rig-registry" {
+		t.Errorf("expected rig pull secret, got %v", pod.Spec.ImagePullSecrets)
+	}
+
+	pod, err = NewBuilder(newPolecat("team-registry", "mirror")).WithImagePullSecrets(rigSecrets).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pod.Spec.ImagePullSecrets) != 2 || pod.Spec.ImagePullSecrets[0].Name != "team-registry" {
+		t.Errorf("expected polecat pull secrets, got %v", pod.Spec.ImagePullSecrets)
+	}
+
+	pod, err = NewBuilder(newPolecat()).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(pod.Spec.ImagePullSecrets) != 0 {
+		t.Errorf("expected no pull secrets, got %v", pod.Spec.ImagePullSecrets)
+	}
+}
+
+func TestPolecatDefaults(t *testing.T) {
+	newPolecat := func() *gastownv1alpha1.Polecat {
+		return &gastownv1alpha1.Polecat{
+			ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default"},
+			Spec: gastownv1alpha1.PolecatSpec{
+				Rig:    "test-rig",
+				BeadID: "gt-1",
+				Kubernetes: &gastownv1alpha1.KubernetesSpec{
+					GitRepository: "git@github.com:org/repo.git",
+					GitBranch:     "main",
+					GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+				},
+			},
+		}
+	}
+	defaults := &gastownv1alpha1.PolecatDefaults{
+		Image: "registry.example.com/agent:rig",
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceCPU: resource.MustParse("4")},
+		},
+		PriorityClassName: "rig-agents",
+		NodeSelector:      map[string]string{"pool": "rig"},
+		Tolerations:       []corev1.Toleration{{Key: "rig", Operator: corev1.TolerationOpExists}},
+	}
+
+	builder := NewBuilder(newPolecat()).WithPolecatDefaults(defaults)
+	if !builder.NeedsImageProbe() {
+		t.Error("expected the rig's default image to be probed")
+	}
+	pod, err := builder.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	agent := pod.Spec.Containers[0]
+	if agent.Image != "registry.example.com/agent:rig" {
+		t.Errorf("expected rig default image, got %q", agent.Image)
+	}
+	if cpu := agent.Resources.Limits[corev1.ResourceCPU]; cpu.String() != "4" {
+		t.Errorf("expected rig default CPU limit, got %s", cpu.String())
+	}
+	if pod.Spec.PriorityClassName != "rig-agents" || pod.Spec.NodeSelector["pool"] != "rig" ||
+		len(pod.Spec.Tolerations) != 1 {
+		t.Errorf("expected rig default scheduling, got %q %v %v",
+			pod.Spec.PriorityClassName, pod.Spec.NodeSelector, pod.Spec.Tolerations)
+	}
+
+	// The polecat's own settings win
+	polecat := newPolecat()
+	polecat.Spec.Kubernetes.Image = "registry.example.com/agent:own"
+	polecat.Spec.Kubernetes.PriorityClassName = "own"
+	polecat.Spec.Kubernetes.NodeSelector = map[string]string{"pool": "own"}
+	pod, err = NewBuilder(polecat).WithPolecatDefaults(defaults).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if pod.Spec.Containers[0].Image != "registry.example.com/agent:own" ||
+		pod.Spec.PriorityClassName != "own" || pod.Spec.NodeSelector["pool"] != "own" {
+		t.Errorf("expected polecat settings, got %q %q %v",
+			pod.Spec.Containers[0].Image, pod.Spec.PriorityClassName, pod.Spec.NodeSelector)
+	}
+	if len(pod.Spec.Tolerations) != 1 || pod.Spec.Tolerations[0].Key != "rig" {
+		t.Errorf("expected rig default tolerations, got %v", pod.Spec.Tolerations)
+	}
+}
+
+func TestBuildYAML(t *testing.T) {
+	polecat := &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default", UID: "1234"},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:    "test-rig",
+			BeadID: "gt-1",
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitBranch:     "main",
+				GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+			},
+		},
+	}
+
+	data, err := NewBuilder(polecat).WithServiceAccount("test-rig-agent").BuildYAML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var p corev1.Pod
+	if err := yaml.UnmarshalStrict(data, &p); err != nil {
+		t.Fatalf("expected a Pod manifest, got %v:\n%s", err, data)
+	}
+	if p.Kind != "Pod" || p.APIVersion != "v1" || p.Name != "polecat-test-polecat" || p.Spec.ServiceAccountName != "test-rig-agent" {
+		t.Errorf("unexpected pod %s/%s %s, service account %q", p.APIVersion, p.Kind, p.Name, p.Spec.ServiceAccountName)
+	}
+	if owner := metav1.GetControllerOf(&p); owner == nil || owner.Kind != "Polecat" || owner.UID != "1234" {
+		t.Errorf("expected the polecat to own the pod, got %v", p.OwnerReferences)
+	}
+	for _, unwanted := range []string{"status:", "creationTimestamp"} {
+		if strings.Contains(string(data), unwanted) {
+			t.Errorf("expected no %s in the manifest:\n%s", unwanted, data)
+		}
+	}
+
+	if _, err := NewBuilder(&gastownv1alpha1.Polecat{}).BuildYAML(); err == nil {
+		t.Error("expected an error without kubernetes spec")
+	}
+}
+
+func TestSplitPlanner(t *testing.T) {
+	polecat := &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: "nux-split", Namespace: "default"},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:     "test-rig",
+			BeadID:  "gt-1",
+			SplitOf: "nux",
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository: "git@github.com:org/repo.git",
+				GitBranch:     "main",
+				GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+			},
+		},
+	}
+
+	pod, err := NewBuilder(polecat).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	agent := pod.Spec.Containers[0]
+
+	var splitOf string
+	for _, env := range agent.Env {
+		if env.Name == "GT_SPLIT_OF" {
+			splitOf = env.Value
+		}
+	}
+	if splitOf != "nux" {
+		t.Errorf("expected GT_SPLIT_OF=nux, got %q", splitOf)
+	}
+	if !strings.Contains(agent.Args[0], SplitLogPrefix+` {\"title\"`) {
+		t.Error("expected the planning prompt to ask for " + SplitLogPrefix + " lines")
+	}
+}
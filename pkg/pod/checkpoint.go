@@ -0,0 +1,163 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// DefaultCheckpointIntervalSeconds is how often work in progress is
+	// checkpointed when spec.kubernetes.checkpoint sets no interval
+	DefaultCheckpointIntervalSeconds = 600
+
+	// CheckpointFile holds the last commit the agent container pushed to
+	// the work branch
+	CheckpointFile = ContextDir + "/checkpoint"
+
+	// ResumedFromFile holds the checkpoint commit the workspace resumed
+	// from, when the work branch already existed
+	ResumedFromFile = ContextDir + "/resumed-from"
+)
+
+// commitPattern matches a full SHA-1 or SHA-256 commit hash
+var commitPattern = regexp.MustCompile(`^[0-9a-f]{40}([0-9a-f]{24})?$`)
+
+// checkpointInterval returns the checkpoint interval in seconds, or 0 when
+// the Polecat does not checkpoint its work.
+func (b *Builder) checkpointInterval() int32 {
+	checkpoint := b.polecat.Spec.Kubernetes.Checkpoint
+	if checkpoint == nil {
+		return 0
+	}
+	if checkpoint.IntervalSeconds > 0 {
+		return checkpoint.IntervalSeconds
+	}
+	return DefaultCheckpointIntervalSeconds
+}
+
+// cloneScript clones the primary repository into the workspace and checks
+// out the work branch. With checkpoints, a work branch already on the remote
+// is cloned instead of the base branch, and its commit recorded in
+// ResumedFromFile and the init container's termination message. Warm Pods
+// stay on the base branch until a bead is assigned.
+func (b *Builder) cloneScript() string {
+	k8sSpec := b.polecat.Spec.Kubernetes
+	workBranch := b.WorkBranch()
+
+	if b.standby {
+		return fmt.Sprintf(`
+# Clone the repository
+echo "Cloning %[1]s branch %[2]s..."
+git clone --depth=1 -b %[2]s %[1]s %[3]s/repo`, k8sSpec.GitRepository, k8sSpec.GitBranch, WorkspaceMountPath)
+	}
+
+	clone := fmt.Sprintf(`echo "Cloning %[1]s branch %[2]s..."
+git clone --depth=1 -b %[2]s %[1]s %[3]s/repo
+
+# Create work branch (or stay on the cloned branch when they are the same)
+cd %[3]s/repo
+git checkout -B %[4]s`, k8sSpec.GitRepository, k8sSpec.GitBranch, WorkspaceMountPath, workBranch)
+	// Polecats committing to the base branch have nothing to resume from
+	if b.checkpointInterval() == 0 || workBranch == k8sSpec.GitBranch {
+		return "\n# Clone the repository\n" + clone
+	}
+
+	return fmt.Sprintf(`
+# Resume from the last checkpoint pushed to the work branch, or clone the
+# repository
+if git ls-remote --exit-code --heads %[1]s %[4]s >/dev/null 2>&1; then
+    echo "Resuming %[1]s from work branch %[4]s..."
+    git clone --depth=1 -b %[4]s %[1]s %[3]s/repo
+    cd %[3]s/repo
+    git fetch --depth=1 origin %[2]s:refs/remotes/origin/%[2]s
+    mkdir -p %[5]s
+    git rev-parse HEAD | tee %[6]s > /dev/termination-log
+    echo "Resumed from checkpoint $(cat %[6]s)"
+else
+%[7]s
+fi`, k8sSpec.GitRepository, k8sSpec.GitBranch, WorkspaceMountPath, workBranch,
+		ContextDir, ResumedFromFile, indent(clone, "    "))
+}
+
+// checkpointScript starts the agent container's checkpoint loop, which
+// commits uncommitted work and pushes the work branch whenever it moved
+// since the last checkpoint.
+func (b *Builder) checkpointScript() string {
+	if b.checkpointInterval() == 0 {
+		return ""
+	}
+	return fmt.Sprintf(`
+# Checkpoint work in progress: commit and push it to the work branch every
+# GT_CHECKPOINT_INTERVAL seconds, so a Pod replacing this one resumes from it
+git rev-parse HEAD > %[1]s
+(
+    while sleep "$GT_CHECKPOINT_INTERVAL"; do
+        if [ -n "$(git status --porcelain 2>/dev/null)" ]; then
+            git add -A && git commit -q --no-verify -m "wip($GT_ISSUE): checkpoint" || continue
+        fi
+        HEAD_COMMIT=$(git rev-parse HEAD)
+        if [ "$HEAD_COMMIT" != "$(cat %[1]s)" ] && git push -q origin "HEAD:refs/heads/$GT_WORK_BRANCH"; then
+            echo "$HEAD_COMMIT" > %[1]s
+            echo "Checkpointed $HEAD_COMMIT to $GT_WORK_BRANCH"
+        fi
+    done
+) &
+`, CheckpointFile)
+}
+
+// checkpointEnv returns the agent container's checkpoint settings, if any
+func (b *Builder) checkpointEnv() []corev1.EnvVar {
+	interval := b.checkpointInterval()
+	if interval == 0 {
+		return nil
+	}
+	return []corev1.EnvVar{
+		{Name: "GT_CHECKPOINT_INTERVAL", Value: fmt.Sprintf("%d", interval)},
+		{Name: "GT_WORK_BRANCH", Value: b.WorkBranch()},
+		{Name: "GT_RESUMED_FROM_FILE", Value: ResumedFromFile},
+	}
+}
+
+// ResumedFrom returns the checkpoint commit a polecat Pod's workspace
+// resumed from, as its git init container reported it, or "".
+func ResumedFrom(p *corev1.Pod) string {
+	for _, cs := range p.Status.InitContainerStatuses {
+		if cs.Name != GitInitContainerName || cs.State.Terminated == nil || cs.State.Terminated.ExitCode != 0 {
+			continue
+		}
+		if commit := strings.TrimSpace(cs.State.Terminated.Message); commitPattern.MatchString(commit) {
+			return commit
+		}
+	}
+	return ""
+}
+
+// indent prefixes the non-empty lines of s with prefix
+func indent(s, prefix string) string {
+	lines := strings.Split(s, "\n")
+	for i, line := range lines {
+		if line != "" {
+			lines[i] = prefix + line
+		}
+	}
+	return strings.Join(lines, "\n")
+}
@@ -0,0 +1,125 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"os/exec"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func TestCheckpoint(t *testing.T) {
+	t.Run("clones the base branch without checkpoints", func(t *testing.T) {
+		p, err := NewBuilder(newContextPolecat()).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if strings.Contains(p.Spec.InitContainers[0].Args[0], "ls-remote") {
+			t.Error("expected no resume from the work branch")
+		}
+		if strings.Contains(p.Spec.Containers[0].Args[0], "Checkpointed") {
+			t.Error("expected no checkpoint loop")
+		}
+		if env := envByName(p.Spec.Containers[0])["GT_CHECKPOINT_INTERVAL"].Value; env != "" {
+			t.Errorf("expected no checkpoint interval, got %q", env)
+		}
+	})
+
+	t.Run("resumes from the work branch and checkpoints to it", func(t *testing.T) {
+		polecat := newContextPolecat()
+		polecat.Spec.Kubernetes.Checkpoint = &gastownv1alpha1.CheckpointSpec{}
+		p, err := NewBuilder(polecat).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		initScript := p.Spec.InitContainers[0].Args[0]
+		for _, want := range []string{
+			"git ls-remote --exit-code --heads git@github.com:org/repo.git feature/gt-1",
+			"git clone --depth=1 -b feature/gt-1 git@github.com:org/repo.git /workspace/repo",
+			"git fetch --depth=1 origin main:refs/remotes/origin/main",
+			"git rev-parse HEAD | tee " + ResumedFromFile + " > /dev/termination-log",
+			"    git clone --depth=1 -b main git@github.com:org/repo.git /workspace/repo",
+		} {
+			if !strings.Contains(initScript, want) {
+				t.Errorf("expected init script to contain %q", want)
+			}
+		}
+
+		agent := p.Spec.Containers[0]
+		if !strings.Contains(agent.Args[0], `git push -q origin "HEAD:refs/heads/$GT_WORK_BRANCH"`) {
+			t.Error("expected the checkpoint loop to push the work branch")
+		}
+		for name, want := range map[string]string{
+			"GT_CHECKPOINT_INTERVAL": "600",
+			"GT_WORK_BRANCH":         "feature/gt-1",
+			"GT_RESUMED_FROM_FILE":   ResumedFromFile,
+		} {
+			if got := envByName(agent)[name].Value; got != want {
+				t.Errorf("expected %s=%q, got %q", name, want, got)
+			}
+		}
+
+		for name, script := range map[string]string{"init": initScript, "agent": agent.Args[0]} {
+			if out, err := exec.Command("sh", "-n", "-c", script).CombinedOutput(); err != nil {
+				t.Errorf("%s script is not valid shell: %v\n%s", name, err, out)
+			}
+		}
+	})
+
+	t.Run("uses the configured interval", func(t *testing.T) {
+		polecat := newContextPolecat()
+		polecat.Spec.Kubernetes.Checkpoint = &gastownv1alpha1.CheckpointSpec{IntervalSeconds: 120}
+		p, err := NewBuilder(polecat).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := envByName(p.Spec.Containers[0])["GT_CHECKPOINT_INTERVAL"].Value; got != "120" {
+			t.Errorf("expected interval 120, got %q", got)
+		}
+	})
+}
+
+func TestResumedFrom(t *testing.T) {
+	commit := "0123456789abcdef0123456789abcdef01234567"
+	initStatus := func(exitCode int32, message string) *corev1.Pod {
+		return &corev1.Pod{Status: corev1.PodStatus{InitContainerStatuses: []corev1.ContainerStatus{{
+			Name: GitInitContainerName,
+			State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{
+				ExitCode: exitCode,
+				Message:  message,
+			}},
+		}}}}
+	}
+
+	if got := ResumedFrom(initStatus(0, commit+"\n")); got != commit {
+		t.Errorf("expected %s, got %q", commit, got)
+	}
+	if got := ResumedFrom(initStatus(1, commit)); got != "" {
+		t.Errorf("expected no commit from a failed init container, got %q", got)
+	}
+	if got := ResumedFrom(initStatus(0, "fatal: not a commit")); got != "" {
+		t.Errorf("expected no commit from another message, got %q", got)
+	}
+	if got := ResumedFrom(&corev1.Pod{}); got != "" {
+		t.Errorf("expected no commit before the init container ran, got %q", got)
+	}
+}
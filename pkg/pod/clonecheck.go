@@ -0,0 +1,136 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// CloneCheckLabel marks the Pods checking that a rig's repository can be
+	// cloned, with the name of the rig
+	CloneCheckLabel = "gastown.io/clone-check"
+
+	// CloneCheckContainerName is the container cloning the repository
+	CloneCheckContainerName = "clone"
+
+	// cloneCheckDeadlineSeconds bounds the clone once the Pod has started
+	cloneCheckDeadlineSeconds = 300
+)
+
+// cloneCheckScript clones the default branch of the repository with the
+// credentials agents use, and reports the failure in the termination message.
+var cloneCheckScript = gitCredsScript + fmt.Sprintf(`
+echo "Cloning $GT_GIT_URL..."
+if ! git clone --depth=1 "$GT_GIT_URL" %[1]s/repo 2>%[1]s/clone.log; then
+    tail -n 5 %[1]s/clone.log | tee /dev/termination-log
+    exit 1
+fi
+echo "Cloned $GT_GIT_URL at $(git -C %[1]s/repo rev-parse --short HEAD)"
+`, WorkspaceMountPath)
+
+// CloneCheckOptions describes a clone check Pod.
+type CloneCheckOptions struct {
+	// Name and Namespace of the Pod
+	Name      string
+	Namespace string
+
+	// Rig whose repository is checked
+	Rig string
+
+	// Image runs the clone; empty uses the git image of agent Pods
+	Image string
+
+	// GitURL is the repository cloned
+	GitURL string
+
+	// GitSecretName is the Secret holding the SSH key or HTTPS credentials
+	// of the clone; empty clones without credentials
+	GitSecretName string
+}
+
+// CloneCheckPod builds a throwaway Pod that clones the rig's repository the
+// way the git-init container of agent Pods does, so that credentials, known
+// hosts and network policies are checked from inside the cluster.
+func CloneCheckPod(opts CloneCheckOptions) *corev1.Pod {
+	restricted := &Builder{}
+
+	image := opts.Image
+	if image == "" {
+		image = GetGitImage()
+	}
+
+	mounts := []corev1.VolumeMount{
+		{Name: WorkspaceVolumeName, MountPath: WorkspaceMountPath},
+		{Name: HomeVolumeName, MountPath: HomeMountPath},
+		{Name: TmpVolumeName, MountPath: TmpMountPath},
+	}
+	volumes := []corev1.Volume{
+		{Name: WorkspaceVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: HomeVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: TmpVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	if opts.GitSecretName != "" {
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      GitCredsVolumeName,
+			MountPath: GitCredsMountPath,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: GitCredsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  opts.GitSecretName,
+					DefaultMode: int32Ptr(0o400),
+				},
+			},
+		})
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opts.Name,
+			Namespace: opts.Namespace,
+			Labels:    map[string]string{CloneCheckLabel: opts.Rig},
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds:        int64Ptr(cloneCheckDeadlineSeconds),
+			AutomountServiceAccountToken: boolPtr(false),
+			SecurityContext:              restricted.buildPodSecurityContext(),
+			Containers: []corev1.Container{{
+				Name:    CloneCheckContainerName,
+				Image:   image,
+				Command: []string{"/bin/sh", "-c"},
+				Args:    []string{cloneCheckScript},
+				Env: []corev1.EnvVar{
+					{Name: "HOME", Value: HomeMountPath},
+					{Name: "GT_GIT_URL", Value: opts.GitURL},
+				},
+				SecurityContext:          restricted.buildSecurityContext(),
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				VolumeMounts:             mounts,
+			}},
+			Volumes: volumes,
+		},
+	}
+	mirrorImages(&pod.Spec)
+	return pod
+}
@@ -0,0 +1,85 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestCloneCheckPod(t *testing.T) {
+	opts := CloneCheckOptions{
+		Name:      "widgets-clone-check",
+		Namespace: "gastown",
+		Rig:       "widgets",
+		GitURL:    "git@github.com:acme/widgets.git",
+	}
+
+	t.Run("clones once with the git image", func(t *testing.T) {
+		p := CloneCheckPod(opts)
+		if p.Labels[CloneCheckLabel] != "widgets" {
+			t.Errorf("unexpected labels: %v", p.Labels)
+		}
+		if p.Spec.RestartPolicy != corev1.RestartPolicyNever || *p.Spec.ActiveDeadlineSeconds != cloneCheckDeadlineSeconds {
+			t.Errorf("expected a single bounded run, got %s %v", p.Spec.RestartPolicy, p.Spec.ActiveDeadlineSeconds)
+		}
+
+		clone := p.Spec.Containers[0]
+		if clone.Image != GetGitImage() {
+			t.Errorf("expected the git image, got %s", clone.Image)
+		}
+		env := map[string]string{}
+		for _, e := range clone.Env {
+			env[e.Name] = e.Value
+		}
+		if env["GT_GIT_URL"] != opts.GitURL {
+			t.Errorf("expected the URL in the environment, got %v", env)
+		}
+		if strings.Contains(clone.Args[0], opts.GitURL) {
+			t.Error("the URL must not be interpreted by the shell")
+		}
+		if len(p.Spec.Volumes) != 3 {
+			t.Errorf("expected no credentials without a secret, got %v", p.Spec.Volumes)
+		}
+	})
+
+	t.Run("mounts the git secret", func(t *testing.T) {
+		withSecret := opts
+		withSecret.GitSecretName = "git-creds"
+		withSecret.Image = "alpine/git:2.47"
+		p := CloneCheckPod(withSecret)
+
+		if p.Spec.Containers[0].Image != "alpine/git:2.47" {
+			t.Errorf("expected the image override, got %s", p.Spec.Containers[0].Image)
+		}
+		var mounted bool
+		for _, m := range p.Spec.Containers[0].VolumeMounts {
+			mounted = mounted || (m.Name == GitCredsVolumeName && m.MountPath == GitCredsMountPath && m.ReadOnly)
+		}
+		var secret string
+		for _, v := range p.Spec.Volumes {
+			if v.Secret != nil {
+				secret = v.Secret.SecretName
+			}
+		}
+		if !mounted || secret != "git-creds" {
+			t.Errorf("expected git-creds mounted read-only, got %v", p.Spec.Volumes)
+		}
+	})
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"text/template"
+)
+
+const (
+	// ConvoyLabel marks a Polecat (and its Pod) as part of a convoy
+	ConvoyLabel = "gastown.io/convoy"
+
+	// LinkAnnotationPrefix marks Polecat annotations that are copied into the
+	// context file links, e.g. "links.gastown.io/issue": "https://..."
+	LinkAnnotationPrefix = "links.gastown.io/"
+
+	// ContextDir holds the assignment metadata written by the init container
+	ContextDir = WorkspaceMountPath + "/.gt"
+	// ContextFile is the assignment metadata file read by agents and hooks
+	ContextFile = ContextDir + "/context.json"
+	// PreviousAttemptLogFile holds the end of the log of the last failed
+	// attempt at the bead, when the Pod is a retry
+	PreviousAttemptLogFile = ContextDir + "/previous-attempt.log"
+
+	// PromptTemplateKey is the ConfigMap key holding a prompt template
+	PromptTemplateKey = "prompt.tmpl"
+)
+
+// AgentContext is the content of ContextFile. It describes the assignment
+// so agents and hooks can introspect it without parsing env vars.
+type AgentContext struct {
+	Polecat   string            `json:"polecat"`
+	Namespace string            `json:"namespace"`
+	Rig       string            `json:"rig"`
+	Bead      string            `json:"bead,omitempty"`
+	Convoy    string            `json:"convoy,omitempty"`
+	Task      string            `json:"task,omitempty"`
+	Branch    ContextBranch     `json:"branch"`
+	Deadlines ContextDeadlines  `json:"deadlines"`
+	Links     map[string]string `json:"links,omitempty"`
+
+	// BeadInfo is the bead's metadata, when the rig has a BeadStore holding it
+	BeadInfo *ContextBead `json:"beadInfo,omitempty"`
+
+	// PreviousAttempt is set when the Pod retries a bead whose last attempt failed
+	PreviousAttempt *ContextAttempt `json:"previousAttempt,omitempty"`
+
+	// SplitOf is the polecat whose bead a planning polecat proposes to split
+	SplitOf string `json:"splitOf,omitempty"`
+
+	// AdditionalRepositories are the repos cloned besides the primary one,
+	// each on the work branch
+	AdditionalRepositories []ContextRepository `json:"additionalRepositories,omitempty"`
+}
+
+// ContextBead is the metadata of the assigned bead
+type ContextBead struct {
+	Title       string `json:"title,omitempty"`
+	Description string `json:"description,omitempty"`
+
+	// Priority ranges from 0 (critical) to 4 (backlog)
+	Priority *int32 `json:"priority,omitempty"`
+}
+
+// ContextAttempt describes the last failed attempt at the bead
+type ContextAttempt struct {
+	Attempt int32  `json:"attempt"`
+	Reason  string `json:"reason"`
+	Message string `json:"message,omitempty"`
+
+	// LogFile is PreviousAttemptLogFile when the attempt's log was captured
+	LogFile string `json:"logFile,omitempty"`
+
+	// LogsArtifact is the URL of the attempt's full log in the rig's log archive
+	LogsArtifact string `json:"logsArtifact,omitempty"`
+}
+
+// ContextBranch describes where the agent's work comes from and goes to
+type ContextBranch struct {
+	Repository string `json:"repository"`
+	Base       string `json:"base"`
+	Work       string `json:"work"`
+}
+
+// ContextRepository is an additional repo checked out in the workspace
+type ContextRepository struct {
+	Repository string `json:"repository"`
+	Base       string `json:"base"`
+	Dir        string `json:"dir"`
+}
+
+// ContextDeadlines are the time limits of the assignment, in seconds.
+// ActiveDeadlineSeconds counts from Pod start; MaxIdleSeconds from the last
+// agent activity.
+type ContextDeadlines struct {
+	ActiveDeadlineSeconds   *int64 `json:"activeDeadlineSeconds,omitempty"`
+	MaxIdleSeconds          *int32 `json:"maxIdleSeconds,omitempty"`
+	TTLSecondsAfterFinished *int32 `json:"ttlSecondsAfterFinished,omitempty"`
+}
+
+// Context returns the assignment metadata for the Polecat
+func (b *Builder) Context() AgentContext {
+	k8sSpec := b.polecat.Spec.Kubernetes
+
+	ctx := AgentContext{
+		Polecat:   b.polecat.Name,
+		Namespace: b.polecat.Namespace,
+		Rig:       b.polecat.Spec.Rig,
+		Bead:      b.polecat.Spec.BeadID,
+		Convoy:    b.polecat.Labels[ConvoyLabel],
+		Task:      b.task(),
+		BeadInfo:  b.bead,
+		Branch: ContextBranch{
+			Repository: k8sSpec.GitRepository,
+			Base:       k8sSpec.GitBranch,
+			Work:       b.WorkBranch(),
+		},
+		Deadlines: ContextDeadlines{
+			ActiveDeadlineSeconds:   k8sSpec.ActiveDeadlineSeconds,
+			MaxIdleSeconds:          b.polecat.Spec.MaxIdleSeconds,
+			TTLSecondsAfterFinished: b.polecat.Spec.TTLSecondsAfterFinished,
+		},
+		PreviousAttempt: b.previousAttempt,
+		SplitOf:         b.polecat.Spec.SplitOf,
+	}
+
+	for _, repo := range b.additionalRepositories() {
+		ctx.AdditionalRepositories = append(ctx.AdditionalRepositories, ContextRepository{
+			Repository: repo.URL,
+			Base:       repositoryBranch(repo),
+			Dir:        repositoryDir(repo),
+		})
+	}
+
+	for key, value := range b.polecat.Annotations {
+		if name, ok := strings.CutPrefix(key, LinkAnnotationPrefix); ok && name != "" {
+			if ctx.Links == nil {
+				ctx.Links = make(map[string]string)
+			}
+			ctx.Links[name] = value
+		}
+	}
+
+	return ctx
+}
+
+// contextJSON renders the context file content
+func (b *Builder) contextJSON() (string, error) {
+	data, err := json.MarshalIndent(b.Context(), "", "  ")
+	if err != nil {
+		return "", err
+	}
+	return string(data), nil
+}
+
+// RenderPrompt renders a Go-template prompt with the agent context.
+// Unknown fields are an error so typos surface before the Pod starts.
+func (b *Builder) RenderPrompt(text string) (string, error) {
+	tmpl, err := template.New("prompt").Option("missingkey=error").Parse(text)
+	if err != nil {
+		return "", fmt.Errorf("invalid prompt template: %w", err)
+	}
+
+	var out bytes.Buffer
+	if err := tmpl.Execute(&out, b.Context()); err != nil {
+		return "", fmt.Errorf("failed to render prompt template: %w", err)
+	}
+	return out.String(), nil
+}
@@ -0,0 +1,309 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func newContextPolecat() *gastownv1alpha1.Polecat {
+	deadline := int64(3600)
+	maxIdle := int32(600)
+	return &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "furiosa",
+			Namespace: "gastown",
+			Labels:    map[string]string{ConvoyLabel: "cv-42"},
+			Annotations: map[string]string{
+				LinkAnnotationPrefix + "issue": "https://tracker.example.com/gt-1",
+				"unrelated":                    "ignored",
+			},
+		},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:             "myproject",
+			BeadID:          "gt-1",
+			TaskDescription: "Fix the widget",
+			MaxIdleSeconds:  &maxIdle,
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository:         "git@github.com:org/repo.git",
+				GitBranch:             "main",
+				GitSecretRef:          gastownv1alpha1.SecretReference{Name: "git-secret"},
+				ActiveDeadlineSeconds: &deadline,
+			},
+		},
+	}
+}
+
+func TestContext(t *testing.T) {
+	ctx := NewBuilder(newContextPolecat()).Context()
+
+	deadline := int64(3600)
+	maxIdle := int32(600)
+	want := AgentContext{
+		Polecat:   "furiosa",
+		Namespace: "gastown",
+		Rig:       "myproject",
+		Bead:      "gt-1",
+		Convoy:    "cv-42",
+		Task:      "Fix the widget",
+		Branch: ContextBranch{
+			Repository: "git@github.com:org/repo.git",
+			Base:       "main",
+			Work:       "feature/gt-1",
+		},
+		Deadlines: ContextDeadlines{
+			ActiveDeadlineSeconds: &deadline,
+			MaxIdleSeconds:        &maxIdle,
+		},
+		Links: map[string]string{"issue": "https://tracker.example.com/gt-1"},
+	}
+	if !reflect.DeepEqual(ctx, want) {
+		t.Errorf("unexpected context:\ngot:  %+v\nwant: %+v", ctx, want)
+	}
+}
+
+func TestContextFileWrittenByInitContainer(t *testing.T) {
+	pod, err := NewBuilder(newContextPolecat()).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	initContainer := pod.Spec.InitContainers[0]
+	if !strings.Contains(initContainer.Args[0], `"$GT_CONTEXT" > `+ContextFile) {
+		t.Error("init container script does not write the context file")
+	}
+
+	var raw string
+	for _, env := range initContainer.Env {
+		if env.Name == "GT_CONTEXT" {
+			raw = env.Value
+		}
+	}
+	var ctx AgentContext
+	if err := json.Unmarshal([]byte(raw), &ctx); err != nil {
+		t.Fatalf("GT_CONTEXT is not valid JSON: %v", err)
+	}
+	if ctx.Bead != "gt-1" || ctx.Convoy != "cv-42" {
+		t.Errorf("unexpected context in init container: %+v", ctx)
+	}
+
+	if pod.Labels[ConvoyLabel] != "cv-42" {
+		t.Error("expected convoy label to be propagated to the pod")
+	}
+}
+
+func TestDownwardAPI(t *testing.T) {
+	pod, err := NewBuilder(newContextPolecat()).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	claude := pod.Spec.Containers[0]
+
+	t.Run("exposes pod fields as env vars", func(t *testing.T) {
+		fieldRefs := make(map[string]string)
+		for _, env := range claude.Env {
+			if env.ValueFrom != nil && env.ValueFrom.FieldRef != nil {
+				fieldRefs[env.Name] = env.ValueFrom.FieldRef.FieldPath
+			}
+		}
+		for name, path := range map[string]string{
+			"GT_POD_NAME":      "metadata.name",
+			"GT_POD_NAMESPACE": "metadata.namespace",
+			"GT_POD_UID":       "metadata.uid",
+			"GT_NODE_NAME":     "spec.nodeName",
+		} {
+			if fieldRefs[name] != path {
+				t.Errorf("expected %s from %s, got %q", name, path, fieldRefs[name])
+			}
+		}
+	})
+
+	t.Run("mounts pod info volume", func(t *testing.T) {
+		mounted := false
+		for _, m := range claude.VolumeMounts {
+			if m.Name == PodInfoVolumeName && m.MountPath == PodInfoMountPath && m.ReadOnly {
+				mounted = true
+			}
+		}
+		if !mounted {
+			t.Errorf("expected read-only %s mount at %s", PodInfoVolumeName, PodInfoMountPath)
+		}
+
+		var source *corev1.DownwardAPIVolumeSource
+		for _, v := range pod.Spec.Volumes {
+			if v.Name == PodInfoVolumeName {
+				source = v.DownwardAPI
+			}
+		}
+		if source == nil {
+			t.Fatal("expected downward API volume")
+		}
+		paths := make([]string, 0, len(source.Items))
+		for _, item := range source.Items {
+			paths = append(paths, item.Path)
+		}
+		if want := []string{"labels", "annotations", "name", "namespace", "uid"}; !reflect.DeepEqual(paths, want) {
+			t.Errorf("expected files %v, got %v", want, paths)
+		}
+	})
+}
+
+func TestPromptTemplate(t *testing.T) {
+	t.Run("renders the template into the agent env", func(t *testing.T) {
+		tmpl := "Fix {{.Bead}} in {{.Branch.Repository}} on {{.Branch.Work}} for rig {{.Rig}}: {{.Task}}"
+		pod, err := NewBuilder(newContextPolecat()).WithPromptTemplate(tmpl).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		var prompt string
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == "GT_AGENT_PROMPT" {
+				prompt = env.Value
+			}
+		}
+		want := "Fix gt-1 in git@github.com:org/repo.git on feature/gt-1 for rig myproject: Fix the widget"
+		if prompt != want {
+			t.Errorf("expected GT_AGENT_PROMPT %q, got %q", want, prompt)
+		}
+		if !strings.Contains(pod.Spec.Containers[0].Args[0], `PROMPT="$GT_AGENT_PROMPT"`) {
+			t.Error("expected startup script to use the rendered prompt")
+		}
+	})
+
+	t.Run("uses the built-in prompt without a template", func(t *testing.T) {
+		pod, err := NewBuilder(newContextPolecat()).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, env := range pod.Spec.Containers[0].Env {
+			if env.Name == "GT_AGENT_PROMPT" {
+				t.Error("GT_AGENT_PROMPT should not be set without a template")
+			}
+		}
+	})
+
+	t.Run("rejects unknown fields", func(t *testing.T) {
+		if _, err := NewBuilder(newContextPolecat()).WithPromptTemplate("{{.Issue}}").Build(); err == nil {
+			t.Error("expected error for unknown template field")
+		}
+	})
+}
+
+func TestPreviousAttempt(t *testing.T) {
+	failure := &gastownv1alpha1.PolecatFailure{
+		Bead:    "gt-1",
+		Attempt: 2,
+		Reason:  ExitReasonTaskIncomplete,
+		Message: "Agent exited without committing any work (exit code 10)",
+	}
+	builder := NewBuilder(newContextPolecat()).
+		WithPreviousAttempt(failure, "ERROR: tests failed\n", "s3://logs/furiosa.log")
+
+	ctx := builder.Context()
+	want := &ContextAttempt{
+		Attempt:      2,
+		Reason:       ExitReasonTaskIncomplete,
+		Message:      failure.Message,
+		LogFile:      PreviousAttemptLogFile,
+		LogsArtifact: "s3://logs/furiosa.log",
+	}
+	if !reflect.DeepEqual(ctx.PreviousAttempt, want) {
+		t.Errorf("unexpected previous attempt:\ngot:  %+v\nwant: %+v", ctx.PreviousAttempt, want)
+	}
+
+	pod, err := builder.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	initContainer := pod.Spec.InitContainers[0]
+	if !strings.Contains(initContainer.Args[0], `"$GT_PREVIOUS_ATTEMPT_LOG" > `+PreviousAttemptLogFile) {
+		t.Error("init container script does not write the previous attempt log")
+	}
+	found := false
+	for _, env := range initContainer.Env {
+		if env.Name == "GT_PREVIOUS_ATTEMPT_LOG" && env.Value == "ERROR: tests failed\n" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected GT_PREVIOUS_ATTEMPT_LOG in the init container")
+	}
+
+	var summary string
+	for _, env := range pod.Spec.Containers[0].Env {
+		if env.Name == "GT_PREVIOUS_ATTEMPT" {
+			summary = env.Value
+		}
+	}
+	if !strings.HasPrefix(summary, "TaskIncomplete (attempt 2): Agent exited") {
+		t.Errorf("unexpected GT_PREVIOUS_ATTEMPT %q", summary)
+	}
+	if !strings.Contains(pod.Spec.Containers[0].Args[0], "PREVIOUS ATTEMPT:") {
+		t.Error("expected the built-in prompt to mention the previous attempt")
+	}
+
+	// Prompt templates reach the attempt through the context
+	tmpl := "{{with .PreviousAttempt}}Last try failed: {{.Reason}}{{end}}"
+	prompt, err := builder.RenderPrompt(tmpl)
+	if err != nil || prompt != "Last try failed: TaskIncomplete" {
+		t.Errorf("unexpected rendered prompt %q (err %v)", prompt, err)
+	}
+	if prompt, _ := NewBuilder(newContextPolecat()).RenderPrompt(tmpl); prompt != "" {
+		t.Errorf("expected an empty prompt on a first attempt, got %q", prompt)
+	}
+}
+
+func TestContextBead(t *testing.T) {
+	priority := int32(1)
+	polecat := newContextPolecat()
+	polecat.Spec.TaskDescription = ""
+	builder := NewBuilder(polecat).WithBead("Fix the widget", "It spins backwards.", &priority)
+
+	ctx := builder.Context()
+	want := &ContextBead{Title: "Fix the widget", Description: "It spins backwards.", Priority: &priority}
+	if !reflect.DeepEqual(ctx.BeadInfo, want) {
+		t.Errorf("unexpected bead info:\ngot:  %+v\nwant: %+v", ctx.BeadInfo, want)
+	}
+	if ctx.Task != "Fix the widget\n\nIt spins backwards." {
+		t.Errorf("expected the bead to be the task, got %q", ctx.Task)
+	}
+
+	pod, err := builder.Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, env := range pod.Spec.Containers[0].Env {
+		if env.Name == "GT_TASK_DESCRIPTION" && env.Value != ctx.Task {
+			t.Errorf("unexpected GT_TASK_DESCRIPTION %q", env.Value)
+		}
+	}
+
+	// An explicit task description wins over the bead
+	if task := NewBuilder(newContextPolecat()).WithBead("Other", "", nil).Context().Task; task != "Fix the widget" {
+		t.Errorf("expected the polecat's task description, got %q", task)
+	}
+}
@@ -0,0 +1,147 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// Exit code contract of the agent container. The startup script maps agent
+// failures onto these codes; custom agents may exit with them directly.
+// Each class owns a range of ten codes so agents can add detail.
+const (
+	// ExitCodeSuccess means the agent finished the task
+	ExitCodeSuccess = 0
+
+	// ExitCodeTaskIncomplete (10-19) means the agent stopped without
+	// committing any work
+	ExitCodeTaskIncomplete = 10
+
+	// ExitCodeAuthFailure (20-29) means the model or git credentials were rejected
+	ExitCodeAuthFailure = 20
+
+	// ExitCodeRateLimited (30-39) means the model provider throttled the agent
+	ExitCodeRateLimited = 30
+
+	// ExitCodeToolError (40-49) means a required tool was missing or failed
+	ExitCodeToolError = 40
+)
+
+// Files the startup script uses to run and classify the agent
+const (
+	agentLaunchFile = TmpMountPath + "/gt-launch.sh"
+	agentExitFile   = TmpMountPath + "/gt-agent-exit"
+	agentLogFile    = TmpMountPath + "/gt-agent.log"
+)
+
+// Patterns matched against the agent output (grep -iE) to classify an
+// unstructured failure.
+const (
+	rateLimitPattern   = `rate[ _-]?limit|too many requests|(^|[^0-9])429([^0-9]|$)|overloaded`
+	authFailurePattern = `unauthori[sz]ed|authentication[ _]?(error|failed)|invalid[ _-]?api[ _-]?key|(^|[^0-9])401([^0-9]|$)`
+)
+
+// Exit reasons, used as the reason of the Polecat Degraded condition.
+const (
+	ExitReasonCompleted      = "Completed"
+	ExitReasonTaskIncomplete = "TaskIncomplete"
+	ExitReasonAuthFailure    = "AuthFailure"
+	ExitReasonRateLimited    = "RateLimited"
+	ExitReasonToolError      = "ToolError"
+	ExitReasonOOMKilled      = "OOMKilled"
+	ExitReasonAgentError     = "AgentError"
+)
+
+// ClassifyExitCode returns the exit reason for an agent exit code.
+// Codes outside the contract ranges are reported as AgentError.
+func ClassifyExitCode(code int32) string {
+	switch {
+	case code == ExitCodeSuccess:
+		return ExitReasonCompleted
+	case code >= ExitCodeTaskIncomplete && code < ExitCodeTaskIncomplete+10:
+		return ExitReasonTaskIncomplete
+	case code >= ExitCodeAuthFailure && code < ExitCodeAuthFailure+10:
+		return ExitReasonAuthFailure
+	case code >= ExitCodeRateLimited && code < ExitCodeRateLimited+10:
+		return ExitReasonRateLimited
+	case code >= ExitCodeToolError && code < ExitCodeToolError+10:
+		return ExitReasonToolError
+	default:
+		return ExitReasonAgentError
+	}
+}
+
+// AgentTermination returns the terminated state of the agent container, or
+// of its last run when the kubelet restarted it in place; nil if the agent
+// container has not terminated.
+func AgentTermination(p *corev1.Pod, agent gastownv1alpha1.AgentType) *corev1.ContainerStateTerminated {
+	name := AgentContainerName(agent)
+	for _, cs := range p.Status.ContainerStatuses {
+		if cs.Name == name {
+			if cs.State.Terminated != nil {
+				return cs.State.Terminated
+			}
+			return cs.LastTerminationState.Terminated
+		}
+	}
+	return nil
+}
+
+// IsTerminalExit reports whether an agent exit ends the attempt even when the
+// kubelet would restart the agent in place: the agent gave up under the exit
+// code contract. Liveness probe kills and other errors are worth a restart.
+func IsTerminalExit(t *corev1.ContainerStateTerminated) bool {
+	if t.Reason == ExitReasonOOMKilled {
+		return false
+	}
+	switch ClassifyExitCode(t.ExitCode) {
+	case ExitReasonCompleted, ExitReasonAgentError:
+		return false
+	default:
+		return true
+	}
+}
+
+// DescribeTermination returns the exit reason and a human-readable message
+// for a terminated agent container.
+func DescribeTermination(t *corev1.ContainerStateTerminated) (string, string) {
+	if t.Reason == ExitReasonOOMKilled {
+		return ExitReasonOOMKilled, "Agent was killed after exceeding its memory limit"
+	}
+
+	reason := ClassifyExitCode(t.ExitCode)
+	var message string
+	switch reason {
+	case ExitReasonCompleted:
+		message = "Agent completed the task"
+	case ExitReasonTaskIncomplete:
+		message = "Agent exited without committing any work"
+	case ExitReasonAuthFailure:
+		message = "Agent credentials were rejected"
+	case ExitReasonRateLimited:
+		message = "Agent was rate limited by the model provider"
+	case ExitReasonToolError:
+		message = "A tool required by the agent is missing or failed"
+	default:
+		message = "Agent failed"
+	}
+	return reason, fmt.Sprintf("%s (exit code %d)", message, t.ExitCode)
+}
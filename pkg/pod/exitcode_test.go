@@ -0,0 +1,146 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"regexp"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func TestClassifyExitCode(t *testing.T) {
+	tests := []struct {
+		code int32
+		want string
+	}{
+		{0, ExitReasonCompleted},
+		{1, ExitReasonAgentError},
+		{10, ExitReasonTaskIncomplete},
+		{19, ExitReasonTaskIncomplete},
+		{20, ExitReasonAuthFailure},
+		{31, ExitReasonRateLimited},
+		{40, ExitReasonToolError},
+		{50, ExitReasonAgentError},
+		{137, ExitReasonAgentError},
+	}
+
+	for _, tt := range tests {
+		if got := ClassifyExitCode(tt.code); got != tt.want {
+			t.Errorf("ClassifyExitCode(%d) = %s, want %s", tt.code, got, tt.want)
+		}
+	}
+}
+
+func TestDescribeTermination(t *testing.T) {
+	reason, message := DescribeTermination(&corev1.ContainerStateTerminated{ExitCode: 20})
+	if reason != ExitReasonAuthFailure || !strings.Contains(message, "exit code 20") {
+		t.Errorf("unexpected description: %s %q", reason, message)
+	}
+
+	reason, _ = DescribeTermination(&corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"})
+	if reason != ExitReasonOOMKilled {
+		t.Errorf("expected OOMKilled, got %s", reason)
+	}
+}
+
+func TestAgentTermination(t *testing.T) {
+	p := &corev1.Pod{Status: corev1.PodStatus{ContainerStatuses: []corev1.ContainerStatus{
+		{Name: "claude", State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+		{Name: "aider", State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 30}}},
+	}}}
+
+	if AgentTermination(p, gastownv1alpha1.AgentTypeClaudeCode) != nil {
+		t.Error("expected no termination for a running agent")
+	}
+	if term := AgentTermination(p, gastownv1alpha1.AgentTypeAider); term == nil || term.ExitCode != 30 {
+		t.Errorf("expected aider termination with exit code 30, got %+v", term)
+	}
+
+	// An agent restarted in place reports its last run
+	p.Status.ContainerStatuses[0].LastTerminationState.Terminated = &corev1.ContainerStateTerminated{ExitCode: 20}
+	if term := AgentTermination(p, gastownv1alpha1.AgentTypeClaudeCode); term == nil || term.ExitCode != 20 {
+		t.Errorf("expected the last claude termination with exit code 20, got %+v", term)
+	}
+}
+
+func TestIsTerminalExit(t *testing.T) {
+	tests := []struct {
+		term *corev1.ContainerStateTerminated
+		want bool
+	}{
+		{&corev1.ContainerStateTerminated{ExitCode: 0}, false},
+		{&corev1.ContainerStateTerminated{ExitCode: 1}, false},
+		{&corev1.ContainerStateTerminated{ExitCode: 137}, false},
+		{&corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}, false},
+		{&corev1.ContainerStateTerminated{ExitCode: 10}, true},
+		{&corev1.ContainerStateTerminated{ExitCode: 20}, true},
+		{&corev1.ContainerStateTerminated{ExitCode: 35}, true},
+		{&corev1.ContainerStateTerminated{ExitCode: 40}, true},
+	}
+	for _, tt := range tests {
+		if got := IsTerminalExit(tt.term); got != tt.want {
+			t.Errorf("IsTerminalExit(%d %s) = %v, want %v", tt.term.ExitCode, tt.term.Reason, got, tt.want)
+		}
+	}
+}
+
+func TestFailurePatterns(t *testing.T) {
+	rateLimit := regexp.MustCompile("(?i)" + rateLimitPattern)
+	auth := regexp.MustCompile("(?i)" + authFailurePattern)
+
+	for _, line := range []string{"API Error: 429 Too Many Requests", "Rate limit exceeded", "overloaded_error"} {
+		if !rateLimit.MatchString(line) {
+			t.Errorf("expected %q to match the rate limit pattern", line)
+		}
+	}
+	for _, line := range []string{"Invalid API key", "401 Unauthorized", "authentication_error"} {
+		if !auth.MatchString(line) {
+			t.Errorf("expected %q to match the auth failure pattern", line)
+		}
+	}
+	if rateLimit.MatchString("processed 4291 files") || auth.MatchString("line 4010") {
+		t.Error("status codes embedded in numbers should not match")
+	}
+}
+
+func TestAgentScriptClassifiesExit(t *testing.T) {
+	pod, err := NewBuilder(agentPolecat("", nil)).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	script := pod.Spec.Containers[0].Args[0]
+	for _, want := range []string{"sh -e " + agentLaunchFile, "tee " + agentLogFile, "exit 30", "exit 20", "exit 10"} {
+		if !strings.Contains(script, want) {
+			t.Errorf("expected agent script to contain %q", want)
+		}
+	}
+
+	found := false
+	for _, env := range pod.Spec.Containers[0].Env {
+		if env.Name == "GT_BASE_BRANCH" {
+			found = true
+		}
+	}
+	if !found {
+		t.Error("expected GT_BASE_BRANCH in the agent environment")
+	}
+}
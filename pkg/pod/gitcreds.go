@@ -0,0 +1,56 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import "fmt"
+
+// Keys of a git Secret holding HTTPS credentials instead of an SSH key: a
+// token, or a username and password. A token wins over a password.
+const (
+	GitUsernameKey = "username"
+	GitPasswordKey = "password"
+	GitTokenKey    = "token"
+)
+
+// DefaultGitUsername authenticates a token without a username. GitHub and
+// Gitea accept any username with a token; GitLab and Bitbucket tokens need
+// the username the provider documents (e.g. oauth2 or x-token-auth).
+const DefaultGitUsername = "x-access-token"
+
+// httpsURLScript defines https_url, which rewrites an SSH git URL
+// (git@host:path or ssh://git@host[:port]/path) to HTTPS and leaves any other
+// URL as it is.
+const httpsURLScript = `https_url() {
+    echo "$1" | sed -E -e 's#^ssh://git@([^:/]+)(:[0-9]+)?/#https://\1/#' -e 's#^git@([^:/]+):#https://\1/#'
+}`
+
+// hasHTTPSCredentials is a shell test for HTTPS credentials in the git
+// secret mounted at dir.
+func hasHTTPSCredentials(dir string) string {
+	return fmt.Sprintf(`[ -f "%[1]s/%[2]s" ] || [ -f "%[1]s/%[3]s" ]`, dir, GitTokenKey, GitPasswordKey)
+}
+
+// gitCredentialHelper returns a git credential helper answering with the
+// HTTPS credentials of the git secret mounted at dir. The files are read on
+// every request, so rotated Secrets are picked up. The helper has no single
+// quotes and can be single-quoted in scripts.
+func gitCredentialHelper(dir string) string {
+	return fmt.Sprintf(`!f() { test "$1" = get || return 0; `+
+		`if [ -f "%[1]s/%[2]s" ]; then echo "username=$(cat "%[1]s/%[2]s")"; else echo "username=%[5]s"; fi; `+
+		`if [ -f "%[1]s/%[3]s" ]; then echo "password=$(cat "%[1]s/%[3]s")"; else echo "password=$(cat "%[1]s/%[4]s")"; fi; }; f`,
+		dir, GitUsernameKey, GitTokenKey, GitPasswordKey, DefaultGitUsername)
+}
@@ -0,0 +1,129 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func TestHTTPSURLScript(t *testing.T) {
+	for url, want := range map[string]string{
+		"git@github.com:org/app.git":                    "https://github.com/org/app.git",
+		"ssh://git@gitlab.example.com/org/app.git":      "https://gitlab.example.com/org/app.git",
+		"ssh://git@gitlab.example.com:2222/org/app.git": "https://gitlab.example.com/org/app.git",
+		"https://github.com/org/app.git":                "https://github.com/org/app.git",
+	} {
+		out, err := exec.Command("sh", "-c", httpsURLScript+"\nhttps_url \"$1\"", "sh", url).Output()
+		if err != nil {
+			t.Fatalf("https_url %s failed: %v", url, err)
+		}
+		if got := strings.TrimSpace(string(out)); got != want {
+			t.Errorf("https_url %s = %s, want %s", url, got, want)
+		}
+	}
+}
+
+func TestGitCredentialHelper(t *testing.T) {
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git not installed")
+	}
+	dir := t.TempDir()
+	fill := func() string {
+		cmd := exec.Command("git", "-c", "credential.helper=", "-c", "credential.helper="+gitCredentialHelper(dir),
+			"credential", "fill")
+		cmd.Env = append(os.Environ(), "GIT_TERMINAL_PROMPT=0")
+		cmd.Stdin = strings.NewReader("protocol=https\nhost=github.com\n\n")
+		out, err := cmd.Output()
+		if err != nil {
+			t.Fatalf("git credential fill failed: %v", err)
+		}
+		return string(out)
+	}
+	write := func(key, value string) {
+		if err := os.WriteFile(filepath.Join(dir, key), []byte(value+"\n"), 0o600); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	write(GitPasswordKey, "hunter2")
+	if out := fill(); !strings.Contains(out, "username="+DefaultGitUsername+"\n") || !strings.Contains(out, "password=hunter2\n") {
+		t.Errorf("expected the password with the default username, got %q", out)
+	}
+	write(GitUsernameKey, "oauth2")
+	write(GitTokenKey, "glpat-secret")
+	if out := fill(); !strings.Contains(out, "username=oauth2\n") || !strings.Contains(out, "password=glpat-secret\n") {
+		t.Errorf("expected the token to win over the password, got %q", out)
+	}
+}
+
+func TestGitInitHTTPSCredentials(t *testing.T) {
+	polecat := &gastownv1alpha1.Polecat{
+		ObjectMeta: metav1.ObjectMeta{Name: "test-polecat", Namespace: "default"},
+		Spec: gastownv1alpha1.PolecatSpec{
+			Rig:    "test-rig",
+			BeadID: "gt-1",
+			Kubernetes: &gastownv1alpha1.KubernetesSpec{
+				GitRepository: "git@github.com:org/api.git",
+				GitBranch:     "main",
+				GitSecretRef:  gastownv1alpha1.SecretReference{Name: "git-secret"},
+				AdditionalRepositories: []gastownv1alpha1.AdditionalRepository{
+					{URL: "git@github.com:org/client.git", Path: "client"},
+					{
+						URL:          "git@gitlab.com:org/docs.git",
+						Path:         "docs",
+						GitSecretRef: &gastownv1alpha1.SecretReference{Name: "docs-secret"},
+					},
+				},
+			},
+		},
+	}
+	p, err := NewBuilder(polecat).Build()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	script := p.Spec.InitContainers[0].Args[0]
+	for _, want := range []string{
+		`if [ -f "/git-creds/token" ] || [ -f "/git-creds/password" ]; then`,
+		"git config --global credential.helper '" + gitCredentialHelper(GitCredsMountPath) + "'",
+		// Only the repositories cloned with the polecat's secret are rewritten
+		"for GIT_URL in git@github.com:org/api.git git@github.com:org/client.git; do",
+		`git config --global --add url."$HTTPS_URL".insteadOf "$GIT_URL"`,
+		`if [ -z "$GT_GIT_HTTPS" ]; then`,
+		// A repository with its own secret gets its own helper
+		`if [ -f "/git-creds-1/token" ] || [ -f "/git-creds-1/password" ]; then`,
+		"cp /git-creds-1/* /home/nonroot/.git-creds-1/",
+		"git clone --depth=1 -b main -c credential.helper= -c credential.helper='" +
+			gitCredentialHelper("/home/nonroot/.git-creds-1") + "' \"$(https_url git@gitlab.com:org/docs.git)\" /workspace/docs",
+	} {
+		if !strings.Contains(script, want) {
+			t.Errorf("expected init script to contain %q", want)
+		}
+	}
+
+	if !strings.Contains(testCheckoutScript, `GT_GIT_URL=$(https_url "$GT_GIT_URL")`) {
+		t.Error("expected the test checkout to rewrite its URL with HTTPS credentials")
+	}
+}
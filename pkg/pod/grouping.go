@@ -0,0 +1,93 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"strconv"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/util/validation"
+)
+
+const (
+	// BeadLabelPrefix prefixes the Pod labels carrying the bead's labels:
+	// "area:payments" becomes "beads.gastown.io/area": "payments" and a plain
+	// "backend" becomes "beads.gastown.io/backend": "true"
+	BeadLabelPrefix = "beads.gastown.io/"
+
+	// EpicLabel carries the ID of the bead's epic on the Pod
+	EpicLabel = "gastown.io/epic"
+
+	// Annotations carrying the bead's grouping on the Polecat and its Pod
+	BeadLabelsAnnotation   = "gastown.io/bead-labels"
+	BeadEpicAnnotation     = "gastown.io/bead-epic"
+	BeadEstimateAnnotation = "gastown.io/bead-estimated-minutes"
+)
+
+// BeadAnnotations are the annotations set from a BeadGrouping
+var BeadAnnotations = []string{BeadLabelsAnnotation, BeadEpicAnnotation, BeadEstimateAnnotation}
+
+// BeadGrouping is the bead metadata that downstream systems, such as cost
+// reporting and dashboards, group agent work by.
+type BeadGrouping struct {
+	// Labels of the bead, e.g. "area:payments" or "sprint-42"
+	Labels []string
+
+	// Epic is the ID of the bead's epic
+	Epic string
+
+	// EstimatedMinutes is the estimated effort; nil when unset
+	EstimatedMinutes *int32
+}
+
+// Annotations returns the grouping as annotations, with the labels
+// comma-separated. Unset fields are left out.
+func (g BeadGrouping) Annotations() map[string]string {
+	annotations := map[string]string{}
+	if len(g.Labels) > 0 {
+		annotations[BeadLabelsAnnotation] = strings.Join(g.Labels, ",")
+	}
+	if g.Epic != "" {
+		annotations[BeadEpicAnnotation] = g.Epic
+	}
+	if g.EstimatedMinutes != nil {
+		annotations[BeadEstimateAnnotation] = strconv.Itoa(int(*g.EstimatedMinutes))
+	}
+	return annotations
+}
+
+// PodLabels returns the grouping as Pod labels, so Pods can be selected by
+// it. Bead labels that are not valid label keys or values are left out;
+// they are still in the annotations.
+func (g BeadGrouping) PodLabels() map[string]string {
+	labels := map[string]string{}
+	for _, label := range g.Labels {
+		key, value, ok := strings.Cut(label, ":")
+		if !ok {
+			value = "true"
+		}
+		key = BeadLabelPrefix + strings.TrimSpace(key)
+		value = strings.TrimSpace(value)
+		if len(validation.IsQualifiedName(key)) == 0 && len(validation.IsValidLabelValue(value)) == 0 {
+			labels[key] = value
+		}
+	}
+	if g.Epic != "" && len(validation.IsValidLabelValue(g.Epic)) == 0 {
+		labels[EpicLabel] = g.Epic
+	}
+	return labels
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"maps"
+	"testing"
+)
+
+func TestBeadGrouping(t *testing.T) {
+	estimate := int32(90)
+	grouping := BeadGrouping{
+		Labels:           []string{"area:payments", "sprint-42", "needs review", "gastown.io/bead:spoofed"},
+		Epic:             "gt-1",
+		EstimatedMinutes: &estimate,
+	}
+
+	wantAnnotations := map[string]string{
+		BeadLabelsAnnotation:   "area:payments,sprint-42,needs review,gastown.io/bead:spoofed",
+		BeadEpicAnnotation:     "gt-1",
+		BeadEstimateAnnotation: "90",
+	}
+	if got := grouping.Annotations(); !maps.Equal(got, wantAnnotations) {
+		t.Errorf("Annotations() = %v, want %v", got, wantAnnotations)
+	}
+
+	// Labels that are no valid label keys stay in the annotations only
+	wantLabels := map[string]string{
+		"beads.gastown.io/area":      "payments",
+		"beads.gastown.io/sprint-42": "true",
+		EpicLabel:                    "gt-1",
+	}
+	if got := grouping.PodLabels(); !maps.Equal(got, wantLabels) {
+		t.Errorf("PodLabels() = %v, want %v", got, wantLabels)
+	}
+
+	if got := (BeadGrouping{}).Annotations(); len(got) != 0 {
+		t.Errorf("expected no annotations without grouping, got %v", got)
+	}
+}
+
+func TestBuildWithBeadGrouping(t *testing.T) {
+	polecat := newContextPolecat()
+	p, err := NewBuilder(polecat).WithBeadGrouping(BeadGrouping{
+		Labels: []string{"area:payments"},
+		Epic:   "gt-1",
+	}).Build()
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	if p.Labels["beads.gastown.io/area"] != "payments" || p.Labels[EpicLabel] != "gt-1" {
+		t.Errorf("expected the grouping labels, got %v", p.Labels)
+	}
+	if p.Labels["gastown.io/polecat"] != polecat.Name || p.Labels["gastown.io/bead"] != polecat.Spec.BeadID {
+		t.Errorf("expected the operator's labels, got %v", p.Labels)
+	}
+	if p.Annotations[BeadEpicAnnotation] != "gt-1" {
+		t.Errorf("expected the grouping annotations, got %v", p.Annotations)
+	}
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// ImageProbeLabel marks capability probe Jobs
+	ImageProbeLabel = "gastown.io/image-probe"
+
+	// ImageProbeContainerName is the container running the tool checks
+	ImageProbeContainerName = "probe"
+
+	// ImageProbeTTLSeconds keeps a finished probe, and so its cached result,
+	// for a day; images behind mutable tags are re-probed afterwards.
+	ImageProbeTTLSeconds = 24 * 60 * 60
+
+	// imageProbeDeadlineSeconds bounds image pull and the checks
+	imageProbeDeadlineSeconds = 300
+)
+
+// imageProbeScript checks that every positional argument resolves to an
+// executable and reports the missing ones in the termination message.
+const imageProbeScript = `
+missing=""
+for tool in "$@"; do
+    command -v "$tool" >/dev/null 2>&1 || missing="$missing $tool"
+done
+if [ -n "$missing" ]; then
+    echo "missing required tools:$missing" | tee /dev/termination-log
+    exit 1
+fi
+echo "all required tools found: $*"
+`
+
+// NeedsImageProbe reports whether the polecat, or its rig's defaults,
+// override the agent image. Default images are known to work and are not
+// probed.
+func (b *Builder) NeedsImageProbe() bool {
+	if b.polecat.Spec.Kubernetes == nil {
+		return false
+	}
+	cfg := b.polecat.Spec.AgentConfig
+	return b.polecat.Spec.Kubernetes.Image != "" || (cfg != nil && cfg.Image != "") ||
+		(b.defaults != nil && b.defaults.Image != "")
+}
+
+// RequiredTools returns the executables the agent image must provide:
+// the runtime's CLI and, when overridden, the agent command.
+func (b *Builder) RequiredTools() ([]string, error) {
+	runtime, err := RuntimeFor(b.polecat.Spec.Agent)
+	if err != nil {
+		return nil, err
+	}
+	tools := runtime.RequiredTools()
+	if cfg := b.polecat.Spec.AgentConfig; cfg != nil && len(cfg.Command) > 0 {
+		tools = append(tools, cfg.Command[0])
+	}
+	return tools, nil
+}
+
+// ImageProbeJob builds the Job that verifies the agent image provides the
+// required tools. The name is derived from the image and the tools so that
+// polecats sharing an image share one probe, whose result is cached for
+// ImageProbeTTLSeconds.
+func (b *Builder) ImageProbeJob() (*batchv1.Job, error) {
+	runtime, err := RuntimeFor(b.polecat.Spec.Agent)
+	if err != nil {
+		return nil, err
+	}
+	tools, err := b.RequiredTools()
+	if err != nil {
+		return nil, err
+	}
+	image := b.agentImage(runtime)
+
+	sum := sha256.Sum256([]byte(image + "\n" + strings.Join(tools, " ")))
+	name := "gt-imageprobe-" + hex.EncodeToString(sum[:])[:12]
+	labels := map[string]string{ImageProbeLabel: "true"}
+
+	job := &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: b.polecat.Namespace,
+			Labels:    labels,
+			Annotations: map[string]string{
+				ImageProbeLabel + "-image": image,
+			},
+		},
+		Spec: batchv1.JobSpec{
+			BackoffLimit:            int32Ptr(0),
+			ActiveDeadlineSeconds:   int64Ptr(imageProbeDeadlineSeconds),
+			TTLSecondsAfterFinished: int32Ptr(ImageProbeTTLSeconds),
+			Template: corev1.PodTemplateSpec{
+				ObjectMeta: metav1.ObjectMeta{Labels: labels},
+				Spec: corev1.PodSpec{
+					RestartPolicy:    corev1.RestartPolicyNever,
+					ImagePullSecrets: b.imagePullSecretRefs(),
+					SecurityContext:  b.buildPodSecurityContext(),
+					Containers: []corev1.Container{{
+						Name:                     ImageProbeContainerName,
+						Image:                    image,
+						Command:                  []string{"/bin/sh", "-c"},
+						Args:                     append([]string{imageProbeScript, "gt-imageprobe"}, tools...),
+						SecurityContext:          b.buildSecurityContext(),
+						TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(TelemetryCPURequest),
+								corev1.ResourceMemory: resource.MustParse(TelemetryMemoryRequest),
+							},
+							Limits: corev1.ResourceList{
+								corev1.ResourceCPU:    resource.MustParse(TelemetryCPULimit),
+								corev1.ResourceMemory: resource.MustParse(TelemetryMemoryLimit),
+							},
+						},
+					}},
+				},
+			},
+		},
+	}
+	// Named and annotated by the configured image, which a mirror serves
+	mirrorImages(&job.Spec.Template.Spec)
+	return job, nil
+}
@@ -0,0 +1,133 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"slices"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+	"github.com/org/gastown-operator/pkg/config"
+)
+
+func TestImageProbeJob(t *testing.T) {
+	t.Run("only probes overridden images", func(t *testing.T) {
+		if NewBuilder(agentPolecat("", nil)).NeedsImageProbe() {
+			t.Error("default image should not be probed")
+		}
+		polecat := agentPolecat("", nil)
+		polecat.Spec.Kubernetes.Image = "example.com/agent:v1"
+		if !NewBuilder(polecat).NeedsImageProbe() {
+			t.Error("custom kubernetes image should be probed")
+		}
+		cfg := &gastownv1alpha1.AgentConfig{Image: "example.com/agent:v1"}
+		if !NewBuilder(agentPolecat(gastownv1alpha1.AgentTypeOpenCode, cfg)).NeedsImageProbe() {
+			t.Error("custom agentConfig image should be probed")
+		}
+	})
+
+	t.Run("checks the runtime tools in the agent image", func(t *testing.T) {
+		polecat := agentPolecat("", nil)
+		polecat.Spec.Kubernetes.Image = "example.com/agent:v1"
+		job, err := NewBuilder(polecat).ImageProbeJob()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		probe := job.Spec.Template.Spec.Containers[0]
+		if probe.Image != "example.com/agent:v1" {
+			t.Errorf("expected probe of the agent image, got %s", probe.Image)
+		}
+		if want := []string{"gt-imageprobe", "claude", "git", "gh"}; !slices.Equal(probe.Args[1:], want) {
+			t.Errorf("expected tool args %v, got %v", want, probe.Args[1:])
+		}
+		if job.Namespace != "default" || job.Labels[ImageProbeLabel] != "true" {
+			t.Errorf("unexpected job metadata: %s %v", job.Namespace, job.Labels)
+		}
+		if *job.Spec.BackoffLimit != 0 || *job.Spec.TTLSecondsAfterFinished != ImageProbeTTLSeconds {
+			t.Error("expected a single-attempt job cached for ImageProbeTTLSeconds")
+		}
+	})
+
+	t.Run("pulls the image with the pull secrets", func(t *testing.T) {
+		polecat := agentPolecat("", nil)
+		polecat.Spec.Kubernetes.Image = "registry.corp.example/agent:v1"
+		job, err := NewBuilder(polecat).
+			WithImagePullSecrets([]corev1.LocalObjectReference{{Name: "corp-registry"}}).ImageProbeJob()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if secrets := job.Spec.Template.Spec.ImagePullSecrets; len(secrets) != 1 || secrets[0].Name != "corp-registry" {
+			t.Errorf("expected the rig pull secret, got %v", secrets)
+		}
+	})
+
+	t.Run("pulls through the image mirror", func(t *testing.T) {
+		polecat := agentPolecat("", nil)
+		polecat.Spec.Kubernetes.Image = "ghcr.io/example/agent:v1"
+		unmirrored, err := NewBuilder(polecat).ImageProbeJob()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+
+		config.Set(&config.Config{ImageMirrors: map[string]string{"ghcr.io": "mirror.internal/ghcr"}})
+		defer config.Set(nil)
+		job, err := NewBuilder(polecat).ImageProbeJob()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if got := job.Spec.Template.Spec.Containers[0].Image; got != "mirror.internal/ghcr/example/agent:v1" {
+			t.Errorf("expected the probe to pull through the mirror, got %s", got)
+		}
+		if job.Name != unmirrored.Name || job.Annotations[ImageProbeLabel+"-image"] != polecat.Spec.Kubernetes.Image {
+			t.Error("expected the probe named and annotated by the configured image")
+		}
+	})
+
+	t.Run("includes the command override", func(t *testing.T) {
+		cfg := &gastownv1alpha1.AgentConfig{Image: "example.com/agent:v1", Command: []string{"my-agent", "--fast"}}
+		tools, err := NewBuilder(agentPolecat(gastownv1alpha1.AgentTypeCustom, cfg)).RequiredTools()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if want := []string{"git", "my-agent"}; !slices.Equal(tools, want) {
+			t.Errorf("expected tools %v, got %v", want, tools)
+		}
+	})
+
+	t.Run("shares one probe per image and tool set", func(t *testing.T) {
+		a := agentPolecat("", nil)
+		a.Spec.Kubernetes.Image = "example.com/agent:v1"
+		b := agentPolecat("", nil)
+		b.Name = "other-polecat"
+		b.Spec.Kubernetes.Image = "example.com/agent:v1"
+		c := agentPolecat("", nil)
+		c.Spec.Kubernetes.Image = "example.com/agent:v2"
+
+		jobA, _ := NewBuilder(a).ImageProbeJob()
+		jobB, _ := NewBuilder(b).ImageProbeJob()
+		jobC, _ := NewBuilder(c).ImageProbeJob()
+		if jobA.Name != jobB.Name {
+			t.Errorf("expected shared probe, got %s and %s", jobA.Name, jobB.Name)
+		}
+		if jobA.Name == jobC.Name {
+			t.Error("expected a new probe for a different image")
+		}
+	})
+}
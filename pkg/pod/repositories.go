@@ -0,0 +1,212 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// primaryRepositoryPath is the workspace directory of spec.kubernetes.gitRepository
+const primaryRepositoryPath = "repo"
+
+// repositoryBranch returns the branch an additional repository is cloned at
+func repositoryBranch(repo gastownv1alpha1.AdditionalRepository) string {
+	if repo.Branch == "" {
+		return "main"
+	}
+	return repo.Branch
+}
+
+// repositoryDir returns the directory an additional repository is cloned into
+func repositoryDir(repo gastownv1alpha1.AdditionalRepository) string {
+	return WorkspaceMountPath + "/" + repo.Path
+}
+
+// repositoryCredsVolumeName names the volume holding the git secret of the
+// i-th additional repository
+func repositoryCredsVolumeName(i int) string {
+	return fmt.Sprintf("%s-%d", GitCredsVolumeName, i)
+}
+
+// repositoryCredsMountPath is where the git init container mounts the git
+// secret of the i-th additional repository
+func repositoryCredsMountPath(i int) string {
+	return fmt.Sprintf("%s-%d", GitCredsMountPath, i)
+}
+
+// validateRepositories rejects rig repositories the rig does not have and
+// additional repositories that would be cloned over the primary repository
+// or each other
+func (b *Builder) validateRepositories() error {
+	for _, name := range b.polecat.Spec.Repositories {
+		if !slices.ContainsFunc(b.rigRepositories, func(repo gastownv1alpha1.RigRepository) bool {
+			return repo.Name == name
+		}) {
+			return fmt.Errorf("rig %s has no repository %q", b.polecat.Spec.Rig, name)
+		}
+	}
+
+	seen := map[string]bool{primaryRepositoryPath: true}
+	for _, repo := range b.additionalRepositories() {
+		if seen[repo.Path] {
+			return fmt.Errorf("additional repository path %q is already in use", repo.Path)
+		}
+		seen[repo.Path] = true
+	}
+	return nil
+}
+
+// additionalRepositories returns the repositories cloned besides the primary
+// one: spec.kubernetes.additionalRepositories, then the rig repositories
+// selected by spec.repositories
+func (b *Builder) additionalRepositories() []gastownv1alpha1.AdditionalRepository {
+	repos := b.polecat.Spec.Kubernetes.AdditionalRepositories
+	for _, repo := range b.rigRepositories {
+		if slices.Contains(b.polecat.Spec.Repositories, repo.Name) {
+			repos = append(slices.Clip(repos), gastownv1alpha1.AdditionalRepository{
+				URL:          repo.GitURL,
+				Branch:       repo.Branch,
+				Path:         repo.Name,
+				GitSecretRef: repo.GitSecretRef,
+			})
+		}
+	}
+	return repos
+}
+
+// repositoryURLs returns the URLs of the primary and additional repositories
+func (b *Builder) repositoryURLs() []string {
+	k8sSpec := b.polecat.Spec.Kubernetes
+	urls := []string{k8sSpec.GitRepository}
+	for _, repo := range b.additionalRepositories() {
+		urls = append(urls, repo.URL)
+	}
+	return urls
+}
+
+// polecatSecretURLs returns the URLs of the repositories cloned with the
+// polecat's git secret: the primary one and additional repositories without
+// their own
+func (b *Builder) polecatSecretURLs() []string {
+	k8sSpec := b.polecat.Spec.Kubernetes
+	urls := []string{k8sSpec.GitRepository}
+	for _, repo := range b.additionalRepositories() {
+		if repo.GitSecretRef == nil {
+			urls = append(urls, repo.URL)
+		}
+	}
+	return urls
+}
+
+// additionalRepositoriesDirs returns the directories the additional
+// repositories are cloned into
+func (b *Builder) additionalRepositoriesDirs() []string {
+	var dirs []string
+	for _, repo := range b.additionalRepositories() {
+		dirs = append(dirs, repositoryDir(repo))
+	}
+	return dirs
+}
+
+// additionalRepositoriesScript clones every additional repository and checks
+// out the work branch in it. A repository with its own git secret gets its
+// credentials in the shared home volume and is configured to push with them:
+// an SSH key, or HTTPS credentials answered by a credential helper of the
+// repository, which is then cloned over HTTPS.
+func (b *Builder) additionalRepositoriesScript() string {
+	workBranch := b.WorkBranch()
+
+	var script strings.Builder
+	for i, repo := range b.additionalRepositories() {
+		dir := repositoryDir(repo)
+		branch := repositoryBranch(repo)
+
+		if repo.GitSecretRef == nil {
+			fmt.Fprintf(&script, `
+echo "Cloning %[1]s branch %[2]s into %[3]s..."
+GIT_SSH_COMMAND="ssh" git clone --depth=1 -b %[2]s %[1]s %[3]s
+`, repo.URL, branch, dir)
+			fmt.Fprintf(&script, "git -C %s checkout -b %s\n", dir, workBranch)
+			continue
+		}
+
+		creds := repositoryCredsMountPath(i)
+		home := fmt.Sprintf("%s/.git-creds-%d", HomeMountPath, i)
+		key := fmt.Sprintf("%s/.ssh/id_rsa_%d", HomeMountPath, i)
+		sshCommand := fmt.Sprintf("ssh -i %s -o IdentitiesOnly=yes", key)
+		fmt.Fprintf(&script, `
+echo "Cloning %[1]s branch %[2]s into %[3]s..."
+if %[4]s; then
+    mkdir -p %[5]s
+    cp %[6]s/* %[5]s/
+    chmod 600 %[5]s/*
+    git clone --depth=1 -b %[2]s -c credential.helper= -c credential.helper='%[7]s' "$(https_url %[1]s)" %[3]s
+else
+    cp %[6]s/ssh-privatekey %[8]s 2>/dev/null || cp %[6]s/id_rsa %[8]s
+    chmod 600 %[8]s
+    GIT_SSH_COMMAND="%[9]s" git clone --depth=1 -b %[2]s %[1]s %[3]s
+    git -C %[3]s config core.sshCommand "%[9]s"
+fi
+`, repo.URL, branch, dir, hasHTTPSCredentials(creds), home, creds, gitCredentialHelper(home), key, sshCommand)
+		fmt.Fprintf(&script, "git -C %s checkout -b %s\n", dir, workBranch)
+	}
+	return script.String()
+}
+
+// additionalRepositoriesVolumes returns the volumes of the additional
+// repositories' own git secrets
+func (b *Builder) additionalRepositoriesVolumes() []corev1.Volume {
+	var volumes []corev1.Volume
+	for i, repo := range b.additionalRepositories() {
+		if repo.GitSecretRef == nil {
+			continue
+		}
+		volumes = append(volumes, corev1.Volume{
+			Name: repositoryCredsVolumeName(i),
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  repo.GitSecretRef.Name,
+					DefaultMode: int32Ptr(0400),
+				},
+			},
+		})
+	}
+	return volumes
+}
+
+// additionalRepositoriesVolumeMounts mounts the additional repositories' own
+// git secrets in the git init container
+func (b *Builder) additionalRepositoriesVolumeMounts() []corev1.VolumeMount {
+	var mounts []corev1.VolumeMount
+	for i, repo := range b.additionalRepositories() {
+		if repo.GitSecretRef == nil {
+			continue
+		}
+		mounts = append(mounts, corev1.VolumeMount{
+			Name:      repositoryCredsVolumeName(i),
+			MountPath: repositoryCredsMountPath(i),
+			ReadOnly:  true,
+		})
+	}
+	return mounts
+}
@@ -0,0 +1,98 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+const (
+	// SigningKeyVolumeName is the volume of the rig's commit signing key
+	SigningKeyVolumeName = "signing-key"
+
+	// SigningKeyMountPath is where the agent container mounts it
+	SigningKeyMountPath = "/signing-key"
+
+	// SigningKeyKey is the key of the signing key Secret holding the
+	// private key
+	SigningKeyKey = "signing-key"
+)
+
+// commitSigningScript configures git in the agent container to sign its
+// commits with the rig's key: imported into a keyring in the home volume for
+// gpg, copied next to the SSH keys for ssh. The agent image must have gpg or
+// ssh-keygen, respectively.
+func (b *Builder) commitSigningScript() string {
+	if b.signing == nil {
+		return ""
+	}
+	key := SigningKeyMountPath + "/" + SigningKeyKey
+	if b.signing.Format == gastownv1alpha1.CommitSigningSSH {
+		return fmt.Sprintf(`
+# Sign commits with the rig's SSH key
+cp "%[1]s" "$HOME/.ssh/signing_key"
+chmod 600 "$HOME/.ssh/signing_key"
+git config --global gpg.format ssh
+git config --global user.signingkey "$HOME/.ssh/signing_key"
+git config --global commit.gpgsign true
+echo "Commit signing configured"
+`, key)
+	}
+	return fmt.Sprintf(`
+# Sign commits with the rig's OpenPGP key
+mkdir -p "$HOME/.gnupg"
+chmod 700 "$HOME/.gnupg"
+gpg --batch --import "%[1]s"
+SIGNING_KEY=$(gpg --batch --with-colons --list-secret-keys | awk -F: '$1 == "fpr" { print $10; exit }')
+git config --global user.signingkey "$SIGNING_KEY"
+git config --global commit.gpgsign true
+echo "Commit signing configured"
+`, key)
+}
+
+// commitSigningVolumes returns the volume of the rig's signing key, if any
+func (b *Builder) commitSigningVolumes() []corev1.Volume {
+	if b.signing == nil {
+		return nil
+	}
+	return []corev1.Volume{{
+		Name: SigningKeyVolumeName,
+		VolumeSource: corev1.VolumeSource{
+			Secret: &corev1.SecretVolumeSource{
+				SecretName:  b.signing.KeySecretRef.Name,
+				DefaultMode: int32Ptr(0400),
+			},
+		},
+	}}
+}
+
+// commitSigningVolumeMounts returns the agent container's mount of the rig's
+// signing key, if any
+func (b *Builder) commitSigningVolumeMounts() []corev1.VolumeMount {
+	if b.signing == nil {
+		return nil
+	}
+	return []corev1.VolumeMount{{
+		Name:      SigningKeyVolumeName,
+		MountPath: SigningKeyMountPath,
+		ReadOnly:  true,
+	}}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"strings"
+	"testing"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func TestCommitSigning(t *testing.T) {
+	t.Run("leaves commits unsigned by default", func(t *testing.T) {
+		p, err := NewBuilder(newContextPolecat()).Build()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		for _, vol := range p.Spec.Volumes {
+			if vol.Name == SigningKeyVolumeName {
+				t.Error("expected no signing key volume")
+			}
+		}
+		if strings.Contains(p.Spec.Containers[0].Args[0], "commit.gpgsign") {
+			t.Error("expected no commit signing")
+		}
+	})
+
+	for format, want := range map[gastownv1alpha1.CommitSigningFormat][]string{
+		gastownv1alpha1.CommitSigningGPG: {
+			`gpg --batch --import "/signing-key/signing-key"`,
+			`git config --global user.signingkey "$SIGNING_KEY"`,
+		},
+		gastownv1alpha1.CommitSigningSSH: {
+			`cp "/signing-key/signing-key" "$HOME/.ssh/signing_key"`,
+			"git config --global gpg.format ssh",
+		},
+	} {
+		t.Run("signs with "+string(format)+" keys", func(t *testing.T) {
+			p, err := NewBuilder(newContextPolecat()).WithCommitSigning(&gastownv1alpha1.CommitSigningSpec{
+				Format:       format,
+				KeySecretRef: gastownv1alpha1.SecretReference{Name: "signing"},
+			}).Build()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			script := p.Spec.Containers[0].Args[0]
+			for _, line := range append(want, "git config --global commit.gpgsign true") {
+				if !strings.Contains(script, line) {
+					t.Errorf("expected agent script to contain %q", line)
+				}
+			}
+
+			found := false
+			for _, vol := range p.Spec.Volumes {
+				if vol.Name == SigningKeyVolumeName {
+					found = vol.Secret != nil && vol.Secret.SecretName == "signing"
+				}
+			}
+			if !found {
+				t.Errorf("expected the signing Secret as a volume, got %v", p.Spec.Volumes)
+			}
+			mounted := false
+			for _, m := range p.Spec.Containers[0].VolumeMounts {
+				mounted = mounted || (m.Name == SigningKeyVolumeName && m.ReadOnly)
+			}
+			if !mounted {
+				t.Error("expected the agent container to mount the signing key read-only")
+			}
+			for _, m := range p.Spec.InitContainers[0].VolumeMounts {
+				if m.Name == SigningKeyVolumeName {
+					t.Error("the git init container must not see the signing key")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,215 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"fmt"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+const (
+	// TestPodLabel marks Refinery test Pods; the value is the Refinery name
+	TestPodLabel = "gastown.io/refinery-test"
+
+	// TestCheckoutContainerName is the init container checking out the commit
+	TestCheckoutContainerName = "checkout"
+
+	// TestContainerName is the container running the test command
+	TestContainerName = "test"
+)
+
+// gitCredsScript installs the credentials of the git-creds mount, when
+// present, and the pre-verified known hosts. HTTPS credentials are configured
+// as a credential helper and $GT_GIT_URL is rewritten to HTTPS; otherwise the
+// mount holds an SSH key.
+var gitCredsScript = fmt.Sprintf(`
+set -e
+%[3]s
+
+mkdir -p ~/.ssh
+if %[4]s; then
+    git config --global credential.helper '%[5]s'
+    GT_GIT_URL=$(https_url "$GT_GIT_URL")
+elif [ -d %[1]s ]; then
+    for key in ssh-privatekey id_rsa id_ed25519 identity; do
+        if [ -f "%[1]s/$key" ]; then
+            cp "%[1]s/$key" ~/.ssh/id_rsa
+            chmod 600 ~/.ssh/id_rsa
+            break
+        fi
+    done
+fi
+echo "StrictHostKeyChecking yes" >> ~/.ssh/config
+cat > ~/.ssh/known_hosts << 'KNOWN_HOSTS_EOF'
+%[2]s
+KNOWN_HOSTS_EOF
+chmod 644 ~/.ssh/known_hosts
+`, GitCredsMountPath, PreVerifiedSSHKnownHosts,
+	httpsURLScript, hasHTTPSCredentials(GitCredsMountPath), gitCredentialHelper(GitCredsMountPath))
+
+// testCheckoutScript clones the branch and verifies that its head is the
+// commit under test. Credentials are read from the git-creds mount, when
+// present; the branch, URL and commit come from the environment so that
+// they are never interpreted by the shell.
+var testCheckoutScript = gitCredsScript + fmt.Sprintf(`
+echo "Cloning $GT_GIT_URL branch $GT_BRANCH..."
+git clone --depth=1 --single-branch -b "$GT_BRANCH" "$GT_GIT_URL" %[1]s/repo
+cd %[1]s/repo
+head=$(git rev-parse HEAD)
+if [ "$head" != "$GT_COMMIT" ]; then
+    echo "branch $GT_BRANCH is at $head, expected $GT_COMMIT" | tee /dev/termination-log
+    exit 1
+fi
+echo "Checked out $GT_COMMIT"
+`, WorkspaceMountPath)
+
+// TestPodOptions describes a Refinery test Pod.
+type TestPodOptions struct {
+	// Name and Namespace of the Pod
+	Name      string
+	Namespace string
+
+	// Labels of the Pod, in addition to TestPodLabel
+	Labels map[string]string
+
+	// Refinery is the name of the Refinery running the tests
+	Refinery string
+
+	// Image runs Command
+	Image string
+
+	// Command is run with sh -c in the checked out repository
+	Command string
+
+	// GitURL, Branch and Commit select what is checked out. The clone
+	// fails if Branch has moved past Commit.
+	GitURL string
+	Branch string
+	Commit string
+
+	// GitSecretName is the Secret holding the SSH key or HTTPS credentials
+	// of the clone; empty clones without credentials
+	GitSecretName string
+
+	// Resources of the test container
+	Resources *corev1.ResourceRequirements
+
+	// DeadlineSeconds bounds the Pod once it has started
+	DeadlineSeconds int64
+}
+
+// TestPod builds the Pod that checks out a rebased branch and runs the
+// Refinery's test command on it. The credentials are only mounted in the
+// checkout init container; the test command runs without them, under the
+// same restricted security context as polecat agents.
+func TestPod(opts TestPodOptions) *corev1.Pod {
+	// The security contexts do not depend on the polecat
+	restricted := &Builder{}
+
+	labels := map[string]string{TestPodLabel: opts.Refinery}
+	for k, v := range opts.Labels {
+		labels[k] = v
+	}
+
+	checkoutMounts := []corev1.VolumeMount{
+		{Name: WorkspaceVolumeName, MountPath: WorkspaceMountPath},
+		{Name: HomeVolumeName, MountPath: HomeMountPath},
+		{Name: TmpVolumeName, MountPath: TmpMountPath},
+	}
+	volumes := []corev1.Volume{
+		{Name: WorkspaceVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: HomeVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		{Name: TmpVolumeName, VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+	}
+	if opts.GitSecretName != "" {
+		checkoutMounts = append(checkoutMounts, corev1.VolumeMount{
+			Name:      GitCredsVolumeName,
+			MountPath: GitCredsMountPath,
+			ReadOnly:  true,
+		})
+		volumes = append(volumes, corev1.Volume{
+			Name: GitCredsVolumeName,
+			VolumeSource: corev1.VolumeSource{
+				Secret: &corev1.SecretVolumeSource{
+					SecretName:  opts.GitSecretName,
+					DefaultMode: int32Ptr(0o400),
+				},
+			},
+		})
+	}
+
+	test := corev1.Container{
+		Name:       TestContainerName,
+		Image:      opts.Image,
+		Command:    []string{"/bin/sh", "-c"},
+		Args:       []string{opts.Command},
+		WorkingDir: WorkspaceMountPath + "/repo",
+		Env: []corev1.EnvVar{
+			{Name: "HOME", Value: HomeMountPath},
+		},
+		SecurityContext:          restricted.buildSecurityContext(),
+		TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+		VolumeMounts: []corev1.VolumeMount{
+			{Name: WorkspaceVolumeName, MountPath: WorkspaceMountPath},
+			{Name: HomeVolumeName, MountPath: HomeMountPath},
+			{Name: TmpVolumeName, MountPath: TmpMountPath},
+		},
+	}
+	if opts.Resources != nil {
+		test.Resources = *opts.Resources
+	}
+
+	var deadline *int64
+	if opts.DeadlineSeconds > 0 {
+		deadline = int64Ptr(opts.DeadlineSeconds)
+	}
+
+	pod := &corev1.Pod{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      opts.Name,
+			Namespace: opts.Namespace,
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			RestartPolicy:                corev1.RestartPolicyNever,
+			ActiveDeadlineSeconds:        deadline,
+			AutomountServiceAccountToken: boolPtr(false),
+			SecurityContext:              restricted.buildPodSecurityContext(),
+			InitContainers: []corev1.Container{{
+				Name:    TestCheckoutContainerName,
+				Image:   GetGitImage(),
+				Command: []string{"/bin/sh", "-c"},
+				Args:    []string{testCheckoutScript},
+				Env: []corev1.EnvVar{
+					{Name: "HOME", Value: HomeMountPath},
+					{Name: "GT_GIT_URL", Value: opts.GitURL},
+					{Name: "GT_BRANCH", Value: opts.Branch},
+					{Name: "GT_COMMIT", Value: opts.Commit},
+				},
+				SecurityContext:          restricted.buildSecurityContext(),
+				TerminationMessagePolicy: corev1.TerminationMessageFallbackToLogsOnError,
+				VolumeMounts:             checkoutMounts,
+			}},
+			Containers: []corev1.Container{test},
+			Volumes:    volumes,
+		},
+	}
+	mirrorImages(&pod.Spec)
+	return pod
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+)
+
+func TestTestPod(t *testing.T) {
+	opts := TestPodOptions{
+		Name:      "refinery-test-abc123",
+		Namespace: "gastown-system",
+		Labels:    map[string]string{"gastown.io/polecat": "furiosa"},
+		Refinery:  "refinery",
+		Image:     "golang:1.25",
+		Command:   "go test ./...",
+		GitURL:    "git@github.com:acme/widgets.git",
+		Branch:    "polecat/furiosa",
+		Commit:    "abc123",
+		Resources: &corev1.ResourceRequirements{
+			Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("2Gi")},
+		},
+		DeadlineSeconds: 1800,
+	}
+
+	t.Run("runs the command on the checked out commit", func(t *testing.T) {
+		p := TestPod(opts)
+		if p.Labels[TestPodLabel] != "refinery" || p.Labels["gastown.io/polecat"] != "furiosa" {
+			t.Errorf("unexpected labels: %v", p.Labels)
+		}
+		if p.Spec.RestartPolicy != corev1.RestartPolicyNever || *p.Spec.ActiveDeadlineSeconds != 1800 {
+			t.Errorf("expected a single run bounded by the deadline, got %s %v",
+				p.Spec.RestartPolicy, p.Spec.ActiveDeadlineSeconds)
+		}
+
+		test := p.Spec.Containers[0]
+		if test.Image != "golang:1.25" || test.Args[0] != "go test ./..." || test.WorkingDir != "/workspace/repo" {
+			t.Errorf("unexpected test container: %s %v %s", test.Image, test.Args, test.WorkingDir)
+		}
+		if test.Resources.Limits.Memory().String() != "2Gi" {
+			t.Errorf("expected test resources, got %v", test.Resources)
+		}
+		if test.TerminationMessagePolicy != corev1.TerminationMessageFallbackToLogsOnError {
+			t.Errorf("expected the log tail as termination message, got %s", test.TerminationMessagePolicy)
+		}
+
+		checkout := p.Spec.InitContainers[0]
+		env := map[string]string{}
+		for _, e := range checkout.Env {
+			env[e.Name] = e.Value
+		}
+		if env["GT_GIT_URL"] != opts.GitURL || env["GT_BRANCH"] != opts.Branch || env["GT_COMMIT"] != opts.Commit {
+			t.Errorf("unexpected checkout env: %v", env)
+		}
+	})
+
+	t.Run("mounts credentials only for the checkout", func(t *testing.T) {
+		withSecret := opts
+		withSecret.GitSecretName = "git-creds"
+		p := TestPod(withSecret)
+
+		hasCreds := func(c corev1.Container) bool {
+			for _, m := range c.VolumeMounts {
+				if m.Name == GitCredsVolumeName {
+					return true
+				}
+			}
+			return false
+		}
+		if !hasCreds(p.Spec.InitContainers[0]) {
+			t.Error("expected git credentials in the checkout container")
+		}
+		if hasCreds(p.Spec.Containers[0]) {
+			t.Error("test container must not see git credentials")
+		}
+		if p.Spec.AutomountServiceAccountToken == nil || *p.Spec.AutomountServiceAccountToken {
+			t.Error("test pod must not mount a service account token")
+		}
+
+		if p := TestPod(opts); len(p.Spec.Volumes) != 3 {
+			t.Errorf("expected no credentials volume without a secret, got %v", p.Spec.Volumes)
+		}
+	})
+}
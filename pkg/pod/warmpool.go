@@ -0,0 +1,170 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+const (
+	// WarmPoolLabel marks the warm Pods of a rig, by rig name, until a
+	// polecat claims one
+	WarmPoolLabel = "gastown.io/warm-pool"
+
+	// StandbyHashAnnotation identifies the Pod spec of a warm Pod. Polecats
+	// whose Pod would differ from it only in their bead claim it.
+	StandbyHashAnnotation = "gastown.io/standby-hash"
+
+	// AssignmentAnnotation hands a bead to a warm Pod, as the shell
+	// variables its agent container sources before starting the agent
+	AssignmentAnnotation = "gastown.io/assignment"
+
+	// AssignmentFile is where the downward API projects AssignmentAnnotation
+	// into a warm Pod. The kubelet refreshes it within a minute or so of the
+	// claim.
+	AssignmentFile = PodInfoMountPath + "/" + assignmentPath
+
+	// MaxAssignmentSize bounds the AssignmentAnnotation, well below the
+	// 256KiB the API server allows for all the annotations of a Pod. A bead
+	// with a larger assignment, e.g. a long previous attempt log, starts its
+	// own Pod instead of claiming a warm one.
+	MaxAssignmentSize = 64 << 10
+
+	assignmentPath = "assignment"
+)
+
+// assignmentEnv are the container variables that describe the bead rather
+// than the Pod. Warm Pods are built without them and their agent gets them
+// from the assignment; the telemetry sidecar of a claimed warm Pod labels its
+// metrics with an unknown polecat and bead.
+var assignmentEnv = []string{
+	"GT_ISSUE",
+	"GT_POLECAT",
+	"GT_TASK_DESCRIPTION",
+	"GT_AGENT_PROMPT",
+	"GT_SPLIT_OF",
+	"GT_PREVIOUS_ATTEMPT",
+	"GT_PREVIOUS_ATTEMPT_LOG_FILE",
+	"GT_CONTEXT",
+	"GT_PREVIOUS_ATTEMPT_LOG",
+	"POLECAT_NAME",
+	"POLECAT_BEAD",
+}
+
+// BuildStandby builds a warm Pod from the builder's Polecat: its workspace
+// is cloned from the base branch and its agent waits for an assignment (see
+// Assignment) before it starts. The bead-specific settings of the Polecat are
+// left out, so any Polecat of the rig whose Pod differs only in them can
+// claim the warm Pod; their BuildStandby has the same StandbyHashAnnotation.
+// The Pod has no active deadline, which the claim sets.
+func (b *Builder) BuildStandby() (*corev1.Pod, error) {
+	standby := *b
+	standby.standby = true
+	p, err := standby.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	rig := b.polecat.Spec.Rig
+	p.Name = ""
+	p.GenerateName = fmt.Sprintf("warm-%s-", rig)
+	p.Labels = map[string]string{
+		"gastown.io/rig": rig,
+		WarmPoolLabel:    rig,
+	}
+	p.Spec.ActiveDeadlineSeconds = nil
+	for _, containers := range [][]corev1.Container{p.Spec.InitContainers, p.Spec.Containers} {
+		for i := range containers {
+			containers[i].Env = slices.DeleteFunc(containers[i].Env, func(env corev1.EnvVar) bool {
+				return slices.Contains(assignmentEnv, env.Name)
+			})
+		}
+	}
+
+	spec, err := json.Marshal(p.Spec)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash warm pod: %w", err)
+	}
+	sum := sha256.Sum256(spec)
+	p.Annotations = map[string]string{StandbyHashAnnotation: hex.EncodeToString(sum[:8])}
+	return p, nil
+}
+
+// Assignment returns the AssignmentAnnotation handing the builder's bead to a
+// warm Pod: the variables the Pod built for it sets, and its work branch.
+func (b *Builder) Assignment() (string, error) {
+	p, err := b.Build()
+	if err != nil {
+		return "", err
+	}
+
+	values := map[string]string{"GT_WORK_BRANCH": b.WorkBranch()}
+	for _, c := range append(p.Spec.InitContainers, p.Spec.Containers...) {
+		for _, env := range c.Env {
+			if env.ValueFrom == nil && slices.Contains(assignmentEnv, env.Name) {
+				values[env.Name] = env.Value
+			}
+		}
+	}
+	names := make([]string, 0, len(values))
+	for name := range values {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var assignment strings.Builder
+	for _, name := range names {
+		fmt.Fprintf(&assignment, "export %s=%s\n", name, shellQuote(values[name]))
+	}
+	return assignment.String(), nil
+}
+
+// standbyScript makes a warm Pod's agent container wait for its assignment,
+// then check out the work branch and write the assignment metadata the git
+// init container writes in other Pods.
+func (b *Builder) standbyScript() string {
+	if !b.standby {
+		return ""
+	}
+	return fmt.Sprintf(`
+# Warm Pod: wait for a bead to be assigned, then take it on
+echo "Warm Pod ready, waiting for a bead..."
+until [ -s %[1]s ]; do
+    sleep 2
+done
+. %[1]s
+git checkout -B "$GT_WORK_BRANCH"
+mkdir -p %[2]s
+printf '%%s\n' "$GT_CONTEXT" > %[3]s
+if [ -n "$GT_PREVIOUS_ATTEMPT_LOG" ]; then
+    printf '%%s\n' "$GT_PREVIOUS_ATTEMPT_LOG" > %[4]s
+fi
+`, AssignmentFile, ContextDir, ContextFile, PreviousAttemptLogFile)
+}
+
+// shellQuote quotes s for a POSIX shell
+func shellQuote(s string) string {
+	return "'" + strings.ReplaceAll(s, "'", `'\''`) + "'"
+}
@@ -0,0 +1,104 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package pod
+
+import (
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestBuildStandby(t *testing.T) {
+	warm, err := NewBuilder(newContextPolecat()).BuildStandby()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if warm.Name != "" || warm.GenerateName != "warm-myproject-" {
+		t.Errorf("expected a generated warm pod name, got %q/%q", warm.Name, warm.GenerateName)
+	}
+	if warm.Labels[WarmPoolLabel] != "myproject" || warm.Labels["gastown.io/polecat"] != "" {
+		t.Errorf("unexpected labels: %v", warm.Labels)
+	}
+	if warm.Spec.ActiveDeadlineSeconds != nil {
+		t.Error("expected no active deadline before the pod is claimed")
+	}
+	if strings.Contains(warm.Spec.InitContainers[0].Args[0], "checkout -B") {
+		t.Error("expected the warm pod to stay on the base branch")
+	}
+	agent := warm.Spec.Containers[0]
+	if !strings.Contains(agent.Args[0], "until [ -s "+AssignmentFile+" ]") {
+		t.Error("expected the agent to wait for its assignment")
+	}
+	if env, ok := envByName(agent)["GT_ISSUE"]; ok {
+		t.Errorf("expected no bead in the warm pod, got %q", env.Value)
+	}
+	for name, script := range map[string]string{"init": warm.Spec.InitContainers[0].Args[0], "agent": agent.Args[0]} {
+		if out, err := exec.Command("sh", "-n", "-c", script).CombinedOutput(); err != nil {
+			t.Errorf("%s script is not valid shell: %v\n%s", name, err, out)
+		}
+	}
+
+	// Another bead of the rig claims the same warm pods, another image does not
+	other := newContextPolecat()
+	other.Name = "nux"
+	other.Spec.BeadID = "gt-2"
+	other.Spec.TaskDescription = "Polish the widget"
+	otherWarm, err := NewBuilder(other).BuildStandby()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	hash := warm.Annotations[StandbyHashAnnotation]
+	if hash == "" || otherWarm.Annotations[StandbyHashAnnotation] != hash {
+		t.Errorf("expected the same standby hash, got %q and %q", hash, otherWarm.Annotations[StandbyHashAnnotation])
+	}
+	other.Spec.Kubernetes.Image = "registry.example.com/agent:v2"
+	otherWarm, err = NewBuilder(other).BuildStandby()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if otherWarm.Annotations[StandbyHashAnnotation] == hash {
+		t.Error("expected another image to change the standby hash")
+	}
+}
+
+func TestAssignment(t *testing.T) {
+	polecat := newContextPolecat()
+	polecat.Spec.TaskDescription = "Don't break the widget's $HOME"
+	assignment, err := NewBuilder(polecat).Assignment()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	file := filepath.Join(t.TempDir(), "assignment")
+	if err := os.WriteFile(file, []byte(assignment), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	out, err := exec.Command("sh", "-c",
+		`. "$1" && printf '%s|%s|%s' "$GT_ISSUE" "$GT_WORK_BRANCH" "$GT_TASK_DESCRIPTION"`, "sh", file).CombinedOutput()
+	if err != nil {
+		t.Fatalf("sourcing the assignment failed: %v\n%s", err, out)
+	}
+	if want := "gt-1|feature/gt-1|Don't break the widget's $HOME"; string(out) != want {
+		t.Errorf("expected %q, got %q", want, out)
+	}
+	if !strings.Contains(assignment, "export GT_CONTEXT=") {
+		t.Error("expected the assignment to carry the agent context")
+	}
+}
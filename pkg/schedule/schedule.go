@@ -0,0 +1,271 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package schedule evaluates time windows opened by 5-field cron schedules.
+package schedule
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	// Embed the IANA database: the operator image has no /usr/share/zoneinfo
+	_ "time/tzdata"
+)
+
+// MaxWindowDuration bounds how long a window stays open after it fires
+const MaxWindowDuration = 7 * 24 * time.Hour
+
+// searchLimit bounds the search for the next fire time. Four years covers
+// schedules that only fire on February 29th.
+const searchLimit = 4 * 366 * 24 * time.Hour
+
+// Schedule is a parsed cron expression: minute hour day-of-month month day-of-week.
+type Schedule struct {
+	minute, hour, dom, month, dow uint64
+
+	// domAny and dowAny record a "*" day field. As in cron, when both day
+	// fields are restricted a time matches if either of them does.
+	domAny, dowAny bool
+}
+
+type field struct {
+	name     string
+	min, max int
+	names    map[string]int
+}
+
+var (
+	minuteField = field{name: "minute", min: 0, max: 59}
+	hourField   = field{name: "hour", min: 0, max: 23}
+	domField    = field{name: "day of month", min: 1, max: 31}
+	monthField  = field{name: "month", min: 1, max: 12, names: map[string]int{
+		"jan": 1, "feb": 2, "mar": 3, "apr": 4, "may": 5, "jun": 6,
+		"jul": 7, "aug": 8, "sep": 9, "oct": 10, "nov": 11, "dec": 12,
+	}}
+	// Day of week accepts 7 as an alias for Sunday
+	dowField = field{name: "day of week", min: 0, max: 7, names: map[string]int{
+		"sun": 0, "mon": 1, "tue": 2, "wed": 3, "thu": 4, "fri": 5, "sat": 6,
+	}}
+)
+
+// Parse parses a 5-field cron expression. Fields accept "*", numbers,
+// ranges (1-5), lists (1,3,5) and steps (*/15, 9-17/2); month and day of
+// week also accept three-letter names (jan, mon-fri).
+func Parse(expr string) (*Schedule, error) {
+	fields := strings.Fields(expr)
+	if len(fields) != 5 {
+		return nil, fmt.Errorf("expected 5 fields (minute hour day-of-month month day-of-week), got %d", len(fields))
+	}
+
+	s := &Schedule{
+		domAny: fields[2] == "*",
+		dowAny: fields[4] == "*",
+	}
+	for i, f := range []struct {
+		field *field
+		bits  *uint64
+	}{
+		{&minuteField, &s.minute},
+		{&hourField, &s.hour},
+		{&domField, &s.dom},
+		{&monthField, &s.month},
+		{&dowField, &s.dow},
+	} {
+		bits, err := f.field.parse(fields[i])
+		if err != nil {
+			return nil, err
+		}
+		*f.bits = bits
+	}
+
+	// Fold Sunday=7 onto Sunday=0
+	if s.dow&(1<<7) != 0 {
+		s.dow = s.dow&^(1<<7) | 1
+	}
+	return s, nil
+}
+
+// parse returns the set of values matched by expr as a bit mask.
+func (f *field) parse(expr string) (uint64, error) {
+	var bits uint64
+	for part := range strings.SplitSeq(expr, ",") {
+		rangeExpr, step := part, 1
+		if before, after, ok := strings.Cut(part, "/"); ok {
+			n, err := strconv.Atoi(after)
+			if err != nil || n < 1 {
+				return 0, fmt.Errorf("invalid step %q in %s field", after, f.name)
+			}
+			rangeExpr, step = before, n
+		}
+
+		lo, hi := f.min, f.max
+		if rangeExpr != "*" {
+			var err error
+			if before, after, ok := strings.Cut(rangeExpr, "-"); ok {
+				if lo, err = f.value(before); err != nil {
+					return 0, err
+				}
+				if hi, err = f.value(after); err != nil {
+					return 0, err
+				}
+				if lo > hi {
+					return 0, fmt.Errorf("invalid range %q in %s field", rangeExpr, f.name)
+				}
+			} else {
+				if lo, err = f.value(rangeExpr); err != nil {
+					return 0, err
+				}
+				// A single value with a step runs to the end of the field, as in cron
+				hi = lo
+				if step > 1 {
+					hi = f.max
+				}
+			}
+		}
+
+		for v := lo; v <= hi; v += step {
+			bits |= 1 << v
+		}
+	}
+	return bits, nil
+}
+
+// value parses a single number or name of the field.
+func (f *field) value(s string) (int, error) {
+	if v, ok := f.names[strings.ToLower(s)]; ok {
+		return v, nil
+	}
+	v, err := strconv.Atoi(s)
+	if err != nil || v < f.min || v > f.max {
+		return 0, fmt.Errorf("invalid value %q in %s field (want %d-%d)", s, f.name, f.min, f.max)
+	}
+	return v, nil
+}
+
+// Matches reports whether the schedule fires at t's minute, in t's location.
+func (s *Schedule) Matches(t time.Time) bool {
+	return s.month&(1<<int(t.Month())) != 0 &&
+		s.dayMatches(t) &&
+		s.hour&(1<<t.Hour()) != 0 &&
+		s.minute&(1<<t.Minute()) != 0
+}
+
+func (s *Schedule) dayMatches(t time.Time) bool {
+	dom := s.dom&(1<<t.Day()) != 0
+	dow := s.dow&(1<<int(t.Weekday())) != 0
+	if s.domAny || s.dowAny {
+		return dom && dow
+	}
+	return dom || dow
+}
+
+// Next returns the first time strictly after t at which the schedule fires,
+// evaluated in t's location. It returns the zero time if the schedule never
+// fires (e.g., February 30th).
+func (s *Schedule) Next(t time.Time) time.Time {
+	loc := t.Location()
+	limit := t.Add(searchLimit)
+	t = t.Truncate(time.Minute).Add(time.Minute)
+
+	// Skip whole months, days and hours that cannot match before stepping
+	// through minutes. time.Date normalizes overflowing fields.
+	for t.Before(limit) {
+		prev := t
+		switch {
+		case s.month&(1<<int(t.Month())) == 0:
+			t = time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, loc)
+		case !s.dayMatches(t):
+			t = time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, loc)
+		case s.hour&(1<<t.Hour()) == 0:
+			t = time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, loc)
+		case s.minute&(1<<t.Minute()) == 0:
+			t = t.Add(time.Minute)
+		default:
+			return t
+		}
+		// Around DST transitions a normalized wall clock time may not move
+		// forward; fall back to stepping through minutes
+		if !t.After(prev) {
+			t = prev.Add(time.Minute)
+		}
+	}
+	return time.Time{}
+}
+
+// Window is a period that opens each time a schedule fires and stays open
+// for a fixed duration.
+type Window struct {
+	Schedule *Schedule
+	Duration time.Duration
+	Location *time.Location
+}
+
+// NewWindow parses a window from a cron expression, a duration and an IANA
+// time zone name ("" is UTC).
+func NewWindow(expr string, duration time.Duration, timeZone string) (*Window, error) {
+	s, err := Parse(expr)
+	if err != nil {
+		return nil, err
+	}
+	if duration < time.Minute || duration > MaxWindowDuration {
+		return nil, fmt.Errorf("duration %s must be between 1m and %s", duration, MaxWindowDuration)
+	}
+	loc, err := time.LoadLocation(timeZone)
+	if err != nil {
+		return nil, fmt.Errorf("unknown time zone %q", timeZone)
+	}
+	return &Window{Schedule: s, Duration: duration, Location: loc}, nil
+}
+
+// OpenAt reports whether the window is open at now. If it is, the time it
+// closes is returned; otherwise the time it next opens (zero if never).
+func (w *Window) OpenAt(now time.Time) (bool, time.Time) {
+	now = now.In(w.Location)
+
+	// Any fire time in (now-Duration, now] keeps the window open; the
+	// earliest candidate is the first one after now-Duration.
+	start := w.Schedule.Next(now.Add(-w.Duration))
+	if !start.IsZero() && !start.After(now) {
+		return true, start.Add(w.Duration)
+	}
+	return false, w.Schedule.Next(now)
+}
+
+// OpenAt reports whether any of the windows is open at now. If one is, the
+// latest close time among the open windows is returned; otherwise the
+// earliest time one of them opens (zero if none ever does).
+func OpenAt(windows []*Window, now time.Time) (bool, time.Time) {
+	var closes, opens time.Time
+	open := false
+	for _, w := range windows {
+		isOpen, at := w.OpenAt(now)
+		switch {
+		case isOpen:
+			open = true
+			if at.After(closes) {
+				closes = at
+			}
+		case !at.IsZero() && (opens.IsZero() || at.Before(opens)):
+			opens = at
+		}
+	}
+	if open {
+		return true, closes
+	}
+	return false, opens
+}
@@ -0,0 +1,167 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package schedule
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParse(t *testing.T) {
+	valid := []string{
+		"* * * * *",
+		"0 22 * * *",
+		"*/15 9-17 * * mon-fri",
+		"0 0 1,15 * *",
+		"30 2 * jan-mar 0,7",
+		"0 9-17/2 * * SAT",
+	}
+	for _, expr := range valid {
+		if _, err := Parse(expr); err != nil {
+			t.Errorf("Parse(%q): unexpected error: %v", expr, err)
+		}
+	}
+
+	invalid := []string{
+		"",
+		"* * * *",
+		"* * * * * *",
+		"60 * * * *",
+		"* 24 * * *",
+		"* * 0 * *",
+		"* * * 13 *",
+		"* * * * 8",
+		"*/0 * * * *",
+		"5-1 * * * *",
+		"* * * * someday",
+		"1,,2 * * * *",
+	}
+	for _, expr := range invalid {
+		if _, err := Parse(expr); err == nil {
+			t.Errorf("Parse(%q): expected error", expr)
+		}
+	}
+}
+
+func TestSchedule_Next(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	if err != nil {
+		t.Fatalf("LoadLocation: %v", err)
+	}
+
+	tests := []struct {
+		expr string
+		from time.Time
+		want time.Time
+	}{
+		{"0 22 * * *", time.Date(2026, 3, 2, 10, 0, 0, 0, time.UTC), time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC)},
+		{"0 22 * * *", time.Date(2026, 3, 2, 22, 0, 0, 0, time.UTC), time.Date(2026, 3, 3, 22, 0, 0, 0, time.UTC)},
+		{"*/15 * * * *", time.Date(2026, 3, 2, 10, 7, 30, 0, time.UTC), time.Date(2026, 3, 2, 10, 15, 0, 0, time.UTC)},
+		// Friday evening to Monday morning
+		{"0 9 * * mon-fri", time.Date(2026, 3, 6, 18, 0, 0, 0, time.UTC), time.Date(2026, 3, 9, 9, 0, 0, 0, time.UTC)},
+		// Sunday as 7
+		{"0 0 * * 7", time.Date(2026, 3, 2, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 8, 0, 0, 0, 0, time.UTC)},
+		// Restricted day-of-month and day-of-week match either
+		{"0 0 15 * mon", time.Date(2026, 3, 10, 0, 0, 0, 0, time.UTC), time.Date(2026, 3, 15, 0, 0, 0, 0, time.UTC)},
+		// Leap day
+		{"0 0 29 feb *", time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC), time.Date(2028, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Wall clock in the schedule's location across the spring DST change
+		{"0 22 * * *", time.Date(2026, 3, 28, 23, 0, 0, 0, berlin), time.Date(2026, 3, 29, 22, 0, 0, 0, berlin)},
+		// Never fires
+		{"0 0 30 feb *", time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC), time.Time{}},
+	}
+	for _, tt := range tests {
+		s, err := Parse(tt.expr)
+		if err != nil {
+			t.Fatalf("Parse(%q): %v", tt.expr, err)
+		}
+		if got := s.Next(tt.from); !got.Equal(tt.want) {
+			t.Errorf("%q.Next(%v) = %v, want %v", tt.expr, tt.from, got, tt.want)
+		}
+	}
+}
+
+func TestWindow_OpenAt(t *testing.T) {
+	// Off-peak: 22:00 to 06:00 New York time on weekdays
+	w, err := NewWindow("0 22 * * mon-fri", 8*time.Hour, "America/New_York")
+	if err != nil {
+		t.Fatalf("NewWindow: %v", err)
+	}
+	ny := w.Location
+
+	tests := []struct {
+		name   string
+		now    time.Time
+		open   bool
+		wantAt time.Time
+	}{
+		{"before opening", time.Date(2026, 3, 4, 21, 59, 0, 0, ny), false, time.Date(2026, 3, 4, 22, 0, 0, 0, ny)},
+		{"at opening", time.Date(2026, 3, 4, 22, 0, 0, 0, ny), true, time.Date(2026, 3, 5, 6, 0, 0, 0, ny)},
+		{"past midnight", time.Date(2026, 3, 5, 3, 0, 0, 0, ny), true, time.Date(2026, 3, 5, 6, 0, 0, 0, ny)},
+		{"at closing", time.Date(2026, 3, 5, 6, 0, 0, 0, ny), false, time.Date(2026, 3, 5, 22, 0, 0, 0, ny)},
+		{"saturday night", time.Date(2026, 3, 7, 23, 0, 0, 0, ny), false, time.Date(2026, 3, 9, 22, 0, 0, 0, ny)},
+		// Evaluated in the window's location whatever the caller's
+		{"utc input", time.Date(2026, 3, 5, 8, 0, 0, 0, time.UTC), true, time.Date(2026, 3, 5, 6, 0, 0, 0, ny)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			open, at := w.OpenAt(tt.now)
+			if open != tt.open || !at.Equal(tt.wantAt) {
+				t.Errorf("OpenAt(%v) = %v, %v; want %v, %v", tt.now, open, at, tt.open, tt.wantAt)
+			}
+		})
+	}
+}
+
+func TestOpenAt_MultipleWindows(t *testing.T) {
+	night, err := NewWindow("0 22 * * *", 8*time.Hour, "UTC")
+	if err != nil {
+		t.Fatalf("NewWindow: %v", err)
+	}
+	lunch, err := NewWindow("0 12 * * *", time.Hour, "UTC")
+	if err != nil {
+		t.Fatalf("NewWindow: %v", err)
+	}
+	windows := []*Window{night, lunch}
+
+	open, at := OpenAt(windows, time.Date(2026, 3, 4, 9, 0, 0, 0, time.UTC))
+	if open || !at.Equal(time.Date(2026, 3, 4, 12, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected closed until the lunch window, got %v, %v", open, at)
+	}
+	open, at = OpenAt(windows, time.Date(2026, 3, 4, 12, 30, 0, 0, time.UTC))
+	if !open || !at.Equal(time.Date(2026, 3, 4, 13, 0, 0, 0, time.UTC)) {
+		t.Errorf("expected open until 13:00, got %v, %v", open, at)
+	}
+	if open, _ := OpenAt(nil, time.Now()); open {
+		t.Error("expected no windows to be closed")
+	}
+}
+
+func TestNewWindow_Invalid(t *testing.T) {
+	if _, err := NewWindow("0 22 * * *", 0, "UTC"); err == nil {
+		t.Error("expected error for zero duration")
+	}
+	if _, err := NewWindow("0 22 * * *", 8*24*time.Hour, "UTC"); err == nil {
+		t.Error("expected error for a duration over a week")
+	}
+	if _, err := NewWindow("0 22 * * *", time.Hour, "Mars/Olympus_Mons"); err == nil {
+		t.Error("expected error for unknown time zone")
+	}
+	if _, err := NewWindow("0 22 * *", time.Hour, ""); err == nil {
+		t.Error("expected error for invalid schedule")
+	}
+}
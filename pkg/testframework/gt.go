@@ -0,0 +1,73 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testframework
+
+import (
+	"context"
+	"slices"
+	"sync"
+)
+
+// Mail is a message sent through the gt backend
+type Mail struct {
+	Address, Subject, Message string
+}
+
+// FakeGT is a gt backend recording the mail it is asked to send, e.g. the
+// escalations of the Witness. Set it as WitnessReconciler.GTClient. It is
+// safe for concurrent use; the zero value is ready to use.
+type FakeGT struct {
+	mu   sync.Mutex
+	mail []Mail
+	err  error
+}
+
+// MailSend records the mail, or returns the error set with FailWith.
+func (f *FakeGT) MailSend(_ context.Context, address, subject, message string) error {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.err != nil {
+		return f.err
+	}
+	f.mail = append(f.mail, Mail{Address: address, Subject: subject, Message: message})
+	return nil
+}
+
+// FailWith makes further sends fail with err; nil makes them succeed again.
+func (f *FakeGT) FailWith(err error) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.err = err
+}
+
+// Mail returns the mail sent so far, in order.
+func (f *FakeGT) Mail() []Mail {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return slices.Clone(f.mail)
+}
+
+// MailTo returns the mail sent so far to address, in order.
+func (f *FakeGT) MailTo(address string) []Mail {
+	var sent []Mail
+	for _, m := range f.Mail() {
+		if m.Address == address {
+			sent = append(sent, m)
+		}
+	}
+	return sent
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testframework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+// SandboxLabel marks the namespaces of sandboxes, so that ones leaked by
+// interrupted tests can be found and deleted
+const SandboxLabel = "gastown.io/test-sandbox"
+
+// Sandbox is a namespace of its own for a test, with the cluster-scoped
+// Rigs created through it. Close deletes both.
+type Sandbox struct {
+	// Namespace is the sandbox's generated namespace
+	Namespace string
+
+	client client.Client
+	rigs   []*gastownv1alpha1.Rig
+}
+
+// NewSandbox creates a namespace named after prefix with a random suffix,
+// labeled with SandboxLabel.
+func NewSandbox(ctx context.Context, c client.Client, prefix string) (*Sandbox, error) {
+	ns := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: prefix + "-",
+			Labels:       map[string]string{SandboxLabel: prefix},
+		},
+	}
+	if err := c.Create(ctx, ns); err != nil {
+		return nil, fmt.Errorf("failed to create sandbox namespace: %w", err)
+	}
+	return &Sandbox{Namespace: ns.Name, client: c}, nil
+}
+
+// CreateRig creates a fixture Rig whose children live in the sandbox. It is
+// named after the sandbox, so that sandboxes do not share Rigs.
+func (s *Sandbox) CreateRig(ctx context.Context, opts ...RigOption) (*gastownv1alpha1.Rig, error) {
+	rig := NewRig(s.Namespace, s.Namespace, opts...)
+	if err := s.client.Create(ctx, rig); err != nil {
+		return nil, fmt.Errorf("failed to create rig %s: %w", rig.Name, err)
+	}
+	s.rigs = append(s.rigs, rig)
+	return rig, nil
+}
+
+// CreatePolecat creates a fixture Polecat of rig in the sandbox.
+func (s *Sandbox) CreatePolecat(
+	ctx context.Context, name string, rig *gastownv1alpha1.Rig, bead string, opts ...PolecatOption,
+) (*gastownv1alpha1.Polecat, error) {
+	polecat := NewPolecat(name, s.Namespace, rig.Name, bead, opts...)
+	if err := s.client.Create(ctx, polecat); err != nil {
+		return nil, fmt.Errorf("failed to create polecat %s: %w", name, err)
+	}
+	return polecat, nil
+}
+
+// Close deletes the sandbox's Rigs and namespace. It waits up to timeout for
+// the Rigs to be gone, so that the operator ran their finalizers; the
+// namespace is left to terminate in the background, which it never does in
+// envtest. A zero timeout waits DefaultWaitTimeout.
+func (s *Sandbox) Close(ctx context.Context, timeout time.Duration) error {
+	var errs []error
+	for _, rig := range s.rigs {
+		if err := s.client.Delete(ctx, rig); err != nil && !apierrors.IsNotFound(err) {
+			errs = append(errs, fmt.Errorf("failed to delete rig %s: %w", rig.Name, err))
+		}
+	}
+	ns := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: s.Namespace}}
+	if err := s.client.Delete(ctx, ns); err != nil && !apierrors.IsNotFound(err) {
+		errs = append(errs, fmt.Errorf("failed to delete sandbox namespace: %w", err))
+	}
+	if len(errs) > 0 {
+		return errors.Join(errs...)
+	}
+
+	for _, rig := range s.rigs {
+		errs = append(errs, WaitForDeletion(ctx, s.client, rig, timeout))
+	}
+	return errors.Join(errs...)
+}
@@ -0,0 +1,166 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testframework
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+func newFakeClient(t *testing.T) client.Client {
+	t.Helper()
+	scheme := runtime.NewScheme()
+	if err := clientgoscheme.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	if err := gastownv1alpha1.AddToScheme(scheme); err != nil {
+		t.Fatal(err)
+	}
+	return fake.NewClientBuilder().WithScheme(scheme).
+		WithStatusSubresource(&gastownv1alpha1.Polecat{}).Build()
+}
+
+func TestFixtures(t *testing.T) {
+	rig := NewRig("app", "agents", WithMaxPolecats(3), Suspended())
+	if rig.Spec.ChildNamespace != "agents" || rig.Spec.Settings.MaxPolecats != 3 || !rig.Spec.Suspend {
+		t.Errorf("unexpected rig spec %+v", rig.Spec)
+	}
+
+	polecat := NewPolecat("nux", "agents", "app", "gt-1", WithTask("Fix login"), Idle())
+	if _, err := (&gastownv1alpha1.PolecatCustomValidator{}).ValidateCreate(context.Background(), polecat); err != nil {
+		t.Errorf("expected a valid polecat, got %v", err)
+	}
+	if polecat.Spec.DesiredState != gastownv1alpha1.PolecatDesiredIdle || polecat.Spec.TaskDescription != "Fix login" {
+		t.Errorf("expected the options applied, got %+v", polecat.Spec)
+	}
+	if polecat.Spec.Kubernetes.GitSecretRef.Name != NewGitSecret("agents").Name {
+		t.Error("expected the polecat to use the fixture git secret")
+	}
+}
+
+func TestFakeGT(t *testing.T) {
+	gt := &FakeGT{}
+	ctx := context.Background()
+	if err := gt.MailSend(ctx, "mayor", "Health Alert", "polecat nux is stuck"); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	gt.FailWith(errors.New("gt unavailable"))
+	if err := gt.MailSend(ctx, "witness", "ignored", ""); err == nil {
+		t.Error("expected the send to fail")
+	}
+	gt.FailWith(nil)
+	if err := gt.MailSend(ctx, "witness", "Escalation", ""); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := gt.Mail(); len(got) != 2 {
+		t.Errorf("expected 2 mails, got %+v", got)
+	}
+	if got := gt.MailTo("mayor"); len(got) != 1 || got[0].Subject != "Health Alert" {
+		t.Errorf("expected the mayor's health alert, got %+v", got)
+	}
+}
+
+func TestWait(t *testing.T) {
+	defer func(interval time.Duration) { PollInterval = interval }(PollInterval)
+	PollInterval = 10 * time.Millisecond
+	ctx := context.Background()
+	c := newFakeClient(t)
+
+	polecat := NewPolecat("nux", "default", "app", "gt-1")
+	if err := c.Create(ctx, polecat); err != nil {
+		t.Fatal(err)
+	}
+	go func() {
+		time.Sleep(50 * time.Millisecond)
+		update := polecat.DeepCopy()
+		update.Status.Phase = gastownv1alpha1.PolecatPhaseWorking
+		update.Status.Conditions = []metav1.Condition{{
+			Type: "Ready", Status: metav1.ConditionTrue, Reason: "PodRunning", LastTransitionTime: metav1.Now(),
+		}}
+		_ = c.Status().Update(ctx, update)
+	}()
+
+	waited := &gastownv1alpha1.Polecat{ObjectMeta: metav1.ObjectMeta{Name: "nux", Namespace: "default"}}
+	if err := WaitForPolecatPhase(ctx, c, waited, gastownv1alpha1.PolecatPhaseWorking, time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := WaitForCondition(ctx, c, waited, "Ready", time.Second); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+
+	err := WaitForPolecatPhase(ctx, c, waited, gastownv1alpha1.PolecatPhaseDone, 50*time.Millisecond)
+	if err == nil || !strings.Contains(err.Error(), "default/nux") {
+		t.Errorf("expected a timeout naming the polecat, got %v", err)
+	}
+
+	if err := c.Delete(ctx, waited); err != nil {
+		t.Fatal(err)
+	}
+	if err := WaitForDeletion(ctx, c, waited, time.Second); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestSandbox(t *testing.T) {
+	ctx := context.Background()
+	c := newFakeClient(t)
+
+	sandbox, err := NewSandbox(ctx, c, "e2e")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(sandbox.Namespace, "e2e-") {
+		t.Errorf("expected a generated namespace, got %q", sandbox.Namespace)
+	}
+	rig, err := sandbox.CreateRig(ctx)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	polecat, err := sandbox.CreatePolecat(ctx, "nux", rig, "gt-1")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if polecat.Namespace != sandbox.Namespace || polecat.Spec.Rig != rig.Name {
+		t.Errorf("expected the polecat in the sandbox on its rig, got %s/%s on %s",
+			polecat.Namespace, polecat.Name, polecat.Spec.Rig)
+	}
+
+	if err := sandbox.Close(ctx, time.Second); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var namespaces corev1.NamespaceList
+	if err := c.List(ctx, &namespaces, client.HasLabels{SandboxLabel}); err != nil {
+		t.Fatal(err)
+	}
+	if len(namespaces.Items) != 0 {
+		t.Errorf("expected the sandbox namespace deleted, got %d", len(namespaces.Items))
+	}
+}
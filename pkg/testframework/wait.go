@@ -0,0 +1,121 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package testframework
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	gastownv1alpha1 "github.com/org/gastown-operator/api/v1alpha1"
+)
+
+var (
+	// DefaultWaitTimeout bounds waits given a zero timeout
+	DefaultWaitTimeout = 2 * time.Minute
+
+	// PollInterval is how often waits read the resource
+	PollInterval = time.Second
+)
+
+// WaitFor reads obj until cond holds for it, and leaves it in obj. A missing
+// resource is waited for. A zero timeout waits DefaultWaitTimeout.
+func WaitFor[T client.Object](ctx context.Context, c client.Reader, obj T, timeout time.Duration, cond func(T) bool) error {
+	if timeout == 0 {
+		timeout = DefaultWaitTimeout
+	}
+	key := client.ObjectKeyFromObject(obj)
+	var lastErr error
+	err := wait.PollUntilContextTimeout(ctx, PollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		lastErr = c.Get(ctx, key, obj)
+		if apierrors.IsNotFound(lastErr) {
+			return false, nil
+		}
+		if lastErr != nil {
+			return false, lastErr
+		}
+		return cond(obj), nil
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for %s: %w", key, errors.Join(err, lastErr))
+	}
+	return nil
+}
+
+// WaitForPolecatPhase waits for the Polecat to reach phase.
+func WaitForPolecatPhase(
+	ctx context.Context, c client.Reader, polecat *gastownv1alpha1.Polecat, phase gastownv1alpha1.PolecatPhase,
+	timeout time.Duration,
+) error {
+	return WaitFor(ctx, c, polecat, timeout, func(p *gastownv1alpha1.Polecat) bool {
+		return p.Status.Phase == phase
+	})
+}
+
+// WaitForCondition waits for the status condition of obj, any Gas Town
+// resource, to be True.
+func WaitForCondition(ctx context.Context, c client.Reader, obj client.Object, conditionType string, timeout time.Duration) error {
+	return WaitFor(ctx, c, obj, timeout, func(obj client.Object) bool {
+		return meta.IsStatusConditionTrue(conditions(obj), conditionType)
+	})
+}
+
+// WaitForDeletion waits for obj to be gone, after its finalizers ran.
+func WaitForDeletion(ctx context.Context, c client.Reader, obj client.Object, timeout time.Duration) error {
+	if timeout == 0 {
+		timeout = DefaultWaitTimeout
+	}
+	key := client.ObjectKeyFromObject(obj)
+	err := wait.PollUntilContextTimeout(ctx, PollInterval, timeout, true, func(ctx context.Context) (bool, error) {
+		err := c.Get(ctx, key, obj)
+		if apierrors.IsNotFound(err) {
+			return true, nil
+		}
+		return false, err
+	})
+	if err != nil {
+		return fmt.Errorf("waiting for deletion of %s: %w", key, err)
+	}
+	return nil
+}
+
+// conditions returns status.conditions of obj, nil when it has none.
+func conditions(obj client.Object) []metav1.Condition {
+	u, err := runtime.DefaultUnstructuredConverter.ToUnstructured(obj)
+	if err != nil {
+		return nil
+	}
+	status, ok := u["status"].(map[string]any)
+	if !ok {
+		return nil
+	}
+	var holder struct {
+		Conditions []metav1.Condition `json:"conditions"`
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(status, &holder); err != nil {
+		return nil
+	}
+	return holder.Conditions
+}
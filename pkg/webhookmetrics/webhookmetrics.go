@@ -0,0 +1,207 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Package webhookmetrics instruments the admission webhooks with latency and
+// rejection metrics, registered with the controller metrics. It is separate
+// from pkg/metrics because the webhooks live in the API package, which
+// pkg/metrics imports.
+package webhookmetrics
+
+import (
+	"context"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"k8s.io/apimachinery/pkg/runtime"
+	"sigs.k8s.io/controller-runtime/pkg/metrics"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+const (
+	labelWebhook   = "webhook"
+	labelOperation = "operation"
+	labelResult    = "result"
+	labelReason    = "reason"
+	labelField     = "field"
+
+	// Operations
+	OperationCreate  = "create"
+	OperationUpdate  = "update"
+	OperationDelete  = "delete"
+	OperationDefault = "default"
+
+	// Results
+	ResultAllowed  = "allowed"
+	ResultRejected = "rejected"
+
+	// Rejection reasons
+	ReasonInvalid   = "invalid"
+	ReasonImmutable = "immutable"
+	ReasonProtected = "protected"
+	ReasonError     = "error"
+)
+
+var (
+	// AdmissionTotal counts admission requests per webhook, operation and result.
+	AdmissionTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gastown_webhook_admission_total",
+			Help: "Total number of admission requests by webhook, operation and result",
+		},
+		[]string{labelWebhook, labelOperation, labelResult},
+	)
+
+	// AdmissionDuration tracks how long the webhooks take to admit a request.
+	AdmissionDuration = prometheus.NewHistogramVec(
+		prometheus.HistogramOpts{
+			Name:    "gastown_webhook_admission_duration_seconds",
+			Help:    "Duration of admission webhook calls in seconds",
+			Buckets: []float64{0.0005, 0.001, 0.0025, 0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5},
+		},
+		[]string{labelWebhook, labelOperation},
+	)
+
+	// RejectionsTotal counts rejected admission requests by reason and by the
+	// field that failed validation, so a misconfigured client repeating the
+	// same bad request stands out.
+	RejectionsTotal = prometheus.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "gastown_webhook_rejections_total",
+			Help: "Total number of rejected admission requests by webhook, operation, reason and field",
+		},
+		[]string{labelWebhook, labelOperation, labelReason, labelField},
+	)
+)
+
+func init() {
+	metrics.Registry.MustRegister(
+		AdmissionTotal,
+		AdmissionDuration,
+		RejectionsTotal,
+	)
+}
+
+// Validator wraps v so every validation is recorded under the webhook name.
+func Validator[T runtime.Object](webhook string, v admission.Validator[T]) admission.Validator[T] {
+	return &validator[T]{webhook: webhook, next: v}
+}
+
+// Defaulter wraps d so every defaulting call is recorded under the webhook name.
+func Defaulter[T runtime.Object](webhook string, d admission.Defaulter[T]) admission.Defaulter[T] {
+	return &defaulter[T]{webhook: webhook, next: d}
+}
+
+type validator[T runtime.Object] struct {
+	webhook string
+	next    admission.Validator[T]
+}
+
+func (v *validator[T]) ValidateCreate(ctx context.Context, obj T) (admission.Warnings, error) {
+	start := time.Now()
+	warnings, err := v.next.ValidateCreate(ctx, obj)
+	Observe(v.webhook, OperationCreate, start, err)
+	return warnings, err
+}
+
+func (v *validator[T]) ValidateUpdate(ctx context.Context, oldObj, newObj T) (admission.Warnings, error) {
+	start := time.Now()
+	warnings, err := v.next.ValidateUpdate(ctx, oldObj, newObj)
+	Observe(v.webhook, OperationUpdate, start, err)
+	return warnings, err
+}
+
+func (v *validator[T]) ValidateDelete(ctx context.Context, obj T) (admission.Warnings, error) {
+	start := time.Now()
+	warnings, err := v.next.ValidateDelete(ctx, obj)
+	Observe(v.webhook, OperationDelete, start, err)
+	return warnings, err
+}
+
+type defaulter[T runtime.Object] struct {
+	webhook string
+	next    admission.Defaulter[T]
+}
+
+func (d *defaulter[T]) Default(ctx context.Context, obj T) error {
+	start := time.Now()
+	err := d.next.Default(ctx, obj)
+	Observe(d.webhook, OperationDefault, start, err)
+	return err
+}
+
+// Observe records one admission call that started at start and returned err.
+func Observe(webhook, operation string, start time.Time, err error) {
+	AdmissionDuration.WithLabelValues(webhook, operation).Observe(time.Since(start).Seconds())
+	if err == nil {
+		AdmissionTotal.WithLabelValues(webhook, operation, ResultAllowed).Inc()
+		return
+	}
+	AdmissionTotal.WithLabelValues(webhook, operation, ResultRejected).Inc()
+	for _, r := range Rejections(err) {
+		RejectionsTotal.WithLabelValues(webhook, operation, r.Reason, r.Field).Inc()
+	}
+}
+
+// Rejection is one reason an admission request was rejected.
+type Rejection struct {
+	Reason string
+	// Field is the path of the offending field with list indices removed,
+	// or "" when the rejection is not about a single field.
+	Field string
+}
+
+// validationFailedPrefix starts the message joining all field failures of a
+// validation: "validation failed: spec.rig: is required; spec.x: ..."
+const validationFailedPrefix = "validation failed: "
+
+var (
+	// fieldPathRe matches a field path at the start of a failure message
+	fieldPathRe = regexp.MustCompile(`^((?:spec|metadata)(?:\.[A-Za-z0-9_]+|\[[^\]]*\])*)`)
+
+	// listIndexRe matches list indices and map keys in a field path
+	listIndexRe = regexp.MustCompile(`\[[^\]]*\]`)
+)
+
+// Rejections classifies the error returned by a webhook. The webhooks report
+// failures as "<field>: <message>", "<field> is immutable: ..." or
+// "... is protected by ...": field paths come from the API schema, which
+// keeps the label values bounded.
+func Rejections(err error) []Rejection {
+	msg := err.Error()
+	if failures, ok := strings.CutPrefix(msg, validationFailedPrefix); ok {
+		var rejections []Rejection
+		for _, failure := range strings.Split(failures, "; ") {
+			rejections = append(rejections, Rejection{Reason: ReasonInvalid, Field: fieldOf(failure)})
+		}
+		return rejections
+	}
+
+	switch {
+	case strings.Contains(msg, " is immutable"):
+		return []Rejection{{Reason: ReasonImmutable, Field: fieldOf(msg)}}
+	case strings.Contains(msg, " is protected "):
+		return []Rejection{{Reason: ReasonProtected}}
+	default:
+		return []Rejection{{Reason: ReasonError}}
+	}
+}
+
+// fieldOf returns the field path a failure message starts with, or "".
+func fieldOf(failure string) string {
+	return listIndexRe.ReplaceAllString(fieldPathRe.FindString(failure), "")
+}
@@ -0,0 +1,107 @@
+/*
+Copyright 2026.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package webhookmetrics
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+)
+
+func TestRejections(t *testing.T) {
+	tests := []struct {
+		err  string
+		want []Rejection
+	}{
+		{
+			"validation failed: spec.rig: is required; spec.executionWindows[2]: bad cron",
+			[]Rejection{{ReasonInvalid, "spec.rig"}, {ReasonInvalid, "spec.executionWindows"}},
+		},
+		{
+			`spec.beadsPrefix is immutable: cannot change from "ab" to "cd"`,
+			[]Rejection{{ReasonImmutable, "spec.beadsPrefix"}},
+		},
+		{
+			`polecat "p" is protected by the gastown.io/protect annotation: remove it to delete the polecat`,
+			[]Rejection{{ReasonProtected, ""}},
+		},
+		{
+			`failed to get rig "r": connection refused`,
+			[]Rejection{{ReasonError, ""}},
+		},
+	}
+	for _, tt := range tests {
+		if got := Rejections(errors.New(tt.err)); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Rejections(%q) = %v, want %v", tt.err, got, tt.want)
+		}
+	}
+}
+
+// podValidator rejects Pods without a name.
+type podValidator struct{}
+
+func (podValidator) ValidateCreate(_ context.Context, p *corev1.Pod) (admission.Warnings, error) {
+	if p.Name == "" {
+		return nil, errors.New("validation failed: metadata.name: is required")
+	}
+	return nil, nil
+}
+
+func (podValidator) ValidateUpdate(_ context.Context, _, _ *corev1.Pod) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func (podValidator) ValidateDelete(_ context.Context, _ *corev1.Pod) (admission.Warnings, error) {
+	return nil, nil
+}
+
+func TestValidator(t *testing.T) {
+	AdmissionTotal.Reset()
+	RejectionsTotal.Reset()
+	AdmissionDuration.Reset()
+
+	v := Validator[*corev1.Pod]("pod", podValidator{})
+	ctx := context.Background()
+	if _, err := v.ValidateCreate(ctx, &corev1.Pod{}); err == nil {
+		t.Fatal("expected the wrapped validator's error")
+	}
+	_, _ = v.ValidateCreate(ctx, &corev1.Pod{})
+	pod := &corev1.Pod{}
+	pod.Name = "ok"
+	if _, err := v.ValidateCreate(ctx, pod); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(AdmissionTotal.WithLabelValues("pod", OperationCreate, ResultRejected)); got != 2 {
+		t.Errorf("rejected = %v, want 2", got)
+	}
+	if got := testutil.ToFloat64(AdmissionTotal.WithLabelValues("pod", OperationCreate, ResultAllowed)); got != 1 {
+		t.Errorf("allowed = %v, want 1", got)
+	}
+	if got := testutil.ToFloat64(
+		RejectionsTotal.WithLabelValues("pod", OperationCreate, ReasonInvalid, "metadata.name")); got != 2 {
+		t.Errorf("rejections = %v, want 2", got)
+	}
+	if got := testutil.CollectAndCount(AdmissionDuration); got != 1 {
+		t.Errorf("duration series = %d, want 1", got)
+	}
+}
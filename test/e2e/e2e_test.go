@@ -30,7 +30,7 @@ import (
 	. "github.com/onsi/ginkgo/v2"
 	. "github.com/onsi/gomega"
 
-	"github.com/org/gastown-operator/test/utils"
+	framework "github.com/org/gastown-operator/pkg/testframework"
 )
 
 // namespace where the project is deployed in
@@ -54,29 +54,29 @@ var _ = Describe("Manager", Ordered, func() {
 	BeforeAll(func() {
 		By("creating manager namespace")
 		cmd := exec.Command("kubectl", "create", "ns", namespace)
-		_, err := utils.Run(cmd)
+		_, err := framework.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to create namespace")
 
 		By("labeling the namespace to enforce the restricted security policy")
 		cmd = exec.Command("kubectl", "label", "--overwrite", "ns", namespace,
 			"pod-security.kubernetes.io/enforce=restricted")
-		_, err = utils.Run(cmd)
+		_, err = framework.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to label namespace with restricted policy")
 
 		By("labeling the namespace to allow metrics access (required by NetworkPolicy)")
 		cmd = exec.Command("kubectl", "label", "--overwrite", "ns", namespace,
 			"metrics=enabled")
-		_, err = utils.Run(cmd)
+		_, err = framework.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to label namespace with metrics=enabled")
 
 		By("installing CRDs")
 		cmd = exec.Command("make", "install")
-		_, err = utils.Run(cmd)
+		_, err = framework.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to install CRDs")
 
 		By("deploying the controller-manager")
 		cmd = exec.Command("make", "deploy", fmt.Sprintf("IMG=%s", projectImage))
-		_, err = utils.Run(cmd)
+		_, err = framework.Run(cmd)
 		Expect(err).NotTo(HaveOccurred(), "Failed to deploy the controller-manager")
 	})
 
@@ -85,45 +85,45 @@ var _ = Describe("Manager", Ordered, func() {
 	AfterAll(func() {
 		By("cleaning up the curl pod for metrics")
 		cmd := exec.Command("kubectl", "delete", "pod", "curl-metrics", "-n", namespace, "--ignore-not-found")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		// Delete all CRs first and wait for them to be fully deleted
 		// This ensures finalizers are processed before the controller is removed
 		By("cleaning up any remaining Polecats")
 		cmd = exec.Command("kubectl", "delete", "polecats", "--all", "-n", namespace, "--ignore-not-found", "--timeout=60s")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		By("cleaning up any remaining Rigs")
 		cmd = exec.Command("kubectl", "delete", "rigs", "--all", "--ignore-not-found", "--timeout=60s")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		By("cleaning up any remaining Witnesses")
 		cmd = exec.Command("kubectl", "delete", "witnesses", "--all", "-n", namespace, "--ignore-not-found", "--timeout=60s")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		By("cleaning up any remaining Refineries")
 		cmd = exec.Command("kubectl", "delete", "refineries", "--all", "-n", namespace, "--ignore-not-found", "--timeout=60s")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		By("cleaning up any remaining Convoys")
 		cmd = exec.Command("kubectl", "delete", "convoys", "--all", "-n", namespace, "--ignore-not-found", "--timeout=60s")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		By("cleaning up any remaining BeadStores")
 		cmd = exec.Command("kubectl", "delete", "beadstores", "--all", "-n", namespace, "--ignore-not-found", "--timeout=60s")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		By("undeploying the controller-manager")
 		cmd = exec.Command("make", "undeploy", "ignore-not-found=true")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		By("uninstalling CRDs")
 		cmd = exec.Command("make", "uninstall", "ignore-not-found=true")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 
 		By("removing manager namespace")
 		cmd = exec.Command("kubectl", "delete", "ns", namespace, "--ignore-not-found", "--timeout=60s")
-		_, _ = utils.Run(cmd)
+		_, _ = framework.Run(cmd)
 	})
 
 	// After each test, check for failures and collect logs, events,
@@ -133,7 +133,7 @@ var _ = Describe("Manager", Ordered, func() {
 		if specReport.Failed() {
 			By("Fetching controller manager pod logs")
 			cmd := exec.Command("kubectl", "logs", controllerPodName, "-n", namespace)
-			controllerLogs, err := utils.Run(cmd)
+			controllerLogs, err := framework.Run(cmd)
 			if err == nil {
 				_, _ = fmt.Fprintf(GinkgoWriter, "Controller logs:\n %s", controllerLogs)
 			} else {
@@ -142,7 +142,7 @@ var _ = Describe("Manager", Ordered, func() {
 
 			By("Fetching Kubernetes events")
 			cmd = exec.Command("kubectl", "get", "events", "-n", namespace, "--sort-by=.lastTimestamp")
-			eventsOutput, err := utils.Run(cmd)
+			eventsOutput, err := framework.Run(cmd)
 			if err == nil {
 				_, _ = fmt.Fprintf(GinkgoWriter, "Kubernetes events:\n%s", eventsOutput)
 			} else {
@@ -151,7 +151,7 @@ var _ = Describe("Manager", Ordered, func() {
 
 			By("Fetching curl-metrics logs")
 			cmd = exec.Command("kubectl", "logs", "curl-metrics", "-n", namespace)
-			metricsOutput, err := utils.Run(cmd)
+			metricsOutput, err := framework.Run(cmd)
 			if err == nil {
 				_, _ = fmt.Fprintf(GinkgoWriter, "Metrics logs:\n %s", metricsOutput)
 			} else {
@@ -160,7 +160,7 @@ var _ = Describe("Manager", Ordered, func() {
 
 			By("Fetching controller manager pod description")
 			cmd = exec.Command("kubectl", "describe", "pod", controllerPodName, "-n", namespace)
-			podDescription, err := utils.Run(cmd)
+			podDescription, err := framework.Run(cmd)
 			if err == nil {
 				fmt.Println("Pod description:\n", podDescription)
 			} else {
@@ -186,9 +186,9 @@ var _ = Describe("Manager", Ordered, func() {
 					"-n", namespace,
 				)
 
-				podOutput, err := utils.Run(cmd)
+				podOutput, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred(), "Failed to retrieve controller-manager pod information")
-				podNames := utils.GetNonEmptyLines(podOutput)
+				podNames := framework.GetNonEmptyLines(podOutput)
 				g.Expect(podNames).To(HaveLen(1), "expected 1 controller pod running")
 				controllerPodName = podNames[0]
 				g.Expect(controllerPodName).To(ContainSubstring("controller-manager"))
@@ -198,7 +198,7 @@ var _ = Describe("Manager", Ordered, func() {
 					"pods", controllerPodName, "-o", "jsonpath={.status.phase}",
 					"-n", namespace,
 				)
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal("Running"), "Incorrect controller-manager pod status")
 			}
@@ -211,12 +211,12 @@ var _ = Describe("Manager", Ordered, func() {
 				"--clusterrole=gastown-operator-metrics-reader",
 				fmt.Sprintf("--serviceaccount=%s:%s", namespace, serviceAccountName),
 			)
-			_, err := utils.Run(cmd)
+			_, err := framework.Run(cmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to create ClusterRoleBinding")
 
 			By("validating that the metrics service is available")
 			cmd = exec.Command("kubectl", "get", "service", metricsServiceName, "-n", namespace)
-			_, err = utils.Run(cmd)
+			_, err = framework.Run(cmd)
 			Expect(err).NotTo(HaveOccurred(), "Metrics service should exist")
 
 			By("getting the service account token")
@@ -228,7 +228,7 @@ var _ = Describe("Manager", Ordered, func() {
 			verifyControllerPodReady := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "pod", controllerPodName, "-n", namespace,
 					"-o", "jsonpath={.status.conditions[?(@.type=='Ready')].status}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal("True"), "Controller pod not ready")
 			}
@@ -237,7 +237,7 @@ var _ = Describe("Manager", Ordered, func() {
 			By("verifying that the controller manager is serving the metrics server")
 			verifyMetricsServerStarted := func(g Gomega) {
 				cmd := exec.Command("kubectl", "logs", controllerPodName, "-n", namespace)
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(ContainSubstring("Serving metrics server"),
 					"Metrics server not yet started")
@@ -274,7 +274,7 @@ var _ = Describe("Manager", Ordered, func() {
 						"serviceAccountName": "%s"
 					}
 				}`, token, metricsServiceName, namespace, serviceAccountName))
-			_, err = utils.Run(cmd)
+			_, err = framework.Run(cmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to create curl-metrics pod")
 
 			By("waiting for the curl-metrics pod to complete.")
@@ -282,7 +282,7 @@ var _ = Describe("Manager", Ordered, func() {
 				cmd := exec.Command("kubectl", "get", "pods", "curl-metrics",
 					"-o", "jsonpath={.status.phase}",
 					"-n", namespace)
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal("Succeeded"), "curl pod in wrong status")
 			}
@@ -319,14 +319,14 @@ spec:
 			Expect(err).NotTo(HaveOccurred())
 
 			cmd := exec.Command("kubectl", "apply", "-f", rigFile)
-			_, err = utils.Run(cmd)
+			_, err = framework.Run(cmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to create Rig")
 
 			By("verifying the Rig has finalizer added")
 			verifyRigFinalizer := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "rig", rigName,
 					"-o", "jsonpath={.metadata.finalizers}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(ContainSubstring("gastown.io/rig-cleanup"))
 			}
@@ -337,7 +337,7 @@ spec:
 			verifyWitnessCreated := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "witness", witnessName,
 					"-n", namespace, "-o", "jsonpath={.metadata.name}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred(), "Witness should be auto-created")
 				g.Expect(output).To(Equal(witnessName))
 			}
@@ -347,7 +347,7 @@ spec:
 			verifyWitnessRigRef := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "witness", witnessName,
 					"-n", namespace, "-o", "jsonpath={.spec.rigRef}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal(rigName))
 			}
@@ -358,7 +358,7 @@ spec:
 			verifyRefineryCreated := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "refinery", refineryName,
 					"-n", namespace, "-o", "jsonpath={.metadata.name}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred(), "Refinery should be auto-created")
 				g.Expect(output).To(Equal(refineryName))
 			}
@@ -368,7 +368,7 @@ spec:
 			verifyRefinerySpec := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "refinery", refineryName,
 					"-n", namespace, "-o", "jsonpath={.spec.rigRef},{.spec.targetBranch}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal(rigName + ",main"))
 			}
@@ -378,7 +378,7 @@ spec:
 			verifyRigStatus := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "rig", rigName,
 					"-o", "jsonpath={.status.witnessCreated},{.status.refineryCreated}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal("true,true"))
 			}
@@ -386,12 +386,12 @@ spec:
 
 			By("cleaning up the test Rig")
 			cmd = exec.Command("kubectl", "delete", "rig", rigName)
-			_, _ = utils.Run(cmd)
+			_, _ = framework.Run(cmd)
 
 			By("verifying Witness is deleted with Rig (finalizer cleanup)")
 			verifyWitnessDeleted := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "witness", witnessName, "-n", namespace)
-				_, err := utils.Run(cmd)
+				_, err := framework.Run(cmd)
 				g.Expect(err).To(HaveOccurred(), "Witness should be deleted")
 			}
 			Eventually(verifyWitnessDeleted, 2*time.Minute, time.Second).Should(Succeed())
@@ -399,7 +399,7 @@ spec:
 			By("verifying Refinery is deleted with Rig (finalizer cleanup)")
 			verifyRefineryDeleted := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "refinery", refineryName, "-n", namespace)
-				_, err := utils.Run(cmd)
+				_, err := framework.Run(cmd)
 				g.Expect(err).To(HaveOccurred(), "Refinery should be deleted")
 			}
 			Eventually(verifyRefineryDeleted, 2*time.Minute, time.Second).Should(Succeed())
@@ -425,7 +425,7 @@ spec:
 			Expect(err).NotTo(HaveOccurred())
 
 			cmd := exec.Command("kubectl", "apply", "-f", rigFile)
-			_, err = utils.Run(cmd)
+			_, err = framework.Run(cmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to create Rig")
 
 			By("waiting for auto-provisioned Refinery")
@@ -433,7 +433,7 @@ spec:
 			verifyRefineryCreated := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "refinery", refineryName,
 					"-n", namespace, "-o", "jsonpath={.metadata.name}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal(refineryName))
 			}
@@ -462,7 +462,7 @@ status:
 			Expect(err).NotTo(HaveOccurred())
 
 			cmd = exec.Command("kubectl", "apply", "-f", polecatFile)
-			_, err = utils.Run(cmd)
+			_, err = framework.Run(cmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to create Polecat")
 
 			By("adding Available=True condition to Polecat (simulating work completion)")
@@ -471,7 +471,7 @@ status:
 			cmd = exec.Command("kubectl", "patch", "polecat", polecatName,
 				"-n", namespace, "--type=json", "--subresource=status",
 				"-p", patchJSON)
-			_, err = utils.Run(cmd)
+			_, err = framework.Run(cmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to patch Polecat status")
 
 			By("verifying Polecat has Available=True condition")
@@ -479,7 +479,7 @@ status:
 				cmd := exec.Command("kubectl", "get", "polecat", polecatName,
 					"-n", namespace,
 					"-o", "jsonpath={.status.conditions[?(@.type=='Available')].status}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal("True"))
 			}
@@ -490,7 +490,7 @@ status:
 				// Trigger reconciliation by getting the refinery
 				cmd := exec.Command("kubectl", "get", "refinery", refineryName,
 					"-n", namespace, "-o", "jsonpath={.status.queueLength}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				// Queue should have at least 1 item
 				g.Expect(output).NotTo(Equal("0"), "Refinery should detect merge-ready polecat")
@@ -500,9 +500,9 @@ status:
 
 			By("cleaning up test resources")
 			cmd = exec.Command("kubectl", "delete", "polecat", polecatName, "-n", namespace)
-			_, _ = utils.Run(cmd)
+			_, _ = framework.Run(cmd)
 			cmd = exec.Command("kubectl", "delete", "rig", rigName)
-			_, _ = utils.Run(cmd)
+			_, _ = framework.Run(cmd)
 		})
 
 		It("should create a Pod for a Polecat in kubernetes execution mode", func() {
@@ -514,14 +514,14 @@ status:
 			gitSecretCmd := exec.Command("kubectl", "create", "secret", "generic",
 				"test-git-creds", "-n", testNamespace,
 				"--from-literal=ssh-privatekey=dummy-key-for-testing")
-			_, err := utils.Run(gitSecretCmd)
+			_, err := framework.Run(gitSecretCmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to create git credentials secret")
 
 			// Create Claude API key secret
 			claudeSecretCmd := exec.Command("kubectl", "create", "secret", "generic",
 				"test-claude-creds", "-n", testNamespace,
 				"--from-literal=api-key=dummy-key-for-testing")
-			_, err = utils.Run(claudeSecretCmd)
+			_, err = framework.Run(claudeSecretCmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to create Claude credentials secret")
 
 			By("creating a Polecat with kubernetes execution mode")
@@ -552,7 +552,7 @@ spec:
 			Expect(err).NotTo(HaveOccurred())
 
 			cmd := exec.Command("kubectl", "apply", "-f", polecatFile)
-			_, err = utils.Run(cmd)
+			_, err = framework.Run(cmd)
 			Expect(err).NotTo(HaveOccurred(), "Failed to create Polecat")
 
 			By("verifying the controller creates a Pod for the Polecat")
@@ -561,7 +561,7 @@ spec:
 					fmt.Sprintf("polecat-%s", polecatName),
 					"-n", testNamespace,
 					"-o", "jsonpath={.metadata.name}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred(), "Pod should be created")
 				g.Expect(output).To(Equal(fmt.Sprintf("polecat-%s", polecatName)))
 			}
@@ -573,7 +573,7 @@ spec:
 					fmt.Sprintf("polecat-%s", polecatName),
 					"-n", testNamespace,
 					"-o", "jsonpath={.metadata.labels}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(ContainSubstring("gastown.io/polecat"))
 				g.Expect(output).To(ContainSubstring("gastown.io/rig"))
@@ -587,7 +587,7 @@ spec:
 					fmt.Sprintf("polecat-%s", polecatName),
 					"-n", testNamespace,
 					"-o", "jsonpath={.spec.initContainers[*].name},{.spec.containers[*].name}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(ContainSubstring("git-init"))
 				g.Expect(output).To(ContainSubstring("claude"))
@@ -599,7 +599,7 @@ spec:
 				cmd := exec.Command("kubectl", "get", "polecat",
 					polecatName, "-n", testNamespace,
 					"-o", "jsonpath={.status.podName}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal(fmt.Sprintf("polecat-%s", polecatName)))
 			}
@@ -610,7 +610,7 @@ spec:
 				cmd := exec.Command("kubectl", "get", "polecat",
 					polecatName, "-n", testNamespace,
 					"-o", "jsonpath={.status.phase}")
-				output, err := utils.Run(cmd)
+				output, err := framework.Run(cmd)
 				g.Expect(err).NotTo(HaveOccurred())
 				g.Expect(output).To(Equal("Working"))
 			}
@@ -618,23 +618,23 @@ spec:
 
 			By("cleaning up the test Polecat")
 			cmd = exec.Command("kubectl", "delete", "polecat", polecatName, "-n", testNamespace)
-			_, _ = utils.Run(cmd)
+			_, _ = framework.Run(cmd)
 
 			By("verifying the Pod is deleted with the Polecat (owner reference)")
 			verifyPodDeleted := func(g Gomega) {
 				cmd := exec.Command("kubectl", "get", "pod",
 					fmt.Sprintf("polecat-%s", polecatName),
 					"-n", testNamespace)
-				_, err := utils.Run(cmd)
+				_, err := framework.Run(cmd)
 				g.Expect(err).To(HaveOccurred(), "Pod should be deleted")
 			}
 			Eventually(verifyPodDeleted, 2*time.Minute, time.Second).Should(Succeed())
 
 			By("cleaning up test secrets")
 			cmd = exec.Command("kubectl", "delete", "secret", "test-git-creds", "-n", testNamespace)
-			_, _ = utils.Run(cmd)
+			_, _ = framework.Run(cmd)
 			cmd = exec.Command("kubectl", "delete", "secret", "test-claude-creds", "-n", testNamespace)
-			_, _ = utils.Run(cmd)
+			_, _ = framework.Run(cmd)
 		})
 	})
 })
@@ -684,7 +684,7 @@ func serviceAccountToken() (string, error) {
 func getMetricsOutput() (string, error) {
 	By("getting the curl-metrics logs")
 	cmd := exec.Command("kubectl", "logs", "curl-metrics", "-n", namespace)
-	return utils.Run(cmd)
+	return framework.Run(cmd)
 }
 
 // tokenRequest is a simplified representation of the Kubernetes TokenRequest API response,